@@ -0,0 +1,96 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Limits of the access control list of a record.
+const (
+	MaxACLPatterns      = 64
+	MaxACLPatternLength = 2048
+)
+
+// aclPatternPrefix is the prefix of all access control list patterns.
+const aclPatternPrefix = "spiffe://"
+
+// Validate checks the patterns of an access control list.
+// Patterns are SPIFFE IDs with a literal trust domain that may contain globs in their path.
+func (x *RecordACL) Validate() error {
+	if len(x.GetAllowPull())+len(x.GetAllowDelete()) > MaxACLPatterns {
+		return fmt.Errorf("access control list exceeds %d patterns", MaxACLPatterns)
+	}
+
+	for _, pattern := range append(x.GetAllowPull(), x.GetAllowDelete()...) {
+		if err := validateACLPattern(pattern); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsEmpty reports whether the access control list has no patterns, i.e. does not restrict any operation.
+func (x *RecordACL) IsEmpty() bool {
+	return len(x.GetAllowPull()) == 0 && len(x.GetAllowDelete()) == 0
+}
+
+func validateACLPattern(pattern string) error {
+	if len(pattern) > MaxACLPatternLength {
+		return fmt.Errorf("pattern %q exceeds %d bytes", pattern, MaxACLPatternLength)
+	}
+
+	path, ok := strings.CutPrefix(pattern, aclPatternPrefix)
+	trustDomain, _, _ := strings.Cut(path, "/")
+
+	if !ok || trustDomain == "" {
+		return fmt.Errorf("invalid pattern %q: must be a SPIFFE ID, e.g. spiffe://example.org/ns/team/*", pattern)
+	}
+
+	if strings.Contains(trustDomain, "*") {
+		return fmt.Errorf("invalid pattern %q: trust domain must not contain globs", pattern)
+	}
+
+	return nil
+}
+
+// MatchACLPattern reports whether the SPIFFE ID matches an access control list pattern.
+// "*" matches any characters within a path segment and "**" matches any characters across segments,
+// e.g. "spiffe://example.org/ns/*/agent" matches "spiffe://example.org/ns/team-a/agent"
+// and "spiffe://example.org/ns/**" matches all SPIFFE IDs below "spiffe://example.org/ns/".
+func MatchACLPattern(pattern, spiffeID string) bool {
+	if !strings.Contains(pattern, "*") {
+		return pattern == spiffeID
+	}
+
+	var expr strings.Builder
+
+	expr.WriteString("^")
+
+	for i, part := range strings.Split(pattern, "**") {
+		if i > 0 {
+			expr.WriteString(".*")
+		}
+
+		for j, literal := range strings.Split(part, "*") {
+			if j > 0 {
+				expr.WriteString("[^/]*")
+			}
+
+			expr.WriteString(regexp.QuoteMeta(literal))
+		}
+	}
+
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return false
+	}
+
+	return re.MatchString(spiffeID)
+}
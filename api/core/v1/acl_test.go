@@ -0,0 +1,60 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordACLValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		acl     *corev1.RecordACL
+		wantErr bool
+	}{
+		{name: "Empty", acl: &corev1.RecordACL{}},
+		{name: "Valid", acl: &corev1.RecordACL{AllowPull: []string{"spiffe://example.org/ns/*/agent"}, AllowDelete: []string{"spiffe://example.org/admin"}}},
+		{name: "Trust domain only", acl: &corev1.RecordACL{AllowPull: []string{"spiffe://example.org"}}},
+		{name: "Not a SPIFFE ID", acl: &corev1.RecordACL{AllowPull: []string{"example.org/agent"}}, wantErr: true},
+		{name: "Missing trust domain", acl: &corev1.RecordACL{AllowDelete: []string{"spiffe:///agent"}}, wantErr: true},
+		{name: "Trust domain glob", acl: &corev1.RecordACL{AllowPull: []string{"spiffe://*.org/agent"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.acl.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMatchACLPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		spiffeID string
+		want     bool
+	}{
+		{pattern: "spiffe://example.org/agent", spiffeID: "spiffe://example.org/agent", want: true},
+		{pattern: "spiffe://example.org/agent", spiffeID: "spiffe://example.org/agent-2", want: false},
+		{pattern: "spiffe://example.org/ns/*/agent", spiffeID: "spiffe://example.org/ns/team-a/agent", want: true},
+		{pattern: "spiffe://example.org/ns/*/agent", spiffeID: "spiffe://example.org/ns/team-a/sub/agent", want: false},
+		{pattern: "spiffe://example.org/ns/team-*", spiffeID: "spiffe://example.org/ns/team-b", want: true},
+		{pattern: "spiffe://example.org/ns/**", spiffeID: "spiffe://example.org/ns/team-a/sub/agent", want: true},
+		{pattern: "spiffe://example.org/ns/**", spiffeID: "spiffe://other.org/ns/team-a", want: false},
+		{pattern: "spiffe://example.org/a.b", spiffeID: "spiffe://example.org/aXb", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.spiffeID, func(t *testing.T) {
+			assert.Equal(t, tt.want, corev1.MatchACLPattern(tt.pattern, tt.spiffeID))
+		})
+	}
+}
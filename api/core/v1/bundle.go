@@ -0,0 +1,173 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// BuildBundle creates a bundle of the referenced records.
+// Roles are keyed by member CID and are optional; a role for a CID
+// that is not a member is an error. Members are sorted by CID.
+func BuildBundle(refs []*RecordRef, roles map[string]string) (*RecordBundle, error) {
+	bundle := &RecordBundle{}
+
+	members := make(map[string]bool, len(refs))
+
+	for _, ref := range refs {
+		members[ref.GetCid()] = true
+
+		bundle.Members = append(bundle.Members, &BundleMember{
+			Cid:  ref.GetCid(),
+			Role: roles[ref.GetCid()],
+		})
+	}
+
+	for cid := range roles {
+		if !members[cid] {
+			return nil, fmt.Errorf("role assigned to %s which is not a bundle member", cid)
+		}
+	}
+
+	slices.SortFunc(bundle.Members, func(a, b *BundleMember) int {
+		return strings.Compare(a.GetCid(), b.GetCid())
+	})
+
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// Validate checks that the bundle has at least one member,
+// and that members are referenced by valid and unique CIDs.
+func (b *RecordBundle) Validate() error {
+	if len(b.GetMembers()) == 0 {
+		return errors.New("bundle has no members")
+	}
+
+	seen := make(map[string]bool, len(b.GetMembers()))
+
+	for _, member := range b.GetMembers() {
+		if !IsValidCID(member.GetCid()) {
+			return fmt.Errorf("invalid bundle member CID: %q", member.GetCid())
+		}
+
+		if seen[member.GetCid()] {
+			return fmt.Errorf("duplicate bundle member: %s", member.GetCid())
+		}
+
+		seen[member.GetCid()] = true
+	}
+
+	return nil
+}
+
+// canonicalBundle is the canonical JSON form of a bundle.
+// Fields are declared in lexical order and map keys are sorted by encoding/json.
+type canonicalBundle struct {
+	Annotations map[string]string       `json:"annotations,omitempty"`
+	Members     []canonicalBundleMember `json:"members"`
+	Name        string                  `json:"name,omitempty"`
+}
+
+type canonicalBundleMember struct {
+	Cid  string `json:"cid"`
+	Role string `json:"role,omitempty"`
+}
+
+// Marshal marshals the bundle using canonical JSON serialization.
+// Members are sorted by CID, so the order in which they were added does not change the bundle CID.
+// The output is used for both CID calculation and storage.
+func (b *RecordBundle) Marshal() ([]byte, error) {
+	if b == nil {
+		return nil, nil
+	}
+
+	canonical := canonicalBundle{
+		Annotations: b.GetAnnotations(),
+		Members:     make([]canonicalBundleMember, 0, len(b.GetMembers())),
+		Name:        b.GetName(),
+	}
+
+	for _, member := range b.GetMembers() {
+		canonical.Members = append(canonical.Members, canonicalBundleMember{
+			Cid:  member.GetCid(),
+			Role: member.GetRole(),
+		})
+	}
+
+	slices.SortFunc(canonical.Members, func(a, b canonicalBundleMember) int {
+		return strings.Compare(a.Cid, b.Cid)
+	})
+
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(canonical); err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// GetCid calculates and returns the CID of the bundle over its canonical bytes,
+// using the same parameters as Record.GetCid.
+// Returns empty string if calculation fails.
+func (b *RecordBundle) GetCid() string {
+	data, err := b.Marshal()
+	if err != nil || len(data) == 0 {
+		return ""
+	}
+
+	digest, err := CalculateDigest(data)
+	if err != nil {
+		return ""
+	}
+
+	cid, _ := ConvertDigestToCID(digest)
+
+	return cid
+}
+
+// GetMember returns the member with the given CID, or nil if the record is not a member.
+func (b *RecordBundle) GetMember(cid string) *BundleMember {
+	for _, member := range b.GetMembers() {
+		if member.GetCid() == cid {
+			return member
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalBundle unmarshals canonical bundle JSON bytes to a bundle.
+func UnmarshalBundle(data []byte) (*RecordBundle, error) {
+	var canonical canonicalBundle
+	if err := json.Unmarshal(data, &canonical); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bundle: %w", err)
+	}
+
+	bundle := &RecordBundle{
+		Name:        canonical.Name,
+		Annotations: canonical.Annotations,
+	}
+
+	for _, member := range canonical.Members {
+		bundle.Members = append(bundle.Members, &BundleMember{
+			Cid:  member.Cid,
+			Role: member.Role,
+		})
+	}
+
+	return bundle, nil
+}
@@ -0,0 +1,106 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bundleTestRefs(t *testing.T, names ...string) []*corev1.RecordRef {
+	t.Helper()
+
+	refs := make([]*corev1.RecordRef, 0, len(names))
+	for _, name := range names {
+		record := corev1.New(&typesv1alpha1.Record{Name: name, Version: "v1.0.0", SchemaVersion: "0.7.0"})
+		refs = append(refs, &corev1.RecordRef{Cid: record.GetCid()})
+	}
+
+	return refs
+}
+
+func TestBuildBundle(t *testing.T) {
+	refs := bundleTestRefs(t, "orchestrator", "worker", "evaluator")
+
+	bundle, err := corev1.BuildBundle(refs, map[string]string{refs[0].GetCid(): "orchestrator"})
+	require.NoError(t, err)
+	require.Len(t, bundle.GetMembers(), 3)
+	assert.Equal(t, "orchestrator", bundle.GetMember(refs[0].GetCid()).GetRole())
+	assert.Empty(t, bundle.GetMember(refs[1].GetCid()).GetRole())
+	assert.Nil(t, bundle.GetMember("unknown"))
+
+	cid := bundle.GetCid()
+	assert.True(t, corev1.IsValidCID(cid))
+
+	t.Run("member order does not change the CID", func(t *testing.T) {
+		reversed, err := corev1.BuildBundle([]*corev1.RecordRef{refs[2], refs[1], refs[0]}, map[string]string{refs[0].GetCid(): "orchestrator"})
+		require.NoError(t, err)
+		assert.Equal(t, cid, reversed.GetCid())
+
+		reversed.Members[0], reversed.Members[2] = reversed.Members[2], reversed.Members[0]
+		assert.Equal(t, cid, reversed.GetCid())
+	})
+
+	t.Run("members, roles, name and annotations change the CID", func(t *testing.T) {
+		fewer, err := corev1.BuildBundle(refs[:2], nil)
+		require.NoError(t, err)
+		assert.NotEqual(t, cid, fewer.GetCid())
+
+		otherRole, err := corev1.BuildBundle(refs, map[string]string{refs[0].GetCid(): "worker"})
+		require.NoError(t, err)
+		assert.NotEqual(t, cid, otherRole.GetCid())
+
+		named, err := corev1.BuildBundle(refs, map[string]string{refs[0].GetCid(): "orchestrator"})
+		require.NoError(t, err)
+
+		named.Name = "my-app"
+		assert.NotEqual(t, cid, named.GetCid())
+
+		annotated, err := corev1.BuildBundle(refs, map[string]string{refs[0].GetCid(): "orchestrator"})
+		require.NoError(t, err)
+
+		annotated.Annotations = map[string]string{"env": "prod"}
+		assert.NotEqual(t, cid, annotated.GetCid())
+	})
+
+	t.Run("canonical round trip", func(t *testing.T) {
+		bundle.Name = "my-app"
+		bundle.Annotations = map[string]string{"env": "prod", "team": "agents"}
+
+		data, err := bundle.Marshal()
+		require.NoError(t, err)
+
+		decoded, err := corev1.UnmarshalBundle(data)
+		require.NoError(t, err)
+		assert.Equal(t, bundle.GetCid(), decoded.GetCid())
+		assert.Equal(t, "my-app", decoded.GetName())
+		assert.Equal(t, bundle.GetAnnotations(), decoded.GetAnnotations())
+	})
+}
+
+func TestBuildBundle_Invalid(t *testing.T) {
+	refs := bundleTestRefs(t, "orchestrator", "worker")
+
+	tests := []struct {
+		name  string
+		refs  []*corev1.RecordRef
+		roles map[string]string
+	}{
+		{name: "No members"},
+		{name: "Invalid CID", refs: []*corev1.RecordRef{{Cid: "not-a-cid"}}},
+		{name: "Duplicate member", refs: []*corev1.RecordRef{refs[0], refs[0]}},
+		{name: "Role for non-member", refs: refs[:1], roles: map[string]string{refs[1].GetCid(): "worker"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := corev1.BuildBundle(tt.refs, tt.roles)
+			assert.Error(t, err)
+		})
+	}
+}
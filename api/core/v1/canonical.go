@@ -0,0 +1,259 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+	"weak"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// canonicalEntry holds the canonical encoding of a record computed for a given data struct,
+// and the CIDs calculated from it by hash function.
+type canonicalEntry struct {
+	data  *structpb.Struct
+	bytes []byte
+
+	mu   sync.Mutex
+	cids map[uint64]string
+}
+
+// canonicalCache caches canonical encodings by record.
+// Entries are keyed by weak pointers so that cached records can still be garbage collected,
+// the entry is removed together with its record.
+var canonicalCache sync.Map // map[weak.Pointer[Record]]*canonicalEntry
+
+// canonical returns the cached canonical encoding of the record, computing it on first use.
+//
+// Records are treated as immutable once they were marshaled.
+// The cache is invalidated when Data is replaced or when the record is modified through
+// its own methods, such as SetPreviousCid, but not when the Data struct is modified in place.
+// Use a new Record to change the contents of a record that was already marshaled.
+func (r *Record) canonical() (*canonicalEntry, error) {
+	key := weak.Make(r)
+
+	if cached, ok := canonicalCache.Load(key); ok {
+		if entry, _ := cached.(*canonicalEntry); entry.data == r.GetData() {
+			return entry, nil
+		}
+	}
+
+	canonicalBytes, err := appendCanonicalStruct(nil, r.GetData())
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &canonicalEntry{data: r.GetData(), bytes: canonicalBytes}
+
+	if _, loaded := canonicalCache.Swap(key, entry); !loaded {
+		runtime.AddCleanup(r, func(key weak.Pointer[Record]) {
+			canonicalCache.Delete(key)
+		}, key)
+	}
+
+	return entry, nil
+}
+
+// cid returns the CID of the canonical encoding calculated with the options,
+// computing it on first use.
+func (e *canonicalEntry) cid(opts CIDOptions) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if cid, ok := e.cids[opts.hash()]; ok {
+		return cid, nil
+	}
+
+	cid, err := opts.CID(e.bytes)
+	if err != nil {
+		return "", err
+	}
+
+	if e.cids == nil {
+		e.cids = make(map[uint64]string, 1)
+	}
+
+	e.cids[opts.hash()] = cid
+
+	return cid, nil
+}
+
+// resetCanonical drops the cached canonical encoding of the record.
+// It must be called by methods that modify the record data in place.
+func (r *Record) resetCanonical() {
+	canonicalCache.Delete(weak.Make(r))
+}
+
+// appendCanonicalStruct appends the canonical JSON encoding of the struct in a single pass.
+// The output is byte-for-byte identical to encoding the data with protojson,
+// decoding it into interface{} and encoding it again with encoding/json:
+//   - object keys are sorted by their bytes,
+//   - numbers are formatted like encoding/json formats float64 values,
+//   - strings are escaped like encoding/json with HTML escaping enabled.
+//
+// This encoding defines record CIDs, so it must never change.
+func appendCanonicalStruct(dst []byte, s *structpb.Struct) ([]byte, error) {
+	fields := s.GetFields()
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	dst = append(dst, '{')
+
+	for i, key := range keys {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+
+		var err error
+
+		if dst, err = appendCanonicalString(dst, key); err != nil {
+			return nil, err
+		}
+
+		dst = append(dst, ':')
+
+		if dst, err = appendCanonicalValue(dst, fields[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	return append(dst, '}'), nil
+}
+
+func appendCanonicalList(dst []byte, l *structpb.ListValue) ([]byte, error) {
+	dst = append(dst, '[')
+
+	for i, value := range l.GetValues() {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+
+		var err error
+
+		if dst, err = appendCanonicalValue(dst, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return append(dst, ']'), nil
+}
+
+func appendCanonicalValue(dst []byte, v *structpb.Value) ([]byte, error) {
+	switch kind := v.GetKind().(type) {
+	case *structpb.Value_NullValue:
+		return append(dst, "null"...), nil
+	case *structpb.Value_BoolValue:
+		return strconv.AppendBool(dst, kind.BoolValue), nil
+	case *structpb.Value_NumberValue:
+		return appendCanonicalNumber(dst, kind.NumberValue)
+	case *structpb.Value_StringValue:
+		return appendCanonicalString(dst, kind.StringValue)
+	case *structpb.Value_StructValue:
+		return appendCanonicalStruct(dst, kind.StructValue)
+	case *structpb.Value_ListValue:
+		return appendCanonicalList(dst, kind.ListValue)
+	default:
+		return nil, errors.New("failed to marshal Record: value has no kind set")
+	}
+}
+
+// appendCanonicalNumber formats the number like encoding/json formats float64 values.
+func appendCanonicalNumber(dst []byte, f float64) ([]byte, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, fmt.Errorf("failed to marshal Record: invalid number %v", f)
+	}
+
+	// Like fmt %g, but the exponent cutoffs are different
+	// and exponents themselves are not padded to two digits.
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+
+	dst = strconv.AppendFloat(dst, f, format, -1, 64) //nolint:mnd
+
+	if format == 'e' {
+		// Clean up e-09 to e-9
+		n := len(dst)
+		if n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+
+	return dst, nil
+}
+
+// appendCanonicalString quotes the string like encoding/json with HTML escaping enabled.
+func appendCanonicalString(dst []byte, s string) ([]byte, error) {
+	const hex = "0123456789abcdef"
+
+	dst = append(dst, '"')
+	start := 0
+
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= ' ' && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+
+				continue
+			}
+
+			dst = append(dst, s[start:i]...)
+
+			switch b {
+			case '\\', '"':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xF])
+			}
+
+			i++
+			start = i
+
+			continue
+		}
+
+		c, size := utf8.DecodeRuneInString(s[i:])
+		if c == utf8.RuneError && size == 1 {
+			return nil, errors.New("failed to marshal Record: string contains invalid UTF-8")
+		}
+
+		// U+2028 and U+2029 are escaped for JavaScript compatibility
+		if c == '\u2028' || c == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hex[c&0xF])
+			start = i + size
+		}
+
+		i += size
+	}
+
+	dst = append(dst, s[start:]...)
+
+	return append(dst, '"'), nil
+}
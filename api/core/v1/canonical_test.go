@@ -0,0 +1,230 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// loadCanonicalRecord loads a record from the canonical corpus without schema validation,
+// so that the corpus can cover JSON edge cases which are not valid OASF records.
+func loadCanonicalRecord(t *testing.T, path string) *corev1.Record {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var recordData structpb.Struct
+	require.NoError(t, protojson.Unmarshal(data, &recordData))
+
+	return &corev1.Record{Data: &recordData}
+}
+
+// TestRecord_Marshal_Golden guards the canonical encoding against changes.
+// The golden files were produced by the original protojson/encoding/json implementation,
+// any difference would change the CIDs of existing records.
+func TestRecord_Marshal_Golden(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "canonical", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			record := loadCanonicalRecord(t, path)
+
+			data, err := record.Marshal()
+			require.NoError(t, err)
+
+			golden := strings.TrimSuffix(path, ".json") + ".golden"
+
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(golden, data, 0o600)) //nolint:mnd
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+
+			assert.Equal(t, string(expected), string(data))
+
+			digest, err := corev1.CalculateDigest(expected)
+			require.NoError(t, err)
+
+			cid, err := corev1.ConvertDigestToCID(digest)
+			require.NoError(t, err)
+
+			assert.Equal(t, cid, record.GetCid())
+		})
+	}
+}
+
+// TestLoadOASFFromReader_Golden ensures that records loaded from OASF documents
+// get the same canonical bytes and CIDs as the server computes for them.
+// Some documents have keys unknown to OASF, so they are loaded in lenient mode.
+func TestLoadOASFFromReader_Golden(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "canonical", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			file, err := os.Open(path)
+			require.NoError(t, err)
+
+			defer file.Close()
+
+			record, err := corev1.LoadOASFFromReader(file, corev1.Lenient())
+			require.NoError(t, err)
+
+			expected, err := os.ReadFile(strings.TrimSuffix(path, ".json") + ".golden")
+			require.NoError(t, err)
+
+			data, err := record.Marshal()
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(data))
+
+			assert.Equal(t, loadCanonicalRecord(t, path).GetCid(), record.GetCid())
+		})
+	}
+}
+
+func TestLoadOASFFromReader_Invalid(t *testing.T) {
+	_, err := corev1.LoadOASFFromReader(strings.NewReader(`{"name":`))
+	require.Error(t, err)
+
+	_, err = corev1.LoadOASFFromReader(strings.NewReader(`"not an object"`))
+	require.Error(t, err)
+
+	_, err = corev1.LoadOASFFromReader(strings.NewReader(strings.Repeat(" ", 4*1024*1024+1)))
+	require.Error(t, err)
+}
+
+func TestRecord_Marshal_Mutation(t *testing.T) {
+	record := loadCanonicalRecord(t, filepath.Join("testdata", "canonical", "record_070.json"))
+
+	first, err := record.Marshal()
+	require.NoError(t, err)
+
+	cid := record.GetCid()
+
+	// Returned bytes are owned by the caller
+	first[0] = 'x'
+
+	second, err := record.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, byte('{'), second[0])
+	assert.Equal(t, cid, record.GetCid())
+
+	// Mutations through the record API invalidate the cache
+	require.NoError(t, record.SetPreviousCid(cid))
+	assert.NotEqual(t, cid, record.GetCid())
+
+	previous := record.GetCid()
+	require.NoError(t, record.SetMigratedFrom(cid))
+	assert.NotEqual(t, previous, record.GetCid())
+
+	migrated := record.GetCid()
+	record.SetCIDOptions(corev1.CIDOptions{MulticodecHash: mh.SHA2_512})
+	assert.NotEqual(t, migrated, record.GetCid())
+
+	// Replacing the data invalidates the cache
+	replaced := record.GetCid()
+	record.Data = loadCanonicalRecord(t, filepath.Join("testdata", "canonical", "record_031.json")).GetData()
+	assert.NotEqual(t, replaced, record.GetCid())
+}
+
+func TestRecord_Marshal_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value *structpb.Value
+	}{
+		{name: "NaN", value: structpb.NewNumberValue(math.NaN())},
+		{name: "infinity", value: structpb.NewNumberValue(math.Inf(1))},
+		{name: "invalid UTF-8", value: structpb.NewStringValue("\xff")},
+		{name: "empty value", value: &structpb.Value{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := &corev1.Record{Data: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"name":  structpb.NewStringValue("invalid"),
+				"value": tt.value,
+			}}}
+
+			_, err := record.Marshal()
+			require.Error(t, err)
+			assert.Empty(t, record.GetCid())
+		})
+	}
+}
+
+// benchmarkRecordData builds record data of roughly the given size in bytes.
+func benchmarkRecordData(b *testing.B, size int) *structpb.Struct {
+	b.Helper()
+
+	modules := make([]any, 0, size/200) //nolint:mnd
+
+	for i := 0; len(modules) < cap(modules); i++ {
+		modules = append(modules, map[string]any{
+			"name":    fmt.Sprintf("integration/module-%d", i),
+			"id":      float64(i),
+			"enabled": i%2 == 0,
+			"data": map[string]any{
+				"url":         fmt.Sprintf("https://example.com/modules/%d", i),
+				"description": "A module used to benchmark canonical marshaling <with> escaping & unicode ✓",
+				"weight":      float64(i) / 3, //nolint:mnd
+			},
+		})
+	}
+
+	data, err := structpb.NewStruct(map[string]any{
+		"name":           "directory.agntcy.org/example/benchmark",
+		"version":        "v1.0.0",
+		"schema_version": "0.7.0",
+		"description":    "Benchmark record",
+		"modules":        modules,
+	})
+	require.NoError(b, err)
+
+	return data
+}
+
+func BenchmarkMarshalCanonical(b *testing.B) {
+	for _, size := range []int{4 << 10, 2 << 20} {
+		data := benchmarkRecordData(b, size)
+
+		b.Run(fmt.Sprintf("size=%d/uncached", size), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				if _, err := (&corev1.Record{Data: data}).Marshal(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("size=%d/cached", size), func(b *testing.B) {
+			b.ReportAllocs()
+
+			record := &corev1.Record{Data: data}
+
+			for b.Loop() {
+				if _, err := record.Marshal(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -4,26 +4,139 @@
 package v1
 
 import (
-	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 
 	cid "github.com/ipfs/go-cid"
 	mh "github.com/multiformats/go-multihash"
 	ocidigest "github.com/opencontainers/go-digest"
 )
 
+// cidCodec is the codec of record CIDs.
+const cidCodec = 1
+
+// ErrUnsupportedHash is returned for CIDs and digests whose hash function cannot be represented
+// as an OCI digest, or is not available.
+var ErrUnsupportedHash = errors.New("unsupported hash function")
+
+// cidHashAlgorithms maps the multihash codes of CIDs to the OCI digest algorithms records are stored under.
+// BLAKE3 digests are only available if an implementation is registered with the go-digest package.
+var cidHashAlgorithms = map[uint64]ocidigest.Algorithm{
+	mh.SHA2_256: ocidigest.SHA256,
+	mh.SHA2_512: ocidigest.SHA512,
+	mh.BLAKE3:   ocidigest.Algorithm("blake3"),
+}
+
+// CIDOptions are the parameters record CIDs are generated with.
+// CIDs are self-describing, so records can be verified and stored regardless of the options they were generated with.
+type CIDOptions struct {
+	// MulticodecHash is the multihash code of the hash function, e.g. mh.SHA2_512.
+	// Uses SHA2-256 if not set.
+	MulticodecHash uint64
+}
+
+// DefaultCIDOptions generates CIDv1 with codec 1 and SHA2-256, matching the SHA-256 digests of OCI registries.
+var DefaultCIDOptions = CIDOptions{MulticodecHash: mh.SHA2_256}
+
+// ParseCIDOptions returns the CID options for a hash function name, e.g. "sha2-256", "sha2-512" or "blake3".
+// An empty name returns DefaultCIDOptions.
+func ParseCIDOptions(hash string) (CIDOptions, error) {
+	if hash == "" {
+		return DefaultCIDOptions, nil
+	}
+
+	code, ok := mh.Names[strings.ToLower(hash)]
+	if !ok {
+		return CIDOptions{}, fmt.Errorf("%w %q", ErrUnsupportedHash, hash)
+	}
+
+	opts := CIDOptions{MulticodecHash: code}
+	if err := opts.Validate(); err != nil {
+		return CIDOptions{}, err
+	}
+
+	return opts, nil
+}
+
+// CIDOptionsOf returns the options a CID was generated with.
+func CIDOptionsOf(cidString string) (CIDOptions, error) {
+	c, err := cid.Decode(cidString)
+	if err != nil {
+		return CIDOptions{}, fmt.Errorf("failed to decode CID %s: %w", cidString, err)
+	}
+
+	return CIDOptions{MulticodecHash: c.Prefix().MhType}, nil
+}
+
+// Validate checks that CIDs can be generated with the options and stored as OCI digests.
+func (o CIDOptions) Validate() error {
+	_, err := o.algorithm()
+
+	return err
+}
+
+// String returns the name of the hash function, e.g. "sha2-256".
+func (o CIDOptions) String() string {
+	return hashName(o.hash())
+}
+
+func (o CIDOptions) hash() uint64 {
+	if o.MulticodecHash == 0 {
+		return mh.SHA2_256
+	}
+
+	return o.MulticodecHash
+}
+
+// algorithm returns the OCI digest algorithm of the hash function.
+func (o CIDOptions) algorithm() (ocidigest.Algorithm, error) {
+	alg, ok := cidHashAlgorithms[o.hash()]
+	if !ok || !alg.Available() {
+		return "", fmt.Errorf("%w %s: CIDs must use a hash function available as an OCI digest, e.g. sha2-256 or sha2-512",
+			ErrUnsupportedHash, hashName(o.hash()))
+	}
+
+	return alg, nil
+}
+
+// Digest calculates the OCI digest of raw bytes with the hash function of the options.
+func (o CIDOptions) Digest(data []byte) (ocidigest.Digest, error) {
+	if len(data) == 0 {
+		return "", errors.New("cannot calculate digest of empty data")
+	}
+
+	alg, err := o.algorithm()
+	if err != nil {
+		return "", err
+	}
+
+	return alg.FromBytes(data), nil
+}
+
+// CID calculates the CID of raw bytes with the hash function of the options.
+func (o CIDOptions) CID(data []byte) (string, error) {
+	digest, err := o.Digest(data)
+	if err != nil {
+		return "", err
+	}
+
+	return ConvertDigestToCID(digest)
+}
+
 // ConvertDigestToCID converts an OCI digest to a CID string.
-// Uses the same CID parameters as the original Record.GetCid(): CIDv1, codec 1, SHA2-256.
+// Uses the same CID parameters as the original Record.GetCid(): CIDv1, codec 1,
+// and the multihash of the digest algorithm, e.g. SHA2-256 for "sha256" digests.
 func ConvertDigestToCID(digest ocidigest.Digest) (string, error) {
 	// Validate digest
 	if err := digest.Validate(); err != nil {
 		return "", fmt.Errorf("invalid digest format: %s", digest)
 	}
 
-	if digest.Algorithm() != ocidigest.SHA256 {
-		return "", fmt.Errorf("unsupported digest algorithm %s, only SHA256 is supported", digest.Algorithm())
+	code, ok := digestHash(digest.Algorithm())
+	if !ok {
+		return "", fmt.Errorf("%w: digest algorithm %s", ErrUnsupportedHash, digest.Algorithm())
 	}
 
 	// Extract the hex-encoded hash from the OCI digest
@@ -36,19 +149,20 @@ func ConvertDigestToCID(digest ocidigest.Digest) (string, error) {
 	}
 
 	// Create multihash from the digest bytes
-	mhash, err := mh.Encode(hashBytes, mh.SHA2_256)
+	mhash, err := mh.Encode(hashBytes, code)
 	if err != nil {
 		return "", fmt.Errorf("failed to create multihash: %w", err)
 	}
 
 	// Create CID with same parameters as original Record.GetCid()
-	cidVal := cid.NewCidV1(1, mhash) // Version 1, codec 1, with our multihash
+	cidVal := cid.NewCidV1(cidCodec, mhash) // Version 1, codec 1, with our multihash
 
 	return cidVal.String(), nil
 }
 
 // ConvertCIDToDigest converts a CID string to an OCI digest.
 // This is the reverse of ConvertDigestToCID.
+// CIDs whose hash function cannot be represented as an OCI digest return an error wrapping ErrUnsupportedHash.
 func ConvertCIDToDigest(cidString string) (ocidigest.Digest, error) {
 	// Decode the CID
 	c, err := cid.Decode(cidString)
@@ -65,27 +179,19 @@ func ConvertCIDToDigest(cidString string) (ocidigest.Digest, error) {
 		return "", fmt.Errorf("failed to decode multihash from CID %s: %w", cidString, err)
 	}
 
-	// Validate it's SHA2-256
-	if decoded.Code != uint64(mh.SHA2_256) {
-		return "", fmt.Errorf("unsupported hash type %d in CID %s, only SHA2-256 is supported", decoded.Code, cidString)
+	alg, err := CIDOptions{MulticodecHash: decoded.Code}.algorithm()
+	if err != nil {
+		return "", fmt.Errorf("CID %s: %w", cidString, err)
 	}
 
 	// Create OCI digest from the hash bytes
-	return ocidigest.NewDigestFromBytes(ocidigest.SHA256, decoded.Digest), nil
+	return ocidigest.NewDigestFromBytes(alg, decoded.Digest), nil
 }
 
 // CalculateDigest calculates a SHA2-256 digest from raw bytes.
 // This is used as a fallback when oras.PushBytes is not available.
 func CalculateDigest(data []byte) (ocidigest.Digest, error) {
-	if len(data) == 0 {
-		return "", errors.New("cannot calculate digest of empty data")
-	}
-
-	// Calculate SHA2-256 hash
-	hash := sha256.Sum256(data)
-
-	// Create OCI digest
-	return ocidigest.NewDigestFromBytes(ocidigest.SHA256, hash[:]), nil
+	return DefaultCIDOptions.Digest(data)
 }
 
 // IsValidCID validates a CID string.
@@ -94,3 +200,23 @@ func IsValidCID(cidString string) bool {
 
 	return err == nil
 }
+
+// digestHash returns the multihash code of an available OCI digest algorithm.
+func digestHash(alg ocidigest.Algorithm) (uint64, bool) {
+	for code, candidate := range cidHashAlgorithms {
+		if candidate == alg && alg.Available() {
+			return code, true
+		}
+	}
+
+	return 0, false
+}
+
+// hashName returns the name of a multihash code, or its hexadecimal code if it is unknown.
+func hashName(code uint64) string {
+	if name, ok := mh.Codes[code]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("0x%x", code)
+}
@@ -4,8 +4,11 @@
 package v1
 
 import (
+	"errors"
 	"testing"
 
+	cid "github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
 	ocidigest "github.com/opencontainers/go-digest"
 )
 
@@ -180,3 +183,78 @@ func TestIsValidCID(t *testing.T) {
 		})
 	}
 }
+
+func TestCIDOptions(t *testing.T) {
+	data := []byte("Hello, World!")
+
+	tests := []struct {
+		name       string
+		hash       string
+		wantDigest string
+		wantErr    error
+	}{
+		{
+			name:       "Default",
+			wantDigest: "sha256:dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f",
+		},
+		{
+			name:       "SHA2-256",
+			hash:       "sha2-256",
+			wantDigest: "sha256:dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f",
+		},
+		{
+			name:       "SHA2-512",
+			hash:       "SHA2-512",
+			wantDigest: ocidigest.SHA512.FromBytes(data).String(),
+		},
+		{
+			name:    "BLAKE3 without an OCI digest implementation",
+			hash:    "blake3",
+			wantErr: ErrUnsupportedHash,
+		},
+		{
+			name:    "Unknown hash function",
+			hash:    "md4",
+			wantErr: ErrUnsupportedHash,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseCIDOptions(tt.hash)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCIDOptions() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			cid, err := opts.CID(data)
+			if err != nil {
+				t.Fatalf("CID() unexpected error: %v", err)
+			}
+
+			// CIDs are self-describing and round-trip to the digest they were calculated from
+			digest, err := ConvertCIDToDigest(cid)
+			if err != nil || digest.String() != tt.wantDigest {
+				t.Errorf("ConvertCIDToDigest() = %v, %v, want %v", digest, err, tt.wantDigest)
+			}
+
+			parsed, err := CIDOptionsOf(cid)
+			if err != nil || parsed.hash() != opts.hash() {
+				t.Errorf("CIDOptionsOf() = %v, %v, want %v", parsed, err, opts)
+			}
+		})
+	}
+}
+
+func TestConvertCIDToDigestUnsupportedHash(t *testing.T) {
+	mhash, err := mh.Sum([]byte("Hello, World!"), mh.BLAKE3, -1)
+	if err != nil {
+		t.Fatalf("failed to hash: %v", err)
+	}
+
+	if _, err := ConvertCIDToDigest(cid.NewCidV1(cidCodec, mhash).String()); !errors.Is(err, ErrUnsupportedHash) {
+		t.Errorf("ConvertCIDToDigest() error = %v, want ErrUnsupportedHash", err)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+//go:generate go run ./gen_vectors -out testdata/cid_vectors.json
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// ConformanceCheck verifies that the record JSON document has the expected CID.
+// The document is loaded as is, like LoadOASFFromReader in lenient mode, and canonicalized with Marshal.
+// It lets other SDKs check their canonicalization against this implementation,
+// see testdata/cid_vectors.json for the shared test vectors.
+func ConformanceCheck(record []byte, expectedCID string) error {
+	if expectedCID == "" {
+		return errors.New("expected CID is required")
+	}
+
+	loaded, err := LoadOASFFromReader(bytes.NewReader(record), Lenient())
+	if err != nil {
+		return err
+	}
+
+	canonical, err := loaded.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to canonicalize record: %w", err)
+	}
+
+	digest, err := CalculateDigest(canonical)
+	if err != nil {
+		return fmt.Errorf("failed to calculate digest: %w", err)
+	}
+
+	cid, err := ConvertDigestToCID(digest)
+	if err != nil {
+		return fmt.Errorf("failed to calculate CID: %w", err)
+	}
+
+	if cid != expectedCID {
+		return fmt.Errorf("CID mismatch: expected %s, computed %s", expectedCID, cid)
+	}
+
+	return nil
+}
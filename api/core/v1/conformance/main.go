@@ -0,0 +1,57 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Command conformance checks that a record JSON document has the expected CID.
+// SDKs in other languages call it from their CI to verify the CIDs they compute
+// against this implementation.
+//
+// Usage:
+//
+//	go run github.com/agntcy/dir/api/core/v1/conformance record.json <cid>
+//	cat record.json | go run github.com/agntcy/dir/api/core/v1/conformance - <cid>
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) != 2 { //nolint:mnd
+		return errors.New("usage: conformance <record.json | -> <expected-cid>")
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read record: %w", err)
+	}
+
+	if err := corev1.ConformanceCheck(data, args[1]); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	fmt.Fprintln(os.Stdout, "OK", args[1])
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cidVector struct {
+	Name      string `json:"name"`
+	Record    string `json:"record"`
+	Canonical string `json:"canonical"`
+	CID       string `json:"cid"`
+}
+
+func loadCIDVectors(t *testing.T) []cidVector {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "cid_vectors.json"))
+	require.NoError(t, err)
+
+	var file struct {
+		Vectors []cidVector `json:"vectors"`
+	}
+	require.NoError(t, json.Unmarshal(data, &file))
+	require.NotEmpty(t, file.Vectors)
+
+	return file.Vectors
+}
+
+// TestCIDVectors fails if the canonicalization drifts from the published test vectors.
+// The vectors are shared with other SDKs, so they must not be regenerated to make this test pass
+// unless the change of CIDs is intended.
+func TestCIDVectors(t *testing.T) {
+	for _, vector := range loadCIDVectors(t) {
+		t.Run(vector.Name, func(t *testing.T) {
+			expected, err := base64.StdEncoding.DecodeString(vector.Canonical)
+			require.NoError(t, err)
+
+			record, err := corev1.LoadOASFFromReader(bytes.NewReader([]byte(vector.Record)), corev1.Lenient())
+			require.NoError(t, err)
+
+			canonical, err := record.Marshal()
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(canonical))
+			assert.Equal(t, vector.CID, record.GetCid())
+
+			require.NoError(t, corev1.ConformanceCheck([]byte(vector.Record), vector.CID))
+		})
+	}
+}
+
+func TestConformanceCheck_Mismatch(t *testing.T) {
+	vectors := loadCIDVectors(t)
+	require.GreaterOrEqual(t, len(vectors), 2)
+
+	err := corev1.ConformanceCheck([]byte(vectors[0].Record), vectors[1].CID)
+	require.ErrorContains(t, err, "CID mismatch")
+
+	require.Error(t, corev1.ConformanceCheck([]byte(vectors[0].Record), ""))
+	require.Error(t, corev1.ConformanceCheck([]byte(`{"name":`), vectors[0].CID))
+}
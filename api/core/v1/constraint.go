@@ -0,0 +1,200 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Comparison operators of version constraints.
+const (
+	opEqual        = "="
+	opNotEqual     = "!="
+	opGreater      = ">"
+	opGreaterEqual = ">="
+	opLess         = "<"
+	opLessEqual    = "<="
+)
+
+// VersionConstraint is a set of version comparisons that a version must all satisfy,
+// e.g. ">=1.2.0 <2.0.0", see ParseVersionConstraint.
+type VersionConstraint struct {
+	raw         string
+	comparisons []versionComparison
+
+	// prerelease is set if a comparison names a pre-release version, which allows pre-release versions to match.
+	prerelease bool
+}
+
+type versionComparison struct {
+	op      string
+	version string
+}
+
+// ParseVersionConstraint parses a version constraint of comparisons separated by spaces or commas.
+// A comparison is a version with an optional operator: "=", "!=", ">", ">=", "<", "<=",
+// "^" for versions compatible with the version, e.g. "^1.2" for >=1.2.0 <2.0.0 and "^0.2.3" for >=0.2.3 <0.3.0,
+// or "~" for patch versions of the version if it has a minor version, e.g. "~1.2" for >=1.2.0 <1.3.0 and "~1" for >=1.0.0 <2.0.0.
+// A version without an operator only matches itself. An empty constraint or "*" matches any version.
+//
+// Pre-release versions only match constraints naming a pre-release version.
+// Versions that are not semantic versions only match constraints of equal versions.
+func ParseVersionConstraint(constraint string) (VersionConstraint, error) {
+	result := VersionConstraint{raw: strings.TrimSpace(constraint)}
+
+	for _, field := range strings.FieldsFunc(constraint, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if field == "*" {
+			continue
+		}
+
+		op, version := splitOperator(field)
+		if version == "" {
+			return VersionConstraint{}, fmt.Errorf("invalid version constraint %q: missing version after %q", constraint, op)
+		}
+
+		switch op {
+		case "^", "~":
+			lower, upper, err := versionRange(op, version)
+			if err != nil {
+				return VersionConstraint{}, fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+			}
+
+			result.comparisons = append(result.comparisons,
+				versionComparison{op: opGreaterEqual, version: lower},
+				versionComparison{op: opLess, version: upper},
+			)
+
+		case opEqual, opNotEqual:
+			result.comparisons = append(result.comparisons, versionComparison{op: op, version: version})
+
+		default:
+			if !IsSemver(version) {
+				return VersionConstraint{}, fmt.Errorf("invalid version constraint %q: %s is not a semantic version", constraint, version)
+			}
+
+			result.comparisons = append(result.comparisons, versionComparison{op: op, version: version})
+		}
+
+		if v, ok := parseSemver(version); ok && len(v.prerelease) > 0 {
+			result.prerelease = true
+		}
+	}
+
+	return result, nil
+}
+
+// splitOperator splits a comparison into its operator, "=" if it has none, and its version.
+func splitOperator(comparison string) (string, string) {
+	for _, op := range []string{opGreaterEqual, opLessEqual, opNotEqual, opGreater, opLess, opEqual, "^", "~"} {
+		if version, ok := strings.CutPrefix(comparison, op); ok {
+			return op, version
+		}
+	}
+
+	return opEqual, comparison
+}
+
+// versionRange returns the bounds of a "^" or "~" comparison. The upper bound increments the first
+// non-zero part for "^", and the minor version for "~", or the major version if only it is given.
+func versionRange(op, version string) (string, string, error) {
+	v, ok := parseSemver(version)
+	if !ok {
+		return "", "", fmt.Errorf("%s is not a semantic version", version)
+	}
+
+	core, _, _ := strings.Cut(strings.TrimLeft(strings.TrimSpace(version), "vV"), "-")
+	core, _, _ = strings.Cut(core, "+")
+	given := strings.Count(core, ".") + 1
+
+	var bump int
+
+	switch {
+	case op == "~":
+		bump = min(given-1, 1)
+	case v.core[0] > 0 || given == 1:
+		bump = 0
+	case v.core[1] > 0 || given == 2: //nolint:mnd
+		bump = 1
+	default:
+		bump = 2 //nolint:mnd
+	}
+
+	var upper [semverParts]uint64
+
+	copy(upper[:bump], v.core[:bump])
+	upper[bump] = v.core[bump] + 1
+
+	return formatSemver(v), formatSemver(semver{core: upper}), nil
+}
+
+// formatSemver formats a parsed semantic version without build metadata.
+func formatSemver(v semver) string {
+	parts := make([]string, semverParts)
+	for i, n := range v.core {
+		parts[i] = strconv.FormatUint(n, 10)
+	}
+
+	version := strings.Join(parts, ".")
+	if len(v.prerelease) > 0 {
+		version += "-" + strings.Join(v.prerelease, ".")
+	}
+
+	return version
+}
+
+// IsExact reports whether the constraint only matches a single version, which is returned.
+func (c VersionConstraint) IsExact() (string, bool) {
+	if len(c.comparisons) != 1 || c.comparisons[0].op != opEqual {
+		return "", false
+	}
+
+	return c.comparisons[0].version, true
+}
+
+// Matches reports whether the version satisfies all comparisons of the constraint.
+func (c VersionConstraint) Matches(version string) bool {
+	v, isSemver := parseSemver(version)
+
+	if isSemver && len(v.prerelease) > 0 && !c.prerelease {
+		return false
+	}
+
+	for _, comparison := range c.comparisons {
+		if !isSemver && comparison.op != opEqual && comparison.op != opNotEqual {
+			return false
+		}
+
+		result := CompareVersions(version, comparison.version)
+
+		var ok bool
+
+		switch comparison.op {
+		case opEqual:
+			ok = result == 0
+		case opNotEqual:
+			ok = result != 0
+		case opGreater:
+			ok = result > 0
+		case opGreaterEqual:
+			ok = result >= 0
+		case opLess:
+			ok = result < 0
+		case opLessEqual:
+			ok = result <= 0
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns the constraint as it was parsed.
+func (c VersionConstraint) String() string {
+	return c.raw
+}
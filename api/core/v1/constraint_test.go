@@ -0,0 +1,62 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		matches    []string
+		rejects    []string
+	}{
+		{constraint: "", matches: []string{"1.0.0", "v0.1.0", "dev"}, rejects: []string{"1.0.0-rc.1"}},
+		{constraint: "*", matches: []string{"2.3.4"}},
+		{constraint: "v1.2.0", matches: []string{"1.2.0", "v1.2"}, rejects: []string{"1.2.1"}},
+		{constraint: "dev", matches: []string{"dev"}, rejects: []string{"1.0.0"}},
+		{constraint: ">=1.2.0 <2.0.0", matches: []string{"1.2.0", "1.9.9"}, rejects: []string{"1.1.9", "2.0.0", "dev"}},
+		{constraint: ">1.0, <=1.5, !=1.3.0", matches: []string{"1.0.1", "1.5.0"}, rejects: []string{"1.0.0", "1.3.0", "1.5.1"}},
+		{constraint: "^1.2", matches: []string{"1.2.0", "1.99.0"}, rejects: []string{"1.1.0", "2.0.0", "1.3.0-beta"}},
+		{constraint: "^0.2.3", matches: []string{"0.2.3", "0.2.9"}, rejects: []string{"0.3.0", "0.2.2"}},
+		{constraint: "^0.0.3", matches: []string{"0.0.3"}, rejects: []string{"0.0.4"}},
+		{constraint: "~1.2", matches: []string{"1.2.0", "1.2.7"}, rejects: []string{"1.3.0"}},
+		{constraint: "~1", matches: []string{"1.9.0"}, rejects: []string{"2.0.0"}},
+		{constraint: ">=1.0.0-rc.1", matches: []string{"1.0.0-rc.2", "1.0.0", "1.1.0"}, rejects: []string{"1.0.0-beta"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			constraint, err := corev1.ParseVersionConstraint(tt.constraint)
+			require.NoError(t, err)
+
+			for _, version := range tt.matches {
+				assert.True(t, constraint.Matches(version), "%s should match %s", version, tt.constraint)
+			}
+
+			for _, version := range tt.rejects {
+				assert.False(t, constraint.Matches(version), "%s should not match %s", version, tt.constraint)
+			}
+		})
+	}
+
+	for _, invalid := range []string{">=", ">=dev", "^latest", "<1.0.0.0"} {
+		_, err := corev1.ParseVersionConstraint(invalid)
+		assert.Error(t, err, invalid)
+	}
+
+	exact, _ := corev1.ParseVersionConstraint("=v1.0.0")
+	version, ok := exact.IsExact()
+	assert.True(t, ok)
+	assert.Equal(t, "v1.0.0", version)
+
+	ranged, _ := corev1.ParseVersionConstraint("^1.0.0")
+	_, ok = ranged.IsExact()
+	assert.False(t, ok)
+}
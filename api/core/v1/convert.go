@@ -0,0 +1,292 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ErrLossyConversion is returned by ConvertTo when the target schema cannot
+// represent all fields of the source record and AllowLossy was not set.
+var ErrLossyConversion = errors.New("lossy record conversion")
+
+// ConvertOption configures record conversion.
+type ConvertOption func(*convertOptions)
+
+type convertOptions struct {
+	allowLossy bool
+}
+
+// AllowLossy permits conversions that drop fields which have no equivalent
+// in the target schema version.
+func AllowLossy() ConvertOption {
+	return func(o *convertOptions) {
+		o.allowLossy = true
+	}
+}
+
+// ConvertTo converts the record into the given OASF schema version.
+//
+// Supported versions are "v0.3.1" (typesv1alpha0) and "0.7.0" (typesv1alpha1),
+// also accepted as "v1" and "v3" respectively.
+// Fields are mapped as follows:
+//   - name, version, description, authors, created_at and annotations are copied as-is.
+//   - locators are copied with their type converted between the kebab-case v0.3.1 names
+//     and the snake_case 0.7.0 names, e.g. "docker-image" and "docker_image".
+//   - v0.3.1 skills map to 0.7.0 skills named "<category>/<class>" with the class UID as ID.
+//     The category UID has no 0.7.0 equivalent.
+//   - v0.3.1 extensions map to 0.7.0 modules by name, annotations and data.
+//     The extension version has no 0.7.0 equivalent; the module ID has no v0.3.1 equivalent.
+//   - 0.7.0 domains, previous record CID and signature annotations have no v0.3.1 equivalent.
+//
+// If any populated field would be dropped, ErrLossyConversion is returned
+// unless AllowLossy is passed. Converting to the record's own version returns a copy.
+// Otherwise the returned record is a new representation, so its CID differs from the source record.
+func (r *Record) ConvertTo(targetVersion string, opts ...ConvertOption) (*Record, error) {
+	options := &convertOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	decoded, err := r.Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := normalizeSchemaVersion(targetVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		converted *Record
+		lost      []string
+	)
+
+	switch {
+	case decoded.HasV1Alpha0() && target == schemaVersionV1Alpha0,
+		decoded.HasV1Alpha1() && target == schemaVersionV1Alpha1:
+		// Same representation, nothing to convert
+		data, _ := proto.Clone(r.GetData()).(*structpb.Struct)
+
+		return &Record{Data: data}, nil
+
+	case decoded.HasV1Alpha0() && target == schemaVersionV1Alpha1:
+		var record *typesv1alpha1.Record
+
+		record, lost = convertV1Alpha0ToV1Alpha1(decoded.GetV1Alpha0())
+		record.SchemaVersion = target
+		converted = New(record)
+
+	case decoded.HasV1Alpha1() && target == schemaVersionV1Alpha0:
+		var record *typesv1alpha0.Record
+
+		record, lost = convertV1Alpha1ToV1Alpha0(decoded.GetV1Alpha1())
+		record.SchemaVersion = target
+		converted = New(record)
+
+	default:
+		return nil, fmt.Errorf("unsupported conversion from %s to %s", r.GetSchemaVersion(), targetVersion)
+	}
+
+	if len(lost) > 0 && !options.allowLossy {
+		return nil, fmt.Errorf("%w to %s: %s", ErrLossyConversion, targetVersion, strings.Join(lost, ", "))
+	}
+
+	return converted, nil
+}
+
+// IsSupportedSchemaVersion reports whether records can be converted to and from the OASF schema version.
+func IsSupportedSchemaVersion(version string) bool {
+	_, err := normalizeSchemaVersion(version)
+
+	return err == nil
+}
+
+// HasSchemaVersion reports whether the record has the given OASF schema version,
+// in any of the spellings accepted by ConvertTo.
+func (r *Record) HasSchemaVersion(version string) bool {
+	expected, err := normalizeSchemaVersion(version)
+	if err != nil {
+		return false
+	}
+
+	actual, err := normalizeSchemaVersion(r.GetSchemaVersion())
+
+	return err == nil && actual == expected
+}
+
+const (
+	schemaVersionV1Alpha0 = "v0.3.1"
+	schemaVersionV1Alpha1 = "0.7.0"
+)
+
+// normalizeSchemaVersion maps the accepted spellings of a schema version to a single value.
+// The record shapes are also accepted by their API version, "v1" for v0.3.1 agent records
+// and "v3" for 0.7.0 records.
+func normalizeSchemaVersion(version string) (string, error) {
+	switch version {
+	case "0.3.1", "v0.3.1", "v1":
+		return schemaVersionV1Alpha0, nil
+	case "0.7.0", "v0.7.0", "v3":
+		return schemaVersionV1Alpha1, nil
+	default:
+		return "", fmt.Errorf("unsupported OASF version: %s", version)
+	}
+}
+
+//nolint:cyclop
+func convertV1Alpha0ToV1Alpha1(src *typesv1alpha0.Record) (*typesv1alpha1.Record, []string) {
+	var lost []string
+
+	dst := &typesv1alpha1.Record{
+		Name:        src.GetName(),
+		Version:     src.GetVersion(),
+		Description: src.GetDescription(),
+		Authors:     src.GetAuthors(),
+		CreatedAt:   src.GetCreatedAt(),
+		Annotations: src.GetAnnotations(),
+	}
+
+	for i, skill := range src.GetSkills() {
+		name := skill.GetCategoryName()
+		if skill.GetClassName() != "" {
+			name = name + "/" + skill.GetClassName()
+		}
+
+		if skill.GetCategoryUid() != 0 {
+			lost = append(lost, fmt.Sprintf("skills[%d].category_uid", i))
+		}
+
+		if skill.GetClassUid() > math.MaxUint32 {
+			lost = append(lost, fmt.Sprintf("skills[%d].class_uid", i))
+		}
+
+		dst.Skills = append(dst.Skills, &typesv1alpha1.Skill{
+			Name:        name,
+			Id:          uint32(skill.GetClassUid()), //nolint:gosec
+			Annotations: skill.GetAnnotations(),
+		})
+	}
+
+	for _, locator := range src.GetLocators() {
+		dst.Locators = append(dst.Locators, &typesv1alpha1.Locator{
+			Type:        strings.ReplaceAll(locator.GetType(), "-", "_"),
+			Url:         locator.GetUrl(),
+			Annotations: locator.GetAnnotations(),
+			Size:        locator.Size,
+			Digest:      locator.Digest,
+		})
+	}
+
+	for i, extension := range src.GetExtensions() {
+		if extension.GetVersion() != "" {
+			lost = append(lost, fmt.Sprintf("extensions[%d].version", i))
+		}
+
+		dst.Modules = append(dst.Modules, &typesv1alpha1.Module{
+			Name:        extension.GetName(),
+			Annotations: extension.GetAnnotations(),
+			Data:        extension.GetData(),
+		})
+	}
+
+	if signature := src.GetSignature(); signature != nil {
+		dst.Signature = &typesv1alpha1.Signature{
+			Algorithm:     signature.GetAlgorithm(),
+			Signature:     signature.GetSignature(),
+			Certificate:   signature.GetCertificate(),
+			ContentType:   signature.GetContentType(),
+			ContentBundle: signature.GetContentBundle(),
+			SignedAt:      signature.GetSignedAt(),
+		}
+	}
+
+	return dst, lost
+}
+
+//nolint:cyclop
+func convertV1Alpha1ToV1Alpha0(src *typesv1alpha1.Record) (*typesv1alpha0.Record, []string) {
+	var lost []string
+
+	dst := &typesv1alpha0.Record{
+		Name:        src.GetName(),
+		Version:     src.GetVersion(),
+		Description: src.GetDescription(),
+		Authors:     src.GetAuthors(),
+		CreatedAt:   src.GetCreatedAt(),
+		Annotations: src.GetAnnotations(),
+	}
+
+	for _, skill := range src.GetSkills() {
+		// Skill names are "<category>/<class>", where the class may itself contain slashes
+		category, class, _ := strings.Cut(skill.GetName(), "/")
+
+		converted := &typesv1alpha0.Skill{
+			CategoryName: &category,
+			ClassUid:     uint64(skill.GetId()),
+			Annotations:  skill.GetAnnotations(),
+		}
+		if class != "" {
+			converted.ClassName = &class
+		}
+
+		dst.Skills = append(dst.Skills, converted)
+	}
+
+	for _, locator := range src.GetLocators() {
+		dst.Locators = append(dst.Locators, &typesv1alpha0.Locator{
+			Type:        strings.ReplaceAll(locator.GetType(), "_", "-"),
+			Url:         locator.GetUrl(),
+			Annotations: locator.GetAnnotations(),
+			Size:        locator.Size,
+			Digest:      locator.Digest,
+		})
+	}
+
+	for i, module := range src.GetModules() {
+		if module.GetId() != 0 {
+			lost = append(lost, fmt.Sprintf("modules[%d].id", i))
+		}
+
+		dst.Extensions = append(dst.Extensions, &typesv1alpha0.Extension{
+			Name:        module.GetName(),
+			Annotations: module.GetAnnotations(),
+			Data:        module.GetData(),
+		})
+	}
+
+	if len(src.GetDomains()) > 0 {
+		lost = append(lost, "domains")
+	}
+
+	if src.GetPreviousRecordCid() != "" {
+		lost = append(lost, "previous_record_cid")
+	}
+
+	if signature := src.GetSignature(); signature != nil {
+		if len(signature.GetAnnotations()) > 0 {
+			lost = append(lost, "signature.annotations")
+		}
+
+		dst.Signature = &typesv1alpha0.Signature{
+			Algorithm:     signature.GetAlgorithm(),
+			Signature:     signature.GetSignature(),
+			Certificate:   signature.GetCertificate(),
+			ContentType:   signature.GetContentType(),
+			ContentBundle: signature.GetContentBundle(),
+			SignedAt:      signature.GetSignedAt(),
+		}
+	}
+
+	return dst, lost
+}
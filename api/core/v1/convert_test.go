@@ -0,0 +1,146 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+func loadRecord(t *testing.T, name string) *corev1.Record {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "convert", name))
+	require.NoError(t, err)
+
+	record, err := corev1.UnmarshalRecord(data)
+	require.NoError(t, err)
+
+	return record
+}
+
+func assertGolden(t *testing.T, name string, record *corev1.Record) {
+	t.Helper()
+
+	data, err := record.Marshal()
+	require.NoError(t, err)
+
+	var indented bytes.Buffer
+	require.NoError(t, json.Indent(&indented, data, "", "  "))
+	indented.WriteString("\n")
+
+	path := filepath.Join("testdata", "convert", name)
+
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(path, indented.Bytes(), 0o600)) //nolint:mnd
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), indented.String())
+}
+
+func TestRecord_ConvertTo(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		target string
+		golden string
+	}{
+		{
+			name:   "v0.3.1 to 0.7.0",
+			input:  "record_031.json",
+			target: "0.7.0",
+			golden: "record_031_to_070.golden.json",
+		},
+		{
+			name:   "0.7.0 to v0.3.1",
+			input:  "record_070.json",
+			target: "v0.3.1",
+			golden: "record_070_to_031.golden.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := loadRecord(t, tt.input)
+
+			_, err := record.ConvertTo(tt.target)
+			require.ErrorIs(t, err, corev1.ErrLossyConversion)
+
+			converted, err := record.ConvertTo(tt.target, corev1.AllowLossy())
+			require.NoError(t, err)
+
+			assertGolden(t, tt.golden, converted)
+
+			// The converted record must decode as the target version and have its own CID
+			_, err = converted.Decode()
+			require.NoError(t, err)
+			assert.NotEmpty(t, converted.GetCid())
+			assert.NotEqual(t, record.GetCid(), converted.GetCid())
+		})
+	}
+}
+
+func TestRecord_ConvertTo_SameVersion(t *testing.T) {
+	record := loadRecord(t, "record_070.json")
+
+	converted, err := record.ConvertTo("0.7.0")
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), converted.GetCid())
+}
+
+func TestRecord_ConvertTo_UnsupportedVersion(t *testing.T) {
+	record := loadRecord(t, "record_070.json")
+
+	_, err := record.ConvertTo("v9.9.9")
+	require.Error(t, err)
+}
+
+func TestRecord_HasSchemaVersion(t *testing.T) {
+	record := loadRecord(t, "record_031.json")
+
+	for _, version := range []string{"v0.3.1", "0.3.1", "v1"} {
+		assert.True(t, record.HasSchemaVersion(version), version)
+	}
+
+	for _, version := range []string{"0.7.0", "v3", "v9.9.9", ""} {
+		assert.False(t, record.HasSchemaVersion(version), version)
+	}
+
+	converted, err := record.ConvertTo("v3", corev1.AllowLossy())
+	require.NoError(t, err)
+	assert.True(t, converted.HasSchemaVersion("0.7.0"))
+}
+
+func TestRecord_SetMigratedFrom(t *testing.T) {
+	record := loadRecord(t, "record_031.json")
+
+	converted, err := record.ConvertTo("0.7.0", corev1.AllowLossy())
+	require.NoError(t, err)
+
+	cid := converted.GetCid()
+
+	require.NoError(t, converted.SetMigratedFrom(record.GetCid()))
+	assert.Equal(t, record.GetCid(), converted.GetMigratedFrom())
+	assert.NotEqual(t, cid, converted.GetCid(), "the annotation is part of the record content")
+
+	// Other annotations are kept
+	assert.Equal(t, record.GetData().GetFields()["annotations"].GetStructValue().GetFields()["key"].GetStringValue(),
+		converted.GetData().GetFields()["annotations"].GetStructValue().GetFields()["key"].GetStringValue())
+
+	assert.Empty(t, record.GetMigratedFrom())
+	require.Error(t, converted.SetMigratedFrom(""))
+}
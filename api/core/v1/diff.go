@@ -0,0 +1,288 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DiffKind describes how a value changed between two records.
+type DiffKind string
+
+const (
+	DiffAdded   DiffKind = "added"
+	DiffRemoved DiffKind = "removed"
+	DiffChanged DiffKind = "changed"
+)
+
+// DiffEntry is a single difference between two records.
+type DiffEntry struct {
+	// Path is the location of the value, e.g. "modules[license].data.header" or "authors[0]".
+	// Elements of named lists (skills, extensions, modules, ...) are addressed by name.
+	Path string
+	Kind DiffKind
+	Old  any
+	New  any
+}
+
+// RecordDiff holds the differences between two records, sorted by path.
+type RecordDiff struct {
+	Entries []DiffEntry
+}
+
+// HasChanges reports whether the records differ.
+func (d *RecordDiff) HasChanges() bool {
+	return d != nil && len(d.Entries) > 0
+}
+
+// ChangedPaths returns the paths of all differences.
+func (d *RecordDiff) ChangedPaths() []string {
+	if d == nil {
+		return nil
+	}
+
+	paths := make([]string, 0, len(d.Entries))
+	for _, entry := range d.Entries {
+		paths = append(paths, entry.Path)
+	}
+
+	return paths
+}
+
+// String returns a human-readable representation of the diff, one change per line.
+func (d *RecordDiff) String() string {
+	if !d.HasChanges() {
+		return "no changes"
+	}
+
+	var sb strings.Builder
+
+	for _, entry := range d.Entries {
+		switch entry.Kind {
+		case DiffAdded:
+			fmt.Fprintf(&sb, "+ %s: %s\n", entry.Path, formatDiffValue(entry.New))
+		case DiffRemoved:
+			fmt.Fprintf(&sb, "- %s: %s\n", entry.Path, formatDiffValue(entry.Old))
+		case DiffChanged:
+			fmt.Fprintf(&sb, "~ %s: %s -> %s\n", entry.Path, formatDiffValue(entry.Old), formatDiffValue(entry.New))
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// DiffRecords computes a structured diff between two records over their canonical JSON.
+//
+// Records of different schema versions are compared by first converting the older
+// record to the newer schema, allowing lossy conversions. Lists whose elements are
+// named objects (such as skills, extensions and modules) are compared as sets keyed
+// by name, so reordering them is not reported as a change.
+func DiffRecords(a, b *Record) (*RecordDiff, error) {
+	if a == nil || b == nil {
+		return nil, errors.New("records must not be nil")
+	}
+
+	a, b, err := alignSchemaVersions(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	oldValue, err := canonicalValue(a)
+	if err != nil {
+		return nil, err
+	}
+
+	newValue, err := canonicalValue(b)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &RecordDiff{}
+	diffValues(diff, "", oldValue, newValue)
+
+	sort.SliceStable(diff.Entries, func(i, j int) bool {
+		return diff.Entries[i].Path < diff.Entries[j].Path
+	})
+
+	return diff, nil
+}
+
+// alignSchemaVersions converts the older of the two records to the schema of the newer one.
+func alignSchemaVersions(a, b *Record) (*Record, *Record, error) {
+	decodedA, err := a.Decode()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decodedB, err := b.Decode()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case decodedA.HasV1Alpha0() && decodedB.HasV1Alpha1():
+		a, err = a.ConvertTo(schemaVersionV1Alpha1, AllowLossy())
+	case decodedA.HasV1Alpha1() && decodedB.HasV1Alpha0():
+		b, err = b.ConvertTo(schemaVersionV1Alpha1, AllowLossy())
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert records to a common version: %w", err)
+	}
+
+	return a, b, nil
+}
+
+func canonicalValue(record *Record) (any, error) {
+	data, err := record.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal canonical record: %w", err)
+	}
+
+	return value, nil
+}
+
+func diffValues(diff *RecordDiff, path string, oldValue, newValue any) {
+	switch oldTyped := oldValue.(type) {
+	case map[string]any:
+		if newTyped, ok := newValue.(map[string]any); ok {
+			diffMaps(diff, path, oldTyped, newTyped, fieldPath)
+
+			return
+		}
+
+	case []any:
+		if newTyped, ok := newValue.([]any); ok {
+			diffSlices(diff, path, oldTyped, newTyped)
+
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(oldValue, newValue) {
+		diff.Entries = append(diff.Entries, DiffEntry{Path: path, Kind: DiffChanged, Old: oldValue, New: newValue})
+	}
+}
+
+func diffMaps(diff *RecordDiff, path string, oldMap, newMap map[string]any, join func(string, string) string) {
+	for key, oldValue := range oldMap {
+		childPath := join(path, key)
+
+		newValue, ok := newMap[key]
+		if !ok {
+			diff.Entries = append(diff.Entries, DiffEntry{Path: childPath, Kind: DiffRemoved, Old: oldValue})
+
+			continue
+		}
+
+		diffValues(diff, childPath, oldValue, newValue)
+	}
+
+	for key, newValue := range newMap {
+		if _, ok := oldMap[key]; !ok {
+			diff.Entries = append(diff.Entries, DiffEntry{Path: join(path, key), Kind: DiffAdded, New: newValue})
+		}
+	}
+}
+
+func diffSlices(diff *RecordDiff, path string, oldSlice, newSlice []any) {
+	oldKeyed, oldOk := keyElements(oldSlice)
+	newKeyed, newOk := keyElements(newSlice)
+
+	// Compare named elements as sets
+	if oldOk && newOk {
+		diffMaps(diff, path, oldKeyed, newKeyed, elementPath)
+
+		return
+	}
+
+	// Otherwise compare by position
+	for i := range max(len(oldSlice), len(newSlice)) {
+		childPath := path + "[" + strconv.Itoa(i) + "]"
+
+		switch {
+		case i >= len(newSlice):
+			diff.Entries = append(diff.Entries, DiffEntry{Path: childPath, Kind: DiffRemoved, Old: oldSlice[i]})
+		case i >= len(oldSlice):
+			diff.Entries = append(diff.Entries, DiffEntry{Path: childPath, Kind: DiffAdded, New: newSlice[i]})
+		default:
+			diffValues(diff, childPath, oldSlice[i], newSlice[i])
+		}
+	}
+}
+
+// keyElements indexes list elements by name. It returns false if any element
+// is not a named object or if names are not unique.
+func keyElements(elements []any) (map[string]any, bool) {
+	keyed := make(map[string]any, len(elements))
+
+	for _, element := range elements {
+		object, ok := element.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		key := elementKey(object)
+		if key == "" {
+			return nil, false
+		}
+
+		if _, exists := keyed[key]; exists {
+			return nil, false
+		}
+
+		keyed[key] = element
+	}
+
+	return keyed, true
+}
+
+// elementKey returns the name of a list element.
+// v0.3.1 skills have no name field, so they are keyed by category and class names.
+func elementKey(object map[string]any) string {
+	if name, ok := object["name"].(string); ok {
+		return name
+	}
+
+	category, _ := object["category_name"].(string)
+	class, _ := object["class_name"].(string)
+
+	if class == "" {
+		return category
+	}
+
+	return category + "/" + class
+}
+
+func fieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func elementPath(path, key string) string {
+	return path + "[" + key + "]"
+}
+
+func formatDiffValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(data)
+}
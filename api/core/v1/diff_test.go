@@ -0,0 +1,120 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"testing"
+
+	oasfv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	oasfv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newDiffRecord(t *testing.T, description string, framework string, skills ...string) *corev1.Record {
+	t.Helper()
+
+	data, err := structpb.NewStruct(map[string]any{
+		"name":    "crewai",
+		"version": framework,
+	})
+	require.NoError(t, err)
+
+	record := &oasfv1alpha1.Record{
+		Name:          "test-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+		Description:   description,
+		Modules: []*oasfv1alpha1.Module{
+			{Name: "license"},
+			{Name: "runtime/framework", Data: data},
+		},
+	}
+
+	for _, skill := range skills {
+		record.Skills = append(record.Skills, &oasfv1alpha1.Skill{Name: skill})
+	}
+
+	return corev1.New(record)
+}
+
+func TestDiffRecords(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        *corev1.Record
+		b        *corev1.Record
+		expected []corev1.DiffEntry
+	}{
+		{
+			name: "identical records",
+			a:    newDiffRecord(t, "agent", "0.55.2", "a/b"),
+			b:    newDiffRecord(t, "agent", "0.55.2", "a/b"),
+		},
+		{
+			name: "reordered skills",
+			a:    newDiffRecord(t, "agent", "0.55.2", "a/b", "c/d"),
+			b:    newDiffRecord(t, "agent", "0.55.2", "c/d", "a/b"),
+		},
+		{
+			name: "changed description and added skill",
+			a:    newDiffRecord(t, "agent", "0.55.2", "a/b"),
+			b:    newDiffRecord(t, "new agent", "0.55.2", "a/b", "c/d"),
+			expected: []corev1.DiffEntry{
+				{Path: "description", Kind: corev1.DiffChanged, Old: "agent", New: "new agent"},
+				{Path: "skills[c/d]", Kind: corev1.DiffAdded, New: map[string]any{"name": "c/d"}},
+			},
+		},
+		{
+			name: "nested module data change",
+			a:    newDiffRecord(t, "agent", "0.55.2"),
+			b:    newDiffRecord(t, "agent", "0.56.0"),
+			expected: []corev1.DiffEntry{
+				{Path: "modules[runtime/framework].data.version", Kind: corev1.DiffChanged, Old: "0.55.2", New: "0.56.0"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := corev1.DiffRecords(tt.a, tt.b)
+			require.NoError(t, err)
+
+			assert.Equal(t, len(tt.expected) > 0, diff.HasChanges())
+			assert.ElementsMatch(t, tt.expected, diff.Entries)
+		})
+	}
+}
+
+func TestDiffRecords_CrossVersion(t *testing.T) {
+	className := "Text Completion"
+	categoryName := "Natural Language Processing"
+
+	a := corev1.New(&oasfv1alpha0.Record{
+		Name:          "test-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "v0.3.1",
+		Description:   "agent",
+		Skills: []*oasfv1alpha0.Skill{
+			{CategoryName: &categoryName, ClassName: &className, ClassUid: 10201}, //nolint:mnd
+		},
+	})
+
+	b := corev1.New(&oasfv1alpha1.Record{
+		Name:          "test-agent",
+		Version:       "v1.1.0",
+		SchemaVersion: "0.7.0",
+		Description:   "agent",
+		Skills: []*oasfv1alpha1.Skill{
+			{Name: "Natural Language Processing/Text Completion", Id: 10201}, //nolint:mnd
+		},
+	})
+
+	diff, err := corev1.DiffRecords(a, b)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"version"}, diff.ChangedPaths())
+	assert.Equal(t, `~ version: "v1.0.0" -> "v1.1.0"`, diff.String())
+}
@@ -0,0 +1,114 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// EncryptionAlgorithmAES256GCM is the content encryption algorithm of record envelopes.
+const EncryptionAlgorithmAES256GCM = "AES-256-GCM"
+
+// IsEncrypted reports whether the content of the record is encrypted, see RecordEnvelope.
+func (r *Record) IsEncrypted() bool {
+	return r.GetEnvelope() != nil || r.GetEncrypted()
+}
+
+// SealRecord returns the encrypted form of a record, given its canonical bytes encrypted as described by the header.
+// The envelope carries the CID, schema version, name, version, creation time, annotations and name tags of the
+// record, so that the server can index, tag and look up the record without reading its content.
+func SealRecord(record *Record, ciphertext []byte, header *EncryptionHeader) (*Record, error) {
+	if record.GetData() == nil {
+		return nil, errors.New("record data is empty")
+	}
+
+	fields := record.GetData().GetFields()
+
+	annotations := map[string]string{}
+	for key, value := range fields["annotations"].GetStructValue().GetFields() {
+		annotations[key] = value.GetStringValue()
+	}
+
+	// The first tag is the CID, which the server derives from the envelope
+	var tags []string
+	if discoveryTags := record.DiscoveryTags(); len(discoveryTags) > 1 {
+		tags = discoveryTags[1:]
+	}
+
+	envelope := &RecordEnvelope{
+		Cid:        record.GetCid(),
+		Ciphertext: ciphertext,
+		Header:     header,
+		Metadata: &EnvelopeMetadata{
+			Name:          fields["name"].GetStringValue(),
+			Version:       fields["version"].GetStringValue(),
+			SchemaVersion: record.GetSchemaVersion(),
+			CreatedAt:     fields["created_at"].GetStringValue(),
+			Annotations:   annotations,
+			Tags:          tags,
+		},
+	}
+
+	if err := envelope.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Record{Envelope: envelope}, nil
+}
+
+// Validate checks that the envelope is complete. The ciphertext itself can only be checked by decrypting it.
+func (e *RecordEnvelope) Validate() error {
+	if !IsValidCID(e.GetCid()) {
+		return fmt.Errorf("invalid envelope CID %q", e.GetCid())
+	}
+
+	if len(e.GetCiphertext()) == 0 {
+		return errors.New("envelope ciphertext is empty")
+	}
+
+	header := e.GetHeader()
+	if header.GetAlgorithm() == "" || header.GetKeyId() == "" || len(header.GetWrappedKey()) == 0 || len(header.GetNonce()) == 0 {
+		return errors.New("envelope encryption header is incomplete")
+	}
+
+	metadata := e.GetMetadata()
+	if metadata.GetName() == "" {
+		return errors.New("envelope metadata has no record name")
+	}
+
+	if metadata.GetSchemaVersion() == "" {
+		return errors.New("envelope metadata has no schema version")
+	}
+
+	for _, tag := range metadata.GetTags() {
+		if !validNameTag(tag) {
+			return fmt.Errorf("envelope metadata has invalid tag %q", tag)
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalEnvelope unmarshals a binary envelope, as returned by Marshal for encrypted records, to an encrypted Record.
+func UnmarshalEnvelope(data []byte) (*Record, error) {
+	envelope := &RecordEnvelope{}
+	if err := proto.Unmarshal(data, envelope); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal record envelope: %w", err)
+	}
+
+	return &Record{Envelope: envelope, Encrypted: true}, nil
+}
+
+// marshalEnvelope marshals the envelope deterministically, so that equal envelopes are stored as equal blobs.
+func marshalEnvelope(envelope *RecordEnvelope) ([]byte, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal record envelope: %w", err)
+	}
+
+	return data, nil
+}
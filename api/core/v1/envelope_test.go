@@ -0,0 +1,94 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"testing"
+
+	oasfv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newEnvelopeTestHeader() *corev1.EncryptionHeader {
+	return &corev1.EncryptionHeader{
+		Algorithm:  corev1.EncryptionAlgorithmAES256GCM,
+		KeyId:      "test-key",
+		WrappedKey: []byte("wrapped"),
+		Nonce:      []byte("nonce"),
+	}
+}
+
+func TestSealRecord(t *testing.T) {
+	record := corev1.New(&oasfv1alpha1.Record{
+		Name:          "My Agent",
+		Version:       "1.0.0",
+		SchemaVersion: "0.7.0",
+		CreatedAt:     "2025-01-01T00:00:00Z",
+		Annotations:   map[string]string{"team": "search"},
+	})
+
+	sealed, err := corev1.SealRecord(record, []byte("ciphertext"), newEnvelopeTestHeader())
+	require.NoError(t, err)
+
+	// The identity and tags of the record are kept
+	assert.Nil(t, sealed.GetData())
+	assert.True(t, sealed.IsEncrypted())
+	assert.Equal(t, record.GetCid(), sealed.GetCid())
+	assert.Equal(t, record.DiscoveryTags(), sealed.DiscoveryTags())
+	assert.Equal(t, "0.7.0", sealed.GetSchemaVersion())
+
+	metadata := sealed.GetEnvelope().GetMetadata()
+	assert.Equal(t, "My Agent", metadata.GetName())
+	assert.Equal(t, "1.0.0", metadata.GetVersion())
+	assert.Equal(t, "2025-01-01T00:00:00Z", metadata.GetCreatedAt())
+	assert.Equal(t, map[string]string{"team": "search"}, metadata.GetAnnotations())
+
+	valid, errs, err := sealed.Validate()
+	require.NoError(t, err)
+	assert.True(t, valid, errs)
+
+	// The envelope round-trips through its stored form
+	data, err := sealed.Marshal()
+	require.NoError(t, err)
+
+	unmarshaled, err := corev1.UnmarshalEnvelope(data)
+	require.NoError(t, err)
+	assert.True(t, unmarshaled.GetEncrypted())
+	assert.Equal(t, record.GetCid(), unmarshaled.GetCid())
+	assert.Equal(t, []byte("ciphertext"), unmarshaled.GetEnvelope().GetCiphertext())
+}
+
+func TestRecordEnvelope_Validate(t *testing.T) {
+	record := corev1.New(&oasfv1alpha1.Record{Name: "agent", Version: "1.0.0", SchemaVersion: "0.7.0"})
+
+	sealed, err := corev1.SealRecord(record, []byte("ciphertext"), newEnvelopeTestHeader())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(*corev1.RecordEnvelope)
+	}{
+		{name: "invalid CID", modify: func(e *corev1.RecordEnvelope) { e.Cid = "not-a-cid" }},
+		{name: "empty ciphertext", modify: func(e *corev1.RecordEnvelope) { e.Ciphertext = nil }},
+		{name: "missing wrapped key", modify: func(e *corev1.RecordEnvelope) { e.Header.WrappedKey = nil }},
+		{name: "missing name", modify: func(e *corev1.RecordEnvelope) { e.Metadata.Name = "" }},
+		{name: "tag shadowing a CID", modify: func(e *corev1.RecordEnvelope) { e.Metadata.Tags = []string{e.GetCid()} }},
+		{name: "unnormalized tag", modify: func(e *corev1.RecordEnvelope) { e.Metadata.Tags = []string{"My Agent:latest"} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, _ := proto.Clone(sealed.GetEnvelope()).(*corev1.RecordEnvelope)
+			tt.modify(envelope)
+
+			valid, errs, err := (&corev1.Record{Envelope: envelope}).Validate()
+			require.NoError(t, err)
+			assert.False(t, valid)
+			assert.Len(t, errs, 1)
+		})
+	}
+}
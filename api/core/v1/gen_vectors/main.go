@@ -0,0 +1,324 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Command gen_vectors generates the language-agnostic CID test vectors.
+//
+// Each vector holds a record JSON document, its canonical bytes and its CID,
+// as computed by this implementation. SDKs in other languages use the vectors
+// to verify that they canonicalize records and compute CIDs identically.
+//
+// Usage:
+//
+//	go generate ./core/v1
+//	go run ./core/v1/gen_vectors -out core/v1/testdata/cid_vectors.json
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+)
+
+// vectorFile is the format of the test vector file.
+type vectorFile struct {
+	Description string   `json:"description"`
+	Vectors     []vector `json:"vectors"`
+}
+
+// vector is a single test vector.
+// The record is kept as a JSON string, so that consumers see the exact input document,
+// e.g. numbers which are not representable as IEEE 754 doubles.
+type vector struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Record      string `json:"record"`
+	Canonical   string `json:"canonical"`
+	CID         string `json:"cid"`
+}
+
+// testCase is a record JSON document to generate a vector for.
+type testCase struct {
+	name        string
+	description string
+	record      string
+}
+
+var testCases = []testCase{
+	// OASF 0.3.1
+	{
+		name:        "v031-minimal",
+		description: "Record with only the required fields",
+		record:      `{"name":"directory.agntcy.org/example/minimal","version":"v1.0.0","schema_version":"0.3.1"}`,
+	},
+	{
+		name:        "v031-full",
+		description: "Record with skills, locators and extensions",
+		record: `{
+  "name": "directory.agntcy.org/cisco/marketing-strategy",
+  "version": "v1.0.0",
+  "schema_version": "0.3.1",
+  "description": "Research agent for Cisco's marketing strategy.",
+  "authors": ["Cisco Systems"],
+  "created_at": "2025-03-19T17:06:37Z",
+  "annotations": {"key": "value"},
+  "skills": [
+    {"category_name": "Natural Language Processing", "category_uid": 1, "class_name": "Text Completion", "class_uid": 10201},
+    {"category_name": "Natural Language Processing", "category_uid": 1, "class_name": "Problem Solving", "class_uid": 10702}
+  ],
+  "locators": [{"type": "docker-image", "url": "https://ghcr.io/agntcy/marketing-strategy"}],
+  "extensions": [
+    {"name": "schema.oasf.agntcy.org/features/runtime/framework", "version": "v0.0.0", "data": {"sbom": {"name": "marketing-strategy", "packages": [{"name": "crewai", "version": "0.83.0"}]}}}
+  ]
+}`,
+	},
+	{
+		name:        "v031-unicode-name",
+		description: "Non-ASCII name, description and authors",
+		record:      `{"name":"directory.agntcy.org/例え/агент-ünïcode","version":"v1.0.0","schema_version":"0.3.1","description":"日本語の説明 🤖","authors":["Zoë Çelik","José Müller"]}`,
+	},
+	{
+		name:        "v031-empty-arrays",
+		description: "Empty arrays are kept, not omitted",
+		record:      `{"name":"directory.agntcy.org/example/empty-arrays","version":"v1.0.0","schema_version":"0.3.1","authors":[],"skills":[],"locators":[],"extensions":[]}`,
+	},
+	{
+		name:        "v031-empty-objects",
+		description: "Empty objects and empty strings are kept, not omitted",
+		record:      `{"name":"directory.agntcy.org/example/empty-objects","version":"","schema_version":"0.3.1","annotations":{},"extensions":[{"name":"empty","version":"v0.0.0","data":{}}]}`,
+	},
+	{
+		name:        "v031-nested-extension-data",
+		description: "Deeply nested extension data with mixed value types",
+		record:      `{"name":"directory.agntcy.org/example/nested","version":"v1.0.0","schema_version":"0.3.1","extensions":[{"name":"schema.oasf.agntcy.org/features/runtime/config","version":"v1.0.0","data":{"level1":{"level2":{"level3":{"level4":{"values":[1,"two",true,null,{"five":[5]}]}}},"flag":false,"nothing":null}}}]}`,
+	},
+	{
+		name:        "v031-large-numbers",
+		description: "Skill UIDs at and beyond the range of exactly representable integers",
+		record:      `{"name":"directory.agntcy.org/example/large-numbers","version":"v1.0.0","schema_version":"0.3.1","skills":[{"category_uid":9007199254740991,"class_uid":9007199254740993},{"category_uid":123456789012345678,"class_uid":1e21}]}`,
+	},
+	{
+		name:        "v031-key-order",
+		description: "Keys in reverse order are sorted by their UTF-8 bytes",
+		record:      `{"version":"v1.0.0","schema_version":"0.3.1","name":"directory.agntcy.org/example/key-order","annotations":{"zeta":"z","Zeta":"Z","alpha":"a","Alpha":"A","ä":"umlaut","_":"underscore","1":"digit"}}`,
+	},
+	{
+		name:        "v031-html-characters",
+		description: "HTML characters are escaped like encoding/json",
+		record:      `{"name":"directory.agntcy.org/example/html","version":"v1.0.0","schema_version":"0.3.1","description":"<script>alert('x') && 1 > 0</script>","annotations":{"<key&>":"value"}}`,
+	},
+	{
+		name:        "v031-whitespace",
+		description: "Insignificant whitespace is removed",
+		record:      "{\n\t\"name\" :  \"directory.agntcy.org/example/whitespace\" ,\r\n  \"version\":\"v1.0.0\",\n\n  \"schema_version\"\t:\t\"0.3.1\"\n}\n",
+	},
+
+	// OASF 0.5.0
+	{
+		name:        "v050-minimal",
+		description: "Record with only the required fields",
+		record:      `{"name":"directory.agntcy.org/example/minimal","version":"v1.0.0","schema_version":"0.5.0"}`,
+	},
+	{
+		name:        "v050-full",
+		description: "Record with skills, domains, locators and modules",
+		record: `{
+  "name": "directory.agntcy.org/example/research-assistant",
+  "version": "v2.1.0",
+  "schema_version": "0.5.0",
+  "description": "Research assistant agent.",
+  "authors": ["AGNTCY Contributors"],
+  "created_at": "2025-06-01T12:00:00Z",
+  "skills": [{"name": "natural_language_processing/text_completion", "id": 10201}],
+  "domains": [{"name": "technology/software_engineering", "id": 102}],
+  "locators": [{"type": "source_code", "url": "https://github.com/agntcy/dir"}],
+  "modules": [{"name": "runtime/model", "data": {"models": [{"provider": "openai", "model": "gpt-4o"}]}}]
+}`,
+	},
+	{
+		name:        "v050-unicode-escapes",
+		description: "Escaped and literal non-ASCII characters canonicalize identically",
+		record:      `{"name":"directory.agntcy.org/example/\u00e9scaped","version":"v1.0.0","schema_version":"0.5.0","description":"\ud83e\udd16 robot, \u2603 snowman, é literal"}`,
+	},
+	{
+		name:        "v050-control-characters",
+		description: "Control characters are escaped",
+		record:      `{"name":"directory.agntcy.org/example/control","version":"v1.0.0","schema_version":"0.5.0","description":"tab\there\nnew line\r\u0000\u0001\u001f\u007f\b\f"}`,
+	},
+	{
+		name:        "v050-line-separators",
+		description: "U+2028 and U+2029 are escaped",
+		record:      `{"name":"directory.agntcy.org/example/separators","version":"v1.0.0","schema_version":"0.5.0","description":"line\u2028paragraph\u2029end"}`,
+	},
+	{
+		name:        "v050-quotes-backslashes",
+		description: "Quotes and backslashes are escaped, slashes are not",
+		record:      `{"name":"directory.agntcy.org/example/quotes","version":"v1.0.0","schema_version":"0.5.0","description":"\"quoted\" \\ back\\slash / slash \/ escaped slash"}`,
+	},
+	{
+		name:        "v050-fractions",
+		description: "Fractional and exponent numbers",
+		record:      `{"name":"directory.agntcy.org/example/fractions","version":"v1.0.0","schema_version":"0.5.0","modules":[{"name":"numbers","data":{"values":[0.1,-0.5,3.14159,1.5e-5,0.000001,0.0000001,2.5e-10,1E2,1.0,-0.0]}}]}`,
+	},
+	{
+		name:        "v050-extreme-numbers",
+		description: "Largest and smallest doubles",
+		record:      `{"name":"directory.agntcy.org/example/extreme-numbers","version":"v1.0.0","schema_version":"0.5.0","modules":[{"name":"numbers","data":{"max":1.7976931348623157e308,"min":5e-324,"big":1e20,"bigger":1e21,"negative":-123456789e15}}]}`,
+	},
+	{
+		name:        "v050-empty-modules",
+		description: "Modules without data and with empty data",
+		record:      `{"name":"directory.agntcy.org/example/empty-modules","version":"v1.0.0","schema_version":"0.5.0","skills":[],"domains":[],"modules":[{"name":"no-data"},{"name":"empty-data","data":{}},{"name":"empty-list","data":{"items":[]}}]}`,
+	},
+	{
+		name:        "v050-duplicate-array-items",
+		description: "Array order and duplicates are preserved",
+		record:      `{"name":"directory.agntcy.org/example/arrays","version":"v1.0.0","schema_version":"0.5.0","authors":["b","a","b","a"],"skills":[{"id":2},{"id":1},{"id":2}]}`,
+	},
+
+	// OASF 0.7.0
+	{
+		name:        "v070-minimal",
+		description: "Record with only the required fields",
+		record:      `{"name":"directory.agntcy.org/example/minimal","version":"v1.0.0","schema_version":"0.7.0"}`,
+	},
+	{
+		name:        "v070-full",
+		description: "Record with skills, domains, locators, modules and annotations",
+		record: `{
+  "name": "directory.agntcy.org/example/travel-planner",
+  "version": "v3.0.0",
+  "schema_version": "0.7.0",
+  "description": "Plans trips end to end.",
+  "authors": ["AGNTCY Contributors <dir@agntcy.org>"],
+  "created_at": "2025-09-17T09:09:56Z",
+  "annotations": {"team": "travel", "tier": "gold"},
+  "skills": [{"name": "natural_language_processing/natural_language_generation/text_completion", "id": 10201}],
+  "domains": [{"name": "hospitality_and_tourism/travel_services", "id": 1505}],
+  "locators": [{"type": "docker_image", "url": "ghcr.io/agntcy/travel-planner:v3.0.0"}],
+  "modules": [{"name": "integration/mcp", "id": 202, "data": {"servers": [{"name": "maps", "command": "npx", "args": ["-y", "@maps/server"], "env": {"API_KEY": "${API_KEY}"}}]}}]
+}`,
+	},
+	{
+		name:        "v070-previous-record",
+		description: "Record linked to a previous version",
+		record:      `{"name":"directory.agntcy.org/example/minimal","version":"v1.1.0","schema_version":"0.7.0","previous_record_cid":"bafkreigh2akiscaildcqabsyg3dfr6chu3fgpregiymsck7e7aqa4s52zy"}`,
+	},
+	{
+		name:        "v070-emoji-and-cjk",
+		description: "Characters outside the Basic Multilingual Plane",
+		record:      `{"name":"directory.agntcy.org/例/🤖-agent","version":"v1.0.0","schema_version":"0.7.0","description":"𝔘𝔫𝔦𝔠𝔬𝔡𝔢 🧑‍💻 family 👨‍👩‍👧","annotations":{"日本語":"キー","emoji🔑":"✓"}}`,
+	},
+	{
+		name:        "v070-integers",
+		description: "Integer edge cases",
+		record:      `{"name":"directory.agntcy.org/example/integers","version":"v1.0.0","schema_version":"0.7.0","modules":[{"name":"numbers","id":0,"data":{"values":[0,1,-1,42,10201,9007199254740991,-9007199254740991,9007199254740992,18446744073709551615,-0]}}]}`,
+	},
+	{
+		name:        "v070-exponent-boundaries",
+		description: "Numbers around the switch between decimal and exponent notation",
+		record:      `{"name":"directory.agntcy.org/example/exponents","version":"v1.0.0","schema_version":"0.7.0","modules":[{"name":"numbers","data":{"values":[999999999999999999999,1e21,1.5e21,0.000001,0.0000001,1e-7,-1e-7,123e-20]}}]}`,
+	},
+	{
+		name:        "v070-nested-arrays",
+		description: "Arrays of arrays and objects in arrays",
+		record:      `{"name":"directory.agntcy.org/example/nested-arrays","version":"v1.0.0","schema_version":"0.7.0","modules":[{"name":"matrix","data":{"matrix":[[1,2],[3,[4,[5,[]]]],[],[{}]],"objects":[{"b":1,"a":2},{"a":{"d":[],"c":{}}}]}}]}`,
+	},
+	{
+		name:        "v070-booleans-and-nulls",
+		description: "Booleans and nulls in objects and arrays",
+		record:      `{"name":"directory.agntcy.org/example/literals","version":"v1.0.0","schema_version":"0.7.0","annotations":{},"modules":[{"name":"literals","data":{"yes":true,"no":false,"none":null,"list":[true,false,null]}}]}`,
+	},
+	{
+		name:        "v070-long-strings",
+		description: "Long string values and keys",
+		record:      `{"name":"directory.agntcy.org/example/long-strings","version":"v1.0.0","schema_version":"0.7.0","description":"Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.","annotations":{"a-very-long-annotation-key-that-keeps-going-and-going-and-going-and-going-and-going":"value"}}`,
+	},
+	{
+		name:        "v070-unknown-fields",
+		description: "Fields unknown to the schema are part of the CID",
+		record:      `{"name":"directory.agntcy.org/example/unknown-fields","version":"v1.0.0","schema_version":"0.7.0","x-custom":{"nested":{"z":1,"a":2}},"X-Custom":"upper"}`,
+	},
+	{
+		name:        "v070-annotations",
+		description: "Annotations with values that look like other types stay strings",
+		record:      `{"name":"directory.agntcy.org/example/plain","version":"v1.0.0","schema_version":"0.7.0","created_at":"2025-01-01T00:00:00Z","annotations":{"protected":"true","count":"42","empty":"","null":"null"}}`,
+	},
+}
+
+func main() {
+	out := flag.String("out", "", "Path of the vector file to write. Writes to standard output if empty.")
+	flag.Parse()
+
+	data, err := generate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to generate CID vectors: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(*out, data, 0o644) //nolint:gosec,mnd
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write CID vectors: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generate returns the encoded vector file. The output only depends on the test cases,
+// so running the generator twice produces identical files.
+func generate() ([]byte, error) {
+	file := vectorFile{
+		Description: "CID test vectors for Directory records. Generated by api/core/v1/gen_vectors, do not edit. " +
+			"For each vector, the record JSON document canonicalizes to the base64 encoded canonical bytes, " +
+			"and the CIDv1 (codec 1, SHA2-256) of the canonical bytes is cid.",
+		Vectors: make([]vector, 0, len(testCases)),
+	}
+
+	names := make(map[string]bool, len(testCases))
+
+	for _, tc := range testCases {
+		if names[tc.name] {
+			return nil, fmt.Errorf("duplicate vector name %s", tc.name)
+		}
+
+		names[tc.name] = true
+
+		record, err := corev1.LoadOASFFromReader(bytes.NewReader([]byte(tc.record)), corev1.Lenient())
+		if err != nil {
+			return nil, fmt.Errorf("vector %s: %w", tc.name, err)
+		}
+
+		canonical, err := record.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("vector %s: %w", tc.name, err)
+		}
+
+		file.Vectors = append(file.Vectors, vector{
+			Name:        tc.name,
+			Description: tc.description,
+			Record:      tc.record,
+			Canonical:   base64.StdEncoding.EncodeToString(canonical),
+			CID:         record.GetCid(),
+		})
+	}
+
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(file); err != nil {
+		return nil, fmt.Errorf("failed to encode vectors: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,27 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerate ensures that the vector file is up to date and the generator is deterministic.
+func TestGenerate(t *testing.T) {
+	first, err := generate()
+	require.NoError(t, err)
+
+	second, err := generate()
+	require.NoError(t, err)
+	assert.Equal(t, string(first), string(second))
+
+	expected, err := os.ReadFile(filepath.Join("..", "testdata", "cid_vectors.json"))
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(first), "run go generate ./core/v1 to update the vector file")
+}
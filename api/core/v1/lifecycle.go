@@ -0,0 +1,81 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// lifecycleStatusNames are the names of the lifecycle statuses used by ParseLifecycleStatus and StatusName.
+var lifecycleStatusNames = map[LifecycleStatus]string{
+	LifecycleStatus_LIFECYCLE_STATUS_ACTIVE:     "active",
+	LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED: "deprecated",
+	LifecycleStatus_LIFECYCLE_STATUS_WITHDRAWN:  "withdrawn",
+}
+
+// ParseLifecycleStatus parses a lifecycle status name: "active", "deprecated" or "withdrawn".
+func ParseLifecycleStatus(name string) (LifecycleStatus, error) {
+	for status, statusName := range lifecycleStatusNames {
+		if strings.EqualFold(name, statusName) {
+			return status, nil
+		}
+	}
+
+	return LifecycleStatus_LIFECYCLE_STATUS_UNSPECIFIED, fmt.Errorf("unknown lifecycle status %q, expected active, deprecated or withdrawn", name)
+}
+
+// StatusName returns the name of the lifecycle status, e.g. "deprecated".
+// Records without a lifecycle status are active.
+func (l *Lifecycle) StatusName() string {
+	if name, ok := lifecycleStatusNames[l.GetStatus()]; ok {
+		return name
+	}
+
+	return lifecycleStatusNames[LifecycleStatus_LIFECYCLE_STATUS_ACTIVE]
+}
+
+// IsActive reports whether the record is active, which is the case for records without a lifecycle status.
+func (l *Lifecycle) IsActive() bool {
+	status := l.GetStatus()
+
+	return status == LifecycleStatus_LIFECYCLE_STATUS_UNSPECIFIED || status == LifecycleStatus_LIFECYCLE_STATUS_ACTIVE
+}
+
+// IsWithdrawn reports whether the record is withdrawn.
+func (l *Lifecycle) IsWithdrawn() bool {
+	return l.GetStatus() == LifecycleStatus_LIFECYCLE_STATUS_WITHDRAWN
+}
+
+// Validate checks that the lifecycle can be set on the record with the given CID.
+// The successor must be a valid CID other than the record itself, and active records have no successor.
+func (l *Lifecycle) Validate(cid string) error {
+	if l == nil {
+		return errors.New("lifecycle is required")
+	}
+
+	if _, ok := LifecycleStatus_name[int32(l.GetStatus())]; !ok {
+		return fmt.Errorf("unknown lifecycle status %d", l.GetStatus())
+	}
+
+	successor := l.GetSuccessorCid()
+	if successor == "" {
+		return nil
+	}
+
+	if l.IsActive() {
+		return errors.New("active records cannot have a successor")
+	}
+
+	if !IsValidCID(successor) {
+		return fmt.Errorf("invalid successor CID %q", successor)
+	}
+
+	if successor == cid {
+		return errors.New("a record cannot be its own successor")
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLifecycleStatus(t *testing.T) {
+	for name, expected := range map[string]corev1.LifecycleStatus{
+		"active":     corev1.LifecycleStatus_LIFECYCLE_STATUS_ACTIVE,
+		"Deprecated": corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED,
+		"withdrawn":  corev1.LifecycleStatus_LIFECYCLE_STATUS_WITHDRAWN,
+	} {
+		status, err := corev1.ParseLifecycleStatus(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, status)
+	}
+
+	_, err := corev1.ParseLifecycleStatus("retired")
+	assert.Error(t, err)
+}
+
+func TestLifecycleStatus(t *testing.T) {
+	var unset *corev1.Lifecycle
+
+	assert.True(t, unset.IsActive())
+	assert.Equal(t, "active", unset.StatusName())
+
+	deprecated := &corev1.Lifecycle{Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED}
+	assert.False(t, deprecated.IsActive())
+	assert.False(t, deprecated.IsWithdrawn())
+	assert.Equal(t, "deprecated", deprecated.StatusName())
+
+	withdrawn := &corev1.Lifecycle{Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_WITHDRAWN}
+	assert.True(t, withdrawn.IsWithdrawn())
+}
+
+func TestLifecycleValidate(t *testing.T) {
+	cid := corev1.New(&typesv1alpha1.Record{Name: "old-agent", SchemaVersion: "0.7.0"}).GetCid()
+	successor := corev1.New(&typesv1alpha1.Record{Name: "new-agent", SchemaVersion: "0.7.0"}).GetCid()
+
+	tests := []struct {
+		name      string
+		lifecycle *corev1.Lifecycle
+		valid     bool
+	}{
+		{name: "deprecated with successor", lifecycle: &corev1.Lifecycle{Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED, SuccessorCid: successor}, valid: true},
+		{name: "withdrawn without successor", lifecycle: &corev1.Lifecycle{Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_WITHDRAWN, Reason: "vulnerable"}, valid: true},
+		{name: "active", lifecycle: &corev1.Lifecycle{Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_ACTIVE}, valid: true},
+		{name: "missing", lifecycle: nil},
+		{name: "unknown status", lifecycle: &corev1.Lifecycle{Status: 42}},
+		{name: "active with successor", lifecycle: &corev1.Lifecycle{Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_ACTIVE, SuccessorCid: successor}},
+		{name: "invalid successor", lifecycle: &corev1.Lifecycle{Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED, SuccessorCid: "not-a-cid"}},
+		{name: "own successor", lifecycle: &corev1.Lifecycle{Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED, SuccessorCid: cid}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.lifecycle.Validate(cid)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
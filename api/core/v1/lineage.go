@@ -0,0 +1,81 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// AnnotationPreviousCid is the record annotation key linking a record
+	// to the CID of the version it supersedes.
+	AnnotationPreviousCid = "previous_cid"
+
+	// MetaAnnotationPreviousCid is the RecordMeta annotation key under which
+	// stores surface the predecessor CID.
+	MetaAnnotationPreviousCid = "previous-cid"
+)
+
+// SetPreviousCid stamps the CID of the predecessor record into the record annotations.
+// Note that this changes the record content and therefore its CID.
+func (r *Record) SetPreviousCid(cid string) error {
+	if r == nil || r.GetData() == nil {
+		return errors.New("record data is empty")
+	}
+
+	if cid == "" {
+		return errors.New("previous CID cannot be empty")
+	}
+
+	r.setAnnotation(AnnotationPreviousCid, cid)
+
+	return nil
+}
+
+// setAnnotation sets a record annotation, creating the annotations if needed,
+// and drops the cached canonical encoding of the record.
+func (r *Record) setAnnotation(key, value string) {
+	fields := r.GetData().GetFields()
+
+	annotations := fields["annotations"].GetStructValue()
+	if annotations == nil {
+		annotations = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		fields["annotations"] = structpb.NewStructValue(annotations)
+	}
+
+	if annotations.Fields == nil {
+		annotations.Fields = map[string]*structpb.Value{}
+	}
+
+	annotations.Fields[key] = structpb.NewStringValue(value)
+
+	r.resetCanonical()
+}
+
+// GetPreviousCid returns the CID of the predecessor record, if any.
+// The previous_cid annotation takes precedence over the OASF previous_record_cid field.
+func (r *Record) GetPreviousCid() string {
+	if r == nil || r.GetData() == nil {
+		return ""
+	}
+
+	fields := r.GetData().GetFields()
+
+	if cid := fields["annotations"].GetStructValue().GetFields()[AnnotationPreviousCid].GetStringValue(); cid != "" {
+		return cid
+	}
+
+	return fields["previous_record_cid"].GetStringValue()
+}
+
+// GetPreviousCid returns the CID of the predecessor record surfaced by the store, if any.
+func (m *RecordMeta) GetPreviousCid() string {
+	if cid := m.GetAnnotations()[MetaAnnotationPreviousCid]; cid != "" {
+		return cid
+	}
+
+	return m.GetAnnotations()[AnnotationPreviousCid]
+}
@@ -0,0 +1,80 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// LatestVersion is the version of a record locator that refers to the highest version of a name.
+const LatestVersion = "latest"
+
+// ErrInvalidRecordLocator is returned by ParseRecordLocator for strings that are neither a CID nor name@version.
+var ErrInvalidRecordLocator = errors.New("invalid record locator")
+
+// RecordLocator refers to a record either by CID or by name and version.
+type RecordLocator struct {
+	// CID of the record, empty if the record is referred to by name.
+	CID string
+	// Name of the record.
+	Name string
+	// Version of the record, or LatestVersion for the highest version of the name.
+	Version string
+}
+
+// ParseRecordLocator parses a reference to a record given by a human:
+// a bare CID, "name@version", "name:version" or a bare name.
+// The version "latest" and a bare name refer to the highest semantic version of the name.
+// Names must not contain search wildcards.
+func ParseRecordLocator(s string) (RecordLocator, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return RecordLocator{}, fmt.Errorf("%w: empty", ErrInvalidRecordLocator)
+	}
+
+	if IsValidCID(s) {
+		return RecordLocator{CID: s}, nil
+	}
+
+	name, version := s, LatestVersion
+
+	// Names may contain path separators, but neither "@" nor ":"
+	if i := strings.LastIndexAny(s, "@:"); i >= 0 {
+		name, version = s[:i], s[i+1:]
+	}
+
+	switch {
+	case name == "":
+		return RecordLocator{}, fmt.Errorf("%w: %q has no name", ErrInvalidRecordLocator, s)
+	case version == "":
+		return RecordLocator{}, fmt.Errorf("%w: %q has no version", ErrInvalidRecordLocator, s)
+	case strings.ContainsAny(name, "*?[]@:"):
+		return RecordLocator{}, fmt.Errorf("%w: name %q contains invalid characters", ErrInvalidRecordLocator, name)
+	case strings.ContainsAny(version, "*?[]"):
+		return RecordLocator{}, fmt.Errorf("%w: version %q contains invalid characters", ErrInvalidRecordLocator, version)
+	}
+
+	return RecordLocator{Name: name, Version: version}, nil
+}
+
+// IsCID reports whether the locator refers to a record by CID.
+func (l RecordLocator) IsCID() bool {
+	return l.CID != ""
+}
+
+// IsLatest reports whether the locator refers to the highest version of a name.
+func (l RecordLocator) IsLatest() bool {
+	return l.CID == "" && strings.EqualFold(l.Version, LatestVersion)
+}
+
+// String returns the CID or "name@version".
+func (l RecordLocator) String() string {
+	if l.IsCID() {
+		return l.CID
+	}
+
+	return l.Name + "@" + l.Version
+}
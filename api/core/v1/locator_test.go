@@ -0,0 +1,108 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const locatorTestCID = "baeareig77vqcdozl2wyk6z3cscaj5q5fggi53aoh64fewkdiri3cdauyn4"
+
+func TestParseRecordLocator(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected corev1.RecordLocator
+		latest   bool
+	}{
+		{name: "CID", input: locatorTestCID, expected: corev1.RecordLocator{CID: locatorTestCID}},
+		{name: "Name at version", input: "my-agent@1.0.0", expected: corev1.RecordLocator{Name: "my-agent", Version: "1.0.0"}},
+		{name: "Name with tag", input: "my-agent:v2.1.0-rc.1", expected: corev1.RecordLocator{Name: "my-agent", Version: "v2.1.0-rc.1"}},
+		{name: "Latest", input: "my-agent:latest", expected: corev1.RecordLocator{Name: "my-agent", Version: "latest"}, latest: true},
+		{name: "Bare name", input: "my-agent", expected: corev1.RecordLocator{Name: "my-agent", Version: "latest"}, latest: true},
+		{name: "Qualified name", input: "my-org/my-agent@1.0.0", expected: corev1.RecordLocator{Name: "my-org/my-agent", Version: "1.0.0"}},
+		{name: "Surrounding whitespace", input: " my-agent@1.0.0 ", expected: corev1.RecordLocator{Name: "my-agent", Version: "1.0.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locator, err := corev1.ParseRecordLocator(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, locator)
+			assert.Equal(t, tt.expected.CID != "", locator.IsCID())
+			assert.Equal(t, tt.latest, locator.IsLatest())
+		})
+	}
+}
+
+func TestParseRecordLocatorInvalid(t *testing.T) {
+	for _, input := range []string{"", "   ", "@1.0.0", "my-agent@", "my-agent:", "my-*@1.0.0", "my-agent@1.*", "a@b@1.0.0"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := corev1.ParseRecordLocator(input)
+			require.ErrorIs(t, err, corev1.ErrInvalidRecordLocator)
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	// Ordered by increasing precedence, see https://semver.org/#spec-item-11
+	ordered := []string{
+		"dev",
+		"snapshot",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"v1.2.0",
+		"1.10.0",
+		"2.0.0",
+	}
+
+	for i := range ordered {
+		for j := range ordered {
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+
+			assert.Equal(t, expected, corev1.CompareVersions(ordered[i], ordered[j]), "%s <=> %s", ordered[i], ordered[j])
+		}
+	}
+
+	shuffled := []string{"2.0.0", "1.0.0-rc.1", "dev", "1.0.0", "1.0.0-beta.11", "1.0.0-beta.2", "v1.2.0", "1.0.0-alpha"}
+	slices.SortFunc(shuffled, corev1.CompareVersions)
+	assert.Equal(t, []string{"dev", "1.0.0-alpha", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "v1.2.0", "2.0.0"}, shuffled)
+
+	// Equivalent spellings of the same version
+	assert.Equal(t, 0, corev1.CompareVersions("v2", "2.0.0+build.5"))
+	assert.True(t, corev1.IsSemver("1.0"))
+	assert.False(t, corev1.IsSemver("1.0.0.0"))
+	assert.False(t, corev1.IsSemver("1.0.0-"))
+}
+
+func TestMajorMinor(t *testing.T) {
+	major, minor, ok := corev1.MajorMinor("v2.1.3-rc.1")
+	assert.True(t, ok)
+	assert.Equal(t, "2", major)
+	assert.Equal(t, "2.1", minor)
+
+	_, _, ok = corev1.MajorMinor("nightly")
+	assert.False(t, ok)
+
+	assert.True(t, corev1.IsPrerelease("2.1.3-rc.1"))
+	assert.False(t, corev1.IsPrerelease("2.1.3+build.1"))
+	assert.False(t, corev1.IsPrerelease("nightly"))
+}
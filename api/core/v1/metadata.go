@@ -0,0 +1,107 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+
+	"github.com/agntcy/dir/api/names"
+)
+
+// Limits of the operational metadata of a record.
+const (
+	MaxMetadataEntries     = 64
+	MaxMetadataKeyLength   = 128
+	MaxMetadataValueLength = 1024
+)
+
+// metadataKeyPattern matches valid operational metadata keys, e.g. "team" or "cost-center".
+var metadataKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._/-]*$`)
+
+// ValidateMetadataUpdate checks an update of the operational metadata of a record.
+// Keys are lowercase and start with a letter or digit, followed by letters, digits, '.', '_', '/' or '-'.
+// Empty values remove the entry.
+func ValidateMetadataUpdate(update map[string]string) error {
+	if len(update) == 0 {
+		return errors.New("metadata is required")
+	}
+
+	for key, value := range update {
+		if len(key) > MaxMetadataKeyLength || !metadataKeyPattern.MatchString(key) {
+			return fmt.Errorf("invalid metadata key %q", key)
+		}
+
+		if len(value) > MaxMetadataValueLength {
+			return fmt.Errorf("value of metadata key %q exceeds %d bytes", key, MaxMetadataValueLength)
+		}
+	}
+
+	return nil
+}
+
+// MergeMetadata applies an update to the operational metadata of a record and returns the result.
+// Entries with empty values are removed, entries not in the update are kept. The current metadata is not modified.
+// It fails if the result has more than MaxMetadataEntries entries.
+func MergeMetadata(current, update map[string]string) (map[string]string, error) {
+	merged := maps.Clone(current)
+	if merged == nil {
+		merged = make(map[string]string, len(update))
+	}
+
+	for key, value := range update {
+		if value == "" {
+			delete(merged, key)
+		} else {
+			merged[key] = value
+		}
+	}
+
+	if len(merged) > MaxMetadataEntries {
+		return nil, fmt.Errorf("metadata exceeds %d entries", MaxMetadataEntries)
+	}
+
+	return merged, nil
+}
+
+// MetadataTags returns the tags derived from the operational metadata of the record for the given keys,
+// "<name>:<key>-<value>" with the canonical name normalized with NormalizeTag, e.g. "my-agent_team-platform"
+// for the "team" key.
+// Unlike DiscoveryTags they change with the metadata, so that records can be found by their owner team or project.
+func (r *Record) MetadataTags(metadata map[string]string, keys []string) []string {
+	return CreatedTags(r.ExplainMetadataTags(metadata, keys))
+}
+
+// ExplainMetadataTags returns the decisions for each candidate metadata tag of the record, see MetadataTags.
+func (r *Record) ExplainMetadataTags(metadata map[string]string, keys []string) []TagDecision {
+	name := r.GetData().GetFields()["name"].GetStringValue()
+	if name == "" || r.GetCid() == "" {
+		return nil
+	}
+
+	explainer := &tagExplainer{}
+
+	for _, key := range keys {
+		value := metadata[key]
+		if value == "" {
+			continue
+		}
+
+		original := name + ":" + key + "-" + value
+		tag := NormalizeTag(names.CanonicalName(name) + ":" + key + "-" + value)
+
+		// A metadata tag must never shadow the CID tag of another record
+		if tag == "" || IsValidCID(tag) {
+			explainer.skip(original, TagSourceMetadata)
+
+			continue
+		}
+
+		explainer.add(tag, TagSourceMetadata, original)
+	}
+
+	return explainer.decisions
+}
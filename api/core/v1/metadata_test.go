@@ -0,0 +1,82 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"strings"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMetadataUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  map[string]string
+		wantErr bool
+	}{
+		{name: "Valid", update: map[string]string{"team": "platform", "cost-center": "cc-42", "org.example/contact": "oncall@example.com"}},
+		{name: "Removal", update: map[string]string{"team": ""}},
+		{name: "Empty", update: map[string]string{}, wantErr: true},
+		{name: "Uppercase key", update: map[string]string{"Team": "platform"}, wantErr: true},
+		{name: "Leading separator", update: map[string]string{"-team": "platform"}, wantErr: true},
+		{name: "Key too long", update: map[string]string{strings.Repeat("k", corev1.MaxMetadataKeyLength+1): "v"}, wantErr: true},
+		{name: "Value too long", update: map[string]string{"team": strings.Repeat("v", corev1.MaxMetadataValueLength+1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := corev1.ValidateMetadataUpdate(tt.update)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMergeMetadata(t *testing.T) {
+	current := map[string]string{"team": "platform", "owner": "jane"}
+
+	merged, err := corev1.MergeMetadata(current, map[string]string{"team": "search", "owner": "", "project": "dir"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"team": "search", "project": "dir"}, merged)
+	assert.Equal(t, map[string]string{"team": "platform", "owner": "jane"}, current, "current metadata must not be modified")
+
+	merged, err = corev1.MergeMetadata(nil, map[string]string{"team": "platform"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "platform"}, merged)
+
+	tooMany := make(map[string]string)
+	for i := range corev1.MaxMetadataEntries + 1 {
+		tooMany[strings.Repeat("k", i+1)] = "v"
+	}
+
+	_, err = corev1.MergeMetadata(nil, tooMany)
+	assert.Error(t, err)
+}
+
+func TestRecordMetadataTags(t *testing.T) {
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "My Agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+
+	metadata := map[string]string{"team": "Platform", "org": "agntcy", "contact": "oncall@example.com"}
+
+	assert.Equal(t, []string{"my-agent_team-platform", "my-agent_project-dir"},
+		record.MetadataTags(map[string]string{"team": "Platform", "project": "dir"}, []string{"team", "project"}))
+
+	// Only the configured keys are tagged, in their order
+	assert.Equal(t, []string{"my-agent_org-agntcy"}, record.MetadataTags(metadata, []string{"org", "project"}))
+
+	assert.Empty(t, record.MetadataTags(metadata, nil))
+	assert.Empty(t, (&corev1.Record{}).MetadataTags(metadata, []string{"team"}))
+}
@@ -0,0 +1,35 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import "errors"
+
+// AnnotationMigratedFrom is the record annotation key linking a record converted
+// to another schema version to the CID of the record it was converted from.
+const AnnotationMigratedFrom = "migrated_from"
+
+// SetMigratedFrom stamps the CID of the record this record was converted from into the record annotations.
+// Note that this changes the record content and therefore its CID.
+func (r *Record) SetMigratedFrom(cid string) error {
+	if r == nil || r.GetData() == nil {
+		return errors.New("record data is empty")
+	}
+
+	if cid == "" {
+		return errors.New("source CID cannot be empty")
+	}
+
+	r.setAnnotation(AnnotationMigratedFrom, cid)
+
+	return nil
+}
+
+// GetMigratedFrom returns the CID of the record this record was converted from, if any.
+func (r *Record) GetMigratedFrom() string {
+	if r == nil || r.GetData() == nil {
+		return ""
+	}
+
+	return r.GetData().GetFields()["annotations"].GetStructValue().GetFields()[AnnotationMigratedFrom].GetStringValue()
+}
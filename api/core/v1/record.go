@@ -4,9 +4,10 @@
 package v1
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
 
 	"github.com/agntcy/oasf-sdk/pkg/decoder"
 	"github.com/agntcy/oasf-sdk/pkg/validator"
@@ -29,28 +30,27 @@ func init() {
 }
 
 // GetCid calculates and returns the CID for this record.
-// The CID is calculated from the record's content using CIDv1, codec 1, SHA2-256.
+// The CID is calculated from the record's content using CIDv1, codec 1, and SHA2-256
+// or the hash function set with SetCIDOptions.
 // Uses canonical JSON marshaling to ensure consistent, cross-language compatible results.
+// The CID is cached together with the canonical bytes, see Marshal.
+// The CID of an encrypted record is the CID of its plaintext, see RecordEnvelope.
 // Returns empty string if calculation fails.
 func (r *Record) GetCid() string {
-	if r == nil || r.GetData() == nil {
-		return ""
+	if envelope := r.GetEnvelope(); envelope != nil {
+		return envelope.GetCid()
 	}
 
-	// Use canonical marshaling for CID calculation
-	canonicalBytes, err := r.Marshal()
-	if err != nil {
+	if r == nil || r.GetData() == nil {
 		return ""
 	}
 
-	// Calculate digest using local utilities
-	digest, err := CalculateDigest(canonicalBytes)
+	entry, err := r.canonical()
 	if err != nil {
 		return ""
 	}
 
-	// Convert digest to CID using local utilities
-	cid, err := ConvertDigestToCID(digest)
+	cid, err := entry.cid(r.CIDOptions())
 	if err != nil {
 		return ""
 	}
@@ -58,40 +58,79 @@ func (r *Record) GetCid() string {
 	return cid
 }
 
+// SetCIDOptions sets the options the CID of the record is calculated with, see GetCid.
+// Records use DefaultCIDOptions unless set. The CID of an encrypted record is the CID of its envelope.
+// The options are held in the cid_hash field, so that copies of the record keep them.
+func (r *Record) SetCIDOptions(opts CIDOptions) {
+	if r == nil {
+		return
+	}
+
+	r.CidHash = opts.hash()
+
+	r.resetCanonical()
+}
+
+// CIDOptions returns the options the CID of the record is calculated with, see SetCIDOptions.
+func (r *Record) CIDOptions() CIDOptions {
+	if hash := r.GetCidHash(); hash != 0 {
+		return CIDOptions{MulticodecHash: hash}
+	}
+
+	return DefaultCIDOptions
+}
+
+// MatchesCid reports whether the content of the record matches a CID. As CIDs are self-describing,
+// the CID of the record is calculated with the hash function of the given CID, which the record keeps,
+// so that records pulled from stores mixing hash functions can be verified.
+func (r *Record) MatchesCid(cid string) bool {
+	if r.GetEnvelope() == nil {
+		opts, err := CIDOptionsOf(cid)
+		if err != nil {
+			return false
+		}
+
+		if opts.hash() != r.CIDOptions().hash() {
+			r.SetCIDOptions(opts)
+		}
+	}
+
+	return r.GetCid() == cid
+}
+
 // Marshal marshals the Record using canonical JSON serialization.
 // This ensures deterministic, cross-language compatible byte representation.
 // The output represents the pure Record data and is used for both CID calculation and storage.
+//
+// Object keys are sorted and the data is written in a single pass.
+// The result is cached on the record, so a record must not be modified in place
+// once it was marshaled or its CID was calculated; replacing Data or using the record
+// methods that modify it, such as SetPreviousCid, is safe.
+// The returned bytes are a copy owned by the caller.
+//
+// Encrypted records are marshaled to their binary envelope instead, which is what stores persist.
 func (r *Record) Marshal() ([]byte, error) {
-	if r == nil || r.GetData() == nil {
-		return nil, nil
+	if envelope := r.GetEnvelope(); envelope != nil {
+		return marshalEnvelope(envelope)
 	}
 
-	// Extract the data marshal it canonically
-	// Use regular JSON marshaling to match the format users work with
-	// Step 1: Convert to JSON using regular json.Marshal (consistent with cli/cmd/pull)
-	jsonBytes, err := json.Marshal(r.GetData())
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal Record: %w", err)
-	}
-
-	// Step 2: Parse and re-marshal to ensure deterministic map key ordering.
-	// This is critical - maps must have consistent key order for deterministic results.
-	var normalized interface{}
-	if err := json.Unmarshal(jsonBytes, &normalized); err != nil {
-		return nil, fmt.Errorf("failed to normalize JSON for canonical ordering: %w", err)
+	if r == nil || r.GetData() == nil {
+		return nil, nil
 	}
 
-	// Step 3: Marshal with sorted keys for deterministic output.
-	// encoding/json.Marshal sorts map keys alphabetically.
-	canonicalBytes, err := json.Marshal(normalized)
+	entry, err := r.canonical()
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal normalized JSON with sorted keys: %w", err)
+		return nil, err
 	}
 
-	return canonicalBytes, nil
+	return slices.Clone(entry.bytes), nil
 }
 
 func (r *Record) GetSchemaVersion() string {
+	if envelope := r.GetEnvelope(); envelope != nil {
+		return envelope.GetMetadata().GetSchemaVersion()
+	}
+
 	if r == nil || r.GetData() == nil {
 		return ""
 	}
@@ -121,8 +160,9 @@ func (r *Record) Decode() (DecodedRecord, error) {
 }
 
 // Validate validates the Record's data against its embedded schema using the OASF SDK.
+// Encrypted records cannot be validated against the schema, only the structure of their envelope is validated.
 func (r *Record) Validate() (bool, []string, error) {
-	if r == nil || r.GetData() == nil {
+	if r == nil || (r.GetData() == nil && r.GetEnvelope() == nil) {
 		return false, []string{"record is nil"}, nil
 	}
 
@@ -131,6 +171,18 @@ func (r *Record) Validate() (bool, []string, error) {
 		return false, []string{fmt.Sprintf("record size %d bytes exceeds maximum allowed size of %d bytes (4MB)", recordSize, maxRecordSize)}, nil
 	}
 
+	if envelope := r.GetEnvelope(); envelope != nil {
+		if r.GetData() != nil {
+			return false, []string{"encrypted record must not have data"}, nil
+		}
+
+		if err := envelope.Validate(); err != nil {
+			return false, []string{err.Error()}, nil
+		}
+
+		return true, nil, nil
+	}
+
 	// Validate the record using OASF SDK
 	//nolint:wrapcheck
 	return defaultValidator.ValidateRecord(r.GetData())
@@ -159,3 +211,55 @@ func UnmarshalRecord(data []byte) (*Record, error) {
 
 	return record, nil
 }
+
+// LoadOption configures loading OASF documents.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	lenient bool
+}
+
+// Lenient accepts OASF documents with fields unknown to their schema version.
+func Lenient() LoadOption {
+	return func(o *loadOptions) {
+		o.lenient = true
+	}
+}
+
+// LoadOASFFromReader reads an OASF document from the reader into a Record.
+// The document is loaded as is, no fields are defaulted or normalized, so the
+// resulting CID matches the CID computed by the server for the same document.
+// Unlike UnmarshalRecord, the record is not decoded, callers that need a
+// structurally valid record should call Decode or Validate.
+//
+// Unless Lenient is passed, documents of supported schema versions are checked like protojson
+// unmarshals them into their typed record: unknown fields and values of the wrong type fail
+// with an *UnmarshalError listing each of them, see ExplainUnmarshalError.
+func LoadOASFFromReader(r io.Reader, opts ...LoadOption) (*Record, error) {
+	options := &loadOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, maxRecordSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OASF document: %w", err)
+	}
+
+	if len(data) > maxRecordSize {
+		return nil, fmt.Errorf("OASF document exceeds maximum allowed size of %d bytes", maxRecordSize)
+	}
+
+	dataStruct, err := decoder.JsonToProto(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal OASF document: %w", err)
+	}
+
+	if !options.lenient {
+		if issues := checkFields(data); len(issues) > 0 {
+			return nil, &UnmarshalError{Issues: issues}
+		}
+	}
+
+	return &Record{Data: dataStruct}, nil
+}
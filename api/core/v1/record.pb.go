@@ -12,6 +12,7 @@ package v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
 	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
@@ -25,31 +26,826 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// LifecycleStatus is the lifecycle status of a record.
+type LifecycleStatus int32
+
+const (
+	// Unknown status, treated as active.
+	LifecycleStatus_LIFECYCLE_STATUS_UNSPECIFIED LifecycleStatus = 0
+	// The record is in use.
+	LifecycleStatus_LIFECYCLE_STATUS_ACTIVE LifecycleStatus = 1
+	// The record can still be used, but consumers should move to its successor.
+	LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED LifecycleStatus = 2
+	// The record must no longer be used.
+	// It can still be pulled, but is excluded from routing lists by default.
+	LifecycleStatus_LIFECYCLE_STATUS_WITHDRAWN LifecycleStatus = 3
+)
+
+// Enum value maps for LifecycleStatus.
+var (
+	LifecycleStatus_name = map[int32]string{
+		0: "LIFECYCLE_STATUS_UNSPECIFIED",
+		1: "LIFECYCLE_STATUS_ACTIVE",
+		2: "LIFECYCLE_STATUS_DEPRECATED",
+		3: "LIFECYCLE_STATUS_WITHDRAWN",
+	}
+	LifecycleStatus_value = map[string]int32{
+		"LIFECYCLE_STATUS_UNSPECIFIED": 0,
+		"LIFECYCLE_STATUS_ACTIVE":      1,
+		"LIFECYCLE_STATUS_DEPRECATED":  2,
+		"LIFECYCLE_STATUS_WITHDRAWN":   3,
+	}
+)
+
+func (x LifecycleStatus) Enum() *LifecycleStatus {
+	p := new(LifecycleStatus)
+	*p = x
+	return p
+}
+
+func (x LifecycleStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LifecycleStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_core_v1_record_proto_enumTypes[0].Descriptor()
+}
+
+func (LifecycleStatus) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_core_v1_record_proto_enumTypes[0]
+}
+
+func (x LifecycleStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LifecycleStatus.Descriptor instead.
+func (LifecycleStatus) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{0}
+}
+
 // Defines a reference or a globally unique content identifier of a record.
 type RecordRef struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Globally-unique content identifier (CID) of the record.
-	// Specs: https://github.com/multiformats/cid
-	Cid           string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Globally-unique content identifier (CID) of the record.
+	// Specs: https://github.com/multiformats/cid
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Set in Push responses if the record was already stored,
+	// in which case its content was not uploaded again.
+	// It is not part of the record identity and is ignored in requests.
+	AlreadyExisted bool `protobuf:"varint,2,opt,name=already_existed,json=alreadyExisted,proto3" json:"already_existed,omitempty"`
+	// Set in Push responses if the record was rejected, in which case it was not stored.
+	// It is ignored in requests.
+	Error *RecordError `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// Set in Push responses if the record was rejected because a record with the
+	// same name and version but different content is already stored.
+	// It is ignored in requests.
+	Conflict *RecordConflict `protobuf:"bytes,4,opt,name=conflict,proto3" json:"conflict,omitempty"`
+	// Set in Push responses to the findings of the advisory lint rules run by the server,
+	// which did not reject the record. It is ignored in requests.
+	LintFindings  []*LintFinding `protobuf:"bytes,5,rep,name=lint_findings,json=lintFindings,proto3" json:"lint_findings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordRef) Reset() {
+	*x = RecordRef{}
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordRef) ProtoMessage() {}
+
+func (x *RecordRef) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordRef.ProtoReflect.Descriptor instead.
+func (*RecordRef) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RecordRef) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *RecordRef) GetAlreadyExisted() bool {
+	if x != nil {
+		return x.AlreadyExisted
+	}
+	return false
+}
+
+func (x *RecordRef) GetError() *RecordError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *RecordRef) GetConflict() *RecordConflict {
+	if x != nil {
+		return x.Conflict
+	}
+	return nil
+}
+
+func (x *RecordRef) GetLintFindings() []*LintFinding {
+	if x != nil {
+		return x.LintFindings
+	}
+	return nil
+}
+
+// LintFinding is a finding of an advisory lint rule, e.g. a record without locators.
+type LintFinding struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Severity of the finding: "info", "warning" or "error".
+	Severity string `protobuf:"bytes,1,opt,name=severity,proto3" json:"severity,omitempty"`
+	// Rule that reported the finding, e.g. "no-locators".
+	RuleId string `protobuf:"bytes,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// Path of the field the finding is about, e.g. "modules[0]", or empty for the whole record.
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// Human-readable description of the finding.
+	Message       string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LintFinding) Reset() {
+	*x = LintFinding{}
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LintFinding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LintFinding) ProtoMessage() {}
+
+func (x *LintFinding) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LintFinding.ProtoReflect.Descriptor instead.
+func (*LintFinding) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LintFinding) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *LintFinding) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *LintFinding) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *LintFinding) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// RecordConflict describes a stored record that has the same name and version
+// as a pushed record, but different content.
+type RecordConflict struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the records.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Version of the records.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// CID of the stored record.
+	Cid           string `protobuf:"bytes,3,opt,name=cid,proto3" json:"cid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordConflict) Reset() {
+	*x = RecordConflict{}
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordConflict) ProtoMessage() {}
+
+func (x *RecordConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordConflict.ProtoReflect.Descriptor instead.
+func (*RecordConflict) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RecordConflict) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RecordConflict) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RecordConflict) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+// ScanFinding describes content of a pushed record flagged by a content scanner.
+// Pushes rejected by a scan carry the findings as error details.
+type ScanFinding struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the scanner, e.g. "secrets" or "webhook".
+	Scanner string `protobuf:"bytes,1,opt,name=scanner,proto3" json:"scanner,omitempty"`
+	// Rule of the scanner that matched, e.g. "aws-access-key-id".
+	Rule string `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+	// Human-readable description of the finding.
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Location of the flagged content in the record, e.g. "extensions[0].data.env.AWS_KEY".
+	Location      string `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanFinding) Reset() {
+	*x = ScanFinding{}
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanFinding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanFinding) ProtoMessage() {}
+
+func (x *ScanFinding) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanFinding.ProtoReflect.Descriptor instead.
+func (*ScanFinding) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ScanFinding) GetScanner() string {
+	if x != nil {
+		return x.Scanner
+	}
+	return ""
+}
+
+func (x *ScanFinding) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *ScanFinding) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ScanFinding) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+// Defines metadata about a record.
+type RecordMeta struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the record.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Annotations attached to the record.
+	Annotations map[string]string `protobuf:"bytes,2,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Schema version of the record.
+	SchemaVersion string `protobuf:"bytes,3,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// Creation timestamp of the record in the RFC3339 format.
+	// Specs: https://www.rfc-editor.org/rfc/rfc3339.html
+	CreatedAt string `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Error describing why the record could not be resolved.
+	// Only set in streaming lookup responses for references that failed,
+	// in which case the remaining fields other than the CID are empty.
+	Error *RecordError `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// Lifecycle status of the record.
+	// Set in lookup responses, records without a lifecycle status are active.
+	Lifecycle *Lifecycle `protobuf:"bytes,6,opt,name=lifecycle,proto3" json:"lifecycle,omitempty"`
+	// Whether the record is pinned because it is published.
+	// Pinned records can only be deleted with force, which also unpublishes them.
+	// Set in lookup responses.
+	Pinned bool `protobuf:"varint,7,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	// Routing labels the record is published with, if it is pinned.
+	PublicationLabels []string `protobuf:"bytes,8,rep,name=publication_labels,json=publicationLabels,proto3" json:"publication_labels,omitempty"`
+	// Mutable operational metadata of the record, e.g. its owner team or cost center.
+	// It is not part of the record content, so changing it does not change the CID.
+	// Set in lookup responses.
+	OperationalMetadata map[string]string `protobuf:"bytes,9,rep,name=operational_metadata,json=operationalMetadata,proto3" json:"operational_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Findings of the content scanners that did not reject the push of the record,
+	// e.g. suspected secrets in extension data. Set in lookup responses.
+	ScanWarnings []*ScanFinding `protobuf:"bytes,10,rep,name=scan_warnings,json=scanWarnings,proto3" json:"scan_warnings,omitempty"`
+	// SPIFFE ID of the caller that first pushed the record, empty if unknown.
+	// Set in lookup responses.
+	Owner string `protobuf:"bytes,11,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Access control list of the record, unset if the record has none.
+	// Set in lookup responses.
+	Acl *RecordACL `protobuf:"bytes,12,opt,name=acl,proto3" json:"acl,omitempty"`
+	// CID of the newest record equivalent to this record, empty if there is none.
+	// Records are equivalent if one was migrated from the other, directly or through a chain of migrations.
+	// Set in lookup responses.
+	SupersededBy  string `protobuf:"bytes,13,opt,name=superseded_by,json=supersededBy,proto3" json:"superseded_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordMeta) Reset() {
+	*x = RecordMeta{}
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordMeta) ProtoMessage() {}
+
+func (x *RecordMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordMeta.ProtoReflect.Descriptor instead.
+func (*RecordMeta) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RecordMeta) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *RecordMeta) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *RecordMeta) GetSchemaVersion() string {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return ""
+}
+
+func (x *RecordMeta) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *RecordMeta) GetError() *RecordError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *RecordMeta) GetLifecycle() *Lifecycle {
+	if x != nil {
+		return x.Lifecycle
+	}
+	return nil
+}
+
+func (x *RecordMeta) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *RecordMeta) GetPublicationLabels() []string {
+	if x != nil {
+		return x.PublicationLabels
+	}
+	return nil
+}
+
+func (x *RecordMeta) GetOperationalMetadata() map[string]string {
+	if x != nil {
+		return x.OperationalMetadata
+	}
+	return nil
+}
+
+func (x *RecordMeta) GetScanWarnings() []*ScanFinding {
+	if x != nil {
+		return x.ScanWarnings
+	}
+	return nil
+}
+
+func (x *RecordMeta) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *RecordMeta) GetAcl() *RecordACL {
+	if x != nil {
+		return x.Acl
+	}
+	return nil
+}
+
+func (x *RecordMeta) GetSupersededBy() string {
+	if x != nil {
+		return x.SupersededBy
+	}
+	return ""
+}
+
+// RecordACL restricts the callers that can access a record beyond the trust domain authorization.
+// Patterns are SPIFFE IDs that may contain globs: "*" matches any characters within a path segment
+// and "**" matches any characters across segments, e.g. "spiffe://example.org/ns/team-a/**".
+// An empty list of patterns does not restrict the operation.
+type RecordACL struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Patterns of the callers allowed to pull the record and its referrers.
+	AllowPull []string `protobuf:"bytes,1,rep,name=allow_pull,json=allowPull,proto3" json:"allow_pull,omitempty"`
+	// Patterns of the callers allowed to delete the record and update its metadata.
+	AllowDelete   []string `protobuf:"bytes,2,rep,name=allow_delete,json=allowDelete,proto3" json:"allow_delete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordACL) Reset() {
+	*x = RecordACL{}
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordACL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordACL) ProtoMessage() {}
+
+func (x *RecordACL) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordACL.ProtoReflect.Descriptor instead.
+func (*RecordACL) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RecordACL) GetAllowPull() []string {
+	if x != nil {
+		return x.AllowPull
+	}
+	return nil
+}
+
+func (x *RecordACL) GetAllowDelete() []string {
+	if x != nil {
+		return x.AllowDelete
+	}
+	return nil
+}
+
+// Lifecycle describes the lifecycle status of a record.
+// It is not part of the record content and can change after the record was pushed.
+type Lifecycle struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Status of the record.
+	Status LifecycleStatus `protobuf:"varint,1,opt,name=status,proto3,enum=agntcy.dir.core.v1.LifecycleStatus" json:"status,omitempty"`
+	// CID of the record replacing this record, if any.
+	SuccessorCid string `protobuf:"bytes,2,opt,name=successor_cid,json=successorCid,proto3" json:"successor_cid,omitempty"`
+	// Human-readable reason for the status.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Time the status was set in the RFC3339 format.
+	// Specs: https://www.rfc-editor.org/rfc/rfc3339.html
+	UpdatedAt     string `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Lifecycle) Reset() {
+	*x = Lifecycle{}
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Lifecycle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lifecycle) ProtoMessage() {}
+
+func (x *Lifecycle) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lifecycle.ProtoReflect.Descriptor instead.
+func (*Lifecycle) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Lifecycle) GetStatus() LifecycleStatus {
+	if x != nil {
+		return x.Status
+	}
+	return LifecycleStatus_LIFECYCLE_STATUS_UNSPECIFIED
+}
+
+func (x *Lifecycle) GetSuccessorCid() string {
+	if x != nil {
+		return x.SuccessorCid
+	}
+	return ""
+}
+
+func (x *Lifecycle) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Lifecycle) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// Record is a generic object that encapsulates data of different Record types.
+//
+// Supported schemas:
+//
+// v0.3.1: https://schema.oasf.outshift.com/0.3.1/objects/agent
+// v0.7.0: https://schema.oasf.outshift.com/0.7.0/objects/record
+type Record struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Data  *structpb.Struct       `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Error describing why the record could not be pulled.
+	// Only set in streaming pull responses for references that failed,
+	// in which case data is empty. It is never part of the record CID.
+	Error *RecordError `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Lifecycle status of the record.
+	// Only set in pull responses for records that are deprecated or withdrawn.
+	// It is never part of the record CID.
+	Lifecycle *Lifecycle `protobuf:"bytes,3,opt,name=lifecycle,proto3" json:"lifecycle,omitempty"`
+	// Whether values of the record were replaced with a redaction marker because
+	// the caller is not allowed to see them, see the server redaction rules.
+	// Redacted records do not match the CID they were pulled by, see original_cid.
+	// Only set in pull responses. It is never part of the record CID.
+	Redacted bool `protobuf:"varint,4,opt,name=redacted,proto3" json:"redacted,omitempty"`
+	// CID of the record before redaction, i.e. the CID it was pulled by.
+	// Only set in pull responses for redacted records. It is never part of the record CID.
+	OriginalCid string `protobuf:"bytes,5,opt,name=original_cid,json=originalCid,proto3" json:"original_cid,omitempty"`
+	// Encrypted content of the record, set instead of data for records encrypted at rest.
+	// The server stores the envelope as is and cannot read the record content.
+	Envelope *RecordEnvelope `protobuf:"bytes,6,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	// Whether the record content is encrypted, i.e. the record carries an envelope instead of data.
+	// Only set in pull responses, clients with the key decrypt such records transparently.
+	// It is never part of the record CID.
+	Encrypted bool `protobuf:"varint,7,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	// Multihash code of the hash function the record CID is calculated with, e.g. 0x13 for SHA2-512.
+	// The CID is calculated with SHA2-256 if not set. Servers reject pushed records whose
+	// hash function differs from the one they are configured with. It is never part of the record CID.
+	CidHash       uint64 `protobuf:"varint,8,opt,name=cid_hash,json=cidHash,proto3" json:"cid_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Record) Reset() {
+	*x = Record{}
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record) ProtoMessage() {}
+
+func (x *Record) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Record.ProtoReflect.Descriptor instead.
+func (*Record) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Record) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Record) GetError() *RecordError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *Record) GetLifecycle() *Lifecycle {
+	if x != nil {
+		return x.Lifecycle
+	}
+	return nil
+}
+
+func (x *Record) GetRedacted() bool {
+	if x != nil {
+		return x.Redacted
+	}
+	return false
+}
+
+func (x *Record) GetOriginalCid() string {
+	if x != nil {
+		return x.OriginalCid
+	}
+	return ""
+}
+
+func (x *Record) GetEnvelope() *RecordEnvelope {
+	if x != nil {
+		return x.Envelope
+	}
+	return nil
+}
+
+func (x *Record) GetEncrypted() bool {
+	if x != nil {
+		return x.Encrypted
+	}
+	return false
+}
+
+func (x *Record) GetCidHash() uint64 {
+	if x != nil {
+		return x.CidHash
+	}
+	return 0
+}
+
+// RecordEnvelope is the encrypted form of a record.
+//
+// The record content is encrypted with a data key generated for the record,
+// which is itself encrypted with a key encryption key of the owner.
+// The CID is the CID of the plaintext record, so encrypting a record does not change its identity.
+type RecordEnvelope struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the plaintext record.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Canonical JSON of the record, encrypted with the data key.
+	Ciphertext []byte `protobuf:"bytes,2,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	// Parameters required to decrypt the ciphertext.
+	Header *EncryptionHeader `protobuf:"bytes,3,opt,name=header,proto3" json:"header,omitempty"`
+	// Metadata of the plaintext record, extracted by the client as the server cannot read the content.
+	// It is used to index, tag and look up the record.
+	Metadata      *EnvelopeMetadata `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecordRef) Reset() {
-	*x = RecordRef{}
-	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[0]
+func (x *RecordEnvelope) Reset() {
+	*x = RecordEnvelope{}
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecordRef) String() string {
+func (x *RecordEnvelope) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordRef) ProtoMessage() {}
+func (*RecordEnvelope) ProtoMessage() {}
 
-func (x *RecordRef) ProtoReflect() protoreflect.Message {
-	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[0]
+func (x *RecordEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -60,49 +856,146 @@ func (x *RecordRef) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordRef.ProtoReflect.Descriptor instead.
-func (*RecordRef) Descriptor() ([]byte, []int) {
-	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use RecordEnvelope.ProtoReflect.Descriptor instead.
+func (*RecordEnvelope) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *RecordRef) GetCid() string {
+func (x *RecordEnvelope) GetCid() string {
 	if x != nil {
 		return x.Cid
 	}
 	return ""
 }
 
-// Defines metadata about a record.
-type RecordMeta struct {
+func (x *RecordEnvelope) GetCiphertext() []byte {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return nil
+}
+
+func (x *RecordEnvelope) GetHeader() *EncryptionHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *RecordEnvelope) GetMetadata() *EnvelopeMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// EncryptionHeader describes how the content of a record envelope was encrypted.
+type EncryptionHeader struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// CID of the record.
-	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
-	// Annotations attached to the record.
-	Annotations map[string]string `protobuf:"bytes,2,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// Schema version of the record.
+	// Content encryption algorithm, e.g. "AES-256-GCM".
+	Algorithm string `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// ID of the key encryption key that wrapped the data key.
+	KeyId string `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// Data key, encrypted with the key encryption key.
+	WrappedKey []byte `protobuf:"bytes,3,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	// Nonce used to encrypt the content.
+	Nonce         []byte `protobuf:"bytes,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EncryptionHeader) Reset() {
+	*x = EncryptionHeader{}
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncryptionHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptionHeader) ProtoMessage() {}
+
+func (x *EncryptionHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptionHeader.ProtoReflect.Descriptor instead.
+func (*EncryptionHeader) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EncryptionHeader) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *EncryptionHeader) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *EncryptionHeader) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *EncryptionHeader) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+// EnvelopeMetadata is the plaintext metadata of an encrypted record.
+type EnvelopeMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the record.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Version of the record.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// OASF schema version of the record.
 	SchemaVersion string `protobuf:"bytes,3,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
 	// Creation timestamp of the record in the RFC3339 format.
-	// Specs: https://www.rfc-editor.org/rfc/rfc3339.html
-	CreatedAt     string `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedAt string `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Annotations of the record.
+	Annotations map[string]string `protobuf:"bytes,5,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Name tags the record is stored under in addition to its CID, see Record.DiscoveryTags.
+	Tags          []string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecordMeta) Reset() {
-	*x = RecordMeta{}
-	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[1]
+func (x *EnvelopeMetadata) Reset() {
+	*x = EnvelopeMetadata{}
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecordMeta) String() string {
+func (x *EnvelopeMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordMeta) ProtoMessage() {}
+func (*EnvelopeMetadata) ProtoMessage() {}
 
-func (x *RecordMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[1]
+func (x *EnvelopeMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -113,67 +1006,87 @@ func (x *RecordMeta) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordMeta.ProtoReflect.Descriptor instead.
-func (*RecordMeta) Descriptor() ([]byte, []int) {
-	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use EnvelopeMetadata.ProtoReflect.Descriptor instead.
+func (*EnvelopeMetadata) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *RecordMeta) GetCid() string {
+func (x *EnvelopeMetadata) GetName() string {
 	if x != nil {
-		return x.Cid
+		return x.Name
 	}
 	return ""
 }
 
-func (x *RecordMeta) GetAnnotations() map[string]string {
+func (x *EnvelopeMetadata) GetVersion() string {
 	if x != nil {
-		return x.Annotations
+		return x.Version
 	}
-	return nil
+	return ""
 }
 
-func (x *RecordMeta) GetSchemaVersion() string {
+func (x *EnvelopeMetadata) GetSchemaVersion() string {
 	if x != nil {
 		return x.SchemaVersion
 	}
 	return ""
 }
 
-func (x *RecordMeta) GetCreatedAt() string {
+func (x *EnvelopeMetadata) GetCreatedAt() string {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return ""
 }
 
-// Record is a generic object that encapsulates data of different Record types.
-//
-// Supported schemas:
-//
-// v0.3.1: https://schema.oasf.outshift.com/0.3.1/objects/agent
-// v0.7.0: https://schema.oasf.outshift.com/0.7.0/objects/record
-type Record struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          *structpb.Struct       `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+func (x *EnvelopeMetadata) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *EnvelopeMetadata) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// RecordError describes a failure to process a single record reference
+// within a streaming operation, allowing the stream to continue with the rest.
+type RecordError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// gRPC status code of the failure.
+	// Specs: https://grpc.io/docs/guides/status-codes/
+	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Human-readable description of the failure.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// CID of the failed record reference, echoed so that clients can
+	// match the failure to the reference it was sent for.
+	Cid string `protobuf:"bytes,3,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Structured details of the failure, as attached to the gRPC status,
+	// e.g. google.rpc.QuotaFailure, google.rpc.BadRequest or google.rpc.RetryInfo.
+	Details       []*anypb.Any `protobuf:"bytes,4,rep,name=details,proto3" json:"details,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Record) Reset() {
-	*x = Record{}
-	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[2]
+func (x *RecordError) Reset() {
+	*x = RecordError{}
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Record) String() string {
+func (x *RecordError) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Record) ProtoMessage() {}
+func (*RecordError) ProtoMessage() {}
 
-func (x *Record) ProtoReflect() protoreflect.Message {
-	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[2]
+func (x *RecordError) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -184,14 +1097,35 @@ func (x *Record) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Record.ProtoReflect.Descriptor instead.
-func (*Record) Descriptor() ([]byte, []int) {
-	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use RecordError.ProtoReflect.Descriptor instead.
+func (*RecordError) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *Record) GetData() *structpb.Struct {
+func (x *RecordError) GetCode() uint32 {
 	if x != nil {
-		return x.Data
+		return x.Code
+	}
+	return 0
+}
+
+func (x *RecordError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RecordError) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *RecordError) GetDetails() []*anypb.Any {
+	if x != nil {
+		return x.Details
 	}
 	return nil
 }
@@ -222,7 +1156,7 @@ type RecordReferrer struct {
 
 func (x *RecordReferrer) Reset() {
 	*x = RecordReferrer{}
-	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[3]
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -234,7 +1168,7 @@ func (x *RecordReferrer) String() string {
 func (*RecordReferrer) ProtoMessage() {}
 
 func (x *RecordReferrer) ProtoReflect() protoreflect.Message {
-	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[3]
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -247,7 +1181,7 @@ func (x *RecordReferrer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecordReferrer.ProtoReflect.Descriptor instead.
 func (*RecordReferrer) Descriptor() ([]byte, []int) {
-	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{3}
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *RecordReferrer) GetType() string {
@@ -285,68 +1219,370 @@ func (x *RecordReferrer) GetData() *structpb.Struct {
 	return nil
 }
 
+// RecordBundle is a manifest of related records that are deployed and approved as a unit.
+//
+// A bundle is content-addressed like a record: its CID is calculated over its canonical
+// JSON form, in which members are sorted by CID. Signing the bundle CID therefore
+// approves the exact combination of its member records.
+type RecordBundle struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the bundle.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Member records of the bundle.
+	Members []*BundleMember `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	// Annotations attached to the bundle.
+	Annotations   map[string]string `protobuf:"bytes,3,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordBundle) Reset() {
+	*x = RecordBundle{}
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordBundle) ProtoMessage() {}
+
+func (x *RecordBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordBundle.ProtoReflect.Descriptor instead.
+func (*RecordBundle) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RecordBundle) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RecordBundle) GetMembers() []*BundleMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *RecordBundle) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+// BundleMember references a record that is part of a bundle.
+type BundleMember struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the member record.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Role of the record within the bundle, e.g. "orchestrator".
+	Role          string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BundleMember) Reset() {
+	*x = BundleMember{}
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BundleMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BundleMember) ProtoMessage() {}
+
+func (x *BundleMember) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_core_v1_record_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BundleMember.ProtoReflect.Descriptor instead.
+func (*BundleMember) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_core_v1_record_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BundleMember) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *BundleMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
 var File_agntcy_dir_core_v1_record_proto protoreflect.FileDescriptor
 
 var file_agntcy_dir_core_v1_record_proto_rawDesc = string([]byte{
 	0x0a, 0x1f, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64, 0x69, 0x72, 0x2f, 0x63, 0x6f, 0x72,
 	0x65, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x12, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f,
-	0x72, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0x1d, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66,
-	0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63,
-	0x69, 0x64, 0x22, 0xf7, 0x01, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4d, 0x65, 0x74,
-	0x61, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x63, 0x69, 0x64, 0x12, 0x51, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63,
-	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
-	0x63, 0x6f, 0x72, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
-	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a,
-	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x1a, 0x3e, 0x0a, 0x10,
-	0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x35, 0x0a, 0x06,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x2b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0xc5, 0x02, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65,
-	0x66, 0x65, 0x72, 0x72, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x3c, 0x0a, 0x0a, 0x72, 0x65,
-	0x63, 0x6f, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
-	0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x52, 0x09, 0x72,
-	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x12, 0x55, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f,
-	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e,
-	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
-	0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65,
-	0x72, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x83,
+	0x02, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x12, 0x10, 0x0a, 0x03,
+	0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x27,
+	0x0a, 0x0f, 0x61, 0x6c, 0x72, 0x65, 0x61, 0x64, 0x79, 0x5f, 0x65, 0x78, 0x69, 0x73, 0x74, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x61, 0x6c, 0x72, 0x65, 0x61, 0x64, 0x79,
+	0x45, 0x78, 0x69, 0x73, 0x74, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
+	0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x3e,
+	0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x43, 0x6f, 0x6e, 0x66,
+	0x6c, 0x69, 0x63, 0x74, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x44,
+	0x0a, 0x0d, 0x6c, 0x69, 0x6e, 0x74, 0x5f, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64,
+	0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x74, 0x46,
+	0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x0c, 0x6c, 0x69, 0x6e, 0x74, 0x46, 0x69, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x73, 0x22, 0x70, 0x0a, 0x0b, 0x4c, 0x69, 0x6e, 0x74, 0x46, 0x69, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12,
+	0x17, 0x0a, 0x07, 0x72, 0x75, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x50, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x22, 0x71, 0x0a, 0x0b, 0x53, 0x63, 0x61, 0x6e,
+	0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x63, 0x61, 0x6e, 0x6e,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x63, 0x61, 0x6e, 0x6e, 0x65,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x98, 0x06, 0x0a, 0x0a,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x51, 0x0a, 0x0b,
+	0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2f, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4d, 0x65, 0x74,
+	0x61, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
 	0x72, 0x79, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x35, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69,
+	0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x3b, 0x0a, 0x09,
+	0x6c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x52, 0x09,
+	0x6c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x69, 0x6e,
+	0x6e, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65,
+	0x64, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x12, 0x6a, 0x0a, 0x14, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37,
+	0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x2e, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x44, 0x0a, 0x0d,
+	0x73, 0x63, 0x61, 0x6e, 0x5f, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x0a, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x46, 0x69, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x52, 0x0c, 0x73, 0x63, 0x61, 0x6e, 0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e,
+	0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x03, 0x61, 0x63, 0x6c, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64,
+	0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x41, 0x43, 0x4c, 0x52, 0x03, 0x61, 0x63, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x75, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x64, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x73, 0x75, 0x70, 0x65, 0x72, 0x73, 0x65, 0x64, 0x65, 0x64, 0x42, 0x79, 0x1a, 0x3e,
+	0x0a, 0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x46,
+	0x0a, 0x18, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4d, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x41, 0x43, 0x4c, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x75, 0x6c,
+	0x6c, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x75,
+	0x6c, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x22, 0xa4, 0x01, 0x0a, 0x09, 0x4c, 0x69, 0x66, 0x65, 0x63, 0x79,
+	0x63, 0x6c, 0x65, 0x12, 0x3b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63,
+	0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x5f, 0x63, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x6f, 0x72, 0x43, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1d, 0x0a,
+	0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xe1, 0x02, 0x0a,
+	0x06, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x2b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x35, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x3b, 0x0a, 0x09, 0x6c,
+	0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x52, 0x09, 0x6c,
+	0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x64, 0x61,
+	0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x64, 0x61,
+	0x63, 0x74, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c,
+	0x5f, 0x63, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x61, 0x6c, 0x43, 0x69, 0x64, 0x12, 0x3e, 0x0a, 0x08, 0x65, 0x6e, 0x76, 0x65, 0x6c,
+	0x6f, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x67, 0x6e, 0x74,
+	0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x52, 0x08, 0x65,
+	0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x65, 0x6e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x69, 0x64, 0x5f, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x69, 0x64, 0x48, 0x61, 0x73, 0x68,
+	0x22, 0xc2, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x45, 0x6e, 0x76, 0x65, 0x6c,
+	0x6f, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x74,
+	0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x69, 0x70, 0x68, 0x65,
+	0x72, 0x74, 0x65, 0x78, 0x74, 0x12, 0x3c, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64,
+	0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x12, 0x40, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64,
+	0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x76, 0x65, 0x6c,
+	0x6f, 0x70, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x7e, 0x0a, 0x10, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0a, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0xb3, 0x02, 0x0a, 0x10, 0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f,
+	0x70, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
 	0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x2b,
-	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
-	0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3e, 0x0a, 0x10, 0x41,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x57,
+	0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70,
+	0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x41,
 	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
 	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
 	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0xb3, 0x01, 0x0a, 0x16,
-	0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63,
-	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x50, 0x72,
-	0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64, 0x69, 0x72, 0x2f, 0x61, 0x70, 0x69,
-	0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x44, 0x43, 0xaa, 0x02,
-	0x12, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x44, 0x69, 0x72, 0x2e, 0x43, 0x6f, 0x72, 0x65,
-	0x2e, 0x56, 0x31, 0xca, 0x02, 0x12, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72,
-	0x5c, 0x43, 0x6f, 0x72, 0x65, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x1e, 0x41, 0x67, 0x6e, 0x74, 0x63,
-	0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x43, 0x6f, 0x72, 0x65, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50,
-	0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x15, 0x41, 0x67, 0x6e, 0x74,
-	0x63, 0x79, 0x3a, 0x3a, 0x44, 0x69, 0x72, 0x3a, 0x3a, 0x43, 0x6f, 0x72, 0x65, 0x3a, 0x3a, 0x56,
-	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x7d, 0x0a, 0x0b, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x2e, 0x0a, 0x07, 0x64, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e,
+	0x79, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x22, 0xc5, 0x02, 0x0a, 0x0e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x3c, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x66, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64,
+	0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x52, 0x65, 0x66, 0x52, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x12,
+	0x55, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69,
+	0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x72, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x2b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x1a, 0x3e, 0x0a, 0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0xf3, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63,
+	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x73, 0x12, 0x53, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63,
+	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x61, 0x6e, 0x6e,
+	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x41, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x34, 0x0a, 0x0c, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f,
+	0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x2a, 0x91,
+	0x01, 0x0a, 0x0f, 0x4c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x20, 0x0a, 0x1c, 0x4c, 0x49, 0x46, 0x45, 0x43, 0x59, 0x43, 0x4c, 0x45, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x4c, 0x49, 0x46, 0x45, 0x43, 0x59, 0x43, 0x4c,
+	0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10,
+	0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x4c, 0x49, 0x46, 0x45, 0x43, 0x59, 0x43, 0x4c, 0x45, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x44, 0x45, 0x50, 0x52, 0x45, 0x43, 0x41, 0x54, 0x45, 0x44,
+	0x10, 0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x4c, 0x49, 0x46, 0x45, 0x43, 0x59, 0x43, 0x4c, 0x45, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x57, 0x49, 0x54, 0x48, 0x44, 0x52, 0x41, 0x57, 0x4e,
+	0x10, 0x03, 0x42, 0xb3, 0x01, 0x0a, 0x16, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63,
+	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0b, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x21, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f,
+	0x64, 0x69, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x31, 0xa2,
+	0x02, 0x03, 0x41, 0x44, 0x43, 0xaa, 0x02, 0x12, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x44,
+	0x69, 0x72, 0x2e, 0x43, 0x6f, 0x72, 0x65, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x12, 0x41, 0x67, 0x6e,
+	0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x43, 0x6f, 0x72, 0x65, 0x5c, 0x56, 0x31, 0xe2,
+	0x02, 0x1e, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x43, 0x6f, 0x72,
+	0x65, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0xea, 0x02, 0x15, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x3a, 0x3a, 0x44, 0x69, 0x72, 0x3a, 0x3a,
+	0x43, 0x6f, 0x72, 0x65, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -361,27 +1597,62 @@ func file_agntcy_dir_core_v1_record_proto_rawDescGZIP() []byte {
 	return file_agntcy_dir_core_v1_record_proto_rawDescData
 }
 
-var file_agntcy_dir_core_v1_record_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_agntcy_dir_core_v1_record_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_agntcy_dir_core_v1_record_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_agntcy_dir_core_v1_record_proto_goTypes = []any{
-	(*RecordRef)(nil),       // 0: agntcy.dir.core.v1.RecordRef
-	(*RecordMeta)(nil),      // 1: agntcy.dir.core.v1.RecordMeta
-	(*Record)(nil),          // 2: agntcy.dir.core.v1.Record
-	(*RecordReferrer)(nil),  // 3: agntcy.dir.core.v1.RecordReferrer
-	nil,                     // 4: agntcy.dir.core.v1.RecordMeta.AnnotationsEntry
-	nil,                     // 5: agntcy.dir.core.v1.RecordReferrer.AnnotationsEntry
-	(*structpb.Struct)(nil), // 6: google.protobuf.Struct
+	(LifecycleStatus)(0),     // 0: agntcy.dir.core.v1.LifecycleStatus
+	(*RecordRef)(nil),        // 1: agntcy.dir.core.v1.RecordRef
+	(*LintFinding)(nil),      // 2: agntcy.dir.core.v1.LintFinding
+	(*RecordConflict)(nil),   // 3: agntcy.dir.core.v1.RecordConflict
+	(*ScanFinding)(nil),      // 4: agntcy.dir.core.v1.ScanFinding
+	(*RecordMeta)(nil),       // 5: agntcy.dir.core.v1.RecordMeta
+	(*RecordACL)(nil),        // 6: agntcy.dir.core.v1.RecordACL
+	(*Lifecycle)(nil),        // 7: agntcy.dir.core.v1.Lifecycle
+	(*Record)(nil),           // 8: agntcy.dir.core.v1.Record
+	(*RecordEnvelope)(nil),   // 9: agntcy.dir.core.v1.RecordEnvelope
+	(*EncryptionHeader)(nil), // 10: agntcy.dir.core.v1.EncryptionHeader
+	(*EnvelopeMetadata)(nil), // 11: agntcy.dir.core.v1.EnvelopeMetadata
+	(*RecordError)(nil),      // 12: agntcy.dir.core.v1.RecordError
+	(*RecordReferrer)(nil),   // 13: agntcy.dir.core.v1.RecordReferrer
+	(*RecordBundle)(nil),     // 14: agntcy.dir.core.v1.RecordBundle
+	(*BundleMember)(nil),     // 15: agntcy.dir.core.v1.BundleMember
+	nil,                      // 16: agntcy.dir.core.v1.RecordMeta.AnnotationsEntry
+	nil,                      // 17: agntcy.dir.core.v1.RecordMeta.OperationalMetadataEntry
+	nil,                      // 18: agntcy.dir.core.v1.EnvelopeMetadata.AnnotationsEntry
+	nil,                      // 19: agntcy.dir.core.v1.RecordReferrer.AnnotationsEntry
+	nil,                      // 20: agntcy.dir.core.v1.RecordBundle.AnnotationsEntry
+	(*structpb.Struct)(nil),  // 21: google.protobuf.Struct
+	(*anypb.Any)(nil),        // 22: google.protobuf.Any
 }
 var file_agntcy_dir_core_v1_record_proto_depIdxs = []int32{
-	4, // 0: agntcy.dir.core.v1.RecordMeta.annotations:type_name -> agntcy.dir.core.v1.RecordMeta.AnnotationsEntry
-	6, // 1: agntcy.dir.core.v1.Record.data:type_name -> google.protobuf.Struct
-	0, // 2: agntcy.dir.core.v1.RecordReferrer.record_ref:type_name -> agntcy.dir.core.v1.RecordRef
-	5, // 3: agntcy.dir.core.v1.RecordReferrer.annotations:type_name -> agntcy.dir.core.v1.RecordReferrer.AnnotationsEntry
-	6, // 4: agntcy.dir.core.v1.RecordReferrer.data:type_name -> google.protobuf.Struct
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	12, // 0: agntcy.dir.core.v1.RecordRef.error:type_name -> agntcy.dir.core.v1.RecordError
+	3,  // 1: agntcy.dir.core.v1.RecordRef.conflict:type_name -> agntcy.dir.core.v1.RecordConflict
+	2,  // 2: agntcy.dir.core.v1.RecordRef.lint_findings:type_name -> agntcy.dir.core.v1.LintFinding
+	16, // 3: agntcy.dir.core.v1.RecordMeta.annotations:type_name -> agntcy.dir.core.v1.RecordMeta.AnnotationsEntry
+	12, // 4: agntcy.dir.core.v1.RecordMeta.error:type_name -> agntcy.dir.core.v1.RecordError
+	7,  // 5: agntcy.dir.core.v1.RecordMeta.lifecycle:type_name -> agntcy.dir.core.v1.Lifecycle
+	17, // 6: agntcy.dir.core.v1.RecordMeta.operational_metadata:type_name -> agntcy.dir.core.v1.RecordMeta.OperationalMetadataEntry
+	4,  // 7: agntcy.dir.core.v1.RecordMeta.scan_warnings:type_name -> agntcy.dir.core.v1.ScanFinding
+	6,  // 8: agntcy.dir.core.v1.RecordMeta.acl:type_name -> agntcy.dir.core.v1.RecordACL
+	0,  // 9: agntcy.dir.core.v1.Lifecycle.status:type_name -> agntcy.dir.core.v1.LifecycleStatus
+	21, // 10: agntcy.dir.core.v1.Record.data:type_name -> google.protobuf.Struct
+	12, // 11: agntcy.dir.core.v1.Record.error:type_name -> agntcy.dir.core.v1.RecordError
+	7,  // 12: agntcy.dir.core.v1.Record.lifecycle:type_name -> agntcy.dir.core.v1.Lifecycle
+	9,  // 13: agntcy.dir.core.v1.Record.envelope:type_name -> agntcy.dir.core.v1.RecordEnvelope
+	10, // 14: agntcy.dir.core.v1.RecordEnvelope.header:type_name -> agntcy.dir.core.v1.EncryptionHeader
+	11, // 15: agntcy.dir.core.v1.RecordEnvelope.metadata:type_name -> agntcy.dir.core.v1.EnvelopeMetadata
+	18, // 16: agntcy.dir.core.v1.EnvelopeMetadata.annotations:type_name -> agntcy.dir.core.v1.EnvelopeMetadata.AnnotationsEntry
+	22, // 17: agntcy.dir.core.v1.RecordError.details:type_name -> google.protobuf.Any
+	1,  // 18: agntcy.dir.core.v1.RecordReferrer.record_ref:type_name -> agntcy.dir.core.v1.RecordRef
+	19, // 19: agntcy.dir.core.v1.RecordReferrer.annotations:type_name -> agntcy.dir.core.v1.RecordReferrer.AnnotationsEntry
+	21, // 20: agntcy.dir.core.v1.RecordReferrer.data:type_name -> google.protobuf.Struct
+	15, // 21: agntcy.dir.core.v1.RecordBundle.members:type_name -> agntcy.dir.core.v1.BundleMember
+	20, // 22: agntcy.dir.core.v1.RecordBundle.annotations:type_name -> agntcy.dir.core.v1.RecordBundle.AnnotationsEntry
+	23, // [23:23] is the sub-list for method output_type
+	23, // [23:23] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_agntcy_dir_core_v1_record_proto_init() }
@@ -394,13 +1665,14 @@ func file_agntcy_dir_core_v1_record_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_agntcy_dir_core_v1_record_proto_rawDesc), len(file_agntcy_dir_core_v1_record_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      1,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_agntcy_dir_core_v1_record_proto_goTypes,
 		DependencyIndexes: file_agntcy_dir_core_v1_record_proto_depIdxs,
+		EnumInfos:         file_agntcy_dir_core_v1_record_proto_enumTypes,
 		MessageInfos:      file_agntcy_dir_core_v1_record_proto_msgTypes,
 	}.Build()
 	File_agntcy_dir_core_v1_record_proto = out.File
@@ -10,6 +10,7 @@ import (
 	oasfv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -302,3 +303,46 @@ func TestRecord_Decode(t *testing.T) {
 		})
 	}
 }
+
+func TestRecord_CIDOptions(t *testing.T) {
+	record := corev1.New(&oasfv1alpha1.Record{
+		Name:          "sha512-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+	sha256CID := record.GetCid()
+
+	opts, err := corev1.ParseCIDOptions("sha2-512")
+	assert.NoError(t, err)
+
+	record.SetCIDOptions(opts)
+	sha512CID := record.GetCid()
+	assert.NotEqual(t, sha256CID, sha512CID)
+
+	digest, err := corev1.ConvertCIDToDigest(sha512CID)
+	assert.NoError(t, err)
+	assert.Equal(t, "sha512", digest.Algorithm().String())
+
+	// Copies of the record keep the options
+	cloned, ok := proto.Clone(record).(*corev1.Record)
+	assert.True(t, ok)
+	assert.Equal(t, sha512CID, cloned.GetCid())
+
+	wire, err := proto.Marshal(record)
+	assert.NoError(t, err)
+
+	unmarshaled := &corev1.Record{}
+	assert.NoError(t, proto.Unmarshal(wire, unmarshaled))
+	assert.Equal(t, sha512CID, unmarshaled.GetCid())
+
+	// Records are verified with the hash function of the CID they are checked against
+	pulled := corev1.New(&oasfv1alpha1.Record{
+		Name:          "sha512-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+	assert.True(t, pulled.MatchesCid(sha512CID))
+	assert.Equal(t, sha512CID, pulled.GetCid())
+	assert.True(t, pulled.MatchesCid(sha256CID))
+	assert.False(t, pulled.MatchesCid("invalid-cid"))
+}
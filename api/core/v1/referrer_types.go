@@ -10,4 +10,12 @@ const (
 
 	// SignatureReferrerType is the type for Signature referrers.
 	SignatureReferrerType = "agntcy.dir.sign.v1.Signature"
+
+	// AttestationReferrerType is the type for attestation referrers,
+	// which carry DSSE envelopes of signed in-toto statements.
+	AttestationReferrerType = "agntcy.dir.attest.v1.Attestation"
+
+	// MigrationReferrerType is the type for migration referrers, which link a record
+	// converted to another schema version to the record it was converted to.
+	MigrationReferrerType = "agntcy.dir.migrate.v1.Migration"
 )
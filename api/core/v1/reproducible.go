@@ -0,0 +1,178 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ScrubRules selects the volatile fields of a record removed or pinned by NormalizeForReproducibility.
+type ScrubRules struct {
+	// AnnotationKeys are removed from the record annotations. A key matches a rule if it equals
+	// the rule or ends with the rule after a "." or "/", e.g. "ci.build.timestamp" matches "build.timestamp".
+	AnnotationKeys []string
+
+	// DataPaths are dot-separated paths removed from the data of each extension (v0.3.1)
+	// or module (0.7.0), e.g. "build.host" removes the host field of the build object.
+	DataPaths []string
+
+	// PinnedFields are top-level record fields set to a fixed value, if present,
+	// e.g. "created_at" pinned to "1970-01-01T00:00:00Z". Required fields are pinned rather than removed.
+	PinnedFields map[string]string
+}
+
+// DefaultScrubRules returns the rules for the well-known volatile fields written by build tooling.
+func DefaultScrubRules() ScrubRules {
+	return ScrubRules{
+		AnnotationKeys: []string{"build.timestamp", "build.host", "generated_at"},
+		DataPaths:      []string{"build.timestamp", "build.host", "generated_at"},
+	}
+}
+
+// ScrubAction describes what NormalizeForReproducibility did with a field.
+type ScrubAction string
+
+const (
+	ScrubRemoved ScrubAction = "removed"
+	ScrubPinned  ScrubAction = "pinned"
+)
+
+// ScrubEntry is a field removed or pinned by NormalizeForReproducibility.
+type ScrubEntry struct {
+	// Path is the location of the field, e.g. "annotations.build.timestamp" or "modules[runtime/model].data.build.host".
+	// Elements of extensions and modules are addressed by name, or by index if unnamed, like in RecordDiff.
+	Path   string
+	Action ScrubAction
+	// Value is the original value of the field.
+	Value any
+}
+
+// ScrubReport lists the fields removed or pinned by NormalizeForReproducibility, sorted by path.
+type ScrubReport struct {
+	Entries []ScrubEntry
+}
+
+// IsEmpty reports whether the record was left unchanged.
+func (r *ScrubReport) IsEmpty() bool {
+	return r == nil || len(r.Entries) == 0
+}
+
+// Paths returns the paths of all removed or pinned fields.
+func (r *ScrubReport) Paths() []string {
+	if r == nil {
+		return nil
+	}
+
+	paths := make([]string, 0, len(r.Entries))
+	for _, entry := range r.Entries {
+		paths = append(paths, entry.Path)
+	}
+
+	return paths
+}
+
+// NormalizeForReproducibility returns a copy of the record without the volatile fields selected by rules,
+// so that rebuilding the same record content yields the same CID, together with a report of the changes.
+// The record itself is not modified. Encrypted records cannot be normalized.
+func NormalizeForReproducibility(record *Record, rules ScrubRules) (*Record, *ScrubReport, error) {
+	if record.IsEncrypted() {
+		return nil, nil, errors.New("encrypted records cannot be normalized")
+	}
+
+	if record == nil || record.GetData() == nil {
+		return nil, nil, errors.New("record data is empty")
+	}
+
+	data, ok := proto.Clone(record.GetData()).(*structpb.Struct)
+	if !ok {
+		return nil, nil, errors.New("failed to copy record data")
+	}
+
+	report := &ScrubReport{}
+	fields := data.GetFields()
+
+	for field, value := range rules.PinnedFields {
+		current, ok := fields[field]
+		if !ok || current.GetStringValue() == value {
+			continue
+		}
+
+		report.add(field, ScrubPinned, current)
+		fields[field] = structpb.NewStringValue(value)
+	}
+
+	if annotations := fields["annotations"].GetStructValue(); annotations != nil {
+		for key, value := range annotations.GetFields() {
+			if matchesAnnotationKey(key, rules.AnnotationKeys) {
+				report.add(fieldPath("annotations", key), ScrubRemoved, value)
+				delete(annotations.GetFields(), key)
+			}
+		}
+	}
+
+	for _, list := range []string{"extensions", "modules"} {
+		for i, element := range fields[list].GetListValue().GetValues() {
+			elementData := element.GetStructValue().GetFields()["data"].GetStructValue()
+			if elementData == nil {
+				continue
+			}
+
+			key := element.GetStructValue().GetFields()["name"].GetStringValue()
+			if key == "" {
+				key = strconv.Itoa(i)
+			}
+
+			for _, path := range rules.DataPaths {
+				scrubDataPath(report, elementPath(list, key)+".data", elementData, strings.Split(path, "."))
+			}
+		}
+	}
+
+	slices.SortFunc(report.Entries, func(a, b ScrubEntry) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	normalized := &Record{Data: data, CidHash: record.GetCidHash()}
+
+	return normalized, report, nil
+}
+
+func (r *ScrubReport) add(path string, action ScrubAction, value *structpb.Value) {
+	r.Entries = append(r.Entries, ScrubEntry{Path: path, Action: action, Value: value.AsInterface()})
+}
+
+func matchesAnnotationKey(key string, rules []string) bool {
+	for _, rule := range rules {
+		if key == rule || strings.HasSuffix(key, "."+rule) || strings.HasSuffix(key, "/"+rule) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scrubDataPath removes the field at the path segments below the struct.
+func scrubDataPath(report *ScrubReport, path string, data *structpb.Struct, segments []string) {
+	for _, segment := range segments[:len(segments)-1] {
+		data = data.GetFields()[segment].GetStructValue()
+		if data == nil {
+			return
+		}
+
+		path = fieldPath(path, segment)
+	}
+
+	last := segments[len(segments)-1]
+
+	if value, ok := data.GetFields()[last]; ok {
+		report.add(fieldPath(path, last), ScrubRemoved, value)
+		delete(data.GetFields(), last)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildRecord returns a record as written by a build, with the given volatile values.
+func buildRecord(t *testing.T, timestamp, host string) *corev1.Record {
+	t.Helper()
+
+	record, err := corev1.LoadOASFFromReader(strings.NewReader(`{
+		"name": "directory.agntcy.org/example/reproducible",
+		"version": "v1.0.0",
+		"schema_version": "0.7.0",
+		"created_at": "`+timestamp+`",
+		"annotations": {"team": "platform", "ci.build.timestamp": "`+timestamp+`", "generated_at": "`+timestamp+`"},
+		"modules": [
+			{"name": "runtime/model", "data": {"models": ["gpt-4o"], "build": {"host": "`+host+`", "timestamp": "`+timestamp+`"}}},
+			{"data": {"generated_at": "`+timestamp+`"}}
+		]
+	}`), corev1.Lenient())
+	require.NoError(t, err)
+
+	return record
+}
+
+func TestNormalizeForReproducibility(t *testing.T) {
+	rules := corev1.DefaultScrubRules()
+	rules.PinnedFields = map[string]string{"created_at": "1970-01-01T00:00:00Z"}
+
+	a := buildRecord(t, "2025-01-01T00:00:00Z", "runner-1")
+	b := buildRecord(t, "2025-06-30T12:34:56Z", "runner-2")
+	require.NotEqual(t, a.GetCid(), b.GetCid())
+
+	normalizedA, reportA, err := corev1.NormalizeForReproducibility(a, rules)
+	require.NoError(t, err)
+
+	normalizedB, reportB, err := corev1.NormalizeForReproducibility(b, rules)
+	require.NoError(t, err)
+
+	assert.Equal(t, normalizedA.GetCid(), normalizedB.GetCid())
+
+	wantPaths := []string{
+		"annotations.ci.build.timestamp",
+		"annotations.generated_at",
+		"created_at",
+		"modules[1].data.generated_at",
+		"modules[runtime/model].data.build.host",
+		"modules[runtime/model].data.build.timestamp",
+	}
+	assert.Equal(t, wantPaths, reportA.Paths())
+	assert.Equal(t, wantPaths, reportB.Paths())
+
+	assert.Equal(t, corev1.ScrubEntry{
+		Path:   "modules[runtime/model].data.build.host",
+		Action: corev1.ScrubRemoved,
+		Value:  "runner-1",
+	}, reportA.Entries[4])
+	assert.Equal(t, corev1.ScrubPinned, reportA.Entries[2].Action)
+
+	// The original record is not modified, and other fields are kept
+	assert.NotEqual(t, a.GetCid(), normalizedA.GetCid())
+	assert.Equal(t, "runner-1", a.GetData().GetFields()["modules"].GetListValue().GetValues()[0].
+		GetStructValue().GetFields()["data"].GetStructValue().GetFields()["build"].GetStructValue().GetFields()["host"].GetStringValue())
+	assert.Equal(t, "platform", normalizedA.GetData().GetFields()["annotations"].GetStructValue().GetFields()["team"].GetStringValue())
+
+	// Normalization is idempotent
+	again, report, err := corev1.NormalizeForReproducibility(normalizedA, rules)
+	require.NoError(t, err)
+	assert.True(t, report.IsEmpty())
+	assert.Equal(t, normalizedA.GetCid(), again.GetCid())
+}
+
+func TestNormalizeForReproducibility_DataPaths(t *testing.T) {
+	record := buildRecord(t, "2025-01-01T00:00:00Z", "runner-1")
+
+	normalized, report, err := corev1.NormalizeForReproducibility(record, corev1.ScrubRules{DataPaths: []string{"build"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"modules[runtime/model].data.build"}, report.Paths())
+	assert.Equal(t, map[string]any{"host": "runner-1", "timestamp": "2025-01-01T00:00:00Z"}, report.Entries[0].Value)
+	assert.NotContains(t, normalized.GetData().GetFields()["modules"].GetListValue().GetValues()[0].
+		GetStructValue().GetFields()["data"].GetStructValue().GetFields(), "build")
+}
+
+func TestNormalizeForReproducibility_Errors(t *testing.T) {
+	_, _, err := corev1.NormalizeForReproducibility(nil, corev1.DefaultScrubRules())
+	require.Error(t, err)
+
+	_, _, err = corev1.NormalizeForReproducibility(&corev1.Record{Encrypted: true}, corev1.DefaultScrubRules())
+	require.Error(t, err)
+}
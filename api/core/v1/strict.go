@@ -0,0 +1,430 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FieldIssue is an unknown or invalid field of an OASF document.
+type FieldIssue struct {
+	// Path is the JSON path of the field, e.g. "skills[0].nmae".
+	// It is empty for issues of the document itself.
+	Path string
+
+	// Message describes the issue.
+	Message string
+
+	// Suggestion is the schema field name closest to an unknown field name, if any.
+	Suggestion string
+}
+
+func (i FieldIssue) String() string {
+	var s string
+
+	if i.Path != "" {
+		s = i.Path + ": "
+	}
+
+	s += i.Message
+
+	if i.Suggestion != "" {
+		s += fmt.Sprintf(" (did you mean %q?)", i.Suggestion)
+	}
+
+	return s
+}
+
+// UnmarshalError is returned when an OASF document does not match the fields of its schema version.
+type UnmarshalError struct {
+	Issues []FieldIssue
+}
+
+func (e *UnmarshalError) Error() string {
+	issues := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		issues = append(issues, issue.String())
+	}
+
+	return "invalid OASF document: " + strings.Join(issues, "; ")
+}
+
+// schemaOnlyFields are fields defined by the OASF JSON schema that the typed records lack.
+// They are accepted in strict mode, as the server accepts them.
+var schemaOnlyFields = map[protoreflect.FullName][]string{
+	(&typesv1alpha0.Signature{}).ProtoReflect().Descriptor().FullName(): {"annotations"},
+}
+
+// ExplainUnmarshalError explains an error unmarshaling the OASF document data into its typed record,
+// e.g. with protojson, listing each unknown or invalid field of the document with its JSON path.
+// Unknown fields come with the closest field name of the schema as suggestion, if there is a close one.
+// If the fields of the document cannot be checked, e.g. as the document is not valid JSON,
+// the error itself is returned as the only issue. Returns nil if err is nil.
+func ExplainUnmarshalError(data []byte, err error) []FieldIssue {
+	if err == nil {
+		return nil
+	}
+
+	if issues := checkFields(data); len(issues) > 0 {
+		return issues
+	}
+
+	return []FieldIssue{{Message: err.Error()}}
+}
+
+// checkFields checks the fields of the OASF document data against the fields of the typed record of its
+// schema version, with the strictness of protojson. Documents of unsupported schema versions are not checked.
+func checkFields(data []byte) []FieldIssue {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return []FieldIssue{{Message: fmt.Sprintf("invalid JSON: %v", err)}}
+	}
+
+	object, ok := document.(map[string]any)
+	if !ok {
+		return []FieldIssue{{Message: "document is not a JSON object"}}
+	}
+
+	schemaVersion, _ := object["schema_version"].(string)
+
+	var descriptor protoreflect.MessageDescriptor
+
+	switch schemaVersion {
+	case "0.3.1", "v0.3.1":
+		descriptor = (&typesv1alpha0.Record{}).ProtoReflect().Descriptor()
+	case "0.7.0":
+		descriptor = (&typesv1alpha1.Record{}).ProtoReflect().Descriptor()
+	default:
+		return nil
+	}
+
+	var issues []FieldIssue
+
+	checkMessage(descriptor, "", object, &issues)
+
+	return issues
+}
+
+func checkMessage(descriptor protoreflect.MessageDescriptor, path string, object map[string]any, issues *[]FieldIssue) {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		fieldPath := joinPath(path, key)
+
+		field := descriptor.Fields().ByJSONName(key)
+		if field == nil {
+			field = descriptor.Fields().ByTextName(key)
+		}
+
+		if field == nil {
+			if slices.Contains(schemaOnlyFields[descriptor.FullName()], key) {
+				continue
+			}
+
+			*issues = append(*issues, FieldIssue{
+				Path:       fieldPath,
+				Message:    "unknown field",
+				Suggestion: suggestField(descriptor, key),
+			})
+
+			continue
+		}
+
+		checkField(field, fieldPath, object[key], issues)
+	}
+}
+
+func checkField(field protoreflect.FieldDescriptor, path string, value any, issues *[]FieldIssue) {
+	if value == nil {
+		return
+	}
+
+	switch {
+	case field.IsMap():
+		object, ok := value.(map[string]any)
+		if !ok {
+			*issues = append(*issues, FieldIssue{Path: path, Message: "invalid value: expected an object"})
+
+			return
+		}
+
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			checkValue(field.MapValue(), joinPath(path, key), object[key], issues)
+		}
+
+	case field.IsList():
+		list, ok := value.([]any)
+		if !ok {
+			*issues = append(*issues, FieldIssue{Path: path, Message: "invalid value: expected an array"})
+
+			return
+		}
+
+		for i, element := range list {
+			checkValue(field, fmt.Sprintf("%s[%d]", path, i), element, issues)
+		}
+
+	default:
+		checkValue(field, path, value, issues)
+	}
+}
+
+// checkValue checks a single value of the field, i.e. an element of lists and maps.
+//
+//nolint:cyclop
+func checkValue(field protoreflect.FieldDescriptor, path string, value any, issues *[]FieldIssue) {
+	if value == nil {
+		return
+	}
+
+	var expected string
+
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if message := field.Message(); message.FullName().Parent() == "google.protobuf" {
+			expected = checkWellKnown(message.FullName().Name(), value)
+
+			break
+		}
+
+		object, ok := value.(map[string]any)
+		if !ok {
+			expected = "an object"
+
+			break
+		}
+
+		checkMessage(field.Message(), path, object, issues)
+
+	case protoreflect.BoolKind:
+		if _, ok := value.(bool); !ok {
+			expected = "a boolean"
+		}
+
+	case protoreflect.StringKind:
+		if _, ok := value.(string); !ok {
+			expected = "a string"
+		}
+
+	case protoreflect.BytesKind:
+		if !isBase64(value) {
+			expected = "a base64 string"
+		}
+
+	case protoreflect.EnumKind:
+		if !isEnum(field.Enum(), value) {
+			expected = "one of " + enumNames(field.Enum())
+		}
+
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		if !isFloat(value) {
+			expected = "a number"
+		}
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if !isInteger(value, math.MinInt32, math.MaxInt32) {
+			expected = "a 32-bit integer"
+		}
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if !isInteger(value, 0, math.MaxUint32) {
+			expected = "an unsigned 32-bit integer"
+		}
+
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if !isInteger(value, math.MinInt64, math.MaxInt64) {
+			expected = "a 64-bit integer"
+		}
+
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if !isInteger(value, 0, math.MaxUint64) {
+			expected = "an unsigned 64-bit integer"
+		}
+	}
+
+	if expected != "" {
+		*issues = append(*issues, FieldIssue{Path: path, Message: "invalid value: expected " + expected})
+	}
+}
+
+// checkWellKnown checks the JSON value of a well-known type, returning what was expected if it does not match.
+func checkWellKnown(name protoreflect.Name, value any) string {
+	switch name {
+	case "Struct":
+		if _, ok := value.(map[string]any); !ok {
+			return "an object"
+		}
+	case "ListValue":
+		if _, ok := value.([]any); !ok {
+			return "an array"
+		}
+	case "Timestamp", "Duration", "FieldMask":
+		if _, ok := value.(string); !ok {
+			return "a string"
+		}
+	}
+
+	return ""
+}
+
+func isBase64(value any) bool {
+	s, ok := value.(string)
+	if !ok {
+		return false
+	}
+
+	// protojson accepts standard and URL-safe encodings, with or without padding
+	s = strings.TrimRight(s, "=")
+	if _, err := base64.RawStdEncoding.DecodeString(s); err == nil {
+		return true
+	}
+
+	_, err := base64.RawURLEncoding.DecodeString(s)
+
+	return err == nil
+}
+
+func isEnum(enum protoreflect.EnumDescriptor, value any) bool {
+	switch v := value.(type) {
+	case string:
+		return enum.Values().ByName(protoreflect.Name(v)) != nil
+	case json.Number:
+		return isInteger(v, math.MinInt32, math.MaxInt32)
+	default:
+		return false
+	}
+}
+
+func enumNames(enum protoreflect.EnumDescriptor) string {
+	names := make([]string, 0, enum.Values().Len())
+	for i := range enum.Values().Len() {
+		names = append(names, strconv.Quote(string(enum.Values().Get(i).Name())))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// isFloat reports whether the value is a number, or a string protojson accepts for floating point fields.
+func isFloat(value any) bool {
+	switch v := value.(type) {
+	case json.Number:
+		return true
+	case string:
+		if v == "NaN" || v == "Infinity" || v == "-Infinity" {
+			return true
+		}
+
+		_, err := strconv.ParseFloat(v, 64)
+
+		return err == nil
+	default:
+		return false
+	}
+}
+
+// isInteger reports whether the value is an integral number within the range, or a string of one.
+func isInteger(value any, minValue, maxValue float64) bool {
+	var s string
+
+	switch v := value.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return false
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.Trunc(f) != f {
+		return false
+	}
+
+	return f >= minValue && f <= maxValue
+}
+
+// suggestField returns the field name of the message closest to the unknown name,
+// or an empty string if no field name is close enough.
+func suggestField(descriptor protoreflect.MessageDescriptor, name string) string {
+	maxDistance := max(2, len(name)/3) //nolint:mnd
+
+	var (
+		suggestion string
+		best       = maxDistance + 1
+	)
+
+	for i := range descriptor.Fields().Len() {
+		candidate := string(descriptor.Fields().Get(i).Name())
+		if distance := editDistance(strings.ToLower(name), candidate); distance < best && distance < len(name) {
+			suggestion, best = candidate, distance
+		}
+	}
+
+	return suggestion
+}
+
+// editDistance returns the optimal string alignment distance of a and b, i.e. the number
+// of insertions, deletions, substitutions and transpositions of adjacent bytes to turn a into b.
+func editDistance(a, b string) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+
+	for j := range len(b) + 1 {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return rows[len(a)][len(b)]
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
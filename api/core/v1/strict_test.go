@@ -0,0 +1,151 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	strictRecord031 = `{
+  "name": "cisco.com/agents/marketing",
+  "version": "v1.0.0",
+  "schema_version": "%s",
+  "%s": "Marketing agent",
+  "skills": [{"category_name": "Natural Language Processing", "category_uid": 1, "%s": "Text Completion", "class_uid": 10201}],
+  "extensions": [{"name": "license", "%s": "v1.0.0", "data": {"license": "Apache-2.0"}}]
+}`
+
+	strictRecord070 = `{
+  "name": "cisco.com/agents/marketing",
+  "version": "v1.0.0",
+  "schema_version": "0.7.0",
+  "%s": "Marketing agent",
+  "skills": [{"%s": "natural_language_processing/natural_language_generation/text_completion", "id": 10201}],
+  "modules": [{"name": "license", "%s": {"license": "Apache-2.0"}}]
+}`
+)
+
+func TestLoadOASFFromReader_Strict(t *testing.T) {
+	tests := []struct {
+		name     string
+		valid    string
+		typos    string
+		expected []corev1.FieldIssue
+	}{
+		{
+			name:  "0.3.1",
+			valid: fmt.Sprintf(strictRecord031, "0.3.1", "description", "class_name", "version"),
+			typos: fmt.Sprintf(strictRecord031, "0.3.1", "descripton", "clas_name", "verison"),
+			expected: []corev1.FieldIssue{
+				{Path: "descripton", Message: "unknown field", Suggestion: "description"},
+				{Path: "extensions[0].verison", Message: "unknown field", Suggestion: "version"},
+				{Path: "skills[0].clas_name", Message: "unknown field", Suggestion: "class_name"},
+			},
+		},
+		{
+			name:  "v0.3.1",
+			valid: fmt.Sprintf(strictRecord031, "v0.3.1", "description", "class_name", "version"),
+			typos: fmt.Sprintf(strictRecord031, "v0.3.1", "Description", "className", "extension_version"),
+			expected: []corev1.FieldIssue{
+				{Path: "Description", Message: "unknown field", Suggestion: "description"},
+				{Path: "extensions[0].extension_version", Message: "unknown field"},
+			},
+		},
+		{
+			name:  "0.7.0",
+			valid: fmt.Sprintf(strictRecord070, "description", "name", "data"),
+			typos: fmt.Sprintf(strictRecord070, "desc", "nmae", "dta"),
+			expected: []corev1.FieldIssue{
+				{Path: "desc", Message: "unknown field"},
+				{Path: "modules[0].dta", Message: "unknown field", Suggestion: "data"},
+				{Path: "skills[0].nmae", Message: "unknown field", Suggestion: "name"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := corev1.LoadOASFFromReader(strings.NewReader(tt.valid))
+			require.NoError(t, err)
+
+			_, err = corev1.LoadOASFFromReader(strings.NewReader(tt.typos))
+
+			var unmarshalErr *corev1.UnmarshalError
+			require.ErrorAs(t, err, &unmarshalErr)
+			assert.Equal(t, tt.expected, unmarshalErr.Issues)
+
+			// Lenient loading keeps the unknown fields
+			record, err := corev1.LoadOASFFromReader(strings.NewReader(tt.typos), corev1.Lenient())
+			require.NoError(t, err)
+			assert.Contains(t, record.GetData().GetFields(), tt.expected[0].Path)
+		})
+	}
+}
+
+func TestLoadOASFFromReader_StrictValues(t *testing.T) {
+	_, err := corev1.LoadOASFFromReader(strings.NewReader(`{
+  "schema_version": "0.3.1",
+  "authors": "Cisco Systems",
+  "skills": [{"class_uid": "text-completion"}, {"class_uid": -1}],
+  "locators": [{"type": "docker-image", "size": 1.5}],
+  "extensions": [{"name": "license", "data": ["Apache-2.0"]}]
+}`))
+
+	var unmarshalErr *corev1.UnmarshalError
+	require.ErrorAs(t, err, &unmarshalErr)
+	assert.Equal(t, []corev1.FieldIssue{
+		{Path: "authors", Message: "invalid value: expected an array"},
+		{Path: "extensions[0].data", Message: "invalid value: expected an object"},
+		{Path: "locators[0].size", Message: "invalid value: expected an unsigned 64-bit integer"},
+		{Path: "skills[0].class_uid", Message: "invalid value: expected an unsigned 64-bit integer"},
+		{Path: "skills[1].class_uid", Message: "invalid value: expected an unsigned 64-bit integer"},
+	}, unmarshalErr.Issues)
+
+	assert.Contains(t, err.Error(), `locators[0].size: invalid value`)
+}
+
+func TestLoadOASFFromReader_StrictCorpus(t *testing.T) {
+	// Documents of unsupported schema versions are left to the schema validation
+	_, err := corev1.LoadOASFFromReader(strings.NewReader(`{"schema_version": "9.9.9", "nmae": "agent"}`))
+	require.NoError(t, err)
+
+	for _, name := range []string{"record_031.json", "record_070.json"} {
+		data, err := os.ReadFile(filepath.Join("testdata", "canonical", name))
+		require.NoError(t, err)
+
+		_, err = corev1.LoadOASFFromReader(strings.NewReader(string(data)))
+		require.NoError(t, err, name)
+	}
+}
+
+func TestExplainUnmarshalError(t *testing.T) {
+	assert.Nil(t, corev1.ExplainUnmarshalError([]byte(`{}`), nil))
+
+	data := []byte(`{"schema_version": "0.7.0", "name": "agent", "skills": [{"name": "text_completion", "idd": 10201}], "domians": []}`)
+
+	err := protojson.Unmarshal(data, &typesv1alpha1.Record{})
+	require.Error(t, err)
+
+	// protojson only reports the first unknown field
+	assert.Equal(t, []corev1.FieldIssue{
+		{Path: "domians", Message: "unknown field", Suggestion: "domains"},
+		{Path: "skills[0].idd", Message: "unknown field", Suggestion: "id"},
+	}, corev1.ExplainUnmarshalError(data, err))
+
+	issues := corev1.ExplainUnmarshalError([]byte(`{"name":`), err)
+	require.Len(t, issues, 1)
+	assert.Empty(t, issues[0].Path)
+	assert.Contains(t, issues[0].Message, "invalid JSON")
+}
@@ -0,0 +1,251 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/agntcy/dir/api/names"
+)
+
+// MaxTagLength is the maximum length of an OCI tag.
+const MaxTagLength = 128
+
+// NormalizeTag converts a tag to the OCI tag grammar [a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}.
+// Tags are lowercased, spaces become hyphens, path separators become dots
+// and other invalid characters become underscores, e.g. "My Agent/v1.0@Company"
+// becomes "my-agent.v1.0_company". Leading and trailing separators are removed.
+func NormalizeTag(tag string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(strings.TrimSpace(tag)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '.', r == '-':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '/':
+			b.WriteRune('.')
+		default:
+			b.WriteRune('_')
+		}
+	}
+
+	normalized := strings.TrimLeft(b.String(), ".-")
+	if len(normalized) > MaxTagLength {
+		normalized = normalized[:MaxTagLength]
+	}
+
+	return strings.TrimRight(normalized, "._-")
+}
+
+// NameTag returns the tag "<name>:<version>" of a record name and version in their canonical forms,
+// see the names package, normalized with NormalizeTag. Names that are the same record name share
+// their tags, e.g. "My Agent" and "MY_AGENT" both have the "my-agent_latest" tag.
+func NameTag(name, version string) string {
+	return NormalizeTag(names.CanonicalName(name) + ":" + names.CanonicalVersion(version))
+}
+
+// NormalizeDiscoveryTag normalizes a tag given by a user in the same way as the name tags of DiscoveryTags,
+// so that "MY_AGENT:latest" refers to the "my-agent_latest" tag of a record named "My Agent".
+// Tags without a version, e.g. CIDs or "my-agent_latest", are normalized with NormalizeTag.
+func NormalizeDiscoveryTag(tag string) string {
+	i := strings.LastIndex(tag, ":")
+	if i < 0 {
+		return NormalizeTag(tag)
+	}
+
+	return NameTag(tag[:i], tag[i+1:])
+}
+
+// MaxTagsPerRecord is the maximum number of discovery tags of a record, including its CID tag.
+// Only encrypted records, whose name tags are computed by the client, can have more candidate tags.
+const MaxTagsPerRecord = 20
+
+// TagSource is the origin of a candidate tag of a record.
+type TagSource string
+
+const (
+	// TagSourceCID is the CID tag of a record.
+	TagSourceCID TagSource = "cid"
+	// TagSourceVersion is the "<name>:<version>" tag of a record.
+	TagSourceVersion TagSource = "version"
+	// TagSourceLatest is the "<name>:latest" tag of a record.
+	TagSourceLatest TagSource = "latest"
+	// TagSourceFloating is a "<name>:<major>" or "<name>:<major>.<minor>" tag of a record.
+	TagSourceFloating TagSource = "floating"
+	// TagSourceClient is a name tag computed by the client of an encrypted record.
+	TagSourceClient TagSource = "client"
+	// TagSourceMetadata is a tag derived from the operational metadata of a record, see MetadataTags.
+	TagSourceMetadata TagSource = "metadata"
+)
+
+// TagAction is the decision taken for a candidate tag of a record.
+type TagAction string
+
+const (
+	// TagActionCreated creates the tag as it is.
+	TagActionCreated TagAction = "created"
+	// TagActionNormalized creates the tag normalized from its original form, e.g. "my-agent_v1.0.0" from "My Agent:V1.0.0".
+	TagActionNormalized TagAction = "normalized_from"
+	// TagActionDeduplicated drops a tag that normalizes to a tag created before it.
+	TagActionDeduplicated TagAction = "deduplicated"
+	// TagActionTruncated drops a tag beyond MaxTagsPerRecord.
+	TagActionTruncated TagAction = "truncated"
+	// TagActionSkipped drops an invalid tag, a tag that would shadow the CID tag of a record
+	// or a floating tag a pre-release version does not capture.
+	TagActionSkipped TagAction = "skipped"
+)
+
+// TagOutcome is the result of creating a tag in the registry.
+type TagOutcome string
+
+const (
+	// TagOutcomeTagged is a tag pointed to the record.
+	TagOutcomeTagged TagOutcome = "tagged"
+	// TagOutcomeUnchanged is a tag that already pointed to the record.
+	TagOutcomeUnchanged TagOutcome = "unchanged"
+	// TagOutcomeKept is a floating tag kept on the greater version it points to.
+	TagOutcomeKept TagOutcome = "kept"
+	// TagOutcomeFailed is a tag the registry failed to create.
+	TagOutcomeFailed TagOutcome = "failed"
+)
+
+// TagDecision explains why a record has, or does not have, a tag: the candidate tag, its source,
+// the action taken for it and the original form it was derived from.
+// Stores add the outcome of creating the tag, if it was created.
+type TagDecision struct {
+	Tag      string
+	Source   TagSource
+	Action   TagAction
+	Original string
+
+	Outcome TagOutcome
+	Error   string
+}
+
+// Creates reports whether the tag of the decision is created.
+func (d TagDecision) Creates() bool {
+	return d.Action == TagActionCreated || d.Action == TagActionNormalized
+}
+
+// CreatedTags returns the tags created by the decisions, in order.
+func CreatedTags(decisions []TagDecision) []string {
+	var tags []string
+
+	for _, decision := range decisions {
+		if decision.Creates() {
+			tags = append(tags, decision.Tag)
+		}
+	}
+
+	return tags
+}
+
+// DiscoveryTags returns the tags a record is stored under: its CID,
+// followed by the name tags "<name>:<version>" and "<name>:latest", see NameTag.
+// Name tags are mutable, pushing a newer record with the same name re-points them.
+// The name tags of encrypted records are the tags computed by the client, see EnvelopeMetadata.
+func (r *Record) DiscoveryTags() []string {
+	return CreatedTags(r.ExplainDiscoveryTags())
+}
+
+// ExplainDiscoveryTags returns the decisions for each candidate discovery tag of the record, see DiscoveryTags.
+// Candidate tags that are dropped, e.g. because they normalize to the same tag, are included with their action.
+func (r *Record) ExplainDiscoveryTags() []TagDecision {
+	cid := r.GetCid()
+	if cid == "" {
+		return nil
+	}
+
+	explainer := &tagExplainer{limit: MaxTagsPerRecord}
+	explainer.add(cid, TagSourceCID, cid)
+
+	if envelope := r.GetEnvelope(); envelope != nil {
+		for _, tag := range envelope.GetMetadata().GetTags() {
+			if !validNameTag(tag) {
+				explainer.skip(tag, TagSourceClient)
+
+				continue
+			}
+
+			explainer.add(tag, TagSourceClient, tag)
+		}
+
+		return explainer.decisions
+	}
+
+	fields := r.GetData().GetFields()
+
+	name := fields["name"].GetStringValue()
+	if name == "" {
+		return explainer.decisions
+	}
+
+	if version := fields["version"].GetStringValue(); version != "" {
+		explainer.addName(name, version, TagSourceVersion)
+	}
+
+	explainer.addName(name, LatestVersion, TagSourceLatest)
+
+	return explainer.decisions
+}
+
+// tagExplainer collects the decisions for the candidate tags of a record, in order.
+type tagExplainer struct {
+	decisions []TagDecision
+	created   int
+
+	// Maximum number of created tags, unlimited if zero
+	limit int
+}
+
+// addName adds the name tag of a name and version.
+// The tag of "<name>:<version>" is "<name>_<version>" unless normalization changed the name or version.
+func (e *tagExplainer) addName(name, version string, source TagSource) {
+	tag := NameTag(name, version)
+	original := name + ":" + version
+
+	// A name tag must never shadow the CID tag of another record
+	if tag == "" || IsValidCID(tag) {
+		e.skip(original, source)
+
+		return
+	}
+
+	e.add(tag, source, original)
+}
+
+// add adds a valid candidate tag derived from original.
+func (e *tagExplainer) add(tag string, source TagSource, original string) {
+	decision := TagDecision{Tag: tag, Source: source, Action: TagActionCreated}
+
+	if tag != strings.Replace(original, ":", "_", 1) {
+		decision.Action = TagActionNormalized
+		decision.Original = original
+	}
+
+	switch {
+	case slices.Contains(CreatedTags(e.decisions), tag):
+		decision.Action = TagActionDeduplicated
+		decision.Original = original
+	case e.limit > 0 && e.created >= e.limit:
+		decision.Action = TagActionTruncated
+	default:
+		e.created++
+	}
+
+	e.decisions = append(e.decisions, decision)
+}
+
+// skip adds an invalid candidate tag.
+func (e *tagExplainer) skip(original string, source TagSource) {
+	e.decisions = append(e.decisions, TagDecision{Source: source, Action: TagActionSkipped, Original: original})
+}
+
+// validNameTag reports whether a tag is a normalized name tag, which must never shadow the CID tag of a record.
+func validNameTag(tag string) bool {
+	return tag != "" && NormalizeTag(tag) == tag && !IsValidCID(tag)
+}
@@ -0,0 +1,153 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Already valid", input: "my-agent_v1.0", expected: "my-agent_v1.0"},
+		{name: "Uppercase", input: "My-Agent", expected: "my-agent"},
+		{name: "Name with version", input: "my-agent:latest", expected: "my-agent_latest"},
+		{name: "Mixed", input: "My Agent/v1.0@Company", expected: "my-agent.v1.0_company"},
+		{name: "Surrounding whitespace", input: "  my-agent  ", expected: "my-agent"},
+		{name: "Leading separators", input: "..-my-agent", expected: "my-agent"},
+		{name: "Trailing separators", input: "my-agent:", expected: "my-agent"},
+		{name: "Only invalid characters", input: "::", expected: ""},
+		{name: "Empty", input: "", expected: ""},
+		{name: "Too long", input: strings.Repeat("a", 200), expected: strings.Repeat("a", corev1.MaxTagLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, corev1.NormalizeTag(tt.input))
+
+			// Normalization is idempotent
+			assert.Equal(t, tt.expected, corev1.NormalizeTag(tt.expected))
+		})
+	}
+}
+
+func TestNormalizeDiscoveryTag(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "MY_AGENT:latest", expected: "my-agent_latest"},
+		{input: "My Agent:V1.0.0", expected: "my-agent_v1.0.0"},
+		{input: "my-agent:team-platform", expected: "my-agent_team-platform"},
+		{input: "my-agent_latest", expected: "my-agent_latest"},
+		{input: "My@Agent:latest", expected: "my_agent_latest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, corev1.NormalizeDiscoveryTag(tt.input))
+		})
+	}
+}
+
+func TestRecord_DiscoveryTags(t *testing.T) {
+	versioned := corev1.New(&typesv1alpha1.Record{
+		Name:          "My Agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+	assert.Equal(t, []string{versioned.GetCid(), "my-agent_v1.0.0", "my-agent_latest"}, versioned.DiscoveryTags())
+
+	// Names that are the same record name share their name tags
+	shouted := corev1.New(&typesv1alpha1.Record{
+		Name:          "MY_AGENT",
+		Version:       "V1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+	assert.Equal(t, []string{shouted.GetCid(), "my-agent_v1.0.0", "my-agent_latest"}, shouted.DiscoveryTags())
+
+	unversioned := corev1.New(&typesv1alpha1.Record{
+		Name:          "my-agent",
+		SchemaVersion: "0.7.0",
+	})
+	assert.Equal(t, []string{unversioned.GetCid(), "my-agent_latest"}, unversioned.DiscoveryTags())
+
+	unnamed := corev1.New(&typesv1alpha1.Record{
+		SchemaVersion: "0.7.0",
+	})
+	assert.Equal(t, []string{unnamed.GetCid()}, unnamed.DiscoveryTags())
+
+	var empty *corev1.Record
+	assert.Empty(t, empty.DiscoveryTags())
+}
+
+func TestRecord_ExplainDiscoveryTags(t *testing.T) {
+	t.Run("Normalization", func(t *testing.T) {
+		record := corev1.New(&typesv1alpha1.Record{
+			Name:          "My Agent",
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+		})
+
+		assert.Equal(t, []corev1.TagDecision{
+			{Tag: record.GetCid(), Source: corev1.TagSourceCID, Action: corev1.TagActionCreated},
+			{Tag: "my-agent_v1.0.0", Source: corev1.TagSourceVersion, Action: corev1.TagActionNormalized, Original: "My Agent:v1.0.0"},
+			{Tag: "my-agent_latest", Source: corev1.TagSourceLatest, Action: corev1.TagActionNormalized, Original: "My Agent:latest"},
+		}, record.ExplainDiscoveryTags())
+	})
+
+	t.Run("Normalization collision", func(t *testing.T) {
+		// Both name tags are cut to the same prefix at the maximum tag length
+		name := strings.Repeat("a", corev1.MaxTagLength)
+		record := corev1.New(&typesv1alpha1.Record{
+			Name:          name,
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+		})
+
+		decisions := record.ExplainDiscoveryTags()
+		require.Len(t, decisions, 3) //nolint:mnd
+		assert.Equal(t, corev1.TagActionNormalized, decisions[1].Action)
+		assert.Equal(t, corev1.TagDecision{
+			Tag: name, Source: corev1.TagSourceLatest, Action: corev1.TagActionDeduplicated, Original: name + ":latest",
+		}, decisions[2])
+		assert.Equal(t, []string{record.GetCid(), name}, record.DiscoveryTags())
+	})
+
+	t.Run("Truncation", func(t *testing.T) {
+		cid := corev1.New(&typesv1alpha1.Record{Name: "sealed-agent", SchemaVersion: "0.7.0"}).GetCid()
+
+		// 21 candidate tags including the CID tag, and an invalid tag
+		tags := []string{"Invalid Tag"}
+		for i := range corev1.MaxTagsPerRecord {
+			tags = append(tags, fmt.Sprintf("sealed-agent_v%d", i))
+		}
+
+		record := &corev1.Record{Envelope: &corev1.RecordEnvelope{
+			Cid:      cid,
+			Metadata: &corev1.EnvelopeMetadata{Name: "sealed-agent", Tags: tags},
+		}}
+
+		decisions := record.ExplainDiscoveryTags()
+		require.Len(t, decisions, corev1.MaxTagsPerRecord+2) //nolint:mnd
+		assert.Equal(t, corev1.TagDecision{Source: corev1.TagSourceClient, Action: corev1.TagActionSkipped, Original: "Invalid Tag"}, decisions[1])
+		assert.Equal(t, corev1.TagDecision{
+			Tag: "sealed-agent_v19", Source: corev1.TagSourceClient, Action: corev1.TagActionTruncated,
+		}, decisions[len(decisions)-1])
+
+		created := record.DiscoveryTags()
+		assert.Len(t, created, corev1.MaxTagsPerRecord)
+		assert.Equal(t, cid, created[0])
+	})
+}
@@ -0,0 +1,188 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ErrInvalidTemplate is returned by ValidateTemplate when a generated record
+// does not pass push-time validation.
+var ErrInvalidTemplate = errors.New("invalid record template")
+
+// instanceFields are record fields that describe a specific published instance
+// of a record rather than its content, and are dropped by TemplateFrom.
+var instanceFields = []string{"signature", "annotations", "created_at", "previous_record_cid"}
+
+// TemplateLocator describes a locator of a record template.
+type TemplateLocator struct {
+	Type string
+	URL  string
+}
+
+// TemplateOptions describes the content of a record generated by NewTemplate.
+type TemplateOptions struct {
+	Name        string
+	Version     string
+	Description string
+	Authors     []string
+	// CreatedAt is the RFC3339 creation timestamp. Defaults to the current time.
+	CreatedAt string
+	// Skills are named "<category>/<class>", e.g. "natural_language_processing/text_completion".
+	Skills []string
+	// Extensions are v0.3.1 extension names, or 0.7.0 module names.
+	Extensions []string
+	Locators   []TemplateLocator
+}
+
+// NewTemplate generates a record skeleton for the given OASF schema version.
+//
+// Supported versions are "v0.3.1" (typesv1alpha0) and "0.7.0" (typesv1alpha1).
+// The generated record is not validated, see ValidateTemplate.
+func NewTemplate(schemaVersion string, opts TemplateOptions) (*Record, error) {
+	version, err := normalizeSchemaVersion(schemaVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.CreatedAt == "" {
+		opts.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	for _, skill := range opts.Skills {
+		if category, class, _ := strings.Cut(skill, "/"); category == "" || class == "" {
+			return nil, fmt.Errorf("invalid skill %q: expected <category>/<class>", skill)
+		}
+	}
+
+	for _, locator := range opts.Locators {
+		if locator.Type == "" || locator.URL == "" {
+			return nil, fmt.Errorf("invalid locator %q: type and URL are required", locator.Type+"="+locator.URL)
+		}
+	}
+
+	if version == schemaVersionV1Alpha0 {
+		return newTemplateV1Alpha0(opts), nil
+	}
+
+	return newTemplateV1Alpha1(opts), nil
+}
+
+func newTemplateV1Alpha0(opts TemplateOptions) *Record {
+	record := &typesv1alpha0.Record{
+		Name:          opts.Name,
+		Version:       opts.Version,
+		SchemaVersion: schemaVersionV1Alpha0,
+		Description:   opts.Description,
+		Authors:       opts.Authors,
+		CreatedAt:     opts.CreatedAt,
+	}
+
+	for _, skill := range opts.Skills {
+		category, class, _ := strings.Cut(skill, "/")
+		record.Skills = append(record.Skills, &typesv1alpha0.Skill{
+			CategoryName: &category,
+			ClassName:    &class,
+		})
+	}
+
+	for _, extension := range opts.Extensions {
+		record.Extensions = append(record.Extensions, &typesv1alpha0.Extension{Name: extension})
+	}
+
+	for _, locator := range opts.Locators {
+		record.Locators = append(record.Locators, &typesv1alpha0.Locator{Type: locator.Type, Url: locator.URL})
+	}
+
+	return New(record)
+}
+
+func newTemplateV1Alpha1(opts TemplateOptions) *Record {
+	record := &typesv1alpha1.Record{
+		Name:          opts.Name,
+		Version:       opts.Version,
+		SchemaVersion: schemaVersionV1Alpha1,
+		Description:   opts.Description,
+		Authors:       opts.Authors,
+		CreatedAt:     opts.CreatedAt,
+	}
+
+	for _, skill := range opts.Skills {
+		record.Skills = append(record.Skills, &typesv1alpha1.Skill{Name: skill})
+	}
+
+	for _, extension := range opts.Extensions {
+		record.Modules = append(record.Modules, &typesv1alpha1.Module{Name: extension})
+	}
+
+	for _, locator := range opts.Locators {
+		record.Locators = append(record.Locators, &typesv1alpha1.Locator{Type: locator.Type, Url: locator.URL})
+	}
+
+	return New(record)
+}
+
+// TemplateFrom returns a copy of the record with instance-specific fields
+// such as the signature, annotations and previous record CID removed,
+// so it can be used as a starting point for a new record.
+//
+// The name, version and description of the copy are replaced by the non-empty options,
+// and the creation time is reset to opts.CreatedAt or the current time.
+// Other options are ignored.
+func TemplateFrom(record *Record, opts TemplateOptions) (*Record, error) {
+	if record == nil || record.GetData() == nil {
+		return nil, errors.New("record data is empty")
+	}
+
+	data, ok := proto.Clone(record.GetData()).(*structpb.Struct)
+	if !ok {
+		return nil, errors.New("failed to copy record data")
+	}
+
+	for _, field := range instanceFields {
+		delete(data.GetFields(), field)
+	}
+
+	if opts.CreatedAt == "" {
+		opts.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	overrides := map[string]string{
+		"name":        opts.Name,
+		"version":     opts.Version,
+		"description": opts.Description,
+		"created_at":  opts.CreatedAt,
+	}
+
+	for field, value := range overrides {
+		if value != "" {
+			data.Fields[field] = structpb.NewStringValue(value)
+		}
+	}
+
+	return &Record{Data: data}, nil
+}
+
+// ValidateTemplate validates a generated record with the same rules used on push.
+// If the record is invalid, the returned error wraps ErrInvalidTemplate and lists all violations.
+func ValidateTemplate(record *Record) error {
+	valid, violations, err := record.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate record: %w", err)
+	}
+
+	if !valid {
+		return fmt.Errorf("%w: %s", ErrInvalidTemplate, strings.Join(violations, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"errors"
+	"testing"
+
+	oasfv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+)
+
+func templateOptions() corev1.TemplateOptions {
+	return corev1.TemplateOptions{
+		Name:        "template-agent",
+		Version:     "v1.0.0",
+		Description: "Agent generated from a template",
+		Authors:     []string{"Jane Doe <jane.doe@example.com>"},
+		CreatedAt:   "2024-01-01T00:00:00Z",
+		Skills:      []string{"natural_language_processing/natural_language_understanding"},
+		Extensions:  []string{"test-extension"},
+		Locators:    []corev1.TemplateLocator{{Type: "docker_image", URL: "ghcr.io/agntcy/template-agent:v1.0.0"}},
+	}
+}
+
+func TestNewTemplate(t *testing.T) {
+	tests := []struct {
+		name          string
+		schemaVersion string
+		modify        func(*corev1.TemplateOptions)
+		wantErr       bool
+		wantInvalid   bool
+	}{
+		{
+			name:          "valid 0.7.0 template",
+			schemaVersion: "0.7.0",
+		},
+		{
+			name:          "v0.3.1 template",
+			schemaVersion: "v0.3.1",
+			// v0.3.1 records additionally require skill UIDs and a signature
+			wantInvalid: true,
+		},
+		{
+			name:          "unknown skill",
+			schemaVersion: "0.7.0",
+			modify:        func(o *corev1.TemplateOptions) { o.Skills = []string{"unknown/skill"} },
+			wantInvalid:   true,
+		},
+		{
+			name:          "missing required fields",
+			schemaVersion: "0.7.0",
+			modify:        func(o *corev1.TemplateOptions) { o.Authors, o.Locators = nil, nil },
+			wantInvalid:   true,
+		},
+		{
+			name:          "malformed skill",
+			schemaVersion: "0.7.0",
+			modify:        func(o *corev1.TemplateOptions) { o.Skills = []string{"natural_language_processing"} },
+			wantErr:       true,
+		},
+		{
+			name:          "malformed locator",
+			schemaVersion: "0.7.0",
+			modify:        func(o *corev1.TemplateOptions) { o.Locators = []corev1.TemplateLocator{{Type: "docker_image"}} },
+			wantErr:       true,
+		},
+		{
+			name:          "unsupported schema version",
+			schemaVersion: "v0.5.0",
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := templateOptions()
+			if tt.modify != nil {
+				tt.modify(&opts)
+			}
+
+			record, err := corev1.NewTemplate(tt.schemaVersion, opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("NewTemplate() expected error, got none")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewTemplate() unexpected error: %v", err)
+			}
+
+			err = corev1.ValidateTemplate(record)
+			if tt.wantInvalid != errors.Is(err, corev1.ErrInvalidTemplate) {
+				t.Errorf("ValidateTemplate() got error = %v, want invalid = %v", err, tt.wantInvalid)
+			}
+		})
+	}
+}
+
+func TestNewTemplate_RoundTrip(t *testing.T) {
+	for _, schemaVersion := range []string{"0.7.0", "v0.3.1"} {
+		t.Run(schemaVersion, func(t *testing.T) {
+			record, err := corev1.NewTemplate(schemaVersion, templateOptions())
+			if err != nil {
+				t.Fatalf("NewTemplate() unexpected error: %v", err)
+			}
+
+			if record.GetSchemaVersion() != schemaVersion {
+				t.Errorf("expected schema version %s, got %s", schemaVersion, record.GetSchemaVersion())
+			}
+
+			data, err := record.Marshal()
+			if err != nil {
+				t.Fatalf("Marshal() unexpected error: %v", err)
+			}
+
+			loaded, err := corev1.UnmarshalRecord(data)
+			if err != nil {
+				t.Fatalf("UnmarshalRecord() unexpected error: %v", err)
+			}
+
+			if loaded.GetCid() == "" || loaded.GetCid() != record.GetCid() {
+				t.Errorf("expected round-tripped CID %s, got %s", record.GetCid(), loaded.GetCid())
+			}
+
+			// Generating the same template again yields the same CID
+			again, err := corev1.NewTemplate(schemaVersion, templateOptions())
+			if err != nil {
+				t.Fatalf("NewTemplate() unexpected error: %v", err)
+			}
+
+			if again.GetCid() != record.GetCid() {
+				t.Errorf("expected stable CID %s, got %s", record.GetCid(), again.GetCid())
+			}
+		})
+	}
+}
+
+func TestTemplateFrom(t *testing.T) {
+	previousCid := "baeareigdr3g5ql6apdqitesrrvxqbuvwqmw2ylfd7jkuxklvjlpmdm2ri"
+
+	source := corev1.New(&oasfv1alpha1.Record{
+		Name:              "published-agent",
+		Version:           "v2.0.0",
+		SchemaVersion:     "0.7.0",
+		CreatedAt:         "2024-01-01T00:00:00Z",
+		PreviousRecordCid: &previousCid,
+		Annotations:       map[string]string{"team": "agents"},
+		Skills:            []*oasfv1alpha1.Skill{{Name: "natural_language_processing/natural_language_understanding"}},
+		Signature:         &oasfv1alpha1.Signature{Signature: "signature"},
+	})
+
+	template, err := corev1.TemplateFrom(source, corev1.TemplateOptions{
+		Version:   "v3.0.0",
+		CreatedAt: "2025-01-01T00:00:00Z",
+	})
+	if err != nil {
+		t.Fatalf("TemplateFrom() unexpected error: %v", err)
+	}
+
+	fields := template.GetData().GetFields()
+	for _, field := range []string{"signature", "annotations", "previous_record_cid"} {
+		if _, ok := fields[field]; ok {
+			t.Errorf("expected %s to be removed", field)
+		}
+	}
+
+	if fields["name"].GetStringValue() != "published-agent" || len(fields["skills"].GetListValue().GetValues()) != 1 {
+		t.Errorf("expected record content to be kept, got %v", fields)
+	}
+
+	if fields["version"].GetStringValue() != "v3.0.0" || fields["created_at"].GetStringValue() != "2025-01-01T00:00:00Z" {
+		t.Errorf("expected version and creation time to be replaced, got %v", fields)
+	}
+
+	// The source record is not modified
+	if _, ok := source.GetData().GetFields()["signature"]; !ok {
+		t.Error("expected source record to keep its signature")
+	}
+
+	if _, err := corev1.TemplateFrom(&corev1.Record{}, corev1.TemplateOptions{}); err == nil {
+		t.Error("TemplateFrom() expected error for empty record")
+	}
+}
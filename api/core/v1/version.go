@@ -0,0 +1,144 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+)
+
+// semverParts is the number of numeric parts of a semantic version.
+const semverParts = 3
+
+// semver is a parsed semantic version, see https://semver.org.
+type semver struct {
+	core       [semverParts]uint64
+	prerelease []string
+}
+
+// parseSemver parses a semantic version. A leading "v" is allowed, missing minor and
+// patch versions default to zero and build metadata is ignored, e.g. "v2" equals "2.0.0+build".
+// Returns false if the version is not a semantic version.
+func parseSemver(version string) (semver, bool) {
+	version = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(version), "v"), "V")
+	version, _, _ = strings.Cut(version, "+")
+
+	core, prerelease, hasPrerelease := strings.Cut(version, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) > semverParts {
+		return semver{}, false
+	}
+
+	var v semver
+
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return semver{}, false
+		}
+
+		v.core[i] = n
+	}
+
+	if hasPrerelease {
+		v.prerelease = strings.Split(prerelease, ".")
+		for _, identifier := range v.prerelease {
+			if identifier == "" {
+				return semver{}, false
+			}
+		}
+	}
+
+	return v, true
+}
+
+// IsSemver reports whether version is a semantic version as accepted by CompareVersions.
+func IsSemver(version string) bool {
+	_, ok := parseSemver(version)
+
+	return ok
+}
+
+// IsPrerelease reports whether version is a pre-release semantic version, e.g. "1.0.0-rc.1".
+func IsPrerelease(version string) bool {
+	v, ok := parseSemver(version)
+
+	return ok && len(v.prerelease) > 0
+}
+
+// MajorMinor returns the major and the major.minor versions of a semantic version,
+// e.g. "2" and "2.1" for "v2.1.3-rc.1". Returns false if the version is not a semantic version.
+func MajorMinor(version string) (string, string, bool) {
+	v, ok := parseSemver(version)
+	if !ok {
+		return "", "", false
+	}
+
+	major := strconv.FormatUint(v.core[0], 10)
+
+	return major, major + "." + strconv.FormatUint(v.core[1], 10), true
+}
+
+// CompareVersions compares two record versions following semantic version precedence,
+// returning -1, 0 or +1. Pre-release versions precede the release, e.g.
+// 1.0.0-alpha < 1.0.0-alpha.1 < 1.0.0-beta < 1.0.0-rc.1 < 1.0.0.
+// Versions that are not semantic versions precede all semantic versions
+// and are compared lexically among themselves.
+func CompareVersions(a, b string) int {
+	va, okA := parseSemver(a)
+	vb, okB := parseSemver(b)
+
+	switch {
+	case !okA && !okB:
+		return strings.Compare(a, b)
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+
+	for i := range semverParts {
+		if c := cmp.Compare(va.core[i], vb.core[i]); c != 0 {
+			return c
+		}
+	}
+
+	// A release has higher precedence than its pre-releases
+	switch {
+	case len(va.prerelease) == 0 && len(vb.prerelease) == 0:
+		return 0
+	case len(va.prerelease) == 0:
+		return 1
+	case len(vb.prerelease) == 0:
+		return -1
+	}
+
+	for i := range min(len(va.prerelease), len(vb.prerelease)) {
+		if c := comparePrerelease(va.prerelease[i], vb.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(len(va.prerelease), len(vb.prerelease))
+}
+
+// comparePrerelease compares pre-release identifiers: numeric identifiers are compared
+// numerically and have lower precedence than alphanumeric ones, which are compared lexically.
+func comparePrerelease(a, b string) int {
+	na, errA := strconv.ParseUint(a, 10, 64)
+	nb, errB := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
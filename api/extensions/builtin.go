@@ -0,0 +1,74 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package extensions
+
+import (
+	"embed"
+	"fmt"
+)
+
+//go:embed schemas/*.json
+var builtinSchemas embed.FS
+
+// Runtime feature data.
+type (
+	// Framework is the data of the runtime/framework feature.
+	Framework struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+
+	// Language is the data of the runtime/language feature.
+	Language struct {
+		Type    string `json:"type"`
+		Version string `json:"version,omitempty"`
+	}
+
+	// License is the data of the license extension.
+	License struct {
+		License string `json:"license"`
+		Header  string `json:"header,omitempty"`
+	}
+
+	// Dependencies is the data of the core/dependencies extension,
+	// the records a record depends on, e.g. the tool agents of an orchestrator.
+	Dependencies struct {
+		Dependencies []Dependency `json:"dependencies"`
+	}
+
+	// Dependency is a record depended on, either by CID or by name and version constraint.
+	Dependency struct {
+		CID     string `json:"cid,omitempty"`
+		Name    string `json:"name,omitempty"`
+		Version string `json:"version,omitempty"`
+	}
+)
+
+// Names of the extensions with built-in schemas.
+const (
+	NameFramework = "runtime/framework"
+	NameLanguage  = "runtime/language"
+	NameLicense   = "license"
+
+	NameDependencies = "schema.oasf.agntcy.org/core/dependencies"
+)
+
+func init() {
+	for name, file := range map[string]string{
+		NameFramework: "schemas/runtime_framework.json",
+		NameLanguage:  "schemas/runtime_language.json",
+		NameLicense:   "schemas/license.json",
+
+		NameDependencies: "schemas/core_dependencies.json",
+	} {
+		schema, err := builtinSchemas.ReadFile(file)
+		if err != nil {
+			panic(fmt.Sprintf("failed to read built-in schema %s: %v", file, err))
+		}
+
+		if err := RegisterExtensionSchema(name, "", schema); err != nil {
+			panic(fmt.Sprintf("failed to register built-in schema %s: %v", file, err))
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package extensions
+
+import (
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+)
+
+// String returns the CID of the dependency, or its name and version constraint as "name@constraint".
+func (d Dependency) String() string {
+	switch {
+	case d.CID != "":
+		return d.CID
+	case d.Version != "":
+		return d.Name + "@" + d.Version
+	default:
+		return d.Name
+	}
+}
+
+// Constraint returns the version constraint of a dependency by name, see corev1.ParseVersionConstraint.
+// Dependencies without a version match any version.
+func (d Dependency) Constraint() (corev1.VersionConstraint, error) {
+	return corev1.ParseVersionConstraint(d.Version) //nolint:wrapcheck
+}
+
+// RecordDependencies returns the dependencies declared by the core/dependencies extensions of the record.
+// Dependencies must be declared by CID or by name, with a valid version constraint.
+func RecordDependencies(record *corev1.Record) ([]Dependency, error) {
+	exts, err := FromRecord(record)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependencies []Dependency
+
+	for _, ext := range exts {
+		if canonicalName(ext.Name) != canonicalName(NameDependencies) {
+			continue
+		}
+
+		data, err := DecodeExtension[Dependencies](ext)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ext.Path, err)
+		}
+
+		for i, dependency := range data.Dependencies {
+			if dependency.CID != "" && !corev1.IsValidCID(dependency.CID) {
+				return nil, fmt.Errorf("%s: dependencies[%d]: %w: invalid CID %q", ext.Path, i, ErrInvalidExtension, dependency.CID)
+			}
+
+			if _, err := dependency.Constraint(); dependency.CID == "" && err != nil {
+				return nil, fmt.Errorf("%s: dependencies[%d]: %w: %w", ext.Path, i, ErrInvalidExtension, err)
+			}
+
+			dependencies = append(dependencies, dependency)
+		}
+	}
+
+	return dependencies, nil
+}
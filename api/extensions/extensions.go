@@ -0,0 +1,247 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package extensions provides typed access to the data of record extensions.
+// Extensions are the v0.3.1 record extensions and their 0.7.0 equivalent, record modules.
+// Their data can be validated against JSON schemas registered per extension name and version.
+// Extensions without a registered schema are allowed, unless validated in strict mode.
+package extensions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/api/labels"
+	"github.com/xeipuuv/gojsonschema"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var (
+	// ErrInvalidExtension is reported for extensions whose data does not match their registered schema.
+	ErrInvalidExtension = errors.New("invalid extension data")
+
+	// ErrUnknownExtension is reported in strict mode for extensions without a registered schema.
+	ErrUnknownExtension = errors.New("unknown extension")
+)
+
+// Extension is an extension of a v0.3.1 record or a module of a 0.7.0 record.
+type Extension struct {
+	// Name of the extension, e.g. "schema.oasf.agntcy.org/features/runtime/framework" or "runtime/framework".
+	Name string
+
+	// Version of the extension. Modules are not versioned.
+	Version string
+
+	// Data attached to the extension.
+	Data *structpb.Struct
+
+	// Path locates the extension in the record, e.g. "extensions[0]".
+	Path string
+}
+
+// FromRecord returns the extensions of a record.
+func FromRecord(record *corev1.Record) ([]*Extension, error) {
+	decoded, err := record.Decode()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	var extensions []*Extension
+
+	switch {
+	case decoded.HasV1Alpha0():
+		for i, extension := range decoded.GetV1Alpha0().GetExtensions() {
+			extensions = append(extensions, &Extension{
+				Name:    extension.GetName(),
+				Version: extension.GetVersion(),
+				Data:    extension.GetData(),
+				Path:    fmt.Sprintf("extensions[%d]", i),
+			})
+		}
+
+	case decoded.HasV1Alpha1():
+		for i, module := range decoded.GetV1Alpha1().GetModules() {
+			extensions = append(extensions, &Extension{
+				Name: module.GetName(),
+				Data: module.GetData(),
+				Path: fmt.Sprintf("modules[%d]", i),
+			})
+		}
+
+	default:
+		return nil, errors.New("unsupported record schema")
+	}
+
+	return extensions, nil
+}
+
+type schemaKey struct {
+	name    string
+	version string
+}
+
+var (
+	mu      sync.RWMutex
+	schemas = map[schemaKey]*gojsonschema.Schema{}
+)
+
+// oasfExtensionPrefix is the prefix of OASF extension names that 0.7.0 module names omit.
+const oasfExtensionPrefix = "schema.oasf.agntcy.org/"
+
+// canonicalName maps v0.3.1 extension names to their 0.7.0 module names,
+// e.g. "schema.oasf.agntcy.org/core/dependencies" to "core/dependencies", so that a schema applies to both.
+func canonicalName(name string) string {
+	name = strings.TrimPrefix(strings.TrimSpace(name), labels.FeaturesExtensionPrefix)
+
+	return strings.TrimPrefix(name, oasfExtensionPrefix)
+}
+
+// RegisterExtensionSchema registers the JSON schema of the data of an extension.
+// Feature extensions can be registered by either their v0.3.1 name or their 0.7.0 module name.
+// Schemas registered with an empty version apply to all versions without a schema of their own.
+// Registering a schema again replaces it.
+func RegisterExtensionSchema(name, version string, schema []byte) error {
+	if canonicalName(name) == "" {
+		return errors.New("extension name is required")
+	}
+
+	compiled, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schema))
+	if err != nil {
+		return fmt.Errorf("invalid schema for extension %s: %w", name, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	schemas[schemaKey{name: canonicalName(name), version: version}] = compiled
+
+	return nil
+}
+
+// lookupSchema returns the schema registered for the extension version, or for all of its versions.
+func lookupSchema(name, version string) (*gojsonschema.Schema, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if schema, ok := schemas[schemaKey{name: canonicalName(name), version: version}]; ok {
+		return schema, true
+	}
+
+	schema, ok := schemas[schemaKey{name: canonicalName(name)}]
+
+	return schema, ok
+}
+
+// Validate checks the data of the extension against its registered schema.
+// Extensions without a registered schema are reported with ErrUnknownExtension.
+func Validate(ext *Extension) error {
+	schema, ok := lookupSchema(ext.Name, ext.Version)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownExtension, ext.Name)
+	}
+
+	data, err := marshalData(ext)
+	if err != nil {
+		return err
+	}
+
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return fmt.Errorf("failed to validate extension %s: %w", ext.Name, err)
+	}
+
+	if !result.Valid() {
+		violations := make([]string, 0, len(result.Errors()))
+		for _, violation := range result.Errors() {
+			violations = append(violations, violation.String())
+		}
+
+		return fmt.Errorf("%w: %s: %s", ErrInvalidExtension, ext.Name, strings.Join(violations, "; "))
+	}
+
+	return nil
+}
+
+// ValidateOption configures the validation of record extensions.
+type ValidateOption func(*validateOptions)
+
+type validateOptions struct {
+	strict bool
+}
+
+// Strict rejects extensions without a registered schema.
+func Strict() ValidateOption {
+	return func(opts *validateOptions) {
+		opts.strict = true
+	}
+}
+
+// ValidateExtensions checks the data of all extensions of the record against their registered schemas.
+// Extensions without a registered schema are allowed, unless validated in strict mode.
+// Errors are reported per extension, prefixed with its path in the record.
+func ValidateExtensions(record *corev1.Record, opts ...ValidateOption) []error {
+	options := &validateOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	extensions, err := FromRecord(record)
+	if err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+
+	for _, ext := range extensions {
+		err := Validate(ext)
+		if errors.Is(err, ErrUnknownExtension) && !options.strict {
+			continue
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ext.Path, err))
+		}
+	}
+
+	return errs
+}
+
+// DecodeExtension decodes the data of the extension into T, e.g. a struct with JSON tags.
+// If a schema is registered for the extension, the data is validated against it first.
+func DecodeExtension[T any](ext *Extension) (T, error) {
+	var decoded T
+
+	if err := Validate(ext); err != nil && !errors.Is(err, ErrUnknownExtension) {
+		return decoded, err
+	}
+
+	data, err := marshalData(ext)
+	if err != nil {
+		return decoded, err
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return decoded, fmt.Errorf("failed to decode extension %s: %w", ext.Name, err)
+	}
+
+	return decoded, nil
+}
+
+// marshalData returns the data of the extension as JSON, an empty object if it has none.
+func marshalData(ext *Extension) ([]byte, error) {
+	if ext.Data == nil {
+		return []byte("{}"), nil
+	}
+
+	data, err := protojson.Marshal(ext.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data of extension %s: %w", ext.Name, err)
+	}
+
+	return data, nil
+}
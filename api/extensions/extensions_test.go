@@ -0,0 +1,154 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package extensions_test
+
+import (
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/api/extensions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeExtension(t *testing.T) {
+	record, err := corev1.UnmarshalRecord([]byte(`{
+		"name": "extension-agent",
+		"version": "v1.0.0",
+		"schema_version": "v0.3.1",
+		"extensions": [
+			{"name": "schema.oasf.agntcy.org/features/runtime/framework", "version": "v0.0.0", "data": {"name": "crewai", "version": "0.55.2"}},
+			{"name": "license", "version": "v1.0.0", "data": {"license": "Apache-2.0"}}
+		]
+	}`))
+	require.NoError(t, err)
+
+	exts, err := extensions.FromRecord(record)
+	require.NoError(t, err)
+	require.Len(t, exts, 2)
+
+	framework, err := extensions.DecodeExtension[extensions.Framework](exts[0])
+	require.NoError(t, err)
+	assert.Equal(t, extensions.Framework{Name: "crewai", Version: "0.55.2"}, framework)
+
+	license, err := extensions.DecodeExtension[extensions.License](exts[1])
+	require.NoError(t, err)
+	assert.Equal(t, "Apache-2.0", license.License)
+
+	assert.Empty(t, extensions.ValidateExtensions(record, extensions.Strict()))
+}
+
+func TestValidateExtensionsSchemaViolation(t *testing.T) {
+	record, err := corev1.UnmarshalRecord([]byte(`{
+		"name": "extension-agent",
+		"version": "v1.0.0",
+		"schema_version": "0.7.0",
+		"modules": [
+			{"name": "runtime/language", "data": {"version": ">=3.11"}},
+			{"name": "runtime/framework", "data": {"name": 42}}
+		]
+	}`))
+	require.NoError(t, err)
+
+	errs := extensions.ValidateExtensions(record)
+	require.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0], extensions.ErrInvalidExtension)
+	assert.Contains(t, errs[0].Error(), "modules[0]")
+	assert.Contains(t, errs[1].Error(), "modules[1]")
+
+	exts, err := extensions.FromRecord(record)
+	require.NoError(t, err)
+
+	_, err = extensions.DecodeExtension[extensions.Language](exts[0])
+	assert.ErrorIs(t, err, extensions.ErrInvalidExtension)
+}
+
+func TestValidateExtensionsUnknown(t *testing.T) {
+	record, err := corev1.UnmarshalRecord([]byte(`{
+		"name": "extension-agent",
+		"version": "v1.0.0",
+		"schema_version": "0.7.0",
+		"modules": [
+			{"name": "custom/unknown", "data": {"anything": ["goes"]}}
+		]
+	}`))
+	require.NoError(t, err)
+
+	assert.Empty(t, extensions.ValidateExtensions(record), "unknown extensions are allowed")
+
+	errs := extensions.ValidateExtensions(record, extensions.Strict())
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], extensions.ErrUnknownExtension)
+
+	exts, err := extensions.FromRecord(record)
+	require.NoError(t, err)
+
+	decoded, err := extensions.DecodeExtension[map[string]any](exts[0])
+	require.NoError(t, err)
+	assert.Equal(t, []any{"goes"}, decoded["anything"])
+}
+
+func TestRegisterExtensionSchema(t *testing.T) {
+	require.NoError(t, extensions.RegisterExtensionSchema("custom/versioned", "v2.0.0", []byte(`{
+		"type": "object",
+		"required": ["endpoint"]
+	}`)))
+
+	_, err := extensions.DecodeExtension[map[string]any](&extensions.Extension{Name: "custom/versioned", Version: "v2.0.0"})
+	require.ErrorIs(t, err, extensions.ErrInvalidExtension)
+
+	// Other versions have no schema
+	err = extensions.Validate(&extensions.Extension{Name: "custom/versioned", Version: "v1.0.0"})
+	require.ErrorIs(t, err, extensions.ErrUnknownExtension)
+
+	require.Error(t, extensions.RegisterExtensionSchema("custom/invalid", "", []byte(`{"type": 1}`)))
+}
+
+func TestRecordDependencies(t *testing.T) {
+	record, err := corev1.UnmarshalRecord([]byte(`{
+		"name": "orchestrator",
+		"version": "v1.0.0",
+		"schema_version": "v0.3.1",
+		"extensions": [
+			{"name": "schema.oasf.agntcy.org/core/dependencies", "version": "v0.0.0", "data": {"dependencies": [
+				{"cid": "baeareig77vqcdozl2wyk6z3cscaj5q5fggi53aoh64fewkdiri3cdauyn4"},
+				{"name": "search-tool", "version": "^1.2"}
+			]}}
+		]
+	}`))
+	require.NoError(t, err)
+
+	dependencies, err := extensions.RecordDependencies(record)
+	require.NoError(t, err)
+	require.Len(t, dependencies, 2)
+	assert.Equal(t, "baeareig77vqcdozl2wyk6z3cscaj5q5fggi53aoh64fewkdiri3cdauyn4", dependencies[0].String())
+	assert.Equal(t, "search-tool@^1.2", dependencies[1].String())
+
+	// 0.7.0 records declare dependencies as a module
+	module, err := corev1.UnmarshalRecord([]byte(`{
+		"name": "orchestrator",
+		"version": "v1.0.0",
+		"schema_version": "0.7.0",
+		"modules": [
+			{"name": "core/dependencies", "data": {"dependencies": [{"name": "search-tool", "version": ">=2.0.0 <"}]}}
+		]
+	}`))
+	require.NoError(t, err)
+
+	_, err = extensions.RecordDependencies(module)
+	require.ErrorIs(t, err, extensions.ErrInvalidExtension)
+	assert.Contains(t, err.Error(), "modules[0]: dependencies[0]")
+
+	assert.Len(t, extensions.ValidateExtensions(module, extensions.Strict()), 0)
+
+	invalid, err := corev1.UnmarshalRecord([]byte(`{
+		"name": "orchestrator",
+		"version": "v1.0.0",
+		"schema_version": "0.7.0",
+		"modules": [{"name": "core/dependencies", "data": {"dependencies": [{"version": "1.0.0"}]}}]
+	}`))
+	require.NoError(t, err)
+
+	assert.Len(t, extensions.ValidateExtensions(invalid), 1)
+}
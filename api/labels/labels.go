@@ -0,0 +1,245 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package labels derives the discovery labels of records.
+// The rules are shared by the server, which announces the labels when a record is published,
+// and by dirctl, which shows the labels a record would be announced with.
+package labels
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+)
+
+// Type is the category of a label, which is also its namespace, e.g. "/skills/".
+type Type string
+
+const (
+	TypeSkill   Type = "skills"
+	TypeDomain  Type = "domains"
+	TypeModule  Type = "modules"
+	TypeLocator Type = "locators"
+)
+
+// types lists the label types in the order labels are reported.
+var types = []Type{TypeSkill, TypeDomain, TypeModule, TypeLocator}
+
+// Prefix returns the namespace of the label type, e.g. "/skills/".
+func (t Type) Prefix() string {
+	return "/" + string(t) + "/"
+}
+
+// ParseType converts a string to a label type if valid.
+func ParseType(s string) (Type, bool) {
+	t := Type(s)
+
+	return t, slices.Contains(types, t)
+}
+
+// Default extension name prefixes of OASF v0.3.1 records.
+const (
+	SkillsExtensionPrefix   = "schema.oasf.agntcy.org/skills/"
+	DomainsExtensionPrefix  = "schema.oasf.agntcy.org/domains/"
+	FeaturesExtensionPrefix = "schema.oasf.agntcy.org/features/"
+)
+
+// defaultExtensionPrefixes map extension name prefixes to label types.
+// Features are labelled as modules, their 0.7.0 equivalent.
+var defaultExtensionPrefixes = map[string]Type{
+	SkillsExtensionPrefix:   TypeSkill,
+	DomainsExtensionPrefix:  TypeDomain,
+	FeaturesExtensionPrefix: TypeModule,
+}
+
+// Field is a record field a label is derived from.
+type Field struct {
+	// Type is the label type of the field.
+	// Module fields are mapped to a label type by their extension name prefix.
+	Type Type
+
+	// Path locates the field in the record, e.g. "skills[0]".
+	Path string
+
+	// Value is the raw field value, e.g. a skill or module name.
+	Value string
+}
+
+// Label is a discovery label together with the record field it was derived from.
+type Label struct {
+	Type  Type
+	Value string
+
+	// Source is the field the label was derived from.
+	// Duplicate labels keep the source of their first occurrence.
+	Source Field
+}
+
+// String returns the namespaced label, e.g. "/skills/<name>".
+func (l Label) String() string {
+	return l.Type.Prefix() + l.Value
+}
+
+type extensionPrefix struct {
+	prefix    string
+	labelType Type
+}
+
+// Extractor derives labels from record fields.
+type Extractor struct {
+	// prefixes are sorted longest first, so the most specific prefix wins.
+	prefixes []extensionPrefix
+}
+
+// NewExtractor creates an extractor with the default extension prefixes
+// and the additional mappings from extension name prefixes to label types,
+// e.g. "example.com/domains/" -> "domains".
+func NewExtractor(extensionPrefixes map[string]string) (*Extractor, error) {
+	mappings := maps.Clone(defaultExtensionPrefixes)
+
+	for prefix, name := range extensionPrefixes {
+		labelType, ok := ParseType(name)
+		if !ok {
+			return nil, fmt.Errorf("invalid label type %q for extension prefix %q", name, prefix)
+		}
+
+		if strings.Trim(prefix, "/ ") == "" {
+			return nil, fmt.Errorf("invalid extension prefix %q", prefix)
+		}
+
+		mappings[prefix] = labelType
+	}
+
+	e := &Extractor{}
+	for prefix, labelType := range mappings {
+		e.prefixes = append(e.prefixes, extensionPrefix{prefix: prefix, labelType: labelType})
+	}
+
+	slices.SortFunc(e.prefixes, func(a, b extensionPrefix) int {
+		return cmp.Or(cmp.Compare(len(b.prefix), len(a.prefix)), strings.Compare(a.prefix, b.prefix))
+	})
+
+	return e, nil
+}
+
+// Extract returns the labels derived from the fields.
+// Labels are grouped by type in the order skills, domains, modules and locators,
+// and deduplicated keeping record order. Empty values yield no label.
+func (e *Extractor) Extract(fields []Field) []Label {
+	grouped := make(map[Type][]Label)
+
+	for _, field := range fields {
+		labelType, value := field.Type, normalize(field.Value)
+		if labelType == TypeModule {
+			labelType, value = e.ParseExtension(field.Value)
+		}
+
+		if value == "" || slices.ContainsFunc(grouped[labelType], func(l Label) bool { return l.Value == value }) {
+			continue
+		}
+
+		grouped[labelType] = append(grouped[labelType], Label{Type: labelType, Value: value, Source: field})
+	}
+
+	var result []Label
+	for _, labelType := range types {
+		result = append(result, grouped[labelType]...)
+	}
+
+	return result
+}
+
+// ExtractRecord returns the labels of the record, see Extract.
+func (e *Extractor) ExtractRecord(record *corev1.Record) ([]Label, error) {
+	fields, err := RecordFields(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Extract(fields), nil
+}
+
+// ParseExtension returns the label type and value of an extension or module name.
+// Names matching a known prefix are labelled with the rest of the name,
+// other names are labelled as modules. Malformed names yield an empty value.
+func (e *Extractor) ParseExtension(name string) (Type, string) {
+	name = strings.TrimSpace(name)
+
+	for _, p := range e.prefixes {
+		if value, ok := strings.CutPrefix(name, p.prefix); ok {
+			return p.labelType, normalize(value)
+		}
+	}
+
+	return TypeModule, normalize(name)
+}
+
+// RecordFields returns the fields of the record that labels are derived from.
+func RecordFields(record *corev1.Record) ([]Field, error) {
+	decoded, err := record.Decode()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	var fields []Field
+
+	add := func(labelType Type, path string, index int, value string) {
+		fields = append(fields, Field{Type: labelType, Path: fmt.Sprintf("%s[%d]", path, index), Value: value})
+	}
+
+	switch {
+	case decoded.HasV1Alpha0():
+		data := decoded.GetV1Alpha0()
+
+		for i, skill := range data.GetSkills() {
+			name := skill.GetCategoryName()
+			if skill.GetClassName() != "" {
+				name += "/" + skill.GetClassName()
+			}
+
+			add(TypeSkill, "skills", i, name)
+		}
+
+		for i, extension := range data.GetExtensions() {
+			add(TypeModule, "extensions", i, extension.GetName())
+		}
+
+		for i, locator := range data.GetLocators() {
+			add(TypeLocator, "locators", i, locator.GetType())
+		}
+
+	case decoded.HasV1Alpha1():
+		data := decoded.GetV1Alpha1()
+
+		for i, skill := range data.GetSkills() {
+			add(TypeSkill, "skills", i, skill.GetName())
+		}
+
+		for i, domain := range data.GetDomains() {
+			add(TypeDomain, "domains", i, domain.GetName())
+		}
+
+		for i, module := range data.GetModules() {
+			add(TypeModule, "modules", i, module.GetName())
+		}
+
+		for i, locator := range data.GetLocators() {
+			add(TypeLocator, "locators", i, locator.GetType())
+		}
+
+	default:
+		return nil, errors.New("unsupported record schema")
+	}
+
+	return fields, nil
+}
+
+// normalize trims surrounding whitespace and slashes from a label value.
+func normalize(value string) string {
+	return strings.Trim(strings.TrimSpace(value), "/")
+}
@@ -0,0 +1,128 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package labels_test
+
+import (
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/api/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractRecord(t *testing.T) {
+	extractor, err := labels.NewExtractor(nil)
+	require.NoError(t, err)
+
+	t.Run("v0.3.1 record", func(t *testing.T) {
+		record, err := corev1.UnmarshalRecord([]byte(`{
+			"name": "test-agent",
+			"version": "1.0.0",
+			"schema_version": "v0.3.1",
+			"skills": [
+				{"category_name": "nlp", "class_name": "text_completion"},
+				{"category_name": "nlp", "class_name": "text_completion"}
+			],
+			"locators": [{"type": "docker-image", "url": "https://example.com/a"}],
+			"extensions": [
+				{"name": "schema.oasf.agntcy.org/features/runtime/framework", "version": "v0.0.0"},
+				{"name": "schema.oasf.agntcy.org/skills/nlp/summarization", "version": "v0.0.0"},
+				{"name": "schema.oasf.agntcy.org/domains/", "version": "v0.0.0"}
+			]
+		}`))
+		require.NoError(t, err)
+
+		recordLabels, err := extractor.ExtractRecord(record)
+		require.NoError(t, err)
+
+		// Duplicates keep their first source, malformed names are dropped
+		assert.Equal(t, []labels.Label{
+			{
+				Type:   labels.TypeSkill,
+				Value:  "nlp/text_completion",
+				Source: labels.Field{Type: labels.TypeSkill, Path: "skills[0]", Value: "nlp/text_completion"},
+			},
+			{
+				Type:   labels.TypeSkill,
+				Value:  "nlp/summarization",
+				Source: labels.Field{Type: labels.TypeModule, Path: "extensions[1]", Value: "schema.oasf.agntcy.org/skills/nlp/summarization"},
+			},
+			{
+				Type:   labels.TypeModule,
+				Value:  "runtime/framework",
+				Source: labels.Field{Type: labels.TypeModule, Path: "extensions[0]", Value: "schema.oasf.agntcy.org/features/runtime/framework"},
+			},
+			{
+				Type:   labels.TypeLocator,
+				Value:  "docker-image",
+				Source: labels.Field{Type: labels.TypeLocator, Path: "locators[0]", Value: "docker-image"},
+			},
+		}, recordLabels)
+	})
+
+	t.Run("0.7.0 record", func(t *testing.T) {
+		record, err := corev1.UnmarshalRecord([]byte(`{
+			"name": "test-agent",
+			"version": "1.0.0",
+			"schema_version": "0.7.0",
+			"skills": [{"name": "natural_language_processing/summarization", "id": 10202}],
+			"domains": [{"name": "/healthcare/medical_technology/", "id": 901}],
+			"modules": [{"name": "integration/mcp"}],
+			"locators": [{"type": "source_code", "url": "https://example.com/a"}]
+		}`))
+		require.NoError(t, err)
+
+		recordLabels, err := extractor.ExtractRecord(record)
+		require.NoError(t, err)
+
+		var names, sources []string
+		for _, label := range recordLabels {
+			names = append(names, label.String())
+			sources = append(sources, label.Source.Path)
+		}
+
+		assert.Equal(t, []string{
+			"/skills/natural_language_processing/summarization",
+			"/domains/healthcare/medical_technology",
+			"/modules/integration/mcp",
+			"/locators/source_code",
+		}, names)
+		assert.Equal(t, []string{"skills[0]", "domains[0]", "modules[0]", "locators[0]"}, sources)
+	})
+
+	t.Run("invalid record", func(t *testing.T) {
+		_, err := extractor.ExtractRecord(&corev1.Record{})
+		require.Error(t, err)
+	})
+}
+
+func TestNewExtractor(t *testing.T) {
+	t.Run("custom prefixes", func(t *testing.T) {
+		extractor, err := labels.NewExtractor(map[string]string{
+			"example.com/domains/":         "domains",
+			"example.com/domains/finance/": "skills",
+		})
+		require.NoError(t, err)
+
+		// The most specific prefix wins
+		labelType, value := extractor.ParseExtension("example.com/domains/finance/banking")
+		assert.Equal(t, labels.TypeSkill, labelType)
+		assert.Equal(t, "banking", value)
+
+		labelType, value = extractor.ParseExtension("example.com/domains/finance")
+		assert.Equal(t, labels.TypeDomain, labelType)
+		assert.Equal(t, "finance", value)
+	})
+
+	t.Run("invalid label type", func(t *testing.T) {
+		_, err := labels.NewExtractor(map[string]string{"example.com/domains/": "unknown"})
+		require.Error(t, err)
+	})
+
+	t.Run("empty prefix", func(t *testing.T) {
+		_, err := labels.NewExtractor(map[string]string{"/": "domains"})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,165 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lint checks records against advisory rules, such as records without locators
+// or with versions that are not semantic versions. Unlike validation, lint findings do not
+// make a record invalid, unless the severity of a rule is escalated to error.
+// The rules are shared by dirctl, which lints record files and stored records,
+// and by the server, which lints pushed records if enabled.
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+)
+
+// Severity is the severity of a lint finding.
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// severities lists the severities from the lowest to the highest.
+var severities = []Severity{SeverityInfo, SeverityWarning, SeverityError}
+
+// ParseSeverity converts a string to a severity if valid.
+func ParseSeverity(s string) (Severity, error) {
+	severity := Severity(s)
+	if !slices.Contains(severities, severity) {
+		return "", fmt.Errorf("invalid severity %q: expected %q, %q or %q", s, SeverityInfo, SeverityWarning, SeverityError)
+	}
+
+	return severity, nil
+}
+
+// AtLeast reports whether the severity is at least as high as the other severity.
+// The empty severity, e.g. the MaxSeverity of no findings, is lower than all severities.
+func (s Severity) AtLeast(other Severity) bool {
+	return slices.Index(severities, s) >= slices.Index(severities, other)
+}
+
+// Finding is a finding of a lint rule.
+type Finding struct {
+	// Severity of the finding.
+	Severity Severity `json:"severity"`
+	// RuleID is the ID of the rule that reported the finding.
+	RuleID string `json:"rule_id"`
+	// Path locates the field the finding is about, e.g. "modules[0]", or is empty for the whole record.
+	Path string `json:"path,omitempty"`
+	// Message describes the finding.
+	Message string `json:"message"`
+}
+
+// String returns the finding in the form "<severity> <path>: <message> (<rule-id>)".
+func (f Finding) String() string {
+	location := f.Path
+	if location == "" {
+		location = "record"
+	}
+
+	return fmt.Sprintf("%s %s: %s (%s)", f.Severity, location, f.Message, f.RuleID)
+}
+
+// MaxSeverity returns the highest severity of the findings, or the empty severity if there are none.
+func MaxSeverity(findings []Finding) Severity {
+	var maxSeverity Severity
+
+	for _, finding := range findings {
+		if finding.Severity.AtLeast(maxSeverity) {
+			maxSeverity = finding.Severity
+		}
+	}
+
+	return maxSeverity
+}
+
+// Rule is a lint rule.
+type Rule interface {
+	// ID identifies the rule in findings and configuration, e.g. "no-locators".
+	ID() string
+
+	// Severity is the default severity of the findings of the rule.
+	Severity() Severity
+
+	// Check returns the findings of the rule for the record, with the default severity.
+	Check(record *corev1.Record) []Finding
+}
+
+// Config enables, disables and escalates lint rules.
+type Config struct {
+	// Disabled lists the IDs of the rules that are not run.
+	Disabled []string `json:"disabled,omitempty" mapstructure:"disabled"`
+
+	// Severities overrides the severities of the findings of rules by rule ID,
+	// e.g. "no-locators: error" to reject pushes of records without locators.
+	Severities map[string]Severity `json:"severities,omitempty" mapstructure:"severities"`
+}
+
+// Validate checks that the configuration only refers to the rules and to valid severities.
+func (c Config) Validate(rules []Rule) error {
+	known := func(id string) bool {
+		return slices.ContainsFunc(rules, func(rule Rule) bool { return rule.ID() == id })
+	}
+
+	var errs []error
+
+	for _, id := range c.Disabled {
+		if !known(id) {
+			errs = append(errs, fmt.Errorf("unknown lint rule %q", id))
+		}
+	}
+
+	for id, severity := range c.Severities {
+		if !known(id) {
+			errs = append(errs, fmt.Errorf("unknown lint rule %q", id))
+		}
+
+		if _, err := ParseSeverity(string(severity)); err != nil {
+			errs = append(errs, fmt.Errorf("lint rule %q: %w", id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run checks the record with the default rules, see DefaultRules.
+func Run(record *corev1.Record, cfg Config) ([]Finding, error) {
+	rules := DefaultRules()
+
+	if err := cfg.Validate(rules); err != nil {
+		return nil, err
+	}
+
+	return RunRules(record, rules, cfg), nil
+}
+
+// RunRules checks the record with the rules that are not disabled by the configuration,
+// returning their findings in rule order with the severities of the configuration.
+func RunRules(record *corev1.Record, rules []Rule, cfg Config) []Finding {
+	var findings []Finding
+
+	for _, rule := range rules {
+		if slices.Contains(cfg.Disabled, rule.ID()) {
+			continue
+		}
+
+		for _, finding := range rule.Check(record) {
+			finding.RuleID = rule.ID()
+			finding.Severity = rule.Severity()
+
+			if severity, ok := cfg.Severities[rule.ID()]; ok {
+				finding.Severity = severity
+			}
+
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,171 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package lint_test
+
+import (
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/api/lint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cleanRecord is a 0.7.0 record without findings.
+const cleanRecord = `{
+	"name": "test-agent",
+	"version": "1.0.0",
+	"schema_version": "0.7.0",
+	"description": "Summarizes medical reports for clinicians",
+	"skills": [{"name": "natural_language_processing/summarization", "id": 10202}],
+	"locators": [{"type": "docker_image", "url": "ghcr.io/example/agent:1.0.0"}],
+	"modules": [{"name": "integration/mcp"}],
+	"annotations": {"example.com/team": "health", "previous_cid": "bafy"}
+}`
+
+func mustRecord(t *testing.T, data string) *corev1.Record {
+	t.Helper()
+
+	record, err := corev1.UnmarshalRecord([]byte(data))
+	require.NoError(t, err)
+
+	return record
+}
+
+func ruleIDs(findings []lint.Finding) []string {
+	ids := []string{}
+	for _, finding := range findings {
+		ids = append(ids, finding.RuleID)
+	}
+
+	return ids
+}
+
+func TestRules(t *testing.T) {
+	t.Run("clean record", func(t *testing.T) {
+		findings, err := lint.Run(mustRecord(t, cleanRecord), lint.Config{})
+		require.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("0.7.0 record", func(t *testing.T) {
+		findings, err := lint.Run(mustRecord(t, `{
+			"name": "test-agent",
+			"version": "latest",
+			"schema_version": "0.7.0",
+			"description": "An agent",
+			"modules": [
+				{"name": "integration/mcp"},
+				{"name": "schema.oasf.agntcy.org/features/runtime/framework"}
+			],
+			"annotations": {"team": "health", "example.com/owner": "alice"}
+		}`), lint.Config{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []lint.Finding{
+			{
+				Severity: lint.SeverityWarning, RuleID: lint.RuleDescriptionTooShort, Path: "description",
+				Message: "description has 8 characters, describe the record in at least 20",
+			},
+			{
+				Severity: lint.SeverityWarning, RuleID: lint.RuleNoLocators, Path: "locators",
+				Message: "no locators declared, the record does not point to its deployment",
+			},
+			{
+				Severity: lint.SeverityWarning, RuleID: lint.RuleNoSkills, Path: "skills",
+				Message: "skill list is empty, the record cannot be discovered by skill",
+			},
+			{
+				Severity: lint.SeverityWarning, RuleID: lint.RuleVersionNotSemver, Path: "version",
+				Message: `version "latest" is not a semantic version`,
+			},
+			{
+				Severity: lint.SeverityWarning, RuleID: lint.RuleDeprecatedExtensionPrefix, Path: "modules[1]",
+				Message: `module "schema.oasf.agntcy.org/features/runtime/framework" uses the deprecated v0.3.1 extension prefix, name it "runtime/framework"`,
+			},
+			{
+				Severity: lint.SeverityInfo, RuleID: lint.RuleAnnotationNotNamespaced, Path: `annotations["team"]`,
+				Message: `annotation key "team" is not namespaced, use a key like "example.com/team"`,
+			},
+		}, findings)
+	})
+
+	t.Run("v0.3.1 record", func(t *testing.T) {
+		findings, err := lint.Run(mustRecord(t, `{
+			"name": "test-agent",
+			"version": "v1",
+			"schema_version": "v0.3.1",
+			"description": "Too short",
+			"skills": [{"category_name": "nlp", "class_name": "text_completion"}],
+			"extensions": [{"name": "schema.oasf.agntcy.org/features/runtime/framework", "version": "v0.0.0"}],
+			"annotations": {"team": "health"}
+		}`), lint.Config{})
+		require.NoError(t, err)
+
+		// Extension prefixes are the naming scheme of v0.3.1 records
+		assert.Equal(t, []string{lint.RuleDescriptionTooShort, lint.RuleNoLocators, lint.RuleAnnotationNotNamespaced}, ruleIDs(findings))
+	})
+
+	t.Run("encrypted record", func(t *testing.T) {
+		record := &corev1.Record{Envelope: &corev1.RecordEnvelope{
+			Metadata: &corev1.EnvelopeMetadata{
+				Name:        "test-agent",
+				Version:     "nightly",
+				Annotations: map[string]string{"team": "health"},
+			},
+		}}
+
+		findings, err := lint.Run(record, lint.Config{})
+		require.NoError(t, err)
+
+		// Only the envelope metadata can be checked
+		assert.Equal(t, []string{lint.RuleVersionNotSemver, lint.RuleAnnotationNotNamespaced}, ruleIDs(findings))
+	})
+}
+
+func TestConfig(t *testing.T) {
+	record := mustRecord(t, `{
+		"name": "test-agent",
+		"version": "1.0.0",
+		"schema_version": "0.7.0",
+		"description": "Summarizes medical reports for clinicians",
+		"skills": [{"name": "natural_language_processing/summarization", "id": 10202}],
+		"annotations": {"team": "health"}
+	}`)
+
+	t.Run("escalation", func(t *testing.T) {
+		findings, err := lint.Run(record, lint.Config{Severities: map[string]lint.Severity{lint.RuleNoLocators: lint.SeverityError}})
+		require.NoError(t, err)
+
+		require.Len(t, findings, 2)
+		assert.Equal(t, lint.SeverityError, findings[0].Severity)
+		assert.Equal(t, lint.SeverityInfo, findings[1].Severity)
+		assert.Equal(t, lint.SeverityError, lint.MaxSeverity(findings))
+	})
+
+	t.Run("disabled rules", func(t *testing.T) {
+		findings, err := lint.Run(record, lint.Config{Disabled: []string{lint.RuleNoLocators}})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{lint.RuleAnnotationNotNamespaced}, ruleIDs(findings))
+		assert.Equal(t, lint.SeverityInfo, lint.MaxSeverity(findings))
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := lint.Run(record, lint.Config{Disabled: []string{"no-such-rule"}})
+		require.ErrorContains(t, err, `unknown lint rule "no-such-rule"`)
+
+		_, err = lint.Run(record, lint.Config{Severities: map[string]lint.Severity{lint.RuleNoSkills: "fatal"}})
+		require.ErrorContains(t, err, `invalid severity "fatal"`)
+	})
+}
+
+func TestMaxSeverity(t *testing.T) {
+	assert.Equal(t, lint.Severity(""), lint.MaxSeverity(nil))
+	assert.Equal(t, lint.SeverityWarning, lint.MaxSeverity([]lint.Finding{
+		{Severity: lint.SeverityInfo}, {Severity: lint.SeverityWarning}, {Severity: lint.SeverityInfo},
+	}))
+	assert.True(t, lint.SeverityError.AtLeast(lint.SeverityWarning))
+	assert.False(t, lint.SeverityInfo.AtLeast(lint.SeverityWarning))
+}
@@ -0,0 +1,219 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+)
+
+// IDs of the built-in rules.
+const (
+	RuleDescriptionTooShort       = "description-too-short"
+	RuleNoLocators                = "no-locators"
+	RuleNoSkills                  = "no-skills"
+	RuleVersionNotSemver          = "version-not-semver"
+	RuleDeprecatedExtensionPrefix = "deprecated-extension-prefix"
+	RuleAnnotationNotNamespaced   = "annotation-not-namespaced"
+)
+
+// MinDescriptionLength is the number of characters below which a description is reported as too short.
+const MinDescriptionLength = 20
+
+// DeprecatedModulePrefix is the name prefix of v0.3.1 extensions, which 0.7.0 modules should not use,
+// e.g. "schema.oasf.agntcy.org/features/runtime/framework" instead of "runtime/framework".
+const DeprecatedModulePrefix = "schema.oasf.agntcy.org/"
+
+// ReservedAnnotations are the annotation keys set by the directory itself, which are not namespaced.
+var ReservedAnnotations = []string{
+	corev1.AnnotationPreviousCid,
+	corev1.AnnotationMigratedFrom,
+	"protected",
+}
+
+// DefaultRules returns the built-in rules.
+func DefaultRules() []Rule {
+	return []Rule{
+		builtinRule{id: RuleDescriptionTooShort, severity: SeverityWarning, check: checkDescription},
+		builtinRule{id: RuleNoLocators, severity: SeverityWarning, check: checkLocators},
+		builtinRule{id: RuleNoSkills, severity: SeverityWarning, check: checkSkills},
+		builtinRule{id: RuleVersionNotSemver, severity: SeverityWarning, check: checkVersion},
+		builtinRule{id: RuleDeprecatedExtensionPrefix, severity: SeverityWarning, check: checkExtensionPrefixes},
+		builtinRule{id: RuleAnnotationNotNamespaced, severity: SeverityInfo, check: checkAnnotations},
+	}
+}
+
+// builtinRule is a rule checking the fields common to all record versions.
+type builtinRule struct {
+	id       string
+	severity Severity
+	check    func(fields *recordFields) []Finding
+}
+
+func (r builtinRule) ID() string {
+	return r.id
+}
+
+func (r builtinRule) Severity() Severity {
+	return r.severity
+}
+
+// Check reports nothing for records that cannot be decoded, which fail validation anyway.
+func (r builtinRule) Check(record *corev1.Record) []Finding {
+	fields, ok := readFields(record)
+	if !ok {
+		return nil
+	}
+
+	return r.check(fields)
+}
+
+// module is a module of a 0.7.0 record.
+type module struct {
+	name string
+	path string
+}
+
+// recordFields are the fields checked by the built-in rules.
+// Encrypted records only expose the fields of their envelope metadata.
+type recordFields struct {
+	encrypted   bool
+	version     string
+	description string
+	skills      int
+	locators    int
+	modules     []module
+	annotations map[string]string
+}
+
+// readFields reads the fields of v0.3.1 and 0.7.0 records, and of the envelope metadata of encrypted records.
+func readFields(record *corev1.Record) (*recordFields, bool) {
+	if envelope := record.GetEnvelope(); envelope != nil {
+		return &recordFields{
+			encrypted:   true,
+			version:     envelope.GetMetadata().GetVersion(),
+			annotations: envelope.GetMetadata().GetAnnotations(),
+		}, true
+	}
+
+	decoded, err := record.Decode()
+	if err != nil {
+		return nil, false
+	}
+
+	switch {
+	case decoded.HasV1Alpha0():
+		data := decoded.GetV1Alpha0()
+
+		return &recordFields{
+			version:     data.GetVersion(),
+			description: data.GetDescription(),
+			skills:      len(data.GetSkills()),
+			locators:    len(data.GetLocators()),
+			annotations: data.GetAnnotations(),
+		}, true
+
+	case decoded.HasV1Alpha1():
+		data := decoded.GetV1Alpha1()
+		fields := &recordFields{
+			version:     data.GetVersion(),
+			description: data.GetDescription(),
+			skills:      len(data.GetSkills()),
+			locators:    len(data.GetLocators()),
+			annotations: data.GetAnnotations(),
+		}
+
+		for i, m := range data.GetModules() {
+			fields.modules = append(fields.modules, module{name: m.GetName(), path: fmt.Sprintf("modules[%d]", i)})
+		}
+
+		return fields, true
+
+	default:
+		return nil, false
+	}
+}
+
+func checkDescription(fields *recordFields) []Finding {
+	if fields.encrypted {
+		return nil
+	}
+
+	if length := utf8.RuneCountInString(strings.TrimSpace(fields.description)); length < MinDescriptionLength {
+		return []Finding{{
+			Path:    "description",
+			Message: fmt.Sprintf("description has %d characters, describe the record in at least %d", length, MinDescriptionLength),
+		}}
+	}
+
+	return nil
+}
+
+func checkLocators(fields *recordFields) []Finding {
+	if fields.encrypted || fields.locators > 0 {
+		return nil
+	}
+
+	return []Finding{{Path: "locators", Message: "no locators declared, the record does not point to its deployment"}}
+}
+
+func checkSkills(fields *recordFields) []Finding {
+	if fields.encrypted || fields.skills > 0 {
+		return nil
+	}
+
+	return []Finding{{Path: "skills", Message: "skill list is empty, the record cannot be discovered by skill"}}
+}
+
+func checkVersion(fields *recordFields) []Finding {
+	if corev1.IsSemver(fields.version) {
+		return nil
+	}
+
+	return []Finding{{Path: "version", Message: fmt.Sprintf("version %q is not a semantic version", fields.version)}}
+}
+
+func checkExtensionPrefixes(fields *recordFields) []Finding {
+	var findings []Finding
+
+	for _, m := range fields.modules {
+		if rest, ok := strings.CutPrefix(m.name, DeprecatedModulePrefix); ok {
+			// Skill, domain and feature prefixes are followed by the module name
+			_, name, _ := strings.Cut(rest, "/")
+
+			findings = append(findings, Finding{
+				Path:    m.path,
+				Message: fmt.Sprintf("module %q uses the deprecated v0.3.1 extension prefix, name it %q", m.name, name),
+			})
+		}
+	}
+
+	return findings
+}
+
+func checkAnnotations(fields *recordFields) []Finding {
+	var findings []Finding
+
+	for _, key := range slices.Sorted(maps.Keys(fields.annotations)) {
+		if slices.Contains(ReservedAnnotations, key) {
+			continue
+		}
+
+		if prefix, name, ok := strings.Cut(key, "/"); ok && prefix != "" && name != "" {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Path:    fmt.Sprintf("annotations[%q]", key),
+			Message: fmt.Sprintf("annotation key %q is not namespaced, use a key like \"example.com/%s\"", key, key),
+		})
+	}
+
+	return findings
+}
@@ -0,0 +1,182 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package names defines the normalization policy of record names and versions.
+//
+// Names are compared case-insensitively and spaces, underscores and hyphens are equivalent,
+// so "My Agent", "my-agent" and "MY_AGENT" are the same name "my-agent". Discovery tags,
+// the search index and the name@version resolver all use the normal forms, while records
+// keep the name they were pushed with for display.
+package names
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MaxNameLength is the maximum length of a normalized record name.
+	MaxNameLength = 100
+
+	// MaxVersionLength is the maximum length of a normalized record version.
+	MaxVersionLength = 64
+)
+
+var (
+	// ErrInvalidName is returned for record names that cannot be normalized.
+	ErrInvalidName = errors.New("invalid record name")
+
+	// ErrInvalidVersion is returned for record versions that cannot be normalized.
+	ErrInvalidVersion = errors.New("invalid record version")
+)
+
+// versionPattern matches semantic versions with an optional "v" prefix and optional minor and patch numbers,
+// e.g. "v1", "1.2" or "v1.2.3-rc.1+build.5".
+var versionPattern = regexp.MustCompile(`^v?\d+(\.\d+){0,2}(-[0-9a-z-]+(\.[0-9a-z-]+)*)?(\+[0-9a-z-]+(\.[0-9a-z-]+)*)?$`)
+
+// NormalizeName returns the normal form of a record name: surrounding whitespace is removed,
+// letters are lowercased and spaces and underscores become hyphens.
+//
+// Names consist of ASCII letters, digits, ".", "-", "_", "/" and spaces, start and end with a letter
+// or digit and are at most MaxNameLength long. Errors wrap ErrInvalidName and name the offending character.
+func NormalizeName(name string) (string, error) {
+	trimmed := strings.TrimSpace(name)
+	if trimmed == "" {
+		return "", fmt.Errorf("%w: name is empty", ErrInvalidName)
+	}
+
+	var b strings.Builder
+
+	for _, r := range trimmed {
+		switch {
+		case isLowerAlnum(r), r == '.', r == '-', r == '/':
+			b.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			b.WriteRune(r + 'a' - 'A')
+		case r == ' ', r == '_':
+			b.WriteRune('-')
+		default:
+			return "", invalidCharacter(ErrInvalidName, name, r)
+		}
+	}
+
+	normalized := b.String()
+
+	if len(normalized) > MaxNameLength {
+		return "", fmt.Errorf("%w %q: longer than %d characters", ErrInvalidName, name, MaxNameLength)
+	}
+
+	for _, r := range []rune{rune(normalized[0]), rune(normalized[len(normalized)-1])} {
+		if !isLowerAlnum(r) {
+			return "", fmt.Errorf("%w %q: must start and end with a letter or digit, not %q", ErrInvalidName, name, r)
+		}
+	}
+
+	return normalized, nil
+}
+
+// NormalizeVersion returns the normal form of a record version: surrounding whitespace is removed
+// and letters are lowercased. Versions must be semantic versions with an optional "v" prefix,
+// optional minor and patch numbers, e.g. "v1" or "1.2.3-rc.1", and at most MaxVersionLength long.
+// Errors wrap ErrInvalidVersion and name the offending character, if any.
+func NormalizeVersion(version string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(version))
+	if normalized == "" {
+		return "", fmt.Errorf("%w: version is empty", ErrInvalidVersion)
+	}
+
+	for _, r := range normalized {
+		if !isLowerAlnum(r) && r != '.' && r != '-' && r != '+' {
+			return "", invalidCharacter(ErrInvalidVersion, version, r)
+		}
+	}
+
+	if len(normalized) > MaxVersionLength {
+		return "", fmt.Errorf("%w %q: longer than %d characters", ErrInvalidVersion, version, MaxVersionLength)
+	}
+
+	if !versionPattern.MatchString(normalized) {
+		return "", fmt.Errorf("%w %q: not a semantic version", ErrInvalidVersion, version)
+	}
+
+	return normalized, nil
+}
+
+// ValidateName checks that a record name is already in normal form, see NormalizeName.
+// The error names the first character that differs from the normal form.
+func ValidateName(name string) error {
+	normalized, err := NormalizeName(name)
+	if err != nil {
+		return err
+	}
+
+	return checkNormalForm(ErrInvalidName, name, normalized)
+}
+
+// ValidateVersion checks that a record version is already in normal form, see NormalizeVersion.
+// The error names the first character that differs from the normal form.
+func ValidateVersion(version string) error {
+	normalized, err := NormalizeVersion(version)
+	if err != nil {
+		return err
+	}
+
+	return checkNormalForm(ErrInvalidVersion, version, normalized)
+}
+
+// CanonicalName returns the normal form of a name, or the trimmed and lowercased name if it cannot
+// be normalized, e.g. for records pushed before names were normalized.
+func CanonicalName(name string) string {
+	if normalized, err := NormalizeName(name); err == nil {
+		return normalized
+	}
+
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// CanonicalVersion returns the normal form of a version, or the trimmed and lowercased version
+// if it cannot be normalized, e.g. for records pushed before versions were normalized.
+func CanonicalVersion(version string) string {
+	if normalized, err := NormalizeVersion(version); err == nil {
+		return normalized
+	}
+
+	return strings.ToLower(strings.TrimSpace(version))
+}
+
+// NormalizePattern normalizes a name search pattern like a name, keeping wildcards,
+// so that "My Agent*" matches the normalized names of "my-agent-v2" and "MY_AGENT_V3".
+func NormalizePattern(pattern string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '_' {
+			return '-'
+		}
+
+		return r
+	}, strings.ToLower(strings.TrimSpace(pattern)))
+}
+
+// checkNormalForm returns an error naming the first character of value that differs from its normal form.
+func checkNormalForm(errInvalid error, value, normalized string) error {
+	if value == normalized {
+		return nil
+	}
+
+	for i, r := range value {
+		if i >= len(normalized) || rune(normalized[i]) != r {
+			return fmt.Errorf("%w %q: character %q is not in normal form, use %q", errInvalid, value, r, normalized)
+		}
+	}
+
+	return fmt.Errorf("%w %q: not in normal form, use %q", errInvalid, value, normalized)
+}
+
+func invalidCharacter(errInvalid error, value string, r rune) error {
+	return fmt.Errorf("%w %q: character %q is not allowed", errInvalid, value, r)
+}
+
+func isLowerAlnum(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')
+}
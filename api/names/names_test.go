@@ -0,0 +1,156 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package names
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		err      string
+	}{
+		{name: "Already normalized", input: "my-agent", expected: "my-agent"},
+		{name: "Spaces", input: "My Agent", expected: "my-agent"},
+		{name: "Underscores", input: "MY_AGENT", expected: "my-agent"},
+		{name: "Surrounding whitespace", input: "  my-agent\t", expected: "my-agent"},
+		{name: "Path", input: "Org/My.Agent", expected: "org/my.agent"},
+		{name: "Empty", input: "  ", err: "name is empty"},
+		{name: "Invalid character", input: "my@agent", err: `character '@' is not allowed`},
+		{name: "Non-ASCII letter", input: "agënt", err: `character 'ë' is not allowed`},
+		{name: "Leading separator", input: "-agent", err: `must start and end with a letter or digit, not '-'`},
+		{name: "Trailing separator", input: "agent/", err: `must start and end with a letter or digit, not '/'`},
+		{name: "Too long", input: strings.Repeat("a", MaxNameLength+1), err: "longer than 100 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, err := NormalizeName(tt.input)
+			if tt.err != "" {
+				require.ErrorIs(t, err, ErrInvalidName)
+				assert.ErrorContains(t, err, tt.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, normalized)
+
+			// Normalization is idempotent
+			again, err := NormalizeName(normalized)
+			require.NoError(t, err)
+			assert.Equal(t, normalized, again)
+		})
+	}
+}
+
+func TestNormalizeName_Collisions(t *testing.T) {
+	// Names that are different strings but the same record name
+	collisions := [][]string{
+		{"My Agent", "my-agent", "MY_AGENT", "my_agent", " My-Agent "},
+		{"Org/Agent V2", "org/agent-v2", "ORG/AGENT_V2"},
+		{"agent.v1", "AGENT.V1", "Agent.v1"},
+	}
+
+	for _, group := range collisions {
+		want, err := NormalizeName(group[0])
+		require.NoError(t, err)
+
+		for _, name := range group[1:] {
+			got, err := NormalizeName(name)
+			require.NoError(t, err)
+			assert.Equal(t, want, got, "%q and %q must collide", group[0], name)
+		}
+	}
+
+	// Names that look alike but are different record names
+	distinct := [][2]string{
+		{"my-agent", "myagent"},
+		{"my-agent", "my--agent"},
+		{"my-agent", "my.agent"},
+		{"org/agent", "org-agent"},
+	}
+
+	for _, pair := range distinct {
+		a, err := NormalizeName(pair[0])
+		require.NoError(t, err)
+
+		b, err := NormalizeName(pair[1])
+		require.NoError(t, err)
+
+		assert.NotEqual(t, a, b, "%q and %q must not collide", pair[0], pair[1])
+	}
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		err      string
+	}{
+		{name: "Semantic version", input: "1.2.3", expected: "1.2.3"},
+		{name: "Prefixed", input: "v1.0.0", expected: "v1.0.0"},
+		{name: "Major only", input: "v1", expected: "v1"},
+		{name: "Uppercase", input: "V1.0.0-RC.1", expected: "v1.0.0-rc.1"},
+		{name: "Build metadata", input: "1.0.0+build.5", expected: "1.0.0+build.5"},
+		{name: "Empty", input: "", err: "version is empty"},
+		{name: "Invalid character", input: "1.0.0@beta", err: `character '@' is not allowed`},
+		{name: "Space", input: "1.0 beta", err: `character ' ' is not allowed`},
+		{name: "Not semantic", input: "latest", err: "not a semantic version"},
+		{name: "Too many components", input: "1.2.3.4", err: "not a semantic version"},
+		{name: "Too long", input: "1.0.0-" + strings.Repeat("a", MaxVersionLength), err: "longer than 64 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, err := NormalizeVersion(tt.input)
+			if tt.err != "" {
+				require.ErrorIs(t, err, ErrInvalidVersion)
+				assert.ErrorContains(t, err, tt.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, normalized)
+		})
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	require.NoError(t, ValidateName("org/my-agent"))
+
+	err := ValidateName("My Agent")
+	require.ErrorIs(t, err, ErrInvalidName)
+	assert.ErrorContains(t, err, `character 'M' is not in normal form, use "my-agent"`)
+
+	err = ValidateName("my_agent")
+	assert.ErrorContains(t, err, `character '_' is not in normal form`)
+
+	err = ValidateName("my-agent ")
+	assert.ErrorContains(t, err, `character ' ' is not in normal form`)
+
+	err = ValidateVersion("V1.0.0")
+	require.ErrorIs(t, err, ErrInvalidVersion)
+	assert.ErrorContains(t, err, `character 'V' is not in normal form, use "v1.0.0"`)
+}
+
+func TestCanonicalName(t *testing.T) {
+	assert.Equal(t, "my-agent", CanonicalName("MY_AGENT"))
+	assert.Equal(t, "my@agent", CanonicalName(" My@Agent "), "names that cannot be normalized are lowercased")
+	assert.Equal(t, "latest", CanonicalVersion("Latest"))
+}
+
+func TestNormalizePattern(t *testing.T) {
+	assert.Equal(t, "my-agent-*", NormalizePattern("My_Agent *"))
+	assert.Equal(t, "org/agent-v[12]", NormalizePattern("Org/Agent V[12]"))
+}
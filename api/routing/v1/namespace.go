@@ -0,0 +1,12 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+const (
+	// NamespaceAll is the namespace of ListRequest listing the records of all namespaces.
+	NamespaceAll = "*"
+
+	// NamespaceLegacy is the namespace of the records published before namespaces were introduced.
+	NamespaceLegacy = "legacy"
+)
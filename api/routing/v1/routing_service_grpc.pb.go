@@ -23,10 +23,11 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	RoutingService_Publish_FullMethodName   = "/agntcy.dir.routing.v1.RoutingService/Publish"
-	RoutingService_Unpublish_FullMethodName = "/agntcy.dir.routing.v1.RoutingService/Unpublish"
-	RoutingService_Search_FullMethodName    = "/agntcy.dir.routing.v1.RoutingService/Search"
-	RoutingService_List_FullMethodName      = "/agntcy.dir.routing.v1.RoutingService/List"
+	RoutingService_Publish_FullMethodName       = "/agntcy.dir.routing.v1.RoutingService/Publish"
+	RoutingService_PublishAtomic_FullMethodName = "/agntcy.dir.routing.v1.RoutingService/PublishAtomic"
+	RoutingService_Unpublish_FullMethodName     = "/agntcy.dir.routing.v1.RoutingService/Unpublish"
+	RoutingService_Search_FullMethodName        = "/agntcy.dir.routing.v1.RoutingService/Search"
+	RoutingService_List_FullMethodName          = "/agntcy.dir.routing.v1.RoutingService/List"
 )
 
 // RoutingServiceClient is the client API for RoutingService service.
@@ -47,6 +48,12 @@ type RoutingServiceClient interface {
 	// Items need to be periodically republished (eg. 24h) to the network
 	// to avoid stale data. Republication should be done in the background.
 	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Announce a set of records together, e.g. the records of a multi-agent application.
+	// The announcements are staged in a transaction and only committed once all records
+	// are validated and staged, so that either all records are announced or none of them.
+	// Unlike Publish, the call returns once the records are announced, or with the
+	// reason none of them is.
+	PublishAtomic(ctx context.Context, in *PublishAtomicRequest, opts ...grpc.CallOption) (*PublishAtomicResponse, error)
 	// Stop serving this record to the network. If other peers try
 	// to retrieve this record, the peer will refuse the request.
 	Unpublish(ctx context.Context, in *UnpublishRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -83,6 +90,16 @@ func (c *routingServiceClient) Publish(ctx context.Context, in *PublishRequest,
 	return out, nil
 }
 
+func (c *routingServiceClient) PublishAtomic(ctx context.Context, in *PublishAtomicRequest, opts ...grpc.CallOption) (*PublishAtomicResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishAtomicResponse)
+	err := c.cc.Invoke(ctx, RoutingService_PublishAtomic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *routingServiceClient) Unpublish(ctx context.Context, in *UnpublishRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -177,6 +194,12 @@ type RoutingServiceServer interface {
 	// Items need to be periodically republished (eg. 24h) to the network
 	// to avoid stale data. Republication should be done in the background.
 	Publish(context.Context, *PublishRequest) (*emptypb.Empty, error)
+	// Announce a set of records together, e.g. the records of a multi-agent application.
+	// The announcements are staged in a transaction and only committed once all records
+	// are validated and staged, so that either all records are announced or none of them.
+	// Unlike Publish, the call returns once the records are announced, or with the
+	// reason none of them is.
+	PublishAtomic(context.Context, *PublishAtomicRequest) (*PublishAtomicResponse, error)
 	// Stop serving this record to the network. If other peers try
 	// to retrieve this record, the peer will refuse the request.
 	Unpublish(context.Context, *UnpublishRequest) (*emptypb.Empty, error)
@@ -205,6 +228,9 @@ type UnimplementedRoutingServiceServer struct{}
 func (UnimplementedRoutingServiceServer) Publish(context.Context, *PublishRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
 }
+func (UnimplementedRoutingServiceServer) PublishAtomic(context.Context, *PublishAtomicRequest) (*PublishAtomicResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishAtomic not implemented")
+}
 func (UnimplementedRoutingServiceServer) Unpublish(context.Context, *UnpublishRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Unpublish not implemented")
 }
@@ -252,6 +278,24 @@ func _RoutingService_Publish_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RoutingService_PublishAtomic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishAtomicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).PublishAtomic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_PublishAtomic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).PublishAtomic(ctx, req.(*PublishAtomicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RoutingService_Unpublish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UnpublishRequest)
 	if err := dec(in); err != nil {
@@ -323,6 +367,10 @@ var RoutingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Publish",
 			Handler:    _RoutingService_Publish_Handler,
 		},
+		{
+			MethodName: "PublishAtomic",
+			Handler:    _RoutingService_PublishAtomic_Handler,
+		},
 		{
 			MethodName: "Unpublish",
 			Handler:    _RoutingService_Unpublish_Handler,
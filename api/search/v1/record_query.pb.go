@@ -24,6 +24,67 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Defines a list of supported record query operators.
+type RecordQueryOperator int32
+
+const (
+	// Match the value as a pattern, with wildcard support.
+	RecordQueryOperator_RECORD_QUERY_OPERATOR_UNSPECIFIED RecordQueryOperator = 0
+	// Match records with a greater version or a later creation time.
+	RecordQueryOperator_RECORD_QUERY_OPERATOR_GREATER_THAN RecordQueryOperator = 1
+	// Match records with a greater or equal version or creation time.
+	RecordQueryOperator_RECORD_QUERY_OPERATOR_GREATER_THAN_OR_EQUAL RecordQueryOperator = 2
+	// Match records with a lower version or an earlier creation time.
+	RecordQueryOperator_RECORD_QUERY_OPERATOR_LESS_THAN RecordQueryOperator = 3
+	// Match records with a lower or equal version or creation time.
+	RecordQueryOperator_RECORD_QUERY_OPERATOR_LESS_THAN_OR_EQUAL RecordQueryOperator = 4
+)
+
+// Enum value maps for RecordQueryOperator.
+var (
+	RecordQueryOperator_name = map[int32]string{
+		0: "RECORD_QUERY_OPERATOR_UNSPECIFIED",
+		1: "RECORD_QUERY_OPERATOR_GREATER_THAN",
+		2: "RECORD_QUERY_OPERATOR_GREATER_THAN_OR_EQUAL",
+		3: "RECORD_QUERY_OPERATOR_LESS_THAN",
+		4: "RECORD_QUERY_OPERATOR_LESS_THAN_OR_EQUAL",
+	}
+	RecordQueryOperator_value = map[string]int32{
+		"RECORD_QUERY_OPERATOR_UNSPECIFIED":           0,
+		"RECORD_QUERY_OPERATOR_GREATER_THAN":          1,
+		"RECORD_QUERY_OPERATOR_GREATER_THAN_OR_EQUAL": 2,
+		"RECORD_QUERY_OPERATOR_LESS_THAN":             3,
+		"RECORD_QUERY_OPERATOR_LESS_THAN_OR_EQUAL":    4,
+	}
+)
+
+func (x RecordQueryOperator) Enum() *RecordQueryOperator {
+	p := new(RecordQueryOperator)
+	*p = x
+	return p
+}
+
+func (x RecordQueryOperator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecordQueryOperator) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_search_v1_record_query_proto_enumTypes[0].Descriptor()
+}
+
+func (RecordQueryOperator) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_search_v1_record_query_proto_enumTypes[0]
+}
+
+func (x RecordQueryOperator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecordQueryOperator.Descriptor instead.
+func (RecordQueryOperator) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_search_v1_record_query_proto_rawDescGZIP(), []int{0}
+}
+
 // Defines a list of supported record query types.
 type RecordQueryType int32
 
@@ -48,27 +109,49 @@ const (
 	// Query for a module.
 	// Supports wildcard patterns: "*-plugin", "*-module", "core*", "mod-?", "plugin-[0-9]"
 	RecordQueryType_RECORD_QUERY_TYPE_MODULE RecordQueryType = 6
+	// Query for a record annotation, as "key=value" or "key".
+	// The key must match exactly, a key without value matches any value.
+	// Values support wildcard patterns: "team=platform-*", "env=prod"
+	RecordQueryType_RECORD_QUERY_TYPE_ANNOTATION RecordQueryType = 7
+	// Query for a locator type qualified with a target architecture or runtime,
+	// derived from the "arch", "platform" and "runtime" locator annotations.
+	// Supports wildcard patterns: "helm_chart.arm64", "*.arm64", "docker_image.*"
+	RecordQueryType_RECORD_QUERY_TYPE_LOCATOR_VARIANT RecordQueryType = 8
+	// Query for the time a record was added to the directory, as an RFC3339 timestamp.
+	// Requires a comparison operator, e.g. less than to match records added before the time.
+	RecordQueryType_RECORD_QUERY_TYPE_CREATED_AT RecordQueryType = 9
+	// Query for the trust domain that pushed a record.
+	// Supports wildcard patterns: "example.org", "*.example.org"
+	RecordQueryType_RECORD_QUERY_TYPE_TRUST_DOMAIN RecordQueryType = 10
 )
 
 // Enum value maps for RecordQueryType.
 var (
 	RecordQueryType_name = map[int32]string{
-		0: "RECORD_QUERY_TYPE_UNSPECIFIED",
-		1: "RECORD_QUERY_TYPE_NAME",
-		2: "RECORD_QUERY_TYPE_VERSION",
-		3: "RECORD_QUERY_TYPE_SKILL_ID",
-		4: "RECORD_QUERY_TYPE_SKILL_NAME",
-		5: "RECORD_QUERY_TYPE_LOCATOR",
-		6: "RECORD_QUERY_TYPE_MODULE",
+		0:  "RECORD_QUERY_TYPE_UNSPECIFIED",
+		1:  "RECORD_QUERY_TYPE_NAME",
+		2:  "RECORD_QUERY_TYPE_VERSION",
+		3:  "RECORD_QUERY_TYPE_SKILL_ID",
+		4:  "RECORD_QUERY_TYPE_SKILL_NAME",
+		5:  "RECORD_QUERY_TYPE_LOCATOR",
+		6:  "RECORD_QUERY_TYPE_MODULE",
+		7:  "RECORD_QUERY_TYPE_ANNOTATION",
+		8:  "RECORD_QUERY_TYPE_LOCATOR_VARIANT",
+		9:  "RECORD_QUERY_TYPE_CREATED_AT",
+		10: "RECORD_QUERY_TYPE_TRUST_DOMAIN",
 	}
 	RecordQueryType_value = map[string]int32{
-		"RECORD_QUERY_TYPE_UNSPECIFIED": 0,
-		"RECORD_QUERY_TYPE_NAME":        1,
-		"RECORD_QUERY_TYPE_VERSION":     2,
-		"RECORD_QUERY_TYPE_SKILL_ID":    3,
-		"RECORD_QUERY_TYPE_SKILL_NAME":  4,
-		"RECORD_QUERY_TYPE_LOCATOR":     5,
-		"RECORD_QUERY_TYPE_MODULE":      6,
+		"RECORD_QUERY_TYPE_UNSPECIFIED":     0,
+		"RECORD_QUERY_TYPE_NAME":            1,
+		"RECORD_QUERY_TYPE_VERSION":         2,
+		"RECORD_QUERY_TYPE_SKILL_ID":        3,
+		"RECORD_QUERY_TYPE_SKILL_NAME":      4,
+		"RECORD_QUERY_TYPE_LOCATOR":         5,
+		"RECORD_QUERY_TYPE_MODULE":          6,
+		"RECORD_QUERY_TYPE_ANNOTATION":      7,
+		"RECORD_QUERY_TYPE_LOCATOR_VARIANT": 8,
+		"RECORD_QUERY_TYPE_CREATED_AT":      9,
+		"RECORD_QUERY_TYPE_TRUST_DOMAIN":    10,
 	}
 )
 
@@ -83,11 +166,11 @@ func (x RecordQueryType) String() string {
 }
 
 func (RecordQueryType) Descriptor() protoreflect.EnumDescriptor {
-	return file_agntcy_dir_search_v1_record_query_proto_enumTypes[0].Descriptor()
+	return file_agntcy_dir_search_v1_record_query_proto_enumTypes[1].Descriptor()
 }
 
 func (RecordQueryType) Type() protoreflect.EnumType {
-	return &file_agntcy_dir_search_v1_record_query_proto_enumTypes[0]
+	return &file_agntcy_dir_search_v1_record_query_proto_enumTypes[1]
 }
 
 func (x RecordQueryType) Number() protoreflect.EnumNumber {
@@ -96,7 +179,7 @@ func (x RecordQueryType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RecordQueryType.Descriptor instead.
 func (RecordQueryType) EnumDescriptor() ([]byte, []int) {
-	return file_agntcy_dir_search_v1_record_query_proto_rawDescGZIP(), []int{0}
+	return file_agntcy_dir_search_v1_record_query_proto_rawDescGZIP(), []int{1}
 }
 
 // A query to match the record against during discovery.
@@ -108,17 +191,26 @@ func (RecordQueryType) EnumDescriptor() ([]byte, []int) {
 //	Question mark:    { type: RECORD_QUERY_TYPE_VERSION, value: "v1.0.?" }
 //	List wildcards:   { type: RECORD_QUERY_TYPE_NAME, value: "agent-[0-9]" }
 //	Complex match:    { type: RECORD_QUERY_TYPE_LOCATOR, value: "docker-image:https://*.example.com/*" }
+//	Comparison:       { type: RECORD_QUERY_TYPE_VERSION, value: "v2", operator: RECORD_QUERY_OPERATOR_GREATER_THAN_OR_EQUAL }
+//	Annotation:       { type: RECORD_QUERY_TYPE_ANNOTATION, value: "team=platform-*" }
+//	Locator variant:  { type: RECORD_QUERY_TYPE_LOCATOR_VARIANT, value: "helm_chart.arm64" }
+//	Added before:     { type: RECORD_QUERY_TYPE_CREATED_AT, value: "2025-01-01T00:00:00Z", operator: RECORD_QUERY_OPERATOR_LESS_THAN }
+//	Trust domain:     { type: RECORD_QUERY_TYPE_TRUST_DOMAIN, value: "example.org" }
 type RecordQuery struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The type of the query to match against.
 	Type RecordQueryType `protobuf:"varint,1,opt,name=type,proto3,enum=agntcy.dir.search.v1.RecordQueryType" json:"type,omitempty"`
 	// The query value to match against.
 	// Supports wildcard patterns:
-	//
-	//	'*' - matches zero or more characters
-	//	'?' - matches exactly one character
-	//	'[]' - matches any character within brackets (e.g., [0-9], [a-z], [abc])
-	Value         string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	//   '*' - matches zero or more characters
+	//   '?' - matches exactly one character
+	//   '[]' - matches any character within brackets (e.g., [0-9], [a-z], [abc])
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// The operator to match the value with.
+	// Defaults to pattern matching. Comparison operators are only supported
+	// for version queries, which compare semantic versions, and for creation time
+	// queries, which compare timestamps.
+	Operator      RecordQueryOperator `protobuf:"varint,3,opt,name=operator,proto3,enum=agntcy.dir.search.v1.RecordQueryOperator" json:"operator,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -167,6 +259,13 @@ func (x *RecordQuery) GetValue() string {
 	return ""
 }
 
+func (x *RecordQuery) GetOperator() RecordQueryOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return RecordQueryOperator_RECORD_QUERY_OPERATOR_UNSPECIFIED
+}
+
 var File_agntcy_dir_search_v1_record_query_proto protoreflect.FileDescriptor
 
 var file_agntcy_dir_search_v1_record_query_proto_rawDesc = string([]byte{
@@ -174,40 +273,69 @@ var file_agntcy_dir_search_v1_record_query_proto_rawDesc = string([]byte{
 	0x72, 0x63, 0x68, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x71, 0x75,
 	0x65, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x61, 0x67, 0x6e, 0x74, 0x63,
 	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x22,
-	0x5e, 0x0a, 0x0b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x39,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x61,
-	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x2a,
-	0xee, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x1d, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55,
-	0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
-	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44,
-	0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x41, 0x4d, 0x45,
-	0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45,
-	0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x10,
-	0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52,
-	0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4b, 0x49, 0x4c, 0x4c, 0x5f, 0x49, 0x44, 0x10,
-	0x03, 0x12, 0x20, 0x0a, 0x1c, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52,
-	0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4b, 0x49, 0x4c, 0x4c, 0x5f, 0x4e, 0x41, 0x4d,
-	0x45, 0x10, 0x04, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55,
-	0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x54, 0x4f, 0x52,
-	0x10, 0x05, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45,
-	0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x55, 0x4c, 0x45, 0x10, 0x06,
-	0x42, 0xc4, 0x01, 0x0a, 0x18, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
-	0x64, 0x69, 0x72, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x52,
-	0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50,
-	0x01, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x67,
-	0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64, 0x69, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x2f, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x44, 0x53, 0xaa, 0x02, 0x14, 0x41,
-	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x44, 0x69, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x2e, 0x56, 0x31, 0xca, 0x02, 0x14, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72,
-	0x5c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x20, 0x41, 0x67, 0x6e,
-	0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5c, 0x56,
-	0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x17,
-	0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x3a, 0x3a, 0x44, 0x69, 0x72, 0x3a, 0x3a, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0xa5, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12,
+	0x39, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x45, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x29, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2a, 0xe8, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12,
+	0x25, 0x0a, 0x21, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f,
+	0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x26, 0x0a, 0x22, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44,
+	0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f,
+	0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x52, 0x5f, 0x54, 0x48, 0x41, 0x4e, 0x10, 0x01, 0x12, 0x2f,
+	0x0a, 0x2b, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x4f,
+	0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x52, 0x5f,
+	0x54, 0x48, 0x41, 0x4e, 0x5f, 0x4f, 0x52, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x02, 0x12,
+	0x23, 0x0a, 0x1f, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f,
+	0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x4c, 0x45, 0x53, 0x53, 0x5f, 0x54, 0x48,
+	0x41, 0x4e, 0x10, 0x03, 0x12, 0x2c, 0x0a, 0x28, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51,
+	0x55, 0x45, 0x52, 0x59, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x4c, 0x45,
+	0x53, 0x53, 0x5f, 0x54, 0x48, 0x41, 0x4e, 0x5f, 0x4f, 0x52, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c,
+	0x10, 0x04, 0x2a, 0xfd, 0x02, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x1d, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44,
+	0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x52, 0x45, 0x43,
+	0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e,
+	0x41, 0x4d, 0x45, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f,
+	0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49,
+	0x4f, 0x4e, 0x10, 0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51,
+	0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4b, 0x49, 0x4c, 0x4c, 0x5f,
+	0x49, 0x44, 0x10, 0x03, 0x12, 0x20, 0x0a, 0x1c, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51,
+	0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4b, 0x49, 0x4c, 0x4c, 0x5f,
+	0x4e, 0x41, 0x4d, 0x45, 0x10, 0x04, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44,
+	0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x4f, 0x43, 0x41,
+	0x54, 0x4f, 0x52, 0x10, 0x05, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f,
+	0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x55, 0x4c,
+	0x45, 0x10, 0x06, 0x12, 0x20, 0x0a, 0x1c, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55,
+	0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x4e, 0x4e, 0x4f, 0x54, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x10, 0x07, 0x12, 0x25, 0x0a, 0x21, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f,
+	0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x54,
+	0x4f, 0x52, 0x5f, 0x56, 0x41, 0x52, 0x49, 0x41, 0x4e, 0x54, 0x10, 0x08, 0x12, 0x20, 0x0a, 0x1c,
+	0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x41, 0x54, 0x10, 0x09, 0x12, 0x22,
+	0x0a, 0x1e, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x54, 0x52, 0x55, 0x53, 0x54, 0x5f, 0x44, 0x4f, 0x4d, 0x41, 0x49, 0x4e,
+	0x10, 0x0a, 0x42, 0xc4, 0x01, 0x0a, 0x18, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63,
+	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x42,
+	0x10, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x50, 0x01, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64, 0x69, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x2f, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x44, 0x53, 0xaa, 0x02,
+	0x14, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x44, 0x69, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x14, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44,
+	0x69, 0x72, 0x5c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x20, 0x41,
+	0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea,
+	0x02, 0x17, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x3a, 0x3a, 0x44, 0x69, 0x72, 0x3a, 0x3a, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 })
 
 var (
@@ -222,19 +350,21 @@ func file_agntcy_dir_search_v1_record_query_proto_rawDescGZIP() []byte {
 	return file_agntcy_dir_search_v1_record_query_proto_rawDescData
 }
 
-var file_agntcy_dir_search_v1_record_query_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_agntcy_dir_search_v1_record_query_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
 var file_agntcy_dir_search_v1_record_query_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_agntcy_dir_search_v1_record_query_proto_goTypes = []any{
-	(RecordQueryType)(0), // 0: agntcy.dir.search.v1.RecordQueryType
-	(*RecordQuery)(nil),  // 1: agntcy.dir.search.v1.RecordQuery
+	(RecordQueryOperator)(0), // 0: agntcy.dir.search.v1.RecordQueryOperator
+	(RecordQueryType)(0),     // 1: agntcy.dir.search.v1.RecordQueryType
+	(*RecordQuery)(nil),      // 2: agntcy.dir.search.v1.RecordQuery
 }
 var file_agntcy_dir_search_v1_record_query_proto_depIdxs = []int32{
-	0, // 0: agntcy.dir.search.v1.RecordQuery.type:type_name -> agntcy.dir.search.v1.RecordQueryType
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1, // 0: agntcy.dir.search.v1.RecordQuery.type:type_name -> agntcy.dir.search.v1.RecordQueryType
+	0, // 1: agntcy.dir.search.v1.RecordQuery.operator:type_name -> agntcy.dir.search.v1.RecordQueryOperator
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_agntcy_dir_search_v1_record_query_proto_init() }
@@ -247,7 +377,7 @@ func file_agntcy_dir_search_v1_record_query_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_agntcy_dir_search_v1_record_query_proto_rawDesc), len(file_agntcy_dir_search_v1_record_query_proto_rawDesc)),
-			NumEnums:      1,
+			NumEnums:      2,
 			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
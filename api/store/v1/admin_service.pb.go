@@ -0,0 +1,2260 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        (unknown)
+// source: agntcy/dir/store/v1/admin_service.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FsckIssueType is the class of an inconsistency.
+type FsckIssueType int32
+
+const (
+	// Unknown issue type.
+	FsckIssueType_FSCK_ISSUE_TYPE_UNSPECIFIED FsckIssueType = 0
+	// The tag points to a manifest that does not exist or cannot be read.
+	// Repaired by removing the manifest and all of its tags.
+	FsckIssueType_FSCK_ISSUE_TYPE_DANGLING_TAG FsckIssueType = 1
+	// The record blob referenced by the manifest does not exist.
+	// Repaired by deleting the record, which cannot be recovered.
+	FsckIssueType_FSCK_ISSUE_TYPE_MISSING_BLOB FsckIssueType = 2
+	// The CID recomputed from the stored record does not match the CID expected by the manifest,
+	// or a CID tag points to another record.
+	// Repaired by deleting the manifest and storing the content under its actual CID,
+	// or by removing the CID tag.
+	FsckIssueType_FSCK_ISSUE_TYPE_CID_MISMATCH FsckIssueType = 3
+	// A discovery tag derived from the record metadata does not exist,
+	// or the CID tag of the record points to another manifest.
+	// Repaired by creating the tag.
+	FsckIssueType_FSCK_ISSUE_TYPE_MISSING_TAG FsckIssueType = 4
+	// Records with different names share their name tags, because the names normalize to the same name,
+	// e.g. "My Agent" and "MY_AGENT". Such records were pushed before names were normalized.
+	// Not repaired, one of the records must be deleted or pushed again under another name.
+	FsckIssueType_FSCK_ISSUE_TYPE_NAME_COLLISION FsckIssueType = 5
+)
+
+// Enum value maps for FsckIssueType.
+var (
+	FsckIssueType_name = map[int32]string{
+		0: "FSCK_ISSUE_TYPE_UNSPECIFIED",
+		1: "FSCK_ISSUE_TYPE_DANGLING_TAG",
+		2: "FSCK_ISSUE_TYPE_MISSING_BLOB",
+		3: "FSCK_ISSUE_TYPE_CID_MISMATCH",
+		4: "FSCK_ISSUE_TYPE_MISSING_TAG",
+		5: "FSCK_ISSUE_TYPE_NAME_COLLISION",
+	}
+	FsckIssueType_value = map[string]int32{
+		"FSCK_ISSUE_TYPE_UNSPECIFIED":    0,
+		"FSCK_ISSUE_TYPE_DANGLING_TAG":   1,
+		"FSCK_ISSUE_TYPE_MISSING_BLOB":   2,
+		"FSCK_ISSUE_TYPE_CID_MISMATCH":   3,
+		"FSCK_ISSUE_TYPE_MISSING_TAG":    4,
+		"FSCK_ISSUE_TYPE_NAME_COLLISION": 5,
+	}
+)
+
+func (x FsckIssueType) Enum() *FsckIssueType {
+	p := new(FsckIssueType)
+	*p = x
+	return p
+}
+
+func (x FsckIssueType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FsckIssueType) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_store_v1_admin_service_proto_enumTypes[0].Descriptor()
+}
+
+func (FsckIssueType) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_store_v1_admin_service_proto_enumTypes[0]
+}
+
+func (x FsckIssueType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FsckIssueType.Descriptor instead.
+func (FsckIssueType) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{0}
+}
+
+// JournalOperation is the operation recorded by a journal entry.
+type JournalOperation int32
+
+const (
+	// Unknown operation.
+	JournalOperation_JOURNAL_OPERATION_UNSPECIFIED JournalOperation = 0
+	// The record was pushed to the store.
+	JournalOperation_JOURNAL_OPERATION_PUSH JournalOperation = 1
+	// The record was deleted from the store.
+	JournalOperation_JOURNAL_OPERATION_DELETE JournalOperation = 2
+	// Entries were dropped because the journal could not keep up with the operations.
+	// The journal is incomplete, the number of dropped entries is reported in dropped.
+	JournalOperation_JOURNAL_OPERATION_GAP JournalOperation = 3
+	// The lifecycle status or operational metadata of the record was updated.
+	JournalOperation_JOURNAL_OPERATION_UPDATE JournalOperation = 4
+)
+
+// Enum value maps for JournalOperation.
+var (
+	JournalOperation_name = map[int32]string{
+		0: "JOURNAL_OPERATION_UNSPECIFIED",
+		1: "JOURNAL_OPERATION_PUSH",
+		2: "JOURNAL_OPERATION_DELETE",
+		3: "JOURNAL_OPERATION_GAP",
+		4: "JOURNAL_OPERATION_UPDATE",
+	}
+	JournalOperation_value = map[string]int32{
+		"JOURNAL_OPERATION_UNSPECIFIED": 0,
+		"JOURNAL_OPERATION_PUSH":        1,
+		"JOURNAL_OPERATION_DELETE":      2,
+		"JOURNAL_OPERATION_GAP":         3,
+		"JOURNAL_OPERATION_UPDATE":      4,
+	}
+)
+
+func (x JournalOperation) Enum() *JournalOperation {
+	p := new(JournalOperation)
+	*p = x
+	return p
+}
+
+func (x JournalOperation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JournalOperation) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_store_v1_admin_service_proto_enumTypes[1].Descriptor()
+}
+
+func (JournalOperation) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_store_v1_admin_service_proto_enumTypes[1]
+}
+
+func (x JournalOperation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JournalOperation.Descriptor instead.
+func (JournalOperation) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{1}
+}
+
+// WebhookEventType is the class of events delivered to webhooks.
+type WebhookEventType int32
+
+const (
+	// Unknown event type.
+	WebhookEventType_WEBHOOK_EVENT_TYPE_UNSPECIFIED WebhookEventType = 0
+	// The record was pushed to the store.
+	WebhookEventType_WEBHOOK_EVENT_TYPE_PUSHED WebhookEventType = 1
+	// The record was deleted from the store.
+	WebhookEventType_WEBHOOK_EVENT_TYPE_DELETED WebhookEventType = 2
+	// The record was published to the network.
+	WebhookEventType_WEBHOOK_EVENT_TYPE_PUBLISHED WebhookEventType = 3
+	// Test event sent by TestWebhook, delivered regardless of the filters.
+	WebhookEventType_WEBHOOK_EVENT_TYPE_TEST WebhookEventType = 4
+)
+
+// Enum value maps for WebhookEventType.
+var (
+	WebhookEventType_name = map[int32]string{
+		0: "WEBHOOK_EVENT_TYPE_UNSPECIFIED",
+		1: "WEBHOOK_EVENT_TYPE_PUSHED",
+		2: "WEBHOOK_EVENT_TYPE_DELETED",
+		3: "WEBHOOK_EVENT_TYPE_PUBLISHED",
+		4: "WEBHOOK_EVENT_TYPE_TEST",
+	}
+	WebhookEventType_value = map[string]int32{
+		"WEBHOOK_EVENT_TYPE_UNSPECIFIED": 0,
+		"WEBHOOK_EVENT_TYPE_PUSHED":      1,
+		"WEBHOOK_EVENT_TYPE_DELETED":     2,
+		"WEBHOOK_EVENT_TYPE_PUBLISHED":   3,
+		"WEBHOOK_EVENT_TYPE_TEST":        4,
+	}
+)
+
+func (x WebhookEventType) Enum() *WebhookEventType {
+	p := new(WebhookEventType)
+	*p = x
+	return p
+}
+
+func (x WebhookEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_store_v1_admin_service_proto_enumTypes[2].Descriptor()
+}
+
+func (WebhookEventType) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_store_v1_admin_service_proto_enumTypes[2]
+}
+
+func (x WebhookEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookEventType.Descriptor instead.
+func (WebhookEventType) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{2}
+}
+
+// SelfTestStatus is the outcome of a self-test step.
+type SelfTestStatus int32
+
+const (
+	// Unknown status.
+	SelfTestStatus_SELF_TEST_STATUS_UNSPECIFIED SelfTestStatus = 0
+	// The step succeeded.
+	SelfTestStatus_SELF_TEST_STATUS_PASSED SelfTestStatus = 1
+	// The step failed.
+	SelfTestStatus_SELF_TEST_STATUS_FAILED SelfTestStatus = 2
+	// The step was not run, because it is disabled or a previous step failed.
+	SelfTestStatus_SELF_TEST_STATUS_SKIPPED SelfTestStatus = 3
+)
+
+// Enum value maps for SelfTestStatus.
+var (
+	SelfTestStatus_name = map[int32]string{
+		0: "SELF_TEST_STATUS_UNSPECIFIED",
+		1: "SELF_TEST_STATUS_PASSED",
+		2: "SELF_TEST_STATUS_FAILED",
+		3: "SELF_TEST_STATUS_SKIPPED",
+	}
+	SelfTestStatus_value = map[string]int32{
+		"SELF_TEST_STATUS_UNSPECIFIED": 0,
+		"SELF_TEST_STATUS_PASSED":      1,
+		"SELF_TEST_STATUS_FAILED":      2,
+		"SELF_TEST_STATUS_SKIPPED":     3,
+	}
+)
+
+func (x SelfTestStatus) Enum() *SelfTestStatus {
+	p := new(SelfTestStatus)
+	*p = x
+	return p
+}
+
+func (x SelfTestStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SelfTestStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_store_v1_admin_service_proto_enumTypes[3].Descriptor()
+}
+
+func (SelfTestStatus) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_store_v1_admin_service_proto_enumTypes[3]
+}
+
+func (x SelfTestStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SelfTestStatus.Descriptor instead.
+func (SelfTestStatus) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{3}
+}
+
+// SelfTestFailure is the class of a self-test step failure.
+type SelfTestFailure int32
+
+const (
+	// The step did not fail, or the failure could not be classified.
+	SelfTestFailure_SELF_TEST_FAILURE_UNSPECIFIED SelfTestFailure = 0
+	// The registry could not be reached, e.g. because of a wrong address, DNS or TLS issues or a timeout.
+	SelfTestFailure_SELF_TEST_FAILURE_NETWORK SelfTestFailure = 1
+	// The registry rejected the credentials.
+	SelfTestFailure_SELF_TEST_FAILURE_AUTH SelfTestFailure = 2
+	// The registry accepted the credentials, but denied the operation.
+	SelfTestFailure_SELF_TEST_FAILURE_PERMISSION SelfTestFailure = 3
+	// The registry or the local store failed otherwise.
+	SelfTestFailure_SELF_TEST_FAILURE_OTHER SelfTestFailure = 4
+)
+
+// Enum value maps for SelfTestFailure.
+var (
+	SelfTestFailure_name = map[int32]string{
+		0: "SELF_TEST_FAILURE_UNSPECIFIED",
+		1: "SELF_TEST_FAILURE_NETWORK",
+		2: "SELF_TEST_FAILURE_AUTH",
+		3: "SELF_TEST_FAILURE_PERMISSION",
+		4: "SELF_TEST_FAILURE_OTHER",
+	}
+	SelfTestFailure_value = map[string]int32{
+		"SELF_TEST_FAILURE_UNSPECIFIED": 0,
+		"SELF_TEST_FAILURE_NETWORK":     1,
+		"SELF_TEST_FAILURE_AUTH":        2,
+		"SELF_TEST_FAILURE_PERMISSION":  3,
+		"SELF_TEST_FAILURE_OTHER":       4,
+	}
+)
+
+func (x SelfTestFailure) Enum() *SelfTestFailure {
+	p := new(SelfTestFailure)
+	*p = x
+	return p
+}
+
+func (x SelfTestFailure) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SelfTestFailure) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_store_v1_admin_service_proto_enumTypes[4].Descriptor()
+}
+
+func (SelfTestFailure) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_store_v1_admin_service_proto_enumTypes[4]
+}
+
+func (x SelfTestFailure) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SelfTestFailure.Descriptor instead.
+func (SelfTestFailure) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{4}
+}
+
+// FsckRequest specifies how to check the store.
+type FsckRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Repair the detected issues.
+	// If unset, issues are only reported.
+	Repair        bool `protobuf:"varint,1,opt,name=repair,proto3" json:"repair,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FsckRequest) Reset() {
+	*x = FsckRequest{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FsckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FsckRequest) ProtoMessage() {}
+
+func (x *FsckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FsckRequest.ProtoReflect.Descriptor instead.
+func (*FsckRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FsckRequest) GetRepair() bool {
+	if x != nil {
+		return x.Repair
+	}
+	return false
+}
+
+// FsckResponse is a progress update, a detected issue or the summary of a check.
+type FsckResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*FsckResponse_Progress
+	//	*FsckResponse_Issue
+	//	*FsckResponse_Summary
+	Response      isFsckResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FsckResponse) Reset() {
+	*x = FsckResponse{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FsckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FsckResponse) ProtoMessage() {}
+
+func (x *FsckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FsckResponse.ProtoReflect.Descriptor instead.
+func (*FsckResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FsckResponse) GetResponse() isFsckResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *FsckResponse) GetProgress() *FsckProgress {
+	if x != nil {
+		if x, ok := x.Response.(*FsckResponse_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
+func (x *FsckResponse) GetIssue() *FsckIssue {
+	if x != nil {
+		if x, ok := x.Response.(*FsckResponse_Issue); ok {
+			return x.Issue
+		}
+	}
+	return nil
+}
+
+func (x *FsckResponse) GetSummary() *FsckSummary {
+	if x != nil {
+		if x, ok := x.Response.(*FsckResponse_Summary); ok {
+			return x.Summary
+		}
+	}
+	return nil
+}
+
+type isFsckResponse_Response interface {
+	isFsckResponse_Response()
+}
+
+type FsckResponse_Progress struct {
+	// Progress of the check.
+	Progress *FsckProgress `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type FsckResponse_Issue struct {
+	// Issue detected by the check.
+	Issue *FsckIssue `protobuf:"bytes,2,opt,name=issue,proto3,oneof"`
+}
+
+type FsckResponse_Summary struct {
+	// Summary of the check, sent once the check is complete.
+	Summary *FsckSummary `protobuf:"bytes,3,opt,name=summary,proto3,oneof"`
+}
+
+func (*FsckResponse_Progress) isFsckResponse_Response() {}
+
+func (*FsckResponse_Issue) isFsckResponse_Response() {}
+
+func (*FsckResponse_Summary) isFsckResponse_Response() {}
+
+// FsckProgress reports how much of the store was checked.
+type FsckProgress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of tags checked so far.
+	CheckedTags uint64 `protobuf:"varint,1,opt,name=checked_tags,json=checkedTags,proto3" json:"checked_tags,omitempty"`
+	// Total number of tags to check.
+	TotalTags     uint64 `protobuf:"varint,2,opt,name=total_tags,json=totalTags,proto3" json:"total_tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FsckProgress) Reset() {
+	*x = FsckProgress{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FsckProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FsckProgress) ProtoMessage() {}
+
+func (x *FsckProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FsckProgress.ProtoReflect.Descriptor instead.
+func (*FsckProgress) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FsckProgress) GetCheckedTags() uint64 {
+	if x != nil {
+		return x.CheckedTags
+	}
+	return 0
+}
+
+func (x *FsckProgress) GetTotalTags() uint64 {
+	if x != nil {
+		return x.TotalTags
+	}
+	return 0
+}
+
+// FsckIssue describes an inconsistency detected by a check.
+type FsckIssue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Class of the issue.
+	Type FsckIssueType `protobuf:"varint,1,opt,name=type,proto3,enum=agntcy.dir.store.v1.FsckIssueType" json:"type,omitempty"`
+	// Tag the issue was detected for.
+	Tag string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	// CID of the affected record, if known.
+	Cid string `protobuf:"bytes,3,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Human-readable description of the issue.
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// Whether the issue was repaired.
+	Repaired bool `protobuf:"varint,5,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	// Reason the issue could not be repaired, if repair was requested.
+	RepairError   string `protobuf:"bytes,6,opt,name=repair_error,json=repairError,proto3" json:"repair_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FsckIssue) Reset() {
+	*x = FsckIssue{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FsckIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FsckIssue) ProtoMessage() {}
+
+func (x *FsckIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FsckIssue.ProtoReflect.Descriptor instead.
+func (*FsckIssue) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FsckIssue) GetType() FsckIssueType {
+	if x != nil {
+		return x.Type
+	}
+	return FsckIssueType_FSCK_ISSUE_TYPE_UNSPECIFIED
+}
+
+func (x *FsckIssue) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *FsckIssue) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *FsckIssue) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *FsckIssue) GetRepaired() bool {
+	if x != nil {
+		return x.Repaired
+	}
+	return false
+}
+
+func (x *FsckIssue) GetRepairError() string {
+	if x != nil {
+		return x.RepairError
+	}
+	return ""
+}
+
+// FsckSummary summarizes a check.
+type FsckSummary struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of tags checked.
+	CheckedTags uint64 `protobuf:"varint,1,opt,name=checked_tags,json=checkedTags,proto3" json:"checked_tags,omitempty"`
+	// Number of records checked.
+	CheckedRecords uint64 `protobuf:"varint,2,opt,name=checked_records,json=checkedRecords,proto3" json:"checked_records,omitempty"`
+	// Number of detected issues.
+	Issues uint64 `protobuf:"varint,3,opt,name=issues,proto3" json:"issues,omitempty"`
+	// Number of repaired issues.
+	Repaired uint64 `protobuf:"varint,4,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	// Whether unreferenced blobs were removed after repairing.
+	// Only supported for local stores.
+	Compacted     bool `protobuf:"varint,5,opt,name=compacted,proto3" json:"compacted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FsckSummary) Reset() {
+	*x = FsckSummary{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FsckSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FsckSummary) ProtoMessage() {}
+
+func (x *FsckSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FsckSummary.ProtoReflect.Descriptor instead.
+func (*FsckSummary) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FsckSummary) GetCheckedTags() uint64 {
+	if x != nil {
+		return x.CheckedTags
+	}
+	return 0
+}
+
+func (x *FsckSummary) GetCheckedRecords() uint64 {
+	if x != nil {
+		return x.CheckedRecords
+	}
+	return 0
+}
+
+func (x *FsckSummary) GetIssues() uint64 {
+	if x != nil {
+		return x.Issues
+	}
+	return 0
+}
+
+func (x *FsckSummary) GetRepaired() uint64 {
+	if x != nil {
+		return x.Repaired
+	}
+	return 0
+}
+
+func (x *FsckSummary) GetCompacted() bool {
+	if x != nil {
+		return x.Compacted
+	}
+	return false
+}
+
+// ReshardRequest specifies how to reshard the store.
+type ReshardRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only report the records that would be moved.
+	DryRun        bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReshardRequest) Reset() {
+	*x = ReshardRequest{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReshardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReshardRequest) ProtoMessage() {}
+
+func (x *ReshardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReshardRequest.ProtoReflect.Descriptor instead.
+func (*ReshardRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReshardRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// ReshardResponse is a checked record or the summary of a migration.
+type ReshardResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*ReshardResponse_Move
+	//	*ReshardResponse_Summary
+	Response      isReshardResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReshardResponse) Reset() {
+	*x = ReshardResponse{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReshardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReshardResponse) ProtoMessage() {}
+
+func (x *ReshardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReshardResponse.ProtoReflect.Descriptor instead.
+func (*ReshardResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReshardResponse) GetResponse() isReshardResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ReshardResponse) GetMove() *ReshardMove {
+	if x != nil {
+		if x, ok := x.Response.(*ReshardResponse_Move); ok {
+			return x.Move
+		}
+	}
+	return nil
+}
+
+func (x *ReshardResponse) GetSummary() *ReshardSummary {
+	if x != nil {
+		if x, ok := x.Response.(*ReshardResponse_Summary); ok {
+			return x.Summary
+		}
+	}
+	return nil
+}
+
+type isReshardResponse_Response interface {
+	isReshardResponse_Response()
+}
+
+type ReshardResponse_Move struct {
+	// Record checked by the migration.
+	Move *ReshardMove `protobuf:"bytes,1,opt,name=move,proto3,oneof"`
+}
+
+type ReshardResponse_Summary struct {
+	// Summary of the migration, sent once the migration is complete.
+	Summary *ReshardSummary `protobuf:"bytes,2,opt,name=summary,proto3,oneof"`
+}
+
+func (*ReshardResponse_Move) isReshardResponse_Response() {}
+
+func (*ReshardResponse_Summary) isReshardResponse_Response() {}
+
+// ReshardMove describes a record that is not stored in its target repository.
+type ReshardMove struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the record.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Repository the record is stored in.
+	SourceRepository string `protobuf:"bytes,2,opt,name=source_repository,json=sourceRepository,proto3" json:"source_repository,omitempty"`
+	// Repository resolved for the record.
+	TargetRepository string `protobuf:"bytes,3,opt,name=target_repository,json=targetRepository,proto3" json:"target_repository,omitempty"`
+	// Whether the record was moved.
+	// Always unset for dry runs.
+	Moved bool `protobuf:"varint,4,opt,name=moved,proto3" json:"moved,omitempty"`
+	// Reason the record could not be moved, if any.
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReshardMove) Reset() {
+	*x = ReshardMove{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReshardMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReshardMove) ProtoMessage() {}
+
+func (x *ReshardMove) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReshardMove.ProtoReflect.Descriptor instead.
+func (*ReshardMove) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReshardMove) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *ReshardMove) GetSourceRepository() string {
+	if x != nil {
+		return x.SourceRepository
+	}
+	return ""
+}
+
+func (x *ReshardMove) GetTargetRepository() string {
+	if x != nil {
+		return x.TargetRepository
+	}
+	return ""
+}
+
+func (x *ReshardMove) GetMoved() bool {
+	if x != nil {
+		return x.Moved
+	}
+	return false
+}
+
+func (x *ReshardMove) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ReshardSummary summarizes a migration.
+type ReshardSummary struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of records checked.
+	CheckedRecords uint64 `protobuf:"varint,1,opt,name=checked_records,json=checkedRecords,proto3" json:"checked_records,omitempty"`
+	// Number of records stored outside of their target repository.
+	Misplaced uint64 `protobuf:"varint,2,opt,name=misplaced,proto3" json:"misplaced,omitempty"`
+	// Number of records moved.
+	Moved uint64 `protobuf:"varint,3,opt,name=moved,proto3" json:"moved,omitempty"`
+	// Number of records that could not be moved.
+	Failed        uint64 `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReshardSummary) Reset() {
+	*x = ReshardSummary{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReshardSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReshardSummary) ProtoMessage() {}
+
+func (x *ReshardSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReshardSummary.ProtoReflect.Descriptor instead.
+func (*ReshardSummary) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReshardSummary) GetCheckedRecords() uint64 {
+	if x != nil {
+		return x.CheckedRecords
+	}
+	return 0
+}
+
+func (x *ReshardSummary) GetMisplaced() uint64 {
+	if x != nil {
+		return x.Misplaced
+	}
+	return 0
+}
+
+func (x *ReshardSummary) GetMoved() uint64 {
+	if x != nil {
+		return x.Moved
+	}
+	return 0
+}
+
+func (x *ReshardSummary) GetFailed() uint64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+// ReadJournalRequest specifies which journal entries to read.
+type ReadJournalRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only read entries with a greater sequence number.
+	// If unset, all retained entries are read.
+	Since         uint64 `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadJournalRequest) Reset() {
+	*x = ReadJournalRequest{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadJournalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadJournalRequest) ProtoMessage() {}
+
+func (x *ReadJournalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadJournalRequest.ProtoReflect.Descriptor instead.
+func (*ReadJournalRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReadJournalRequest) GetSince() uint64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+// JournalEntry records an operation on the store.
+type JournalEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sequence number of the entry, increasing by one for every entry.
+	Sequence uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Time of the operation in the RFC3339 format.
+	Timestamp string `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Recorded operation.
+	Operation JournalOperation `protobuf:"varint,3,opt,name=operation,proto3,enum=agntcy.dir.store.v1.JournalOperation" json:"operation,omitempty"`
+	// CID of the record.
+	Cid string `protobuf:"bytes,4,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Name of the record, if known.
+	Name string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	// Version of the record, if known.
+	Version string `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
+	// Trust domain of the caller, empty if unauthenticated.
+	TrustDomain string `protobuf:"bytes,7,opt,name=trust_domain,json=trustDomain,proto3" json:"trust_domain,omitempty"`
+	// Size of the pushed record in bytes.
+	SizeBytes uint64 `protobuf:"varint,8,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// Number of dropped entries, for gap entries.
+	Dropped       uint64 `protobuf:"varint,9,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JournalEntry) Reset() {
+	*x = JournalEntry{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JournalEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JournalEntry) ProtoMessage() {}
+
+func (x *JournalEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JournalEntry.ProtoReflect.Descriptor instead.
+func (*JournalEntry) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *JournalEntry) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *JournalEntry) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetOperation() JournalOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return JournalOperation_JOURNAL_OPERATION_UNSPECIFIED
+}
+
+func (x *JournalEntry) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetTrustDomain() string {
+	if x != nil {
+		return x.TrustDomain
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetSizeBytes() uint64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *JournalEntry) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+// Webhook is a subscription of an HTTP endpoint to store and routing events.
+//
+// Events are POSTed as JSON with the event type, CID, record metadata, timestamp and sequence number.
+// The body is signed with HMAC-SHA256 using the secret, the hex-encoded signature
+// is sent in the X-Dir-Signature header as "sha256=<signature>".
+type Webhook struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the subscription, assigned by the server.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// URL the events are POSTed to.
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Secret used to sign the events.
+	// Write-only, never returned by the server.
+	Secret string `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Types of the delivered events.
+	// If empty, all events are delivered.
+	EventTypes []WebhookEventType `protobuf:"varint,4,rep,packed,name=event_types,json=eventTypes,proto3,enum=agntcy.dir.store.v1.WebhookEventType" json:"event_types,omitempty"`
+	// Name patterns of which the record must match one, e.g. "cisco.com/*".
+	// Patterns use the path.Match syntax. If empty, records of any name are delivered.
+	Names []string `protobuf:"bytes,5,rep,name=names,proto3" json:"names,omitempty"`
+	// Routing labels of which the record must have one, e.g. "/skills/natural_language_processing".
+	// A label also matches all labels below it. If empty, records with any labels are delivered.
+	Labels []string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty"`
+	// Number of delivery attempts before an event is moved to the dead-letter log.
+	// If unset, the server default is used.
+	MaxAttempts uint32 `protobuf:"varint,7,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	// Delay before the first retry, doubled for every further retry.
+	// If unset, the server default is used.
+	InitialBackoff *durationpb.Duration `protobuf:"bytes,8,opt,name=initial_backoff,json=initialBackoff,proto3" json:"initial_backoff,omitempty"`
+	// Whether the subscription is part of the server configuration.
+	// Set by the server, such subscriptions cannot be removed.
+	Configured    bool `protobuf:"varint,9,opt,name=configured,proto3" json:"configured,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Webhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Webhook) GetEventTypes() []WebhookEventType {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *Webhook) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *Webhook) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Webhook) GetMaxAttempts() uint32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *Webhook) GetInitialBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.InitialBackoff
+	}
+	return nil
+}
+
+func (x *Webhook) GetConfigured() bool {
+	if x != nil {
+		return x.Configured
+	}
+	return false
+}
+
+// ListWebhooksRequest specifies which webhook subscriptions to list.
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{12}
+}
+
+// RemoveWebhookRequest specifies the webhook subscription to remove.
+type RemoveWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the subscription.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveWebhookRequest) Reset() {
+	*x = RemoveWebhookRequest{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWebhookRequest) ProtoMessage() {}
+
+func (x *RemoveWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWebhookRequest.ProtoReflect.Descriptor instead.
+func (*RemoveWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RemoveWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// TestWebhookRequest specifies the webhook subscription to test.
+type TestWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the subscription.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestWebhookRequest) Reset() {
+	*x = TestWebhookRequest{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestWebhookRequest) ProtoMessage() {}
+
+func (x *TestWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestWebhookRequest.ProtoReflect.Descriptor instead.
+func (*TestWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TestWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// TestWebhookResponse is the response of the endpoint to a test event.
+type TestWebhookResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// HTTP status code returned by the endpoint, unset if the request failed.
+	StatusCode uint32 `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	// Reason the delivery failed, if any.
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestWebhookResponse) Reset() {
+	*x = TestWebhookResponse{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestWebhookResponse) ProtoMessage() {}
+
+func (x *TestWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestWebhookResponse.ProtoReflect.Descriptor instead.
+func (*TestWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TestWebhookResponse) GetStatusCode() uint32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *TestWebhookResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// GetLogLevelsRequest specifies which log levels to get.
+type GetLogLevelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogLevelsRequest) Reset() {
+	*x = GetLogLevelsRequest{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogLevelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogLevelsRequest) ProtoMessage() {}
+
+func (x *GetLogLevelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogLevelsRequest.ProtoReflect.Descriptor instead.
+func (*GetLogLevelsRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{16}
+}
+
+// SetLogLevelRequest specifies the log level of a component.
+type SetLogLevelRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Component to set the level of, e.g. "store/oci".
+	// If unset, the default level of all components without their own level is set.
+	Component string `protobuf:"bytes,1,opt,name=component,proto3" json:"component,omitempty"`
+	// Log level, one of "debug", "info", "warn" or "error".
+	// If unset, the component uses the level of the component above it again.
+	Level         string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetLogLevelRequest) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+// LogLevels are the log levels of the server.
+type LogLevels struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Level of all components without their own level.
+	DefaultLevel string `protobuf:"bytes,1,opt,name=default_level,json=defaultLevel,proto3" json:"default_level,omitempty"`
+	// Levels of components, by component name.
+	ComponentLevels map[string]string `protobuf:"bytes,2,rep,name=component_levels,json=componentLevels,proto3" json:"component_levels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LogLevels) Reset() {
+	*x = LogLevels{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogLevels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLevels) ProtoMessage() {}
+
+func (x *LogLevels) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLevels.ProtoReflect.Descriptor instead.
+func (*LogLevels) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *LogLevels) GetDefaultLevel() string {
+	if x != nil {
+		return x.DefaultLevel
+	}
+	return ""
+}
+
+func (x *LogLevels) GetComponentLevels() map[string]string {
+	if x != nil {
+		return x.ComponentLevels
+	}
+	return nil
+}
+
+// SelfTestRequest specifies how to run the self-test of the store backend.
+type SelfTestRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Skip the push and delete of the probe blob, e.g. for read-only registry credentials.
+	// The probe is also skipped if disabled in the server configuration.
+	SkipWrite     bool `protobuf:"varint,1,opt,name=skip_write,json=skipWrite,proto3" json:"skip_write,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelfTestRequest) Reset() {
+	*x = SelfTestRequest{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelfTestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelfTestRequest) ProtoMessage() {}
+
+func (x *SelfTestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelfTestRequest.ProtoReflect.Descriptor instead.
+func (*SelfTestRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SelfTestRequest) GetSkipWrite() bool {
+	if x != nil {
+		return x.SkipWrite
+	}
+	return false
+}
+
+// SelfTestStep is the outcome of a single self-test step.
+type SelfTestStep struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the step: "ping", "push", "delete" or "list-tags".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Outcome of the step.
+	Status SelfTestStatus `protobuf:"varint,2,opt,name=status,proto3,enum=agntcy.dir.store.v1.SelfTestStatus" json:"status,omitempty"`
+	// Duration of the step.
+	Latency *durationpb.Duration `protobuf:"bytes,3,opt,name=latency,proto3" json:"latency,omitempty"`
+	// Class of the failure, for failed steps.
+	Failure SelfTestFailure `protobuf:"varint,4,opt,name=failure,proto3,enum=agntcy.dir.store.v1.SelfTestFailure" json:"failure,omitempty"`
+	// Reason the step failed or was skipped.
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelfTestStep) Reset() {
+	*x = SelfTestStep{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelfTestStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelfTestStep) ProtoMessage() {}
+
+func (x *SelfTestStep) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelfTestStep.ProtoReflect.Descriptor instead.
+func (*SelfTestStep) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SelfTestStep) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SelfTestStep) GetStatus() SelfTestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SelfTestStatus_SELF_TEST_STATUS_UNSPECIFIED
+}
+
+func (x *SelfTestStep) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *SelfTestStep) GetFailure() SelfTestFailure {
+	if x != nil {
+		return x.Failure
+	}
+	return SelfTestFailure_SELF_TEST_FAILURE_UNSPECIFIED
+}
+
+func (x *SelfTestStep) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// SelfTestReport is the outcome of a self-test of the store backend.
+type SelfTestReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether no step failed.
+	Passed bool `protobuf:"varint,1,opt,name=passed,proto3" json:"passed,omitempty"`
+	// Steps in the order they were run.
+	Steps []*SelfTestStep `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	// Time the self-test started in the RFC3339 format.
+	StartedAt     string `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelfTestReport) Reset() {
+	*x = SelfTestReport{}
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelfTestReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelfTestReport) ProtoMessage() {}
+
+func (x *SelfTestReport) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_admin_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelfTestReport.ProtoReflect.Descriptor instead.
+func (*SelfTestReport) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SelfTestReport) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *SelfTestReport) GetSteps() []*SelfTestStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *SelfTestReport) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+var File_agntcy_dir_store_v1_admin_service_proto protoreflect.FileDescriptor
+
+var file_agntcy_dir_store_v1_admin_service_proto_rawDesc = string([]byte{
+	0x0a, 0x27, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64, 0x69, 0x72, 0x2f, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x61, 0x67, 0x6e, 0x74, 0x63,
+	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x25, 0x0a, 0x0b, 0x46,
+	0x73, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x70, 0x61,
+	0x69, 0x72, 0x22, 0xd1, 0x01, 0x0a, 0x0c, 0x46, 0x73, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64,
+	0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x73, 0x63, 0x6b,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x36, 0x0a, 0x05, 0x69, 0x73, 0x73, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x73, 0x63, 0x6b, 0x49, 0x73,
+	0x73, 0x75, 0x65, 0x48, 0x00, 0x52, 0x05, 0x69, 0x73, 0x73, 0x75, 0x65, 0x12, 0x3c, 0x0a, 0x07,
+	0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x46, 0x73, 0x63, 0x6b, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x48,
+	0x00, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x50, 0x0a, 0x0c, 0x46, 0x73, 0x63, 0x6b, 0x50, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65,
+	0x64, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x65, 0x64, 0x54, 0x61, 0x67, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x54, 0x61, 0x67, 0x73, 0x22, 0xc0, 0x01, 0x0a, 0x09, 0x46, 0x73, 0x63,
+	0x6b, 0x49, 0x73, 0x73, 0x75, 0x65, 0x12, 0x36, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69,
+	0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x73, 0x63, 0x6b, 0x49,
+	0x73, 0x73, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67,
+	0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63,
+	0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x61,
+	0x69, 0x72, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xab, 0x01, 0x0a, 0x0b,
+	0x46, 0x73, 0x63, 0x6b, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0b, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x54, 0x61, 0x67, 0x73, 0x12, 0x27,
+	0x0a, 0x0f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x65, 0x64, 0x22, 0x29, 0x0a, 0x0e, 0x52, 0x65, 0x73,
+	0x68, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x64,
+	0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72,
+	0x79, 0x52, 0x75, 0x6e, 0x22, 0x96, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x04, 0x6d, 0x6f, 0x76, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
+	0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73,
+	0x68, 0x61, 0x72, 0x64, 0x4d, 0x6f, 0x76, 0x65, 0x48, 0x00, 0x52, 0x04, 0x6d, 0x6f, 0x76, 0x65,
+	0x12, 0x3f, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72, 0x64, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa5, 0x01,
+	0x0a, 0x0b, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72, 0x64, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12,
+	0x2b, 0x0a, 0x11, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x11,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x76,
+	0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x85, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72,
+	0x64, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6d, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x22, 0x2a, 0x0a,
+	0x12, 0x52, 0x65, 0x61, 0x64, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x22, 0xa9, 0x02, 0x0a, 0x0c, 0x4a, 0x6f,
+	0x75, 0x72, 0x6e, 0x61, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x43, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79,
+	0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f,
+	0x75, 0x72, 0x6e, 0x61, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x72, 0x75,
+	0x73, 0x74, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x74, 0x72, 0x75, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x64,
+	0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x64, 0x72,
+	0x6f, 0x70, 0x70, 0x65, 0x64, 0x22, 0xc0, 0x02, 0x0a, 0x07, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x75, 0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x46, 0x0a, 0x0b, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e,
+	0x32, 0x25, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x41, 0x74, 0x74, 0x65,
+	0x6d, 0x70, 0x74, 0x73, 0x12, 0x42, 0x0a, 0x0f, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f,
+	0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61,
+	0x6c, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74,
+	0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x26, 0x0a, 0x14, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x24, 0x0a, 0x12, 0x54, 0x65, 0x73, 0x74, 0x57,
+	0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x4c, 0x0a,
+	0x13, 0x54, 0x65, 0x73, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x15, 0x0a, 0x13, 0x47,
+	0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x48, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0xd4, 0x01, 0x0a,
+	0x09, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12,
+	0x5e, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x61, 0x67, 0x6e, 0x74,
+	0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
+	0x65, 0x6e, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f,
+	0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x1a,
+	0x42, 0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x30, 0x0a, 0x0f, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x77,
+	0x72, 0x69, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x6b, 0x69, 0x70,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x22, 0xea, 0x01, 0x0a, 0x0c, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65,
+	0x73, 0x74, 0x53, 0x74, 0x65, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x61, 0x67, 0x6e,
+	0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x3e, 0x0a, 0x07,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x46, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x52, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x22, 0x80, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x73, 0x73, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x73, 0x73, 0x65, 0x64, 0x12, 0x37, 0x0a,
+	0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x61,
+	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x53, 0x74, 0x65, 0x70, 0x52,
+	0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x2a, 0xdb, 0x01, 0x0a, 0x0d, 0x46, 0x73, 0x63, 0x6b, 0x49, 0x73,
+	0x73, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x1b, 0x46, 0x53, 0x43, 0x4b, 0x5f,
+	0x49, 0x53, 0x53, 0x55, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x46, 0x53, 0x43, 0x4b,
+	0x5f, 0x49, 0x53, 0x53, 0x55, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x41, 0x4e, 0x47,
+	0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x54, 0x41, 0x47, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x46, 0x53,
+	0x43, 0x4b, 0x5f, 0x49, 0x53, 0x53, 0x55, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x49,
+	0x53, 0x53, 0x49, 0x4e, 0x47, 0x5f, 0x42, 0x4c, 0x4f, 0x42, 0x10, 0x02, 0x12, 0x20, 0x0a, 0x1c,
+	0x46, 0x53, 0x43, 0x4b, 0x5f, 0x49, 0x53, 0x53, 0x55, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x43, 0x49, 0x44, 0x5f, 0x4d, 0x49, 0x53, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x10, 0x03, 0x12, 0x1f,
+	0x0a, 0x1b, 0x46, 0x53, 0x43, 0x4b, 0x5f, 0x49, 0x53, 0x53, 0x55, 0x45, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x5f, 0x54, 0x41, 0x47, 0x10, 0x04, 0x12,
+	0x22, 0x0a, 0x1e, 0x46, 0x53, 0x43, 0x4b, 0x5f, 0x49, 0x53, 0x53, 0x55, 0x45, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x4e, 0x41, 0x4d, 0x45, 0x5f, 0x43, 0x4f, 0x4c, 0x4c, 0x49, 0x53, 0x49, 0x4f,
+	0x4e, 0x10, 0x05, 0x2a, 0xa8, 0x01, 0x0a, 0x10, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x1d, 0x4a, 0x4f, 0x55, 0x52,
+	0x4e, 0x41, 0x4c, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x4a,
+	0x4f, 0x55, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x50, 0x55, 0x53, 0x48, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x4a, 0x4f, 0x55, 0x52, 0x4e,
+	0x41, 0x4c, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x4c,
+	0x45, 0x54, 0x45, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x4a, 0x4f, 0x55, 0x52, 0x4e, 0x41, 0x4c,
+	0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x47, 0x41, 0x50, 0x10, 0x03,
+	0x12, 0x1c, 0x0a, 0x18, 0x4a, 0x4f, 0x55, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x4f, 0x50, 0x45, 0x52,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x04, 0x2a, 0xb4,
+	0x01, 0x0a, 0x10, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x1e, 0x57, 0x45, 0x42, 0x48, 0x4f, 0x4f, 0x4b, 0x5f, 0x45,
+	0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x57, 0x45, 0x42, 0x48, 0x4f,
+	0x4f, 0x4b, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x55,
+	0x53, 0x48, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x57, 0x45, 0x42, 0x48, 0x4f, 0x4f,
+	0x4b, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x45, 0x4c,
+	0x45, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x20, 0x0a, 0x1c, 0x57, 0x45, 0x42, 0x48, 0x4f, 0x4f,
+	0x4b, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x55, 0x42,
+	0x4c, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x57, 0x45, 0x42, 0x48,
+	0x4f, 0x4f, 0x4b, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54,
+	0x45, 0x53, 0x54, 0x10, 0x04, 0x2a, 0x8a, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65,
+	0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x0a, 0x1c, 0x53, 0x45, 0x4c, 0x46,
+	0x5f, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x45,
+	0x4c, 0x46, 0x5f, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50,
+	0x41, 0x53, 0x53, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x45, 0x4c, 0x46, 0x5f,
+	0x54, 0x45, 0x53, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49, 0x4c,
+	0x45, 0x44, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x45, 0x4c, 0x46, 0x5f, 0x54, 0x45, 0x53,
+	0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x4b, 0x49, 0x50, 0x50, 0x45, 0x44,
+	0x10, 0x03, 0x2a, 0xae, 0x01, 0x0a, 0x0f, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x21, 0x0a, 0x1d, 0x53, 0x45, 0x4c, 0x46, 0x5f, 0x54,
+	0x45, 0x53, 0x54, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x45, 0x4c,
+	0x46, 0x5f, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x4e,
+	0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45, 0x4c, 0x46,
+	0x5f, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x41, 0x55,
+	0x54, 0x48, 0x10, 0x02, 0x12, 0x20, 0x0a, 0x1c, 0x53, 0x45, 0x4c, 0x46, 0x5f, 0x54, 0x45, 0x53,
+	0x54, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x50, 0x45, 0x52, 0x4d, 0x49, 0x53,
+	0x53, 0x49, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x45, 0x4c, 0x46, 0x5f, 0x54,
+	0x45, 0x53, 0x54, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x4f, 0x54, 0x48, 0x45,
+	0x52, 0x10, 0x04, 0x32, 0xf5, 0x06, 0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x4d, 0x0a, 0x04, 0x46, 0x73, 0x63, 0x6b, 0x12, 0x20, 0x2e, 0x61,
+	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x46, 0x73, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x73, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x30, 0x01, 0x12, 0x56, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72, 0x64, 0x12, 0x23,
+	0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72,
+	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x5b, 0x0a, 0x0b, 0x52,
+	0x65, 0x61, 0x64, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x12, 0x27, 0x2e, 0x61, 0x67, 0x6e,
+	0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x61, 0x64, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61,
+	0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x30, 0x01, 0x12, 0x58, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74,
+	0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x12, 0x28, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63,
+	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b,
+	0x30, 0x01, 0x12, 0x48, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b,
+	0x12, 0x1c, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x1a, 0x1c,
+	0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x52, 0x0a, 0x0d,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x29, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x60, 0x0a, 0x0b, 0x54, 0x65, 0x73, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12,
+	0x27, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63,
+	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x65, 0x73, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x58, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x73, 0x12, 0x28, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x61,
+	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x12, 0x56, 0x0a, 0x0b,
+	0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x27, 0x2e, 0x61, 0x67,
+	0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69,
+	0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x73, 0x12, 0x55, 0x0a, 0x08, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74,
+	0x12, 0x24, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
+	0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6c,
+	0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x42, 0xbf, 0x01, 0x0a, 0x17,
+	0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x11, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x22, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f,
+	0x64, 0x69, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x31,
+	0xa2, 0x02, 0x03, 0x41, 0x44, 0x53, 0xaa, 0x02, 0x13, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
+	0x44, 0x69, 0x72, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x13, 0x41,
+	0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x5c,
+	0x56, 0x31, 0xe2, 0x02, 0x1f, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x16, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x3a, 0x3a, 0x44,
+	0x69, 0x72, 0x3a, 0x3a, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_agntcy_dir_store_v1_admin_service_proto_rawDescOnce sync.Once
+	file_agntcy_dir_store_v1_admin_service_proto_rawDescData []byte
+)
+
+func file_agntcy_dir_store_v1_admin_service_proto_rawDescGZIP() []byte {
+	file_agntcy_dir_store_v1_admin_service_proto_rawDescOnce.Do(func() {
+		file_agntcy_dir_store_v1_admin_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_agntcy_dir_store_v1_admin_service_proto_rawDesc), len(file_agntcy_dir_store_v1_admin_service_proto_rawDesc)))
+	})
+	return file_agntcy_dir_store_v1_admin_service_proto_rawDescData
+}
+
+var file_agntcy_dir_store_v1_admin_service_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_agntcy_dir_store_v1_admin_service_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_agntcy_dir_store_v1_admin_service_proto_goTypes = []any{
+	(FsckIssueType)(0),           // 0: agntcy.dir.store.v1.FsckIssueType
+	(JournalOperation)(0),        // 1: agntcy.dir.store.v1.JournalOperation
+	(WebhookEventType)(0),        // 2: agntcy.dir.store.v1.WebhookEventType
+	(SelfTestStatus)(0),          // 3: agntcy.dir.store.v1.SelfTestStatus
+	(SelfTestFailure)(0),         // 4: agntcy.dir.store.v1.SelfTestFailure
+	(*FsckRequest)(nil),          // 5: agntcy.dir.store.v1.FsckRequest
+	(*FsckResponse)(nil),         // 6: agntcy.dir.store.v1.FsckResponse
+	(*FsckProgress)(nil),         // 7: agntcy.dir.store.v1.FsckProgress
+	(*FsckIssue)(nil),            // 8: agntcy.dir.store.v1.FsckIssue
+	(*FsckSummary)(nil),          // 9: agntcy.dir.store.v1.FsckSummary
+	(*ReshardRequest)(nil),       // 10: agntcy.dir.store.v1.ReshardRequest
+	(*ReshardResponse)(nil),      // 11: agntcy.dir.store.v1.ReshardResponse
+	(*ReshardMove)(nil),          // 12: agntcy.dir.store.v1.ReshardMove
+	(*ReshardSummary)(nil),       // 13: agntcy.dir.store.v1.ReshardSummary
+	(*ReadJournalRequest)(nil),   // 14: agntcy.dir.store.v1.ReadJournalRequest
+	(*JournalEntry)(nil),         // 15: agntcy.dir.store.v1.JournalEntry
+	(*Webhook)(nil),              // 16: agntcy.dir.store.v1.Webhook
+	(*ListWebhooksRequest)(nil),  // 17: agntcy.dir.store.v1.ListWebhooksRequest
+	(*RemoveWebhookRequest)(nil), // 18: agntcy.dir.store.v1.RemoveWebhookRequest
+	(*TestWebhookRequest)(nil),   // 19: agntcy.dir.store.v1.TestWebhookRequest
+	(*TestWebhookResponse)(nil),  // 20: agntcy.dir.store.v1.TestWebhookResponse
+	(*GetLogLevelsRequest)(nil),  // 21: agntcy.dir.store.v1.GetLogLevelsRequest
+	(*SetLogLevelRequest)(nil),   // 22: agntcy.dir.store.v1.SetLogLevelRequest
+	(*LogLevels)(nil),            // 23: agntcy.dir.store.v1.LogLevels
+	(*SelfTestRequest)(nil),      // 24: agntcy.dir.store.v1.SelfTestRequest
+	(*SelfTestStep)(nil),         // 25: agntcy.dir.store.v1.SelfTestStep
+	(*SelfTestReport)(nil),       // 26: agntcy.dir.store.v1.SelfTestReport
+	nil,                          // 27: agntcy.dir.store.v1.LogLevels.ComponentLevelsEntry
+	(*durationpb.Duration)(nil),  // 28: google.protobuf.Duration
+	(*emptypb.Empty)(nil),        // 29: google.protobuf.Empty
+}
+var file_agntcy_dir_store_v1_admin_service_proto_depIdxs = []int32{
+	7,  // 0: agntcy.dir.store.v1.FsckResponse.progress:type_name -> agntcy.dir.store.v1.FsckProgress
+	8,  // 1: agntcy.dir.store.v1.FsckResponse.issue:type_name -> agntcy.dir.store.v1.FsckIssue
+	9,  // 2: agntcy.dir.store.v1.FsckResponse.summary:type_name -> agntcy.dir.store.v1.FsckSummary
+	0,  // 3: agntcy.dir.store.v1.FsckIssue.type:type_name -> agntcy.dir.store.v1.FsckIssueType
+	12, // 4: agntcy.dir.store.v1.ReshardResponse.move:type_name -> agntcy.dir.store.v1.ReshardMove
+	13, // 5: agntcy.dir.store.v1.ReshardResponse.summary:type_name -> agntcy.dir.store.v1.ReshardSummary
+	1,  // 6: agntcy.dir.store.v1.JournalEntry.operation:type_name -> agntcy.dir.store.v1.JournalOperation
+	2,  // 7: agntcy.dir.store.v1.Webhook.event_types:type_name -> agntcy.dir.store.v1.WebhookEventType
+	28, // 8: agntcy.dir.store.v1.Webhook.initial_backoff:type_name -> google.protobuf.Duration
+	27, // 9: agntcy.dir.store.v1.LogLevels.component_levels:type_name -> agntcy.dir.store.v1.LogLevels.ComponentLevelsEntry
+	3,  // 10: agntcy.dir.store.v1.SelfTestStep.status:type_name -> agntcy.dir.store.v1.SelfTestStatus
+	28, // 11: agntcy.dir.store.v1.SelfTestStep.latency:type_name -> google.protobuf.Duration
+	4,  // 12: agntcy.dir.store.v1.SelfTestStep.failure:type_name -> agntcy.dir.store.v1.SelfTestFailure
+	25, // 13: agntcy.dir.store.v1.SelfTestReport.steps:type_name -> agntcy.dir.store.v1.SelfTestStep
+	5,  // 14: agntcy.dir.store.v1.AdminService.Fsck:input_type -> agntcy.dir.store.v1.FsckRequest
+	10, // 15: agntcy.dir.store.v1.AdminService.Reshard:input_type -> agntcy.dir.store.v1.ReshardRequest
+	14, // 16: agntcy.dir.store.v1.AdminService.ReadJournal:input_type -> agntcy.dir.store.v1.ReadJournalRequest
+	17, // 17: agntcy.dir.store.v1.AdminService.ListWebhooks:input_type -> agntcy.dir.store.v1.ListWebhooksRequest
+	16, // 18: agntcy.dir.store.v1.AdminService.AddWebhook:input_type -> agntcy.dir.store.v1.Webhook
+	18, // 19: agntcy.dir.store.v1.AdminService.RemoveWebhook:input_type -> agntcy.dir.store.v1.RemoveWebhookRequest
+	19, // 20: agntcy.dir.store.v1.AdminService.TestWebhook:input_type -> agntcy.dir.store.v1.TestWebhookRequest
+	21, // 21: agntcy.dir.store.v1.AdminService.GetLogLevels:input_type -> agntcy.dir.store.v1.GetLogLevelsRequest
+	22, // 22: agntcy.dir.store.v1.AdminService.SetLogLevel:input_type -> agntcy.dir.store.v1.SetLogLevelRequest
+	24, // 23: agntcy.dir.store.v1.AdminService.SelfTest:input_type -> agntcy.dir.store.v1.SelfTestRequest
+	6,  // 24: agntcy.dir.store.v1.AdminService.Fsck:output_type -> agntcy.dir.store.v1.FsckResponse
+	11, // 25: agntcy.dir.store.v1.AdminService.Reshard:output_type -> agntcy.dir.store.v1.ReshardResponse
+	15, // 26: agntcy.dir.store.v1.AdminService.ReadJournal:output_type -> agntcy.dir.store.v1.JournalEntry
+	16, // 27: agntcy.dir.store.v1.AdminService.ListWebhooks:output_type -> agntcy.dir.store.v1.Webhook
+	16, // 28: agntcy.dir.store.v1.AdminService.AddWebhook:output_type -> agntcy.dir.store.v1.Webhook
+	29, // 29: agntcy.dir.store.v1.AdminService.RemoveWebhook:output_type -> google.protobuf.Empty
+	20, // 30: agntcy.dir.store.v1.AdminService.TestWebhook:output_type -> agntcy.dir.store.v1.TestWebhookResponse
+	23, // 31: agntcy.dir.store.v1.AdminService.GetLogLevels:output_type -> agntcy.dir.store.v1.LogLevels
+	23, // 32: agntcy.dir.store.v1.AdminService.SetLogLevel:output_type -> agntcy.dir.store.v1.LogLevels
+	26, // 33: agntcy.dir.store.v1.AdminService.SelfTest:output_type -> agntcy.dir.store.v1.SelfTestReport
+	24, // [24:34] is the sub-list for method output_type
+	14, // [14:24] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_agntcy_dir_store_v1_admin_service_proto_init() }
+func file_agntcy_dir_store_v1_admin_service_proto_init() {
+	if File_agntcy_dir_store_v1_admin_service_proto != nil {
+		return
+	}
+	file_agntcy_dir_store_v1_admin_service_proto_msgTypes[1].OneofWrappers = []any{
+		(*FsckResponse_Progress)(nil),
+		(*FsckResponse_Issue)(nil),
+		(*FsckResponse_Summary)(nil),
+	}
+	file_agntcy_dir_store_v1_admin_service_proto_msgTypes[6].OneofWrappers = []any{
+		(*ReshardResponse_Move)(nil),
+		(*ReshardResponse_Summary)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_agntcy_dir_store_v1_admin_service_proto_rawDesc), len(file_agntcy_dir_store_v1_admin_service_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_agntcy_dir_store_v1_admin_service_proto_goTypes,
+		DependencyIndexes: file_agntcy_dir_store_v1_admin_service_proto_depIdxs,
+		EnumInfos:         file_agntcy_dir_store_v1_admin_service_proto_enumTypes,
+		MessageInfos:      file_agntcy_dir_store_v1_admin_service_proto_msgTypes,
+	}.Build()
+	File_agntcy_dir_store_v1_admin_service_proto = out.File
+	file_agntcy_dir_store_v1_admin_service_proto_goTypes = nil
+	file_agntcy_dir_store_v1_admin_service_proto_depIdxs = nil
+}
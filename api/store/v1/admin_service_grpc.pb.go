@@ -0,0 +1,656 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: agntcy/dir/store/v1/admin_service.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	AdminService_Fsck_FullMethodName          = "/agntcy.dir.store.v1.AdminService/Fsck"
+	AdminService_Reshard_FullMethodName       = "/agntcy.dir.store.v1.AdminService/Reshard"
+	AdminService_ReadJournal_FullMethodName   = "/agntcy.dir.store.v1.AdminService/ReadJournal"
+	AdminService_ListWebhooks_FullMethodName  = "/agntcy.dir.store.v1.AdminService/ListWebhooks"
+	AdminService_AddWebhook_FullMethodName    = "/agntcy.dir.store.v1.AdminService/AddWebhook"
+	AdminService_RemoveWebhook_FullMethodName = "/agntcy.dir.store.v1.AdminService/RemoveWebhook"
+	AdminService_TestWebhook_FullMethodName   = "/agntcy.dir.store.v1.AdminService/TestWebhook"
+	AdminService_GetLogLevels_FullMethodName  = "/agntcy.dir.store.v1.AdminService/GetLogLevels"
+	AdminService_SetLogLevel_FullMethodName   = "/agntcy.dir.store.v1.AdminService/SetLogLevel"
+	AdminService_SelfTest_FullMethodName      = "/agntcy.dir.store.v1.AdminService/SelfTest"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AdminService provides administrative operations on the store.
+type AdminServiceClient interface {
+	// Fsck checks the consistency of the store and optionally repairs it.
+	//
+	// All tags of the store are scanned. Every tag must resolve to a manifest whose
+	// record blob exists, the CID of a record must match the CID recomputed from its
+	// canonical bytes, and the discovery tags of a record must exist.
+	//
+	// Progress and detected issues are streamed while the check runs,
+	// the last response is the summary of the check.
+	Fsck(ctx context.Context, in *FsckRequest, opts ...grpc.CallOption) (AdminService_FsckClient, error)
+	// Reshard moves the records of a sharded store to the repositories
+	// resolved from their metadata by the current repository template.
+	//
+	// Records are moved with their referrers, such as signatures and lifecycle status,
+	// and their discovery tags. Every checked record is streamed,
+	// the last response is the summary of the migration.
+	Reshard(ctx context.Context, in *ReshardRequest, opts ...grpc.CallOption) (AdminService_ReshardClient, error)
+	// ReadJournal streams the entries of the operation journal, oldest first.
+	//
+	// The journal records every record pushed to or deleted from the store,
+	// so that lost records can be identified and recovered from other instances.
+	// Fails with FailedPrecondition if journaling is disabled.
+	ReadJournal(ctx context.Context, in *ReadJournalRequest, opts ...grpc.CallOption) (AdminService_ReadJournalClient, error)
+	// ListWebhooks streams the webhook subscriptions of the server,
+	// including the subscriptions of the server configuration.
+	// Secrets are never returned.
+	// Fails with FailedPrecondition if webhooks are disabled.
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (AdminService_ListWebhooksClient, error)
+	// AddWebhook subscribes an HTTP endpoint to store and routing events.
+	// The ID of the subscription is assigned by the server and returned without the secret.
+	AddWebhook(ctx context.Context, in *Webhook, opts ...grpc.CallOption) (*Webhook, error)
+	// RemoveWebhook removes a webhook subscription added with AddWebhook.
+	// Subscriptions of the server configuration cannot be removed.
+	RemoveWebhook(ctx context.Context, in *RemoveWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// TestWebhook delivers a test event to the endpoint of a webhook subscription,
+	// once and without retries, and reports the response of the endpoint.
+	TestWebhook(ctx context.Context, in *TestWebhookRequest, opts ...grpc.CallOption) (*TestWebhookResponse, error)
+	// GetLogLevels returns the default log level of the server
+	// and the log levels of the components overriding it.
+	GetLogLevels(ctx context.Context, in *GetLogLevelsRequest, opts ...grpc.CallOption) (*LogLevels, error)
+	// SetLogLevel changes the log level of a component and the components below it,
+	// e.g. "store/oci" also sets the level of "store/oci/tags", until the server restarts.
+	// Returns the resulting log levels.
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*LogLevels, error)
+	// SelfTest checks the store backend step by step: it pings the registry,
+	// pushes and deletes a probe blob in a dedicated repository, and lists tags.
+	// The report replaces the report of the startup self-test,
+	// which is also reported by the "store-selftest" component of the health service.
+	// Fails with Unimplemented if the store does not support self-tests.
+	SelfTest(ctx context.Context, in *SelfTestRequest, opts ...grpc.CallOption) (*SelfTestReport, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) Fsck(ctx context.Context, in *FsckRequest, opts ...grpc.CallOption) (AdminService_FsckClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AdminService_ServiceDesc.Streams[0], AdminService_Fsck_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceFsckClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_FsckClient interface {
+	Recv() (*FsckResponse, error)
+	grpc.ClientStream
+}
+
+type adminServiceFsckClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceFsckClient) Recv() (*FsckResponse, error) {
+	m := new(FsckResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) Reshard(ctx context.Context, in *ReshardRequest, opts ...grpc.CallOption) (AdminService_ReshardClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AdminService_ServiceDesc.Streams[1], AdminService_Reshard_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceReshardClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_ReshardClient interface {
+	Recv() (*ReshardResponse, error)
+	grpc.ClientStream
+}
+
+type adminServiceReshardClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceReshardClient) Recv() (*ReshardResponse, error) {
+	m := new(ReshardResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) ReadJournal(ctx context.Context, in *ReadJournalRequest, opts ...grpc.CallOption) (AdminService_ReadJournalClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AdminService_ServiceDesc.Streams[2], AdminService_ReadJournal_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceReadJournalClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_ReadJournalClient interface {
+	Recv() (*JournalEntry, error)
+	grpc.ClientStream
+}
+
+type adminServiceReadJournalClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceReadJournalClient) Recv() (*JournalEntry, error) {
+	m := new(JournalEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (AdminService_ListWebhooksClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AdminService_ServiceDesc.Streams[3], AdminService_ListWebhooks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceListWebhooksClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_ListWebhooksClient interface {
+	Recv() (*Webhook, error)
+	grpc.ClientStream
+}
+
+type adminServiceListWebhooksClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceListWebhooksClient) Recv() (*Webhook, error) {
+	m := new(Webhook)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) AddWebhook(ctx context.Context, in *Webhook, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, AdminService_AddWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RemoveWebhook(ctx context.Context, in *RemoveWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AdminService_RemoveWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) TestWebhook(ctx context.Context, in *TestWebhookRequest, opts ...grpc.CallOption) (*TestWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestWebhookResponse)
+	err := c.cc.Invoke(ctx, AdminService_TestWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetLogLevels(ctx context.Context, in *GetLogLevelsRequest, opts ...grpc.CallOption) (*LogLevels, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogLevels)
+	err := c.cc.Invoke(ctx, AdminService_GetLogLevels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*LogLevels, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogLevels)
+	err := c.cc.Invoke(ctx, AdminService_SetLogLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SelfTest(ctx context.Context, in *SelfTestRequest, opts ...grpc.CallOption) (*SelfTestReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SelfTestReport)
+	err := c.cc.Invoke(ctx, AdminService_SelfTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations should embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// AdminService provides administrative operations on the store.
+type AdminServiceServer interface {
+	// Fsck checks the consistency of the store and optionally repairs it.
+	//
+	// All tags of the store are scanned. Every tag must resolve to a manifest whose
+	// record blob exists, the CID of a record must match the CID recomputed from its
+	// canonical bytes, and the discovery tags of a record must exist.
+	//
+	// Progress and detected issues are streamed while the check runs,
+	// the last response is the summary of the check.
+	Fsck(*FsckRequest, AdminService_FsckServer) error
+	// Reshard moves the records of a sharded store to the repositories
+	// resolved from their metadata by the current repository template.
+	//
+	// Records are moved with their referrers, such as signatures and lifecycle status,
+	// and their discovery tags. Every checked record is streamed,
+	// the last response is the summary of the migration.
+	Reshard(*ReshardRequest, AdminService_ReshardServer) error
+	// ReadJournal streams the entries of the operation journal, oldest first.
+	//
+	// The journal records every record pushed to or deleted from the store,
+	// so that lost records can be identified and recovered from other instances.
+	// Fails with FailedPrecondition if journaling is disabled.
+	ReadJournal(*ReadJournalRequest, AdminService_ReadJournalServer) error
+	// ListWebhooks streams the webhook subscriptions of the server,
+	// including the subscriptions of the server configuration.
+	// Secrets are never returned.
+	// Fails with FailedPrecondition if webhooks are disabled.
+	ListWebhooks(*ListWebhooksRequest, AdminService_ListWebhooksServer) error
+	// AddWebhook subscribes an HTTP endpoint to store and routing events.
+	// The ID of the subscription is assigned by the server and returned without the secret.
+	AddWebhook(context.Context, *Webhook) (*Webhook, error)
+	// RemoveWebhook removes a webhook subscription added with AddWebhook.
+	// Subscriptions of the server configuration cannot be removed.
+	RemoveWebhook(context.Context, *RemoveWebhookRequest) (*emptypb.Empty, error)
+	// TestWebhook delivers a test event to the endpoint of a webhook subscription,
+	// once and without retries, and reports the response of the endpoint.
+	TestWebhook(context.Context, *TestWebhookRequest) (*TestWebhookResponse, error)
+	// GetLogLevels returns the default log level of the server
+	// and the log levels of the components overriding it.
+	GetLogLevels(context.Context, *GetLogLevelsRequest) (*LogLevels, error)
+	// SetLogLevel changes the log level of a component and the components below it,
+	// e.g. "store/oci" also sets the level of "store/oci/tags", until the server restarts.
+	// Returns the resulting log levels.
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*LogLevels, error)
+	// SelfTest checks the store backend step by step: it pings the registry,
+	// pushes and deletes a probe blob in a dedicated repository, and lists tags.
+	// The report replaces the report of the startup self-test,
+	// which is also reported by the "store-selftest" component of the health service.
+	// Fails with Unimplemented if the store does not support self-tests.
+	SelfTest(context.Context, *SelfTestRequest) (*SelfTestReport, error)
+}
+
+// UnimplementedAdminServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) Fsck(*FsckRequest, AdminService_FsckServer) error {
+	return status.Errorf(codes.Unimplemented, "method Fsck not implemented")
+}
+func (UnimplementedAdminServiceServer) Reshard(*ReshardRequest, AdminService_ReshardServer) error {
+	return status.Errorf(codes.Unimplemented, "method Reshard not implemented")
+}
+func (UnimplementedAdminServiceServer) ReadJournal(*ReadJournalRequest, AdminService_ReadJournalServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadJournal not implemented")
+}
+func (UnimplementedAdminServiceServer) ListWebhooks(*ListWebhooksRequest, AdminService_ListWebhooksServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedAdminServiceServer) AddWebhook(context.Context, *Webhook) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWebhook not implemented")
+}
+func (UnimplementedAdminServiceServer) RemoveWebhook(context.Context, *RemoveWebhookRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWebhook not implemented")
+}
+func (UnimplementedAdminServiceServer) TestWebhook(context.Context, *TestWebhookRequest) (*TestWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestWebhook not implemented")
+}
+func (UnimplementedAdminServiceServer) GetLogLevels(context.Context, *GetLogLevelsRequest) (*LogLevels, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogLevels not implemented")
+}
+func (UnimplementedAdminServiceServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*LogLevels, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedAdminServiceServer) SelfTest(context.Context, *SelfTestRequest) (*SelfTestReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelfTest not implemented")
+}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue() {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_Fsck_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FsckRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).Fsck(m, &adminServiceFsckServer{ServerStream: stream})
+}
+
+type AdminService_FsckServer interface {
+	Send(*FsckResponse) error
+	grpc.ServerStream
+}
+
+type adminServiceFsckServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceFsckServer) Send(m *FsckResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminService_Reshard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReshardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).Reshard(m, &adminServiceReshardServer{ServerStream: stream})
+}
+
+type AdminService_ReshardServer interface {
+	Send(*ReshardResponse) error
+	grpc.ServerStream
+}
+
+type adminServiceReshardServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceReshardServer) Send(m *ReshardResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminService_ReadJournal_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadJournalRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).ReadJournal(m, &adminServiceReadJournalServer{ServerStream: stream})
+}
+
+type AdminService_ReadJournalServer interface {
+	Send(*JournalEntry) error
+	grpc.ServerStream
+}
+
+type adminServiceReadJournalServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceReadJournalServer) Send(m *JournalEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminService_ListWebhooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListWebhooksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).ListWebhooks(m, &adminServiceListWebhooksServer{ServerStream: stream})
+}
+
+type AdminService_ListWebhooksServer interface {
+	Send(*Webhook) error
+	grpc.ServerStream
+}
+
+type adminServiceListWebhooksServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceListWebhooksServer) Send(m *Webhook) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminService_AddWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Webhook)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_AddWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddWebhook(ctx, req.(*Webhook))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RemoveWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RemoveWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RemoveWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RemoveWebhook(ctx, req.(*RemoveWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_TestWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).TestWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_TestWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).TestWebhook(ctx, req.(*TestWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogLevelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetLogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetLogLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetLogLevels(ctx, req.(*GetLogLevelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SelfTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelfTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SelfTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SelfTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SelfTest(ctx, req.(*SelfTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "agntcy.dir.store.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddWebhook",
+			Handler:    _AdminService_AddWebhook_Handler,
+		},
+		{
+			MethodName: "RemoveWebhook",
+			Handler:    _AdminService_RemoveWebhook_Handler,
+		},
+		{
+			MethodName: "TestWebhook",
+			Handler:    _AdminService_TestWebhook_Handler,
+		},
+		{
+			MethodName: "GetLogLevels",
+			Handler:    _AdminService_GetLogLevels_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _AdminService_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "SelfTest",
+			Handler:    _AdminService_SelfTest_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Fsck",
+			Handler:       _AdminService_Fsck_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Reshard",
+			Handler:       _AdminService_Reshard_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReadJournal",
+			Handler:       _AdminService_ReadJournal_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListWebhooks",
+			Handler:       _AdminService_ListWebhooks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "agntcy/dir/store/v1/admin_service.proto",
+}
@@ -0,0 +1,59 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// PushOverwriteMetadataKey is the gRPC metadata key of Push calls that store records
+// even if a record with the same name and version but different content is stored.
+// It only has an effect on servers enforcing unique names and versions.
+const PushOverwriteMetadataKey = "x-dir-push-overwrite"
+
+// ContextWithPushOverwrite returns a context whose Push calls overwrite records with the same name and version.
+func ContextWithPushOverwrite(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, PushOverwriteMetadataKey, "true")
+}
+
+// IsPushOverwrite reports whether the incoming call requests overwriting records with the same name and version.
+func IsPushOverwrite(ctx context.Context) bool {
+	values := metadata.ValueFromIncomingContext(ctx, PushOverwriteMetadataKey)
+
+	return len(values) > 0 && values[len(values)-1] == "true"
+}
+
+// DeleteForceMetadataKey is the gRPC metadata key of Delete and DeleteWithAck calls
+// that delete pinned records, unpublishing them first.
+const DeleteForceMetadataKey = "x-dir-delete-force"
+
+// ContextWithDeleteForce returns a context whose Delete calls unpublish and delete pinned records.
+func ContextWithDeleteForce(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, DeleteForceMetadataKey, "true")
+}
+
+// IsDeleteForce reports whether the incoming call requests deleting pinned records.
+func IsDeleteForce(ctx context.Context) bool {
+	values := metadata.ValueFromIncomingContext(ctx, DeleteForceMetadataKey)
+
+	return len(values) > 0 && values[len(values)-1] == "true"
+}
+
+// IncludeDeletedMetadataKey is the gRPC metadata key of Pull and Lookup calls
+// that return records deleted in soft deletion mode.
+const IncludeDeletedMetadataKey = "x-dir-include-deleted"
+
+// ContextWithIncludeDeleted returns a context whose Pull and Lookup calls return records in the trash.
+func ContextWithIncludeDeleted(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, IncludeDeletedMetadataKey, "true")
+}
+
+// IsIncludeDeleted reports whether the incoming call requests returning records in the trash.
+func IsIncludeDeleted(ctx context.Context) bool {
+	values := metadata.ValueFromIncomingContext(ctx, IncludeDeletedMetadataKey)
+
+	return len(values) > 0 && values[len(values)-1] == "true"
+}
@@ -0,0 +1,377 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        (unknown)
+// source: agntcy/dir/store/v1/stats_service.proto
+
+package v1
+
+import (
+	v1 "github.com/agntcy/dir/api/core/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TopRecordsOrder is the order of the records returned by TopRecords.
+type TopRecordsOrder int32
+
+const (
+	// Defaults to TOP_RECORDS_ORDER_PULLS.
+	TopRecordsOrder_TOP_RECORDS_ORDER_UNSPECIFIED TopRecordsOrder = 0
+	// Most pulled records first.
+	TopRecordsOrder_TOP_RECORDS_ORDER_PULLS TopRecordsOrder = 1
+	// Most recently accessed records first.
+	TopRecordsOrder_TOP_RECORDS_ORDER_RECENT TopRecordsOrder = 2
+)
+
+// Enum value maps for TopRecordsOrder.
+var (
+	TopRecordsOrder_name = map[int32]string{
+		0: "TOP_RECORDS_ORDER_UNSPECIFIED",
+		1: "TOP_RECORDS_ORDER_PULLS",
+		2: "TOP_RECORDS_ORDER_RECENT",
+	}
+	TopRecordsOrder_value = map[string]int32{
+		"TOP_RECORDS_ORDER_UNSPECIFIED": 0,
+		"TOP_RECORDS_ORDER_PULLS":       1,
+		"TOP_RECORDS_ORDER_RECENT":      2,
+	}
+)
+
+func (x TopRecordsOrder) Enum() *TopRecordsOrder {
+	p := new(TopRecordsOrder)
+	*p = x
+	return p
+}
+
+func (x TopRecordsOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TopRecordsOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_store_v1_stats_service_proto_enumTypes[0].Descriptor()
+}
+
+func (TopRecordsOrder) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_store_v1_stats_service_proto_enumTypes[0]
+}
+
+func (x TopRecordsOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TopRecordsOrder.Descriptor instead.
+func (TopRecordsOrder) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_stats_service_proto_rawDescGZIP(), []int{0}
+}
+
+// TopRecordsRequest specifies the records returned by TopRecords.
+type TopRecordsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Order of the returned records.
+	Order TopRecordsOrder `protobuf:"varint,1,opt,name=order,proto3,enum=agntcy.dir.store.v1.TopRecordsOrder" json:"order,omitempty"`
+	// Maximum number of returned records.
+	// If not set, 10 records are returned.
+	Limit         uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopRecordsRequest) Reset() {
+	*x = TopRecordsRequest{}
+	mi := &file_agntcy_dir_store_v1_stats_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopRecordsRequest) ProtoMessage() {}
+
+func (x *TopRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_stats_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopRecordsRequest.ProtoReflect.Descriptor instead.
+func (*TopRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_stats_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TopRecordsRequest) GetOrder() TopRecordsOrder {
+	if x != nil {
+		return x.Order
+	}
+	return TopRecordsOrder_TOP_RECORDS_ORDER_UNSPECIFIED
+}
+
+func (x *TopRecordsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// TopRecordsResponse contains the most used records.
+type TopRecordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*RecordStats         `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopRecordsResponse) Reset() {
+	*x = TopRecordsResponse{}
+	mi := &file_agntcy_dir_store_v1_stats_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopRecordsResponse) ProtoMessage() {}
+
+func (x *TopRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_stats_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopRecordsResponse.ProtoReflect.Descriptor instead.
+func (*TopRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_stats_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TopRecordsResponse) GetRecords() []*RecordStats {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+// RecordStats describes how a record is used.
+type RecordStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the record.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Number of times the record was pulled.
+	PullCount uint64 `protobuf:"varint,2,opt,name=pull_count,json=pullCount,proto3" json:"pull_count,omitempty"`
+	// Number of times the record metadata was looked up.
+	LookupCount uint64 `protobuf:"varint,3,opt,name=lookup_count,json=lookupCount,proto3" json:"lookup_count,omitempty"`
+	// Time of the last pull or lookup in the RFC3339 format.
+	// Empty if the record was never accessed.
+	LastAccessedAt string `protobuf:"bytes,4,opt,name=last_accessed_at,json=lastAccessedAt,proto3" json:"last_accessed_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RecordStats) Reset() {
+	*x = RecordStats{}
+	mi := &file_agntcy_dir_store_v1_stats_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordStats) ProtoMessage() {}
+
+func (x *RecordStats) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_store_v1_stats_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordStats.ProtoReflect.Descriptor instead.
+func (*RecordStats) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_store_v1_stats_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RecordStats) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *RecordStats) GetPullCount() uint64 {
+	if x != nil {
+		return x.PullCount
+	}
+	return 0
+}
+
+func (x *RecordStats) GetLookupCount() uint64 {
+	if x != nil {
+		return x.LookupCount
+	}
+	return 0
+}
+
+func (x *RecordStats) GetLastAccessedAt() string {
+	if x != nil {
+		return x.LastAccessedAt
+	}
+	return ""
+}
+
+var File_agntcy_dir_store_v1_stats_service_proto protoreflect.FileDescriptor
+
+var file_agntcy_dir_store_v1_stats_service_proto_rawDesc = string([]byte{
+	0x0a, 0x27, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64, 0x69, 0x72, 0x2f, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x61, 0x67, 0x6e, 0x74, 0x63,
+	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1f,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64, 0x69, 0x72, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f,
+	0x76, 0x31, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x65, 0x0a, 0x11, 0x54, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x50, 0x0a, 0x12, 0x54, 0x6f, 0x70, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x07,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x8b, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75,
+	0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x70, 0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x6f, 0x6f,
+	0x6b, 0x75, 0x70, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0b, 0x6c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x10,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x65, 0x64, 0x41, 0x74, 0x2a, 0x6f, 0x0a, 0x0f, 0x54, 0x6f, 0x70, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x1d, 0x54, 0x4f, 0x50,
+	0x5f, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x53, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17,
+	0x54, 0x4f, 0x50, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x53, 0x5f, 0x4f, 0x52, 0x44, 0x45,
+	0x52, 0x5f, 0x50, 0x55, 0x4c, 0x4c, 0x53, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x54, 0x4f, 0x50,
+	0x5f, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x53, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x52,
+	0x45, 0x43, 0x45, 0x4e, 0x54, 0x10, 0x02, 0x32, 0xba, 0x01, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x1d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69,
+	0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x52, 0x65, 0x66, 0x1a, 0x20, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x5d, 0x0a, 0x0a, 0x54, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x12, 0x26, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x61, 0x67,
+	0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0xbf, 0x01, 0x0a, 0x17, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x67, 0x6e,
+	0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x42, 0x11, 0x53, 0x74, 0x61, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64, 0x69, 0x72, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x44, 0x53, 0xaa,
+	0x02, 0x13, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x44, 0x69, 0x72, 0x2e, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x13, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44,
+	0x69, 0x72, 0x5c, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x1f, 0x41, 0x67,
+	0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x5c, 0x56,
+	0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x16,
+	0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x3a, 0x3a, 0x44, 0x69, 0x72, 0x3a, 0x3a, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_agntcy_dir_store_v1_stats_service_proto_rawDescOnce sync.Once
+	file_agntcy_dir_store_v1_stats_service_proto_rawDescData []byte
+)
+
+func file_agntcy_dir_store_v1_stats_service_proto_rawDescGZIP() []byte {
+	file_agntcy_dir_store_v1_stats_service_proto_rawDescOnce.Do(func() {
+		file_agntcy_dir_store_v1_stats_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_agntcy_dir_store_v1_stats_service_proto_rawDesc), len(file_agntcy_dir_store_v1_stats_service_proto_rawDesc)))
+	})
+	return file_agntcy_dir_store_v1_stats_service_proto_rawDescData
+}
+
+var file_agntcy_dir_store_v1_stats_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_agntcy_dir_store_v1_stats_service_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_agntcy_dir_store_v1_stats_service_proto_goTypes = []any{
+	(TopRecordsOrder)(0),       // 0: agntcy.dir.store.v1.TopRecordsOrder
+	(*TopRecordsRequest)(nil),  // 1: agntcy.dir.store.v1.TopRecordsRequest
+	(*TopRecordsResponse)(nil), // 2: agntcy.dir.store.v1.TopRecordsResponse
+	(*RecordStats)(nil),        // 3: agntcy.dir.store.v1.RecordStats
+	(*v1.RecordRef)(nil),       // 4: agntcy.dir.core.v1.RecordRef
+}
+var file_agntcy_dir_store_v1_stats_service_proto_depIdxs = []int32{
+	0, // 0: agntcy.dir.store.v1.TopRecordsRequest.order:type_name -> agntcy.dir.store.v1.TopRecordsOrder
+	3, // 1: agntcy.dir.store.v1.TopRecordsResponse.records:type_name -> agntcy.dir.store.v1.RecordStats
+	4, // 2: agntcy.dir.store.v1.StatsService.GetStats:input_type -> agntcy.dir.core.v1.RecordRef
+	1, // 3: agntcy.dir.store.v1.StatsService.TopRecords:input_type -> agntcy.dir.store.v1.TopRecordsRequest
+	3, // 4: agntcy.dir.store.v1.StatsService.GetStats:output_type -> agntcy.dir.store.v1.RecordStats
+	2, // 5: agntcy.dir.store.v1.StatsService.TopRecords:output_type -> agntcy.dir.store.v1.TopRecordsResponse
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_agntcy_dir_store_v1_stats_service_proto_init() }
+func file_agntcy_dir_store_v1_stats_service_proto_init() {
+	if File_agntcy_dir_store_v1_stats_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_agntcy_dir_store_v1_stats_service_proto_rawDesc), len(file_agntcy_dir_store_v1_stats_service_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_agntcy_dir_store_v1_stats_service_proto_goTypes,
+		DependencyIndexes: file_agntcy_dir_store_v1_stats_service_proto_depIdxs,
+		EnumInfos:         file_agntcy_dir_store_v1_stats_service_proto_enumTypes,
+		MessageInfos:      file_agntcy_dir_store_v1_stats_service_proto_msgTypes,
+	}.Build()
+	File_agntcy_dir_store_v1_stats_service_proto = out.File
+	file_agntcy_dir_store_v1_stats_service_proto_goTypes = nil
+	file_agntcy_dir_store_v1_stats_service_proto_depIdxs = nil
+}
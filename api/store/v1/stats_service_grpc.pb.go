@@ -0,0 +1,179 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: agntcy/dir/store/v1/stats_service.proto
+
+package v1
+
+import (
+	context "context"
+	v1 "github.com/agntcy/dir/api/core/v1"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	StatsService_GetStats_FullMethodName   = "/agntcy.dir.store.v1.StatsService/GetStats"
+	StatsService_TopRecords_FullMethodName = "/agntcy.dir.store.v1.StatsService/TopRecords"
+)
+
+// StatsServiceClient is the client API for StatsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StatsService provides access to the usage statistics of stored records,
+// e.g. to find records that are no longer used and can be retired.
+//
+// Pulls and lookups are counted in memory and written to the server database periodically,
+// so statistics can lag behind the calls by the configured flush interval.
+type StatsServiceClient interface {
+	// GetStats returns the usage statistics of a record.
+	// Records that were never pulled or looked up are reported with zero counts.
+	GetStats(ctx context.Context, in *v1.RecordRef, opts ...grpc.CallOption) (*RecordStats, error)
+	// TopRecords returns the most used records.
+	TopRecords(ctx context.Context, in *TopRecordsRequest, opts ...grpc.CallOption) (*TopRecordsResponse, error)
+}
+
+type statsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStatsServiceClient(cc grpc.ClientConnInterface) StatsServiceClient {
+	return &statsServiceClient{cc}
+}
+
+func (c *statsServiceClient) GetStats(ctx context.Context, in *v1.RecordRef, opts ...grpc.CallOption) (*RecordStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordStats)
+	err := c.cc.Invoke(ctx, StatsService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsServiceClient) TopRecords(ctx context.Context, in *TopRecordsRequest, opts ...grpc.CallOption) (*TopRecordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopRecordsResponse)
+	err := c.cc.Invoke(ctx, StatsService_TopRecords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StatsServiceServer is the server API for StatsService service.
+// All implementations should embed UnimplementedStatsServiceServer
+// for forward compatibility.
+//
+// StatsService provides access to the usage statistics of stored records,
+// e.g. to find records that are no longer used and can be retired.
+//
+// Pulls and lookups are counted in memory and written to the server database periodically,
+// so statistics can lag behind the calls by the configured flush interval.
+type StatsServiceServer interface {
+	// GetStats returns the usage statistics of a record.
+	// Records that were never pulled or looked up are reported with zero counts.
+	GetStats(context.Context, *v1.RecordRef) (*RecordStats, error)
+	// TopRecords returns the most used records.
+	TopRecords(context.Context, *TopRecordsRequest) (*TopRecordsResponse, error)
+}
+
+// UnimplementedStatsServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStatsServiceServer struct{}
+
+func (UnimplementedStatsServiceServer) GetStats(context.Context, *v1.RecordRef) (*RecordStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedStatsServiceServer) TopRecords(context.Context, *TopRecordsRequest) (*TopRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopRecords not implemented")
+}
+func (UnimplementedStatsServiceServer) testEmbeddedByValue() {}
+
+// UnsafeStatsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StatsServiceServer will
+// result in compilation errors.
+type UnsafeStatsServiceServer interface {
+	mustEmbedUnimplementedStatsServiceServer()
+}
+
+func RegisterStatsServiceServer(s grpc.ServiceRegistrar, srv StatsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStatsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StatsService_ServiceDesc, srv)
+}
+
+func _StatsService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.RecordRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).GetStats(ctx, req.(*v1.RecordRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsService_TopRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).TopRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsService_TopRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).TopRecords(ctx, req.(*TopRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StatsService_ServiceDesc is the grpc.ServiceDesc for StatsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StatsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "agntcy.dir.store.v1.StatsService",
+	HandlerType: (*StatsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStats",
+			Handler:    _StatsService_GetStats_Handler,
+		},
+		{
+			MethodName: "TopRecords",
+			Handler:    _StatsService_TopRecords_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "agntcy/dir/store/v1/stats_service.proto",
+}
@@ -24,12 +24,25 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	StoreService_Push_FullMethodName         = "/agntcy.dir.store.v1.StoreService/Push"
-	StoreService_Pull_FullMethodName         = "/agntcy.dir.store.v1.StoreService/Pull"
-	StoreService_Lookup_FullMethodName       = "/agntcy.dir.store.v1.StoreService/Lookup"
-	StoreService_Delete_FullMethodName       = "/agntcy.dir.store.v1.StoreService/Delete"
-	StoreService_PushReferrer_FullMethodName = "/agntcy.dir.store.v1.StoreService/PushReferrer"
-	StoreService_PullReferrer_FullMethodName = "/agntcy.dir.store.v1.StoreService/PullReferrer"
+	StoreService_Push_FullMethodName          = "/agntcy.dir.store.v1.StoreService/Push"
+	StoreService_Pull_FullMethodName          = "/agntcy.dir.store.v1.StoreService/Pull"
+	StoreService_Lookup_FullMethodName        = "/agntcy.dir.store.v1.StoreService/Lookup"
+	StoreService_Delete_FullMethodName        = "/agntcy.dir.store.v1.StoreService/Delete"
+	StoreService_DeleteWithAck_FullMethodName = "/agntcy.dir.store.v1.StoreService/DeleteWithAck"
+	StoreService_PushReferrer_FullMethodName  = "/agntcy.dir.store.v1.StoreService/PushReferrer"
+	StoreService_PullReferrer_FullMethodName  = "/agntcy.dir.store.v1.StoreService/PullReferrer"
+	StoreService_Resolve_FullMethodName       = "/agntcy.dir.store.v1.StoreService/Resolve"
+	StoreService_PushDryRun_FullMethodName    = "/agntcy.dir.store.v1.StoreService/PushDryRun"
+	StoreService_PushBundle_FullMethodName    = "/agntcy.dir.store.v1.StoreService/PushBundle"
+	StoreService_PullBundle_FullMethodName    = "/agntcy.dir.store.v1.StoreService/PullBundle"
+	StoreService_SetLifecycle_FullMethodName  = "/agntcy.dir.store.v1.StoreService/SetLifecycle"
+	StoreService_SetMetadata_FullMethodName   = "/agntcy.dir.store.v1.StoreService/SetMetadata"
+	StoreService_GetMetadata_FullMethodName   = "/agntcy.dir.store.v1.StoreService/GetMetadata"
+	StoreService_SetACL_FullMethodName        = "/agntcy.dir.store.v1.StoreService/SetACL"
+	StoreService_WatchStore_FullMethodName    = "/agntcy.dir.store.v1.StoreService/WatchStore"
+	StoreService_Restore_FullMethodName       = "/agntcy.dir.store.v1.StoreService/Restore"
+	StoreService_ListTrash_FullMethodName     = "/agntcy.dir.store.v1.StoreService/ListTrash"
+	StoreService_Purge_FullMethodName         = "/agntcy.dir.store.v1.StoreService/Purge"
 )
 
 // StoreServiceClient is the client API for StoreService service.
@@ -50,7 +63,9 @@ const (
 //
 // Each operation is performed sequentially, meaning that
 // for the N-th request, N-th response will be returned.
-// If an error occurs, the stream will be cancelled.
+// If an error occurs, the stream will be cancelled, except for
+// Pull, Lookup and DeleteWithAck which report failures for individual references
+// via the error field of the response and continue with the rest.
 type StoreServiceClient interface {
 	// Push performs write operation for given records.
 	Push(ctx context.Context, opts ...grpc.CallOption) (StoreService_PushClient, error)
@@ -60,10 +75,61 @@ type StoreServiceClient interface {
 	Lookup(ctx context.Context, opts ...grpc.CallOption) (StoreService_LookupClient, error)
 	// Remove performs delete operation for the records.
 	Delete(ctx context.Context, opts ...grpc.CallOption) (StoreService_DeleteClient, error)
+	// DeleteWithAck performs delete operation for the records,
+	// acknowledging each record once it has been deleted.
+	// Failures are reported via the error field of the response
+	// and do not cancel the stream.
+	DeleteWithAck(ctx context.Context, opts ...grpc.CallOption) (StoreService_DeleteWithAckClient, error)
 	// PushReferrer performs write operation for record referrers.
 	PushReferrer(ctx context.Context, opts ...grpc.CallOption) (StoreService_PushReferrerClient, error)
 	// PullReferrer performs read operation for record referrers.
 	PullReferrer(ctx context.Context, opts ...grpc.CallOption) (StoreService_PullReferrerClient, error)
+	// Resolve resolves a discovery tag, e.g. "my-agent:latest", to the record it currently points to.
+	// Returns NOT_FOUND if no record is tagged with the given tag.
+	Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*ResolveResponse, error)
+	// PushDryRun previews what Push would do for the given record without storing anything.
+	// The record goes through the same marshaling, CID computation, validation,
+	// annotation and tag generation as on Push.
+	PushDryRun(ctx context.Context, in *v1.Record, opts ...grpc.CallOption) (*PushPreview, error)
+	// PushBundle stores a bundle manifest and returns its reference.
+	// All member records must already exist in the store, otherwise FAILED_PRECONDITION is returned.
+	PushBundle(ctx context.Context, in *v1.RecordBundle, opts ...grpc.CallOption) (*v1.RecordRef, error)
+	// PullBundle returns the bundle manifest stored under the given CID.
+	// Member records are pulled separately with Pull.
+	PullBundle(ctx context.Context, in *v1.RecordRef, opts ...grpc.CallOption) (*v1.RecordBundle, error)
+	// SetLifecycle sets the lifecycle status of a record, e.g. to deprecate it,
+	// and returns the updated record metadata.
+	// Only callers of the trust domain that pushed the record can change its status.
+	SetLifecycle(ctx context.Context, in *SetLifecycleRequest, opts ...grpc.CallOption) (*v1.RecordMeta, error)
+	// SetMetadata updates the operational metadata of a record, e.g. its owner team or cost center,
+	// and returns the updated record metadata.
+	// Operational metadata is stored apart from the record content, so updating it does not change the CID.
+	// Only callers of the trust domain that pushed the record can update its metadata.
+	SetMetadata(ctx context.Context, in *SetMetadataRequest, opts ...grpc.CallOption) (*v1.RecordMeta, error)
+	// GetMetadata returns the operational metadata of a record.
+	// Only callers of the trust domain of the server can get the metadata of records.
+	GetMetadata(ctx context.Context, in *GetMetadataRequest, opts ...grpc.CallOption) (*GetMetadataResponse, error)
+	// SetACL sets the access control list of a record and returns the updated record metadata.
+	// An ACL without patterns removes the access control list of the record.
+	// Only the caller that first pushed the record can set its access control list.
+	SetACL(ctx context.Context, in *SetACLRequest, opts ...grpc.CallOption) (*v1.RecordMeta, error)
+	// WatchStore streams the changes of the store, oldest first, starting after the given sequence number,
+	// and keeps streaming new changes as they happen until the call is canceled.
+	// Events are read from the operation journal, FAILED_PRECONDITION is returned if it is not enabled.
+	// Watchers can resume after a disconnection from the sequence number of the last received event.
+	// OUT_OF_RANGE is returned if events after that sequence number are no longer retained,
+	// in which case the watcher has to rescan the store, and RESOURCE_EXHAUSTED if the watcher
+	// falls too far behind the changes of the store.
+	WatchStore(ctx context.Context, in *WatchStoreRequest, opts ...grpc.CallOption) (StoreService_WatchStoreClient, error)
+	// Restore reinstates a record deleted in soft deletion mode, restoring its tags, search index entry
+	// and publication, and returns its metadata.
+	// NOT_FOUND is returned if the record is not in the trash, FAILED_PRECONDITION if its retention expired.
+	Restore(ctx context.Context, in *v1.RecordRef, opts ...grpc.CallOption) (*v1.RecordMeta, error)
+	// ListTrash streams the records deleted in soft deletion mode, most recently deleted first.
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (StoreService_ListTrashClient, error)
+	// Purge permanently deletes a record deleted in soft deletion mode before its retention expires.
+	// FAILED_PRECONDITION is returned if the record is not in the trash.
+	Purge(ctx context.Context, in *v1.RecordRef, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type storeServiceClient struct {
@@ -205,9 +271,41 @@ func (x *storeServiceDeleteClient) CloseAndRecv() (*emptypb.Empty, error) {
 	return m, nil
 }
 
+func (c *storeServiceClient) DeleteWithAck(ctx context.Context, opts ...grpc.CallOption) (StoreService_DeleteWithAckClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StoreService_ServiceDesc.Streams[4], StoreService_DeleteWithAck_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storeServiceDeleteWithAckClient{ClientStream: stream}
+	return x, nil
+}
+
+type StoreService_DeleteWithAckClient interface {
+	Send(*v1.RecordRef) error
+	Recv() (*DeleteResponse, error)
+	grpc.ClientStream
+}
+
+type storeServiceDeleteWithAckClient struct {
+	grpc.ClientStream
+}
+
+func (x *storeServiceDeleteWithAckClient) Send(m *v1.RecordRef) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *storeServiceDeleteWithAckClient) Recv() (*DeleteResponse, error) {
+	m := new(DeleteResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *storeServiceClient) PushReferrer(ctx context.Context, opts ...grpc.CallOption) (StoreService_PushReferrerClient, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StoreService_ServiceDesc.Streams[4], StoreService_PushReferrer_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &StoreService_ServiceDesc.Streams[5], StoreService_PushReferrer_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -239,7 +337,7 @@ func (x *storeServicePushReferrerClient) Recv() (*PushReferrerResponse, error) {
 
 func (c *storeServiceClient) PullReferrer(ctx context.Context, opts ...grpc.CallOption) (StoreService_PullReferrerClient, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StoreService_ServiceDesc.Streams[5], StoreService_PullReferrer_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &StoreService_ServiceDesc.Streams[6], StoreService_PullReferrer_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -269,6 +367,172 @@ func (x *storeServicePullReferrerClient) Recv() (*PullReferrerResponse, error) {
 	return m, nil
 }
 
+func (c *storeServiceClient) Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*ResolveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveResponse)
+	err := c.cc.Invoke(ctx, StoreService_Resolve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) PushDryRun(ctx context.Context, in *v1.Record, opts ...grpc.CallOption) (*PushPreview, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PushPreview)
+	err := c.cc.Invoke(ctx, StoreService_PushDryRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) PushBundle(ctx context.Context, in *v1.RecordBundle, opts ...grpc.CallOption) (*v1.RecordRef, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.RecordRef)
+	err := c.cc.Invoke(ctx, StoreService_PushBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) PullBundle(ctx context.Context, in *v1.RecordRef, opts ...grpc.CallOption) (*v1.RecordBundle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.RecordBundle)
+	err := c.cc.Invoke(ctx, StoreService_PullBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) SetLifecycle(ctx context.Context, in *SetLifecycleRequest, opts ...grpc.CallOption) (*v1.RecordMeta, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.RecordMeta)
+	err := c.cc.Invoke(ctx, StoreService_SetLifecycle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) SetMetadata(ctx context.Context, in *SetMetadataRequest, opts ...grpc.CallOption) (*v1.RecordMeta, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.RecordMeta)
+	err := c.cc.Invoke(ctx, StoreService_SetMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) GetMetadata(ctx context.Context, in *GetMetadataRequest, opts ...grpc.CallOption) (*GetMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMetadataResponse)
+	err := c.cc.Invoke(ctx, StoreService_GetMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) SetACL(ctx context.Context, in *SetACLRequest, opts ...grpc.CallOption) (*v1.RecordMeta, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.RecordMeta)
+	err := c.cc.Invoke(ctx, StoreService_SetACL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) WatchStore(ctx context.Context, in *WatchStoreRequest, opts ...grpc.CallOption) (StoreService_WatchStoreClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StoreService_ServiceDesc.Streams[7], StoreService_WatchStore_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storeServiceWatchStoreClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StoreService_WatchStoreClient interface {
+	Recv() (*StoreEvent, error)
+	grpc.ClientStream
+}
+
+type storeServiceWatchStoreClient struct {
+	grpc.ClientStream
+}
+
+func (x *storeServiceWatchStoreClient) Recv() (*StoreEvent, error) {
+	m := new(StoreEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storeServiceClient) Restore(ctx context.Context, in *v1.RecordRef, opts ...grpc.CallOption) (*v1.RecordMeta, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.RecordMeta)
+	err := c.cc.Invoke(ctx, StoreService_Restore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (StoreService_ListTrashClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StoreService_ServiceDesc.Streams[8], StoreService_ListTrash_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storeServiceListTrashClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StoreService_ListTrashClient interface {
+	Recv() (*TrashedRecord, error)
+	grpc.ClientStream
+}
+
+type storeServiceListTrashClient struct {
+	grpc.ClientStream
+}
+
+func (x *storeServiceListTrashClient) Recv() (*TrashedRecord, error) {
+	m := new(TrashedRecord)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storeServiceClient) Purge(ctx context.Context, in *v1.RecordRef, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StoreService_Purge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StoreServiceServer is the server API for StoreService service.
 // All implementations should embed UnimplementedStoreServiceServer
 // for forward compatibility.
@@ -287,7 +551,9 @@ func (x *storeServicePullReferrerClient) Recv() (*PullReferrerResponse, error) {
 //
 // Each operation is performed sequentially, meaning that
 // for the N-th request, N-th response will be returned.
-// If an error occurs, the stream will be cancelled.
+// If an error occurs, the stream will be cancelled, except for
+// Pull, Lookup and DeleteWithAck which report failures for individual references
+// via the error field of the response and continue with the rest.
 type StoreServiceServer interface {
 	// Push performs write operation for given records.
 	Push(StoreService_PushServer) error
@@ -297,10 +563,61 @@ type StoreServiceServer interface {
 	Lookup(StoreService_LookupServer) error
 	// Remove performs delete operation for the records.
 	Delete(StoreService_DeleteServer) error
+	// DeleteWithAck performs delete operation for the records,
+	// acknowledging each record once it has been deleted.
+	// Failures are reported via the error field of the response
+	// and do not cancel the stream.
+	DeleteWithAck(StoreService_DeleteWithAckServer) error
 	// PushReferrer performs write operation for record referrers.
 	PushReferrer(StoreService_PushReferrerServer) error
 	// PullReferrer performs read operation for record referrers.
 	PullReferrer(StoreService_PullReferrerServer) error
+	// Resolve resolves a discovery tag, e.g. "my-agent:latest", to the record it currently points to.
+	// Returns NOT_FOUND if no record is tagged with the given tag.
+	Resolve(context.Context, *ResolveRequest) (*ResolveResponse, error)
+	// PushDryRun previews what Push would do for the given record without storing anything.
+	// The record goes through the same marshaling, CID computation, validation,
+	// annotation and tag generation as on Push.
+	PushDryRun(context.Context, *v1.Record) (*PushPreview, error)
+	// PushBundle stores a bundle manifest and returns its reference.
+	// All member records must already exist in the store, otherwise FAILED_PRECONDITION is returned.
+	PushBundle(context.Context, *v1.RecordBundle) (*v1.RecordRef, error)
+	// PullBundle returns the bundle manifest stored under the given CID.
+	// Member records are pulled separately with Pull.
+	PullBundle(context.Context, *v1.RecordRef) (*v1.RecordBundle, error)
+	// SetLifecycle sets the lifecycle status of a record, e.g. to deprecate it,
+	// and returns the updated record metadata.
+	// Only callers of the trust domain that pushed the record can change its status.
+	SetLifecycle(context.Context, *SetLifecycleRequest) (*v1.RecordMeta, error)
+	// SetMetadata updates the operational metadata of a record, e.g. its owner team or cost center,
+	// and returns the updated record metadata.
+	// Operational metadata is stored apart from the record content, so updating it does not change the CID.
+	// Only callers of the trust domain that pushed the record can update its metadata.
+	SetMetadata(context.Context, *SetMetadataRequest) (*v1.RecordMeta, error)
+	// GetMetadata returns the operational metadata of a record.
+	// Only callers of the trust domain of the server can get the metadata of records.
+	GetMetadata(context.Context, *GetMetadataRequest) (*GetMetadataResponse, error)
+	// SetACL sets the access control list of a record and returns the updated record metadata.
+	// An ACL without patterns removes the access control list of the record.
+	// Only the caller that first pushed the record can set its access control list.
+	SetACL(context.Context, *SetACLRequest) (*v1.RecordMeta, error)
+	// WatchStore streams the changes of the store, oldest first, starting after the given sequence number,
+	// and keeps streaming new changes as they happen until the call is canceled.
+	// Events are read from the operation journal, FAILED_PRECONDITION is returned if it is not enabled.
+	// Watchers can resume after a disconnection from the sequence number of the last received event.
+	// OUT_OF_RANGE is returned if events after that sequence number are no longer retained,
+	// in which case the watcher has to rescan the store, and RESOURCE_EXHAUSTED if the watcher
+	// falls too far behind the changes of the store.
+	WatchStore(*WatchStoreRequest, StoreService_WatchStoreServer) error
+	// Restore reinstates a record deleted in soft deletion mode, restoring its tags, search index entry
+	// and publication, and returns its metadata.
+	// NOT_FOUND is returned if the record is not in the trash, FAILED_PRECONDITION if its retention expired.
+	Restore(context.Context, *v1.RecordRef) (*v1.RecordMeta, error)
+	// ListTrash streams the records deleted in soft deletion mode, most recently deleted first.
+	ListTrash(*ListTrashRequest, StoreService_ListTrashServer) error
+	// Purge permanently deletes a record deleted in soft deletion mode before its retention expires.
+	// FAILED_PRECONDITION is returned if the record is not in the trash.
+	Purge(context.Context, *v1.RecordRef) (*emptypb.Empty, error)
 }
 
 // UnimplementedStoreServiceServer should be embedded to have
@@ -322,12 +639,51 @@ func (UnimplementedStoreServiceServer) Lookup(StoreService_LookupServer) error {
 func (UnimplementedStoreServiceServer) Delete(StoreService_DeleteServer) error {
 	return status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedStoreServiceServer) DeleteWithAck(StoreService_DeleteWithAckServer) error {
+	return status.Errorf(codes.Unimplemented, "method DeleteWithAck not implemented")
+}
 func (UnimplementedStoreServiceServer) PushReferrer(StoreService_PushReferrerServer) error {
 	return status.Errorf(codes.Unimplemented, "method PushReferrer not implemented")
 }
 func (UnimplementedStoreServiceServer) PullReferrer(StoreService_PullReferrerServer) error {
 	return status.Errorf(codes.Unimplemented, "method PullReferrer not implemented")
 }
+func (UnimplementedStoreServiceServer) Resolve(context.Context, *ResolveRequest) (*ResolveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resolve not implemented")
+}
+func (UnimplementedStoreServiceServer) PushDryRun(context.Context, *v1.Record) (*PushPreview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushDryRun not implemented")
+}
+func (UnimplementedStoreServiceServer) PushBundle(context.Context, *v1.RecordBundle) (*v1.RecordRef, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushBundle not implemented")
+}
+func (UnimplementedStoreServiceServer) PullBundle(context.Context, *v1.RecordRef) (*v1.RecordBundle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PullBundle not implemented")
+}
+func (UnimplementedStoreServiceServer) SetLifecycle(context.Context, *SetLifecycleRequest) (*v1.RecordMeta, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLifecycle not implemented")
+}
+func (UnimplementedStoreServiceServer) SetMetadata(context.Context, *SetMetadataRequest) (*v1.RecordMeta, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMetadata not implemented")
+}
+func (UnimplementedStoreServiceServer) GetMetadata(context.Context, *GetMetadataRequest) (*GetMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetadata not implemented")
+}
+func (UnimplementedStoreServiceServer) SetACL(context.Context, *SetACLRequest) (*v1.RecordMeta, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetACL not implemented")
+}
+func (UnimplementedStoreServiceServer) WatchStore(*WatchStoreRequest, StoreService_WatchStoreServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchStore not implemented")
+}
+func (UnimplementedStoreServiceServer) Restore(context.Context, *v1.RecordRef) (*v1.RecordMeta, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedStoreServiceServer) ListTrash(*ListTrashRequest, StoreService_ListTrashServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedStoreServiceServer) Purge(context.Context, *v1.RecordRef) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Purge not implemented")
+}
 func (UnimplementedStoreServiceServer) testEmbeddedByValue() {}
 
 // UnsafeStoreServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -452,6 +808,32 @@ func (x *storeServiceDeleteServer) Recv() (*v1.RecordRef, error) {
 	return m, nil
 }
 
+func _StoreService_DeleteWithAck_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StoreServiceServer).DeleteWithAck(&storeServiceDeleteWithAckServer{ServerStream: stream})
+}
+
+type StoreService_DeleteWithAckServer interface {
+	Send(*DeleteResponse) error
+	Recv() (*v1.RecordRef, error)
+	grpc.ServerStream
+}
+
+type storeServiceDeleteWithAckServer struct {
+	grpc.ServerStream
+}
+
+func (x *storeServiceDeleteWithAckServer) Send(m *DeleteResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *storeServiceDeleteWithAckServer) Recv() (*v1.RecordRef, error) {
+	m := new(v1.RecordRef)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _StoreService_PushReferrer_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(StoreServiceServer).PushReferrer(&storeServicePushReferrerServer{ServerStream: stream})
 }
@@ -504,13 +886,276 @@ func (x *storeServicePullReferrerServer) Recv() (*PullReferrerRequest, error) {
 	return m, nil
 }
 
+func _StoreService_Resolve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).Resolve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_Resolve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).Resolve(ctx, req.(*ResolveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_PushDryRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.Record)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).PushDryRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_PushDryRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).PushDryRun(ctx, req.(*v1.Record))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_PushBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.RecordBundle)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).PushBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_PushBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).PushBundle(ctx, req.(*v1.RecordBundle))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_PullBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.RecordRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).PullBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_PullBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).PullBundle(ctx, req.(*v1.RecordRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_SetLifecycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLifecycleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).SetLifecycle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_SetLifecycle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).SetLifecycle(ctx, req.(*SetLifecycleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_SetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).SetMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_SetMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).SetMetadata(ctx, req.(*SetMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_GetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).GetMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_GetMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).GetMetadata(ctx, req.(*GetMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_SetACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetACLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).SetACL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_SetACL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).SetACL(ctx, req.(*SetACLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_WatchStore_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStoreRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StoreServiceServer).WatchStore(m, &storeServiceWatchStoreServer{ServerStream: stream})
+}
+
+type StoreService_WatchStoreServer interface {
+	Send(*StoreEvent) error
+	grpc.ServerStream
+}
+
+type storeServiceWatchStoreServer struct {
+	grpc.ServerStream
+}
+
+func (x *storeServiceWatchStoreServer) Send(m *StoreEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StoreService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.RecordRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).Restore(ctx, req.(*v1.RecordRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_ListTrash_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListTrashRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StoreServiceServer).ListTrash(m, &storeServiceListTrashServer{ServerStream: stream})
+}
+
+type StoreService_ListTrashServer interface {
+	Send(*TrashedRecord) error
+	grpc.ServerStream
+}
+
+type storeServiceListTrashServer struct {
+	grpc.ServerStream
+}
+
+func (x *storeServiceListTrashServer) Send(m *TrashedRecord) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StoreService_Purge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.RecordRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).Purge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_Purge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).Purge(ctx, req.(*v1.RecordRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StoreService_ServiceDesc is the grpc.ServiceDesc for StoreService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var StoreService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "agntcy.dir.store.v1.StoreService",
 	HandlerType: (*StoreServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Resolve",
+			Handler:    _StoreService_Resolve_Handler,
+		},
+		{
+			MethodName: "PushDryRun",
+			Handler:    _StoreService_PushDryRun_Handler,
+		},
+		{
+			MethodName: "PushBundle",
+			Handler:    _StoreService_PushBundle_Handler,
+		},
+		{
+			MethodName: "PullBundle",
+			Handler:    _StoreService_PullBundle_Handler,
+		},
+		{
+			MethodName: "SetLifecycle",
+			Handler:    _StoreService_SetLifecycle_Handler,
+		},
+		{
+			MethodName: "SetMetadata",
+			Handler:    _StoreService_SetMetadata_Handler,
+		},
+		{
+			MethodName: "GetMetadata",
+			Handler:    _StoreService_GetMetadata_Handler,
+		},
+		{
+			MethodName: "SetACL",
+			Handler:    _StoreService_SetACL_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _StoreService_Restore_Handler,
+		},
+		{
+			MethodName: "Purge",
+			Handler:    _StoreService_Purge_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Push",
@@ -535,6 +1180,12 @@ var StoreService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _StoreService_Delete_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DeleteWithAck",
+			Handler:       _StoreService_DeleteWithAck_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "PushReferrer",
 			Handler:       _StoreService_PushReferrer_Handler,
@@ -547,6 +1198,16 @@ var StoreService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "WatchStore",
+			Handler:       _StoreService_WatchStore_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListTrash",
+			Handler:       _StoreService_ListTrash_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "agntcy/dir/store/v1/store_service.proto",
 }
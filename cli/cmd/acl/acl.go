@@ -0,0 +1,40 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package acl
+
+import (
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "acl",
+	Short: "Manage the access control lists of records",
+	Long: `Manage the access control lists of records in the Directory store.
+
+Access control lists restrict the callers that can pull or delete a record
+beyond the authorization of the trust domain. Patterns are SPIFFE IDs where
+"*" matches any characters within a path segment and "**" matches any
+characters across segments. The caller that first pushed a record owns it,
+is always allowed and is the only one that can change its access control list.
+
+- set: Set the access control list of a record
+
+The owner and access control list of a record are shown by "dirctl info".
+
+Examples:
+
+1. Only allow team-a to pull a record:
+   dirctl acl set <cid> --allow-pull "spiffe://example.org/ns/team-a/**"
+
+2. Remove the access control list of a record:
+   dirctl acl set <cid>
+`,
+}
+
+func init() {
+	Command.AddCommand(setCmd)
+
+	presenter.AddOutputFlags(setCmd)
+}
@@ -0,0 +1,95 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package acl
+
+import (
+	"errors"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var setOpts struct {
+	AllowPull   []string
+	AllowDelete []string
+}
+
+var setCmd = &cobra.Command{
+	Use:   "set <cid|name@version>",
+	Short: "Set the access control list of a record",
+	Long: `Set the access control list of a record, replacing the current one.
+
+Operations without patterns are not restricted, setting an access control
+list without patterns removes the restrictions of the record.
+
+Only the caller that first pushed the record may set its access control list.
+
+Usage examples:
+
+1. Only allow team-a to pull a record and its signatures:
+   dirctl acl set <cid> --allow-pull "spiffe://example.org/ns/team-a/**"
+
+2. Only allow the release agent to delete a record or update its metadata:
+   dirctl acl set <cid> --allow-delete spiffe://example.org/ns/ci/release
+
+3. Remove the access control list:
+   dirctl acl set <cid>
+`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.CIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSetCommand(cmd, args[0])
+	},
+}
+
+func init() {
+	setCmd.Flags().StringArrayVar(&setOpts.AllowPull, "allow-pull", nil, "SPIFFE ID pattern of the callers allowed to pull the record (repeatable)")
+	setCmd.Flags().StringArrayVar(&setOpts.AllowDelete, "allow-delete", nil, "SPIFFE ID pattern of the callers allowed to delete the record (repeatable)")
+}
+
+func runSetCommand(cmd *cobra.Command, ref string) error {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	acl := &corev1.RecordACL{AllowPull: setOpts.AllowPull, AllowDelete: setOpts.AllowDelete}
+
+	meta, err := c.SetRecordACL(cmd.Context(), &corev1.RecordRef{Cid: ref}, acl)
+	if err != nil {
+		return err
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "record", "Record access control list", meta)
+	}
+
+	if acl.IsEmpty() {
+		presenter.Printf(cmd, "Removed the access control list of record %s\n", meta.GetCid())
+
+		return nil
+	}
+
+	presenter.Printf(cmd, "Access control list of record %s:\n", meta.GetCid())
+	presenter.Printf(cmd, "  owner:        %s\n", meta.GetOwner())
+	presenter.Printf(cmd, "  allow pull:   %s\n", patterns(meta.GetAcl().GetAllowPull()))
+	presenter.Printf(cmd, "  allow delete: %s\n", patterns(meta.GetAcl().GetAllowDelete()))
+
+	return nil
+}
+
+// patterns formats the patterns of an operation, which is not restricted if there are none.
+func patterns(patterns []string) string {
+	if len(patterns) == 0 {
+		return "(anyone authorized)"
+	}
+
+	return strings.Join(patterns, ", ")
+}
@@ -0,0 +1,65 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package admin
+
+import (
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "admin",
+	Short: "Administrative operations on the Directory server",
+	Long: `Administrative operations on the Directory server.
+
+Admin operations are restricted to clients of the server's own trust domain.
+
+- fsck: Check the consistency of the store and repair it
+- reshard: Move records to the repositories of the sharding template
+- journal: Read the journal of pushed and deleted records
+- migrate: Convert stored records to another schema version
+- webhooks: Manage the webhook subscriptions of the server
+- log-level: Show or change the log levels of the server
+- selftest: Check the store backend of the server
+
+Examples:
+
+1. Check the store without changing it:
+   dirctl admin fsck
+
+2. Check the store and repair the detected issues:
+   dirctl admin fsck --repair
+
+3. Move records after changing the sharding template:
+   dirctl admin reshard
+
+4. Read the journal entries after sequence number 1200:
+   dirctl admin journal --since 1200
+
+5. Convert v0.3.1 agent records to 0.7.0 records:
+   dirctl admin migrate --from v1 --to v3
+
+6. Notify a CI pipeline of pushed records:
+   dirctl admin webhooks add --url https://ci.example.org/hooks/dir --event pushed
+
+7. Debug the OCI store without restarting the server:
+   dirctl admin log-level store/oci=debug
+
+8. Check the registry credentials of the server:
+   dirctl admin selftest
+`,
+}
+
+func init() {
+	Command.AddCommand(fsckCmd, reshardCmd, journalCmd, migrateCmd, webhooksCmd, logLevelCmd, selfTestCmd)
+
+	presenter.AddOutputFlags(fsckCmd)
+	presenter.AddOutputFlags(reshardCmd)
+	presenter.AddOutputFlags(journalCmd)
+	presenter.AddOutputFlags(webhooksListCmd)
+	presenter.AddOutputFlags(webhooksAddCmd)
+	presenter.AddOutputFlags(webhooksTestCmd)
+	presenter.AddOutputFlags(logLevelCmd)
+	presenter.AddOutputFlags(selfTestCmd)
+}
@@ -0,0 +1,143 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var fsckCmd = &cobra.Command{
+	Use:   "fsck",
+	Short: "Check the consistency of the store",
+	Long: `Check the consistency of the server store and optionally repair it.
+
+All tags of the store are scanned. The following issues are detected:
+
+- dangling tag: the tag points to a manifest that does not exist
+- missing blob: the record blob of a manifest does not exist
+- CID mismatch: the CID recomputed from the stored record does not match
+  the CID expected by the manifest, or a CID tag points to another record
+- missing tag: a discovery tag derived from the record does not exist
+
+With --repair, dangling tags and CID tags pointing to other records are removed,
+records with missing blobs are deleted, mismatching records are stored under their
+actual CID and missing tags are recreated. Records with missing blobs cannot be
+recovered and must be pushed again.
+
+Progress is reported on stderr. The command fails if issues remain unrepaired.
+
+Usage examples:
+
+1. Check the store:
+   dirctl admin fsck
+
+2. Check and repair the store:
+   dirctl admin fsck --repair
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runFsckCommand(cmd)
+	},
+}
+
+var fsckOpts struct {
+	Repair bool
+}
+
+func init() {
+	fsckCmd.Flags().BoolVar(&fsckOpts.Repair, "repair", false, "Repair the detected issues")
+}
+
+func runFsckCommand(cmd *cobra.Command) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	human := presenter.GetOutputOptions(cmd).Format == presenter.FormatHuman
+
+	var issues []interface{}
+
+	summary, err := c.CheckStore(cmd.Context(), fsckOpts.Repair, func(resp *storev1.FsckResponse) {
+		if progress := resp.GetProgress(); progress != nil {
+			presenter.Errorf(cmd, "Checked %d/%d tags\n", progress.GetCheckedTags(), progress.GetTotalTags())
+		}
+
+		if issue := resp.GetIssue(); issue != nil {
+			issues = append(issues, issue)
+
+			if human {
+				printIssue(cmd, issue)
+			}
+		}
+	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if !human {
+		if err := presenter.PrintMessage(cmd, "issues", "Store issues", issues); err != nil {
+			return err //nolint:wrapcheck
+		}
+	} else {
+		presenter.Printf(cmd, "Checked %d tags and %d records: %d issues, %d repaired\n",
+			summary.GetCheckedTags(), summary.GetCheckedRecords(), summary.GetIssues(), summary.GetRepaired())
+
+		if summary.GetCompacted() {
+			presenter.Println(cmd, "Removed unreferenced blobs")
+		}
+	}
+
+	if unrepaired := summary.GetIssues() - summary.GetRepaired(); unrepaired > 0 {
+		if !fsckOpts.Repair {
+			return fmt.Errorf("store has %d issues, run with --repair to repair them", unrepaired)
+		}
+
+		return fmt.Errorf("failed to repair %d issues", unrepaired)
+	}
+
+	return nil
+}
+
+func printIssue(cmd *cobra.Command, issue *storev1.FsckIssue) {
+	presenter.Printf(cmd, "%s: tag %s", issueName(issue.GetType()), issue.GetTag())
+
+	if issue.GetCid() != "" && issue.GetCid() != issue.GetTag() {
+		presenter.Printf(cmd, " (record %s)", issue.GetCid())
+	}
+
+	presenter.Printf(cmd, ": %s", issue.GetMessage())
+
+	switch {
+	case issue.GetRepaired():
+		presenter.Printf(cmd, " [repaired]")
+	case issue.GetRepairError() != "":
+		presenter.Printf(cmd, " [repair failed: %s]", issue.GetRepairError())
+	}
+
+	presenter.Println(cmd)
+}
+
+func issueName(issueType storev1.FsckIssueType) string {
+	switch issueType {
+	case storev1.FsckIssueType_FSCK_ISSUE_TYPE_DANGLING_TAG:
+		return "dangling tag"
+	case storev1.FsckIssueType_FSCK_ISSUE_TYPE_MISSING_BLOB:
+		return "missing blob"
+	case storev1.FsckIssueType_FSCK_ISSUE_TYPE_CID_MISMATCH:
+		return "CID mismatch"
+	case storev1.FsckIssueType_FSCK_ISSUE_TYPE_MISSING_TAG:
+		return "missing tag"
+	case storev1.FsckIssueType_FSCK_ISSUE_TYPE_NAME_COLLISION:
+		return "name collision"
+	default:
+		return "unknown issue"
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package admin
+
+import (
+	"errors"
+	"strings"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var journalCmd = &cobra.Command{
+	Use:   "journal",
+	Short: "Read the operation journal of the server",
+	Long: `Read the journal of the records pushed to, updated in and deleted from the server store.
+
+The server appends an entry for every successful push, lifecycle or metadata
+update and delete when the journal is enabled. Entries are numbered sequentially, use --since with the
+last sequence number read to only read newer entries.
+
+A gap entry reports operations the server dropped from the journal because
+its writer could not keep up. The records of a lost store can be restored
+from the journal by re-pulling the live records from another instance.
+
+Usage examples:
+
+1. Read the whole journal:
+   dirctl admin journal
+
+2. Read the entries after sequence number 1200 as JSON:
+   dirctl admin journal --since 1200 --output json
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runJournalCommand(cmd)
+	},
+}
+
+var journalOpts struct {
+	Since uint64
+}
+
+func init() {
+	journalCmd.Flags().Uint64Var(&journalOpts.Since, "since", 0, "Only read the entries with a greater sequence number")
+}
+
+func runJournalCommand(cmd *cobra.Command) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	human := presenter.GetOutputOptions(cmd).Format == presenter.FormatHuman
+
+	var entries []interface{}
+
+	err := c.ReadStoreJournal(cmd.Context(), journalOpts.Since, func(entry *storev1.JournalEntry) {
+		if human {
+			printEntry(cmd, entry)
+		} else {
+			entries = append(entries, entry)
+		}
+	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if !human {
+		return presenter.PrintMessage(cmd, "entries", "Journal entries", entries) //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+func printEntry(cmd *cobra.Command, entry *storev1.JournalEntry) {
+	operation := strings.TrimPrefix(entry.GetOperation().String(), "JOURNAL_OPERATION_")
+
+	if entry.GetOperation() == storev1.JournalOperation_JOURNAL_OPERATION_GAP {
+		presenter.Printf(cmd, "%d %s %s: %d operations dropped\n", entry.GetSequence(), entry.GetTimestamp(), operation, entry.GetDropped())
+
+		return
+	}
+
+	presenter.Printf(cmd, "%d %s %s %s", entry.GetSequence(), entry.GetTimestamp(), operation, entry.GetCid())
+
+	if entry.GetName() != "" {
+		presenter.Printf(cmd, " %s@%s", entry.GetName(), entry.GetVersion())
+	}
+
+	if entry.GetTrustDomain() != "" {
+		presenter.Printf(cmd, " [%s]", entry.GetTrustDomain())
+	}
+
+	presenter.Println(cmd)
+}
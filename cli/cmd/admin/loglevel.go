@@ -0,0 +1,85 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var logLevelCmd = &cobra.Command{
+	Use:   "log-level [[component=]level...]",
+	Short: "Show or change the log levels of the server",
+	Long: `Show or change the log levels of the server at runtime.
+
+Without arguments, the default log level and the levels of components are shown.
+A component=level argument sets the level of a component and the components
+below it, e.g. store/oci also sets the level of store/oci/tags. A level without
+a component sets the default level, and an empty level removes the level of
+the component. Levels are one of debug, info, warn or error, and are reset to
+the server configuration when the server restarts.
+
+Usage examples:
+
+1. Show the log levels:
+   dirctl admin log-level
+
+2. Debug the OCI store:
+   dirctl admin log-level store/oci=debug
+
+3. Log warnings only, except for authorization decisions:
+   dirctl admin log-level warn authz=info
+
+4. Stop debugging the OCI store:
+   dirctl admin log-level store/oci=
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runLogLevelCommand(cmd, args)
+	},
+}
+
+func runLogLevelCommand(cmd *cobra.Command, args []string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	levels, err := c.GetLogLevels(cmd.Context(), &storev1.GetLogLevelsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get log levels: %w", err)
+	}
+
+	for _, arg := range args {
+		req := &storev1.SetLogLevelRequest{Level: arg}
+		if component, level, ok := strings.Cut(arg, "="); ok {
+			req.Component, req.Level = component, level
+		}
+
+		levels, err = c.SetLogLevel(cmd.Context(), req)
+		if err != nil {
+			return fmt.Errorf("failed to set log level %q: %w", arg, err)
+		}
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "levels", "Log levels", levels)
+	}
+
+	presenter.Printf(cmd, "default: %s\n", levels.GetDefaultLevel())
+
+	for _, component := range slices.Sorted(maps.Keys(levels.GetComponentLevels())) {
+		presenter.Printf(cmd, "%s: %s\n", component, levels.GetComponentLevels()[component])
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Convert stored records to another schema version",
+	Long: `Convert the stored records of one OASF schema version to another.
+
+Versions are given as OASF schema versions, e.g. v0.3.1 and 0.7.0, or by
+their record shape, v1 for v0.3.1 agent records and v3 for 0.7.0 records.
+
+The records matching the filters are listed with the search index of the
+server. Every record of the source version is converted and the converted
+record is pushed with a migrated_from annotation carrying the original CID.
+A migration referrer linking to the converted record is attached to the
+original record, so that either record can be found from the other.
+
+With --republish, the converted records are published to the routing network.
+With --retire-old, the original records are deprecated with the converted
+record as their successor.
+
+Migrations can be resumed: records already converted by an earlier run are
+recognized by the migrated_from annotation and only their missing links are
+created. The command fails if some records could not be migrated.
+
+Usage examples:
+
+1. Report the records that would be migrated and their new CIDs:
+   dirctl admin migrate --from v1 --to v3 --dry-run
+
+2. Migrate the records of a team and retire the original records:
+   dirctl admin migrate --from v1 --to v3 --filter team=platform --retire-old
+
+3. Migrate records that lose fields without equivalent in the target version:
+   dirctl admin migrate --from v1 --to v3 --allow-lossy --republish
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runMigrateCommand(cmd)
+	},
+}
+
+var migrateOpts struct {
+	From       string
+	To         string
+	Filters    []string
+	DryRun     bool
+	AllowLossy bool
+	Republish  bool
+	RetireOld  bool
+}
+
+func init() {
+	flags := migrateCmd.Flags()
+
+	flags.StringVar(&migrateOpts.From, "from", "", "Schema version of the records to migrate, e.g. v1")
+	flags.StringVar(&migrateOpts.To, "to", "", "Schema version to convert the records to, e.g. v3")
+	flags.StringArrayVar(&migrateOpts.Filters, "filter", nil,
+		"Only migrate records matching key=value, where key is name, trust-domain or an annotation key (can be repeated)")
+	flags.BoolVar(&migrateOpts.DryRun, "dry-run", false, "Only report the records that would be migrated")
+	flags.BoolVar(&migrateOpts.AllowLossy, "allow-lossy", false, "Migrate records whose fields cannot all be converted")
+	flags.BoolVar(&migrateOpts.Republish, "republish", false, "Publish the migrated records to the routing network")
+	flags.BoolVar(&migrateOpts.RetireOld, "retire-old", false, "Deprecate the original records with the migrated record as successor")
+
+	_ = migrateCmd.MarkFlagRequired("from")
+	_ = migrateCmd.MarkFlagRequired("to")
+}
+
+// parseMigrateFilter converts the --filter flags into a search filter.
+func parseMigrateFilter() (client.SearchFilter, error) {
+	var filter client.SearchFilter
+
+	for _, entry := range migrateOpts.Filters {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok || key == "" {
+			return filter, fmt.Errorf("invalid filter %q, expected key=value", entry)
+		}
+
+		switch key {
+		case "name":
+			filter.Name = value
+		case "trust-domain":
+			filter.TrustDomain = value
+		default:
+			if filter.Annotations == nil {
+				filter.Annotations = make(map[string]string)
+			}
+
+			filter.Annotations[key] = value
+		}
+	}
+
+	return filter, nil
+}
+
+func runMigrateCommand(cmd *cobra.Command) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	filter, err := parseMigrateFilter()
+	if err != nil {
+		return err
+	}
+
+	results, err := c.Migrate(cmd.Context(), client.MigrateOptions{
+		From:       migrateOpts.From,
+		To:         migrateOpts.To,
+		Filter:     filter,
+		DryRun:     migrateOpts.DryRun,
+		AllowLossy: migrateOpts.AllowLossy,
+		Republish:  migrateOpts.Republish,
+		RetireOld:  migrateOpts.RetireOld,
+	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	var migrated, resumed, failed int
+
+	for result := range results {
+		switch {
+		case result.SourceCID == "":
+			return result.Error
+		case result.Error != nil:
+			presenter.Printf(cmd, "%s [failed: %v]\n", result.SourceCID, result.Error)
+
+			failed++
+		case result.AlreadyMigrated:
+			presenter.Printf(cmd, "%s -> %s [already migrated]\n", result.SourceCID, result.TargetCID)
+
+			resumed++
+		default:
+			presenter.Printf(cmd, "%s -> %s\n", result.SourceCID, result.TargetCID)
+
+			migrated++
+		}
+	}
+
+	if err := cmd.Context().Err(); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if migrateOpts.DryRun {
+		presenter.Printf(cmd, "%d records would be migrated, %d already migrated, %d cannot be migrated\n", migrated, resumed, failed)
+	} else {
+		presenter.Printf(cmd, "Migrated %d records, %d already migrated, %d failed\n", migrated, resumed, failed)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to migrate %d records", failed)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var reshardCmd = &cobra.Command{
+	Use:   "reshard",
+	Short: "Move records to the repositories of the sharding template",
+	Long: `Move the records of a sharded OCI store to the repositories resolved
+from their metadata by the current repository template.
+
+Run it after enabling sharding for an existing store or after changing the
+template. Records are copied with their signatures, lifecycle status and
+discovery tags before they are deleted from their previous repository,
+so they remain available while they are moved.
+
+Records stay reachable by CID in any repository, so resharding can be
+run at any time. The command fails if some records could not be moved.
+
+Usage examples:
+
+1. Report the records that would be moved:
+   dirctl admin reshard --dry-run
+
+2. Move the records:
+   dirctl admin reshard
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runReshardCommand(cmd)
+	},
+}
+
+var reshardOpts struct {
+	DryRun bool
+}
+
+func init() {
+	reshardCmd.Flags().BoolVar(&reshardOpts.DryRun, "dry-run", false, "Only report the records that would be moved")
+}
+
+func runReshardCommand(cmd *cobra.Command) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	human := presenter.GetOutputOptions(cmd).Format == presenter.FormatHuman
+
+	var moves []interface{}
+
+	summary, err := c.ReshardStore(cmd.Context(), reshardOpts.DryRun, func(move *storev1.ReshardMove) {
+		moves = append(moves, move)
+
+		if human {
+			printMove(cmd, move)
+		}
+	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if !human {
+		if err := presenter.PrintMessage(cmd, "moves", "Record moves", moves); err != nil {
+			return err //nolint:wrapcheck
+		}
+	} else {
+		presenter.Printf(cmd, "Checked %d records: %d misplaced, %d moved\n",
+			summary.GetCheckedRecords(), summary.GetMisplaced(), summary.GetMoved())
+	}
+
+	if summary.GetFailed() > 0 {
+		return fmt.Errorf("failed to move %d records", summary.GetFailed())
+	}
+
+	return nil
+}
+
+func printMove(cmd *cobra.Command, move *storev1.ReshardMove) {
+	presenter.Printf(cmd, "%s: %s -> %s", move.GetCid(), move.GetSourceRepository(), move.GetTargetRepository())
+
+	switch {
+	case move.GetMoved():
+		presenter.Printf(cmd, " [moved]")
+	case move.GetError() != "":
+		presenter.Printf(cmd, " [failed: %s]", move.GetError())
+	}
+
+	presenter.Println(cmd)
+}
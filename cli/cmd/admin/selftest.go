@@ -0,0 +1,101 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var selfTestOpts struct {
+	SkipWrite bool
+}
+
+var selfTestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Check the store backend of the server",
+	Long: `Run the self-test of the store backend of the server, which is also run
+when the server starts. The self-test pings the registry, pushes and deletes a
+probe blob in a dedicated repository, and lists the tags of the records
+repository. Failed steps name the class of the failure: network, auth for
+rejected credentials, or permission for credentials lacking access.
+
+The command fails if a step of the self-test fails. The report replaces the
+report of the last self-test, which is reported by the store-selftest
+component of the health service.
+
+Usage examples:
+
+1. Check the store backend:
+   dirctl admin selftest
+
+2. Check the store backend of a server with read-only credentials:
+   dirctl admin selftest --skip-write
+`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runSelfTestCommand(cmd)
+	},
+}
+
+func init() {
+	selfTestCmd.Flags().BoolVar(&selfTestOpts.SkipWrite, "skip-write", false,
+		"Skip the push and delete of the probe blob.",
+	)
+}
+
+func runSelfTestCommand(cmd *cobra.Command) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	report, err := c.SelfTest(cmd.Context(), &storev1.SelfTestRequest{SkipWrite: selfTestOpts.SkipWrite})
+	if err != nil {
+		return fmt.Errorf("failed to run store self-test: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		if err := presenter.PrintMessage(cmd, "selftest", "Store self-test", report); err != nil {
+			return err
+		}
+	} else {
+		printSelfTestReport(cmd, report)
+	}
+
+	if !report.GetPassed() {
+		return errors.New("store self-test failed")
+	}
+
+	return nil
+}
+
+func printSelfTestReport(cmd *cobra.Command, report *storev1.SelfTestReport) {
+	for _, step := range report.GetSteps() {
+		presenter.Println(cmd, formatSelfTestStep(step))
+	}
+
+	if report.GetPassed() {
+		presenter.Println(cmd, "Store self-test passed")
+	}
+}
+
+func formatSelfTestStep(step *storev1.SelfTestStep) string {
+	switch step.GetStatus() {
+	case storev1.SelfTestStatus_SELF_TEST_STATUS_PASSED:
+		return fmt.Sprintf("PASS  %-10s %s", step.GetName(), step.GetLatency().AsDuration())
+	case storev1.SelfTestStatus_SELF_TEST_STATUS_SKIPPED:
+		return fmt.Sprintf("SKIP  %-10s %s", step.GetName(), step.GetError())
+	default:
+		failure := strings.ToLower(strings.TrimPrefix(step.GetFailure().String(), "SELF_TEST_FAILURE_"))
+
+		return fmt.Sprintf("FAIL  %-10s %s: %s", step.GetName(), failure, step.GetError())
+	}
+}
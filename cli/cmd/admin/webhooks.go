@@ -0,0 +1,268 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var webhooksCmd = &cobra.Command{
+	Use:   "webhooks",
+	Short: "Manage the webhook subscriptions of the server",
+	Long: `Manage the webhook subscriptions of the server.
+
+The server delivers an HTTP POST request to the endpoint of every matching
+subscription when records are pushed, deleted or published. Requests are
+signed with the secret of the subscription in the X-Dir-Signature header.
+Webhooks must be enabled in the server configuration, subscriptions of the
+configuration cannot be removed.
+
+- list: List the subscriptions
+- add: Subscribe an endpoint to events
+- remove: Remove a subscription
+- test: Deliver a test event to the endpoint of a subscription
+
+Examples:
+
+1. Notify a CI pipeline of pushed records of an organization:
+   dirctl admin webhooks add --url https://ci.example.org/hooks/dir --secret s3cret --event pushed --name "cisco.com/*"
+
+2. Check that the endpoint of a subscription is reachable:
+   dirctl admin webhooks test <id>
+`,
+}
+
+var webhooksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the webhook subscriptions",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runWebhooksListCommand(cmd)
+	},
+}
+
+var webhooksAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Subscribe an endpoint to store and routing events",
+	Long: `Subscribe an HTTP endpoint to store and routing events of records.
+
+Events are only delivered if they pass all given filters: --event selects the
+event types (pushed, deleted, published), --name the record name patterns and
+--label the labels of which the record must have one, e.g. /skills/nlp also
+matches records with the /skills/nlp/text_completion label.
+
+Usage examples:
+
+1. Notify a chat channel of all events:
+   dirctl admin webhooks add --url https://chat.example.org/hooks/dir
+
+2. Trigger a CI pipeline when records with a skill are published:
+   dirctl admin webhooks add --url https://ci.example.org/hooks/dir --secret s3cret \
+     --event published --label /skills/natural_language_processing
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runWebhooksAddCommand(cmd)
+	},
+}
+
+var webhooksRemoveCmd = &cobra.Command{
+	Use:   "remove <id>",
+	Short: "Remove a webhook subscription",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWebhooksRemoveCommand(cmd, args[0])
+	},
+}
+
+var webhooksTestCmd = &cobra.Command{
+	Use:   "test <id>",
+	Short: "Deliver a test event to the endpoint of a webhook subscription",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWebhooksTestCommand(cmd, args[0])
+	},
+}
+
+var webhooksAddOpts struct {
+	URL            string
+	Secret         string
+	Events         []string
+	Names          []string
+	Labels         []string
+	MaxAttempts    uint32
+	InitialBackoff time.Duration
+}
+
+// webhookEventTypes maps the event names accepted by --event to their API types.
+var webhookEventTypes = map[string]storev1.WebhookEventType{
+	"pushed":    storev1.WebhookEventType_WEBHOOK_EVENT_TYPE_PUSHED,
+	"deleted":   storev1.WebhookEventType_WEBHOOK_EVENT_TYPE_DELETED,
+	"published": storev1.WebhookEventType_WEBHOOK_EVENT_TYPE_PUBLISHED,
+}
+
+func init() {
+	webhooksCmd.AddCommand(webhooksListCmd, webhooksAddCmd, webhooksRemoveCmd, webhooksTestCmd)
+
+	flags := webhooksAddCmd.Flags()
+	flags.StringVar(&webhooksAddOpts.URL, "url", "", "HTTP(S) URL of the endpoint")
+	flags.StringVar(&webhooksAddOpts.Secret, "secret", "", "Secret to sign the requests with")
+	flags.StringArrayVar(&webhooksAddOpts.Events, "event", nil, "Event type to deliver: pushed, deleted or published (repeatable, default all)")
+	flags.StringArrayVar(&webhooksAddOpts.Names, "name", nil, "Record name pattern, e.g. cisco.com/* (repeatable)")
+	flags.StringArrayVar(&webhooksAddOpts.Labels, "label", nil, "Record label, e.g. /skills/natural_language_processing (repeatable)")
+	flags.Uint32Var(&webhooksAddOpts.MaxAttempts, "max-attempts", 0, "Delivery attempts per event, 0 for the server default")
+	flags.DurationVar(&webhooksAddOpts.InitialBackoff, "initial-backoff", 0, "Delay before the first retry, 0 for the server default")
+
+	_ = webhooksAddCmd.MarkFlagRequired("url")
+}
+
+func runWebhooksListCommand(cmd *cobra.Command) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	webhooks, err := c.GetWebhooks(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		results := make([]interface{}, 0, len(webhooks))
+		for _, webhook := range webhooks {
+			results = append(results, webhook)
+		}
+
+		return presenter.PrintMessage(cmd, "webhooks", "Webhook subscriptions", results)
+	}
+
+	if len(webhooks) == 0 {
+		presenter.Println(cmd, "No webhook subscriptions")
+
+		return nil
+	}
+
+	for _, webhook := range webhooks {
+		printWebhook(cmd, webhook)
+	}
+
+	return nil
+}
+
+func runWebhooksAddCommand(cmd *cobra.Command) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	req := &storev1.Webhook{
+		Url:         webhooksAddOpts.URL,
+		Secret:      webhooksAddOpts.Secret,
+		Names:       webhooksAddOpts.Names,
+		Labels:      webhooksAddOpts.Labels,
+		MaxAttempts: webhooksAddOpts.MaxAttempts,
+	}
+
+	if webhooksAddOpts.InitialBackoff > 0 {
+		req.InitialBackoff = durationpb.New(webhooksAddOpts.InitialBackoff)
+	}
+
+	for _, event := range webhooksAddOpts.Events {
+		eventType, ok := webhookEventTypes[event]
+		if !ok {
+			return fmt.Errorf("invalid event type %q, expected pushed, deleted or published", event)
+		}
+
+		req.EventTypes = append(req.EventTypes, eventType)
+	}
+
+	webhook, err := c.AddWebhook(cmd.Context(), req)
+	if err != nil {
+		return fmt.Errorf("failed to add webhook: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "webhook", "Added webhook", webhook)
+	}
+
+	presenter.Printf(cmd, "Added webhook %s\n", webhook.GetId())
+
+	return nil
+}
+
+func runWebhooksRemoveCommand(cmd *cobra.Command, id string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	if _, err := c.RemoveWebhook(cmd.Context(), &storev1.RemoveWebhookRequest{Id: id}); err != nil {
+		return fmt.Errorf("failed to remove webhook: %w", err)
+	}
+
+	presenter.Printf(cmd, "Removed webhook %s\n", id)
+
+	return nil
+}
+
+func runWebhooksTestCommand(cmd *cobra.Command, id string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	resp, err := c.TestWebhook(cmd.Context(), &storev1.TestWebhookRequest{Id: id})
+	if err != nil {
+		return fmt.Errorf("failed to test webhook: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "test", "Test delivery", resp)
+	}
+
+	if resp.GetError() != "" {
+		return fmt.Errorf("test delivery failed (status %d): %s", resp.GetStatusCode(), resp.GetError())
+	}
+
+	presenter.Printf(cmd, "Test event delivered (status %d)\n", resp.GetStatusCode())
+
+	return nil
+}
+
+func printWebhook(cmd *cobra.Command, webhook *storev1.Webhook) {
+	presenter.Printf(cmd, "%s %s", webhook.GetId(), webhook.GetUrl())
+
+	if webhook.GetConfigured() {
+		presenter.Print(cmd, " [configured]")
+	}
+
+	presenter.Println(cmd)
+
+	if len(webhook.GetEventTypes()) > 0 {
+		events := make([]string, 0, len(webhook.GetEventTypes()))
+		for _, eventType := range webhook.GetEventTypes() {
+			events = append(events, strings.ToLower(strings.TrimPrefix(eventType.String(), "WEBHOOK_EVENT_TYPE_")))
+		}
+
+		presenter.Printf(cmd, "  Events: %s\n", strings.Join(events, ", "))
+	}
+
+	if len(webhook.GetNames()) > 0 {
+		presenter.Printf(cmd, "  Names: %s\n", strings.Join(webhook.GetNames(), ", "))
+	}
+
+	if len(webhook.GetLabels()) > 0 {
+		presenter.Printf(cmd, "  Labels: %s\n", strings.Join(webhook.GetLabels(), ", "))
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package attest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+var addCmd = &cobra.Command{
+	Use:   "add <cid>",
+	Short: "Sign a predicate and attach it to a record",
+	Long: `Wrap a JSON predicate in an in-toto statement about the record,
+sign it with the given private key and attach it to the record.
+
+The predicate type defaults to SLSA provenance. Encrypted Cosign keys
+are decrypted with the password from COSIGN_PASSWORD or the terminal.
+
+Usage examples:
+
+1. Attach SLSA provenance:
+   dirctl attest add <cid> --predicate provenance.json --key cosign.key
+
+2. Attach an SPDX SBOM:
+   dirctl attest add <cid> --predicate sbom.spdx.json --predicate-type https://spdx.dev/Document --key cosign.key
+`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.CIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAddCommand(cmd, args[0])
+	},
+}
+
+var addOpts struct {
+	Predicate     string
+	PredicateType string
+	Key           string
+}
+
+func init() {
+	flags := addCmd.Flags()
+	flags.StringVar(&addOpts.Predicate, "predicate", "", "Path to the JSON predicate to attest")
+	flags.StringVar(&addOpts.PredicateType, "predicate-type", client.PredicateTypeSLSAProvenance, "Predicate type URI of the attestation")
+	flags.StringVar(&addOpts.Key, "key", "", "Path to the PEM encoded private key to sign the attestation with")
+
+	_ = addCmd.MarkFlagRequired("predicate")
+	_ = addCmd.MarkFlagRequired("key")
+}
+
+func runAddCommand(cmd *cobra.Command, cid string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	payload, err := os.ReadFile(filepath.Clean(addOpts.Predicate))
+	if err != nil {
+		return fmt.Errorf("failed to read predicate: %w", err)
+	}
+
+	signer, err := loadSigner(addOpts.Key)
+	if err != nil {
+		return err
+	}
+
+	if err := c.AttachAttestation(cmd.Context(), &corev1.RecordRef{Cid: cid}, addOpts.PredicateType, payload, signer); err != nil {
+		return fmt.Errorf("failed to attach attestation: %w", err)
+	}
+
+	return presenter.PrintMessage(cmd, "attestation", "Attached attestation of type", addOpts.PredicateType)
+}
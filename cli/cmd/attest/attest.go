@@ -0,0 +1,43 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package attest
+
+import (
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "attest",
+	Short: "Attestation operations for records",
+	Long: `Attestation operations for records.
+
+An attestation is an in-toto statement about a record, such as SLSA provenance
+or an SBOM, signed as a DSSE envelope and attached to the record as a referrer.
+Attestations are verified against the given public keys and the record CID.
+
+- add: Sign a predicate and attach it to a record
+- list: List the verified attestations of a record
+- verify: Verify that a record has valid attestations
+
+Examples:
+
+1. Attach SLSA provenance to a record:
+   dirctl attest add <cid> --predicate provenance.json --key cosign.key
+
+2. List and verify the attestations of a record:
+   dirctl attest list <cid> --key cosign.pub
+   dirctl attest verify <cid> --predicate-type https://slsa.dev/provenance/v1 --key cosign.pub
+`,
+}
+
+func init() {
+	Command.AddCommand(addCmd)
+	Command.AddCommand(listCmd)
+	Command.AddCommand(verifyCmd)
+
+	presenter.AddOutputFlags(addCmd)
+	presenter.AddOutputFlags(listCmd)
+	presenter.AddOutputFlags(verifyCmd)
+}
@@ -0,0 +1,66 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package attest
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/agntcy/dir/utils/cosign"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+// loadSigner loads a PEM encoded private key.
+// Encrypted Cosign keys are decrypted with the password read as for 'dirctl sign --key'.
+func loadSigner(path string) (crypto.Signer, error) {
+	rawKey, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	pw, err := cosign.ReadPrivateKeyPassword()()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read password: %w", err)
+	}
+
+	privateKey, err := cryptoutils.UnmarshalPEMToPrivateKey(rawKey, cryptoutils.StaticPasswordFunc(pw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load private key: %w", err)
+	}
+
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("private key does not implement crypto.Signer")
+	}
+
+	return signer, nil
+}
+
+// loadPublicKeys loads PEM encoded public keys.
+func loadPublicKeys(paths []string) ([]crypto.PublicKey, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("at least one public key is required, use --key")
+	}
+
+	keys := make([]crypto.PublicKey, 0, len(paths))
+
+	for _, path := range paths {
+		rawKey, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file: %w", err)
+		}
+
+		key, err := cryptoutils.UnmarshalPEMToPublicKey(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load public key %s: %w", path, err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,109 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package attest
+
+import (
+	"encoding/json"
+	"errors"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list <cid>",
+	Short: "List the verified attestations of a record",
+	Long: `List the attestations of a record that are signed by one of the given keys.
+Attestations that fail verification are reported but not listed.
+
+Usage examples:
+
+1. List all attestations:
+   dirctl attest list <cid> --key cosign.pub
+
+2. List SBOM attestations with their predicates as JSON:
+   dirctl attest list <cid> --predicate-type https://spdx.dev/Document --key cosign.pub --json
+`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.CIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runListCommand(cmd, args[0])
+	},
+}
+
+var listOpts struct {
+	PredicateType string
+	Keys          []string
+}
+
+func init() {
+	addVerifyFlags(listCmd, &listOpts.PredicateType, &listOpts.Keys)
+}
+
+// attestationOutput is the output of a single attestation.
+type attestationOutput struct {
+	PredicateType string          `json:"predicate_type"`
+	KeyID         string          `json:"key_id"`
+	CreatedAt     string          `json:"created_at,omitempty"`
+	Predicate     json.RawMessage `json:"predicate,omitempty"`
+}
+
+func runListCommand(cmd *cobra.Command, cid string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	keys, err := loadPublicKeys(listOpts.Keys)
+	if err != nil {
+		return err
+	}
+
+	attestations, err := c.GetAttestations(cmd.Context(), &corev1.RecordRef{Cid: cid}, listOpts.PredicateType, keys...)
+	if err != nil {
+		if !errors.Is(err, client.ErrAttestationInvalid) {
+			return err //nolint:wrapcheck
+		}
+
+		presenter.Errorf(cmd, "Warning: %v\n", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		outputs := make([]attestationOutput, 0, len(attestations))
+		for _, attestation := range attestations {
+			outputs = append(outputs, attestationOutput{
+				PredicateType: attestation.Statement.PredicateType,
+				KeyID:         attestation.KeyID,
+				CreatedAt:     attestation.CreatedAt,
+				Predicate:     attestation.Statement.Predicate,
+			})
+		}
+
+		return presenter.PrintMessage(cmd, "attestations", "Attestations", outputs)
+	}
+
+	if len(attestations) == 0 {
+		presenter.Println(cmd, "No verified attestations found")
+
+		return nil
+	}
+
+	for _, attestation := range attestations {
+		presenter.Printf(cmd, "%s\tkey %s\t%s\n", attestation.Statement.PredicateType, attestation.KeyID, attestation.CreatedAt)
+	}
+
+	return nil
+}
+
+func addVerifyFlags(cmd *cobra.Command, predicateType *string, keys *[]string) {
+	flags := cmd.Flags()
+	flags.StringVar(predicateType, "predicate-type", "", "Only include attestations with this predicate type URI")
+	flags.StringArrayVar(keys, "key", nil, "Path to a PEM encoded public key trusted to sign attestations, can be repeated")
+
+	_ = cmd.MarkFlagRequired("key")
+}
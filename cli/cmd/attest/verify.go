@@ -0,0 +1,65 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package attest
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <cid>",
+	Short: "Verify that a record has valid attestations",
+	Long: `Verify that a record has at least one attestation signed by one of the given keys,
+and that none of its attestations fail verification.
+
+Usage examples:
+
+1. Verify the SLSA provenance of a record:
+   dirctl attest verify <cid> --predicate-type https://slsa.dev/provenance/v1 --key cosign.pub
+`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.CIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVerifyCommand(cmd, args[0])
+	},
+}
+
+var verifyOpts struct {
+	PredicateType string
+	Keys          []string
+}
+
+func init() {
+	addVerifyFlags(verifyCmd, &verifyOpts.PredicateType, &verifyOpts.Keys)
+}
+
+func runVerifyCommand(cmd *cobra.Command, cid string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	keys, err := loadPublicKeys(verifyOpts.Keys)
+	if err != nil {
+		return err
+	}
+
+	attestations, err := c.GetAttestations(cmd.Context(), &corev1.RecordRef{Cid: cid}, verifyOpts.PredicateType, keys...)
+	if err != nil {
+		return fmt.Errorf("attestations failed verification: %w", err)
+	}
+
+	if len(attestations) == 0 {
+		return errors.New("no attestations found")
+	}
+
+	return presenter.PrintMessage(cmd, "attestations", "Verified attestations", len(attestations))
+}
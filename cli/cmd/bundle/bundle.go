@@ -0,0 +1,47 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "bundle",
+	Short: "Bundle operations for sets of related records",
+	Long: `Bundle operations for sets of related records.
+
+A bundle is a manifest listing the CIDs of records that are deployed and approved
+as a unit, together with their roles. A bundle has its own CID, so signing it with
+'dirctl sign <bundle-cid>' approves the exact combination of its member records.
+
+- create: Create a bundle manifest from record CIDs
+- push: Store a bundle manifest
+- pull: Pull a bundle and its member records
+- verify: Verify the members and the signature of a bundle
+
+Examples:
+
+1. Create, push and sign a bundle:
+   dirctl bundle create <cid-1> <cid-2> --role <cid-1>=orchestrator --name my-app > bundle.json
+   dirctl bundle push bundle.json
+   dirctl sign <bundle-cid> --key cosign.key
+
+2. Pull and verify a bundle:
+   dirctl bundle pull <bundle-cid>
+   dirctl bundle verify <bundle-cid>
+`,
+}
+
+func init() {
+	Command.AddCommand(createCmd)
+	Command.AddCommand(pushCmd)
+	Command.AddCommand(pullCmd)
+	Command.AddCommand(verifyCmd)
+
+	presenter.AddOutputFlags(pushCmd)
+	presenter.AddOutputFlags(pullCmd)
+	presenter.AddOutputFlags(verifyCmd)
+}
@@ -0,0 +1,68 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
+	"github.com/spf13/cobra"
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create <cid>...",
+	Short: "Create a bundle manifest from record CIDs",
+	Long: `Create a bundle manifest from record CIDs and print it as canonical JSON.
+The bundle is not stored, use 'dirctl bundle push' to store it.
+
+Usage examples:
+
+1. Create a bundle with roles:
+   dirctl bundle create <cid-1> <cid-2> --role <cid-1>=orchestrator --role <cid-2>=worker --name my-app > bundle.json
+`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completion.CIDs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCreateCommand(cmd, args)
+	},
+}
+
+var createOpts struct {
+	Name        string
+	Roles       map[string]string
+	Annotations map[string]string
+}
+
+func init() {
+	flags := createCmd.Flags()
+	flags.StringVar(&createOpts.Name, "name", "", "Name of the bundle")
+	flags.StringToStringVar(&createOpts.Roles, "role", nil, "Role of a member record as <cid>=<role>, can be repeated")
+	flags.StringToStringVar(&createOpts.Annotations, "annotation", nil, "Annotation of the bundle as <key>=<value>, can be repeated")
+}
+
+func runCreateCommand(cmd *cobra.Command, cids []string) error {
+	refs := make([]*corev1.RecordRef, 0, len(cids))
+	for _, cid := range cids {
+		refs = append(refs, &corev1.RecordRef{Cid: cid})
+	}
+
+	bundle, err := corev1.BuildBundle(refs, createOpts.Roles)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+
+	bundle.Name = createOpts.Name
+	bundle.Annotations = createOpts.Annotations
+
+	data, err := bundle.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+
+	presenter.Println(cmd, string(data))
+
+	return nil
+}
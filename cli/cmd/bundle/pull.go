@@ -0,0 +1,120 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+var pullCmd = &cobra.Command{
+	Use:   "pull <bundle-cid>",
+	Short: "Pull a bundle and its member records",
+	Long: `Pull a bundle manifest and its member records.
+Every member record is verified against the CID listed in the bundle.
+Members that are missing or do not match are reported individually,
+and the command fails if the bundle is incomplete.
+
+Usage examples:
+
+1. Pull a bundle:
+   dirctl bundle pull <bundle-cid>
+
+2. Pull a bundle with its member records as JSON:
+   dirctl bundle pull <bundle-cid> --json
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPullCommand(cmd, args[0])
+	},
+}
+
+// memberOutput is the output of a single bundle member.
+type memberOutput struct {
+	Cid    string         `json:"cid"`
+	Role   string         `json:"role,omitempty"`
+	Record map[string]any `json:"record,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+func runPullCommand(cmd *cobra.Command, cid string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	result, err := c.PullBundle(cmd.Context(), &corev1.RecordRef{Cid: cid})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		members := make([]memberOutput, 0, len(result.Members))
+		for _, member := range result.Members {
+			members = append(members, newMemberOutput(member))
+		}
+
+		if err := presenter.PrintMessage(cmd, "bundle", "Bundle", map[string]any{
+			"cid":         cid,
+			"name":        result.Bundle.GetName(),
+			"annotations": result.Bundle.GetAnnotations(),
+			"members":     members,
+		}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	} else {
+		printBundle(cmd, cid, result)
+	}
+
+	if err := result.Err(); err != nil {
+		return fmt.Errorf("bundle is incomplete: %w", err)
+	}
+
+	return nil
+}
+
+func newMemberOutput(member *client.BundleMemberResult) memberOutput {
+	output := memberOutput{
+		Cid:  member.Member.GetCid(),
+		Role: member.Member.GetRole(),
+	}
+
+	if member.Error != nil {
+		output.Error = member.Error.Error()
+	} else {
+		output.Record = member.Record.GetData().AsMap()
+	}
+
+	return output
+}
+
+func printBundle(cmd *cobra.Command, cid string, result *client.BundleResult) {
+	presenter.Printf(cmd, "Bundle: %s\n", cid)
+
+	if name := result.Bundle.GetName(); name != "" {
+		presenter.Printf(cmd, "Name: %s\n", name)
+	}
+
+	presenter.Println(cmd, "Members:")
+
+	for _, member := range result.Members {
+		role := member.Member.GetRole()
+		if role == "" {
+			role = "-"
+		}
+
+		state := "ok"
+		if member.Error != nil {
+			state = member.Error.Error()
+		}
+
+		presenter.Printf(cmd, "  %s  %s  %s\n", member.Member.GetCid(), role, state)
+	}
+}
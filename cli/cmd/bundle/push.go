@@ -0,0 +1,132 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+var pushCmd = &cobra.Command{
+	Use:   "push <file>",
+	Short: "Store a bundle manifest",
+	Long: `Store a bundle manifest created with 'dirctl bundle create'.
+All member records must have been pushed before.
+
+Usage examples:
+
+1. Push a bundle from file:
+   dirctl bundle push bundle.json
+
+2. Push a bundle from standard input:
+   dirctl bundle create <cid-1> <cid-2> | dirctl bundle push -
+
+3. Push a bundle and publish its member records atomically, so that either all
+   of them or none of them are listed on the network:
+   dirctl bundle push bundle.json --publish --ttl 24h
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPushCommand(cmd, args[0])
+	},
+}
+
+// Push command options.
+var pushOpts struct {
+	Publish bool
+	TTL     time.Duration
+	Public  bool
+}
+
+func init() {
+	flags := pushCmd.Flags()
+	flags.BoolVar(&pushOpts.Publish, "publish", false, "Publish all member records in a single transaction after pushing the bundle")
+	flags.DurationVar(&pushOpts.TTL, "ttl", 0, "Expire the announcements after this duration unless re-announced (0 = never expire), requires --publish")
+	flags.BoolVar(&pushOpts.Public, "public", false, "List the member records to callers of all namespaces, requires --publish")
+}
+
+func runPushCommand(cmd *cobra.Command, path string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	bundle, err := corev1.UnmarshalBundle(data)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if pushOpts.TTL != 0 && !pushOpts.Publish {
+		return errors.New("--ttl requires --publish")
+	}
+
+	if pushOpts.Public && !pushOpts.Publish {
+		return errors.New("--public requires --publish")
+	}
+
+	ref, err := c.PushBundle(cmd.Context(), bundle)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if pushOpts.Publish {
+		if err := publishMembers(cmd, c, bundle); err != nil {
+			return err
+		}
+	}
+
+	if ref.GetAlreadyExisted() {
+		return presenter.PrintMessage(cmd, "bundle", "Bundle already exists with CID", ref.GetCid())
+	}
+
+	return presenter.PrintMessage(cmd, "bundle", "Pushed bundle with CID", ref.GetCid())
+}
+
+// publishMembers publishes the member records of the bundle atomically.
+func publishMembers(cmd *cobra.Command, c *client.Client, bundle *corev1.RecordBundle) error {
+	refs := make([]*corev1.RecordRef, 0, len(bundle.GetMembers()))
+	for _, member := range bundle.GetMembers() {
+		refs = append(refs, &corev1.RecordRef{Cid: member.GetCid()})
+	}
+
+	var opts []client.PublishOption
+	if pushOpts.TTL > 0 {
+		opts = append(opts, client.WithTTL(pushOpts.TTL))
+	}
+
+	if pushOpts.Public {
+		opts = append(opts, client.WithVisibility(routingv1.Visibility_VISIBILITY_PUBLIC))
+	}
+
+	if err := c.PublishAtomic(cmd.Context(), refs, opts...); err != nil {
+		return fmt.Errorf("failed to publish bundle members: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	signv1 "github.com/agntcy/dir/api/sign/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <bundle-cid>",
+	Short: "Verify the members and the signature of a bundle",
+	Long: `Verify that every member record of a bundle exists and matches the CID
+listed in the bundle, and that the bundle is signed.
+
+Usage examples:
+
+1. Verify a bundle:
+   dirctl bundle verify <bundle-cid>
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVerifyCommand(cmd, args[0])
+	},
+}
+
+func runVerifyCommand(cmd *cobra.Command, cid string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	ref := &corev1.RecordRef{Cid: cid}
+
+	result, err := c.PullBundle(cmd.Context(), ref)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := result.Err(); err != nil {
+		return fmt.Errorf("bundle members failed verification: %w", err)
+	}
+
+	response, err := c.Verify(cmd.Context(), &signv1.VerifyRequest{RecordRef: ref})
+	if err != nil {
+		return fmt.Errorf("failed to verify bundle signature: %w", err)
+	}
+
+	if !response.GetSuccess() {
+		return fmt.Errorf("bundle signature is not trusted: %s", response.GetErrorMessage())
+	}
+
+	return presenter.PrintMessage(cmd, "bundle", "Bundle is", "trusted")
+}
@@ -0,0 +1,95 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package cid
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "cid <file.json | ->",
+	Short: "Compute the CID of a record without pushing it",
+	Long: `This command computes the CID that Directory assigns to a record, without contacting the server.
+The record is loaded as is, no fields are defaulted, so the CID matches the one returned by push.
+
+Usage examples:
+
+1. Compute the CID of a record file
+
+	dirctl cid record.json
+
+2. Compute the CID of a record read from standard input
+
+	cat record.json | dirctl cid -
+
+3. Fail if the record does not have the expected CID
+
+	dirctl cid record.json --verify <cid>
+
+4. Hash the canonical bytes with an external tool
+
+	dirctl cid record.json --canonical | sha256sum
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("exactly one argument is required which is the record file or - for standard input")
+		}
+
+		if args[0] == "-" {
+			return runCommand(cmd, cmd.InOrStdin())
+		}
+
+		source, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("could not open file %s: %w", args[0], err)
+		}
+		defer source.Close()
+
+		return runCommand(cmd, source)
+	},
+}
+
+func runCommand(cmd *cobra.Command, source io.Reader) error {
+	var loadOpts []corev1.LoadOption
+	if opts.Lenient {
+		loadOpts = append(loadOpts, corev1.Lenient())
+	}
+
+	record, err := corev1.LoadOASFFromReader(source, loadOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to load OASF: %w", err)
+	}
+
+	canonical, err := record.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	cid := record.GetCid()
+	if cid == "" {
+		return errors.New("failed to compute record CID")
+	}
+
+	if opts.Verify != "" && opts.Verify != cid {
+		return fmt.Errorf("CID mismatch: expected %s, computed %s", opts.Verify, cid)
+	}
+
+	if opts.Canonical {
+		_, err := cmd.OutOrStdout().Write(canonical)
+
+		return err
+	}
+
+	presenter.Println(cmd, cid)
+
+	return nil
+}
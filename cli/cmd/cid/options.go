@@ -0,0 +1,25 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cid
+
+var opts = &options{}
+
+type options struct {
+	Verify    string
+	Canonical bool
+	Lenient   bool
+}
+
+func init() {
+	flags := Command.Flags()
+	flags.StringVar(&opts.Verify, "verify", "",
+		"Expected CID of the record. The command fails if the computed CID differs.",
+	)
+	flags.BoolVar(&opts.Canonical, "canonical", false,
+		"Write the canonical bytes of the record to standard output instead of its CID.",
+	)
+	flags.BoolVar(&opts.Lenient, "lenient", false,
+		"Accept fields unknown to the schema version of the record.",
+	)
+}
@@ -8,13 +8,29 @@ import (
 	"errors"
 	"fmt"
 
-	corev1 "github.com/agntcy/dir/api/core/v1"
 	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
 	"github.com/spf13/cobra"
 )
 
+var force bool
+
 func init() {
+	Command.Flags().BoolVar(&force, "force", false,
+		"Delete the record even if it is published. The record is unpublished before it is deleted.",
+	)
+	Command.Flags().StringArrayVar(&filterOpts.Filters, "filter", nil,
+		"Delete the records matching key=value instead of a single record. Keys are name, trust-domain or an annotation key, values are patterns. Can be repeated.",
+	)
+	Command.Flags().DurationVar(&filterOpts.OlderThan, "older-than", 0,
+		"Only delete the matching records added to the directory longer ago than the duration, e.g. 720h.",
+	)
+	Command.Flags().BoolVarP(&filterOpts.Yes, "yes", "y", false,
+		"Delete the matching records without asking for confirmation.",
+	)
+
 	// Add output format flags
 	presenter.AddOutputFlags(Command)
 }
@@ -27,9 +43,31 @@ var Command = &cobra.Command{
 Usage example:
 
 	dirctl delete <cid>
+	dirctl delete <name>@<version>
+
+Published records are protected from deletion. Unpublish them first,
+or delete and unpublish them at once:
+
+	dirctl delete <cid> --force
+
+Records can also be deleted in bulk by filter. The matching records are
+listed first, and only deleted after confirmation or with --yes. Published
+records are skipped unless --force is given:
+
+	dirctl delete --filter name=stream-test-agent-* --older-than 720h
+	dirctl delete --filter team=qa --filter trust-domain=example.org --yes
 
 `,
+	ValidArgsFunction: completion.CIDs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(filterOpts.Filters) > 0 || filterOpts.OlderThan > 0 {
+			if len(args) > 0 {
+				return errors.New("cid cannot be combined with --filter or --older-than")
+			}
+
+			return runFilterCommand(cmd)
+		}
+
 		if len(args) != 1 {
 			return errors.New("cid is a required argument")
 		}
@@ -45,11 +83,21 @@ func runCommand(cmd *cobra.Command, cid string) error {
 		return errors.New("failed to get client from context")
 	}
 
-	// Delete object from store
-	err := c.Delete(cmd.Context(), &corev1.RecordRef{
-		Cid: cid,
-	})
+	// Resolve name@version locators to the record they refer to
+	ref, err := c.ResolveLocator(cmd.Context(), cid)
 	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", cid, err)
+	}
+
+	cid = ref.GetCid()
+
+	var deleteOpts []client.DeleteOption
+	if force {
+		deleteOpts = append(deleteOpts, client.WithForce())
+	}
+
+	// Delete object from store
+	if err := c.Delete(cmd.Context(), ref, deleteOpts...); err != nil {
 		return fmt.Errorf("failed to delete record: %w", err)
 	}
 
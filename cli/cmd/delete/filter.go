@@ -0,0 +1,141 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:predeclared,wrapcheck
+package delete
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+var filterOpts struct {
+	Filters   []string
+	OlderThan time.Duration
+	Yes       bool
+}
+
+// parseFilter converts the --filter and --older-than flags into a search filter.
+func parseFilter() (client.SearchFilter, error) {
+	var filter client.SearchFilter
+
+	for _, entry := range filterOpts.Filters {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok || key == "" {
+			return filter, fmt.Errorf("invalid filter %q, expected key=value", entry)
+		}
+
+		switch key {
+		case "name":
+			filter.Name = value
+		case "trust-domain":
+			filter.TrustDomain = value
+		default:
+			if filter.Annotations == nil {
+				filter.Annotations = make(map[string]string)
+			}
+
+			filter.Annotations[key] = value
+		}
+	}
+
+	if filterOpts.OlderThan < 0 {
+		return filter, errors.New("--older-than must be positive")
+	}
+
+	if filterOpts.OlderThan > 0 {
+		filter.CreatedBefore = time.Now().Add(-filterOpts.OlderThan)
+	}
+
+	return filter, nil
+}
+
+func runFilterCommand(cmd *cobra.Command) error {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	filter, err := parseFilter()
+	if err != nil {
+		return err
+	}
+
+	var deleteOpts []client.DeleteOption
+	if force {
+		deleteOpts = append(deleteOpts, client.WithForce())
+	}
+
+	// List the candidates with a dry run first
+	results, err := c.DeleteByFilter(cmd.Context(), filter, deleteOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to search records: %w", err)
+	}
+
+	var candidates int
+
+	for result := range results {
+		switch {
+		case result.Error != nil:
+			return fmt.Errorf("failed to search records: %w", result.Error)
+		case result.Pinned:
+			presenter.Printf(cmd, "%s (published, skipped)\n", result.Ref.GetCid())
+		default:
+			presenter.Println(cmd, result.Ref.GetCid())
+
+			candidates++
+		}
+	}
+
+	if candidates == 0 {
+		presenter.Println(cmd, "No records to delete")
+
+		return nil
+	}
+
+	if !filterOpts.Yes && !confirm(cmd, fmt.Sprintf("Delete %d records? [y/N]: ", candidates)) {
+		return errors.New("delete aborted")
+	}
+
+	results, err = c.DeleteByFilter(cmd.Context(), filter, append(deleteOpts, client.Confirm())...)
+	if err != nil {
+		return fmt.Errorf("failed to delete records: %w", err)
+	}
+
+	var (
+		deleted int
+		errs    error
+	)
+
+	for result := range results {
+		switch {
+		case result.Error != nil:
+			errs = errors.Join(errs, fmt.Errorf("failed to delete record %s: %w", result.Ref.GetCid(), result.Error))
+		case !result.Pinned:
+			deleted++
+		}
+	}
+
+	presenter.Printf(cmd, "Deleted %d records\n", deleted)
+
+	return errs
+}
+
+// confirm asks the user a yes or no question on the command input.
+func confirm(cmd *cobra.Command, question string) bool {
+	presenter.Error(cmd, question)
+
+	answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,83 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package deprecate
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "deprecate",
+	Short: "Deprecate or withdraw a record in the Directory store",
+	Long: `This command sets the lifecycle status of a record without deleting it.
+Deprecated and withdrawn records can still be pulled, but consumers are warned.
+Withdrawn records are also excluded from 'dirctl routing list' by default.
+
+Only callers of the trust domain that pushed the record may change its status.
+
+Usage examples:
+
+1. Deprecate a record in favour of a newer one
+
+	dirctl deprecate <cid> --successor <cid2> --reason "superseded by v2"
+
+2. Withdraw a record
+
+	dirctl deprecate <cid> --status withdrawn --reason "security issue"
+
+3. Reactivate a record
+
+	dirctl deprecate <cid> --status active
+`,
+	ValidArgsFunction: completion.CIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("cid is a required argument")
+		}
+
+		return runCommand(cmd, args[0])
+	},
+}
+
+func runCommand(cmd *cobra.Command, cid string) error {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	lifecycleStatus, err := corev1.ParseLifecycleStatus(opts.Status)
+	if err != nil {
+		return err
+	}
+
+	lifecycle := &corev1.Lifecycle{
+		Status:       lifecycleStatus,
+		SuccessorCid: opts.Successor,
+		Reason:       opts.Reason,
+	}
+
+	if err := lifecycle.Validate(cid); err != nil {
+		return fmt.Errorf("invalid lifecycle: %w", err)
+	}
+
+	meta, err := c.SetRecordLifecycle(cmd.Context(), cid, lifecycle)
+	if err != nil {
+		return err
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "record", "Record metadata", meta)
+	}
+
+	return presenter.PrintMessage(cmd, "record", "Set lifecycle status of record "+cid+" to", meta.GetLifecycle().StatusName())
+}
@@ -0,0 +1,33 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package deprecate
+
+import (
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
+	"github.com/spf13/cobra"
+)
+
+var opts = &options{}
+
+type options struct {
+	Status    string
+	Successor string
+	Reason    string
+}
+
+func init() {
+	flags := Command.Flags()
+	flags.StringVar(&opts.Status, "status", "deprecated", "Lifecycle status to set: active, deprecated or withdrawn.")
+	flags.StringVar(&opts.Successor, "successor", "", "CID of the record that replaces the deprecated record.")
+	flags.StringVar(&opts.Reason, "reason", "", "Reason shown to consumers pulling the record.")
+
+	_ = Command.RegisterFlagCompletionFunc("status", cobra.FixedCompletions(
+		[]cobra.Completion{"active", "deprecated", "withdrawn"}, cobra.ShellCompDirectiveNoFileComp,
+	))
+	_ = Command.RegisterFlagCompletionFunc("successor", completion.CIDFlag)
+
+	// Add output format flags
+	presenter.AddOutputFlags(Command)
+}
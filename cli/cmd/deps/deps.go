@@ -0,0 +1,120 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package deps
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "deps",
+	Short: "Show the dependencies of a record",
+	Long: `This command resolves the records a record depends on, as declared by
+its core/dependencies extension. Dependencies by name resolve to the record
+with the highest version satisfying the version constraint.
+
+By default, the transitive dependencies are listed in deployment order,
+with every record after the records it depends on.
+
+Usage examples:
+
+1. List the dependencies of a record in deployment order
+
+	dirctl deps <cid>
+
+2. Print the dependencies as a tree
+
+	dirctl deps <name>@<version> --tree
+
+3. Only resolve the direct dependencies
+
+	dirctl deps <cid> --direct
+`,
+	ValidArgsFunction: completion.CIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("exactly one argument is required which is the cid of the record")
+		}
+
+		return runCommand(cmd, args[0])
+	},
+}
+
+func runCommand(cmd *cobra.Command, cid string) error {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	// Resolve name@version locators to the record they refer to
+	ref, err := c.ResolveLocator(cmd.Context(), cid)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", cid, err)
+	}
+
+	graph, err := c.ResolveDependencies(cmd.Context(), ref, client.ResolveOptions{
+		Transitive: !opts.Direct,
+		MaxDepth:   opts.MaxDepth,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve dependencies: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "deps", "Record dependencies", graph)
+	}
+
+	if opts.Tree {
+		printTree(cmd, graph, graph.Root, "", "")
+
+		return nil
+	}
+
+	for _, cid := range graph.Order {
+		presenter.Println(cmd, formatNode(graph.Nodes[cid]))
+	}
+
+	return nil
+}
+
+// printTree prints the dependencies of a record below it. Records depended on
+// several times are printed each time.
+func printTree(cmd *cobra.Command, graph *client.DependencyGraph, cid, prefix, childPrefix string) {
+	node := graph.Nodes[cid]
+	presenter.Println(cmd, prefix+formatNode(node))
+
+	for i, dependency := range node.Dependencies {
+		if i == len(node.Dependencies)-1 {
+			printTree(cmd, graph, dependency, childPrefix+"└── ", childPrefix+"    ")
+		} else {
+			printTree(cmd, graph, dependency, childPrefix+"├── ", childPrefix+"│   ")
+		}
+	}
+}
+
+func formatNode(node *client.DependencyNode) string {
+	var b strings.Builder
+
+	b.WriteString(node.CID)
+
+	if node.Name != "" {
+		fmt.Fprintf(&b, " (%s@%s)", node.Name, node.Version)
+	}
+
+	if node.Truncated {
+		b.WriteString(" ...")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,30 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package deps
+
+import "github.com/agntcy/dir/cli/presenter"
+
+var opts = &options{}
+
+type options struct {
+	Tree     bool
+	Direct   bool
+	MaxDepth int
+}
+
+func init() {
+	flags := Command.Flags()
+	flags.BoolVar(&opts.Tree, "tree", false,
+		"Print the dependencies as a tree instead of in deployment order.",
+	)
+	flags.BoolVar(&opts.Direct, "direct", false,
+		"Only resolve the direct dependencies of the record.",
+	)
+	flags.IntVar(&opts.MaxDepth, "max-depth", 0,
+		"Maximum depth of transitive dependencies to resolve. Zero means no limit.",
+	)
+
+	// Add output format flags
+	presenter.AddOutputFlags(Command)
+}
@@ -0,0 +1,89 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package diff
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+// ErrRecordsDiffer is returned when the compared records are different,
+// so that the command exits with a non-zero status.
+var ErrRecordsDiffer = errors.New("records differ")
+
+func init() {
+	// Add output format flags
+	presenter.AddOutputFlags(Command)
+}
+
+var Command = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare two records from Directory server",
+	Long: `This command pulls two records from Directory API and prints the differences between them.
+
+Records of different OASF schema versions are converted to a common version before comparison.
+Named lists such as skills and modules are compared by name, so reordering is not reported.
+The command exits with status 1 if the records differ.
+
+Usage examples:
+
+1. Compare two records
+
+	dirctl diff <cid1> <cid2>
+
+2. Output the differences as JSON
+
+	dirctl diff <cid1> <cid2> --json
+`,
+	ValidArgsFunction: completion.CIDs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 { //nolint:mnd
+			return errors.New("exactly two cids are required")
+		}
+
+		return runCommand(cmd, args[0], args[1])
+	},
+}
+
+func runCommand(cmd *cobra.Command, cid1, cid2 string) error {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	a, err := c.Pull(cmd.Context(), &corev1.RecordRef{Cid: cid1})
+	if err != nil {
+		return fmt.Errorf("failed to pull %s: %w", cid1, err)
+	}
+
+	b, err := c.Pull(cmd.Context(), &corev1.RecordRef{Cid: cid2})
+	if err != nil {
+		return fmt.Errorf("failed to pull %s: %w", cid2, err)
+	}
+
+	diff, err := corev1.DiffRecords(a, b)
+	if err != nil {
+		return fmt.Errorf("failed to diff records: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format == presenter.FormatHuman {
+		presenter.Println(cmd, diff.String())
+	} else if err := presenter.PrintMessage(cmd, "differences", "Differences", diff.Entries); err != nil {
+		return err
+	}
+
+	if diff.HasChanges() {
+		return ErrRecordsDiffer
+	}
+
+	return nil
+}
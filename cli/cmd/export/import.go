@@ -0,0 +1,105 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	ImportCommand.Flags().BoolVar(&importOpts.ApplyDeletes, "apply-deletes", false, "Delete the records exported as deleted")
+	ImportCommand.Flags().StringVar(&importOpts.SinceSnapshot, "since-snapshot", "", "Snapshot file of the last imported export, to reject exports that do not continue it")
+	ImportCommand.Flags().StringVar(&importOpts.SnapshotOut, "snapshot-out", "", "File to write the snapshot of the imported export to")
+}
+
+var importOpts struct {
+	ApplyDeletes  bool
+	SinceSnapshot string
+	SnapshotOut   string
+}
+
+var ImportCommand = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import an export into the Directory store",
+	Long: `Push the records of an export written by "dirctl export" to the server
+store. Use "-" to read the export from standard input.
+
+Deletions carried by incremental exports are only applied with --apply-deletes.
+With --since-snapshot, the export is validated to continue the last imported
+export without gaps before any change is applied. Importing an export more than
+once is harmless.
+
+Usage examples:
+
+1. Import a full export and save the snapshot:
+   dirctl import full.jsonl --snapshot-out imported.json
+
+2. Import the next incremental export, including deletions:
+   dirctl import changes.jsonl --apply-deletes --since-snapshot imported.json --snapshot-out imported.json
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImportCommand(cmd, args[0])
+	},
+}
+
+func runImportCommand(cmd *cobra.Command, path string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	importOptions := client.ImportOptions{ApplyDeletes: importOpts.ApplyDeletes}
+
+	if importOpts.SinceSnapshot != "" {
+		snapshot, err := LoadSnapshot(importOpts.SinceSnapshot)
+		if err != nil {
+			return err
+		}
+
+		importOptions.Since = snapshot.ToSequence
+	}
+
+	var r io.Reader = cmd.InOrStdin()
+
+	if path != "-" {
+		file, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return fmt.Errorf("failed to open export: %w", err)
+		}
+
+		defer file.Close()
+
+		r = file
+	}
+
+	summary, err := c.Import(cmd.Context(), r, importOptions)
+	if err != nil {
+		return err
+	}
+
+	if importOpts.SnapshotOut != "" {
+		if err := SaveSnapshot(importOpts.SnapshotOut, &summary.Manifest); err != nil {
+			return err
+		}
+	}
+
+	presenter.Printf(cmd, "Imported %d records and %d deletions up to sequence %d\n", summary.Records, summary.Deleted, summary.Manifest.ToSequence)
+
+	if summary.SkippedDeletes > 0 {
+		presenter.Printf(cmd, "Skipped %d deletions, use --apply-deletes to apply them\n", summary.SkippedDeletes)
+	}
+
+	return nil
+}
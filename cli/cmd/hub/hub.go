@@ -18,5 +18,12 @@ func NewCommand(hub Hub) *cobra.Command {
 		SilenceUsage:       true,
 	}
 
+	// Arguments are not parsed by dirctl, so completion is delegated to the hub
+	if completer, ok := hub.(Completer); ok {
+		cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			return completer.Complete(cmd.Context(), args, toComplete)
+		}
+	}
+
 	return cmd
 }
@@ -3,8 +3,17 @@
 
 package hub
 
-import "context"
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
 
 type Hub interface {
 	Run(ctx context.Context, args []string) error
 }
+
+// Completer is implemented by hubs that complete the arguments of their commands in shells.
+type Completer interface {
+	Complete(ctx context.Context, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+}
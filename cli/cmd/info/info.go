@@ -8,13 +8,20 @@ import (
 	"errors"
 	"fmt"
 
-	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
 	"github.com/spf13/cobra"
 )
 
+var includeDeleted bool
+
 func init() {
+	Command.Flags().BoolVar(&includeDeleted, "include-deleted", false,
+		"Also look up records in the trash of servers in soft deletion mode. Requires a caller of the server's trust domain.",
+	)
+
 	// Add output format flags
 	presenter.AddOutputFlags(Command)
 }
@@ -27,8 +34,14 @@ var Command = &cobra.Command{
 Usage example:
 
 	dirctl info <cid>
+	dirctl info <name>@<version>
+
+Records deleted on servers in soft deletion mode can be looked up until they are purged:
+
+	dirctl info <cid> --include-deleted
 
 `,
+	ValidArgsFunction: completion.CIDs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
 			return errors.New("exactly one argument is required which is the cid of the object")
@@ -45,10 +58,19 @@ func runCommand(cmd *cobra.Command, cid string) error {
 		return errors.New("failed to get client from context")
 	}
 
+	// Resolve name@version locators to the record they refer to
+	ref, err := c.ResolveLocator(cmd.Context(), cid)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", cid, err)
+	}
+
+	ctx := cmd.Context()
+	if includeDeleted {
+		ctx = storev1.ContextWithIncludeDeleted(ctx)
+	}
+
 	// Fetch info from store
-	info, err := c.Lookup(cmd.Context(), &corev1.RecordRef{
-		Cid: cid,
-	})
+	info, err := c.Lookup(ctx, ref)
 	if err != nil {
 		return fmt.Errorf("failed to pull data: %w", err)
 	}
@@ -0,0 +1,150 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package initialize
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "init",
+	Short: "Generate a record skeleton",
+	Long: `This command generates a new OASF record that can be edited and pushed to Directory.
+The generated record is validated with the same rules that are applied on push.
+
+Usage examples:
+
+1. Generate a record and write it to a file
+
+	dirctl init --name my-agent --version v1.0.0 \
+		--description "My agent" --author "Jane Doe <jane.doe@example.com>" \
+		--skill natural_language_processing/text_completion \
+		--locator docker_image=ghcr.io/example/my-agent:v1.0.0 \
+		-o record.json
+
+2. Generate a record for an older OASF schema version, skipping validation
+
+	dirctl init --schema-version v0.3.1 --name my-agent --allow-invalid
+
+3. Scaffold a new version from an existing record
+
+	dirctl init --from <cid> --version v2.0.0 -o record.json
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			return errors.New("no arguments are allowed")
+		}
+
+		return runCommand(cmd)
+	},
+}
+
+func runCommand(cmd *cobra.Command) error {
+	templateOpts := corev1.TemplateOptions{
+		Name:        opts.Name,
+		Version:     opts.Version,
+		Description: opts.Description,
+		Authors:     opts.Authors,
+		Skills:      opts.Skills,
+		Extensions:  opts.Extensions,
+	}
+
+	for _, locator := range opts.Locators {
+		locatorType, url, ok := strings.Cut(locator, "=")
+		if !ok {
+			return fmt.Errorf("invalid locator %q: expected <type>=<url>", locator)
+		}
+
+		templateOpts.Locators = append(templateOpts.Locators, corev1.TemplateLocator{Type: locatorType, URL: url})
+	}
+
+	var (
+		record *corev1.Record
+		err    error
+	)
+
+	if opts.FromCid != "" {
+		record, err = scaffoldFrom(cmd, opts.FromCid, templateOpts)
+	} else {
+		record, err = corev1.NewTemplate(opts.SchemaVersion, templateOpts)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to generate record: %w", err)
+	}
+
+	if err := corev1.ValidateTemplate(record); err != nil {
+		if !opts.AllowInvalid {
+			return err
+		}
+
+		presenter.Errorf(cmd, "Warning: %v\n", err)
+	}
+
+	return writeRecord(cmd, record)
+}
+
+// scaffoldFrom pulls an existing record and strips its instance-specific fields.
+func scaffoldFrom(cmd *cobra.Command, cid string, templateOpts corev1.TemplateOptions) (*corev1.Record, error) {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return nil, errors.New("failed to get client from context")
+	}
+
+	record, err := c.Pull(cmd.Context(), &corev1.RecordRef{Cid: cid})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull %s: %w", cid, err)
+	}
+
+	if cmd.Flags().Changed("schema-version") {
+		record, err = record.ConvertTo(opts.SchemaVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert record: %w", err)
+		}
+	}
+
+	return corev1.TemplateFrom(record, templateOpts)
+}
+
+// writeRecord writes the record as indented JSON to the output file or standard output.
+func writeRecord(cmd *cobra.Command, record *corev1.Record) error {
+	data, err := record.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	var output bytes.Buffer
+	if err := json.Indent(&output, data, "", "  "); err != nil {
+		return fmt.Errorf("failed to format record: %w", err)
+	}
+
+	output.WriteString("\n")
+
+	if opts.Output == "" {
+		presenter.Print(cmd, output.String())
+
+		return nil
+	}
+
+	//nolint:mnd
+	if err := os.WriteFile(opts.Output, output.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write record to %s: %w", opts.Output, err)
+	}
+
+	presenter.Printf(cmd, "Record written to %s\n", opts.Output)
+
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package initialize
+
+import "github.com/agntcy/dir/cli/util/completion"
+
+var opts = &options{}
+
+type options struct {
+	SchemaVersion string
+	Name          string
+	Version       string
+	Description   string
+	Authors       []string
+	Skills        []string
+	Extensions    []string
+	Locators      []string
+	FromCid       string
+	Output        string
+	AllowInvalid  bool
+}
+
+func init() {
+	flags := Command.Flags()
+	flags.StringVar(&opts.SchemaVersion, "schema-version", "0.7.0", "OASF schema version of the generated record (0.7.0 or v0.3.1).")
+	flags.StringVar(&opts.Name, "name", "", "Name of the record.")
+	flags.StringVar(&opts.Version, "version", "", "Version of the record.")
+	flags.StringVar(&opts.Description, "description", "", "Description of the record.")
+	flags.StringArrayVar(&opts.Authors, "author", nil, "Author of the record (can be repeated)")
+	flags.StringArrayVar(&opts.Skills, "skill", nil, "Skill in <category>/<class> format (can be repeated)")
+	flags.StringArrayVar(&opts.Extensions, "extension", nil, "Extension or module name (can be repeated)")
+	flags.StringArrayVar(&opts.Locators, "locator", nil, "Locator in <type>=<url> format (can be repeated)")
+	flags.StringVar(&opts.FromCid, "from", "", "Scaffold from an existing record pulled by CID, dropping its signature and annotations.")
+	flags.StringVarP(&opts.Output, "output", "o", "", "Write the record to the given file instead of standard output.")
+	flags.BoolVar(&opts.AllowInvalid, "allow-invalid", false, "Write the record even if it does not pass push-time validation.")
+
+	_ = Command.RegisterFlagCompletionFunc("schema-version", completion.SchemaVersionFlag)
+	_ = Command.RegisterFlagCompletionFunc("from", completion.CIDFlag)
+}
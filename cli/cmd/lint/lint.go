@@ -0,0 +1,167 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/api/lint"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var opts struct {
+	Disabled   []string
+	Severities map[string]string
+}
+
+func init() {
+	flags := Command.Flags()
+	flags.StringSliceVar(&opts.Disabled, "disable", nil,
+		"Rules that are not run (e.g., --disable no-locators,no-skills)")
+	flags.StringToStringVar(&opts.Severities, "severity", nil,
+		"Severity overriding the default of a rule: info, warning or error (e.g., --severity no-locators=error)")
+
+	// Add output format flags
+	presenter.AddOutputFlags(Command)
+}
+
+var Command = &cobra.Command{
+	Use:   "lint <file.json|cid>",
+	Short: "Check a record against advisory lint rules",
+	Long: `This command checks a record against advisory rules that validation does not enforce.
+
+The record is loaded from a file, or pulled from the store if the argument is a CID.
+The built-in rules are:
+
+- description-too-short (warning): the description has less than 20 characters
+- no-locators (warning): the record does not point to its deployment
+- no-skills (warning): the record cannot be discovered by skill
+- version-not-semver (warning): the version is not a semantic version
+- deprecated-extension-prefix (warning): a 0.7.0 module uses a v0.3.1 extension name prefix
+- annotation-not-namespaced (info): an annotation key is not namespaced, e.g. "example.com/team"
+
+Only the envelope metadata of encrypted records is checked.
+The command exits with status 1 if the highest severity of the findings is warning,
+and with status 2 if it is error.
+
+Usage examples:
+
+1. Lint a record file
+
+	dirctl lint record.json
+
+2. Fail on records without locators, ignoring annotations
+
+	dirctl lint <cid> --severity no-locators=error --disable annotation-not-namespaced
+
+3. Output the findings as JSON
+
+	dirctl lint record.json --json
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCommand(cmd, args[0])
+	},
+}
+
+// SeverityError is returned when the lint reports findings of at least the warning severity,
+// so that the command exits with a status reflecting the highest severity.
+type SeverityError struct {
+	Severity lint.Severity
+}
+
+func (e SeverityError) Error() string {
+	return fmt.Sprintf("lint reported findings of severity %s", e.Severity)
+}
+
+// ExitCode returns 2 for findings of the error severity and 1 for warnings.
+func (e SeverityError) ExitCode() int {
+	if e.Severity.AtLeast(lint.SeverityError) {
+		return 2 //nolint:mnd
+	}
+
+	return 1
+}
+
+func runCommand(cmd *cobra.Command, source string) error {
+	cfg := lint.Config{Disabled: opts.Disabled, Severities: map[string]lint.Severity{}}
+
+	for id, severity := range opts.Severities {
+		parsed, err := lint.ParseSeverity(severity)
+		if err != nil {
+			return fmt.Errorf("invalid --severity for %s: %w", id, err)
+		}
+
+		cfg.Severities[id] = parsed
+	}
+
+	record, err := loadRecord(cmd, source)
+	if err != nil {
+		return err
+	}
+
+	findings, err := lint.Run(record, cfg)
+	if err != nil {
+		return fmt.Errorf("invalid lint configuration: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		if findings == nil {
+			findings = []lint.Finding{}
+		}
+
+		if err := presenter.PrintMessage(cmd, "findings", "Lint findings", findings); err != nil {
+			return err
+		}
+	} else {
+		if len(findings) == 0 {
+			presenter.Println(cmd, "No lint findings")
+		}
+
+		for _, finding := range findings {
+			presenter.Println(cmd, finding.String())
+		}
+	}
+
+	if severity := lint.MaxSeverity(findings); severity.AtLeast(lint.SeverityWarning) {
+		return SeverityError{Severity: severity}
+	}
+
+	return nil
+}
+
+// loadRecord loads the record from a file, or pulls it from the store by CID.
+func loadRecord(cmd *cobra.Command, source string) (*corev1.Record, error) {
+	data, err := os.ReadFile(source)
+	if err == nil {
+		record, err := corev1.UnmarshalRecord(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OASF: %w", err)
+		}
+
+		return record, nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) || !corev1.IsValidCID(source) {
+		return nil, fmt.Errorf("could not open file %s: %w", source, err)
+	}
+
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return nil, errors.New("failed to get client from context")
+	}
+
+	record, err := c.Pull(cmd.Context(), &corev1.RecordRef{Cid: source})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull record: %w", err)
+	}
+
+	return record, nil
+}
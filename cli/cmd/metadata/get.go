@@ -0,0 +1,76 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package metadata
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var getCmd = &cobra.Command{
+	Use:   "get <cid|name@version>",
+	Short: "Show the metadata of a record",
+	Long: `Show the operational metadata of a record.
+
+Usage examples:
+
+1. Show the metadata of a record:
+   dirctl metadata get <cid>
+
+2. Show the metadata as JSON:
+   dirctl metadata get <cid> --output json
+`,
+	ValidArgsFunction: completion.CIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("cid is a required argument")
+		}
+
+		return runGetCommand(cmd, args[0])
+	},
+}
+
+func runGetCommand(cmd *cobra.Command, ref string) error {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	metadata, err := c.GetRecordMetadata(cmd.Context(), &corev1.RecordRef{Cid: ref})
+	if err != nil {
+		return err
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "metadata", "Record metadata", metadata)
+	}
+
+	return printMetadata(cmd, ref, metadata)
+}
+
+// printMetadata prints the metadata entries of a record sorted by key.
+func printMetadata(cmd *cobra.Command, ref string, metadata map[string]string) error {
+	if len(metadata) == 0 {
+		presenter.Println(cmd, "Record "+ref+" has no metadata")
+
+		return nil
+	}
+
+	presenter.Println(cmd, "Metadata of record "+ref+":")
+
+	for _, key := range slices.Sorted(maps.Keys(metadata)) {
+		presenter.Println(cmd, "  "+key+"="+metadata[key])
+	}
+
+	return nil
+}
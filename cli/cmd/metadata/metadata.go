@@ -0,0 +1,41 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "metadata",
+	Short: "Manage the operational metadata of records",
+	Long: `Manage the operational metadata of records in the Directory store.
+
+Operational metadata are key-value pairs such as the owner team or cost center
+of a record. Unlike the record content, they can be changed after the push
+without changing the record CID.
+
+- set: Set or remove metadata entries of a record
+- get: Show the metadata of a record
+
+Examples:
+
+1. Set the owner team of a record:
+   dirctl metadata set <cid> team=platform
+
+2. Remove an entry:
+   dirctl metadata set <cid> team=
+
+3. Show the metadata of a record by its locator:
+   dirctl metadata get my-agent@v1.0.0
+`,
+}
+
+func init() {
+	Command.AddCommand(setCmd, getCmd)
+
+	presenter.AddOutputFlags(setCmd)
+	presenter.AddOutputFlags(getCmd)
+}
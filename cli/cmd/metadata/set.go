@@ -0,0 +1,80 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var setCmd = &cobra.Command{
+	Use:   "set <cid|name@version> <key=value>...",
+	Short: "Set or remove metadata entries of a record",
+	Long: `Set or remove operational metadata entries of a record.
+
+Entries are merged into the current metadata of the record, entries with
+an empty value are removed. Keys are lowercase letters, digits, '.', '_',
+'/' or '-'. The record CID is not changed.
+
+Only callers of the trust domain that pushed the record may change its metadata.
+
+Usage examples:
+
+1. Set the owner team and cost center of a record:
+   dirctl metadata set <cid> team=platform cost-center=cc-42
+
+2. Remove the cost center:
+   dirctl metadata set <cid> cost-center=
+`,
+	ValidArgsFunction: completion.CIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 { //nolint:mnd
+			return errors.New("cid and at least one key=value entry are required arguments")
+		}
+
+		return runSetCommand(cmd, args[0], args[1:])
+	},
+}
+
+func runSetCommand(cmd *cobra.Command, ref string, entries []string) error {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	update := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("invalid metadata entry %q, expected key=value", entry)
+		}
+
+		update[key] = value
+	}
+
+	if err := corev1.ValidateMetadataUpdate(update); err != nil {
+		return fmt.Errorf("invalid metadata: %w", err)
+	}
+
+	meta, err := c.SetRecordMetadata(cmd.Context(), &corev1.RecordRef{Cid: ref}, update)
+	if err != nil {
+		return err
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "record", "Record metadata", meta)
+	}
+
+	return printMetadata(cmd, meta.GetCid(), meta.GetOperationalMetadata())
+}
@@ -9,6 +9,12 @@ import (
 
 var clientConfig = &client.DefaultConfig
 
+// encryptionKeyFile is the local key file records are encrypted with, if set.
+var encryptionKeyFile string
+
+// localDir is the directory of the local record store commands are routed to instead of the server, if set.
+var localDir string
+
 func init() {
 	// load config
 	if cfg, err := client.LoadConfig(); err == nil {
@@ -19,6 +25,9 @@ func init() {
 	flags := RootCmd.PersistentFlags()
 	flags.StringVar(&clientConfig.ServerAddress, "server-addr", clientConfig.ServerAddress, "Directory Server API address")
 	flags.StringVar(&clientConfig.SpiffeSocketPath, "spiffe-socket-path", clientConfig.SpiffeSocketPath, "")
+	flags.StringVar(&clientConfig.Compression, "compression", clientConfig.Compression, "Compress calls to the server with gzip or zstd")
+	flags.StringVar(&encryptionKeyFile, "encryption-key", "", "Key file to encrypt pushed records at rest and decrypt pulled records with")
+	flags.StringVar(&localDir, "local", "", "Use the local record store in this directory instead of the server, e.g. ~/.dir")
 
 	RootCmd.MarkFlagRequired("server-addr") //nolint:errcheck
 }
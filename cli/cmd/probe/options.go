@@ -0,0 +1,31 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/client"
+)
+
+var opts = &options{}
+
+type options struct {
+	client.ProbeOptions
+}
+
+func init() {
+	flags := Command.Flags()
+	flags.DurationVar(&opts.Timeout, "timeout", client.DefaultProbeTimeout,
+		"Timeout of each locator probe.",
+	)
+	flags.IntVar(&opts.Concurrency, "concurrency", client.DefaultProbeConcurrency,
+		"Number of locators probed at once.",
+	)
+	flags.IntVar(&opts.MaxRedirects, "max-redirects", client.DefaultProbeMaxRedirects,
+		"Maximum number of redirects followed by each request. Negative follows no redirects.",
+	)
+
+	// Add output format flags
+	presenter.AddOutputFlags(Command)
+}
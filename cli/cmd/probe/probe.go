@@ -0,0 +1,118 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package probe
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "probe",
+	Short: "Check the locators of a record against their live targets",
+	Long: `This command checks that the targets of the locators of a record exist,
+to detect drift between the directory and what is actually deployed:
+
+- docker image locators get a registry HEAD of the image tag or digest
+- helm chart locators are looked up in the index of their chart repository
+- locators with HTTP URLs get a HEAD, and their content is hashed if they pin a digest
+
+The digest of each target is compared with the digest pinned by the locator,
+or its "digest" annotation. Locators are probed concurrently.
+
+Usage examples:
+
+1. Probe the locators of a record
+
+	dirctl probe <cid>
+
+2. Probe with a shorter timeout per locator
+
+	dirctl probe <name>@<version> --timeout 3s
+
+3. Print the results as JSON
+
+	dirctl probe <cid> --json
+`,
+	ValidArgsFunction: completion.CIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("exactly one argument is required which is the cid of the record")
+		}
+
+		return runCommand(cmd, args[0])
+	},
+}
+
+func runCommand(cmd *cobra.Command, cid string) error {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	// Resolve name@version locators to the record they refer to
+	ref, err := c.ResolveLocator(cmd.Context(), cid)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", cid, err)
+	}
+
+	results, err := c.ProbeLocators(cmd.Context(), ref, opts.ProbeOptions)
+	if err != nil {
+		return fmt.Errorf("failed to probe locators: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "probe", "Locator probes", results)
+	}
+
+	if len(results) == 0 {
+		presenter.Println(cmd, "Record has no locators")
+
+		return nil
+	}
+
+	for _, result := range results {
+		presenter.Println(cmd, formatResult(result))
+	}
+
+	return nil
+}
+
+func formatResult(result client.LocatorProbeResult) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "[%d] %s %s: %s", result.Index, result.Type, result.URL, result.Status)
+
+	if result.StatusCode != 0 {
+		fmt.Fprintf(&b, " (HTTP %d)", result.StatusCode)
+	}
+
+	switch result.Digest {
+	case client.DigestCheckMatch:
+		fmt.Fprintf(&b, ", digest %s matches", result.RemoteDigest)
+	case client.DigestCheckMismatch:
+		fmt.Fprintf(&b, ", digest %s does not match pinned %s", result.RemoteDigest, result.PinnedDigest)
+	case client.DigestCheckUnknown:
+		fmt.Fprintf(&b, ", digest unknown, pinned %s", result.PinnedDigest)
+	case client.DigestCheckUnpinned:
+		if result.RemoteDigest != "" {
+			fmt.Fprintf(&b, ", digest %s", result.RemoteDigest)
+		}
+	}
+
+	if result.Error != "" {
+		fmt.Fprintf(&b, ": %s", result.Error)
+	}
+
+	return b.String()
+}
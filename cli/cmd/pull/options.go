@@ -3,19 +3,28 @@
 
 package pull
 
-import "github.com/agntcy/dir/cli/presenter"
+import (
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
+)
 
 var opts = &options{}
 
 type options struct {
-	PublicKey bool
-	Signature bool
+	PublicKey  bool
+	Signature  bool
+	AsVersion  string
+	AllowLossy bool
 }
 
 func init() {
 	flags := Command.Flags()
 	flags.BoolVar(&opts.PublicKey, "public-key", false, "Pull the public key for the record.")
 	flags.BoolVar(&opts.Signature, "signature", false, "Pull the signature for the record.")
+	flags.StringVar(&opts.AsVersion, "as-version", "", "Convert the record to the given OASF schema version (e.g. 0.7.0, v0.3.1).")
+	flags.BoolVar(&opts.AllowLossy, "allow-lossy", false, "Allow --as-version conversions that drop fields with no equivalent in the target version.")
+
+	_ = Command.RegisterFlagCompletionFunc("as-version", completion.SchemaVersionFlag)
 
 	// Add output format flags
 	presenter.AddOutputFlags(Command)
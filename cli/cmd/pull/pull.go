@@ -12,7 +12,9 @@ import (
 	signv1 "github.com/agntcy/dir/api/sign/v1"
 	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
 	"github.com/spf13/cobra"
 )
 
@@ -35,10 +37,23 @@ Usage examples:
 3. Pull by cid and output signature
 
 	dirctl pull <cid> --signature
+
+4. Pull by cid and convert to another OASF schema version
+
+	dirctl pull <cid> --as-version 0.7.0
+
+5. Pull by name and version, or the highest semantic version of a name
+
+	dirctl pull my-agent@v1.0.0
+	dirctl pull my-agent:latest
+
+   Names matching several records with the same version are rejected,
+   listing the CIDs of the candidates.
 `,
+	ValidArgsFunction: completion.CIDs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
-			return errors.New("cid is a required argument")
+			return errors.New("cid or name@version is a required argument")
 		}
 
 		return runCommand(cmd, args[0])
@@ -53,6 +68,14 @@ func runCommand(cmd *cobra.Command, cid string) error {
 		return errors.New("failed to get client from context")
 	}
 
+	// Resolve name@version locators to the record they refer to
+	ref, err := c.ResolveLocator(cmd.Context(), cid)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", cid, err)
+	}
+
+	cid = ref.GetCid()
+
 	// Fetch record from store
 	record, err := c.Pull(cmd.Context(), &corev1.RecordRef{
 		Cid: cid,
@@ -61,6 +84,24 @@ func runCommand(cmd *cobra.Command, cid string) error {
 		return fmt.Errorf("failed to pull data: %w", err)
 	}
 
+	// Deprecated and withdrawn records can still be pulled, but should no longer be used
+	if deprecation := client.NewDeprecationInfo(record); deprecation != nil {
+		presenter.Errorf(cmd, "Warning: record %s is %s\n", cid, deprecation)
+	}
+
+	// Convert record to the requested schema version
+	if opts.AsVersion != "" {
+		var convertOpts []corev1.ConvertOption
+		if opts.AllowLossy {
+			convertOpts = append(convertOpts, corev1.AllowLossy())
+		}
+
+		record, err = record.ConvertTo(opts.AsVersion, convertOpts...)
+		if err != nil {
+			return fmt.Errorf("failed to convert record: %w", err)
+		}
+	}
+
 	if !opts.PublicKey && !opts.Signature {
 		// Handle different output formats
 		return presenter.PrintMessage(cmd, "record", "Record data", record.GetData())
@@ -0,0 +1,90 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package push
+
+import (
+	"maps"
+	"slices"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+// runDryRun prints what pushing the record would do without storing it.
+// Fields removed by --reproducible are listed in the human preview, and on standard error otherwise.
+func runDryRun(cmd *cobra.Command, c *client.Client, record *corev1.Record, report *corev1.ScrubReport) error {
+	preview, err := c.PushDryRun(cmd.Context(), record)
+	if err != nil {
+		return err
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		printScrubReport(cmd, report)
+
+		return presenter.PrintMessage(cmd, "preview", "Push preview", preview)
+	}
+
+	printPreview(cmd, preview)
+
+	if !report.IsEmpty() {
+		presenter.Println(cmd, "Scrubbed fields (--reproducible):")
+
+		for _, entry := range report.Entries {
+			presenter.Printf(cmd, "  %s (%s)\n", entry.Path, entry.Action)
+		}
+	}
+
+	return nil
+}
+
+func printPreview(cmd *cobra.Command, preview *storev1.PushPreview) {
+	presenter.Printf(cmd, "CID: %s\n", preview.GetCid())
+
+	if preview.GetAlreadyExists() {
+		presenter.Println(cmd, "Record already exists, only missing tags would be created")
+	}
+
+	presenter.Println(cmd, "Tags:")
+
+	for _, tag := range preview.GetTags() {
+		presenter.Printf(cmd, "  %s\n", tag)
+	}
+
+	// Candidate tags that would not be created, e.g. because they normalize to the same tag
+	for _, decision := range preview.GetTagDecisions() {
+		switch decision.GetAction() {
+		case "created", "normalized_from":
+		default:
+			tag := decision.GetOriginal()
+			if tag == "" {
+				tag = decision.GetTag()
+			}
+
+			presenter.Printf(cmd, "  (%s %s tag %q)\n", decision.GetAction(), decision.GetSource(), tag)
+		}
+	}
+
+	presenter.Println(cmd, "Labels:")
+
+	for _, label := range preview.GetLabels() {
+		presenter.Printf(cmd, "  %s\n", label)
+	}
+
+	presenter.Println(cmd, "Annotations:")
+
+	for _, key := range slices.Sorted(maps.Keys(preview.GetAnnotations())) {
+		presenter.Printf(cmd, "  %s=%s\n", key, preview.GetAnnotations()[key])
+	}
+
+	if len(preview.GetValidationErrors()) > 0 {
+		presenter.Println(cmd, "Validation errors (the push would be rejected):")
+
+		for _, validationError := range preview.GetValidationErrors() {
+			presenter.Printf(cmd, "  %s\n", validationError)
+		}
+	}
+}
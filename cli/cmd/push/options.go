@@ -14,6 +14,13 @@ var opts = &options{}
 type options struct {
 	FromStdin bool
 	Sign      bool
+	DryRun    bool
+	Overwrite bool
+	Lenient   bool
+
+	// Reproducibility options
+	Reproducible bool
+	ScrubPaths   []string
 
 	// Signing options
 	client.SignOpts
@@ -28,6 +35,27 @@ func init() {
 	flags.BoolVar(&opts.Sign, "sign", false,
 		"Sign the record with the specified signing options.",
 	)
+	flags.BoolVar(&opts.DryRun, "dry-run", false,
+		"Preview the CID, tags, annotations and labels of the record without pushing it.",
+	)
+	flags.BoolVar(&opts.Overwrite, "overwrite", false,
+		"Replace a stored record with the same name and version but different content. "+
+			"Required if the server enforces unique names and versions.",
+	)
+
+	flags.BoolVar(&opts.Lenient, "lenient", false,
+		"Accept fields unknown to the schema version of the record. "+
+			"By default, unknown fields and values of the wrong type are reported with their path.",
+	)
+
+	flags.BoolVar(&opts.Reproducible, "reproducible", false,
+		"Remove volatile build fields before pushing, so that rebuilds of the same record get the same CID. "+
+			"Removes annotations and extension or module data such as build.timestamp, build.host and generated_at.",
+	)
+	flags.StringSliceVar(&opts.ScrubPaths, "scrub-path", nil,
+		"Additional dot-separated path removed from the data of each extension or module with --reproducible, "+
+			"e.g. build.commit. Can be repeated.",
+	)
 
 	signcmd.AddSigningFlags(flags)
 
@@ -5,12 +5,14 @@
 package push
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
 	signcmd "github.com/agntcy/dir/cli/cmd/sign"
 	"github.com/agntcy/dir/cli/presenter"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
@@ -37,6 +39,18 @@ Usage examples:
 
 	dirctl push model.json --sign
 
+4. Preview the push without storing the record:
+
+	dirctl push model.json --dry-run
+
+5. Replace a stored record with the same name and version:
+
+	dirctl push model.json --overwrite
+
+6. Remove volatile build fields, so that CI can assert that the CID is stable:
+
+	dirctl push model.json --reproducible --dry-run --json
+
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var path string
@@ -80,20 +94,60 @@ func runCommand(cmd *cobra.Command, source io.Reader) error {
 		return fmt.Errorf("failed to read source data: %w", err)
 	}
 
-	// Load OASF data into a Record
-	record, err := corev1.UnmarshalRecord(sourceData)
+	// Load OASF data into a Record, rejecting fields unknown to its schema version unless lenient
+	var loadOpts []corev1.LoadOption
+	if opts.Lenient {
+		loadOpts = append(loadOpts, corev1.Lenient())
+	}
+
+	record, err := corev1.LoadOASFFromReader(bytes.NewReader(sourceData), loadOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to load OASF: %w", err)
 	}
 
-	var recordRef *corev1.RecordRef
+	if _, err := record.Decode(); err != nil {
+		return fmt.Errorf("failed to load OASF: %w", err)
+	}
+
+	var report *corev1.ScrubReport
+
+	if opts.Reproducible {
+		if record, report, err = normalizeRecord(record); err != nil {
+			return err
+		}
+	} else if len(opts.ScrubPaths) > 0 {
+		return errors.New("--scrub-path requires --reproducible")
+	}
+
+	if opts.DryRun {
+		if opts.Sign {
+			return errors.New("--sign cannot be used with --dry-run")
+		}
+
+		return runDryRun(cmd, c, record, report)
+	}
+
+	printScrubReport(cmd, report)
+
+	ctx := cmd.Context()
+	if opts.Overwrite {
+		ctx = storev1.ContextWithPushOverwrite(ctx)
+	}
+
+	// Push the record, reporting progress on interactive terminals
+	refs, err := c.PushBatch(ctx, []*corev1.Record{record}, presenter.ProgressOptions(cmd, "Pushing records")...)
+	presenter.FinishProgress(cmd)
 
-	// Use the client's Push method to send the record
-	recordRef, err = c.Push(cmd.Context(), record)
 	if err != nil {
 		return fmt.Errorf("failed to push data: %w", err)
 	}
 
+	if len(refs) != 1 || refs[0] == nil {
+		return errors.New("failed to push data: no data returned")
+	}
+
+	recordRef := refs[0]
+
 	if opts.Sign {
 		err = signcmd.Sign(cmd.Context(), c, recordRef.GetCid())
 		if err != nil {
@@ -102,5 +156,9 @@ func runCommand(cmd *cobra.Command, source io.Reader) error {
 	}
 
 	// Output in the appropriate format
+	if recordRef.GetAlreadyExisted() {
+		return presenter.PrintMessage(cmd, "record", "Record already exists with CID", recordRef.GetCid())
+	}
+
 	return presenter.PrintMessage(cmd, "record", "Pushed record with CID", recordRef.GetCid())
 }
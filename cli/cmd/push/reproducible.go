@@ -0,0 +1,36 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package push
+
+import (
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
+
+// normalizeRecord removes the volatile fields of the record selected by the default rules and --scrub-path.
+func normalizeRecord(record *corev1.Record) (*corev1.Record, *corev1.ScrubReport, error) {
+	rules := corev1.DefaultScrubRules()
+	rules.DataPaths = append(rules.DataPaths, opts.ScrubPaths...)
+
+	normalized, report, err := corev1.NormalizeForReproducibility(record, rules)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to normalize record: %w", err)
+	}
+
+	return normalized, report, nil
+}
+
+// printScrubReport lists the removed fields on standard error, keeping the output parseable.
+func printScrubReport(cmd *cobra.Command, report *corev1.ScrubReport) {
+	if report.IsEmpty() {
+		return
+	}
+
+	for _, entry := range report.Entries {
+		presenter.Errorf(cmd, "Scrubbed %s (%s)\n", entry.Path, entry.Action)
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import "github.com/agntcy/dir/cli/presenter"
+
+var opts = &options{}
+
+type options struct {
+	TrustDomain string
+}
+
+func init() {
+	flags := Command.Flags()
+	flags.StringVar(&opts.TrustDomain, "trust-domain", "",
+		"Trust domain to report, e.g. example.org. Reports all trust domains owning records if empty.",
+	)
+
+	// Add output format flags
+	presenter.AddOutputFlags(Command)
+}
@@ -0,0 +1,99 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package quota
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "quota",
+	Short: "Show storage usage and quotas of trust domains",
+	Long: `This command shows the number and total size of records owned by trust domains,
+together with the quotas configured on the server. Quotas of zero are unlimited.
+
+Usage examples:
+
+1. Show the usage of all trust domains
+
+	dirctl quota
+
+2. Show the usage of a single trust domain
+
+	dirctl quota --trust-domain example.org
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			return errors.New("no arguments are allowed")
+		}
+
+		return runCommand(cmd)
+	},
+}
+
+func runCommand(cmd *cobra.Command) error {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	usages, err := c.QuotaUsage(cmd.Context(), opts.TrustDomain)
+	if err != nil {
+		return err
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		results := make([]interface{}, 0, len(usages))
+		for _, usage := range usages {
+			results = append(results, usage)
+		}
+
+		return presenter.PrintMessage(cmd, "usage", "Quota usage", results)
+	}
+
+	if len(usages) == 0 {
+		presenter.Println(cmd, "No records owned by any trust domain")
+
+		return nil
+	}
+
+	for _, usage := range usages {
+		printUsage(cmd, usage)
+	}
+
+	return nil
+}
+
+func printUsage(cmd *cobra.Command, usage *storev1.QuotaUsage) {
+	trustDomain := usage.GetTrustDomain()
+	if trustDomain == "" {
+		trustDomain = "(unauthenticated)"
+	}
+
+	presenter.Printf(cmd, "Trust domain: %s\n", trustDomain)
+	presenter.Printf(cmd, "  Records: %d / %s\n", usage.GetRecordCount(), formatLimit(usage.GetMaxRecordCount()))
+	presenter.Printf(cmd, "  Bytes:   %d / %s\n", usage.GetTotalBytes(), formatLimit(usage.GetMaxTotalBytes()))
+
+	if usage.GetMaxAgeSeconds() > 0 {
+		maxAge := time.Duration(usage.GetMaxAgeSeconds()) * time.Second //nolint:gosec
+		presenter.Printf(cmd, "  Max age: %s (%d expired)\n", maxAge, usage.GetExpiredRecordCount())
+	}
+}
+
+func formatLimit(limit uint64) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+
+	return strconv.FormatUint(limit, 10)
+}
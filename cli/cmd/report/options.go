@@ -0,0 +1,33 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+var opts = &options{}
+
+type options struct {
+	Output            string
+	Keys              []string
+	AllowAttachedKeys bool
+	Attestations      []string
+	Mandatory         []string
+}
+
+func init() {
+	flags := Command.Flags()
+	flags.StringVarP(&opts.Output, "output", "o", outputTable, "Output format, either \"table\" or \"json\".")
+	flags.StringArrayVar(&opts.Keys, "key", nil,
+		"Path to a PEM encoded public key trusted to sign the record and its attestations. Can be repeated.",
+	)
+	flags.BoolVar(&opts.AllowAttachedKeys, "allow-attached-keys", false,
+		"Verify the signature and attestations with the public keys attached to the record if no --key is given. "+
+			"This proves that they are intact, but not who made them.",
+	)
+	flags.StringArrayVar(&opts.Attestations, "require-attestation", nil,
+		"Predicate type of an attestation the record must carry: \"slsa\", \"spdx\", \"cyclonedx\" or a predicate type URI. Can be repeated.",
+	)
+	flags.StringArrayVar(&opts.Mandatory, "mandatory", nil,
+		"Name of a check that must pass for the report to pass, e.g. \"lifecycle\" or \"attestation:slsa\". Can be repeated. "+
+			"Defaults to the cid, signature and required attestation checks.",
+	)
+}
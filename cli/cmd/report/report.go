@@ -0,0 +1,189 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// predicateAliases are the short names of common attestation predicate types.
+var predicateAliases = map[string]string{
+	"slsa":      client.PredicateTypeSLSAProvenance,
+	"spdx":      client.PredicateTypeSPDX,
+	"cyclonedx": client.PredicateTypeCycloneDX,
+}
+
+var Command = &cobra.Command{
+	Use:   "report <cid>",
+	Short: "Report the integrity of a record",
+	Long: `This command runs all integrity checks of a record and reports their results
+with the evidence found, together with an overall verdict:
+
+- cid: the record content matches its CID
+- signature: the record is signed by a trusted key, skipped if no --key is given
+  unless --allow-attached-keys accepts the keys attached to the record
+- attestation:<predicate type>: the record carries a verified attestation for each --require-attestation
+- registration: the record is registered on-chain, skipped as no chain client is configured
+- lifecycle: the record is neither deprecated nor withdrawn
+- scan: the record was stored without scan warnings
+- lint: the record has no lint findings of warning or error severity
+
+The verdict passes if all mandatory checks pass, by default the cid, signature
+and required attestation checks. The command fails if the verdict fails.
+
+Usage examples:
+
+1. Report the integrity of a record signed with a trusted key:
+
+	dirctl report <cid> --key cosign.pub
+
+2. Require SLSA provenance and an SPDX SBOM, and fail on deprecated records:
+
+	dirctl report <cid> --key cosign.pub --require-attestation slsa --require-attestation spdx \
+	  --mandatory cid --mandatory signature --mandatory attestation:slsa --mandatory attestation:spdx --mandatory lifecycle
+
+3. Output the report as JSON:
+
+	dirctl report <cid> --key cosign.pub --output json
+`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.CIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCommand(cmd, args[0])
+	},
+}
+
+func runCommand(cmd *cobra.Command, cid string) error {
+	if opts.Output != outputTable && opts.Output != outputJSON {
+		return fmt.Errorf("invalid output format %q, expected %q or %q", opts.Output, outputTable, outputJSON)
+	}
+
+	reportOpts, err := reportOptions()
+	if err != nil {
+		return err
+	}
+
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	report, err := c.IntegrityReport(cmd.Context(), &corev1.RecordRef{Cid: cid}, reportOpts)
+	if err != nil {
+		return fmt.Errorf("failed to report record integrity: %w", err)
+	}
+
+	if opts.Output == outputJSON {
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal report: %w", err)
+		}
+
+		presenter.Println(cmd, string(output))
+	} else if err := printTable(cmd, report); err != nil {
+		return err
+	}
+
+	if report.Verdict != client.CheckPass {
+		return fmt.Errorf("record %s failed mandatory checks: %s", report.CID, strings.Join(report.Failures, ", "))
+	}
+
+	return nil
+}
+
+// reportOptions builds the report options from the flags.
+func reportOptions() (client.ReportOptions, error) {
+	reportOpts := client.ReportOptions{AllowAttachedKeys: opts.AllowAttachedKeys}
+
+	for _, path := range opts.Keys {
+		key, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return client.ReportOptions{}, fmt.Errorf("failed to read key file: %w", err)
+		}
+
+		reportOpts.TrustBundle.Keys = append(reportOpts.TrustBundle.Keys, string(key))
+	}
+
+	for _, predicateType := range opts.Attestations {
+		reportOpts.RequiredPredicates = append(reportOpts.RequiredPredicates, resolvePredicateType(predicateType))
+	}
+
+	for _, name := range opts.Mandatory {
+		if predicateType, ok := strings.CutPrefix(name, "attestation:"); ok {
+			name = client.AttestationCheckName(resolvePredicateType(predicateType))
+		}
+
+		reportOpts.Mandatory = append(reportOpts.Mandatory, name)
+	}
+
+	return reportOpts, nil
+}
+
+// resolvePredicateType returns the predicate type of an alias such as "slsa", or the predicate type itself.
+func resolvePredicateType(predicateType string) string {
+	if resolved, ok := predicateAliases[strings.ToLower(predicateType)]; ok {
+		return resolved
+	}
+
+	return predicateType
+}
+
+func printTable(cmd *cobra.Command, report *client.IntegrityReport) error {
+	presenter.Printf(cmd, "CID: %s\n\n", report.CID)
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0) //nolint:mnd
+
+	fmt.Fprintln(w, "CHECK\tSTATUS\tMANDATORY\tDETAILS")
+
+	for _, check := range report.Checks {
+		mandatory := ""
+		if slices.Contains(report.Mandatory, check.Name) {
+			mandatory = "yes"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", check.Name, check.Status, mandatory, details(check))
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	presenter.Printf(cmd, "\nVerdict: %s\n", report.Verdict)
+
+	return nil
+}
+
+// details returns the message and the evidence of a check, sorted by key.
+func details(check client.Check) string {
+	var parts []string
+	if check.Message != "" {
+		parts = append(parts, check.Message)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(check.Evidence)) {
+		parts = append(parts, key+"="+check.Evidence[key])
+	}
+
+	return strings.Join(parts, "; ")
+}
@@ -6,19 +6,40 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/agntcy/dir/cli/cmd/acl"
+	"github.com/agntcy/dir/cli/cmd/admin"
+	"github.com/agntcy/dir/cli/cmd/attest"
+	"github.com/agntcy/dir/cli/cmd/bundle"
+	"github.com/agntcy/dir/cli/cmd/cid"
 	"github.com/agntcy/dir/cli/cmd/delete"
+	"github.com/agntcy/dir/cli/cmd/deprecate"
+	"github.com/agntcy/dir/cli/cmd/deps"
+	"github.com/agntcy/dir/cli/cmd/diff"
+	"github.com/agntcy/dir/cli/cmd/export"
 	hubCmd "github.com/agntcy/dir/cli/cmd/hub"
 	"github.com/agntcy/dir/cli/cmd/info"
+	"github.com/agntcy/dir/cli/cmd/initialize"
+	"github.com/agntcy/dir/cli/cmd/lint"
+	"github.com/agntcy/dir/cli/cmd/metadata"
 	"github.com/agntcy/dir/cli/cmd/network"
+	"github.com/agntcy/dir/cli/cmd/probe"
 	"github.com/agntcy/dir/cli/cmd/pull"
 	"github.com/agntcy/dir/cli/cmd/push"
+	"github.com/agntcy/dir/cli/cmd/quota"
+	"github.com/agntcy/dir/cli/cmd/report"
 	"github.com/agntcy/dir/cli/cmd/routing"
 	"github.com/agntcy/dir/cli/cmd/search"
 	"github.com/agntcy/dir/cli/cmd/sign"
+	"github.com/agntcy/dir/cli/cmd/stats"
 	"github.com/agntcy/dir/cli/cmd/sync"
+	"github.com/agntcy/dir/cli/cmd/trash"
 	"github.com/agntcy/dir/cli/cmd/verify"
 	"github.com/agntcy/dir/cli/cmd/version"
+	"github.com/agntcy/dir/cli/cmd/watch"
 	"github.com/agntcy/dir/cli/presenter"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
 	"github.com/agntcy/dir/client"
@@ -34,7 +55,18 @@ var RootCmd = &cobra.Command{
 	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
 		// Set client via context for all requests
 		// TODO: make client config configurable via CLI args
-		c, err := client.New(client.WithConfig(clientConfig))
+		opts := []client.Option{client.WithConfig(clientConfig)}
+
+		if encryptionKeyFile != "" {
+			keys, err := client.NewLocalKeyProvider(encryptionKeyFile)
+			if err != nil {
+				return fmt.Errorf("failed to load encryption key: %w", err)
+			}
+
+			opts = append(opts, client.WithEncryption(keys))
+		}
+
+		c, err := newClient(opts...)
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
@@ -52,13 +84,33 @@ var RootCmd = &cobra.Command{
 	},
 }
 
+// newClient creates a client for the server, or for the local record store if --local is set.
+func newClient(opts ...client.Option) (*client.Client, error) {
+	if localDir == "" {
+		return client.New(opts...) //nolint:wrapcheck
+	}
+
+	dir := localDir
+	if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve home directory: %w", err)
+		}
+
+		dir = filepath.Join(home, rest)
+	}
+
+	return client.NewLocal(dir, opts...) //nolint:wrapcheck
+}
+
 func init() {
 	network.Command.Hidden = true
 
 	RootCmd.AddCommand(
 		// local commands
 		version.Command,
-		// initialize.Command, // REMOVED: Initialize functionality
+		initialize.Command,
+		cid.Command,
 		sign.Command,
 		verify.Command,
 		// storage commands
@@ -66,6 +118,24 @@ func init() {
 		pull.Command,
 		push.Command,
 		delete.Command,
+		trash.Command,
+		trash.RestoreCommand,
+		trash.PurgeCommand,
+		deprecate.Command,
+		metadata.Command,
+		acl.Command,
+		diff.Command,
+		deps.Command,
+		lint.Command,
+		probe.Command,
+		quota.Command,
+		stats.Command,
+		bundle.Command,
+		attest.Command,
+		report.Command,
+		watch.Command,
+		export.Command,
+		export.ImportCommand,
 		// routing commands (all under routing subcommand)
 		routing.Command, // Contains: publish, unpublish, list, search
 		network.Command,
@@ -74,6 +144,8 @@ func init() {
 		search.Command, // General search (searchv1)
 		// sync commands
 		sync.Command,
+		// admin commands
+		admin.Command,
 	)
 }
 
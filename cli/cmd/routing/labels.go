@@ -0,0 +1,195 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/api/labels"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var labelsCmd = &cobra.Command{
+	Use:   "labels <file.json|cid>",
+	Short: "Show the routing labels and tags of a record",
+	Long: `Show the routing labels a record is announced with when it is published,
+and the discovery tags it is stored under.
+
+The record is loaded from a file, or pulled from the store if the argument is a CID.
+Labels are derived with the same rules the server uses at publish time:
+
+- skills[i] -> /skills/<name>
+- domains[i] -> /domains/<name>
+- modules[i] and v0.3.1 extensions[i] -> /modules/<name>, unless the name matches
+  an extension prefix such as "schema.oasf.agntcy.org/skills/", which maps it to
+  the corresponding label type with the prefix removed
+- locators[i] -> /locators/<type>
+
+Duplicate labels are announced once. Custom extension prefixes configured on the
+server (labels.extension_prefixes) must be passed with --extension-prefix.
+
+Usage examples:
+
+1. Show the labels of a record file:
+   dirctl routing labels record.json
+
+2. Show where each label of a stored record comes from:
+   dirctl routing labels <cid> --explain
+
+3. Include a custom extension prefix configured on the server:
+   dirctl routing labels record.json --extension-prefix example.com/domains/=domains
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runLabelsCommand(cmd, args[0])
+	},
+}
+
+var labelsOpts struct {
+	Explain           bool
+	ExtensionPrefixes map[string]string
+}
+
+func init() {
+	labelsCmd.Flags().BoolVar(&labelsOpts.Explain, "explain", false, "Show the record field each label is derived from")
+	labelsCmd.Flags().StringToStringVar(&labelsOpts.ExtensionPrefixes, "extension-prefix", nil,
+		"Additional extension name prefix to label type mapping (e.g., --extension-prefix example.com/domains/=domains)")
+
+	presenter.AddOutputFlags(labelsCmd)
+}
+
+// recordLabel is the JSON representation of a label.
+type recordLabel struct {
+	Label  string `json:"label"`
+	Source string `json:"source"`
+	Value  string `json:"value"`
+}
+
+func runLabelsCommand(cmd *cobra.Command, source string) error {
+	record, err := loadLabelsRecord(cmd, source)
+	if err != nil {
+		return err
+	}
+
+	extractor, err := labels.NewExtractor(labelsOpts.ExtensionPrefixes)
+	if err != nil {
+		return fmt.Errorf("invalid extension prefix: %w", err)
+	}
+
+	recordLabels, err := extractor.ExtractRecord(record)
+	if err != nil {
+		return fmt.Errorf("failed to extract labels: %w", err)
+	}
+
+	tags := record.DiscoveryTags()
+
+	switch presenter.GetOutputOptions(cmd).Format {
+	case presenter.FormatJSON:
+		result := struct {
+			Cid    string        `json:"cid"`
+			Labels []recordLabel `json:"labels"`
+			Tags   []string      `json:"tags"`
+		}{
+			Cid:    record.GetCid(),
+			Labels: make([]recordLabel, 0, len(recordLabels)),
+			Tags:   tags,
+		}
+
+		for _, label := range recordLabels {
+			result.Labels = append(result.Labels, recordLabel{
+				Label:  label.String(),
+				Source: label.Source.Path,
+				Value:  label.Source.Value,
+			})
+		}
+
+		output, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+
+		presenter.Print(cmd, string(output)+"\n")
+
+	case presenter.FormatRaw:
+		for _, label := range recordLabels {
+			presenter.Println(cmd, label.String())
+		}
+
+	case presenter.FormatHuman:
+		displayLabels(cmd, recordLabels, tags)
+	}
+
+	return nil
+}
+
+// loadLabelsRecord loads the record from a file, or pulls it from the store by CID.
+func loadLabelsRecord(cmd *cobra.Command, source string) (*corev1.Record, error) {
+	data, err := os.ReadFile(source)
+	if err == nil {
+		record, err := corev1.UnmarshalRecord(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OASF: %w", err)
+		}
+
+		return record, nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) || !corev1.IsValidCID(source) {
+		return nil, fmt.Errorf("could not open file %s: %w", source, err)
+	}
+
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return nil, errors.New("failed to get client from context")
+	}
+
+	record, err := c.Pull(cmd.Context(), &corev1.RecordRef{Cid: source})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull record: %w", err)
+	}
+
+	return record, nil
+}
+
+// displayLabels displays the labels and tags in human-readable format.
+func displayLabels(cmd *cobra.Command, recordLabels []labels.Label, tags []string) {
+	presenter.Printf(cmd, "Routing labels:\n")
+
+	if len(recordLabels) == 0 {
+		presenter.Printf(cmd, "  (none)\n")
+	}
+
+	width := 0
+	for _, label := range recordLabels {
+		width = max(width, len(label.String()))
+	}
+
+	for _, label := range recordLabels {
+		if !labelsOpts.Explain {
+			presenter.Printf(cmd, "  %s\n", label.String())
+
+			continue
+		}
+
+		explanation := label.Source.Path
+		if label.Source.Value != label.Value {
+			explanation += fmt.Sprintf(" %q", label.Source.Value)
+		}
+
+		presenter.Printf(cmd, "  %s%s  <- %s\n", label.String(), strings.Repeat(" ", width-len(label.String())), explanation)
+	}
+
+	presenter.Printf(cmd, "\nDiscovery tags:\n")
+
+	for _, tag := range tags {
+		presenter.Printf(cmd, "  %s\n", tag)
+	}
+}
@@ -27,6 +27,7 @@ Key Features:
 - Local-only: Only shows records published on this peer
 - Fast: Uses local storage index, no network access
 - Filtering: Supports skill and locator queries with AND logic
+- Label queries: Supports wildcards and AND/OR/NOT with parentheses
 - Efficient: Extracts labels from storage keys, no content parsing
 
 Usage examples:
@@ -43,6 +44,20 @@ Usage examples:
 4. List specific record by CID:
    dirctl routing list --cid <cid>
 
+5. List records including withdrawn ones:
+   dirctl routing list --include-withdrawn
+
+6. List records matching a label query:
+   dirctl routing list --query '/skills/nlp/* AND NOT /locators/docker-image'
+
+7. List records of another namespace, or of all namespaces (requires permission):
+   dirctl routing list --namespace tenant-b.example.org
+   dirctl routing list --namespace '*'
+
+By default, the records of the caller's namespace and the public records of
+all namespaces are listed. Records published before namespaces were introduced
+are kept in the "legacy" namespace.
+
 Note: For network-wide discovery, use 'dirctl routing search' instead.
 `,
 	//nolint:gocritic // Lambda required due to signature mismatch - runListCommand doesn't use args
@@ -53,12 +68,17 @@ Note: For network-wide discovery, use 'dirctl routing search' instead.
 
 // List command options.
 var listOpts struct {
-	Cid      string
-	Skills   []string
-	Locators []string
-	Domains  []string
-	Modules  []string
-	Limit    uint32
+	Cid       string
+	Skills    []string
+	Locators  []string
+	Domains   []string
+	Modules   []string
+	Query     string
+	Namespace string
+	Limit     uint32
+
+	IncludeWithdrawn  bool
+	IncludeSuperseded bool
 }
 
 func init() {
@@ -68,7 +88,11 @@ func init() {
 	listCmd.Flags().StringArrayVar(&listOpts.Locators, "locator", nil, "Filter by locator type (can be repeated)")
 	listCmd.Flags().StringArrayVar(&listOpts.Domains, "domain", nil, "Filter by domain (can be repeated)")
 	listCmd.Flags().StringArrayVar(&listOpts.Modules, "module", nil, "Filter by module (can be repeated)")
+	listCmd.Flags().StringVar(&listOpts.Query, "query", "", "Filter by label query (cannot be combined with other filters)")
+	listCmd.Flags().StringVar(&listOpts.Namespace, "namespace", "", "List the records of a namespace, or of all namespaces with '*' (default: own namespace and public records)")
 	listCmd.Flags().Uint32Var(&listOpts.Limit, "limit", 0, "Maximum number of results (0 = no limit)")
+	listCmd.Flags().BoolVar(&listOpts.IncludeWithdrawn, "include-withdrawn", false, "Include records withdrawn by their publisher")
+	listCmd.Flags().BoolVar(&listOpts.IncludeSuperseded, "include-superseded", false, "Include records superseded by an equivalent record, e.g. migrated to a newer schema version")
 
 	// Add examples in flag help
 	listCmd.Flags().Lookup("skill").Usage = "Filter by skill (e.g., --skill 'AI' --skill 'web-development')"
@@ -76,6 +100,12 @@ func init() {
 	listCmd.Flags().Lookup("domain").Usage = "Filter by domain (e.g., --domain 'research' --domain 'analytics')"
 	listCmd.Flags().Lookup("module").Usage = "Filter by module (e.g., --module 'runtime/language' --module 'runtime/framework')"
 	listCmd.Flags().Lookup("cid").Usage = "List specific record by CID"
+	listCmd.Flags().Lookup("query").Usage = "Filter by label query (e.g., --query '/skills/nlp/* AND NOT /locators/docker-image')"
+
+	listCmd.MarkFlagsMutuallyExclusive("query", "skill")
+	listCmd.MarkFlagsMutuallyExclusive("query", "locator")
+	listCmd.MarkFlagsMutuallyExclusive("query", "domain")
+	listCmd.MarkFlagsMutuallyExclusive("query", "module")
 
 	// Add output format flags
 	presenter.AddOutputFlags(listCmd)
@@ -130,7 +160,11 @@ func runListCommand(cmd *cobra.Command) error {
 
 	// Build list request
 	req := &routingv1.ListRequest{
-		Queries: queries,
+		Queries:           queries,
+		Query:             listOpts.Query,
+		Namespace:         listOpts.Namespace,
+		IncludeWithdrawn:  listOpts.IncludeWithdrawn,
+		IncludeSuperseded: listOpts.IncludeSuperseded,
 	}
 
 	// Add optional limit
@@ -157,7 +191,10 @@ func runListCommand(cmd *cobra.Command) error {
 func listByCID(cmd *cobra.Command, c *client.Client, cid string) error {
 	// For CID-specific queries, we can use an empty query list
 	req := &routingv1.ListRequest{
-		Queries: []*routingv1.RecordQuery{}, // Empty = list all, then we filter by CID match
+		Queries:           []*routingv1.RecordQuery{}, // Empty = list all, then we filter by CID match
+		Namespace:         listOpts.Namespace,
+		IncludeWithdrawn:  listOpts.IncludeWithdrawn,
+		IncludeSuperseded: true, // The record is requested explicitly, even if a newer equivalent exists
 	}
 
 	resultCh, err := c.List(cmd.Context(), req)
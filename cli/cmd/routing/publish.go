@@ -8,11 +8,14 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
 	"github.com/spf13/cobra"
 )
 
@@ -36,15 +39,40 @@ Usage examples:
 
 1. Publish a record to the network:
    dirctl routing publish <cid>
+   dirctl routing publish <name>@<version>
+
+2. Publish a record with an announcement that expires after 6 hours:
+   dirctl routing publish <cid> --ttl 6h
+
+3. Publish a record listed to callers of all namespaces:
+   dirctl routing publish <cid> --public
+
+Announcements with a TTL are re-announced by the server while the record
+remains in storage, and expire once the record is deleted.
+
+Records are published in the namespace of the caller, its trust domain, and
+are listed only to callers of that namespace unless published with --public.
 
 Note: The record must already be pushed to storage before publishing.
 `,
-	Args: cobra.ExactArgs(1),
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.CIDs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runPublishCommand(cmd, args[0])
 	},
 }
 
+// Publish command options.
+var publishOpts struct {
+	TTL    time.Duration
+	Public bool
+}
+
+func init() {
+	publishCmd.Flags().DurationVar(&publishOpts.TTL, "ttl", 0, "Expire the announcement after this duration unless re-announced (0 = never expire)")
+	publishCmd.Flags().BoolVar(&publishOpts.Public, "public", false, "List the record to callers of all namespaces, not only to callers of the publisher's namespace")
+}
+
 func runPublishCommand(cmd *cobra.Command, cid string) error {
 	// Get the client from the context
 	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
@@ -52,17 +80,26 @@ func runPublishCommand(cmd *cobra.Command, cid string) error {
 		return errors.New("failed to get client from context")
 	}
 
-	// Create RecordRef from cid
-	recordRef := &corev1.RecordRef{
-		Cid: cid,
+	// Resolve name@version locators to the record they refer to
+	recordRef, err := c.ResolveLocator(cmd.Context(), cid)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", cid, err)
 	}
 
 	// Lookup metadata to verify record exists
-	_, err := c.Lookup(cmd.Context(), recordRef)
-	if err != nil {
+	if _, err := c.Lookup(cmd.Context(), recordRef); err != nil {
 		return fmt.Errorf("failed to lookup: %w", err)
 	}
 
+	var opts []client.PublishOption
+	if publishOpts.TTL > 0 {
+		opts = append(opts, client.WithTTL(publishOpts.TTL))
+	}
+
+	if publishOpts.Public {
+		opts = append(opts, client.WithVisibility(routingv1.Visibility_VISIBILITY_PUBLIC))
+	}
+
 	// Start publishing using the same RecordRef
 	if err := c.Publish(cmd.Context(), &routingv1.PublishRequest{
 		Request: &routingv1.PublishRequest_RecordRefs{
@@ -70,7 +107,7 @@ func runPublishCommand(cmd *cobra.Command, cid string) error {
 				Refs: []*corev1.RecordRef{recordRef},
 			},
 		},
-	}); err != nil {
+	}, opts...); err != nil {
 		if strings.Contains(err.Error(), "failed to announce object") {
 			return errors.New("failed to announce object, it will be retried in the background on the API server")
 		}
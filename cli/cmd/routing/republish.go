@@ -0,0 +1,213 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package routing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var republishCmd = &cobra.Command{
+	Use:   "republish --all",
+	Short: "Republish all local records to the network",
+	Long: `Republish all records in local storage to the network.
+
+This command restores network announcements for records that were published
+before, for example after the routing state of the node was lost.
+Labels are derived from the record content in the same way as for publish.
+
+Republishing is resumable. Records that were republished successfully are
+tracked in a state file, and a subsequent run only republishes the records
+that failed or were not processed yet. The state file is removed once all
+records were republished successfully.
+
+Usage examples:
+
+1. Republish all local records:
+   dirctl routing republish --all
+
+2. Show which records would be republished:
+   dirctl routing republish --all --dry-run
+
+Note: Use 'dirctl routing publish <cid>' to publish a single record.
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runRepublishCommand(cmd)
+	},
+}
+
+// Republish command options.
+var republishOpts struct {
+	All       bool
+	DryRun    bool
+	StateFile string
+}
+
+func init() {
+	republishCmd.Flags().BoolVar(&republishOpts.All, "all", false, "Republish all records in local storage")
+	republishCmd.Flags().BoolVar(&republishOpts.DryRun, "dry-run", false, "Print the records that would be republished without publishing them")
+	republishCmd.Flags().StringVar(&republishOpts.StateFile, "state-file", defaultRepublishStateFile(), "File used to track republished records between runs")
+}
+
+// republishState tracks records republished by previous runs.
+type republishState struct {
+	Published []string `json:"published"`
+}
+
+func defaultRepublishStateFile() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+
+	return filepath.Join(cacheDir, "dirctl", "republish-state.json")
+}
+
+func loadRepublishState(path string) (*republishState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &republishState{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var state republishState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+
+	return &state, nil
+}
+
+func saveRepublishState(path string, state *republishState) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:mnd
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil { //nolint:mnd
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	return nil
+}
+
+func runRepublishCommand(cmd *cobra.Command) error {
+	if !republishOpts.All {
+		return errors.New("--all is required, use 'dirctl routing publish <cid>' to publish a single record")
+	}
+
+	// Get the client from the context
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	state, err := loadRepublishState(republishOpts.StateFile)
+	if err != nil {
+		return err
+	}
+
+	done := make(map[string]bool, len(state.Published))
+	for _, cid := range state.Published {
+		done[cid] = true
+	}
+
+	if len(done) > 0 {
+		presenter.Printf(cmd, "Resuming republish, skipping %d records republished by a previous run\n", len(done))
+	}
+
+	var pending []string
+
+	filter := func(meta *corev1.RecordMeta) bool {
+		if done[meta.GetCid()] {
+			return false
+		}
+
+		// Collect the records without publishing them
+		if republishOpts.DryRun {
+			pending = append(pending, meta.GetCid())
+
+			return false
+		}
+
+		return true
+	}
+
+	results, err := c.RepublishAll(cmd.Context(), filter)
+	if err != nil {
+		return fmt.Errorf("failed to republish: %w", err)
+	}
+
+	var published, failed int
+
+	for result := range results {
+		if result.Error != nil {
+			failed++
+
+			if result.Ref == nil {
+				presenter.Errorf(cmd, "Error: %v\n", result.Error)
+
+				continue
+			}
+
+			presenter.Errorf(cmd, "Error: failed to republish %s: %v\n", result.Ref.GetCid(), result.Error)
+
+			continue
+		}
+
+		published++
+
+		state.Published = append(state.Published, result.Ref.GetCid())
+
+		presenter.Printf(cmd, "Republished %s\n", result.Ref.GetCid())
+	}
+
+	if err := cmd.Context().Err(); err != nil {
+		failed++
+	}
+
+	if republishOpts.DryRun {
+		for _, cid := range pending {
+			presenter.Printf(cmd, "Would republish %s\n", cid)
+		}
+
+		presenter.Printf(cmd, "%d records would be republished\n", len(pending))
+
+		return nil
+	}
+
+	if failed > 0 {
+		if err := saveRepublishState(republishOpts.StateFile, state); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("failed to republish %d records (%d republished), run the command again to retry", failed, published)
+	}
+
+	// Everything was republished, so the next run starts from scratch
+	if err := os.Remove(republishOpts.StateFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove state file: %w", err)
+	}
+
+	presenter.Printf(cmd, "Successfully submitted publication requests for %d records\n", published)
+
+	return nil
+}
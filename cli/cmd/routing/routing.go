@@ -17,9 +17,11 @@ This command group provides access to all routing-specific operations:
 
 - publish: Announce records to the network for discovery
 - unpublish: Remove records from network discovery
+- republish: Republish all local records to the network
 - list: Query local records with filtering
 - search: Discover remote records from other peers
 - info: Show routing statistics and summary information
+- labels: Show the labels a record is announced with
 
 Examples:
 
@@ -35,6 +37,9 @@ Examples:
 4. Unpublish a record from the network:
    dirctl routing unpublish <cid>
 
+5. Show the labels a record is announced with:
+   dirctl routing labels record.json --explain
+
 This follows clear service separation - all routing API operations are grouped together.
 `,
 }
@@ -43,9 +48,11 @@ func init() {
 	// Add all routing subcommands
 	Command.AddCommand(publishCmd)
 	Command.AddCommand(unpublishCmd)
+	Command.AddCommand(republishCmd)
 	Command.AddCommand(listCmd)
 	Command.AddCommand(searchCmd)
 	Command.AddCommand(infoCmd)
+	Command.AddCommand(labelsCmd)
 
 	// Add output format flags to routing subcommands
 	presenter.AddOutputFlags(publishCmd)
@@ -39,6 +39,9 @@ Usage examples:
 3. Search with result limiting:
    dirctl routing search --skill "web-development" --limit 5
 
+4. Search with a label query (wildcards, AND/OR/NOT):
+   dirctl routing search --query '/skills/nlp/* AND NOT /locators/docker-image'
+
 `,
 	//nolint:gocritic // Lambda required due to signature mismatch - runSearchCommand doesn't use args
 	RunE: func(cmd *cobra.Command, _ []string) error {
@@ -52,6 +55,7 @@ var searchOpts struct {
 	Locators []string
 	Domains  []string
 	Modules  []string
+	Query    string
 	Limit    uint32
 	MinScore uint32
 	JSON     bool
@@ -69,6 +73,7 @@ func init() {
 	searchCmd.Flags().StringArrayVar(&searchOpts.Locators, "locator", nil, "Search for records with specific locator type (can be repeated)")
 	searchCmd.Flags().StringArrayVar(&searchOpts.Domains, "domain", nil, "Search for records with specific domain (can be repeated)")
 	searchCmd.Flags().StringArrayVar(&searchOpts.Modules, "module", nil, "Search for records with specific module (can be repeated)")
+	searchCmd.Flags().StringVar(&searchOpts.Query, "query", "", "Search for records matching a label query (cannot be combined with other filters)")
 	searchCmd.Flags().Uint32Var(&searchOpts.Limit, "limit", defaultSearchLimit, "Maximum number of results to return")
 	searchCmd.Flags().Uint32Var(&searchOpts.MinScore, "min-score", defaultMinScore, "Minimum match score (number of queries that must match)")
 	searchCmd.Flags().BoolVar(&searchOpts.JSON, "json", false, "Output results in JSON format")
@@ -78,6 +83,12 @@ func init() {
 	searchCmd.Flags().Lookup("locator").Usage = "Search for records with specific locator type (e.g., --locator 'docker-image')"
 	searchCmd.Flags().Lookup("domain").Usage = "Search for records with specific domain (e.g., --domain 'research' --domain 'analytics')"
 	searchCmd.Flags().Lookup("module").Usage = "Search for records with specific module (e.g., --module 'runtime/language' --module 'runtime/framework')"
+	searchCmd.Flags().Lookup("query").Usage = "Search for records matching a label query (e.g., --query '/skills/nlp/* OR /skills/vision/*')"
+
+	searchCmd.MarkFlagsMutuallyExclusive("query", "skill")
+	searchCmd.MarkFlagsMutuallyExclusive("query", "locator")
+	searchCmd.MarkFlagsMutuallyExclusive("query", "domain")
+	searchCmd.MarkFlagsMutuallyExclusive("query", "module")
 }
 
 func runSearchCommand(cmd *cobra.Command) error {
@@ -123,8 +134,8 @@ func runSearchCommand(cmd *cobra.Command) error {
 	}
 
 	// Validate that we have at least some criteria
-	if len(queries) == 0 {
-		presenter.Printf(cmd, "No search criteria specified. Use --skill, --locator, --domain, or --module flags.\n")
+	if len(queries) == 0 && searchOpts.Query == "" {
+		presenter.Printf(cmd, "No search criteria specified. Use --skill, --locator, --domain, --module, or --query flags.\n")
 		presenter.Printf(cmd, "Examples:\n")
 		presenter.Printf(cmd, "  dirctl routing search --skill 'AI' --locator 'docker-image'\n")
 		presenter.Printf(cmd, "  dirctl routing search --domain 'research' --module 'runtime/language'\n")
@@ -135,6 +146,7 @@ func runSearchCommand(cmd *cobra.Command) error {
 	// Build search request
 	req := &routingv1.SearchRequest{
 		Queries: queries,
+		Query:   searchOpts.Query,
 	}
 
 	// Add optional parameters
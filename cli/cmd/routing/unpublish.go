@@ -11,6 +11,7 @@ import (
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/cli/presenter"
+	"github.com/agntcy/dir/cli/util/completion"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
 	"github.com/spf13/cobra"
 )
@@ -33,10 +34,12 @@ Usage examples:
 
 1. Unpublish a record from the network:
    dirctl routing unpublish <cid>
+   dirctl routing unpublish <name>@<version>
 
 Note: This only removes network announcements. Use 'dirctl delete' to remove the record entirely.
 `,
-	Args: cobra.ExactArgs(1),
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.CIDs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runUnpublishCommand(cmd, args[0])
 	},
@@ -49,14 +52,14 @@ func runUnpublishCommand(cmd *cobra.Command, cid string) error {
 		return errors.New("failed to get client from context")
 	}
 
-	// Create RecordRef from cid
-	recordRef := &corev1.RecordRef{
-		Cid: cid,
+	// Resolve name@version locators to the record they refer to
+	recordRef, err := c.ResolveLocator(cmd.Context(), cid)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", cid, err)
 	}
 
 	// Lookup metadata to verify record exists
-	_, err := c.Lookup(cmd.Context(), recordRef)
-	if err != nil {
+	if _, err := c.Lookup(cmd.Context(), recordRef); err != nil {
 		return fmt.Errorf("failed to lookup: %w", err)
 	}
 
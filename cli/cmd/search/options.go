@@ -11,13 +11,17 @@ type options struct {
 	Limit  uint32
 	Offset uint32
 
+	IncludeSuperseded bool
+
 	// Direct field flags (consistent with routing search)
-	Names      []string
-	Versions   []string
-	SkillIDs   []string
-	SkillNames []string
-	Locators   []string
-	Modules    []string
+	Names           []string
+	Versions        []string
+	SkillIDs        []string
+	SkillNames      []string
+	Locators        []string
+	LocatorVariants []string
+	Modules         []string
+	Annotations     []string
 }
 
 func init() {
@@ -25,6 +29,7 @@ func init() {
 
 	flags.Uint32Var(&opts.Limit, "limit", 100, "Maximum number of results to return (default: 100)") //nolint:mnd
 	flags.Uint32Var(&opts.Offset, "offset", 0, "Pagination offset (default: 0)")
+	flags.BoolVar(&opts.IncludeSuperseded, "include-superseded", false, "Include records superseded by an equivalent record, e.g. migrated to a newer schema version")
 
 	// Direct field flags
 	flags.StringArrayVar(&opts.Names, "name", nil, "Search for records with specific name (can be repeated)")
@@ -32,15 +37,19 @@ func init() {
 	flags.StringArrayVar(&opts.SkillIDs, "skill-id", nil, "Search for records with specific skill ID (can be repeated)")
 	flags.StringArrayVar(&opts.SkillNames, "skill", nil, "Search for records with specific skill name (can be repeated)")
 	flags.StringArrayVar(&opts.Locators, "locator", nil, "Search for records with specific locator type (can be repeated)")
+	flags.StringArrayVar(&opts.LocatorVariants, "locator-variant", nil, "Search for records with specific locator variant (can be repeated)")
 	flags.StringArrayVar(&opts.Modules, "module", nil, "Search for records with specific module (can be repeated)")
+	flags.StringArrayVar(&opts.Annotations, "annotation", nil, "Search for records with specific annotation (can be repeated)")
 
 	// Add examples in flag help
 	flags.Lookup("name").Usage = "Search for records with specific name (e.g., --name 'my-agent' --name 'web-*')"
-	flags.Lookup("version").Usage = "Search for records with specific version (e.g., --version 'v1.0.0' --version 'v1.*')"
+	flags.Lookup("version").Usage = "Search for records with specific version (e.g., --version 'v1.0.0' --version 'v1.*' --version '>=v2.0.0')"
 	flags.Lookup("skill-id").Usage = "Search for records with specific skill ID (e.g., --skill-id '10201')"
 	flags.Lookup("skill").Usage = "Search for records with specific skill name (e.g., --skill 'natural_language_processing' --skill 'audio')"
 	flags.Lookup("locator").Usage = "Search for records with specific locator type (e.g., --locator 'docker-image')"
+	flags.Lookup("locator-variant").Usage = "Search for records with a locator for a specific architecture or runtime (e.g., --locator-variant 'helm_chart.arm64')"
 	flags.Lookup("module").Usage = "Search for records with specific module (e.g., --module 'runtime/language')"
+	flags.Lookup("annotation").Usage = "Search for records with specific annotation (e.g., --annotation 'team=platform' --annotation 'env')"
 
 	// Add output format flags
 	presenter.AddOutputFlags(Command)
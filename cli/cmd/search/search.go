@@ -7,6 +7,7 @@ package search
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	searchv1 "github.com/agntcy/dir/api/search/v1"
 	"github.com/agntcy/dir/cli/presenter"
@@ -15,7 +16,7 @@ import (
 )
 
 var Command = &cobra.Command{
-	Use:   "search",
+	Use:   "search [query]",
 	Short: "Search for records",
 	Long: `Search for records in the directory using various filters and options.
 
@@ -90,13 +91,56 @@ Usage examples:
 	# Combine different wildcard types
 	dirctl search --name "web-[0-9]?" --version "v?.*.?"
 
+6. Version comparisons (>, >=, <, <=):
+
+	# Find agents with version 2.0.0 or later
+	dirctl search --version ">=v2.0.0"
+
+	# Find v1 agents released after v1.2
+	dirctl search --version ">v1.2" --version "<v2"
+
+7. Annotation search (key=value or key, values support wildcards):
+
+	# Find agents owned by a team
+	dirctl search --annotation "team=platform"
+
+	# Find agents having an annotation with any value
+	dirctl search --annotation "deprecated"
+
+8. Locator variant search (locator type qualified with the target architecture or runtime):
+
+	# Find agents with a helm chart for arm64
+	dirctl search --locator-variant "helm_chart.arm64"
+
+	# Find agents with any locator for arm64
+	dirctl search --locator-variant "*.arm64"
+
+9. Free-text search (ranked by relevance, name matches rank above description matches):
+
+	# Find summarization agents for legal documents
+	dirctl search "summarization agent for legal documents"
+
+	# Rank only v2 agents
+	dirctl search "summarization agent" --version "v2.*"
+
 `,
-	RunE: func(cmd *cobra.Command, _ []string) error {
-		return runCommand(cmd)
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCommand(cmd, strings.Join(args, " "))
 	},
 }
 
-func runCommand(cmd *cobra.Command) error {
+// scoredRecord is a record found by a free-text search.
+type scoredRecord struct {
+	CID   string  `json:"cid"`
+	Score float64 `json:"score"`
+}
+
+func (r scoredRecord) String() string {
+	return fmt.Sprintf("%s (score %g)", r.CID, r.Score)
+}
+
+func runCommand(cmd *cobra.Command, query string) error {
 	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
 	if !ok {
 		return errors.New("failed to get client from context")
@@ -105,11 +149,18 @@ func runCommand(cmd *cobra.Command) error {
 	// Build queries from direct field flags
 	queries := buildQueriesFromFlags()
 
-	ch, err := c.Search(cmd.Context(), &searchv1.SearchRequest{
-		Limit:   &opts.Limit,
-		Offset:  &opts.Offset,
-		Queries: queries,
-	})
+	req := &searchv1.SearchRequest{
+		Limit:             &opts.Limit,
+		Offset:            &opts.Offset,
+		Queries:           queries,
+		IncludeSuperseded: opts.IncludeSuperseded,
+	}
+
+	if query != "" {
+		req.Query = &query
+	}
+
+	ch, err := c.SearchResults(cmd.Context(), req)
 	if err != nil {
 		return fmt.Errorf("failed to search: %w", err)
 	}
@@ -117,12 +168,19 @@ func runCommand(cmd *cobra.Command) error {
 	// Collect results and convert to interface{} slice
 	results := make([]interface{}, 0, opts.Limit)
 
-	for recordCid := range ch {
-		if recordCid == "" {
+	for result := range ch {
+		if result.CID == "" {
 			continue
 		}
 
-		results = append(results, recordCid)
+		// Results of free-text searches are shown with their relevance
+		if query != "" {
+			results = append(results, scoredRecord{CID: result.CID, Score: result.Score})
+
+			continue
+		}
+
+		results = append(results, result.CID)
 	}
 
 	return presenter.PrintMessage(cmd, "record CIDs", "Record CIDs found", results)
@@ -132,7 +190,7 @@ func runCommand(cmd *cobra.Command) error {
 func buildQueriesFromFlags() []*searchv1.RecordQuery {
 	queries := make([]*searchv1.RecordQuery, 0,
 		len(opts.Names)+len(opts.Versions)+len(opts.SkillIDs)+
-			len(opts.SkillNames)+len(opts.Locators)+len(opts.Modules))
+			len(opts.SkillNames)+len(opts.Locators)+len(opts.Modules)+len(opts.Annotations))
 
 	// Add name queries
 	for _, name := range opts.Names {
@@ -144,9 +202,12 @@ func buildQueriesFromFlags() []*searchv1.RecordQuery {
 
 	// Add version queries
 	for _, version := range opts.Versions {
+		operator, value := parseVersionOperator(version)
+
 		queries = append(queries, &searchv1.RecordQuery{
-			Type:  searchv1.RecordQueryType_RECORD_QUERY_TYPE_VERSION,
-			Value: version,
+			Type:     searchv1.RecordQueryType_RECORD_QUERY_TYPE_VERSION,
+			Value:    value,
+			Operator: operator,
 		})
 	}
 
@@ -174,6 +235,14 @@ func buildQueriesFromFlags() []*searchv1.RecordQuery {
 		})
 	}
 
+	// Add locator variant queries
+	for _, variant := range opts.LocatorVariants {
+		queries = append(queries, &searchv1.RecordQuery{
+			Type:  searchv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR_VARIANT,
+			Value: variant,
+		})
+	}
+
 	// Add module queries
 	for _, module := range opts.Modules {
 		queries = append(queries, &searchv1.RecordQuery{
@@ -182,5 +251,36 @@ func buildQueriesFromFlags() []*searchv1.RecordQuery {
 		})
 	}
 
+	// Add annotation queries
+	for _, annotation := range opts.Annotations {
+		queries = append(queries, &searchv1.RecordQuery{
+			Type:  searchv1.RecordQueryType_RECORD_QUERY_TYPE_ANNOTATION,
+			Value: annotation,
+		})
+	}
+
 	return queries
 }
+
+// versionOperators maps version flag prefixes to query operators.
+// Two-character prefixes come first so that ">=" is not parsed as ">".
+var versionOperators = []struct {
+	prefix   string
+	operator searchv1.RecordQueryOperator
+}{
+	{">=", searchv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_GREATER_THAN_OR_EQUAL},
+	{"<=", searchv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_LESS_THAN_OR_EQUAL},
+	{">", searchv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_GREATER_THAN},
+	{"<", searchv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_LESS_THAN},
+}
+
+// parseVersionOperator splits a comparison prefix such as ">=" from a version flag value.
+func parseVersionOperator(version string) (searchv1.RecordQueryOperator, string) {
+	for _, op := range versionOperators {
+		if value, ok := strings.CutPrefix(version, op.prefix); ok {
+			return op.operator, strings.TrimSpace(value)
+		}
+	}
+
+	return searchv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_UNSPECIFIED, version
+}
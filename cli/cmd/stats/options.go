@@ -0,0 +1,26 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import "github.com/agntcy/dir/cli/presenter"
+
+var opts = &options{}
+
+type options struct {
+	Top uint32
+	By  string
+}
+
+func init() {
+	flags := Command.Flags()
+	flags.Uint32Var(&opts.Top, "top", 0,
+		"Show the statistics of the given number of most used records instead of a single record.",
+	)
+	flags.StringVar(&opts.By, "by", "pulls",
+		"Order of the records shown with --top, either \"pulls\" or \"recent\".",
+	)
+
+	// Add output format flags
+	presenter.AddOutputFlags(Command)
+}
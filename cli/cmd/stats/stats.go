@@ -0,0 +1,137 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package stats
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "stats [<cid>]",
+	Short: "Show usage statistics of records",
+	Long: `This command shows how often records were pulled and looked up,
+and when they were last accessed. Statistics are collected by the server
+and written periodically, so that the most recent accesses can be missing.
+
+Usage examples:
+
+1. Show the statistics of a record
+
+	dirctl stats <cid>
+
+2. Show the 20 most pulled records
+
+	dirctl stats --top 20
+
+3. Show the 20 most recently accessed records
+
+	dirctl stats --top 20 --by recent
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if opts.Top > 0 {
+			if len(args) > 0 {
+				return errors.New("no arguments are allowed with --top")
+			}
+
+			return runTopCommand(cmd)
+		}
+
+		if len(args) != 1 {
+			return errors.New("exactly one argument is required which is the cid of the record")
+		}
+
+		return runCommand(cmd, args[0])
+	},
+}
+
+func runCommand(cmd *cobra.Command, cid string) error {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	stats, err := c.RecordStats(cmd.Context(), &corev1.RecordRef{Cid: cid})
+	if err != nil {
+		return err
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "stats", "Record stats", stats)
+	}
+
+	printStats(cmd, stats)
+
+	return nil
+}
+
+func runTopCommand(cmd *cobra.Command) error {
+	order, err := parseOrder(opts.By)
+	if err != nil {
+		return err
+	}
+
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	records, err := c.TopRecordStats(cmd.Context(), order, opts.Top)
+	if err != nil {
+		return err
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		results := make([]interface{}, 0, len(records))
+		for _, stats := range records {
+			results = append(results, stats)
+		}
+
+		return presenter.PrintMessage(cmd, "stats", "Record stats", results)
+	}
+
+	if len(records) == 0 {
+		presenter.Println(cmd, "No records were accessed")
+
+		return nil
+	}
+
+	for _, stats := range records {
+		printStats(cmd, stats)
+	}
+
+	return nil
+}
+
+func parseOrder(by string) (storev1.TopRecordsOrder, error) {
+	switch by {
+	case "pulls":
+		return storev1.TopRecordsOrder_TOP_RECORDS_ORDER_PULLS, nil
+	case "recent":
+		return storev1.TopRecordsOrder_TOP_RECORDS_ORDER_RECENT, nil
+	default:
+		return storev1.TopRecordsOrder_TOP_RECORDS_ORDER_UNSPECIFIED, fmt.Errorf("invalid order %q, expected \"pulls\" or \"recent\"", by)
+	}
+}
+
+func printStats(cmd *cobra.Command, stats *storev1.RecordStats) {
+	lastAccessed := stats.GetLastAccessedAt()
+	if lastAccessed == "" {
+		lastAccessed = "never"
+	}
+
+	presenter.Printf(cmd, "CID: %s\n", stats.GetCid())
+	presenter.Printf(cmd, "  Pulls:         %d\n", stats.GetPullCount())
+	presenter.Printf(cmd, "  Lookups:       %d\n", stats.GetLookupCount())
+	presenter.Printf(cmd, "  Last accessed: %s\n", lastAccessed)
+}
@@ -3,15 +3,21 @@
 
 package sync
 
-import "github.com/agntcy/dir/cli/presenter"
+import (
+	"time"
+
+	"github.com/agntcy/dir/cli/presenter"
+)
 
 var opts = &options{}
 
 type options struct {
-	Limit  uint32
-	Offset uint32
-	CIDs   []string
-	Stdin  bool
+	Limit    uint32
+	Offset   uint32
+	CIDs     []string
+	Labels   []string
+	Interval time.Duration
+	Stdin    bool
 }
 
 //nolint:mnd
@@ -24,6 +30,8 @@ func init() {
 	// Add flags for create command
 	createFlags := createCmd.Flags()
 	createFlags.StringSliceVar(&opts.CIDs, "cids", []string{}, "List of CIDs to synchronize from the remote Directory. If empty, all objects will be synchronized.")
+	createFlags.StringSliceVar(&opts.Labels, "labels", []string{}, "Only synchronize records with a matching label, e.g. /skills/natural_language_processing")
+	createFlags.DurationVar(&opts.Interval, "interval", 0, "Repeat the synchronization at this interval. If zero, the synchronization runs once.")
 	createFlags.BoolVar(&opts.Stdin, "stdin", false, "Parse routing search output from stdin to create sync operations for each provider")
 
 	// Add output format flags to all sync subcommands
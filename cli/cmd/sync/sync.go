@@ -14,6 +14,7 @@ import (
 	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/agntcy/dir/cli/presenter"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
 	"github.com/spf13/cobra"
 )
 
@@ -42,7 +43,10 @@ Usage examples:
 2. Create sync with specific CIDs:
   dir sync create http://localhost:8080 --cids cid1,cid2,cid3
 
-3. Create sync from routing search output:
+3. Create sync of records with a skill, repeated every 10 minutes:
+  dir sync create localhost:8888 --labels /skills/natural_language_processing --interval 10m
+
+4. Create sync from routing search output:
   dirctl routing search --skill "AI" --json | dirctl sync create --stdin`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if opts.Stdin {
@@ -56,7 +60,11 @@ Usage examples:
 			return runCreateSyncFromStdin(cmd)
 		}
 
-		return runCreateSync(cmd, args[0], opts.CIDs)
+		return runCreateSync(cmd, args[0], client.SyncOptions{
+			CIDs:     opts.CIDs,
+			Labels:   opts.Labels,
+			Interval: opts.Interval,
+		})
 	},
 }
 
@@ -106,18 +114,18 @@ func init() {
 	Command.AddCommand(deleteCmd)
 }
 
-func runCreateSync(cmd *cobra.Command, remoteURL string, cids []string) error {
+func runCreateSync(cmd *cobra.Command, remoteURL string, syncOpts client.SyncOptions) error {
 	// Validate remote URL
 	if remoteURL == "" {
 		return errors.New("remote URL is required")
 	}
 
-	client, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
 	if !ok {
 		return errors.New("failed to get client from context")
 	}
 
-	syncID, err := client.CreateSync(cmd.Context(), remoteURL, cids)
+	syncID, err := c.CreateSync(cmd.Context(), remoteURL, syncOpts)
 	if err != nil {
 		return fmt.Errorf("failed to create sync: %w", err)
 	}
@@ -275,7 +283,7 @@ func groupResultsByAPIAddress(results []*routingv1.SearchResponse) map[string]Pe
 }
 
 func createSyncOperations(cmd *cobra.Command, peerResults map[string]PeerSyncInfo) error {
-	client, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
 	if !ok {
 		return errors.New("failed to get client from context")
 	}
@@ -294,7 +302,7 @@ func createSyncOperations(cmd *cobra.Command, peerResults map[string]PeerSyncInf
 		}
 
 		// Create sync operation
-		syncID, err := client.CreateSync(cmd.Context(), syncInfo.APIAddress, syncInfo.CIDs)
+		syncID, err := c.CreateSync(cmd.Context(), syncInfo.APIAddress, client.SyncOptions{CIDs: syncInfo.CIDs})
 		if err != nil {
 			presenter.Printf(cmd, "ERROR: Failed to create sync for peer %s: %v\n", apiAddress, err)
 
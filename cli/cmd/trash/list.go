@@ -0,0 +1,72 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package trash
+
+import (
+	"errors"
+
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the records in the trash",
+	Long: `List the records in the trash, most recently deleted first,
+with the time they are purged unless they are restored before.
+
+Usage examples:
+
+1. List the records in the trash:
+   dirctl trash list
+
+2. List the records in the trash as JSON:
+   dirctl trash list --output json
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			return errors.New("no arguments are allowed")
+		}
+
+		return runListCommand(cmd)
+	},
+}
+
+func runListCommand(cmd *cobra.Command) error {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	records, err := c.TrashedRecords(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		results := make([]interface{}, 0, len(records))
+		for _, record := range records {
+			results = append(results, record)
+		}
+
+		return presenter.PrintMessage(cmd, "trash", "Trashed records", results)
+	}
+
+	if len(records) == 0 {
+		presenter.Println(cmd, "Trash is empty")
+
+		return nil
+	}
+
+	for _, record := range records {
+		presenter.Printf(cmd, "%s %s@%s\n", record.GetCid(), record.GetName(), record.GetVersion())
+		presenter.Printf(cmd, "  Deleted: %s\n", record.GetDeletedAt())
+		presenter.Printf(cmd, "  Expires: %s\n", record.GetExpiresAt())
+	}
+
+	return nil
+}
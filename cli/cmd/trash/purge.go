@@ -0,0 +1,48 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package trash
+
+import (
+	"errors"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var PurgeCommand = &cobra.Command{
+	Use:   "purge <cid>",
+	Short: "Permanently delete a record from the trash",
+	Long: `Permanently delete a record deleted on a server in soft deletion mode,
+without waiting for its retention to expire. Purged records cannot be restored.
+
+Only records in the trash can be purged, delete the record first:
+
+	dirctl delete <cid>
+	dirctl purge <cid>
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("cid is a required argument")
+		}
+
+		return runPurgeCommand(cmd, args[0])
+	},
+}
+
+func runPurgeCommand(cmd *cobra.Command, cid string) error {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	if err := c.PurgeRecord(cmd.Context(), &corev1.RecordRef{Cid: cid}); err != nil {
+		return err
+	}
+
+	return presenter.PrintMessage(cmd, "record", "Purged record with CID", cid)
+}
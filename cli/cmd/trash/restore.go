@@ -0,0 +1,55 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package trash
+
+import (
+	"errors"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var RestoreCommand = &cobra.Command{
+	Use:   "restore <cid>",
+	Short: "Restore a record from the trash",
+	Long: `Restore a record deleted on a server in soft deletion mode.
+
+The record is restored with its tags and search index entry, and published
+records are published again. Records can only be restored until their
+retention expires, see "dirctl trash list".
+
+Usage example:
+
+	dirctl restore <cid>
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("cid is a required argument")
+		}
+
+		return runRestoreCommand(cmd, args[0])
+	},
+}
+
+func runRestoreCommand(cmd *cobra.Command, cid string) error {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	meta, err := c.RestoreRecord(cmd.Context(), &corev1.RecordRef{Cid: cid})
+	if err != nil {
+		return err
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "record", "Restored record", meta)
+	}
+
+	return presenter.PrintMessage(cmd, "record", "Restored record with CID", cid)
+}
@@ -0,0 +1,42 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package trash
+
+import (
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "trash",
+	Short: "Manage records deleted in soft deletion mode",
+	Long: `Manage the trash of a Directory server in soft deletion mode.
+
+Deleted records are moved to the trash, where they are kept until their
+retention expires. Trashed records are hidden from pulls, lookups, searches
+and the network, and can be restored with "dirctl restore" or permanently
+deleted with "dirctl purge".
+
+- list: List the records in the trash
+
+Examples:
+
+1. List the records in the trash:
+   dirctl trash list
+
+2. Restore a record deleted by mistake:
+   dirctl restore <cid>
+
+3. Permanently delete a record before its retention expires:
+   dirctl purge <cid>
+`,
+}
+
+func init() {
+	Command.AddCommand(listCmd)
+
+	presenter.AddOutputFlags(listCmd)
+	presenter.AddOutputFlags(RestoreCommand)
+	presenter.AddOutputFlags(PurgeCommand)
+}
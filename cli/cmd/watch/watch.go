@@ -0,0 +1,106 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package watch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	Command.Flags().Uint64Var(&opts.From, "from", 0, "Only stream the events with a greater sequence number")
+
+	// Add output format flags
+	presenter.AddOutputFlags(Command)
+}
+
+var opts struct {
+	From uint64
+}
+
+var Command = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream the changes of the Directory store",
+	Long: `Stream the records pushed to, updated in and deleted from the server store
+as they happen, until interrupted. The server operation journal must be enabled.
+
+Events are numbered sequentially. Interrupted connections are resumed from the
+last received event, use --from with the last sequence number handled to resume
+after a restart. A gap event reports changes the server did not record, a full
+rescan of the store is needed to recover them.
+
+Usage examples:
+
+1. Stream all retained and new changes:
+   dirctl watch
+
+2. Stream the changes after sequence number 1200 as JSON lines:
+   dirctl watch --from 1200 --json
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runCommand(cmd)
+	},
+}
+
+func runCommand(cmd *cobra.Command) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	eventCh, err := c.WatchStore(cmd.Context(), opts.From)
+	if err != nil {
+		return err
+	}
+
+	human := presenter.GetOutputOptions(cmd).Format == presenter.FormatHuman
+
+	for event := range eventCh {
+		if event.Error != nil {
+			return event.Error
+		}
+
+		if human {
+			printEvent(cmd, event.StoreEvent)
+
+			continue
+		}
+
+		output, err := json.Marshal(event.StoreEvent)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+
+		presenter.Println(cmd, string(output))
+	}
+
+	return nil
+}
+
+func printEvent(cmd *cobra.Command, event *storev1.StoreEvent) {
+	eventType := strings.TrimPrefix(event.GetType().String(), "STORE_EVENT_TYPE_")
+
+	if event.GetType() == storev1.StoreEventType_STORE_EVENT_TYPE_GAP {
+		presenter.Printf(cmd, "%d %s %s: %d changes missed\n", event.GetSequence(), event.GetTimestamp(), eventType, event.GetDropped())
+
+		return
+	}
+
+	presenter.Printf(cmd, "%d %s %s %s", event.GetSequence(), event.GetTimestamp(), eventType, event.GetCid())
+
+	if annotations := event.GetMeta().GetAnnotations(); annotations["name"] != "" {
+		presenter.Printf(cmd, " %s@%s", annotations["name"], annotations["version"])
+	}
+
+	presenter.Println(cmd)
+}
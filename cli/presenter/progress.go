@@ -0,0 +1,47 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package presenter
+
+import (
+	"os"
+
+	"github.com/agntcy/dir/client/streaming"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// ProgressOptions returns stream options that render a progress counter to stderr.
+// No options are returned if stderr is not a terminal, so piped output stays clean.
+func ProgressOptions(cmd *cobra.Command, action string) []streaming.Option {
+	if !IsTerminal(cmd) {
+		return nil
+	}
+
+	return []streaming.Option{
+		streaming.WithProgress(func(p streaming.Progress) {
+			if p.Total > 0 {
+				Errorf(cmd, "\r%s: %d/%d (%d%%), %d failed", action, p.Completed, p.Total, p.Completed*100/p.Total, p.Failed) //nolint:mnd
+			} else {
+				Errorf(cmd, "\r%s: %d done, %d failed", action, p.Completed, p.Failed)
+			}
+		}),
+	}
+}
+
+// FinishProgress terminates the progress line written by ProgressOptions.
+func FinishProgress(cmd *cobra.Command) {
+	if IsTerminal(cmd) {
+		Error(cmd, "\n")
+	}
+}
+
+// IsTerminal reports whether the command's stderr is attached to a terminal.
+func IsTerminal(cmd *cobra.Command) bool {
+	f, ok := cmd.ErrOrStderr().(*os.File)
+	if !ok {
+		return false
+	}
+
+	return term.IsTerminal(int(f.Fd())) //nolint:gosec
+}
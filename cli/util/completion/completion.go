@@ -0,0 +1,81 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package completion provides dynamic shell completion of command arguments and flag values.
+// Completions never fail: if the server cannot be reached in time, nothing is suggested.
+package completion
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// MaxCIDs bounds the number of CIDs suggested for a record argument.
+	MaxCIDs = 50
+
+	// Timeout bounds the time spent querying the server, so that completion never hangs.
+	Timeout = 2 * time.Second
+)
+
+// SchemaVersions are the OASF schema versions records can be generated or converted to.
+var SchemaVersions = []string{"0.7.0", "v0.3.1"}
+
+// CIDs completes up to maxArgs record CID arguments with the CIDs of records stored on the server.
+// A maxArgs of zero completes any number of arguments. CIDs given already are not suggested again.
+func CIDs(maxArgs int) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if maxArgs > 0 && len(args) >= maxArgs {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return storedCIDs(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// CIDFlag completes a flag value with the CIDs of records stored on the server.
+func CIDFlag(cmd *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return storedCIDs(cmd.Context(), nil, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// SchemaVersionFlag completes a flag value with the supported OASF schema versions.
+var SchemaVersionFlag = cobra.FixedCompletions(SchemaVersions, cobra.ShellCompDirectiveNoFileComp)
+
+// storedCIDs returns the CIDs of stored records starting with prefix, except the excluded ones.
+func storedCIDs(ctx context.Context, exclude []string, prefix string) []cobra.Completion {
+	if ctx == nil {
+		return nil
+	}
+
+	c, ok := ctxUtils.GetClientFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	limit := uint32(MaxCIDs)
+
+	// The stream is closed on errors, so an unreachable server yields no CIDs
+	cids, err := c.Search(ctx, &searchv1.SearchRequest{Limit: &limit})
+	if err != nil {
+		return nil
+	}
+
+	var completions []cobra.Completion
+
+	for cid := range cids {
+		if strings.HasPrefix(cid, prefix) && !slices.Contains(exclude, cid) {
+			completions = append(completions, cid)
+		}
+	}
+
+	return completions
+}
@@ -0,0 +1,36 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+)
+
+// SetRecordACL replaces the access control list of a record and returns its updated metadata.
+// An ACL without patterns removes the restrictions of the record.
+// Only the caller that first pushed the record may set its access control list.
+func (c *Client) SetRecordACL(ctx context.Context, recordRef *corev1.RecordRef, acl *corev1.RecordACL) (*corev1.RecordMeta, error) {
+	if err := acl.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid access control list: %w", err)
+	}
+
+	recordRef, err := c.resolveRef(ctx, recordRef)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := c.SetACL(ctx, &storev1.SetACLRequest{
+		RecordRef: recordRef,
+		Acl:       acl,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set record access control list: %w", err)
+	}
+
+	return meta, nil
+}
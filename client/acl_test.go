@@ -0,0 +1,64 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// aclServer sets the access control lists of the records it knows.
+type aclServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	acls map[string]*corev1.RecordACL
+}
+
+func (s aclServer) SetACL(_ context.Context, req *storev1.SetACLRequest) (*corev1.RecordMeta, error) {
+	if _, ok := s.acls[req.GetRecordRef().GetCid()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", req.GetRecordRef().GetCid())
+	}
+
+	s.acls[req.GetRecordRef().GetCid()] = req.GetAcl()
+
+	return &corev1.RecordMeta{Cid: req.GetRecordRef().GetCid(), Acl: req.GetAcl()}, nil
+}
+
+func TestSetRecordACL(t *testing.T) {
+	const cid = "bafkreigh2akiscaildcqabsyg3dfr6chu3fgpregiymsck7e7aqa4s52zy"
+
+	server := aclServer{acls: map[string]*corev1.RecordACL{cid: nil}}
+
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterStoreServiceServer(s, server)
+	})
+
+	ref := &corev1.RecordRef{Cid: cid}
+	want := []string{"spiffe://example.org/ns/team-a/**"}
+
+	meta, err := c.SetRecordACL(t.Context(), ref, &corev1.RecordACL{AllowPull: want})
+	if err != nil {
+		t.Fatalf("SetRecordACL() unexpected error: %v", err)
+	}
+
+	if !slices.Equal(meta.GetAcl().GetAllowPull(), want) {
+		t.Errorf("expected pull patterns %v, got %v", want, meta.GetAcl().GetAllowPull())
+	}
+
+	_, err = c.SetRecordACL(t.Context(), ref, &corev1.RecordACL{AllowDelete: []string{"team-a"}})
+	if err == nil {
+		t.Error("expected invalid patterns to be rejected")
+	}
+
+	if !slices.Equal(server.acls[cid].GetAllowPull(), want) {
+		t.Errorf("expected invalid access control lists not to be sent, got %v", server.acls[cid])
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+)
+
+// CheckStore runs a consistency check of the server store, repairing the detected issues if requested.
+// Progress updates and detected issues are passed to fn as they are received, fn may be nil.
+// It returns the summary of the check.
+func (c *Client) CheckStore(ctx context.Context, repair bool, fn func(*storev1.FsckResponse)) (*storev1.FsckSummary, error) {
+	stream, err := c.Fsck(ctx, &storev1.FsckRequest{Repair: repair})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check store: %w", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("failed to check store: stream ended without a summary")
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to check store: %w", err)
+		}
+
+		if summary := resp.GetSummary(); summary != nil {
+			return summary, nil
+		}
+
+		if fn != nil {
+			fn(resp)
+		}
+	}
+}
+
+// ReshardStore moves the records of a sharded server store to the repositories resolved for them
+// by the current repository template, only reporting the records to move if dryRun is set.
+// Records stored outside of their target repository are passed to fn as they are received, fn may be nil.
+// It returns the summary of the migration.
+func (c *Client) ReshardStore(ctx context.Context, dryRun bool, fn func(*storev1.ReshardMove)) (*storev1.ReshardSummary, error) {
+	stream, err := c.Reshard(ctx, &storev1.ReshardRequest{DryRun: dryRun})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reshard store: %w", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("failed to reshard store: stream ended without a summary")
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to reshard store: %w", err)
+		}
+
+		if summary := resp.GetSummary(); summary != nil {
+			return summary, nil
+		}
+
+		if move := resp.GetMove(); move != nil && fn != nil {
+			fn(move)
+		}
+	}
+}
+
+// ReadStoreJournal reads the operation journal of the server, passing the entries with a sequence number
+// greater than since to fn as they are received, oldest first.
+// Entries of the GAP operation mark operations the server dropped from the journal.
+func (c *Client) ReadStoreJournal(ctx context.Context, since uint64, fn func(*storev1.JournalEntry)) error {
+	stream, err := c.ReadJournal(ctx, &storev1.ReadJournalRequest{Since: since})
+	if err != nil {
+		return fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	for {
+		entry, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read journal: %w", err)
+		}
+
+		fn(entry)
+	}
+}
+
+// GetWebhooks returns the webhook subscriptions of the server, those of the server configuration first.
+// The secrets of the subscriptions are not returned.
+func (c *Client) GetWebhooks(ctx context.Context) ([]*storev1.Webhook, error) {
+	stream, err := c.ListWebhooks(ctx, &storev1.ListWebhooksRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	var webhooks []*storev1.Webhook
+
+	for {
+		webhook, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return webhooks, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to list webhooks: %w", err)
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"testing"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adminServer reports a single missing tag, repaired if requested.
+type adminServer struct {
+	storev1.UnimplementedAdminServiceServer
+}
+
+func (adminServer) Fsck(req *storev1.FsckRequest, stream storev1.AdminService_FsckServer) error {
+	if err := stream.Send(&storev1.FsckResponse{Response: &storev1.FsckResponse_Issue{Issue: &storev1.FsckIssue{
+		Type:     storev1.FsckIssueType_FSCK_ISSUE_TYPE_MISSING_TAG,
+		Tag:      "my-agent_latest",
+		Repaired: req.GetRepair(),
+	}}}); err != nil {
+		return err
+	}
+
+	if err := stream.Send(&storev1.FsckResponse{Response: &storev1.FsckResponse_Progress{Progress: &storev1.FsckProgress{
+		CheckedTags: 3,
+		TotalTags:   3,
+	}}}); err != nil {
+		return err
+	}
+
+	summary := &storev1.FsckSummary{CheckedTags: 3, CheckedRecords: 1, Issues: 1}
+	if req.GetRepair() {
+		summary.Repaired = 1
+	}
+
+	return stream.Send(&storev1.FsckResponse{Response: &storev1.FsckResponse_Summary{Summary: summary}})
+}
+
+func (adminServer) Reshard(req *storev1.ReshardRequest, stream storev1.AdminService_ReshardServer) error {
+	if err := stream.Send(&storev1.ReshardResponse{Response: &storev1.ReshardResponse_Move{Move: &storev1.ReshardMove{
+		Cid:              "baeareihdr6t7s6sr2q4zo456sza66eewqc7huzatyfgvoupaqyjw23ilvi",
+		SourceRepository: "dir",
+		TargetRepository: "agents/team-a",
+		Moved:            !req.GetDryRun(),
+	}}}); err != nil {
+		return err
+	}
+
+	summary := &storev1.ReshardSummary{CheckedRecords: 2, Misplaced: 1}
+	if !req.GetDryRun() {
+		summary.Moved = 1
+	}
+
+	return stream.Send(&storev1.ReshardResponse{Response: &storev1.ReshardResponse_Summary{Summary: summary}})
+}
+
+func (adminServer) ReadJournal(req *storev1.ReadJournalRequest, stream storev1.AdminService_ReadJournalServer) error {
+	for seq := req.GetSince() + 1; seq <= 3; seq++ {
+		if err := stream.Send(&storev1.JournalEntry{Sequence: seq, Operation: storev1.JournalOperation_JOURNAL_OPERATION_PUSH}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+func (adminServer) ListWebhooks(_ *storev1.ListWebhooksRequest, stream storev1.AdminService_ListWebhooksServer) error {
+	for _, id := range []string{"ci", "chat"} {
+		if err := stream.Send(&storev1.Webhook{Id: id, Url: "https://" + id + ".example.org", Configured: id == "ci"}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+func TestCheckStore(t *testing.T) {
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterAdminServiceServer(s, adminServer{})
+	})
+
+	for _, repair := range []bool{false, true} {
+		var responses []*storev1.FsckResponse
+
+		summary, err := c.CheckStore(t.Context(), repair, func(resp *storev1.FsckResponse) {
+			responses = append(responses, resp)
+		})
+		if err != nil {
+			t.Fatalf("CheckStore() unexpected error: %v", err)
+		}
+
+		if len(responses) != 2 || responses[0].GetIssue().GetRepaired() != repair || responses[1].GetProgress().GetCheckedTags() != 3 {
+			t.Errorf("expected issue and progress, got %v", responses)
+		}
+
+		if summary.GetIssues() != 1 || summary.GetRepaired() == 0 == repair {
+			t.Errorf("unexpected summary %v for repair=%t", summary, repair)
+		}
+	}
+}
+
+func TestCheckStoreUnimplemented(t *testing.T) {
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterAdminServiceServer(s, storev1.UnimplementedAdminServiceServer{})
+	})
+
+	_, err := c.CheckStore(t.Context(), false, nil)
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("expected Unimplemented error, got %v", err)
+	}
+}
+
+func TestReshardStore(t *testing.T) {
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterAdminServiceServer(s, adminServer{})
+	})
+
+	for _, dryRun := range []bool{true, false} {
+		var moves []*storev1.ReshardMove
+
+		summary, err := c.ReshardStore(t.Context(), dryRun, func(move *storev1.ReshardMove) {
+			moves = append(moves, move)
+		})
+		if err != nil {
+			t.Fatalf("ReshardStore() unexpected error: %v", err)
+		}
+
+		if len(moves) != 1 || moves[0].GetMoved() == dryRun || moves[0].GetTargetRepository() != "agents/team-a" {
+			t.Errorf("expected a single move, got %v", moves)
+		}
+
+		if summary.GetMisplaced() != 1 || summary.GetMoved() == 0 != dryRun {
+			t.Errorf("unexpected summary %v for dryRun=%t", summary, dryRun)
+		}
+	}
+}
+
+func TestReadStoreJournal(t *testing.T) {
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterAdminServiceServer(s, adminServer{})
+	})
+
+	var sequences []uint64
+
+	err := c.ReadStoreJournal(t.Context(), 1, func(entry *storev1.JournalEntry) {
+		sequences = append(sequences, entry.GetSequence())
+	})
+	if err != nil {
+		t.Fatalf("ReadStoreJournal() unexpected error: %v", err)
+	}
+
+	if len(sequences) != 2 || sequences[0] != 2 || sequences[1] != 3 {
+		t.Errorf("expected entries 2 and 3, got %v", sequences)
+	}
+}
+
+func TestGetWebhooks(t *testing.T) {
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterAdminServiceServer(s, adminServer{})
+	})
+
+	webhooks, err := c.GetWebhooks(t.Context())
+	if err != nil {
+		t.Fatalf("GetWebhooks() unexpected error: %v", err)
+	}
+
+	if len(webhooks) != 2 || webhooks[0].GetId() != "ci" || !webhooks[0].GetConfigured() || webhooks[1].GetUrl() != "https://chat.example.org" {
+		t.Errorf("expected the ci and chat webhooks, got %v", webhooks)
+	}
+
+	c = newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterAdminServiceServer(s, storev1.UnimplementedAdminServiceServer{})
+	})
+
+	if _, err := c.GetWebhooks(t.Context()); status.Code(err) != codes.Unimplemented {
+		t.Errorf("expected Unimplemented error, got %v", err)
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultMaxRetryDelay is the longest delay requested by the server with a RetryInfo detail
+	// that calls are automatically retried after.
+	DefaultMaxRetryDelay = 5 * time.Second
+
+	// maxRetryAttempts is how many times a call is retried after the delays requested by the server.
+	maxRetryAttempts = 3
+)
+
+// APIError is an error returned by the server, with the structured details it attached to the error.
+// The errors of calls and the errors of Results wrap an APIError when they were returned by the server,
+// so that programs can react to the kind of failure:
+//
+//	var apiErr client.APIError
+//	if errors.As(err, &apiErr) {
+//		for _, detail := range apiErr.Details() {
+//			switch detail := detail.(type) {
+//			case client.QuotaExceededDetail:
+//			case client.ValidationDetail:
+//			case client.ConflictDetail:
+//			case client.RetryInfo:
+//			}
+//		}
+//	}
+//
+// The gRPC status is preserved, so status.Code and status.FromError can still be used on the error.
+type APIError struct {
+	err    error
+	status *status.Status
+}
+
+// ErrorDetail is a structured detail of an APIError: QuotaExceededDetail, ValidationDetail, ConflictDetail or RetryInfo.
+type ErrorDetail interface {
+	errorDetail()
+}
+
+// QuotaExceededDetail is reported when a push is rejected because it would exceed a storage quota.
+type QuotaExceededDetail struct {
+	// Subject is the quota owner that exceeded the quota, e.g. "trust_domain:example.org".
+	Subject string
+	// Description describes the exceeded quota.
+	Description string
+	// Usage is the usage and quotas of the trust domain, if reported by the server.
+	Usage *storev1.QuotaUsage
+}
+
+// ValidationDetail is reported for each invalid field of a record rejected by validation.
+type ValidationDetail struct {
+	// FieldPath is the path of the invalid field, e.g. "skills[0].name",
+	// or empty if the failure is not about a specific field.
+	FieldPath string
+	// Message describes why the field is invalid.
+	Message string
+}
+
+// ConflictDetail is reported when a pushed record conflicts with a stored record
+// with the same name and version but different content.
+type ConflictDetail struct {
+	// Name and Version are the name and version shared by both records.
+	Name    string
+	Version string
+	// ExistingCID is the CID of the stored record.
+	ExistingCID string
+}
+
+// RetryInfo is reported when the call can be retried after a delay, e.g. when it was rate limited.
+// Calls are retried automatically after delays up to the maximum set with WithMaxRetryDelay.
+type RetryInfo struct {
+	// Delay is how long to wait before retrying the call.
+	Delay time.Duration
+}
+
+func (QuotaExceededDetail) errorDetail() {}
+func (ValidationDetail) errorDetail()    {}
+func (ConflictDetail) errorDetail()      {}
+func (RetryInfo) errorDetail()           {}
+
+func (e APIError) Error() string {
+	return e.err.Error()
+}
+
+func (e APIError) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus returns the gRPC status of the error, including its details.
+func (e APIError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// Code returns the gRPC status code of the error.
+func (e APIError) Code() codes.Code {
+	return e.status.Code()
+}
+
+// Message returns the message of the error, as sent by the server.
+func (e APIError) Message() string {
+	return e.status.Message()
+}
+
+// Details returns the structured details of the error that the client understands.
+func (e APIError) Details() []ErrorDetail {
+	var (
+		details []ErrorDetail
+		usage   *storev1.QuotaUsage
+		quota   bool
+	)
+
+	for _, detail := range e.status.Details() {
+		if u, ok := detail.(*storev1.QuotaUsage); ok {
+			usage = u
+		}
+	}
+
+	for _, detail := range e.status.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.QuotaFailure:
+			for _, violation := range detail.GetViolations() {
+				details = append(details, QuotaExceededDetail{
+					Subject:     violation.GetSubject(),
+					Description: violation.GetDescription(),
+					Usage:       usage,
+				})
+				quota = true
+			}
+		case *errdetails.BadRequest:
+			for _, violation := range detail.GetFieldViolations() {
+				details = append(details, ValidationDetail{
+					FieldPath: violation.GetField(),
+					Message:   violation.GetDescription(),
+				})
+			}
+		case *corev1.RecordConflict:
+			details = append(details, ConflictDetail{
+				Name:        detail.GetName(),
+				Version:     detail.GetVersion(),
+				ExistingCID: detail.GetCid(),
+			})
+		case *errdetails.RetryInfo:
+			details = append(details, RetryInfo{Delay: detail.GetRetryDelay().AsDuration()})
+		}
+	}
+
+	// Servers reporting only the usage of the exceeded quota
+	if usage != nil && !quota {
+		details = append(details, QuotaExceededDetail{
+			Subject:     "trust_domain:" + usage.GetTrustDomain(),
+			Description: e.status.Message(),
+			Usage:       usage,
+		})
+	}
+
+	return details
+}
+
+// apiError wraps an error returned by the server in an APIError.
+// Errors that are not gRPC status errors, e.g. io.EOF, and errors that already wrap an APIError are returned unchanged.
+func apiError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.As(err, new(APIError)) {
+		return err
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	return APIError{err: err, status: st}
+}
+
+// retryDelay returns the delay the server asked to wait before retrying the failed call,
+// if it is at most maxDelay and the call can still complete before the context deadline.
+func retryDelay(ctx context.Context, err error, maxDelay time.Duration) (time.Duration, bool) {
+	var apiErr APIError
+	if maxDelay <= 0 || !errors.As(err, &apiErr) {
+		return 0, false
+	}
+
+	for _, detail := range apiErr.Details() {
+		info, ok := detail.(RetryInfo)
+		if !ok || info.Delay > maxDelay {
+			continue
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= info.Delay {
+			return 0, false
+		}
+
+		return info.Delay, true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for the delay, returning early with the context error if the context is done.
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	}
+}
+
+// apiErrorInterceptors wrap the errors returned by the server in APIErrors,
+// and retry unary calls after the delays requested by the server.
+type apiErrorInterceptors struct {
+	maxRetryDelay time.Duration
+}
+
+func (i apiErrorInterceptors) unaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		for attempt := 1; ; attempt++ {
+			err := apiError(invoker(ctx, method, req, reply, cc, opts...))
+
+			delay, ok := retryDelay(ctx, err, i.maxRetryDelay)
+			if !ok || attempt > maxRetryAttempts {
+				return err
+			}
+
+			logger.Debug("Retrying call after the delay requested by the server", "method", method, "delay", delay, "attempt", attempt)
+
+			if sleepContext(ctx, delay) != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (i apiErrorInterceptors) streamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		clientStream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, apiError(err)
+		}
+
+		return &apiErrorClientStream{ClientStream: clientStream}, nil
+	}
+}
+
+// apiErrorClientStream wraps the errors of a client stream in APIErrors.
+type apiErrorClientStream struct {
+	grpc.ClientStream
+}
+
+func (s *apiErrorClientStream) SendMsg(msg any) error {
+	return apiError(s.ClientStream.SendMsg(msg))
+}
+
+func (s *apiErrorClientStream) RecvMsg(msg any) error {
+	return apiError(s.ClientStream.RecvMsg(msg))
+}
@@ -0,0 +1,265 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// rejectingStoreServer answers every pushed record with the response of reject.
+type rejectingStoreServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	reject func(record *corev1.Record) (*corev1.RecordRef, error)
+}
+
+func (s rejectingStoreServer) Push(stream storev1.StoreService_PushServer) error {
+	for {
+		record, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		ref, err := s.reject(record)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(ref); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+// retryingQuotaServer is rate limited for the first calls, asking to retry after a short delay.
+type retryingQuotaServer struct {
+	storev1.UnimplementedQuotaServiceServer
+
+	limited int32
+	calls   *atomic.Int32
+}
+
+func (s retryingQuotaServer) GetUsage(context.Context, *storev1.GetUsageRequest) (*storev1.GetUsageResponse, error) {
+	if s.calls.Add(1) <= s.limited {
+		return nil, retryError(10 * time.Millisecond) //nolint:mnd
+	}
+
+	return &storev1.GetUsageResponse{Usages: []*storev1.QuotaUsage{{TrustDomain: "example.org"}}}, nil
+}
+
+func detailedError(t *testing.T, code codes.Code, message string, details ...protoadapt.MessageV1) error {
+	t.Helper()
+
+	st, err := status.New(code, message).WithDetails(details...)
+	if err != nil {
+		t.Fatalf("failed to add details: %v", err)
+	}
+
+	return st.Err()
+}
+
+func retryError(delay time.Duration) error {
+	st, _ := status.New(codes.ResourceExhausted, "rate limit exceeded").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+
+	return st.Err()
+}
+
+// pushDetails pushes a record to a server rejecting it with reject, and returns the details of the push error.
+func pushDetails(t *testing.T, reject func(*corev1.Record) (*corev1.RecordRef, error)) (APIError, []ErrorDetail) {
+	t.Helper()
+
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterStoreServiceServer(s, rejectingStoreServer{reject: reject})
+	})
+
+	_, err := c.Push(t.Context(), newSizedRecord(1024)) //nolint:mnd
+	if err == nil {
+		t.Fatal("expected push to fail")
+	}
+
+	var apiErr APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %T: %v", err, err)
+	}
+
+	return apiErr, apiErr.Details()
+}
+
+func TestAPIErrorDetails(t *testing.T) {
+	t.Run("quota exceeded", func(t *testing.T) {
+		usage := &storev1.QuotaUsage{TrustDomain: "example.org", RecordCount: 2, MaxRecordCount: 2}
+
+		apiErr, details := pushDetails(t, func(*corev1.Record) (*corev1.RecordRef, error) {
+			return nil, detailedError(t, codes.ResourceExhausted, "quota exceeded", usage, &errdetails.QuotaFailure{
+				Violations: []*errdetails.QuotaFailure_Violation{{Subject: "trust_domain:example.org", Description: "record count quota"}},
+			})
+		})
+
+		if apiErr.Code() != codes.ResourceExhausted || len(details) != 1 {
+			t.Fatalf("expected a single quota detail, got %s %v", apiErr.Code(), details)
+		}
+
+		quota, ok := details[0].(QuotaExceededDetail)
+		if !ok || quota.Subject != "trust_domain:example.org" || quota.Description != "record count quota" ||
+			quota.Usage.GetRecordCount() != 2 {
+			t.Errorf("unexpected quota detail: %+v", details[0])
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		_, details := pushDetails(t, func(*corev1.Record) (*corev1.RecordRef, error) {
+			return nil, detailedError(t, codes.InvalidArgument, "record validation failed", &errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{Field: "skills[0]", Description: "unknown skill"},
+					{Field: "version", Description: "version is required"},
+				},
+			})
+		})
+
+		expected := []ErrorDetail{
+			ValidationDetail{FieldPath: "skills[0]", Message: "unknown skill"},
+			ValidationDetail{FieldPath: "version", Message: "version is required"},
+		}
+
+		if len(details) != len(expected) || details[0] != expected[0] || details[1] != expected[1] {
+			t.Errorf("expected validation details %v, got %v", expected, details)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		conflict := &corev1.RecordConflict{Name: "agent", Version: "v1.0.0", Cid: "existing"}
+
+		_, details := pushDetails(t, func(record *corev1.Record) (*corev1.RecordRef, error) {
+			st := status.Convert(detailedError(t, codes.AlreadyExists, "record conflicts", conflict))
+
+			return &corev1.RecordRef{
+				Cid:      record.GetCid(),
+				Conflict: conflict,
+				Error: &corev1.RecordError{
+					Code:    uint32(st.Code()),
+					Message: st.Message(),
+					Cid:     record.GetCid(),
+					Details: st.Proto().GetDetails(),
+				},
+			}, nil
+		})
+
+		expected := ConflictDetail{Name: "agent", Version: "v1.0.0", ExistingCID: "existing"}
+		if len(details) != 1 || details[0] != expected {
+			t.Errorf("expected conflict detail %v, got %v", expected, details)
+		}
+	})
+
+	t.Run("retry info", func(t *testing.T) {
+		c := newBufconnClient(t, func(s *grpc.Server) {
+			storev1.RegisterStoreServiceServer(s, rejectingStoreServer{reject: func(*corev1.Record) (*corev1.RecordRef, error) {
+				return nil, retryError(time.Hour)
+			}})
+		})
+
+		_, err := c.Push(t.Context(), newSizedRecord(1024)) //nolint:mnd
+
+		var apiErr APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected an APIError, got %T: %v", err, err)
+		}
+
+		details := apiErr.Details()
+		if len(details) != 1 || details[0] != (RetryInfo{Delay: time.Hour}) {
+			t.Errorf("expected retry info of an hour, got %v", details)
+		}
+
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("expected status code to be preserved, got %s", status.Code(err))
+		}
+	})
+}
+
+func TestAPIErrorRetry(t *testing.T) {
+	t.Run("unary calls are retried after the requested delay", func(t *testing.T) {
+		calls := &atomic.Int32{}
+		c := newBufconnClient(t, func(s *grpc.Server) {
+			storev1.RegisterQuotaServiceServer(s, retryingQuotaServer{limited: 2, calls: calls})
+		})
+
+		if _, err := c.QuotaUsage(t.Context(), ""); err != nil {
+			t.Fatalf("expected rate limited call to be retried, got %v", err)
+		}
+
+		if calls.Load() != 3 { //nolint:mnd
+			t.Errorf("expected 3 calls, got %d", calls.Load())
+		}
+	})
+
+	t.Run("retries are disabled", func(t *testing.T) {
+		calls := &atomic.Int32{}
+		c := newBufconnClient(t, func(s *grpc.Server) {
+			storev1.RegisterQuotaServiceServer(s, retryingQuotaServer{limited: 1, calls: calls})
+		}, WithMaxRetryDelay(0))
+
+		if _, err := c.QuotaUsage(t.Context(), ""); status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("expected rate limited call to fail, got %v", err)
+		}
+
+		if calls.Load() != 1 {
+			t.Errorf("expected a single call, got %d", calls.Load())
+		}
+	})
+
+	t.Run("push batches are retried after the requested delay", func(t *testing.T) {
+		var pushes atomic.Int32
+
+		c := newBufconnClient(t, func(s *grpc.Server) {
+			storev1.RegisterStoreServiceServer(s, rejectingStoreServer{reject: func(record *corev1.Record) (*corev1.RecordRef, error) {
+				// The second record is rate limited once
+				if pushes.Add(1) == 2 { //nolint:mnd
+					return nil, retryError(10 * time.Millisecond) //nolint:mnd
+				}
+
+				return &corev1.RecordRef{Cid: record.GetCid()}, nil
+			}})
+		})
+
+		records := []*corev1.Record{newSizedRecord(1024), newSizedRecord(2048), newSizedRecord(4096)} //nolint:mnd
+
+		refs, err := c.PushBatch(t.Context(), records)
+		if err != nil {
+			t.Fatalf("expected rate limited push to be retried, got %v", err)
+		}
+
+		if len(refs) != len(records) || pushes.Load() != 4 { //nolint:mnd
+			t.Errorf("expected %d refs after 4 pushes, got %d refs after %d pushes", len(records), len(refs), pushes.Load())
+		}
+	})
+
+	t.Run("delays longer than the maximum are not waited for", func(t *testing.T) {
+		calls := &atomic.Int32{}
+		c := newBufconnClient(t, func(s *grpc.Server) {
+			storev1.RegisterQuotaServiceServer(s, retryingQuotaServer{limited: 1, calls: calls})
+		}, WithMaxRetryDelay(time.Millisecond))
+
+		if _, err := c.QuotaUsage(t.Context(), ""); status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("expected rate limited call to fail, got %v", err)
+		}
+	})
+}
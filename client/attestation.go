@@ -0,0 +1,373 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// InTotoStatementType is the type of in-toto v1 statements.
+	InTotoStatementType = "https://in-toto.io/Statement/v1"
+
+	// InTotoPayloadType is the DSSE payload type of in-toto statements.
+	InTotoPayloadType = "application/vnd.in-toto+json"
+
+	// Common predicate types of attestations.
+	PredicateTypeSLSAProvenance = "https://slsa.dev/provenance/v1"
+	PredicateTypeSPDX           = "https://spdx.dev/Document"
+	PredicateTypeCycloneDX      = "https://cyclonedx.org/bom"
+
+	// attestationPredicateTypeKey is the referrer annotation holding the predicate type.
+	attestationPredicateTypeKey = "predicate_type"
+)
+
+// ErrAttestationInvalid is reported for attestations that fail verification.
+var ErrAttestationInvalid = errors.New("invalid attestation")
+
+// Statement is an in-toto v1 statement about a record.
+type Statement struct {
+	Type          string          `json:"_type"`
+	Subject       []Subject       `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate,omitempty"`
+}
+
+// Subject identifies the artifact a statement is about.
+// For records, the name is the record CID and the digest is the digest the CID encodes.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Envelope is a DSSE envelope carrying a signed payload.
+type Envelope struct {
+	PayloadType string              `json:"payloadType"`
+	Payload     []byte              `json:"payload"`
+	Signatures  []EnvelopeSignature `json:"signatures"`
+}
+
+// EnvelopeSignature is a signature of a DSSE envelope.
+type EnvelopeSignature struct {
+	KeyID string `json:"keyid,omitempty"`
+	Sig   []byte `json:"sig"`
+}
+
+// Attestation is a verified attestation attached to a record.
+type Attestation struct {
+	// Statement is the parsed in-toto statement.
+	Statement *Statement
+	// Envelope is the signed envelope the statement was read from.
+	Envelope *Envelope
+	// KeyID is the ID of the key that verified the envelope, see KeyID.
+	KeyID string
+	// CreatedAt is the time the attestation was attached, if known.
+	CreatedAt string
+}
+
+// AttachAttestation wraps the predicate payload in an in-toto statement about the record,
+// signs it as a DSSE envelope and pushes it as an attestation referrer of the record.
+//
+// ECDSA and RSA signers sign the SHA-256 digest of the envelope, ed25519 signers the envelope itself.
+func (c *Client) AttachAttestation(ctx context.Context, ref *corev1.RecordRef, predicateType string, payload []byte, signer crypto.Signer) error {
+	if predicateType == "" {
+		return errors.New("predicate type is required")
+	}
+
+	if !json.Valid(payload) {
+		return errors.New("attestation predicate must be valid JSON")
+	}
+
+	subject, err := recordSubject(ref.GetCid())
+	if err != nil {
+		return err
+	}
+
+	statement, err := json.Marshal(&Statement{
+		Type:          InTotoStatementType,
+		Subject:       []Subject{subject},
+		PredicateType: predicateType,
+		Predicate:     payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal statement: %w", err)
+	}
+
+	envelope, err := signEnvelope(InTotoPayloadType, statement, signer)
+	if err != nil {
+		return err
+	}
+
+	data, err := envelopeToStruct(envelope)
+	if err != nil {
+		return err
+	}
+
+	return c.PushReferrer(ctx, &storev1.PushReferrerRequest{
+		RecordRef: ref,
+		Referrer: &corev1.RecordReferrer{
+			Type:        corev1.AttestationReferrerType,
+			RecordRef:   ref,
+			Annotations: map[string]string{attestationPredicateTypeKey: predicateType},
+			CreatedAt:   time.Now().UTC().Format(time.RFC3339),
+			Data:        data,
+		},
+	})
+}
+
+// GetAttestations lists the attestations of the record and returns the ones with the
+// given predicate type, or all of them if predicateType is empty.
+//
+// Every envelope must be signed by one of the given keys and its statement must be about the record.
+// Attestations that fail verification are left out and reported together
+// via an error wrapping ErrAttestationInvalid, alongside the verified ones.
+func (c *Client) GetAttestations(ctx context.Context, ref *corev1.RecordRef, predicateType string, keys ...crypto.PublicKey) ([]Attestation, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one public key is required to verify attestations")
+	}
+
+	subject, err := recordSubject(ref.GetCid())
+	if err != nil {
+		return nil, err
+	}
+
+	referrerType := corev1.AttestationReferrerType
+
+	resultCh, err := c.PullReferrer(ctx, &storev1.PullReferrerRequest{
+		RecordRef:    ref,
+		ReferrerType: &referrerType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		attestations []Attestation
+		errs         error
+	)
+
+	for response := range resultCh {
+		referrer := response.GetReferrer()
+		if referrer.GetType() != corev1.AttestationReferrerType {
+			continue
+		}
+
+		envelope, statement, err := parseAttestation(referrer)
+		if err != nil {
+			errs = errors.Join(errs, err)
+
+			continue
+		}
+
+		// The unverified statement is only used for filtering, a tampered
+		// predicate type fails verification below if it is selected.
+		if predicateType != "" && statement.PredicateType != predicateType {
+			continue
+		}
+
+		keyID, err := verifyEnvelope(envelope, keys)
+		if err == nil {
+			err = checkStatement(statement, subject)
+		}
+
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("attestation %s: %w", statement.PredicateType, err))
+
+			continue
+		}
+
+		attestations = append(attestations, Attestation{
+			Statement: statement,
+			Envelope:  envelope,
+			KeyID:     keyID,
+			CreatedAt: referrer.GetCreatedAt(),
+		})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list attestations: %w", err)
+	}
+
+	return attestations, errs
+}
+
+// KeyID returns the ID of a public key as recorded in envelope signatures:
+// the hex encoded SHA-256 digest of its PKIX encoding.
+func KeyID(key crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal public key: %w", err)
+	}
+
+	sum := sha256.Sum256(der)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// recordSubject returns the in-toto subject of the record with the given CID.
+func recordSubject(cid string) (Subject, error) {
+	digest, err := corev1.ConvertCIDToDigest(cid)
+	if err != nil {
+		return Subject{}, fmt.Errorf("invalid record CID %q: %w", cid, err)
+	}
+
+	return Subject{
+		Name:   cid,
+		Digest: map[string]string{digest.Algorithm().String(): digest.Encoded()},
+	}, nil
+}
+
+// pae returns the DSSE pre-authentication encoding of the payload.
+func pae(payloadType string, payload []byte) []byte {
+	encoded := "DSSEv1 " + strconv.Itoa(len(payloadType)) + " " + payloadType + " " + strconv.Itoa(len(payload)) + " "
+
+	return append([]byte(encoded), payload...)
+}
+
+func signEnvelope(payloadType string, payload []byte, signer crypto.Signer) (*Envelope, error) {
+	if signer == nil {
+		return nil, errors.New("signer is required")
+	}
+
+	keyID, err := KeyID(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	message := pae(payloadType, payload)
+
+	var sig []byte
+
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		sig, err = signer.Sign(rand.Reader, message, crypto.Hash(0))
+	} else {
+		sum := sha256.Sum256(message)
+		sig, err = signer.Sign(rand.Reader, sum[:], crypto.SHA256)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign attestation: %w", err)
+	}
+
+	return &Envelope{
+		PayloadType: payloadType,
+		Payload:     payload,
+		Signatures:  []EnvelopeSignature{{KeyID: keyID, Sig: sig}},
+	}, nil
+}
+
+// verifyEnvelope checks that the envelope is signed by one of the keys and returns the ID of that key.
+func verifyEnvelope(envelope *Envelope, keys []crypto.PublicKey) (string, error) {
+	if envelope.PayloadType != InTotoPayloadType {
+		return "", fmt.Errorf("%w: unsupported payload type %q", ErrAttestationInvalid, envelope.PayloadType)
+	}
+
+	message := pae(envelope.PayloadType, envelope.Payload)
+	sum := sha256.Sum256(message)
+
+	for _, key := range keys {
+		keyID, err := KeyID(key)
+		if err != nil {
+			return "", err
+		}
+
+		for _, signature := range envelope.Signatures {
+			if signature.KeyID != "" && signature.KeyID != keyID {
+				continue
+			}
+
+			if verifySignature(key, message, sum[:], signature.Sig) {
+				return keyID, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("%w: no valid signature from a trusted key", ErrAttestationInvalid)
+}
+
+func verifySignature(key crypto.PublicKey, message, digest, sig []byte) bool {
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, message, sig)
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest, sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, sig) == nil
+	default:
+		return false
+	}
+}
+
+// checkStatement checks that the statement is an in-toto statement about the subject.
+func checkStatement(statement *Statement, subject Subject) error {
+	if statement.Type != InTotoStatementType {
+		return fmt.Errorf("%w: unsupported statement type %q", ErrAttestationInvalid, statement.Type)
+	}
+
+	for _, candidate := range statement.Subject {
+		if candidate.Name != subject.Name {
+			continue
+		}
+
+		for algorithm, digest := range subject.Digest {
+			if candidate.Digest[algorithm] == digest {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: statement is not about record %s", ErrAttestationInvalid, subject.Name)
+}
+
+func envelopeToStruct(envelope *Envelope) (*structpb.Struct, error) {
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal envelope: %w", err)
+	}
+
+	result := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("failed to convert envelope: %w", err)
+	}
+
+	return result, nil
+}
+
+// parseAttestation reads the envelope of an attestation referrer and the unverified statement it carries.
+func parseAttestation(referrer *corev1.RecordReferrer) (*Envelope, *Statement, error) {
+	data, err := protojson.Marshal(referrer.GetData())
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to read envelope: %w", ErrAttestationInvalid, err)
+	}
+
+	envelope := &Envelope{}
+	if err := json.Unmarshal(data, envelope); err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to parse envelope: %w", ErrAttestationInvalid, err)
+	}
+
+	statement := &Statement{}
+	if err := json.Unmarshal(envelope.Payload, statement); err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to parse statement: %w", ErrAttestationInvalid, err)
+	}
+
+	return envelope, statement, nil
+}
@@ -0,0 +1,249 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// attestationServer stores pushed referrers in addition to serving them.
+type attestationServer struct {
+	referrerServer
+}
+
+func (s attestationServer) PushReferrer(stream storev1.StoreService_PushReferrerServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	cid := req.GetRecordRef().GetCid()
+	s.referrers[cid] = append(s.referrers[cid], req.GetReferrer())
+
+	return stream.Send(&storev1.PushReferrerResponse{Success: true}) //nolint:wrapcheck
+}
+
+// fakeSigner signs with an in-memory key and counts its signatures.
+type fakeSigner struct {
+	crypto.Signer
+
+	signed int
+}
+
+func (s *fakeSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	s.signed++
+
+	return s.Signer.Sign(rand, digest, opts) //nolint:wrapcheck
+}
+
+func newFakeSigner(t *testing.T) *fakeSigner {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	return &fakeSigner{Signer: key}
+}
+
+func newAttestationClient(t *testing.T) (*Client, attestationServer) {
+	t.Helper()
+
+	server := attestationServer{referrerServer{referrers: map[string][]*corev1.RecordReferrer{}}}
+
+	return newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) }), server
+}
+
+func TestAttestations(t *testing.T) {
+	c, server := newAttestationClient(t)
+	signer := newFakeSigner(t)
+
+	record := newBundleTestRecord("attested")
+	ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+	attach := func(t *testing.T, ref *corev1.RecordRef, predicateType, payload string, signer crypto.Signer) {
+		t.Helper()
+
+		if err := c.AttachAttestation(t.Context(), ref, predicateType, []byte(payload), signer); err != nil {
+			t.Fatalf("AttachAttestation() unexpected error: %v", err)
+		}
+	}
+
+	attach(t, ref, PredicateTypeSLSAProvenance, `{"buildDefinition":{"buildType":"https://example.org/build"}}`, signer)
+	attach(t, ref, PredicateTypeSPDX, `{"spdxVersion":"SPDX-2.3"}`, signer)
+	attach(t, ref, PredicateTypeCycloneDX, `{"bomFormat":"CycloneDX"}`, signer)
+
+	if signer.signed != 3 {
+		t.Fatalf("signer used %d times, want 3", signer.signed)
+	}
+
+	t.Run("filters by predicate type", func(t *testing.T) {
+		attestations, err := c.GetAttestations(t.Context(), ref, PredicateTypeSPDX, signer.Public())
+		if err != nil {
+			t.Fatalf("GetAttestations() unexpected error: %v", err)
+		}
+
+		if len(attestations) != 1 {
+			t.Fatalf("GetAttestations() returned %d attestations, want 1", len(attestations))
+		}
+
+		statement := attestations[0].Statement
+		if statement.PredicateType != PredicateTypeSPDX || string(statement.Predicate) != `{"spdxVersion":"SPDX-2.3"}` {
+			t.Errorf("GetAttestations() statement = %+v", statement)
+		}
+
+		if statement.Subject[0].Name != ref.GetCid() {
+			t.Errorf("statement subject = %s, want %s", statement.Subject[0].Name, ref.GetCid())
+		}
+
+		keyID, _ := KeyID(signer.Public())
+		if attestations[0].KeyID != keyID {
+			t.Errorf("attestation verified by %s, want %s", attestations[0].KeyID, keyID)
+		}
+	})
+
+	t.Run("lists all predicate types", func(t *testing.T) {
+		attestations, err := c.GetAttestations(t.Context(), ref, "", signer.Public())
+		if err != nil {
+			t.Fatalf("GetAttestations() unexpected error: %v", err)
+		}
+
+		if len(attestations) != 3 {
+			t.Errorf("GetAttestations() returned %d attestations, want 3", len(attestations))
+		}
+	})
+
+	t.Run("rejects untrusted keys", func(t *testing.T) {
+		attestations, err := c.GetAttestations(t.Context(), ref, PredicateTypeSLSAProvenance, newFakeSigner(t).Public())
+		if !errors.Is(err, ErrAttestationInvalid) {
+			t.Errorf("GetAttestations() error = %v, want ErrAttestationInvalid", err)
+		}
+
+		if len(attestations) != 0 {
+			t.Errorf("GetAttestations() returned %d attestations, want 0", len(attestations))
+		}
+	})
+
+	t.Run("requires keys", func(t *testing.T) {
+		if _, err := c.GetAttestations(t.Context(), ref, ""); err == nil {
+			t.Error("GetAttestations() without keys should fail")
+		}
+	})
+
+	t.Run("detects tampering", func(t *testing.T) {
+		other := newBundleTestRecord("tampered")
+		otherRef := &corev1.RecordRef{Cid: other.GetCid()}
+
+		attach(t, otherRef, PredicateTypeSLSAProvenance, `{"builder":"trusted"}`, signer)
+		attach(t, otherRef, PredicateTypeSPDX, `{"spdxVersion":"SPDX-2.3"}`, signer)
+
+		// Rewrite the predicate of the signed provenance statement
+		tampered := proto.Clone(server.referrers[otherRef.GetCid()][0]).(*corev1.RecordReferrer) //nolint:forcetypeassert
+
+		envelope, statement, err := parseAttestation(tampered)
+		if err != nil {
+			t.Fatalf("parseAttestation() unexpected error: %v", err)
+		}
+
+		statement.Predicate = []byte(`{"builder":"attacker"}`)
+		if envelope.Payload, err = json.Marshal(statement); err != nil {
+			t.Fatalf("failed to marshal statement: %v", err)
+		}
+
+		if tampered.Data, err = envelopeToStruct(envelope); err != nil {
+			t.Fatalf("envelopeToStruct() unexpected error: %v", err)
+		}
+
+		server.referrers[otherRef.GetCid()][0] = tampered
+
+		attestations, err := c.GetAttestations(t.Context(), otherRef, PredicateTypeSLSAProvenance, signer.Public())
+		if !errors.Is(err, ErrAttestationInvalid) {
+			t.Errorf("GetAttestations() error = %v, want ErrAttestationInvalid", err)
+		}
+
+		if len(attestations) != 0 {
+			t.Errorf("GetAttestations() returned tampered attestation: %+v", attestations)
+		}
+
+		// Other attestations of the record are still returned
+		attestations, err = c.GetAttestations(t.Context(), otherRef, PredicateTypeSPDX, signer.Public())
+		if err != nil || len(attestations) != 1 {
+			t.Errorf("GetAttestations() = %d attestations, %v; want 1, nil", len(attestations), err)
+		}
+	})
+
+	t.Run("rejects attestations of other records", func(t *testing.T) {
+		copied := newBundleTestRecord("copied")
+		copiedRef := &corev1.RecordRef{Cid: copied.GetCid()}
+
+		server.referrers[copiedRef.GetCid()] = []*corev1.RecordReferrer{server.referrers[ref.GetCid()][0]}
+
+		_, err := c.GetAttestations(t.Context(), copiedRef, "", signer.Public())
+		if !errors.Is(err, ErrAttestationInvalid) {
+			t.Errorf("GetAttestations() error = %v, want ErrAttestationInvalid", err)
+		}
+	})
+}
+
+func TestAttestationsEd25519(t *testing.T) {
+	c, _ := newAttestationClient(t)
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	ref := &corev1.RecordRef{Cid: newBundleTestRecord("ed25519").GetCid()}
+
+	if err := c.AttachAttestation(t.Context(), ref, PredicateTypeSLSAProvenance, []byte(`{}`), private); err != nil {
+		t.Fatalf("AttachAttestation() unexpected error: %v", err)
+	}
+
+	attestations, err := c.GetAttestations(t.Context(), ref, PredicateTypeSLSAProvenance, public)
+	if err != nil || len(attestations) != 1 {
+		t.Errorf("GetAttestations() = %d attestations, %v; want 1, nil", len(attestations), err)
+	}
+}
+
+func TestAttachAttestationValidation(t *testing.T) {
+	c, _ := newAttestationClient(t)
+	signer := newFakeSigner(t)
+	ref := &corev1.RecordRef{Cid: newBundleTestRecord("invalid").GetCid()}
+
+	tests := map[string]struct {
+		ref           *corev1.RecordRef
+		predicateType string
+		payload       string
+	}{
+		"missing predicate type": {ref: ref, payload: `{}`},
+		"invalid payload":        {ref: ref, predicateType: PredicateTypeSPDX, payload: `not json`},
+		"invalid record CID":     {ref: &corev1.RecordRef{Cid: "invalid"}, predicateType: PredicateTypeSPDX, payload: `{}`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := c.AttachAttestation(t.Context(), tt.ref, tt.predicateType, []byte(tt.payload), signer); err == nil {
+				t.Error("AttachAttestation() should fail")
+			}
+		})
+	}
+
+	if signer.signed != 0 {
+		t.Errorf("signer used %d times for invalid attestations, want 0", signer.signed)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/client/streaming"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrBundleMismatch is reported when a bundle or one of its members does not match its CID.
+var ErrBundleMismatch = errors.New("content does not match the bundle")
+
+// BundleResult is a pulled bundle together with its member records.
+type BundleResult struct {
+	// Bundle is the bundle manifest, verified against the requested CID.
+	Bundle *corev1.RecordBundle
+	// Members are the outcomes of pulling the member records, in bundle order.
+	Members []*BundleMemberResult
+}
+
+// BundleMemberResult is the outcome of pulling a single bundle member.
+type BundleMemberResult struct {
+	// Member is the bundle member.
+	Member *corev1.BundleMember
+	// Record is the pulled record, or nil if the pull failed.
+	Record *corev1.Record
+	// Error is the pull failure, or nil on success.
+	// Missing records are reported with ErrNotFound and records that
+	// do not match the member CID with ErrBundleMismatch.
+	Error error
+}
+
+// Err returns the errors of all members that could not be pulled, or nil if the bundle is complete.
+func (r *BundleResult) Err() error {
+	var errs error
+
+	for _, member := range r.Members {
+		if member.Error != nil {
+			errs = errors.Join(errs, fmt.Errorf("bundle member %s: %w", member.Member.GetCid(), member.Error))
+		}
+	}
+
+	return errs
+}
+
+// PushBundle stores the bundle manifest and returns its reference.
+// All member records must have been pushed before.
+//
+// The bundle is stored under its CID like a record, so it can be signed
+// and verified with Sign and Verify to approve the exact combination of its members.
+func (c *Client) PushBundle(ctx context.Context, bundle *corev1.RecordBundle) (*corev1.RecordRef, error) {
+	ref, err := c.StoreServiceClient.PushBundle(ctx, bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to push bundle: %w", err)
+	}
+
+	return ref, nil
+}
+
+// PullBundle pulls the bundle manifest and then its member records via PullStream.
+// The manifest and every member record are verified against their CIDs.
+//
+// Members that could not be pulled or verified are reported via BundleMemberResult.Error
+// without failing the pull, see BundleResult.Err. An error is returned if the manifest
+// itself could not be pulled or verified; ErrNotFound if the bundle does not exist.
+func (c *Client) PullBundle(ctx context.Context, ref *corev1.RecordRef) (*BundleResult, error) {
+	bundle, err := c.StoreServiceClient.PullBundle(ctx, ref)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+		}
+
+		return nil, fmt.Errorf("failed to pull bundle %s: %w", ref.GetCid(), err)
+	}
+
+	if bundle.GetCid() != ref.GetCid() {
+		return nil, fmt.Errorf("%w: bundle %s", ErrBundleMismatch, ref.GetCid())
+	}
+
+	result := &BundleResult{Bundle: bundle}
+	refs := make([]*corev1.RecordRef, 0, len(bundle.GetMembers()))
+
+	for _, member := range bundle.GetMembers() {
+		result.Members = append(result.Members, &BundleMemberResult{Member: member})
+		refs = append(refs, &corev1.RecordRef{Cid: member.GetCid()})
+	}
+
+	stream, err := c.PullStream(ctx, streaming.SliceToChan(ctx, refs))
+	if err != nil {
+		return nil, err
+	}
+
+	var streamErr error
+
+	for {
+		select {
+		case err := <-stream.ErrCh():
+			streamErr = errors.Join(streamErr, err)
+		case resp := <-stream.ResCh():
+			member := result.Members[resp.Index]
+
+			switch {
+			case resp.Error != nil:
+				member.Error = resp.Error
+			case resp.Record.GetCid() != member.Member.GetCid():
+				member.Error = fmt.Errorf("%w: member %s", ErrBundleMismatch, member.Member.GetCid())
+			default:
+				member.Record = resp.Record
+			}
+		case <-stream.DoneCh():
+			if streamErr != nil {
+				return nil, fmt.Errorf("failed to pull bundle members: %w", streamErr)
+			}
+
+			return result, nil
+		}
+	}
+}
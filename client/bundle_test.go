@@ -0,0 +1,175 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bundleServer serves bundles and records from fixed sets.
+type bundleServer struct {
+	pullServer
+
+	bundles map[string]*corev1.RecordBundle
+}
+
+func (s bundleServer) PushBundle(_ context.Context, bundle *corev1.RecordBundle) (*corev1.RecordRef, error) {
+	for _, member := range bundle.GetMembers() {
+		if _, ok := s.records[member.GetCid()]; !ok {
+			return nil, status.Errorf(codes.FailedPrecondition, "bundle member not found: %s", member.GetCid())
+		}
+	}
+
+	s.bundles[bundle.GetCid()] = bundle
+
+	return &corev1.RecordRef{Cid: bundle.GetCid()}, nil
+}
+
+func (s bundleServer) PullBundle(_ context.Context, ref *corev1.RecordRef) (*corev1.RecordBundle, error) {
+	bundle, ok := s.bundles[ref.GetCid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "bundle not found: %s", ref.GetCid())
+	}
+
+	return bundle, nil
+}
+
+func newBundleTestRecord(name string) *corev1.Record {
+	return corev1.New(&typesv1alpha1.Record{Name: name, Version: "v1.0.0", SchemaVersion: "0.7.0"})
+}
+
+func TestPushPullBundle(t *testing.T) {
+	orchestrator := newBundleTestRecord("orchestrator")
+	worker := newBundleTestRecord("worker")
+	evaluator := newBundleTestRecord("evaluator")
+
+	server := bundleServer{
+		pullServer: pullServer{records: map[string]*corev1.Record{
+			orchestrator.GetCid(): orchestrator,
+			worker.GetCid():       worker,
+			evaluator.GetCid():    evaluator,
+		}},
+		bundles: map[string]*corev1.RecordBundle{},
+	}
+
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterStoreServiceServer(s, server)
+	})
+
+	refs := []*corev1.RecordRef{{Cid: orchestrator.GetCid()}, {Cid: worker.GetCid()}, {Cid: evaluator.GetCid()}}
+
+	bundle, err := corev1.BuildBundle(refs, map[string]string{orchestrator.GetCid(): "orchestrator"})
+	if err != nil {
+		t.Fatalf("BuildBundle() unexpected error: %v", err)
+	}
+
+	ref, err := c.PushBundle(t.Context(), bundle)
+	if err != nil {
+		t.Fatalf("PushBundle() unexpected error: %v", err)
+	}
+
+	t.Run("complete bundle", func(t *testing.T) {
+		result, err := c.PullBundle(t.Context(), ref)
+		if err != nil {
+			t.Fatalf("PullBundle() unexpected error: %v", err)
+		}
+
+		if err := result.Err(); err != nil {
+			t.Fatalf("expected complete bundle, got %v", err)
+		}
+
+		for _, member := range result.Members {
+			if member.Record.GetCid() != member.Member.GetCid() {
+				t.Errorf("expected record %s, got %s", member.Member.GetCid(), member.Record.GetCid())
+			}
+		}
+	})
+
+	t.Run("deleted member is reported per member", func(t *testing.T) {
+		delete(server.records, worker.GetCid())
+		t.Cleanup(func() { server.records[worker.GetCid()] = worker })
+
+		result, err := c.PullBundle(t.Context(), ref)
+		if err != nil {
+			t.Fatalf("PullBundle() unexpected error: %v", err)
+		}
+
+		for _, member := range result.Members {
+			switch member.Member.GetCid() {
+			case worker.GetCid():
+				if !errors.Is(member.Error, ErrNotFound) || member.Record != nil {
+					t.Errorf("expected deleted member to be reported with ErrNotFound, got %v", member.Error)
+				}
+			default:
+				if member.Error != nil || member.Record == nil {
+					t.Errorf("expected member %s to be pulled, got %v", member.Member.GetCid(), member.Error)
+				}
+			}
+		}
+
+		if !errors.Is(result.Err(), ErrNotFound) {
+			t.Errorf("expected incomplete bundle, got %v", result.Err())
+		}
+	})
+
+	t.Run("tampered member fails verification", func(t *testing.T) {
+		server.records[evaluator.GetCid()] = newBundleTestRecord("impostor")
+		t.Cleanup(func() { server.records[evaluator.GetCid()] = evaluator })
+
+		result, err := c.PullBundle(t.Context(), ref)
+		if err != nil {
+			t.Fatalf("PullBundle() unexpected error: %v", err)
+		}
+
+		if !errors.Is(result.Err(), ErrBundleMismatch) {
+			t.Errorf("expected ErrBundleMismatch, got %v", result.Err())
+		}
+
+		for _, member := range result.Members {
+			if member.Member.GetCid() == evaluator.GetCid() && member.Record != nil {
+				t.Errorf("tampered member must not be returned")
+			}
+		}
+	})
+
+	t.Run("tampered manifest fails verification", func(t *testing.T) {
+		tampered, err := corev1.BuildBundle(refs[:2], nil)
+		if err != nil {
+			t.Fatalf("BuildBundle() unexpected error: %v", err)
+		}
+
+		server.bundles[ref.GetCid()] = tampered
+		t.Cleanup(func() { server.bundles[ref.GetCid()] = bundle })
+
+		if _, err := c.PullBundle(t.Context(), ref); !errors.Is(err, ErrBundleMismatch) {
+			t.Errorf("expected ErrBundleMismatch, got %v", err)
+		}
+	})
+
+	t.Run("missing bundle", func(t *testing.T) {
+		if _, err := c.PullBundle(t.Context(), refs[0]); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("bundle with missing member is rejected", func(t *testing.T) {
+		missing, err := corev1.BuildBundle([]*corev1.RecordRef{{Cid: newBundleTestRecord("missing").GetCid()}}, nil)
+		if err != nil {
+			t.Fatalf("BuildBundle() unexpected error: %v", err)
+		}
+
+		if _, err := c.PushBundle(t.Context(), missing); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected FailedPrecondition, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,345 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultLookupCacheTTL is how long record metadata is served from cache.
+// Metadata is cached only briefly, as tags can change while records are immutable.
+const DefaultLookupCacheTTL = 30 * time.Second
+
+// Cache stores canonical record bytes by CID.
+// Records are content-addressed and immutable, so cached entries never become stale,
+// but entries of deleted records are evicted once the deletion is observed.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the canonical bytes of the record with the given CID.
+	Get(cid string) ([]byte, bool)
+	// Set stores the canonical bytes of the record with the given CID.
+	// Implementations account for the size of data and may evict other entries.
+	Set(cid string, data []byte)
+	// Delete evicts the record with the given CID.
+	Delete(cid string)
+}
+
+// LRUCache is a Cache evicting the least recently used records
+// once the total size of cached records exceeds its budget.
+type LRUCache struct {
+	maxBytes int64
+
+	mu      sync.Mutex
+	size    int64
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	cid  string
+	data []byte
+}
+
+var _ Cache = (*LRUCache)(nil)
+
+// NewLRUCache creates a cache holding at most maxBytes of canonical record bytes.
+// Records larger than the budget are not cached.
+func NewLRUCache(maxBytes int64) *LRUCache {
+	return &LRUCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached record bytes and marks the record as recently used.
+func (c *LRUCache) Get(cid string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[cid]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+
+	entry, _ := elem.Value.(*lruEntry)
+
+	return entry.data, true
+}
+
+// Set caches the record bytes, evicting the least recently used records to stay within budget.
+func (c *LRUCache) Set(cid string, data []byte) {
+	if int64(len(data)) > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[cid]; ok {
+		c.remove(elem)
+	}
+
+	c.entries[cid] = c.order.PushFront(&lruEntry{cid: cid, data: data})
+	c.size += int64(len(data))
+
+	for c.size > c.maxBytes {
+		c.remove(c.order.Back())
+	}
+}
+
+// Delete evicts the record.
+func (c *LRUCache) Delete(cid string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[cid]; ok {
+		c.remove(elem)
+	}
+}
+
+// Size returns the total size of cached records in bytes.
+func (c *LRUCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.size
+}
+
+// Len returns the number of cached records.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+func (c *LRUCache) remove(elem *list.Element) {
+	entry, _ := c.order.Remove(elem).(*lruEntry)
+
+	delete(c.entries, entry.cid)
+	c.size -= int64(len(entry.data))
+}
+
+// CacheStats reports how many record references were served from the client cache.
+type CacheStats struct {
+	// Hits is the number of records served from cache.
+	Hits uint64
+	// Misses is the number of cacheable records fetched from the server.
+	Misses uint64
+	// LookupHits is the number of record metadata served from cache.
+	LookupHits uint64
+	// LookupMisses is the number of cacheable record metadata fetched from the server.
+	LookupMisses uint64
+}
+
+// recordCache serves pulled records and looked up metadata from cache.
+type recordCache struct {
+	records   Cache
+	lookupTTL time.Duration
+	metrics   *clientMetrics
+
+	mu        sync.Mutex
+	metas     map[string]lookupEntry
+	nextPrune time.Time
+
+	hits, misses             atomic.Uint64
+	lookupHits, lookupMisses atomic.Uint64
+}
+
+type lookupEntry struct {
+	meta    *corev1.RecordMeta
+	expires time.Time
+}
+
+func newRecordCache(records Cache, lookupTTL time.Duration, metrics *clientMetrics) *recordCache {
+	return &recordCache{
+		records:   records,
+		lookupTTL: lookupTTL,
+		metrics:   metrics,
+		metas:     make(map[string]lookupEntry),
+	}
+}
+
+// cacheKey returns the CID of a reference, or false if the reference cannot be cached,
+// e.g. because it is a tag that can be re-pointed.
+func cacheKey(ref *corev1.RecordRef) (string, bool) {
+	cid := ref.GetCid()
+
+	return cid, corev1.IsValidCID(cid)
+}
+
+// getRecord returns a cached record for the reference.
+func (c *recordCache) getRecord(ref *corev1.RecordRef) (*corev1.Record, bool) {
+	cid, ok := cacheKey(ref)
+	if !ok {
+		return nil, false
+	}
+
+	data, ok := c.records.Get(cid)
+	if ok {
+		// Corrupted entries are dropped and pulled again
+		record, err := corev1.UnmarshalRecord(data)
+		if err == nil {
+			c.hits.Add(1)
+			c.metrics.cacheResult(cacheRecords, true)
+
+			return record, true
+		}
+
+		c.records.Delete(cid)
+	}
+
+	c.misses.Add(1)
+	c.metrics.cacheResult(cacheRecords, false)
+
+	return nil, false
+}
+
+// putRecord caches a record pulled for the reference.
+// Records are cached only if their CID matches the reference,
+// and missing records are evicted.
+func (c *recordCache) putRecord(ref *corev1.RecordRef, record *corev1.Record) {
+	cid, ok := cacheKey(ref)
+	if !ok {
+		return
+	}
+
+	if record.GetError() != nil {
+		if isNotFound(record.GetError()) && matchesCID(record.GetError().GetCid(), cid) {
+			c.evict(cid)
+		}
+
+		return
+	}
+
+	// Redacted records are specific to the caller and do not match their CID
+	if record.GetRedacted() {
+		return
+	}
+
+	if !record.MatchesCid(cid) {
+		logger.Warn("Not caching record with unexpected CID", "expected", cid, "actual", record.GetCid())
+
+		return
+	}
+
+	data, err := record.Marshal()
+	if err != nil {
+		return
+	}
+
+	c.records.Set(cid, data)
+}
+
+// getMeta returns cached record metadata for the reference, if not expired.
+func (c *recordCache) getMeta(ref *corev1.RecordRef) (*corev1.RecordMeta, bool) {
+	cid, ok := cacheKey(ref)
+	if !ok || c.lookupTTL <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.metas[cid]
+	c.mu.Unlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		c.lookupHits.Add(1)
+		c.metrics.cacheResult(cacheLookups, true)
+
+		return proto.Clone(entry.meta).(*corev1.RecordMeta), true //nolint:forcetypeassert
+	}
+
+	c.lookupMisses.Add(1)
+	c.metrics.cacheResult(cacheLookups, false)
+
+	return nil, false
+}
+
+// putMeta caches record metadata looked up for the reference.
+// Missing records are evicted.
+func (c *recordCache) putMeta(ref *corev1.RecordRef, meta *corev1.RecordMeta) {
+	cid, ok := cacheKey(ref)
+	if !ok {
+		return
+	}
+
+	// The response may answer another reference if the server answers out of order
+	if !matchesCID(meta.GetCid(), cid) {
+		return
+	}
+
+	if meta.GetError() != nil {
+		if isNotFound(meta.GetError()) {
+			c.evict(cid)
+		}
+
+		return
+	}
+
+	if c.lookupTTL <= 0 {
+		return
+	}
+
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Periodically drop expired entries, so that metadata of records
+	// that are not looked up again is released
+	if now.After(c.nextPrune) {
+		for key, entry := range c.metas {
+			if now.After(entry.expires) {
+				delete(c.metas, key)
+			}
+		}
+
+		c.nextPrune = now.Add(c.lookupTTL)
+	}
+
+	c.metas[cid] = lookupEntry{
+		meta:    proto.Clone(meta).(*corev1.RecordMeta), //nolint:forcetypeassert
+		expires: now.Add(c.lookupTTL),
+	}
+}
+
+// matchesCID reports whether a CID echoed in a response matches the reference CID.
+// Responses of servers that do not echo CIDs are assumed to match.
+func matchesCID(echoed, cid string) bool {
+	return echoed == "" || echoed == cid
+}
+
+// evict removes the record and its metadata from cache, e.g. after it was deleted.
+func (c *recordCache) evict(cid string) {
+	c.records.Delete(cid)
+
+	c.mu.Lock()
+	delete(c.metas, cid)
+	c.mu.Unlock()
+}
+
+// stats returns the cache hit and miss counters.
+func (c *recordCache) stats() CacheStats {
+	return CacheStats{
+		Hits:         c.hits.Load(),
+		Misses:       c.misses.Load(),
+		LookupHits:   c.lookupHits.Load(),
+		LookupMisses: c.lookupMisses.Load(),
+	}
+}
+
+// isNotFound reports whether the server reported the record as missing.
+func isNotFound(recordErr *corev1.RecordError) bool {
+	return codes.Code(recordErr.GetCode()) == codes.NotFound
+}
@@ -0,0 +1,116 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/client/streaming"
+)
+
+// errStreamClosed is returned when sending on a cached stream whose receiving side has ended.
+var errStreamClosed = errors.New("stream closed")
+
+// cachedStream wraps a bidirectional stream returning one response per record reference,
+// serving references from cache instead of sending them to the server.
+// Cached responses are returned at the position their reference was sent,
+// other responses in the order the server answers them.
+// Send and CloseSend must be called from one goroutine and Recv from another.
+type cachedStream[OutT any] struct {
+	streaming.BidiStream[corev1.RecordRef, OutT]
+
+	ctx context.Context //nolint:containedctx
+	get func(*corev1.RecordRef) (*OutT, bool)
+	put func(*corev1.RecordRef, *OutT)
+
+	// pending holds sent references in order, with their response if it was cached
+	pending chan pendingRef[OutT]
+
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+type pendingRef[OutT any] struct {
+	ref    *corev1.RecordRef
+	cached *OutT
+}
+
+// cachedStreamBuffer bounds how far sending can run ahead of receiving.
+const cachedStreamBuffer = 256
+
+func newCachedStream[OutT any](
+	ctx context.Context,
+	stream streaming.BidiStream[corev1.RecordRef, OutT],
+	get func(*corev1.RecordRef) (*OutT, bool),
+	put func(*corev1.RecordRef, *OutT),
+) *cachedStream[OutT] {
+	return &cachedStream[OutT]{
+		BidiStream: stream,
+		ctx:        ctx,
+		get:        get,
+		put:        put,
+		pending:    make(chan pendingRef[OutT], cachedStreamBuffer),
+		done:       make(chan struct{}),
+	}
+}
+
+func (s *cachedStream[OutT]) Send(ref *corev1.RecordRef) error {
+	entry := pendingRef[OutT]{ref: ref}
+
+	if cached, ok := s.get(ref); ok {
+		entry.cached = cached
+	} else if err := s.BidiStream.Send(ref); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	select {
+	case s.pending <- entry:
+		return nil
+	case <-s.done:
+		return errStreamClosed
+	case <-s.ctx.Done():
+		return s.ctx.Err() //nolint:wrapcheck
+	}
+}
+
+func (s *cachedStream[OutT]) CloseSend() error {
+	close(s.pending)
+
+	return s.BidiStream.CloseSend() //nolint:wrapcheck
+}
+
+func (s *cachedStream[OutT]) Recv() (*OutT, error) {
+	entry, ok := <-s.pending
+	if !ok {
+		// All responses were received, wait for the server to end the stream
+		out, err := s.BidiStream.Recv()
+		if err != nil {
+			s.close()
+		}
+
+		return out, err //nolint:wrapcheck
+	}
+
+	if entry.cached != nil {
+		return entry.cached, nil
+	}
+
+	out, err := s.BidiStream.Recv()
+	if err != nil {
+		s.close()
+
+		return nil, err //nolint:wrapcheck
+	}
+
+	s.put(entry.ref, out)
+
+	return out, nil
+}
+
+func (s *cachedStream[OutT]) close() {
+	s.doneOnce.Do(func() { close(s.done) })
+}
@@ -0,0 +1,429 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client/streaming"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// countingStoreServer serves records and counts the references sent to it.
+// Records can be removed out of band to simulate deletion by another client.
+type countingStoreServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	delay time.Duration
+
+	mu      sync.Mutex
+	records map[string]*corev1.Record
+	pulls   map[string]int
+	lookups map[string]int
+}
+
+func newCountingStoreServer(records ...*corev1.Record) *countingStoreServer {
+	s := &countingStoreServer{
+		records: map[string]*corev1.Record{},
+		pulls:   map[string]int{},
+		lookups: map[string]int{},
+	}
+
+	for _, record := range records {
+		s.records[record.GetCid()] = record
+	}
+
+	return s
+}
+
+func (s *countingStoreServer) Pull(stream storev1.StoreService_PullServer) error {
+	for {
+		ref, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		time.Sleep(s.delay)
+
+		s.mu.Lock()
+		s.pulls[ref.GetCid()]++
+		record, ok := s.records[ref.GetCid()]
+		s.mu.Unlock()
+
+		if !ok {
+			record = &corev1.Record{
+				Error: &corev1.RecordError{Code: uint32(codes.NotFound), Message: "record not found: " + ref.GetCid()},
+			}
+		}
+
+		if err := stream.Send(record); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func (s *countingStoreServer) Lookup(stream storev1.StoreService_LookupServer) error {
+	for {
+		ref, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		s.mu.Lock()
+		s.lookups[ref.GetCid()]++
+		_, ok := s.records[ref.GetCid()]
+		s.mu.Unlock()
+
+		meta := &corev1.RecordMeta{Cid: ref.GetCid()}
+		if !ok {
+			meta = &corev1.RecordMeta{
+				Error: &corev1.RecordError{Code: uint32(codes.NotFound), Message: "record not found: " + ref.GetCid()},
+			}
+		}
+
+		if err := stream.Send(meta); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func (s *countingStoreServer) DeleteWithAck(stream storev1.StoreService_DeleteWithAckServer) error {
+	for {
+		ref, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		s.remove(ref.GetCid())
+
+		if err := stream.Send(&storev1.DeleteResponse{RecordRef: ref}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func (s *countingStoreServer) remove(cid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, cid)
+}
+
+func (s *countingStoreServer) pullCount(cid string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.pulls[cid]
+}
+
+func (s *countingStoreServer) lookupCount(cid string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lookups[cid]
+}
+
+func newCacheTestRecord(name string) *corev1.Record {
+	return corev1.New(&typesv1alpha1.Record{
+		Name:          name,
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+}
+
+func newCachingClient(t *testing.T, server *countingStoreServer, opts ...Option) *Client {
+	t.Helper()
+
+	opts = append([]Option{WithCache(NewLRUCache(1 << 20))}, opts...) //nolint:mnd
+
+	return newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) }, opts...)
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := NewLRUCache(10) //nolint:mnd
+
+	cache.Set("a", []byte("aaaa"))
+	cache.Set("b", []byte("bbbb"))
+
+	// Reading a marks it as recently used, so b is evicted first
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+
+	cache.Set("c", []byte("cccc"))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+
+	if cache.Size() != 8 || cache.Len() != 2 {
+		t.Errorf("expected 2 records of 8 bytes, got %d records of %d bytes", cache.Len(), cache.Size())
+	}
+
+	// Records larger than the budget are not cached
+	cache.Set("d", []byte("ddddddddddd"))
+
+	if _, ok := cache.Get("d"); ok {
+		t.Error("expected oversized record not to be cached")
+	}
+
+	cache.Delete("a")
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("expected a to be deleted")
+	}
+
+	if cache.Size() != 4 {
+		t.Errorf("expected 4 bytes after delete, got %d", cache.Size())
+	}
+}
+
+func TestPullCache(t *testing.T) {
+	record := newCacheTestRecord("cached-agent")
+	cid := record.GetCid()
+
+	server := newCountingStoreServer(record)
+	c := newCachingClient(t, server)
+
+	for range 3 {
+		pulled, err := c.Pull(t.Context(), &corev1.RecordRef{Cid: cid})
+		if err != nil {
+			t.Fatalf("Pull() unexpected error: %v", err)
+		}
+
+		if pulled.GetCid() != cid {
+			t.Errorf("expected record %s, got %s", cid, pulled.GetCid())
+		}
+	}
+
+	if got := server.pullCount(cid); got != 1 {
+		t.Errorf("expected 1 upstream pull, got %d", got)
+	}
+
+	if stats := c.CacheStats(); stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("expected 2 hits and 1 miss, got %+v", stats)
+	}
+}
+
+func TestPullStreamCache(t *testing.T) {
+	cached := newCacheTestRecord("cached-agent")
+	uncached := newCacheTestRecord("uncached-agent")
+
+	server := newCountingStoreServer(cached, uncached)
+	c := newCachingClient(t, server)
+
+	if _, err := c.Pull(t.Context(), &corev1.RecordRef{Cid: cached.GetCid()}); err != nil {
+		t.Fatalf("Pull() unexpected error: %v", err)
+	}
+
+	refs := []*corev1.RecordRef{
+		{Cid: uncached.GetCid()},
+		{Cid: cached.GetCid()},
+		{Cid: "missing"},
+		{Cid: cached.GetCid()},
+	}
+
+	result, err := c.PullStream(t.Context(), streaming.SliceToChan(t.Context(), refs))
+	if err != nil {
+		t.Fatalf("PullStream() unexpected error: %v", err)
+	}
+
+	var results []*PullResult
+
+	for done := false; !done; {
+		select {
+		case err := <-result.ErrCh():
+			t.Fatalf("unexpected stream error: %v", err)
+		case res := <-result.ResCh():
+			results = append(results, res)
+		case <-result.DoneCh():
+			done = true
+		}
+	}
+
+	if len(results) != len(refs) {
+		t.Fatalf("expected %d results, got %d", len(refs), len(results))
+	}
+
+	// Cached and pulled records are returned in input order
+	for i, res := range results {
+		if res.Index != i {
+			t.Errorf("expected result %d to have index %d", i, res.Index)
+		}
+
+		if refs[i].GetCid() == "missing" {
+			if !errors.Is(res.Error, ErrNotFound) {
+				t.Errorf("expected ErrNotFound at index %d, got %v", i, res.Error)
+			}
+
+			continue
+		}
+
+		if res.Error != nil || res.Record.GetCid() != refs[i].GetCid() {
+			t.Errorf("expected record %s at index %d, got %v (error %v)", refs[i].GetCid(), i, res.Record.GetCid(), res.Error)
+		}
+	}
+
+	if got := server.pullCount(cached.GetCid()); got != 1 {
+		t.Errorf("expected cached record to be pulled once, got %d", got)
+	}
+}
+
+func TestPullSingleflight(t *testing.T) {
+	record := newCacheTestRecord("popular-agent")
+	cid := record.GetCid()
+
+	server := newCountingStoreServer(record)
+	server.delay = 200 * time.Millisecond //nolint:mnd
+
+	c := newCachingClient(t, server)
+
+	const pullers = 20
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, pullers)
+
+	for range pullers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			pulled, err := c.Pull(t.Context(), &corev1.RecordRef{Cid: cid})
+			if err == nil && pulled.GetCid() != cid {
+				err = errors.New("unexpected record " + pulled.GetCid())
+			}
+
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Pull() unexpected error: %v", err)
+		}
+	}
+
+	if got := server.pullCount(cid); got != 1 {
+		t.Errorf("expected parallel pulls to share 1 upstream pull, got %d", got)
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	t.Run("delete", func(t *testing.T) {
+		record := newCacheTestRecord("deleted-agent")
+		ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+		server := newCountingStoreServer(record)
+		c := newCachingClient(t, server)
+
+		if _, err := c.Pull(t.Context(), ref); err != nil {
+			t.Fatalf("Pull() unexpected error: %v", err)
+		}
+
+		if err := c.Delete(t.Context(), ref); err != nil {
+			t.Fatalf("Delete() unexpected error: %v", err)
+		}
+
+		if _, err := c.Pull(t.Context(), ref); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound after delete, got %v", err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		record := newCacheTestRecord("removed-agent")
+		ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+		server := newCountingStoreServer(record)
+		c := newCachingClient(t, server, WithLookupCacheTTL(0))
+
+		if _, err := c.Pull(t.Context(), ref); err != nil {
+			t.Fatalf("Pull() unexpected error: %v", err)
+		}
+
+		// Deleted by another client, observed by a lookup
+		server.remove(record.GetCid())
+
+		if _, err := c.Lookup(t.Context(), ref); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound from lookup, got %v", err)
+		}
+
+		if _, err := c.Pull(t.Context(), ref); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound after eviction, got %v", err)
+		}
+	})
+}
+
+func TestLookupCache(t *testing.T) {
+	record := newCacheTestRecord("looked-up-agent")
+	ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+	t.Run("ttl", func(t *testing.T) {
+		server := newCountingStoreServer(record)
+		c := newCachingClient(t, server, WithLookupCacheTTL(100*time.Millisecond)) //nolint:mnd
+
+		for range 2 {
+			if _, err := c.Lookup(t.Context(), ref); err != nil {
+				t.Fatalf("Lookup() unexpected error: %v", err)
+			}
+		}
+
+		if got := server.lookupCount(ref.GetCid()); got != 1 {
+			t.Errorf("expected 1 upstream lookup, got %d", got)
+		}
+
+		time.Sleep(150 * time.Millisecond) //nolint:mnd
+
+		if _, err := c.Lookup(t.Context(), ref); err != nil {
+			t.Fatalf("Lookup() unexpected error: %v", err)
+		}
+
+		if got := server.lookupCount(ref.GetCid()); got != 2 { //nolint:mnd
+			t.Errorf("expected expired metadata to be looked up again, got %d lookups", got)
+		}
+
+		if stats := c.CacheStats(); stats.LookupHits != 1 || stats.LookupMisses != 2 {
+			t.Errorf("expected 1 lookup hit and 2 misses, got %+v", stats)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		server := newCountingStoreServer(record)
+		c := newCachingClient(t, server, WithLookupCacheTTL(0))
+
+		for range 2 {
+			if _, err := c.Lookup(t.Context(), ref); err != nil {
+				t.Fatalf("Lookup() unexpected error: %v", err)
+			}
+		}
+
+		if got := server.lookupCount(ref.GetCid()); got != 2 { //nolint:mnd
+			t.Errorf("expected 2 upstream lookups, got %d", got)
+		}
+	})
+}
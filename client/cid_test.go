@@ -0,0 +1,85 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+)
+
+// cidServer acknowledges pushes with the CIDs calculated with its CID options, like the server does.
+type cidServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	opts corev1.CIDOptions
+}
+
+func (s cidServer) Push(stream storev1.StoreService_PushServer) error {
+	for {
+		record, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		record.SetCIDOptions(s.opts)
+
+		if err := stream.Send(&corev1.RecordRef{Cid: record.GetCid()}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func TestCIDOptions(t *testing.T) {
+	sha512, err := corev1.ParseCIDOptions("sha2-512")
+	if err != nil {
+		t.Fatalf("failed to parse CID options: %v", err)
+	}
+
+	register := func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, cidServer{opts: sha512}) }
+
+	t.Run("push", func(t *testing.T) {
+		c := newBufconnClient(t, register, WithCIDOptions(sha512))
+		record := newSizedRecord(1024) //nolint:mnd
+
+		ref, err := c.Push(t.Context(), record)
+		if err != nil {
+			t.Fatalf("failed to push record: %v", err)
+		}
+
+		if !record.MatchesCid(ref.GetCid()) {
+			t.Errorf("expected record to match CID %s, got %s", ref.GetCid(), record.GetCid())
+		}
+	})
+
+	t.Run("push batch from config", func(t *testing.T) {
+		c := newBufconnClient(t, register, WithConfig(&Config{ServerAddress: "passthrough:///bufnet", CIDHash: "sha2-512"}))
+		records := []*corev1.Record{newSizedRecord(1024), newSizedRecord(2048)} //nolint:mnd
+
+		refs, err := c.PushBatch(t.Context(), records)
+		if err != nil {
+			t.Fatalf("failed to push records: %v", err)
+		}
+
+		for i, ref := range refs {
+			if opts, err := corev1.CIDOptionsOf(ref.GetCid()); err != nil || opts != sha512 {
+				t.Errorf("expected record %d to be pushed with %s CID, got %s (%v)", i, sha512, opts, err)
+			}
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		if _, err := New(WithConfig(&Config{CIDHash: "md4"})); err == nil {
+			t.Error("expected unsupported CID hash to be rejected")
+		}
+	})
+}
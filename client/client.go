@@ -5,14 +5,19 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	searchv1 "github.com/agntcy/dir/api/search/v1"
 	signv1 "github.com/agntcy/dir/api/sign/v1"
 	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/spiffe/go-spiffe/v2/workloadapi"
+	"golang.org/x/sync/singleflight"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type Client struct {
@@ -20,10 +25,43 @@ type Client struct {
 	routingv1.RoutingServiceClient
 	searchv1.SearchServiceClient
 	storev1.SyncServiceClient
+	storev1.QuotaServiceClient
+	storev1.StatsServiceClient
+	storev1.AdminServiceClient
 	signv1.SignServiceClient
 
+	healthClient healthpb.HealthClient
+
 	config     *Config
+	pool       *endpointPool
 	authClient *workloadapi.Client
+
+	cache *recordCache
+	pulls singleflight.Group
+
+	pullPolicy *pullPolicy
+
+	verifyContent bool
+
+	newRequestID func() string
+
+	hooks *Hooks
+
+	encryption KeyProvider
+
+	// cidOptions are the options the CIDs of pushed records are calculated with, if set.
+	cidOptions *corev1.CIDOptions
+
+	// maxRetryDelay is the longest delay requested by the server that push batches are retried after.
+	maxRetryDelay time.Duration
+
+	// staleTimeout fails quiet List and WatchStore streams with ErrStreamStale, disabled if zero.
+	staleTimeout time.Duration
+
+	sharedPush *sharedPushStream
+
+	// local serves the records of a client created with NewLocal.
+	local *localServer
 }
 
 func New(opts ...Option) (*Client, error) {
@@ -38,29 +76,90 @@ func New(opts ...Option) (*Client, error) {
 		}
 	}
 
-	// Create client
-	client, err := grpc.NewClient(options.config.ServerAddress, options.authOpts...)
+	callOpts, err := options.callOptions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load options: %w", err)
+	}
+
+	cidOptions, err := options.cidOpts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load options: %w", err)
+	}
+
+	// Collect dial options
+	dialOpts := append([]grpc.DialOption{grpc.WithKeepaliveParams(options.keepaliveParams()), grpc.WithDefaultCallOptions(callOpts...)}, options.authOpts...)
+	dialOpts = append(dialOpts, options.dialOpts...)
+	dialOpts = append(dialOpts, options.interceptorDialOptions()...)
+
+	// Create a connection to each server endpoint
+	client, err := newEndpointPool(options.serverAddresses(), dialOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gRPC client: %w", err)
 	}
 
-	return &Client{
+	c := &Client{
 		StoreServiceClient:   storev1.NewStoreServiceClient(client),
 		RoutingServiceClient: routingv1.NewRoutingServiceClient(client),
 		SearchServiceClient:  searchv1.NewSearchServiceClient(client),
 		SyncServiceClient:    storev1.NewSyncServiceClient(client),
+		QuotaServiceClient:   storev1.NewQuotaServiceClient(client),
+		StatsServiceClient:   storev1.NewStatsServiceClient(client),
+		AdminServiceClient:   storev1.NewAdminServiceClient(client),
 		SignServiceClient:    signv1.NewSignServiceClient(client),
+		healthClient:         healthpb.NewHealthClient(client),
 		config:               options.config,
+		pool:                 client,
 		authClient:           options.authClient,
-	}, nil
+		cache:                options.recordCache(),
+		pullPolicy:           options.pullPolicy,
+		verifyContent:        options.verifyContent,
+		newRequestID:         options.requestIDs(),
+		hooks:                options.hooks,
+		encryption:           options.encryption,
+		cidOptions:           cidOptions,
+		maxRetryDelay:        options.retryDelay(),
+		staleTimeout:         options.staleTimeout(),
+	}
+
+	if options.sharedPushIdle > 0 {
+		c.sharedPush = newSharedPushStream(c, options.sharedPushIdle)
+	}
+
+	return c, nil
+}
+
+// CacheStats returns the hit and miss counters of the cache configured with WithCache.
+// All counters are zero if caching is disabled.
+func (c *Client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+
+	return c.cache.stats()
 }
 
 func (c *Client) Close() error {
+	var errs error
+
+	// Close the shared push stream before its connection
+	if c.sharedPush != nil {
+		c.sharedPush.close()
+	}
+
+	// Close server connections
+	if c.pool != nil {
+		errs = c.pool.close()
+	}
+
 	// Close auth client if it exists
 	if c.authClient != nil {
-		//nolint:wrapcheck
-		return c.authClient.Close()
+		errs = errors.Join(errs, c.authClient.Close())
+	}
+
+	// Stop the local store after its connection
+	if c.local != nil {
+		c.local.close()
 	}
 
-	return nil
+	return errs
 }
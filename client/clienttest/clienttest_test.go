@@ -0,0 +1,257 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package clienttest_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/client/clienttest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newRecord(i int) *corev1.Record {
+	return corev1.New(&typesv1alpha1.Record{
+		Name:          fmt.Sprintf("agent-%d", i),
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+}
+
+// newClient connects a client to the fakes.
+func newClient(t *testing.T, services ...clienttest.Service) *client.Client {
+	t.Helper()
+
+	server := clienttest.NewServer(services...)
+	t.Cleanup(func() { _ = server.Close() })
+
+	c, err := client.New(
+		client.WithConfig(&client.Config{ServerAddress: server.Target()}),
+		client.WithDialOptions(server.DialOptions()...),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.Close() })
+
+	return c
+}
+
+func TestFakeStoreService(t *testing.T) {
+	fake := clienttest.NewFakeStoreService()
+	defer fake.Close()
+
+	c := newClient(t, fake)
+
+	stored, pushed := newRecord(1), newRecord(2)
+	fake.AddRecords(stored)
+
+	ref, err := c.Push(t.Context(), pushed)
+	if err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	if _, ok := fake.Record(ref.GetCid()); !ok {
+		t.Errorf("pushed record %s is not stored", ref.GetCid())
+	}
+
+	record, err := c.Pull(t.Context(), &corev1.RecordRef{Cid: stored.GetCid()})
+	if err != nil {
+		t.Fatalf("Pull() error = %v", err)
+	}
+
+	if record.GetCid() != stored.GetCid() {
+		t.Errorf("pulled %s, want %s", record.GetCid(), stored.GetCid())
+	}
+
+	if _, err := c.Lookup(t.Context(), &corev1.RecordRef{Cid: newRecord(3).GetCid()}); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Lookup() of a missing record error = %v, want ErrNotFound", err)
+	}
+
+	fake.SetError(stored.GetCid(), status.Error(codes.PermissionDenied, "denied"))
+
+	if _, err := c.Pull(t.Context(), &corev1.RecordRef{Cid: stored.GetCid()}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Pull() error = %v, want PermissionDenied", err)
+	}
+
+	if err := c.Delete(t.Context(), &corev1.RecordRef{Cid: ref.GetCid()}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, ok := fake.Record(ref.GetCid()); ok {
+		t.Errorf("deleted record %s is still stored", ref.GetCid())
+	}
+}
+
+func TestFakeStoreServiceOutOfOrder(t *testing.T) {
+	fake := clienttest.NewFakeStoreService()
+	defer fake.Close()
+
+	fake.SetOutOfOrder(true)
+
+	c := newClient(t, fake)
+
+	refs := make([]*corev1.RecordRef, 5)
+	for i := range refs {
+		record := newRecord(i)
+		fake.AddRecords(record)
+		refs[i] = &corev1.RecordRef{Cid: record.GetCid()}
+	}
+
+	missing := &corev1.RecordRef{Cid: newRecord(len(refs)).GetCid()}
+	refs = append(refs, missing)
+
+	result, err := c.PullStream(t.Context(), streamRefs(refs))
+	if err != nil {
+		t.Fatalf("PullStream() error = %v", err)
+	}
+
+	var indexes []int
+
+	for {
+		select {
+		case res := <-result.ResCh():
+			if res.Index == len(refs)-1 {
+				if !errors.Is(res.Error, client.ErrNotFound) {
+					t.Errorf("result of the missing record error = %v, want ErrNotFound", res.Error)
+				}
+			} else if res.Record.GetCid() != refs[res.Index].GetCid() {
+				t.Errorf("result %d is %s, want %s", res.Index, res.Record.GetCid(), refs[res.Index].GetCid())
+			}
+
+			indexes = append(indexes, res.Index)
+		case err := <-result.ErrCh():
+			t.Fatalf("stream error = %v", err)
+		case <-result.DoneCh():
+			// Answers in reverse order are matched to the references they were sent for
+			if want := []int{5, 4, 3, 2, 1, 0}; !slices.Equal(indexes, want) {
+				t.Errorf("result indexes = %v, want %v", indexes, want)
+			}
+
+			return
+		}
+	}
+}
+
+func TestFakeRoutingService(t *testing.T) {
+	fake := clienttest.NewFakeRoutingService()
+	defer fake.Close()
+
+	c := newClient(t, fake)
+
+	req := &routingv1.PublishRequest{
+		Request: &routingv1.PublishRequest_RecordRefs{
+			RecordRefs: &routingv1.RecordRefs{Refs: []*corev1.RecordRef{{Cid: "cid-1"}, {Cid: "cid-2"}}},
+		},
+	}
+
+	fake.FailAt(routingv1.RoutingService_Publish_FullMethodName, 1, status.Error(codes.Unavailable, "no peers"))
+
+	if err := c.Publish(t.Context(), req); status.Code(err) != codes.Unavailable {
+		t.Errorf("Publish() error = %v, want Unavailable", err)
+	}
+
+	if err := c.Publish(t.Context(), req); err != nil {
+		t.Fatalf("Publish() retry error = %v", err)
+	}
+
+	if published := fake.Published(); !slices.Equal(published, []string{"cid-1", "cid-2"}) {
+		t.Errorf("published %v, want [cid-1 cid-2]", published)
+	}
+
+	fake.AddListResponses(
+		&routingv1.ListResponse{RecordRef: &corev1.RecordRef{Cid: "cid-1"}},
+		&routingv1.ListResponse{RecordRef: &corev1.RecordRef{Cid: "cid-2"}},
+	)
+
+	resCh, err := c.List(t.Context(), &routingv1.ListRequest{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	var listed []string
+	for res := range resCh {
+		listed = append(listed, res.GetRecordRef().GetCid())
+	}
+
+	if !slices.Equal(listed, []string{"cid-1", "cid-2"}) {
+		t.Errorf("listed %v, want [cid-1 cid-2]", listed)
+	}
+
+	if got := fake.Messages(routingv1.RoutingService_Publish_FullMethodName); got != 2 {
+		t.Errorf("server received %d publish requests, want 2", got)
+	}
+}
+
+func TestFakeRoutingServicePublishAtomic(t *testing.T) {
+	fake := clienttest.NewFakeRoutingService()
+	defer fake.Close()
+
+	c := newClient(t, fake)
+
+	refs := []*corev1.RecordRef{{Cid: "cid-1"}, {Cid: "cid-2"}, {Cid: "cid-3"}}
+
+	fake.FailAt(routingv1.RoutingService_PublishAtomic_FullMethodName, 1, status.Error(codes.NotFound, "record 2 (cid-3) not found"))
+
+	if err := c.PublishAtomic(t.Context(), refs); status.Code(err) != codes.NotFound {
+		t.Errorf("PublishAtomic() error = %v, want NotFound", err)
+	}
+
+	if published := fake.Published(); len(published) != 0 {
+		t.Errorf("published %v after failed atomic publication, want none", published)
+	}
+
+	if err := c.PublishAtomic(t.Context(), refs); err != nil {
+		t.Fatalf("PublishAtomic() retry error = %v", err)
+	}
+
+	if published := fake.Published(); !slices.Equal(published, []string{"cid-1", "cid-2", "cid-3"}) {
+		t.Errorf("published %v, want [cid-1 cid-2 cid-3]", published)
+	}
+}
+
+func TestFakeServices(t *testing.T) {
+	store := clienttest.NewFakeStoreService()
+	defer store.Close()
+
+	routing := clienttest.NewFakeRoutingService()
+	defer routing.Close()
+
+	// A single client can call several fakes
+	c := newClient(t, store, routing)
+
+	record := newRecord(1)
+	store.AddRecords(record)
+
+	if _, err := c.Lookup(t.Context(), &corev1.RecordRef{Cid: record.GetCid()}); err != nil {
+		t.Errorf("Lookup() error = %v", err)
+	}
+
+	if _, err := c.Resolve(t.Context(), "agent-1:latest"); status.Code(err) != codes.Unimplemented {
+		t.Errorf("Resolve() error = %v, want Unimplemented", err)
+	}
+
+	if err := c.Unpublish(t.Context(), &routingv1.UnpublishRequest{}); err != nil {
+		t.Errorf("Unpublish() error = %v", err)
+	}
+}
+
+func streamRefs(refs []*corev1.RecordRef) <-chan *corev1.RecordRef {
+	refsCh := make(chan *corev1.RecordRef, len(refs))
+	for _, ref := range refs {
+		refsCh <- ref
+	}
+
+	close(refsCh)
+
+	return refsCh
+}
@@ -0,0 +1,6 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clienttest provides fakes of the Directory services to test code calling them,
+// with programmable responses, failures, latency and ordering.
+package clienttest
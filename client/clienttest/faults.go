@@ -0,0 +1,222 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Faults programs the failures and timing of the calls answered by a fake.
+// Methods are identified by their full name, e.g. storev1.StoreService_Pull_FullMethodName.
+type Faults struct {
+	mu         sync.Mutex
+	latency    time.Duration
+	outOfOrder bool
+	failures   map[string]failure
+	messages   map[string]int
+}
+
+type failure struct {
+	n   int
+	err error
+}
+
+func newFaults() *Faults {
+	return &Faults{
+		failures: map[string]failure{},
+		messages: map[string]int{},
+	}
+}
+
+// FailAt fails the next call of the method with err at its nth message, counting from 1.
+// Messages are the requests received on streams sending requests, and the responses
+// otherwise: unary calls fail at message 1, and server streams instead of sending the nth response.
+// Only one call fails, so that retries of the call succeed. Passing a nil error removes the failure.
+func (f *Faults) FailAt(method string, n int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err == nil {
+		delete(f.failures, method)
+
+		return
+	}
+
+	f.failures[method] = failure{n: n, err: err}
+}
+
+// SetLatency delays every response by d.
+func (f *Faults) SetLatency(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.latency = d
+}
+
+// SetOutOfOrder sends the responses of streams in reverse order. Responses to streams sending
+// requests are held back until the client closes its side of the stream.
+func (f *Faults) SetOutOfOrder(outOfOrder bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.outOfOrder = outOfOrder
+}
+
+// Messages returns the number of requests received for the method.
+func (f *Faults) Messages(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.messages[method]
+}
+
+// received counts a request of the method.
+func (f *Faults) received(method string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.messages[method]++
+}
+
+// failure returns the error to fail the call of the method with at its nth message, if any.
+func (f *Faults) failure(method string, n int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	failure, ok := f.failures[method]
+	if !ok || failure.n != n {
+		return nil
+	}
+
+	delete(f.failures, method)
+
+	return failure.err
+}
+
+// wait delays a response by the programmed latency.
+func (f *Faults) wait(ctx context.Context) error {
+	f.mu.Lock()
+	latency := f.latency
+	f.mu.Unlock()
+
+	if latency <= 0 {
+		return nil
+	}
+
+	select {
+	case <-time.After(latency):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	}
+}
+
+func (f *Faults) isOutOfOrder() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.outOfOrder
+}
+
+// unary answers a unary call of the method with handle.
+func unary[OutT any](ctx context.Context, f *Faults, method string, handle func() (*OutT, error)) (*OutT, error) {
+	f.received(method)
+
+	if err := f.failure(method, 1); err != nil {
+		return nil, err
+	}
+
+	if err := f.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return handle()
+}
+
+type sender[OutT any] interface {
+	Send(*OutT) error
+	Context() context.Context
+}
+
+type bidiServer[InT, OutT any] interface {
+	sender[OutT]
+	Recv() (*InT, error)
+}
+
+// serveBidi answers every request received on the stream of the method with the responses returned by handle.
+func serveBidi[InT, OutT any](f *Faults, method string, stream bidiServer[InT, OutT], handle func(*InT) []*OutT) error {
+	var held []*OutT
+
+	for n := 1; ; n++ {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		f.received(method)
+
+		if err := f.failure(method, n); err != nil {
+			return err
+		}
+
+		if f.isOutOfOrder() {
+			held = append(held, handle(in)...)
+
+			continue
+		}
+
+		if err := send(f, stream, handle(in)); err != nil {
+			return err
+		}
+	}
+
+	slices.Reverse(held)
+
+	return send(f, stream, held)
+}
+
+// serveList sends the responses on the stream of a server streaming call of the method.
+func serveList[OutT any](f *Faults, method string, stream sender[OutT], responses []*OutT) error {
+	f.received(method)
+
+	if f.isOutOfOrder() {
+		responses = slices.Clone(responses)
+		slices.Reverse(responses)
+	}
+
+	for i, response := range responses {
+		if err := f.failure(method, i+1); err != nil {
+			return err
+		}
+
+		if err := send(f, stream, []*OutT{response}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func send[OutT any](f *Faults, stream sender[OutT], responses []*OutT) error {
+	for _, response := range responses {
+		if err := f.wait(stream.Context()); err != nil {
+			return err
+		}
+
+		if err := stream.Send(response); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package clienttest
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// FakeRoutingService is a fake routing service recording announcements in memory.
+//
+// It satisfies routingv1.RoutingServiceClient by calling the fake over an in-process gRPC connection.
+// Publish and Unpublish add and remove the CIDs of the referenced records from the published ones,
+// PublishAtomic adds all of them, or none if a fault is programmed for it,
+// List and Search send the programmed responses.
+// Failures and timing of the calls are programmed with the embedded Faults.
+type FakeRoutingService struct {
+	routingv1.RoutingServiceClient
+	*Faults
+
+	server *Server
+
+	mu        sync.Mutex
+	published map[string]bool
+	list      []*routingv1.ListResponse
+	search    []*routingv1.SearchResponse
+}
+
+// NewFakeRoutingService starts a fake routing service. Close it once done.
+func NewFakeRoutingService() *FakeRoutingService {
+	f := &FakeRoutingService{
+		Faults:    newFaults(),
+		published: map[string]bool{},
+	}
+
+	f.server = NewServer(f)
+	f.RoutingServiceClient = routingv1.NewRoutingServiceClient(f.server.Conn())
+
+	return f
+}
+
+// Register registers the fake with s, e.g. to serve it together with other fakes, see NewServer.
+func (f *FakeRoutingService) Register(s *grpc.Server) {
+	routingv1.RegisterRoutingServiceServer(s, &routingServer{fake: f})
+}
+
+// Server returns the server the fake is called through.
+func (f *FakeRoutingService) Server() *Server {
+	return f.server
+}
+
+// Close stops the fake.
+func (f *FakeRoutingService) Close() error {
+	return f.server.Close()
+}
+
+// Published returns the sorted CIDs of the published records.
+func (f *FakeRoutingService) Published() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cids := make([]string, 0, len(f.published))
+	for cid := range f.published {
+		cids = append(cids, cid)
+	}
+
+	slices.Sort(cids)
+
+	return cids
+}
+
+// AddListResponses adds responses sent by List, regardless of the request.
+func (f *FakeRoutingService) AddListResponses(responses ...*routingv1.ListResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.list = append(f.list, responses...)
+}
+
+// AddSearchResponses adds responses sent by Search, regardless of the request.
+func (f *FakeRoutingService) AddSearchResponses(responses ...*routingv1.SearchResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.search = append(f.search, responses...)
+}
+
+// routingServer serves the fake routing service.
+type routingServer struct {
+	routingv1.UnimplementedRoutingServiceServer
+
+	fake *FakeRoutingService
+}
+
+func (s *routingServer) Publish(ctx context.Context, req *routingv1.PublishRequest) (*emptypb.Empty, error) {
+	return unary(ctx, s.fake.Faults, routingv1.RoutingService_Publish_FullMethodName, func() (*emptypb.Empty, error) {
+		s.fake.mu.Lock()
+		defer s.fake.mu.Unlock()
+
+		for _, ref := range req.GetRecordRefs().GetRefs() {
+			s.fake.published[ref.GetCid()] = true
+		}
+
+		return &emptypb.Empty{}, nil
+	})
+}
+
+func (s *routingServer) PublishAtomic(ctx context.Context, req *routingv1.PublishAtomicRequest) (*routingv1.PublishAtomicResponse, error) {
+	return unary(ctx, s.fake.Faults, routingv1.RoutingService_PublishAtomic_FullMethodName, func() (*routingv1.PublishAtomicResponse, error) {
+		s.fake.mu.Lock()
+		defer s.fake.mu.Unlock()
+
+		for _, ref := range req.GetRefs() {
+			s.fake.published[ref.GetCid()] = true
+		}
+
+		return &routingv1.PublishAtomicResponse{TransactionId: uuid.NewString()}, nil
+	})
+}
+
+func (s *routingServer) Unpublish(ctx context.Context, req *routingv1.UnpublishRequest) (*emptypb.Empty, error) {
+	return unary(ctx, s.fake.Faults, routingv1.RoutingService_Unpublish_FullMethodName, func() (*emptypb.Empty, error) {
+		s.fake.mu.Lock()
+		defer s.fake.mu.Unlock()
+
+		for _, ref := range req.GetRecordRefs().GetRefs() {
+			delete(s.fake.published, ref.GetCid())
+		}
+
+		return &emptypb.Empty{}, nil
+	})
+}
+
+func (s *routingServer) List(_ *routingv1.ListRequest, stream routingv1.RoutingService_ListServer) error {
+	s.fake.mu.Lock()
+	responses := slices.Clone(s.fake.list)
+	s.fake.mu.Unlock()
+
+	return serveList(s.fake.Faults, routingv1.RoutingService_List_FullMethodName, stream, responses)
+}
+
+func (s *routingServer) Search(_ *routingv1.SearchRequest, stream routingv1.RoutingService_SearchServer) error {
+	s.fake.mu.Lock()
+	responses := slices.Clone(s.fake.search)
+	s.fake.mu.Unlock()
+
+	return serveList(s.fake.Faults, routingv1.RoutingService_Search_FullMethodName, stream, responses)
+}
+
+var _ routingv1.RoutingServiceClient = (*FakeRoutingService)(nil)
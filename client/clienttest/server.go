@@ -0,0 +1,90 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package clienttest
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const bufSize = 1024 * 1024
+
+// Service is a fake that can be served by a Server.
+type Service interface {
+	Register(s *grpc.Server)
+}
+
+// Server is an in-process gRPC server serving fakes over an in-memory connection.
+//
+// Use it to connect a client.Client to several fakes at once:
+//
+//	server := clienttest.NewServer(store, routing)
+//	defer server.Close()
+//
+//	c, err := client.New(
+//		client.WithConfig(&client.Config{ServerAddress: server.Target()}),
+//		client.WithDialOptions(server.DialOptions()...),
+//	)
+type Server struct {
+	listener *bufconn.Listener
+	server   *grpc.Server
+	conn     *grpc.ClientConn
+}
+
+// NewServer starts serving the services.
+func NewServer(services ...Service) *Server {
+	s := &Server{
+		listener: bufconn.Listen(bufSize),
+		server:   grpc.NewServer(),
+	}
+
+	for _, service := range services {
+		service.Register(s.server)
+	}
+
+	go func() { _ = s.server.Serve(s.listener) }()
+
+	conn, err := grpc.NewClient(s.Target(), s.DialOptions()...)
+	if err != nil {
+		// Only fails for invalid targets or options, which are fixed here
+		panic(fmt.Sprintf("failed to connect to fake server: %v", err))
+	}
+
+	s.conn = conn
+
+	return s
+}
+
+// Target is the address to connect to the server with, together with DialOptions.
+func (s *Server) Target() string {
+	return "passthrough:///bufnet"
+}
+
+// DialOptions returns the options to connect to the server with.
+func (s *Server) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+}
+
+// Conn returns a connection to the server.
+func (s *Server) Conn() *grpc.ClientConn {
+	return s.conn
+}
+
+// Close closes the connection and stops the server.
+func (s *Server) Close() error {
+	err := s.conn.Close()
+	s.server.Stop()
+
+	return err //nolint:wrapcheck
+}
@@ -0,0 +1,354 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package clienttest
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"sync"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// FakeStoreService is a fake store service keeping records in memory.
+//
+// It satisfies storev1.StoreServiceClient by calling the fake over an in-process gRPC connection,
+// so that streams have the semantics of real streams, e.g. for the helpers of the streaming package.
+// The fake answers Push, Pull, Lookup, Delete, DeleteWithAck, PushReferrer, PullReferrer,
+// WatchStore and ListTrash; other methods fail with Unimplemented.
+// Failures and timing of the calls are programmed with the embedded Faults.
+type FakeStoreService struct {
+	storev1.StoreServiceClient
+	*Faults
+
+	server *Server
+
+	mu        sync.Mutex
+	records   map[string]*corev1.Record
+	errs      map[string]error
+	referrers map[string][]*corev1.RecordReferrer
+	events    []*storev1.StoreEvent
+	trashed   []*storev1.TrashedRecord
+}
+
+// NewFakeStoreService starts a fake store service. Close it once done.
+func NewFakeStoreService() *FakeStoreService {
+	f := &FakeStoreService{
+		Faults:    newFaults(),
+		records:   map[string]*corev1.Record{},
+		errs:      map[string]error{},
+		referrers: map[string][]*corev1.RecordReferrer{},
+	}
+
+	f.server = NewServer(f)
+	f.StoreServiceClient = storev1.NewStoreServiceClient(f.server.Conn())
+
+	return f
+}
+
+// Register registers the fake with s, e.g. to serve it together with other fakes, see NewServer.
+func (f *FakeStoreService) Register(s *grpc.Server) {
+	storev1.RegisterStoreServiceServer(s, &storeServer{fake: f})
+}
+
+// Server returns the server the fake is called through.
+func (f *FakeStoreService) Server() *Server {
+	return f.server
+}
+
+// Close stops the fake.
+func (f *FakeStoreService) Close() error {
+	return f.server.Close()
+}
+
+// AddRecords stores the records under their CIDs.
+func (f *FakeStoreService) AddRecords(records ...*corev1.Record) {
+	for _, record := range records {
+		f.SetRecord(record.GetCid(), record)
+	}
+}
+
+// SetRecord stores the record under the CID, which does not need to match its content,
+// e.g. to test content verification. A nil record removes the CID.
+func (f *FakeStoreService) SetRecord(cid string, record *corev1.Record) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if record == nil {
+		delete(f.records, cid)
+
+		return
+	}
+
+	f.records[cid] = record
+}
+
+// Record returns the record stored under the CID, e.g. to check pushed and deleted records.
+func (f *FakeStoreService) Record(cid string) (*corev1.Record, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	record, ok := f.records[cid]
+
+	return record, ok
+}
+
+// SetError fails the requests for the CID with err, reported in the response for the CID
+// where the method supports it, or by failing the call otherwise. A nil error removes the failure.
+func (f *FakeStoreService) SetError(cid string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err == nil {
+		delete(f.errs, cid)
+
+		return
+	}
+
+	f.errs[cid] = err
+}
+
+// AddReferrers stores referrers for the record with the CID.
+func (f *FakeStoreService) AddReferrers(cid string, referrers ...*corev1.RecordReferrer) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.referrers[cid] = append(f.referrers[cid], referrers...)
+}
+
+// AddEvents adds events sent by WatchStore if their sequence number is after the requested one.
+func (f *FakeStoreService) AddEvents(events ...*storev1.StoreEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.events = append(f.events, events...)
+}
+
+// AddTrashed adds records listed by ListTrash.
+func (f *FakeStoreService) AddTrashed(records ...*storev1.TrashedRecord) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.trashed = append(f.trashed, records...)
+}
+
+// get returns the record stored under the CID, or the error programmed for it.
+func (f *FakeStoreService) get(cid string) (*corev1.Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs[cid]; err != nil {
+		return nil, err
+	}
+
+	record, ok := f.records[cid]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", cid)
+	}
+
+	return proto.Clone(record).(*corev1.Record), nil //nolint:forcetypeassert
+}
+
+// put stores the record unless an error is programmed for its CID, and reports whether it was already stored.
+func (f *FakeStoreService) put(record *corev1.Record) (bool, error) {
+	cid := record.GetCid()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs[cid]; err != nil {
+		return false, err
+	}
+
+	_, existed := f.records[cid]
+	f.records[cid] = record
+
+	return existed, nil
+}
+
+// remove deletes the record stored under the CID.
+func (f *FakeStoreService) remove(cid string) error {
+	if _, err := f.get(cid); err != nil {
+		return err
+	}
+
+	f.SetRecord(cid, nil)
+
+	return nil
+}
+
+// storeServer serves the fake store service.
+type storeServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	fake *FakeStoreService
+}
+
+func (s *storeServer) Push(stream storev1.StoreService_PushServer) error {
+	return serveBidi[corev1.Record, corev1.RecordRef](s.fake.Faults, storev1.StoreService_Push_FullMethodName, stream,
+		func(record *corev1.Record) []*corev1.RecordRef {
+			cid := record.GetCid()
+
+			existed, err := s.fake.put(record)
+			if err != nil {
+				return []*corev1.RecordRef{{Cid: cid, Error: recordError(cid, err)}}
+			}
+
+			return []*corev1.RecordRef{{Cid: cid, AlreadyExisted: existed}}
+		})
+}
+
+func (s *storeServer) Pull(stream storev1.StoreService_PullServer) error {
+	return serveBidi[corev1.RecordRef, corev1.Record](s.fake.Faults, storev1.StoreService_Pull_FullMethodName, stream,
+		func(ref *corev1.RecordRef) []*corev1.Record {
+			record, err := s.fake.get(ref.GetCid())
+			if err != nil {
+				return []*corev1.Record{{Error: recordError(ref.GetCid(), err)}}
+			}
+
+			return []*corev1.Record{record}
+		})
+}
+
+func (s *storeServer) Lookup(stream storev1.StoreService_LookupServer) error {
+	return serveBidi[corev1.RecordRef, corev1.RecordMeta](s.fake.Faults, storev1.StoreService_Lookup_FullMethodName, stream,
+		func(ref *corev1.RecordRef) []*corev1.RecordMeta {
+			record, err := s.fake.get(ref.GetCid())
+			if err != nil {
+				return []*corev1.RecordMeta{{Cid: ref.GetCid(), Error: recordError(ref.GetCid(), err)}}
+			}
+
+			return []*corev1.RecordMeta{{Cid: ref.GetCid(), SchemaVersion: record.GetSchemaVersion()}}
+		})
+}
+
+func (s *storeServer) Delete(stream storev1.StoreService_DeleteServer) error {
+	faults := s.fake.Faults
+
+	for n := 1; ; n++ {
+		ref, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		faults.received(storev1.StoreService_Delete_FullMethodName)
+
+		if err := faults.failure(storev1.StoreService_Delete_FullMethodName, n); err != nil {
+			return err
+		}
+
+		if err := s.fake.remove(ref.GetCid()); err != nil {
+			return err
+		}
+	}
+
+	if err := faults.wait(stream.Context()); err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(&emptypb.Empty{}) //nolint:wrapcheck
+}
+
+func (s *storeServer) DeleteWithAck(stream storev1.StoreService_DeleteWithAckServer) error {
+	return serveBidi[corev1.RecordRef, storev1.DeleteResponse](s.fake.Faults, storev1.StoreService_DeleteWithAck_FullMethodName, stream,
+		func(ref *corev1.RecordRef) []*storev1.DeleteResponse {
+			if err := s.fake.remove(ref.GetCid()); err != nil {
+				return []*storev1.DeleteResponse{{RecordRef: ref, Error: recordError(ref.GetCid(), err)}}
+			}
+
+			return []*storev1.DeleteResponse{{RecordRef: ref}}
+		})
+}
+
+func (s *storeServer) PushReferrer(stream storev1.StoreService_PushReferrerServer) error {
+	return serveBidi[storev1.PushReferrerRequest, storev1.PushReferrerResponse](s.fake.Faults, storev1.StoreService_PushReferrer_FullMethodName, stream,
+		func(req *storev1.PushReferrerRequest) []*storev1.PushReferrerResponse {
+			cid := req.GetRecordRef().GetCid()
+
+			if _, err := s.fake.get(cid); err != nil {
+				return []*storev1.PushReferrerResponse{{ErrorMessage: proto.String(err.Error())}}
+			}
+
+			s.fake.AddReferrers(cid, req.GetReferrer())
+
+			return []*storev1.PushReferrerResponse{{Success: true}}
+		})
+}
+
+func (s *storeServer) PullReferrer(stream storev1.StoreService_PullReferrerServer) error {
+	return serveBidi[storev1.PullReferrerRequest, storev1.PullReferrerResponse](s.fake.Faults, storev1.StoreService_PullReferrer_FullMethodName, stream,
+		func(req *storev1.PullReferrerRequest) []*storev1.PullReferrerResponse {
+			cid := req.GetRecordRef().GetCid()
+
+			s.fake.mu.Lock()
+			defer s.fake.mu.Unlock()
+
+			var responses []*storev1.PullReferrerResponse
+
+			for _, referrer := range s.fake.referrers[cid] {
+				if req.ReferrerType != nil && referrer.GetType() != req.GetReferrerType() {
+					continue
+				}
+
+				responses = append(responses, &storev1.PullReferrerResponse{Referrer: referrer})
+			}
+
+			return responses
+		})
+}
+
+func (s *storeServer) WatchStore(req *storev1.WatchStoreRequest, stream storev1.StoreService_WatchStoreServer) error {
+	s.fake.mu.Lock()
+
+	var events []*storev1.StoreEvent
+
+	for _, event := range s.fake.events {
+		if event.GetSequence() > req.GetFromSequence() {
+			events = append(events, event)
+		}
+	}
+
+	s.fake.mu.Unlock()
+
+	if err := serveList(s.fake.Faults, storev1.StoreService_WatchStore_FullMethodName, stream, events); err != nil {
+		return err
+	}
+
+	// Watches only end when they are canceled
+	<-stream.Context().Done()
+
+	return nil
+}
+
+func (s *storeServer) ListTrash(_ *storev1.ListTrashRequest, stream storev1.StoreService_ListTrashServer) error {
+	s.fake.mu.Lock()
+	trashed := slices.Clone(s.fake.trashed)
+	s.fake.mu.Unlock()
+
+	return serveList(s.fake.Faults, storev1.StoreService_ListTrash_FullMethodName, stream, trashed)
+}
+
+// recordError converts an error for a single record reference into its wire representation.
+func recordError(cid string, err error) *corev1.RecordError {
+	st := status.Convert(err)
+
+	return &corev1.RecordError{
+		Code:    uint32(st.Code()), //nolint:gosec
+		Message: st.Message(),
+		Cid:     cid,
+	}
+}
+
+var _ storev1.StoreServiceClient = (*FakeStoreService)(nil)
@@ -0,0 +1,147 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/utils/compression"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// wireCounter counts the bytes of payloads sent on the wire, after compression.
+type wireCounter struct {
+	sent atomic.Int64
+}
+
+func (c *wireCounter) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context { return ctx }
+
+func (c *wireCounter) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if payload, ok := s.(*stats.OutPayload); ok {
+		c.sent.Add(int64(payload.WireLength))
+	}
+}
+
+func (c *wireCounter) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context { return ctx }
+
+func (c *wireCounter) HandleConn(context.Context, stats.ConnStats) {}
+
+// newSizedRecord creates a record of roughly size bytes with highly repetitive content.
+func newSizedRecord(size int) *corev1.Record {
+	return corev1.New(&typesv1alpha1.Record{
+		Name:          "large-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+		Description:   strings.Repeat("An agent describing itself at great length. ", size/44), //nolint:mnd
+	})
+}
+
+func TestCompression(t *testing.T) {
+	record := newSizedRecord(1024 * 1024) //nolint:mnd
+
+	// pushedBytes returns the bytes sent on the wire to push the record.
+	pushedBytes := func(t *testing.T, opts ...Option) int64 {
+		t.Helper()
+
+		counter := &wireCounter{}
+
+		opts = append(opts, WithDialOptions(grpc.WithStatsHandler(counter)))
+		c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, pushServer{}) }, opts...)
+
+		ref, err := c.Push(t.Context(), record)
+		if err != nil {
+			t.Fatalf("failed to push record: %v", err)
+		}
+
+		if ref.GetCid() != record.GetCid() {
+			t.Fatalf("expected CID %s, got %s", record.GetCid(), ref.GetCid())
+		}
+
+		return counter.sent.Load()
+	}
+
+	uncompressed := pushedBytes(t)
+
+	for _, name := range []string{compression.Gzip, compression.Zstd} {
+		t.Run(name, func(t *testing.T) {
+			compressed := pushedBytes(t, WithCompression(name))
+			if compressed*10 > uncompressed {
+				t.Errorf("expected %s to reduce %d bytes on the wire substantially, got %d bytes", name, uncompressed, compressed)
+			}
+		})
+	}
+
+	t.Run("from config", func(t *testing.T) {
+		compressed := pushedBytes(t, WithConfig(&Config{ServerAddress: "passthrough:///bufnet", Compression: compression.Zstd}))
+		if compressed*10 > uncompressed {
+			t.Errorf("expected configured compression to reduce %d bytes on the wire, got %d bytes", uncompressed, compressed)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		if _, err := New(WithConfig(&Config{}), WithCompression("snappy")); err == nil {
+			t.Error("expected unsupported compression to be rejected")
+		}
+	})
+}
+
+// largeRecordServer serves pulls of stored records and acknowledges pushes.
+type largeRecordServer struct {
+	pullServer
+}
+
+func (largeRecordServer) Push(stream storev1.StoreService_PushServer) error {
+	return pushServer{}.Push(stream)
+}
+
+func TestMaxMessageSize(t *testing.T) {
+	const limit = 8 * 1024 * 1024
+
+	record := newSizedRecord(5 * 1024 * 1024) //nolint:mnd
+	server := largeRecordServer{pullServer{
+		lookupServer: lookupServer{metas: map[string]*corev1.RecordMeta{}},
+		records:      map[string]*corev1.Record{record.GetCid(): record},
+	}}
+	register := func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) }
+	ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+	t.Run("default limits", func(t *testing.T) {
+		c := newBufconnClient(t, register)
+
+		if _, err := c.Pull(t.Context(), ref); status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("expected ResourceExhausted for record exceeding the default limit, got %v", err)
+		}
+	})
+
+	t.Run("raised limits", func(t *testing.T) {
+		c := newBufconnServerClient(t,
+			[]grpc.ServerOption{grpc.MaxRecvMsgSize(limit)},
+			register,
+			WithMaxRecvMsgSize(limit),
+			WithMaxSendMsgSize(limit),
+		)
+
+		pulled, err := c.Pull(t.Context(), ref)
+		if err != nil {
+			t.Fatalf("expected pull to succeed with raised limits, got %v", err)
+		}
+
+		if pulled.GetCid() != record.GetCid() {
+			t.Errorf("expected record %s, got %s", record.GetCid(), pulled.GetCid())
+		}
+
+		if _, err := c.Push(t.Context(), record); err != nil {
+			t.Errorf("expected push to succeed with raised limits, got %v", err)
+		}
+	})
+}
@@ -6,6 +6,7 @@ package client
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
@@ -15,6 +16,12 @@ const (
 	DefaultEnvPrefix = "DIRECTORY_CLIENT"
 
 	DefaultServerAddress = "0.0.0.0:8888"
+
+	// Keepalive pings are sent after this long without activity on a connection with active streams.
+	DefaultKeepaliveTime = 30 * time.Second
+
+	// Connections are closed if a keepalive ping is not acknowledged within this timeout.
+	DefaultKeepaliveTimeout = 10 * time.Second
 )
 
 var DefaultConfig = Config{
@@ -22,10 +29,27 @@ var DefaultConfig = Config{
 }
 
 type Config struct {
-	ServerAddress    string `json:"server_address,omitempty"     mapstructure:"server_address"`
-	SpiffeSocketPath string `json:"spiffe_socket_path,omitempty" mapstructure:"spiffe_socket_path"`
-	AuthMode         string `json:"auth_mode,omitempty"          mapstructure:"auth_mode"`
-	JWTAudience      string `json:"jwt_audience,omitempty"       mapstructure:"jwt_audience"`
+	ServerAddress    string   `json:"server_address,omitempty"     mapstructure:"server_address"`
+	ServerAddresses  []string `json:"server_addresses,omitempty"   mapstructure:"server_addresses"`
+	SpiffeSocketPath string   `json:"spiffe_socket_path,omitempty" mapstructure:"spiffe_socket_path"`
+	AuthMode         string   `json:"auth_mode,omitempty"          mapstructure:"auth_mode"`
+	JWTAudience      string   `json:"jwt_audience,omitempty"       mapstructure:"jwt_audience"`
+
+	// Compression compresses calls with the named compressor, "gzip" or "zstd".
+	Compression string `json:"compression,omitempty" mapstructure:"compression"`
+
+	// CIDHash is the hash function the CIDs of pushed records are calculated with, "sha2-256" or "sha2-512".
+	// It must match the CID hash of the server. Uses SHA2-256 if not set.
+	CIDHash string `json:"cid_hash,omitempty" mapstructure:"cid_hash"`
+
+	// Keepalive pings of the connections, see WithKeepalive.
+	// DefaultKeepaliveTime and DefaultKeepaliveTimeout are used if not set.
+	KeepaliveTime                time.Duration `json:"keepalive_time,omitempty"                  mapstructure:"keepalive_time"`
+	KeepaliveTimeout             time.Duration `json:"keepalive_timeout,omitempty"               mapstructure:"keepalive_timeout"`
+	KeepalivePermitWithoutStream bool          `json:"keepalive_permit_without_stream,omitempty" mapstructure:"keepalive_permit_without_stream"`
+
+	// StreamStaleTimeout fails quiet streams with ErrStreamStale, see WithStreamStaleTimeout.
+	StreamStaleTimeout time.Duration `json:"stream_stale_timeout,omitempty" mapstructure:"stream_stale_timeout"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -41,6 +65,9 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("server_address")
 	v.SetDefault("server_address", DefaultServerAddress)
 
+	_ = v.BindEnv("server_addresses")
+	v.SetDefault("server_addresses", "")
+
 	_ = v.BindEnv("spiffe_socket_path")
 	v.SetDefault("spiffe_socket_path", "")
 
@@ -50,6 +77,24 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("jwt_audience")
 	v.SetDefault("jwt_audience", "")
 
+	_ = v.BindEnv("compression")
+	v.SetDefault("compression", "")
+
+	_ = v.BindEnv("cid_hash")
+	v.SetDefault("cid_hash", "")
+
+	_ = v.BindEnv("keepalive_time")
+	v.SetDefault("keepalive_time", 0)
+
+	_ = v.BindEnv("keepalive_timeout")
+	v.SetDefault("keepalive_timeout", 0)
+
+	_ = v.BindEnv("keepalive_permit_without_stream")
+	v.SetDefault("keepalive_permit_without_stream", false)
+
+	_ = v.BindEnv("stream_stale_timeout")
+	v.SetDefault("stream_stale_timeout", 0)
+
 	// Load configuration into struct
 	decodeHooks := mapstructure.ComposeDecodeHookFunc(
 		mapstructure.TextUnmarshallerHookFunc(),
@@ -0,0 +1,283 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/api/extensions"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrDependencyCycle is returned by ResolveDependencies when a record depends on itself.
+	ErrDependencyCycle = errors.New("record dependencies contain a cycle")
+
+	// ErrMissingDependency is returned by ResolveDependencies when no record satisfies a dependency.
+	ErrMissingDependency = errors.New("record dependency not found")
+)
+
+// ResolveOptions configures ResolveDependencies.
+type ResolveOptions struct {
+	// Transitive resolves the dependencies of dependencies. Otherwise only the
+	// direct dependencies of the record are resolved.
+	Transitive bool
+	// MaxDepth limits the depth of transitive dependencies, the direct dependencies
+	// being at depth 1. Zero means no limit.
+	MaxDepth int
+}
+
+// DependencyNode is a record of a dependency graph.
+type DependencyNode struct {
+	// CID is the CID of the record.
+	CID string
+	// Name is the name of the record.
+	Name string
+	// Version is the version of the record.
+	Version string
+	// Depth is the shortest distance from the root record, which is at depth 0.
+	Depth int
+	// Dependencies are the CIDs of the records the record depends on, in declaration order.
+	// It is empty if the dependencies of the record were not resolved.
+	Dependencies []string
+	// Truncated is set if the record declares dependencies that were not resolved
+	// because of the resolve options.
+	Truncated bool
+}
+
+// DependencyGraph is the dependency graph of a record returned by ResolveDependencies.
+type DependencyGraph struct {
+	// Root is the CID of the record the graph was resolved for.
+	Root string
+	// Nodes are the records of the graph by CID.
+	Nodes map[string]*DependencyNode
+	// Order lists the CIDs of the records in deployment order: every record
+	// comes after the records it depends on, so the root record is last.
+	Order []string
+}
+
+// ResolveDependencies resolves the records a record depends on, as declared by its
+// core/dependencies extension, see extensions.RecordDependencies. Dependencies by CID are
+// pulled directly. Dependencies by name resolve to the record with the highest version of
+// the name satisfying the version constraint, see corev1.ParseVersionConstraint.
+//
+// If a dependency cannot be satisfied, the graph resolved so far is returned along with
+// ErrMissingDependency. Graphs containing a cycle are returned without Order along with
+// ErrDependencyCycle, whose message lists the records of the cycle.
+func (c *Client) ResolveDependencies(ctx context.Context, ref *corev1.RecordRef, opts ResolveOptions) (*DependencyGraph, error) {
+	root, err := c.Pull(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull record %s: %w", ref.GetCid(), err)
+	}
+
+	graph := &DependencyGraph{Root: root.GetCid(), Nodes: map[string]*DependencyNode{}}
+	records := map[string]*corev1.Record{root.GetCid(): root}
+	queue := []string{root.GetCid()}
+
+	graph.Nodes[root.GetCid()] = newDependencyNode(root, 0)
+
+	for len(queue) > 0 {
+		node := graph.Nodes[queue[0]]
+		record := records[queue[0]]
+		queue = queue[1:]
+
+		dependencies, err := extensions.RecordDependencies(record)
+		if err != nil {
+			return graph, fmt.Errorf("invalid dependencies of record %s: %w", node.CID, err)
+		}
+
+		if len(dependencies) == 0 {
+			continue
+		}
+
+		if (node.Depth > 0 && !opts.Transitive) || (opts.MaxDepth > 0 && node.Depth >= opts.MaxDepth) {
+			node.Truncated = true
+
+			continue
+		}
+
+		for _, dependency := range dependencies {
+			resolved, err := c.resolveDependency(ctx, dependency)
+			if err != nil {
+				return graph, fmt.Errorf("failed to resolve dependency %s of record %s: %w", dependency, node.CID, err)
+			}
+
+			cid := resolved.GetCid()
+			if !slices.Contains(node.Dependencies, cid) {
+				node.Dependencies = append(node.Dependencies, cid)
+			}
+
+			if _, ok := graph.Nodes[cid]; ok {
+				continue
+			}
+
+			graph.Nodes[cid] = newDependencyNode(resolved, node.Depth+1)
+			records[cid] = resolved
+			queue = append(queue, cid)
+		}
+	}
+
+	order, err := graph.sort()
+	if err != nil {
+		return graph, err
+	}
+
+	graph.Order = order
+
+	return graph, nil
+}
+
+func newDependencyNode(record *corev1.Record, depth int) *DependencyNode {
+	fields := record.GetData().GetFields()
+
+	return &DependencyNode{
+		CID:     record.GetCid(),
+		Name:    fields["name"].GetStringValue(),
+		Version: fields["version"].GetStringValue(),
+		Depth:   depth,
+	}
+}
+
+// resolveDependency returns the record satisfying a dependency.
+func (c *Client) resolveDependency(ctx context.Context, dependency extensions.Dependency) (*corev1.Record, error) {
+	ref := &corev1.RecordRef{Cid: dependency.CID}
+
+	if dependency.CID == "" {
+		constraint, err := dependency.Constraint()
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		if version, ok := constraint.IsExact(); ok {
+			ref, err = c.ResolveLocator(ctx, dependency.Name+"@"+version)
+		} else {
+			ref, err = c.resolveConstraint(ctx, dependency.Name, constraint)
+		}
+
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrMissingDependency, dependency)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	record, err := c.Pull(ctx, ref)
+	if errors.Is(err, ErrNotFound) || status.Code(err) == codes.NotFound {
+		return nil, fmt.Errorf("%w: %s", ErrMissingDependency, dependency)
+	}
+
+	return record, err
+}
+
+// resolveConstraint returns the reference of the record with the highest version of the name
+// satisfying the constraint. Returns ErrNotFound if no record satisfies it.
+func (c *Client) resolveConstraint(ctx context.Context, name string, constraint corev1.VersionConstraint) (*corev1.RecordRef, error) {
+	limit := uint32(maxLocatorCandidates)
+
+	cids, err := c.Search(ctx, &searchv1.SearchRequest{
+		Queries:           []*searchv1.RecordQuery{{Type: searchv1.RecordQueryType_RECORD_QUERY_TYPE_NAME, Value: name}},
+		Limit:             &limit,
+		IncludeSuperseded: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []*corev1.RecordRef
+
+	for cid := range cids {
+		refs = append(refs, &corev1.RecordRef{Cid: cid})
+	}
+
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	records, err := c.PullBatch(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		best    *corev1.Record
+		highest string
+	)
+
+	for _, record := range records {
+		version := record.GetData().GetFields()["version"].GetStringValue()
+		if !constraint.Matches(version) {
+			continue
+		}
+
+		// Ties between records of the same version are broken by CID for stable results
+		cmp := corev1.CompareVersions(version, highest)
+		if best == nil || cmp > 0 || (cmp == 0 && record.GetCid() < best.GetCid()) {
+			best, highest = record, version
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("%w: %s@%s", ErrNotFound, name, constraint)
+	}
+
+	return &corev1.RecordRef{Cid: best.GetCid()}, nil
+}
+
+// sort returns the CIDs of the graph in topological order, dependencies first.
+func (g *DependencyGraph) sort() ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var (
+		order []string
+		path  []string
+		visit func(cid string) error
+	)
+
+	state := map[string]int{}
+
+	visit = func(cid string) error {
+		switch state[cid] {
+		case visited:
+			return nil
+		case visiting:
+			start := slices.Index(path, cid)
+
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(append(slices.Clone(path[start:]), cid), " -> "))
+		}
+
+		state[cid] = visiting
+		path = append(path, cid)
+
+		for _, dependency := range g.Nodes[cid].Dependencies {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[cid] = visited
+		order = append(order, cid)
+
+		return nil
+	}
+
+	if err := visit(g.Root); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
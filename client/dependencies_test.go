@@ -0,0 +1,180 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+)
+
+func newDependentRecord(t *testing.T, name, version string, dependencies ...map[string]string) *corev1.Record {
+	t.Helper()
+
+	data := map[string]any{
+		"name":           name,
+		"version":        version,
+		"schema_version": "v0.3.1",
+	}
+
+	if len(dependencies) > 0 {
+		data["extensions"] = []any{map[string]any{
+			"name":    "schema.oasf.agntcy.org/core/dependencies",
+			"version": "v0.0.0",
+			"data":    map[string]any{"dependencies": dependencies},
+		}}
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("failed to marshal record: %v", err)
+	}
+
+	record, err := corev1.UnmarshalRecord(raw)
+	if err != nil {
+		t.Fatalf("failed to unmarshal record: %v", err)
+	}
+
+	return record
+}
+
+func newDependencyClient(t *testing.T, records ...*corev1.Record) *Client {
+	t.Helper()
+
+	return newBufconnClient(t, func(s *grpc.Server) {
+		searchv1.RegisterSearchServiceServer(s, recordSearchServer{records: records})
+		storev1.RegisterStoreServiceServer(s, newCountingStoreServer(records...))
+	})
+}
+
+func TestResolveDependencies(t *testing.T) {
+	db := newDependentRecord(t, "db", "1.0.0")
+	cache := newDependentRecord(t, "cache", "2.0.0")
+	search10 := newDependentRecord(t, "search-tool", "1.0.0", map[string]string{"cid": db.GetCid()})
+	search13 := newDependentRecord(t, "search-tool", "1.3.0", map[string]string{"cid": db.GetCid()}, map[string]string{"name": "cache", "version": "2.0.0"})
+	search20 := newDependentRecord(t, "search-tool", "2.0.0")
+	search14rc := newDependentRecord(t, "search-tool", "1.4.0-rc.1")
+	root := newDependentRecord(t, "orchestrator", "1.0.0",
+		map[string]string{"name": "search-tool", "version": "^1.2"},
+		map[string]string{"cid": db.GetCid()},
+	)
+
+	c := newDependencyClient(t, db, cache, search10, search13, search20, search14rc, root)
+
+	t.Run("transitive", func(t *testing.T) {
+		graph, err := c.ResolveDependencies(t.Context(), &corev1.RecordRef{Cid: root.GetCid()}, ResolveOptions{Transitive: true})
+		if err != nil {
+			t.Fatalf("ResolveDependencies() error = %v", err)
+		}
+
+		// ^1.2 resolves to the highest matching release, skipping 2.0.0 and the pre-release
+		want := []string{db.GetCid(), cache.GetCid(), search13.GetCid(), root.GetCid()}
+		if !slices.Equal(graph.Order, want) {
+			t.Errorf("Order = %v, want %v", graph.Order, want)
+		}
+
+		if got := graph.Nodes[root.GetCid()].Dependencies; !slices.Equal(got, []string{search13.GetCid(), db.GetCid()}) {
+			t.Errorf("root dependencies = %v", got)
+		}
+
+		if node := graph.Nodes[cache.GetCid()]; node.Depth != 2 || node.Name != "cache" || node.Version != "2.0.0" {
+			t.Errorf("cache node = %+v, want depth 2", node)
+		}
+	})
+
+	t.Run("direct", func(t *testing.T) {
+		graph, err := c.ResolveDependencies(t.Context(), &corev1.RecordRef{Cid: root.GetCid()}, ResolveOptions{})
+		if err != nil {
+			t.Fatalf("ResolveDependencies() error = %v", err)
+		}
+
+		if len(graph.Nodes) != 3 || !graph.Nodes[search13.GetCid()].Truncated {
+			t.Errorf("Nodes = %v, want root and its truncated direct dependencies", graph.Nodes)
+		}
+
+		if graph.Order[len(graph.Order)-1] != root.GetCid() {
+			t.Errorf("Order = %v, want root last", graph.Order)
+		}
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		graph, err := c.ResolveDependencies(t.Context(), &corev1.RecordRef{Cid: root.GetCid()}, ResolveOptions{Transitive: true, MaxDepth: 1})
+		if err != nil {
+			t.Fatalf("ResolveDependencies() error = %v", err)
+		}
+
+		if _, ok := graph.Nodes[cache.GetCid()]; ok {
+			t.Errorf("Nodes contain %s beyond max depth", cache.GetCid())
+		}
+
+		if !graph.Nodes[search13.GetCid()].Truncated || graph.Nodes[db.GetCid()].Truncated {
+			t.Errorf("only records with unresolved dependencies must be truncated")
+		}
+	})
+
+	t.Run("exact version", func(t *testing.T) {
+		exact := newDependentRecord(t, "pinned", "1.0.0", map[string]string{"name": "search-tool", "version": "1.0.0"})
+		c := newDependencyClient(t, db, search10, search13, exact)
+
+		graph, err := c.ResolveDependencies(t.Context(), &corev1.RecordRef{Cid: exact.GetCid()}, ResolveOptions{Transitive: true})
+		if err != nil {
+			t.Fatalf("ResolveDependencies() error = %v", err)
+		}
+
+		want := []string{db.GetCid(), search10.GetCid(), exact.GetCid()}
+		if !slices.Equal(graph.Order, want) {
+			t.Errorf("Order = %v, want %v", graph.Order, want)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		for name, dependency := range map[string]map[string]string{
+			"cid":        {"cid": newDependentRecord(t, "unknown", "1.0.0").GetCid()},
+			"name":       {"name": "unknown"},
+			"constraint": {"name": "search-tool", "version": ">=3.0.0"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				record := newDependentRecord(t, "broken", "1.0.0", dependency)
+				c := newDependencyClient(t, db, search10, record)
+
+				graph, err := c.ResolveDependencies(t.Context(), &corev1.RecordRef{Cid: record.GetCid()}, ResolveOptions{Transitive: true})
+				if !errors.Is(err, ErrMissingDependency) {
+					t.Fatalf("ResolveDependencies() error = %v, want ErrMissingDependency", err)
+				}
+
+				if graph == nil || graph.Root != record.GetCid() {
+					t.Errorf("ResolveDependencies() graph = %v, want partial graph", graph)
+				}
+			})
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		a := newDependentRecord(t, "a", "1.0.0", map[string]string{"name": "b"})
+		b := newDependentRecord(t, "b", "1.0.0", map[string]string{"name": "c", "version": "~1"})
+		cc := newDependentRecord(t, "c", "1.0.0", map[string]string{"name": "a", "version": "1.0.0"})
+		c := newDependencyClient(t, a, b, cc)
+
+		graph, err := c.ResolveDependencies(t.Context(), &corev1.RecordRef{Cid: a.GetCid()}, ResolveOptions{Transitive: true})
+		if !errors.Is(err, ErrDependencyCycle) {
+			t.Fatalf("ResolveDependencies() error = %v, want ErrDependencyCycle", err)
+		}
+
+		want := strings.Join([]string{a.GetCid(), b.GetCid(), cc.GetCid(), a.GetCid()}, " -> ")
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %v, want cycle %s", err, want)
+		}
+
+		if len(graph.Nodes) != 3 || graph.Order != nil {
+			t.Errorf("graph = %+v, want all nodes without order", graph)
+		}
+	})
+}
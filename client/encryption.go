@@ -0,0 +1,267 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+)
+
+// keySize is the size of data keys and local key encryption keys, for AES-256.
+const keySize = 32
+
+// ErrDecryption is wrapped by the errors of encrypted records that could not be decrypted,
+// e.g. because they were encrypted with another key.
+var ErrDecryption = errors.New("failed to decrypt record")
+
+// KeyProvider wraps and unwraps the per-record data keys of encrypted records with a key encryption key (KEK),
+// see WithEncryption. NewLocalKeyProvider keeps the KEK in a local key file, implementations backed
+// by a key management service wrap data keys without the KEK ever leaving the service.
+// Implementations must be safe for concurrent use.
+type KeyProvider interface {
+	// KeyID returns the ID of the KEK that wraps new data keys.
+	KeyID() string
+
+	// WrapKey encrypts a data key with the KEK.
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+
+	// UnwrapKey decrypts a data key wrapped with the KEK of the given ID.
+	// It fails if the provider does not have that KEK.
+	UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error)
+}
+
+// WithEncryption encrypts the content of pushed records at rest, and decrypts pulled records transparently.
+//
+// Each record is encrypted with a new AES-256-GCM data key, wrapped by the key provider and stored with the
+// record in its envelope, see corev1.RecordEnvelope. The CID is computed over the plaintext, so encrypting a
+// record does not change its identity. The server cannot read encrypted records, so their name, version,
+// annotations and tags are extracted by the client and sent alongside the ciphertext for indexing and lookups.
+//
+// Pulled records that cannot be decrypted fail with ErrDecryption. Clients without encryption
+// receive encrypted records as is, with Encrypted set.
+func WithEncryption(keys KeyProvider) Option {
+	return func(opts *options) error {
+		if keys == nil {
+			return errors.New("key provider is nil")
+		}
+
+		opts.encryption = keys
+
+		return nil
+	}
+}
+
+// LocalKeyProvider wraps data keys with an AES-256 KEK held in memory.
+type LocalKeyProvider struct {
+	kek   cipher.AEAD
+	keyID string
+}
+
+// NewLocalKeyProvider loads the KEK from a key file containing 32 raw bytes or their base64 encoding.
+func NewLocalKeyProvider(path string) (*LocalKeyProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	if len(data) != keySize {
+		decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+		if err != nil {
+			return nil, fmt.Errorf("key file must contain %d bytes or their base64 encoding", keySize)
+		}
+
+		data = decoded
+	}
+
+	return NewLocalKeyProviderFromKey(data)
+}
+
+// NewLocalKeyProviderFromKey creates a key provider for a 32-byte KEK.
+// The key ID is derived from the KEK, so that records encrypted with another KEK are recognized.
+func NewLocalKeyProviderFromKey(kek []byte) (*LocalKeyProvider, error) {
+	if len(kek) != keySize {
+		return nil, fmt.Errorf("key encryption key must be %d bytes, got %d", keySize, len(kek))
+	}
+
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := sha256.Sum256(kek)
+
+	return &LocalKeyProvider{
+		kek:   aead,
+		keyID: "local:" + hex.EncodeToString(fingerprint[:8]),
+	}, nil
+}
+
+// KeyID implements KeyProvider.
+func (p *LocalKeyProvider) KeyID() string {
+	return p.keyID
+}
+
+// WrapKey implements KeyProvider. The wrapped key is the nonce followed by the encrypted data key.
+func (p *LocalKeyProvider) WrapKey(_ context.Context, dataKey []byte) ([]byte, error) {
+	nonce := make([]byte, p.kek.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return p.kek.Seal(nonce, nonce, dataKey, []byte(p.keyID)), nil
+}
+
+// UnwrapKey implements KeyProvider.
+func (p *LocalKeyProvider) UnwrapKey(_ context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	if keyID != p.keyID {
+		return nil, fmt.Errorf("data key was wrapped with unknown key %q", keyID)
+	}
+
+	if len(wrappedKey) < p.kek.NonceSize() {
+		return nil, errors.New("wrapped data key is too short")
+	}
+
+	nonce, ciphertext := wrappedKey[:p.kek.NonceSize()], wrappedKey[p.kek.NonceSize():]
+
+	dataKey, err := p.kek.Open(nil, nonce, ciphertext, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	return dataKey, nil
+}
+
+// sealRecord encrypts the record with a new data key wrapped by the key provider.
+// Records that are already encrypted are returned as is.
+func sealRecord(ctx context.Context, keys KeyProvider, record *corev1.Record) (*corev1.Record, error) {
+	if record.IsEncrypted() {
+		return record, nil
+	}
+
+	plaintext, err := record.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	wrappedKey, err := keys.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	// The CID is authenticated, so that an envelope cannot be moved to another CID
+	cid := record.GetCid()
+
+	sealed, err := corev1.SealRecord(record, aead.Seal(nil, nonce, plaintext, []byte(cid)), &corev1.EncryptionHeader{
+		Algorithm:  corev1.EncryptionAlgorithmAES256GCM,
+		KeyId:      keys.KeyID(),
+		WrappedKey: wrappedKey,
+		Nonce:      nonce,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal record: %w", err)
+	}
+
+	return sealed, nil
+}
+
+// openRecord decrypts an encrypted record and checks that its content matches its CID.
+// The lifecycle of the pulled record is kept.
+func openRecord(ctx context.Context, keys KeyProvider, record *corev1.Record) (*corev1.Record, error) {
+	envelope := record.GetEnvelope()
+	header := envelope.GetHeader()
+
+	if header.GetAlgorithm() != corev1.EncryptionAlgorithmAES256GCM {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrDecryption, header.GetAlgorithm())
+	}
+
+	dataKey, err := keys.UnwrapKey(ctx, header.GetKeyId(), header.GetWrappedKey())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryption, err)
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryption, err)
+	}
+
+	if len(header.GetNonce()) != aead.NonceSize() {
+		return nil, fmt.Errorf("%w: invalid nonce", ErrDecryption)
+	}
+
+	plaintext, err := aead.Open(nil, header.GetNonce(), envelope.GetCiphertext(), []byte(envelope.GetCid()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryption, err)
+	}
+
+	opened, err := corev1.UnmarshalRecord(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryption, err)
+	}
+
+	if opened.GetCid() != envelope.GetCid() {
+		return nil, fmt.Errorf("%w: content does not match CID %s", ErrDecryption, envelope.GetCid())
+	}
+
+	opened.Lifecycle = record.GetLifecycle()
+
+	return opened, nil
+}
+
+// withDecryption decrypts successfully pulled encrypted records before converting them into results.
+func (c *Client) withDecryption(ctx context.Context, toResult func(int, *corev1.Record) *PullResult) func(int, *corev1.Record) *PullResult {
+	return func(index int, record *corev1.Record) *PullResult {
+		if record.GetError() != nil || record.GetEnvelope() == nil {
+			return toResult(index, record)
+		}
+
+		opened, err := openRecord(ctx, c.encryption, record)
+		if err != nil {
+			logger.Debug("Failed to decrypt pulled record", "cid", record.GetCid(), "error", err)
+
+			return &PullResult{Index: index, Error: err}
+		}
+
+		return toResult(index, opened)
+	}
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return aead, nil
+}
@@ -0,0 +1,184 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// envelopeStoreServer stores pushed records as is, like the server stores encrypted records.
+type envelopeStoreServer struct {
+	*countingStoreServer
+}
+
+func (s envelopeStoreServer) Push(stream storev1.StoreService_PushServer) error {
+	for {
+		record, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		stored, _ := proto.Clone(record).(*corev1.Record)
+		stored.Encrypted = stored.GetEnvelope() != nil
+
+		s.mu.Lock()
+		s.records[record.GetCid()] = stored
+		s.mu.Unlock()
+
+		if err := stream.Send(&corev1.RecordRef{Cid: record.GetCid()}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func newEncryptionTestKeys(t *testing.T, b byte) *LocalKeyProvider {
+	t.Helper()
+
+	keys, err := NewLocalKeyProviderFromKey(bytes.Repeat([]byte{b}, keySize))
+	if err != nil {
+		t.Fatalf("failed to create key provider: %v", err)
+	}
+
+	return keys
+}
+
+func TestEncryptionRoundTrip(t *testing.T) {
+	server := envelopeStoreServer{newCountingStoreServer()}
+	register := func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) }
+
+	keys := newEncryptionTestKeys(t, 1)
+	c := newBufconnClient(t, register, WithEncryption(keys))
+
+	record := newCacheTestRecord("encrypted-agent")
+
+	ref, err := c.Push(t.Context(), record)
+	if err != nil {
+		t.Fatalf("failed to push record: %v", err)
+	}
+
+	if ref.GetCid() != record.GetCid() {
+		t.Errorf("expected CID of the plaintext %s, got %s", record.GetCid(), ref.GetCid())
+	}
+
+	// The server only sees the envelope
+	stored := server.records[ref.GetCid()]
+	if stored.GetData() != nil || stored.GetEnvelope() == nil {
+		t.Fatal("expected the record to be stored encrypted")
+	}
+
+	if got := stored.GetEnvelope().GetMetadata().GetName(); got != "encrypted-agent" {
+		t.Errorf("expected the envelope metadata to carry the name, got %q", got)
+	}
+
+	if got := stored.GetEnvelope().GetHeader().GetKeyId(); got != keys.KeyID() {
+		t.Errorf("expected key ID %q in the encryption header, got %q", keys.KeyID(), got)
+	}
+
+	pulled, err := c.Pull(t.Context(), ref)
+	if err != nil {
+		t.Fatalf("failed to pull record: %v", err)
+	}
+
+	if pulled.IsEncrypted() || pulled.GetCid() != record.GetCid() {
+		t.Errorf("expected the decrypted record %s, got %v", record.GetCid(), pulled)
+	}
+
+	// Clients without encryption get the ciphertext with the encrypted marker
+	plain := newBufconnClient(t, register)
+
+	encrypted, err := plain.Pull(t.Context(), ref)
+	if err != nil {
+		t.Fatalf("failed to pull record without encryption: %v", err)
+	}
+
+	if !encrypted.GetEncrypted() || encrypted.GetData() != nil {
+		t.Errorf("expected an encrypted record, got %v", encrypted)
+	}
+}
+
+func TestEncryptionWrongKey(t *testing.T) {
+	server := envelopeStoreServer{newCountingStoreServer()}
+	register := func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) }
+
+	c := newBufconnClient(t, register, WithEncryption(newEncryptionTestKeys(t, 1)))
+
+	ref, err := c.Push(t.Context(), newCacheTestRecord("encrypted-agent"))
+	if err != nil {
+		t.Fatalf("failed to push record: %v", err)
+	}
+
+	other := newBufconnClient(t, register, WithEncryption(newEncryptionTestKeys(t, 2)))
+
+	if _, err := other.Pull(t.Context(), ref); !errors.Is(err, ErrDecryption) {
+		t.Errorf("expected ErrDecryption with another key, got %v", err)
+	}
+
+	// A tampered ciphertext fails even with the right key
+	server.records[ref.GetCid()].Envelope.Ciphertext[0] ^= 0xff
+
+	if _, err := c.Pull(t.Context(), ref); !errors.Is(err, ErrDecryption) {
+		t.Errorf("expected ErrDecryption for tampered ciphertext, got %v", err)
+	}
+}
+
+func TestNewLocalKeyProvider(t *testing.T) {
+	kek := bytes.Repeat([]byte{7}, keySize)
+	dir := t.TempDir()
+
+	raw := filepath.Join(dir, "raw.key")
+	encoded := filepath.Join(dir, "encoded.key")
+	invalid := filepath.Join(dir, "invalid.key")
+
+	for path, data := range map[string][]byte{
+		raw:     kek,
+		encoded: []byte(base64.StdEncoding.EncodeToString(kek) + "\n"),
+		invalid: []byte("too short"),
+	} {
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rawKeys, err := NewLocalKeyProvider(raw)
+	if err != nil {
+		t.Fatalf("failed to load raw key file: %v", err)
+	}
+
+	encodedKeys, err := NewLocalKeyProvider(encoded)
+	if err != nil {
+		t.Fatalf("failed to load base64 key file: %v", err)
+	}
+
+	if rawKeys.KeyID() != encodedKeys.KeyID() {
+		t.Errorf("expected the same key ID for the same key, got %q and %q", rawKeys.KeyID(), encodedKeys.KeyID())
+	}
+
+	wrapped, err := rawKeys.WrapKey(t.Context(), []byte("data key"))
+	if err != nil {
+		t.Fatalf("failed to wrap key: %v", err)
+	}
+
+	if unwrapped, err := encodedKeys.UnwrapKey(t.Context(), rawKeys.KeyID(), wrapped); err != nil || string(unwrapped) != "data key" {
+		t.Errorf("expected the data key to unwrap, got %q, %v", unwrapped, err)
+	}
+
+	if _, err := NewLocalKeyProvider(invalid); err == nil {
+		t.Error("expected an invalid key file to be rejected")
+	}
+}
@@ -0,0 +1,438 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// ExportFormatVersion is the version of the export format written by Export.
+const ExportFormatVersion = 1
+
+// maxExportLineSize bounds the size of a single exported record, above the 4MB record size limit.
+const maxExportLineSize = 8 * 1024 * 1024
+
+var (
+	// ErrExportGap is reported for incremental exports that cannot be chained to a checkpoint,
+	// because the journal no longer covers the changes since, or dropped some of them.
+	// A full export is needed to recover.
+	ErrExportGap = errors.New("export does not cover all changes since the checkpoint")
+
+	// ErrInvalidExport is reported by Import for malformed exports.
+	ErrInvalidExport = errors.New("invalid export")
+)
+
+// ExportOptions configure an export of the store.
+type ExportOptions struct {
+	// Since is the journal sequence number of the checkpoint to export the changes since,
+	// usually the ToSequence of the manifest of the previous export. Zero exports all records.
+	Since uint64
+	// SinceTime exports the changes made after this time instead of Since.
+	// It is resolved to a sequence number using the journal timestamps.
+	SinceTime time.Time
+}
+
+// ExportManifest describes the changes covered by an export. It is written first, so that chained
+// exports can be validated before they are applied, and doubles as the checkpoint for the next export.
+type ExportManifest struct {
+	// Version is the format version of the export, see ExportFormatVersion.
+	Version int `json:"version"`
+	// Full is set for exports of all records.
+	Full bool `json:"full"`
+	// FromSequence is the journal sequence number the export starts after, zero for full exports.
+	FromSequence uint64 `json:"from_sequence"`
+	// ToSequence is the journal sequence number of the last change covered by the export.
+	ToSequence uint64 `json:"to_sequence"`
+	// CreatedAt is the time the export was started.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportSummary reports what Export wrote.
+type ExportSummary struct {
+	// Manifest is the manifest of the export, to be used as the checkpoint of the next export.
+	Manifest ExportManifest
+	// Records is the number of exported records.
+	Records int
+	// Deleted is the number of CIDs exported as deleted.
+	Deleted int
+}
+
+// exportLine is a line of an export, holding exactly one of its fields.
+type exportLine struct {
+	Manifest *ExportManifest `json:"manifest,omitempty"`
+	Record   json.RawMessage `json:"record,omitempty"`
+	Deleted  string          `json:"deleted,omitempty"`
+}
+
+// Export writes the records of the store to w as newline-delimited JSON: the manifest first,
+// followed by a line for every record and for every CID deleted since the checkpoint.
+//
+// Without a checkpoint, all records found via the search index of the server are exported.
+// With a checkpoint, only the records pushed since and the CIDs deleted since are exported, as read
+// from the operation journal of the server, which must be enabled. Full exports of servers without
+// a journal have a ToSequence of zero. Changes of the metadata of records are not exported.
+// Exports fail with ErrExportGap if the journal no longer covers the changes since the checkpoint,
+// in which case a full export is needed.
+//
+// Records changed while the export runs may be exported with either their old or new state;
+// changes after the ToSequence of the manifest are exported again by the next incremental export.
+func (c *Client) Export(ctx context.Context, w io.Writer, opts ExportOptions) (*ExportSummary, error) {
+	since := opts.Since
+	if !opts.SinceTime.IsZero() {
+		var err error
+
+		since, err = c.journalSequenceAt(ctx, opts.SinceTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	changes, err := c.readJournalChanges(ctx, since)
+
+	switch {
+	case since == 0 && status.Code(err) == codes.FailedPrecondition:
+		// Full exports do not need the journal, but cannot be continued incrementally without it
+		logger.Warn("Journal disabled on the server, the export cannot be continued incrementally")
+
+		changes = &journalChanges{}
+	case err != nil:
+		return nil, err
+	}
+
+	summary := &ExportSummary{
+		Manifest: ExportManifest{
+			Version:      ExportFormatVersion,
+			Full:         since == 0,
+			FromSequence: since,
+			ToSequence:   changes.last,
+			CreatedAt:    time.Now().UTC(),
+		},
+	}
+
+	var pushed, deleted []string
+
+	if summary.Manifest.Full {
+		pushed, err = c.searchAll(ctx, SearchFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records: %w", err)
+		}
+	} else {
+		pushed, deleted = changes.pushed, changes.deleted
+	}
+
+	enc := json.NewEncoder(w)
+
+	if err := enc.Encode(exportLine{Manifest: &summary.Manifest}); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	for _, cid := range pushed {
+		record, err := c.Pull(ctx, &corev1.RecordRef{Cid: cid})
+		if errors.Is(err, ErrNotFound) {
+			// Deleted since it was listed, the next export carries the deletion
+			logger.Debug("Skipping record deleted during export", "cid", cid)
+
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to pull record %s: %w", cid, err)
+		}
+
+		data, err := protojson.Marshal(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal record %s: %w", cid, err)
+		}
+
+		if err := enc.Encode(exportLine{Record: data}); err != nil {
+			return nil, fmt.Errorf("failed to write record %s: %w", cid, err)
+		}
+
+		summary.Records++
+	}
+
+	for _, cid := range deleted {
+		if err := enc.Encode(exportLine{Deleted: cid}); err != nil {
+			return nil, fmt.Errorf("failed to write deletion of %s: %w", cid, err)
+		}
+
+		summary.Deleted++
+	}
+
+	return summary, nil
+}
+
+// journalChanges are the records changed according to the journal.
+type journalChanges struct {
+	// pushed and deleted are the CIDs whose last change was a push or delete, in the order of those changes.
+	pushed, deleted []string
+	// last is the sequence number of the last read entry, or the sequence read since if there are none.
+	last uint64
+}
+
+// readJournalChanges reads the journal entries after since into the changes of the records,
+// failing with ErrExportGap if entries after since are missing.
+func (c *Client) readJournalChanges(ctx context.Context, since uint64) (*journalChanges, error) {
+	var (
+		last     = since
+		gap      error
+		lastOp   = map[string]storev1.JournalOperation{}
+		sequence = map[string]uint64{}
+	)
+
+	err := c.ReadStoreJournal(ctx, since, func(entry *storev1.JournalEntry) {
+		if gap != nil {
+			return
+		}
+
+		switch {
+		case since > 0 && entry.GetSequence() != last+1:
+			gap = fmt.Errorf("%w: journal continues at sequence %d after %d", ErrExportGap, entry.GetSequence(), last)
+		case since > 0 && entry.GetOperation() == storev1.JournalOperation_JOURNAL_OPERATION_GAP:
+			gap = fmt.Errorf("%w: journal dropped %d entries at sequence %d", ErrExportGap, entry.GetDropped(), entry.GetSequence())
+		case since == 0:
+			// Full exports list the records instead
+		case entry.GetOperation() == storev1.JournalOperation_JOURNAL_OPERATION_PUSH,
+			entry.GetOperation() == storev1.JournalOperation_JOURNAL_OPERATION_DELETE:
+			lastOp[entry.GetCid()] = entry.GetOperation()
+			sequence[entry.GetCid()] = entry.GetSequence()
+		}
+
+		last = entry.GetSequence()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if gap != nil {
+		return nil, gap
+	}
+
+	cids := make([]string, 0, len(lastOp))
+	for cid := range lastOp {
+		cids = append(cids, cid)
+	}
+
+	slices.SortFunc(cids, func(a, b string) int {
+		return cmp.Compare(sequence[a], sequence[b])
+	})
+
+	changes := &journalChanges{last: last}
+
+	for _, cid := range cids {
+		if lastOp[cid] == storev1.JournalOperation_JOURNAL_OPERATION_DELETE {
+			changes.deleted = append(changes.deleted, cid)
+		} else {
+			changes.pushed = append(changes.pushed, cid)
+		}
+	}
+
+	return changes, nil
+}
+
+// journalSequenceAt returns the sequence number of the last journal entry at or before t.
+// Fails with ErrExportGap if the journal no longer retains the entries up to t.
+func (c *Client) journalSequenceAt(ctx context.Context, t time.Time) (uint64, error) {
+	var seq, first uint64
+
+	err := c.ReadStoreJournal(ctx, 0, func(entry *storev1.JournalEntry) {
+		if first == 0 {
+			first = entry.GetSequence()
+		}
+
+		timestamp, err := time.Parse(time.RFC3339, entry.GetTimestamp())
+		if err == nil && !timestamp.After(t) {
+			seq = entry.GetSequence()
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Entries before the first retained one may have been made after t
+	if seq == 0 && first > 1 {
+		return 0, fmt.Errorf("%w: journal starts at sequence %d after %s", ErrExportGap, first, t.Format(time.RFC3339))
+	}
+
+	return seq, nil
+}
+
+// ImportOptions configure an import of an export.
+type ImportOptions struct {
+	// Since is the ToSequence of the manifest of the last export imported into the store,
+	// used to validate that an incremental export continues it without a gap.
+	// Zero skips the validation.
+	Since uint64
+	// ApplyDeletes deletes the records exported as deleted. Deletions are skipped otherwise.
+	ApplyDeletes bool
+}
+
+// ImportSummary reports what Import applied.
+type ImportSummary struct {
+	// Manifest is the manifest of the imported export.
+	Manifest ExportManifest
+	// Records is the number of pushed records, including records that already existed.
+	Records int
+	// Deleted is the number of deleted records, including records that did not exist.
+	Deleted int
+	// SkippedDeletes is the number of deletions skipped without ApplyDeletes.
+	SkippedDeletes int
+}
+
+// ValidateChain checks that the manifest continues the export with ToSequence since without a gap.
+// Full exports continue any export.
+func (m *ExportManifest) ValidateChain(since uint64) error {
+	if m.Full {
+		return nil
+	}
+
+	if m.FromSequence > since {
+		return fmt.Errorf("%w: export starts after sequence %d, expected %d or earlier", ErrExportGap, m.FromSequence, since)
+	}
+
+	if m.ToSequence < since {
+		return fmt.Errorf("%w: export ends at sequence %d, before %d", ErrExportGap, m.ToSequence, since)
+	}
+
+	return nil
+}
+
+// ReadExportManifest reads the manifest at the start of an export written by Export.
+func ReadExportManifest(r io.Reader) (*ExportManifest, error) {
+	dec := newExportDecoder(r)
+
+	return dec.manifest()
+}
+
+// Import pushes the records of an export written by Export to the store, and deletes
+// the records exported as deleted if ApplyDeletes is set. The manifest is validated against
+// Since before any change is applied. Importing an export more than once is harmless.
+func (c *Client) Import(ctx context.Context, r io.Reader, opts ImportOptions) (*ImportSummary, error) {
+	dec := newExportDecoder(r)
+
+	manifest, err := dec.manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Since > 0 {
+		if err := manifest.ValidateChain(opts.Since); err != nil {
+			return nil, err
+		}
+	}
+
+	summary := &ImportSummary{Manifest: *manifest}
+
+	for {
+		line, err := dec.next()
+		if errors.Is(err, io.EOF) {
+			return summary, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case line.Record != nil:
+			record := &corev1.Record{}
+			if err := protojson.Unmarshal(line.Record, record); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidExport, dec.line, err)
+			}
+
+			// Exported records keep their name and version, which may be taken by an older record
+			if _, err := c.Push(storev1.ContextWithPushOverwrite(ctx), record); err != nil {
+				return nil, fmt.Errorf("failed to push record %s: %w", record.GetCid(), err)
+			}
+
+			summary.Records++
+
+		case line.Deleted != "":
+			if !opts.ApplyDeletes {
+				summary.SkippedDeletes++
+
+				continue
+			}
+
+			err := c.Delete(ctx, &corev1.RecordRef{Cid: line.Deleted}, WithForce())
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				return nil, fmt.Errorf("failed to delete record %s: %w", line.Deleted, err)
+			}
+
+			summary.Deleted++
+
+		default:
+			return nil, fmt.Errorf("%w: line %d: expected a record or deletion", ErrInvalidExport, dec.line)
+		}
+	}
+}
+
+// exportDecoder reads the lines of an export.
+type exportDecoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newExportDecoder(r io.Reader) *exportDecoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxExportLineSize)
+
+	return &exportDecoder{scanner: scanner}
+}
+
+// next returns the next line, or io.EOF at the end of the export.
+func (d *exportDecoder) next() (*exportLine, error) {
+	if !d.scanner.Scan() {
+		if err := d.scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read export: %w", err)
+		}
+
+		return nil, io.EOF
+	}
+
+	d.line++
+
+	line := &exportLine{}
+	if err := json.Unmarshal(d.scanner.Bytes(), line); err != nil {
+		return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidExport, d.line, err)
+	}
+
+	return line, nil
+}
+
+// manifest reads the manifest, which must be the first line.
+func (d *exportDecoder) manifest() (*ExportManifest, error) {
+	line, err := d.next()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: missing manifest", ErrInvalidExport)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if line.Manifest == nil {
+		return nil, fmt.Errorf("%w: missing manifest", ErrInvalidExport)
+	}
+
+	if line.Manifest.Version != ExportFormatVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %d", ErrInvalidExport, line.Manifest.Version)
+	}
+
+	return line.Manifest, nil
+}
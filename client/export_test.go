@@ -0,0 +1,56 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExportManifestValidateChain(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest ExportManifest
+		since    uint64
+		wantGap  bool
+	}{
+		{name: "full export", manifest: ExportManifest{Full: true, ToSequence: 5}, since: 10},
+		{name: "continues checkpoint", manifest: ExportManifest{FromSequence: 10, ToSequence: 20}, since: 10},
+		{name: "overlaps checkpoint", manifest: ExportManifest{FromSequence: 5, ToSequence: 20}, since: 10},
+		{name: "starts after checkpoint", manifest: ExportManifest{FromSequence: 11, ToSequence: 20}, since: 10, wantGap: true},
+		{name: "ends before checkpoint", manifest: ExportManifest{FromSequence: 1, ToSequence: 9}, since: 10, wantGap: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.manifest.ValidateChain(tt.since)
+			if got := errors.Is(err, ErrExportGap); got != tt.wantGap {
+				t.Errorf("ValidateChain(%d) = %v, want gap %v", tt.since, err, tt.wantGap)
+			}
+		})
+	}
+}
+
+func TestReadExportManifest(t *testing.T) {
+	manifest, err := ReadExportManifest(strings.NewReader(`{"manifest":{"version":1,"from_sequence":3,"to_sequence":7}}` + "\n" + `{"deleted":"cid"}`))
+	if err != nil {
+		t.Fatalf("ReadExportManifest() error = %v", err)
+	}
+
+	if manifest.FromSequence != 3 || manifest.ToSequence != 7 {
+		t.Errorf("ReadExportManifest() = %+v, want sequences 3 to 7", manifest)
+	}
+
+	for _, input := range []string{
+		"",
+		`{"deleted":"cid"}`,
+		`{"manifest":{"version":2}}`,
+		"not json",
+	} {
+		if _, err := ReadExportManifest(strings.NewReader(input)); !errors.Is(err, ErrInvalidExport) {
+			t.Errorf("ReadExportManifest(%q) error = %v, want ErrInvalidExport", input, err)
+		}
+	}
+}
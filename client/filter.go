@@ -0,0 +1,253 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client/streaming"
+)
+
+// deleteByFilterPageSize is the number of matching records searched and deleted at once.
+const deleteByFilterPageSize = 100
+
+// ErrEmptyFilter is returned when a delete by filter has no criteria, as it would match every record.
+var ErrEmptyFilter = errors.New("filter has no criteria")
+
+// SearchFilter selects records by their name, annotations, age and owner.
+// All set criteria must match.
+type SearchFilter struct {
+	// Name is a record name pattern, e.g. "test-agent-*" to match a name prefix.
+	Name string
+	// Annotations must all be set on the record. Values are patterns, an empty value matches any value.
+	Annotations map[string]string
+	// CreatedBefore matches records added to the directory before the time.
+	CreatedBefore time.Time
+	// TrustDomain is the trust domain that pushed the record, as a pattern.
+	TrustDomain string
+}
+
+// IsEmpty reports whether the filter has no criteria.
+func (f SearchFilter) IsEmpty() bool {
+	return f.Name == "" && len(f.Annotations) == 0 && f.CreatedBefore.IsZero() && f.TrustDomain == ""
+}
+
+// Queries returns the search queries of the filter.
+func (f SearchFilter) Queries() []*searchv1.RecordQuery {
+	var queries []*searchv1.RecordQuery
+
+	if f.Name != "" {
+		queries = append(queries, &searchv1.RecordQuery{Type: searchv1.RecordQueryType_RECORD_QUERY_TYPE_NAME, Value: f.Name})
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(f.Annotations)) {
+		value := key
+		if f.Annotations[key] != "" {
+			value += "=" + f.Annotations[key]
+		}
+
+		queries = append(queries, &searchv1.RecordQuery{Type: searchv1.RecordQueryType_RECORD_QUERY_TYPE_ANNOTATION, Value: value})
+	}
+
+	if !f.CreatedBefore.IsZero() {
+		queries = append(queries, &searchv1.RecordQuery{
+			Type:     searchv1.RecordQueryType_RECORD_QUERY_TYPE_CREATED_AT,
+			Value:    f.CreatedBefore.UTC().Format(time.RFC3339),
+			Operator: searchv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_LESS_THAN,
+		})
+	}
+
+	if f.TrustDomain != "" {
+		queries = append(queries, &searchv1.RecordQuery{Type: searchv1.RecordQueryType_RECORD_QUERY_TYPE_TRUST_DOMAIN, Value: f.TrustDomain})
+	}
+
+	return queries
+}
+
+// Confirm makes DeleteByFilter delete the matching records.
+// Without it, DeleteByFilter is a dry run that only reports what would be deleted.
+func Confirm() DeleteOption {
+	return func(opts *deleteOptions) {
+		opts.confirm = true
+	}
+}
+
+// DeleteByFilter deletes the records that match the filter and returns a result for every match.
+//
+// It is a dry run by default: matching records are reported with DeleteResult.DryRun set and nothing
+// is deleted unless the Confirm option is given. Published records are pinned and skipped, they are
+// reported with DeleteResult.Pinned set unless WithForce is given, in which case they are unpublished
+// and deleted.
+//
+// Matches are searched and deleted page by page, so neither the client nor the server loads all of
+// them at once. A failure to search a page is reported as a result without a reference and ends the
+// delete. The filter must have at least one criterion, see ErrEmptyFilter.
+func (c *Client) DeleteByFilter(ctx context.Context, filter SearchFilter, opts ...DeleteOption) (<-chan DeleteResult, error) {
+	if filter.IsEmpty() {
+		return nil, ErrEmptyFilter
+	}
+
+	options := &deleteOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	queries := filter.Queries()
+
+	// Search the first page upfront, so that invalid filters fail the call
+	page, err := c.searchPage(ctx, queries, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	resultCh := make(chan DeleteResult)
+
+	go func() {
+		defer close(resultCh)
+
+		send := func(result DeleteResult) bool {
+			select {
+			case resultCh <- result:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// Kept records still match the filter and are skipped by the next search
+		var index, kept int
+
+		for len(page) > 0 {
+			results := c.deletePage(ctx, page, options)
+
+			for _, result := range results {
+				result.Index = index
+				index++
+
+				if result.DryRun || result.Pinned || result.Error != nil {
+					kept++
+				}
+
+				if !send(result) {
+					return
+				}
+			}
+
+			if len(page) < deleteByFilterPageSize {
+				return
+			}
+
+			page, err = c.searchPage(ctx, queries, kept)
+			if err != nil {
+				send(DeleteResult{Index: index, Error: err})
+
+				return
+			}
+		}
+	}()
+
+	return resultCh, nil
+}
+
+// deletePage deletes a page of matching records, skipping pinned records unless forced.
+// Nothing is deleted unless the delete is confirmed.
+func (c *Client) deletePage(ctx context.Context, cids []string, options *deleteOptions) []DeleteResult {
+	refs := make([]*corev1.RecordRef, len(cids))
+	for i, cid := range cids {
+		refs[i] = &corev1.RecordRef{Cid: cid}
+	}
+
+	pinned := make(map[string]bool)
+
+	if !options.force {
+		// Lookup failures are not fatal, the server rejects pinned records anyway
+		metas, _ := c.LookupBatch(ctx, refs)
+		for _, meta := range metas {
+			pinned[meta.GetCid()] = meta.GetPinned()
+		}
+	}
+
+	results := make([]DeleteResult, 0, len(refs))
+	deletable := make([]*corev1.RecordRef, 0, len(refs))
+
+	for _, ref := range refs {
+		switch {
+		case pinned[ref.GetCid()]:
+			results = append(results, DeleteResult{Ref: ref, Pinned: true})
+		case !options.confirm:
+			results = append(results, DeleteResult{Ref: ref, DryRun: true})
+		default:
+			deletable = append(deletable, ref)
+		}
+	}
+
+	if len(deletable) == 0 {
+		return results
+	}
+
+	if options.force {
+		ctx = storev1.ContextWithDeleteForce(ctx)
+	}
+
+	stream, err := c.DeleteStream(ctx, streaming.SliceToChan(ctx, deletable))
+	if err != nil {
+		for _, ref := range deletable {
+			results = append(results, DeleteResult{Ref: ref, Error: err})
+		}
+
+		return results
+	}
+
+	for {
+		select {
+		case err := <-stream.ErrCh():
+			results = append(results, DeleteResult{Error: err})
+		case result := <-stream.ResCh():
+			results = append(results, *result)
+		case <-stream.DoneCh():
+			return results
+		}
+	}
+}
+
+// searchPage returns a page of the CIDs of the records matching the queries,
+// including records superseded by an equivalent record.
+func (c *Client) searchPage(ctx context.Context, queries []*searchv1.RecordQuery, offset int) ([]string, error) {
+	limit := uint32(deleteByFilterPageSize)
+	pageOffset := uint32(offset) //nolint:gosec
+
+	stream, err := c.SearchServiceClient.Search(ctx, &searchv1.SearchRequest{
+		Queries:           queries,
+		Limit:             &limit,
+		Offset:            &pageOffset,
+		IncludeSuperseded: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search records: %w", err)
+	}
+
+	var cids []string
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return cids, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to search records: %w", err)
+		}
+
+		cids = append(cids, resp.GetRecordCid())
+	}
+}
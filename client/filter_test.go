@@ -0,0 +1,232 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// filterServer serves records by CID, which doubles as their name, and pages search results by CID.
+type filterServer struct {
+	storev1.UnimplementedStoreServiceServer
+	searchv1.UnimplementedSearchServiceServer
+
+	mu       sync.Mutex
+	records  map[string]bool // CID to pinned
+	searches int
+}
+
+func (s *filterServer) Search(req *searchv1.SearchRequest, stream searchv1.SearchService_SearchServer) error {
+	s.mu.Lock()
+	s.searches++
+
+	var matches []string
+
+	for _, cid := range slices.Sorted(maps.Keys(s.records)) {
+		if strings.HasPrefix(cid, strings.TrimSuffix(req.GetQueries()[0].GetValue(), "*")) {
+			matches = append(matches, cid)
+		}
+	}
+	s.mu.Unlock()
+
+	if req.GetLimit() > 100 {
+		return fmt.Errorf("unbounded search with limit %d", req.GetLimit())
+	}
+
+	matches = matches[min(int(req.GetOffset()), len(matches)):]
+	matches = matches[:min(int(req.GetLimit()), len(matches))]
+
+	for _, cid := range matches {
+		if err := stream.Send(&searchv1.SearchResponse{RecordCid: cid}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+func (s *filterServer) Lookup(stream storev1.StoreService_LookupServer) error {
+	for {
+		ref, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		s.mu.Lock()
+		pinned := s.records[ref.GetCid()]
+		s.mu.Unlock()
+
+		if err := stream.Send(&corev1.RecordMeta{Cid: ref.GetCid(), Pinned: pinned}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func (s *filterServer) DeleteWithAck(stream storev1.StoreService_DeleteWithAckServer) error {
+	for {
+		ref, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		resp := &storev1.DeleteResponse{RecordRef: ref}
+
+		s.mu.Lock()
+		if s.records[ref.GetCid()] && !storev1.IsDeleteForce(stream.Context()) {
+			resp.Error = &corev1.RecordError{Code: uint32(codes.FailedPrecondition), Message: "record is published"}
+		} else {
+			delete(s.records, ref.GetCid())
+		}
+		s.mu.Unlock()
+
+		if err := stream.Send(resp); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func (s *filterServer) cids() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Sorted(maps.Keys(s.records))
+}
+
+func TestDeleteByFilter(t *testing.T) {
+	const matching = 250
+
+	newServer := func() *filterServer {
+		s := &filterServer{records: map[string]bool{"prod-agent": false}}
+		for i := range matching {
+			// Every 50th test record is published
+			s.records[fmt.Sprintf("test-agent-%03d", i)] = i%50 == 0
+		}
+
+		return s
+	}
+
+	newClient := func(t *testing.T, s *filterServer) *Client {
+		t.Helper()
+
+		return newBufconnClient(t, func(gs *grpc.Server) {
+			storev1.RegisterStoreServiceServer(gs, s)
+			searchv1.RegisterSearchServiceServer(gs, s)
+		})
+	}
+
+	collect := func(t *testing.T, ch <-chan DeleteResult) []DeleteResult {
+		t.Helper()
+
+		var results []DeleteResult
+
+		for result := range ch {
+			if result.Ref == nil {
+				t.Fatalf("unexpected result without reference: %v", result.Error)
+			}
+
+			if result.Index != len(results) {
+				t.Errorf("expected result index %d, got %d", len(results), result.Index)
+			}
+
+			results = append(results, result)
+		}
+
+		return results
+	}
+
+	filter := SearchFilter{Name: "test-agent-*", CreatedBefore: time.Now().Add(-time.Hour)}
+
+	t.Run("dry run deletes nothing", func(t *testing.T) {
+		s := newServer()
+		c := newClient(t, s)
+
+		ch, err := c.DeleteByFilter(t.Context(), filter)
+		if err != nil {
+			t.Fatalf("DeleteByFilter() unexpected error: %v", err)
+		}
+
+		results := collect(t, ch)
+		if len(results) != matching {
+			t.Fatalf("expected %d candidates, got %d", matching, len(results))
+		}
+
+		var pinned int
+
+		for _, result := range results {
+			if result.Pinned {
+				pinned++
+			} else if !result.DryRun {
+				t.Errorf("expected a dry run result for %s, got %+v", result.Ref.GetCid(), result)
+			}
+		}
+
+		if pinned != matching/50 {
+			t.Errorf("expected %d pinned candidates, got %d", matching/50, pinned)
+		}
+
+		if got := len(s.cids()); got != matching+1 {
+			t.Errorf("expected no records to be deleted, %d of %d left", got, matching+1)
+		}
+	})
+
+	t.Run("confirmed run deletes matches and skips pinned records", func(t *testing.T) {
+		s := newServer()
+		c := newClient(t, s)
+
+		ch, err := c.DeleteByFilter(t.Context(), filter, Confirm())
+		if err != nil {
+			t.Fatalf("DeleteByFilter() unexpected error: %v", err)
+		}
+
+		results := collect(t, ch)
+		if len(results) != matching {
+			t.Fatalf("expected %d results, got %d", matching, len(results))
+		}
+
+		for _, result := range results {
+			if result.Error != nil || result.DryRun {
+				t.Errorf("unexpected result for %s: %+v", result.Ref.GetCid(), result)
+			}
+		}
+
+		want := []string{"prod-agent", "test-agent-000", "test-agent-050", "test-agent-100", "test-agent-150", "test-agent-200"}
+		if got := s.cids(); !slices.Equal(got, want) {
+			t.Errorf("expected records %v to be left, got %v", want, got)
+		}
+
+		if s.searches < 3 {
+			t.Errorf("expected matches to be searched page by page, got %d searches", s.searches)
+		}
+	})
+
+	t.Run("empty filter is rejected", func(t *testing.T) {
+		c := newClient(t, newServer())
+
+		if _, err := c.DeleteByFilter(t.Context(), SearchFilter{}, Confirm()); !errors.Is(err, ErrEmptyFilter) {
+			t.Errorf("expected ErrEmptyFilter, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// ComponentHealth describes the health of a single server component.
+type ComponentHealth struct {
+	// Name of the component, e.g. "store" or "routing"
+	Name string
+
+	// Serving status reported by the server
+	Status healthpb.HealthCheckResponse_ServingStatus
+
+	// Round-trip latency of the component check
+	Latency time.Duration
+}
+
+// Serving reports whether the component is able to serve requests.
+func (h ComponentHealth) Serving() bool {
+	return h.Status == healthpb.HealthCheckResponse_SERVING
+}
+
+// HealthStatus describes the health of the server and its components.
+type HealthStatus struct {
+	// Overall serving status of the server
+	Status healthpb.HealthCheckResponse_ServingStatus
+
+	// Round-trip latency of the overall check
+	Latency time.Duration
+
+	// Per-component health, sorted by name
+	Components []ComponentHealth
+}
+
+// Serving reports whether the server is able to serve requests.
+func (h HealthStatus) Serving() bool {
+	return h.Status == healthpb.HealthCheckResponse_SERVING
+}
+
+// HealthCheck checks the health of the server and each of its components
+// using the standard grpc.health.v1 Health service.
+func (c *Client) HealthCheck(ctx context.Context) (HealthStatus, error) {
+	overall, err := c.checkHealth(ctx, "")
+	if err != nil {
+		return HealthStatus{}, err
+	}
+
+	health := HealthStatus{
+		Status:  overall.Status,
+		Latency: overall.Latency,
+	}
+
+	// Discover components
+	list, err := c.healthClient.List(ctx, &healthpb.HealthListRequest{})
+	if err != nil {
+		// Servers without component checks only report overall health
+		if status.Code(err) == codes.Unimplemented {
+			return health, nil
+		}
+
+		return HealthStatus{}, fmt.Errorf("failed to list health components: %w", err)
+	}
+
+	names := make([]string, 0, len(list.GetStatuses()))
+	for name := range list.GetStatuses() {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	// Check each component individually to measure its latency
+	for _, name := range names {
+		component, err := c.checkHealth(ctx, name)
+		if err != nil {
+			return HealthStatus{}, err
+		}
+
+		health.Components = append(health.Components, component)
+	}
+
+	return health, nil
+}
+
+func (c *Client) checkHealth(ctx context.Context, name string) (ComponentHealth, error) {
+	start := time.Now()
+
+	resp, err := c.healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: name})
+	if err != nil {
+		return ComponentHealth{}, fmt.Errorf("failed to check health of %q: %w", name, err)
+	}
+
+	return ComponentHealth{
+		Name:    name,
+		Status:  resp.GetStatus(),
+		Latency: time.Since(start),
+	}, nil
+}
@@ -0,0 +1,49 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthCheck(t *testing.T) {
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus("store", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus("routing", healthpb.HealthCheckResponse_SERVING)
+
+	c := newBufconnClient(t, func(server *grpc.Server) { healthpb.RegisterHealthServer(server, healthServer) })
+
+	status, err := c.HealthCheck(t.Context())
+	if err != nil {
+		t.Fatalf("failed to check health: %v", err)
+	}
+
+	if status.Serving() {
+		t.Error("expected server to be not serving")
+	}
+
+	if len(status.Components) != 2 { //nolint:mnd
+		t.Fatalf("expected 2 components, got %d", len(status.Components))
+	}
+
+	// Components are sorted by name
+	if routing := status.Components[0]; routing.Name != "routing" || !routing.Serving() {
+		t.Errorf("expected routing to be serving, got %+v", routing)
+	}
+
+	if store := status.Components[1]; store.Name != "store" || store.Serving() {
+		t.Errorf("expected store to be not serving, got %+v", store)
+	}
+
+	for _, component := range status.Components {
+		if component.Latency <= 0 {
+			t.Errorf("expected positive latency for %s", component.Name)
+		}
+	}
+}
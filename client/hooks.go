@@ -0,0 +1,311 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/client/streaming"
+)
+
+// ErrHookPanic is wrapped by the HookError reported for a hook that panicked.
+var ErrHookPanic = errors.New("hook panicked")
+
+// Hooks are optional callbacks invoked for every item of an operation,
+// e.g. to log pushed CIDs, block pulls of specific CIDs or add metadata to the context.
+// Unset hooks are skipped. Hooks may be called concurrently and must be safe for concurrent use.
+//
+// A Before hook returning an error skips the item: it is not sent to the server,
+// and a HookError is reported in its place, see WithHooks.
+// After hooks are called with the outcome of every item, including items skipped by a Before hook.
+// Panics in hooks are recovered. A panicking Before hook skips the item with a HookError wrapping ErrHookPanic,
+// a panicking After hook is logged.
+type Hooks struct {
+	BeforePush func(ctx context.Context, record *corev1.Record) error
+	AfterPush  func(ctx context.Context, ref *corev1.RecordRef, err error)
+
+	BeforePull func(ctx context.Context, ref *corev1.RecordRef) error
+	AfterPull  func(ctx context.Context, record *corev1.Record, err error)
+
+	BeforeLookup func(ctx context.Context, ref *corev1.RecordRef) error
+	AfterLookup  func(ctx context.Context, meta *corev1.RecordMeta, err error)
+
+	BeforeDelete func(ctx context.Context, ref *corev1.RecordRef) error
+	AfterDelete  func(ctx context.Context, ref *corev1.RecordRef, err error)
+
+	BeforePublish func(ctx context.Context, req *routingv1.PublishRequest) error
+	AfterPublish  func(ctx context.Context, req *routingv1.PublishRequest, err error)
+}
+
+// HookError is reported for items skipped by a Before hook.
+// Use errors.As to distinguish items rejected by a hook from items the server failed to process.
+type HookError struct {
+	// Hook is the name of the hook, e.g. BeforePull.
+	Hook string
+	// Err is the error returned by the hook, or ErrHookPanic if it panicked.
+	Err error
+}
+
+func (e *HookError) Error() string {
+	return fmt.Sprintf("%s hook: %v", e.Hook, e.Err)
+}
+
+func (e *HookError) Unwrap() error {
+	return e.Err
+}
+
+// WithHooks invokes the hooks for every record pushed, pulled, looked up, deleted or published.
+// Hooks are invoked by the streaming methods for every item, and thus by the batch and single-item methods built on them.
+//
+// Items skipped by a Before hook are reported with a HookError: PullStream, LookupStream and DeleteStream
+// report it via the Error of the result at the item's Index, PushStream on its error channel,
+// and the batch methods in their returned error.
+func WithHooks(hooks Hooks) Option {
+	return func(opts *options) error {
+		opts.hooks = &hooks
+
+		return nil
+	}
+}
+
+// runBefore calls a Before hook, converting panics into errors.
+func runBefore[T any](ctx context.Context, name string, hook func(context.Context, *T) error, item *T) (err error) {
+	if hook == nil {
+		return nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = &HookError{Hook: name, Err: fmt.Errorf("%w: %v", ErrHookPanic, r)}
+		}
+	}()
+
+	if err := hook(ctx, item); err != nil {
+		return &HookError{Hook: name, Err: err}
+	}
+
+	return nil
+}
+
+// runAfter calls an After hook, logging panics.
+func runAfter[T any](ctx context.Context, name string, hook func(context.Context, *T, error), item *T, err error) {
+	if hook == nil {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("Hook panicked", "hook", name, "panic", r)
+		}
+	}()
+
+	hook(ctx, item, err)
+}
+
+// resultHooks are the hooks a resultStream invokes for every reference.
+type resultHooks[ResT any] struct {
+	before   func(context.Context, *corev1.RecordRef) error
+	after    func(context.Context, *ResT)
+	rejected func(index int, ref *corev1.RecordRef, err error) *ResT
+}
+
+func (c *Client) pullHooks() *resultHooks[PullResult] {
+	if c.hooks == nil || (c.hooks.BeforePull == nil && c.hooks.AfterPull == nil) {
+		return nil
+	}
+
+	return &resultHooks[PullResult]{
+		before: func(ctx context.Context, ref *corev1.RecordRef) error {
+			return runBefore(ctx, "BeforePull", c.hooks.BeforePull, ref)
+		},
+		after: func(ctx context.Context, result *PullResult) {
+			runAfter(ctx, "AfterPull", c.hooks.AfterPull, result.Record, result.Error)
+		},
+		rejected: func(index int, _ *corev1.RecordRef, err error) *PullResult {
+			return &PullResult{Index: index, Error: err}
+		},
+	}
+}
+
+func (c *Client) lookupHooks() *resultHooks[LookupResult] {
+	if c.hooks == nil || (c.hooks.BeforeLookup == nil && c.hooks.AfterLookup == nil) {
+		return nil
+	}
+
+	return &resultHooks[LookupResult]{
+		before: func(ctx context.Context, ref *corev1.RecordRef) error {
+			return runBefore(ctx, "BeforeLookup", c.hooks.BeforeLookup, ref)
+		},
+		after: func(ctx context.Context, result *LookupResult) {
+			runAfter(ctx, "AfterLookup", c.hooks.AfterLookup, result.Meta, result.Error)
+		},
+		rejected: func(index int, _ *corev1.RecordRef, err error) *LookupResult {
+			return &LookupResult{Index: index, Error: err}
+		},
+	}
+}
+
+func (c *Client) deleteHooks() *resultHooks[DeleteResult] {
+	if c.hooks == nil || (c.hooks.BeforeDelete == nil && c.hooks.AfterDelete == nil) {
+		return nil
+	}
+
+	return &resultHooks[DeleteResult]{
+		before: func(ctx context.Context, ref *corev1.RecordRef) error {
+			return runBefore(ctx, "BeforeDelete", c.hooks.BeforeDelete, ref)
+		},
+		after: func(ctx context.Context, result *DeleteResult) {
+			runAfter(ctx, "AfterDelete", c.hooks.AfterDelete, result.Ref, result.Error)
+		},
+		rejected: func(index int, ref *corev1.RecordRef, err error) *DeleteResult {
+			return &DeleteResult{Index: index, Ref: ref, Error: err}
+		},
+	}
+}
+
+// publishWithHooks runs the publish hooks around the publish call.
+func (c *Client) publishWithHooks(ctx context.Context, req *routingv1.PublishRequest, publish func() error) error {
+	if c.hooks == nil {
+		return publish()
+	}
+
+	err := runBefore(ctx, "BeforePublish", c.hooks.BeforePublish, req)
+	if err == nil {
+		err = publish()
+	}
+
+	runAfter(ctx, "AfterPublish", c.hooks.AfterPublish, req, err)
+
+	return err
+}
+
+// pushStreamFiltered pushes the records accepted by the BeforePush hook on the stream started by push,
+// encrypted if encryption is enabled with WithEncryption.
+// Records skipped by the hook or failing to encrypt are reported on the error channel of the result with their input position.
+func (c *Client) pushStreamFiltered(
+	ctx context.Context,
+	recordsCh <-chan *corev1.Record,
+	push func(<-chan *corev1.Record) (streaming.StreamResult[corev1.RecordRef], error),
+) (streaming.StreamResult[corev1.RecordRef], error) {
+	accepted := make(chan *corev1.Record)
+
+	inner, err := push(accepted)
+	if err != nil {
+		close(accepted)
+
+		return nil, err
+	}
+
+	var afterPush func(context.Context, *corev1.RecordRef, error)
+	if c.hooks != nil {
+		afterPush = c.hooks.AfterPush
+	}
+
+	rejected := make(chan error)
+
+	// Filter the input, stopping early if the stream ends before all records are sent
+	go func() {
+		defer close(rejected)
+		defer close(accepted)
+
+		index := 0
+
+		for record := range recordsCh {
+			if record, err := c.beforePush(ctx, record); err != nil {
+				runAfter(ctx, "AfterPush", afterPush, nil, err)
+
+				// Rejected records are always drained by the merging goroutine
+				rejected <- fmt.Errorf("failed to push record at index %d: %w", index, err)
+			} else {
+				select {
+				case accepted <- record:
+				case <-inner.DoneCh():
+					return
+				}
+			}
+
+			index++
+		}
+	}()
+
+	result := &hookedResult[corev1.RecordRef]{
+		resCh:  make(chan *corev1.RecordRef),
+		errCh:  make(chan error),
+		doneCh: make(chan struct{}),
+	}
+
+	// Merge the results of the stream with the rejected records
+	go func() {
+		defer close(result.doneCh)
+
+		innerDone := inner.DoneCh()
+
+		for innerDone != nil || rejected != nil {
+			select {
+			case ref := <-inner.ResCh():
+				var err error
+				if ref.GetError() != nil {
+					err = recordError(ref.GetError())
+				}
+
+				runAfter(ctx, "AfterPush", afterPush, ref, err)
+				result.resCh <- ref
+			case err := <-inner.ErrCh():
+				result.errCh <- err
+			case err, ok := <-rejected:
+				if !ok {
+					rejected = nil
+
+					continue
+				}
+
+				result.errCh <- err
+			case <-innerDone:
+				innerDone = nil
+			}
+		}
+	}()
+
+	return result, nil
+}
+
+// beforePush runs the BeforePush hook and encrypts the record if encryption is enabled.
+func (c *Client) beforePush(ctx context.Context, record *corev1.Record) (*corev1.Record, error) {
+	c.applyCIDOptions(record)
+
+	if c.hooks != nil {
+		if err := runBefore(ctx, "BeforePush", c.hooks.BeforePush, record); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.encryption == nil {
+		return record, nil
+	}
+
+	return sealRecord(ctx, c.encryption, record)
+}
+
+// hookedResult is the stream result of a push stream with hooks.
+type hookedResult[OutT any] struct {
+	resCh  chan *OutT
+	errCh  chan error
+	doneCh chan struct{}
+}
+
+func (r *hookedResult[OutT]) ResCh() <-chan *OutT {
+	return r.resCh
+}
+
+func (r *hookedResult[OutT]) ErrCh() <-chan error {
+	return r.errCh
+}
+
+func (r *hookedResult[OutT]) DoneCh() <-chan struct{} {
+	return r.doneCh
+}
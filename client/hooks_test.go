@@ -0,0 +1,256 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client/streaming"
+	"google.golang.org/grpc"
+)
+
+var errBlocked = errors.New("blocked")
+
+// countingHooks counts hook invocations and blocks the CIDs in blocked.
+type countingHooks struct {
+	before, after, afterErrors atomic.Int32
+
+	blocked map[string]bool
+	panics  map[string]bool
+}
+
+func (h *countingHooks) beforeRef(_ context.Context, cid string) error {
+	h.before.Add(1)
+
+	if h.panics[cid] {
+		panic("hook failure")
+	}
+
+	if h.blocked[cid] {
+		return errBlocked
+	}
+
+	return nil
+}
+
+func (h *countingHooks) afterRef(_ context.Context, err error) {
+	h.after.Add(1)
+
+	if err != nil {
+		h.afterErrors.Add(1)
+	}
+}
+
+func (h *countingHooks) hooks() Hooks {
+	return Hooks{
+		BeforePush: func(ctx context.Context, record *corev1.Record) error { return h.beforeRef(ctx, record.GetCid()) },
+		AfterPush:  func(ctx context.Context, _ *corev1.RecordRef, err error) { h.afterRef(ctx, err) },
+		BeforePull: func(ctx context.Context, ref *corev1.RecordRef) error { return h.beforeRef(ctx, ref.GetCid()) },
+		AfterPull:  func(ctx context.Context, _ *corev1.Record, err error) { h.afterRef(ctx, err) },
+	}
+}
+
+func collectResults[T any](t *testing.T, result streaming.StreamResult[T]) ([]*T, []error) {
+	t.Helper()
+
+	var (
+		results []*T
+		errs    []error
+	)
+
+	for {
+		select {
+		case err := <-result.ErrCh():
+			errs = append(errs, err)
+		case res := <-result.ResCh():
+			results = append(results, res)
+		case <-result.DoneCh():
+			return results, errs
+		}
+	}
+}
+
+func TestHooksPullStream(t *testing.T) {
+	hooks := &countingHooks{blocked: map[string]bool{}, panics: map[string]bool{}}
+
+	c, refs := newResultsClient(t, 8, WithHooks(hooks.hooks()))
+
+	// Block an existing and a missing record, and panic for another existing record
+	hooks.blocked[refs[2].GetCid()] = true
+	hooks.blocked[refs[3].GetCid()] = true
+	hooks.panics[refs[4].GetCid()] = true
+
+	result, err := c.PullStream(t.Context(), streaming.SliceToChan(t.Context(), refs))
+	if err != nil {
+		t.Fatalf("failed to create pull stream: %v", err)
+	}
+
+	results, errs := collectResults(t, result)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected stream errors: %v", errs)
+	}
+
+	if len(results) != len(refs) {
+		t.Fatalf("expected %d results, got %d", len(refs), len(results))
+	}
+
+	seen := map[int]bool{}
+
+	for _, res := range results {
+		seen[res.Index] = true
+
+		var hookErr *HookError
+
+		switch res.Index {
+		case 2, 3:
+			if !errors.As(res.Error, &hookErr) || !errors.Is(res.Error, errBlocked) || hookErr.Hook != "BeforePull" {
+				t.Errorf("expected blocked HookError at index %d, got %v", res.Index, res.Error)
+			}
+		case 4:
+			if !errors.As(res.Error, &hookErr) || !errors.Is(res.Error, ErrHookPanic) {
+				t.Errorf("expected panic HookError at index %d, got %v", res.Index, res.Error)
+			}
+		default:
+			if errors.As(res.Error, &hookErr) {
+				t.Errorf("unexpected HookError at index %d: %v", res.Index, res.Error)
+			}
+
+			if res.Index%2 == 0 && res.Record.GetCid() != refs[res.Index].GetCid() {
+				t.Errorf("expected record %s at index %d, got %s", refs[res.Index].GetCid(), res.Index, res.Record.GetCid())
+			}
+		}
+	}
+
+	if len(seen) != len(refs) {
+		t.Errorf("expected a result for every index, got %v", seen)
+	}
+
+	if got := hooks.before.Load(); got != int32(len(refs)) {
+		t.Errorf("expected %d BeforePull calls, got %d", len(refs), got)
+	}
+
+	if got := hooks.after.Load(); got != int32(len(refs)) {
+		t.Errorf("expected %d AfterPull calls, got %d", len(refs), got)
+	}
+
+	// Missing records 1, 5, 7 plus the skipped records 2, 3, 4
+	if got := hooks.afterErrors.Load(); got != 6 {
+		t.Errorf("expected 6 AfterPull calls with errors, got %d", got)
+	}
+}
+
+func TestHooksPushStream(t *testing.T) {
+	hooks := &countingHooks{blocked: map[string]bool{}, panics: map[string]bool{}}
+
+	c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, pushServer{}) }, WithHooks(hooks.hooks()))
+
+	var records []*corev1.Record
+
+	for i := range 6 {
+		records = append(records, corev1.New(&typesv1alpha1.Record{
+			Name:          fmt.Sprintf("hooks-agent-%d", i),
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+		}))
+	}
+
+	hooks.blocked[records[1].GetCid()] = true
+	hooks.panics[records[4].GetCid()] = true
+
+	result, err := c.PushStream(t.Context(), streaming.SliceToChan(t.Context(), records))
+	if err != nil {
+		t.Fatalf("failed to create push stream: %v", err)
+	}
+
+	refs, errs := collectResults(t, result)
+
+	if len(refs) != 4 {
+		t.Errorf("expected 4 pushed records, got %d", len(refs))
+	}
+
+	for _, ref := range refs {
+		if hooks.blocked[ref.GetCid()] || hooks.panics[ref.GetCid()] {
+			t.Errorf("skipped record %s was pushed", ref.GetCid())
+		}
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors for skipped records, got %v", errs)
+	}
+
+	var hookErr *HookError
+	if !errors.As(errs[0], &hookErr) || !errors.Is(errs[0], errBlocked) || hookErr.Hook != "BeforePush" {
+		t.Errorf("expected blocked HookError, got %v", errs[0])
+	}
+
+	if !errors.Is(errs[1], ErrHookPanic) {
+		t.Errorf("expected panic HookError, got %v", errs[1])
+	}
+
+	if got := hooks.before.Load(); got != 6 {
+		t.Errorf("expected 6 BeforePush calls, got %d", got)
+	}
+
+	if got := hooks.after.Load(); got != 6 {
+		t.Errorf("expected 6 AfterPush calls, got %d", got)
+	}
+
+	t.Run("PushBatch", func(t *testing.T) {
+		_, err := c.PushBatch(t.Context(), records)
+		if !errors.As(err, &hookErr) {
+			t.Errorf("expected HookError from PushBatch, got %v", err)
+		}
+	})
+}
+
+func TestHooksPublish(t *testing.T) {
+	routing := &publishServer{}
+
+	var after atomic.Int32
+
+	c := newBufconnClient(t, func(s *grpc.Server) { routingv1.RegisterRoutingServiceServer(s, routing) }, WithHooks(Hooks{
+		BeforePublish: func(_ context.Context, req *routingv1.PublishRequest) error {
+			if len(req.GetRecordRefs().GetRefs()) == 0 {
+				return errBlocked
+			}
+
+			return nil
+		},
+		AfterPublish: func(context.Context, *routingv1.PublishRequest, error) {
+			after.Add(1)
+
+			panic("after hook failure")
+		},
+	}))
+
+	err := c.Publish(t.Context(), &routingv1.PublishRequest{})
+
+	var hookErr *HookError
+	if !errors.As(err, &hookErr) || hookErr.Hook != "BeforePublish" {
+		t.Errorf("expected BeforePublish HookError, got %v", err)
+	}
+
+	err = c.Publish(t.Context(), &routingv1.PublishRequest{
+		Request: &routingv1.PublishRequest_RecordRefs{RecordRefs: &routingv1.RecordRefs{Refs: []*corev1.RecordRef{{Cid: "a"}}}},
+	})
+	if err != nil {
+		t.Errorf("unexpected publish error: %v", err)
+	}
+
+	if len(routing.published) != 1 {
+		t.Errorf("expected only the accepted request to be published, got %v", routing.published)
+	}
+
+	if after.Load() != 2 {
+		t.Errorf("expected AfterPublish to be called twice, got %d", after.Load())
+	}
+}
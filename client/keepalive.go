@@ -0,0 +1,91 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ErrStreamStale is returned by streams that received neither a message nor a heartbeat within the
+// stale timeout, see WithStreamStaleTimeout. The connection was likely dropped silently, e.g. by a
+// load balancer closing idle connections.
+var ErrStreamStale = errors.New("stream is stale")
+
+// heartbeatsPerStaleTimeout is the number of heartbeats requested per stale timeout,
+// so that a single late heartbeat does not make a stream stale.
+const heartbeatsPerStaleTimeout = 3
+
+// heartbeatInterval returns the heartbeat interval to request for streams with the stale timeout,
+// nil if the stale timeout is disabled.
+func heartbeatInterval(staleTimeout time.Duration) *durationpb.Duration {
+	if staleTimeout <= 0 {
+		return nil
+	}
+
+	return durationpb.New(staleTimeout / heartbeatsPerStaleTimeout)
+}
+
+// staleWatchdog cancels the context of a stream with ErrStreamStale if it is not reset within the timeout.
+//
+//nolint:containedctx // The context is the context of the watched stream
+type staleWatchdog struct {
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+	timeout time.Duration
+	timer   *time.Timer
+}
+
+// newStaleWatchdog returns a watchdog of a stream to open with its context.
+// The watchdog is disabled if the timeout is zero.
+func newStaleWatchdog(ctx context.Context, timeout time.Duration) *staleWatchdog {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	w := &staleWatchdog{ctx: ctx, cancel: cancel, timeout: timeout}
+
+	if timeout > 0 {
+		w.timer = time.AfterFunc(timeout, func() {
+			cancel(fmt.Errorf("%w: no message received for %s", ErrStreamStale, timeout))
+		})
+	}
+
+	return w
+}
+
+// pause stops the timeout while a received message is handed to a consumer, which may be slow.
+func (w *staleWatchdog) pause() {
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+}
+
+// reset restarts the timeout once a message was received, or handed to a consumer.
+func (w *staleWatchdog) reset() {
+	if w.timer != nil {
+		w.timer.Reset(w.timeout)
+	}
+}
+
+// stop stops the watchdog and cancels the context of the stream.
+func (w *staleWatchdog) stop() {
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+
+	w.cancel(nil)
+}
+
+// err returns the stale error if the stream failed with err because it was canceled as stale,
+// and err otherwise.
+func (w *staleWatchdog) err(err error) error {
+	if cause := context.Cause(w.ctx); errors.Is(cause, ErrStreamStale) {
+		return cause
+	}
+
+	return err
+}
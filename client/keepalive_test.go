@@ -0,0 +1,238 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// quietServer stops responding after sending heartbeats for the heartbeat period, like a server behind
+// a load balancer that silently dropped the connection. Events and records are sent after the heartbeats.
+type quietServer struct {
+	storev1.UnimplementedStoreServiceServer
+	routingv1.UnimplementedRoutingServiceServer
+
+	heartbeats time.Duration
+	intervals  chan time.Duration
+}
+
+func (s *quietServer) heartbeat(interval time.Duration, send func() error) error {
+	s.intervals <- interval
+
+	if s.heartbeats == 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	deadline := time.After(s.heartbeats)
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := send(); err != nil {
+				return err
+			}
+		case <-deadline:
+			return nil
+		}
+	}
+}
+
+func (s *quietServer) WatchStore(req *storev1.WatchStoreRequest, stream storev1.StoreService_WatchStoreServer) error {
+	err := s.heartbeat(req.GetHeartbeatInterval().AsDuration(), func() error {
+		return stream.Send(&storev1.StoreEvent{Type: storev1.StoreEventType_STORE_EVENT_TYPE_HEARTBEAT}) //nolint:wrapcheck
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.heartbeats > 0 {
+		if err := stream.Send(&storev1.StoreEvent{Sequence: 1, Type: storev1.StoreEventType_STORE_EVENT_TYPE_PUSHED}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	<-stream.Context().Done()
+
+	return nil
+}
+
+func (s *quietServer) List(req *routingv1.ListRequest, stream routingv1.RoutingService_ListServer) error {
+	err := s.heartbeat(req.GetHeartbeatInterval().AsDuration(), func() error {
+		return stream.Send(&routingv1.ListResponse{Heartbeat: true}) //nolint:wrapcheck
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.heartbeats > 0 {
+		return stream.Send(&routingv1.ListResponse{RecordRef: &corev1.RecordRef{Cid: "bafy"}}) //nolint:wrapcheck
+	}
+
+	<-stream.Context().Done()
+
+	return nil
+}
+
+func newQuietClient(t *testing.T, server *quietServer, staleTimeout time.Duration) *Client {
+	t.Helper()
+
+	server.intervals = make(chan time.Duration, 1)
+
+	return newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterStoreServiceServer(s, server)
+		routingv1.RegisterRoutingServiceServer(s, server)
+	}, WithStreamStaleTimeout(staleTimeout))
+}
+
+func TestWatchStoreStale(t *testing.T) {
+	const staleTimeout = 300 * time.Millisecond
+
+	t.Run("quiet stream", func(t *testing.T) {
+		server := &quietServer{}
+		c := newQuietClient(t, server, staleTimeout)
+
+		start := time.Now()
+
+		eventCh, err := c.WatchStore(t.Context(), 0)
+		if err != nil {
+			t.Fatalf("WatchStore() error = %v", err)
+		}
+
+		if interval := <-server.intervals; interval != staleTimeout/3 {
+			t.Errorf("heartbeat interval = %s, want %s", interval, staleTimeout/3)
+		}
+
+		select {
+		case event := <-eventCh:
+			if !errors.Is(event.Error, ErrStreamStale) {
+				t.Fatalf("event error = %v, want ErrStreamStale", event.Error)
+			}
+		case <-time.After(5 * staleTimeout):
+			t.Fatal("watch did not fail within the stale timeout")
+		}
+
+		if elapsed := time.Since(start); elapsed < staleTimeout {
+			t.Errorf("watch failed after %s, before the stale timeout", elapsed)
+		}
+	})
+
+	t.Run("heartbeats", func(t *testing.T) {
+		server := &quietServer{heartbeats: 3 * staleTimeout}
+		c := newQuietClient(t, server, staleTimeout)
+
+		eventCh, err := c.WatchStore(t.Context(), 0)
+		if err != nil {
+			t.Fatalf("WatchStore() error = %v", err)
+		}
+
+		// Heartbeats keep the watch alive, and are not passed on
+		event := <-eventCh
+		if event.Error != nil {
+			t.Fatalf("event error = %v", event.Error)
+		}
+
+		if event.GetSequence() != 1 {
+			t.Errorf("event sequence = %d, want 1", event.GetSequence())
+		}
+	})
+}
+
+func TestListResultsStale(t *testing.T) {
+	const staleTimeout = 300 * time.Millisecond
+
+	t.Run("quiet stream", func(t *testing.T) {
+		c := newQuietClient(t, &quietServer{}, staleTimeout)
+
+		results, err := c.ListResults(t.Context(), &routingv1.ListRequest{})
+		if err != nil {
+			t.Fatalf("ListResults() error = %v", err)
+		}
+
+		select {
+		case result := <-results:
+			if !errors.Is(result.Error, ErrStreamStale) {
+				t.Fatalf("result error = %v, want ErrStreamStale", result.Error)
+			}
+		case <-time.After(5 * staleTimeout):
+			t.Fatal("list did not fail within the stale timeout")
+		}
+	})
+
+	t.Run("heartbeats", func(t *testing.T) {
+		c := newQuietClient(t, &quietServer{heartbeats: 3 * staleTimeout}, staleTimeout)
+
+		items, err := c.List(t.Context(), &routingv1.ListRequest{})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+
+		var cids []string
+		for item := range items {
+			cids = append(cids, item.GetRecordRef().GetCid())
+		}
+
+		if len(cids) != 1 || cids[0] != "bafy" {
+			t.Errorf("listed %v, want [bafy]", cids)
+		}
+	})
+}
+
+func TestKeepaliveParams(t *testing.T) {
+	defaults := keepalive.ClientParameters{Time: DefaultKeepaliveTime, Timeout: DefaultKeepaliveTimeout}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want keepalive.ClientParameters
+	}{
+		{
+			name: "defaults",
+			opts: []Option{WithConfig(&Config{})},
+			want: defaults,
+		},
+		{
+			name: "configuration",
+			opts: []Option{WithConfig(&Config{KeepaliveTime: time.Minute, KeepalivePermitWithoutStream: true})},
+			want: keepalive.ClientParameters{Time: time.Minute, Timeout: DefaultKeepaliveTimeout, PermitWithoutStream: true},
+		},
+		{
+			name: "option",
+			opts: []Option{
+				WithConfig(&Config{KeepaliveTime: time.Minute}),
+				WithKeepalive(20*time.Second, 5*time.Second, true),
+			},
+			want: keepalive.ClientParameters{Time: 20 * time.Second, Timeout: 5 * time.Second, PermitWithoutStream: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &options{}
+			for _, opt := range tt.opts {
+				if err := opt(o); err != nil {
+					t.Fatalf("option error = %v", err)
+				}
+			}
+
+			if got := o.keepaliveParams(); got != tt.want {
+				t.Errorf("keepaliveParams() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if err := WithKeepalive(0, time.Second, false)(&options{}); err == nil {
+		t.Error("expected a zero keepalive time to be rejected")
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+)
+
+// DeprecationInfo describes why a pulled record should no longer be used.
+type DeprecationInfo struct {
+	// Status is the lifecycle status of the record, either deprecated or withdrawn.
+	Status corev1.LifecycleStatus
+	// SuccessorCID is the CID of the record that replaces it, if any.
+	SuccessorCID string
+	// Reason is the reason given by the publisher, if any.
+	Reason string
+}
+
+// NewDeprecationInfo returns the deprecation info of a pulled record, or nil if the record is active.
+func NewDeprecationInfo(record *corev1.Record) *DeprecationInfo {
+	lifecycle := record.GetLifecycle()
+	if lifecycle.IsActive() {
+		return nil
+	}
+
+	return &DeprecationInfo{
+		Status:       lifecycle.GetStatus(),
+		SuccessorCID: lifecycle.GetSuccessorCid(),
+		Reason:       lifecycle.GetReason(),
+	}
+}
+
+// Withdrawn reports whether the record was withdrawn rather than deprecated.
+func (d *DeprecationInfo) Withdrawn() bool {
+	return d != nil && d.Status == corev1.LifecycleStatus_LIFECYCLE_STATUS_WITHDRAWN
+}
+
+// String returns a human-readable warning, e.g. "deprecated: superseded by v2 (use <cid> instead)".
+func (d *DeprecationInfo) String() string {
+	if d == nil {
+		return ""
+	}
+
+	lifecycle := &corev1.Lifecycle{Status: d.Status}
+	msg := lifecycle.StatusName()
+
+	if d.Reason != "" {
+		msg += ": " + d.Reason
+	}
+
+	if d.SuccessorCID != "" {
+		msg += fmt.Sprintf(" (use %s instead)", d.SuccessorCID)
+	}
+
+	return msg
+}
+
+// SetRecordLifecycle sets the lifecycle status of a record and returns its updated metadata.
+// Only callers of the trust domain that pushed the record may change its status.
+func (c *Client) SetRecordLifecycle(ctx context.Context, cid string, lifecycle *corev1.Lifecycle) (*corev1.RecordMeta, error) {
+	meta, err := c.SetLifecycle(ctx, &storev1.SetLifecycleRequest{
+		RecordRef: &corev1.RecordRef{Cid: cid},
+		Lifecycle: lifecycle,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set record lifecycle: %w", err)
+	}
+
+	return meta, nil
+}
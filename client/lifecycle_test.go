@@ -0,0 +1,119 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client/streaming"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// lifecycleServer stores lifecycle updates on the records it serves.
+type lifecycleServer struct {
+	pullServer
+}
+
+func (s lifecycleServer) SetLifecycle(_ context.Context, req *storev1.SetLifecycleRequest) (*corev1.RecordMeta, error) {
+	record, ok := s.records[req.GetRecordRef().GetCid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", req.GetRecordRef().GetCid())
+	}
+
+	record.Lifecycle = req.GetLifecycle()
+
+	return &corev1.RecordMeta{Cid: req.GetRecordRef().GetCid(), Lifecycle: req.GetLifecycle()}, nil
+}
+
+func TestSetRecordLifecycle(t *testing.T) {
+	var refs []*corev1.RecordRef
+
+	records := make(map[string]*corev1.Record)
+
+	for _, version := range []string{"v1.0.0", "v2.0.0"} {
+		record := corev1.New(&typesv1alpha1.Record{Name: "agent", Version: version, SchemaVersion: "0.7.0"})
+		records[record.GetCid()] = record
+		refs = append(refs, &corev1.RecordRef{Cid: record.GetCid()})
+	}
+
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterStoreServiceServer(s, lifecycleServer{pullServer{records: records}})
+	})
+
+	pullOne := func(t *testing.T) *PullResult {
+		t.Helper()
+
+		result, err := c.PullStream(t.Context(), streaming.SliceToChan(t.Context(), refs[:1]))
+		if err != nil {
+			t.Fatalf("PullStream() unexpected error: %v", err)
+		}
+
+		var res *PullResult
+
+		for done := false; !done; {
+			select {
+			case err := <-result.ErrCh():
+				t.Fatalf("PullStream() unexpected stream error: %v", err)
+			case r := <-result.ResCh():
+				res = r
+			case <-result.DoneCh():
+				done = true
+			}
+		}
+
+		if res == nil || res.Error != nil {
+			t.Fatalf("expected a pulled record, got %v", res)
+		}
+
+		return res
+	}
+
+	if res := pullOne(t); res.Deprecation != nil {
+		t.Errorf("expected no deprecation for an active record, got %v", res.Deprecation)
+	}
+
+	meta, err := c.SetRecordLifecycle(t.Context(), refs[0].GetCid(), &corev1.Lifecycle{
+		Status:       corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED,
+		SuccessorCid: refs[1].GetCid(),
+		Reason:       "superseded by v2",
+	})
+	if err != nil {
+		t.Fatalf("SetRecordLifecycle() unexpected error: %v", err)
+	}
+
+	if meta.GetLifecycle().GetStatus() != corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED {
+		t.Errorf("expected deprecated status, got %v", meta.GetLifecycle().GetStatus())
+	}
+
+	res := pullOne(t)
+	if res.Deprecation == nil || res.Deprecation.SuccessorCID != refs[1].GetCid() || res.Deprecation.Withdrawn() {
+		t.Fatalf("expected deprecation with successor %s, got %v", refs[1].GetCid(), res.Deprecation)
+	}
+
+	want := "deprecated: superseded by v2 (use " + refs[1].GetCid() + " instead)"
+	if got := res.Deprecation.String(); got != want {
+		t.Errorf("expected warning %q, got %q", want, got)
+	}
+
+	if _, err := c.SetRecordLifecycle(t.Context(), refs[0].GetCid(), &corev1.Lifecycle{
+		Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_WITHDRAWN,
+	}); err != nil {
+		t.Fatalf("SetRecordLifecycle() unexpected error: %v", err)
+	}
+
+	if res := pullOne(t); !res.Deprecation.Withdrawn() {
+		t.Errorf("expected withdrawn record, got %v", res.Deprecation)
+	}
+
+	_, err = c.SetRecordLifecycle(t.Context(), "missing", &corev1.Lifecycle{})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for a missing record, got %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// ErrLineageCycle is returned by History when a record is its own ancestor.
+	ErrLineageCycle = errors.New("record lineage contains a cycle")
+
+	// ErrMissingAncestor is returned by History when a predecessor record does not exist.
+	ErrMissingAncestor = errors.New("record lineage references a missing ancestor")
+)
+
+// PushUpdate pushes a new version of a record linked to its predecessor.
+// The predecessor CID is stamped into the previous_cid annotation of a copy
+// of the record before push, so the given record is not modified.
+func (c *Client) PushUpdate(ctx context.Context, record *corev1.Record, previousRef *corev1.RecordRef) (*corev1.RecordRef, error) {
+	if previousRef.GetCid() == "" {
+		return nil, errors.New("previous record reference is required")
+	}
+
+	update, ok := proto.Clone(record).(*corev1.Record)
+	if !ok {
+		return nil, errors.New("failed to copy record")
+	}
+
+	if err := update.SetPreviousCid(previousRef.GetCid()); err != nil {
+		return nil, fmt.Errorf("failed to link record to its predecessor: %w", err)
+	}
+
+	return c.Push(ctx, update)
+}
+
+// History returns the metadata of the given record followed by all of its
+// ancestors, newest first. The chain is walked via Lookup until a record has
+// no predecessor.
+//
+// If the chain contains a cycle or references a record that does not exist,
+// the history collected so far is returned along with ErrLineageCycle or
+// ErrMissingAncestor respectively.
+func (c *Client) History(ctx context.Context, ref *corev1.RecordRef) ([]*corev1.RecordMeta, error) {
+	meta, err := c.Lookup(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup record %s: %w", ref.GetCid(), err)
+	}
+
+	history := []*corev1.RecordMeta{meta}
+	seen := map[string]bool{ref.GetCid(): true}
+
+	for {
+		previousCid := meta.GetPreviousCid()
+		if previousCid == "" {
+			return history, nil
+		}
+
+		if seen[previousCid] {
+			return history, fmt.Errorf("%w: %s", ErrLineageCycle, previousCid)
+		}
+
+		seen[previousCid] = true
+
+		meta, err = c.Lookup(ctx, &corev1.RecordRef{Cid: previousCid})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return history, fmt.Errorf("%w: %s", ErrMissingAncestor, previousCid)
+			}
+
+			return history, fmt.Errorf("failed to lookup ancestor %s: %w", previousCid, err)
+		}
+
+		history = append(history, meta)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// lookupServer serves record metadata from a fixed set of records.
+// Unknown references are reported as per-record NotFound errors.
+type lookupServer struct {
+	pushServer
+
+	metas map[string]*corev1.RecordMeta
+}
+
+func (s lookupServer) Lookup(stream storev1.StoreService_LookupServer) error {
+	for {
+		ref, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		meta, ok := s.metas[ref.GetCid()]
+		if !ok {
+			meta = &corev1.RecordMeta{
+				Cid:   ref.GetCid(),
+				Error: &corev1.RecordError{Code: uint32(codes.NotFound), Message: "record not found: " + ref.GetCid()},
+			}
+		}
+
+		if err := stream.Send(meta); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func newLineageClient(t *testing.T, metas ...*corev1.RecordMeta) *Client {
+	t.Helper()
+
+	server := lookupServer{metas: map[string]*corev1.RecordMeta{}}
+	for _, meta := range metas {
+		server.metas[meta.GetCid()] = meta
+	}
+
+	return newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) })
+}
+
+func newLineageMeta(cid, previousCid string) *corev1.RecordMeta {
+	meta := &corev1.RecordMeta{Cid: cid, Annotations: map[string]string{}}
+	if previousCid != "" {
+		meta.Annotations[corev1.MetaAnnotationPreviousCid] = previousCid
+	}
+
+	return meta
+}
+
+func historyCids(history []*corev1.RecordMeta) []string {
+	cids := make([]string, 0, len(history))
+	for _, meta := range history {
+		cids = append(cids, meta.GetCid())
+	}
+
+	return cids
+}
+
+func TestHistory(t *testing.T) {
+	tests := []struct {
+		name        string
+		metas       []*corev1.RecordMeta
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name: "linear chain",
+			metas: []*corev1.RecordMeta{
+				newLineageMeta("v1", ""),
+				newLineageMeta("v2", "v1"),
+				newLineageMeta("v3", "v2"),
+			},
+			expected: []string{"v3", "v2", "v1"},
+		},
+		{
+			name: "cycle",
+			metas: []*corev1.RecordMeta{
+				newLineageMeta("v1", "v3"),
+				newLineageMeta("v2", "v1"),
+				newLineageMeta("v3", "v2"),
+			},
+			expected:    []string{"v3", "v2", "v1"},
+			expectedErr: ErrLineageCycle,
+		},
+		{
+			name: "missing ancestor",
+			metas: []*corev1.RecordMeta{
+				newLineageMeta("v2", "v1"),
+				newLineageMeta("v3", "v2"),
+			},
+			expected:    []string{"v3", "v2"},
+			expectedErr: ErrMissingAncestor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newLineageClient(t, tt.metas...)
+
+			history, err := c.History(t.Context(), &corev1.RecordRef{Cid: "v3"})
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+
+			if got := historyCids(history); !slices.Equal(got, tt.expected) {
+				t.Errorf("expected history %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPushUpdate(t *testing.T) {
+	c := newLineageClient(t)
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "lineage-agent",
+		Version:       "v2.0.0",
+		SchemaVersion: "0.7.0",
+	})
+	originalCid := record.GetCid()
+
+	ref, err := c.PushUpdate(t.Context(), record, &corev1.RecordRef{Cid: "previous"})
+	if err != nil {
+		t.Fatalf("failed to push update: %v", err)
+	}
+
+	if record.GetCid() != originalCid {
+		t.Error("expected the given record to be unmodified")
+	}
+
+	expected := corev1.New(&typesv1alpha1.Record{
+		Name:          "lineage-agent",
+		Version:       "v2.0.0",
+		SchemaVersion: "0.7.0",
+		Annotations:   map[string]string{corev1.AnnotationPreviousCid: "previous"},
+	})
+
+	if ref.GetCid() != expected.GetCid() {
+		t.Errorf("expected pushed record CID %s, got %s", expected.GetCid(), ref.GetCid())
+	}
+
+	if _, err := c.PushUpdate(t.Context(), record, nil); err == nil {
+		t.Error("expected error for missing previous reference")
+	}
+}
@@ -0,0 +1,743 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	apilabels "github.com/agntcy/dir/api/labels"
+	"github.com/agntcy/dir/api/names"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// localTarget is the address local clients connect to their in-process server with.
+	localTarget = "passthrough:///local"
+
+	localBufSize = 1024 * 1024
+
+	localBlobsDir  = "blobs"
+	localLabelsDir = "labels"
+	localIndexFile = "index.json"
+
+	localDirPerm  = 0o755
+	localFilePerm = 0o644
+)
+
+// NewLocal creates a client backed by a record store in the directory dir instead of a server,
+// e.g. for offline development. The directory is created if it does not exist.
+//
+// The client has the same surface as a client created with New: records are pushed, pulled,
+// looked up and deleted with the store methods and their streaming variants, published and listed
+// with the routing methods, and "name@version" record locators are resolved. Records are stored
+// under their canonical bytes in blobs/<cid>, so their CIDs are the CIDs the server computes.
+// Metadata is indexed in index.json, and the routing labels of published records are kept in labels/<cid>.
+//
+// The local store does not support encrypted records, referrers, label queries, or searches other
+// than by name and version. The server address and authentication of the options are ignored.
+// Use SyncLocalToRemote to push the records to a server.
+func NewLocal(dir string, opts ...Option) (*Client, error) {
+	store, err := openLocalStore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	local := newLocalServer(store)
+
+	opts = append(opts,
+		WithConfig(&Config{ServerAddress: localTarget}),
+		WithDialOptions(local.dialOptions()...),
+	)
+
+	c, err := New(opts...)
+	if err != nil {
+		local.close()
+
+		return nil, err
+	}
+
+	c.local = local
+
+	return c, nil
+}
+
+// localEntry is the metadata of a record in the index of a local store.
+type localEntry struct {
+	Name          string `json:"name,omitempty"`
+	Version       string `json:"version,omitempty"`
+	SchemaVersion string `json:"schema_version,omitempty"`
+	CreatedAt     string `json:"created_at,omitempty"`
+	Published     bool   `json:"published,omitempty"`
+}
+
+// localStore keeps records in a directory, see NewLocal.
+type localStore struct {
+	dir       string
+	extractor *apilabels.Extractor
+
+	mu    sync.Mutex
+	index map[string]*localEntry
+}
+
+// openLocalStore opens the store in the directory, creating it if needed.
+func openLocalStore(dir string) (*localStore, error) {
+	for _, sub := range []string{localBlobsDir, localLabelsDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), localDirPerm); err != nil {
+			return nil, fmt.Errorf("failed to create local store: %w", err)
+		}
+	}
+
+	extractor, err := apilabels.NewExtractor(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label extractor: %w", err)
+	}
+
+	s := &localStore{
+		dir:       dir,
+		extractor: extractor,
+		index:     map[string]*localEntry{},
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, localIndexFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read local store index: %w", err)
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &s.index); err != nil {
+			return nil, fmt.Errorf("failed to parse local store index: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+// saveIndex writes the index atomically. The caller must hold the lock.
+func (s *localStore) saveIndex() error {
+	data, err := json.MarshalIndent(s.index, "", "  ")
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to marshal local store index: %v", err)
+	}
+
+	if err := writeFileAtomic(filepath.Join(s.dir, localIndexFile), data); err != nil {
+		return status.Errorf(codes.Internal, "failed to write local store index: %v", err)
+	}
+
+	return nil
+}
+
+// writeFileAtomic replaces the file with the data, so that readers never see partial content.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return err //nolint:wrapcheck
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := os.Chmod(tmp.Name(), localFilePerm); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return os.Rename(tmp.Name(), path) //nolint:wrapcheck
+}
+
+func (s *localStore) blobPath(cid string) string {
+	return filepath.Join(s.dir, localBlobsDir, cid)
+}
+
+func (s *localStore) labelsPath(cid string) string {
+	return filepath.Join(s.dir, localLabelsDir, cid)
+}
+
+// cids returns the sorted CIDs of the stored records.
+func (s *localStore) cids() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cids := make([]string, 0, len(s.index))
+	for cid := range s.index {
+		cids = append(cids, cid)
+	}
+
+	slices.Sort(cids)
+
+	return cids
+}
+
+// entry returns a copy of the index entry of the record.
+func (s *localStore) entry(cid string) (localEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.index[cid]
+	if !ok {
+		return localEntry{}, status.Errorf(codes.NotFound, "record not found: %s", cid)
+	}
+
+	return *entry, nil
+}
+
+// push stores the record, and reports whether it was already stored.
+func (s *localStore) push(record *corev1.Record) (bool, error) {
+	if record.GetEnvelope() != nil {
+		return false, status.Error(codes.InvalidArgument, "encrypted records are not supported by the local store")
+	}
+
+	isValid, validationErrors, err := record.Validate()
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to validate record: %v", err)
+	}
+
+	if !isValid {
+		return false, status.Errorf(codes.InvalidArgument, "record validation failed: %v", validationErrors)
+	}
+
+	cid := record.GetCid()
+	if cid == "" {
+		return false, status.Error(codes.InvalidArgument, "failed to calculate record CID")
+	}
+
+	data, err := record.Marshal()
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to marshal record: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.index[cid]; ok {
+		return true, nil
+	}
+
+	if err := writeFileAtomic(s.blobPath(cid), data); err != nil {
+		return false, status.Errorf(codes.Internal, "failed to write record: %v", err)
+	}
+
+	fields := record.GetData().GetFields()
+
+	s.index[cid] = &localEntry{
+		Name:          fields["name"].GetStringValue(),
+		Version:       fields["version"].GetStringValue(),
+		SchemaVersion: record.GetSchemaVersion(),
+		CreatedAt:     fields["created_at"].GetStringValue(),
+	}
+
+	if err := s.saveIndex(); err != nil {
+		delete(s.index, cid)
+
+		return false, err
+	}
+
+	return false, nil
+}
+
+// get reads the record from its canonical bytes.
+func (s *localStore) get(cid string) (*corev1.Record, error) {
+	if _, err := s.entry(cid); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(s.blobPath(cid))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read record %s: %v", cid, err)
+	}
+
+	record, err := corev1.LoadOASFFromReader(bytes.NewReader(data), corev1.Lenient())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load record %s: %v", cid, err)
+	}
+
+	return record, nil
+}
+
+// lookup returns the metadata of the record.
+func (s *localStore) lookup(cid string) (*corev1.RecordMeta, error) {
+	entry, err := s.entry(cid)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &corev1.RecordMeta{
+		Cid:           cid,
+		Annotations:   map[string]string{},
+		SchemaVersion: entry.SchemaVersion,
+		CreatedAt:     entry.CreatedAt,
+		Pinned:        entry.Published,
+	}
+
+	if entry.Name != "" {
+		meta.Annotations["name"] = entry.Name
+	}
+
+	if entry.Version != "" {
+		meta.Annotations["version"] = entry.Version
+	}
+
+	if entry.Published {
+		meta.PublicationLabels, _ = s.labels(cid)
+	}
+
+	return meta, nil
+}
+
+// remove deletes the record. Published records are only deleted if force is set, unpublishing them first.
+func (s *localStore) remove(cid string, force bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.index[cid]
+	if !ok {
+		return status.Errorf(codes.NotFound, "record not found: %s", cid)
+	}
+
+	if entry.Published && !force {
+		return status.Errorf(codes.FailedPrecondition, "record %s is published, unpublish it first or delete it with force", cid)
+	}
+
+	delete(s.index, cid)
+
+	if err := s.saveIndex(); err != nil {
+		s.index[cid] = entry
+
+		return err
+	}
+
+	for _, path := range []string{s.blobPath(cid), s.labelsPath(cid)} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return status.Errorf(codes.Internal, "failed to remove record %s: %v", cid, err)
+		}
+	}
+
+	return nil
+}
+
+// publish writes the routing labels of the record and marks it published.
+func (s *localStore) publish(cid string) error {
+	record, err := s.get(cid)
+	if err != nil {
+		return err
+	}
+
+	labels, err := s.extractor.ExtractRecord(record)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to extract labels of record %s: %v", cid, err)
+	}
+
+	var content strings.Builder
+	for _, label := range labels {
+		content.WriteString(label.String() + "\n")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.index[cid]
+	if !ok {
+		return status.Errorf(codes.NotFound, "record not found: %s", cid)
+	}
+
+	if err := writeFileAtomic(s.labelsPath(cid), []byte(content.String())); err != nil {
+		return status.Errorf(codes.Internal, "failed to write labels of record %s: %v", cid, err)
+	}
+
+	entry.Published = true
+
+	return s.saveIndex()
+}
+
+// unpublish removes the routing labels of the record.
+func (s *localStore) unpublish(cid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.index[cid]
+	if !ok {
+		return status.Errorf(codes.NotFound, "record not found: %s", cid)
+	}
+
+	if err := os.Remove(s.labelsPath(cid)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return status.Errorf(codes.Internal, "failed to remove labels of record %s: %v", cid, err)
+	}
+
+	entry.Published = false
+
+	return s.saveIndex()
+}
+
+// labels reads the routing labels of a published record.
+func (s *localStore) labels(cid string) ([]string, error) {
+	data, err := os.ReadFile(s.labelsPath(cid))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return strings.Fields(string(data)), nil
+}
+
+// search returns the CIDs of the records matching all queries.
+// Only name and version queries are supported, matched in their normal form.
+func (s *localStore) search(queries []*searchv1.RecordQuery) ([]string, error) {
+	for _, query := range queries {
+		switch query.GetType() { //nolint:exhaustive
+		case searchv1.RecordQueryType_RECORD_QUERY_TYPE_NAME, searchv1.RecordQueryType_RECORD_QUERY_TYPE_VERSION:
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "query type %s is not supported by the local store", query.GetType())
+		}
+	}
+
+	var matches []string
+
+	for _, cid := range s.cids() {
+		entry, err := s.entry(cid)
+		if err != nil {
+			continue
+		}
+
+		if slices.ContainsFunc(queries, func(query *searchv1.RecordQuery) bool {
+			if query.GetType() == searchv1.RecordQueryType_RECORD_QUERY_TYPE_NAME {
+				return names.CanonicalName(entry.Name) != names.CanonicalName(query.GetValue())
+			}
+
+			return names.CanonicalVersion(entry.Version) != names.CanonicalVersion(query.GetValue())
+		}) {
+			continue
+		}
+
+		matches = append(matches, cid)
+	}
+
+	return matches, nil
+}
+
+// localServer serves a local store to a client over an in-memory connection.
+type localServer struct {
+	store    *localStore
+	listener *bufconn.Listener
+	server   *grpc.Server
+}
+
+func newLocalServer(store *localStore) *localServer {
+	s := &localServer{
+		store:    store,
+		listener: bufconn.Listen(localBufSize),
+		server:   grpc.NewServer(),
+	}
+
+	storev1.RegisterStoreServiceServer(s.server, &localStoreServer{store: store})
+	routingv1.RegisterRoutingServiceServer(s.server, &localRoutingServer{store: store})
+	searchv1.RegisterSearchServiceServer(s.server, &localSearchServer{store: store})
+
+	go func() { _ = s.server.Serve(s.listener) }()
+
+	return s
+}
+
+func (s *localServer) dialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+}
+
+func (s *localServer) close() {
+	s.server.Stop()
+}
+
+// localRecordError converts an error for a single record reference into its wire representation.
+func localRecordError(cid string, err error) *corev1.RecordError {
+	st := status.Convert(err)
+
+	return &corev1.RecordError{
+		Code:    uint32(st.Code()), //nolint:gosec
+		Message: st.Message(),
+		Cid:     cid,
+	}
+}
+
+// serveLocal answers every request received on the bidirectional stream with handle.
+func serveLocal[Req, Resp any](stream interface {
+	Recv() (*Req, error)
+	Send(*Resp) error
+}, handle func(*Req) (*Resp, error),
+) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		resp, err := handle(req)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+// localStoreServer serves the store service of a local store.
+type localStoreServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	store *localStore
+}
+
+func (s *localStoreServer) Push(stream storev1.StoreService_PushServer) error {
+	return serveLocal(stream, func(record *corev1.Record) (*corev1.RecordRef, error) {
+		existed, err := s.store.push(record)
+		if err != nil {
+			return nil, err
+		}
+
+		return &corev1.RecordRef{Cid: record.GetCid(), AlreadyExisted: existed}, nil
+	})
+}
+
+func (s *localStoreServer) Pull(stream storev1.StoreService_PullServer) error {
+	return serveLocal(stream, func(ref *corev1.RecordRef) (*corev1.Record, error) {
+		record, err := s.store.get(ref.GetCid())
+		if err != nil {
+			return &corev1.Record{Error: localRecordError(ref.GetCid(), err)}, nil
+		}
+
+		return record, nil
+	})
+}
+
+func (s *localStoreServer) Lookup(stream storev1.StoreService_LookupServer) error {
+	return serveLocal(stream, func(ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+		meta, err := s.store.lookup(ref.GetCid())
+		if err != nil {
+			return &corev1.RecordMeta{Cid: ref.GetCid(), Error: localRecordError(ref.GetCid(), err)}, nil
+		}
+
+		return meta, nil
+	})
+}
+
+func (s *localStoreServer) Delete(stream storev1.StoreService_DeleteServer) error {
+	force := storev1.IsDeleteForce(stream.Context())
+
+	for {
+		ref, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&emptypb.Empty{}) //nolint:wrapcheck
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if err := s.store.remove(ref.GetCid(), force); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *localStoreServer) DeleteWithAck(stream storev1.StoreService_DeleteWithAckServer) error {
+	force := storev1.IsDeleteForce(stream.Context())
+
+	return serveLocal(stream, func(ref *corev1.RecordRef) (*storev1.DeleteResponse, error) {
+		if err := s.store.remove(ref.GetCid(), force); err != nil {
+			return &storev1.DeleteResponse{RecordRef: ref, Error: localRecordError(ref.GetCid(), err)}, nil
+		}
+
+		return &storev1.DeleteResponse{RecordRef: ref}, nil
+	})
+}
+
+// localRoutingServer serves the routing service of a local store.
+// Records are announced to no one, publishing only records their routing labels for List.
+type localRoutingServer struct {
+	routingv1.UnimplementedRoutingServiceServer
+
+	store *localStore
+}
+
+// refs returns the CIDs of the records referenced by a publish or unpublish request.
+func (s *localRoutingServer) refs(refs *routingv1.RecordRefs, queries *routingv1.RecordQueries) ([]string, error) {
+	if queries != nil {
+		return s.store.search(queries.GetQueries())
+	}
+
+	cids := make([]string, 0, len(refs.GetRefs()))
+	for _, ref := range refs.GetRefs() {
+		cids = append(cids, ref.GetCid())
+	}
+
+	return cids, nil
+}
+
+func (s *localRoutingServer) Publish(_ context.Context, req *routingv1.PublishRequest) (*emptypb.Empty, error) {
+	cids, err := s.refs(req.GetRecordRefs(), req.GetQueries())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cid := range cids {
+		if err := s.store.publish(cid); err != nil {
+			st := status.Convert(err)
+
+			return nil, status.Errorf(st.Code(), "failed to publish record: %s", st.Message())
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *localRoutingServer) Unpublish(_ context.Context, req *routingv1.UnpublishRequest) (*emptypb.Empty, error) {
+	cids, err := s.refs(req.GetRecordRefs(), req.GetQueries())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cid := range cids {
+		if err := s.store.unpublish(cid); err != nil {
+			st := status.Convert(err)
+
+			return nil, status.Errorf(st.Code(), "failed to unpublish record: %s", st.Message())
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *localRoutingServer) List(req *routingv1.ListRequest, stream routingv1.RoutingService_ListServer) error {
+	if req.GetQuery() != "" {
+		return status.Error(codes.InvalidArgument, "label queries are not supported by the local store")
+	}
+
+	var sent uint32
+
+	for _, cid := range s.store.cids() {
+		if req.Limit != nil && sent >= req.GetLimit() {
+			break
+		}
+
+		labels, err := s.store.labels(cid)
+		if errors.Is(err, os.ErrNotExist) {
+			// Not published
+			continue
+		}
+
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to read labels of record %s: %v", cid, err)
+		}
+
+		if !localQueriesMatch(req.GetQueries(), labels) {
+			continue
+		}
+
+		if err := stream.Send(&routingv1.ListResponse{RecordRef: &corev1.RecordRef{Cid: cid}, Labels: labels}); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		sent++
+	}
+
+	return nil
+}
+
+// localQueriesMatch reports whether the labels match all queries like the server does:
+// skills, domains and modules match exactly or by prefix, locators match exactly.
+func localQueriesMatch(queries []*routingv1.RecordQuery, labels []string) bool {
+	for _, query := range queries {
+		var labelType apilabels.Type
+
+		switch query.GetType() { //nolint:exhaustive
+		case routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL:
+			labelType = apilabels.TypeSkill
+		case routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN:
+			labelType = apilabels.TypeDomain
+		case routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE:
+			labelType = apilabels.TypeModule
+		case routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR:
+			labelType = apilabels.TypeLocator
+		default:
+			return false
+		}
+
+		target := labelType.Prefix() + query.GetValue()
+
+		if !slices.ContainsFunc(labels, func(label string) bool {
+			return label == target || (labelType != apilabels.TypeLocator && strings.HasPrefix(label, target+"/"))
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// localSearchServer serves the search service of a local store.
+type localSearchServer struct {
+	searchv1.UnimplementedSearchServiceServer
+
+	store *localStore
+}
+
+func (s *localSearchServer) Search(req *searchv1.SearchRequest, stream searchv1.SearchService_SearchServer) error {
+	if req.Query != nil {
+		return status.Error(codes.InvalidArgument, "free-text queries are not supported by the local store")
+	}
+
+	cids, err := s.store.search(req.GetQueries())
+	if err != nil {
+		return err
+	}
+
+	offset := min(int(req.GetOffset()), len(cids))
+	cids = cids[offset:]
+
+	if req.Limit != nil && int(req.GetLimit()) < len(cids) {
+		cids = cids[:req.GetLimit()]
+	}
+
+	for _, cid := range cids {
+		if err := stream.Send(&searchv1.SearchResponse{RecordCid: cid}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
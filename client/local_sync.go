@@ -0,0 +1,126 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+)
+
+// ErrNotLocal is returned by SyncLocalToRemote for source clients not created with NewLocal.
+var ErrNotLocal = errors.New("client is not backed by a local store")
+
+// LocalSyncStatus is the outcome of syncing a single record with SyncLocalToRemote.
+type LocalSyncStatus string
+
+const (
+	// LocalSyncPushed is reported for records pushed to the remote.
+	LocalSyncPushed LocalSyncStatus = "pushed"
+	// LocalSyncSkipped is reported for records already stored on the remote.
+	LocalSyncSkipped LocalSyncStatus = "skipped"
+	// LocalSyncFailed is reported for records that could not be synced, see LocalSyncResult.Error.
+	LocalSyncFailed LocalSyncStatus = "failed"
+)
+
+// LocalSyncOptions configures SyncLocalToRemote.
+type LocalSyncOptions struct {
+	// Publish also publishes records on the remote that are published in the local store.
+	Publish bool
+
+	// DryRun only reports which records would be pushed, without pushing them.
+	// Records that would be pushed are reported with the LocalSyncPushed status.
+	DryRun bool
+}
+
+// LocalSyncResult is the outcome of syncing a single record of the local store.
+type LocalSyncResult struct {
+	// CID is the CID of the record, which is the same in both stores.
+	CID string
+	// Name and Version identify the record for display.
+	Name    string
+	Version string
+	// Status is the outcome of the sync.
+	Status LocalSyncStatus
+	// Error is the failure of records with the LocalSyncFailed status.
+	Error error
+}
+
+// SyncLocalToRemote pushes the records of the local store of local, created with NewLocal,
+// that are missing on remote. Records already stored on the remote are found with Lookup and skipped,
+// so syncing again only pushes records added since.
+//
+// A result is returned for every record of the local store, sorted by CID. Failures of single
+// records are reported in their result, the returned error is only set if the sync could not run.
+func SyncLocalToRemote(ctx context.Context, local, remote *Client, opts LocalSyncOptions) ([]*LocalSyncResult, error) {
+	if local.local == nil {
+		return nil, ErrNotLocal
+	}
+
+	store := local.local.store
+
+	var results []*LocalSyncResult
+
+	for _, cid := range store.cids() {
+		entry, err := store.entry(cid)
+		if err != nil {
+			// Deleted concurrently
+			continue
+		}
+
+		result := &LocalSyncResult{CID: cid, Name: entry.Name, Version: entry.Version}
+		results = append(results, result)
+
+		result.Status, result.Error = syncLocalRecord(ctx, store, remote, cid, opts)
+		if result.Status == LocalSyncPushed && opts.Publish && entry.Published && !opts.DryRun {
+			if err := publishSynced(ctx, remote, cid); err != nil {
+				result.Status, result.Error = LocalSyncFailed, err
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// syncLocalRecord pushes the record to the remote unless it is already stored there.
+func syncLocalRecord(ctx context.Context, store *localStore, remote *Client, cid string, opts LocalSyncOptions) (LocalSyncStatus, error) {
+	_, err := remote.Lookup(ctx, &corev1.RecordRef{Cid: cid})
+
+	switch {
+	case err == nil:
+		return LocalSyncSkipped, nil
+	case !errors.Is(err, ErrNotFound):
+		return LocalSyncFailed, fmt.Errorf("failed to lookup record on remote: %w", err)
+	case opts.DryRun:
+		return LocalSyncPushed, nil
+	}
+
+	record, err := store.get(cid)
+	if err != nil {
+		return LocalSyncFailed, fmt.Errorf("failed to read local record: %w", err)
+	}
+
+	if _, err := remote.Push(ctx, record); err != nil {
+		return LocalSyncFailed, fmt.Errorf("failed to push record to remote: %w", err)
+	}
+
+	return LocalSyncPushed, nil
+}
+
+// publishSynced publishes a pushed record on the remote.
+func publishSynced(ctx context.Context, remote *Client, cid string) error {
+	err := remote.Publish(ctx, &routingv1.PublishRequest{
+		Request: &routingv1.PublishRequest_RecordRefs{
+			RecordRefs: &routingv1.RecordRefs{Refs: []*corev1.RecordRef{{Cid: cid}}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish record on remote: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/client/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLocalTestRecord returns a 0.7.0 record passing schema validation.
+func newLocalTestRecord(name, version string) *corev1.Record {
+	return corev1.New(&typesv1alpha1.Record{
+		Name:          name,
+		Version:       version,
+		SchemaVersion: "0.7.0",
+		Description:   "A test agent",
+		Authors:       []string{"AGNTCY Contributors"},
+		CreatedAt:     "2025-01-01T00:00:00Z",
+		Skills: []*typesv1alpha1.Skill{
+			{Name: "natural_language_processing/natural_language_generation/text_completion", Id: 10201}, //nolint:mnd
+		},
+		Locators: []*typesv1alpha1.Locator{
+			{Type: "docker_image", Url: "https://ghcr.io/agntcy/" + name},
+		},
+	})
+}
+
+func newLocalTestClient(t *testing.T, dir string) *Client {
+	t.Helper()
+
+	c, err := NewLocal(dir)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = c.Close() })
+
+	return c
+}
+
+func publishRef(cid string) *routingv1.PublishRequest {
+	return &routingv1.PublishRequest{
+		Request: &routingv1.PublishRequest_RecordRefs{
+			RecordRefs: &routingv1.RecordRefs{Refs: []*corev1.RecordRef{{Cid: cid}}},
+		},
+	}
+}
+
+func TestLocalClient(t *testing.T) {
+	dir := t.TempDir()
+
+	c, err := NewLocal(dir)
+	require.NoError(t, err)
+
+	records := make([]*corev1.Record, 3)
+	for i := range records {
+		records[i] = newLocalTestRecord("local-agent", fmt.Sprintf("v1.0.%d", i))
+	}
+
+	// Push
+	pushed, err := c.PushStream(t.Context(), streaming.SliceToChan(t.Context(), records))
+	require.NoError(t, err)
+
+	var refs []*corev1.RecordRef
+
+	for done := false; !done; {
+		select {
+		case err := <-pushed.ErrCh():
+			require.NoError(t, err)
+		case ref := <-pushed.ResCh():
+			refs = append(refs, ref)
+		case <-pushed.DoneCh():
+			done = true
+		}
+	}
+
+	require.Len(t, refs, len(records))
+
+	for i, ref := range refs {
+		// CIDs are computed from the canonical bytes like on the server
+		assert.Equal(t, records[i].GetCid(), ref.GetCid())
+		assert.FileExists(t, dir+"/blobs/"+ref.GetCid())
+	}
+
+	// Pull, including a missing record
+	missing := newLocalTestRecord("missing-agent", "v1.0.0").GetCid()
+	pullRefs := append(refs, &corev1.RecordRef{Cid: missing}) //nolint:gocritic
+
+	pulledResult, err := c.PullStream(t.Context(), streaming.SliceToChan(t.Context(), pullRefs))
+	require.NoError(t, err)
+
+	var pulled, notFound int
+
+	for done := false; !done; {
+		select {
+		case err := <-pulledResult.ErrCh():
+			require.NoError(t, err)
+		case res := <-pulledResult.ResCh():
+			if res.Error != nil {
+				require.ErrorIs(t, res.Error, ErrNotFound)
+
+				notFound++
+
+				continue
+			}
+
+			assert.Equal(t, pullRefs[res.Index].GetCid(), res.Record.GetCid())
+
+			pulled++
+		case <-pulledResult.DoneCh():
+			done = true
+		}
+	}
+
+	assert.Equal(t, len(records), pulled)
+	assert.Equal(t, 1, notFound)
+
+	// Lookup by record locator
+	meta, err := c.Lookup(t.Context(), &corev1.RecordRef{Cid: "local-agent@v1.0.1"})
+	require.NoError(t, err)
+	assert.Equal(t, records[1].GetCid(), meta.GetCid())
+	assert.Equal(t, "local-agent", meta.GetAnnotations()["name"])
+	assert.Equal(t, "0.7.0", meta.GetSchemaVersion())
+
+	// Publish and List
+	require.NoError(t, c.Publish(t.Context(), publishRef(refs[0].GetCid())))
+
+	listed, err := c.List(t.Context(), &routingv1.ListRequest{
+		Queries: []*routingv1.RecordQuery{
+			{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "natural_language_processing"},
+		},
+	})
+	require.NoError(t, err)
+
+	var listedCIDs []string
+	for resp := range listed {
+		listedCIDs = append(listedCIDs, resp.GetRecordRef().GetCid())
+		assert.Contains(t, resp.GetLabels(), "/locators/docker_image")
+	}
+
+	assert.Equal(t, []string{refs[0].GetCid()}, listedCIDs)
+
+	// Published records are pinned
+	err = c.Delete(t.Context(), refs[0])
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "is published")
+
+	require.NoError(t, c.Delete(t.Context(), refs[0], WithForce()))
+	assert.NoFileExists(t, dir+"/labels/"+refs[0].GetCid())
+
+	// Records are kept on disk
+	require.NoError(t, c.Close())
+
+	reopened := newLocalTestClient(t, dir)
+
+	_, err = reopened.Lookup(t.Context(), refs[0])
+	require.ErrorIs(t, err, ErrNotFound)
+
+	record, err := reopened.Pull(t.Context(), refs[2])
+	require.NoError(t, err)
+	assert.Equal(t, refs[2].GetCid(), record.GetCid())
+}
+
+func TestSyncLocalToRemote(t *testing.T) {
+	local := newLocalTestClient(t, t.TempDir())
+	remote := newLocalTestClient(t, t.TempDir())
+
+	records := []*corev1.Record{
+		newLocalTestRecord("sync-agent", "v1.0.0"),
+		newLocalTestRecord("sync-agent", "v1.1.0"),
+		newLocalTestRecord("other-agent", "v1.0.0"),
+	}
+
+	refs, err := local.PushBatch(t.Context(), records)
+	require.NoError(t, err)
+	require.NoError(t, local.Publish(t.Context(), publishRef(refs[1].GetCid())))
+
+	// The remote already stores one of the records
+	_, err = remote.Push(t.Context(), records[2])
+	require.NoError(t, err)
+
+	results, err := SyncLocalToRemote(t.Context(), local, remote, LocalSyncOptions{Publish: true})
+	require.NoError(t, err)
+	require.Len(t, results, len(records))
+
+	statuses := map[string]LocalSyncStatus{}
+
+	for _, result := range results {
+		require.NoError(t, result.Error)
+
+		statuses[result.CID] = result.Status
+	}
+
+	assert.Equal(t, map[string]LocalSyncStatus{
+		records[0].GetCid(): LocalSyncPushed,
+		records[1].GetCid(): LocalSyncPushed,
+		records[2].GetCid(): LocalSyncSkipped,
+	}, statuses)
+
+	for _, record := range records {
+		pulled, err := remote.Pull(t.Context(), &corev1.RecordRef{Cid: record.GetCid()})
+		require.NoError(t, err)
+		assert.Equal(t, record.GetCid(), pulled.GetCid())
+	}
+
+	meta, err := remote.Lookup(t.Context(), refs[1])
+	require.NoError(t, err)
+	assert.True(t, meta.GetPinned())
+
+	// Syncing again skips everything
+	results, err = SyncLocalToRemote(t.Context(), local, remote, LocalSyncOptions{})
+	require.NoError(t, err)
+
+	for _, result := range results {
+		assert.Equal(t, LocalSyncSkipped, result.Status)
+	}
+
+	// Only local clients can be synced
+	_, err = SyncLocalToRemote(t.Context(), &Client{}, remote, LocalSyncOptions{})
+	require.ErrorIs(t, err, ErrNotLocal)
+}
@@ -0,0 +1,139 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+)
+
+// maxLocatorCandidates bounds the number of records considered when resolving a record locator.
+const maxLocatorCandidates = 1000
+
+// ErrAmbiguousLocator is reported via an AmbiguousLocatorError when a record locator matches several records.
+var ErrAmbiguousLocator = errors.New("ambiguous record locator")
+
+// AmbiguousLocatorError is returned for record locators matching several records,
+// e.g. records pushed with the same name and version. Use one of the candidate CIDs instead.
+type AmbiguousLocatorError struct {
+	// Locator is the ambiguous locator.
+	Locator string
+	// Candidates are the CIDs of the matching records.
+	Candidates []string
+}
+
+func (e *AmbiguousLocatorError) Error() string {
+	return fmt.Sprintf("%s %s matches %d records: %s", ErrAmbiguousLocator, e.Locator, len(e.Candidates), strings.Join(e.Candidates, ", "))
+}
+
+func (e *AmbiguousLocatorError) Unwrap() error {
+	return ErrAmbiguousLocator
+}
+
+// ResolveLocator resolves a record locator parsed with corev1.ParseRecordLocator to a record reference.
+// A CID resolves to itself. A name@version locator is resolved by searching the records with that
+// name and version; the locator "name@latest" resolves to the record with the highest semantic version
+// of the name, regardless of when it was pushed. Names are matched in their normal form, so
+// "my-agent@latest" also resolves records named "My Agent", see the api names package.
+//
+// Returns ErrNotFound if no record matches, and an AmbiguousLocatorError listing the candidates
+// if several records match, rather than picking one of them.
+func (c *Client) ResolveLocator(ctx context.Context, locator string) (*corev1.RecordRef, error) {
+	parsed, err := corev1.ParseRecordLocator(locator)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if parsed.IsCID() {
+		return &corev1.RecordRef{Cid: parsed.CID}, nil
+	}
+
+	queries := []*searchv1.RecordQuery{
+		{Type: searchv1.RecordQueryType_RECORD_QUERY_TYPE_NAME, Value: parsed.Name},
+	}
+
+	if !parsed.IsLatest() {
+		queries = append(queries, &searchv1.RecordQuery{Type: searchv1.RecordQueryType_RECORD_QUERY_TYPE_VERSION, Value: parsed.Version})
+	}
+
+	limit := uint32(maxLocatorCandidates)
+
+	cids, err := c.Search(ctx, &searchv1.SearchRequest{Queries: queries, Limit: &limit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", parsed, err)
+	}
+
+	var candidates []string
+
+	for cid := range cids {
+		if !slices.Contains(candidates, cid) {
+			candidates = append(candidates, cid)
+		}
+	}
+
+	if parsed.IsLatest() && len(candidates) > 1 {
+		candidates, err = c.latestCandidates(ctx, candidates)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", parsed, err)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, parsed)
+	case 1:
+		return &corev1.RecordRef{Cid: candidates[0]}, nil
+	default:
+		slices.Sort(candidates)
+
+		return nil, &AmbiguousLocatorError{Locator: parsed.String(), Candidates: candidates}
+	}
+}
+
+// latestCandidates pulls the candidate records and returns the CIDs of those with the highest version.
+func (c *Client) latestCandidates(ctx context.Context, cids []string) ([]string, error) {
+	refs := make([]*corev1.RecordRef, len(cids))
+	for i, cid := range cids {
+		refs[i] = &corev1.RecordRef{Cid: cid}
+	}
+
+	records, err := c.PullBatch(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		latest  []string
+		highest string
+	)
+
+	for _, record := range records {
+		version := record.GetData().GetFields()["version"].GetStringValue()
+
+		switch cmp := corev1.CompareVersions(version, highest); {
+		case latest == nil || cmp > 0:
+			latest, highest = []string{record.GetCid()}, version
+		case cmp == 0:
+			latest = append(latest, record.GetCid())
+		}
+	}
+
+	return latest, nil
+}
+
+// resolveRef resolves references whose CID is a "name@version" or "name:version" record locator,
+// see ResolveLocator. Other references are returned as they are.
+func (c *Client) resolveRef(ctx context.Context, recordRef *corev1.RecordRef) (*corev1.RecordRef, error) {
+	if !strings.ContainsAny(recordRef.GetCid(), "@:") || corev1.IsValidCID(recordRef.GetCid()) {
+		return recordRef, nil
+	}
+
+	return c.ResolveLocator(ctx, recordRef.GetCid())
+}
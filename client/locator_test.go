@@ -0,0 +1,174 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+)
+
+// recordSearchServer matches name and version queries exactly against a set of records.
+type recordSearchServer struct {
+	searchv1.UnimplementedSearchServiceServer
+
+	records []*corev1.Record
+}
+
+func (s recordSearchServer) Search(req *searchv1.SearchRequest, stream searchv1.SearchService_SearchServer) error {
+	for _, record := range s.records {
+		fields := record.GetData().GetFields()
+
+		matches := true
+
+		for _, query := range req.GetQueries() {
+			switch query.GetType() {
+			case searchv1.RecordQueryType_RECORD_QUERY_TYPE_NAME:
+				matches = matches && fields["name"].GetStringValue() == query.GetValue()
+			case searchv1.RecordQueryType_RECORD_QUERY_TYPE_VERSION:
+				matches = matches && fields["version"].GetStringValue() == query.GetValue()
+			}
+		}
+
+		if !matches {
+			continue
+		}
+
+		if err := stream.Send(&searchv1.SearchResponse{RecordCid: record.GetCid()}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+func newLocatorRecord(name, version, description string) *corev1.Record {
+	return corev1.New(&typesv1alpha1.Record{
+		Name:          name,
+		Version:       version,
+		Description:   description,
+		SchemaVersion: "0.7.0",
+	})
+}
+
+func TestResolveLocator(t *testing.T) {
+	// Versions are pushed out of order, latest must follow semver and not push order
+	v100 := newLocatorRecord("my-agent", "1.0.0", "")
+	v110 := newLocatorRecord("my-agent", "v1.1.0", "")
+	v110rc := newLocatorRecord("my-agent", "1.1.0-rc.1", "")
+	v120beta := newLocatorRecord("my-agent", "1.2.0-beta.2", "")
+	v120beta11 := newLocatorRecord("my-agent", "1.2.0-beta.11", "")
+	v102 := newLocatorRecord("my-agent", "1.0.2", "")
+	dup := newLocatorRecord("dup-agent", "1.0.0", "first")
+	dupOther := newLocatorRecord("dup-agent", "1.0.0", "second")
+
+	records := []*corev1.Record{v100, v120beta11, v110, v120beta, v110rc, v102, dup, dupOther}
+	store := newCountingStoreServer(records...)
+
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		searchv1.RegisterSearchServiceServer(s, recordSearchServer{records: records})
+		storev1.RegisterStoreServiceServer(s, store)
+	})
+
+	t.Run("name at version", func(t *testing.T) {
+		ref, err := c.ResolveLocator(t.Context(), "my-agent@1.0.2")
+		if err != nil {
+			t.Fatalf("ResolveLocator() error = %v", err)
+		}
+
+		if ref.GetCid() != v102.GetCid() {
+			t.Errorf("ResolveLocator() = %s, want %s", ref.GetCid(), v102.GetCid())
+		}
+	})
+
+	t.Run("latest uses semver ordering", func(t *testing.T) {
+		ref, err := c.ResolveLocator(t.Context(), "my-agent:latest")
+		if err != nil {
+			t.Fatalf("ResolveLocator() error = %v", err)
+		}
+
+		// 1.2.0-beta.11 > 1.2.0-beta.2 > 1.1.0 > 1.1.0-rc.1
+		if ref.GetCid() != v120beta11.GetCid() {
+			t.Errorf("ResolveLocator() = %s, want 1.2.0-beta.11 %s", ref.GetCid(), v120beta11.GetCid())
+		}
+	})
+
+	t.Run("CID", func(t *testing.T) {
+		ref, err := c.ResolveLocator(t.Context(), v100.GetCid())
+		if err != nil || ref.GetCid() != v100.GetCid() {
+			t.Errorf("ResolveLocator() = %v, %v, want %s", ref, err, v100.GetCid())
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if _, err := c.ResolveLocator(t.Context(), "my-agent@9.9.9"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("ResolveLocator() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		if _, err := c.ResolveLocator(t.Context(), "my-agent@"); !errors.Is(err, corev1.ErrInvalidRecordLocator) {
+			t.Errorf("ResolveLocator() error = %v, want ErrInvalidRecordLocator", err)
+		}
+	})
+
+	for _, locator := range []string{"dup-agent@1.0.0", "dup-agent:latest"} {
+		t.Run("ambiguous "+locator, func(t *testing.T) {
+			_, err := c.ResolveLocator(t.Context(), locator)
+			if !errors.Is(err, ErrAmbiguousLocator) {
+				t.Fatalf("ResolveLocator() error = %v, want ErrAmbiguousLocator", err)
+			}
+
+			var ambiguous *AmbiguousLocatorError
+			if !errors.As(err, &ambiguous) {
+				t.Fatalf("ResolveLocator() error = %T, want *AmbiguousLocatorError", err)
+			}
+
+			want := []string{dup.GetCid(), dupOther.GetCid()}
+			slices.Sort(want)
+
+			if !slices.Equal(ambiguous.Candidates, want) {
+				t.Errorf("candidates = %v, want %v", ambiguous.Candidates, want)
+			}
+		})
+	}
+
+	t.Run("pull lookup and delete accept locators", func(t *testing.T) {
+		record, err := c.Pull(t.Context(), &corev1.RecordRef{Cid: "my-agent@v1.1.0"})
+		if err != nil {
+			t.Fatalf("Pull() error = %v", err)
+		}
+
+		if record.GetCid() != v110.GetCid() {
+			t.Errorf("Pull() = %s, want %s", record.GetCid(), v110.GetCid())
+		}
+
+		meta, err := c.Lookup(t.Context(), &corev1.RecordRef{Cid: "my-agent@1.0.0"})
+		if err != nil {
+			t.Fatalf("Lookup() error = %v", err)
+		}
+
+		if meta.GetCid() != v100.GetCid() {
+			t.Errorf("Lookup() = %s, want %s", meta.GetCid(), v100.GetCid())
+		}
+
+		if err := c.Delete(t.Context(), &corev1.RecordRef{Cid: "dup-agent@1.0.0"}); !errors.Is(err, ErrAmbiguousLocator) {
+			t.Errorf("Delete() error = %v, want ErrAmbiguousLocator", err)
+		}
+
+		if err := c.Delete(t.Context(), &corev1.RecordRef{Cid: "my-agent@1.0.2"}); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		if _, err := c.Pull(t.Context(), &corev1.RecordRef{Cid: v102.GetCid()}); err == nil {
+			t.Error("Pull() of deleted record succeeded")
+		}
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+)
+
+// SetRecordMetadata updates the operational metadata of a record and returns its updated metadata.
+// Entries are merged into the current metadata, empty values remove the entry. The record CID is not changed.
+// Only callers of the trust domain that pushed the record may change its metadata.
+func (c *Client) SetRecordMetadata(ctx context.Context, recordRef *corev1.RecordRef, metadata map[string]string) (*corev1.RecordMeta, error) {
+	recordRef, err := c.resolveRef(ctx, recordRef)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := c.SetMetadata(ctx, &storev1.SetMetadataRequest{
+		RecordRef: recordRef,
+		Metadata:  metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set record metadata: %w", err)
+	}
+
+	return meta, nil
+}
+
+// GetRecordMetadata returns the operational metadata of a record, empty if none was set.
+func (c *Client) GetRecordMetadata(ctx context.Context, recordRef *corev1.RecordRef) (map[string]string, error) {
+	recordRef, err := c.resolveRef(ctx, recordRef)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.GetMetadata(ctx, &storev1.GetMetadataRequest{RecordRef: recordRef})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get record metadata: %w", err)
+	}
+
+	return resp.GetMetadata(), nil
+}
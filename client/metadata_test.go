@@ -0,0 +1,83 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// metadataServer merges metadata updates into the metadata of the records it knows.
+type metadataServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	metadata map[string]map[string]string
+}
+
+func (s metadataServer) SetMetadata(_ context.Context, req *storev1.SetMetadataRequest) (*corev1.RecordMeta, error) {
+	current, ok := s.metadata[req.GetRecordRef().GetCid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", req.GetRecordRef().GetCid())
+	}
+
+	merged, err := corev1.MergeMetadata(current, req.GetMetadata())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid metadata: %v", err)
+	}
+
+	s.metadata[req.GetRecordRef().GetCid()] = merged
+
+	return &corev1.RecordMeta{Cid: req.GetRecordRef().GetCid(), OperationalMetadata: merged}, nil
+}
+
+func (s metadataServer) GetMetadata(_ context.Context, req *storev1.GetMetadataRequest) (*storev1.GetMetadataResponse, error) {
+	metadata, ok := s.metadata[req.GetRecordRef().GetCid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", req.GetRecordRef().GetCid())
+	}
+
+	return &storev1.GetMetadataResponse{Metadata: metadata}, nil
+}
+
+func TestRecordMetadata(t *testing.T) {
+	const cid = "bafkreigh2akiscaildcqabsyg3dfr6chu3fgpregiymsck7e7aqa4s52zy"
+
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterStoreServiceServer(s, metadataServer{metadata: map[string]map[string]string{cid: {"team": "platform"}}})
+	})
+
+	ref := &corev1.RecordRef{Cid: cid}
+
+	meta, err := c.SetRecordMetadata(t.Context(), ref, map[string]string{"team": "", "project": "dir"})
+	if err != nil {
+		t.Fatalf("SetRecordMetadata() unexpected error: %v", err)
+	}
+
+	if meta.GetCid() != cid {
+		t.Errorf("expected CID %s to be kept, got %s", cid, meta.GetCid())
+	}
+
+	want := map[string]string{"project": "dir"}
+
+	metadata, err := c.GetRecordMetadata(t.Context(), ref)
+	if err != nil {
+		t.Fatalf("GetRecordMetadata() unexpected error: %v", err)
+	}
+
+	if !maps.Equal(metadata, want) {
+		t.Errorf("expected metadata %v, got %v", want, metadata)
+	}
+
+	_, err = c.GetRecordMetadata(t.Context(), &corev1.RecordRef{Cid: "bafkreibm6jg3ux5qumhcn2b3flc3tyu6dmlb4xa7u5bf44yegnrjhc4yeq"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for a missing record, got %v", err)
+	}
+}
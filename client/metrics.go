@@ -0,0 +1,244 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	metricsNamespace = "dir"
+	metricsSubsystem = "client"
+
+	directionPushed = "pushed"
+	directionPulled = "pulled"
+
+	cacheRecords = "records"
+	cacheLookups = "lookups"
+)
+
+// clientMetrics holds the Prometheus collectors recorded by the metrics interceptors.
+type clientMetrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	bytes    *prometheus.CounterVec
+	cache    *prometheus.CounterVec
+}
+
+func newClientMetrics(registerer prometheus.Registerer) (*clientMetrics, error) {
+	m := &clientMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "requests_total",
+			Help:      "Total number of RPCs started by the client.",
+		}, []string{"method", "stream"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "errors_total",
+			Help:      "Total number of RPCs that completed with an error, by gRPC code.",
+		}, []string{"method", "stream", "code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of RPCs from start until completion.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "stream"}),
+		bytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "record_bytes_total",
+			Help:      "Total canonical size of records pushed to and pulled from the server.",
+		}, []string{"method", "stream", "direction"}),
+		cache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "cache_requests_total",
+			Help:      "Total number of cacheable record references, by whether they were served from the client cache.",
+		}, []string{"cache", "result"}),
+	}
+
+	var err error
+
+	m.requests, err = registerCollector(registerer, m.requests)
+	if err != nil {
+		return nil, err
+	}
+
+	m.errors, err = registerCollector(registerer, m.errors)
+	if err != nil {
+		return nil, err
+	}
+
+	m.latency, err = registerCollector(registerer, m.latency)
+	if err != nil {
+		return nil, err
+	}
+
+	m.bytes, err = registerCollector(registerer, m.bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	m.cache, err = registerCollector(registerer, m.cache)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// registerCollector registers the collector, reusing an identical collector
+// if one was already registered, e.g. by another client sharing the registry.
+func registerCollector[T prometheus.Collector](registerer prometheus.Registerer, collector T) (T, error) {
+	if err := registerer.Register(collector); err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if errors.As(err, &alreadyRegistered) {
+			if existing, ok := alreadyRegistered.ExistingCollector.(T); ok {
+				return existing, nil
+			}
+		}
+
+		return collector, err //nolint:wrapcheck
+	}
+
+	return collector, nil
+}
+
+// done records the completion of an RPC.
+func (m *clientMetrics) done(method, stream string, start time.Time, err error) {
+	m.latency.WithLabelValues(method, stream).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		m.errors.WithLabelValues(method, stream, status.Code(err).String()).Inc()
+	}
+}
+
+// cacheResult records whether a record reference was served from the client cache.
+// It is a no-op if metrics are disabled.
+func (m *clientMetrics) cacheResult(cache string, hit bool) {
+	if m == nil {
+		return
+	}
+
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	m.cache.WithLabelValues(cache, result).Inc()
+}
+
+// recordBytes records the canonical size of a record message.
+func (m *clientMetrics) recordBytes(method, stream, direction string, msg any) {
+	record, ok := msg.(*corev1.Record)
+	if !ok {
+		return
+	}
+
+	data, err := record.Marshal()
+	if err != nil {
+		return
+	}
+
+	m.bytes.WithLabelValues(method, stream, direction).Add(float64(len(data)))
+}
+
+func (m *clientMetrics) unaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		const stream = "false"
+
+		start := time.Now()
+
+		m.requests.WithLabelValues(method, stream).Inc()
+		m.recordBytes(method, stream, directionPushed, req)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			m.recordBytes(method, stream, directionPulled, reply)
+		}
+
+		m.done(method, stream, start, err)
+
+		return err
+	}
+}
+
+func (m *clientMetrics) streamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream := strconv.FormatBool(desc.ClientStreams || desc.ServerStreams)
+
+		start := time.Now()
+
+		m.requests.WithLabelValues(method, stream).Inc()
+
+		clientStream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			m.done(method, stream, start, err)
+
+			return nil, err
+		}
+
+		return &monitoredClientStream{
+			ClientStream: clientStream,
+			metrics:      m,
+			method:       method,
+			stream:       stream,
+			start:        start,
+		}, nil
+	}
+}
+
+// monitoredClientStream records bytes and completion of a client stream.
+type monitoredClientStream struct {
+	grpc.ClientStream
+
+	metrics *clientMetrics
+	method  string
+	stream  string
+	start   time.Time
+	once    sync.Once
+}
+
+func (s *monitoredClientStream) SendMsg(msg any) error {
+	err := s.ClientStream.SendMsg(msg)
+	if err == nil {
+		s.metrics.recordBytes(s.method, s.stream, directionPushed, msg)
+	}
+
+	return err //nolint:wrapcheck
+}
+
+func (s *monitoredClientStream) RecvMsg(msg any) error {
+	err := s.ClientStream.RecvMsg(msg)
+	if err == nil {
+		s.metrics.recordBytes(s.method, s.stream, directionPulled, msg)
+
+		return nil
+	}
+
+	// The stream is complete on EOF or any other error
+	s.once.Do(func() {
+		if errors.Is(err, io.EOF) {
+			s.metrics.done(s.method, s.stream, s.start, nil)
+		} else {
+			s.metrics.done(s.method, s.stream, s.start, err)
+		}
+	})
+
+	return err //nolint:wrapcheck
+}
@@ -0,0 +1,151 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const pushMethod = "/agntcy.dir.store.v1.StoreService/Push"
+
+// pushServer acknowledges pushed records with their CID.
+type pushServer struct {
+	storev1.UnimplementedStoreServiceServer
+}
+
+func (pushServer) Push(stream storev1.StoreService_PushServer) error {
+	for {
+		record, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if err := stream.Send(&corev1.RecordRef{Cid: record.GetCid()}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+// newBufconnClient creates a client connected to an in-process server with services added by register.
+func newBufconnClient(t *testing.T, register func(*grpc.Server), opts ...Option) *Client {
+	t.Helper()
+
+	return newBufconnServerClient(t, nil, register, opts...)
+}
+
+// newBufconnServerClient creates a client connected to an in-process server created with serverOpts.
+func newBufconnServerClient(t *testing.T, serverOpts []grpc.ServerOption, register func(*grpc.Server), opts ...Option) *Client {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024) //nolint:mnd
+
+	server := grpc.NewServer(serverOpts...)
+	register(server)
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	opts = append([]Option{
+		WithConfig(&Config{ServerAddress: "passthrough:///bufnet"}),
+		WithDialOptions(
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		),
+	}, opts...)
+
+	c, err := New(opts...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.Close() })
+
+	return c
+}
+
+func TestClientMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	var intercepted int
+
+	c := newBufconnClient(t,
+		func(server *grpc.Server) { storev1.RegisterStoreServiceServer(server, pushServer{}) },
+		WithMetrics(registry),
+		WithStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			intercepted++
+
+			return streamer(ctx, desc, cc, method, opts...)
+		}),
+	)
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "metrics-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+
+	recordBytes, err := record.Marshal()
+	if err != nil {
+		t.Fatalf("failed to marshal record: %v", err)
+	}
+
+	const pushes = 3
+
+	for range pushes {
+		if _, err := c.Push(t.Context(), record); err != nil {
+			t.Fatalf("failed to push record: %v", err)
+		}
+	}
+
+	if intercepted != pushes {
+		t.Errorf("expected custom interceptor to run %d times, got %d", pushes, intercepted)
+	}
+
+	metrics, err := newClientMetrics(registry)
+	if err != nil {
+		t.Fatalf("failed to reuse registered metrics: %v", err)
+	}
+
+	if got := testutil.ToFloat64(metrics.requests.WithLabelValues(pushMethod, "true")); got != pushes {
+		t.Errorf("expected %d requests, got %v", pushes, got)
+	}
+
+	if got := testutil.ToFloat64(metrics.bytes.WithLabelValues(pushMethod, "true", directionPushed)); got != float64(pushes*len(recordBytes)) {
+		t.Errorf("expected %d pushed bytes, got %v", pushes*len(recordBytes), got)
+	}
+
+	if got := testutil.CollectAndCount(metrics.latency); got != 1 {
+		t.Errorf("expected 1 latency series, got %d", got)
+	}
+
+	// Unimplemented methods are counted as errors
+	if _, err := c.Lookup(t.Context(), &corev1.RecordRef{Cid: record.GetCid()}); err == nil {
+		t.Fatal("expected lookup to fail")
+	}
+
+	lookupMethod := "/agntcy.dir.store.v1.StoreService/Lookup"
+	if got := testutil.ToFloat64(metrics.errors.WithLabelValues(lookupMethod, "true", "Unimplemented")); got != 1 {
+		t.Errorf("expected 1 lookup error, got %v", got)
+	}
+}
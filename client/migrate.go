@@ -0,0 +1,283 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+)
+
+// MigrateOptions configure a migration of records to another OASF schema version.
+type MigrateOptions struct {
+	// From is the schema version of the records to migrate, records of other versions are left alone.
+	From string
+	// To is the schema version to convert the records to.
+	To string
+	// Filter selects the records to migrate, an empty filter selects all records.
+	Filter SearchFilter
+	// DryRun only reports the records that would be migrated and the CIDs they would have.
+	DryRun bool
+	// AllowLossy migrates records whose fields cannot all be represented in the target version,
+	// see corev1.AllowLossy. Such records fail to migrate otherwise.
+	AllowLossy bool
+	// Republish publishes the migrated records to the routing network.
+	Republish bool
+	// RetireOld deprecates the source records, with the migrated record as their successor.
+	RetireOld bool
+}
+
+// MigrateResult is the outcome of migrating a single record with Migrate.
+type MigrateResult struct {
+	// SourceCID is the CID of the source record, or empty if the records could not be listed.
+	SourceCID string
+	// TargetCID is the CID of the migrated record, or of the record it would be migrated to for dry runs.
+	TargetCID string
+	// AlreadyMigrated is set if the record was migrated by an earlier run,
+	// in which case only the missing links to the migrated record were created.
+	AlreadyMigrated bool
+	// DryRun is set if nothing was changed.
+	DryRun bool
+	// Error is the migration failure, or nil on success.
+	Error error
+}
+
+// Migrate converts the stored records of one schema version to another and pushes the converted records.
+//
+// Records matching the filter are enumerated via the search index of the server. For every record of the
+// source version, the converted record is pushed with a migrated_from annotation carrying the source CID,
+// and a migration referrer carrying the migrated record is attached to the source record, so that either
+// record can be found from the other. With Republish and RetireOld, the migrated record is also published
+// and the source record deprecated with the migrated record as its successor.
+//
+// Migrations are resumable: records with a migrated record, found by its annotation, are not converted
+// again, only the links missing after an interrupted run are created. Each record is migrated separately
+// and reported on the returned channel, so failures do not stop the remaining records from being migrated.
+// The channel is closed once all records have been processed or the context is done.
+func (c *Client) Migrate(ctx context.Context, opts MigrateOptions) (<-chan MigrateResult, error) {
+	// Fail early on unsupported versions rather than for every record
+	for _, version := range []string{opts.From, opts.To} {
+		if !corev1.IsSupportedSchemaVersion(version) {
+			return nil, fmt.Errorf("unsupported schema version %q", version)
+		}
+	}
+
+	resultCh := make(chan MigrateResult)
+
+	go func() {
+		defer close(resultCh)
+
+		send := func(result MigrateResult) bool {
+			select {
+			case resultCh <- result:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// Enumerate all matches first, as the migrated records are added to the same index
+		cids, err := c.searchAll(ctx, opts.Filter)
+		if err != nil {
+			send(MigrateResult{Error: fmt.Errorf("failed to list records: %w", err)})
+
+			return
+		}
+
+		for _, cid := range cids {
+			result, ok := c.migrateRecord(ctx, cid, opts)
+			if !ok {
+				continue
+			}
+
+			if !send(result) {
+				return
+			}
+		}
+	}()
+
+	return resultCh, nil
+}
+
+// migrateRecord migrates a single record.
+// Returns false if the record does not have the source version.
+func (c *Client) migrateRecord(ctx context.Context, cid string, opts MigrateOptions) (MigrateResult, bool) {
+	result := MigrateResult{SourceCID: cid, DryRun: opts.DryRun}
+
+	record, err := c.Pull(ctx, &corev1.RecordRef{Cid: cid})
+	if err != nil {
+		result.Error = fmt.Errorf("failed to pull record: %w", err)
+
+		return result, true
+	}
+
+	if !record.HasSchemaVersion(opts.From) {
+		return result, false
+	}
+
+	// Find the record migrated by an earlier run
+	migrated, err := c.searchPage(ctx, SearchFilter{Annotations: map[string]string{corev1.AnnotationMigratedFrom: cid}}.Queries(), 0)
+	if err != nil {
+		result.Error = fmt.Errorf("failed to search migrated record: %w", err)
+
+		return result, true
+	}
+
+	if len(migrated) > 0 {
+		result.TargetCID = migrated[0]
+		result.AlreadyMigrated = true
+	} else {
+		var convertOpts []corev1.ConvertOption
+		if opts.AllowLossy {
+			convertOpts = append(convertOpts, corev1.AllowLossy())
+		}
+
+		converted, err := record.ConvertTo(opts.To, convertOpts...)
+		if err != nil {
+			result.Error = fmt.Errorf("failed to convert record: %w", err)
+
+			return result, true
+		}
+
+		if err := converted.SetMigratedFrom(cid); err != nil {
+			result.Error = fmt.Errorf("failed to link migrated record: %w", err)
+
+			return result, true
+		}
+
+		result.TargetCID = converted.GetCid()
+
+		if opts.DryRun {
+			return result, true
+		}
+
+		// The migrated record has the name and version of the source record by design
+		if _, err := c.Push(storev1.ContextWithPushOverwrite(ctx), converted); err != nil {
+			result.Error = fmt.Errorf("failed to push migrated record: %w", err)
+
+			return result, true
+		}
+	}
+
+	if opts.DryRun {
+		return result, true
+	}
+
+	result.Error = c.linkMigration(ctx, cid, result.TargetCID, opts)
+
+	return result, true
+}
+
+// linkMigration creates the links from a source record to its migrated record that do not exist yet.
+func (c *Client) linkMigration(ctx context.Context, sourceCID, targetCID string, opts MigrateOptions) error {
+	sourceRef := &corev1.RecordRef{Cid: sourceCID}
+	targetRef := &corev1.RecordRef{Cid: targetCID}
+
+	linked, err := c.migrationReferrerExists(ctx, sourceRef, targetCID)
+	if err != nil {
+		return err
+	}
+
+	if !linked {
+		err := c.PushReferrer(ctx, &storev1.PushReferrerRequest{
+			RecordRef: sourceRef,
+			Referrer: &corev1.RecordReferrer{
+				Type:        corev1.MigrationReferrerType,
+				RecordRef:   targetRef,
+				Annotations: map[string]string{"schema_version": opts.To},
+				CreatedAt:   time.Now().UTC().Format(time.RFC3339),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to attach migration referrer: %w", err)
+		}
+	}
+
+	if opts.Republish {
+		err := c.Publish(ctx, &routingv1.PublishRequest{
+			Request: &routingv1.PublishRequest_RecordRefs{
+				RecordRefs: &routingv1.RecordRefs{Refs: []*corev1.RecordRef{targetRef}},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to publish migrated record: %w", err)
+		}
+	}
+
+	if opts.RetireOld {
+		meta, err := c.Lookup(ctx, sourceRef)
+		if err != nil {
+			return fmt.Errorf("failed to lookup record: %w", err)
+		}
+
+		if meta.GetLifecycle().IsActive() || meta.GetLifecycle().GetSuccessorCid() != targetCID {
+			_, err := c.SetRecordLifecycle(ctx, sourceCID, &corev1.Lifecycle{
+				Status:       corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED,
+				SuccessorCid: targetCID,
+				Reason:       "migrated to schema version " + opts.To,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to retire record: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// MigratedTo returns the CIDs of the records the record was migrated to by Migrate, oldest first.
+func (c *Client) MigratedTo(ctx context.Context, ref *corev1.RecordRef) ([]string, error) {
+	referrerType := corev1.MigrationReferrerType
+
+	resultCh, err := c.PullReferrer(ctx, &storev1.PullReferrerRequest{
+		RecordRef:    ref,
+		ReferrerType: &referrerType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []string
+
+	for response := range resultCh {
+		if referrer := response.GetReferrer(); referrer.GetType() == corev1.MigrationReferrerType {
+			targets = append(targets, referrer.GetRecordRef().GetCid())
+		}
+	}
+
+	return targets, nil
+}
+
+// migrationReferrerExists reports whether the record has a migration referrer to the target.
+func (c *Client) migrationReferrerExists(ctx context.Context, ref *corev1.RecordRef, targetCID string) (bool, error) {
+	targets, err := c.MigratedTo(ctx, ref)
+	if err != nil {
+		return false, fmt.Errorf("failed to pull migration referrers: %w", err)
+	}
+
+	return slices.Contains(targets, targetCID), nil
+}
+
+// searchAll returns the CIDs of all records matching the filter.
+func (c *Client) searchAll(ctx context.Context, filter SearchFilter) ([]string, error) {
+	var cids []string
+
+	for {
+		page, err := c.searchPage(ctx, filter.Queries(), len(cids))
+		if err != nil {
+			return nil, err
+		}
+
+		cids = append(cids, page...)
+
+		if len(page) < deleteByFilterPageSize {
+			return cids, nil
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestMigrateVersions(t *testing.T) {
+	c := newBufconnClient(t, func(*grpc.Server) {})
+
+	for _, opts := range []MigrateOptions{
+		{From: "v1"},
+		{To: "v3"},
+		{From: "v1", To: "v9.9.9"},
+	} {
+		if _, err := c.Migrate(t.Context(), opts); err == nil {
+			t.Errorf("Migrate(%+v) expected unsupported version error", opts)
+		}
+	}
+}
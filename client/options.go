@@ -7,12 +7,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/utils/compression"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spiffe/go-spiffe/v2/spiffegrpc/grpccredentials"
 	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
 	"github.com/spiffe/go-spiffe/v2/workloadapi"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 )
 
 type Option func(*options) error
@@ -20,8 +26,42 @@ type Option func(*options) error
 // TODO: options need to be granular per key rather than for full config.
 type options struct {
 	config     *Config
+	endpoints  []string
 	authOpts   []grpc.DialOption
 	authClient *workloadapi.Client
+	dialOpts   []grpc.DialOption
+
+	unaryInterceptors  []grpc.UnaryClientInterceptor
+	streamInterceptors []grpc.StreamClientInterceptor
+	metrics            *clientMetrics
+
+	cache          Cache
+	lookupCacheTTL *time.Duration
+
+	pullPolicy *pullPolicy
+
+	requestIDGenerator func() string
+
+	hooks *Hooks
+
+	readOnly bool
+
+	compression    *string
+	maxRecvMsgSize int
+	maxSendMsgSize int
+
+	sharedPushIdle time.Duration
+
+	verifyContent bool
+
+	encryption KeyProvider
+
+	cidOptions *corev1.CIDOptions
+
+	maxRetryDelay *time.Duration
+
+	keepalive          *keepalive.ClientParameters
+	streamStaleTimeout *time.Duration
 }
 
 func WithEnvConfig() Option {
@@ -42,6 +82,432 @@ func WithConfig(config *Config) Option {
 	}
 }
 
+// WithEndpoints connects to multiple server replicas instead of the configured server address.
+// Unary calls and new streams are balanced across healthy endpoints in round-robin order,
+// and calls failing with Unavailable are retried on another endpoint.
+// Endpoints are health-checked in the background every DefaultHealthCheckInterval.
+func WithEndpoints(endpoints []string) Option {
+	return func(opts *options) error {
+		if len(endpoints) == 0 {
+			return errors.New("at least one endpoint is required")
+		}
+
+		opts.endpoints = endpoints
+
+		return nil
+	}
+}
+
+// WithDialOptions appends extra gRPC dial options used when connecting to the server.
+// This is useful for custom dialers, e.g. connecting to an in-process server in tests.
+func WithDialOptions(dialOpts ...grpc.DialOption) Option {
+	return func(opts *options) error {
+		opts.dialOpts = append(opts.dialOpts, dialOpts...)
+
+		return nil
+	}
+}
+
+// WithCompression compresses calls with the named compressor, compression.Gzip or compression.Zstd.
+// Record JSON compresses well, which speeds up pushing and pulling large batches over slow links.
+// Servers answer compressed calls with compressed responses.
+// It takes precedence over the compression set in the configuration; an empty name disables compression.
+func WithCompression(name string) Option {
+	return func(opts *options) error {
+		if err := compression.Validate(name); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		opts.compression = &name
+
+		return nil
+	}
+}
+
+// WithMaxRecvMsgSize sets the maximum size in bytes of messages received from the server.
+// The default is 4MB, which rejects responses carrying the largest records together with their metadata.
+func WithMaxRecvMsgSize(size int) Option {
+	return func(opts *options) error {
+		if size <= 0 {
+			return errors.New("max receive message size must be positive")
+		}
+
+		opts.maxRecvMsgSize = size
+
+		return nil
+	}
+}
+
+// WithMaxSendMsgSize sets the maximum size in bytes of messages sent to the server.
+// The server must accept messages of that size as well.
+func WithMaxSendMsgSize(size int) Option {
+	return func(opts *options) error {
+		if size <= 0 {
+			return errors.New("max send message size must be positive")
+		}
+
+		opts.maxSendMsgSize = size
+
+		return nil
+	}
+}
+
+// WithContentVerification fails pulls of records whose content does not match the CID they were pulled by.
+// Records redacted by the server for the caller do not match their CID by design: they are accepted
+// if the server reports the CID they were pulled by as their original CID, but their content is not verified.
+func WithContentVerification() Option {
+	return func(opts *options) error {
+		opts.verifyContent = true
+
+		return nil
+	}
+}
+
+// WithCIDOptions calculates the CIDs of pushed records with the given options, which must match the
+// CID hash function of the server, as servers reject pushed records set to other hash functions.
+// It takes precedence over the CID hash set in the configuration.
+// Pulled records are verified with the hash function of the CID they were pulled by, regardless of the options.
+func WithCIDOptions(cidOptions corev1.CIDOptions) Option {
+	return func(opts *options) error {
+		if err := cidOptions.Validate(); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		opts.cidOptions = &cidOptions
+
+		return nil
+	}
+}
+
+// WithMaxRetryDelay sets the longest delay requested by the server with a RetryInfo error detail,
+// e.g. by a rate limited call, that calls and push batches are automatically retried after.
+// DefaultMaxRetryDelay is used by default, and zero disables automatic retries.
+func WithMaxRetryDelay(maxDelay time.Duration) Option {
+	return func(opts *options) error {
+		if maxDelay < 0 {
+			return errors.New("maximum retry delay must not be negative")
+		}
+
+		opts.maxRetryDelay = &maxDelay
+
+		return nil
+	}
+}
+
+// retryDelay returns the longest delay requested by the server that calls are retried after.
+func (o *options) retryDelay() time.Duration {
+	if o.maxRetryDelay != nil {
+		return *o.maxRetryDelay
+	}
+
+	return DefaultMaxRetryDelay
+}
+
+// WithSharedPushStream sends the records of Push calls on a single long-lived push stream
+// instead of opening a stream per call, which reduces the overhead of many concurrent single pushes.
+// Responses are matched back to their callers by CID, and a rejected record only fails its own caller.
+// The stream is closed after maxIdle without pending pushes and reopened by the next push.
+// Pushes observed by push hooks or carrying outgoing metadata, e.g. from storev1.ContextWithPushOverwrite, use their own stream.
+func WithSharedPushStream(maxIdle time.Duration) Option {
+	return func(opts *options) error {
+		if maxIdle <= 0 {
+			return errors.New("shared push stream idle time must be positive")
+		}
+
+		opts.sharedPushIdle = maxIdle
+
+		return nil
+	}
+}
+
+// cidOpts returns the options the CIDs of pushed records are calculated with,
+// or nil to keep the options of the records.
+func (o *options) cidOpts() (*corev1.CIDOptions, error) {
+	if o.cidOptions != nil {
+		return o.cidOptions, nil
+	}
+
+	if o.config == nil || o.config.CIDHash == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	cidOptions, err := corev1.ParseCIDOptions(o.config.CIDHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CID hash: %w", err)
+	}
+
+	return &cidOptions, nil
+}
+
+// callOptions returns the default call options for compression and message size limits.
+func (o *options) callOptions() ([]grpc.CallOption, error) {
+	name := o.config.Compression
+	if o.compression != nil {
+		name = *o.compression
+	}
+
+	if err := compression.Validate(name); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	var callOpts []grpc.CallOption
+
+	if name != "" {
+		callOpts = append(callOpts, grpc.UseCompressor(name))
+	}
+
+	if o.maxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(o.maxRecvMsgSize))
+	}
+
+	if o.maxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(o.maxSendMsgSize))
+	}
+
+	return callOpts, nil
+}
+
+// WithUnaryInterceptor adds unary interceptors to the client connection.
+// Interceptors are chained in the order they are added.
+func WithUnaryInterceptor(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return func(opts *options) error {
+		opts.unaryInterceptors = append(opts.unaryInterceptors, interceptors...)
+
+		return nil
+	}
+}
+
+// WithStreamInterceptor adds stream interceptors to the client connection.
+// Interceptors are chained in the order they are added.
+func WithStreamInterceptor(interceptors ...grpc.StreamClientInterceptor) Option {
+	return func(opts *options) error {
+		opts.streamInterceptors = append(opts.streamInterceptors, interceptors...)
+
+		return nil
+	}
+}
+
+// WithMetrics records client metrics to the given registerer.
+// Per-method request counts, error counts by code, latencies and canonical
+// record bytes pushed and pulled are recorded, labeled by whether the RPC is streamed.
+// Metrics are recorded before any interceptors added with WithUnaryInterceptor or WithStreamInterceptor.
+func WithMetrics(registerer prometheus.Registerer) Option {
+	return func(opts *options) error {
+		metrics, err := newClientMetrics(registerer)
+		if err != nil {
+			return fmt.Errorf("failed to register client metrics: %w", err)
+		}
+
+		opts.metrics = metrics
+
+		return nil
+	}
+}
+
+// WithCache serves pulled records from the given cache.
+// Pull, PullBatch and PullStream consult the cache before contacting the server,
+// and cache records pulled by CID once their CID has been verified.
+// Concurrent Pull calls for the same CID share a single request to the server.
+// Lookup results are cached for DefaultLookupCacheTTL, see WithLookupCacheTTL.
+// Records are evicted when they are deleted with this client or reported as missing by the server.
+func WithCache(cache Cache) Option {
+	return func(opts *options) error {
+		if cache == nil {
+			return errors.New("cache is nil")
+		}
+
+		opts.cache = cache
+
+		return nil
+	}
+}
+
+// WithLookupCacheTTL sets how long Lookup results are served from cache when caching is enabled with WithCache.
+// A zero TTL disables caching of Lookup results.
+func WithLookupCacheTTL(ttl time.Duration) Option {
+	return func(opts *options) error {
+		if ttl < 0 {
+			return errors.New("lookup cache TTL must not be negative")
+		}
+
+		opts.lookupCacheTTL = &ttl
+
+		return nil
+	}
+}
+
+// WithPullPolicy rejects pulled records that violate the policy, e.g. records
+// that are not signed by a trusted identity. Rejected records are not returned;
+// PullStream reports them via PullResult.Error with ErrPolicyViolation,
+// and Pull and PullBatch return the violation as an error.
+// The policy is evaluated for records served from cache as well.
+// Use PullUnsafe to pull a record without evaluating the policy.
+func WithPullPolicy(policy PullPolicy) Option {
+	return func(opts *options) error {
+		compiled, err := newPullPolicy(policy)
+		if err != nil {
+			return fmt.Errorf("invalid pull policy: %w", err)
+		}
+
+		opts.pullPolicy = compiled
+
+		return nil
+	}
+}
+
+// recordCache returns the record cache configured with WithCache, or nil if caching is disabled.
+func (o *options) recordCache() *recordCache {
+	if o.cache == nil {
+		return nil
+	}
+
+	lookupTTL := DefaultLookupCacheTTL
+	if o.lookupCacheTTL != nil {
+		lookupTTL = *o.lookupCacheTTL
+	}
+
+	return newRecordCache(o.cache, lookupTTL, o.metrics)
+}
+
+// serverAddresses returns the addresses of the server endpoints to connect to.
+// Explicit endpoints take precedence over configured server addresses,
+// which take precedence over the single configured server address.
+func (o *options) serverAddresses() []string {
+	if len(o.endpoints) > 0 {
+		return o.endpoints
+	}
+
+	if len(o.config.ServerAddresses) > 0 {
+		return o.config.ServerAddresses
+	}
+
+	return []string{o.config.ServerAddress}
+}
+
+// WithKeepalive pings the server after keepaliveTime without activity on a connection, and closes the
+// connection if a ping is not acknowledged within timeout, failing its calls with Unavailable.
+// Connections without active streams are only pinged with permitWithoutStream, which keeps them open
+// through load balancers dropping idle connections.
+// The server must permit the pings: it disconnects clients pinging more often than its keepalive
+// minimum time, 15s by default, or without active streams unless configured to permit it.
+// It takes precedence over the keepalive set in the configuration.
+func WithKeepalive(keepaliveTime, timeout time.Duration, permitWithoutStream bool) Option {
+	return func(opts *options) error {
+		if keepaliveTime <= 0 || timeout <= 0 {
+			return errors.New("keepalive time and timeout must be positive")
+		}
+
+		opts.keepalive = &keepalive.ClientParameters{
+			Time:                keepaliveTime,
+			Timeout:             timeout,
+			PermitWithoutStream: permitWithoutStream,
+		}
+
+		return nil
+	}
+}
+
+// keepaliveParams returns the keepalive parameters of the connections. By default, the server is
+// pinged on active connections, so that streams on a dead endpoint fail with Unavailable instead
+// of hanging until the TCP timeout.
+func (o *options) keepaliveParams() keepalive.ClientParameters {
+	if o.keepalive != nil {
+		return *o.keepalive
+	}
+
+	params := keepalive.ClientParameters{
+		Time:    DefaultKeepaliveTime,
+		Timeout: DefaultKeepaliveTimeout,
+	}
+
+	if o.config != nil {
+		if o.config.KeepaliveTime > 0 {
+			params.Time = o.config.KeepaliveTime
+		}
+
+		if o.config.KeepaliveTimeout > 0 {
+			params.Timeout = o.config.KeepaliveTimeout
+		}
+
+		params.PermitWithoutStream = o.config.KeepalivePermitWithoutStream
+	}
+
+	return params
+}
+
+// WithStreamStaleTimeout fails List and WatchStore streams with ErrStreamStale if no message arrives
+// within the timeout, instead of hanging forever on a connection dropped silently, e.g. by a load balancer
+// closing idle connections. Servers are asked to send heartbeats on the streams three times per timeout,
+// so that quiet streams are neither stale nor idle.
+// It takes precedence over the stale timeout set in the configuration; zero disables it.
+func WithStreamStaleTimeout(timeout time.Duration) Option {
+	return func(opts *options) error {
+		if timeout < 0 {
+			return errors.New("stream stale timeout must not be negative")
+		}
+
+		opts.streamStaleTimeout = &timeout
+
+		return nil
+	}
+}
+
+// staleTimeout returns the stale timeout of streams, zero if disabled.
+func (o *options) staleTimeout() time.Duration {
+	if o.streamStaleTimeout != nil {
+		return *o.streamStaleTimeout
+	}
+
+	if o.config != nil {
+		return o.config.StreamStaleTimeout
+	}
+
+	return 0
+}
+
+// requestIDs returns the function generating request IDs, see WithRequestIDGenerator.
+func (o *options) requestIDs() func() string {
+	if o.requestIDGenerator != nil {
+		return o.requestIDGenerator
+	}
+
+	return uuid.NewString
+}
+
+// interceptorDialOptions returns the dial options installing all configured interceptors.
+// Request IDs are set first, so that all other interceptors see them.
+func (o *options) interceptorDialOptions() []grpc.DialOption {
+	unary := o.unaryInterceptors
+	stream := o.streamInterceptors
+
+	if o.metrics != nil {
+		unary = append([]grpc.UnaryClientInterceptor{o.metrics.unaryInterceptor()}, unary...)
+		stream = append([]grpc.StreamClientInterceptor{o.metrics.streamInterceptor()}, stream...)
+	}
+
+	apiErrors := apiErrorInterceptors{maxRetryDelay: o.retryDelay()}
+	unary = append([]grpc.UnaryClientInterceptor{apiErrors.unaryInterceptor()}, unary...)
+	stream = append([]grpc.StreamClientInterceptor{apiErrors.streamInterceptor()}, stream...)
+
+	unary = append([]grpc.UnaryClientInterceptor{unauthorizedUnaryInterceptor()}, unary...)
+	stream = append([]grpc.StreamClientInterceptor{unauthorizedStreamInterceptor()}, stream...)
+
+	requestIDs := requestIDInterceptors{generate: o.requestIDs()}
+	unary = append([]grpc.UnaryClientInterceptor{requestIDs.unaryInterceptor()}, unary...)
+	stream = append([]grpc.StreamClientInterceptor{requestIDs.streamInterceptor()}, stream...)
+
+	// Methods unavailable on read-only clients are rejected before anything else
+	if o.readOnly {
+		unary = append([]grpc.UnaryClientInterceptor{readOnlyUnaryInterceptor()}, unary...)
+		stream = append([]grpc.StreamClientInterceptor{readOnlyStreamInterceptor()}, stream...)
+	}
+
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unary...),
+		grpc.WithChainStreamInterceptor(stream...),
+	}
+}
+
 func withAuth(ctx context.Context) Option {
 	return func(o *options) error {
 		// Use insecure access in case SpiffeSocketPath is not set or no auth mode specified
@@ -0,0 +1,340 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	signv1 "github.com/agntcy/dir/api/sign/v1"
+	sigs "github.com/sigstore/cosign/v2/pkg/signature"
+	"github.com/sigstore/sigstore-go/pkg/fulcio/certificate"
+)
+
+// PolicyViolationReason identifies why a record was rejected by the pull policy.
+type PolicyViolationReason string
+
+const (
+	// PolicyReasonUnsigned is reported for records without a signature.
+	PolicyReasonUnsigned PolicyViolationReason = "unsigned"
+	// PolicyReasonInvalidSignature is reported for records whose signatures
+	// cannot be verified with the public keys attached to them.
+	PolicyReasonInvalidSignature PolicyViolationReason = "invalid_signature"
+	// PolicyReasonUntrustedSignature is reported for records that are not signed
+	// by any of the trusted keys or keyless identities.
+	PolicyReasonUntrustedSignature PolicyViolationReason = "untrusted_signature"
+	// PolicyReasonUnregistered is reported for records that failed the registration check.
+	PolicyReasonUnregistered PolicyViolationReason = "unregistered"
+)
+
+// ErrPolicyViolation is reported for pulled records rejected by the pull policy.
+// Use errors.As to inspect the reason.
+type ErrPolicyViolation struct {
+	// Reason is why the record was rejected.
+	Reason PolicyViolationReason
+	// CID is the CID of the rejected record.
+	CID string
+}
+
+func (e ErrPolicyViolation) Error() string {
+	return fmt.Sprintf("record %s violates pull policy: %s", e.CID, e.Reason)
+}
+
+// KeylessIdentity is an identity trusted to sign records with keyless signing.
+// Both patterns are regular expressions matched against the whole value.
+type KeylessIdentity struct {
+	// Issuer matches the OIDC issuer of the signing certificate, e.g. "https://token.actions.githubusercontent.com".
+	Issuer string
+	// Subject matches the subject alternative name of the signing certificate,
+	// e.g. an email address or a workflow URI.
+	Subject string
+}
+
+// RegistrationChecker checks whether a record is registered, e.g. on-chain.
+type RegistrationChecker interface {
+	// IsRegistered reports whether the record with the given CID is registered.
+	IsRegistered(ctx context.Context, cid string) (bool, error)
+}
+
+// PullPolicy decides whether pulled records may be returned to the caller.
+// Records violating the policy are reported with ErrPolicyViolation instead.
+//
+// If trusted keys or keyless identities are configured, a record must carry a signature
+// made by one of them. Otherwise, if RequireSignature is set, a record must carry a
+// signature that can be verified with the public keys attached to the record.
+type PullPolicy struct {
+	// RequireSignature rejects records without a valid signature.
+	RequireSignature bool
+
+	// TrustedKeys are PEM-encoded public keys trusted to sign records.
+	TrustedKeys []string
+
+	// TrustedIdentities are identities trusted to sign records with keyless signing.
+	// Signing certificates must chain up to FulcioRoots.
+	TrustedIdentities []KeylessIdentity
+	// FulcioRoots are the root certificates of the Fulcio instances issuing signing certificates.
+	FulcioRoots *x509.CertPool
+	// FulcioIntermediates are the intermediate certificates of the Fulcio instances.
+	FulcioIntermediates *x509.CertPool
+
+	// Registration optionally requires records to be registered.
+	Registration RegistrationChecker
+}
+
+// keylessMatcher is a trusted keyless identity with compiled patterns.
+type keylessMatcher struct {
+	issuer  *regexp.Regexp
+	subject *regexp.Regexp
+}
+
+// pullPolicy is a validated PullPolicy.
+type pullPolicy struct {
+	PullPolicy
+
+	identities []keylessMatcher
+}
+
+func newPullPolicy(policy PullPolicy) (*pullPolicy, error) {
+	if len(policy.TrustedIdentities) > 0 && policy.FulcioRoots == nil {
+		return nil, errors.New("fulcio roots are required to trust keyless identities")
+	}
+
+	for _, key := range policy.TrustedKeys {
+		if _, err := sigs.LoadPublicKeyRaw([]byte(key), crypto.SHA256); err != nil {
+			return nil, fmt.Errorf("invalid trusted key: %w", err)
+		}
+	}
+
+	compiled := &pullPolicy{PullPolicy: policy}
+
+	for _, identity := range policy.TrustedIdentities {
+		issuer, err := regexp.Compile("^(?:" + identity.Issuer + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid issuer pattern %q: %w", identity.Issuer, err)
+		}
+
+		subject, err := regexp.Compile("^(?:" + identity.Subject + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid subject pattern %q: %w", identity.Subject, err)
+		}
+
+		compiled.identities = append(compiled.identities, keylessMatcher{issuer: issuer, subject: subject})
+	}
+
+	return compiled, nil
+}
+
+// requiresSignature reports whether records must be signed.
+func (p *pullPolicy) requiresSignature() bool {
+	return p.RequireSignature || len(p.TrustedKeys) > 0 || len(p.identities) > 0
+}
+
+// evaluate checks the record against the policy, returning ErrPolicyViolation if it is rejected.
+// Other errors are returned if the policy could not be evaluated.
+func (p *pullPolicy) evaluate(ctx context.Context, c *Client, record *corev1.Record) error {
+	cid := record.GetCid()
+
+	if p.requiresSignature() {
+		if err := p.checkSignature(ctx, c, cid); err != nil {
+			return err
+		}
+	}
+
+	if p.Registration != nil {
+		registered, err := p.Registration.IsRegistered(ctx, cid)
+		if err != nil {
+			return fmt.Errorf("failed to check registration of record %s: %w", cid, err)
+		}
+
+		if !registered {
+			return ErrPolicyViolation{Reason: PolicyReasonUnregistered, CID: cid}
+		}
+	}
+
+	return nil
+}
+
+func (p *pullPolicy) checkSignature(ctx context.Context, c *Client, cid string) error {
+	payload, err := expectedSignaturePayload(cid)
+	if err != nil {
+		return err
+	}
+
+	signatures, err := c.pullSignatureReferrer(ctx, cid)
+	if err != nil {
+		return err
+	}
+
+	if len(signatures) == 0 {
+		return ErrPolicyViolation{Reason: PolicyReasonUnsigned, CID: cid}
+	}
+
+	// Without trust anchors, any signature verifiable with an attached key is accepted
+	if len(p.TrustedKeys) == 0 && len(p.identities) == 0 {
+		publicKeys, err := c.pullPublicKeyReferrer(ctx, cid)
+		if err != nil {
+			return err
+		}
+
+		for _, signature := range signatures {
+			for _, publicKey := range publicKeys {
+				if verifySignatureWithKey(publicKey, signature, payload) == nil {
+					return nil
+				}
+			}
+		}
+
+		return ErrPolicyViolation{Reason: PolicyReasonInvalidSignature, CID: cid}
+	}
+
+	for _, signature := range signatures {
+		if p.isTrusted(signature, payload) {
+			return nil
+		}
+	}
+
+	return ErrPolicyViolation{Reason: PolicyReasonUntrustedSignature, CID: cid}
+}
+
+// isTrusted reports whether the signature was made by a trusted key or keyless identity.
+func (p *pullPolicy) isTrusted(signature *signv1.Signature, payload []byte) bool {
+	_, ok := p.trustedSigner(signature, payload)
+
+	return ok
+}
+
+// trustedSigner returns the trusted key or keyless identity that made the signature, if any.
+func (p *pullPolicy) trustedSigner(signature *signv1.Signature, payload []byte) (map[string]string, bool) {
+	for _, key := range p.TrustedKeys {
+		if verifySignatureWithKey(key, signature, payload) == nil {
+			return map[string]string{"signer": "key", "key_id": pemKeyID(key)}, true
+		}
+	}
+
+	if len(p.identities) == 0 || signature.GetCertificate() == "" {
+		return nil, false
+	}
+
+	summary, err := p.verifyKeyless(signature, payload)
+	if err != nil {
+		logger.Debug("Keyless signature is not trusted", "error", err)
+
+		return nil, false
+	}
+
+	return map[string]string{
+		"signer":  "keyless",
+		"subject": summary.SubjectAlternativeName,
+		"issuer":  summary.Extensions.Issuer,
+	}, true
+}
+
+// verifyKeyless verifies a signature made with a Fulcio signing certificate
+// issued to a trusted identity, and returns the summary of the certificate.
+//
+// Signing certificates are short-lived, so the certificate chain is verified at the time
+// the certificate was issued. Transparency log inclusion is not verified.
+func (p *pullPolicy) verifyKeyless(signature *signv1.Signature, payload []byte) (certificate.Summary, error) {
+	der, err := base64.StdEncoding.DecodeString(signature.GetCertificate())
+	if err != nil {
+		return certificate.Summary{}, fmt.Errorf("failed to decode signing certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return certificate.Summary{}, fmt.Errorf("failed to parse signing certificate: %w", err)
+	}
+
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         p.FulcioRoots,
+		Intermediates: p.FulcioIntermediates,
+		CurrentTime:   cert.NotBefore,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return certificate.Summary{}, fmt.Errorf("failed to verify signing certificate chain: %w", err)
+	}
+
+	summary, err := certificate.SummarizeCertificate(cert)
+	if err != nil {
+		return certificate.Summary{}, fmt.Errorf("failed to read signing certificate identity: %w", err)
+	}
+
+	if !p.matchesIdentity(summary.Extensions.Issuer, summary.SubjectAlternativeName) {
+		return certificate.Summary{}, fmt.Errorf("identity %s issued by %s is not trusted", summary.SubjectAlternativeName, summary.Extensions.Issuer)
+	}
+
+	if err := cert.CheckSignature(signatureAlgorithm(cert), payload, decodeSignature(signature)); err != nil {
+		return certificate.Summary{}, fmt.Errorf("failed to verify signature: %w", err)
+	}
+
+	return summary, nil
+}
+
+func (p *pullPolicy) matchesIdentity(issuer, subject string) bool {
+	for _, identity := range p.identities {
+		if identity.issuer.MatchString(issuer) && identity.subject.MatchString(subject) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// signatureAlgorithm returns the algorithm of signatures made with the certificate key over a SHA-256 digest.
+func signatureAlgorithm(cert *x509.Certificate) x509.SignatureAlgorithm {
+	switch cert.PublicKeyAlgorithm {
+	case x509.ECDSA:
+		return x509.ECDSAWithSHA256
+	case x509.RSA:
+		return x509.SHA256WithRSA
+	case x509.Ed25519:
+		return x509.PureEd25519
+	default:
+		return x509.UnknownSignatureAlgorithm
+	}
+}
+
+// unsafePullKey marks contexts of pulls bypassing the pull policy.
+type unsafePullKey struct{}
+
+// PullUnsafe retrieves a record like Pull, without evaluating the pull policy.
+// It is meant for break-glass debugging of records rejected by the policy.
+func (c *Client) PullUnsafe(ctx context.Context, recordRef *corev1.RecordRef) (*corev1.Record, error) {
+	logger.Warn("Pulling record without evaluating the pull policy", "cid", recordRef.GetCid())
+
+	return c.Pull(context.WithValue(ctx, unsafePullKey{}, true), recordRef)
+}
+
+// pullPolicyFor returns the pull policy to evaluate for pulls made with the context, if any.
+func (c *Client) pullPolicyFor(ctx context.Context) *pullPolicy {
+	if unsafe, _ := ctx.Value(unsafePullKey{}).(bool); unsafe {
+		return nil
+	}
+
+	return c.pullPolicy
+}
+
+// withPullPolicy rejects successfully pulled records that violate the policy.
+func (c *Client) withPullPolicy(ctx context.Context, policy *pullPolicy) func(int, *corev1.Record) *PullResult {
+	return func(index int, record *corev1.Record) *PullResult {
+		result := newPullResult(index, record)
+		if result.Error != nil {
+			return result
+		}
+
+		if err := policy.evaluate(ctx, c, record); err != nil {
+			logger.Debug("Pulled record rejected by pull policy", "cid", record.GetCid(), "error", err)
+
+			return &PullResult{Index: index, Error: err}
+		}
+
+		return result
+	}
+}
@@ -0,0 +1,376 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	signv1 "github.com/agntcy/dir/api/sign/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client/streaming"
+	"google.golang.org/grpc"
+)
+
+// referrerServer serves records and the referrers attached to them.
+type referrerServer struct {
+	pullServer
+
+	referrers map[string][]*corev1.RecordReferrer
+}
+
+func (s referrerServer) PullReferrer(stream storev1.StoreService_PullReferrerServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	for _, referrer := range s.referrers[req.GetRecordRef().GetCid()] {
+		if req.GetReferrerType() != "" && referrer.GetType() != req.GetReferrerType() {
+			continue
+		}
+
+		if err := stream.Send(&storev1.PullReferrerResponse{Referrer: referrer}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+// attach adds a referrer to the record.
+func (s referrerServer) attach(t *testing.T, cid string, referrer interface {
+	MarshalReferrer() (*corev1.RecordReferrer, error)
+},
+) {
+	t.Helper()
+
+	encoded, err := referrer.MarshalReferrer()
+	if err != nil {
+		t.Fatalf("failed to marshal referrer: %v", err)
+	}
+
+	s.referrers[cid] = append(s.referrers[cid], encoded)
+}
+
+func newPolicyRecord(name string) *corev1.Record {
+	return corev1.New(&typesv1alpha1.Record{
+		Name:          name,
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+}
+
+func newSigningKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// signRecord signs the record payload with the key, as done by Sign.
+func signRecord(t *testing.T, key *ecdsa.PrivateKey, cid string) *signv1.Signature {
+	t.Helper()
+
+	payload, err := expectedSignaturePayload(cid)
+	if err != nil {
+		t.Fatalf("failed to generate payload: %v", err)
+	}
+
+	digest := sha256.Sum256(payload)
+
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign payload: %v", err)
+	}
+
+	return &signv1.Signature{Signature: base64.StdEncoding.EncodeToString(signature)}
+}
+
+// newFulcioCertificate issues a code signing certificate for the identity from a test root.
+func newFulcioCertificate(t *testing.T, root *x509.Certificate, rootKey *ecdsa.PrivateKey, key *ecdsa.PrivateKey, issuer, email string) string {
+	t.Helper()
+
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(2), //nolint:mnd
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(-50 * time.Minute), // Already expired, as Fulcio certificates are short-lived
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		EmailAddresses: []string{email},
+		ExtraExtensions: []pkix.Extension{
+			{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}, Value: []byte(issuer)}, //nolint:mnd
+		},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, root, &key.PublicKey, rootKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+func newFulcioRoot(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-fulcio"},
+		NotBefore:             time.Now().Add(-24 * time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create root certificate: %v", err)
+	}
+
+	root, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse root certificate: %v", err)
+	}
+
+	return root, key
+}
+
+// fixedRegistry reports the given CIDs as registered.
+type fixedRegistry map[string]bool
+
+func (r fixedRegistry) IsRegistered(_ context.Context, cid string) (bool, error) {
+	return r[cid], nil
+}
+
+func TestPullPolicy(t *testing.T) {
+	server := referrerServer{
+		pullServer: pullServer{
+			lookupServer: lookupServer{metas: map[string]*corev1.RecordMeta{}},
+			records:      map[string]*corev1.Record{},
+		},
+		referrers: map[string][]*corev1.RecordReferrer{},
+	}
+
+	trustedKey, trustedPublicKey := newSigningKey(t)
+	unknownKey, unknownPublicKey := newSigningKey(t)
+	root, rootKey := newFulcioRoot(t)
+
+	records := map[string]*corev1.Record{}
+	for _, name := range []string{"trusted", "unsigned", "unknown", "keyless", "keyless-unknown", "forged"} {
+		record := newPolicyRecord("policy-agent-" + name)
+		records[name] = record
+		server.records[record.GetCid()] = record
+	}
+
+	server.attach(t, records["trusted"].GetCid(), signRecord(t, trustedKey, records["trusted"].GetCid()))
+	server.attach(t, records["trusted"].GetCid(), &signv1.PublicKey{Key: trustedPublicKey})
+
+	server.attach(t, records["unknown"].GetCid(), signRecord(t, unknownKey, records["unknown"].GetCid()))
+	server.attach(t, records["unknown"].GetCid(), &signv1.PublicKey{Key: unknownPublicKey})
+
+	// A signature of another record attached to this record
+	server.attach(t, records["forged"].GetCid(), signRecord(t, unknownKey, records["unknown"].GetCid()))
+	server.attach(t, records["forged"].GetCid(), &signv1.PublicKey{Key: unknownPublicKey})
+
+	for name, email := range map[string]string{"keyless": "release@example.com", "keyless-unknown": "someone@example.com"} {
+		cid := records[name].GetCid()
+		ephemeralKey, _ := newSigningKey(t)
+
+		signature := signRecord(t, ephemeralKey, cid)
+		signature.Certificate = newFulcioCertificate(t, root, rootKey, ephemeralKey, "https://issuer.example.com", email)
+		server.attach(t, cid, signature)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+
+	c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) },
+		WithPullPolicy(PullPolicy{
+			TrustedKeys: []string{trustedPublicKey},
+			TrustedIdentities: []KeylessIdentity{
+				{Issuer: "https://issuer.example.com", Subject: "release@example.com"},
+			},
+			FulcioRoots: roots,
+		}),
+	)
+
+	tests := []struct {
+		name   string
+		record string
+		reason PolicyViolationReason
+	}{
+		{name: "signed by trusted key", record: "trusted"},
+		{name: "signed by trusted keyless identity", record: "keyless"},
+		{name: "unsigned", record: "unsigned", reason: PolicyReasonUnsigned},
+		{name: "signed by unknown key", record: "unknown", reason: PolicyReasonUntrustedSignature},
+		{name: "signed by unknown keyless identity", record: "keyless-unknown", reason: PolicyReasonUntrustedSignature},
+		{name: "signature of another record", record: "forged", reason: PolicyReasonUntrustedSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cid := records[tt.record].GetCid()
+
+			record, err := c.Pull(t.Context(), &corev1.RecordRef{Cid: cid})
+			if tt.reason == "" {
+				if err != nil {
+					t.Fatalf("expected record to pass the policy: %v", err)
+				}
+
+				if record.GetCid() != cid {
+					t.Fatalf("expected record %s, got %s", cid, record.GetCid())
+				}
+
+				return
+			}
+
+			var violation ErrPolicyViolation
+			if !errors.As(err, &violation) {
+				t.Fatalf("expected policy violation, got %v", err)
+			}
+
+			if violation.Reason != tt.reason || violation.CID != cid {
+				t.Fatalf("expected violation %s of record %s, got %s of record %s", tt.reason, cid, violation.Reason, violation.CID)
+			}
+
+			if record != nil {
+				t.Fatal("expected rejected record not to be returned")
+			}
+		})
+	}
+
+	t.Run("stream results", func(t *testing.T) {
+		refs := []*corev1.RecordRef{{Cid: records["trusted"].GetCid()}, {Cid: records["unsigned"].GetCid()}}
+
+		result, err := c.PullStream(t.Context(), streaming.SliceToChan(t.Context(), refs))
+		if err != nil {
+			t.Fatalf("failed to create pull stream: %v", err)
+		}
+
+		var results []*PullResult
+
+		for done := false; !done; {
+			select {
+			case err := <-result.ErrCh():
+				t.Fatalf("unexpected stream error: %v", err)
+			case res := <-result.ResCh():
+				results = append(results, res)
+			case <-result.DoneCh():
+				done = true
+			}
+		}
+
+		if len(results) != 2 || results[0].Error != nil || results[0].Record == nil {
+			t.Fatalf("expected trusted record to be pulled, got %+v", results)
+		}
+
+		var violation ErrPolicyViolation
+		if !errors.As(results[1].Error, &violation) || violation.Reason != PolicyReasonUnsigned || results[1].Record != nil {
+			t.Fatalf("expected unsigned record to be rejected, got %+v", results[1])
+		}
+	})
+
+	t.Run("unsafe pull", func(t *testing.T) {
+		cid := records["unsigned"].GetCid()
+
+		record, err := c.PullUnsafe(t.Context(), &corev1.RecordRef{Cid: cid})
+		if err != nil {
+			t.Fatalf("expected unsafe pull to skip the policy: %v", err)
+		}
+
+		if record.GetCid() != cid {
+			t.Fatalf("expected record %s, got %s", cid, record.GetCid())
+		}
+	})
+}
+
+func TestPullPolicyAttachedKeysAndRegistration(t *testing.T) {
+	server := referrerServer{
+		pullServer: pullServer{
+			lookupServer: lookupServer{metas: map[string]*corev1.RecordMeta{}},
+			records:      map[string]*corev1.Record{},
+		},
+		referrers: map[string][]*corev1.RecordReferrer{},
+	}
+
+	key, publicKey := newSigningKey(t)
+	registered := newPolicyRecord("policy-agent-registered")
+	unregistered := newPolicyRecord("policy-agent-unregistered")
+
+	for _, record := range []*corev1.Record{registered, unregistered} {
+		server.records[record.GetCid()] = record
+		server.attach(t, record.GetCid(), signRecord(t, key, record.GetCid()))
+		server.attach(t, record.GetCid(), &signv1.PublicKey{Key: publicKey})
+	}
+
+	c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) },
+		WithPullPolicy(PullPolicy{
+			RequireSignature: true,
+			Registration:     fixedRegistry{registered.GetCid(): true},
+		}),
+	)
+
+	if _, err := c.Pull(t.Context(), &corev1.RecordRef{Cid: registered.GetCid()}); err != nil {
+		t.Fatalf("expected registered record to pass the policy: %v", err)
+	}
+
+	_, err := c.Pull(t.Context(), &corev1.RecordRef{Cid: unregistered.GetCid()})
+
+	var violation ErrPolicyViolation
+	if !errors.As(err, &violation) || violation.Reason != PolicyReasonUnregistered {
+		t.Fatalf("expected unregistered violation, got %v", err)
+	}
+}
+
+func TestWithPullPolicyValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy PullPolicy
+	}{
+		{name: "invalid trusted key", policy: PullPolicy{TrustedKeys: []string{"not a key"}}},
+		{name: "keyless identity without roots", policy: PullPolicy{TrustedIdentities: []KeylessIdentity{{Issuer: ".*", Subject: ".*"}}}},
+		{name: "invalid identity pattern", policy: PullPolicy{
+			TrustedIdentities: []KeylessIdentity{{Issuer: "(", Subject: ".*"}},
+			FulcioRoots:       x509.NewCertPool(),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := WithPullPolicy(tt.policy)(&options{}); err == nil {
+				t.Fatal("expected invalid policy to be rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultHealthCheckInterval is how often endpoints are checked when multiple endpoints are configured.
+	DefaultHealthCheckInterval = 5 * time.Second
+
+	// healthCheckTimeout bounds a single endpoint health check.
+	healthCheckTimeout = 2 * time.Second
+)
+
+// isRetryable reports whether a failed call can be retried on another endpoint.
+func isRetryable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
+
+// endpoint is a connection to a single server replica.
+type endpoint struct {
+	address string
+	conn    *grpc.ClientConn
+	healthy atomic.Bool
+}
+
+// endpointPool balances calls across server replicas.
+// It implements grpc.ClientConnInterface, routing each unary call and each new
+// stream to the next healthy endpoint in round-robin order.
+// Endpoints are marked unhealthy when a call fails with Unavailable and are
+// restored by background health checks.
+type endpointPool struct {
+	endpoints []*endpoint
+	next      atomic.Uint64
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+var _ grpc.ClientConnInterface = (*endpointPool)(nil)
+
+func newEndpointPool(addresses []string, dialOpts []grpc.DialOption) (*endpointPool, error) {
+	if len(addresses) == 0 {
+		return nil, errors.New("at least one server address is required")
+	}
+
+	pool := &endpointPool{
+		stopCh: make(chan struct{}),
+	}
+
+	for _, address := range addresses {
+		conn, err := grpc.NewClient(address, dialOpts...)
+		if err != nil {
+			_ = pool.close()
+
+			return nil, fmt.Errorf("failed to create gRPC client for %s: %w", address, err)
+		}
+
+		ep := &endpoint{address: address, conn: conn}
+		ep.healthy.Store(true)
+
+		pool.endpoints = append(pool.endpoints, ep)
+	}
+
+	// A single endpoint has nothing to fail over to
+	if len(pool.endpoints) > 1 {
+		pool.wg.Add(1)
+
+		go pool.watch(DefaultHealthCheckInterval)
+	}
+
+	return pool, nil
+}
+
+// failovers returns how many times a failed call can be retried on another endpoint.
+func (p *endpointPool) failovers() int {
+	return len(p.endpoints) - 1
+}
+
+// pick returns the next healthy endpoint.
+// If no endpoint is healthy, all endpoints are tried in turn.
+func (p *endpointPool) pick() *endpoint {
+	n := uint64(len(p.endpoints))
+
+	for range n {
+		ep := p.endpoints[(p.next.Add(1)-1)%n]
+		if ep.healthy.Load() {
+			return ep
+		}
+	}
+
+	return p.endpoints[(p.next.Add(1)-1)%n]
+}
+
+// markFailed marks the endpoint unhealthy if the call failed because it is unavailable.
+func (p *endpointPool) markFailed(ep *endpoint, err error) {
+	if isRetryable(err) && ep.healthy.Swap(false) {
+		logger.Warn("Server endpoint unavailable", "address", ep.address, "error", err)
+	}
+}
+
+func (p *endpointPool) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	var err error
+
+	for range len(p.endpoints) {
+		ep := p.pick()
+
+		err = ep.conn.Invoke(ctx, method, args, reply, opts...)
+		if err == nil || !isRetryable(err) || ctx.Err() != nil {
+			return err //nolint:wrapcheck
+		}
+
+		p.markFailed(ep, err)
+	}
+
+	return err //nolint:wrapcheck
+}
+
+func (p *endpointPool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	var err error
+
+	for range len(p.endpoints) {
+		ep := p.pick()
+
+		var stream grpc.ClientStream
+
+		stream, err = ep.conn.NewStream(ctx, desc, method, opts...)
+		if err == nil {
+			return &endpointStream{ClientStream: stream, pool: p, endpoint: ep}, nil
+		}
+
+		if !isRetryable(err) || ctx.Err() != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		p.markFailed(ep, err)
+	}
+
+	return nil, err //nolint:wrapcheck
+}
+
+// watch periodically checks the health of all endpoints until the pool is closed.
+func (p *endpointPool) watch(interval time.Duration) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopCh:
+			return
+		case <-ticker.C:
+			for _, ep := range p.endpoints {
+				p.check(ep)
+			}
+		}
+	}
+}
+
+func (p *endpointPool) check(ep *endpoint) {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(ep.conn).Check(ctx, &healthpb.HealthCheckRequest{})
+
+	// Servers without the health service are considered healthy if reachable
+	healthy := (err == nil && resp.GetStatus() == healthpb.HealthCheckResponse_SERVING) ||
+		status.Code(err) == codes.Unimplemented
+
+	if was := ep.healthy.Swap(healthy); was != healthy {
+		logger.Info("Server endpoint health changed", "address", ep.address, "healthy", healthy)
+	}
+}
+
+func (p *endpointPool) close() error {
+	close(p.stopCh)
+	p.wg.Wait()
+
+	var errs error
+
+	for _, ep := range p.endpoints {
+		if err := ep.conn.Close(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to close connection to %s: %w", ep.address, err))
+		}
+	}
+
+	return errs
+}
+
+// endpointStream marks its endpoint unhealthy when the stream fails because the endpoint is unavailable.
+type endpointStream struct {
+	grpc.ClientStream
+
+	pool     *endpointPool
+	endpoint *endpoint
+}
+
+func (s *endpointStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.pool.markFailed(s.endpoint, err)
+	}
+
+	return err //nolint:wrapcheck
+}
@@ -0,0 +1,208 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// replica is an in-process server reachable at its name.
+type replica struct {
+	name     string
+	server   *grpc.Server
+	listener *bufconn.Listener
+}
+
+// newReplicaClient starts an in-process server per register function
+// and creates a client connected to all of them.
+func newReplicaClient(t *testing.T, register ...func(*grpc.Server)) (*Client, []*replica) {
+	t.Helper()
+
+	replicas := make(map[string]*replica)
+	endpoints := make([]string, 0, len(register))
+	ordered := make([]*replica, 0, len(register))
+
+	for i, reg := range register {
+		r := &replica{
+			name:     fmt.Sprintf("replica-%d", i),
+			server:   grpc.NewServer(),
+			listener: bufconn.Listen(1024 * 1024), //nolint:mnd
+		}
+
+		reg(r.server)
+
+		go func() { _ = r.server.Serve(r.listener) }()
+
+		t.Cleanup(r.server.Stop)
+
+		replicas[r.name] = r
+		endpoints = append(endpoints, "passthrough:///"+r.name)
+		ordered = append(ordered, r)
+	}
+
+	c, err := New(
+		WithConfig(&Config{}),
+		WithEndpoints(endpoints),
+		WithDialOptions(
+			grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+				return replicas[addr].listener.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.Close() })
+
+	return c, ordered
+}
+
+// dyingPushServer acknowledges a number of records, then stops its server.
+type dyingPushServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	acks int
+	stop func()
+}
+
+func (s *dyingPushServer) Push(stream storev1.StoreService_PushServer) error {
+	for range s.acks {
+		record, err := stream.Recv()
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if err := stream.Send(&corev1.RecordRef{Cid: record.GetCid()}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	// The server goes down in the middle of the batch
+	go s.stop()
+
+	<-stream.Context().Done()
+
+	return stream.Context().Err() //nolint:wrapcheck
+}
+
+func TestPushBatchFailover(t *testing.T) {
+	dying := &dyingPushServer{acks: 3}
+
+	c, replicas := newReplicaClient(t,
+		func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, dying) },
+		func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, pushServer{}) },
+	)
+
+	dying.stop = replicas[0].server.Stop
+
+	records := make([]*corev1.Record, 10) //nolint:mnd
+	for i := range records {
+		records[i] = corev1.New(&typesv1alpha1.Record{
+			Name:          fmt.Sprintf("failover-agent-%d", i),
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+		})
+	}
+
+	refs, err := c.PushBatch(t.Context(), records)
+	if err != nil {
+		t.Fatalf("PushBatch() unexpected error: %v", err)
+	}
+
+	if len(refs) != len(records) {
+		t.Fatalf("expected %d refs, got %d", len(records), len(refs))
+	}
+
+	for i, ref := range refs {
+		if ref.GetCid() != records[i].GetCid() {
+			t.Errorf("expected ref %d to be %s, got %s", i, records[i].GetCid(), ref.GetCid())
+		}
+	}
+
+	// The dead endpoint is no longer used for new streams
+	if _, err := c.Push(t.Context(), records[0]); err != nil {
+		t.Errorf("Push() unexpected error after failover: %v", err)
+	}
+}
+
+// countingPublishServer counts unary publish calls.
+type countingPublishServer struct {
+	routingv1.UnimplementedRoutingServiceServer
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (s *countingPublishServer) Publish(context.Context, *routingv1.PublishRequest) (*emptypb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *countingPublishServer) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls
+}
+
+func TestUnaryFailover(t *testing.T) {
+	first, second := &countingPublishServer{}, &countingPublishServer{}
+
+	c, replicas := newReplicaClient(t,
+		func(s *grpc.Server) { routingv1.RegisterRoutingServiceServer(s, first) },
+		func(s *grpc.Server) { routingv1.RegisterRoutingServiceServer(s, second) },
+	)
+
+	publish := func() {
+		t.Helper()
+
+		err := c.Publish(t.Context(), &routingv1.PublishRequest{
+			Request: &routingv1.PublishRequest_RecordRefs{
+				RecordRefs: &routingv1.RecordRefs{Refs: []*corev1.RecordRef{{Cid: "cid"}}},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Publish() unexpected error: %v", err)
+		}
+	}
+
+	// Calls are balanced across healthy endpoints
+	for range 4 {
+		publish()
+	}
+
+	if first.count() != 2 || second.count() != 2 {
+		t.Errorf("expected calls to be balanced, got %d and %d", first.count(), second.count())
+	}
+
+	// Calls fail over to the surviving endpoint
+	replicas[0].server.Stop()
+
+	for range 4 {
+		publish()
+	}
+
+	if first.count() != 2 || second.count() != 6 {
+		t.Errorf("expected calls to fail over, got %d and %d", first.count(), second.count())
+	}
+}
@@ -0,0 +1,615 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/google/go-containerregistry/pkg/name"
+	ocidigest "github.com/opencontainers/go-digest"
+	"golang.org/x/sync/errgroup"
+	"sigs.k8s.io/yaml"
+)
+
+// Defaults of ProbeOptions.
+const (
+	DefaultProbeTimeout      = 10 * time.Second
+	DefaultProbeConcurrency  = 4
+	DefaultProbeMaxRedirects = 5
+)
+
+// Locator annotations read by ProbeLocators.
+const (
+	// LocatorAnnotationDigest pins the digest of the locator target, e.g. "sha256:...",
+	// for locators without a digest field.
+	LocatorAnnotationDigest = "digest"
+	// LocatorAnnotationChart names the chart of helm chart locators pointing to a chart repository.
+	LocatorAnnotationChart = "chart"
+	// LocatorAnnotationChartVersion is the chart version of helm chart locators pointing to a chart repository.
+	// Any version of the chart matches if not set.
+	LocatorAnnotationChartVersion = "version"
+)
+
+// Locator types probed by ProbeLocators. Locators of other types are probed with an HTTP HEAD
+// if their URL is an HTTP URL.
+const (
+	LocatorTypeDockerImage = "docker_image"
+	LocatorTypeHelmChart   = "helm_chart"
+)
+
+// manifestMediaTypes are accepted for manifest HEAD requests, so that registries
+// report the digest of the manifest or index the reference is tagged with.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// ProbeOptions configures ProbeLocators.
+type ProbeOptions struct {
+	// Timeout bounds each probe. Defaults to DefaultProbeTimeout.
+	Timeout time.Duration
+	// Concurrency bounds the probes running at once. Defaults to DefaultProbeConcurrency.
+	Concurrency int
+	// MaxRedirects bounds the redirects followed by each request of a probe.
+	// Defaults to DefaultProbeMaxRedirects, negative follows no redirects.
+	MaxRedirects int
+	// Transport sends the requests of probes, http.DefaultTransport if nil.
+	Transport http.RoundTripper
+}
+
+// ProbeStatus is the outcome of probing a locator.
+type ProbeStatus string
+
+const (
+	// ProbeStatusReachable reports that the locator target exists.
+	ProbeStatusReachable ProbeStatus = "reachable"
+	// ProbeStatusUnreachable reports that the locator target does not exist or could not be reached.
+	ProbeStatusUnreachable ProbeStatus = "unreachable"
+	// ProbeStatusUnsupported reports that the locator cannot be probed, e.g. a source code locator
+	// with a git URL.
+	ProbeStatusUnsupported ProbeStatus = "unsupported"
+)
+
+// DigestCheck is the outcome of comparing the digest of a locator target with the digest pinned by the locator.
+type DigestCheck string
+
+const (
+	// DigestCheckMatch reports that the target has the pinned digest.
+	DigestCheckMatch DigestCheck = "match"
+	// DigestCheckMismatch reports that the target has another digest than the pinned digest,
+	// i.e. the deployment drifted from the record.
+	DigestCheckMismatch DigestCheck = "mismatch"
+	// DigestCheckUnpinned reports that the locator pins no digest.
+	DigestCheckUnpinned DigestCheck = "unpinned"
+	// DigestCheckUnknown reports that the locator pins a digest, but the digest of the target is not known.
+	DigestCheckUnknown DigestCheck = "unknown"
+)
+
+// LocatorProbeResult is the result of probing a locator of a record.
+type LocatorProbeResult struct {
+	// Index is the index of the locator in the record.
+	Index int
+	// Type is the locator type, e.g. "docker_image".
+	Type string
+	// URL is the locator URL.
+	URL string
+	// Status is the outcome of the probe.
+	Status ProbeStatus
+	// StatusCode is the HTTP status of the last response, if any.
+	StatusCode int
+	// RemoteDigest is the digest of the target reported by the remote, if available.
+	RemoteDigest string
+	// PinnedDigest is the digest pinned by the locator, if any.
+	PinnedDigest string
+	// Digest compares RemoteDigest with PinnedDigest.
+	Digest DigestCheck
+	// Error describes why the target is unreachable or cannot be probed.
+	Error string
+	// Duration is the time the probe took.
+	Duration time.Duration
+}
+
+// ProbeLocators pulls the record and checks that the targets of its locators exist, to detect drift
+// between the directory and the deployment state. Probes run concurrently, each bound by a timeout:
+//   - docker image locators get a registry HEAD of the manifest the image tag or digest refers to;
+//   - helm chart locators are looked up in the index of their chart repository, or get a registry HEAD
+//     for oci:// charts. Locators point either to a chart archive listed by the repository index,
+//     or to the repository with the chart named by the "chart" and "version" annotations;
+//   - locators of other types with HTTP URLs get a HEAD. The content is hashed if the locator pins a digest.
+//
+// The remote digest is compared with the digest pinned by the locator digest, or its "digest" annotation.
+// Probe failures are reported in the results, which are in locator order. An error is only returned
+// if the record cannot be pulled or its locators cannot be read, e.g. for encrypted records.
+func (c *Client) ProbeLocators(ctx context.Context, ref *corev1.RecordRef, opts ProbeOptions) ([]LocatorProbeResult, error) {
+	record, err := c.Pull(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	locators, err := recordLocators(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read locators of %s: %w", ref.GetCid(), err)
+	}
+
+	return probeLocators(ctx, locators, opts), nil
+}
+
+// probeLocator is a locator of a record to probe.
+type probeLocator struct {
+	locatorType string
+	url         string
+	annotations map[string]string
+	digest      string
+}
+
+// recordLocators returns the locators of a record with their v0.7.0 snake_case types.
+func recordLocators(record *corev1.Record) ([]probeLocator, error) {
+	if record.GetEncrypted() || record.GetEnvelope() != nil {
+		return nil, errors.New("record is encrypted")
+	}
+
+	decoded, err := record.Decode()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	var locators []probeLocator
+
+	switch {
+	case decoded.HasV1Alpha1():
+		for _, locator := range decoded.GetV1Alpha1().GetLocators() {
+			locators = append(locators, probeLocator{locator.GetType(), locator.GetUrl(), locator.GetAnnotations(), locator.GetDigest()})
+		}
+	case decoded.HasV1Alpha0():
+		for _, locator := range decoded.GetV1Alpha0().GetLocators() {
+			locatorType := strings.ReplaceAll(locator.GetType(), "-", "_")
+			locators = append(locators, probeLocator{locatorType, locator.GetUrl(), locator.GetAnnotations(), locator.GetDigest()})
+		}
+	}
+
+	return locators, nil
+}
+
+// probeLocators probes the locators concurrently, returning the results in locator order.
+func probeLocators(ctx context.Context, locators []probeLocator, opts ProbeOptions) []LocatorProbeResult {
+	p := newProber(opts)
+	results := make([]LocatorProbeResult, len(locators))
+
+	group := &errgroup.Group{}
+	group.SetLimit(p.concurrency)
+
+	for i, locator := range locators {
+		group.Go(func() error {
+			results[i] = p.probe(ctx, i, locator)
+
+			return nil
+		})
+	}
+
+	_ = group.Wait()
+
+	return results
+}
+
+// prober probes locators with the HTTP client and limits of ProbeOptions.
+type prober struct {
+	client      *http.Client
+	timeout     time.Duration
+	concurrency int
+}
+
+func newProber(opts ProbeOptions) *prober {
+	p := &prober{
+		timeout:     opts.Timeout,
+		concurrency: opts.Concurrency,
+	}
+
+	if p.timeout <= 0 {
+		p.timeout = DefaultProbeTimeout
+	}
+
+	if p.concurrency <= 0 {
+		p.concurrency = DefaultProbeConcurrency
+	}
+
+	maxRedirects := opts.MaxRedirects
+	if maxRedirects == 0 {
+		maxRedirects = DefaultProbeMaxRedirects
+	}
+
+	transport := opts.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	p.client = &http.Client{
+		Transport: transport,
+		CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", max(maxRedirects, 0))
+			}
+
+			return nil
+		},
+	}
+
+	return p
+}
+
+// probe probes a locator within the probe timeout.
+func (p *prober) probe(ctx context.Context, index int, locator probeLocator) LocatorProbeResult {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	result := LocatorProbeResult{
+		Index:        index,
+		Type:         locator.locatorType,
+		URL:          locator.url,
+		PinnedDigest: locator.digest,
+	}
+
+	if result.PinnedDigest == "" {
+		result.PinnedDigest = locator.annotations[LocatorAnnotationDigest]
+	}
+
+	var err error
+
+	switch {
+	case locator.locatorType == LocatorTypeDockerImage:
+		err = p.probeImage(ctx, locator.url, &result)
+	case locator.locatorType == LocatorTypeHelmChart && strings.HasPrefix(locator.url, "oci://"):
+		err = p.probeImage(ctx, strings.TrimPrefix(locator.url, "oci://"), &result)
+	case locator.locatorType == LocatorTypeHelmChart:
+		err = p.probeChart(ctx, locator, &result)
+	case isHTTPURL(locator.url):
+		err = p.probeHTTP(ctx, locator.url, &result)
+	default:
+		result.Status = ProbeStatusUnsupported
+		result.Error = fmt.Sprintf("locator type %q with URL %q cannot be probed", locator.locatorType, locator.url)
+	}
+
+	switch {
+	case err != nil:
+		result.Status = ProbeStatusUnreachable
+		result.Error = err.Error()
+	case result.Status == "":
+		result.Status = ProbeStatusReachable
+	}
+
+	result.Digest = compareDigests(result.RemoteDigest, result.PinnedDigest)
+	result.Duration = time.Since(start)
+
+	return result
+}
+
+// probeImage checks that an image reference, e.g. "ghcr.io/org/agent:v1", resolves to a manifest.
+// References prefixed with "http://" are probed over plain HTTP, as are registries on localhost.
+func (p *prober) probeImage(ctx context.Context, reference string, result *LocatorProbeResult) error {
+	scheme := ""
+	for _, prefix := range []string{"docker://", "https://", "http://"} {
+		if rest, ok := strings.CutPrefix(reference, prefix); ok {
+			scheme, reference = strings.TrimSuffix(prefix, "://"), rest
+		}
+	}
+
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return fmt.Errorf("invalid image reference: %w", err)
+	}
+
+	registry := ref.Context().Registry
+	if scheme == "" || scheme == "docker" {
+		scheme = registry.Scheme()
+	}
+
+	manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", scheme, registry.RegistryStr(), ref.Context().RepositoryStr(), ref.Identifier())
+
+	resp, err := p.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return err
+	}
+
+	// Registries requiring a token for anonymous pulls answer with a bearer challenge, e.g. Docker Hub
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := p.anonymousToken(ctx, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return fmt.Errorf("registry requires authentication: %w", err)
+		}
+
+		if resp, err = p.headManifest(ctx, manifestURL, token); err != nil {
+			return err
+		}
+	}
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry returned %s for %s", resp.Status, ref)
+	}
+
+	result.RemoteDigest = resp.Header.Get("Docker-Content-Digest")
+	if digest, ok := ref.(name.Digest); ok && result.RemoteDigest == "" {
+		result.RemoteDigest = digest.DigestStr()
+	}
+
+	return nil
+}
+
+// headManifest sends a HEAD request for a manifest, with a bearer token if not empty.
+func (p *prober) headManifest(ctx context.Context, manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest URL: %w", err)
+	}
+
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return p.do(req)
+}
+
+// anonymousToken requests an anonymous pull token for a bearer challenge,
+// e.g. `Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/nginx:pull"`.
+func (p *prober) anonymousToken(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported challenge %q", challenge)
+	}
+
+	values := parseChallengeParams(params)
+
+	realm, err := url.Parse(values["realm"])
+	if err != nil || !isHTTPURL(realm.String()) {
+		return "", fmt.Errorf("invalid token realm %q", values["realm"])
+	}
+
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if value := values[key]; value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid token realm: %w", err)
+	}
+
+	resp, err := p.do(req)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("invalid token response: %w", err)
+	}
+
+	if body.Token == "" {
+		body.Token = body.AccessToken
+	}
+
+	return body.Token, nil
+}
+
+// parseChallengeParams parses the comma separated key="value" parameters of an authentication challenge.
+func parseChallengeParams(params string) map[string]string {
+	values := map[string]string{}
+
+	for params != "" {
+		var key, value string
+
+		key, params, _ = strings.Cut(params, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		if rest, ok := strings.CutPrefix(params, `"`); ok {
+			value, params, _ = strings.Cut(rest, `"`)
+			_, params, _ = strings.Cut(params, ",")
+		} else {
+			value, params, _ = strings.Cut(params, ",")
+		}
+
+		values[key] = value
+	}
+
+	return values
+}
+
+// helmIndex is the part of a chart repository index.yaml read to look up charts.
+type helmIndex struct {
+	Entries map[string][]struct {
+		Version string   `json:"version"`
+		Digest  string   `json:"digest"`
+		URLs    []string `json:"urls"`
+	} `json:"entries"`
+}
+
+// probeChart checks that a helm chart is listed by the index of its chart repository.
+func (p *prober) probeChart(ctx context.Context, locator probeLocator, result *LocatorProbeResult) error {
+	if !isHTTPURL(locator.url) {
+		return fmt.Errorf("unsupported chart URL %q", locator.url)
+	}
+
+	chartURL, err := url.Parse(locator.url)
+	if err != nil {
+		return fmt.Errorf("invalid chart URL: %w", err)
+	}
+
+	// Locators point to a chart archive in the repository, or to the repository itself
+	repoURL, archive := *chartURL, ""
+	if strings.HasSuffix(chartURL.Path, ".tgz") {
+		repoURL.Path, archive = path.Dir(chartURL.Path), path.Base(chartURL.Path)
+	}
+
+	chart, version := locator.annotations[LocatorAnnotationChart], locator.annotations[LocatorAnnotationChartVersion]
+	if archive == "" && chart == "" {
+		return fmt.Errorf("chart locator must point to a chart archive or name the chart with the %q annotation", LocatorAnnotationChart)
+	}
+
+	indexURL := repoURL.JoinPath("index.yaml")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, indexURL.String(), nil)
+	if err != nil {
+		return fmt.Errorf("invalid chart repository URL: %w", err)
+	}
+
+	resp, err := p.do(req)
+	if err != nil {
+		return err
+	}
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("chart repository returned %s for %s", resp.Status, indexURL)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read chart repository index: %w", err)
+	}
+
+	var index helmIndex
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return fmt.Errorf("invalid chart repository index: %w", err)
+	}
+
+	for entryName, entries := range index.Entries {
+		for _, entry := range entries {
+			matches := entryName == chart && (version == "" || entry.Version == version)
+			if archive != "" {
+				matches = listsArchive(indexURL, entry.URLs, chartURL)
+			}
+
+			if !matches {
+				continue
+			}
+
+			if entry.Digest != "" && !strings.Contains(entry.Digest, ":") {
+				// Chart digests are the hex encoded SHA-256 of the archive
+				entry.Digest = ocidigest.SHA256.String() + ":" + entry.Digest
+			}
+
+			result.RemoteDigest = entry.Digest
+
+			return nil
+		}
+	}
+
+	if archive != "" {
+		return fmt.Errorf("chart archive %s is not listed by the repository index", archive)
+	}
+
+	return fmt.Errorf("chart %s %s is not listed by the repository index", chart, version)
+}
+
+// listsArchive reports whether the URLs of an index entry, absolute or relative to the index, include the chart URL.
+func listsArchive(indexURL *url.URL, urls []string, chartURL *url.URL) bool {
+	for _, entryURL := range urls {
+		resolved, err := indexURL.Parse(entryURL)
+		if err == nil && resolved.String() == chartURL.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// probeHTTP sends a HEAD request to the URL. If the locator pins a digest, the content
+// is downloaded and hashed with the algorithm of the pinned digest.
+func (p *prober) probeHTTP(ctx context.Context, rawURL string, result *LocatorProbeResult) error {
+	method := http.MethodHead
+	if result.PinnedDigest != "" {
+		method = http.MethodGet
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+
+	resp, err := p.do(req)
+	if err != nil {
+		return err
+	}
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("server returned %s", resp.Status)
+	}
+
+	if method != http.MethodGet {
+		return nil
+	}
+
+	pinned, err := ocidigest.Parse(result.PinnedDigest)
+	if err != nil {
+		// The target is reachable, but the pinned digest cannot be compared
+		return nil //nolint:nilerr
+	}
+
+	digester := pinned.Algorithm().Digester()
+	if _, err := io.Copy(digester.Hash(), resp.Body); err != nil {
+		return fmt.Errorf("failed to read content: %w", err)
+	}
+
+	result.RemoteDigest = digester.Digest().String()
+
+	return nil
+}
+
+// do sends a request, closing the response body once read by the caller's request context ends.
+func (p *prober) do(req *http.Request) (*http.Response, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	context.AfterFunc(req.Context(), func() { _ = resp.Body.Close() })
+
+	return resp, nil
+}
+
+// compareDigests compares the remote digest of a locator target with the pinned digest.
+func compareDigests(remote, pinned string) DigestCheck {
+	switch {
+	case pinned == "":
+		return DigestCheckUnpinned
+	case remote == "":
+		return DigestCheckUnknown
+	case strings.EqualFold(remote, pinned):
+		return DigestCheckMatch
+	default:
+		return DigestCheckMismatch
+	}
+}
+
+func isHTTPURL(rawURL string) bool {
+	return strings.HasPrefix(rawURL, "http://") || strings.HasPrefix(rawURL, "https://")
+}
@@ -0,0 +1,201 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	ocidigest "github.com/opencontainers/go-digest"
+	"google.golang.org/grpc"
+)
+
+const imageDigest = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+
+// newRegistryServer serves manifest HEAD requests of the "org/agent:v1" image,
+// requiring an anonymous bearer token like Docker Hub.
+func newRegistryServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			if r.URL.Query().Get("scope") != "repository:org/agent:pull" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"token": "anonymous"}`))
+		case r.Header.Get("Authorization") != "Bearer anonymous":
+			w.Header().Set("WWW-Authenticate",
+				fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:org/agent:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.Method == http.MethodHead && (r.URL.Path == "/v2/org/agent/manifests/v1" || r.URL.Path == "/v2/org/agent/manifests/"+imageDigest):
+			w.Header().Set("Docker-Content-Digest", imageDigest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newContentServer serves a chart repository under /charts, a file under /agent.bin,
+// and a redirect loop under /loop.
+func newContentServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/charts/index.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`apiVersion: v1
+entries:
+  agent:
+    - version: 1.2.0
+      digest: 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824
+      urls:
+        - agent-1.2.0.tgz
+    - version: 1.1.0
+      digest: 486ea46224d1bb4fb680f34f7c9ad96a8f24ec88be73ea8e5a6c65260e9cb8a7
+      urls:
+        - agent-1.1.0.tgz
+`))
+	})
+	mux.HandleFunc("/agent.bin", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("agent binary"))
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestProbeLocators(t *testing.T) {
+	registry := strings.TrimPrefix(newRegistryServer(t).URL, "http://")
+	content := newContentServer(t).URL
+	binaryDigest := ocidigest.FromString("agent binary").String()
+	otherDigest := ocidigest.FromString("other").String()
+
+	locators := []*typesv1alpha1.Locator{
+		{Type: LocatorTypeDockerImage, Url: registry + "/org/agent:v1", Digest: stringPtr(imageDigest)},
+		{Type: LocatorTypeDockerImage, Url: registry + "/org/agent@" + imageDigest},
+		{Type: LocatorTypeDockerImage, Url: registry + "/org/agent:v2"},
+		{Type: LocatorTypeDockerImage, Url: registry + "/org/agent:v1", Annotations: map[string]string{LocatorAnnotationDigest: otherDigest}},
+		{Type: LocatorTypeHelmChart, Url: content + "/charts/agent-1.2.0.tgz", Digest: stringPtr("sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")},
+		{Type: LocatorTypeHelmChart, Url: content + "/charts", Annotations: map[string]string{LocatorAnnotationChart: "agent", LocatorAnnotationChartVersion: "1.1.0"}},
+		{Type: LocatorTypeHelmChart, Url: content + "/charts", Annotations: map[string]string{LocatorAnnotationChart: "agent", LocatorAnnotationChartVersion: "9.9.9"}},
+		{Type: "binary", Url: content + "/agent.bin", Digest: stringPtr(binaryDigest)},
+		{Type: "binary", Url: content + "/missing.bin"},
+		{Type: "source_code", Url: content + "/loop"},
+		{Type: "source_code", Url: "git@github.com:org/agent.git"},
+	}
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "probed-agent",
+		Version:       "1.0.0",
+		SchemaVersion: "0.7.0",
+		Locators:      locators,
+	})
+
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterStoreServiceServer(s, newCountingStoreServer(record))
+	})
+
+	results, err := c.ProbeLocators(t.Context(), &corev1.RecordRef{Cid: record.GetCid()}, ProbeOptions{MaxRedirects: 2})
+	if err != nil {
+		t.Fatalf("failed to probe locators: %v", err)
+	}
+
+	expected := []struct {
+		status ProbeStatus
+		digest DigestCheck
+		remote string
+	}{
+		{ProbeStatusReachable, DigestCheckMatch, imageDigest},
+		{ProbeStatusReachable, DigestCheckUnpinned, imageDigest},
+		{ProbeStatusUnreachable, DigestCheckUnpinned, ""},
+		{ProbeStatusReachable, DigestCheckMismatch, imageDigest},
+		{ProbeStatusReachable, DigestCheckMatch, "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		{ProbeStatusReachable, DigestCheckUnpinned, "sha256:486ea46224d1bb4fb680f34f7c9ad96a8f24ec88be73ea8e5a6c65260e9cb8a7"},
+		{ProbeStatusUnreachable, DigestCheckUnpinned, ""},
+		{ProbeStatusReachable, DigestCheckMatch, binaryDigest},
+		{ProbeStatusUnreachable, DigestCheckUnpinned, ""},
+		{ProbeStatusUnreachable, DigestCheckUnpinned, ""},
+		{ProbeStatusUnsupported, DigestCheckUnpinned, ""},
+	}
+
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d results, got %d", len(expected), len(results))
+	}
+
+	for i, want := range expected {
+		got := results[i]
+		if got.Index != i || got.URL != locators[i].GetUrl() {
+			t.Errorf("result %d: expected locator %s, got %d %s", i, locators[i].GetUrl(), got.Index, got.URL)
+		}
+
+		if got.Status != want.status || got.Digest != want.digest || got.RemoteDigest != want.remote {
+			t.Errorf("result %d (%s): expected %s/%s/%q, got %s/%s/%q (%s)",
+				i, got.URL, want.status, want.digest, want.remote, got.Status, got.Digest, got.RemoteDigest, got.Error)
+		}
+	}
+
+	if !strings.Contains(results[9].Error, "stopped after 2 redirects") {
+		t.Errorf("expected redirect loop to stop after 2 redirects, got %q", results[9].Error)
+	}
+}
+
+func TestProbeLocatorsTimeout(t *testing.T) {
+	release := make(chan struct{})
+
+	slow := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { <-release }))
+	t.Cleanup(slow.Close)
+	t.Cleanup(func() { close(release) })
+
+	locators := make([]probeLocator, 4)
+	for i := range locators {
+		locators[i] = probeLocator{locatorType: "binary", url: slow.URL}
+	}
+
+	start := time.Now()
+
+	// Probes run concurrently, so all of them time out at about the same time
+	results := probeLocators(t.Context(), locators, ProbeOptions{Timeout: 100 * time.Millisecond, Concurrency: len(locators)})
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected concurrent probes to time out together, took %s", elapsed)
+	}
+
+	for i, result := range results {
+		if result.Status != ProbeStatusUnreachable {
+			t.Errorf("result %d: expected timed out probe to be unreachable, got %s", i, result.Status)
+		}
+	}
+}
+
+func TestParseChallengeParams(t *testing.T) {
+	params := parseChallengeParams(`realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/nginx:pull,push"`)
+
+	if params["realm"] != "https://auth.docker.io/token" || params["service"] != "registry.docker.io" ||
+		params["scope"] != "repository:library/nginx:pull,push" {
+		t.Errorf("unexpected challenge params: %v", params)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
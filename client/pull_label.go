@@ -0,0 +1,183 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+)
+
+// PullByLabelOption configures PullByLabel.
+type PullByLabelOption func(*pullByLabelOptions)
+
+type pullByLabelOptions struct {
+	limit    uint32
+	metadata bool
+	verify   *bool
+}
+
+// WithPullLimit pulls at most limit records.
+func WithPullLimit(limit uint32) PullByLabelOption {
+	return func(opts *pullByLabelOptions) {
+		opts.limit = limit
+	}
+}
+
+// WithPullMetadata also looks up the metadata of every pulled record, returned in PullResult.Meta.
+func WithPullMetadata() PullByLabelOption {
+	return func(opts *pullByLabelOptions) {
+		opts.metadata = true
+	}
+}
+
+// WithPullVerification enables or disables the content verification of the pulled records,
+// overriding WithContentVerification for the call.
+func WithPullVerification(enabled bool) PullByLabelOption {
+	return func(opts *pullByLabelOptions) {
+		opts.verify = &enabled
+	}
+}
+
+// PullByLabel pulls the records carrying any of the labels, e.g. "/skills/nlp/*", see
+// routingv1.ListRequest. Records are listed with a single label query and pulled as their
+// references arrive, rather than after the list completes, so that listing and pulling overlap.
+// Records carrying several of the labels are pulled once.
+//
+// The channel is closed once all records are pulled or ctx is done. Records are pulled
+// as fast as the channel is read. Records that could not be pulled are reported via
+// PullResult.Error, and a failure of the list is reported last with PullResult.Index -1.
+func (c *Client) PullByLabel(ctx context.Context, labels []string, opts ...PullByLabelOption) (<-chan PullResult, error) {
+	if len(labels) == 0 {
+		return nil, errors.New("at least one label is required")
+	}
+
+	options := &pullByLabelOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	verify := c.verifyContent
+	if options.verify != nil {
+		verify = *options.verify
+	}
+
+	query, err := labelQuery(labels)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &routingv1.ListRequest{Query: query}
+	if options.limit > 0 {
+		req.Limit = &options.limit
+	}
+
+	listStream, err := c.RoutingServiceClient.List(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create list stream: %w", err)
+	}
+
+	// References are handed over one at a time, so that the list is only read as fast as records are pulled
+	refsCh := make(chan *corev1.RecordRef)
+	listErrCh := make(chan error, 1)
+
+	go func() {
+		defer close(refsCh)
+
+		seen := map[string]bool{}
+
+		for {
+			resp, err := listStream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				listErrCh <- fmt.Errorf("failed to list records: %w", err)
+
+				return
+			}
+
+			cid := resp.GetRecordRef().GetCid()
+			if seen[cid] {
+				continue
+			}
+
+			seen[cid] = true
+
+			select {
+			case refsCh <- resp.GetRecordRef():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	result, err := c.pullStream(ctx, refsCh, verify)
+	if err != nil {
+		return nil, err
+	}
+
+	resCh := make(chan PullResult)
+
+	send := func(res PullResult) bool {
+		select {
+		case resCh <- res:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer close(resCh)
+
+		for {
+			select {
+			case err := <-result.ErrCh():
+				if !send(PullResult{Index: -1, Error: err}) {
+					return
+				}
+			case res := <-result.ResCh():
+				if res.Error == nil && options.metadata {
+					res.Meta, res.Error = c.Lookup(ctx, &corev1.RecordRef{Cid: res.Record.GetCid()})
+				}
+
+				if !send(*res) {
+					return
+				}
+			case <-result.DoneCh():
+				select {
+				case err := <-listErrCh:
+					send(PullResult{Index: -1, Error: err})
+				default:
+				}
+
+				return
+			}
+		}
+	}()
+
+	return resCh, nil
+}
+
+// labelQuery returns the label query matching records carrying any of the labels.
+func labelQuery(labels []string) (string, error) {
+	quoted := make([]string, 0, len(labels))
+
+	for _, label := range labels {
+		if label == "" || strings.Contains(label, `"`) {
+			return "", fmt.Errorf("invalid label %q", label)
+		}
+
+		quoted = append(quoted, `"`+label+`"`)
+	}
+
+	return strings.Join(quoted, " OR "), nil
+}
@@ -0,0 +1,183 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// labelListServer lists fixed responses, one per record and matching label,
+// and fails with err once they are sent if it is set.
+type labelListServer struct {
+	routingv1.UnimplementedRoutingServiceServer
+
+	responses []*routingv1.ListResponse
+	err       error
+	queries   []string
+}
+
+func (s *labelListServer) List(req *routingv1.ListRequest, stream routingv1.RoutingService_ListServer) error {
+	s.queries = append(s.queries, req.GetQuery())
+
+	for i, resp := range s.responses {
+		if req.Limit != nil && uint32(i) >= req.GetLimit() { //nolint:gosec
+			break
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return s.err
+}
+
+func newPullByLabelClient(t *testing.T, routing *labelListServer, store *countingStoreServer) *Client {
+	t.Helper()
+
+	return newBufconnClient(t, func(s *grpc.Server) {
+		routingv1.RegisterRoutingServiceServer(s, routing)
+		storev1.RegisterStoreServiceServer(s, store)
+	})
+}
+
+func collectPullResults(t *testing.T, resCh <-chan PullResult) ([]PullResult, []error) {
+	t.Helper()
+
+	var (
+		results []PullResult
+		errs    []error
+	)
+
+	for res := range resCh {
+		if res.Error != nil {
+			errs = append(errs, res.Error)
+
+			continue
+		}
+
+		results = append(results, res)
+	}
+
+	return results, errs
+}
+
+func TestPullByLabel(t *testing.T) {
+	var (
+		records   []*corev1.Record
+		responses []*routingv1.ListResponse
+	)
+
+	for i := range 4 {
+		record := newCacheTestRecord(fmt.Sprintf("labelled-agent-%d", i))
+		records = append(records, record)
+
+		// The first two records carry both labels and are listed twice
+		labels := []string{"/skills/a"}
+		if i < 2 { //nolint:mnd
+			labels = append(labels, "/skills/b")
+		}
+
+		for range labels {
+			responses = append(responses, &routingv1.ListResponse{
+				RecordRef: &corev1.RecordRef{Cid: record.GetCid()},
+				Labels:    labels,
+			})
+		}
+	}
+
+	t.Run("overlapping labels", func(t *testing.T) {
+		routing := &labelListServer{responses: responses}
+		store := newCountingStoreServer(records...)
+		c := newPullByLabelClient(t, routing, store)
+
+		resCh, err := c.PullByLabel(t.Context(), []string{"/skills/a", "/skills/b"})
+		if err != nil {
+			t.Fatalf("PullByLabel() unexpected error: %v", err)
+		}
+
+		results, errs := collectPullResults(t, resCh)
+		if len(errs) > 0 {
+			t.Fatalf("PullByLabel() unexpected errors: %v", errs)
+		}
+
+		if len(results) != len(records) {
+			t.Errorf("expected %d records, got %d", len(records), len(results))
+		}
+
+		for _, record := range records {
+			if got := store.pullCount(record.GetCid()); got != 1 {
+				t.Errorf("expected record %s to be pulled once, got %d", record.GetCid(), got)
+			}
+		}
+
+		if want := `"/skills/a" OR "/skills/b"`; routing.queries[0] != want {
+			t.Errorf("expected query %q, got %q", want, routing.queries[0])
+		}
+	})
+
+	t.Run("limit and metadata", func(t *testing.T) {
+		routing := &labelListServer{responses: responses}
+		store := newCountingStoreServer(records...)
+		c := newPullByLabelClient(t, routing, store)
+
+		resCh, err := c.PullByLabel(t.Context(), []string{"/skills/*"}, WithPullLimit(2), WithPullMetadata())
+		if err != nil {
+			t.Fatalf("PullByLabel() unexpected error: %v", err)
+		}
+
+		results, errs := collectPullResults(t, resCh)
+		if len(errs) > 0 {
+			t.Fatalf("PullByLabel() unexpected errors: %v", errs)
+		}
+
+		// The first two responses list the same record
+		if len(results) != 1 {
+			t.Fatalf("expected 1 record, got %d", len(results))
+		}
+
+		if got := results[0].Meta.GetCid(); got != records[0].GetCid() {
+			t.Errorf("expected metadata of %s, got %q", records[0].GetCid(), got)
+		}
+	})
+
+	t.Run("list failure", func(t *testing.T) {
+		routing := &labelListServer{responses: responses[:1], err: status.Error(codes.Unavailable, "routing unavailable")}
+		store := newCountingStoreServer(records...)
+		c := newPullByLabelClient(t, routing, store)
+
+		resCh, err := c.PullByLabel(t.Context(), []string{"/skills/a"})
+		if err != nil {
+			t.Fatalf("PullByLabel() unexpected error: %v", err)
+		}
+
+		results, errs := collectPullResults(t, resCh)
+		if len(results) != 1 {
+			t.Errorf("expected the record listed before the failure, got %d records", len(results))
+		}
+
+		if len(errs) != 1 || status.Code(errors.Unwrap(errs[0])) != codes.Unavailable {
+			t.Errorf("expected the list failure, got %v", errs)
+		}
+	})
+
+	t.Run("invalid labels", func(t *testing.T) {
+		c := newPullByLabelClient(t, &labelListServer{}, newCountingStoreServer())
+
+		for _, labels := range [][]string{nil, {""}, {`/skills/"a"`}} {
+			if _, err := c.PullByLabel(t.Context(), labels); err == nil {
+				t.Errorf("expected an error for labels %q", labels)
+			}
+		}
+	})
+}
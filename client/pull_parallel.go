@@ -0,0 +1,267 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+)
+
+// pullWindow bounds the references in flight on each stream of a parallel pull,
+// so that references are spread across streams and unread records do not pile up.
+const pullWindow = 64
+
+// maxBatchErrorDetails bounds the item errors listed in the message of a BatchError.
+const maxBatchErrorDetails = 10
+
+// errNotPulled is reported for references whose stream ended without a response.
+var errNotPulled = errors.New("pull stream ended before the record was pulled")
+
+// BatchError reports the items of a batch that failed, by their index in the input.
+// It unwraps to the item errors, so errors.Is and errors.As match any of them.
+type BatchError struct {
+	// Total is the number of items in the batch.
+	Total int
+	// Errors are the failures by index of the input.
+	Errors map[int]error
+}
+
+func (e *BatchError) Error() string {
+	indexes := e.Indexes()
+
+	details := make([]string, 0, min(len(indexes), maxBatchErrorDetails)+1)
+	for _, index := range indexes[:min(len(indexes), maxBatchErrorDetails)] {
+		details = append(details, fmt.Sprintf("index %d: %v", index, e.Errors[index]))
+	}
+
+	if more := len(indexes) - maxBatchErrorDetails; more > 0 {
+		details = append(details, fmt.Sprintf("and %d more", more))
+	}
+
+	return fmt.Sprintf("%d of %d items failed: %s", len(indexes), e.Total, strings.Join(details, "; "))
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, index := range e.Indexes() {
+		errs = append(errs, e.Errors[index])
+	}
+
+	return errs
+}
+
+// Indexes returns the indexes of the failed items in ascending order.
+func (e *BatchError) Indexes() []int {
+	return slices.Sorted(maps.Keys(e.Errors))
+}
+
+// PullBatchParallel pulls the records over up to parallelism concurrent streams,
+// so that pulling many records is not bound by the round trips of a single stream
+// and a slow record only delays the records behind it on its own stream.
+//
+// Records are returned at the index of their reference, with nil records for references that failed.
+// Failures are reported in a BatchError. Use PullBatchFunc to process records without holding all of them.
+func (c *Client) PullBatchParallel(ctx context.Context, refs []*corev1.RecordRef, parallelism int) ([]*corev1.Record, error) {
+	records := make([]*corev1.Record, len(refs))
+
+	err := c.PullBatchFunc(ctx, refs, parallelism, func(index int, record *corev1.Record, err error) {
+		if err == nil {
+			records[index] = record
+		}
+	})
+
+	return records, err
+}
+
+// PullBatchFunc pulls the records like PullBatchParallel, but passes each record or failure to fn
+// instead of collecting them. fn is called exactly once per reference with its index, in no
+// particular order and never concurrently. References still pending when ctx is done fail with its error.
+//
+// Returns a BatchError if any reference failed.
+func (c *Client) PullBatchFunc(ctx context.Context, refs []*corev1.RecordRef, parallelism int, fn func(index int, record *corev1.Record, err error)) error {
+	if parallelism <= 0 {
+		return errors.New("parallelism must be positive")
+	}
+
+	if len(refs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	batch := &parallelPull{fn: fn, errs: map[int]error{}, pulled: make([]bool, len(refs))}
+
+	jobs := make(chan int)
+
+	go func() {
+		defer close(jobs)
+
+		for index := range refs {
+			select {
+			case jobs <- index:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+
+	for range min(parallelism, len(refs)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			c.pullWorker(ctx, refs, jobs, batch)
+		}()
+	}
+
+	wg.Wait()
+
+	// References that were never sent failed because ctx is done or all streams failed
+	cause := context.Cause(ctx)
+	if cause == nil {
+		cause = errNotPulled
+	}
+
+	for index, pulled := range batch.pulled {
+		if !pulled {
+			batch.finish(index, nil, fmt.Errorf("failed to pull record: %w", cause))
+		}
+	}
+
+	if len(batch.errs) > 0 {
+		return &BatchError{Total: len(refs), Errors: batch.errs}
+	}
+
+	return nil
+}
+
+// parallelPull collects the results of a parallel pull.
+type parallelPull struct {
+	fn func(int, *corev1.Record, error)
+
+	mu     sync.Mutex
+	errs   map[int]error
+	pulled []bool // whether fn was called for each index
+}
+
+func (p *parallelPull) finish(index int, record *corev1.Record, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.pulled[index] {
+		return
+	}
+
+	p.pulled[index] = true
+
+	if err != nil {
+		p.errs[index] = err
+	}
+
+	p.fn(index, record, err)
+}
+
+// pullWorker pulls the references of the jobs it takes on a single stream,
+// with at most pullWindow references in flight.
+func (c *Client) pullWorker(ctx context.Context, refs []*corev1.RecordRef, jobs <-chan int, batch *parallelPull) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		indexes []int // input index of each reference sent on the stream
+	)
+
+	window := make(chan struct{}, pullWindow)
+	refsCh := make(chan *corev1.RecordRef)
+
+	go func() {
+		defer close(refsCh)
+
+		for {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			var (
+				index int
+				ok    bool
+			)
+
+			select {
+			case index, ok = <-jobs:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			mu.Lock()
+			indexes = append(indexes, index)
+			mu.Unlock()
+
+			select {
+			case refsCh <- refs[index]:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Indexes taken from the jobs but not answered when the stream ends fail with its error
+	streamErr := errNotPulled
+
+	defer func() {
+		cancel()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, index := range indexes {
+			batch.finish(index, nil, fmt.Errorf("failed to pull record: %w", streamErr))
+		}
+	}()
+
+	result, err := c.PullStream(ctx, refsCh)
+	if err != nil {
+		streamErr = err
+
+		return
+	}
+
+	for {
+		select {
+		case err := <-result.ErrCh():
+			streamErr = err
+		case resp := <-result.ResCh():
+			mu.Lock()
+			index := indexes[resp.Index]
+			mu.Unlock()
+
+			batch.finish(index, resp.Record, resp.Error)
+
+			<-window
+		case <-result.DoneCh():
+			if ctx.Err() != nil {
+				streamErr = context.Cause(ctx)
+			}
+
+			return
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+)
+
+// streamCountingServer counts the pull streams opened on a countingStoreServer.
+type streamCountingServer struct {
+	*countingStoreServer
+
+	streams atomic.Int32
+}
+
+func (s *streamCountingServer) Pull(stream storev1.StoreService_PullServer) error {
+	s.streams.Add(1)
+
+	return s.countingStoreServer.Pull(stream)
+}
+
+func newParallelPullClient(t *testing.T, count int) (*Client, *streamCountingServer, []*corev1.RecordRef) {
+	t.Helper()
+
+	server := &streamCountingServer{countingStoreServer: newCountingStoreServer()}
+
+	refs := make([]*corev1.RecordRef, count)
+
+	for i := range refs {
+		record := newCacheTestRecord(fmt.Sprintf("parallel-agent-%d", i))
+		refs[i] = &corev1.RecordRef{Cid: record.GetCid()}
+
+		// Every 10th record is missing
+		if i%10 != 3 {
+			server.records[record.GetCid()] = record
+		}
+	}
+
+	c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) })
+
+	return c, server, refs
+}
+
+func TestPullBatchParallel(t *testing.T) {
+	const count = 500
+
+	c, server, refs := newParallelPullClient(t, count)
+
+	records, err := c.PullBatchParallel(t.Context(), refs, 4)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected BatchError, got %v", err)
+	}
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected BatchError to unwrap to ErrNotFound, got %v", err)
+	}
+
+	var missing []int
+
+	for i, ref := range refs {
+		if i%10 == 3 {
+			missing = append(missing, i)
+
+			if records[i] != nil {
+				t.Errorf("expected no record at failed index %d", i)
+			}
+
+			continue
+		}
+
+		if records[i].GetCid() != ref.GetCid() {
+			t.Errorf("index %d: expected record %s, got %s", i, ref.GetCid(), records[i].GetCid())
+		}
+	}
+
+	if got := batchErr.Indexes(); !slices.Equal(got, missing) {
+		t.Errorf("expected failed indexes %v, got %v", missing, got)
+	}
+
+	if streams := server.streams.Load(); streams != 4 {
+		t.Errorf("expected 4 pull streams, got %d", streams)
+	}
+}
+
+func TestPullBatchFunc(t *testing.T) {
+	const count = 300
+
+	c, _, refs := newParallelPullClient(t, count)
+
+	var (
+		calls   = make([]int, count)
+		running atomic.Int32
+	)
+
+	err := c.PullBatchFunc(t.Context(), refs, 8, func(index int, record *corev1.Record, err error) {
+		if running.Add(1) > 1 {
+			t.Error("callback called concurrently")
+		}
+		defer running.Add(-1)
+
+		calls[index]++
+
+		if err == nil && record.GetCid() != refs[index].GetCid() {
+			t.Errorf("index %d: unexpected record %s", index, record.GetCid())
+		}
+	})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != count/10 {
+		t.Errorf("expected %d failures, got %v", count/10, err)
+	}
+
+	for index, n := range calls {
+		if n != 1 {
+			t.Errorf("index %d: expected a single callback, got %d", index, n)
+		}
+	}
+}
+
+func TestPullBatchParallelCancel(t *testing.T) {
+	c, server, refs := newParallelPullClient(t, 200)
+	server.delay = 5 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	var calls atomic.Int32
+
+	err := c.PullBatchFunc(ctx, refs, 2, func(int, *corev1.Record, error) { calls.Add(1) })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+
+	if got := calls.Load(); got != int32(len(refs)) {
+		t.Errorf("expected a callback for each of %d references, got %d", len(refs), got)
+	}
+}
+
+func TestPullBatchParallelValidation(t *testing.T) {
+	c, _, refs := newParallelPullClient(t, 1)
+
+	if _, err := c.PullBatchParallel(t.Context(), refs, 0); err == nil {
+		t.Error("expected zero parallelism to be rejected")
+	}
+
+	records, err := c.PullBatchParallel(t.Context(), nil, 4)
+	if err != nil || len(records) != 0 {
+		t.Errorf("expected no records for no references, got %v, %v", records, err)
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// errSharedPushStreamClosed is returned by pushes on the shared push stream of a closed client.
+var errSharedPushStreamClosed = errors.New("shared push stream is closed")
+
+// sharedPushStream multiplexes single-record pushes onto a long-lived push stream, see WithSharedPushStream.
+//
+// The server handles the records of a stream in order and ends the stream when it fails to handle one,
+// so a failure is attributed to the oldest unanswered record. The other unanswered records are pushed
+// again on a new stream, so that a bad record only fails its own caller.
+type sharedPushStream struct {
+	client  *Client
+	maxIdle time.Duration
+
+	// sendMu serializes sends, which must not be concurrent on a gRPC stream.
+	// It is acquired before mu.
+	sendMu sync.Mutex
+
+	mu      sync.Mutex
+	current *pushStreamConn
+	closed  bool
+
+	// opened counts the streams opened so far.
+	opened atomic.Int64
+}
+
+// pushStreamConn is a single push stream and the records sent on it that are not answered yet.
+type pushStreamConn struct {
+	stream  storev1.StoreService_PushClient
+	cancel  context.CancelFunc
+	idle    *time.Timer
+	pending []*sharedPush
+}
+
+// sharedPush is a record pushed on the shared push stream on behalf of a Push caller.
+type sharedPush struct {
+	record *corev1.Record
+	cid    string
+	// attempts counts the streams the record was sent on that became unavailable.
+	attempts int
+	done     chan sharedPushResult
+}
+
+type sharedPushResult struct {
+	ref *corev1.RecordRef
+	err error
+}
+
+func newSharedPushStream(client *Client, maxIdle time.Duration) *sharedPushStream {
+	return &sharedPushStream{client: client, maxIdle: maxIdle}
+}
+
+// accepts reports whether a push with the context can use the shared stream.
+// Pushes with outgoing metadata, e.g. overwrite requests, and pushes observed by hooks use their own stream,
+// as the metadata of a stream is fixed when it is opened.
+func (s *sharedPushStream) accepts(ctx context.Context) bool {
+	if hooks := s.client.hooks; hooks != nil && (hooks.BeforePush != nil || hooks.AfterPush != nil) {
+		return false
+	}
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+
+	return len(md) == 0
+}
+
+// push sends the record on the shared stream and waits for its reference.
+// If ctx is done first, the record may still be pushed but its reference is discarded.
+func (s *sharedPushStream) push(ctx context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
+	p := &sharedPush{record: record, cid: record.GetCid(), done: make(chan sharedPushResult, 1)}
+
+	s.send(p)
+
+	select {
+	case result := <-p.done:
+		return result.ref, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err() //nolint:wrapcheck
+	}
+}
+
+// send sends the record on the current stream, opening a new stream if there is none.
+// Failures are reported to the record's caller.
+func (s *sharedPushStream) send(p *sharedPush) {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	s.mu.Lock()
+
+	if s.closed {
+		s.mu.Unlock()
+		p.finish(nil, errSharedPushStreamClosed)
+
+		return
+	}
+
+	conn := s.current
+	if conn == nil {
+		var err error
+		if conn, err = s.open(); err != nil {
+			s.mu.Unlock()
+			p.finish(nil, err)
+
+			return
+		}
+	}
+
+	conn.idle.Stop()
+	conn.pending = append(conn.pending, p)
+	s.mu.Unlock()
+
+	// A failed send also fails the stream, which the receiver reports
+	if err := conn.stream.Send(p.record); err != nil {
+		logger.Debug("Failed to send record on shared push stream", "cid", p.cid, "error", err)
+	}
+}
+
+// open opens a new stream and starts receiving its responses.
+// The caller must hold both locks.
+func (s *sharedPushStream) open() (*pushStreamConn, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream, err := s.client.StoreServiceClient.Push(ctx)
+	if err != nil {
+		cancel()
+
+		return nil, fmt.Errorf("failed to create push stream: %w", err)
+	}
+
+	s.opened.Add(1)
+
+	conn := &pushStreamConn{stream: stream, cancel: cancel}
+	conn.idle = time.AfterFunc(s.maxIdle, func() { s.closeIdle(conn) })
+	conn.idle.Stop()
+
+	s.current = conn
+
+	go s.receive(conn)
+
+	return conn, nil
+}
+
+// receive delivers the responses of the stream to the callers until the stream ends.
+func (s *sharedPushStream) receive(conn *pushStreamConn) {
+	defer conn.cancel()
+
+	for {
+		ref, err := conn.stream.Recv()
+		if err != nil {
+			s.fail(conn, err)
+
+			return
+		}
+
+		s.mu.Lock()
+		p := conn.match(ref.GetCid())
+
+		if len(conn.pending) == 0 && s.current == conn {
+			conn.idle.Reset(s.maxIdle)
+		}
+		s.mu.Unlock()
+
+		if p == nil {
+			logger.Warn("Unexpected response on shared push stream", "cid", ref.GetCid())
+
+			continue
+		}
+
+		p.finish(ref, nil)
+	}
+}
+
+// fail handles the end of the stream: unanswered records are failed or pushed again on a new stream.
+func (s *sharedPushStream) fail(conn *pushStreamConn, err error) {
+	s.mu.Lock()
+
+	if s.current == conn {
+		s.current = nil
+	}
+
+	pending := conn.pending
+	conn.pending = nil
+	closed := s.closed
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	if errors.Is(err, io.EOF) {
+		err = errors.New("push stream closed by server")
+	}
+
+	switch {
+	case closed:
+		err = errSharedPushStreamClosed
+	case isRetryable(err):
+		// The endpoint became unavailable, records are pushed again on another endpoint
+		for _, p := range pending {
+			if p.attempts++; p.attempts > s.client.pool.failovers() {
+				p.finish(nil, fmt.Errorf("failed to push record: %w", err))
+			} else {
+				s.send(p)
+			}
+		}
+
+		return
+	case isRecordError(err):
+		// The oldest record failed the stream, the others were not handled
+		pending[0].finish(nil, fmt.Errorf("failed to push record: %w", err))
+
+		for _, p := range pending[1:] {
+			s.send(p)
+		}
+
+		return
+	}
+
+	for _, p := range pending {
+		p.finish(nil, fmt.Errorf("failed to push record: %w", err))
+	}
+}
+
+// closeIdle closes the stream if it is still the current stream and has no unanswered records.
+func (s *sharedPushStream) closeIdle(conn *pushStreamConn) {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.current != conn || len(conn.pending) > 0 {
+		return
+	}
+
+	s.current = nil
+
+	logger.Debug("Closing idle shared push stream", "max_idle", s.maxIdle)
+
+	// The receiver ends once the server ends the stream
+	_ = conn.stream.CloseSend()
+}
+
+// close closes the shared stream, failing unanswered pushes.
+func (s *sharedPushStream) close() {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+
+	if s.current != nil {
+		s.current.idle.Stop()
+		s.current.cancel()
+		s.current = nil
+	}
+}
+
+// match returns the unanswered record the response is for and stops tracking it.
+// Responses are matched by CID, responses without a known CID to the oldest record.
+// The caller must hold the lock of the shared stream.
+func (c *pushStreamConn) match(cid string) *sharedPush {
+	if len(c.pending) == 0 {
+		return nil
+	}
+
+	index := 0
+
+	for i, p := range c.pending {
+		if p.cid == cid {
+			index = i
+
+			break
+		}
+	}
+
+	p := c.pending[index]
+	c.pending = append(c.pending[:index], c.pending[index+1:]...)
+
+	return p
+}
+
+// finish reports the result to the caller. Results after the first are ignored.
+func (p *sharedPush) finish(ref *corev1.RecordRef, err error) {
+	select {
+	case p.done <- sharedPushResult{ref: ref, err: err}:
+	default:
+	}
+}
+
+// isRecordError reports whether the stream failed because of the record being handled,
+// rather than because of the connection or the caller.
+func isRecordError(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.AlreadyExists, codes.ResourceExhausted,
+		codes.PermissionDenied, codes.NotFound, codes.OutOfRange, codes.Internal:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sharedPushServer acknowledges pushed records in order and ends the stream on records with a bad CID,
+// like the server does for records failing validation.
+type sharedPushServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	bad map[string]bool
+
+	opened, closed atomic.Int32
+}
+
+func (s *sharedPushServer) Push(stream storev1.StoreService_PushServer) error {
+	s.opened.Add(1)
+	defer s.closed.Add(1)
+
+	for {
+		record, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if s.bad[record.GetCid()] {
+			return status.Errorf(codes.InvalidArgument, "record validation failed: bad record %s", record.GetCid())
+		}
+
+		if err := stream.Send(&corev1.RecordRef{Cid: record.GetCid()}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func newSharedPushRecord(i int) *corev1.Record {
+	return corev1.New(&typesv1alpha1.Record{
+		Name:          fmt.Sprintf("shared-agent-%d", i),
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+}
+
+func TestSharedPushStream(t *testing.T) {
+	const (
+		pushes = 1000
+		bad    = 10
+	)
+
+	records := make([]*corev1.Record, pushes)
+	server := &sharedPushServer{bad: map[string]bool{}}
+
+	for i := range records {
+		records[i] = newSharedPushRecord(i)
+
+		if i%(pushes/bad) == 0 {
+			server.bad[records[i].GetCid()] = true
+		}
+	}
+
+	c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) }, WithSharedPushStream(time.Minute))
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures int
+	)
+
+	for i, record := range records {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			ref, err := c.Push(t.Context(), record)
+
+			if server.bad[record.GetCid()] {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("push %d: expected InvalidArgument, got %v", i, err)
+				}
+
+				mu.Lock()
+				failures++
+				mu.Unlock()
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("push %d: unexpected error: %v", i, err)
+
+				return
+			}
+
+			if ref.GetCid() != record.GetCid() {
+				t.Errorf("push %d: expected reference %s, got %s", i, record.GetCid(), ref.GetCid())
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if failures != bad {
+		t.Errorf("expected %d failed pushes, got %d", bad, failures)
+	}
+
+	// Every bad record ends a stream, all other pushes share them
+	if opened := server.opened.Load(); opened > bad+1 {
+		t.Errorf("expected at most %d push streams, got %d", bad+1, opened)
+	}
+}
+
+func TestSharedPushStreamIdle(t *testing.T) {
+	const maxIdle = 50 * time.Millisecond
+
+	server := &sharedPushServer{}
+	c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) }, WithSharedPushStream(maxIdle))
+
+	for i := range 3 {
+		if _, err := c.Push(t.Context(), newSharedPushRecord(i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if opened := server.opened.Load(); opened != 1 {
+		t.Fatalf("expected sequential pushes to share a stream, got %d streams", opened)
+	}
+
+	// The idle stream is closed
+	deadline := time.Now().Add(5 * time.Second)
+	for server.closed.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected idle stream to be closed")
+		}
+
+		time.Sleep(maxIdle)
+	}
+
+	// And reopened on demand
+	ref, err := c.Push(t.Context(), newSharedPushRecord(3))
+	if err != nil {
+		t.Fatalf("unexpected error after idle period: %v", err)
+	}
+
+	if ref.GetCid() != newSharedPushRecord(3).GetCid() {
+		t.Errorf("unexpected reference %s", ref.GetCid())
+	}
+
+	if opened := server.opened.Load(); opened != 2 {
+		t.Errorf("expected stream to be reopened once, got %d streams", opened)
+	}
+}
+
+func TestSharedPushStreamClosed(t *testing.T) {
+	c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, &sharedPushServer{}) }, WithSharedPushStream(time.Minute))
+
+	if _, err := c.Push(t.Context(), newSharedPushRecord(0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c.sharedPush.close()
+
+	if _, err := c.Push(t.Context(), newSharedPushRecord(1)); !errors.Is(err, errSharedPushStreamClosed) {
+		t.Errorf("expected push on closed stream to fail, got %v", err)
+	}
+}
+
+func TestWithSharedPushStreamValidation(t *testing.T) {
+	if err := WithSharedPushStream(0)(&options{}); err == nil {
+		t.Error("expected zero idle time to be rejected")
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// QuotaUsage returns the storage usage and quotas of a trust domain,
+// or of all trust domains owning records if the trust domain is empty.
+func (c *Client) QuotaUsage(ctx context.Context, trustDomain string) ([]*storev1.QuotaUsage, error) {
+	req := &storev1.GetUsageRequest{}
+	if trustDomain != "" {
+		req.TrustDomain = &trustDomain
+	}
+
+	resp, err := c.GetUsage(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quota usage: %w", err)
+	}
+
+	return resp.GetUsages(), nil
+}
+
+// QuotaUsageFromError returns the usage reported by a push rejected because a quota was exceeded.
+func QuotaUsageFromError(err error) (*storev1.QuotaUsage, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		return nil, false
+	}
+
+	for _, detail := range st.Details() {
+		if usage, ok := detail.(*storev1.QuotaUsage); ok {
+			return usage, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,74 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// quotaServer reports the usage of a single trust domain.
+type quotaServer struct {
+	storev1.UnimplementedQuotaServiceServer
+}
+
+func (quotaServer) GetUsage(_ context.Context, req *storev1.GetUsageRequest) (*storev1.GetUsageResponse, error) {
+	if req.TrustDomain != nil && req.GetTrustDomain() != "example.org" {
+		return &storev1.GetUsageResponse{Usages: []*storev1.QuotaUsage{{TrustDomain: req.GetTrustDomain()}}}, nil
+	}
+
+	return &storev1.GetUsageResponse{Usages: []*storev1.QuotaUsage{
+		{TrustDomain: "example.org", RecordCount: 2, MaxRecordCount: 2},
+	}}, nil
+}
+
+func TestQuotaUsage(t *testing.T) {
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterQuotaServiceServer(s, quotaServer{})
+	})
+
+	usages, err := c.QuotaUsage(t.Context(), "")
+	if err != nil {
+		t.Fatalf("QuotaUsage() unexpected error: %v", err)
+	}
+
+	if len(usages) != 1 || usages[0].GetRecordCount() != 2 {
+		t.Errorf("expected usage of example.org, got %v", usages)
+	}
+
+	usages, err = c.QuotaUsage(t.Context(), "other.org")
+	if err != nil {
+		t.Fatalf("QuotaUsage() unexpected error: %v", err)
+	}
+
+	if len(usages) != 1 || usages[0].GetTrustDomain() != "other.org" || usages[0].GetRecordCount() != 0 {
+		t.Errorf("expected empty usage of other.org, got %v", usages)
+	}
+}
+
+func TestQuotaUsageFromError(t *testing.T) {
+	st, err := status.New(codes.ResourceExhausted, "quota exceeded").
+		WithDetails(&storev1.QuotaUsage{TrustDomain: "example.org", RecordCount: 2})
+	if err != nil {
+		t.Fatalf("failed to add details: %v", err)
+	}
+
+	usage, ok := QuotaUsageFromError(st.Err())
+	if !ok || usage.GetRecordCount() != 2 {
+		t.Errorf("expected usage from error details, got %v", usage)
+	}
+
+	if _, ok := QuotaUsageFromError(status.Error(codes.ResourceExhausted, "rate limit exceeded")); ok {
+		t.Error("expected no usage for errors without details")
+	}
+
+	if _, ok := QuotaUsageFromError(status.Error(codes.Internal, "failed")); ok {
+		t.Error("expected no usage for other errors")
+	}
+}
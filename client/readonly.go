@@ -0,0 +1,136 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrReadOnlyClient is returned by clients created with NewReadOnly for API methods
+	// that are not available to callers outside of the trust domain of the server, e.g. Push or Delete.
+	// Such calls fail immediately without contacting the server.
+	ErrReadOnlyClient = errors.New("method not available on read-only client")
+
+	// ErrUnauthorized is reported for calls the server denied with PermissionDenied.
+	// The gRPC status is preserved, so status.Code can be used on the error as well.
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// readOnlyMethods are the API methods available to clients created with NewReadOnly.
+// They match the methods the server authorizes for callers outside of its trust domain.
+var readOnlyMethods = map[string]bool{
+	storev1.StoreService_Pull_FullMethodName:                      true,
+	storev1.StoreService_PullReferrer_FullMethodName:              true,
+	storev1.StoreService_Lookup_FullMethodName:                    true,
+	storev1.StoreService_Resolve_FullMethodName:                   true,
+	storev1.StoreService_PullBundle_FullMethodName:                true,
+	storev1.SyncService_RequestRegistryCredentials_FullMethodName: true,
+	healthpb.Health_Check_FullMethodName:                          true,
+	healthpb.Health_List_FullMethodName:                           true,
+}
+
+// NewReadOnly creates a client for anonymous, read-only access to the server at serverAddr,
+// e.g. for public consumers pulling records without credentials.
+// The client connects without authentication and only the read path is available:
+// Pull, Lookup, Resolve and PullBundle and their batch and stream variants, pulling referrers and health checks.
+// All other methods, e.g. Push, Delete or Publish, return ErrReadOnlyClient without contacting the server.
+// Configuration loaded with WithEnvConfig or WithConfig is ignored.
+func NewReadOnly(serverAddr string, opts ...Option) (*Client, error) {
+	if serverAddr == "" {
+		return nil, errors.New("server address is required")
+	}
+
+	opts = append(opts, WithConfig(&Config{ServerAddress: serverAddr}), withReadOnly())
+
+	return New(opts...)
+}
+
+func withReadOnly() Option {
+	return func(opts *options) error {
+		opts.readOnly = true
+
+		return nil
+	}
+}
+
+// readOnlyError returns ErrReadOnlyClient for methods that are not available on read-only clients.
+func readOnlyError(method string) error {
+	if readOnlyMethods[method] {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrReadOnlyClient, method)
+}
+
+func readOnlyUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := readOnlyError(method); err != nil {
+			return err
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func readOnlyStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if err := readOnlyError(method); err != nil {
+			return nil, err
+		}
+
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// unauthorizedError reports PermissionDenied errors of a call to the API method with ErrUnauthorized.
+func unauthorizedError(err error, method string) error {
+	if status.Code(err) != codes.PermissionDenied {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s: %w", ErrUnauthorized, method, err)
+}
+
+func unauthorizedUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return unauthorizedError(invoker(ctx, method, req, reply, cc, opts...), method)
+	}
+}
+
+// unauthorizedStreamInterceptor reports denied streams with ErrUnauthorized.
+// The server denies streams when they are established, which clients only observe on the first receive.
+func unauthorizedStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		clientStream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, unauthorizedError(err, method)
+		}
+
+		return &unauthorizedClientStream{ClientStream: clientStream, method: method}, nil
+	}
+}
+
+// unauthorizedClientStream reports PermissionDenied errors of a client stream with ErrUnauthorized.
+type unauthorizedClientStream struct {
+	grpc.ClientStream
+
+	method string
+}
+
+func (s *unauthorizedClientStream) SendMsg(msg any) error {
+	return unauthorizedError(s.ClientStream.SendMsg(msg), s.method)
+}
+
+func (s *unauthorizedClientStream) RecvMsg(msg any) error {
+	return unauthorizedError(s.ClientStream.RecvMsg(msg), s.method)
+}
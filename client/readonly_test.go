@@ -0,0 +1,174 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// externalServer serves stored records like a server authorizing the client as a caller
+// outside of its trust domain, denying everything but reads.
+type externalServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	record *corev1.Record
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func (s *externalServer) called(method string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, method)
+}
+
+func (s *externalServer) Pull(stream storev1.StoreService_PullServer) error {
+	s.called("Pull")
+
+	refs, err := recvAll(stream.Recv)
+	if err != nil {
+		return err
+	}
+
+	for range refs {
+		if err := stream.Send(s.record); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+func (s *externalServer) Lookup(stream storev1.StoreService_LookupServer) error {
+	s.called("Lookup")
+
+	refs, err := recvAll(stream.Recv)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		if err := stream.Send(&corev1.RecordMeta{Cid: ref.GetCid()}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+func (s *externalServer) Push(storev1.StoreService_PushServer) error {
+	s.called("Push")
+
+	return status.Error(codes.PermissionDenied, "access denied") //nolint:wrapcheck
+}
+
+func (s *externalServer) DeleteWithAck(storev1.StoreService_DeleteWithAckServer) error {
+	s.called("Delete")
+
+	return status.Error(codes.PermissionDenied, "access denied") //nolint:wrapcheck
+}
+
+func newExternalServer(t *testing.T) (*externalServer, func(*grpc.Server)) {
+	t.Helper()
+
+	server := &externalServer{
+		record: corev1.New(&typesv1alpha1.Record{
+			Name:          "public-agent",
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+		}),
+	}
+
+	return server, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) }
+}
+
+func TestNewReadOnly(t *testing.T) {
+	server, register := newExternalServer(t)
+
+	listener := bufconn.Listen(1024 * 1024) //nolint:mnd
+
+	grpcServer := grpc.NewServer()
+	register(grpcServer)
+
+	go func() { _ = grpcServer.Serve(listener) }()
+
+	t.Cleanup(grpcServer.Stop)
+
+	c, err := NewReadOnly("passthrough:///bufnet", WithDialOptions(
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.Close() })
+
+	ref := &corev1.RecordRef{Cid: server.record.GetCid()}
+
+	if _, err := c.Pull(t.Context(), ref); err != nil {
+		t.Errorf("expected pull to succeed, got %v", err)
+	}
+
+	if _, err := c.Lookup(t.Context(), ref); err != nil {
+		t.Errorf("expected lookup to succeed, got %v", err)
+	}
+
+	if _, err := c.Push(t.Context(), server.record); !errors.Is(err, ErrReadOnlyClient) {
+		t.Errorf("expected push to fail with ErrReadOnlyClient, got %v", err)
+	}
+
+	if err := c.Delete(t.Context(), ref); !errors.Is(err, ErrReadOnlyClient) {
+		t.Errorf("expected delete to fail with ErrReadOnlyClient, got %v", err)
+	}
+
+	if err := c.Publish(t.Context(), &routingv1.PublishRequest{}); !errors.Is(err, ErrReadOnlyClient) {
+		t.Errorf("expected publish to fail with ErrReadOnlyClient, got %v", err)
+	}
+
+	// Write methods must not reach the server
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if strings.Join(server.calls, ",") != "Pull,Lookup" {
+		t.Errorf("expected only reads to reach the server, got %v", server.calls)
+	}
+}
+
+func TestUnauthorizedError(t *testing.T) {
+	server, register := newExternalServer(t)
+	c := newBufconnClient(t, register)
+
+	_, err := c.Push(t.Context(), server.record)
+	if !errors.Is(err, ErrUnauthorized) || status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected push to fail with ErrUnauthorized, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), storev1.StoreService_Push_FullMethodName) {
+		t.Errorf("expected error to name the API method, got %v", err)
+	}
+
+	if err := c.Delete(t.Context(), &corev1.RecordRef{Cid: server.record.GetCid()}); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected delete to fail with ErrUnauthorized, got %v", err)
+	}
+}
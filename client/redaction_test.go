@@ -0,0 +1,107 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// redactedCopy returns a copy of the record with its name redacted, as the server returns it to external callers.
+func redactedCopy(record *corev1.Record, originalCID string) *corev1.Record {
+	redacted, _ := proto.Clone(record).(*corev1.Record)
+	redacted.GetData().GetFields()["name"] = structpb.NewStringValue("[REDACTED]")
+	redacted.Redacted = true
+	redacted.OriginalCid = originalCID
+
+	return redacted
+}
+
+func TestPullRedacted(t *testing.T) {
+	plain := newCacheTestRecord("plain-agent")
+	internal := newCacheTestRecord("internal-agent")
+	tampered := newCacheTestRecord("tampered-agent")
+	mislabeled := newCacheTestRecord("mislabeled-agent")
+
+	server := newCountingStoreServer(plain)
+	server.records[internal.GetCid()] = redactedCopy(internal, internal.GetCid())
+	server.records[tampered.GetCid()] = newCacheTestRecord("other-agent")
+	server.records[mislabeled.GetCid()] = redactedCopy(mislabeled, plain.GetCid())
+
+	register := func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) }
+
+	t.Run("redacted records are matched by their original CID", func(t *testing.T) {
+		c := newBufconnClient(t, register)
+
+		refs := []*corev1.RecordRef{{Cid: internal.GetCid()}, {Cid: plain.GetCid()}}
+
+		records, err := c.PullBatch(t.Context(), refs)
+		if err != nil {
+			t.Fatalf("PullBatch() unexpected error: %v", err)
+		}
+
+		if !records[0].GetRedacted() || records[0].GetOriginalCid() != internal.GetCid() {
+			t.Errorf("expected redacted record pulled by %s, got %v", internal.GetCid(), records[0])
+		}
+
+		if records[1].GetRedacted() || records[1].GetCid() != plain.GetCid() {
+			t.Errorf("expected unredacted record %s, got %v", plain.GetCid(), records[1])
+		}
+	})
+
+	t.Run("content verification skips redacted records", func(t *testing.T) {
+		c := newBufconnClient(t, register, WithContentVerification())
+
+		for _, record := range []*corev1.Record{plain, internal} {
+			pulled, err := c.Pull(t.Context(), &corev1.RecordRef{Cid: record.GetCid()})
+			if err != nil {
+				t.Errorf("Pull(%s) unexpected error: %v", record.GetCid(), err)
+
+				continue
+			}
+
+			if pulled.GetRedacted() != (record == internal) {
+				t.Errorf("Pull(%s) unexpected redaction flag %v", record.GetCid(), pulled.GetRedacted())
+			}
+		}
+	})
+
+	t.Run("content verification fails mismatching records", func(t *testing.T) {
+		c := newBufconnClient(t, register, WithContentVerification())
+
+		for _, record := range []*corev1.Record{tampered, mislabeled} {
+			_, err := c.Pull(t.Context(), &corev1.RecordRef{Cid: record.GetCid()})
+			if status.Code(err) != codes.DataLoss {
+				t.Errorf("Pull(%s) expected DataLoss, got %v", record.GetCid(), err)
+			}
+		}
+
+		// Without verification, the content is returned as is
+		unverified := newBufconnClient(t, register)
+		if _, err := unverified.Pull(t.Context(), &corev1.RecordRef{Cid: tampered.GetCid()}); err != nil {
+			t.Errorf("Pull() unexpected error without verification: %v", err)
+		}
+	})
+
+	t.Run("redacted records are not cached", func(t *testing.T) {
+		c := newCachingClient(t, server)
+
+		for range 2 {
+			if _, err := c.Pull(t.Context(), &corev1.RecordRef{Cid: internal.GetCid()}); err != nil {
+				t.Fatalf("Pull() unexpected error: %v", err)
+			}
+		}
+
+		if pulls := server.pullCount(internal.GetCid()); pulls < 2 {
+			t.Errorf("expected redacted record to be pulled from the server every time, got %d pulls", pulls)
+		}
+	})
+}
@@ -0,0 +1,500 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/api/lint"
+	sigs "github.com/sigstore/cosign/v2/pkg/signature"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CheckStatus is the outcome of an integrity check.
+type CheckStatus string
+
+const (
+	CheckPass CheckStatus = "pass"
+	CheckFail CheckStatus = "fail"
+	// CheckSkip is reported for checks that could not be run, e.g. without a chain client.
+	CheckSkip CheckStatus = "skip"
+)
+
+// Names of the integrity checks. Attestation checks are named by AttestationCheckName.
+const (
+	CheckCID          = "cid"
+	CheckSignature    = "signature"
+	CheckRegistration = "registration"
+	CheckLifecycle    = "lifecycle"
+	CheckScan         = "scan"
+	CheckLint         = "lint"
+)
+
+// AttestationCheckName returns the name of the check of the attestations with the predicate type,
+// e.g. "attestation:https://slsa.dev/provenance/v1".
+func AttestationCheckName(predicateType string) string {
+	return "attestation:" + predicateType
+}
+
+// Check is the result of an integrity check of a record.
+type Check struct {
+	// Name identifies the check, e.g. "signature".
+	Name string `json:"name"`
+	// Status is whether the check passed, failed or was skipped.
+	Status CheckStatus `json:"status"`
+	// Message explains why the check failed or was skipped.
+	Message string `json:"message,omitempty"`
+	// Evidence holds what the check found, e.g. the ID of the key that made the signature.
+	Evidence map[string]string `json:"evidence,omitempty"`
+}
+
+// TrustBundle holds the keys and identities trusted to sign records and their attestations.
+type TrustBundle struct {
+	// Keys are PEM-encoded public keys trusted to sign records and attestations.
+	Keys []string
+	// Identities are identities trusted to sign records with keyless signing.
+	// Signing certificates must chain up to FulcioRoots.
+	Identities []KeylessIdentity
+	// FulcioRoots are the root certificates of the Fulcio instances issuing signing certificates.
+	FulcioRoots *x509.CertPool
+	// FulcioIntermediates are the intermediate certificates of the Fulcio instances.
+	FulcioIntermediates *x509.CertPool
+}
+
+// ChainRegistration is the on-chain registration of a record.
+type ChainRegistration struct {
+	// Owner is the account that registered the record.
+	Owner string
+	// RegisteredAt is the time the record was registered.
+	RegisteredAt time.Time
+	// Transaction optionally identifies the registering transaction.
+	Transaction string
+}
+
+// ChainClient looks up the on-chain registration of records.
+type ChainClient interface {
+	// GetRegistration returns the registration of the record with the given CID, or nil if it is not registered.
+	GetRegistration(ctx context.Context, cid string) (*ChainRegistration, error)
+}
+
+// ReportOptions configures the checks of IntegrityReport.
+type ReportOptions struct {
+	// TrustBundle holds the keys and identities trusted to sign the record and its attestations.
+	// If empty, the signature and attestation checks are skipped, which fails them if mandatory,
+	// unless AllowAttachedKeys is set.
+	TrustBundle TrustBundle
+
+	// AllowAttachedKeys verifies signatures and attestations with the public keys attached to the record
+	// if the trust bundle has no keys. This proves that they are intact but not who made them,
+	// as anyone can attach their own key to a record.
+	AllowAttachedKeys bool
+
+	// ChainClient looks up the on-chain registration of the record. The check is skipped if not set.
+	ChainClient ChainClient
+
+	// RequiredPredicates are the predicate types of the attestations the record must carry,
+	// e.g. PredicateTypeSLSAProvenance and PredicateTypeSPDX. Each is checked separately.
+	RequiredPredicates []string
+
+	// Mandatory are the names of the checks that must pass for the report to pass.
+	// Defaults to the CID, signature and required attestation checks.
+	Mandatory []string
+}
+
+// IntegrityReport aggregates the integrity checks of a record.
+type IntegrityReport struct {
+	// CID is the CID of the record.
+	CID string `json:"cid"`
+	// Checks are the results of all checks, in the order they were run.
+	Checks []Check `json:"checks"`
+	// Mandatory are the names of the checks that must pass.
+	Mandatory []string `json:"mandatory"`
+	// Verdict passes if all mandatory checks passed, and fails otherwise.
+	Verdict CheckStatus `json:"verdict"`
+	// Failures are the names of the mandatory checks that failed or were skipped.
+	Failures []string `json:"failures,omitempty"`
+}
+
+// Check returns the check with the given name.
+func (r *IntegrityReport) Check(name string) (Check, bool) {
+	for _, check := range r.Checks {
+		if check.Name == name {
+			return check, true
+		}
+	}
+
+	return Check{}, false
+}
+
+// IntegrityReport runs all integrity checks of a record and computes a verdict from the mandatory checks:
+//   - cid: the record content matches its CID
+//   - signature: the record is signed by a trusted key or identity
+//   - attestation:<predicate type>: the record carries a verified attestation for each required predicate
+//   - registration: the record is registered on-chain, with its owner and registration time
+//   - lifecycle: the record is neither deprecated nor withdrawn
+//   - scan: the record was stored without scan warnings
+//   - lint: the record has no lint findings of warning or error severity
+//
+// Failing checks do not fail the report itself, an error is only returned if the record
+// cannot be pulled or looked up, or if the options are invalid.
+// The pull policy configured with WithPullPolicy is not evaluated.
+func (c *Client) IntegrityReport(ctx context.Context, ref *corev1.RecordRef, opts ReportOptions) (*IntegrityReport, error) {
+	trust, err := newPullPolicy(PullPolicy{
+		TrustedKeys:         opts.TrustBundle.Keys,
+		TrustedIdentities:   opts.TrustBundle.Identities,
+		FulcioRoots:         opts.TrustBundle.FulcioRoots,
+		FulcioIntermediates: opts.TrustBundle.FulcioIntermediates,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust bundle: %w", err)
+	}
+
+	ref, err = c.resolveRef(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	cid := ref.GetCid()
+
+	meta, err := c.Lookup(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up record %s: %w", cid, err)
+	}
+
+	// The report evaluates the pull policy checks itself
+	record, err := c.Pull(context.WithValue(ctx, unsafePullKey{}, true), ref)
+	if err != nil && status.Code(err) != codes.DataLoss {
+		return nil, fmt.Errorf("failed to pull record %s: %w", cid, err)
+	}
+
+	report := &IntegrityReport{CID: cid}
+
+	report.Checks = append(report.Checks, checkCID(cid, record), c.checkSignature(ctx, trust, cid, opts.AllowAttachedKeys))
+
+	for _, predicateType := range opts.RequiredPredicates {
+		report.Checks = append(report.Checks, c.checkAttestation(ctx, trust, ref, predicateType, opts.AllowAttachedKeys))
+	}
+
+	report.Checks = append(report.Checks,
+		checkRegistration(ctx, opts.ChainClient, cid),
+		checkLifecycle(meta.GetLifecycle()),
+		checkScan(meta.GetScanWarnings()),
+		checkLint(record),
+	)
+
+	report.Mandatory = opts.Mandatory
+	if report.Mandatory == nil {
+		report.Mandatory = []string{CheckCID, CheckSignature}
+		for _, predicateType := range opts.RequiredPredicates {
+			report.Mandatory = append(report.Mandatory, AttestationCheckName(predicateType))
+		}
+	}
+
+	report.Verdict = CheckPass
+
+	for _, name := range report.Mandatory {
+		check, ok := report.Check(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown mandatory check %q", name)
+		}
+
+		if check.Status != CheckPass {
+			report.Verdict = CheckFail
+			report.Failures = append(report.Failures, name)
+		}
+	}
+
+	return report, nil
+}
+
+// checkCID checks that the pulled record matches the CID, the record is nil if the pull reported a mismatch.
+func checkCID(cid string, record *corev1.Record) Check {
+	check := Check{Name: CheckCID, Evidence: map[string]string{"expected": cid}}
+
+	switch {
+	case record == nil:
+		check.Status = CheckFail
+		check.Message = "record content does not match its CID"
+	case record.GetRedacted():
+		check.Status = CheckSkip
+		check.Message = "record is redacted"
+	case !record.MatchesCid(cid):
+		check.Status = CheckFail
+		check.Message = "record content does not match its CID"
+		check.Evidence["computed"] = record.GetCid()
+	default:
+		check.Status = CheckPass
+		check.Evidence["computed"] = record.GetCid()
+	}
+
+	return check
+}
+
+// checkSignature checks that the record is signed by a trusted key or identity, or with
+// one of the attached public keys if the trust bundle is empty and allowAttached is set.
+func (c *Client) checkSignature(ctx context.Context, trust *pullPolicy, cid string, allowAttached bool) Check {
+	check := Check{Name: CheckSignature}
+
+	payload, err := expectedSignaturePayload(cid)
+	if err != nil {
+		return check.failed(err)
+	}
+
+	signatures, err := c.pullSignatureReferrer(ctx, cid)
+	if err != nil {
+		return check.failed(err)
+	}
+
+	if len(signatures) == 0 {
+		return check.failed(errors.New("record is not signed"))
+	}
+
+	if !trust.requiresSignature() {
+		if !allowAttached {
+			check.Status = CheckSkip
+			check.Message = "no trusted keys or identities to verify the signature with"
+
+			return check
+		}
+
+		keys, err := c.pullPublicKeyReferrer(ctx, cid)
+		if err != nil {
+			return check.failed(err)
+		}
+
+		trust = &pullPolicy{PullPolicy: PullPolicy{TrustedKeys: keys}}
+		check.Evidence = map[string]string{"trust": "attached"}
+	}
+
+	for _, signature := range signatures {
+		if signer, ok := trust.trustedSigner(signature, payload); ok {
+			check.Status = CheckPass
+			check.Evidence = mergeEvidence(check.Evidence, signer)
+
+			return check
+		}
+	}
+
+	check.Evidence = mergeEvidence(check.Evidence, map[string]string{"signatures": strconv.Itoa(len(signatures))})
+
+	return check.failed(errors.New("no signature was made by a trusted key or identity"))
+}
+
+// checkAttestation checks that the record carries a verified attestation with the predicate type,
+// made by a trusted key or by one of the attached public keys if there are none and allowAttached is set.
+func (c *Client) checkAttestation(ctx context.Context, trust *pullPolicy, ref *corev1.RecordRef, predicateType string, allowAttached bool) Check {
+	check := Check{Name: AttestationCheckName(predicateType)}
+
+	pemKeys := trust.TrustedKeys
+	if len(pemKeys) == 0 && allowAttached {
+		keys, err := c.pullPublicKeyReferrer(ctx, ref.GetCid())
+		if err != nil {
+			return check.failed(err)
+		}
+
+		pemKeys = keys
+		check.Evidence = map[string]string{"trust": "attached"}
+	}
+
+	if len(pemKeys) == 0 {
+		check.Status = CheckSkip
+		check.Message = "no public keys to verify attestations with"
+
+		return check
+	}
+
+	keys := make([]crypto.PublicKey, 0, len(pemKeys))
+
+	for _, pemKey := range pemKeys {
+		key, err := loadPublicKey(pemKey)
+		if err != nil {
+			return check.failed(err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	attestations, err := c.GetAttestations(ctx, ref, predicateType, keys...)
+	if len(attestations) == 0 {
+		if err == nil {
+			err = errors.New("no attestation found")
+		}
+
+		return check.failed(err)
+	}
+
+	check.Status = CheckPass
+	check.Evidence = mergeEvidence(check.Evidence, map[string]string{
+		"key_id":     attestations[0].KeyID,
+		"created_at": attestations[0].CreatedAt,
+		"count":      strconv.Itoa(len(attestations)),
+	})
+
+	// Attestations failing verification are reported next to the verified ones
+	if err != nil {
+		check.Message = err.Error()
+	}
+
+	return check
+}
+
+// checkRegistration checks that the record is registered on-chain.
+func checkRegistration(ctx context.Context, chain ChainClient, cid string) Check {
+	check := Check{Name: CheckRegistration}
+
+	if chain == nil {
+		check.Status = CheckSkip
+		check.Message = "no chain client configured"
+
+		return check
+	}
+
+	registration, err := chain.GetRegistration(ctx, cid)
+	if err != nil {
+		return check.failed(fmt.Errorf("failed to look up registration: %w", err))
+	}
+
+	if registration == nil {
+		return check.failed(errors.New("record is not registered"))
+	}
+
+	check.Status = CheckPass
+	check.Evidence = map[string]string{
+		"owner":         registration.Owner,
+		"registered_at": registration.RegisteredAt.UTC().Format(time.RFC3339),
+	}
+
+	if registration.Transaction != "" {
+		check.Evidence["transaction"] = registration.Transaction
+	}
+
+	return check
+}
+
+// checkLifecycle checks that the record is active.
+func checkLifecycle(lifecycle *corev1.Lifecycle) Check {
+	check := Check{Name: CheckLifecycle, Status: CheckPass, Evidence: map[string]string{"status": lifecycle.StatusName()}}
+
+	if lifecycle.IsActive() {
+		return check
+	}
+
+	for key, value := range map[string]string{
+		"successor":  lifecycle.GetSuccessorCid(),
+		"reason":     lifecycle.GetReason(),
+		"updated_at": lifecycle.GetUpdatedAt(),
+	} {
+		if value != "" {
+			check.Evidence[key] = value
+		}
+	}
+
+	return check.failed(fmt.Errorf("record is %s", lifecycle.StatusName()))
+}
+
+// checkScan checks that the record was stored without scan warnings.
+func checkScan(warnings []*corev1.ScanFinding) Check {
+	check := Check{Name: CheckScan, Status: CheckPass}
+
+	if len(warnings) == 0 {
+		return check
+	}
+
+	check.Evidence = make(map[string]string, len(warnings))
+	for i, warning := range warnings {
+		check.Evidence[fmt.Sprintf("warning[%d]", i)] = fmt.Sprintf("%s %s: %s (%s)",
+			warning.GetScanner(), warning.GetLocation(), warning.GetMessage(), warning.GetRule())
+	}
+
+	return check.failed(fmt.Errorf("record has %d scan warnings", len(warnings)))
+}
+
+// checkLint checks that the record has no lint findings of warning or error severity.
+// Findings are not stored, so they are computed from the pulled record.
+func checkLint(record *corev1.Record) Check {
+	check := Check{Name: CheckLint}
+
+	if record == nil || record.IsEncrypted() || record.GetRedacted() {
+		check.Status = CheckSkip
+		check.Message = "record content is not available"
+
+		return check
+	}
+
+	findings, err := lint.Run(record, lint.Config{})
+	if err != nil {
+		return check.failed(err)
+	}
+
+	check.Status = CheckPass
+
+	if len(findings) > 0 {
+		check.Evidence = make(map[string]string, len(findings))
+		for i, finding := range findings {
+			check.Evidence[fmt.Sprintf("finding[%d]", i)] = finding.String()
+		}
+	}
+
+	if severity := lint.MaxSeverity(findings); severity.AtLeast(lint.SeverityWarning) {
+		return check.failed(fmt.Errorf("record has lint findings of %s severity", severity))
+	}
+
+	return check
+}
+
+// failed returns the check failed with the error.
+func (c Check) failed(err error) Check {
+	c.Status = CheckFail
+	c.Message = err.Error()
+
+	return c
+}
+
+// mergeEvidence returns the evidence with the other evidence added.
+func mergeEvidence(evidence, other map[string]string) map[string]string {
+	if evidence == nil {
+		evidence = make(map[string]string, len(other))
+	}
+
+	for key, value := range other {
+		evidence[key] = value
+	}
+
+	return evidence
+}
+
+// loadPublicKey parses a PEM-encoded public key.
+func loadPublicKey(pemKey string) (crypto.PublicKey, error) {
+	verifier, err := sigs.LoadPublicKeyRaw([]byte(pemKey), crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load public key: %w", err)
+	}
+
+	key, err := verifier.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load public key: %w", err)
+	}
+
+	return key, nil
+}
+
+// pemKeyID returns the ID of a PEM-encoded public key, see KeyID, or an empty ID if it is invalid.
+func pemKeyID(pemKey string) string {
+	key, err := loadPublicKey(pemKey)
+	if err != nil {
+		return ""
+	}
+
+	id, _ := KeyID(key)
+
+	return id
+}
@@ -0,0 +1,314 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	signv1 "github.com/agntcy/dir/api/sign/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+)
+
+// fixedChain serves on-chain registrations from a fixed set of records.
+type fixedChain map[string]*ChainRegistration
+
+func (c fixedChain) GetRegistration(_ context.Context, cid string) (*ChainRegistration, error) {
+	return c[cid], nil
+}
+
+// failingChain fails all registration lookups.
+type failingChain struct{}
+
+func (failingChain) GetRegistration(context.Context, string) (*ChainRegistration, error) {
+	return nil, errors.New("chain unavailable")
+}
+
+// newReportClient creates a client for a server storing the records with their metadata.
+func newReportClient(t *testing.T, records ...*corev1.Record) (*Client, attestationServer) {
+	t.Helper()
+
+	server := attestationServer{referrerServer{
+		pullServer: pullServer{
+			lookupServer: lookupServer{metas: map[string]*corev1.RecordMeta{}},
+			records:      map[string]*corev1.Record{},
+		},
+		referrers: map[string][]*corev1.RecordReferrer{},
+	}}
+
+	for _, record := range records {
+		server.records[record.GetCid()] = record
+		server.metas[record.GetCid()] = &corev1.RecordMeta{Cid: record.GetCid()}
+	}
+
+	return newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) }), server
+}
+
+// checkStatuses returns the status of every check of the report by name.
+func checkStatuses(report *IntegrityReport) map[string]CheckStatus {
+	statuses := make(map[string]CheckStatus, len(report.Checks))
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status
+	}
+
+	return statuses
+}
+
+func TestIntegrityReport(t *testing.T) {
+	trusted, trustedPEM := newSigningKey(t)
+	untrusted, untrustedPEM := newSigningKey(t)
+
+	provenanceCheck := AttestationCheckName(PredicateTypeSLSAProvenance)
+	sbomCheck := AttestationCheckName(PredicateTypeSPDX)
+
+	good := newPolicyRecord("report-agent-good")
+	bad := newPolicyRecord("report-agent-bad")
+	c, server := newReportClient(t, good, bad)
+
+	registeredAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	chain := fixedChain{good.GetCid(): {Owner: "0xowner", RegisteredAt: registeredAt}}
+
+	// The good record is signed by the trusted key and carries its provenance and SBOM
+	server.attach(t, good.GetCid(), signRecord(t, trusted, good.GetCid()))
+
+	for _, predicateType := range []string{PredicateTypeSLSAProvenance, PredicateTypeSPDX} {
+		if err := c.AttachAttestation(t.Context(), &corev1.RecordRef{Cid: good.GetCid()}, predicateType, []byte(`{}`), trusted); err != nil {
+			t.Fatalf("AttachAttestation() unexpected error: %v", err)
+		}
+	}
+
+	// The bad record is signed by an untrusted key, has provenance but no SBOM,
+	// is deprecated and was stored with scan warnings
+	server.attach(t, bad.GetCid(), signRecord(t, untrusted, bad.GetCid()))
+	server.attach(t, bad.GetCid(), &signv1.PublicKey{Key: untrustedPEM})
+
+	if err := c.AttachAttestation(t.Context(), &corev1.RecordRef{Cid: bad.GetCid()}, PredicateTypeSLSAProvenance, []byte(`{}`), trusted); err != nil {
+		t.Fatalf("AttachAttestation() unexpected error: %v", err)
+	}
+
+	server.metas[bad.GetCid()].Lifecycle = &corev1.Lifecycle{
+		Status:       corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED,
+		SuccessorCid: good.GetCid(),
+	}
+	server.metas[bad.GetCid()].ScanWarnings = []*corev1.ScanFinding{
+		{Scanner: "secrets", Rule: "aws-key", Message: "possible AWS key", Location: "annotations.key"},
+	}
+
+	opts := ReportOptions{
+		TrustBundle:        TrustBundle{Keys: []string{trustedPEM}},
+		ChainClient:        chain,
+		RequiredPredicates: []string{PredicateTypeSLSAProvenance, PredicateTypeSPDX},
+	}
+
+	t.Run("passing record", func(t *testing.T) {
+		report, err := c.IntegrityReport(t.Context(), &corev1.RecordRef{Cid: good.GetCid()}, opts)
+		if err != nil {
+			t.Fatalf("IntegrityReport() unexpected error: %v", err)
+		}
+
+		want := map[string]CheckStatus{
+			CheckCID:          CheckPass,
+			CheckSignature:    CheckPass,
+			provenanceCheck:   CheckPass,
+			sbomCheck:         CheckPass,
+			CheckRegistration: CheckPass,
+			CheckLifecycle:    CheckPass,
+			CheckScan:         CheckPass,
+			// The minimal record has no description, locators or skills
+			CheckLint: CheckFail,
+		}
+		if got := checkStatuses(report); !maps.Equal(got, want) {
+			t.Errorf("check statuses = %v, want %v", got, want)
+		}
+
+		// Optional checks do not fail the report
+		if report.Verdict != CheckPass || len(report.Failures) != 0 {
+			t.Errorf("verdict = %s with failures %v, want pass", report.Verdict, report.Failures)
+		}
+
+		keyID := pemKeyID(trustedPEM)
+
+		signature, _ := report.Check(CheckSignature)
+		if signature.Evidence["signer"] != "key" || signature.Evidence["key_id"] != keyID {
+			t.Errorf("signature evidence = %v, want key %s", signature.Evidence, keyID)
+		}
+
+		provenance, _ := report.Check(provenanceCheck)
+		if provenance.Evidence["key_id"] != keyID {
+			t.Errorf("attestation evidence = %v, want key %s", provenance.Evidence, keyID)
+		}
+
+		registration, _ := report.Check(CheckRegistration)
+		if registration.Evidence["owner"] != "0xowner" || registration.Evidence["registered_at"] != "2025-03-01T12:00:00Z" {
+			t.Errorf("registration evidence = %v", registration.Evidence)
+		}
+	})
+
+	t.Run("mixed record", func(t *testing.T) {
+		report, err := c.IntegrityReport(t.Context(), &corev1.RecordRef{Cid: bad.GetCid()}, opts)
+		if err != nil {
+			t.Fatalf("IntegrityReport() unexpected error: %v", err)
+		}
+
+		want := map[string]CheckStatus{
+			CheckCID:          CheckPass,
+			CheckSignature:    CheckFail,
+			provenanceCheck:   CheckPass,
+			sbomCheck:         CheckFail,
+			CheckRegistration: CheckFail,
+			CheckLifecycle:    CheckFail,
+			CheckScan:         CheckFail,
+			CheckLint:         CheckFail,
+		}
+		if got := checkStatuses(report); !maps.Equal(got, want) {
+			t.Errorf("check statuses = %v, want %v", got, want)
+		}
+
+		wantFailures := []string{CheckSignature, sbomCheck}
+		if report.Verdict != CheckFail || !slices.Equal(report.Failures, wantFailures) {
+			t.Errorf("verdict = %s with failures %v, want fail with %v", report.Verdict, report.Failures, wantFailures)
+		}
+
+		lifecycle, _ := report.Check(CheckLifecycle)
+		if lifecycle.Evidence["status"] != "deprecated" || lifecycle.Evidence["successor"] != good.GetCid() {
+			t.Errorf("lifecycle evidence = %v", lifecycle.Evidence)
+		}
+
+		scan, _ := report.Check(CheckScan)
+		if scan.Evidence["warning[0]"] != "secrets annotations.key: possible AWS key (aws-key)" {
+			t.Errorf("scan evidence = %v", scan.Evidence)
+		}
+	})
+
+	t.Run("self-signed record without trust bundle", func(t *testing.T) {
+		report, err := c.IntegrityReport(t.Context(), &corev1.RecordRef{Cid: bad.GetCid()}, ReportOptions{
+			RequiredPredicates: []string{PredicateTypeSLSAProvenance},
+		})
+		if err != nil {
+			t.Fatalf("IntegrityReport() unexpected error: %v", err)
+		}
+
+		if signature, _ := report.Check(CheckSignature); signature.Status != CheckSkip || signature.Evidence["trust"] != "" {
+			t.Errorf("signature check = %+v, want skip without trusted keys", signature)
+		}
+
+		if provenance, _ := report.Check(provenanceCheck); provenance.Status != CheckSkip {
+			t.Errorf("provenance check = %+v, want skip without trusted keys", provenance)
+		}
+
+		wantFailures := []string{CheckSignature, provenanceCheck}
+		if report.Verdict != CheckFail || !slices.Equal(report.Failures, wantFailures) {
+			t.Errorf("verdict = %s with failures %v, want fail with %v", report.Verdict, report.Failures, wantFailures)
+		}
+	})
+
+	t.Run("attached keys", func(t *testing.T) {
+		report, err := c.IntegrityReport(t.Context(), &corev1.RecordRef{Cid: bad.GetCid()}, ReportOptions{AllowAttachedKeys: true})
+		if err != nil {
+			t.Fatalf("IntegrityReport() unexpected error: %v", err)
+		}
+
+		signature, _ := report.Check(CheckSignature)
+		if signature.Status != CheckPass || signature.Evidence["trust"] != "attached" || signature.Evidence["key_id"] != pemKeyID(untrustedPEM) {
+			t.Errorf("signature check = %+v, want pass with the attached key", signature)
+		}
+
+		if registration, _ := report.Check(CheckRegistration); registration.Status != CheckSkip {
+			t.Errorf("registration status = %s without chain client, want skip", registration.Status)
+		}
+
+		if report.Verdict != CheckPass {
+			t.Errorf("verdict = %s with failures %v, want pass", report.Verdict, report.Failures)
+		}
+	})
+
+	t.Run("mandatory checks", func(t *testing.T) {
+		report, err := c.IntegrityReport(t.Context(), &corev1.RecordRef{Cid: good.GetCid()}, ReportOptions{
+			TrustBundle: TrustBundle{Keys: []string{trustedPEM}},
+			ChainClient: failingChain{},
+			Mandatory:   []string{CheckCID, CheckRegistration, CheckLifecycle},
+		})
+		if err != nil {
+			t.Fatalf("IntegrityReport() unexpected error: %v", err)
+		}
+
+		if report.Verdict != CheckFail || !slices.Equal(report.Failures, []string{CheckRegistration}) {
+			t.Errorf("verdict = %s with failures %v, want fail with registration", report.Verdict, report.Failures)
+		}
+
+		// Skipped mandatory checks fail the report
+		report, err = c.IntegrityReport(t.Context(), &corev1.RecordRef{Cid: good.GetCid()}, ReportOptions{
+			Mandatory: []string{CheckRegistration},
+		})
+		if err != nil {
+			t.Fatalf("IntegrityReport() unexpected error: %v", err)
+		}
+
+		if report.Verdict != CheckFail {
+			t.Errorf("verdict = %s with skipped mandatory check, want fail", report.Verdict)
+		}
+
+		if _, err := c.IntegrityReport(t.Context(), &corev1.RecordRef{Cid: good.GetCid()}, ReportOptions{
+			Mandatory: []string{"unknown"},
+		}); err == nil {
+			t.Error("expected unknown mandatory check to be rejected")
+		}
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		if _, err := c.IntegrityReport(t.Context(), &corev1.RecordRef{Cid: good.GetCid()}, ReportOptions{
+			TrustBundle: TrustBundle{Keys: []string{"not a key"}},
+		}); err == nil {
+			t.Error("expected invalid trusted key to be rejected")
+		}
+
+		if _, err := c.IntegrityReport(t.Context(), &corev1.RecordRef{Cid: newPolicyRecord("missing").GetCid()}, ReportOptions{}); err == nil {
+			t.Error("expected missing record to fail the report")
+		}
+	})
+}
+
+func TestIntegrityReportCIDMismatch(t *testing.T) {
+	record := newPolicyRecord("report-agent")
+	tampered := newPolicyRecord("report-agent-tampered")
+
+	for _, tt := range []struct {
+		name string
+		opts []Option
+	}{
+		{name: "without content verification"},
+		{name: "with content verification", opts: []Option{WithContentVerification()}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server := referrerServer{
+				pullServer: pullServer{
+					lookupServer: lookupServer{metas: map[string]*corev1.RecordMeta{record.GetCid(): {Cid: record.GetCid()}}},
+					records:      map[string]*corev1.Record{record.GetCid(): tampered},
+				},
+				referrers: map[string][]*corev1.RecordReferrer{},
+			}
+			c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) }, tt.opts...)
+
+			report, err := c.IntegrityReport(t.Context(), &corev1.RecordRef{Cid: record.GetCid()}, ReportOptions{})
+			if err != nil {
+				t.Fatalf("IntegrityReport() unexpected error: %v", err)
+			}
+
+			check, _ := report.Check(CheckCID)
+			if check.Status != CheckFail || check.Evidence["expected"] != record.GetCid() {
+				t.Errorf("cid check = %+v, want fail", check)
+			}
+
+			if !slices.Equal(report.Failures, []string{CheckCID, CheckSignature}) {
+				t.Errorf("failures = %v, want cid and signature", report.Failures)
+			}
+		})
+	}
+}
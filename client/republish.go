@@ -0,0 +1,97 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+)
+
+// PublishResult is the outcome of republishing a single record with RepublishAll.
+type PublishResult struct {
+	// Ref is the record reference, or nil if the local records could not be listed.
+	Ref *corev1.RecordRef
+	// Error is the publish failure, or nil on success.
+	Error error
+}
+
+// RepublishAll publishes every record in the local store that matches the filter,
+// for example to restore routing announcements after the routing state was lost.
+// A nil filter matches all records.
+//
+// Local records, including records superseded by an equivalent record, are enumerated via the search index
+// and looked up before publishing.
+// Labels are derived from the record content by the server, as with Publish.
+// Each record is published separately and reported on the returned channel,
+// so failures do not stop the remaining records from being republished.
+// The channel is closed once all records have been processed or the context is done.
+func (c *Client) RepublishAll(ctx context.Context, filter func(*corev1.RecordMeta) bool) (<-chan PublishResult, error) {
+	stream, err := c.SearchServiceClient.Search(ctx, &searchv1.SearchRequest{IncludeSuperseded: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create search stream: %w", err)
+	}
+
+	resultCh := make(chan PublishResult)
+
+	go func() {
+		defer close(resultCh)
+
+		send := func(result PublishResult) bool {
+			select {
+			case resultCh <- result:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			obj, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				send(PublishResult{Error: fmt.Errorf("failed to list local records: %w", err)})
+
+				return
+			}
+
+			ref := &corev1.RecordRef{Cid: obj.GetRecordCid()}
+
+			meta, err := c.Lookup(ctx, ref)
+			if err != nil {
+				if !send(PublishResult{Ref: ref, Error: err}) {
+					return
+				}
+
+				continue
+			}
+
+			if filter != nil && !filter(meta) {
+				continue
+			}
+
+			err = c.Publish(ctx, &routingv1.PublishRequest{
+				Request: &routingv1.PublishRequest_RecordRefs{
+					RecordRefs: &routingv1.RecordRefs{
+						Refs: []*corev1.RecordRef{ref},
+					},
+				},
+			})
+
+			if !send(PublishResult{Ref: ref, Error: err}) {
+				return
+			}
+		}
+	}()
+
+	return resultCh, nil
+}
@@ -0,0 +1,125 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// searchServer lists a fixed set of record CIDs.
+type searchServer struct {
+	searchv1.UnimplementedSearchServiceServer
+
+	cids []string
+}
+
+func (s searchServer) Search(_ *searchv1.SearchRequest, stream searchv1.SearchService_SearchServer) error {
+	for _, cid := range s.cids {
+		if err := stream.Send(&searchv1.SearchResponse{RecordCid: cid}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+// publishServer records published CIDs and rejects the configured ones.
+type publishServer struct {
+	routingv1.UnimplementedRoutingServiceServer
+
+	mu        sync.Mutex
+	published []string
+	ttls      []*durationpb.Duration
+	failOn    map[string]bool
+}
+
+func (s *publishServer) Publish(_ context.Context, req *routingv1.PublishRequest) (*emptypb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ttls = append(s.ttls, req.GetTtl())
+
+	for _, ref := range req.GetRecordRefs().GetRefs() {
+		if s.failOn[ref.GetCid()] {
+			return nil, status.Error(codes.Unavailable, "routing unavailable")
+		}
+
+		s.published = append(s.published, ref.GetCid())
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func TestRepublishAll(t *testing.T) {
+	// "missing" is indexed but no longer in the store
+	search := searchServer{cids: []string{"a", "b", "missing", "c", "skipped"}}
+	lookup := lookupServer{metas: map[string]*corev1.RecordMeta{
+		"a":       {Cid: "a"},
+		"b":       {Cid: "b"},
+		"c":       {Cid: "c"},
+		"skipped": {Cid: "skipped"},
+	}}
+	routing := &publishServer{failOn: map[string]bool{"b": true}}
+
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		searchv1.RegisterSearchServiceServer(s, search)
+		storev1.RegisterStoreServiceServer(s, lookup)
+		routingv1.RegisterRoutingServiceServer(s, routing)
+	})
+
+	results, err := c.RepublishAll(t.Context(), func(meta *corev1.RecordMeta) bool {
+		return meta.GetCid() != "skipped"
+	})
+	if err != nil {
+		t.Fatalf("RepublishAll() unexpected error: %v", err)
+	}
+
+	failed := map[string]error{}
+
+	var succeeded []string
+
+	for result := range results {
+		if result.Error != nil {
+			failed[result.Ref.GetCid()] = result.Error
+
+			continue
+		}
+
+		succeeded = append(succeeded, result.Ref.GetCid())
+	}
+
+	if !slices.Equal(succeeded, []string{"a", "c"}) {
+		t.Errorf("expected a and c to be republished, got %v", succeeded)
+	}
+
+	if status.Code(failed["b"]) != codes.Unavailable {
+		t.Errorf("expected b to fail with Unavailable, got %v", failed["b"])
+	}
+
+	if !errors.Is(failed["missing"], ErrNotFound) {
+		t.Errorf("expected missing record to fail with ErrNotFound, got %v", failed["missing"])
+	}
+
+	if len(failed) != 2 { //nolint:mnd
+		t.Errorf("expected 2 failures, got %v", failed)
+	}
+
+	if !slices.Equal(routing.published, []string{"a", "c"}) {
+		t.Errorf("expected server to receive a and c, got %v", routing.published)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDMetadataKey is the gRPC metadata key carrying the request ID of a call.
+// The server logs it for every request, so that client errors can be correlated with server logs.
+const RequestIDMetadataKey = "x-dir-request-id"
+
+// WithRequestIDGenerator sets the function generating request IDs, random UUIDs by default.
+// It is mainly useful to make request IDs deterministic in tests.
+func WithRequestIDGenerator(generator func() string) Option {
+	return func(opts *options) error {
+		if generator == nil {
+			return errors.New("request ID generator must not be nil")
+		}
+
+		opts.requestIDGenerator = generator
+
+		return nil
+	}
+}
+
+// ContextWithRequestID returns a context whose calls are made with the given request ID
+// instead of a generated one, e.g. to use the same ID for multiple calls.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, RequestIDMetadataKey, requestID)
+}
+
+// RequestIDFromContext returns the request ID set on the context with ContextWithRequestID,
+// or an empty string if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if ids := md.Get(RequestIDMetadataKey); len(ids) > 0 {
+		return ids[len(ids)-1]
+	}
+
+	return ""
+}
+
+// withRequestID returns a context carrying a request ID and the ID,
+// reusing the ID already set on the context if any.
+func withRequestID(ctx context.Context, generate func() string) (context.Context, string) {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		return ctx, requestID
+	}
+
+	requestID := generate()
+
+	return ContextWithRequestID(ctx, requestID), requestID
+}
+
+// requestIDError adds the request ID to the message of an error returned by a call.
+// io.EOF is returned unchanged, as it marks the regular end of a stream.
+func requestIDError(err error, requestID string) error {
+	if err == nil || errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return fmt.Errorf("%w (request ID %s)", err, requestID)
+}
+
+// requestIDInterceptors send a request ID with every call and add it to the errors of the call.
+type requestIDInterceptors struct {
+	generate func() string
+}
+
+func (i requestIDInterceptors) unaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, requestID := withRequestID(ctx, i.generate)
+
+		return requestIDError(invoker(ctx, method, req, reply, cc, opts...), requestID)
+	}
+}
+
+func (i requestIDInterceptors) streamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, requestID := withRequestID(ctx, i.generate)
+
+		args := []any{"method", method, "request_id", requestID}
+		if deadline, ok := ctx.Deadline(); ok {
+			args = append(args, "deadline_remaining", time.Until(deadline))
+		}
+
+		logger.Debug("Creating stream", args...)
+
+		clientStream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, requestIDError(err, requestID)
+		}
+
+		return &requestIDClientStream{ClientStream: clientStream, requestID: requestID}, nil
+	}
+}
+
+// requestIDClientStream adds the request ID to the errors of a client stream.
+type requestIDClientStream struct {
+	grpc.ClientStream
+
+	requestID string
+}
+
+func (s *requestIDClientStream) SendMsg(msg any) error {
+	return requestIDError(s.ClientStream.SendMsg(msg), s.requestID)
+}
+
+func (s *requestIDClientStream) RecvMsg(msg any) error {
+	return requestIDError(s.ClientStream.RecvMsg(msg), s.requestID)
+}
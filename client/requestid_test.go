@@ -0,0 +1,213 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client/streaming"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDServer records the request IDs it receives.
+// Pushes succeed, pulls report every record as missing, and lookups and bundle pushes fail.
+type requestIDServer struct {
+	pushServer
+
+	mu       *sync.Mutex
+	received *[]string
+}
+
+func newRequestIDServer() requestIDServer {
+	return requestIDServer{mu: &sync.Mutex{}, received: &[]string{}}
+}
+
+func (s requestIDServer) record(ctx context.Context) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	*s.received = append(*s.received, strings.Join(md.Get(RequestIDMetadataKey), ","))
+}
+
+func (s requestIDServer) ids() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), *s.received...)
+}
+
+func (s requestIDServer) Push(stream storev1.StoreService_PushServer) error {
+	s.record(stream.Context())
+
+	return s.pushServer.Push(stream)
+}
+
+func (s requestIDServer) Pull(stream storev1.StoreService_PullServer) error {
+	s.record(stream.Context())
+
+	for {
+		ref, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		err = stream.Send(&corev1.Record{Error: &corev1.RecordError{
+			Cid:     ref.GetCid(),
+			Code:    uint32(codes.NotFound),
+			Message: "record not found",
+		}})
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func (s requestIDServer) Lookup(stream storev1.StoreService_LookupServer) error {
+	s.record(stream.Context())
+
+	return status.Error(codes.Internal, "lookup failed") //nolint:wrapcheck
+}
+
+func (s requestIDServer) PushBundle(ctx context.Context, _ *corev1.RecordBundle) (*corev1.RecordRef, error) {
+	s.record(ctx)
+
+	return nil, status.Error(codes.FailedPrecondition, "bundle rejected") //nolint:wrapcheck
+}
+
+func TestRequestID(t *testing.T) {
+	server := newRequestIDServer()
+
+	var (
+		mu   sync.Mutex
+		next int
+	)
+
+	c := newBufconnClient(t,
+		func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) },
+		WithRequestIDGenerator(func() string {
+			mu.Lock()
+			defer mu.Unlock()
+
+			next++
+
+			return fmt.Sprintf("req-%d", next)
+		}),
+	)
+
+	record := newBundleTestRecord("request-id")
+	ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+	t.Run("unary call error", func(t *testing.T) {
+		_, err := c.PushBundle(t.Context(), &corev1.RecordBundle{})
+		if err == nil {
+			t.Fatal("PushBundle() should fail")
+		}
+
+		assertRequestID(t, server, err, "req-1")
+
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("status code = %v, want FailedPrecondition", status.Code(err))
+		}
+	})
+
+	t.Run("stream error", func(t *testing.T) {
+		_, err := c.LookupBatch(t.Context(), []*corev1.RecordRef{ref})
+		if err == nil {
+			t.Fatal("LookupBatch() should fail")
+		}
+
+		assertRequestID(t, server, err, "req-2")
+	})
+
+	t.Run("result error", func(t *testing.T) {
+		stream, err := c.PullStream(t.Context(), streaming.SliceToChan(t.Context(), []*corev1.RecordRef{ref}))
+		if err != nil {
+			t.Fatalf("PullStream() unexpected error: %v", err)
+		}
+
+		var results []*PullResult
+
+	loop:
+		for {
+			select {
+			case result := <-stream.ResCh():
+				results = append(results, result)
+			case err := <-stream.ErrCh():
+				t.Fatalf("PullStream() unexpected stream error: %v", err)
+			case <-stream.DoneCh():
+				break loop
+			}
+		}
+
+		if len(results) != 1 {
+			t.Fatalf("PullStream() returned %d results, want 1", len(results))
+		}
+
+		if results[0].RequestID != "req-3" {
+			t.Errorf("PullResult.RequestID = %q, want req-3", results[0].RequestID)
+		}
+
+		if !errors.Is(results[0].Error, ErrNotFound) {
+			t.Errorf("PullResult.Error = %v, want ErrNotFound", results[0].Error)
+		}
+
+		assertRequestID(t, server, results[0].Error, "req-3")
+	})
+
+	t.Run("push results", func(t *testing.T) {
+		results, err := c.PushBatchResults(t.Context(), []*corev1.Record{record})
+		if err != nil {
+			t.Fatalf("PushBatchResults() unexpected error: %v", err)
+		}
+
+		if len(results) != 1 || results[0].RequestID != "req-4" {
+			t.Errorf("PushBatchResults() = %+v, want a result with request ID req-4", results)
+		}
+	})
+
+	t.Run("context request ID", func(t *testing.T) {
+		ctx := ContextWithRequestID(t.Context(), "caller-id")
+
+		_, err := c.PushBundle(ctx, &corev1.RecordBundle{})
+		assertRequestID(t, server, err, "caller-id")
+	})
+}
+
+func TestWithRequestIDGeneratorValidation(t *testing.T) {
+	if err := WithRequestIDGenerator(nil)(&options{}); err == nil {
+		t.Error("WithRequestIDGenerator(nil) should fail")
+	}
+}
+
+// assertRequestID checks that the request ID was the last one received by the server
+// and that it is part of the error message.
+func assertRequestID(t *testing.T, server requestIDServer, err error, want string) {
+	t.Helper()
+
+	ids := server.ids()
+	if len(ids) == 0 || ids[len(ids)-1] != want {
+		t.Errorf("server received request IDs %v, want last %s", ids, want)
+	}
+
+	if err == nil || !strings.Contains(err.Error(), "request ID "+want) {
+		t.Errorf("error %v does not contain request ID %s", err, want)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Resolve resolves a discovery tag, e.g. "my-agent:latest" or "my-agent:v1.0.0",
+// to the record it currently points to. A CID resolves to itself.
+// Tags are normalized by the server in the same way as when they are created on push.
+// Returns ErrNotFound if no record is tagged with the given tag.
+//
+// Name tags are mutable and may be re-pointed to a newer record, in which case the server
+// returns a warning that is logged. Use StoreServiceClient.Resolve to access the warning.
+func (c *Client) Resolve(ctx context.Context, tag string) (*corev1.RecordRef, error) {
+	resp, err := c.StoreServiceClient.Resolve(ctx, &storev1.ResolveRequest{Tag: tag})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+		}
+
+		return nil, fmt.Errorf("failed to resolve tag %s: %w", tag, err)
+	}
+
+	if resp.Warning != nil {
+		logger.Warn("Resolved mutable tag", "tag", tag, "cid", resp.GetRecordRef().GetCid(), "warning", resp.GetWarning())
+	}
+
+	return resp.GetRecordRef(), nil
+}
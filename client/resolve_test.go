@@ -0,0 +1,61 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// resolveServer resolves a fixed set of tags.
+type resolveServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	tags map[string]string
+}
+
+func (s resolveServer) Resolve(_ context.Context, req *storev1.ResolveRequest) (*storev1.ResolveResponse, error) {
+	cid, ok := s.tags[req.GetTag()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", req.GetTag())
+	}
+
+	warning := "tag is mutable"
+
+	return &storev1.ResolveResponse{
+		RecordRef: &corev1.RecordRef{Cid: cid},
+		Warning:   &warning,
+	}, nil
+}
+
+func TestResolve(t *testing.T) {
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterStoreServiceServer(s, resolveServer{tags: map[string]string{"my-agent:latest": "cid"}})
+	})
+
+	ref, err := c.Resolve(t.Context(), "my-agent:latest")
+	if err != nil {
+		t.Fatalf("Resolve() unexpected error: %v", err)
+	}
+
+	if ref.GetCid() != "cid" {
+		t.Errorf("expected cid, got %s", ref.GetCid())
+	}
+
+	_, err = c.Resolve(t.Context(), "unknown:latest")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound status to be preserved, got %v", err)
+	}
+}
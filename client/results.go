@@ -0,0 +1,460 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/api/lint"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client/streaming"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrNotFound is reported for record references that do not exist in the store.
+	ErrNotFound = errors.New("record not found")
+
+	// ErrConflict is reported for records rejected because a record with the same name
+	// and version but different content is already stored. Push with storev1.ContextWithPushOverwrite to replace it.
+	ErrConflict = errors.New("record conflicts with a stored record")
+)
+
+// LookupResult is the outcome of looking up a single record reference with LookupStream.
+type LookupResult struct {
+	// Index is the position of the reference in the input stream.
+	Index int
+	// Meta is the record metadata, or nil if the lookup failed.
+	Meta *corev1.RecordMeta
+	// Error is the lookup failure, or nil on success.
+	// Missing records are reported with ErrNotFound.
+	Error error
+	// RequestID is the ID of the request the result was returned for.
+	RequestID string
+}
+
+// PullResult is the outcome of pulling a single record reference with PullStream.
+type PullResult struct {
+	// Index is the position of the reference in the input stream.
+	Index int
+	// Record is the pulled record, or nil if the pull failed.
+	Record *corev1.Record
+	// Deprecation is set if the pulled record is deprecated or withdrawn.
+	Deprecation *DeprecationInfo
+	// Meta is the metadata of the pulled record, only set by PullByLabel WithPullMetadata.
+	Meta *corev1.RecordMeta
+	// Error is the pull failure, or nil on success.
+	// Missing records are reported with ErrNotFound.
+	Error error
+	// RequestID is the ID of the request the result was returned for.
+	RequestID string
+}
+
+// PushResult is the outcome of pushing a single record with PushBatchResults.
+type PushResult struct {
+	// Index is the position of the record in the input.
+	Index int
+	// Ref is the reference of the pushed record.
+	Ref *corev1.RecordRef
+	// AlreadyExisted reports whether the record was already stored,
+	// in which case its content was not uploaded again.
+	AlreadyExisted bool
+	// Conflict is set if the record was rejected because a record with the same name
+	// and version but different content is already stored.
+	Conflict *ConflictInfo
+	// LintFindings are the findings of the lint of the pushed record, if the server lints pushed records.
+	// Findings never fail a push, unless the server escalated their rule to the error severity.
+	LintFindings []lint.Finding
+	// Error is the push failure, or nil on success.
+	// Conflicting records are reported with ErrConflict.
+	Error error
+	// RequestID is the ID of the request the record was pushed with.
+	RequestID string
+}
+
+// ConflictInfo describes the stored record a pushed record conflicts with.
+type ConflictInfo struct {
+	// Name and Version are the name and version shared by both records.
+	Name    string
+	Version string
+	// ExistingCID is the CID of the stored record.
+	ExistingCID string
+}
+
+// DeleteResult is the outcome of deleting a single record reference with DeleteStream.
+type DeleteResult struct {
+	// Index is the position of the reference in the input stream.
+	Index int
+	// Ref is the deleted record reference.
+	Ref *corev1.RecordRef
+	// Error is the delete failure, or nil on success.
+	// Missing records are reported with ErrNotFound.
+	Error error
+	// RequestID is the ID of the request the result was returned for.
+	RequestID string
+	// DryRun is set by DeleteByFilter for records that would be deleted if the delete was confirmed.
+	DryRun bool
+	// Pinned is set by DeleteByFilter for published records that were skipped.
+	Pinned bool
+}
+
+// setRequestID sets the request ID of the result and adds it to the result error.
+func (r *LookupResult) setRequestID(requestID string) {
+	r.RequestID = requestID
+	r.Error = requestIDError(r.Error, requestID)
+}
+
+// setRequestID sets the request ID of the result and adds it to the result error.
+func (r *PullResult) setRequestID(requestID string) {
+	r.RequestID = requestID
+	r.Error = requestIDError(r.Error, requestID)
+}
+
+// setRequestID sets the request ID of the result and adds it to the result error.
+func (r *DeleteResult) setRequestID(requestID string) {
+	r.RequestID = requestID
+	r.Error = requestIDError(r.Error, requestID)
+}
+
+func newLookupResult(index int, meta *corev1.RecordMeta) *LookupResult {
+	if meta.GetError() != nil {
+		return &LookupResult{Index: index, Error: recordError(meta.GetError())}
+	}
+
+	return &LookupResult{Index: index, Meta: meta}
+}
+
+func newPullResult(index int, record *corev1.Record) *PullResult {
+	if record.GetError() != nil {
+		return &PullResult{Index: index, Error: recordError(record.GetError())}
+	}
+
+	return &PullResult{Index: index, Record: record, Deprecation: NewDeprecationInfo(record)}
+}
+
+func newPushResult(index int, ref *corev1.RecordRef) *PushResult {
+	result := &PushResult{Index: index, Ref: ref, AlreadyExisted: ref.GetAlreadyExisted()}
+	if ref.GetError() != nil {
+		result.Error = recordError(ref.GetError())
+	}
+
+	if conflict := ref.GetConflict(); conflict != nil {
+		result.Conflict = &ConflictInfo{
+			Name:        conflict.GetName(),
+			Version:     conflict.GetVersion(),
+			ExistingCID: conflict.GetCid(),
+		}
+	}
+
+	for _, finding := range ref.GetLintFindings() {
+		result.LintFindings = append(result.LintFindings, lint.Finding{
+			Severity: lint.Severity(finding.GetSeverity()),
+			RuleID:   finding.GetRuleId(),
+			Path:     finding.GetPath(),
+			Message:  finding.GetMessage(),
+		})
+	}
+
+	return result
+}
+
+func newDeleteResult(index int, resp *storev1.DeleteResponse) *DeleteResult {
+	result := &DeleteResult{Index: index, Ref: resp.GetRecordRef()}
+	if resp.GetError() != nil {
+		result.Error = recordError(resp.GetError())
+	}
+
+	return result
+}
+
+// recordError converts a failure reported by the server for a single record reference into an APIError.
+// The gRPC status and its details are preserved, so errors.Is, errors.As and status.Code can be used on the result.
+func recordError(recordErr *corev1.RecordError) error {
+	err := apiError(status.FromProto(&spb.Status{
+		Code:    int32(recordErr.GetCode()), //nolint:gosec
+		Message: recordErr.GetMessage(),
+		Details: recordErr.GetDetails(),
+	}).Err())
+
+	switch status.Code(err) { //nolint:exhaustive
+	case codes.NotFound:
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	case codes.AlreadyExists:
+		return fmt.Errorf("%w: %w", ErrConflict, err)
+	default:
+		return err
+	}
+}
+
+// inflight tracks the input positions of references that were sent on a stream
+// but not answered yet, so that responses can be matched to their input by CID
+// even if the server answers out of order.
+type inflight struct {
+	mu sync.Mutex
+
+	next  int
+	order []int            // pending positions in send order
+	byCID map[string][]int // pending positions by CID, in send order
+	cids  map[int]string   // CID of each pending position
+}
+
+func newInflight() *inflight {
+	return &inflight{
+		byCID: map[string][]int{},
+		cids:  map[int]string{},
+	}
+}
+
+// add registers the next input position for the CID.
+func (p *inflight) add(cid string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pos := p.next
+	p.next++
+
+	p.order = append(p.order, pos)
+	p.byCID[cid] = append(p.byCID[cid], pos)
+	p.cids[pos] = cid
+}
+
+// skip returns the next input position without tracking it,
+// for references that are not sent.
+func (p *inflight) skip() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pos := p.next
+	p.next++
+
+	return pos
+}
+
+// match returns the input position answered by a response and stops tracking it.
+// The response CID is only computed if more than one reference is pending.
+// Responses without a pending CID, e.g. from servers that do not echo CIDs
+// for failures, are matched to the oldest pending position.
+// Returns -1 if nothing is pending.
+func (p *inflight) match(cidOf func() string) int {
+	pos, _ := p.matchCID(cidOf)
+
+	return pos
+}
+
+// matchCID is like match, but also returns the CID of the reference sent at the position.
+func (p *inflight) matchCID(cidOf func() string) (int, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.order) == 0 {
+		return -1, ""
+	}
+
+	pos := p.order[0]
+
+	if len(p.order) > 1 {
+		if positions := p.byCID[cidOf()]; len(positions) > 0 {
+			pos = positions[0]
+		}
+	}
+
+	cid := p.cids[pos]
+	delete(p.cids, pos)
+
+	if positions := p.byCID[cid][1:]; len(positions) > 0 {
+		p.byCID[cid] = positions
+	} else {
+		delete(p.byCID, cid)
+	}
+
+	p.order = slices.DeleteFunc(p.order, func(other int) bool { return other == pos })
+
+	return pos, cid
+}
+
+// resultStream wraps a bidirectional stream that returns one response per record reference,
+// converting each response into a result indexed by the position of its reference in the input.
+// Responses are matched to references by CID, so indexes are correct even if the server
+// answers out of order.
+// Send must only be called from a single goroutine, and Recv from another.
+type resultStream[OutT, ResT any] struct {
+	streaming.BidiStream[corev1.RecordRef, OutT]
+
+	inflight  *inflight
+	cidOf     func(*OutT) string
+	toResult  func(int, *OutT) *ResT
+	requestID string
+
+	// Set by withVerification
+	verify func(cid string, out *OutT) *OutT
+
+	// Set by withHooks
+	ctx   context.Context //nolint:containedctx
+	hooks *resultHooks[ResT]
+
+	// pending holds the results of references skipped by a hook in send order,
+	// with nil entries for references sent to the server
+	pending  chan *ResT
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// requestIDSetter is implemented by results that carry the ID of their request.
+type requestIDSetter interface {
+	setRequestID(requestID string)
+}
+
+func newResultStream[OutT, ResT any](
+	stream streaming.BidiStream[corev1.RecordRef, OutT],
+	cidOf func(*OutT) string,
+	toResult func(int, *OutT) *ResT,
+	requestID string,
+) *resultStream[OutT, ResT] {
+	return &resultStream[OutT, ResT]{
+		BidiStream: stream,
+		inflight:   newInflight(),
+		cidOf:      cidOf,
+		toResult:   toResult,
+		requestID:  requestID,
+	}
+}
+
+// withHooks invokes the hooks for every reference, see WithHooks.
+// References rejected by the before hook are not sent to the server,
+// their results are returned in the order the references were sent.
+func (s *resultStream[OutT, ResT]) withHooks(ctx context.Context, hooks *resultHooks[ResT]) *resultStream[OutT, ResT] {
+	if hooks == nil {
+		return s
+	}
+
+	s.ctx = ctx
+	s.hooks = hooks
+	s.pending = make(chan *ResT, cachedStreamBuffer)
+	s.done = make(chan struct{})
+
+	return s
+}
+
+// withVerification checks every response against the CID of the reference it answers.
+// verify returns the response to convert into a result, e.g. a failure if it does not match the CID.
+func (s *resultStream[OutT, ResT]) withVerification(verify func(cid string, out *OutT) *OutT) *resultStream[OutT, ResT] {
+	s.verify = verify
+
+	return s
+}
+
+func (s *resultStream[OutT, ResT]) Send(ref *corev1.RecordRef) error {
+	if s.hooks == nil {
+		return s.send(ref)
+	}
+
+	var rejected *ResT
+
+	if err := s.hooks.before(s.ctx, ref); err != nil {
+		rejected = s.hooks.rejected(s.inflight.skip(), ref, err)
+	} else if err := s.send(ref); err != nil {
+		return err
+	}
+
+	select {
+	case s.pending <- rejected:
+		return nil
+	case <-s.done:
+		return errStreamClosed
+	case <-s.ctx.Done():
+		return s.ctx.Err() //nolint:wrapcheck
+	}
+}
+
+func (s *resultStream[OutT, ResT]) send(ref *corev1.RecordRef) error {
+	// Track the reference before sending, the response may arrive before Send returns
+	s.inflight.add(ref.GetCid())
+
+	return s.BidiStream.Send(ref) //nolint:wrapcheck
+}
+
+func (s *resultStream[OutT, ResT]) CloseSend() error {
+	if s.pending != nil {
+		close(s.pending)
+	}
+
+	return s.BidiStream.CloseSend() //nolint:wrapcheck
+}
+
+func (s *resultStream[OutT, ResT]) Recv() (*ResT, error) {
+	if s.pending != nil {
+		if rejected := <-s.pending; rejected != nil {
+			return s.finish(rejected), nil
+		}
+	}
+
+	result, err := s.recv()
+	if err != nil && s.done != nil {
+		s.doneOnce.Do(func() { close(s.done) })
+	}
+
+	return result, err
+}
+
+func (s *resultStream[OutT, ResT]) recv() (*ResT, error) {
+	out, err := s.BidiStream.Recv()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	index, cid := s.inflight.matchCID(func() string { return s.cidOf(out) })
+	if index < 0 {
+		return nil, errors.New("received a response without a pending request")
+	}
+
+	if s.verify != nil {
+		out = s.verify(cid, out)
+	}
+
+	return s.finish(s.toResult(index, out)), nil
+}
+
+// finish sets the request ID of the result and invokes the after hook.
+func (s *resultStream[OutT, ResT]) finish(result *ResT) *ResT {
+	if setter, ok := any(result).(requestIDSetter); ok {
+		setter.setRequestID(s.requestID)
+	}
+
+	if s.hooks != nil {
+		s.hooks.after(s.ctx, result)
+	}
+
+	return result
+}
+
+// pulledCID returns the CID of a pulled record, or of the reference that failed.
+func pulledCID(record *corev1.Record) string {
+	if record.GetError() != nil {
+		return record.GetError().GetCid()
+	}
+
+	// Redacted records do not match the CID they were pulled by
+	if record.GetRedacted() {
+		return record.GetOriginalCid()
+	}
+
+	return record.GetCid()
+}
+
+// lookedUpCID returns the CID of looked up record metadata.
+func lookedUpCID(meta *corev1.RecordMeta) string {
+	return meta.GetCid()
+}
+
+// deletedCID returns the CID of the reference a delete response acknowledges.
+func deletedCID(resp *storev1.DeleteResponse) string {
+	return resp.GetRecordRef().GetCid()
+}
@@ -0,0 +1,793 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"sync"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/api/lint"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client/streaming"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pullServer serves records from a fixed set of records.
+// Unknown references are reported as per-record NotFound errors.
+type pullServer struct {
+	lookupServer
+
+	records map[string]*corev1.Record
+}
+
+func (s pullServer) Pull(stream storev1.StoreService_PullServer) error {
+	for {
+		ref, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		record, ok := s.records[ref.GetCid()]
+		if !ok {
+			record = &corev1.Record{
+				Error: &corev1.RecordError{Code: uint32(codes.NotFound), Message: "record not found: " + ref.GetCid()},
+			}
+		}
+
+		if err := stream.Send(record); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func (s pullServer) DeleteWithAck(stream storev1.StoreService_DeleteWithAckServer) error {
+	for {
+		ref, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		response := &storev1.DeleteResponse{RecordRef: ref}
+		if _, ok := s.records[ref.GetCid()]; !ok {
+			response.Error = &corev1.RecordError{Code: uint32(codes.NotFound), Message: "record not found: " + ref.GetCid()}
+		}
+
+		if err := stream.Send(response); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+// newResultsClient creates a client for a server storing the given records,
+// and returns references where every odd one does not exist.
+func newResultsClient(t *testing.T, count int, opts ...Option) (*Client, []*corev1.RecordRef) {
+	t.Helper()
+
+	server := pullServer{
+		lookupServer: lookupServer{metas: map[string]*corev1.RecordMeta{}},
+		records:      map[string]*corev1.Record{},
+	}
+
+	var refs []*corev1.RecordRef
+
+	for i := range count {
+		if i%2 == 1 {
+			refs = append(refs, &corev1.RecordRef{Cid: "missing-" + string(rune('a'+i))})
+
+			continue
+		}
+
+		record := corev1.New(&typesv1alpha1.Record{
+			Name:          "results-agent-" + string(rune('a'+i)),
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+		})
+
+		cid := record.GetCid()
+		server.records[cid] = record
+		server.metas[cid] = &corev1.RecordMeta{Cid: cid}
+		refs = append(refs, &corev1.RecordRef{Cid: cid})
+	}
+
+	client := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) }, opts...)
+
+	return client, refs
+}
+
+func TestLookupStreamResults(t *testing.T) {
+	c, refs := newResultsClient(t, 6)
+
+	result, err := c.LookupStream(t.Context(), streaming.SliceToChan(t.Context(), refs))
+	if err != nil {
+		t.Fatalf("failed to create lookup stream: %v", err)
+	}
+
+	var results []*LookupResult
+
+	for done := false; !done; {
+		select {
+		case err := <-result.ErrCh():
+			t.Fatalf("unexpected stream error: %v", err)
+		case res := <-result.ResCh():
+			results = append(results, res)
+		case <-result.DoneCh():
+			done = true
+		}
+	}
+
+	if len(results) != len(refs) {
+		t.Fatalf("expected %d results, got %d", len(refs), len(results))
+	}
+
+	for i, res := range results {
+		if res.Index != i {
+			t.Errorf("expected result %d to have index %d, got %d", i, i, res.Index)
+		}
+
+		if i%2 == 1 {
+			if !errors.Is(res.Error, ErrNotFound) {
+				t.Errorf("expected ErrNotFound for %s, got %v", refs[i].GetCid(), res.Error)
+			}
+
+			if status.Code(res.Error) != codes.NotFound {
+				t.Errorf("expected NotFound status for %s, got %v", refs[i].GetCid(), status.Code(res.Error))
+			}
+
+			continue
+		}
+
+		if res.Error != nil {
+			t.Errorf("unexpected error for %s: %v", refs[i].GetCid(), res.Error)
+		}
+
+		if res.Meta.GetCid() != refs[i].GetCid() {
+			t.Errorf("expected metadata for %s, got %s", refs[i].GetCid(), res.Meta.GetCid())
+		}
+	}
+}
+
+func TestPullStreamResults(t *testing.T) {
+	c, refs := newResultsClient(t, 5)
+
+	result, err := c.PullStream(t.Context(), streaming.SliceToChan(t.Context(), refs))
+	if err != nil {
+		t.Fatalf("failed to create pull stream: %v", err)
+	}
+
+	var results []*PullResult
+
+	for done := false; !done; {
+		select {
+		case err := <-result.ErrCh():
+			t.Fatalf("unexpected stream error: %v", err)
+		case res := <-result.ResCh():
+			results = append(results, res)
+		case <-result.DoneCh():
+			done = true
+		}
+	}
+
+	if len(results) != len(refs) {
+		t.Fatalf("expected %d results, got %d", len(refs), len(results))
+	}
+
+	for i, res := range results {
+		if res.Index != i {
+			t.Errorf("expected result %d to have index %d, got %d", i, i, res.Index)
+		}
+
+		if i%2 == 1 {
+			if !errors.Is(res.Error, ErrNotFound) {
+				t.Errorf("expected ErrNotFound for %s, got %v", refs[i].GetCid(), res.Error)
+			}
+
+			continue
+		}
+
+		if res.Error != nil {
+			t.Errorf("unexpected error for %s: %v", refs[i].GetCid(), res.Error)
+		}
+
+		if res.Record.GetCid() != refs[i].GetCid() {
+			t.Errorf("expected record %s, got %s", refs[i].GetCid(), res.Record.GetCid())
+		}
+	}
+}
+
+func TestDeleteStreamResults(t *testing.T) {
+	c, refs := newResultsClient(t, 4)
+
+	result, err := c.DeleteStream(t.Context(), streaming.SliceToChan(t.Context(), refs))
+	if err != nil {
+		t.Fatalf("failed to create delete stream: %v", err)
+	}
+
+	var results []*DeleteResult
+
+	for done := false; !done; {
+		select {
+		case err := <-result.ErrCh():
+			t.Fatalf("unexpected stream error: %v", err)
+		case res := <-result.ResCh():
+			results = append(results, res)
+		case <-result.DoneCh():
+			done = true
+		}
+	}
+
+	if len(results) != len(refs) {
+		t.Fatalf("expected %d results, got %d", len(refs), len(results))
+	}
+
+	for i, res := range results {
+		if res.Index != i || res.Ref.GetCid() != refs[i].GetCid() {
+			t.Errorf("expected result %d for %s, got index %d for %s", i, refs[i].GetCid(), res.Index, res.Ref.GetCid())
+		}
+
+		if i%2 == 1 {
+			if !errors.Is(res.Error, ErrNotFound) {
+				t.Errorf("expected ErrNotFound for %s, got %v", refs[i].GetCid(), res.Error)
+			}
+
+			continue
+		}
+
+		if res.Error != nil {
+			t.Errorf("unexpected error for %s: %v", refs[i].GetCid(), res.Error)
+		}
+	}
+}
+
+func TestBatchResults(t *testing.T) {
+	c, refs := newResultsClient(t, 4)
+
+	var lookupProgress, pullProgress streaming.Progress
+
+	metas, err := c.LookupBatch(t.Context(), refs, streaming.WithProgress(func(p streaming.Progress) { lookupProgress = p }))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected lookup batch to report ErrNotFound, got %v", err)
+	}
+
+	records, err := c.PullBatch(t.Context(), refs, streaming.WithProgress(func(p streaming.Progress) { pullProgress = p }))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected pull batch to report ErrNotFound, got %v", err)
+	}
+
+	// Missing records are counted as failed, not completed
+	for name, progress := range map[string]streaming.Progress{"lookup": lookupProgress, "pull": pullProgress} {
+		if progress.Failed != 2 || progress.Completed != 2 || progress.Total != len(refs) {
+			t.Errorf("%s progress = %+v, want 2 failed and 2 completed of %d", name, progress, len(refs))
+		}
+	}
+
+	if len(metas) != len(refs) || len(records) != len(refs) {
+		t.Fatalf("expected %d results, got %d metadata and %d records", len(refs), len(metas), len(records))
+	}
+
+	// Results are aligned with the refs, with nil results for missing records
+	for i, ref := range refs {
+		if missing := i%2 == 1; missing != (metas[i] == nil) || missing != (records[i] == nil) {
+			t.Errorf("index %d: unexpected metadata %v and record %v", i, metas[i], records[i])
+
+			continue
+		}
+
+		if metas[i] != nil && (metas[i].GetCid() != ref.GetCid() || records[i].GetCid() != ref.GetCid()) {
+			t.Errorf("index %d: expected results for %s, got %s and %s", i, ref.GetCid(), metas[i].GetCid(), records[i].GetCid())
+		}
+	}
+
+	if err := c.DeleteBatch(t.Context(), refs); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected delete batch to report ErrNotFound, got %v", err)
+	}
+
+	if _, err := c.Lookup(t.Context(), refs[1]); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound status for missing record, got %v", err)
+	}
+}
+
+// idempotentPushServer acknowledges pushes, flagging records that were pushed before.
+type idempotentPushServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	mu     sync.Mutex
+	stored map[string]bool
+}
+
+func (s *idempotentPushServer) Push(stream storev1.StoreService_PushServer) error {
+	for {
+		record, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		s.mu.Lock()
+		existed := s.stored[record.GetCid()]
+		s.stored[record.GetCid()] = true
+		s.mu.Unlock()
+
+		if err := stream.Send(&corev1.RecordRef{Cid: record.GetCid(), AlreadyExisted: existed}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func TestPushBatchResults(t *testing.T) {
+	server := &idempotentPushServer{stored: map[string]bool{}}
+	c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) })
+
+	var records []*corev1.Record
+
+	for i := range 3 {
+		records = append(records, corev1.New(&typesv1alpha1.Record{
+			Name:          "push-agent-" + string(rune('a'+i)),
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+		}))
+	}
+
+	// Push the first record, then all of them
+	if _, err := c.PushBatchResults(t.Context(), records[:1]); err != nil {
+		t.Fatalf("failed to push records: %v", err)
+	}
+
+	results, err := c.PushBatchResults(t.Context(), records)
+	if err != nil {
+		t.Fatalf("failed to push records: %v", err)
+	}
+
+	if len(results) != len(records) {
+		t.Fatalf("expected %d results, got %d", len(records), len(results))
+	}
+
+	for i, result := range results {
+		if result.Index != i || result.Ref.GetCid() != records[i].GetCid() {
+			t.Errorf("result %d: unexpected index %d or CID %s", i, result.Index, result.Ref.GetCid())
+		}
+
+		if expected := i == 0; result.AlreadyExisted != expected {
+			t.Errorf("result %d: expected AlreadyExisted %v, got %v", i, expected, result.AlreadyExisted)
+		}
+	}
+}
+
+// failingFirstPushServer acknowledges all records but the first of a stream,
+// then fails the stream before the first record is acknowledged.
+type failingFirstPushServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	records int
+}
+
+func (s failingFirstPushServer) Push(stream storev1.StoreService_PushServer) error {
+	for i := range s.records {
+		record, err := stream.Recv()
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		if err := stream.Send(&corev1.RecordRef{Cid: record.GetCid()}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return status.Error(codes.Internal, "storage failure")
+}
+
+func TestPushBatchFirstRecordFails(t *testing.T) {
+	var records []*corev1.Record
+
+	for i := range 3 {
+		records = append(records, corev1.New(&typesv1alpha1.Record{
+			Name:          "failing-agent-" + string(rune('a'+i)),
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+		}))
+	}
+
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterStoreServiceServer(s, failingFirstPushServer{records: len(records)})
+	})
+
+	refs, err := c.PushBatch(t.Context(), records)
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected push batch to fail with Internal, got %v", err)
+	}
+
+	// Later records are returned at their positions even though the first one failed
+	if len(refs) != len(records) || refs[0] != nil {
+		t.Fatalf("expected %d refs with a nil first ref, got %v", len(records), refs)
+	}
+
+	for i := 1; i < len(records); i++ {
+		if refs[i].GetCid() != records[i].GetCid() {
+			t.Errorf("ref %d: expected %s, got %s", i, records[i].GetCid(), refs[i].GetCid())
+		}
+	}
+
+	results, err := c.PushBatchResults(t.Context(), records)
+	if err == nil {
+		t.Fatal("expected push batch results to fail")
+	}
+
+	if len(results) != 2 || results[0].Index != 1 || results[1].Index != 2 {
+		t.Fatalf("expected results for the records at index 1 and 2, got %+v", results)
+	}
+}
+
+// conflictPushServer rejects records with the name of a stored record but a different CID,
+// unless the push requests to overwrite it.
+type conflictPushServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	names map[string]string
+}
+
+func (s *conflictPushServer) Push(stream storev1.StoreService_PushServer) error {
+	for {
+		record, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+		name := record.GetData().GetFields()["name"].GetStringValue()
+		if existing, ok := s.names[name]; ok && existing != record.GetCid() && !storev1.IsPushOverwrite(stream.Context()) {
+			ref.Error = &corev1.RecordError{Code: uint32(codes.AlreadyExists), Message: "record conflicts", Cid: record.GetCid()}
+			ref.Conflict = &corev1.RecordConflict{Name: name, Version: "v1.0.0", Cid: existing}
+		} else {
+			s.names[name] = record.GetCid()
+		}
+
+		if err := stream.Send(ref); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func TestPushBatchResultsConflict(t *testing.T) {
+	server := &conflictPushServer{names: map[string]string{}}
+	c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) })
+
+	newRecord := func(name, description string) *corev1.Record {
+		return corev1.New(&typesv1alpha1.Record{
+			Name:          name,
+			Version:       "v1.0.0",
+			Description:   description,
+			SchemaVersion: "0.7.0",
+		})
+	}
+
+	original := newRecord("conflict-agent", "original")
+	if _, err := c.Push(t.Context(), original); err != nil {
+		t.Fatalf("failed to push record: %v", err)
+	}
+
+	changed := newRecord("conflict-agent", "changed")
+	records := []*corev1.Record{newRecord("other-agent", ""), changed}
+
+	results, err := c.PushBatchResults(t.Context(), records)
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("expected push to report ErrConflict, got %v", err)
+	}
+
+	if len(results) != len(records) {
+		t.Fatalf("expected %d results, got %d", len(records), len(results))
+	}
+
+	if results[0].Conflict != nil || results[0].Error != nil {
+		t.Errorf("expected first record to be pushed, got conflict %v and error %v", results[0].Conflict, results[0].Error)
+	}
+
+	expected := &ConflictInfo{Name: "conflict-agent", Version: "v1.0.0", ExistingCID: original.GetCid()}
+	if conflict := results[1].Conflict; conflict == nil || *conflict != *expected {
+		t.Errorf("expected conflict %v, got %v", expected, conflict)
+	}
+
+	if !errors.Is(results[1].Error, ErrConflict) || status.Code(results[1].Error) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists conflict error, got %v", results[1].Error)
+	}
+
+	if _, err := c.Push(t.Context(), changed); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected push to report ErrConflict, got %v", err)
+	}
+
+	ref, err := c.Push(storev1.ContextWithPushOverwrite(t.Context()), changed)
+	if err != nil {
+		t.Fatalf("failed to overwrite record: %v", err)
+	}
+
+	if ref.GetCid() != changed.GetCid() || server.names["conflict-agent"] != changed.GetCid() {
+		t.Errorf("expected record to be overwritten with %s", changed.GetCid())
+	}
+}
+
+// reorderServer answers streams only after all requests were received,
+// in reverse order, like a server processing requests concurrently.
+type reorderServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	records map[string]*corev1.Record
+}
+
+// recvAll receives all requests of a stream.
+func recvAll[T any](recv func() (*T, error)) ([]*T, error) {
+	var requests []*T
+
+	for {
+		request, err := recv()
+		if errors.Is(err, io.EOF) {
+			return requests, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, request)
+	}
+}
+
+func (s reorderServer) Pull(stream storev1.StoreService_PullServer) error {
+	refs, err := recvAll(stream.Recv)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range slices.Backward(refs) {
+		record, ok := s.records[ref.GetCid()]
+		if !ok {
+			record = &corev1.Record{
+				Error: &corev1.RecordError{Code: uint32(codes.NotFound), Message: "record not found", Cid: ref.GetCid()},
+			}
+		}
+
+		if err := stream.Send(record); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+func (s reorderServer) Lookup(stream storev1.StoreService_LookupServer) error {
+	refs, err := recvAll(stream.Recv)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range slices.Backward(refs) {
+		meta := &corev1.RecordMeta{Cid: ref.GetCid()}
+		if _, ok := s.records[ref.GetCid()]; !ok {
+			meta.Error = &corev1.RecordError{Code: uint32(codes.NotFound), Message: "record not found", Cid: ref.GetCid()}
+		}
+
+		if err := stream.Send(meta); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+func (s reorderServer) Push(stream storev1.StoreService_PushServer) error {
+	records, err := recvAll(stream.Recv)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range slices.Backward(records) {
+		if err := stream.Send(&corev1.RecordRef{Cid: record.GetCid()}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+func TestPushBatchResultsLintFindings(t *testing.T) {
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterStoreServiceServer(s, rejectingStoreServer{reject: func(record *corev1.Record) (*corev1.RecordRef, error) {
+			return &corev1.RecordRef{
+				Cid: record.GetCid(),
+				LintFindings: []*corev1.LintFinding{
+					{Severity: "warning", RuleId: "no-locators", Path: "locators", Message: "no locators declared"},
+				},
+			}, nil
+		}})
+	})
+
+	results, err := c.PushBatchResults(t.Context(), []*corev1.Record{newSizedRecord(1024)}) //nolint:mnd
+	if err != nil {
+		t.Fatalf("expected findings not to fail the push, got %v", err)
+	}
+
+	expected := lint.Finding{Severity: lint.SeverityWarning, RuleID: "no-locators", Path: "locators", Message: "no locators declared"}
+	if findings := results[0].LintFindings; len(findings) != 1 || findings[0] != expected {
+		t.Errorf("expected lint finding %v, got %v", expected, findings)
+	}
+}
+
+func TestStreamResultsOutOfOrder(t *testing.T) {
+	server := reorderServer{records: map[string]*corev1.Record{}}
+
+	var (
+		records []*corev1.Record
+		refs    []*corev1.RecordRef
+	)
+
+	for i := range 6 {
+		record := corev1.New(&typesv1alpha1.Record{
+			Name:          "reorder-agent-" + string(rune('a'+i)),
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+		})
+
+		records = append(records, record)
+		refs = append(refs, &corev1.RecordRef{Cid: record.GetCid()})
+
+		// Every third record is missing
+		if i%3 != 2 {
+			server.records[record.GetCid()] = record
+		}
+	}
+
+	c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) })
+
+	t.Run("PullStream", func(t *testing.T) {
+		result, err := c.PullStream(t.Context(), streaming.SliceToChan(t.Context(), refs))
+		if err != nil {
+			t.Fatalf("failed to create pull stream: %v", err)
+		}
+
+		seen := map[int]bool{}
+
+		for done := false; !done; {
+			select {
+			case err := <-result.ErrCh():
+				t.Fatalf("unexpected stream error: %v", err)
+			case res := <-result.ResCh():
+				seen[res.Index] = true
+
+				if res.Index%3 == 2 {
+					if !errors.Is(res.Error, ErrNotFound) {
+						t.Errorf("expected ErrNotFound at index %d, got %v", res.Index, res.Error)
+					}
+
+					continue
+				}
+
+				if res.Record.GetCid() != refs[res.Index].GetCid() {
+					t.Errorf("index %d: expected record %s, got %s", res.Index, refs[res.Index].GetCid(), res.Record.GetCid())
+				}
+			case <-result.DoneCh():
+				done = true
+			}
+		}
+
+		if len(seen) != len(refs) {
+			t.Errorf("expected results for %d distinct indexes, got %d", len(refs), len(seen))
+		}
+	})
+
+	t.Run("LookupStream", func(t *testing.T) {
+		result, err := c.LookupStream(t.Context(), streaming.SliceToChan(t.Context(), refs))
+		if err != nil {
+			t.Fatalf("failed to create lookup stream: %v", err)
+		}
+
+		seen := map[int]bool{}
+
+		for done := false; !done; {
+			select {
+			case err := <-result.ErrCh():
+				t.Fatalf("unexpected stream error: %v", err)
+			case res := <-result.ResCh():
+				seen[res.Index] = true
+
+				if missing := res.Index%3 == 2; missing != errors.Is(res.Error, ErrNotFound) {
+					t.Errorf("index %d: unexpected error %v", res.Index, res.Error)
+				}
+
+				if res.Error == nil && res.Meta.GetCid() != refs[res.Index].GetCid() {
+					t.Errorf("index %d: expected metadata of %s, got %s", res.Index, refs[res.Index].GetCid(), res.Meta.GetCid())
+				}
+			case <-result.DoneCh():
+				done = true
+			}
+		}
+
+		if len(seen) != len(refs) {
+			t.Errorf("expected results for %d distinct indexes, got %d", len(refs), len(seen))
+		}
+	})
+
+	t.Run("PushBatch", func(t *testing.T) {
+		pushed, err := c.PushBatch(t.Context(), records)
+		if err != nil {
+			t.Fatalf("failed to push records: %v", err)
+		}
+
+		if len(pushed) != len(records) {
+			t.Fatalf("expected %d refs, got %d", len(records), len(pushed))
+		}
+
+		for i, ref := range pushed {
+			if ref.GetCid() != records[i].GetCid() {
+				t.Errorf("ref %d: expected %s, got %s", i, records[i].GetCid(), ref.GetCid())
+			}
+		}
+	})
+}
+
+// pinnedDeleteServer rejects deletes of published records unless they are forced.
+type pinnedDeleteServer struct {
+	storev1.UnimplementedStoreServiceServer
+}
+
+func (pinnedDeleteServer) DeleteWithAck(stream storev1.StoreService_DeleteWithAckServer) error {
+	force := storev1.IsDeleteForce(stream.Context())
+
+	for {
+		ref, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		response := &storev1.DeleteResponse{RecordRef: ref}
+		if !force {
+			response.Error = &corev1.RecordError{Code: uint32(codes.FailedPrecondition), Message: "record is published: " + ref.GetCid()}
+		}
+
+		if err := stream.Send(response); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func TestDeleteWithForce(t *testing.T) {
+	c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, pinnedDeleteServer{}) })
+
+	ref := &corev1.RecordRef{Cid: "published-agent"}
+
+	if err := c.Delete(t.Context(), ref); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition for published record, got %v", err)
+	}
+
+	if err := c.Delete(t.Context(), ref, WithForce()); err != nil {
+		t.Errorf("expected forced delete to succeed, got %v", err)
+	}
+}
@@ -8,26 +8,103 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
+	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 var logger = logging.Logger("client")
 
-func (c *Client) Publish(ctx context.Context, req *routingv1.PublishRequest) error {
-	_, err := c.RoutingServiceClient.Publish(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to publish object: %w", err)
+// PublishOption configures a publish request.
+type PublishOption func(*routingv1.PublishRequest)
+
+// WithTTL publishes the records with announcements that expire after the TTL.
+// The server re-announces them before they expire while the records remain in its store,
+// and lets them expire once the records are deleted.
+// Publishing an already published record with a different TTL updates its announcement.
+func WithTTL(ttl time.Duration) PublishOption {
+	return func(req *routingv1.PublishRequest) {
+		req.Ttl = durationpb.New(ttl)
 	}
+}
 
-	return nil
+// WithVisibility publishes the records with the visibility. Servers list records published with
+// routingv1.Visibility_VISIBILITY_PUBLIC to callers of all namespaces, and other records only
+// to callers of the publisher's namespace, its trust domain.
+func WithVisibility(visibility routingv1.Visibility) PublishOption {
+	return func(req *routingv1.PublishRequest) {
+		req.Visibility = visibility
+	}
 }
 
+func (c *Client) Publish(ctx context.Context, req *routingv1.PublishRequest, opts ...PublishOption) error {
+	if len(opts) > 0 {
+		// Leave the caller's request untouched
+		req = proto.Clone(req).(*routingv1.PublishRequest) //nolint:forcetypeassert
+
+		for _, opt := range opts {
+			opt(req)
+		}
+	}
+
+	return c.publishWithHooks(ctx, req, func() error {
+		_, err := c.RoutingServiceClient.Publish(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to publish object: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// PublishAtomic publishes the records together, e.g. the records of a multi-agent application:
+// the server validates all records before announcing any of them, and commits their announcements
+// together, so that either all records become discoverable or none of them does.
+// Unlike Publish, it returns once the records are announced, or with the reason none of them is.
+// Options apply like for Publish, e.g. WithTTL and WithVisibility; publish hooks see a request with the references.
+func (c *Client) PublishAtomic(ctx context.Context, refs []*corev1.RecordRef, opts ...PublishOption) error {
+	req := &routingv1.PublishRequest{
+		Request: &routingv1.PublishRequest_RecordRefs{RecordRefs: &routingv1.RecordRefs{Refs: refs}},
+	}
+
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	return c.publishWithHooks(ctx, req, func() error {
+		resp, err := c.RoutingServiceClient.PublishAtomic(ctx, &routingv1.PublishAtomicRequest{
+			Refs:       req.GetRecordRefs().GetRefs(),
+			Ttl:        req.GetTtl(),
+			Visibility: req.GetVisibility(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to publish records atomically: %w", err)
+		}
+
+		logger.Debug("Published records atomically", "transaction_id", resp.GetTransactionId(), "records", len(refs))
+
+		return nil
+	})
+}
+
+// ListResult is a record listed with ListResults.
+type ListResult struct {
+	*routingv1.ListResponse
+
+	// Error is set on the last value sent before the channel is closed if listing failed,
+	// e.g. with ErrStreamStale.
+	Error error
+}
+
+// List lists the published records like ListResults, but only logs the error listing failed with.
 func (c *Client) List(ctx context.Context, req *routingv1.ListRequest) (<-chan *routingv1.ListResponse, error) {
-	stream, err := c.RoutingServiceClient.List(ctx, req)
+	results, err := c.ListResults(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create list stream: %w", err)
+		return nil, err
 	}
 
 	resCh := make(chan *routingv1.ListResponse, 100) //nolint:mnd
@@ -35,20 +112,76 @@ func (c *Client) List(ctx context.Context, req *routingv1.ListRequest) (<-chan *
 	go func() {
 		defer close(resCh)
 
+		for result := range results {
+			if result.Error != nil {
+				logger.Error("error receiving object", "error", result.Error)
+
+				return
+			}
+
+			resCh <- result.ListResponse
+		}
+	}()
+
+	return resCh, nil
+}
+
+// ListResults lists the published records matching the request.
+// With a stream stale timeout, see WithStreamStaleTimeout, the server is asked for heartbeats,
+// which are not passed on, and listing fails with ErrStreamStale if nothing is received within the timeout.
+//
+// The channel is closed once all records were sent or listing failed, see ListResult.Error.
+func (c *Client) ListResults(ctx context.Context, req *routingv1.ListRequest) (<-chan ListResult, error) {
+	if interval := heartbeatInterval(c.staleTimeout); interval != nil && req.GetHeartbeatInterval() == nil {
+		req, _ = proto.Clone(req).(*routingv1.ListRequest)
+		req.HeartbeatInterval = interval
+	}
+
+	watchdog := newStaleWatchdog(ctx, c.staleTimeout)
+
+	stream, err := c.RoutingServiceClient.List(watchdog.ctx, req)
+	if err != nil {
+		watchdog.stop()
+
+		return nil, fmt.Errorf("failed to create list stream: %w", err)
+	}
+
+	resCh := make(chan ListResult)
+
+	go func() {
+		defer close(resCh)
+		defer watchdog.stop()
+
 		for {
 			obj, err := stream.Recv()
 			if errors.Is(err, io.EOF) {
-				break
+				return
 			}
 
 			if err != nil {
-				logger.Error("error receiving object", "error", err)
+				select {
+				case resCh <- ListResult{Error: fmt.Errorf("failed to list: %w", watchdog.err(err))}:
+				case <-ctx.Done():
+				}
 
 				return
 			}
 
-			// Stream ListResponse directly (no legacy wrapper)
-			resCh <- obj
+			if obj.GetHeartbeat() {
+				watchdog.reset()
+
+				continue
+			}
+
+			watchdog.pause()
+
+			select {
+			case resCh <- ListResult{ListResponse: obj}:
+			case <-ctx.Done():
+				return
+			}
+
+			watchdog.reset()
 		}
 	}()
 
@@ -0,0 +1,51 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"google.golang.org/grpc"
+)
+
+func TestPublishWithTTL(t *testing.T) {
+	routing := &publishServer{}
+
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		routingv1.RegisterRoutingServiceServer(s, routing)
+	})
+
+	req := &routingv1.PublishRequest{
+		Request: &routingv1.PublishRequest_RecordRefs{
+			RecordRefs: &routingv1.RecordRefs{Refs: []*corev1.RecordRef{{Cid: "a"}}},
+		},
+	}
+
+	if err := c.Publish(t.Context(), req, WithTTL(6*time.Hour)); err != nil {
+		t.Fatalf("Publish() unexpected error: %v", err)
+	}
+
+	if err := c.Publish(t.Context(), req); err != nil {
+		t.Fatalf("Publish() unexpected error: %v", err)
+	}
+
+	if len(routing.ttls) != 2 { //nolint:mnd
+		t.Fatalf("expected 2 publish requests, got %d", len(routing.ttls))
+	}
+
+	if got := routing.ttls[0].AsDuration(); got != 6*time.Hour {
+		t.Errorf("expected TTL of 6h, got %v", got)
+	}
+
+	if routing.ttls[1] != nil {
+		t.Errorf("expected no TTL without WithTTL, got %v", routing.ttls[1].AsDuration())
+	}
+
+	if req.GetTtl() != nil {
+		t.Error("expected caller's request to be left untouched")
+	}
+}
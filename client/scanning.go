@@ -0,0 +1,28 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ScanFindingsFromError returns the findings reported by a push rejected because a content scan flagged the record.
+func ScanFindingsFromError(err error) ([]*corev1.ScanFinding, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		return nil, false
+	}
+
+	var findings []*corev1.ScanFinding
+
+	for _, detail := range st.Details() {
+		if finding, ok := detail.(*corev1.ScanFinding); ok {
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings, len(findings) > 0
+}
@@ -0,0 +1,35 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestScanFindingsFromError(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "record rejected by content scan").WithDetails(
+		&corev1.ScanFinding{Scanner: "secrets", Rule: "private-key", Location: "modules[0].data"},
+		&corev1.ScanFinding{Scanner: "webhook", Rule: "malware"},
+	)
+	if err != nil {
+		t.Fatalf("failed to add details: %v", err)
+	}
+
+	findings, ok := ScanFindingsFromError(st.Err())
+	if !ok || len(findings) != 2 || findings[0].GetRule() != "private-key" || findings[1].GetRule() != "malware" {
+		t.Errorf("expected findings from error details, got %v", findings)
+	}
+
+	if _, ok := ScanFindingsFromError(status.Error(codes.InvalidArgument, "record validation failed")); ok {
+		t.Error("expected no findings for errors without details")
+	}
+
+	if _, ok := ScanFindingsFromError(status.Error(codes.Internal, "failed")); ok {
+		t.Error("expected no findings for other errors")
+	}
+}
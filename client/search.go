@@ -12,13 +12,35 @@ import (
 	searchv1 "github.com/agntcy/dir/api/search/v1"
 )
 
+// SearchResult is a record found by a search.
+type SearchResult struct {
+	// CID is the CID of the record.
+	CID string
+	// Score is the relevance of the record to the free-text query of the search, higher is more relevant.
+	// Zero if the search has no query.
+	Score float64
+}
+
 func (c *Client) Search(ctx context.Context, req *searchv1.SearchRequest) (<-chan string, error) {
+	return search(ctx, c, req, (*searchv1.SearchResponse).GetRecordCid)
+}
+
+// SearchResults searches like Search, but returns the results with their relevance score.
+// Results of a search with a free-text query are sent most relevant first.
+func (c *Client) SearchResults(ctx context.Context, req *searchv1.SearchRequest) (<-chan *SearchResult, error) {
+	return search(ctx, c, req, func(resp *searchv1.SearchResponse) *SearchResult {
+		return &SearchResult{CID: resp.GetRecordCid(), Score: resp.GetScore()}
+	})
+}
+
+// search streams the search responses converted with result.
+func search[T any](ctx context.Context, c *Client, req *searchv1.SearchRequest, result func(*searchv1.SearchResponse) T) (<-chan T, error) {
 	stream, err := c.SearchServiceClient.Search(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create search stream: %w", err)
 	}
 
-	resultCh := make(chan string)
+	resultCh := make(chan T)
 
 	go func() {
 		defer close(resultCh)
@@ -36,7 +58,7 @@ func (c *Client) Search(ctx context.Context, req *searchv1.SearchRequest) (<-cha
 			}
 
 			select {
-			case resultCh <- obj.GetRecordCid():
+			case resultCh <- result(obj):
 			case <-ctx.Done():
 				logger.Error("context cancelled while receiving search response", "error", ctx.Err())
 
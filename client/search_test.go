@@ -0,0 +1,78 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"testing"
+
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	"google.golang.org/grpc"
+)
+
+// rankingServer ranks fixed results for requests with a free-text query.
+type rankingServer struct {
+	searchv1.UnimplementedSearchServiceServer
+}
+
+func (s *rankingServer) Search(req *searchv1.SearchRequest, stream searchv1.SearchService_SearchServer) error {
+	results := []*searchv1.SearchResponse{{RecordCid: "cid-a"}, {RecordCid: "cid-b"}}
+	if req.GetQuery() != "" {
+		results = []*searchv1.SearchResponse{{RecordCid: "cid-b", Score: 8}, {RecordCid: "cid-a", Score: 1}}
+	}
+
+	for _, result := range results {
+		if err := stream.Send(result); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+func TestSearchResults(t *testing.T) {
+	c := newBufconnClient(t, func(s *grpc.Server) { searchv1.RegisterSearchServiceServer(s, &rankingServer{}) })
+
+	collect := func(t *testing.T, req *searchv1.SearchRequest) []SearchResult {
+		t.Helper()
+
+		ch, err := c.SearchResults(t.Context(), req)
+		if err != nil {
+			t.Fatalf("SearchResults() unexpected error: %v", err)
+		}
+
+		var results []SearchResult
+		for result := range ch {
+			results = append(results, *result)
+		}
+
+		return results
+	}
+
+	query := "legal agent"
+
+	ranked := collect(t, &searchv1.SearchRequest{Query: &query})
+	if len(ranked) != 2 || ranked[0] != (SearchResult{CID: "cid-b", Score: 8}) || ranked[1] != (SearchResult{CID: "cid-a", Score: 1}) {
+		t.Errorf("unexpected ranked results %v", ranked)
+	}
+
+	unranked := collect(t, &searchv1.SearchRequest{})
+	if len(unranked) != 2 || unranked[0] != (SearchResult{CID: "cid-a"}) || unranked[1] != (SearchResult{CID: "cid-b"}) {
+		t.Errorf("unexpected unranked results %v", unranked)
+	}
+
+	// Search returns the CIDs only
+	ch, err := c.Search(t.Context(), &searchv1.SearchRequest{Query: &query})
+	if err != nil {
+		t.Fatalf("Search() unexpected error: %v", err)
+	}
+
+	var cids []string
+	for cid := range ch {
+		cids = append(cids, cid)
+	}
+
+	if len(cids) != 2 || cids[0] != "cid-b" {
+		t.Errorf("unexpected CIDs %v", cids)
+	}
+}
@@ -83,7 +83,8 @@ func (c *Client) SignWithOIDC(ctx context.Context, req *signv1.SignRequest) (*si
 	}
 
 	signatureObj := &signv1.Signature{
-		Signature: result.Signature,
+		Signature:   result.Signature,
+		Certificate: result.Certificate,
 		Annotations: map[string]string{
 			"payload": string(payloadBytes),
 		},
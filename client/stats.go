@@ -0,0 +1,33 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+)
+
+// RecordStats returns how often the referenced record was pulled and looked up, and when it was last accessed.
+func (c *Client) RecordStats(ctx context.Context, ref *corev1.RecordRef) (*storev1.RecordStats, error) {
+	stats, err := c.GetStats(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get record stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// TopRecordStats returns the statistics of the most used records in the given order.
+// If limit is zero, the server default is used.
+func (c *Client) TopRecordStats(ctx context.Context, order storev1.TopRecordsOrder, limit uint32) ([]*storev1.RecordStats, error) {
+	resp, err := c.TopRecords(ctx, &storev1.TopRecordsRequest{Order: order, Limit: limit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top records: %w", err)
+	}
+
+	return resp.GetRecords(), nil
+}
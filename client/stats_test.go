@@ -0,0 +1,91 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statsServer reports the statistics of two records.
+type statsServer struct {
+	storev1.UnimplementedStatsServiceServer
+}
+
+var testRecordStats = []*storev1.RecordStats{
+	{Cid: "cid-1", PullCount: 5, LastAccessedAt: "2026-01-01T00:00:00Z"},
+	{Cid: "cid-2", PullCount: 3, LookupCount: 1, LastAccessedAt: "2026-01-02T00:00:00Z"},
+}
+
+func (statsServer) GetStats(_ context.Context, ref *corev1.RecordRef) (*storev1.RecordStats, error) {
+	for _, stats := range testRecordStats {
+		if stats.GetCid() == ref.GetCid() {
+			return stats, nil
+		}
+	}
+
+	return nil, status.Error(codes.NotFound, "no stats") //nolint:wrapcheck
+}
+
+func (statsServer) TopRecords(_ context.Context, req *storev1.TopRecordsRequest) (*storev1.TopRecordsResponse, error) {
+	records := testRecordStats
+	if req.GetOrder() == storev1.TopRecordsOrder_TOP_RECORDS_ORDER_RECENT {
+		records = []*storev1.RecordStats{testRecordStats[1], testRecordStats[0]}
+	}
+
+	if limit := int(req.GetLimit()); limit > 0 && limit < len(records) {
+		records = records[:limit]
+	}
+
+	return &storev1.TopRecordsResponse{Records: records}, nil
+}
+
+func TestRecordStats(t *testing.T) {
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterStatsServiceServer(s, statsServer{})
+	})
+
+	stats, err := c.RecordStats(t.Context(), &corev1.RecordRef{Cid: "cid-2"})
+	if err != nil {
+		t.Fatalf("RecordStats() unexpected error: %v", err)
+	}
+
+	if stats.GetPullCount() != 3 || stats.GetLookupCount() != 1 {
+		t.Errorf("expected stats of cid-2, got %v", stats)
+	}
+
+	if _, err := c.RecordStats(t.Context(), &corev1.RecordRef{Cid: "cid-3"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound error, got %v", err)
+	}
+}
+
+func TestTopRecordStats(t *testing.T) {
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterStatsServiceServer(s, statsServer{})
+	})
+
+	records, err := c.TopRecordStats(t.Context(), storev1.TopRecordsOrder_TOP_RECORDS_ORDER_RECENT, 1)
+	if err != nil {
+		t.Fatalf("TopRecordStats() unexpected error: %v", err)
+	}
+
+	if len(records) != 1 || records[0].GetCid() != "cid-2" {
+		t.Errorf("expected most recently accessed cid-2, got %v", records)
+	}
+
+	records, err = c.TopRecordStats(t.Context(), storev1.TopRecordsOrder_TOP_RECORDS_ORDER_PULLS, 0)
+	if err != nil {
+		t.Fatalf("TopRecordStats() unexpected error: %v", err)
+	}
+
+	if len(records) != 2 || records[0].GetCid() != "cid-1" {
+		t.Errorf("expected most pulled cid-1 first, got %v", records)
+	}
+}
@@ -12,59 +12,208 @@ import (
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/agntcy/dir/client/streaming"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
 )
 
 // Push sends a complete record to the store and returns a record reference.
 // This is a convenience wrapper around PushBatch for single-record operations.
+// With WithSharedPushStream, the record is sent on the stream shared by concurrent Push calls instead.
 // The record must be ≤4MB as per the v1 store service specification.
 func (c *Client) Push(ctx context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
+	if c.sharedPush != nil && c.sharedPush.accepts(ctx) {
+		record, err := c.beforePush(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+
+		ref, err := c.sharedPush.push(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+
+		if ref.GetError() != nil {
+			return nil, rejected([]*corev1.RecordRef{ref})
+		}
+
+		return ref, nil
+	}
+
 	refs, err := c.PushBatch(ctx, []*corev1.Record{record})
 	if err != nil {
 		return nil, err
 	}
 
-	if len(refs) != 1 {
+	if len(refs) != 1 || refs[0] == nil {
 		return nil, errors.New("no data returned")
 	}
 
 	return refs[0], nil
 }
 
+// PushDryRun previews what pushing the record would do without storing anything:
+// the CID, the tags and annotations it would be stored with, the routing labels it would be
+// announced with, and its validation errors. The preview is computed by the same server code as a push.
+func (c *Client) PushDryRun(ctx context.Context, record *corev1.Record) (*storev1.PushPreview, error) {
+	preview, err := c.StoreServiceClient.PushDryRun(ctx, record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview push: %w", err)
+	}
+
+	return preview, nil
+}
+
 // PullStream retrieves multiple records efficiently using a single bidirectional stream.
 // This method is ideal for batch operations and takes full advantage of gRPC streaming.
 // The input channel allows you to send record refs as they become available.
-func (c *Client) PullStream(ctx context.Context, refsCh <-chan *corev1.RecordRef) (streaming.StreamResult[corev1.Record], error) {
+//
+// A result is returned for every ref in the order the server answers. Results are matched
+// to refs by CID, so PullResult.Index is the position of the ref in the input even if
+// the server answers out of order. Refs that could not be pulled are reported via
+// PullResult.Error without interrupting the stream.
+// Records rejected by the policy configured with WithPullPolicy are reported with ErrPolicyViolation.
+// Refs skipped by the BeforePull hook configured with WithHooks are reported with a HookError.
+//
+// When caching is enabled with WithCache, refs to cached records are not sent to the server.
+func (c *Client) PullStream(ctx context.Context, refsCh <-chan *corev1.RecordRef, opts ...streaming.Option) (streaming.StreamResult[PullResult], error) {
+	return c.pullStream(ctx, refsCh, c.verifyContent, opts...)
+}
+
+// pullStream is PullStream, verifying the content of the pulled records if verify is set.
+func (c *Client) pullStream(ctx context.Context, refsCh <-chan *corev1.RecordRef, verify bool, opts ...streaming.Option) (streaming.StreamResult[PullResult], error) {
+	ctx, requestID := withRequestID(ctx, c.newRequestID)
+
 	stream, err := c.StoreServiceClient.Pull(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pull stream: %w", err)
 	}
 
+	var pullStream streaming.BidiStream[corev1.RecordRef, corev1.Record] = stream
+	if c.cache != nil {
+		pullStream = newCachedStream(ctx, stream, c.cache.getRecord, c.cache.putRecord)
+	}
+
+	toResult := newPullResult
+	if policy := c.pullPolicyFor(ctx); policy != nil {
+		toResult = c.withPullPolicy(ctx, policy)
+	}
+
+	// Records are decrypted before the policy is evaluated
+	if c.encryption != nil {
+		toResult = c.withDecryption(ctx, toResult)
+	}
+
+	results := newResultStream(pullStream, pulledCID, toResult, requestID).withHooks(ctx, c.pullHooks())
+	if verify {
+		results = results.withVerification(verifyPulledContent)
+	}
+
 	//nolint:wrapcheck
-	return streaming.ProcessBidiStream(ctx, stream, refsCh)
+	return streaming.ProcessBidiStream(ctx, results, refsCh, opts...)
 }
 
 // Pull retrieves a single record from the store using its reference.
 // This is a convenience wrapper around PullBatch for single-record operations.
+// The reference may be a "name@version" record locator instead of a CID, see ResolveLocator.
+//
+// When caching is enabled with WithCache, concurrent pulls of the same CID
+// share a single request to the server.
 func (c *Client) Pull(ctx context.Context, recordRef *corev1.RecordRef) (*corev1.Record, error) {
+	recordRef, err := c.resolveRef(ctx, recordRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.cache == nil {
+		return c.pull(ctx, recordRef)
+	}
+
+	cid, ok := cacheKey(recordRef)
+	if !ok {
+		return c.pull(ctx, recordRef)
+	}
+
+	// Pulls bypassing the pull policy must not share results with pulls evaluating it
+	key := cid
+	if c.pullPolicy != nil && c.pullPolicyFor(ctx) == nil {
+		key = "unsafe:" + cid
+	}
+
+	result, err, shared := c.pulls.Do(key, func() (any, error) {
+		return c.pull(ctx, recordRef)
+	})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	record, _ := result.(*corev1.Record)
+
+	// Callers sharing a request receive their own copy of the record
+	if shared {
+		record, _ = proto.Clone(record).(*corev1.Record)
+	}
+
+	return record, nil
+}
+
+func (c *Client) pull(ctx context.Context, recordRef *corev1.RecordRef) (*corev1.Record, error) {
 	records, err := c.PullBatch(ctx, []*corev1.RecordRef{recordRef})
 	if err != nil {
 		return nil, err
 	}
 
-	if len(records) != 1 {
+	if len(records) != 1 || records[0] == nil {
 		return nil, errors.New("no data returned")
 	}
 
 	return records[0], nil
 }
 
+// applyCIDOptions sets the CID options configured with WithCIDOptions on a record to push,
+// so that its CID matches the reference returned by the server.
+func (c *Client) applyCIDOptions(record *corev1.Record) {
+	if c.cidOptions != nil && record.CIDOptions() != *c.cidOptions {
+		record.SetCIDOptions(*c.cidOptions)
+	}
+}
+
+// verifyPulledContent returns the record if its content matches the CID it was pulled by,
+// or a failure otherwise. Redacted records only need to report the CID they were pulled by.
+func verifyPulledContent(cid string, record *corev1.Record) *corev1.Record {
+	switch {
+	case record.GetError() != nil:
+		return record
+	case record.GetRedacted() && record.GetOriginalCid() == cid:
+		logger.Debug("Skipping content verification of redacted record", "cid", cid)
+
+		return record
+	case !record.GetRedacted() && record.MatchesCid(cid):
+		return record
+	}
+
+	return &corev1.Record{Error: &corev1.RecordError{
+		Code:    uint32(codes.DataLoss),
+		Message: fmt.Sprintf("record content does not match CID %s", cid),
+		Cid:     cid,
+	}}
+}
+
 // PullBatch retrieves multiple records in a single stream for efficiency.
 // This is a convenience method that accepts a slice and returns a slice,
 // built on top of the streaming implementation for consistency.
-func (c *Client) PullBatch(ctx context.Context, recordRefs []*corev1.RecordRef) ([]*corev1.Record, error) {
+// Use streaming.WithProgress to observe progress of large batches.
+//
+// Records are returned at the positions of their refs, with nil records for refs
+// that failed to pull, whose failures are reported in the error.
+func (c *Client) PullBatch(ctx context.Context, recordRefs []*corev1.RecordRef, opts ...streaming.Option) ([]*corev1.Record, error) {
+	// Report the batch size and failed refs unless overridden by the caller
+	opts = append([]streaming.Option{
+		streaming.WithTotal(len(recordRefs)),
+		streaming.WithFailure(func(result *PullResult) bool { return result.Error != nil }),
+	}, opts...)
+
 	// Use channel to communicate error safely (no race condition)
-	result, err := c.PullStream(ctx, streaming.SliceToChan(ctx, recordRefs))
+	result, err := c.PullStream(ctx, streaming.SliceToChan(ctx, recordRefs), opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -72,16 +221,22 @@ func (c *Client) PullBatch(ctx context.Context, recordRefs []*corev1.RecordRef)
 	// Check for results
 	var errs error
 
-	var metas []*corev1.Record
+	records := make([]*corev1.Record, len(recordRefs))
 
 	for {
 		select {
 		case err := <-result.ErrCh():
 			errs = errors.Join(errs, err)
 		case resp := <-result.ResCh():
-			metas = append(metas, resp)
+			if resp.Error != nil {
+				errs = errors.Join(errs, fmt.Errorf("failed to pull record at index %d: %w", resp.Index, resp.Error))
+
+				continue
+			}
+
+			records[resp.Index] = resp.Record
 		case <-result.DoneCh():
-			return metas, errs
+			return records, errs
 		}
 	}
 }
@@ -89,22 +244,169 @@ func (c *Client) PullBatch(ctx context.Context, recordRefs []*corev1.RecordRef)
 // PushStream uploads multiple records efficiently using a single bidirectional stream.
 // This method is ideal for batch operations and takes full advantage of gRPC streaming.
 // The input channel allows you to send records as they become available.
-func (c *Client) PushStream(ctx context.Context, recordsCh <-chan *corev1.Record) (streaming.StreamResult[corev1.RecordRef], error) {
-	stream, err := c.StoreServiceClient.Push(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create push stream: %w", err)
+//
+// Records skipped by the BeforePush hook configured with WithHooks are reported on the error channel
+// with a HookError, without interrupting the stream. With WithEncryption, records are encrypted before
+// they are sent, and records failing to encrypt are reported on the error channel as well. Records rejected by the server are returned
+// with RecordRef.Error set, and RecordRef.Conflict if they conflict with a stored record.
+func (c *Client) PushStream(ctx context.Context, recordsCh <-chan *corev1.Record, opts ...streaming.Option) (streaming.StreamResult[corev1.RecordRef], error) {
+	push := func(recordsCh <-chan *corev1.Record) (streaming.StreamResult[corev1.RecordRef], error) {
+		stream, err := c.StoreServiceClient.Push(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create push stream: %w", err)
+		}
+
+		//nolint:wrapcheck
+		return streaming.ProcessBidiStream(ctx, stream, recordsCh, opts...)
 	}
 
-	//nolint:wrapcheck
-	return streaming.ProcessBidiStream(ctx, stream, recordsCh)
+	if c.encryption == nil && (c.hooks == nil || (c.hooks.BeforePush == nil && c.hooks.AfterPush == nil)) {
+		return push(recordsCh)
+	}
+
+	return c.pushStreamFiltered(ctx, recordsCh, push)
 }
 
 // PushBatch sends multiple records in a single stream for efficiency.
 // This is a convenience method that accepts a slice and returns a slice,
 // built on top of the streaming implementation for consistency.
-func (c *Client) PushBatch(ctx context.Context, records []*corev1.Record) ([]*corev1.RecordRef, error) {
+// Use streaming.WithProgress to observe progress of large batches.
+//
+// When connected to multiple endpoints, a stream failing because its endpoint
+// became unavailable is re-established on another endpoint, and the records
+// that were not acknowledged yet are pushed again. Progress is reported per stream.
+// Likewise, a stream rejected with a RetryInfo detail, e.g. because it was rate limited,
+// is re-established after the requested delay, see WithMaxRetryDelay.
+//
+// Refs are returned at the positions of their records, with nil refs for records that were
+// not acknowledged, e.g. because the stream failed before. Records rejected by the server are
+// returned with their refs and reported in the error, with ErrConflict if a record with the same
+// name and version but different content is already stored.
+func (c *Client) PushBatch(ctx context.Context, records []*corev1.Record, opts ...streaming.Option) ([]*corev1.RecordRef, error) {
+	// Refs of acknowledged records by input position
+	refs := make([]*corev1.RecordRef, len(records))
+
+	for attempt, retries := 0, 0; ; {
+		var (
+			positions []int
+			remaining []*corev1.Record
+		)
+
+		for i, ref := range refs {
+			if ref == nil {
+				positions = append(positions, i)
+				remaining = append(remaining, records[i])
+			}
+		}
+
+		pushed, err := c.pushBatch(ctx, remaining, opts...)
+		for i, ref := range pushed {
+			refs[positions[i]] = ref
+		}
+
+		if err == nil || ctx.Err() != nil {
+			return refs, errors.Join(err, rejected(refs))
+		}
+
+		if delay, ok := retryDelay(ctx, err, c.maxRetryDelay); ok && retries < maxRetryAttempts {
+			logger.Warn("Push stream rejected, retrying after the delay requested by the server",
+				"error", err, "delay", delay, "remaining", len(remaining)-countNonNil(pushed))
+
+			if sleepContext(ctx, delay) != nil {
+				return refs, errors.Join(err, rejected(refs))
+			}
+
+			retries++
+
+			continue
+		}
+
+		if !isRetryable(err) || attempt >= c.pool.failovers() {
+			return refs, errors.Join(err, rejected(refs))
+		}
+
+		attempt++
+
+		logger.Warn("Push stream failed, retrying on another endpoint", "error", err, "remaining", len(remaining)-countNonNil(pushed))
+	}
+}
+
+// rejected returns the errors of the records that were acknowledged but rejected by the server,
+// e.g. because they conflict with a stored record.
+func rejected(refs []*corev1.RecordRef) error {
+	var errs error
+
+	for i, ref := range refs {
+		if ref.GetError() != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to push record at index %d: %w", i, recordError(ref.GetError())))
+		}
+	}
+
+	return errs
+}
+
+func countNonNil(refs []*corev1.RecordRef) int {
+	count := 0
+
+	for _, ref := range refs {
+		if ref != nil {
+			count++
+		}
+	}
+
+	return count
+}
+
+// PushBatchResults pushes records like PushBatch, reporting for each pushed record
+// whether it was already stored. Pushing is idempotent, records that already exist
+// are not uploaded again, which makes re-pushing large unchanged sets cheap.
+// On failure, results for the acknowledged records are returned with the error, with the Index of their record.
+// All streams of the batch, including retries on other endpoints, use the same request ID.
+func (c *Client) PushBatchResults(ctx context.Context, records []*corev1.Record, opts ...streaming.Option) ([]*PushResult, error) {
+	ctx, requestID := withRequestID(ctx, c.newRequestID)
+
+	refs, err := c.PushBatch(ctx, records, opts...)
+
+	results := make([]*PushResult, 0, len(refs))
+	existing := 0
+
+	for index, ref := range refs {
+		if ref == nil {
+			continue
+		}
+
+		result := newPushResult(index, ref)
+		result.RequestID = requestID
+		if result.AlreadyExisted {
+			existing++
+		}
+
+		results = append(results, result)
+	}
+
+	logger.Debug("Pushed records", "request_id", requestID, "pushed", len(results), "new", len(results)-existing, "existing", existing)
+
+	return results, err
+}
+
+// pushBatch pushes the records on a single stream and returns their refs by input position,
+// with nil refs for records that were not acknowledged. Refs are matched to records by CID,
+// so positions are correct even if the server acknowledges records out of order.
+func (c *Client) pushBatch(ctx context.Context, records []*corev1.Record, opts ...streaming.Option) ([]*corev1.RecordRef, error) {
+	// Report the batch size and rejected records unless overridden by the caller
+	opts = append([]streaming.Option{
+		streaming.WithTotal(len(records)),
+		streaming.WithFailure(func(ref *corev1.RecordRef) bool { return ref.GetError() != nil }),
+	}, opts...)
+
+	pending := newInflight()
+	for _, record := range records {
+		c.applyCIDOptions(record)
+		pending.add(record.GetCid())
+	}
+
 	// Use channel to communicate error safely (no race condition)
-	result, err := c.PushStream(ctx, streaming.SliceToChan(ctx, records))
+	result, err := c.PushStream(ctx, streaming.SliceToChan(ctx, records), opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -112,14 +414,16 @@ func (c *Client) PushBatch(ctx context.Context, records []*corev1.Record) ([]*co
 	// Check for results
 	var errs error
 
-	var refs []*corev1.RecordRef
+	refs := make([]*corev1.RecordRef, len(records))
 
 	for {
 		select {
 		case err := <-result.ErrCh():
 			errs = errors.Join(errs, err)
 		case resp := <-result.ResCh():
-			refs = append(refs, resp)
+			if index := pending.match(resp.GetCid); index >= 0 {
+				refs[index] = resp
+			}
 		case <-result.DoneCh():
 			return refs, errs
 		}
@@ -202,13 +506,19 @@ func (c *Client) PullReferrer(ctx context.Context, req *storev1.PullReferrerRequ
 }
 
 // Lookup retrieves metadata for a record using its reference.
+// The reference may be a "name@version" record locator instead of a CID, see ResolveLocator.
 func (c *Client) Lookup(ctx context.Context, recordRef *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	recordRef, err := c.resolveRef(ctx, recordRef)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := c.LookupBatch(ctx, []*corev1.RecordRef{recordRef})
 	if err != nil {
 		return nil, err
 	}
 
-	if len(resp) != 1 {
+	if len(resp) != 1 || resp[0] == nil {
 		return nil, errors.New("no data returned")
 	}
 
@@ -216,9 +526,18 @@ func (c *Client) Lookup(ctx context.Context, recordRef *corev1.RecordRef) (*core
 }
 
 // LookupBatch retrieves metadata for multiple records in a single stream for efficiency.
-func (c *Client) LookupBatch(ctx context.Context, recordRefs []*corev1.RecordRef) ([]*corev1.RecordMeta, error) {
+// Metadata is returned at the positions of the refs, with nil metadata for refs
+// that failed to look up, whose failures are reported in the error.
+// Use streaming.WithProgress to observe progress of large batches.
+func (c *Client) LookupBatch(ctx context.Context, recordRefs []*corev1.RecordRef, opts ...streaming.Option) ([]*corev1.RecordMeta, error) {
+	// Report the batch size and failed refs unless overridden by the caller
+	opts = append([]streaming.Option{
+		streaming.WithTotal(len(recordRefs)),
+		streaming.WithFailure(func(result *LookupResult) bool { return result.Error != nil }),
+	}, opts...)
+
 	// Use channel to communicate error safely (no race condition)
-	result, err := c.LookupStream(ctx, streaming.SliceToChan(ctx, recordRefs))
+	result, err := c.LookupStream(ctx, streaming.SliceToChan(ctx, recordRefs), opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -226,14 +545,20 @@ func (c *Client) LookupBatch(ctx context.Context, recordRefs []*corev1.RecordRef
 	// Check for results
 	var errs error
 
-	var metas []*corev1.RecordMeta
+	metas := make([]*corev1.RecordMeta, len(recordRefs))
 
 	for {
 		select {
 		case err := <-result.ErrCh():
 			errs = errors.Join(errs, err)
 		case resp := <-result.ResCh():
-			metas = append(metas, resp)
+			if resp.Error != nil {
+				errs = errors.Join(errs, fmt.Errorf("failed to lookup record at index %d: %w", resp.Index, resp.Error))
+
+				continue
+			}
+
+			metas[resp.Index] = resp.Meta
 		case <-result.DoneCh():
 			return metas, errs
 		}
@@ -244,25 +569,72 @@ func (c *Client) LookupBatch(ctx context.Context, recordRefs []*corev1.RecordRef
 // Record references are sent as they become available and metadata is returned as it's processed.
 // This method maintains a single gRPC stream for all operations, dramatically improving efficiency.
 //
-// Uses sequential streaming pattern (Send → Recv → Send → Recv) which ensures
-// strict ordering of request-response pairs.
-func (c *Client) LookupStream(ctx context.Context, refsCh <-chan *corev1.RecordRef) (streaming.StreamResult[corev1.RecordMeta], error) {
+// A result is returned for every ref in the order the server answers. Results are matched
+// to refs by CID, so LookupResult.Index is the position of the ref in the input even if
+// the server answers out of order. Refs that could not be resolved are reported via
+// LookupResult.Error without interrupting the stream.
+//
+// When caching is enabled with WithCache, metadata of recently looked up CIDs is served from cache.
+func (c *Client) LookupStream(ctx context.Context, refsCh <-chan *corev1.RecordRef, opts ...streaming.Option) (streaming.StreamResult[LookupResult], error) {
+	ctx, requestID := withRequestID(ctx, c.newRequestID)
+
 	stream, err := c.StoreServiceClient.Lookup(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create lookup stream: %w", err)
 	}
 
+	var lookupStream streaming.BidiStream[corev1.RecordRef, corev1.RecordMeta] = stream
+	if c.cache != nil {
+		lookupStream = newCachedStream(ctx, stream, c.cache.getMeta, c.cache.putMeta)
+	}
+
+	results := newResultStream(lookupStream, lookedUpCID, newLookupResult, requestID).withHooks(ctx, c.lookupHooks())
+
 	//nolint:wrapcheck
-	return streaming.ProcessBidiStream(ctx, stream, refsCh)
+	return streaming.ProcessBidiStream(ctx, results, refsCh, opts...)
+}
+
+// DeleteOption configures a delete request.
+type DeleteOption func(*deleteOptions)
+
+type deleteOptions struct {
+	force   bool
+	confirm bool
+}
+
+// WithForce deletes records even if they are published, unpublishing them first.
+// Without it, deleting a published record fails with FailedPrecondition.
+func WithForce() DeleteOption {
+	return func(opts *deleteOptions) {
+		opts.force = true
+	}
 }
 
 // Delete removes a record from the store using its reference.
-func (c *Client) Delete(ctx context.Context, recordRef *corev1.RecordRef) error {
-	return c.DeleteBatch(ctx, []*corev1.RecordRef{recordRef})
+// The reference may be a "name@version" record locator instead of a CID, see ResolveLocator.
+// Published records are pinned and can only be deleted WithForce.
+func (c *Client) Delete(ctx context.Context, recordRef *corev1.RecordRef, opts ...DeleteOption) error {
+	recordRef, err := c.resolveRef(ctx, recordRef)
+	if err != nil {
+		return err
+	}
+
+	return c.DeleteBatch(ctx, []*corev1.RecordRef{recordRef}, opts...)
 }
 
 // DeleteBatch removes multiple records from the store in a single stream for efficiency.
-func (c *Client) DeleteBatch(ctx context.Context, recordRefs []*corev1.RecordRef) error {
+// All records are processed, and failures for individual records are joined into the returned error.
+// Published records are pinned and can only be deleted WithForce.
+func (c *Client) DeleteBatch(ctx context.Context, recordRefs []*corev1.RecordRef, opts ...DeleteOption) error {
+	options := &deleteOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.force {
+		ctx = storev1.ContextWithDeleteForce(ctx)
+	}
+
 	// Use channel to communicate error safely (no race condition)
 	result, err := c.DeleteStream(ctx, streaming.SliceToChan(ctx, recordRefs))
 	if err != nil {
@@ -270,15 +642,18 @@ func (c *Client) DeleteBatch(ctx context.Context, recordRefs []*corev1.RecordRef
 	}
 
 	// Check for results
+	var errs error
+
 	for {
 		select {
 		case err := <-result.ErrCh():
-			// If any error occurs, return immediately
-			return err
-		case <-result.ResCh():
-			// We don't expect any results, just confirmations
+			errs = errors.Join(errs, err)
+		case resp := <-result.ResCh():
+			if resp.Error != nil {
+				errs = errors.Join(errs, fmt.Errorf("failed to delete record at index %d: %w", resp.Index, resp.Error))
+			}
 		case <-result.DoneCh():
-			return nil
+			return errs
 		}
 	}
 }
@@ -286,13 +661,35 @@ func (c *Client) DeleteBatch(ctx context.Context, recordRefs []*corev1.RecordRef
 // DeleteStream provides efficient streaming delete operations using channels.
 // Record references are sent as they become available and delete confirmations are returned as they're processed.
 // This method maintains a single gRPC stream for all operations, dramatically improving efficiency.
-func (c *Client) DeleteStream(ctx context.Context, refsCh <-chan *corev1.RecordRef) (streaming.StreamResult[emptypb.Empty], error) {
+//
+// A result is returned for every ref once the server has processed it. Results are matched
+// to refs by CID, so DeleteResult.Index is the position of the ref in the input.
+// Refs that could not be deleted are reported via DeleteResult.Error without interrupting the stream.
+// Published records are pinned, use storev1.ContextWithDeleteForce to unpublish and delete them.
+func (c *Client) DeleteStream(ctx context.Context, refsCh <-chan *corev1.RecordRef) (streaming.StreamResult[DeleteResult], error) {
+	ctx, requestID := withRequestID(ctx, c.newRequestID)
+
 	// Create gRPC stream
-	stream, err := c.StoreServiceClient.Delete(ctx)
+	stream, err := c.StoreServiceClient.DeleteWithAck(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create delete stream: %w", err)
 	}
 
+	toResult := newDeleteResult
+	if c.cache != nil {
+		// Deleted records must not be served from cache
+		toResult = func(index int, resp *storev1.DeleteResponse) *DeleteResult {
+			result := newDeleteResult(index, resp)
+			if result.Error == nil || errors.Is(result.Error, ErrNotFound) {
+				c.cache.evict(result.Ref.GetCid())
+			}
+
+			return result
+		}
+	}
+
+	results := newResultStream(stream, deletedCID, toResult, requestID).withHooks(ctx, c.deleteHooks())
+
 	//nolint:wrapcheck
-	return streaming.ProcessClientStream(ctx, stream, refsCh)
+	return streaming.ProcessBidiStream(ctx, results, refsCh)
 }
@@ -0,0 +1,60 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// previewServer previews pushes without storing anything.
+type previewServer struct {
+	storev1.UnimplementedStoreServiceServer
+}
+
+func (previewServer) PushDryRun(_ context.Context, record *corev1.Record) (*storev1.PushPreview, error) {
+	if record.GetData() == nil {
+		return nil, status.Error(codes.InvalidArgument, "record is required")
+	}
+
+	return &storev1.PushPreview{
+		Cid:  record.GetCid(),
+		Tags: record.DiscoveryTags(),
+	}, nil
+}
+
+func TestPushDryRun(t *testing.T) {
+	c := newBufconnClient(t, func(s *grpc.Server) {
+		storev1.RegisterStoreServiceServer(s, previewServer{})
+	})
+
+	record, err := corev1.UnmarshalRecord([]byte(`{"name":"my-agent","version":"v1.0.0","schema_version":"0.7.0"}`))
+	if err != nil {
+		t.Fatalf("failed to create record: %v", err)
+	}
+
+	preview, err := c.PushDryRun(t.Context(), record)
+	if err != nil {
+		t.Fatalf("PushDryRun() unexpected error: %v", err)
+	}
+
+	if preview.GetCid() != record.GetCid() {
+		t.Errorf("expected CID %s, got %s", record.GetCid(), preview.GetCid())
+	}
+
+	if len(preview.GetTags()) != 3 {
+		t.Errorf("expected CID and name tags, got %v", preview.GetTags())
+	}
+
+	_, err = c.PushDryRun(t.Context(), &corev1.Record{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument status to be preserved, got %v", err)
+	}
+}
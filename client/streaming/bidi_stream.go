@@ -41,6 +41,8 @@ type BidiStream[InT, OutT any] interface {
 //   - Responses can arrive in any order or timing
 //   - Network latency is significant
 //
+// Options can be used to observe progress of the stream, see WithProgress.
+//
 // Returns:
 //   - result: StreamResult containing result, error, and done channels
 //   - error: Immediate error if validation fails
@@ -53,6 +55,7 @@ func ProcessBidiStream[InT, OutT any](
 	ctx context.Context,
 	stream BidiStream[InT, OutT],
 	inputCh <-chan *InT,
+	opts ...Option,
 ) (StreamResult[OutT], error) {
 	// Validate inputs
 	if ctx == nil {
@@ -70,11 +73,17 @@ func ProcessBidiStream[InT, OutT any](
 	// Create result channels
 	result := newResult[OutT]()
 
+	// Start progress reporting if requested
+	reporter := newProgressReporter(newOptions(opts...))
+
 	// Start goroutines
 	go func() {
 		// Close result once the goroutine ends
 		defer result.close()
 
+		// Emit final progress before signalling completion
+		defer reporter.stop()
+
 		// WaitGroup to coordinate send/receive goroutines
 		var wg sync.WaitGroup
 
@@ -101,10 +110,14 @@ func ProcessBidiStream[InT, OutT any](
 			// which terminates this goroutine.
 			for input := range inputCh {
 				if err := stream.Send(input); err != nil {
+					reporter.failure()
+
 					result.errCh <- fmt.Errorf("failed to send: %w", err)
 
 					return
 				}
+
+				reporter.sent(input)
 			}
 		}()
 
@@ -129,11 +142,15 @@ func ProcessBidiStream[InT, OutT any](
 				}
 
 				if err != nil {
+					reporter.failure()
+
 					result.errCh <- fmt.Errorf("failed to receive: %w", err)
 
 					return
 				}
 
+				reporter.received(output)
+
 				// Send output to the output channel
 				result.resCh <- output
 			}
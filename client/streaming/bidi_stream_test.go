@@ -0,0 +1,184 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package streaming
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client/clienttest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newFakeStore returns a fake store service holding n records and the references to them.
+func newFakeStore(t *testing.T, n int) (*clienttest.FakeStoreService, []*corev1.RecordRef) {
+	t.Helper()
+
+	fake := clienttest.NewFakeStoreService()
+	t.Cleanup(func() { _ = fake.Close() })
+
+	refs := make([]*corev1.RecordRef, n)
+
+	for i := range refs {
+		record := corev1.New(&typesv1alpha1.Record{
+			Name:          fmt.Sprintf("agent-%d", i),
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+		})
+
+		fake.AddRecords(record)
+		refs[i] = &corev1.RecordRef{Cid: record.GetCid()}
+	}
+
+	return fake, refs
+}
+
+// collect returns the results and errors of the stream once it is done.
+func collect[T any](t *testing.T, result StreamResult[T]) ([]*T, []error) {
+	t.Helper()
+
+	var (
+		outputs []*T
+		errs    []error
+	)
+
+	for {
+		select {
+		case output := <-result.ResCh():
+			outputs = append(outputs, output)
+		case err := <-result.ErrCh():
+			errs = append(errs, err)
+		case <-result.DoneCh():
+			return outputs, errs
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for stream to complete")
+		}
+	}
+}
+
+// pulledCIDs pulls the references from the fake and returns the CIDs of the received records.
+func pulledCIDs(ctx context.Context, t *testing.T, fake *clienttest.FakeStoreService, refs []*corev1.RecordRef) ([]string, []error) {
+	t.Helper()
+
+	stream, err := fake.Pull(ctx)
+	if err != nil {
+		t.Fatalf("failed to open pull stream: %v", err)
+	}
+
+	result, err := ProcessBidiStream(ctx, stream, SliceToChan(ctx, refs))
+	if err != nil {
+		t.Fatalf("failed to process stream: %v", err)
+	}
+
+	records, errs := collect(t, result)
+
+	cids := make([]string, 0, len(records))
+	for _, record := range records {
+		cids = append(cids, record.GetCid())
+	}
+
+	return cids, errs
+}
+
+func TestProcessBidiStreamOutOfOrder(t *testing.T) {
+	fake, refs := newFakeStore(t, 5)
+	fake.SetOutOfOrder(true)
+
+	cids, errs := pulledCIDs(t.Context(), t, fake, refs)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected stream errors: %v", errs)
+	}
+
+	want := make([]string, 0, len(refs))
+	for _, ref := range slices.Backward(refs) {
+		want = append(want, ref.GetCid())
+	}
+
+	if !slices.Equal(cids, want) {
+		t.Errorf("pulled %v, want %v", cids, want)
+	}
+}
+
+func TestProcessBidiStreamRecordError(t *testing.T) {
+	fake, refs := newFakeStore(t, 3)
+	fake.SetError(refs[1].GetCid(), status.Error(codes.PermissionDenied, "denied"))
+
+	stream, err := fake.Pull(t.Context())
+	if err != nil {
+		t.Fatalf("failed to open pull stream: %v", err)
+	}
+
+	result, err := ProcessBidiStream(t.Context(), stream, SliceToChan(t.Context(), refs))
+	if err != nil {
+		t.Fatalf("failed to process stream: %v", err)
+	}
+
+	records, errs := collect(t, result)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected stream errors: %v", errs)
+	}
+
+	// Failures of single references are reported in their response without interrupting the stream
+	if len(records) != len(refs) {
+		t.Fatalf("received %d records, want %d", len(records), len(refs))
+	}
+
+	if code := codes.Code(records[1].GetError().GetCode()); code != codes.PermissionDenied {
+		t.Errorf("record error code = %v, want PermissionDenied", code)
+	}
+
+	if records[1].GetError().GetCid() != refs[1].GetCid() {
+		t.Errorf("record error CID = %s, want %s", records[1].GetError().GetCid(), refs[1].GetCid())
+	}
+}
+
+func TestProcessBidiStreamFailure(t *testing.T) {
+	fake, refs := newFakeStore(t, 5)
+	fake.FailAt(storev1.StoreService_Pull_FullMethodName, 3, status.Error(codes.Unavailable, "connection lost"))
+
+	cids, errs := pulledCIDs(t.Context(), t, fake, refs)
+
+	if len(cids) != 2 {
+		t.Errorf("pulled %d records before the failure, want 2", len(cids))
+	}
+
+	if len(errs) != 1 || status.Code(errs[0]) != codes.Unavailable {
+		t.Fatalf("stream errors = %v, want a single Unavailable error", errs)
+	}
+
+	// The failure is injected once, so that the stream can be retried
+	cids, errs = pulledCIDs(t.Context(), t, fake, refs)
+	if len(errs) != 0 || len(cids) != len(refs) {
+		t.Errorf("retry pulled %d records with errors %v, want %d records", len(cids), errs, len(refs))
+	}
+
+	if got := fake.Messages(storev1.StoreService_Pull_FullMethodName); got != 3+len(refs) {
+		t.Errorf("server received %d requests, want %d", got, 3+len(refs))
+	}
+}
+
+func TestProcessBidiStreamCanceled(t *testing.T) {
+	fake, refs := newFakeStore(t, 3)
+	fake.SetLatency(time.Minute)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+
+	cids, errs := pulledCIDs(ctx, t, fake, refs)
+
+	if len(cids) != 0 {
+		t.Errorf("pulled %v, want no records", cids)
+	}
+
+	if !slices.ContainsFunc(errs, func(err error) bool { return status.Code(err) == codes.DeadlineExceeded }) {
+		t.Errorf("stream errors = %v, want DeadlineExceeded", errs)
+	}
+}
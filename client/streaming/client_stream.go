@@ -29,6 +29,8 @@ type ClientStream[InT, OutT any] interface {
 //   - Closes the send side when input channel closes
 //   - Receives the final response via CloseAndRecv()
 //
+// Options can be used to observe progress of the stream, see WithProgress.
+//
 // Returns:
 //   - result: StreamResult containing result, error, and done channels
 //   - error: Immediate error if validation fails
@@ -41,6 +43,7 @@ func ProcessClientStream[InT, OutT any](
 	ctx context.Context,
 	stream ClientStream[InT, OutT],
 	inputCh <-chan *InT,
+	opts ...Option,
 ) (StreamResult[OutT], error) {
 	// Validate inputs
 	if ctx == nil {
@@ -58,11 +61,17 @@ func ProcessClientStream[InT, OutT any](
 	// Create result channels
 	result := newResult[OutT]()
 
+	// Start progress reporting if requested
+	reporter := newProgressReporter(newOptions(opts...))
+
 	// Process items
 	go func() {
 		// Close result once the goroutine ends
 		defer result.close()
 
+		// Emit final progress before signalling completion
+		defer reporter.stop()
+
 		// Close the send side when done sending inputs
 		//nolint:errcheck
 		defer stream.CloseSend()
@@ -71,21 +80,29 @@ func ProcessClientStream[InT, OutT any](
 		for input := range inputCh {
 			// Send the input to the network buffer and handle errors
 			if err := stream.Send(input); err != nil {
+				reporter.failure()
+
 				result.errCh <- fmt.Errorf("failed to send: %w", err)
 
 				return
 			}
+
+			reporter.sent(input)
 		}
 
 		// Once the channel is closed, send the data through the stream and exit.
 		// Handle any errors using the error handler function.
 		resp, err := stream.CloseAndRecv()
 		if err != nil {
+			reporter.failure()
+
 			result.errCh <- fmt.Errorf("failed to receive final response: %w", err)
 
 			return
 		}
 
+		reporter.received(resp)
+
 		// Send the final response to the output channel
 		result.resCh <- resp
 	}()
@@ -0,0 +1,96 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package streaming
+
+import (
+	"testing"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client/clienttest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProcessClientStream(t *testing.T) {
+	fake, refs := newFakeStore(t, 3)
+
+	stream, err := fake.Delete(t.Context())
+	if err != nil {
+		t.Fatalf("failed to open delete stream: %v", err)
+	}
+
+	result, err := ProcessClientStream(t.Context(), stream, SliceToChan(t.Context(), refs))
+	if err != nil {
+		t.Fatalf("failed to process stream: %v", err)
+	}
+
+	responses, errs := collect(t, result)
+	if len(errs) != 0 || len(responses) != 1 {
+		t.Fatalf("received %d responses with errors %v, want a single response", len(responses), errs)
+	}
+
+	for _, ref := range refs {
+		if _, ok := fake.Record(ref.GetCid()); ok {
+			t.Errorf("record %s was not deleted", ref.GetCid())
+		}
+	}
+}
+
+func TestProcessClientStreamFailure(t *testing.T) {
+	tests := []struct {
+		name     string
+		program  func(fake *clienttest.FakeStoreService, cid string)
+		wantCode codes.Code
+	}{
+		{
+			name: "injected at message",
+			program: func(fake *clienttest.FakeStoreService, _ string) {
+				fake.FailAt(storev1.StoreService_Delete_FullMethodName, 2, status.Error(codes.Internal, "storage failure"))
+			},
+			wantCode: codes.Internal,
+		},
+		{
+			name: "per CID",
+			program: func(fake *clienttest.FakeStoreService, cid string) {
+				fake.SetError(cid, status.Error(codes.FailedPrecondition, "record is pinned"))
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, refs := newFakeStore(t, 3)
+			tt.program(fake, refs[1].GetCid())
+
+			stream, err := fake.Delete(t.Context())
+			if err != nil {
+				t.Fatalf("failed to open delete stream: %v", err)
+			}
+
+			result, err := ProcessClientStream(t.Context(), stream, SliceToChan(t.Context(), refs))
+			if err != nil {
+				t.Fatalf("failed to process stream: %v", err)
+			}
+
+			responses, errs := collect(t, result)
+			if len(responses) != 0 {
+				t.Errorf("received %d responses, want none", len(responses))
+			}
+
+			if len(errs) != 1 || status.Code(errs[0]) != tt.wantCode {
+				t.Errorf("stream errors = %v, want a single %v error", errs, tt.wantCode)
+			}
+
+			// References before the failing one were handled
+			if _, ok := fake.Record(refs[0].GetCid()); ok {
+				t.Errorf("record %s was not deleted", refs[0].GetCid())
+			}
+
+			if _, ok := fake.Record(refs[2].GetCid()); !ok {
+				t.Errorf("record %s was deleted after the failure", refs[2].GetCid())
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package streaming
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/protobuf/proto"
+)
+
+var logger = logging.Logger("client/streaming")
+
+// DefaultProgressInterval is the default interval at which progress is reported.
+const DefaultProgressInterval = 500 * time.Millisecond
+
+// Progress describes the state of a long-running stream operation.
+type Progress struct {
+	// Completed is the number of inputs processed successfully.
+	Completed int
+	// Failed is the number of errors reported by the stream,
+	// including outputs classified as failures with WithFailure.
+	Failed int
+	// Total is the number of expected inputs, or 0 if unknown.
+	Total int
+	// BytesSent is the serialized size of all inputs sent so far.
+	BytesSent int64
+	// Elapsed is the time since the stream was started.
+	Elapsed time.Duration
+}
+
+// ProgressFunc is called with the current progress of a stream operation.
+type ProgressFunc func(Progress)
+
+// Option configures stream processing.
+type Option func(*options)
+
+type options struct {
+	progressFn       ProgressFunc
+	progressInterval time.Duration
+	progressEvery    int
+	total            int
+	isFailure        func(output any) bool
+}
+
+// WithProgress registers a callback that receives progress updates.
+// The callback is always invoked from a single goroutine, and once more
+// with the final totals when the stream completes.
+// A slow callback never blocks the stream; intermediate updates are coalesced instead.
+func WithProgress(fn ProgressFunc) Option {
+	return func(o *options) {
+		o.progressFn = fn
+	}
+}
+
+// WithProgressInterval sets how often progress is reported.
+// Defaults to DefaultProgressInterval.
+func WithProgressInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.progressInterval = interval
+	}
+}
+
+// WithProgressEvery additionally reports progress after every n results.
+func WithProgressEvery(n int) Option {
+	return func(o *options) {
+		o.progressEvery = n
+	}
+}
+
+// WithTotal sets the number of expected inputs reported in Progress.Total.
+func WithTotal(total int) Option {
+	return func(o *options) {
+		o.total = total
+	}
+}
+
+// WithFailure counts received outputs for which isFailure returns true in Progress.Failed
+// instead of Progress.Completed, e.g. responses that carry the error of a single input.
+// The outputs are still returned on the result channel.
+func WithFailure[OutT any](isFailure func(*OutT) bool) Option {
+	return func(o *options) {
+		o.isFailure = func(output any) bool {
+			out, ok := output.(*OutT)
+
+			return ok && isFailure(out)
+		}
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		progressInterval: DefaultProgressInterval,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.progressInterval <= 0 {
+		o.progressInterval = DefaultProgressInterval
+	}
+
+	return o
+}
+
+// progressReporter tracks stream progress and invokes the progress callback.
+// Recording methods are safe to call from any goroutine and never block.
+type progressReporter struct {
+	opts  *options
+	start time.Time
+
+	completed atomic.Int64
+	failed    atomic.Int64
+	bytesSent atomic.Int64
+
+	notifyCh chan struct{}
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+}
+
+// newProgressReporter starts a reporter. It returns nil if no callback is configured.
+func newProgressReporter(opts *options) *progressReporter {
+	if opts.progressFn == nil {
+		return nil
+	}
+
+	r := &progressReporter{
+		opts:     opts,
+		start:    time.Now(),
+		notifyCh: make(chan struct{}, 1),
+		stopCh:   make(chan struct{}),
+	}
+
+	r.wg.Add(1)
+
+	go r.run()
+
+	return r
+}
+
+func (r *progressReporter) run() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.opts.progressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.report()
+		case <-r.notifyCh:
+			r.report()
+		case <-r.stopCh:
+			r.report()
+
+			return
+		}
+	}
+}
+
+// report invokes the callback, recovering from panics so a faulty callback cannot kill the stream.
+func (r *progressReporter) report() {
+	defer func() {
+		if err := recover(); err != nil {
+			logger.Error("progress callback panicked", "error", err)
+		}
+	}()
+
+	r.opts.progressFn(r.snapshot())
+}
+
+func (r *progressReporter) snapshot() Progress {
+	return Progress{
+		Completed: int(r.completed.Load()),
+		Failed:    int(r.failed.Load()),
+		Total:     r.opts.total,
+		BytesSent: r.bytesSent.Load(),
+		Elapsed:   time.Since(r.start),
+	}
+}
+
+func (r *progressReporter) sent(input any) {
+	if r == nil {
+		return
+	}
+
+	if msg, ok := input.(proto.Message); ok {
+		r.bytesSent.Add(int64(proto.Size(msg)))
+	}
+}
+
+// received records a received output as a success, or as a failure if WithFailure classifies it as one.
+func (r *progressReporter) received(output any) {
+	if r == nil {
+		return
+	}
+
+	if r.opts.isFailure != nil && r.opts.isFailure(output) {
+		r.failure()
+
+		return
+	}
+
+	r.success()
+}
+
+func (r *progressReporter) success() {
+	if r == nil {
+		return
+	}
+
+	completed := r.completed.Add(1)
+	if r.opts.progressEvery > 0 && completed%int64(r.opts.progressEvery) == 0 {
+		r.notify()
+	}
+}
+
+func (r *progressReporter) failure() {
+	if r == nil {
+		return
+	}
+
+	r.failed.Add(1)
+	r.notify()
+}
+
+// notify requests a report without blocking. Pending requests are coalesced.
+func (r *progressReporter) notify() {
+	select {
+	case r.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// stop emits the final progress and waits for the reporter goroutine to exit.
+func (r *progressReporter) stop() {
+	if r == nil {
+		return
+	}
+
+	close(r.stopCh)
+	r.wg.Wait()
+}
@@ -0,0 +1,165 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package streaming
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client/clienttest"
+)
+
+// newPullStream opens a pull stream to a fake store service.
+func newPullStream(t *testing.T) storev1.StoreService_PullClient {
+	t.Helper()
+
+	fake := clienttest.NewFakeStoreService()
+	t.Cleanup(func() { _ = fake.Close() })
+
+	stream, err := fake.Pull(t.Context())
+	if err != nil {
+		t.Fatalf("failed to open pull stream: %v", err)
+	}
+
+	return stream
+}
+
+func drain[T any](t *testing.T, result StreamResult[T]) int {
+	t.Helper()
+
+	count := 0
+
+	for {
+		select {
+		case <-result.ResCh():
+			count++
+		case err := <-result.ErrCh():
+			t.Fatalf("unexpected stream error: %v", err)
+		case <-result.DoneCh():
+			return count
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for stream to complete")
+		}
+	}
+}
+
+func testRefs(n int) []*corev1.RecordRef {
+	refs := make([]*corev1.RecordRef, n)
+	for i := range refs {
+		refs[i] = &corev1.RecordRef{Cid: "cid"}
+	}
+
+	return refs
+}
+
+func TestProgressFinalTotals(t *testing.T) {
+	const total = 50
+
+	var (
+		mu   sync.Mutex
+		last Progress
+		n    int
+	)
+
+	ctx := t.Context()
+
+	result, err := ProcessBidiStream(ctx, newPullStream(t), SliceToChan(ctx, testRefs(total)),
+		WithTotal(total),
+		WithProgressEvery(10),
+		WithProgress(func(p Progress) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			last = p
+			n++
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to process stream: %v", err)
+	}
+
+	results := drain(t, result)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if n == 0 {
+		t.Fatal("progress callback was never invoked")
+	}
+
+	if last.Completed != results || last.Completed != total {
+		t.Errorf("expected %d completed, got %d (results: %d)", total, last.Completed, results)
+	}
+
+	if last.Total != total {
+		t.Errorf("expected total %d, got %d", total, last.Total)
+	}
+
+	if last.Failed != 0 {
+		t.Errorf("expected no failures, got %d", last.Failed)
+	}
+
+	if last.BytesSent <= 0 {
+		t.Errorf("expected bytes sent to be tracked, got %d", last.BytesSent)
+	}
+}
+
+func TestProgressFailures(t *testing.T) {
+	const total = 10
+
+	var (
+		mu   sync.Mutex
+		last Progress
+	)
+
+	ctx := t.Context()
+
+	// The fake store has no records, so every ref is answered with a per-record error
+	result, err := ProcessBidiStream(ctx, newPullStream(t), SliceToChan(ctx, testRefs(total)),
+		WithFailure(func(record *corev1.Record) bool { return record.GetError() != nil }),
+		WithProgress(func(p Progress) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			last = p
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to process stream: %v", err)
+	}
+
+	if results := drain(t, result); results != total {
+		t.Errorf("expected %d results, got %d", total, results)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if last.Failed != total || last.Completed != 0 {
+		t.Errorf("expected %d failed and none completed, got %d failed and %d completed", total, last.Failed, last.Completed)
+	}
+}
+
+func TestProgressPanickingCallback(t *testing.T) {
+	const total = 20
+
+	ctx := t.Context()
+
+	result, err := ProcessBidiStream(ctx, newPullStream(t), SliceToChan(ctx, testRefs(total)),
+		WithProgressEvery(1),
+		WithProgress(func(Progress) {
+			panic("boom")
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to process stream: %v", err)
+	}
+
+	if results := drain(t, result); results != total {
+		t.Errorf("expected %d results, got %d", total, results)
+	}
+}
@@ -8,15 +8,41 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	storev1 "github.com/agntcy/dir/api/store/v1"
 )
 
-func (c *Client) CreateSync(ctx context.Context, remoteURL string, cids []string) (string, error) {
-	meta, err := c.SyncServiceClient.CreateSync(ctx, &storev1.CreateSyncRequest{
+// SyncOptions configures which records a sync copies from the remote Directory and how often.
+type SyncOptions struct {
+	// CIDs to synchronize. If empty, all records are synchronized.
+	CIDs []string
+
+	// Labels only synchronizes records with a matching label, e.g. "/skills/natural_language_processing".
+	// Labels nested under a filter also match.
+	Labels []string
+
+	// Interval at which the sync is repeated, with second precision.
+	// If zero, the sync runs once.
+	Interval time.Duration
+}
+
+// CreateSync creates a sync from the remote Directory and returns its ID.
+// Syncs with labels or an interval pull records through the remote store API,
+// their progress is reported by GetSync.
+func (c *Client) CreateSync(ctx context.Context, remoteURL string, opts SyncOptions) (string, error) {
+	req := &storev1.CreateSyncRequest{
 		RemoteDirectoryUrl: remoteURL,
-		Cids:               cids,
-	})
+		Cids:               opts.CIDs,
+		Labels:             opts.Labels,
+	}
+
+	if opts.Interval > 0 {
+		interval := uint32(opts.Interval / time.Second) //nolint:gosec
+		req.IntervalSeconds = &interval
+	}
+
+	meta, err := c.SyncServiceClient.CreateSync(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("failed to create sync: %w", err)
 	}
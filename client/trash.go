@@ -0,0 +1,61 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+)
+
+// TrashedRecords returns the records deleted on a server in soft deletion mode, most recently deleted first.
+// Trashed records can be pulled and looked up with a context from storev1.ContextWithIncludeDeleted.
+func (c *Client) TrashedRecords(ctx context.Context) ([]*storev1.TrashedRecord, error) {
+	stream, err := c.ListTrash(ctx, &storev1.ListTrashRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trash: %w", err)
+	}
+
+	var records []*storev1.TrashedRecord
+
+	for {
+		record, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to list trash: %w", err)
+		}
+
+		records = append(records, record)
+	}
+}
+
+// RestoreRecord reinstates a record from the trash before its retention expires and returns its metadata.
+func (c *Client) RestoreRecord(ctx context.Context, recordRef *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	meta, err := c.Restore(ctx, recordRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore record: %w", err)
+	}
+
+	return meta, nil
+}
+
+// PurgeRecord permanently deletes a record from the trash.
+func (c *Client) PurgeRecord(ctx context.Context, recordRef *corev1.RecordRef) error {
+	if _, err := c.Purge(ctx, recordRef); err != nil {
+		return fmt.Errorf("failed to purge record: %w", err)
+	}
+
+	if c.cache != nil {
+		c.cache.evict(recordRef.GetCid())
+	}
+
+	return nil
+}
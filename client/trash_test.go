@@ -0,0 +1,89 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// trashServer holds a single trashed record.
+type trashServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	trashed map[string]bool
+}
+
+func (s *trashServer) ListTrash(_ *storev1.ListTrashRequest, stream storev1.StoreService_ListTrashServer) error {
+	for cid := range s.trashed {
+		if err := stream.Send(&storev1.TrashedRecord{Cid: cid}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+func (s *trashServer) Restore(_ context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	if !s.trashed[ref.GetCid()] {
+		return nil, status.Errorf(codes.NotFound, "record %s is not in the trash", ref.GetCid())
+	}
+
+	delete(s.trashed, ref.GetCid())
+
+	return &corev1.RecordMeta{Cid: ref.GetCid()}, nil
+}
+
+func (s *trashServer) Purge(_ context.Context, ref *corev1.RecordRef) (*emptypb.Empty, error) {
+	if !s.trashed[ref.GetCid()] {
+		return nil, status.Errorf(codes.FailedPrecondition, "record %s is not in the trash", ref.GetCid())
+	}
+
+	delete(s.trashed, ref.GetCid())
+
+	return &emptypb.Empty{}, nil
+}
+
+func TestTrash(t *testing.T) {
+	server := &trashServer{trashed: map[string]bool{"cid-1": true, "cid-2": true}}
+	c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) })
+
+	records, err := c.TrashedRecords(t.Context())
+	if err != nil {
+		t.Fatalf("TrashedRecords() error = %v", err)
+	}
+
+	if len(records) != 2 {
+		t.Errorf("TrashedRecords() = %v, want 2 records", records)
+	}
+
+	meta, err := c.RestoreRecord(t.Context(), &corev1.RecordRef{Cid: "cid-1"})
+	if err != nil {
+		t.Fatalf("RestoreRecord() error = %v", err)
+	}
+
+	if meta.GetCid() != "cid-1" {
+		t.Errorf("RestoreRecord() = %v, want the metadata of cid-1", meta)
+	}
+
+	if err := c.PurgeRecord(t.Context(), &corev1.RecordRef{Cid: "cid-2"}); err != nil {
+		t.Fatalf("PurgeRecord() error = %v", err)
+	}
+
+	// Records are no longer in the trash once restored or purged
+	if _, err := c.RestoreRecord(t.Context(), &corev1.RecordRef{Cid: "cid-2"}); status.Code(err) != codes.NotFound {
+		t.Errorf("RestoreRecord() error = %v, want NotFound", err)
+	}
+
+	if err := c.PurgeRecord(t.Context(), &corev1.RecordRef{Cid: "cid-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("PurgeRecord() error = %v, want FailedPrecondition", err)
+	}
+}
@@ -51,14 +51,9 @@ func (c *Client) verifyClientSide(ctx context.Context, recordCID string) (bool,
 	logger.Debug("Starting client-side verification", "recordCID", recordCID)
 
 	// Generate the expected payload for this record CID
-	digest, err := corev1.ConvertCIDToDigest(recordCID)
-	if err != nil {
-		return false, fmt.Errorf("failed to convert CID to digest: %w", err)
-	}
-
-	expectedPayload, err := cosignutils.GeneratePayload(digest.String())
+	expectedPayload, err := expectedSignaturePayload(recordCID)
 	if err != nil {
-		return false, fmt.Errorf("failed to generate expected payload: %w", err)
+		return false, err
 	}
 
 	// Retrieve signature from OCI referrers
@@ -84,25 +79,7 @@ func (c *Client) verifyClientSide(ctx context.Context, recordCID string) (bool,
 	// Compare all public keys with all signatures
 	for _, publicKey := range publicKeys {
 		for _, signature := range signatures {
-			// Verify signature using cosign
-			verifier, err := sigs.LoadPublicKeyRaw([]byte(publicKey), crypto.SHA256)
-			if err != nil {
-				// Skip this public key if it's invalid, try the next one
-				logger.Debug("Failed to load public key, skipping", "error", err)
-
-				continue
-			}
-
-			// Decode base64 signature if needed
-			signatureBytes, err := base64.StdEncoding.DecodeString(signature.GetSignature())
-			if err != nil {
-				// If decoding fails, assume it's already raw bytes
-				signatureBytes = []byte(signature.GetSignature())
-			}
-
-			// Verify signature against the expected payload
-			err = verifier.VerifySignature(bytes.NewReader(signatureBytes), bytes.NewReader(expectedPayload))
-			if err != nil {
+			if err := verifySignatureWithKey(publicKey, signature, expectedPayload); err != nil {
 				// Verification failed for this combination, try the next one
 				logger.Debug("Signature verification failed, trying next combination", "error", err)
 
@@ -117,6 +94,47 @@ func (c *Client) verifyClientSide(ctx context.Context, recordCID string) (bool,
 	return false, nil
 }
 
+// expectedSignaturePayload returns the payload signed for the record with the given CID.
+func expectedSignaturePayload(recordCID string) ([]byte, error) {
+	digest, err := corev1.ConvertCIDToDigest(recordCID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert CID to digest: %w", err)
+	}
+
+	payload, err := cosignutils.GeneratePayload(digest.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate expected payload: %w", err)
+	}
+
+	return payload, nil
+}
+
+// verifySignatureWithKey verifies a record signature over the payload with a PEM-encoded public key.
+func verifySignatureWithKey(publicKey string, signature *signv1.Signature, payload []byte) error {
+	verifier, err := sigs.LoadPublicKeyRaw([]byte(publicKey), crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("failed to load public key: %w", err)
+	}
+
+	if err := verifier.VerifySignature(bytes.NewReader(decodeSignature(signature)), bytes.NewReader(payload)); err != nil {
+		return fmt.Errorf("failed to verify signature: %w", err)
+	}
+
+	return nil
+}
+
+// decodeSignature returns the raw signature bytes.
+func decodeSignature(signature *signv1.Signature) []byte {
+	// Decode base64 signature if needed
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature.GetSignature())
+	if err != nil {
+		// If decoding fails, assume it's already raw bytes
+		return []byte(signature.GetSignature())
+	}
+
+	return signatureBytes
+}
+
 // pullSignatureReferrer retrieves the signature referrer for a record.
 func (c *Client) pullSignatureReferrer(ctx context.Context, recordCID string) ([]*signv1.Signature, error) {
 	signatureType := corev1.SignatureReferrerType
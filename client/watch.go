@@ -0,0 +1,156 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Delays between attempts to resume an interrupted store watch, doubling with every failed attempt.
+const (
+	minWatchRetryDelay = 100 * time.Millisecond
+	maxWatchRetryDelay = 30 * time.Second
+)
+
+// StoreEvent is a change of the server store received with WatchStore.
+type StoreEvent struct {
+	*storev1.StoreEvent
+
+	// Error is set on the last value sent before the channel is closed if the watch cannot be resumed,
+	// e.g. with OutOfRange if the server no longer retains the events to resume from.
+	Error error
+}
+
+// WatchStore streams the changes of the server store with a sequence number greater than fromSeq,
+// oldest first, and keeps streaming new changes until the context is done.
+//
+// Interrupted watches, e.g. by a connection failure or the server disconnecting a watcher that fell
+// behind, are resumed from the sequence number of the last received event, so that events are neither
+// lost nor duplicated. Consumers can persist the sequence numbers of the events they handled to resume
+// watching after a restart. Events of the GAP type report changes the server did not record; those
+// and watches failing with OutOfRange require a rescan of the store.
+//
+// With a stream stale timeout, see WithStreamStaleTimeout, the server is asked for heartbeats,
+// which are not passed on, and watches receiving nothing within the timeout fail with ErrStreamStale.
+//
+// The channel is closed once the context is done or the watch failed, see StoreEvent.Error.
+func (c *Client) WatchStore(ctx context.Context, fromSeq uint64) (<-chan StoreEvent, error) {
+	watch := func(from uint64) (*staleWatchdog, storev1.StoreService_WatchStoreClient, error) {
+		watchdog := newStaleWatchdog(ctx, c.staleTimeout)
+
+		stream, err := c.StoreServiceClient.WatchStore(watchdog.ctx, &storev1.WatchStoreRequest{
+			FromSequence:      from,
+			HeartbeatInterval: heartbeatInterval(c.staleTimeout),
+		})
+		if err != nil {
+			watchdog.stop()
+		}
+
+		return watchdog, stream, err //nolint:wrapcheck
+	}
+
+	watchdog, stream, err := watch(fromSeq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch store: %w", err)
+	}
+
+	eventCh := make(chan StoreEvent)
+
+	go func() {
+		defer close(eventCh)
+		defer func() { watchdog.stop() }()
+
+		last := fromSeq
+		delay := minWatchRetryDelay
+
+		for {
+			var event *storev1.StoreEvent
+
+			if stream == nil {
+				watchdog.stop()
+
+				watchdog, stream, err = watch(last)
+			}
+
+			if err == nil {
+				event, err = stream.Recv()
+				if err != nil {
+					err = watchdog.err(err)
+				}
+			}
+
+			if err == nil {
+				// Heartbeats and events already received before the watch was resumed are skipped
+				if event.GetType() == storev1.StoreEventType_STORE_EVENT_TYPE_HEARTBEAT || event.GetSequence() <= last {
+					watchdog.reset()
+
+					continue
+				}
+
+				watchdog.pause()
+
+				select {
+				case eventCh <- StoreEvent{StoreEvent: event}:
+				case <-ctx.Done():
+					return
+				}
+
+				watchdog.reset()
+
+				last = event.GetSequence()
+				delay = minWatchRetryDelay
+
+				continue
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			if !isWatchResumable(err) {
+				select {
+				case eventCh <- StoreEvent{Error: fmt.Errorf("failed to watch store: %w", err)}:
+				case <-ctx.Done():
+				}
+
+				return
+			}
+
+			logger.Warn("Store watch interrupted, resuming", "error", err, "sequence", last, "delay", delay)
+
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return
+			}
+
+			stream, err = nil, nil
+			delay = min(delay*2, maxWatchRetryDelay) //nolint:mnd
+		}
+	}()
+
+	return eventCh, nil
+}
+
+// isWatchResumable reports whether a store watch that failed with the error can be resumed.
+func isWatchResumable(err error) bool {
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+
+	switch status.Code(err) { //nolint:exhaustive
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchServer streams numbered events, interrupting the first watch after interruptAfter events
+// with the interruptCode status.
+type watchServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	events         int
+	interruptAfter int
+	interruptCode  codes.Code
+
+	mu    sync.Mutex
+	froms []uint64
+}
+
+func (s *watchServer) WatchStore(req *storev1.WatchStoreRequest, stream storev1.StoreService_WatchStoreServer) error {
+	s.mu.Lock()
+	s.froms = append(s.froms, req.GetFromSequence())
+	first := len(s.froms) == 1
+	s.mu.Unlock()
+
+	for seq := req.GetFromSequence() + 1; seq <= uint64(s.events); seq++ {
+		if first && seq > uint64(s.interruptAfter) {
+			return status.Error(s.interruptCode, "watch interrupted")
+		}
+
+		if err := stream.Send(&storev1.StoreEvent{Sequence: seq, Type: storev1.StoreEventType_STORE_EVENT_TYPE_PUSHED}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	<-stream.Context().Done()
+
+	return nil
+}
+
+func (s *watchServer) fromSequences() []uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]uint64(nil), s.froms...)
+}
+
+func TestWatchStoreResumes(t *testing.T) {
+	for _, code := range []codes.Code{codes.Unavailable, codes.ResourceExhausted} {
+		t.Run(code.String(), func(t *testing.T) {
+			server := &watchServer{events: 10, interruptAfter: 4, interruptCode: code}
+			c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) })
+
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+
+			eventCh, err := c.WatchStore(ctx, 2)
+			if err != nil {
+				t.Fatalf("WatchStore() error = %v", err)
+			}
+
+			// Events after the requested sequence number must be received once each, in order
+			for want := uint64(3); want <= 10; want++ {
+				event := <-eventCh
+				if event.Error != nil {
+					t.Fatalf("event error = %v", event.Error)
+				}
+
+				if event.GetSequence() != want {
+					t.Fatalf("event sequence = %d, want %d", event.GetSequence(), want)
+				}
+			}
+
+			if froms := server.fromSequences(); len(froms) != 2 || froms[0] != 2 || froms[1] != 4 {
+				t.Errorf("watches started from %v, want [2 4]", froms)
+			}
+
+			cancel()
+
+			for event := range eventCh {
+				t.Errorf("unexpected event after cancel: %v", event)
+			}
+		})
+	}
+}
+
+func TestWatchStoreFails(t *testing.T) {
+	server := &watchServer{events: 5, interruptAfter: 2, interruptCode: codes.OutOfRange}
+	c := newBufconnClient(t, func(s *grpc.Server) { storev1.RegisterStoreServiceServer(s, server) })
+
+	eventCh, err := c.WatchStore(t.Context(), 0)
+	if err != nil {
+		t.Fatalf("WatchStore() error = %v", err)
+	}
+
+	var (
+		sequences []uint64
+		lastErr   error
+	)
+
+	for event := range eventCh {
+		if event.Error != nil {
+			lastErr = event.Error
+
+			continue
+		}
+
+		sequences = append(sequences, event.GetSequence())
+	}
+
+	if len(sequences) != 2 {
+		t.Errorf("received %v, want the events before the failure", sequences)
+	}
+
+	if status.Code(lastErr) != codes.OutOfRange {
+		t.Errorf("error = %v, want OutOfRange", lastErr)
+	}
+
+	if froms := server.fromSequences(); len(froms) != 1 {
+		t.Errorf("watches started from %v, want a single watch", froms)
+	}
+}
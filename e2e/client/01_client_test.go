@@ -16,6 +16,8 @@ import (
 	"github.com/agntcy/dir/e2e/shared/utils"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // convertLabelsToRecordQueries converts legacy label format to RecordQuery format for e2e tests.
@@ -154,6 +156,17 @@ var _ = ginkgo.Describe("Running client end-to-end tests using a local single no
 				time.Sleep(15 * time.Second)
 			})
 
+			// Step 3b: Published records are protected from deletion (depends on publish)
+			ginkgo.It("should reject deleting a published record", func() {
+				meta, err := c.Lookup(ctx, recordRef)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(meta.GetPinned()).To(gomega.BeTrue())
+				gomega.Expect(meta.GetPublicationLabels()).To(gomega.ContainElements(version.expectedSkillLabels))
+
+				err = c.Delete(ctx, recordRef)
+				gomega.Expect(status.Code(err)).To(gomega.Equal(codes.FailedPrecondition))
+			})
+
 			// Step 4: List by one label (depends on publish)
 			ginkgo.It("should list published record by one label", func() {
 				// Convert skill label to RecordQuery
@@ -285,7 +298,12 @@ var _ = ginkgo.Describe("Running client end-to-end tests using a local single no
 
 			// Step 9: Delete (depends on previous steps)
 			ginkgo.It("should delete a record from store", func() {
-				err := c.Delete(ctx, recordRef)
+				// Unpublishing released the pin of the record
+				meta, err := c.Lookup(ctx, recordRef)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(meta.GetPinned()).To(gomega.BeFalse())
+
+				err = c.Delete(ctx, recordRef)
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			})
 
@@ -298,6 +316,12 @@ var _ = ginkgo.Describe("Running client end-to-end tests using a local single no
 				gomega.Expect(err).To(gomega.HaveOccurred())
 				gomega.Expect(pulledRecord).To(gomega.BeNil())
 			})
+
+			// Step 11: Verify deleting a missing record fails (depends on delete)
+			ginkgo.It("should fail to delete a record that no longer exists", func() {
+				err := c.Delete(ctx, recordRef)
+				gomega.Expect(err).To(gomega.MatchError(client.ErrNotFound))
+			})
 		})
 	}
 })
@@ -0,0 +1,80 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/e2e/shared/config"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Running client record lineage end-to-end tests", ginkgo.Ordered, ginkgo.Serial, func() {
+	ginkgo.BeforeEach(func() {
+		if cfg.DeploymentMode != config.DeploymentModeLocal {
+			ginkgo.Skip("Skipping test, not in local mode")
+		}
+	})
+
+	ctx := context.Background()
+
+	// Create a new client
+	c, err := client.New(client.WithEnvConfig())
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	defer c.Close()
+
+	const versions = 3
+
+	var refs []*corev1.RecordRef
+
+	ginkgo.AfterAll(func() {
+		for _, ref := range refs {
+			_ = c.Delete(ctx, ref)
+		}
+	})
+
+	ginkgo.It("should push chained record versions", func() {
+		for i := range versions {
+			record := corev1.New(&typesv1alpha1.Record{
+				Name:          "e2e-lineage-agent",
+				Version:       fmt.Sprintf("v%d.0.0", i+1),
+				SchemaVersion: "0.7.0",
+				Description:   "Record lineage test agent",
+			})
+
+			var ref *corev1.RecordRef
+
+			if len(refs) == 0 {
+				ref, err = c.Push(ctx, record)
+			} else {
+				ref, err = c.PushUpdate(ctx, record, refs[len(refs)-1])
+			}
+
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			refs = append(refs, ref)
+		}
+	})
+
+	ginkgo.It("should return the history newest-first", func() {
+		gomega.Expect(refs).To(gomega.HaveLen(versions))
+
+		history, err := c.History(ctx, refs[len(refs)-1])
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(history).To(gomega.HaveLen(versions))
+
+		for i, meta := range history {
+			expected := refs[len(refs)-1-i]
+			gomega.Expect(meta.GetCid()).To(gomega.Equal(expected.GetCid()))
+		}
+
+		// Oldest version has no predecessor
+		gomega.Expect(history[len(history)-1].GetPreviousCid()).To(gomega.BeEmpty())
+	})
+})
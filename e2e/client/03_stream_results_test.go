@@ -0,0 +1,183 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/client/streaming"
+	"github.com/agntcy/dir/e2e/shared/config"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Running client per-record stream results end-to-end tests", ginkgo.Ordered, ginkgo.Serial, func() {
+	ginkgo.BeforeEach(func() {
+		// Streaming does not depend on the deployment, so it is also tested against an embedded server
+		if cfg.DeploymentMode != config.DeploymentModeLocal && cfg.DeploymentMode != config.DeploymentModeEmbedded {
+			ginkgo.Skip("Skipping test, not in local or embedded mode")
+		}
+	})
+
+	ctx := context.Background()
+
+	// Create a new client
+	c, err := client.New(client.WithEnvConfig())
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	defer c.Close()
+
+	const count = 6
+
+	// Every odd reference points to a record that was never pushed
+	var (
+		refs   []*corev1.RecordRef
+		pushed []*corev1.RecordRef
+	)
+
+	newRecord := func(i int) *corev1.Record {
+		return corev1.New(&typesv1alpha1.Record{
+			Name:          fmt.Sprintf("e2e-stream-results-agent-%d", i),
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+			Description:   "Stream results test agent",
+		})
+	}
+
+	isBogus := func(i int) bool { return i%2 == 1 }
+
+	ginkgo.AfterAll(func() {
+		for _, ref := range pushed {
+			_ = c.Delete(ctx, ref)
+		}
+	})
+
+	ginkgo.It("should push the valid records", func() {
+		for i := range count {
+			record := newRecord(i)
+
+			if isBogus(i) {
+				refs = append(refs, &corev1.RecordRef{Cid: record.GetCid()})
+
+				continue
+			}
+
+			ref, err := c.Push(ctx, record)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			refs = append(refs, ref)
+			pushed = append(pushed, ref)
+		}
+	})
+
+	ginkgo.It("should report missing records in lookup stream results", func() {
+		result, err := c.LookupStream(ctx, streaming.SliceToChan(ctx, refs))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		var results []*client.LookupResult
+
+		for done := false; !done; {
+			select {
+			case err := <-result.ErrCh():
+				ginkgo.Fail(fmt.Sprintf("unexpected stream error: %v", err))
+			case res := <-result.ResCh():
+				results = append(results, res)
+			case <-result.DoneCh():
+				done = true
+			}
+		}
+
+		gomega.Expect(results).To(gomega.HaveLen(count))
+
+		for i, res := range results {
+			gomega.Expect(res.Index).To(gomega.Equal(i))
+
+			if isBogus(i) {
+				gomega.Expect(res.Error).To(gomega.MatchError(client.ErrNotFound))
+
+				continue
+			}
+
+			gomega.Expect(res.Error).NotTo(gomega.HaveOccurred())
+			gomega.Expect(res.Meta.GetCid()).To(gomega.Equal(refs[i].GetCid()))
+		}
+	})
+
+	ginkgo.It("should report missing records in pull stream results", func() {
+		result, err := c.PullStream(ctx, streaming.SliceToChan(ctx, refs))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		var results []*client.PullResult
+
+		for done := false; !done; {
+			select {
+			case err := <-result.ErrCh():
+				ginkgo.Fail(fmt.Sprintf("unexpected stream error: %v", err))
+			case res := <-result.ResCh():
+				results = append(results, res)
+			case <-result.DoneCh():
+				done = true
+			}
+		}
+
+		gomega.Expect(results).To(gomega.HaveLen(count))
+
+		for i, res := range results {
+			gomega.Expect(res.Index).To(gomega.Equal(i))
+
+			if isBogus(i) {
+				gomega.Expect(res.Error).To(gomega.MatchError(client.ErrNotFound))
+
+				continue
+			}
+
+			gomega.Expect(res.Error).NotTo(gomega.HaveOccurred())
+			gomega.Expect(res.Record.GetCid()).To(gomega.Equal(refs[i].GetCid()))
+		}
+	})
+
+	ginkgo.It("should report missing records in delete stream results", func() {
+		result, err := c.DeleteStream(ctx, streaming.SliceToChan(ctx, refs))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		var results []*client.DeleteResult
+
+		for done := false; !done; {
+			select {
+			case err := <-result.ErrCh():
+				ginkgo.Fail(fmt.Sprintf("unexpected stream error: %v", err))
+			case res := <-result.ResCh():
+				results = append(results, res)
+			case <-result.DoneCh():
+				done = true
+			}
+		}
+
+		gomega.Expect(results).To(gomega.HaveLen(count))
+
+		for i, res := range results {
+			gomega.Expect(res.Index).To(gomega.Equal(i))
+			gomega.Expect(res.Ref.GetCid()).To(gomega.Equal(refs[i].GetCid()))
+
+			if isBogus(i) {
+				gomega.Expect(res.Error).To(gomega.MatchError(client.ErrNotFound))
+
+				continue
+			}
+
+			gomega.Expect(res.Error).NotTo(gomega.HaveOccurred())
+		}
+
+		// Valid records are gone and no longer need cleanup
+		for _, ref := range pushed {
+			_, err := c.Lookup(ctx, ref)
+			gomega.Expect(err).To(gomega.MatchError(client.ErrNotFound))
+		}
+
+		pushed = nil
+	})
+})
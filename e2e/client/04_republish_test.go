@@ -0,0 +1,135 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/e2e/shared/config"
+	"github.com/agntcy/dir/e2e/shared/utils"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Running client republish end-to-end tests", ginkgo.Ordered, ginkgo.Serial, func() {
+	ginkgo.BeforeEach(func() {
+		if cfg.DeploymentMode != config.DeploymentModeLocal {
+			ginkgo.Skip("Skipping test, not in local mode")
+		}
+	})
+
+	ctx := context.Background()
+
+	// Create a new client
+	c, err := client.New(client.WithEnvConfig())
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	defer c.Close()
+
+	const count = 3
+
+	var refs []*corev1.RecordRef
+
+	ours := func(meta *corev1.RecordMeta) bool {
+		for _, ref := range refs {
+			if ref.GetCid() == meta.GetCid() {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	listedCids := func() []string {
+		itemsChan, err := c.List(ctx, &routingv1.ListRequest{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		var cids []string
+		for _, item := range utils.CollectListItems(itemsChan) {
+			cids = append(cids, item.GetRecordRef().GetCid())
+		}
+
+		return cids
+	}
+
+	refCids := func() []string {
+		cids := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			cids = append(cids, ref.GetCid())
+		}
+
+		return cids
+	}
+
+	ginkgo.AfterAll(func() {
+		for _, ref := range refs {
+			_ = c.Delete(ctx, ref, client.WithForce())
+		}
+	})
+
+	ginkgo.It("should push and publish the records", func() {
+		for i := range count {
+			ref, err := c.Push(ctx, corev1.New(&typesv1alpha1.Record{
+				Name:          fmt.Sprintf("e2e-republish-agent-%d", i),
+				Version:       "v1.0.0",
+				SchemaVersion: "0.7.0",
+				Description:   "Republish test agent",
+				Skills: []*typesv1alpha1.Skill{
+					{Name: "natural_language_processing/natural_language_understanding"},
+				},
+			}))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			refs = append(refs, ref)
+		}
+
+		err := c.Publish(ctx, &routingv1.PublishRequest{
+			Request: &routingv1.PublishRequest_RecordRefs{
+				RecordRefs: &routingv1.RecordRefs{Refs: refs},
+			},
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		gomega.Eventually(listedCids, 30*time.Second, time.Second).Should(gomega.ContainElements(refCids()))
+	})
+
+	ginkgo.It("should not list the records after the routing state is wiped", func() {
+		// Drop the local routing state of the records, as a node wipe would
+		err := c.Unpublish(ctx, &routingv1.UnpublishRequest{
+			Request: &routingv1.UnpublishRequest_RecordRefs{
+				RecordRefs: &routingv1.RecordRefs{Refs: refs},
+			},
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		listed := listedCids()
+		for _, cid := range refCids() {
+			gomega.Expect(listed).NotTo(gomega.ContainElement(cid))
+		}
+	})
+
+	ginkgo.It("should republish the records", func() {
+		results, err := c.RepublishAll(ctx, ours)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		var republished []string
+
+		for result := range results {
+			gomega.Expect(result.Error).NotTo(gomega.HaveOccurred())
+
+			republished = append(republished, result.Ref.GetCid())
+		}
+
+		gomega.Expect(republished).To(gomega.ConsistOf(refCids()))
+	})
+
+	ginkgo.It("should list the republished records again", func() {
+		gomega.Eventually(listedCids, 30*time.Second, time.Second).Should(gomega.ContainElements(refCids()))
+	})
+})
@@ -0,0 +1,78 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/e2e/shared/config"
+	"github.com/agntcy/dir/e2e/shared/testdata"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+// The read-only client connects without credentials, as a caller outside of the trust domain of the server.
+// It must be able to use the methods the server allows for external callers, and nothing else.
+var _ = ginkgo.Describe("Running client end-to-end tests with an external read-only identity", ginkgo.Ordered, func() {
+	ginkgo.BeforeEach(func() {
+		if cfg.DeploymentMode != config.DeploymentModeLocal {
+			ginkgo.Skip("Skipping test, not in local mode")
+		}
+	})
+
+	ctx := context.Background()
+
+	// Records are pushed by an identity of the trust domain
+	c, err := client.New(client.WithEnvConfig())
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	clientConfig, err := client.LoadConfig()
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	readOnly, err := client.NewReadOnly(clientConfig.ServerAddress)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	record, err := corev1.UnmarshalRecord(testdata.ExpectedRecordV070JSON)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	var ref *corev1.RecordRef
+
+	ginkgo.AfterAll(func() {
+		if ref != nil {
+			_ = c.Delete(ctx, ref)
+		}
+
+		_ = readOnly.Close()
+		_ = c.Close()
+	})
+
+	ginkgo.It("should push the record with the trust domain identity", func() {
+		ref, err = c.Push(ctx, record)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("should pull and lookup the record", func() {
+		pulled, err := readOnly.Pull(ctx, ref)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(pulled.GetCid()).To(gomega.Equal(ref.GetCid()))
+
+		meta, err := readOnly.Lookup(ctx, ref)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(meta.GetCid()).To(gomega.Equal(ref.GetCid()))
+	})
+
+	ginkgo.It("should fail to push and delete with a typed error", func() {
+		_, err := readOnly.Push(ctx, record)
+		gomega.Expect(err).To(gomega.MatchError(client.ErrReadOnlyClient))
+
+		err = readOnly.Delete(ctx, ref)
+		gomega.Expect(err).To(gomega.MatchError(client.ErrReadOnlyClient))
+
+		// The record is still stored
+		_, err = c.Lookup(ctx, ref)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+})
@@ -0,0 +1,118 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"time"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/e2e/shared/config"
+	"github.com/agntcy/dir/e2e/shared/utils"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = ginkgo.Describe("Running client end-to-end tests for deleting published records", ginkgo.Ordered, ginkgo.Serial, func() {
+	ginkgo.BeforeEach(func() {
+		if cfg.DeploymentMode != config.DeploymentModeLocal {
+			ginkgo.Skip("Skipping test, not in local mode")
+		}
+	})
+
+	ctx := context.Background()
+
+	// Create a new client
+	c, err := client.New(client.WithEnvConfig())
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	var ref *corev1.RecordRef
+
+	const skill = "natural_language_processing/natural_language_understanding"
+
+	listedCids := func() []string {
+		items, err := c.List(ctx, &routingv1.ListRequest{
+			Queries: []*routingv1.RecordQuery{
+				{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: skill},
+			},
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		var cids []string
+		for _, item := range utils.CollectListItems(items) {
+			cids = append(cids, item.GetRecordRef().GetCid())
+		}
+
+		return cids
+	}
+
+	ginkgo.AfterAll(func() {
+		if ref != nil {
+			_ = c.Delete(ctx, ref, client.WithForce())
+		}
+
+		_ = c.Close()
+	})
+
+	ginkgo.It("should push and publish the record", func() {
+		ref, err = c.Push(ctx, corev1.New(&typesv1alpha1.Record{
+			Name:          "e2e-pinned-delete-agent",
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+			Description:   "Pinned delete test agent",
+			Skills: []*typesv1alpha1.Skill{
+				{Name: skill},
+			},
+		}))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		err = c.Publish(ctx, &routingv1.PublishRequest{
+			Request: &routingv1.PublishRequest_RecordRefs{
+				RecordRefs: &routingv1.RecordRefs{Refs: []*corev1.RecordRef{ref}},
+			},
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		gomega.Eventually(listedCids, 30*time.Second, time.Second).Should(gomega.ContainElement(ref.GetCid()))
+	})
+
+	ginkgo.It("should report the record as pinned", func() {
+		gomega.Eventually(func() bool {
+			meta, err := c.Lookup(ctx, ref)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			return meta.GetPinned()
+		}, 30*time.Second, time.Second).Should(gomega.BeTrue())
+
+		meta, err := c.Lookup(ctx, ref)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(meta.GetPublicationLabels()).To(gomega.ContainElement("/skills/" + skill))
+	})
+
+	ginkgo.It("should reject deleting the published record", func() {
+		err := c.Delete(ctx, ref)
+		gomega.Expect(status.Code(err)).To(gomega.Equal(codes.FailedPrecondition))
+
+		// The record is still stored and published
+		_, err = c.Lookup(ctx, ref)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(listedCids()).To(gomega.ContainElement(ref.GetCid()))
+	})
+
+	ginkgo.It("should force delete the record and its routing entries", func() {
+		err := c.Delete(ctx, ref, client.WithForce())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		_, err = c.Lookup(ctx, ref)
+		gomega.Expect(err).To(gomega.MatchError(client.ErrNotFound))
+		gomega.Expect(listedCids()).NotTo(gomega.ContainElement(ref.GetCid()))
+
+		ref = nil
+	})
+})
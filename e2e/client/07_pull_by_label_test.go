@@ -0,0 +1,123 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/e2e/shared/config"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Running client end-to-end tests for pulling records by label", ginkgo.Ordered, ginkgo.Serial, func() {
+	ginkgo.BeforeEach(func() {
+		if cfg.DeploymentMode != config.DeploymentModeLocal {
+			ginkgo.Skip("Skipping test, not in local mode")
+		}
+	})
+
+	ctx := context.Background()
+
+	// Create a new client
+	c, err := client.New(client.WithEnvConfig())
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	const (
+		firstSkill  = "natural_language_processing/natural_language_generation/text_completion"
+		secondSkill = "natural_language_processing/summarization"
+	)
+
+	labels := []string{"/skills/" + firstSkill, "/skills/" + secondSkill}
+
+	var refs []*corev1.RecordRef
+
+	// pulledCounts pulls the records by label and counts the pulls of each record
+	pulledCounts := func(opts ...client.PullByLabelOption) map[string]int {
+		resCh, err := c.PullByLabel(ctx, labels, opts...)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		counts := map[string]int{}
+
+		for res := range resCh {
+			gomega.Expect(res.Error).NotTo(gomega.HaveOccurred())
+
+			counts[res.Record.GetCid()]++
+		}
+
+		return counts
+	}
+
+	ginkgo.AfterAll(func() {
+		for _, ref := range refs {
+			_ = c.Delete(ctx, ref, client.WithForce())
+		}
+
+		_ = c.Close()
+	})
+
+	ginkgo.It("should push and publish records across two labels", func() {
+		for i := range 10 {
+			// Records carry the first, the second or both skills
+			var skills []*typesv1alpha1.Skill
+
+			if i%3 != 1 {
+				skills = append(skills, &typesv1alpha1.Skill{Name: firstSkill})
+			}
+
+			if i%3 != 0 {
+				skills = append(skills, &typesv1alpha1.Skill{Name: secondSkill})
+			}
+
+			ref, err := c.Push(ctx, corev1.New(&typesv1alpha1.Record{
+				Name:          fmt.Sprintf("e2e-pull-by-label-agent-%d", i),
+				Version:       "v1.0.0",
+				SchemaVersion: "0.7.0",
+				Description:   "Pull by label test agent",
+				Skills:        skills,
+			}))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			refs = append(refs, ref)
+		}
+
+		err := c.Publish(ctx, &routingv1.PublishRequest{
+			Request: &routingv1.PublishRequest_RecordRefs{
+				RecordRefs: &routingv1.RecordRefs{Refs: refs},
+			},
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("should pull each record exactly once", func() {
+		gomega.Eventually(func(g gomega.Gomega) {
+			counts := pulledCounts()
+
+			for _, ref := range refs {
+				g.Expect(counts).To(gomega.HaveKeyWithValue(ref.GetCid(), 1))
+			}
+		}, 30*time.Second, time.Second).Should(gomega.Succeed())
+	})
+
+	ginkgo.It("should pull the records with their metadata", func() {
+		resCh, err := c.PullByLabel(ctx, labels, client.WithPullMetadata(), client.WithPullVerification(true))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		for res := range resCh {
+			gomega.Expect(res.Error).NotTo(gomega.HaveOccurred())
+			gomega.Expect(res.Meta.GetCid()).To(gomega.Equal(res.Record.GetCid()))
+		}
+	})
+
+	ginkgo.It("should pull at most the limit", func() {
+		counts := pulledCounts(client.WithPullLimit(3))
+		gomega.Expect(len(counts)).To(gomega.BeNumerically("<=", 3))
+	})
+})
@@ -0,0 +1,149 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"time"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/e2e/shared/config"
+	"github.com/agntcy/dir/e2e/shared/utils"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Two tenants in different trust domains publish to the same server.
+// Each of them must only list the records of its own namespace and the public records of the other.
+var _ = ginkgo.Describe("Running client end-to-end tests for routing namespace isolation", ginkgo.Ordered, ginkgo.Serial, func() {
+	ctx := context.Background()
+
+	var (
+		tenantA, tenantB *client.Client
+		privateRef       *corev1.RecordRef
+		publicRef        *corev1.RecordRef
+		namespaceA       string
+	)
+
+	// listedNamespaces lists the records in the namespace, returning their namespaces by CID.
+	listedNamespaces := func(c *client.Client, namespace string) map[string]string {
+		itemsChan, err := c.List(ctx, &routingv1.ListRequest{Namespace: namespace})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		listed := make(map[string]string)
+		for _, item := range utils.CollectListItems(itemsChan) {
+			listed[item.GetRecordRef().GetCid()] = item.GetNamespace()
+		}
+
+		return listed
+	}
+
+	newTenant := func(socketPath string) *client.Client {
+		clientConfig, err := client.LoadConfig()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		clientConfig.SpiffeSocketPath = socketPath
+		clientConfig.AuthMode = "x509"
+
+		c, err := client.New(client.WithConfig(clientConfig))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		return c
+	}
+
+	ginkgo.BeforeAll(func() {
+		if cfg.DeploymentMode != config.DeploymentModeLocal {
+			ginkgo.Skip("Skipping test, not in local mode")
+		}
+
+		if len(cfg.TenantSpiffeSocketPaths) != 2 { //nolint:mnd
+			ginkgo.Skip("Skipping test, two tenant SPIFFE sockets are required")
+		}
+
+		tenantA = newTenant(cfg.TenantSpiffeSocketPaths[0])
+		tenantB = newTenant(cfg.TenantSpiffeSocketPaths[1])
+	})
+
+	ginkgo.AfterAll(func() {
+		for _, ref := range []*corev1.RecordRef{privateRef, publicRef} {
+			if ref != nil {
+				_ = tenantA.Delete(ctx, ref, client.WithForce())
+			}
+		}
+
+		if tenantA != nil {
+			_ = tenantA.Close()
+		}
+
+		if tenantB != nil {
+			_ = tenantB.Close()
+		}
+	})
+
+	push := func(name string) *corev1.RecordRef {
+		ref, err := tenantA.Push(ctx, corev1.New(&typesv1alpha1.Record{
+			Name:          name,
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+			Description:   "Namespace isolation test agent",
+			Skills: []*typesv1alpha1.Skill{
+				{Name: "natural_language_processing/summarization"},
+			},
+		}))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		return ref
+	}
+
+	ginkgo.It("should publish a private and a public record with the first tenant", func() {
+		privateRef = push("e2e-namespace-private-agent")
+		publicRef = push("e2e-namespace-public-agent")
+
+		err := tenantA.Publish(ctx, &routingv1.PublishRequest{
+			Request: &routingv1.PublishRequest_RecordRefs{
+				RecordRefs: &routingv1.RecordRefs{Refs: []*corev1.RecordRef{privateRef}},
+			},
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		err = tenantA.Publish(ctx, &routingv1.PublishRequest{
+			Request: &routingv1.PublishRequest_RecordRefs{
+				RecordRefs: &routingv1.RecordRefs{Refs: []*corev1.RecordRef{publicRef}},
+			},
+		}, client.WithVisibility(routingv1.Visibility_VISIBILITY_PUBLIC))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		gomega.Eventually(func() map[string]string {
+			return listedNamespaces(tenantA, "")
+		}, 30*time.Second, time.Second).Should(gomega.And(
+			gomega.HaveKey(privateRef.GetCid()),
+			gomega.HaveKey(publicRef.GetCid()),
+		))
+
+		// Both records are published in the namespace of the first tenant
+		listed := listedNamespaces(tenantA, "")
+		namespaceA = listed[privateRef.GetCid()]
+		gomega.Expect(namespaceA).NotTo(gomega.BeEmpty())
+		gomega.Expect(listed[publicRef.GetCid()]).To(gomega.Equal(namespaceA))
+	})
+
+	ginkgo.It("should only list the public record to the second tenant", func() {
+		listed := listedNamespaces(tenantB, "")
+		gomega.Expect(listed).To(gomega.HaveKeyWithValue(publicRef.GetCid(), namespaceA))
+		gomega.Expect(listed).NotTo(gomega.HaveKey(privateRef.GetCid()))
+	})
+
+	ginkgo.It("should deny the second tenant listing the namespace of the first tenant", func() {
+		stream, err := tenantB.RoutingServiceClient.List(ctx, &routingv1.ListRequest{Namespace: namespaceA})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		_, err = stream.Recv()
+		gomega.Expect(status.Code(err)).To(gomega.Equal(codes.PermissionDenied))
+	})
+})
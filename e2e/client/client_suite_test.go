@@ -13,6 +13,10 @@ import (
 
 var cfg *config.Config
 
+// startEmbeddedServer starts an in-process server for the embedded deployment mode, see embedded_test.go.
+// It is nil unless the tests are built with the embedded tag.
+var startEmbeddedServer func(t *testing.T)
+
 func TestClientE2E(t *testing.T) {
 	gomega.RegisterFailHandler(ginkgo.Fail)
 
@@ -21,7 +25,15 @@ func TestClientE2E(t *testing.T) {
 	cfg, err = config.LoadConfig()
 	gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
-	if cfg.DeploymentMode != config.DeploymentModeLocal {
+	switch cfg.DeploymentMode {
+	case config.DeploymentModeLocal:
+	case config.DeploymentModeEmbedded:
+		if startEmbeddedServer == nil {
+			t.Skip("Skipping client tests - embedded mode requires the embedded build tag")
+		}
+
+		startEmbeddedServer(t)
+	default:
 		t.Skip("Skipping client tests - not in local mode")
 	}
 
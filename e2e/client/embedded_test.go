@@ -0,0 +1,28 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build embedded
+
+package client
+
+import (
+	"testing"
+
+	"github.com/agntcy/dir/server/embedded"
+)
+
+func init() {
+	startEmbeddedServer = func(t *testing.T) {
+		t.Helper()
+
+		srv, err := embedded.Start(t.Context(), embedded.Options{AuthzDisabled: true})
+		if err != nil {
+			t.Fatalf("failed to start embedded server: %v", err)
+		}
+
+		t.Cleanup(srv.Stop)
+
+		// Specs create their clients from the environment
+		t.Setenv("DIRECTORY_CLIENT_SERVER_ADDRESS", srv.Address)
+	}
+}
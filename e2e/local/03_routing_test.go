@@ -4,8 +4,10 @@
 package local
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/agntcy/dir/e2e/shared/config"
@@ -203,6 +205,57 @@ var _ = ginkgo.Describe("Running dirctl routing commands in local single node de
 		})
 	})
 
+	ginkgo.Context("routing labels command", func() {
+		// publishedLabels returns the labels the server announced for the record
+		publishedLabels := func() []string {
+			output := cli.Routing().List().WithCid(cid).WithArgs("--json").ShouldSucceed()
+
+			var results []struct {
+				Labels []string `json:"labels"`
+			}
+			gomega.Expect(json.Unmarshal([]byte(output), &results)).To(gomega.Succeed())
+			gomega.Expect(results).To(gomega.HaveLen(1))
+
+			return results[0].Labels
+		}
+
+		ginkgo.It("should show the published labels of a stored record", func() {
+			output := cli.Routing().Labels(cid).WithArgs("--raw").ShouldSucceed()
+
+			gomega.Expect(strings.Fields(output)).To(gomega.ConsistOf(publishedLabels()))
+		})
+
+		ginkgo.It("should show the published labels of a record file", func() {
+			output := cli.Routing().Labels(tempPath).WithArgs("--json").ShouldSucceed()
+
+			var result struct {
+				Cid    string `json:"cid"`
+				Labels []struct {
+					Label string `json:"label"`
+				} `json:"labels"`
+				Tags []string `json:"tags"`
+			}
+			gomega.Expect(json.Unmarshal([]byte(output), &result)).To(gomega.Succeed())
+
+			labels := make([]string, 0, len(result.Labels))
+			for _, label := range result.Labels {
+				labels = append(labels, label.Label)
+			}
+
+			gomega.Expect(result.Cid).To(gomega.Equal(cid))
+			gomega.Expect(labels).To(gomega.ConsistOf(publishedLabels()))
+			gomega.Expect(result.Tags).To(gomega.ContainElement(cid))
+		})
+
+		ginkgo.It("should explain the source of each label", func() {
+			output := cli.Routing().Labels(tempPath).WithArgs("--explain").ShouldSucceed()
+
+			gomega.Expect(output).To(gomega.ContainSubstring("/skills/natural_language_processing/natural_language_generation/text_completion"))
+			gomega.Expect(output).To(gomega.ContainSubstring("<- skills[0]"))
+			gomega.Expect(output).To(gomega.ContainSubstring("Discovery tags"))
+		})
+	})
+
 	ginkgo.Context("routing unpublish command", func() {
 		ginkgo.It("should unpublish a previously published record", func() {
 			output := cli.Routing().Unpublish(cid).ShouldSucceed()
@@ -0,0 +1,142 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/e2e/shared/config"
+	"github.com/agntcy/dir/e2e/shared/utils"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Running client end-to-end tests for label-filtered sync", ginkgo.Ordered, func() {
+	const count = 3
+
+	ctx := context.Background()
+
+	var (
+		source, target *client.Client
+		refs           []*corev1.RecordRef
+		skipped        *corev1.RecordRef
+		syncID         string
+	)
+
+	newPeerClient := func(addr string) *client.Client {
+		c, err := client.New(client.WithConfig(&client.Config{ServerAddress: addr}))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		return c
+	}
+
+	newRecord := func(name, skill string) *corev1.Record {
+		return corev1.New(&typesv1alpha1.Record{
+			Name:          name,
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+			Description:   "Store sync test agent",
+			Skills:        []*typesv1alpha1.Skill{{Name: skill}},
+		})
+	}
+
+	ginkgo.BeforeAll(func() {
+		if cfg.DeploymentMode != config.DeploymentModeNetwork {
+			ginkgo.Skip("Skipping test, not in network mode")
+		}
+
+		source = newPeerClient(utils.Peer1Addr)
+		target = newPeerClient(utils.Peer3Addr)
+	})
+
+	ginkgo.AfterAll(func() {
+		if syncID != "" {
+			_ = target.DeleteSync(ctx, syncID)
+		}
+
+		for _, c := range []*client.Client{source, target} {
+			if c != nil {
+				_ = c.Close()
+			}
+		}
+	})
+
+	ginkgo.It("should push the records to peer 1", func() {
+		for i := range count {
+			ref, err := source.Push(ctx, newRecord(
+				fmt.Sprintf("directory.agntcy.org/e2e/store-sync-agent-%d", i),
+				"natural_language_processing/summarization",
+			))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			RegisterCIDForCleanup(ref.GetCid(), "sync")
+
+			refs = append(refs, ref)
+		}
+
+		// A record without the synced label stays on peer 1
+		var err error
+
+		skipped, err = source.Push(ctx, newRecord("directory.agntcy.org/e2e/store-sync-skipped", "images_computer_vision/image_segmentation"))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		RegisterCIDForCleanup(skipped.GetCid(), "sync")
+	})
+
+	ginkgo.It("should not find the records on peer 3", func() {
+		for _, ref := range refs {
+			_, err := target.Pull(ctx, ref)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		}
+	})
+
+	ginkgo.It("should create a label-filtered sync from peer 1 to peer 3", func() {
+		var err error
+
+		syncID, err = target.CreateSync(ctx, utils.Peer1InternalAddr, client.SyncOptions{
+			Labels: []string{"/skills/natural_language_processing"},
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(syncID).NotTo(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should report the sync progress", func() {
+		gomega.Eventually(func(g gomega.Gomega) {
+			sync, err := target.GetSync(ctx, syncID)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(sync.GetLastRunTime()).NotTo(gomega.BeEmpty())
+			g.Expect(sync.GetRecordsSynced()).To(gomega.BeNumerically(">=", count))
+			g.Expect(sync.GetStatus()).To(gomega.Equal(storev1.SyncStatus_SYNC_STATUS_IN_PROGRESS))
+		}, 120*time.Second, 5*time.Second).Should(gomega.Succeed())
+	})
+
+	ginkgo.It("should pull the synced records from peer 3", func() {
+		for _, ref := range refs {
+			record, err := target.Pull(ctx, ref)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(record.GetCid()).To(gomega.Equal(ref.GetCid()))
+		}
+	})
+
+	ginkgo.It("should not sync records without a matching label", func() {
+		_, err := target.Pull(ctx, skipped)
+		gomega.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("should not sync records back to peer 1", func() {
+		ref, err := target.Push(ctx, newRecord("directory.agntcy.org/e2e/store-sync-target-only", "natural_language_processing/summarization"))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		RegisterCIDForCleanup(ref.GetCid(), "sync")
+
+		_, err = source.Pull(ctx, ref)
+		gomega.Expect(err).To(gomega.HaveOccurred())
+	})
+})
@@ -16,6 +16,10 @@ type DeploymentMode string
 const (
 	DeploymentModeLocal   DeploymentMode = "local"
 	DeploymentModeNetwork DeploymentMode = "network"
+
+	// DeploymentModeEmbedded runs the streaming client tests against an in-process server,
+	// the tests must be built with the embedded tag.
+	DeploymentModeEmbedded DeploymentMode = "embedded"
 )
 
 const (
@@ -26,6 +30,10 @@ const (
 
 type Config struct {
 	DeploymentMode DeploymentMode `json:"deployment_mode,omitempty" mapstructure:"deployment_mode"`
+
+	// TenantSpiffeSocketPaths are the SPIFFE workload API sockets of two identities in different trust domains,
+	// both allowed to publish and list records. Namespace isolation tests are skipped unless set.
+	TenantSpiffeSocketPaths []string `json:"tenant_spiffe_socket_paths,omitempty" mapstructure:"tenant_spiffe_socket_paths"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -41,6 +49,9 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("deployment_mode")
 	v.SetDefault("deployment_mode", DefaultDeploymentMode)
 
+	_ = v.BindEnv("tenant_spiffe_socket_paths")
+	v.SetDefault("tenant_spiffe_socket_paths", "")
+
 	// Load configuration into struct
 	decodeHooks := mapstructure.ComposeDecodeHookFunc(
 		mapstructure.TextUnmarshallerHookFunc(),
@@ -103,6 +103,10 @@ func (r *RoutingCommands) Info() *CommandBuilder {
 	return r.cli.Command("routing").WithArgs("info")
 }
 
+func (r *RoutingCommands) Labels(source string) *CommandBuilder {
+	return r.cli.Command("routing").WithArgs("labels", source)
+}
+
 func (r *RoutingCommands) WithArgs(args ...string) *CommandBuilder {
 	return r.cli.Command("routing").WithArgs(args...)
 }
@@ -19,6 +19,7 @@ import (
 )
 
 // Client defines the interface for interacting with the Agent Hub backend for agent operations.
+// It holds the methods used by the service layer, so that commands can be tested against fakes.
 type Client interface {
 	// PushAgent uploads an agent to the hub and returns the response or an error.
 	PushAgent(ctx context.Context, agent []byte, repository any) (*v1alpha1.PushRecordResponse, error)
@@ -30,6 +31,24 @@ type Client interface {
 	DeleteAPIKey(ctx context.Context, clientID string) (*v1alpha1.DeleteApiKeyResponse, error)
 	// ListAPIKeys lists all API keys for a specific organization and returns the response or an error.
 	ListAPIKeys(ctx context.Context, organization any) (*v1alpha1.ListApiKeyResponse, error)
+	// ListOrganizations lists the organizations of the current user and returns the response or an error.
+	ListOrganizations(ctx context.Context, in *v1alpha1.ListOrganizationsRequest, opts ...grpc.CallOption) (*v1alpha1.ListOrganizationsResponse, error)
+	// GetOrganization gets an organization with the role of the current user and returns it or an error.
+	GetOrganization(ctx context.Context, in *v1alpha1.GetOrganizationRequest, opts ...grpc.CallOption) (*v1alpha1.OrganizationWithRole, error)
+	// CreateOrganization creates an organization and returns it or an error.
+	CreateOrganization(ctx context.Context, in *v1alpha1.CreateOrganizationRequest, opts ...grpc.CallOption) (*v1alpha1.Organization, error)
+	// GetUser gets the current user and returns it or an error.
+	GetUser(ctx context.Context, in *v1alpha1.GetUserRequest, opts ...grpc.CallOption) (*v1alpha1.User, error)
+	// CreateUser creates the current user and returns it or an error.
+	CreateUser(ctx context.Context, in *v1alpha1.CreateUserRequest, opts ...grpc.CallOption) (*v1alpha1.User, error)
+	// ListRepositories lists a page of repositories and returns the response or an error.
+	ListRepositories(ctx context.Context, in *v1alpha1.ListRepositoriesRequest, opts ...grpc.CallOption) (*v1alpha1.ListRepositoriesResponse, error)
+	// GetRepository gets a repository by name or ID and returns the response or an error.
+	GetRepository(ctx context.Context, in *v1alpha1.GetRepositoryRequest, opts ...grpc.CallOption) (*v1alpha1.GetRepositoryResponse, error)
+	// CreateRepository creates a repository in an organization and returns the response or an error.
+	CreateRepository(ctx context.Context, in *v1alpha1.CreateRepositoryRequest, opts ...grpc.CallOption) (*v1alpha1.CreateRepositoryResponse, error)
+	// ListRepositoryRecords lists a page of records of a repository and returns the response or an error.
+	ListRepositoryRecords(ctx context.Context, in *v1alpha1.ListRepositoryRecordsRequest, opts ...grpc.CallOption) (*v1alpha1.ListRepositoryRecordsResponse, error)
 }
 
 // client implements the Client interface for the Agent Hub backend.
@@ -37,12 +56,13 @@ type client struct {
 	v1alpha1.AgentDirServiceClient
 	v1alpha1.ApiKeyServiceClient
 	v1alpha1.OrganizationServiceClient
+	v1alpha1.RepositoryServiceClient
 	v1alpha1.UserServiceClient
 }
 
 // New creates a new Agent Hub client for the given server address.
 // Returns the client or an error if the connection could not be established.
-func New(serverAddr string) (*client, error) { //nolint:revive
+func New(serverAddr string) (Client, error) {
 	// Create connection
 	conn, err := grpc.NewClient(
 		serverAddr,
@@ -56,6 +76,7 @@ func New(serverAddr string) (*client, error) { //nolint:revive
 		AgentDirServiceClient:     v1alpha1.NewAgentDirServiceClient(conn),
 		ApiKeyServiceClient:       v1alpha1.NewApiKeyServiceClient(conn),
 		OrganizationServiceClient: v1alpha1.NewOrganizationServiceClient(conn),
+		RepositoryServiceClient:   v1alpha1.NewRepositoryServiceClient(conn),
 		UserServiceClient:         v1alpha1.NewUserServiceClient(conn),
 	}, nil
 }
@@ -4,15 +4,14 @@
 package list
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 
 	v1alpha1 "github.com/agntcy/dir/hub/api/v1alpha1"
 	hubClient "github.com/agntcy/dir/hub/client/hub"
 	"github.com/agntcy/dir/hub/cmd/apikey/options"
 	hubOptions "github.com/agntcy/dir/hub/cmd/options"
+	"github.com/agntcy/dir/hub/cmd/presenter"
 	service "github.com/agntcy/dir/hub/service"
 	"github.com/agntcy/dir/hub/sessionstore"
 	authUtils "github.com/agntcy/dir/hub/utils/auth"
@@ -90,34 +89,35 @@ func runCommand(cmd *cobra.Command, _ []string, opts *options.APIKeyListOptions)
 		return fmt.Errorf("failed to list API keys: %w", err)
 	}
 
-	if !opts.JSONOutput {
-		if opts.OrganizationID != "" {
-			fmt.Fprintf(cmd.OutOrStdout(), "API Keys for organization %s:\n", opts.OrganizationID)
-		} else if opts.OrganizationName != "" {
-			fmt.Fprintf(cmd.OutOrStdout(), "API Keys for organization %s:\n", opts.OrganizationName)
-		}
+	output := presenter.APIKeyListOutput{
+		Organization: opts.OrganizationID,
+		APIKeys:      make([]presenter.APIKey, 0, len(apikeys)),
 	}
 
-	if err := renderList(cmd.OutOrStdout(), apikeys); err != nil {
-		return fmt.Errorf("failed to render API keys list: %w", err)
+	if opts.OrganizationName != "" {
+		output.Organization = opts.OrganizationName
 	}
 
-	return nil
-}
-
-func renderList(stream io.Writer, apikeys []*service.APIKeyWithRoleName) error {
-	if len(apikeys) == 0 {
-		fmt.Fprintf(stream, "[]\n")
-
-		return nil
+	for _, apikey := range apikeys {
+		output.APIKeys = append(output.APIKeys, presenter.APIKey{
+			ClientID: apikey.ClientID,
+			RoleName: apikey.RoleName,
+		})
 	}
 
-	prettyModel, err := json.MarshalIndent(apikeys, "", "  ")
+	outputOpts, err := presenter.GetOptions(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to marshal response list: %w", err)
+		return err //nolint:wrapcheck
+	}
+
+	// The --json flag is kept for compatibility with --output json
+	if opts.JSONOutput {
+		outputOpts.Format = presenter.FormatJSON
 	}
 
-	fmt.Fprintf(stream, "%s\n", string(prettyModel))
+	if err := presenter.Render(cmd.OutOrStdout(), outputOpts, output); err != nil {
+		return fmt.Errorf("failed to render API keys list: %w", err)
+	}
 
 	return nil
 }
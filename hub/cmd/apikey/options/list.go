@@ -24,7 +24,7 @@ func NewAPIKeyListOptions(hubOpts *options.HubOptions, cmd *cobra.Command) *APIK
 	opt.AddRegisterFn(func() error {
 		cmd.Flags().StringVarP(&opt.OrganizationID, "org-id", "o", "", "Organization ID")
 		cmd.Flags().StringVarP(&opt.OrganizationName, "org-name", "n", "", "Organization Name")
-		cmd.Flags().BoolVarP(&opt.JSONOutput, "json", "j", false, "Output in JSON format")
+		cmd.Flags().BoolVarP(&opt.JSONOutput, "json", "j", false, "Output in JSON format, same as --output json")
 
 		return nil
 	})
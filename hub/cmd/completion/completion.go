@@ -0,0 +1,159 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package completion provides dynamic shell completion of repository and organization arguments
+// of the Agent Hub CLI. Suggestions are listed from the hub backend of the stored login session.
+// Completions never fail: if the user is not logged in or the hub cannot be reached in time,
+// nothing is suggested.
+package completion
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	v1alpha1 "github.com/agntcy/dir/hub/api/v1alpha1"
+	authUtils "github.com/agntcy/dir/hub/auth/utils"
+	hubClient "github.com/agntcy/dir/hub/client/hub"
+	hubOptions "github.com/agntcy/dir/hub/cmd/options"
+	"github.com/agntcy/dir/hub/service"
+	"github.com/agntcy/dir/hub/sessionstore"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// MaxCompletions bounds the number of suggestions.
+	MaxCompletions = 50
+
+	// Timeout bounds the time spent querying the hub, so that completion never hangs.
+	Timeout = 2 * time.Second
+)
+
+// Repositories completes the first argument with <owner>/<repo> repository names.
+// Organizations of the user are suggested as owners until an owner is given,
+// then the repositories of the owner.
+func Repositories(hubOpts *hubOptions.HubOptions) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		owner, _, found := strings.Cut(toComplete, "/")
+		if !found {
+			// Complete the owner first, without a space, so that its repositories can be completed next
+			return withSuffix(organizations(cmd.Context(), hubOpts, toComplete), "/"), cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return repositories(cmd.Context(), hubOpts, owner, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Organizations completes the first argument with the organizations of the user.
+func Organizations(hubOpts *hubOptions.HubOptions) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return organizations(cmd.Context(), hubOpts, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// connect returns a client for the hub backend of the stored session, if the user is logged in.
+func connect(ctx context.Context, hubOpts *hubOptions.HubOptions) (context.Context, hubClient.Client, *sessionstore.HubSession, bool) {
+	// Completion runs without the pre-run hooks of the hub command, so options are completed here
+	hubOpts.Complete()
+
+	session, err := hubOpts.GetSession(hubOpts.ServerAddress)
+	if err != nil || session == nil || session.Tokens == nil || session.Tokens.AccessToken == "" ||
+		session.AuthConfig == nil || session.HubBackendAddress == "" {
+		return nil, nil, nil, false
+	}
+
+	hc, err := hubOpts.NewClient(session.HubBackendAddress)
+	if err != nil {
+		return nil, nil, nil, false
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return authUtils.AddAuthToContext(ctx, session), hc, session, true
+}
+
+func organizations(ctx context.Context, hubOpts *hubOptions.HubOptions, prefix string) []cobra.Completion {
+	ctx, hc, _, ok := connect(ctx, hubOpts)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	resp, err := hc.ListOrganizations(ctx, &v1alpha1.ListOrganizationsRequest{})
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, org := range resp.GetOrganizations() {
+		names = append(names, org.GetOrganization().GetName())
+	}
+
+	return filter(names, prefix)
+}
+
+func repositories(ctx context.Context, hubOpts *hubOptions.HubOptions, owner, prefix string) []cobra.Completion {
+	ctx, hc, session, ok := connect(ctx, hubOpts)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	repos, err := service.ListRepositories(ctx, hc, owner, session)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+
+	for _, repo := range repos {
+		// Repository names are usually qualified with their owner already
+		name := repo.GetName()
+		if !strings.Contains(name, "/") {
+			name = owner + "/" + name
+		}
+
+		names = append(names, name)
+	}
+
+	return filter(names, prefix)
+}
+
+// filter returns up to MaxCompletions values starting with prefix.
+func filter(values []string, prefix string) []cobra.Completion {
+	var completions []cobra.Completion
+
+	for _, value := range values {
+		if len(completions) == MaxCompletions {
+			break
+		}
+
+		if strings.HasPrefix(value, prefix) {
+			completions = append(completions, value)
+		}
+	}
+
+	return completions
+}
+
+func withSuffix(values []cobra.Completion, suffix string) []cobra.Completion {
+	for i := range values {
+		values[i] += suffix
+	}
+
+	return values
+}
@@ -0,0 +1,201 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package completion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	v1alpha1 "github.com/agntcy/dir/hub/api/v1alpha1"
+	hubClient "github.com/agntcy/dir/hub/client/hub"
+	hubOptions "github.com/agntcy/dir/hub/cmd/options"
+	"github.com/agntcy/dir/hub/sessionstore"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+const orgID = "935a67e3-0276-4f61-b1ff-000fb163eedd"
+
+// mockHubClient serves a fixed set of organizations and repositories.
+type mockHubClient struct {
+	hubClient.Client
+
+	organizations map[string]string // name -> ID
+	repositories  map[string][]*v1alpha1.Repository
+	err           error
+}
+
+func (m *mockHubClient) ListOrganizations(context.Context, *v1alpha1.ListOrganizationsRequest, ...grpc.CallOption) (*v1alpha1.ListOrganizationsResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	resp := &v1alpha1.ListOrganizationsResponse{}
+	for name, id := range m.organizations {
+		resp.Organizations = append(resp.Organizations, &v1alpha1.OrganizationWithRole{
+			Organization: &v1alpha1.Organization{Id: id, Name: name},
+		})
+	}
+
+	return resp, nil
+}
+
+func (m *mockHubClient) ListRepositories(_ context.Context, req *v1alpha1.ListRepositoriesRequest, _ ...grpc.CallOption) (*v1alpha1.ListRepositoriesResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	repositories := m.repositories[req.GetOrganizationId()]
+
+	return &v1alpha1.ListRepositoriesResponse{
+		PaginatedResponse: &v1alpha1.PaginatedResponse{Count: uint32(len(repositories)), Pages: 1}, //nolint:gosec
+		Repositories:      repositories,
+	}, nil
+}
+
+func setup(t *testing.T, client hubClient.Client, session *sessionstore.HubSession) (*cobra.Command, *hubOptions.HubOptions) {
+	t.Helper()
+
+	cmd := &cobra.Command{Use: "hub"}
+	cmd.SetContext(t.Context())
+
+	hubOpts := hubOptions.NewHubOptions(hubOptions.NewBaseOption(), cmd)
+	hubOpts.NewClient = func(string) (hubClient.Client, error) { return client, nil }
+	hubOpts.GetSession = func(string) (*sessionstore.HubSession, error) {
+		if session == nil {
+			return nil, sessionstore.ErrSessionNotFound
+		}
+
+		return session, nil
+	}
+
+	return cmd, hubOpts
+}
+
+func newSession() *sessionstore.HubSession {
+	return &sessionstore.HubSession{
+		Tokens:     &sessionstore.Tokens{AccessToken: "access", IDToken: "id", RefreshToken: "refresh"},
+		AuthConfig: &sessionstore.AuthConfig{HubBackendAddress: "hub.example.org:443"},
+	}
+}
+
+func newClient() *mockHubClient {
+	return &mockHubClient{
+		organizations: map[string]string{"my-org": orgID, "other-org": "5d0c1a2b-3e4f-4a6b-8c7d-9e0f1a2b3c4d"},
+		repositories: map[string][]*v1alpha1.Repository{
+			orgID: {
+				{Name: "my-org/my-agent"},
+				{Name: "my-org/my-other-agent"},
+				{Name: "tool"},
+			},
+		},
+	}
+}
+
+func TestRepositories(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     *mockHubClient
+		session    *sessionstore.HubSession
+		args       []string
+		toComplete string
+		want       []string
+		directive  cobra.ShellCompDirective
+	}{
+		{
+			name:       "owners",
+			client:     newClient(),
+			session:    newSession(),
+			toComplete: "my",
+			want:       []string{"my-org/"},
+			directive:  cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp,
+		},
+		{
+			name:       "repositories of owner",
+			client:     newClient(),
+			session:    newSession(),
+			toComplete: "my-org/my-",
+			want:       []string{"my-org/my-agent", "my-org/my-other-agent"},
+			directive:  cobra.ShellCompDirectiveNoFileComp,
+		},
+		{
+			name:       "unqualified repository names",
+			client:     newClient(),
+			session:    newSession(),
+			toComplete: "my-org/t",
+			want:       []string{"my-org/tool"},
+			directive:  cobra.ShellCompDirectiveNoFileComp,
+		},
+		{
+			name:       "later arguments",
+			client:     newClient(),
+			session:    newSession(),
+			args:       []string{"my-org/my-agent"},
+			toComplete: "",
+			directive:  cobra.ShellCompDirectiveDefault,
+		},
+		{
+			name:       "not logged in",
+			client:     newClient(),
+			toComplete: "my-org/",
+			directive:  cobra.ShellCompDirectiveNoFileComp,
+		},
+		{
+			name:       "unreachable hub",
+			client:     &mockHubClient{err: errors.New("connection refused")},
+			session:    newSession(),
+			toComplete: "my-org/",
+			directive:  cobra.ShellCompDirectiveNoFileComp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, hubOpts := setup(t, tt.client, tt.session)
+
+			got, directive := Repositories(hubOpts)(cmd, tt.args, tt.toComplete)
+
+			slices.Sort(got)
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Repositories() = %v, want %v", got, tt.want)
+			}
+
+			if directive != tt.directive {
+				t.Errorf("Repositories() directive = %v, want %v", directive, tt.directive)
+			}
+		})
+	}
+}
+
+func TestOrganizations(t *testing.T) {
+	cmd, hubOpts := setup(t, newClient(), newSession())
+
+	got, _ := Organizations(hubOpts)(cmd, nil, "")
+	slices.Sort(got)
+
+	if want := []string{"my-org", "other-org"}; !slices.Equal(got, want) {
+		t.Errorf("Organizations() = %v, want %v", got, want)
+	}
+
+	if got, _ := Organizations(hubOpts)(cmd, []string{"my-org"}, ""); len(got) != 0 {
+		t.Errorf("Organizations() completed a second argument: %v", got)
+	}
+}
+
+func TestCompletionsAreBounded(t *testing.T) {
+	client := newClient()
+	for i := range MaxCompletions + 10 {
+		client.repositories[orgID] = append(client.repositories[orgID], &v1alpha1.Repository{Name: fmt.Sprintf("my-org/agent-%d", i)})
+	}
+
+	cmd, hubOpts := setup(t, client, newSession())
+
+	if got, _ := Repositories(hubOpts)(cmd, nil, "my-org/"); len(got) != MaxCompletions {
+		t.Errorf("Repositories() returned %d completions, want %d", len(got), MaxCompletions)
+	}
+}
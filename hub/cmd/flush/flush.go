@@ -0,0 +1,118 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package flush provides the CLI command for replaying pushes queued for the Agent Hub.
+package flush
+
+import (
+	"fmt"
+
+	hubOptions "github.com/agntcy/dir/hub/cmd/options"
+	"github.com/agntcy/dir/hub/cmd/presenter"
+	"github.com/agntcy/dir/hub/service"
+	"github.com/agntcy/dir/hub/spool"
+	authUtils "github.com/agntcy/dir/hub/utils/auth"
+	"github.com/agntcy/dir/hub/utils/file"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the "flush" command for the Agent Hub CLI.
+// It replays the pushes queued by 'dirctl hub push --offline' or '--queue-on-failure'.
+// Returns the configured *cobra.Command.
+func NewCommand(hubOpts *hubOptions.HubOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "flush",
+		Short: "Replay pushes queued for Agent Hub",
+		Long: `Replay the pushes queued by 'dirctl hub push --offline' or 'dirctl hub push --queue-on-failure'.
+
+Queued pushes are replayed in the order they were queued, and removed from the queue once pushed.
+Pushes that fail are kept and reported, so that the flush can be retried.
+
+Queued pushes are skipped with a warning and kept if:
+  - they were queued by another user or for another hub
+  - they were modified after they were queued
+
+Authentication:
+  API key authentication can be provided via:
+  1. API key file: --apikey-file (JSON file with API key credentials)
+  2. Environment variables: DIRCTL_CLIENT_ID and DIRCTL_CLIENT_SECRET
+  3. Session file created via 'dirctl hub login'
+
+  API key file takes precedence over environment variables, which take precedence over session file.
+
+Examples:
+  # Queue a push while offline and replay it later
+  dirctl hub push my-org/my-agent record.json --offline
+  dirctl hub flush
+
+  # Print only the digests of the pushed records, e.g. for scripting
+  dirctl hub flush --output plain`,
+		Args: cobra.NoArgs,
+	}
+
+	// API key authentication flags
+	var apikeyFile string
+
+	cmd.Flags().StringVar(&apikeyFile, "apikey-file", "", `Path to a JSON file containing API key credentials (format: {"client_id": "...", "secret": "..."})`)
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		// Authenticate using either API key file or session file
+		currentSession, err := authUtils.GetOrCreateSession(cmd, hubOpts.ServerAddress, "", "", apikeyFile, false)
+		if err != nil {
+			return fmt.Errorf("failed to get or create session: %w", err)
+		}
+
+		hc, err := hubOpts.NewClient(currentSession.HubBackendAddress)
+		if err != nil {
+			return fmt.Errorf("failed to create hub client: %w", err)
+		}
+
+		results, err := service.FlushSpool(cmd.Context(), hc, spool.New(file.GetSpoolDirPath()), currentSession)
+		if err != nil {
+			return fmt.Errorf("failed to flush queued pushes: %w", err)
+		}
+
+		output := presenter.FlushOutput{
+			Entries: make([]presenter.FlushEntryOutput, 0, len(results)),
+		}
+
+		failed := 0
+
+		for _, result := range results {
+			entry := presenter.FlushEntryOutput{
+				QueueID:    result.ID,
+				Repository: result.Repository,
+				Digest:     result.Digest,
+				Status:     string(result.Status),
+			}
+
+			if result.Err != nil {
+				entry.Error = result.Err.Error()
+			}
+
+			output.Entries = append(output.Entries, entry)
+
+			switch result.Status {
+			case service.FlushStatusSkipped:
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipped queued push %s: %v\n", result.ID, result.Err)
+			case service.FlushStatusFailed:
+				failed++
+
+				fmt.Fprintf(cmd.ErrOrStderr(), "Error: failed to push queued push %s: %v\n", result.ID, result.Err)
+			case service.FlushStatusPushed:
+			}
+		}
+
+		if err := presenter.Print(cmd, output); err != nil {
+			return fmt.Errorf("failed to write results: %w", err)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("failed to push %d of %d queued pushes", failed, len(results))
+		}
+
+		return nil
+	}
+
+	return cmd
+}
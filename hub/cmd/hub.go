@@ -12,13 +12,17 @@ import (
 
 	"github.com/agntcy/dir/hub/client/okta"
 	"github.com/agntcy/dir/hub/cmd/apikey"
+	"github.com/agntcy/dir/hub/cmd/flush"
 	"github.com/agntcy/dir/hub/cmd/info"
+	"github.com/agntcy/dir/hub/cmd/list"
 	"github.com/agntcy/dir/hub/cmd/login"
 	"github.com/agntcy/dir/hub/cmd/logout"
 	"github.com/agntcy/dir/hub/cmd/options"
 	"github.com/agntcy/dir/hub/cmd/orgs"
+	"github.com/agntcy/dir/hub/cmd/presenter"
 	"github.com/agntcy/dir/hub/cmd/pull"
 	"github.com/agntcy/dir/hub/cmd/push"
+	"github.com/agntcy/dir/hub/cmd/versions"
 	"github.com/agntcy/dir/hub/config"
 	"github.com/agntcy/dir/hub/sessionstore"
 	"github.com/agntcy/dir/hub/utils/file"
@@ -29,7 +33,7 @@ import (
 
 // NewHubCommand creates the root "hub" command for the Agent Hub CLI.
 // It sets up persistent pre-run logic for session/config loading and token refresh,
-// attaches the session to the command context, and adds all subcommands (login, logout, push, pull, orgs).
+// attaches the session to the command context, and adds all subcommands (login, logout, push, flush, pull, list, versions, orgs).
 // Returns the configured *cobra.Command.
 func NewHubCommand(ctx context.Context, baseOption *options.BaseOption) *cobra.Command {
 	cmd := &cobra.Command{
@@ -47,6 +51,8 @@ func NewHubCommand(ctx context.Context, baseOption *options.BaseOption) *cobra.C
 
 	cmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Skip session file operations and use only API key authentication")
 
+	presenter.AddFlags(cmd, presenter.FormatTable)
+
 	//nolint:contextcheck // context is set via cmd.SetContext(ctx) and accessed via cmd.Context()
 	cmd.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
 		cmd.SetOut(os.Stdout)
@@ -64,6 +70,14 @@ func NewHubCommand(ctx context.Context, baseOption *options.BaseOption) *cobra.C
 			currentSession = &sessionstore.HubSession{}
 		}
 
+		// Offline commands use the stored session as is, as the hub may not be reachable
+		if isOffline(cmd) && currentSession.AuthConfig != nil {
+			ctx := context.WithValue(cmd.Context(), sessionstore.SessionContextKey, currentSession)
+			cmd.SetContext(ctx)
+
+			return nil
+		}
+
 		authConfig, err := config.FetchAuthConfig(cmd.Context(), opts.ServerAddress)
 		if err != nil {
 			return fmt.Errorf("failed to fetch auth config: %w", err)
@@ -89,7 +103,7 @@ func NewHubCommand(ctx context.Context, baseOption *options.BaseOption) *cobra.C
 
 		// If --no-cache is specified, skip all session file operations
 		if cmd.Flags().Changed("no-cache") {
-			fmt.Fprintf(cmd.OutOrStdout(), "Skipping session file operations due to --no-cache flag\n")
+			fmt.Fprintf(cmd.ErrOrStderr(), "Skipping session file operations due to --no-cache flag\n")
 		} else {
 			if err := sessionStore.SaveHubSession(opts.ServerAddress, currentSession); err != nil {
 				return fmt.Errorf("failed to save updated session with auth config: %w", err)
@@ -107,7 +121,10 @@ func NewHubCommand(ctx context.Context, baseOption *options.BaseOption) *cobra.C
 		login.NewCommand(opts),
 		logout.NewCommand(opts),
 		push.NewCommand(opts),
+		flush.NewCommand(opts),
 		pull.NewCommand(opts),
+		list.NewCommand(opts),
+		versions.NewCommand(opts),
 		orgs.NewCommand(opts),
 		apikey.NewCommand(opts),
 		info.NewCommand(opts),
@@ -115,3 +132,10 @@ func NewHubCommand(ctx context.Context, baseOption *options.BaseOption) *cobra.C
 
 	return cmd
 }
+
+// isOffline returns true if the command is run with --offline.
+func isOffline(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup("offline")
+
+	return flag != nil && flag.Value.String() == "true"
+}
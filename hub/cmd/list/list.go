@@ -0,0 +1,100 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package list provides the CLI command for listing the repositories of an organization in the Agent Hub.
+package list
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/hub/cmd/completion"
+	hubOptions "github.com/agntcy/dir/hub/cmd/options"
+	"github.com/agntcy/dir/hub/cmd/presenter"
+	"github.com/agntcy/dir/hub/service"
+	authUtils "github.com/agntcy/dir/hub/utils/auth"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the "list" command for the Agent Hub CLI.
+// It lists the repositories of an organization, identified by name or ID.
+// Returns the configured *cobra.Command.
+func NewCommand(hubOpts *hubOptions.HubOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list <owner>",
+		Short: "List the repositories of an organization in Agent Hub",
+		Long: `List the repositories of an organization in the Agent Hub.
+
+Parameters:
+  <owner>    Organization name or organization ID
+
+Authentication:
+  API key authentication can be provided via:
+  1. API key file: --apikey-file (JSON file with API key credentials)
+  2. Environment variables: DIRCTL_CLIENT_ID and DIRCTL_CLIENT_SECRET
+  3. Session file created via 'dirctl hub login'
+
+  API key file takes precedence over environment variables, which take precedence over session file.
+
+Examples:
+  # List the repositories of an organization
+  dirctl hub list my-org
+
+  # List the repositories of an organization by ID as JSON
+  dirctl hub list 935a67e3-0276-4f61-b1ff-000fb163eedd --output json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.Organizations(hubOpts),
+	}
+
+	// API key authentication flags
+	var apikeyFile string
+
+	cmd.Flags().StringVar(&apikeyFile, "apikey-file", "", `Path to a JSON file containing API key credentials (format: {"client_id": "...", "secret": "..."})`)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		owner := args[0]
+		if owner == "" {
+			return errors.New("owner is required")
+		}
+
+		// Authenticate using either API key file or session file
+		currentSession, err := authUtils.GetOrCreateSession(cmd, hubOpts.ServerAddress, "", "", apikeyFile, false)
+		if err != nil {
+			return fmt.Errorf("failed to get or create session: %w", err)
+		}
+
+		hc, err := hubOpts.NewClient(currentSession.HubBackendAddress)
+		if err != nil {
+			return fmt.Errorf("failed to create hub client: %w", err)
+		}
+
+		repositories, err := service.ListRepositories(cmd.Context(), hc, owner, currentSession)
+		if err != nil {
+			return fmt.Errorf("failed to list repositories: %w", err)
+		}
+
+		output := presenter.RepositoryListOutput{
+			Owner:        owner,
+			Repositories: make([]presenter.Repository, 0, len(repositories)),
+		}
+
+		for _, repo := range repositories {
+			updatedAt := ""
+			if repo.GetUpdatedAt() != nil {
+				updatedAt = repo.GetUpdatedAt().AsTime().Format(time.RFC3339)
+			}
+
+			output.Repositories = append(output.Repositories, presenter.Repository{
+				Name:      repo.GetName(),
+				ID:        repo.GetId(),
+				Private:   repo.GetPrivate(),
+				UpdatedAt: updatedAt,
+			})
+		}
+
+		return presenter.Print(cmd, output)
+	}
+
+	return cmd
+}
@@ -0,0 +1,175 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package list
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/agntcy/dir/hub/api/v1alpha1"
+	hubClient "github.com/agntcy/dir/hub/client/hub"
+	hubOptions "github.com/agntcy/dir/hub/cmd/options"
+	"github.com/agntcy/dir/hub/cmd/presenter"
+	"github.com/agntcy/dir/hub/service"
+	"github.com/agntcy/dir/hub/sessionstore"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockHubClient serves a fixed set of organizations and repositories.
+type mockHubClient struct {
+	hubClient.Client
+
+	organizations map[string]string // name -> ID
+	repositories  map[string][]*v1alpha1.Repository
+	err           error
+}
+
+func (m *mockHubClient) ListOrganizations(context.Context, *v1alpha1.ListOrganizationsRequest, ...grpc.CallOption) (*v1alpha1.ListOrganizationsResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	resp := &v1alpha1.ListOrganizationsResponse{}
+	for name, id := range m.organizations {
+		resp.Organizations = append(resp.Organizations, &v1alpha1.OrganizationWithRole{
+			Organization: &v1alpha1.Organization{Id: id, Name: name},
+		})
+	}
+
+	return resp, nil
+}
+
+func (m *mockHubClient) ListRepositories(_ context.Context, req *v1alpha1.ListRepositoriesRequest, _ ...grpc.CallOption) (*v1alpha1.ListRepositoriesResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	repositories, ok := m.repositories[req.GetOrganizationId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "organization not found")
+	}
+
+	return &v1alpha1.ListRepositoriesResponse{
+		PaginatedResponse: &v1alpha1.PaginatedResponse{Count: uint32(len(repositories)), Pages: 1}, //nolint:gosec
+		Repositories:      repositories,
+	}, nil
+}
+
+func newSession(t *testing.T) *sessionstore.HubSession {
+	t.Helper()
+
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "user",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	return &sessionstore.HubSession{
+		Tokens:     &sessionstore.Tokens{AccessToken: accessToken, IDToken: "id", RefreshToken: "refresh"},
+		AuthConfig: &sessionstore.AuthConfig{HubBackendAddress: "hub.example.org:443"},
+	}
+}
+
+func runCommand(t *testing.T, client hubClient.Client, session *sessionstore.HubSession, args ...string) (string, error) {
+	t.Helper()
+
+	t.Setenv("DIRCTL_CLIENT_ID", "")
+	t.Setenv("DIRCTL_CLIENT_SECRET", "")
+
+	root := &cobra.Command{Use: "hub"}
+	presenter.AddFlags(root, presenter.FormatTable)
+
+	hubOpts := hubOptions.NewHubOptions(hubOptions.NewBaseOption(), root)
+	hubOpts.NewClient = func(string) (hubClient.Client, error) { return client, nil }
+
+	cmd := NewCommand(hubOpts)
+	root.AddCommand(cmd)
+
+	var out bytes.Buffer
+
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs(append([]string{"list"}, args...))
+
+	err := root.ExecuteContext(context.WithValue(t.Context(), sessionstore.SessionContextKey, session))
+
+	return out.String(), err
+}
+
+func TestListCommand(t *testing.T) {
+	client := &mockHubClient{
+		organizations: map[string]string{"my-org": "935a67e3-0276-4f61-b1ff-000fb163eedd"},
+		repositories: map[string][]*v1alpha1.Repository{
+			"935a67e3-0276-4f61-b1ff-000fb163eedd": {
+				{Id: "5d0c1a2b-3e4f-4a6b-8c7d-9e0f1a2b3c4d", Name: "my-org/my-agent"},
+			},
+		},
+	}
+
+	t.Run("lists repositories by organization name", func(t *testing.T) {
+		out, err := runCommand(t, client, newSession(t), "my-org", "--output", "json")
+		if err != nil {
+			t.Fatalf("list unexpected error: %v", err)
+		}
+
+		var output presenter.RepositoryListOutput
+		if err := json.Unmarshal([]byte(out), &output); err != nil {
+			t.Fatalf("failed to parse output %q: %v", out, err)
+		}
+
+		if len(output.Repositories) != 1 || output.Repositories[0].Name != "my-org/my-agent" {
+			t.Errorf("list output = %+v", output)
+		}
+	})
+
+	t.Run("lists repositories by organization ID", func(t *testing.T) {
+		out, err := runCommand(t, client, newSession(t), "935a67e3-0276-4f61-b1ff-000fb163eedd", "--output", "plain")
+		if err != nil {
+			t.Fatalf("list unexpected error: %v", err)
+		}
+
+		if strings.TrimSpace(out) != "my-org/my-agent" {
+			t.Errorf("list output = %q", out)
+		}
+	})
+
+	t.Run("unknown organization", func(t *testing.T) {
+		_, err := runCommand(t, client, newSession(t), "other-org")
+		if !errors.Is(err, service.ErrNotFound) || !strings.Contains(err.Error(), "organization other-org: not found") {
+			t.Errorf("list error = %v, want organization not found", err)
+		}
+	})
+
+	t.Run("unknown organization ID", func(t *testing.T) {
+		_, err := runCommand(t, client, newSession(t), "7f3e2d1c-0b9a-4876-a5b4-c3d2e1f0a9b8")
+		if !errors.Is(err, service.ErrNotFound) {
+			t.Errorf("list error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("rejected credentials", func(t *testing.T) {
+		_, err := runCommand(t, &mockHubClient{err: status.Error(codes.Unauthenticated, "invalid token")}, newSession(t), "my-org")
+		if !errors.Is(err, service.ErrUnauthorized) || !strings.Contains(err.Error(), "dirctl hub login") {
+			t.Errorf("list error = %v, want ErrUnauthorized", err)
+		}
+	})
+
+	t.Run("not logged in", func(t *testing.T) {
+		_, err := runCommand(t, client, &sessionstore.HubSession{}, "my-org")
+		if err == nil || !strings.Contains(err.Error(), "dirctl hub login") {
+			t.Errorf("list error = %v, want login hint", err)
+		}
+	})
+}
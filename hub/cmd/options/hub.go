@@ -6,7 +6,10 @@ package options
 import (
 	"fmt"
 
+	hubClient "github.com/agntcy/dir/hub/client/hub"
 	"github.com/agntcy/dir/hub/config"
+	"github.com/agntcy/dir/hub/sessionstore"
+	"github.com/agntcy/dir/hub/utils/file"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -21,11 +24,23 @@ type HubOptions struct {
 	*BaseOption
 
 	ServerAddress string
+
+	// NewClient creates a client of the hub backend at the given address, hubClient.New by default.
+	// Tests replace it to serve commands with a fake client.
+	NewClient func(address string) (hubClient.Client, error)
+
+	// GetSession returns the stored login session of the hub at the given server address,
+	// read from the session file by default.
+	GetSession func(serverAddress string) (*sessionstore.HubSession, error)
 }
 
 func NewHubOptions(base *BaseOption, cmd *cobra.Command) *HubOptions {
 	hubOpts := &HubOptions{
 		BaseOption: base,
+		NewClient:  hubClient.New,
+		GetSession: func(serverAddress string) (*sessionstore.HubSession, error) {
+			return sessionstore.NewFileSessionStore(file.GetSessionFilePath()).GetHubSession(serverAddress) //nolint:wrapcheck
+		},
 	}
 
 	hubOpts.AddRegisterFn(
@@ -5,6 +5,8 @@ package options
 
 type HubPullOptions struct {
 	*HubOptions
+
+	OutputFile string
 }
 
 func NewHubPullOptions(hubOptions *HubOptions) *HubPullOptions {
@@ -9,6 +9,19 @@ type HubPushOptions struct {
 	*HubOptions
 
 	FromStdIn bool
+
+	// Bulk push options
+	ContinueOnError bool
+	FailFast        bool
+	Concurrency     int
+
+	// Offline queue options
+	Offline        bool
+	QueueOnFailure bool
+
+	// Repository creation options
+	Create     bool
+	Visibility string
 }
 
 func NewHubPushOptions(hubOptions *HubOptions, cmd *cobra.Command) *HubPushOptions {
@@ -18,6 +31,13 @@ func NewHubPushOptions(hubOptions *HubOptions, cmd *cobra.Command) *HubPushOptio
 
 	opts.AddRegisterFn(func() error {
 		cmd.Flags().BoolVar(&opts.FromStdIn, "stdin", false, "Read from stdin")
+		cmd.Flags().BoolVar(&opts.ContinueOnError, "continue-on-error", true, "Continue pushing remaining files when a push fails")
+		cmd.Flags().BoolVar(&opts.FailFast, "fail-fast", false, "Stop pushing remaining files after the first failure")
+		cmd.Flags().IntVar(&opts.Concurrency, "concurrency", 1, "Number of files to push in parallel")
+		cmd.Flags().BoolVar(&opts.Offline, "offline", false, "Queue pushes locally without contacting the hub, replay them with 'dirctl hub flush'")
+		cmd.Flags().BoolVar(&opts.QueueOnFailure, "queue-on-failure", false, "Queue pushes locally if the hub is unreachable, replay them with 'dirctl hub flush'")
+		cmd.Flags().BoolVar(&opts.Create, "create", false, "Create the repository if it does not exist")
+		cmd.Flags().StringVar(&opts.Visibility, "visibility", "private", "Visibility of a repository created with --create: public or private")
 
 		return nil
 	})
@@ -8,7 +8,6 @@ package orgs
 import (
 	"errors"
 	"fmt"
-	"io"
 	"regexp"
 
 	saasv1alpha1 "github.com/agntcy/dir/hub/api/v1alpha1"
@@ -16,18 +15,11 @@ import (
 	authUtils "github.com/agntcy/dir/hub/auth/utils"
 	hubClient "github.com/agntcy/dir/hub/client/hub"
 	hubOptions "github.com/agntcy/dir/hub/cmd/options"
+	"github.com/agntcy/dir/hub/cmd/presenter"
 	"github.com/agntcy/dir/hub/sessionstore"
-	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/spf13/cobra"
 )
 
-const (
-	nameHeader = "Organization Name"
-	idHeader   = "Organization ID"
-	roleHeader = "Role"
-	gapSize    = 4
-)
-
 // isOrganizationNameValid validates organization name against the API format and rejects UUID format.
 func isOrganizationNameValid(name string) bool {
 	if name == "" {
@@ -86,9 +78,19 @@ func newListCommand(_ *hubOptions.HubOptions) *cobra.Command {
 			return fmt.Errorf("failed to get orgs list: %w", err)
 		}
 
-		renderList(cmd.OutOrStdout(), orgs.GetOrganizations())
+		output := presenter.OrganizationListOutput{
+			Organizations: make([]presenter.Organization, 0, len(orgs.GetOrganizations())),
+		}
+
+		for _, org := range orgs.GetOrganizations() {
+			output.Organizations = append(output.Organizations, presenter.Organization{
+				Name: org.GetOrganization().GetName(),
+				ID:   org.GetOrganization().GetId(),
+				Role: org.GetRole().String(),
+			})
+		}
 
-		return nil
+		return presenter.Print(cmd, output)
 	}
 
 	return cmd
@@ -169,44 +171,3 @@ Examples:
 
 	return cmd
 }
-
-type renderFn func(int, int, int) string
-
-func renderList(stream io.Writer, organizationsWithRoles []*saasv1alpha1.OrganizationWithRole) {
-	renderFns := make([]renderFn, len(organizationsWithRoles))
-
-	longestNameLen := len(nameHeader)
-	longestRoleLen := len(roleHeader)
-	longestIDLen := len(idHeader)
-
-	for i, org := range organizationsWithRoles {
-		if len(org.GetOrganization().GetName()) > longestNameLen {
-			longestNameLen = len(org.GetOrganization().GetName())
-		}
-
-		if len(org.GetOrganization().GetId()) > longestIDLen {
-			longestIDLen = len(org.GetOrganization().GetId())
-		}
-
-		if len(org.GetRole().String()) > longestRoleLen {
-			longestRoleLen = len(org.GetRole().String())
-		}
-
-		renderFns[i] = func(lName, lId, lRole int) string {
-			nameCol := text.AlignLeft.Apply(org.GetOrganization().GetName(), lName+gapSize)
-			idCol := text.AlignLeft.Apply(org.GetOrganization().GetId(), lId+gapSize)
-			roleCol := text.AlignLeft.Apply(org.GetRole().String(), lRole)
-
-			return fmt.Sprintf("%s%s%s", nameCol, idCol, roleCol)
-		}
-	}
-
-	nameHeader := text.AlignLeft.Apply(nameHeader, longestNameLen+gapSize)
-	idHeader := text.AlignLeft.Apply(idHeader, longestIDLen+gapSize)
-	roleHeader := text.AlignLeft.Apply(roleHeader, longestRoleLen)
-	fmt.Fprintf(stream, "%s%s%s\n", nameHeader, idHeader, roleHeader) //nolint:errcheck
-
-	for _, organization := range renderFns {
-		fmt.Fprintln(stream, organization(longestNameLen, longestIDLen, longestRoleLen)) //nolint:errcheck
-	}
-}
@@ -0,0 +1,268 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package presenter
+
+// PushOutput is the result of pushing a single record.
+type PushOutput struct {
+	Digest     string `json:"digest"`
+	Repository string `json:"repository"`
+}
+
+func (o PushOutput) Table() Table {
+	return Table{
+		Headers:  []string{"REPOSITORY", "DIGEST"},
+		Rows:     [][]string{{o.Repository, o.Digest}},
+		Truncate: []int{1},
+	}
+}
+
+func (o PushOutput) Plain() []string {
+	return []string{o.Digest}
+}
+
+// QueuedPushOutput is the result of queueing a single record push.
+type QueuedPushOutput struct {
+	QueueID    string `json:"queue_id"`
+	Repository string `json:"repository"`
+}
+
+func (o QueuedPushOutput) Table() Table {
+	return Table{
+		Headers: []string{"REPOSITORY", "QUEUE ID"},
+		Rows:    [][]string{{o.Repository, o.QueueID}},
+	}
+}
+
+func (o QueuedPushOutput) Plain() []string {
+	return []string{o.QueueID}
+}
+
+// PushFileOutput is the result of pushing a single file in a bulk push.
+type PushFileOutput struct {
+	File   string `json:"file"`
+	Digest string `json:"digest,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+
+	QueueID string `json:"queue_id,omitempty"`
+}
+
+// BulkPushOutput is the result of pushing multiple records.
+type BulkPushOutput struct {
+	Repository string           `json:"repository"`
+	Files      []PushFileOutput `json:"files"`
+}
+
+func (o BulkPushOutput) Table() Table {
+	table := Table{
+		Headers:  []string{"FILE", "DIGEST", "STATUS"},
+		Truncate: []int{1},
+	}
+
+	for _, file := range o.Files {
+		table.Rows = append(table.Rows, []string{file.File, file.Digest, file.Status})
+	}
+
+	return table
+}
+
+// Plain returns the digests of the pushed records.
+func (o BulkPushOutput) Plain() []string {
+	var lines []string
+
+	for _, file := range o.Files {
+		if file.Digest != "" {
+			lines = append(lines, file.Digest)
+		}
+	}
+
+	return lines
+}
+
+// FlushEntryOutput is the result of replaying a single queued push.
+type FlushEntryOutput struct {
+	QueueID    string `json:"queue_id"`
+	Repository string `json:"repository,omitempty"`
+	Digest     string `json:"digest,omitempty"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// FlushOutput is the result of replaying the queued pushes.
+type FlushOutput struct {
+	Entries []FlushEntryOutput `json:"entries"`
+}
+
+func (o FlushOutput) Table() Table {
+	table := Table{
+		Headers:  []string{"QUEUE ID", "REPOSITORY", "DIGEST", "STATUS"},
+		Truncate: []int{2},
+	}
+
+	for _, entry := range o.Entries {
+		table.Rows = append(table.Rows, []string{entry.QueueID, entry.Repository, entry.Digest, entry.Status})
+	}
+
+	return table
+}
+
+// Plain returns the digests of the pushed records.
+func (o FlushOutput) Plain() []string {
+	var lines []string
+
+	for _, entry := range o.Entries {
+		if entry.Digest != "" {
+			lines = append(lines, entry.Digest)
+		}
+	}
+
+	return lines
+}
+
+// Organization is a row of OrganizationListOutput.
+type Organization struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+	Role string `json:"role"`
+}
+
+// OrganizationListOutput is the list of organizations of the current user.
+type OrganizationListOutput struct {
+	Organizations []Organization `json:"organizations"`
+}
+
+func (o OrganizationListOutput) Table() Table {
+	table := Table{
+		Headers:  []string{"NAME", "ID", "ROLE"},
+		Truncate: []int{1},
+	}
+
+	for _, org := range o.Organizations {
+		table.Rows = append(table.Rows, []string{org.Name, org.ID, org.Role})
+	}
+
+	return table
+}
+
+// Plain returns the organization names.
+func (o OrganizationListOutput) Plain() []string {
+	lines := make([]string, 0, len(o.Organizations))
+	for _, org := range o.Organizations {
+		lines = append(lines, org.Name)
+	}
+
+	return lines
+}
+
+// APIKey is a row of APIKeyListOutput.
+type APIKey struct {
+	ClientID string `json:"client_id"`
+	RoleName string `json:"role_name"`
+}
+
+// APIKeyListOutput is the list of API keys of an organization.
+type APIKeyListOutput struct {
+	Organization string   `json:"organization"`
+	APIKeys      []APIKey `json:"api_keys"`
+}
+
+func (o APIKeyListOutput) Table() Table {
+	table := Table{
+		Headers: []string{"CLIENT ID", "ROLE"},
+	}
+
+	for _, key := range o.APIKeys {
+		table.Rows = append(table.Rows, []string{key.ClientID, key.RoleName})
+	}
+
+	return table
+}
+
+// Plain returns the client IDs of the API keys.
+func (o APIKeyListOutput) Plain() []string {
+	lines := make([]string, 0, len(o.APIKeys))
+	for _, key := range o.APIKeys {
+		lines = append(lines, key.ClientID)
+	}
+
+	return lines
+}
+
+// Repository is a row of RepositoryListOutput.
+type Repository struct {
+	Name      string `json:"name"`
+	ID        string `json:"id"`
+	Private   bool   `json:"private"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// RepositoryListOutput is the list of repositories of an organization.
+type RepositoryListOutput struct {
+	Owner        string       `json:"owner"`
+	Repositories []Repository `json:"repositories"`
+}
+
+func (o RepositoryListOutput) Table() Table {
+	table := Table{
+		Headers:  []string{"NAME", "ID", "VISIBILITY", "UPDATED"},
+		Truncate: []int{1},
+	}
+
+	for _, repo := range o.Repositories {
+		visibility := "public"
+		if repo.Private {
+			visibility = "private"
+		}
+
+		table.Rows = append(table.Rows, []string{repo.Name, repo.ID, visibility, repo.UpdatedAt})
+	}
+
+	return table
+}
+
+// Plain returns the repository names.
+func (o RepositoryListOutput) Plain() []string {
+	lines := make([]string, 0, len(o.Repositories))
+	for _, repo := range o.Repositories {
+		lines = append(lines, repo.Name)
+	}
+
+	return lines
+}
+
+// RepositoryVersion is a row of RepositoryVersionsOutput.
+type RepositoryVersion struct {
+	Version   string `json:"version"`
+	Digest    string `json:"digest"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// RepositoryVersionsOutput is the list of record versions of a repository.
+type RepositoryVersionsOutput struct {
+	Repository string              `json:"repository"`
+	Versions   []RepositoryVersion `json:"versions"`
+}
+
+func (o RepositoryVersionsOutput) Table() Table {
+	table := Table{
+		Headers:  []string{"VERSION", "DIGEST", "CREATED"},
+		Truncate: []int{1},
+	}
+
+	for _, version := range o.Versions {
+		table.Rows = append(table.Rows, []string{version.Version, version.Digest, version.CreatedAt})
+	}
+
+	return table
+}
+
+// Plain returns the digests of the versions.
+func (o RepositoryVersionsOutput) Plain() []string {
+	lines := make([]string, 0, len(o.Versions))
+	for _, version := range o.Versions {
+		lines = append(lines, version.Digest)
+	}
+
+	return lines
+}
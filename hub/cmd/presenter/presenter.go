@@ -0,0 +1,196 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package presenter renders typed command results for the Agent Hub CLI.
+//
+// Commands construct result structs implementing Output and pass them to Render,
+// which writes them to stdout in the format selected with the persistent --output flag:
+//
+//   - json: indented JSON of the result struct. The field names are part of the
+//     CLI contract and only change in a backwards compatible way.
+//   - table: aligned columns with a header row. Long identifiers such as digests
+//     and IDs are truncated unless --no-trunc is given.
+//   - plain: the essential values only, one per line, e.g. the digest of a pushed record.
+//
+// Only results are written to stdout. Progress, warnings and log lines go to stderr,
+// so the output of any format can be piped to other tools.
+package presenter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// Format is the output format of command results.
+type Format string
+
+const (
+	FormatJSON  Format = "json"
+	FormatTable Format = "table"
+	FormatPlain Format = "plain"
+)
+
+const (
+	outputFlagName  = "output"
+	noTruncFlagName = "no-trunc"
+
+	// truncatedLength is the length of truncated identifiers, including the ellipsis.
+	truncatedLength = 19
+	ellipsis        = "..."
+)
+
+// Formats lists the supported output formats.
+var Formats = []Format{FormatJSON, FormatTable, FormatPlain}
+
+// Options control how results are rendered.
+type Options struct {
+	Format Format
+
+	// NoTrunc disables truncation of long identifiers in table output.
+	NoTrunc bool
+}
+
+// Output is a typed command result.
+type Output interface {
+	// Table returns the result as a table.
+	Table() Table
+
+	// Plain returns the essential values of the result, one per line.
+	Plain() []string
+}
+
+// Table is a tabular view of a result.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+
+	// Truncate lists the indexes of columns holding long identifiers.
+	// Their values are truncated unless truncation is disabled.
+	Truncate []int
+}
+
+// AddFlags adds the persistent --output and --no-trunc flags to a command and its subcommands.
+func AddFlags(cmd *cobra.Command, defaultFormat Format) {
+	flags := cmd.PersistentFlags()
+	flags.String(outputFlagName, string(defaultFormat), fmt.Sprintf("Output format, one of %s", formatNames()))
+	flags.Bool(noTruncFlagName, false, "Do not truncate long identifiers in table output")
+
+	names := make([]cobra.Completion, len(Formats))
+	for i, format := range Formats {
+		names[i] = string(format)
+	}
+
+	cmd.RegisterFlagCompletionFunc(outputFlagName, cobra.FixedCompletions(names, cobra.ShellCompDirectiveNoFileComp)) //nolint:errcheck
+}
+
+// GetOptions returns the output options of a command.
+func GetOptions(cmd *cobra.Command) (Options, error) {
+	opts := Options{Format: FormatTable}
+
+	if format, err := cmd.Flags().GetString(outputFlagName); err == nil {
+		opts.Format = Format(strings.ToLower(format))
+	}
+
+	if noTrunc, err := cmd.Flags().GetBool(noTruncFlagName); err == nil {
+		opts.NoTrunc = noTrunc
+	}
+
+	if !slices.Contains(Formats, opts.Format) {
+		return Options{}, fmt.Errorf("invalid output format %q, must be one of %s", opts.Format, formatNames())
+	}
+
+	return opts, nil
+}
+
+// Print renders the result to the standard output of the command.
+func Print(cmd *cobra.Command, out Output) error {
+	opts, err := GetOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	return Render(cmd.OutOrStdout(), opts, out)
+}
+
+// Render writes the result in the given format.
+func Render(w io.Writer, opts Options, out Output) error {
+	switch opts.Format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal output: %w", err)
+		}
+
+		_, err = fmt.Fprintf(w, "%s\n", data)
+
+		return err //nolint:wrapcheck
+
+	case FormatTable:
+		return renderTable(w, out.Table(), opts.NoTrunc)
+
+	case FormatPlain:
+		for _, line := range out.Plain() {
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err //nolint:wrapcheck
+			}
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("invalid output format %q", opts.Format)
+}
+
+func renderTable(w io.Writer, table Table, noTrunc bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	fmt.Fprintln(tw, strings.Join(table.Headers, "\t"))
+
+	for _, row := range table.Rows {
+		cells := slices.Clone(row)
+
+		if !noTrunc {
+			for _, i := range table.Truncate {
+				if i < len(cells) {
+					cells[i] = Truncate(cells[i])
+				}
+			}
+		}
+
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	return tw.Flush() //nolint:wrapcheck
+}
+
+// Truncate shortens a long identifier, keeping its start and end so that it stays recognizable.
+// Digests keep their algorithm prefix, e.g. "sha256:4f2c...9e1a".
+func Truncate(id string) string {
+	prefix := ""
+	if algorithm, digest, ok := strings.Cut(id, ":"); ok {
+		prefix, id = algorithm+":", digest
+	}
+
+	if len(id) <= truncatedLength {
+		return prefix + id
+	}
+
+	keep := (truncatedLength - len(ellipsis)) / 2 //nolint:mnd
+
+	return prefix + id[:keep] + ellipsis + id[len(id)-keep:]
+}
+
+func formatNames() string {
+	names := make([]string, len(Formats))
+	for i, format := range Formats {
+		names[i] = string(format)
+	}
+
+	return strings.Join(names, "|")
+}
@@ -0,0 +1,162 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package presenter
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+const testDigest = "sha256:4f2c8e0b1d7a6c3e5f9b2a8d4c6e1f3a5b7d9c2e4f6a8b0d1c3e5f7a9b2d4e6f"
+
+var testOutputs = map[string]Output{
+	"push": PushOutput{
+		Digest:     testDigest,
+		Repository: "my-org/my-agent",
+	},
+	"bulk_push": BulkPushOutput{
+		Repository: "my-org/my-agent",
+		Files: []PushFileOutput{
+			{File: "agents/a.json", Digest: testDigest, Status: "pushed"},
+			{File: "agents/long-agent-name.json", Status: "failed", Error: "permission denied"},
+		},
+	},
+	"orgs_list": OrganizationListOutput{
+		Organizations: []Organization{
+			{Name: "my-org", ID: "935a67e3-0276-4f61-b1ff-000fb163eedd", Role: "ROLE_ADMIN"},
+			{Name: "other", ID: "2b1f0c5e-8d4a-4e6b-9c3f-7a1e5d9b0c2f", Role: "ROLE_VIEWER"},
+		},
+	},
+	"apikey_list": APIKeyListOutput{
+		Organization: "my-org",
+		APIKeys: []APIKey{
+			{ClientID: "client-1", RoleName: "ROLE_EDITOR"},
+		},
+	},
+	"repository_list": RepositoryListOutput{
+		Owner: "my-org",
+		Repositories: []Repository{
+			{Name: "my-org/my-agent", ID: "5d0c1a2b-3e4f-4a6b-8c7d-9e0f1a2b3c4d", UpdatedAt: "2025-10-01T12:00:00Z"},
+			{Name: "my-org/internal", ID: "7f3e2d1c-0b9a-4876-a5b4-c3d2e1f0a9b8", Private: true},
+		},
+	},
+	"repository_versions": RepositoryVersionsOutput{
+		Repository: "my-org/my-agent",
+		Versions: []RepositoryVersion{
+			{Version: "v1.1.0", Digest: testDigest, CreatedAt: "2025-10-01T12:00:00Z"},
+			{Version: "v1.0.0", Digest: "sha256:0a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f9"},
+		},
+	},
+}
+
+func TestRender_Golden(t *testing.T) {
+	for name, out := range testOutputs {
+		for _, opts := range []Options{
+			{Format: FormatJSON},
+			{Format: FormatTable},
+			{Format: FormatTable, NoTrunc: true},
+			{Format: FormatPlain},
+		} {
+			golden := name + "." + string(opts.Format)
+			if opts.NoTrunc {
+				golden += ".no-trunc"
+			}
+
+			t.Run(golden, func(t *testing.T) {
+				var buf bytes.Buffer
+				if err := Render(&buf, opts, out); err != nil {
+					t.Fatalf("Render() unexpected error: %v", err)
+				}
+
+				assertGolden(t, golden+".golden", buf.String())
+			})
+		}
+	}
+}
+
+func assertGolden(t *testing.T, name, actual string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+
+	if *updateGolden {
+		if err := os.WriteFile(path, []byte(actual), 0o600); err != nil { //nolint:mnd
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+
+	if string(expected) != actual {
+		t.Errorf("output does not match %s\nexpected:\n%s\nactual:\n%s", name, expected, actual)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{id: "short", want: "short"},
+		{id: testDigest, want: "sha256:4f2c8e0b...9b2d4e6f"},
+		{id: "935a67e3-0276-4f61-b1ff-000fb163eedd", want: "935a67e3...b163eedd"},
+		{id: "bafyreigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi", want: "bafyreig...y55fbzdi"},
+	}
+
+	for _, tt := range tests {
+		if got := Truncate(tt.id); got != tt.want {
+			t.Errorf("Truncate(%q) = %q, expected %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestGetOptions(t *testing.T) {
+	newCommand := func(args ...string) *cobra.Command {
+		root := &cobra.Command{Use: "hub"}
+		AddFlags(root, FormatTable)
+
+		child := &cobra.Command{Use: "list", RunE: func(*cobra.Command, []string) error { return nil }}
+		root.AddCommand(child)
+
+		root.SetArgs(append([]string{"list"}, args...))
+
+		if err := root.Execute(); err != nil {
+			t.Fatalf("failed to execute command: %v", err)
+		}
+
+		return child
+	}
+
+	tests := []struct {
+		args    []string
+		want    Options
+		wantErr bool
+	}{
+		{args: nil, want: Options{Format: FormatTable}},
+		{args: []string{"--output", "JSON", "--no-trunc"}, want: Options{Format: FormatJSON, NoTrunc: true}},
+		{args: []string{"--output", "plain"}, want: Options{Format: FormatPlain}},
+		{args: []string{"--output", "yaml"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		opts, err := GetOptions(newCommand(tt.args...))
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("GetOptions(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+		}
+
+		if opts != tt.want {
+			t.Errorf("GetOptions(%v) = %+v, expected %+v", tt.args, opts, tt.want)
+		}
+	}
+}
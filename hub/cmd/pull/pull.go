@@ -8,8 +8,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
-	hubClient "github.com/agntcy/dir/hub/client/hub"
+	"github.com/agntcy/dir/hub/cmd/completion"
 	hubOptions "github.com/agntcy/dir/hub/cmd/options"
 	service "github.com/agntcy/dir/hub/service"
 	authUtils "github.com/agntcy/dir/hub/utils/auth"
@@ -17,7 +18,7 @@ import (
 )
 
 // NewCommand creates the "pull" command for the Agent Hub CLI.
-// It pulls a record from the hub by digest or repository:version and prints the result.
+// It pulls a record from the hub by digest or repository[:version] and prints the result.
 // Returns the configured *cobra.Command.
 func NewCommand(hubOpts *hubOptions.HubOptions) *cobra.Command {
 	cmd := &cobra.Command{
@@ -27,8 +28,12 @@ func NewCommand(hubOpts *hubOptions.HubOptions) *cobra.Command {
 
 Parameters:
   <agent_ref>    Agent reference in one of the following formats:
-                - sha256:<hash>    : Pull by digest
-                - <repo>:<version> : Pull by repository and version
+                - sha256:<hash>                 : Pull by digest
+                - <owner>/<name>[:<version>]    : Pull by repository name and version
+                - <repository-id>[:<version>]   : Pull by repository ID and version
+                Without a version, the most recently pushed version is pulled.
+
+  --output-file  Write the record to a file instead of standard output
 
 Authentication:
   API key authentication can be provided via:
@@ -45,6 +50,12 @@ Examples:
   # Pull agent by repository name and version
   dirctl hub pull repo-name:v1.0.0
 
+  # Pull the latest version of a repository to a file
+  dirctl hub pull my-org/my-agent --output-file agent.json
+
+  # Pull agent by repository ID and version
+  dirctl hub pull 123e4567-e89b-12d3-a456-426614174000:v1.0.0
+
   # Pull using API key file (JSON format)
   # File content example:
   # {
@@ -61,6 +72,7 @@ Examples:
   # Pull using session file (after login)
   dirctl hub login
   dirctl hub pull repo-name:v1.0.0`,
+		ValidArgsFunction: completion.Repositories(hubOpts),
 	}
 
 	opts := hubOptions.NewHubPullOptions(hubOpts)
@@ -69,6 +81,7 @@ Examples:
 	var apikeyFile string
 
 	cmd.Flags().StringVar(&apikeyFile, "apikey-file", "", `Path to a JSON file containing API key credentials (format: {"client_id": "...", "secret": "..."})`)
+	cmd.Flags().StringVar(&opts.OutputFile, "output-file", "", "Path of the file to write the record to, instead of standard output")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -84,14 +97,14 @@ Examples:
 			return fmt.Errorf("failed to get or create session: %w", err)
 		}
 
-		hc, err := hubClient.New(currentSession.HubBackendAddress)
+		hc, err := hubOpts.NewClient(currentSession.HubBackendAddress)
 		if err != nil {
 			return fmt.Errorf("failed to create hub client: %w", err)
 		}
 
-		agentID, err := service.ParseAgentID(args[0])
+		agentID, err := service.ResolveAgentID(cmd.Context(), hc, args[0], currentSession)
 		if err != nil {
-			return fmt.Errorf("invalid agent id: %w", err)
+			return fmt.Errorf("failed to resolve agent id: %w", err)
 		}
 
 		prettyModel, err := service.PullAgent(cmd.Context(), hc, agentID, currentSession)
@@ -99,6 +112,14 @@ Examples:
 			return fmt.Errorf("failed to pull agent: %w", err)
 		}
 
+		if opts.OutputFile != "" {
+			if err := os.WriteFile(filepath.Clean(opts.OutputFile), append(prettyModel, '\n'), 0o600); err != nil {
+				return fmt.Errorf("failed to write record: %w", err)
+			}
+
+			return nil
+		}
+
 		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", string(prettyModel))
 
 		return nil
@@ -0,0 +1,198 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pull
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/agntcy/dir/hub/api/v1alpha1"
+	hubClient "github.com/agntcy/dir/hub/client/hub"
+	hubOptions "github.com/agntcy/dir/hub/cmd/options"
+	"github.com/agntcy/dir/hub/service"
+	"github.com/agntcy/dir/hub/sessionstore"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// mockHubClient serves records of repositories, keyed by repository name or ID.
+type mockHubClient struct {
+	hubClient.Client
+
+	records map[string][]*v1alpha1.Record
+	agents  map[string]string // digest -> record JSON
+	err     error
+
+	pulled *v1alpha1.RecordIdentifier
+}
+
+func (m *mockHubClient) ListRepositoryRecords(_ context.Context, req *v1alpha1.ListRepositoryRecordsRequest, _ ...grpc.CallOption) (*v1alpha1.ListRepositoryRecordsResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	key := req.GetId().GetName()
+	if key == "" {
+		key = req.GetId().GetDbId()
+	}
+
+	records, ok := m.records[key]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "repository not found")
+	}
+
+	return &v1alpha1.ListRepositoryRecordsResponse{
+		PaginatedResponse: &v1alpha1.PaginatedResponse{Pages: 1},
+		Records:           records,
+	}, nil
+}
+
+func (m *mockHubClient) PullAgent(_ context.Context, req *v1alpha1.PullRecordRequest) ([]byte, error) {
+	m.pulled = req.GetId()
+
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	agent, ok := m.agents[req.GetId().GetDigest()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "record not found")
+	}
+
+	return []byte(agent), nil
+}
+
+func newSession(t *testing.T) *sessionstore.HubSession {
+	t.Helper()
+
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "user",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	return &sessionstore.HubSession{
+		Tokens:     &sessionstore.Tokens{AccessToken: accessToken, IDToken: "id", RefreshToken: "refresh"},
+		AuthConfig: &sessionstore.AuthConfig{HubBackendAddress: "hub.example.org:443"},
+	}
+}
+
+func runCommand(t *testing.T, client hubClient.Client, session *sessionstore.HubSession, args ...string) error {
+	t.Helper()
+
+	t.Setenv("DIRCTL_CLIENT_ID", "")
+	t.Setenv("DIRCTL_CLIENT_SECRET", "")
+
+	root := &cobra.Command{Use: "hub"}
+	hubOpts := hubOptions.NewHubOptions(hubOptions.NewBaseOption(), root)
+	hubOpts.NewClient = func(string) (hubClient.Client, error) { return client, nil }
+
+	root.AddCommand(NewCommand(hubOpts))
+	root.SetArgs(append([]string{"pull"}, args...))
+
+	return root.ExecuteContext(context.WithValue(t.Context(), sessionstore.SessionContextKey, session)) //nolint:wrapcheck
+}
+
+func TestPullCommand(t *testing.T) {
+	const repositoryID = "123e4567-e89b-12d3-a456-426614174000"
+
+	versions := []*v1alpha1.Record{
+		{Version: "v1.0.0", Digest: "sha256:old", CreatedAt: timestamppb.New(time.Unix(1000, 0))},
+		{Version: "v1.1.0", Digest: "sha256:new", CreatedAt: timestamppb.New(time.Unix(2000, 0))},
+	}
+
+	client := &mockHubClient{
+		records: map[string][]*v1alpha1.Record{
+			"my-org/my-agent": versions,
+			repositoryID:      versions,
+		},
+		agents: map[string]string{
+			"sha256:old": `{"name":"my-org/my-agent","version":"v1.0.0"}`,
+			"sha256:new": `{"name":"my-org/my-agent","version":"v1.1.0"}`,
+		},
+	}
+
+	pull := func(t *testing.T, ref string) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), "agent.json")
+
+		if err := runCommand(t, client, newSession(t), ref, "--output-file", path); err != nil {
+			t.Fatalf("pull %s unexpected error: %v", ref, err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read pulled record: %v", err)
+		}
+
+		return string(data)
+	}
+
+	t.Run("latest version by repository name", func(t *testing.T) {
+		if out := pull(t, "my-org/my-agent"); !strings.Contains(out, `"version": "v1.1.0"`) {
+			t.Errorf("pulled record = %s, want latest version", out)
+		}
+	})
+
+	t.Run("version by repository ID", func(t *testing.T) {
+		if out := pull(t, repositoryID+":v1.0.0"); !strings.Contains(out, `"version": "v1.0.0"`) {
+			t.Errorf("pulled record = %s, want v1.0.0", out)
+		}
+
+		if client.pulled.GetDigest() != "sha256:old" {
+			t.Errorf("pulled %v, want digest sha256:old", client.pulled)
+		}
+	})
+
+	t.Run("version by repository name is resolved by the hub", func(t *testing.T) {
+		err := runCommand(t, client, newSession(t), "my-org/my-agent:v2.0.0")
+		if !errors.Is(err, service.ErrNotFound) || !strings.Contains(err.Error(), "record my-org/my-agent:v2.0.0: not found") {
+			t.Errorf("pull error = %v, want record not found", err)
+		}
+
+		if client.pulled.GetRepoVersionId().GetVersion() != "v2.0.0" {
+			t.Errorf("pulled %v, want repository version v2.0.0", client.pulled)
+		}
+	})
+
+	t.Run("unknown version by repository ID", func(t *testing.T) {
+		err := runCommand(t, client, newSession(t), repositoryID+":v2.0.0")
+		if !errors.Is(err, service.ErrNotFound) || !strings.Contains(err.Error(), "version v2.0.0 of repository") {
+			t.Errorf("pull error = %v, want version not found", err)
+		}
+	})
+
+	t.Run("unknown repository", func(t *testing.T) {
+		err := runCommand(t, client, newSession(t), "my-org/other")
+		if !errors.Is(err, service.ErrNotFound) || !strings.Contains(err.Error(), "repository my-org/other: not found") {
+			t.Errorf("pull error = %v, want repository not found", err)
+		}
+	})
+
+	t.Run("rejected credentials", func(t *testing.T) {
+		err := runCommand(t, &mockHubClient{err: status.Error(codes.PermissionDenied, "forbidden")}, newSession(t), "sha256:new")
+		if !errors.Is(err, service.ErrUnauthorized) || !strings.Contains(err.Error(), "permission denied") {
+			t.Errorf("pull error = %v, want ErrUnauthorized", err)
+		}
+	})
+
+	t.Run("not logged in", func(t *testing.T) {
+		err := runCommand(t, client, &sessionstore.HubSession{}, "my-org/my-agent")
+		if err == nil || !strings.Contains(err.Error(), "dirctl hub login") {
+			t.Errorf("pull error = %v, want login hint", err)
+		}
+	})
+}
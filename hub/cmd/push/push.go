@@ -10,27 +10,63 @@ import (
 	"io"
 	"os"
 
+	baseauth "github.com/agntcy/dir/hub/auth"
 	hubClient "github.com/agntcy/dir/hub/client/hub"
+	"github.com/agntcy/dir/hub/cmd/completion"
 	hubOptions "github.com/agntcy/dir/hub/cmd/options"
+	"github.com/agntcy/dir/hub/cmd/presenter"
 	"github.com/agntcy/dir/hub/service"
+	"github.com/agntcy/dir/hub/sessionstore"
+	"github.com/agntcy/dir/hub/spool"
 	authUtils "github.com/agntcy/dir/hub/utils/auth"
+	"github.com/agntcy/dir/hub/utils/file"
 	"github.com/spf13/cobra"
 )
 
+// Visibilities of repositories created with --create.
+const (
+	visibilityPublic  = "public"
+	visibilityPrivate = "private"
+)
+
 // NewCommand creates the "push" command for the Agent Hub CLI.
 // It pushes a record to the hub by repository name or ID, reading the record from a file or stdin.
 // Returns the configured *cobra.Command.
 func NewCommand(hubOpts *hubOptions.HubOptions) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "push <repository> {<record.json> | --stdin} ",
+		Use:   "push <repository> {<record.json>... | <directory> | <glob> | --stdin} ",
 		Short: "Push record to Agent Hub",
 		Long: `Push a record to the Agent Hub.
 
 Parameters:
   <repository>    Repository name or repository ID. Note that the repository name must be in the format '<org-name>/<name>' and match the name in the record being pushed.
-  <record.json>   Path to the record file (optional)
+  <record.json>   Path to the record file, a directory of *.json files or a glob pattern (optional, repeatable)
   --stdin         Read record from standard input (optional)
 
+Bulk push:
+  When more than one file is given, all files are pushed using a single session and a
+  summary of file, digest and status is printed. The session is refreshed when its
+  access token is about to expire. Files whose OASF version cannot be detected are
+  skipped with a warning.
+
+  --continue-on-error   Keep pushing remaining files after a failure (default)
+  --fail-fast           Stop pushing remaining files after the first failure
+  --concurrency N       Number of files to push in parallel (default 1)
+
+Offline queue:
+  Pushes can be queued in a local spool directory and replayed with 'dirctl hub flush'.
+  Queued pushes are only replayed by the user and hub they were queued for.
+
+  --offline             Queue pushes without contacting the hub, using the session of 'dirctl hub login'
+  --queue-on-failure    Queue pushes that fail because the hub is unreachable
+
+Repository creation:
+  Pushes to a repository name fail if the repository does not exist, unless it is created first.
+  Repository IDs refer to existing repositories and are not checked.
+
+  --create              Create the repository in the organization of its name if it does not exist
+  --visibility          Visibility of the created repository, public or private (default private)
+
 Authentication:
   API key authentication can be provided via:
   1. API key file: --apikey-file (JSON file with API key credentials)
@@ -43,12 +79,22 @@ Examples:
   # Push record to a repository by name
   dirctl hub push repo-name record.json
 
+  # Print only the digest of the pushed record, e.g. for scripting
+  dirctl hub push repo-name record.json --output plain
+
+  # Create the repository if it does not exist yet, then push to it
+  dirctl hub push org-name/new-repo record.json --create --visibility public
+
   # Push record to a repository by ID
   dirctl hub push 123e4567-e89b-12d3-a456-426614174000 record.json
 
   # Push record from stdin
   dirctl hub push repo-name --stdin < record.json
 
+  # Push all records in a directory or matching a glob pattern
+  dirctl hub push repo-name ./models/
+  dirctl hub push repo-name './models/*.json' --concurrency 4 --fail-fast
+
   # Push using API key file (JSON format)
   # File content example:
   # {
@@ -64,7 +110,12 @@ Examples:
 
   # Push using session file (after login)
   dirctl hub login
-  dirctl hub push repo-name record.json`,
+  dirctl hub push repo-name record.json
+
+  # Queue a push while offline and replay it later
+  dirctl hub push repo-name record.json --offline
+  dirctl hub flush`,
+		ValidArgsFunction: completion.Repositories(hubOpts),
 	}
 
 	opts := hubOptions.NewHubPushOptions(hubOpts, cmd)
@@ -79,18 +130,48 @@ Examples:
 		cmd.SetErr(os.Stderr)
 
 		// Authenticate using either API key file or session file
-		currentSession, err := authUtils.GetOrCreateSession(cmd, opts.ServerAddress, "", "", apikeyFile, false)
+		getSession := func() (*sessionstore.HubSession, error) {
+			if opts.Offline {
+				return offlineSession(cmd)
+			}
+
+			// Sessions are cached, so this only re-authenticates when the token is about to expire
+			return authUtils.GetOrCreateSession(cmd, opts.ServerAddress, "", "", apikeyFile, false)
+		}
+
+		currentSession, err := getSession()
 		if err != nil {
 			return fmt.Errorf("failed to get or create session: %w", err)
 		}
 
-		hc, err := hubClient.New(currentSession.HubBackendAddress)
+		hc, err := hubOpts.NewClient(currentSession.HubBackendAddress)
 		if err != nil {
 			return fmt.Errorf("failed to create hub client: %w", err)
 		}
 
-		if len(args) > 2 { //nolint:mnd
-			return errors.New("the following arguments could be given: <repository>:<version> [record.json]")
+		if len(args) < 1 {
+			return errors.New("the following arguments could be given: <repository> [record.json...]")
+		}
+
+		// TODO: Push based on repoName and version misleading
+		repository := service.ParseRepoTagID(args[0])
+
+		var queue *service.PushQueue
+		if opts.Offline || opts.QueueOnFailure {
+			queue = &service.PushQueue{
+				Spool:     spool.New(file.GetSpoolDirPath()),
+				Offline:   opts.Offline,
+				OnFailure: opts.QueueOnFailure,
+			}
+		}
+
+		if err := ensureRepository(cmd, hc, args[0], currentSession, opts); err != nil {
+			return err
+		}
+
+		// Push multiple files if more than a single regular file is given
+		if len(args) > 2 || (len(args) == 2 && !isRegularFile(args[1])) { //nolint:mnd
+			return runBulkPush(cmd, hc, args[0], args[1:], repository, currentSession, getSession, queue, opts)
 		}
 
 		fpath := ""
@@ -108,22 +189,167 @@ Examples:
 			return fmt.Errorf("failed to read data: %w", err)
 		}
 
-		// TODO: Push based on repoName and version misleading
-		repository := service.ParseRepoTagID(args[0])
-
-		resp, err := service.PushAgent(cmd.Context(), hc, agentBytes, repository, currentSession)
+		resp, queueID, err := service.PushOrQueueAgent(cmd.Context(), hc, agentBytes, repository, currentSession, queue)
 		if err != nil {
 			return fmt.Errorf("failed to push agent: %w", err)
 		}
 
-		fmt.Fprintln(cmd.OutOrStdout(), resp.GetId().GetDigest())
+		if queueID != "" {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Queued push to %s, replay it with 'dirctl hub flush'\n", args[0])
 
-		return nil
+			return presenter.Print(cmd, presenter.QueuedPushOutput{
+				QueueID:    queueID,
+				Repository: args[0],
+			})
+		}
+
+		return presenter.Print(cmd, presenter.PushOutput{
+			Digest:     resp.GetId().GetDigest(),
+			Repository: args[0],
+		})
 	}
 
 	return cmd
 }
 
+func runBulkPush(
+	cmd *cobra.Command,
+	hc hubClient.Client,
+	repositoryArg string,
+	patterns []string,
+	repository any,
+	session *sessionstore.HubSession,
+	getSession func() (*sessionstore.HubSession, error),
+	queue *service.PushQueue,
+	opts *hubOptions.HubPushOptions,
+) error {
+	paths, err := service.ExpandRecordPaths(patterns)
+	if err != nil {
+		return fmt.Errorf("failed to resolve record files: %w", err)
+	}
+
+	results := service.PushAgentFiles(cmd.Context(), hc, paths, repository, session, service.BulkPushOptions{
+		Concurrency: opts.Concurrency,
+		FailFast:    opts.FailFast || !opts.ContinueOnError,
+		GetSession:  getSession,
+		Queue:       queue,
+	})
+
+	output := presenter.BulkPushOutput{
+		Repository: repositoryArg,
+		Files:      make([]presenter.PushFileOutput, 0, len(results)),
+	}
+
+	failed, queued := 0, 0
+
+	for _, result := range results {
+		file := presenter.PushFileOutput{
+			File:   result.Path,
+			Digest: result.Digest,
+			Status: string(result.Status),
+
+			QueueID: result.QueueID,
+		}
+
+		if result.Err != nil {
+			file.Error = result.Err.Error()
+		}
+
+		output.Files = append(output.Files, file)
+
+		switch result.Status {
+		case service.PushStatusSkipped:
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipped %s: %v\n", result.Path, result.Err)
+		case service.PushStatusFailed:
+			failed++
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: failed to push %s: %v\n", result.Path, result.Err)
+		case service.PushStatusQueued:
+			queued++
+		case service.PushStatusPushed:
+		}
+	}
+
+	if queued > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Queued %d of %d files, replay them with 'dirctl hub flush'\n", queued, len(results))
+	}
+
+	if err := presenter.Print(cmd, output); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to push %d of %d files", failed, len(results))
+	}
+
+	return nil
+}
+
+// ensureRepository checks that the repository exists before pushing to it, creating it with --create.
+// Offline pushes are not checked, nor are pushes queued because the hub is unreachable.
+func ensureRepository(
+	cmd *cobra.Command,
+	hc hubClient.Client,
+	repository string,
+	session *sessionstore.HubSession,
+	opts *hubOptions.HubPushOptions,
+) error {
+	var create *service.CreateRepositoryOptions
+
+	if opts.Create {
+		if opts.Visibility != visibilityPublic && opts.Visibility != visibilityPrivate {
+			return fmt.Errorf("invalid visibility %q, expected %s or %s", opts.Visibility, visibilityPublic, visibilityPrivate)
+		}
+
+		create = &service.CreateRepositoryOptions{Private: opts.Visibility == visibilityPrivate}
+	} else if cmd.Flags().Changed("visibility") {
+		return errors.New("--visibility requires --create")
+	}
+
+	if opts.Offline {
+		if opts.Create {
+			return errors.New("--create cannot be used with --offline")
+		}
+
+		return nil
+	}
+
+	created, err := service.EnsureRepository(cmd.Context(), hc, repository, create, session)
+
+	switch {
+	case err == nil:
+	case errors.Is(err, service.ErrNotFound) && create == nil:
+		return fmt.Errorf("repository %s does not exist, pass --create to create it", repository)
+	case opts.QueueOnFailure && service.IsUnreachable(err):
+		return nil
+	default:
+		return err //nolint:wrapcheck
+	}
+
+	if created {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Created %s repository %s\n", opts.Visibility, repository)
+	}
+
+	return nil
+}
+
+// offlineSession returns the session of 'dirctl hub login' without refreshing it,
+// as offline pushes do not contact the hub.
+func offlineSession(cmd *cobra.Command) (*sessionstore.HubSession, error) {
+	session, ok := cmd.Context().Value(sessionstore.SessionContextKey).(*sessionstore.HubSession)
+	if !ok || !baseauth.HasLoginCreds(session) {
+		return nil, errors.New("offline pushes require a session\nuse `dirctl hub login` command to login")
+	}
+
+	return session, nil
+}
+
+func isRegularFile(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && info.Mode().IsRegular()
+}
+
 func getReader(fpath string, fromStdin bool) (io.ReadCloser, error) {
 	if fpath == "" && !fromStdin {
 		return nil, errors.New("if no path defined --stdin flag must be set")
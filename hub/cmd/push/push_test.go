@@ -0,0 +1,302 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package push
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	v1alpha1 "github.com/agntcy/dir/hub/api/v1alpha1"
+	hubClient "github.com/agntcy/dir/hub/client/hub"
+	hubOptions "github.com/agntcy/dir/hub/cmd/options"
+	"github.com/agntcy/dir/hub/service"
+	"github.com/agntcy/dir/hub/sessionstore"
+	"github.com/agntcy/dir/hub/spool"
+	"github.com/agntcy/dir/hub/utils/file"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockHubClient records pushed repositories and fails all pushes with err if it is set.
+// Repositories exist unless missing is set, in which case they are created unless createErr is set.
+type mockHubClient struct {
+	hubClient.Client
+
+	err    error
+	pushed []any
+
+	missing   bool
+	createErr error
+	created   []*v1alpha1.CreateRepositoryRequest
+}
+
+func (m *mockHubClient) GetRepository(_ context.Context, in *v1alpha1.GetRepositoryRequest, _ ...grpc.CallOption) (*v1alpha1.GetRepositoryResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	if m.missing && len(m.created) == 0 {
+		return nil, status.Error(codes.NotFound, "repository not found")
+	}
+
+	return &v1alpha1.GetRepositoryResponse{Repository: &v1alpha1.Repository{Name: in.GetId().GetName()}}, nil
+}
+
+func (m *mockHubClient) ListOrganizations(context.Context, *v1alpha1.ListOrganizationsRequest, ...grpc.CallOption) (*v1alpha1.ListOrganizationsResponse, error) {
+	return &v1alpha1.ListOrganizationsResponse{
+		Organizations: []*v1alpha1.OrganizationWithRole{{Organization: &v1alpha1.Organization{Id: "org-id", Name: "org"}}},
+	}, nil
+}
+
+func (m *mockHubClient) CreateRepository(_ context.Context, in *v1alpha1.CreateRepositoryRequest, _ ...grpc.CallOption) (*v1alpha1.CreateRepositoryResponse, error) {
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+
+	m.created = append(m.created, in)
+
+	return &v1alpha1.CreateRepositoryResponse{Id: "repo-id"}, nil
+}
+
+func (m *mockHubClient) PushAgent(_ context.Context, _ []byte, repository any) (*v1alpha1.PushRecordResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	m.pushed = append(m.pushed, repository)
+
+	return &v1alpha1.PushRecordResponse{
+		Id: &v1alpha1.RecordIdentifierResponse{Digest: "sha256:test"},
+	}, nil
+}
+
+func newSession(t *testing.T) *sessionstore.HubSession {
+	t.Helper()
+
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "user",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	return &sessionstore.HubSession{
+		Tokens:     &sessionstore.Tokens{AccessToken: accessToken, IDToken: "id", RefreshToken: "refresh"},
+		AuthConfig: &sessionstore.AuthConfig{HubBackendAddress: "hub.example.org:443"},
+	}
+}
+
+func writeRecord(t *testing.T) string {
+	t.Helper()
+
+	data, err := corev1.New(&typesv1alpha0.Record{
+		Name:          "org/agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "v0.3.1",
+	}).Marshal()
+	if err != nil {
+		t.Fatalf("failed to marshal record: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "agent.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write record: %v", err)
+	}
+
+	return path
+}
+
+func runCommand(t *testing.T, client hubClient.Client, args ...string) error {
+	t.Helper()
+
+	// Queued pushes are stored below the home directory
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DIRCTL_CLIENT_ID", "")
+	t.Setenv("DIRCTL_CLIENT_SECRET", "")
+
+	base := hubOptions.NewBaseOption()
+
+	root := &cobra.Command{Use: "hub"}
+	hubOpts := hubOptions.NewHubOptions(base, root)
+	hubOpts.NewClient = func(string) (hubClient.Client, error) { return client, nil }
+
+	root.AddCommand(NewCommand(hubOpts))
+
+	if err := base.Register(); err != nil {
+		t.Fatalf("failed to register flags: %v", err)
+	}
+
+	root.SetArgs(append([]string{"push"}, args...))
+
+	return root.ExecuteContext(context.WithValue(t.Context(), sessionstore.SessionContextKey, newSession(t))) //nolint:wrapcheck
+}
+
+func queuedPushes(t *testing.T) []string {
+	t.Helper()
+
+	ids, err := spool.New(file.GetSpoolDirPath()).List()
+	if err != nil {
+		t.Fatalf("failed to list queued pushes: %v", err)
+	}
+
+	return ids
+}
+
+func TestPushCommand(t *testing.T) {
+	record := writeRecord(t)
+
+	t.Run("push", func(t *testing.T) {
+		client := &mockHubClient{}
+
+		if err := runCommand(t, client, "org/agent", record, "--queue-on-failure"); err != nil {
+			t.Fatalf("push unexpected error: %v", err)
+		}
+
+		if len(client.pushed) != 1 || len(queuedPushes(t)) != 0 {
+			t.Errorf("expected 1 push and no queued pushes, got %d pushes", len(client.pushed))
+		}
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		client := &mockHubClient{}
+
+		if err := runCommand(t, client, "org/agent", record, "--offline"); err != nil {
+			t.Fatalf("push unexpected error: %v", err)
+		}
+
+		if len(client.pushed) != 0 || len(queuedPushes(t)) != 1 {
+			t.Errorf("expected 1 queued push and no pushes, got %d pushes", len(client.pushed))
+		}
+	})
+
+	t.Run("queue on failure", func(t *testing.T) {
+		client := &mockHubClient{err: status.Error(codes.Unavailable, "connection refused")}
+
+		if err := runCommand(t, client, "org/agent", record, "--queue-on-failure"); err != nil {
+			t.Fatalf("push unexpected error: %v", err)
+		}
+
+		if len(queuedPushes(t)) != 1 {
+			t.Error("expected 1 queued push")
+		}
+	})
+
+	t.Run("unreachable hub", func(t *testing.T) {
+		client := &mockHubClient{err: status.Error(codes.Unavailable, "connection refused")}
+
+		if err := runCommand(t, client, "org/agent", record); err == nil {
+			t.Fatal("push expected an error")
+		}
+
+		if len(queuedPushes(t)) != 0 {
+			t.Error("expected no queued pushes without --queue-on-failure")
+		}
+	})
+}
+
+func TestPushCommandRepositoryCreation(t *testing.T) {
+	record := writeRecord(t)
+
+	t.Run("missing repository", func(t *testing.T) {
+		client := &mockHubClient{missing: true}
+
+		err := runCommand(t, client, "org/agent", record)
+		if err == nil || !strings.Contains(err.Error(), "repository org/agent does not exist, pass --create to create it") {
+			t.Fatalf("expected a missing repository error, got %v", err)
+		}
+
+		if len(client.pushed) != 0 || len(client.created) != 0 {
+			t.Errorf("expected no pushes and no created repositories, got %d pushes", len(client.pushed))
+		}
+	})
+
+	t.Run("create", func(t *testing.T) {
+		client := &mockHubClient{missing: true}
+
+		if err := runCommand(t, client, "org/agent", record, "--create", "--visibility", "public"); err != nil {
+			t.Fatalf("push unexpected error: %v", err)
+		}
+
+		if len(client.created) != 1 || len(client.pushed) != 1 {
+			t.Fatalf("expected 1 created repository and 1 push, got %d and %d", len(client.created), len(client.pushed))
+		}
+
+		created := client.created[0]
+		if created.GetName() != "org/agent" || created.GetOrganizationId() != "org-id" || created.GetPrivacySettings().GetPrivate() {
+			t.Errorf("unexpected create request: %v", created)
+		}
+	})
+
+	t.Run("existing repository", func(t *testing.T) {
+		client := &mockHubClient{}
+
+		if err := runCommand(t, client, "org/agent", record, "--create"); err != nil {
+			t.Fatalf("push unexpected error: %v", err)
+		}
+
+		if len(client.created) != 0 || len(client.pushed) != 1 {
+			t.Errorf("expected no created repositories and 1 push, got %d and %d", len(client.created), len(client.pushed))
+		}
+	})
+
+	t.Run("create permission denied", func(t *testing.T) {
+		client := &mockHubClient{missing: true, createErr: status.Error(codes.PermissionDenied, "forbidden")}
+
+		err := runCommand(t, client, "org/agent", record, "--create")
+		if !errors.Is(err, service.ErrCreateDenied) {
+			t.Fatalf("expected ErrCreateDenied, got %v", err)
+		}
+
+		if errors.Is(err, service.ErrUnauthorized) {
+			t.Error("create permission errors must be distinct from push permission errors")
+		}
+
+		if len(client.pushed) != 0 {
+			t.Error("expected no pushes")
+		}
+	})
+
+	t.Run("push permission denied", func(t *testing.T) {
+		client := &deniedPushClient{mockHubClient: &mockHubClient{}, err: status.Error(codes.PermissionDenied, "forbidden")}
+
+		err := runCommand(t, client, "org/agent", record, "--create")
+		if err == nil || errors.Is(err, service.ErrCreateDenied) || !strings.Contains(err.Error(), "failed to push agent") {
+			t.Fatalf("expected a push error distinct from ErrCreateDenied, got %v", err)
+		}
+	})
+
+	t.Run("repository ID", func(t *testing.T) {
+		client := &mockHubClient{missing: true}
+
+		if err := runCommand(t, client, "123e4567-e89b-12d3-a456-426614174000", record); err != nil {
+			t.Fatalf("push unexpected error: %v", err)
+		}
+
+		if len(client.pushed) != 1 {
+			t.Error("expected repository IDs to be pushed without checking the repository")
+		}
+	})
+}
+
+// deniedPushClient is a mockHubClient whose repositories exist but whose pushes fail with err.
+type deniedPushClient struct {
+	*mockHubClient
+
+	err error
+}
+
+func (d *deniedPushClient) PushAgent(context.Context, []byte, any) (*v1alpha1.PushRecordResponse, error) {
+	return nil, d.err
+}
@@ -0,0 +1,93 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package versions provides the CLI command for listing the record versions of a repository in the Agent Hub.
+package versions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/hub/cmd/completion"
+	hubOptions "github.com/agntcy/dir/hub/cmd/options"
+	"github.com/agntcy/dir/hub/cmd/presenter"
+	"github.com/agntcy/dir/hub/service"
+	authUtils "github.com/agntcy/dir/hub/utils/auth"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the "versions" command for the Agent Hub CLI.
+// It lists the record versions and digests of a repository, identified by name or ID.
+// Returns the configured *cobra.Command.
+func NewCommand(hubOpts *hubOptions.HubOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "versions <repository>",
+		Short: "List the record versions of a repository in Agent Hub",
+		Long: `List the record versions of a repository in the Agent Hub, with their digests.
+
+Parameters:
+  <repository>    Repository name in the format '<owner>/<name>' or repository ID
+
+Authentication:
+  API key authentication can be provided via:
+  1. API key file: --apikey-file (JSON file with API key credentials)
+  2. Environment variables: DIRCTL_CLIENT_ID and DIRCTL_CLIENT_SECRET
+  3. Session file created via 'dirctl hub login'
+
+  API key file takes precedence over environment variables, which take precedence over session file.
+
+Examples:
+  # List the versions of a repository
+  dirctl hub versions my-org/my-agent
+
+  # Print only the digests, e.g. for scripting
+  dirctl hub versions 123e4567-e89b-12d3-a456-426614174000 --output plain`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.Repositories(hubOpts),
+	}
+
+	// API key authentication flags
+	var apikeyFile string
+
+	cmd.Flags().StringVar(&apikeyFile, "apikey-file", "", `Path to a JSON file containing API key credentials (format: {"client_id": "...", "secret": "..."})`)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		// Authenticate using either API key file or session file
+		currentSession, err := authUtils.GetOrCreateSession(cmd, hubOpts.ServerAddress, "", "", apikeyFile, false)
+		if err != nil {
+			return fmt.Errorf("failed to get or create session: %w", err)
+		}
+
+		hc, err := hubOpts.NewClient(currentSession.HubBackendAddress)
+		if err != nil {
+			return fmt.Errorf("failed to create hub client: %w", err)
+		}
+
+		records, err := service.ListRepositoryVersions(cmd.Context(), hc, args[0], currentSession)
+		if err != nil {
+			return fmt.Errorf("failed to list versions: %w", err)
+		}
+
+		output := presenter.RepositoryVersionsOutput{
+			Repository: args[0],
+			Versions:   make([]presenter.RepositoryVersion, 0, len(records)),
+		}
+
+		for _, record := range records {
+			createdAt := ""
+			if record.GetCreatedAt() != nil {
+				createdAt = record.GetCreatedAt().AsTime().Format(time.RFC3339)
+			}
+
+			output.Versions = append(output.Versions, presenter.RepositoryVersion{
+				Version:   record.GetVersion(),
+				Digest:    record.GetDigest(),
+				CreatedAt: createdAt,
+			})
+		}
+
+		return presenter.Print(cmd, output)
+	}
+
+	return cmd
+}
@@ -8,7 +8,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/agntcy/dir/hub/cmd"
 	"github.com/agntcy/dir/hub/cmd/options"
@@ -61,3 +64,59 @@ func (h *hub) Run(ctx context.Context, args []string) error {
 
 	return nil
 }
+
+// Complete returns shell completions of the arguments of an Agent Hub CLI command.
+// The hidden completion command of cobra is run on the hub command tree, without the
+// pre-run hooks of the hub command, so that completing never logs in or refreshes tokens.
+// Errors yield no completions.
+func (h *hub) Complete(ctx context.Context, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := config.LoadConfig(); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	opts := options.NewBaseOption()
+	c := cmd.NewHubCommand(ctx, opts)
+	c.PersistentPreRunE = nil
+
+	if err := opts.Register(); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	outBuf := bytes.NewBuffer([]byte{})
+
+	c.SetOut(outBuf)
+	c.SetErr(io.Discard)
+	c.SetArgs(append(append([]string{cobra.ShellCompNoDescRequestCmd}, args...), toComplete))
+
+	if err := c.ExecuteContext(ctx); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return parseCompletions(outBuf.String())
+}
+
+// parseCompletions parses the output of the hidden completion command of cobra:
+// one completion per line, followed by a line with the directive prefixed by a colon.
+func parseCompletions(out string) ([]string, cobra.ShellCompDirective) {
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+
+	last := lines[len(lines)-1]
+	if !strings.HasPrefix(last, ":") {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	directive, err := strconv.Atoi(strings.TrimPrefix(last, ":"))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var completions []string
+
+	for _, line := range lines[:len(lines)-1] {
+		if line != "" {
+			completions = append(completions, line)
+		}
+	}
+
+	return completions, cobra.ShellCompDirective(directive)
+}
@@ -31,7 +31,7 @@ func PullAgent(
 		Id: agentID,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to pull agent: %w", err)
+		return nil, friendlyError(fmt.Errorf("failed to pull agent: %w", err), "record "+agentIDString(agentID))
 	}
 
 	var modelObj map[string]interface{}
@@ -47,6 +47,15 @@ func PullAgent(
 	return prettyModel, nil
 }
 
+// agentIDString returns the agent identifier as accepted by ParseAgentID.
+func agentIDString(agentID *v1alpha1.RecordIdentifier) string {
+	if repoVersion := agentID.GetRepoVersionId(); repoVersion != nil {
+		return repoVersion.GetRepositoryName() + ":" + repoVersion.GetVersion()
+	}
+
+	return agentID.GetDigest()
+}
+
 // ParseAgentID parses a string into an AgentIdentifier.
 // Accepts either a digest (sha256:<hash>), a repository:version format or a CID.
 func ParseAgentID(agentID string) (*v1alpha1.RecordIdentifier, error) {
@@ -0,0 +1,241 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	hubClient "github.com/agntcy/dir/hub/client/hub"
+	"github.com/agntcy/dir/hub/sessionstore"
+)
+
+// PushStatus describes the outcome of pushing a single file.
+type PushStatus string
+
+const (
+	PushStatusPushed  PushStatus = "pushed"
+	PushStatusFailed  PushStatus = "failed"
+	PushStatusSkipped PushStatus = "skipped"
+	PushStatusQueued  PushStatus = "queued"
+)
+
+// PushFileResult holds the result of pushing a single record file.
+type PushFileResult struct {
+	Path   string
+	Digest string
+	Status PushStatus
+	Err    error
+
+	// QueueID is the ID of the spool entry of queued pushes.
+	QueueID string
+}
+
+// BulkPushOptions configures PushAgentFiles.
+type BulkPushOptions struct {
+	// Concurrency is the maximum number of parallel pushes. Values below 1 mean sequential.
+	Concurrency int
+
+	// FailFast stops scheduling new pushes after the first failure.
+	FailFast bool
+
+	// GetSession, if set, is called before each push to get the session to push with,
+	// so that expiring tokens can be refreshed during long-running pushes.
+	// It must be safe for concurrent use.
+	GetSession func() (*sessionstore.HubSession, error)
+
+	// Queue, if set, queues pushes instead of or after failing to push them, see PushOrQueueAgent.
+	Queue *PushQueue
+}
+
+// ExpandRecordPaths resolves files, directories and glob patterns into a sorted
+// list of unique record file paths. Directories contribute their top-level *.json files.
+func ExpandRecordPaths(patterns []string) ([]string, error) {
+	seen := make(map[string]struct{})
+
+	var paths []string
+
+	add := func(path string) {
+		if _, ok := seen[path]; ok {
+			return
+		}
+
+		seen[path] = struct{}{}
+		paths = append(paths, path)
+	}
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %s", pattern)
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, fmt.Errorf("failed to stat %s: %w", match, err)
+			}
+
+			if !info.IsDir() {
+				add(match)
+
+				continue
+			}
+
+			dirMatches, err := filepath.Glob(filepath.Join(match, "*.json"))
+			if err != nil {
+				return nil, fmt.Errorf("failed to list directory %s: %w", match, err)
+			}
+
+			for _, dirMatch := range dirMatches {
+				add(dirMatch)
+			}
+		}
+	}
+
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// PushAgentFiles pushes every record file to the hub using a single client and session.
+// Files whose OASF schema version cannot be detected are skipped.
+// Results are returned in the same order as paths.
+func PushAgentFiles(
+	ctx context.Context,
+	hc hubClient.Client,
+	paths []string,
+	repository any,
+	session *sessionstore.HubSession,
+	opts BulkPushOptions,
+) []PushFileResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	concurrency := max(opts.Concurrency, 1)
+	sem := make(chan struct{}, concurrency)
+	results := make([]PushFileResult, len(paths))
+
+	var wg sync.WaitGroup
+
+	for i, path := range paths {
+		results[i] = PushFileResult{Path: path}
+
+		// Acquire a slot, or stop scheduling if we were cancelled
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Status = PushStatusSkipped
+			results[i].Err = ctx.Err()
+
+			continue
+		}
+
+		if ctx.Err() != nil {
+			<-sem
+
+			results[i].Status = PushStatusSkipped
+			results[i].Err = ctx.Err()
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(result *PushFileResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			pushSession := session
+
+			if opts.GetSession != nil {
+				var err error
+
+				if pushSession, err = opts.GetSession(); err != nil {
+					result.Status = PushStatusFailed
+					result.Err = fmt.Errorf("failed to get session: %w", err)
+				}
+			}
+
+			if result.Status != PushStatusFailed {
+				pushAgentFile(ctx, hc, result, repository, pushSession, opts.Queue)
+			}
+
+			if result.Status == PushStatusFailed && opts.FailFast {
+				cancel()
+			}
+		}(&results[i])
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func pushAgentFile(
+	ctx context.Context,
+	hc hubClient.Client,
+	result *PushFileResult,
+	repository any,
+	session *sessionstore.HubSession,
+	queue *PushQueue,
+) {
+	agentBytes, err := os.ReadFile(result.Path)
+	if err != nil {
+		result.Status = PushStatusFailed
+		result.Err = fmt.Errorf("failed to read file: %w", err)
+
+		return
+	}
+
+	if err := detectSchemaVersion(agentBytes); err != nil {
+		result.Status = PushStatusSkipped
+		result.Err = err
+
+		return
+	}
+
+	resp, queueID, err := PushOrQueueAgent(ctx, hc, agentBytes, repository, session, queue)
+	if err != nil {
+		result.Status = PushStatusFailed
+		result.Err = err
+
+		return
+	}
+
+	if queueID != "" {
+		result.Status = PushStatusQueued
+		result.QueueID = queueID
+
+		return
+	}
+
+	result.Status = PushStatusPushed
+	result.Digest = resp.GetId().GetDigest()
+}
+
+// detectSchemaVersion checks that the data is a record with a detectable OASF schema version.
+func detectSchemaVersion(data []byte) error {
+	record, err := corev1.UnmarshalRecord(data)
+	if err != nil {
+		return fmt.Errorf("failed to detect OASF version: %w", err)
+	}
+
+	if strings.TrimSpace(record.GetSchemaVersion()) == "" {
+		return errors.New("failed to detect OASF version: schema version is missing")
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	v1alpha1 "github.com/agntcy/dir/hub/api/v1alpha1"
+	hubClient "github.com/agntcy/dir/hub/client/hub"
+	"github.com/agntcy/dir/hub/sessionstore"
+)
+
+// mockHubClient counts PushAgent calls and fails for the configured payloads,
+// or for all payloads with err if it is set.
+type mockHubClient struct {
+	hubClient.Client
+
+	calls  atomic.Int32
+	mu     sync.Mutex
+	failOn map[string]bool
+	err    error
+}
+
+func (m *mockHubClient) PushAgent(_ context.Context, agent []byte, _ any) (*v1alpha1.PushRecordResponse, error) {
+	m.calls.Add(1)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	if m.failOn[string(agent)] {
+		return nil, errors.New("push rejected")
+	}
+
+	return &v1alpha1.PushRecordResponse{
+		Id: &v1alpha1.RecordIdentifierResponse{Digest: "sha256:test"},
+	}, nil
+}
+
+func writeRecord(t *testing.T, dir, name string) (string, []byte) {
+	t.Helper()
+
+	data, err := corev1.New(&typesv1alpha0.Record{
+		Name:          "org/" + name,
+		Version:       "v1.0.0",
+		SchemaVersion: "v0.3.1",
+	}).Marshal()
+	if err != nil {
+		t.Fatalf("failed to marshal record: %v", err)
+	}
+
+	path := filepath.Join(dir, name+".json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write record: %v", err)
+	}
+
+	return path, data
+}
+
+func TestPushAgentFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	writeRecord(t, dir, "a")
+	_, failing := writeRecord(t, dir, "b")
+	writeRecord(t, dir, "c")
+
+	if err := os.WriteFile(filepath.Join(dir, "invalid.json"), []byte(`{"foo": "bar"}`), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	paths, err := ExpandRecordPaths([]string{dir})
+	if err != nil {
+		t.Fatalf("failed to expand paths: %v", err)
+	}
+
+	if len(paths) != 4 { //nolint:mnd
+		t.Fatalf("expected 4 paths, got %d", len(paths))
+	}
+
+	hc := &mockHubClient{failOn: map[string]bool{string(failing): true}}
+
+	results := PushAgentFiles(t.Context(), hc, paths, "org/repo", &sessionstore.HubSession{}, BulkPushOptions{Concurrency: 2})
+
+	if calls := hc.calls.Load(); calls != 3 { //nolint:mnd
+		t.Errorf("expected 3 push calls, got %d", calls)
+	}
+
+	expected := map[string]PushStatus{
+		"a.json":       PushStatusPushed,
+		"b.json":       PushStatusFailed,
+		"c.json":       PushStatusPushed,
+		"invalid.json": PushStatusSkipped,
+	}
+
+	for _, result := range results {
+		if status := expected[filepath.Base(result.Path)]; status != result.Status {
+			t.Errorf("expected %s to be %s, got %s (%v)", result.Path, status, result.Status, result.Err)
+		}
+	}
+}
+
+func TestPushAgentFilesFailFast(t *testing.T) {
+	dir := t.TempDir()
+
+	_, failing := writeRecord(t, dir, "a")
+	writeRecord(t, dir, "b")
+	writeRecord(t, dir, "c")
+
+	paths, err := ExpandRecordPaths([]string{filepath.Join(dir, "*.json")})
+	if err != nil {
+		t.Fatalf("failed to expand paths: %v", err)
+	}
+
+	hc := &mockHubClient{failOn: map[string]bool{string(failing): true}}
+
+	results := PushAgentFiles(t.Context(), hc, paths, "org/repo", &sessionstore.HubSession{}, BulkPushOptions{FailFast: true})
+
+	if calls := hc.calls.Load(); calls != 1 {
+		t.Errorf("expected 1 push call, got %d", calls)
+	}
+
+	for _, result := range results[1:] {
+		if result.Status != PushStatusSkipped {
+			t.Errorf("expected %s to be skipped, got %s", result.Path, result.Status)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	v1alpha1 "github.com/agntcy/dir/hub/api/v1alpha1"
+	hubClient "github.com/agntcy/dir/hub/client/hub"
+	"github.com/agntcy/dir/hub/sessionstore"
+	"github.com/agntcy/dir/hub/spool"
+	"github.com/agntcy/dir/hub/utils/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrSessionMismatch indicates a queued push that was queued by another user or for another hub.
+var ErrSessionMismatch = errors.New("push was queued by another session")
+
+// PushQueue configures the queueing of pushes to a spool, replayed later with FlushSpool.
+type PushQueue struct {
+	Spool *spool.Spool
+
+	// Offline queues all pushes without contacting the hub.
+	Offline bool
+
+	// OnFailure queues pushes that failed because the hub is unreachable.
+	OnFailure bool
+}
+
+// FlushStatus describes the outcome of replaying a single queued push.
+type FlushStatus string
+
+const (
+	FlushStatusPushed  FlushStatus = "pushed"
+	FlushStatusFailed  FlushStatus = "failed"
+	FlushStatusSkipped FlushStatus = "skipped"
+)
+
+// FlushResult holds the result of replaying a single queued push.
+type FlushResult struct {
+	ID         string
+	Repository string
+	Digest     string
+	Status     FlushStatus
+	Err        error
+}
+
+// IsUnreachable returns true if the error indicates that the hub could not be reached.
+func IsUnreachable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return errors.Is(err, context.DeadlineExceeded)
+	}
+}
+
+// PushOrQueueAgent pushes an agent to the hub like PushAgent, or queues the push if the queue is
+// offline or the hub is unreachable and the queue is set to queue failed pushes.
+// Returns the ID of the spool entry instead of a response for queued pushes.
+func PushOrQueueAgent(
+	ctx context.Context,
+	hc hubClient.Client,
+	agentBytes []byte,
+	repository any,
+	session *sessionstore.HubSession,
+	queue *PushQueue,
+) (*v1alpha1.PushRecordResponse, string, error) {
+	if queue != nil && queue.Offline {
+		id, err := QueueAgent(queue.Spool, agentBytes, repository, session)
+
+		return nil, id, err
+	}
+
+	resp, err := PushAgent(ctx, hc, agentBytes, repository, session)
+	if err != nil && queue != nil && queue.OnFailure && IsUnreachable(err) {
+		id, queueErr := QueueAgent(queue.Spool, agentBytes, repository, session)
+		if queueErr != nil {
+			return nil, "", fmt.Errorf("%w, and failed to queue it: %w", err, queueErr)
+		}
+
+		return nil, id, nil
+	}
+
+	return resp, "", err
+}
+
+// QueueAgent stores a push of an agent to a repository in the spool, together with the hash of
+// the session identity, and returns the ID of the spool entry.
+func QueueAgent(sp *spool.Spool, agentBytes []byte, repository any, session *sessionstore.HubSession) (string, error) {
+	if err := detectSchemaVersion(agentBytes); err != nil {
+		return "", err
+	}
+
+	entry := &spool.Entry{
+		Record:   agentBytes,
+		AuthHash: authHash(session),
+	}
+
+	switch parsedRepo := repository.(type) {
+	case *v1alpha1.PushRecordRequest_RepositoryName:
+		entry.RepositoryName = parsedRepo.RepositoryName
+	case *v1alpha1.PushRecordRequest_RepositoryId:
+		entry.RepositoryID = parsedRepo.RepositoryId
+	default:
+		return "", fmt.Errorf("unknown repository type: %T", repository)
+	}
+
+	if err := sp.Add(entry); err != nil {
+		return "", fmt.Errorf("failed to queue push: %w", err)
+	}
+
+	return entry.ID, nil
+}
+
+// FlushSpool replays the queued pushes in the order they were queued.
+// Pushed entries are removed from the spool. Entries that do not match their checksum or were
+// queued by another session are skipped and kept, as are entries that failed to push.
+// Results are returned in the order of the entries.
+func FlushSpool(
+	ctx context.Context,
+	hc hubClient.Client,
+	sp *spool.Spool,
+	session *sessionstore.HubSession,
+) ([]FlushResult, error) {
+	ids, err := sp.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queued pushes: %w", err)
+	}
+
+	hash := authHash(session)
+	results := make([]FlushResult, 0, len(ids))
+
+	for _, id := range ids {
+		result := FlushResult{ID: id}
+
+		entry, err := sp.Load(id)
+
+		switch {
+		case errors.Is(err, spool.ErrTampered):
+			result.Status = FlushStatusSkipped
+			result.Err = err
+		case err != nil:
+			result.Status = FlushStatusFailed
+			result.Err = err
+		case entry.AuthHash != hash:
+			result.Repository = entry.Repository()
+			result.Status = FlushStatusSkipped
+			result.Err = ErrSessionMismatch
+		default:
+			result.Repository = entry.Repository()
+			flushEntry(ctx, hc, sp, entry, session, &result)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func flushEntry(
+	ctx context.Context,
+	hc hubClient.Client,
+	sp *spool.Spool,
+	entry *spool.Entry,
+	session *sessionstore.HubSession,
+	result *FlushResult,
+) {
+	var repository any = &v1alpha1.PushRecordRequest_RepositoryName{RepositoryName: entry.RepositoryName}
+	if entry.RepositoryID != "" {
+		repository = &v1alpha1.PushRecordRequest_RepositoryId{RepositoryId: entry.RepositoryID}
+	}
+
+	resp, err := PushAgent(ctx, hc, entry.Record, repository, session)
+	if err != nil {
+		result.Status = FlushStatusFailed
+		result.Err = err
+
+		return
+	}
+
+	result.Digest = resp.GetId().GetDigest()
+
+	if err := sp.Remove(entry.ID); err != nil {
+		result.Status = FlushStatusFailed
+		result.Err = fmt.Errorf("pushed, but failed to remove from the spool: %w", err)
+
+		return
+	}
+
+	result.Status = FlushStatusPushed
+}
+
+// authHash returns the hash of the hub and user of the session, so that queued pushes are only
+// replayed by the session they were queued by without storing the identity in the spool.
+func authHash(session *sessionstore.HubSession) string {
+	var hubAddress, user string
+
+	if session != nil {
+		user = session.User
+
+		if session.AuthConfig != nil {
+			hubAddress = session.HubBackendAddress
+		}
+
+		// Sessions created from API keys carry the user in their access token only
+		if user == "" && session.Tokens != nil {
+			user, _ = token.GetUserFromToken(session.Tokens.AccessToken)
+		}
+	}
+
+	sum := sha256.Sum256([]byte(hubAddress + "\n" + user))
+
+	return hex.EncodeToString(sum[:])
+}
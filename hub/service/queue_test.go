@@ -0,0 +1,183 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1alpha1 "github.com/agntcy/dir/hub/api/v1alpha1"
+	"github.com/agntcy/dir/hub/sessionstore"
+	"github.com/agntcy/dir/hub/spool"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func userSession(user string) *sessionstore.HubSession {
+	return &sessionstore.HubSession{
+		User:       user,
+		AuthConfig: &sessionstore.AuthConfig{HubBackendAddress: "hub.example.org:443"},
+	}
+}
+
+func TestPushOrQueueAgent(t *testing.T) {
+	_, record := writeRecord(t, t.TempDir(), "a")
+	repository := ParseRepoTagID("org/a")
+	session := userSession("alice")
+
+	t.Run("offline", func(t *testing.T) {
+		sp := spool.New(t.TempDir())
+		hc := &mockHubClient{}
+
+		resp, id, err := PushOrQueueAgent(t.Context(), hc, record, repository, session, &PushQueue{Spool: sp, Offline: true})
+		if err != nil || resp != nil || id == "" {
+			t.Fatalf("PushOrQueueAgent() = %v, %q, %v, want a queued push", resp, id, err)
+		}
+
+		if calls := hc.calls.Load(); calls != 0 {
+			t.Errorf("expected no push calls, got %d", calls)
+		}
+
+		entry, err := sp.Load(id)
+		if err != nil {
+			t.Fatalf("failed to load queued push: %v", err)
+		}
+
+		if entry.RepositoryName != "org/a" || string(entry.Record) != string(record) {
+			t.Errorf("queued push = %+v, want record pushed to org/a", entry)
+		}
+	})
+
+	t.Run("unreachable hub", func(t *testing.T) {
+		sp := spool.New(t.TempDir())
+		hc := &mockHubClient{err: status.Error(codes.Unavailable, "connection refused")}
+
+		_, id, err := PushOrQueueAgent(t.Context(), hc, record, repository, session, &PushQueue{Spool: sp, OnFailure: true})
+		if err != nil || id == "" {
+			t.Fatalf("PushOrQueueAgent() = %q, %v, want a queued push", id, err)
+		}
+
+		// Without queueing, the failure is returned
+		if _, _, err := PushOrQueueAgent(t.Context(), hc, record, repository, session, nil); status.Code(errors.Unwrap(err)) != codes.Unavailable {
+			t.Errorf("PushOrQueueAgent() error = %v, want Unavailable", err)
+		}
+	})
+
+	t.Run("rejected push is not queued", func(t *testing.T) {
+		sp := spool.New(t.TempDir())
+		hc := &mockHubClient{err: status.Error(codes.PermissionDenied, "forbidden")}
+
+		if _, _, err := PushOrQueueAgent(t.Context(), hc, record, repository, session, &PushQueue{Spool: sp, OnFailure: true}); err == nil {
+			t.Fatal("PushOrQueueAgent() expected an error")
+		}
+
+		if ids, _ := sp.List(); len(ids) != 0 {
+			t.Errorf("expected no queued pushes, got %v", ids)
+		}
+	})
+}
+
+func TestFlushSpool(t *testing.T) {
+	dir := t.TempDir()
+	_, pushed := writeRecord(t, dir, "a")
+	_, failing := writeRecord(t, dir, "b")
+	_, other := writeRecord(t, dir, "c")
+
+	sp := spool.New(t.TempDir())
+	alice := userSession("alice")
+
+	queue := func(record []byte, repository any, session *sessionstore.HubSession) string {
+		t.Helper()
+
+		id, err := QueueAgent(sp, record, repository, session)
+		if err != nil {
+			t.Fatalf("QueueAgent() unexpected error: %v", err)
+		}
+
+		return id
+	}
+
+	pushedID := queue(pushed, ParseRepoTagID("org/a"), alice)
+	failingID := queue(failing, ParseRepoTagID("123e4567-e89b-12d3-a456-426614174000"), alice)
+	otherID := queue(other, ParseRepoTagID("org/c"), userSession("bob"))
+	tamperedID := queue(pushed, ParseRepoTagID("org/a"), alice)
+
+	tamperedPath := filepath.Join(sp.Dir(), tamperedID+".json")
+	if err := os.WriteFile(tamperedPath, []byte(`{"repository_name":"org/evil","checksum":"0"}`), 0o600); err != nil {
+		t.Fatalf("failed to tamper with queued push: %v", err)
+	}
+
+	hc := &mockHubClient{failOn: map[string]bool{string(failing): true}}
+
+	results, err := FlushSpool(t.Context(), hc, sp, alice)
+	if err != nil {
+		t.Fatalf("FlushSpool() unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		id     string
+		status FlushStatus
+		err    error
+	}{
+		{pushedID, FlushStatusPushed, nil},
+		{failingID, FlushStatusFailed, nil},
+		{otherID, FlushStatusSkipped, ErrSessionMismatch},
+		{tamperedID, FlushStatusSkipped, spool.ErrTampered},
+	}
+
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d results, got %d", len(expected), len(results))
+	}
+
+	for i, want := range expected {
+		result := results[i]
+		if result.ID != want.id || result.Status != want.status || (want.err != nil && !errors.Is(result.Err, want.err)) {
+			t.Errorf("result %d = %s %s (%v), want %s %s", i, result.ID, result.Status, result.Err, want.id, want.status)
+		}
+	}
+
+	if results[0].Digest != "sha256:test" || results[1].Repository != "123e4567-e89b-12d3-a456-426614174000" {
+		t.Errorf("unexpected results %+v", results)
+	}
+
+	if calls := hc.calls.Load(); calls != 2 { //nolint:mnd
+		t.Errorf("expected 2 push calls, got %d", calls)
+	}
+
+	// Only the pushed entry is removed
+	ids, err := sp.List()
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+
+	if len(ids) != 3 || ids[0] != failingID { //nolint:mnd
+		t.Errorf("remaining queued pushes = %v, want all but %s", ids, pushedID)
+	}
+
+	// The failed push is retried by the next flush
+	hc.failOn = nil
+
+	results, err = FlushSpool(t.Context(), hc, sp, alice)
+	if err != nil {
+		t.Fatalf("FlushSpool() unexpected error: %v", err)
+	}
+
+	if results[0].ID != failingID || results[0].Status != FlushStatusPushed {
+		t.Errorf("retried result = %+v, want pushed", results[0])
+	}
+}
+
+func TestQueueAgentRepository(t *testing.T) {
+	_, record := writeRecord(t, t.TempDir(), "a")
+
+	if _, err := QueueAgent(spool.New(t.TempDir()), record, "org/a", userSession("alice")); err == nil {
+		t.Error("QueueAgent() expected an error for an unparsed repository")
+	}
+
+	if _, err := QueueAgent(spool.New(t.TempDir()), []byte(`{"foo":"bar"}`), &v1alpha1.PushRecordRequest_RepositoryName{RepositoryName: "org/a"}, userSession("alice")); err == nil {
+		t.Error("QueueAgent() expected an error for an invalid record")
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	v1alpha1 "github.com/agntcy/dir/hub/api/v1alpha1"
+	authUtils "github.com/agntcy/dir/hub/auth/utils"
+	hubClient "github.com/agntcy/dir/hub/client/hub"
+	"github.com/agntcy/dir/hub/sessionstore"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// listPageSize is the number of items requested per page when listing repositories and records.
+const listPageSize = 100
+
+var (
+	// ErrNotFound is returned when a repository, record or organization does not exist
+	// or is not visible to the current user.
+	ErrNotFound = errors.New("not found")
+
+	// ErrUnauthorized is returned when the hub rejects the credentials of the current session.
+	ErrUnauthorized = errors.New("not authorized, use `dirctl hub login` or provide an API key")
+
+	// ErrCreateDenied is returned when the current user may not create repositories in an organization.
+	// It is distinct from ErrUnauthorized, which is returned when pushing to a repository is denied.
+	ErrCreateDenied = errors.New("not allowed to create repositories")
+)
+
+// CreateRepositoryOptions describes the repository created by EnsureRepository if it is missing.
+type CreateRepositoryOptions struct {
+	// Private repositories are only visible to members of their organization.
+	Private bool
+}
+
+// ListRepositories returns all repositories of the owner organization, given by name or ID.
+func ListRepositories(
+	ctx context.Context,
+	hc hubClient.Client,
+	owner string,
+	session *sessionstore.HubSession,
+) ([]*v1alpha1.Repository, error) {
+	ctx = authUtils.AddAuthToContext(ctx, session)
+
+	organizationID, err := resolveOrganizationID(ctx, hc, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	var repositories []*v1alpha1.Repository
+
+	for page := uint32(1); ; page++ {
+		resp, err := hc.ListRepositories(ctx, &v1alpha1.ListRepositoriesRequest{
+			Pagination:     &v1alpha1.PaginationParam{PageNumber: page, PageSize: listPageSize},
+			OrganizationId: &organizationID,
+		})
+		if err != nil {
+			return nil, friendlyError(fmt.Errorf("failed to list repositories: %w", err), "organization "+owner)
+		}
+
+		repositories = append(repositories, resp.GetRepositories()...)
+
+		if page >= resp.GetPaginatedResponse().GetPages() || len(resp.GetRepositories()) == 0 {
+			return repositories, nil
+		}
+	}
+}
+
+// EnsureRepository checks that the repository given as '<owner>/<name>' exists before pushing to it.
+// A missing repository is created in the owner organization if create is set, otherwise an error
+// wrapping ErrNotFound is returned. Repository IDs are not checked, as they refer to existing repositories.
+// Returns whether the repository was created.
+func EnsureRepository(
+	ctx context.Context,
+	hc hubClient.Client,
+	repository string,
+	create *CreateRepositoryOptions,
+	session *sessionstore.HubSession,
+) (bool, error) {
+	if _, isRepositoryName := ParseRepoTagID(repository).(*v1alpha1.PushRecordRequest_RepositoryName); !isRepositoryName {
+		return false, nil
+	}
+
+	ctx = authUtils.AddAuthToContext(ctx, session)
+
+	_, err := hc.GetRepository(ctx, &v1alpha1.GetRepositoryRequest{
+		Id: &v1alpha1.RepositoryIdentifier{Id: &v1alpha1.RepositoryIdentifier_Name{Name: repository}},
+	})
+	if err == nil {
+		return false, nil
+	}
+
+	if status.Code(err) != codes.NotFound {
+		return false, friendlyError(fmt.Errorf("failed to get repository: %w", err), "repository "+repository)
+	}
+
+	if create == nil {
+		return false, fmt.Errorf("repository %s: %w", repository, ErrNotFound)
+	}
+
+	owner, err := ParseOrganizationName(repository)
+	if err != nil {
+		return false, err
+	}
+
+	organizationID, err := resolveOrganizationID(ctx, hc, owner)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = hc.CreateRepository(ctx, &v1alpha1.CreateRepositoryRequest{
+		Name:            repository,
+		OrganizationId:  organizationID,
+		PrivacySettings: &v1alpha1.RepositoryPrivacySettings{Private: create.Private},
+	})
+
+	switch status.Code(err) {
+	case codes.OK:
+		return true, nil
+	case codes.AlreadyExists:
+		// Created concurrently, e.g. by another push
+		return false, nil
+	case codes.PermissionDenied:
+		return false, fmt.Errorf("failed to create repository %s: %w in organization %s", repository, ErrCreateDenied, owner)
+	default:
+		return false, friendlyError(fmt.Errorf("failed to create repository: %w", err), "repository "+repository)
+	}
+}
+
+// ListRepositoryVersions returns all records of a repository, given as '<owner>/<name>' or
+// repository ID with the same semantics as ParseRepoTagID.
+func ListRepositoryVersions(
+	ctx context.Context,
+	hc hubClient.Client,
+	repository string,
+	session *sessionstore.HubSession,
+) ([]*v1alpha1.Record, error) {
+	ctx = authUtils.AddAuthToContext(ctx, session)
+
+	return listRepositoryRecords(ctx, hc, repository)
+}
+
+// ResolveAgentID resolves a record reference to a RecordIdentifier.
+// In addition to the formats accepted by ParseAgentID, the reference can be a repository name
+// or ID with an optional version, i.e. '<owner>/<name>[:<version>]' or '<repository-id>[:<version>]'.
+// Without a version, the most recently created record of the repository is resolved.
+func ResolveAgentID(
+	ctx context.Context,
+	hc hubClient.Client,
+	ref string,
+	session *sessionstore.HubSession,
+) (*v1alpha1.RecordIdentifier, error) {
+	repository, version, _ := strings.Cut(ref, ":")
+
+	// Digests, CIDs and '<owner>/<name>:<version>' are resolved by the hub
+	if _, isRepositoryID := ParseRepoTagID(repository).(*v1alpha1.PushRecordRequest_RepositoryId); !isRepositoryID {
+		if id, err := ParseAgentID(ref); err == nil {
+			return id, nil
+		}
+	}
+
+	if repository == "" {
+		return nil, fmt.Errorf("invalid agent ID format: %s", ref)
+	}
+
+	ctx = authUtils.AddAuthToContext(ctx, session)
+
+	records, err := listRepositoryRecords(ctx, hc, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *v1alpha1.Record
+
+	for _, record := range records {
+		switch {
+		case version != "":
+			if record.GetVersion() == version {
+				found = record
+			}
+		case found == nil || record.GetCreatedAt().AsTime().After(found.GetCreatedAt().AsTime()):
+			found = record
+		}
+	}
+
+	if found == nil {
+		if version != "" {
+			return nil, fmt.Errorf("version %s of repository %s: %w", version, repository, ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("repository %s has no records: %w", repository, ErrNotFound)
+	}
+
+	return &v1alpha1.RecordIdentifier{
+		Id: &v1alpha1.RecordIdentifier_Digest{Digest: found.GetDigest()},
+	}, nil
+}
+
+func listRepositoryRecords(ctx context.Context, hc hubClient.Client, repository string) ([]*v1alpha1.Record, error) {
+	id := &v1alpha1.RepositoryIdentifier{}
+
+	switch parsed := ParseRepoTagID(repository).(type) {
+	case *v1alpha1.PushRecordRequest_RepositoryId:
+		id.Id = &v1alpha1.RepositoryIdentifier_DbId{DbId: parsed.RepositoryId}
+	case *v1alpha1.PushRecordRequest_RepositoryName:
+		id.Id = &v1alpha1.RepositoryIdentifier_Name{Name: parsed.RepositoryName}
+	}
+
+	var records []*v1alpha1.Record
+
+	for page := uint32(1); ; page++ {
+		resp, err := hc.ListRepositoryRecords(ctx, &v1alpha1.ListRepositoryRecordsRequest{
+			Id:         id,
+			Pagination: &v1alpha1.PaginationParam{PageNumber: page, PageSize: listPageSize},
+		})
+		if err != nil {
+			return nil, friendlyError(fmt.Errorf("failed to list repository records: %w", err), "repository "+repository)
+		}
+
+		records = append(records, resp.GetRecords()...)
+
+		if page >= resp.GetPaginatedResponse().GetPages() || len(resp.GetRecords()) == 0 {
+			return records, nil
+		}
+	}
+}
+
+// resolveOrganizationID returns the ID of the organization given by name or ID.
+// Names are resolved among the organizations of the current user.
+func resolveOrganizationID(ctx context.Context, hc hubClient.Client, owner string) (string, error) {
+	if _, err := uuid.Parse(owner); err == nil {
+		return owner, nil
+	}
+
+	resp, err := hc.ListOrganizations(ctx, &v1alpha1.ListOrganizationsRequest{})
+	if err != nil {
+		return "", friendlyError(fmt.Errorf("failed to list organizations: %w", err), "organization "+owner)
+	}
+
+	for _, org := range resp.GetOrganizations() {
+		if org.GetOrganization().GetName() == owner {
+			return org.GetOrganization().GetId(), nil
+		}
+	}
+
+	return "", fmt.Errorf("organization %s: %w", owner, ErrNotFound)
+}
+
+// friendlyError replaces not found and authentication errors of the hub with
+// errors wrapping ErrNotFound and ErrUnauthorized that name the requested object.
+// Other errors are returned unchanged.
+func friendlyError(err error, object string) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return fmt.Errorf("%s: %w", object, ErrNotFound)
+	case codes.Unauthenticated:
+		return ErrUnauthorized
+	case codes.PermissionDenied:
+		return fmt.Errorf("%s: permission denied: %w", object, ErrUnauthorized)
+	default:
+		return err
+	}
+}
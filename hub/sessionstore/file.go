@@ -8,10 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"os"
-
-	fileUtils "github.com/agntcy/dir/hub/utils/file"
 )
 
 const (
@@ -45,108 +42,98 @@ func (s *FileSecretStore) GetHubSession(sessionKey string) (*HubSession, error)
 }
 
 // SaveHubSession saves a session by key to the file store.
+// The file is locked for the duration of the update and replaced atomically,
+// so concurrent writers from multiple processes do not corrupt it.
 func (s *FileSecretStore) SaveHubSession(sessionKey string, session *HubSession) error {
-	file, err := os.OpenFile(s.path, os.O_RDWR|os.O_CREATE, ModeCurrentUserReadWrite)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			var err error
-			if file, err = fileUtils.CreateAll(s.path); err != nil {
-				return fmt.Errorf("%w: %w", ErrCouldNotOpenFile, err)
-			}
-		} else {
-			return fmt.Errorf("%w: %w: %s", ErrCouldNotOpenFile, err, s.path)
-		}
-	}
-	defer file.Close()
+	return s.update(func(sessions *HubSessions) error {
+		sessions.HubSessions[sessionKey] = session
 
-	var sessions HubSessions
-	if err = json.NewDecoder(file).Decode(&sessions); err != nil {
-		if !errors.Is(err, io.EOF) {
-			return fmt.Errorf("%w: %w", ErrMalformedSecret, err)
+		return nil
+	})
+}
+
+// UpdateHubSession atomically updates a session by key.
+// The function receives the stored session, or nil if there is none, and returns the session to store.
+// The file is locked while the function runs, so it can be used to coordinate
+// operations such as token refresh across processes.
+func (s *FileSecretStore) UpdateHubSession(sessionKey string, fn func(*HubSession) (*HubSession, error)) error {
+	return s.update(func(sessions *HubSessions) error {
+		session, err := fn(sessions.HubSessions[sessionKey])
+		if err != nil {
+			return err
 		}
-	}
 
-	if sessions.HubSessions == nil {
-		sessions.HubSessions = make(map[string]*HubSession)
-	}
+		sessions.HubSessions[sessionKey] = session
 
-	sessions.HubSessions[sessionKey] = session
+		return nil
+	})
+}
 
-	if err = rewriteJSONFilePretty(file, sessions); err != nil {
-		return fmt.Errorf("%w: %w", ErrCouldNotWriteFile, err)
+// RemoveSession deletes a session by key from the file store.
+func (s *FileSecretStore) RemoveSession(sessionKey string) error {
+	if _, err := os.Stat(s.path); errors.Is(err, os.ErrNotExist) {
+		return nil
 	}
 
-	return nil
+	return s.update(func(sessions *HubSessions) error {
+		delete(sessions.HubSessions, sessionKey)
+
+		return nil
+	})
 }
 
-// RemoveSession deletes a session by key from the file store.
-func (s *FileSecretStore) RemoveSession(sessionKey string) error {
-	sessions, file, err := s.getSessionsAndFile()
+// update runs a read-modify-write cycle on the sessions file while holding the file lock.
+func (s *FileSecretStore) update(fn func(*HubSessions) error) error {
+	unlock, err := lockFile(s.path)
 	if err != nil {
 		return err
 	}
+	defer unlock()
 
-	if file == nil || sessions == nil {
-		return nil
+	sessions, err := s.readSessions()
+	if err != nil {
+		return err
 	}
 
-	defer file.Close()
+	if err := fn(sessions); err != nil {
+		return err
+	}
 
-	delete(sessions.HubSessions, sessionKey)
+	data, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCouldNotWriteFile, err)
+	}
 
-	if err = rewriteJSONFilePretty(file, sessions); err != nil {
+	if err := writeFileAtomic(s.path, append(data, '\n')); err != nil {
 		return fmt.Errorf("%w: %w", ErrCouldNotWriteFile, err)
 	}
 
 	return nil
 }
 
-// getSessionsAndFile returns the sessions and open file handle for internal use.
-func (s *FileSecretStore) getSessionsAndFile() (*HubSessions, *os.File, error) {
-	file, err := os.OpenFile(s.path, os.O_RDWR, ModeCurrentUserReadWrite)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return &HubSessions{}, nil, nil
-		}
-
-		return nil, nil, fmt.Errorf("%w: %w: %s", ErrCouldNotOpenFile, err, s.path)
+// readSessions reads the sessions file, returning empty sessions if it does not exist or is empty.
+func (s *FileSecretStore) readSessions() (*HubSessions, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %w: %s", ErrCouldNotOpenFile, err, s.path)
 	}
 
-	var secrets *HubSessions
-	if err = json.NewDecoder(file).Decode(&secrets); err != nil {
-		file.Close()
+	var sessions HubSessions
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &sessions); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedSecretFile, err)
+		}
+	}
 
-		return nil, nil, fmt.Errorf("%w: %w", ErrMalformedSecretFile, err)
+	if sessions.HubSessions == nil {
+		sessions.HubSessions = make(map[string]*HubSession)
 	}
 
-	return secrets, file, nil
+	return &sessions, nil
 }
 
 // getSessions returns the sessions for internal use.
+// Writes replace the file atomically, so reading does not require the lock.
 func (s *FileSecretStore) getSessions() (*HubSessions, error) {
-	secrets, file, err := s.getSessionsAndFile()
-	//nolint:errcheck
-	defer file.Close()
-
-	return secrets, err
-}
-
-// rewriteJSONFilePretty rewrites the file with pretty-printed JSON.
-func rewriteJSONFilePretty(file *os.File, model any) error {
-	if file == nil {
-		return errors.New("file is nil")
-	}
-
-	//nolint:errcheck
-	file.Seek(0, 0)
-	//nolint:errcheck
-	file.Truncate(0)
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-
-	if err := encoder.Encode(model); err != nil {
-		return fmt.Errorf("%w: %w", ErrCouldNotWriteFile, err)
-	}
-
-	return nil
+	return s.readSessions()
 }
@@ -0,0 +1,124 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sessionstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestFileSecretStoreConcurrentAccess(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.json")
+
+	const writers = 20
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, 2*writers)
+
+	for i := range writers {
+		wg.Add(2) //nolint:mnd
+
+		// Each writer uses its own store, like separate CLI processes would
+		go func() {
+			defer wg.Done()
+
+			key := fmt.Sprintf("server-%d", i)
+			if err := NewFileSessionStore(path).SaveHubSession(key, &HubSession{User: key}); err != nil {
+				errs <- err
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			// Readers must never observe a partially written file
+			if _, err := NewFileSessionStore(path).GetHubSession("server-0"); err != nil && !errors.Is(err, ErrSessionNotFound) {
+				errs <- err
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read session file: %v", err)
+	}
+
+	var sessions HubSessions
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		t.Fatalf("session file is corrupted: %v", err)
+	}
+
+	if len(sessions.HubSessions) != writers {
+		t.Errorf("expected %d sessions, got %d", writers, len(sessions.HubSessions))
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read directory: %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("expected only the session file to remain, got %d entries", len(entries))
+	}
+}
+
+func TestFileSecretStoreUpdateHubSession(t *testing.T) {
+	store := NewFileSessionStore(filepath.Join(t.TempDir(), "session.json"))
+
+	if err := store.SaveHubSession("server", &HubSession{User: "user"}); err != nil {
+		t.Fatalf("SaveHubSession() unexpected error: %v", err)
+	}
+
+	err := store.UpdateHubSession("server", func(session *HubSession) (*HubSession, error) {
+		if session == nil || session.User != "user" {
+			return nil, fmt.Errorf("unexpected stored session: %v", session)
+		}
+
+		return &HubSession{User: "updated"}, nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateHubSession() unexpected error: %v", err)
+	}
+
+	// A failed update leaves the stored session untouched
+	errUpdate := errors.New("update failed")
+
+	err = store.UpdateHubSession("server", func(*HubSession) (*HubSession, error) {
+		return nil, errUpdate
+	})
+	if !errors.Is(err, errUpdate) {
+		t.Fatalf("UpdateHubSession() expected update error, got %v", err)
+	}
+
+	session, err := store.GetHubSession("server")
+	if err != nil {
+		t.Fatalf("GetHubSession() unexpected error: %v", err)
+	}
+
+	if session.User != "updated" {
+		t.Errorf("expected updated session, got user %q", session.User)
+	}
+
+	if err := store.RemoveSession("server"); err != nil {
+		t.Fatalf("RemoveSession() unexpected error: %v", err)
+	}
+
+	if _, err := store.GetHubSession("server"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("expected session to be removed, got %v", err)
+	}
+}
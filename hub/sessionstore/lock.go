@@ -0,0 +1,113 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sessionstore provides session and token storage for the Agent Hub CLI and related applications.
+package sessionstore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// lockRetryInterval is how often a held lock file is checked.
+	lockRetryInterval = 10 * time.Millisecond
+	// lockTimeout is how long to wait for a lock file before giving up.
+	lockTimeout = 10 * time.Second
+	// lockStaleAge is the age after which a lock file is considered abandoned
+	// by a crashed process and is removed.
+	lockStaleAge = 30 * time.Second
+)
+
+// ErrLockTimeout indicates that the session file lock could not be acquired in time.
+var ErrLockTimeout = errors.New("timed out waiting for session file lock")
+
+// lockFile acquires an exclusive lock for the given path using a lock file next to it.
+// Lock files work across processes and platforms, unlike flock.
+// The returned function releases the lock.
+func lockFile(path string) (func(), error) {
+	lockPath := path + ".lock"
+
+	if err := os.MkdirAll(filepath.Dir(lockPath), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCouldNotOpenFile, err)
+	}
+
+	deadline := time.Now().Add(lockTimeout)
+
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, ModeCurrentUserReadWrite)
+		if err == nil {
+			file.Close()
+
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("%w: %w: %s", ErrCouldNotOpenFile, err, lockPath)
+		}
+
+		// Break locks left behind by crashed processes
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > lockStaleAge {
+			_ = os.Remove(lockPath)
+
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: %s", ErrLockTimeout, lockPath)
+		}
+
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory and renames it over path,
+// so readers never observe a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file: %w", err)
+	}
+
+	tmpPath := tmp.Name()
+
+	// Clean up the temporary file unless it was renamed
+	defer os.Remove(tmpPath) //nolint:errcheck
+
+	if err := tmp.Chmod(ModeCurrentUserReadWrite); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("error setting file mode: %w", err)
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("error writing temporary file: %w", err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("error syncing temporary file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error closing temporary file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("error replacing file: %w", err)
+	}
+
+	return nil
+}
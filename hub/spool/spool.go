@@ -0,0 +1,221 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package spool provides a local queue of pushes to the Agent Hub, so that pushes can be
+// recorded while the hub is unreachable and replayed later.
+package spool
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// ModeCurrentUserDir is the file mode of the spool directory.
+	ModeCurrentUserDir os.FileMode = 0o700
+
+	entryExt = ".json"
+)
+
+var (
+	// ErrTampered indicates a spool entry that does not match its checksum.
+	ErrTampered = errors.New("spool entry does not match its checksum")
+	// ErrEntryNotFound indicates that the requested spool entry was not found.
+	ErrEntryNotFound = errors.New("spool entry not found")
+)
+
+// Entry is a queued push of a record to a repository.
+// Exactly one of RepositoryName and RepositoryID is set.
+type Entry struct {
+	// ID identifies the entry in the spool. Entries are replayed in the order of their IDs.
+	ID string `json:"-"`
+
+	RepositoryName string    `json:"repository_name,omitempty"`
+	RepositoryID   string    `json:"repository_id,omitempty"`
+	Record         []byte    `json:"record"`
+	AuthHash       string    `json:"auth_hash"`
+	QueuedAt       time.Time `json:"queued_at"`
+
+	// Checksum is the SHA-256 of the entry without checksum, set by Add.
+	Checksum string `json:"checksum"`
+}
+
+// Repository returns the repository name or ID of the entry.
+func (e *Entry) Repository() string {
+	if e.RepositoryID != "" {
+		return e.RepositoryID
+	}
+
+	return e.RepositoryName
+}
+
+// checksum returns the checksum of the entry, computed over its fields except the checksum.
+func (e *Entry) checksum() (string, error) {
+	unsummed := *e
+	unsummed.Checksum = ""
+
+	data, err := json.Marshal(&unsummed)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal spool entry: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Spool stores queued pushes as one file per entry in a directory.
+type Spool struct {
+	dir string
+}
+
+// New creates a spool storing its entries in dir. The directory is created by the first Add.
+func New(dir string) *Spool {
+	return &Spool{dir: dir}
+}
+
+// Dir returns the directory of the spool.
+func (s *Spool) Dir() string {
+	return s.dir
+}
+
+// Add stores the entry and sets its ID, queue time and checksum.
+func (s *Spool) Add(entry *Entry) error {
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+
+	entry.ID = id
+	entry.QueuedAt = time.Now().UTC()
+
+	if entry.Checksum, err = entry.checksum(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal spool entry: %w", err)
+	}
+
+	if err := os.MkdirAll(s.dir, ModeCurrentUserDir); err != nil {
+		return fmt.Errorf("failed to create spool directory: %w", err)
+	}
+
+	// Write to a temporary file first, so that partially written entries are never listed.
+	// Temporary files are only readable by the current user.
+	tmp, err := os.CreateTemp(s.dir, id+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create spool entry: %w", err)
+	}
+
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("failed to write spool entry: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write spool entry: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path(id)); err != nil {
+		return fmt.Errorf("failed to write spool entry: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the IDs of the entries in the order they were queued.
+// An empty list is returned if the spool directory does not exist.
+func (s *Spool) List() ([]string, error) {
+	files, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spool directory: %w", err)
+	}
+
+	var ids []string
+
+	for _, file := range files {
+		if file.Type().IsRegular() && strings.HasSuffix(file.Name(), entryExt) {
+			ids = append(ids, strings.TrimSuffix(file.Name(), entryExt))
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// Load reads the entry with the given ID.
+// Returns ErrTampered if the entry was modified after it was queued.
+func (s *Spool) Load(id string) (*Entry, error) {
+	data, err := os.ReadFile(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrEntryNotFound, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spool entry %s: %w", id, err)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrTampered, id, err)
+	}
+
+	entry.ID = id
+
+	checksum, err := entry.checksum()
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Checksum != checksum {
+		return nil, fmt.Errorf("%w: %s", ErrTampered, id)
+	}
+
+	return &entry, nil
+}
+
+// Remove deletes the entry with the given ID.
+func (s *Spool) Remove(id string) error {
+	if err := os.Remove(s.path(id)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrEntryNotFound, id)
+		}
+
+		return fmt.Errorf("failed to remove spool entry %s: %w", id, err)
+	}
+
+	return nil
+}
+
+func (s *Spool) path(id string) string {
+	return filepath.Join(s.dir, filepath.Base(id)+entryExt)
+}
+
+// newID returns an ID sorting after the IDs of previously queued entries.
+func newID() (string, error) {
+	suffix := make([]byte, 4) //nolint:mnd
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate spool entry ID: %w", err)
+	}
+
+	return fmt.Sprintf("%020d-%s", time.Now().UnixNano(), hex.EncodeToString(suffix)), nil
+}
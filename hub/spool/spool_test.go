@@ -0,0 +1,90 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package spool
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSpool(t *testing.T) {
+	sp := New(filepath.Join(t.TempDir(), "spool"))
+
+	ids, err := sp.List()
+	if err != nil || len(ids) != 0 {
+		t.Fatalf("List() of a missing spool = %v, %v, want no entries", ids, err)
+	}
+
+	first := &Entry{RepositoryName: "org/a", Record: []byte(`{"name":"org/a"}`), AuthHash: "hash"}
+	second := &Entry{RepositoryID: "123e4567-e89b-12d3-a456-426614174000", Record: []byte(`{"name":"org/b"}`), AuthHash: "hash"}
+
+	for _, entry := range []*Entry{first, second} {
+		if err := sp.Add(entry); err != nil {
+			t.Fatalf("Add() unexpected error: %v", err)
+		}
+	}
+
+	ids, err = sp.List()
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+
+	if len(ids) != 2 || ids[0] != first.ID || ids[1] != second.ID {
+		t.Fatalf("List() = %v, want [%s %s]", ids, first.ID, second.ID)
+	}
+
+	loaded, err := sp.Load(second.ID)
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	if loaded.Repository() != second.RepositoryID || !bytes.Equal(loaded.Record, second.Record) || loaded.AuthHash != "hash" {
+		t.Errorf("Load() = %+v, want %+v", loaded, second)
+	}
+
+	if err := sp.Remove(first.ID); err != nil {
+		t.Fatalf("Remove() unexpected error: %v", err)
+	}
+
+	if _, err := sp.Load(first.ID); !errors.Is(err, ErrEntryNotFound) {
+		t.Errorf("Load() of a removed entry error = %v, want ErrEntryNotFound", err)
+	}
+}
+
+func TestSpoolTampered(t *testing.T) {
+	sp := New(t.TempDir())
+
+	entry := &Entry{RepositoryName: "org/a", Record: []byte(`{"name":"org/a"}`), AuthHash: "hash"}
+	if err := sp.Add(entry); err != nil {
+		t.Fatalf("Add() unexpected error: %v", err)
+	}
+
+	path := filepath.Join(sp.Dir(), entry.ID+entryExt)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read entry: %v", err)
+	}
+
+	// Redirect the push to another repository
+	data = bytes.Replace(data, []byte(`"org/a"`), []byte(`"org/b"`), 1)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write entry: %v", err)
+	}
+
+	if _, err := sp.Load(entry.ID); !errors.Is(err, ErrTampered) {
+		t.Errorf("Load() of a modified entry error = %v, want ErrTampered", err)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatalf("failed to write entry: %v", err)
+	}
+
+	if _, err := sp.Load(entry.ID); !errors.Is(err, ErrTampered) {
+		t.Errorf("Load() of a corrupted entry error = %v, want ErrTampered", err)
+	}
+}
@@ -9,13 +9,42 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync"
 
 	baseauth "github.com/agntcy/dir/hub/auth"
+	"github.com/agntcy/dir/hub/client/okta"
 	"github.com/agntcy/dir/hub/service"
 	"github.com/agntcy/dir/hub/sessionstore"
+	"github.com/agntcy/dir/hub/utils/file"
+	httpUtils "github.com/agntcy/dir/hub/utils/http"
+	"github.com/agntcy/dir/hub/utils/token"
 	"github.com/spf13/cobra"
 )
 
+// These are variables so that tests can replace them.
+var (
+	createInMemorySession = baseauth.CreateInMemorySessionFromAPIKey
+	newOktaClient         = func(issuer string) okta.Client {
+		return okta.NewClient(issuer, httpUtils.CreateSecureHTTPClient())
+	}
+)
+
+// cachedSession is a session returned by GetOrCreateSession.
+type cachedSession struct {
+	// source is the context session the cached session was copied from, if any.
+	source  *sessionstore.HubSession
+	session *sessionstore.HubSession
+}
+
+// sessionCache holds sessions returned by GetOrCreateSession, so that commands
+// sending many requests, such as bulk push, authenticate only once per process.
+// Cached sessions are never modified; a refreshed session replaces the cached one.
+var sessionCache = struct {
+	sync.Mutex
+
+	sessions map[string]cachedSession
+}{sessions: make(map[string]cachedSession)}
+
 func CheckForCreds(cmd *cobra.Command, currentSession *sessionstore.HubSession, serverAddress string, jsonOutput bool) error {
 	if !baseauth.HasLoginCreds(currentSession) {
 		return errors.New("you need to be logged to execute this action\nuse `dirctl hub login` command to login")
@@ -67,19 +96,33 @@ func resolveAPIKeyCredentials(clientID, secret, apikeyFile string) (string, stri
 // 2. API key from environment variables
 // 3. Existing session from context (session file created via 'dirctl hub login').
 // Secret must be provided as base64-encoded.
+//
+// Sessions are cached for the lifetime of the process and are safe to call concurrently.
+// A cached session whose access token expires within token.RefreshWindow is re-authenticated or refreshed.
 func GetOrCreateSession(cmd *cobra.Command, serverAddress, clientID, secret, apikeyFile string, jsonOutput bool) (*sessionstore.HubSession, error) {
 	effectiveClientID, effectiveSecret, err := resolveAPIKeyCredentials(clientID, secret, apikeyFile)
 	if err != nil {
 		return nil, err
 	}
 
+	sessionCache.Lock()
+	defer sessionCache.Unlock()
+
 	// If API key credentials are available, use in-memory session.
 	if effectiveClientID != "" && effectiveSecret != "" {
-		session, err := baseauth.CreateInMemorySessionFromAPIKey(cmd.Context(), serverAddress, effectiveClientID, effectiveSecret)
+		cacheKey := "apikey/" + serverAddress + "/" + effectiveClientID
+
+		if cached, ok := sessionCache.sessions[cacheKey]; ok && !isExpiring(cached.session) {
+			return cached.session, nil
+		}
+
+		session, err := createInMemorySession(cmd.Context(), serverAddress, effectiveClientID, effectiveSecret)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create in-memory session: %w", err)
 		}
 
+		sessionCache.sessions[cacheKey] = cachedSession{session: session}
+
 		return session, nil
 	}
 
@@ -96,5 +139,31 @@ func GetOrCreateSession(cmd *cobra.Command, serverAddress, clientID, secret, api
 		return nil, err //nolint:wrapcheck
 	}
 
-	return currentSession, nil
+	cacheKey := "session/" + serverAddress
+
+	cached, ok := sessionCache.sessions[cacheKey]
+	if !ok || cached.source != currentSession {
+		cached = cachedSession{source: currentSession, session: currentSession}
+	}
+
+	if isExpiring(cached.session) && cached.session.AuthConfig != nil {
+		// Refresh a copy, so that callers holding the previous session are not affected
+		refreshed := *cached.session
+
+		sessionStore := sessionstore.NewFileSessionStore(file.GetSessionFilePath())
+		if err := token.RefreshTokenIfExpired(serverAddress, &refreshed, sessionStore, newOktaClient(refreshed.IdpIssuerAddress)); err != nil {
+			return nil, fmt.Errorf("failed to refresh expired access token: %w", err)
+		}
+
+		cached.session = &refreshed
+	}
+
+	sessionCache.sessions[cacheKey] = cached
+
+	return cached.session, nil
+}
+
+// isExpiring returns true if the session has no access token or it expires within token.RefreshWindow.
+func isExpiring(session *sessionstore.HubSession) bool {
+	return session.Tokens == nil || token.IsTokenExpiring(session.Tokens.AccessToken, token.RefreshWindow)
 }
@@ -0,0 +1,198 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/hub/client/okta"
+	"github.com/agntcy/dir/hub/sessionstore"
+	"github.com/agntcy/dir/hub/utils/file"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/cobra"
+)
+
+// fakeOktaClient counts refreshes and returns tokens valid for an hour.
+type fakeOktaClient struct {
+	okta.Client
+
+	t         *testing.T
+	refreshes atomic.Int32
+}
+
+func (f *fakeOktaClient) RefreshToken(*okta.RefreshTokenRequest) (*okta.RefreshTokenResponse, error) {
+	f.refreshes.Add(1)
+
+	return &okta.RefreshTokenResponse{
+		Response: &http.Response{StatusCode: http.StatusOK},
+		Token: &okta.Token{
+			AccessToken:  newToken(f.t, time.Hour),
+			RefreshToken: "refreshed",
+		},
+	}, nil
+}
+
+func newToken(t *testing.T, expiresIn time.Duration) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "user",
+		"exp": time.Now().Add(expiresIn).Unix(),
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	return token
+}
+
+// setupSessionCache isolates the session cache and session file of a test.
+func setupSessionCache(t *testing.T) {
+	t.Helper()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+	t.Setenv("DIRCTL_CLIENT_ID", "")
+	t.Setenv("DIRCTL_CLIENT_SECRET", "")
+
+	sessionCache.sessions = make(map[string]cachedSession)
+
+	t.Cleanup(func() {
+		sessionCache.sessions = make(map[string]cachedSession)
+	})
+}
+
+// hammer calls GetOrCreateSession concurrently and returns the sessions it got.
+func hammer(t *testing.T, cmd *cobra.Command, apikeyFile string) []*sessionstore.HubSession {
+	t.Helper()
+
+	const workers = 20
+
+	var wg sync.WaitGroup
+
+	sessions := make([]*sessionstore.HubSession, workers)
+
+	for i := range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			session, err := GetOrCreateSession(cmd, "server", "", "", apikeyFile, false)
+			if err != nil {
+				t.Errorf("GetOrCreateSession() unexpected error: %v", err)
+
+				return
+			}
+
+			sessions[i] = session
+		}()
+	}
+
+	wg.Wait()
+
+	return sessions
+}
+
+func TestGetOrCreateSessionRefreshesOnce(t *testing.T) {
+	setupSessionCache(t)
+
+	oktaClient := &fakeOktaClient{t: t}
+
+	origNewOktaClient := newOktaClient
+	newOktaClient = func(string) okta.Client { return oktaClient }
+
+	t.Cleanup(func() { newOktaClient = origNewOktaClient })
+
+	// The session expires within the refresh window
+	session := &sessionstore.HubSession{
+		Tokens: &sessionstore.Tokens{
+			IDToken:      "id",
+			AccessToken:  newToken(t, time.Minute),
+			RefreshToken: "refresh",
+		},
+		AuthConfig: &sessionstore.AuthConfig{IdpIssuerAddress: "https://idp.example.com"},
+	}
+
+	if err := sessionstore.NewFileSessionStore(file.GetSessionFilePath()).SaveHubSession("server", session); err != nil {
+		t.Fatalf("failed to save session: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.WithValue(t.Context(), sessionstore.SessionContextKey, session))
+
+	for _, got := range hammer(t, cmd, "") {
+		if got == nil || got.Tokens.RefreshToken != "refreshed" {
+			t.Fatalf("expected refreshed session, got %v", got)
+		}
+	}
+
+	if refreshes := oktaClient.refreshes.Load(); refreshes != 1 {
+		t.Errorf("expected exactly one refresh, got %d", refreshes)
+	}
+
+	// The context session is not modified
+	if session.Tokens.RefreshToken != "refresh" {
+		t.Errorf("expected context session to be unchanged, got %q", session.Tokens.RefreshToken)
+	}
+
+	data, err := os.ReadFile(file.GetSessionFilePath())
+	if err != nil {
+		t.Fatalf("failed to read session file: %v", err)
+	}
+
+	var sessions sessionstore.HubSessions
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		t.Fatalf("session file is corrupted: %v", err)
+	}
+
+	if stored := sessions.HubSessions["server"]; stored == nil || stored.Tokens.RefreshToken != "refreshed" {
+		t.Errorf("expected refreshed tokens to be saved, got %v", stored)
+	}
+}
+
+func TestGetOrCreateSessionAuthenticatesOnce(t *testing.T) {
+	setupSessionCache(t)
+
+	var logins atomic.Int32
+
+	origCreateInMemorySession := createInMemorySession
+	createInMemorySession = func(_ context.Context, _, _, _ string) (*sessionstore.HubSession, error) {
+		logins.Add(1)
+
+		return &sessionstore.HubSession{
+			Tokens: &sessionstore.Tokens{AccessToken: newToken(t, time.Hour)},
+		}, nil
+	}
+
+	t.Cleanup(func() { createInMemorySession = origCreateInMemorySession })
+
+	apikeyFile := filepath.Join(t.TempDir(), "apikey.json")
+	if err := os.WriteFile(apikeyFile, []byte(`{"client_id": "client", "secret": "c2VjcmV0"}`), 0o600); err != nil {
+		t.Fatalf("failed to write API key file: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(t.Context())
+
+	sessions := hammer(t, cmd, apikeyFile)
+
+	if n := logins.Load(); n != 1 {
+		t.Errorf("expected exactly one authentication, got %d", n)
+	}
+
+	for _, got := range sessions {
+		if got != sessions[0] {
+			t.Fatal("expected all callers to share the cached session")
+		}
+	}
+}
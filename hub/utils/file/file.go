@@ -28,3 +28,7 @@ func CreateAll(path string) (*os.File, error) {
 func GetSessionFilePath() string {
 	return filepath.Join(dir.GetAppDir(), "session.json")
 }
+
+func GetSpoolDirPath() string {
+	return filepath.Join(dir.GetAppDir(), "hub-spool")
+}
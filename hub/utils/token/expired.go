@@ -10,15 +10,24 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// RefreshWindow is how long before expiry an access token is refreshed,
+// so that long-running operations do not fail with an expired token midway.
+const RefreshWindow = 5 * time.Minute
+
 // IsTokenExpired returns true if the given JWT access token is expired or invalid.
 func IsTokenExpired(token string) bool {
+	return IsTokenExpiring(token, 0)
+}
+
+// IsTokenExpiring returns true if the given JWT access token is invalid or expires within the given duration.
+func IsTokenExpiring(token string, within time.Duration) bool {
 	claims := jwt.MapClaims{}
 	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
 		return true
 	}
 
 	expTime, err := claims.GetExpirationTime()
-	if err != nil || expTime == nil || expTime.Before(time.Now()) {
+	if err != nil || expTime == nil || expTime.Before(time.Now().Add(within)) {
 		return true
 	}
 
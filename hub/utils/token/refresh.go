@@ -18,8 +18,12 @@ const (
 	userClaim = "sub"
 )
 
-// RefreshTokenIfExpired refreshes the access token for the current session if it is expired.
+// RefreshTokenIfExpired refreshes the access token for the current session if it is expired
+// or expires within RefreshWindow.
 // It uses the provided Okta client and session store to update the session and persist the new tokens.
+//
+// If the session store supports atomic updates, the refresh happens while the stored session is locked.
+// When another process has already refreshed the stored tokens, they are reused instead of refreshing again.
 // Returns an error if the refresh or save fails.
 func RefreshTokenIfExpired(sessionKey string, session *sessionstore.HubSession, secretStore sessionstore.SessionStore, oktaClient okta.Client) error {
 	if session == nil ||
@@ -28,20 +32,65 @@ func RefreshTokenIfExpired(sessionKey string, session *sessionstore.HubSession,
 		return nil
 	}
 
-	accessToken := session.Tokens.AccessToken
-	refreshToken := session.Tokens.RefreshToken
+	if !IsTokenExpiring(session.Tokens.AccessToken, RefreshWindow) {
+		return nil
+	}
+
+	updater, ok := secretStore.(interface {
+		UpdateHubSession(string, func(*sessionstore.HubSession) (*sessionstore.HubSession, error)) error
+	})
+	if !ok {
+		if err := refreshSessionTokens(session, oktaClient); err != nil {
+			return err
+		}
+
+		// Update tokens store with new token
+		if err := secretStore.SaveHubSession(sessionKey, session); err != nil {
+			return fmt.Errorf("failed to save hub tokens: %w", err)
+		}
 
-	if !IsTokenExpired(accessToken) {
 		return nil
 	}
 
+	err := updater.UpdateHubSession(sessionKey, func(stored *sessionstore.HubSession) (*sessionstore.HubSession, error) {
+		// Start from the stored tokens, which may have been refreshed by someone else
+		// while we were waiting for the lock
+		if stored != nil && stored.Tokens != nil && stored.Tokens.RefreshToken != "" {
+			session.Tokens = stored.Tokens
+
+			if !IsTokenExpiring(session.Tokens.AccessToken, RefreshWindow) {
+				return session, nil
+			}
+		}
+
+		if err := refreshSessionTokens(session, oktaClient); err != nil {
+			return nil, err
+		}
+
+		return session, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update hub tokens: %w", err)
+	}
+
+	return nil
+}
+
+// refreshSessionTokens exchanges the refresh token of the session for new tokens.
+func refreshSessionTokens(session *sessionstore.HubSession, oktaClient okta.Client) error {
+	refreshToken := session.Tokens.RefreshToken
 	if refreshToken == "" {
 		return errors.New("access token is expired and refresh token is empty")
 	}
 
+	var clientID string
+	if session.AuthConfig != nil {
+		clientID = session.ClientID
+	}
+
 	resp, err := oktaClient.RefreshToken(&okta.RefreshTokenRequest{
 		RefreshToken: refreshToken,
-		ClientID:     session.ClientID,
+		ClientID:     clientID,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to refresh token: %w", err)
@@ -51,17 +100,11 @@ func RefreshTokenIfExpired(sessionKey string, session *sessionstore.HubSession,
 		return fmt.Errorf("failed to refresh token: %s", string(resp.Body))
 	}
 
-	newTokenSecret := &sessionstore.Tokens{
+	session.Tokens = &sessionstore.Tokens{
 		AccessToken:  resp.Token.AccessToken,
 		RefreshToken: resp.Token.RefreshToken,
 		IDToken:      resp.Token.IDToken,
 	}
-	session.Tokens = newTokenSecret
-
-	// Update tokens store with new token
-	if err = secretStore.SaveHubSession(sessionKey, session); err != nil {
-		return fmt.Errorf("failed to save hub tokens: %w", err)
-	}
 
 	return nil
 }
@@ -0,0 +1,137 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"net/http"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/hub/client/okta"
+	"github.com/agntcy/dir/hub/sessionstore"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// fakeOktaClient counts refreshes and returns tokens valid for an hour.
+type fakeOktaClient struct {
+	okta.Client
+
+	t         *testing.T
+	refreshes atomic.Int32
+}
+
+func (f *fakeOktaClient) RefreshToken(*okta.RefreshTokenRequest) (*okta.RefreshTokenResponse, error) {
+	f.refreshes.Add(1)
+
+	return &okta.RefreshTokenResponse{
+		Response: &http.Response{StatusCode: http.StatusOK},
+		Token: &okta.Token{
+			AccessToken:  newToken(f.t, time.Hour),
+			RefreshToken: "refreshed",
+		},
+	}, nil
+}
+
+func newToken(t *testing.T, expiresIn time.Duration) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "user",
+		"exp": time.Now().Add(expiresIn).Unix(),
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	return token
+}
+
+func TestIsTokenExpiring(t *testing.T) {
+	token := newToken(t, time.Minute)
+
+	if IsTokenExpired(token) {
+		t.Error("expected token not to be expired")
+	}
+
+	if !IsTokenExpiring(token, RefreshWindow) {
+		t.Error("expected token to be expiring within the refresh window")
+	}
+
+	if !IsTokenExpired("invalid") {
+		t.Error("expected invalid token to be expired")
+	}
+}
+
+func TestRefreshTokenIfExpiredConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	err := sessionstore.NewFileSessionStore(path).SaveHubSession("server", &sessionstore.HubSession{
+		Tokens: &sessionstore.Tokens{
+			AccessToken:  newToken(t, time.Minute),
+			RefreshToken: "refresh",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to save session: %v", err)
+	}
+
+	oktaClient := &fakeOktaClient{t: t}
+
+	const workers = 10
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, workers)
+
+	for range workers {
+		wg.Add(1)
+
+		// Each worker loads its own session, like separate CLI processes would
+		go func() {
+			defer wg.Done()
+
+			store := sessionstore.NewFileSessionStore(path)
+
+			session, err := store.GetHubSession("server")
+			if err != nil {
+				errs <- err
+
+				return
+			}
+
+			if err := RefreshTokenIfExpired("server", session, store, oktaClient); err != nil {
+				errs <- err
+
+				return
+			}
+
+			if IsTokenExpiring(session.Tokens.AccessToken, RefreshWindow) {
+				t.Error("expected session to have a refreshed access token")
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if refreshes := oktaClient.refreshes.Load(); refreshes != 1 {
+		t.Errorf("expected exactly one refresh, got %d", refreshes)
+	}
+
+	session, err := sessionstore.NewFileSessionStore(path).GetHubSession("server")
+	if err != nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+
+	if session.Tokens.RefreshToken != "refreshed" {
+		t.Errorf("expected refreshed tokens to be saved, got %q", session.Tokens.RefreshToken)
+	}
+}
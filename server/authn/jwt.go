@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/agntcy/dir/utils/logging"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/svid/jwtsvid"
 	"github.com/spiffe/go-spiffe/v2/workloadapi"
@@ -70,6 +71,7 @@ func NewJWTInterceptor(jwtSource *workloadapi.JWTSource, audiences []string) JWT
 
 		// Store SPIFFE ID in context for downstream handlers
 		ctx = context.WithValue(ctx, SpiffeIDContextKey, spiffeID)
+		ctx = logging.ContextWithFields(ctx, "trust_domain", spiffeID.TrustDomain().String())
 
 		return ctx, nil
 	}
@@ -6,6 +6,7 @@ package authn
 import (
 	"context"
 
+	"github.com/agntcy/dir/utils/logging"
 	"github.com/spiffe/go-spiffe/v2/spiffegrpc/grpccredentials"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -30,6 +31,8 @@ func NewX509Interceptor() X509InterceptorFn {
 		)
 
 		// Store the SPIFFE ID in context using the same approach as JWT
+		ctx = logging.ContextWithFields(ctx, "trust_domain", sid.TrustDomain().String())
+
 		return context.WithValue(ctx, SpiffeIDContextKey, sid), nil
 	}
 }
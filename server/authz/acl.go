@@ -0,0 +1,223 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"context"
+	"slices"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/server/authz/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ACLOperation is an operation on a record that its access control list restricts.
+type ACLOperation string
+
+const (
+	// ACLOperationPull is pulling a record or its referrers.
+	ACLOperationPull ACLOperation = "pull"
+
+	// ACLOperationDelete is deleting a record or updating its metadata.
+	ACLOperationDelete ACLOperation = "delete"
+)
+
+// ACLInput is the input of record access decisions.
+type ACLInput struct {
+	// SPIFFE ID of the caller, empty if the caller is not authenticated.
+	SpiffeID string `json:"spiffe_id"`
+
+	// Operation of the caller on the record.
+	Operation ACLOperation `json:"operation"`
+
+	// CID of the record.
+	CID string `json:"cid"`
+
+	// Owner is the SPIFFE ID of the caller that first pushed the record, empty if unknown.
+	Owner string `json:"owner"`
+
+	// AllowPull are the patterns of the callers that can pull the record.
+	AllowPull []string `json:"allow_pull"`
+
+	// AllowDelete are the patterns of the callers that can delete the record.
+	AllowDelete []string `json:"allow_delete"`
+
+	// Allowed reports whether the caller is the owner or matches the patterns of the operation.
+	Allowed bool `json:"allowed"`
+}
+
+// ACLDecider decides whether a caller can perform an operation on a record with an access control list.
+type ACLDecider interface {
+	Decide(ctx context.Context, input ACLInput) (bool, error)
+}
+
+// ACLDeciderFunc adapts a function to an ACLDecider.
+type ACLDeciderFunc func(ctx context.Context, input ACLInput) (bool, error)
+
+func (f ACLDeciderFunc) Decide(ctx context.Context, input ACLInput) (bool, error) {
+	return f(ctx, input)
+}
+
+// PatternACLDecider allows the owner of records and the callers matching their access control list.
+var PatternACLDecider = ACLDeciderFunc(func(_ context.Context, input ACLInput) (bool, error) {
+	return input.Allowed, nil
+})
+
+// ACLEnforcer enforces the access control lists of records after the API methods have been authorized.
+// Records without an access control list can be accessed by all callers authorized for the method.
+// A nil enforcer does not restrict any record.
+type ACLEnforcer struct {
+	db      types.ACLDatabaseAPI
+	decider ACLDecider
+}
+
+// NewACLEnforcer creates an enforcer of the access control lists stored in the database.
+// Access is decided by the configured OPA, or by PatternACLDecider if none is configured.
+func NewACLEnforcer(cfg config.Config, db types.ACLDatabaseAPI) *ACLEnforcer {
+	var decider ACLDecider = PatternACLDecider
+	if cfg.OPA.URL != "" {
+		decider = NewOPAACLDecider(cfg.OPA)
+	}
+
+	return NewACLEnforcerWithDecider(db, decider)
+}
+
+// NewACLEnforcerWithDecider creates an enforcer of the access control lists stored in the database with a custom decider.
+func NewACLEnforcerWithDecider(db types.ACLDatabaseAPI, decider ACLDecider) *ACLEnforcer {
+	return &ACLEnforcer{db: db, decider: decider}
+}
+
+// Check verifies that the caller of the context can perform the operation on the record.
+// It returns a PermissionDenied error if the access control list of the record does not allow it,
+// or if the decision fails.
+func (e *ACLEnforcer) Check(ctx context.Context, cid string, operation ACLOperation) error {
+	if e == nil {
+		return nil
+	}
+
+	acl, exists, err := e.db.GetRecordACL(cid)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get record access control list: %v", err)
+	}
+
+	if !exists || !acl.Restricted() {
+		return nil
+	}
+
+	input := ACLInput{
+		SpiffeID:    spiffeIDFromContext(ctx),
+		Operation:   operation,
+		CID:         cid,
+		Owner:       acl.Owner,
+		AllowPull:   acl.AllowPull,
+		AllowDelete: acl.AllowDelete,
+	}
+	input.Allowed = allowed(input)
+
+	allow, err := e.decider.Decide(ctx, input)
+	if err != nil {
+		logging.WithContext(ctx, logger).Warn("Failed to decide record access, denying it", "error", err, "cid", cid, "operation", operation, "spiffe_id", input.SpiffeID)
+
+		allow = false
+	}
+
+	if !allow {
+		logging.WithContext(ctx, logger).Warn("Record access denied", "cid", cid, "operation", operation, "spiffe_id", input.SpiffeID)
+
+		return status.Errorf(codes.PermissionDenied, "not allowed to %s record %s", operation, cid)
+	}
+
+	return nil
+}
+
+// RecordOwner records the caller of the context as the owner of a pushed record.
+// Records that already have an owner keep it, records pushed by unauthenticated callers have none.
+func (e *ACLEnforcer) RecordOwner(ctx context.Context, cid string) {
+	if e == nil {
+		return
+	}
+
+	owner := spiffeIDFromContext(ctx)
+	if owner == "" {
+		return
+	}
+
+	if err := e.db.SetRecordOwner(cid, owner); err != nil {
+		logging.WithContext(ctx, logger).Error("Failed to record owner of record", "error", err, "cid", cid, "owner", owner)
+	}
+}
+
+// SetACL replaces the access control list of a record, an ACL without patterns removes it.
+// It returns a PermissionDenied error if the caller of the context is not the owner of the record.
+func (e *ACLEnforcer) SetACL(ctx context.Context, cid string, acl *corev1.RecordACL) error {
+	if err := acl.Validate(); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid access control list: %v", err)
+	}
+
+	current, _, err := e.db.GetRecordACL(cid)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get record access control list: %v", err)
+	}
+
+	if current.Owner == "" {
+		return status.Errorf(codes.FailedPrecondition, "record %s has no owner", cid)
+	}
+
+	if caller := spiffeIDFromContext(ctx); caller != current.Owner {
+		return status.Errorf(codes.PermissionDenied, "record %s is owned by %q", cid, current.Owner)
+	}
+
+	if err := e.db.SetRecordACL(cid, acl.GetAllowPull(), acl.GetAllowDelete()); err != nil {
+		return status.Errorf(codes.Internal, "failed to set record access control list: %v", err)
+	}
+
+	logging.WithContext(ctx, logger).Info("Record access control list set", "cid", cid, "allow_pull", len(acl.GetAllowPull()), "allow_delete", len(acl.GetAllowDelete()))
+
+	return nil
+}
+
+// Remove removes the owner and access control list of a deleted record.
+func (e *ACLEnforcer) Remove(cid string) {
+	if e == nil {
+		return
+	}
+
+	if err := e.db.RemoveRecordACL(cid); err != nil {
+		logger.Error("Failed to remove record access control list", "error", err, "cid", cid)
+	}
+}
+
+// allowed reports whether the caller is the owner of the record or matches the patterns of the operation.
+// Operations without patterns are not restricted.
+func allowed(input ACLInput) bool {
+	if input.SpiffeID != "" && input.SpiffeID == input.Owner {
+		return true
+	}
+
+	patterns := input.AllowPull
+	if input.Operation == ACLOperationDelete {
+		patterns = input.AllowDelete
+	}
+
+	if len(patterns) == 0 {
+		return true
+	}
+
+	return input.SpiffeID != "" && slices.ContainsFunc(patterns, func(pattern string) bool {
+		return corev1.MatchACLPattern(pattern, input.SpiffeID)
+	})
+}
+
+// spiffeIDFromContext returns the SPIFFE ID of the caller of the context, empty if it is not authenticated.
+func spiffeIDFromContext(ctx context.Context) string {
+	if sid, ok := authn.SpiffeIDFromContext(ctx); ok {
+		return sid.String()
+	}
+
+	return ""
+}
@@ -0,0 +1,148 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/server/authz/config"
+	"github.com/agntcy/dir/server/database/sqlite"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ownerID  = "spiffe://example.org/ns/team-a/owner"
+	readerID = "spiffe://example.org/ns/team-a/reader"
+	otherID  = "spiffe://example.org/ns/team-b/agent"
+)
+
+func contextForSpiffeID(t *testing.T, id string) context.Context {
+	t.Helper()
+
+	return context.WithValue(t.Context(), authn.SpiffeIDContextKey, spiffeid.RequireFromString(id))
+}
+
+func newTestACLEnforcer(t *testing.T, cfg config.Config) *ACLEnforcer {
+	t.Helper()
+
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "dir.db"))
+	require.NoError(t, err)
+
+	return NewACLEnforcer(cfg, db)
+}
+
+func TestACLEnforcer(t *testing.T) {
+	enforcer := newTestACLEnforcer(t, config.Config{})
+
+	enforcer.RecordOwner(contextForSpiffeID(t, ownerID), "cid-1")
+	enforcer.RecordOwner(contextForSpiffeID(t, otherID), "cid-1")
+
+	t.Run("records without an ACL are not restricted", func(t *testing.T) {
+		require.NoError(t, enforcer.Check(contextForSpiffeID(t, otherID), "cid-1", ACLOperationPull))
+		require.NoError(t, enforcer.Check(contextForSpiffeID(t, otherID), "cid-2", ACLOperationDelete))
+		require.NoError(t, enforcer.Check(t.Context(), "cid-2", ACLOperationPull))
+	})
+
+	t.Run("only the owner can set the ACL", func(t *testing.T) {
+		acl := &corev1.RecordACL{AllowPull: []string{"spiffe://example.org/ns/team-a/**"}}
+
+		err := enforcer.SetACL(contextForSpiffeID(t, otherID), "cid-1", acl)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "the first pusher owns the record")
+
+		err = enforcer.SetACL(contextForSpiffeID(t, ownerID), "cid-2", acl)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err), "records without an owner have no ACL")
+
+		err = enforcer.SetACL(contextForSpiffeID(t, ownerID), "cid-1", &corev1.RecordACL{AllowPull: []string{"team-a"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		require.NoError(t, enforcer.SetACL(contextForSpiffeID(t, ownerID), "cid-1", acl))
+	})
+
+	t.Run("patterns deny callers of the trust domain", func(t *testing.T) {
+		require.NoError(t, enforcer.Check(contextForSpiffeID(t, readerID), "cid-1", ACLOperationPull))
+
+		err := enforcer.Check(contextForSpiffeID(t, otherID), "cid-1", ACLOperationPull)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		err = enforcer.Check(t.Context(), "cid-1", ACLOperationPull)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "unauthenticated callers never match")
+
+		require.NoError(t, enforcer.Check(contextForSpiffeID(t, otherID), "cid-1", ACLOperationDelete), "operations without patterns are not restricted")
+	})
+
+	t.Run("the owner is always allowed", func(t *testing.T) {
+		require.NoError(t, enforcer.SetACL(contextForSpiffeID(t, ownerID), "cid-1", &corev1.RecordACL{AllowDelete: []string{readerID}}))
+
+		require.NoError(t, enforcer.Check(contextForSpiffeID(t, ownerID), "cid-1", ACLOperationDelete))
+		require.NoError(t, enforcer.Check(contextForSpiffeID(t, readerID), "cid-1", ACLOperationDelete))
+
+		err := enforcer.Check(contextForSpiffeID(t, otherID), "cid-1", ACLOperationDelete)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("empty ACLs remove the restrictions", func(t *testing.T) {
+		require.NoError(t, enforcer.SetACL(contextForSpiffeID(t, ownerID), "cid-1", &corev1.RecordACL{}))
+		require.NoError(t, enforcer.Check(contextForSpiffeID(t, otherID), "cid-1", ACLOperationDelete))
+	})
+
+	t.Run("nil enforcers do not restrict records", func(t *testing.T) {
+		var disabled *ACLEnforcer
+
+		require.NoError(t, disabled.Check(contextForSpiffeID(t, otherID), "cid-1", ACLOperationPull))
+	})
+}
+
+func TestOPAACLDecider(t *testing.T) {
+	var input struct {
+		Input ACLInput `json:"input"`
+	}
+
+	opa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+
+		// Auditors can pull everything, others are restricted by the ACL
+		allow := input.Input.Allowed || input.Input.SpiffeID == "spiffe://example.org/auditor" && input.Input.Operation == ACLOperationPull
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"result": allow})
+	}))
+	t.Cleanup(opa.Close)
+
+	enforcer := newTestACLEnforcer(t, config.Config{OPA: config.OPAConfig{URL: opa.URL + "/v1/data/dir/acl/allow", Timeout: time.Second}})
+
+	enforcer.RecordOwner(contextForSpiffeID(t, ownerID), "cid-1")
+	require.NoError(t, enforcer.SetACL(contextForSpiffeID(t, ownerID), "cid-1", &corev1.RecordACL{AllowPull: []string{readerID}}))
+
+	require.NoError(t, enforcer.Check(contextForSpiffeID(t, "spiffe://example.org/auditor"), "cid-1", ACLOperationPull))
+	assert.False(t, input.Input.Allowed)
+	assert.Equal(t, ownerID, input.Input.Owner)
+	assert.Equal(t, []string{readerID}, input.Input.AllowPull)
+	assert.Equal(t, "cid-1", input.Input.CID)
+
+	require.NoError(t, enforcer.Check(contextForSpiffeID(t, readerID), "cid-1", ACLOperationPull))
+	assert.True(t, input.Input.Allowed)
+
+	err := enforcer.Check(contextForSpiffeID(t, otherID), "cid-1", ACLOperationPull)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	t.Run("Failed decision", func(t *testing.T) {
+		failing := NewACLEnforcerWithDecider(enforcer.db, ACLDeciderFunc(func(context.Context, ACLInput) (bool, error) {
+			return false, assert.AnError
+		}))
+
+		err := failing.Check(contextForSpiffeID(t, readerID), "cid-1", ACLOperationPull)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "access is denied if the decision fails")
+	})
+}
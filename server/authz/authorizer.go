@@ -7,10 +7,14 @@ import (
 	_ "embed"
 	"fmt"
 
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/agntcy/dir/server/authz/config"
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionpbv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha" //nolint:staticcheck
 )
 
 // Defines the Casbin authorization model
@@ -24,9 +28,36 @@ var allowedExternalAPIMethods = []string{
 	storev1.StoreService_Pull_FullMethodName,                      // store: pull
 	storev1.StoreService_PullReferrer_FullMethodName,              // store: pull referrer
 	storev1.StoreService_Lookup_FullMethodName,                    // store: lookup
+	storev1.StoreService_Resolve_FullMethodName,                   // store: resolve
+	storev1.StoreService_PullBundle_FullMethodName,                // store: pull bundle
 	storev1.SyncService_RequestRegistryCredentials_FullMethodName, // sync: negotiate
+	healthpb.Health_Check_FullMethodName,                          // health: check
+	healthpb.Health_List_FullMethodName,                           // health: list
 }
 
+// Defines the reflection methods allowed to users outside of our trust domain
+// if the server serves gRPC reflection.
+var reflectionAPIMethods = []string{
+	reflectionpb.ServerReflection_ServerReflectionInfo_FullMethodName,        // reflection: describe services
+	reflectionpbv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName, // reflection: describe services (legacy clients)
+}
+
+// PushOverwritePermission is authorized in addition to the Push method for pushes
+// that overwrite records with the same name and version, see storev1.IsPushOverwrite.
+// It is only granted to users within our trust domain.
+const PushOverwritePermission = storev1.StoreService_Push_FullMethodName + ":overwrite"
+
+// IncludeDeletedPermission is authorized in addition to the Pull and Lookup methods for calls
+// that return records in the trash, see storev1.IsIncludeDeleted.
+// It is only granted to users within our trust domain, and is not derived from the Pull method,
+// whose external policy would match it as a pattern.
+const IncludeDeletedPermission = "/agntcy.dir.store.v1.StoreService/IncludeDeleted"
+
+// ListNamespacesPermission is authorized by the routing controller for List calls that
+// request the records of namespaces other than the caller's, see routingv1.ListRequest.
+// It is only granted to users within our trust domain.
+const ListNamespacesPermission = routingv1.RoutingService_List_FullMethodName + ":namespaces"
+
 type Authorizer struct {
 	enforcer *casbin.Enforcer
 }
@@ -74,5 +105,11 @@ func getPolicies(cfg config.Config) [][]string {
 		policies = append(policies, []string{"*", method})
 	}
 
+	if cfg.Reflection {
+		for _, method := range reflectionAPIMethods {
+			policies = append(policies, []string{"*", method})
+		}
+	}
+
 	return policies
 }
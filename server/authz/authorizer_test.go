@@ -9,6 +9,8 @@ import (
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/agntcy/dir/server/authz/config"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
 )
 
 func TestAuthorizer(t *testing.T) {
@@ -28,13 +30,29 @@ func TestAuthorizer(t *testing.T) {
 		{"dir.com", storev1.StoreService_Delete_FullMethodName, true},
 		{"dir.com", storev1.StoreService_Push_FullMethodName, true},
 		{"dir.com", routingv1.RoutingService_Publish_FullMethodName, true},
+		{"dir.com", storev1.StoreService_SetLifecycle_FullMethodName, true},
+		{"dir.com", storev1.StoreService_SetMetadata_FullMethodName, true},
+		{"dir.com", storev1.StoreService_SetACL_FullMethodName, true},
+		{"dir.com", PushOverwritePermission, true},
+		{"dir.com", IncludeDeletedPermission, true},
 
-		// anyone else: only pull/lookup/sync
+		// anyone else: only pull/lookup/sync/health
 		{"other.com", storev1.StoreService_Pull_FullMethodName, true},
 		{"other.com", storev1.StoreService_Lookup_FullMethodName, true},
+		{"other.com", storev1.StoreService_Resolve_FullMethodName, true},
+		{"other.com", storev1.StoreService_PullBundle_FullMethodName, true},
+		{"other.com", storev1.StoreService_PushBundle_FullMethodName, false},
 		{"other.com", storev1.SyncService_RequestRegistryCredentials_FullMethodName, true},
+		{"other.com", healthpb.Health_Check_FullMethodName, true},
+		{"other.com", reflectionpb.ServerReflection_ServerReflectionInfo_FullMethodName, false},
 		{"other.com", storev1.StoreService_Push_FullMethodName, false},
 		{"other.com", routingv1.RoutingService_Publish_FullMethodName, false},
+		{"other.com", storev1.StoreService_SetLifecycle_FullMethodName, false},
+		{"other.com", storev1.StoreService_SetMetadata_FullMethodName, false},
+		{"other.com", storev1.StoreService_SetACL_FullMethodName, false},
+		{"other.com", storev1.StoreService_GetMetadata_FullMethodName, false},
+		{"other.com", PushOverwritePermission, false},
+		{"other.com", IncludeDeletedPermission, false},
 	}
 
 	for _, tt := range tests {
@@ -48,3 +66,31 @@ func TestAuthorizer(t *testing.T) {
 		}
 	}
 }
+
+func TestAuthorizerReflection(t *testing.T) {
+	for _, served := range []bool{false, true} {
+		authz, err := NewAuthorizer(config.Config{
+			TrustDomain: "dir.com",
+			Reflection:  served,
+		})
+		if err != nil {
+			t.Fatalf("failed to create Casbin authorizer: %v", err)
+		}
+
+		for _, method := range reflectionAPIMethods {
+			allowed, err := authz.Authorize("other.com", method)
+			if err != nil {
+				t.Errorf("Authorize() error: %v", err)
+			}
+
+			// External users may only describe the services if reflection is served
+			if allowed != served {
+				t.Errorf("Authorize(%q, %q) with reflection %v = %v, want %v", "other.com", method, served, allowed, served)
+			}
+
+			if allowed, _ := authz.Authorize("dir.com", method); !allowed {
+				t.Errorf("Authorize(%q, %q) = false, want true", "dir.com", method)
+			}
+		}
+	}
+}
@@ -3,7 +3,22 @@
 
 package config
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
+
+const DefaultOPATimeout = 2 * time.Second
+
+// OPAConfig configures an Open Policy Agent deciding the access of callers to records with access control lists.
+type OPAConfig struct {
+	// URL of the OPA decision, e.g. "http://localhost:8181/v1/data/dir/acl/allow".
+	// The decision must be a boolean. Access control lists are matched by the server if it is not set.
+	URL string `json:"url,omitempty" mapstructure:"url"`
+
+	// Timeout of a decision request
+	Timeout time.Duration `json:"timeout,omitempty" mapstructure:"timeout"`
+}
 
 // Config contains configuration for authorization (AuthZ) services.
 // Authorization is separate from authentication (AuthN) - it receives
@@ -15,6 +30,13 @@ type Config struct {
 	// Trust domain for this Directory server
 	// Used to distinguish internal vs external requests
 	TrustDomain string `json:"trust_domain,omitempty" mapstructure:"trust_domain"`
+
+	// Optional policy agent deciding the access to records with access control lists
+	OPA OPAConfig `json:"opa,omitempty" mapstructure:"opa"`
+
+	// Indicates if the server serves gRPC reflection, set from the reflection setting of the server.
+	// Reflection is only allowed to users outside of the trust domain if it is served.
+	Reflection bool `json:"-" mapstructure:"-"`
 }
 
 func (c *Config) Validate() error {
@@ -26,5 +48,9 @@ func (c *Config) Validate() error {
 		return errors.New("trust domain is required for authorization")
 	}
 
+	if c.OPA.URL != "" && c.OPA.Timeout <= 0 {
+		return errors.New("OPA timeout must be positive")
+	}
+
 	return nil
 }
@@ -7,7 +7,9 @@ import (
 	"context"
 	"fmt"
 
+	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/utils/logging"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -21,10 +23,13 @@ type InterceptorFn func(ctx context.Context, apiMethod string) error
 //nolint:wrapcheck
 func NewInterceptor(authorizer *Authorizer) InterceptorFn {
 	return func(ctx context.Context, apiMethod string) error {
+		// The trust domain of the caller is logged with the request context
+		log := logging.WithContext(ctx, logger)
+
 		// Get SPIFFE ID from context (set by authentication interceptor)
 		sid, ok := authn.SpiffeIDFromContext(ctx)
 		if !ok {
-			logger.Error("Authorization failed: no SPIFFE ID in context", "method", apiMethod)
+			log.Error("Authorization failed: no SPIFFE ID in context", "method", apiMethod)
 
 			return status.Error(codes.Unauthenticated, "not authenticated")
 		}
@@ -34,10 +39,9 @@ func NewInterceptor(authorizer *Authorizer) InterceptorFn {
 		// Perform authorization check
 		allowed, err := authorizer.Authorize(trustDomain, apiMethod)
 		if err != nil {
-			logger.Error("Authorization error",
+			log.Error("Authorization error",
 				"error", err,
 				"method", apiMethod,
-				"trust_domain", trustDomain,
 				"spiffe_id", sid.String(),
 			)
 
@@ -45,18 +49,16 @@ func NewInterceptor(authorizer *Authorizer) InterceptorFn {
 		}
 
 		if !allowed {
-			logger.Warn("Authorization denied",
+			log.Warn("Authorization denied",
 				"method", apiMethod,
-				"trust_domain", trustDomain,
 				"spiffe_id", sid.String(),
 			)
 
 			return status.Error(codes.PermissionDenied, "not allowed to access "+apiMethod)
 		}
 
-		logger.Debug("Authorization successful",
+		log.Debug("Authorization successful",
 			"method", apiMethod,
-			"trust_domain", trustDomain,
 			"spiffe_id", sid.String(),
 		)
 
@@ -64,6 +66,20 @@ func NewInterceptor(authorizer *Authorizer) InterceptorFn {
 	}
 }
 
+// permissions returns the permissions required to call the API method with the context.
+func permissions(ctx context.Context, apiMethod string) []string {
+	if apiMethod == storev1.StoreService_Push_FullMethodName && storev1.IsPushOverwrite(ctx) {
+		return []string{apiMethod, PushOverwritePermission}
+	}
+
+	if (apiMethod == storev1.StoreService_Pull_FullMethodName || apiMethod == storev1.StoreService_Lookup_FullMethodName) &&
+		storev1.IsIncludeDeleted(ctx) {
+		return []string{apiMethod, IncludeDeletedPermission}
+	}
+
+	return []string{apiMethod}
+}
+
 func UnaryInterceptorFor(fn InterceptorFn) func(context.Context, any, *grpc.UnaryServerInfo, grpc.UnaryHandler) (any, error) {
 	return func(ctx context.Context, req any, sInfo *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 		if err := fn(ctx, sInfo.FullMethod); err != nil {
@@ -76,8 +92,10 @@ func UnaryInterceptorFor(fn InterceptorFn) func(context.Context, any, *grpc.Unar
 
 func StreamInterceptorFor(fn InterceptorFn) func(any, grpc.ServerStream, *grpc.StreamServerInfo, grpc.StreamHandler) error {
 	return func(srv any, ss grpc.ServerStream, sInfo *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		if err := fn(ss.Context(), sInfo.FullMethod); err != nil {
-			return err
+		for _, permission := range permissions(ss.Context(), sInfo.FullMethod) {
+			if err := fn(ss.Context(), permission); err != nil {
+				return err
+			}
 		}
 
 		return handler(srv, ss)
@@ -0,0 +1,72 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/agntcy/dir/server/authz/config"
+)
+
+// OPAACLDecider decides record access with the Data API of an Open Policy Agent.
+//
+// The decision input is the caller, the operation and the access control list of the record,
+// together with the decision of matching the access control list, e.g.
+//
+//	{"input": {"spiffe_id": "spiffe://example.org/ns/team-b/agent", "operation": "pull", "cid": "baf...",
+//	           "owner": "spiffe://example.org/ns/team-a/agent", "allow_pull": ["spiffe://example.org/ns/team-a/**"],
+//	           "allow_delete": [], "allowed": false}}
+//
+// and the result must be a boolean. Policies can use "allowed" to combine their rules with the access control list.
+type OPAACLDecider struct {
+	url    string
+	client *http.Client
+}
+
+// NewOPAACLDecider creates a decider querying the OPA decision at the configured URL.
+func NewOPAACLDecider(cfg config.OPAConfig) *OPAACLDecider {
+	return &OPAACLDecider{url: cfg.URL, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+func (d *OPAACLDecider) Decide(ctx context.Context, input ACLInput) (bool, error) {
+	body, err := json.Marshal(map[string]any{"input": input})
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal OPA input: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create OPA request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to query OPA: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("failed to query OPA: unexpected status %s", resp.Status)
+	}
+
+	var decision struct {
+		Result *bool `json:"result"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return false, fmt.Errorf("failed to decode OPA decision: %w", err)
+	}
+
+	if decision.Result == nil {
+		return false, fmt.Errorf("OPA decision %s is undefined", d.url)
+	}
+
+	return *decision.Result, nil
+}
@@ -5,6 +5,7 @@ package authz
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/agntcy/dir/server/authz/config"
@@ -49,6 +50,30 @@ func (s *Service) GetServerOptions() []grpc.ServerOption {
 	}
 }
 
+// Authorize checks that the caller of the context is granted the permission,
+// for permissions that depend on the request, e.g. ListNamespacesPermission.
+func (s *Service) Authorize(ctx context.Context, permission string) error {
+	return NewInterceptor(s.authorizer)(ctx, permission)
+}
+
+// CheckHealth verifies that the authorization policies are loaded.
+func (s *Service) CheckHealth(_ context.Context) error {
+	if s.authorizer == nil {
+		return errors.New("authorizer is not initialized")
+	}
+
+	policies, err := s.authorizer.enforcer.GetPolicy()
+	if err != nil {
+		return fmt.Errorf("failed to get policies: %w", err)
+	}
+
+	if len(policies) == 0 {
+		return errors.New("no authorization policies loaded")
+	}
+
+	return nil
+}
+
 // Stop closes any resources used by the authorization service.
 func (s *Service) Stop() error {
 	// No resources to clean up in the current implementation
@@ -11,20 +11,31 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var configFile string
+
 var rootCmd = &cobra.Command{
 	Use:   "server",
 	Short: "Run a server for the Directory services.",
 	Long:  "Run a server for the Directory services.",
 	RunE: func(cmd *cobra.Command, _ []string) error {
-		cfg, err := config.LoadConfig()
+		cfg, err := config.LoadConfigFile(configFile)
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config:\n%w", err)
+		}
+
 		return server.Run(cmd.Context(), cfg)
 	},
 }
 
+func init() {
+	rootCmd.Flags().StringVar(&configFile, "config", "",
+		"Path to a YAML or JSON config file (default "+config.DefaultConfigPath+"/"+config.DefaultConfigName+"."+config.DefaultConfigType+")")
+}
+
 func main() {
 	cobra.CheckErr(rootCmd.Execute())
 }
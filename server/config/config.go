@@ -12,12 +12,25 @@ import (
 	authz "github.com/agntcy/dir/server/authz/config"
 	database "github.com/agntcy/dir/server/database/config"
 	sqliteconfig "github.com/agntcy/dir/server/database/sqlite/config"
+	drain "github.com/agntcy/dir/server/drain/config"
+	gateway "github.com/agntcy/dir/server/gateway/config"
+	journal "github.com/agntcy/dir/server/journal/config"
+	labels "github.com/agntcy/dir/server/labels/config"
+	metrics "github.com/agntcy/dir/server/metrics/config"
 	publication "github.com/agntcy/dir/server/publication/config"
+	quota "github.com/agntcy/dir/server/quota/config"
+	ratelimit "github.com/agntcy/dir/server/ratelimit/config"
+	redaction "github.com/agntcy/dir/server/redaction/config"
 	routing "github.com/agntcy/dir/server/routing/config"
+	scanning "github.com/agntcy/dir/server/scanning/config"
+	stats "github.com/agntcy/dir/server/stats/config"
 	store "github.com/agntcy/dir/server/store/config"
 	oci "github.com/agntcy/dir/server/store/oci/config"
 	sync "github.com/agntcy/dir/server/sync/config"
 	syncmonitor "github.com/agntcy/dir/server/sync/monitor/config"
+	tracing "github.com/agntcy/dir/server/tracing/config"
+	trash "github.com/agntcy/dir/server/trash/config"
+	webhooks "github.com/agntcy/dir/server/webhooks/config"
 	"github.com/agntcy/dir/utils/logging"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
@@ -44,18 +57,67 @@ type Config struct {
 	ListenAddress      string `json:"listen_address,omitempty"      mapstructure:"listen_address"`
 	HealthCheckAddress string `json:"healthcheck_address,omitempty" mapstructure:"healthcheck_address"`
 
+	// Maximum sizes in bytes of messages received from and sent to clients.
+	// gRPC defaults apply if zero: 4MB for received messages, unlimited for sent messages.
+	// Compressed calls are accepted regardless, with gzip or zstd.
+	MaxRecvMsgSize int `json:"max_recv_msg_size,omitempty" mapstructure:"max_recv_msg_size"`
+	MaxSendMsgSize int `json:"max_send_msg_size,omitempty" mapstructure:"max_send_msg_size"`
+
+	// Serve the gRPC reflection service, e.g. to call the server with grpcurl.
+	// Disabled by default, as it describes all services of the server to its callers.
+	Reflection bool `json:"reflection,omitempty" mapstructure:"reflection"`
+
+	// gRPC keepalive configuration
+	Keepalive KeepaliveConfig `json:"keepalive,omitempty" mapstructure:"keepalive"`
+
+	// HTTP/JSON gateway configuration
+	Gateway gateway.Config `json:"gateway,omitempty" mapstructure:"gateway"`
+
+	// Prometheus metrics configuration
+	Metrics metrics.Config `json:"metrics,omitempty" mapstructure:"metrics"`
+
+	// Drain configuration (graceful shutdown)
+	Drain drain.Config `json:"drain,omitempty" mapstructure:"drain"`
+
 	// Authn configuration (JWT or X.509 authentication)
 	Authn authn.Config `json:"authn,omitempty" mapstructure:"authn"`
 
 	// Authz configuration
 	Authz authz.Config `json:"authz,omitempty" mapstructure:"authz"`
 
+	// Rate limiting configuration
+	RateLimit ratelimit.Config `json:"rate_limit,omitempty" mapstructure:"rate_limit"`
+
+	// Quota and retention configuration
+	Quota quota.Config `json:"quota,omitempty" mapstructure:"quota"`
+
+	// Operation journal configuration
+	Journal journal.Config `json:"journal,omitempty" mapstructure:"journal"`
+
+	// Webhooks configuration
+	Webhooks webhooks.Config `json:"webhooks,omitempty" mapstructure:"webhooks"`
+
+	// Record deletion configuration
+	Deletion trash.Config `json:"deletion,omitempty" mapstructure:"deletion"`
+
+	// Record usage statistics configuration
+	Stats stats.Config `json:"stats,omitempty" mapstructure:"stats"`
+
+	// Pull redaction configuration
+	Redaction redaction.Config `json:"redaction,omitempty" mapstructure:"redaction"`
+
+	// Push scanning configuration
+	Scanning scanning.Config `json:"scanning,omitempty" mapstructure:"scanning"`
+
 	// Store configuration
 	Store store.Config `json:"store,omitempty" mapstructure:"store"`
 
 	// Routing configuration
 	Routing routing.Config `json:"routing,omitempty" mapstructure:"routing"`
 
+	// Labels configuration
+	Labels labels.Config `json:"labels,omitempty" mapstructure:"labels"`
+
 	// Database configuration
 	Database database.Config `json:"database,omitempty" mapstructure:"database"`
 
@@ -64,17 +126,39 @@ type Config struct {
 
 	// Publication configuration
 	Publication publication.Config `json:"publication,omitempty" mapstructure:"publication"`
+
+	// Tracing configuration
+	Tracing tracing.Config `json:"tracing,omitempty" mapstructure:"tracing"`
+
+	// Logging configuration, overriding the DIRECTORY_LOGGER_* configuration if set
+	Logging logging.Config `json:"logging,omitempty" mapstructure:"logging"`
 }
 
+// LoadConfig loads the configuration from the default config file location,
+// see LoadConfigFile.
 func LoadConfig() (*Config, error) {
+	return LoadConfigFile("")
+}
+
+// LoadConfigFile loads the configuration from the given YAML or JSON file,
+// with DIRECTORY_SERVER_* environment variables taking precedence over the file,
+// and the file taking precedence over defaults.
+//
+// If path is empty, the file is looked up at DefaultConfigPath and defaults are used
+// if it does not exist. The loaded configuration is not validated, see Config.Validate.
+func LoadConfigFile(path string) (*Config, error) {
 	v := viper.NewWithOptions(
 		viper.KeyDelimiter("."),
 		viper.EnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_")),
 	)
 
-	v.SetConfigName(DefaultConfigName)
-	v.SetConfigType(DefaultConfigType)
-	v.AddConfigPath(DefaultConfigPath)
+	if path != "" {
+		v.SetConfigFile(path)
+	} else {
+		v.SetConfigName(DefaultConfigName)
+		v.SetConfigType(DefaultConfigType)
+		v.AddConfigPath(DefaultConfigPath)
+	}
 
 	v.SetEnvPrefix(DefaultEnvPrefix)
 	v.AllowEmptyEnv(true)
@@ -99,6 +183,42 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("healthcheck_address")
 	v.SetDefault("healthcheck_address", DefaultHealthCheckAddress)
 
+	_ = v.BindEnv("max_recv_msg_size")
+	v.SetDefault("max_recv_msg_size", 0)
+
+	_ = v.BindEnv("max_send_msg_size")
+	v.SetDefault("max_send_msg_size", 0)
+
+	_ = v.BindEnv("reflection")
+	v.SetDefault("reflection", false)
+
+	//
+	// Keepalive configuration
+	//
+	_ = v.BindEnv("keepalive.min_time")
+	v.SetDefault("keepalive.min_time", DefaultKeepaliveMinTime)
+
+	_ = v.BindEnv("keepalive.permit_without_stream")
+	v.SetDefault("keepalive.permit_without_stream", false)
+
+	_ = v.BindEnv("keepalive.time")
+	v.SetDefault("keepalive.time", 0)
+
+	_ = v.BindEnv("keepalive.timeout")
+	v.SetDefault("keepalive.timeout", 0)
+
+	//
+	// HTTP/JSON gateway configuration
+	//
+	_ = v.BindEnv("gateway.listen_address")
+	v.SetDefault("gateway.listen_address", "")
+
+	//
+	// Metrics configuration
+	//
+	_ = v.BindEnv("metrics.listen_address")
+	v.SetDefault("metrics.listen_address", "")
+
 	//
 	// Authn configuration (authentication: JWT or X.509)
 	//
@@ -114,6 +234,12 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("authn.audiences")
 	v.SetDefault("authn.audiences", "")
 
+	//
+	// Drain configuration
+	//
+	_ = v.BindEnv("drain.grace_period")
+	v.SetDefault("drain.grace_period", drain.DefaultGracePeriod)
+
 	//
 	// Authz configuration (authorization policies)
 	//
@@ -123,12 +249,186 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("authz.trust_domain")
 	v.SetDefault("authz.trust_domain", "")
 
+	_ = v.BindEnv("authz.opa.url")
+	v.SetDefault("authz.opa.url", "")
+
+	_ = v.BindEnv("authz.opa.timeout")
+	v.SetDefault("authz.opa.timeout", authz.DefaultOPATimeout)
+
+	//
+	// Rate limiting configuration
+	//
+	_ = v.BindEnv("rate_limit.enabled")
+	v.SetDefault("rate_limit.enabled", "false")
+
+	_ = v.BindEnv("rate_limit.default.rate")
+	v.SetDefault("rate_limit.default.rate", ratelimit.DefaultRate)
+
+	_ = v.BindEnv("rate_limit.default.burst")
+	v.SetDefault("rate_limit.default.burst", ratelimit.DefaultBurst)
+
+	//
+	// Quota and retention configuration
+	//
+	_ = v.BindEnv("quota.enabled")
+	v.SetDefault("quota.enabled", "false")
+
+	_ = v.BindEnv("quota.default.max_records")
+	v.SetDefault("quota.default.max_records", 0)
+
+	_ = v.BindEnv("quota.default.max_bytes")
+	v.SetDefault("quota.default.max_bytes", 0)
+
+	_ = v.BindEnv("quota.default.max_age")
+	v.SetDefault("quota.default.max_age", 0)
+
+	_ = v.BindEnv("quota.reaper_interval")
+	v.SetDefault("quota.reaper_interval", quota.DefaultReaperInterval)
+
+	_ = v.BindEnv("quota.reaper_action")
+	v.SetDefault("quota.reaper_action", string(quota.DefaultReaperAction))
+
+	//
+	// Pull redaction configuration
+	//
+	_ = v.BindEnv("redaction.enabled")
+	v.SetDefault("redaction.enabled", "false")
+
+	_ = v.BindEnv("redaction.marker")
+	v.SetDefault("redaction.marker", redaction.DefaultMarker)
+
+	_ = v.BindEnv("redaction.opa.url")
+	v.SetDefault("redaction.opa.url", "")
+
+	_ = v.BindEnv("redaction.opa.timeout")
+	v.SetDefault("redaction.opa.timeout", redaction.DefaultOPATimeout)
+
+	//
+	// Push scanning configuration
+	//
+	_ = v.BindEnv("scanning.scanners")
+	v.SetDefault("scanning.scanners", "")
+
+	_ = v.BindEnv("scanning.timeout")
+	v.SetDefault("scanning.timeout", scanning.DefaultTimeout)
+
+	_ = v.BindEnv("scanning.secrets.action")
+	v.SetDefault("scanning.secrets.action", scanning.DefaultSecretsAction)
+
+	_ = v.BindEnv("scanning.webhook.url")
+	v.SetDefault("scanning.webhook.url", "")
+
+	_ = v.BindEnv("scanning.webhook.timeout")
+	v.SetDefault("scanning.webhook.timeout", scanning.DefaultWebhookTimeout)
+
+	_ = v.BindEnv("scanning.webhook.fail_open")
+	v.SetDefault("scanning.webhook.fail_open", "false")
+
+	//
+	// Operation journal configuration
+	//
+	_ = v.BindEnv("journal.enabled")
+	v.SetDefault("journal.enabled", "false")
+
+	_ = v.BindEnv("journal.path")
+	v.SetDefault("journal.path", journal.DefaultPath)
+
+	_ = v.BindEnv("journal.max_file_size")
+	v.SetDefault("journal.max_file_size", journal.DefaultMaxFileSize)
+
+	_ = v.BindEnv("journal.max_files")
+	v.SetDefault("journal.max_files", journal.DefaultMaxFiles)
+
+	_ = v.BindEnv("journal.queue_size")
+	v.SetDefault("journal.queue_size", journal.DefaultQueueSize)
+
+	_ = v.BindEnv("journal.max_watch_lag")
+	v.SetDefault("journal.max_watch_lag", journal.DefaultMaxWatchLag)
+
+	//
+	// Webhooks configuration
+	//
+	_ = v.BindEnv("webhooks.enabled")
+	v.SetDefault("webhooks.enabled", "false")
+
+	_ = v.BindEnv("webhooks.queue_size")
+	v.SetDefault("webhooks.queue_size", webhooks.DefaultQueueSize)
+
+	_ = v.BindEnv("webhooks.workers")
+	v.SetDefault("webhooks.workers", webhooks.DefaultWorkers)
+
+	_ = v.BindEnv("webhooks.timeout")
+	v.SetDefault("webhooks.timeout", webhooks.DefaultTimeout)
+
+	_ = v.BindEnv("webhooks.max_attempts")
+	v.SetDefault("webhooks.max_attempts", webhooks.DefaultMaxAttempts)
+
+	_ = v.BindEnv("webhooks.initial_backoff")
+	v.SetDefault("webhooks.initial_backoff", webhooks.DefaultInitialBackoff)
+
+	_ = v.BindEnv("webhooks.max_backoff")
+	v.SetDefault("webhooks.max_backoff", webhooks.DefaultMaxBackoff)
+
+	_ = v.BindEnv("webhooks.dead_letter_path")
+	v.SetDefault("webhooks.dead_letter_path", webhooks.DefaultDeadLetterPath)
+
+	//
+	// Record deletion configuration
+	//
+	_ = v.BindEnv("deletion.mode")
+	v.SetDefault("deletion.mode", string(trash.DefaultMode))
+
+	_ = v.BindEnv("deletion.retention")
+	v.SetDefault("deletion.retention", trash.DefaultRetention)
+
+	_ = v.BindEnv("deletion.reaper_interval")
+	v.SetDefault("deletion.reaper_interval", trash.DefaultReaperInterval)
+
+	//
+	// Record usage statistics configuration
+	//
+	_ = v.BindEnv("stats.flush_interval")
+	v.SetDefault("stats.flush_interval", stats.DefaultFlushInterval)
+
 	//
 	// Store configuration
 	//
 	_ = v.BindEnv("store.provider")
 	v.SetDefault("store.provider", store.DefaultProvider)
 
+	_ = v.BindEnv("store.unique_name_version")
+	v.SetDefault("store.unique_name_version", false)
+
+	_ = v.BindEnv("store.name_policy")
+	v.SetDefault("store.name_policy", store.DefaultNamePolicy)
+
+	_ = v.BindEnv("store.validate_extensions")
+	v.SetDefault("store.validate_extensions", false)
+
+	_ = v.BindEnv("store.strict_extensions")
+	v.SetDefault("store.strict_extensions", false)
+
+	_ = v.BindEnv("store.dependency_policy")
+	v.SetDefault("store.dependency_policy", store.DefaultDependencyPolicy)
+
+	_ = v.BindEnv("store.cid_hash")
+	v.SetDefault("store.cid_hash", store.DefaultCIDHash)
+
+	_ = v.BindEnv("store.lint.enabled")
+	v.SetDefault("store.lint.enabled", false)
+
+	_ = v.BindEnv("store.lint.disabled")
+	_ = v.BindEnv("store.lint.severities")
+
+	_ = v.BindEnv("store.self_test.enabled")
+	v.SetDefault("store.self_test.enabled", store.DefaultSelfTestEnabled)
+
+	_ = v.BindEnv("store.self_test.skip_write")
+	v.SetDefault("store.self_test.skip_write", store.DefaultSelfTestSkipWrite)
+
+	_ = v.BindEnv("store.self_test.fail_on_error")
+	v.SetDefault("store.self_test.fail_on_error", store.DefaultSelfTestFailOnError)
+
 	_ = v.BindEnv("store.oci.local_dir")
 	v.SetDefault("store.oci.local_dir", "")
 
@@ -149,6 +449,29 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("store.oci.auth_config.access_token")
 	_ = v.BindEnv("store.oci.auth_config.refresh_token")
 
+	_ = v.BindEnv("store.oci.compression.enabled")
+	v.SetDefault("store.oci.compression.enabled", oci.DefaultCompressionEnabled)
+
+	_ = v.BindEnv("store.oci.compression.min_size_bytes")
+	v.SetDefault("store.oci.compression.min_size_bytes", oci.DefaultCompressionMinSizeBytes)
+
+	_ = v.BindEnv("store.oci.compression.level")
+	v.SetDefault("store.oci.compression.level", oci.DefaultCompressionLevel)
+
+	_ = v.BindEnv("store.oci.tag_concurrency")
+	v.SetDefault("store.oci.tag_concurrency", oci.DefaultTagConcurrency)
+
+	_ = v.BindEnv("store.oci.sharding.repository_template")
+	_ = v.BindEnv("store.oci.sharding.fallback_repository")
+
+	_ = v.BindEnv("store.oci.metadata_tag_keys")
+
+	_ = v.BindEnv("store.oci.tag_strategy.non_semver_order")
+	v.SetDefault("store.oci.tag_strategy.non_semver_order", oci.DefaultNonSemverOrder)
+
+	_ = v.BindEnv("store.oci.tag_strategy.enable_semver_floating_tags")
+	_ = v.BindEnv("store.oci.tag_strategy.include_prereleases")
+
 	//
 	// Routing configuration
 	//
@@ -216,6 +539,28 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("publication.worker_timeout")
 	v.SetDefault("publication.worker_timeout", publication.DefaultPublicationWorkerTimeout)
 
+	//
+	// Tracing configuration
+	//
+
+	_ = v.BindEnv("tracing.otlp_endpoint")
+	v.SetDefault("tracing.otlp_endpoint", tracing.DefaultOTLPEndpoint)
+
+	_ = v.BindEnv("tracing.insecure")
+	v.SetDefault("tracing.insecure", tracing.DefaultInsecure)
+
+	_ = v.BindEnv("tracing.sampling_ratio")
+	v.SetDefault("tracing.sampling_ratio", tracing.DefaultSamplingRatio)
+
+	//
+	// Logging configuration
+	//
+
+	_ = v.BindEnv("logging.log_file")
+	_ = v.BindEnv("logging.log_level")
+	_ = v.BindEnv("logging.log_format")
+	_ = v.BindEnv("logging.component_levels")
+
 	// Load configuration into struct
 	decodeHooks := mapstructure.ComposeDecodeHookFunc(
 		mapstructure.TextUnmarshallerHookFunc(),
@@ -230,3 +575,134 @@ func LoadConfig() (*Config, error) {
 
 	return config, nil
 }
+
+// Default returns a configuration suitable for local development.
+// It uses the in-memory store and keeps authentication, authorization,
+// quotas and rate limiting disabled.
+func Default() *Config {
+	return &Config{
+		ListenAddress:      DefaultListenAddress,
+		HealthCheckAddress: DefaultHealthCheckAddress,
+		Keepalive: KeepaliveConfig{
+			MinTime: DefaultKeepaliveMinTime,
+		},
+		Drain: drain.Config{
+			GracePeriod: drain.DefaultGracePeriod,
+		},
+		Authn: authn.Config{
+			Mode: authn.AuthModeX509,
+		},
+		Authz: authz.Config{
+			OPA: authz.OPAConfig{
+				Timeout: authz.DefaultOPATimeout,
+			},
+		},
+		RateLimit: ratelimit.Config{
+			Default: ratelimit.Limit{
+				Rate:  ratelimit.DefaultRate,
+				Burst: ratelimit.DefaultBurst,
+			},
+		},
+		Quota: quota.Config{
+			ReaperInterval: quota.DefaultReaperInterval,
+			ReaperAction:   quota.DefaultReaperAction,
+		},
+		Redaction: redaction.Config{
+			Marker: redaction.DefaultMarker,
+			OPA: redaction.OPAConfig{
+				Timeout: redaction.DefaultOPATimeout,
+			},
+		},
+		Scanning: scanning.Config{
+			Timeout: scanning.DefaultTimeout,
+			Secrets: scanning.SecretsConfig{
+				Action: scanning.DefaultSecretsAction,
+			},
+			Webhook: scanning.WebhookConfig{
+				Timeout: scanning.DefaultWebhookTimeout,
+			},
+		},
+		Journal: journal.Config{
+			Path:        journal.DefaultPath,
+			MaxFileSize: journal.DefaultMaxFileSize,
+			MaxFiles:    journal.DefaultMaxFiles,
+			QueueSize:   journal.DefaultQueueSize,
+			MaxWatchLag: journal.DefaultMaxWatchLag,
+		},
+		Webhooks: webhooks.Config{
+			QueueSize:      webhooks.DefaultQueueSize,
+			Workers:        webhooks.DefaultWorkers,
+			Timeout:        webhooks.DefaultTimeout,
+			MaxAttempts:    webhooks.DefaultMaxAttempts,
+			InitialBackoff: webhooks.DefaultInitialBackoff,
+			MaxBackoff:     webhooks.DefaultMaxBackoff,
+			DeadLetterPath: webhooks.DefaultDeadLetterPath,
+		},
+		Deletion: trash.Config{
+			Mode:           trash.DefaultMode,
+			Retention:      trash.DefaultRetention,
+			ReaperInterval: trash.DefaultReaperInterval,
+		},
+		Stats: stats.Config{
+			FlushInterval: stats.DefaultFlushInterval,
+		},
+		Store: store.Config{
+			Provider:         store.ProviderMemory,
+			NamePolicy:       store.DefaultNamePolicy,
+			DependencyPolicy: store.DefaultDependencyPolicy,
+			CIDHash:          store.DefaultCIDHash,
+			SelfTest: store.SelfTestConfig{
+				Enabled:     store.DefaultSelfTestEnabled,
+				SkipWrite:   store.DefaultSelfTestSkipWrite,
+				FailOnError: store.DefaultSelfTestFailOnError,
+			},
+			OCI: oci.Config{
+				RegistryAddress: oci.DefaultRegistryAddress,
+				RepositoryName:  oci.DefaultRepositoryName,
+				AuthConfig: oci.AuthConfig{
+					Insecure: oci.DefaultAuthConfigInsecure,
+				},
+				Compression: oci.CompressionConfig{
+					Enabled:      oci.DefaultCompressionEnabled,
+					MinSizeBytes: oci.DefaultCompressionMinSizeBytes,
+					Level:        oci.DefaultCompressionLevel,
+				},
+				TagConcurrency: oci.DefaultTagConcurrency,
+				TagStrategy: oci.TagStrategy{
+					NonSemverOrder: oci.DefaultNonSemverOrder,
+				},
+			},
+		},
+		Routing: routing.Config{
+			ListenAddress:  routing.DefaultListenAddress,
+			BootstrapPeers: routing.DefaultBootstrapPeers,
+			GossipSub: routing.GossipSubConfig{
+				Enabled: routing.DefaultGossipSubEnabled,
+			},
+		},
+		Database: database.Config{
+			DBType: database.DefaultDBType,
+			SQLite: sqliteconfig.Config{
+				DBPath: sqliteconfig.DefaultSQLiteDBPath,
+			},
+		},
+		Sync: sync.Config{
+			SchedulerInterval: sync.DefaultSyncSchedulerInterval,
+			WorkerCount:       sync.DefaultSyncWorkerCount,
+			WorkerTimeout:     sync.DefaultSyncWorkerTimeout,
+			RegistryMonitor: syncmonitor.Config{
+				CheckInterval: syncmonitor.DefaultCheckInterval,
+			},
+		},
+		Publication: publication.Config{
+			SchedulerInterval: publication.DefaultPublicationSchedulerInterval,
+			WorkerCount:       publication.DefaultPublicationWorkerCount,
+			WorkerTimeout:     publication.DefaultPublicationWorkerTimeout,
+		},
+		Tracing: tracing.Config{
+			OTLPEndpoint:  tracing.DefaultOTLPEndpoint,
+			Insecure:      tracing.DefaultInsecure,
+			SamplingRatio: tracing.DefaultSamplingRatio,
+		},
+	}
+}
@@ -5,20 +5,36 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	corev1 "github.com/agntcy/dir/api/core/v1"
 	authn "github.com/agntcy/dir/server/authn/config"
 	authz "github.com/agntcy/dir/server/authz/config"
 	database "github.com/agntcy/dir/server/database/config"
 	sqliteconfig "github.com/agntcy/dir/server/database/sqlite/config"
+	drain "github.com/agntcy/dir/server/drain/config"
+	gateway "github.com/agntcy/dir/server/gateway/config"
+	journal "github.com/agntcy/dir/server/journal/config"
+	metrics "github.com/agntcy/dir/server/metrics/config"
 	publication "github.com/agntcy/dir/server/publication/config"
+	quota "github.com/agntcy/dir/server/quota/config"
+	ratelimit "github.com/agntcy/dir/server/ratelimit/config"
+	redaction "github.com/agntcy/dir/server/redaction/config"
 	routing "github.com/agntcy/dir/server/routing/config"
+	scanning "github.com/agntcy/dir/server/scanning/config"
+	stats "github.com/agntcy/dir/server/stats/config"
 	store "github.com/agntcy/dir/server/store/config"
 	oci "github.com/agntcy/dir/server/store/oci/config"
 	sync "github.com/agntcy/dir/server/sync/config"
 	monitor "github.com/agntcy/dir/server/sync/monitor/config"
+	tracing "github.com/agntcy/dir/server/tracing/config"
+	trash "github.com/agntcy/dir/server/trash/config"
+	webhooks "github.com/agntcy/dir/server/webhooks/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConfig(t *testing.T) {
@@ -30,45 +46,116 @@ func TestConfig(t *testing.T) {
 		{
 			Name: "Custom config",
 			EnvVars: map[string]string{
-				"DIRECTORY_SERVER_LISTEN_ADDRESS":                       "example.com:8889",
-				"DIRECTORY_SERVER_HEALTHCHECK_ADDRESS":                  "example.com:18888",
-				"DIRECTORY_SERVER_STORE_PROVIDER":                       "provider",
-				"DIRECTORY_SERVER_STORE_OCI_LOCAL_DIR":                  "local-dir",
-				"DIRECTORY_SERVER_STORE_OCI_REGISTRY_ADDRESS":           "example.com:5001",
-				"DIRECTORY_SERVER_STORE_OCI_REPOSITORY_NAME":            "test-dir",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_INSECURE":       "true",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_USERNAME":       "username",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_PASSWORD":       "password",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_ACCESS_TOKEN":   "access-token",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_REFRESH_TOKEN":  "refresh-token",
-				"DIRECTORY_SERVER_ROUTING_LISTEN_ADDRESS":               "/ip4/1.1.1.1/tcp/1",
-				"DIRECTORY_SERVER_ROUTING_BOOTSTRAP_PEERS":              "/ip4/1.1.1.1/tcp/1,/ip4/1.1.1.1/tcp/2",
-				"DIRECTORY_SERVER_ROUTING_KEY_PATH":                     "/path/to/key",
-				"DIRECTORY_SERVER_DATABASE_DB_TYPE":                     "sqlite",
-				"DIRECTORY_SERVER_DATABASE_SQLITE_DB_PATH":              "sqlite.db",
-				"DIRECTORY_SERVER_SYNC_SCHEDULER_INTERVAL":              "1s",
-				"DIRECTORY_SERVER_SYNC_WORKER_COUNT":                    "1",
-				"DIRECTORY_SERVER_SYNC_REGISTRY_MONITOR_CHECK_INTERVAL": "10s",
-				"DIRECTORY_SERVER_SYNC_WORKER_TIMEOUT":                  "10s",
-				"DIRECTORY_SERVER_SYNC_AUTH_CONFIG_USERNAME":            "sync-user",
-				"DIRECTORY_SERVER_SYNC_AUTH_CONFIG_PASSWORD":            "sync-password",
-				"DIRECTORY_SERVER_AUTHZ_ENABLED":                        "true",
-				"DIRECTORY_SERVER_AUTHZ_SOCKET_PATH":                    "/test/agent.sock",
-				"DIRECTORY_SERVER_AUTHZ_TRUST_DOMAIN":                   "dir.com",
-				"DIRECTORY_SERVER_PUBLICATION_SCHEDULER_INTERVAL":       "10s",
-				"DIRECTORY_SERVER_PUBLICATION_WORKER_COUNT":             "1",
-				"DIRECTORY_SERVER_PUBLICATION_WORKER_TIMEOUT":           "10s",
+				"DIRECTORY_SERVER_LISTEN_ADDRESS":                                     "example.com:8889",
+				"DIRECTORY_SERVER_HEALTHCHECK_ADDRESS":                                "example.com:18888",
+				"DIRECTORY_SERVER_DRAIN_GRACE_PERIOD":                                 "45s",
+				"DIRECTORY_SERVER_MAX_RECV_MSG_SIZE":                                  "8388608",
+				"DIRECTORY_SERVER_MAX_SEND_MSG_SIZE":                                  "8388608",
+				"DIRECTORY_SERVER_REFLECTION":                                         "true",
+				"DIRECTORY_SERVER_KEEPALIVE_MIN_TIME":                                 "5s",
+				"DIRECTORY_SERVER_KEEPALIVE_PERMIT_WITHOUT_STREAM":                    "true",
+				"DIRECTORY_SERVER_KEEPALIVE_TIME":                                     "1m",
+				"DIRECTORY_SERVER_STORE_PROVIDER":                                     "provider",
+				"DIRECTORY_SERVER_STORE_UNIQUE_NAME_VERSION":                          "true",
+				"DIRECTORY_SERVER_STORE_NAME_POLICY":                                  "reject",
+				"DIRECTORY_SERVER_STORE_VALIDATE_EXTENSIONS":                          "true",
+				"DIRECTORY_SERVER_STORE_STRICT_EXTENSIONS":                            "true",
+				"DIRECTORY_SERVER_STORE_DEPENDENCY_POLICY":                            "enforce",
+				"DIRECTORY_SERVER_STORE_CID_HASH":                                     "sha2-512",
+				"DIRECTORY_SERVER_STORE_SELF_TEST_ENABLED":                            "false",
+				"DIRECTORY_SERVER_STORE_SELF_TEST_SKIP_WRITE":                         "true",
+				"DIRECTORY_SERVER_STORE_SELF_TEST_FAIL_ON_ERROR":                      "true",
+				"DIRECTORY_SERVER_STORE_OCI_LOCAL_DIR":                                "local-dir",
+				"DIRECTORY_SERVER_STORE_OCI_REGISTRY_ADDRESS":                         "example.com:5001",
+				"DIRECTORY_SERVER_STORE_OCI_REPOSITORY_NAME":                          "test-dir",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_INSECURE":                     "true",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_USERNAME":                     "username",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_PASSWORD":                     "password",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_ACCESS_TOKEN":                 "access-token",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_REFRESH_TOKEN":                "refresh-token",
+				"DIRECTORY_SERVER_STORE_OCI_COMPRESSION_ENABLED":                      "true",
+				"DIRECTORY_SERVER_STORE_OCI_COMPRESSION_MIN_SIZE_BYTES":               "1024",
+				"DIRECTORY_SERVER_STORE_OCI_COMPRESSION_LEVEL":                        "9",
+				"DIRECTORY_SERVER_STORE_OCI_TAG_CONCURRENCY":                          "10",
+				"DIRECTORY_SERVER_STORE_OCI_SHARDING_REPOSITORY_TEMPLATE":             "agents/{team}",
+				"DIRECTORY_SERVER_STORE_OCI_METADATA_TAG_KEYS":                        "team,project",
+				"DIRECTORY_SERVER_STORE_OCI_TAG_STRATEGY_NON_SEMVER_ORDER":            "lexicographic",
+				"DIRECTORY_SERVER_STORE_OCI_TAG_STRATEGY_ENABLE_SEMVER_FLOATING_TAGS": "true",
+				"DIRECTORY_SERVER_STORE_OCI_TAG_STRATEGY_INCLUDE_PRERELEASES":         "true",
+				"DIRECTORY_SERVER_ROUTING_LISTEN_ADDRESS":                             "/ip4/1.1.1.1/tcp/1",
+				"DIRECTORY_SERVER_ROUTING_BOOTSTRAP_PEERS":                            "/ip4/1.1.1.1/tcp/1,/ip4/1.1.1.1/tcp/2",
+				"DIRECTORY_SERVER_ROUTING_KEY_PATH":                                   "/path/to/key",
+				"DIRECTORY_SERVER_DATABASE_DB_TYPE":                                   "sqlite",
+				"DIRECTORY_SERVER_DATABASE_SQLITE_DB_PATH":                            "sqlite.db",
+				"DIRECTORY_SERVER_SYNC_SCHEDULER_INTERVAL":                            "1s",
+				"DIRECTORY_SERVER_SYNC_WORKER_COUNT":                                  "1",
+				"DIRECTORY_SERVER_SYNC_REGISTRY_MONITOR_CHECK_INTERVAL":               "10s",
+				"DIRECTORY_SERVER_SYNC_WORKER_TIMEOUT":                                "10s",
+				"DIRECTORY_SERVER_SYNC_AUTH_CONFIG_USERNAME":                          "sync-user",
+				"DIRECTORY_SERVER_SYNC_AUTH_CONFIG_PASSWORD":                          "sync-password",
+				"DIRECTORY_SERVER_AUTHZ_ENABLED":                                      "true",
+				"DIRECTORY_SERVER_AUTHZ_SOCKET_PATH":                                  "/test/agent.sock",
+				"DIRECTORY_SERVER_AUTHZ_TRUST_DOMAIN":                                 "dir.com",
+				"DIRECTORY_SERVER_RATE_LIMIT_ENABLED":                                 "true",
+				"DIRECTORY_SERVER_RATE_LIMIT_DEFAULT_RATE":                            "10",
+				"DIRECTORY_SERVER_RATE_LIMIT_DEFAULT_BURST":                           "20",
+				"DIRECTORY_SERVER_GATEWAY_LISTEN_ADDRESS":                             "0.0.0.0:8080",
+				"DIRECTORY_SERVER_METRICS_LISTEN_ADDRESS":                             "0.0.0.0:9090",
+				"DIRECTORY_SERVER_QUOTA_ENABLED":                                      "true",
+				"DIRECTORY_SERVER_QUOTA_DEFAULT_MAX_RECORDS":                          "1000",
+				"DIRECTORY_SERVER_QUOTA_DEFAULT_MAX_AGE":                              "720h",
+				"DIRECTORY_SERVER_QUOTA_REAPER_ACTION":                                "delete",
+				"DIRECTORY_SERVER_JOURNAL_ENABLED":                                    "true",
+				"DIRECTORY_SERVER_JOURNAL_PATH":                                       "/data/journal",
+				"DIRECTORY_SERVER_WEBHOOKS_ENABLED":                                   "true",
+				"DIRECTORY_SERVER_WEBHOOKS_MAX_ATTEMPTS":                              "3",
+				"DIRECTORY_SERVER_DELETION_MODE":                                      "soft",
+				"DIRECTORY_SERVER_DELETION_RETENTION":                                 "24h",
+				"DIRECTORY_SERVER_STATS_FLUSH_INTERVAL":                               "1m",
+				"DIRECTORY_SERVER_SCANNING_SCANNERS":                                  "secrets,webhook",
+				"DIRECTORY_SERVER_SCANNING_SECRETS_ACTION":                            "warn",
+				"DIRECTORY_SERVER_SCANNING_WEBHOOK_URL":                               "http://scanner:8080/scan",
+				"DIRECTORY_SERVER_SCANNING_WEBHOOK_TIMEOUT":                           "2s",
+				"DIRECTORY_SERVER_SCANNING_WEBHOOK_FAIL_OPEN":                         "true",
+				"DIRECTORY_SERVER_JOURNAL_MAX_FILES":                                  "4",
+				"DIRECTORY_SERVER_PUBLICATION_SCHEDULER_INTERVAL":                     "10s",
+				"DIRECTORY_SERVER_PUBLICATION_WORKER_COUNT":                           "1",
+				"DIRECTORY_SERVER_PUBLICATION_WORKER_TIMEOUT":                         "10s",
+				"DIRECTORY_SERVER_TRACING_OTLP_ENDPOINT":                              "otel-collector:4317",
+				"DIRECTORY_SERVER_TRACING_INSECURE":                                   "true",
+				"DIRECTORY_SERVER_TRACING_SAMPLING_RATIO":                             "0.25",
 			},
 			ExpectedConfig: &Config{
 				ListenAddress:      "example.com:8889",
 				HealthCheckAddress: "example.com:18888",
+				MaxRecvMsgSize:     8388608, //nolint:mnd
+				MaxSendMsgSize:     8388608, //nolint:mnd
+				Reflection:         true,
+				Keepalive: KeepaliveConfig{
+					MinTime:             5 * time.Second, //nolint:mnd
+					PermitWithoutStream: true,
+					Time:                time.Minute,
+				},
+				Drain: drain.Config{
+					GracePeriod: 45 * time.Second, //nolint:mnd
+				},
 				Authn: authn.Config{
 					Enabled:   false,
 					Mode:      authn.AuthModeX509, // Default from config.go:109
 					Audiences: []string{},
 				},
 				Store: store.Config{
-					Provider: "provider",
+					Provider:           "provider",
+					UniqueNameVersion:  true,
+					NamePolicy:         "reject",
+					ValidateExtensions: true,
+					StrictExtensions:   true,
+					DependencyPolicy:   "enforce",
+					CIDHash:            "sha2-512",
+					SelfTest: store.SelfTestConfig{
+						SkipWrite:   true,
+						FailOnError: true,
+					},
 					OCI: oci.Config{
 						LocalDir:        "local-dir",
 						RegistryAddress: "example.com:5001",
@@ -80,6 +167,21 @@ func TestConfig(t *testing.T) {
 							RefreshToken: "refresh-token",
 							AccessToken:  "access-token",
 						},
+						Compression: oci.CompressionConfig{
+							Enabled:      true,
+							MinSizeBytes: 1024, //nolint:mnd
+							Level:        9,    //nolint:mnd
+						},
+						TagConcurrency: 10, //nolint:mnd
+						Sharding: oci.ShardingConfig{
+							RepositoryTemplate: "agents/{team}",
+						},
+						MetadataTagKeys: []string{"team", "project"},
+						TagStrategy: oci.TagStrategy{
+							NonSemverOrder:           oci.NonSemverOrderLexicographic,
+							EnableSemverFloatingTags: true,
+							IncludePrereleases:       true,
+						},
 					},
 				},
 				Routing: routing.Config{
@@ -114,12 +216,86 @@ func TestConfig(t *testing.T) {
 				Authz: authz.Config{
 					Enabled:     true,
 					TrustDomain: "dir.com",
+					OPA: authz.OPAConfig{
+						Timeout: authz.DefaultOPATimeout,
+					},
+				},
+				RateLimit: ratelimit.Config{
+					Enabled: true,
+					Default: ratelimit.Limit{
+						Rate:  10, //nolint:mnd
+						Burst: 20, //nolint:mnd
+					},
+				},
+				Gateway: gateway.Config{
+					ListenAddress: "0.0.0.0:8080",
+				},
+				Metrics: metrics.Config{
+					ListenAddress: "0.0.0.0:9090",
+				},
+				Quota: quota.Config{
+					Enabled: true,
+					Default: quota.Limits{
+						MaxRecords: 1000, //nolint:mnd
+						MaxAge:     720 * time.Hour,
+					},
+					ReaperInterval: quota.DefaultReaperInterval,
+					ReaperAction:   quota.ReaperActionDelete,
+				},
+				Redaction: redaction.Config{
+					Marker: redaction.DefaultMarker,
+					OPA: redaction.OPAConfig{
+						Timeout: redaction.DefaultOPATimeout,
+					},
+				},
+				Scanning: scanning.Config{
+					Scanners: []string{"secrets", "webhook"},
+					Timeout:  scanning.DefaultTimeout,
+					Secrets: scanning.SecretsConfig{
+						Action: scanning.ActionWarn,
+					},
+					Webhook: scanning.WebhookConfig{
+						URL:      "http://scanner:8080/scan",
+						Timeout:  2 * time.Second,
+						FailOpen: true,
+					},
+				},
+				Journal: journal.Config{
+					Enabled:     true,
+					Path:        "/data/journal",
+					MaxFileSize: journal.DefaultMaxFileSize,
+					MaxFiles:    4, //nolint:mnd
+					QueueSize:   journal.DefaultQueueSize,
+					MaxWatchLag: journal.DefaultMaxWatchLag,
+				},
+				Webhooks: webhooks.Config{
+					Enabled:        true,
+					QueueSize:      webhooks.DefaultQueueSize,
+					Workers:        webhooks.DefaultWorkers,
+					Timeout:        webhooks.DefaultTimeout,
+					MaxAttempts:    3, //nolint:mnd
+					InitialBackoff: webhooks.DefaultInitialBackoff,
+					MaxBackoff:     webhooks.DefaultMaxBackoff,
+					DeadLetterPath: webhooks.DefaultDeadLetterPath,
+				},
+				Deletion: trash.Config{
+					Mode:           trash.ModeSoft,
+					Retention:      24 * time.Hour,
+					ReaperInterval: trash.DefaultReaperInterval,
+				},
+				Stats: stats.Config{
+					FlushInterval: time.Minute,
 				},
 				Publication: publication.Config{
 					SchedulerInterval: 10 * time.Second,
 					WorkerCount:       1,
 					WorkerTimeout:     10 * time.Second,
 				},
+				Tracing: tracing.Config{
+					OTLPEndpoint:  "otel-collector:4317",
+					Insecure:      true,
+					SamplingRatio: 0.25, //nolint:mnd
+				},
 			},
 		},
 		{
@@ -128,19 +304,42 @@ func TestConfig(t *testing.T) {
 			ExpectedConfig: &Config{
 				ListenAddress:      DefaultListenAddress,
 				HealthCheckAddress: DefaultHealthCheckAddress,
+				Keepalive: KeepaliveConfig{
+					MinTime: DefaultKeepaliveMinTime,
+				},
+				Drain: drain.Config{
+					GracePeriod: drain.DefaultGracePeriod,
+				},
 				Authn: authn.Config{
 					Enabled:   false,
 					Mode:      authn.AuthModeX509, // Default from config.go:109
 					Audiences: []string{},
 				},
 				Store: store.Config{
-					Provider: store.DefaultProvider,
+					Provider:         store.DefaultProvider,
+					NamePolicy:       store.DefaultNamePolicy,
+					DependencyPolicy: store.DefaultDependencyPolicy,
+					CIDHash:          store.DefaultCIDHash,
+					SelfTest: store.SelfTestConfig{
+						Enabled:     store.DefaultSelfTestEnabled,
+						SkipWrite:   store.DefaultSelfTestSkipWrite,
+						FailOnError: store.DefaultSelfTestFailOnError,
+					},
 					OCI: oci.Config{
 						RegistryAddress: oci.DefaultRegistryAddress,
 						RepositoryName:  oci.DefaultRepositoryName,
 						AuthConfig: oci.AuthConfig{
 							Insecure: oci.DefaultAuthConfigInsecure,
 						},
+						Compression: oci.CompressionConfig{
+							Enabled:      oci.DefaultCompressionEnabled,
+							MinSizeBytes: oci.DefaultCompressionMinSizeBytes,
+							Level:        oci.DefaultCompressionLevel,
+						},
+						TagConcurrency: oci.DefaultTagConcurrency,
+						TagStrategy: oci.TagStrategy{
+							NonSemverOrder: oci.DefaultNonSemverOrder,
+						},
 					},
 				},
 				Routing: routing.Config{
@@ -164,12 +363,71 @@ func TestConfig(t *testing.T) {
 						CheckInterval: monitor.DefaultCheckInterval,
 					},
 				},
-				Authz: authz.Config{},
+				Authz: authz.Config{
+					OPA: authz.OPAConfig{
+						Timeout: authz.DefaultOPATimeout,
+					},
+				},
+				RateLimit: ratelimit.Config{
+					Default: ratelimit.Limit{
+						Rate:  ratelimit.DefaultRate,
+						Burst: ratelimit.DefaultBurst,
+					},
+				},
+				Quota: quota.Config{
+					ReaperInterval: quota.DefaultReaperInterval,
+					ReaperAction:   quota.DefaultReaperAction,
+				},
+				Redaction: redaction.Config{
+					Marker: redaction.DefaultMarker,
+					OPA: redaction.OPAConfig{
+						Timeout: redaction.DefaultOPATimeout,
+					},
+				},
+				Scanning: scanning.Config{
+					Scanners: []string{},
+					Timeout:  scanning.DefaultTimeout,
+					Secrets: scanning.SecretsConfig{
+						Action: scanning.DefaultSecretsAction,
+					},
+					Webhook: scanning.WebhookConfig{
+						Timeout: scanning.DefaultWebhookTimeout,
+					},
+				},
+				Journal: journal.Config{
+					Path:        journal.DefaultPath,
+					MaxFileSize: journal.DefaultMaxFileSize,
+					MaxFiles:    journal.DefaultMaxFiles,
+					QueueSize:   journal.DefaultQueueSize,
+					MaxWatchLag: journal.DefaultMaxWatchLag,
+				},
+				Webhooks: webhooks.Config{
+					QueueSize:      webhooks.DefaultQueueSize,
+					Workers:        webhooks.DefaultWorkers,
+					Timeout:        webhooks.DefaultTimeout,
+					MaxAttempts:    webhooks.DefaultMaxAttempts,
+					InitialBackoff: webhooks.DefaultInitialBackoff,
+					MaxBackoff:     webhooks.DefaultMaxBackoff,
+					DeadLetterPath: webhooks.DefaultDeadLetterPath,
+				},
+				Deletion: trash.Config{
+					Mode:           trash.DefaultMode,
+					Retention:      trash.DefaultRetention,
+					ReaperInterval: trash.DefaultReaperInterval,
+				},
+				Stats: stats.Config{
+					FlushInterval: stats.DefaultFlushInterval,
+				},
 				Publication: publication.Config{
 					SchedulerInterval: publication.DefaultPublicationSchedulerInterval,
 					WorkerCount:       publication.DefaultPublicationWorkerCount,
 					WorkerTimeout:     publication.DefaultPublicationWorkerTimeout,
 				},
+				Tracing: tracing.Config{
+					OTLPEndpoint:  tracing.DefaultOTLPEndpoint,
+					Insecure:      tracing.DefaultInsecure,
+					SamplingRatio: tracing.DefaultSamplingRatio,
+				},
 			},
 		},
 	}
@@ -186,3 +444,157 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadConfigFilePrecedence(t *testing.T) {
+	writeFile := func(t *testing.T, name, content string) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		return path
+	}
+
+	tests := []struct {
+		Name    string
+		File    string
+		Content string
+	}{
+		{
+			Name: "YAML file",
+			File: "server.config.yaml",
+			Content: `
+listen_address: file.example.com:8888
+healthcheck_address: file.example.com:8889
+store:
+  provider: memory
+sync:
+  worker_count: 4
+`,
+		},
+		{
+			Name: "JSON file",
+			File: "server.config.json",
+			Content: `{
+  "listen_address": "file.example.com:8888",
+  "healthcheck_address": "file.example.com:8889",
+  "store": {"provider": "memory"},
+  "sync": {"worker_count": 4}
+}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			path := writeFile(t, test.File, test.Content)
+
+			t.Setenv("DIRECTORY_SERVER_LISTEN_ADDRESS", "env.example.com:8888")
+			t.Setenv("DIRECTORY_SERVER_SYNC_WORKER_COUNT", "8")
+
+			config, err := LoadConfigFile(path)
+			require.NoError(t, err)
+
+			// Env overrides file
+			assert.Equal(t, "env.example.com:8888", config.ListenAddress)
+			assert.Equal(t, 8, config.Sync.WorkerCount)
+
+			// File overrides defaults
+			assert.Equal(t, "file.example.com:8889", config.HealthCheckAddress)
+			assert.Equal(t, store.ProviderMemory, config.Store.Provider)
+
+			// Defaults apply to unset values
+			assert.Equal(t, sync.DefaultSyncWorkerTimeout, config.Sync.WorkerTimeout)
+			assert.Equal(t, oci.DefaultRegistryAddress, config.Store.OCI.RegistryAddress)
+		})
+	}
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := LoadConfigFile(filepath.Join(t.TempDir(), "missing.yaml"))
+		assert.Error(t, err)
+	})
+}
+
+func TestDefault(t *testing.T) {
+	config := Default()
+
+	assert.Equal(t, store.ProviderMemory, config.Store.Provider)
+	assert.False(t, config.Authz.Enabled)
+	assert.NoError(t, config.Validate())
+
+	// Apart from the store, the defaults match those of the loader
+	loaded, err := LoadConfig()
+	require.NoError(t, err)
+
+	loaded.Store.Provider = store.ProviderMemory
+	loaded.Authn.Audiences = nil
+	loaded.Scanning.Scanners = nil
+	assert.Equal(t, *loaded, *config)
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("Valid config", func(t *testing.T) {
+		config := Default()
+		config.Store.Provider = store.ProviderOCI
+		config.Routing.KeyPath = filepath.Join(t.TempDir(), "key")
+		require.NoError(t, os.WriteFile(config.Routing.KeyPath, []byte("key"), 0o600))
+
+		assert.NoError(t, config.Validate())
+	})
+
+	t.Run("All errors are reported", func(t *testing.T) {
+		config := Default()
+		config.ListenAddress = ""
+		config.Authz = authz.Config{Enabled: true}
+		config.Store.Provider = "oci,memory"
+		config.Routing.KeyPath = filepath.Join(t.TempDir(), "missing-key")
+		config.Database.SQLite.DBPath = filepath.Join(t.TempDir(), "missing", "dir.db")
+		config.Sync.WorkerCount = 0
+		config.Publication.WorkerTimeout = -time.Second
+		config.Tracing = tracing.Config{OTLPEndpoint: "otel-collector:4317", SamplingRatio: 2}
+
+		err := config.Validate()
+		require.Error(t, err)
+
+		for _, field := range []string{
+			"listen_address:",
+			"authz: trust domain is required",
+			"authz: authorization requires authn",
+			"store: unsupported provider",
+			"routing.key_path:",
+			"database.sqlite.db_path:",
+			"sync.worker_count: must be positive",
+			"publication.worker_timeout: must be positive",
+			"tracing: sampling ratio",
+		} {
+			assert.Contains(t, err.Error(), field)
+		}
+
+		joined, ok := err.(interface{ Unwrap() []error }) //nolint:errorlint
+		require.True(t, ok)
+		assert.Len(t, joined.Unwrap(), 9) //nolint:mnd
+	})
+
+	t.Run("Unsupported CID hash", func(t *testing.T) {
+		config := Default()
+		config.Store.CIDHash = "blake3"
+
+		err := config.Validate()
+		require.ErrorIs(t, err, corev1.ErrUnsupportedHash)
+	})
+
+	t.Run("OCI store limits", func(t *testing.T) {
+		config := Default()
+		config.Store.Provider = store.ProviderOCI
+		config.Store.OCI.RegistryAddress = ""
+		config.Store.OCI.TagConcurrency = -1
+		config.Store.OCI.TagStrategy.NonSemverOrder = "semver"
+		config.Store.OCI.Compression = oci.CompressionConfig{Enabled: true, Level: 30} //nolint:mnd
+
+		err := config.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "local dir or registry address is required")
+		assert.Contains(t, err.Error(), "tag concurrency must not be negative")
+		assert.Contains(t, err.Error(), "tag strategy non-semver order must be")
+		assert.Contains(t, err.Error(), "compression level must be between 1 and 22")
+	})
+}
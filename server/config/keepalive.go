@@ -0,0 +1,28 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import "time"
+
+// DefaultKeepaliveMinTime is the default minimum interval between client keepalive pings.
+// It is below the default keepalive time of the client, 30s.
+const DefaultKeepaliveMinTime = 15 * time.Second
+
+// KeepaliveConfig contains the gRPC keepalive settings of the server.
+// The enforcement policy must allow the keepalive settings of the clients, clients pinging
+// more often, or without active streams unless permitted, are disconnected.
+type KeepaliveConfig struct {
+	// Minimum interval between client keepalive pings.
+	MinTime time.Duration `json:"min_time,omitempty" mapstructure:"min_time"`
+
+	// Permit client keepalive pings on connections without active streams.
+	PermitWithoutStream bool `json:"permit_without_stream,omitempty" mapstructure:"permit_without_stream"`
+
+	// Interval of the server pings on idle connections, the gRPC default of 2h applies if zero.
+	Time time.Duration `json:"time,omitempty" mapstructure:"time"`
+
+	// Connections are closed if a server ping is not acknowledged within this timeout,
+	// the gRPC default of 20s applies if zero.
+	Timeout time.Duration `json:"timeout,omitempty" mapstructure:"timeout"`
+}
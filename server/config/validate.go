@@ -0,0 +1,134 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	database "github.com/agntcy/dir/server/database/config"
+)
+
+// Validate checks the configuration of all components.
+// All problems are reported at once, joined into a single error.
+func (c *Config) Validate() error {
+	var errs []error
+
+	add := func(component string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", component, err))
+		}
+	}
+
+	if c.ListenAddress == "" {
+		add("listen_address", errors.New("address is required"))
+	}
+
+	// Component validation
+	add("gateway", c.Gateway.Validate())
+	add("authn", c.Authn.Validate())
+	add("authz", c.Authz.Validate())
+	add("rate_limit", c.RateLimit.Validate())
+	add("quota", c.Quota.Validate())
+	add("redaction", c.Redaction.Validate())
+	add("scanning", c.Scanning.Validate())
+	add("store", c.Store.Validate())
+	add("tracing", c.Tracing.Validate())
+	add("logging", c.Logging.Validate())
+
+	// Authorization decisions require an authenticated caller,
+	// either via authn or via gateway tokens.
+	if c.Authz.Enabled && !c.Authn.Enabled && len(c.Gateway.Tokens) == 0 {
+		add("authz", errors.New("authorization requires authn to be enabled or gateway tokens to be configured"))
+	}
+
+	// Callers are internal if they belong to the trust domain of authorization,
+	// without it all callers would be redacted unless OPA decides.
+	if c.Redaction.Enabled && c.Redaction.OPA.URL == "" && c.Authz.TrustDomain == "" {
+		add("redaction", errors.New("redaction requires the authz trust domain or an OPA decision to be configured"))
+	}
+
+	// Referenced paths
+	if c.Routing.KeyPath != "" {
+		add("routing.key_path", fileExists(c.Routing.KeyPath))
+	}
+
+	switch c.Database.DBType {
+	case database.DefaultDBType:
+		add("database.sqlite.db_path", dirExists(filepath.Dir(c.Database.SQLite.DBPath)))
+	default:
+		add("database.db_type", fmt.Errorf("unsupported database type %q", c.Database.DBType))
+	}
+
+	// Numeric limits
+	if c.MaxRecvMsgSize < 0 {
+		add("max_recv_msg_size", errors.New("must not be negative"))
+	}
+
+	if c.MaxSendMsgSize < 0 {
+		add("max_send_msg_size", errors.New("must not be negative"))
+	}
+
+	add("drain.grace_period", notNegative(c.Drain.GracePeriod))
+	add("sync.scheduler_interval", positive(c.Sync.SchedulerInterval))
+	add("sync.worker_timeout", positive(c.Sync.WorkerTimeout))
+	add("sync.registry_monitor.check_interval", positive(c.Sync.RegistryMonitor.CheckInterval))
+	add("publication.scheduler_interval", positive(c.Publication.SchedulerInterval))
+	add("publication.worker_timeout", positive(c.Publication.WorkerTimeout))
+
+	if c.Sync.WorkerCount <= 0 {
+		add("sync.worker_count", errors.New("must be positive"))
+	}
+
+	if c.Publication.WorkerCount <= 0 {
+		add("publication.worker_count", errors.New("must be positive"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func fileExists(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	}
+
+	return nil
+}
+
+func dirExists(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", path)
+	}
+
+	return nil
+}
+
+func positive(d time.Duration) error {
+	if d <= 0 {
+		return errors.New("must be positive")
+	}
+
+	return nil
+}
+
+func notNegative(d time.Duration) error {
+	if d < 0 {
+		return errors.New("must not be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,346 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/journal"
+	"github.com/agntcy/dir/server/quota"
+	"github.com/agntcy/dir/server/selftest"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/webhooks"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var adminLogger = logging.Logger("controller/admin")
+
+// fscker is implemented by stores that support consistency checks.
+type fscker interface {
+	Fsck(ctx context.Context, repair bool, fn func(*storev1.FsckResponse) error) error
+}
+
+// resharder is implemented by stores that shard records across repositories.
+type resharder interface {
+	Reshard(ctx context.Context, dryRun bool, fn func(*storev1.ReshardResponse) error) error
+}
+
+type adminCtrl struct {
+	storev1.UnimplementedAdminServiceServer
+	store    types.StoreAPI
+	db       types.DatabaseAPI
+	quota    *quota.Service
+	journal  *journal.Journal
+	webhooks *webhooks.Dispatcher
+	selfTest *selftest.Runner
+}
+
+// NewAdminController creates a new admin service controller.
+// Usage accounting is skipped if the quota service is nil, and the journal cannot be read if it is nil.
+// Webhooks cannot be managed if the webhook dispatcher is nil, and the store cannot be self-tested if the runner is nil.
+func NewAdminController(
+	store types.StoreAPI,
+	db types.DatabaseAPI,
+	quotaService *quota.Service,
+	opJournal *journal.Journal,
+	webhookDispatcher *webhooks.Dispatcher,
+	selfTestRunner *selftest.Runner,
+) storev1.AdminServiceServer {
+	return &adminCtrl{
+		store:    store,
+		db:       db,
+		quota:    quotaService,
+		journal:  opJournal,
+		webhooks: webhookDispatcher,
+		selfTest: selfTestRunner,
+	}
+}
+
+func (c *adminCtrl) Fsck(req *storev1.FsckRequest, stream storev1.AdminService_FsckServer) error {
+	adminLogger.Debug("Called admin controller's Fsck method", "repair", req.GetRepair())
+
+	checker, ok := c.store.(fscker)
+	if !ok {
+		return status.Error(codes.Unimplemented, "consistency checks not supported by current store implementation") //nolint:wrapcheck
+	}
+
+	// Records affected by repairs are only known to be gone once the check completes,
+	// as later repairs may restore their tags
+	var repaired []string
+
+	err := checker.Fsck(stream.Context(), req.GetRepair(), func(resp *storev1.FsckResponse) error {
+		if issue := resp.GetIssue(); issue.GetRepaired() && issue.GetCid() != "" {
+			repaired = append(repaired, issue.GetCid())
+		}
+
+		return stream.Send(resp)
+	})
+
+	for _, cid := range repaired {
+		_, lookupErr := c.store.Lookup(stream.Context(), &corev1.RecordRef{Cid: cid})
+		if status.Code(lookupErr) == codes.NotFound {
+			c.forgetRecord(cid)
+		}
+	}
+
+	if err != nil {
+		st := status.Convert(err)
+
+		return status.Errorf(st.Code(), "failed to check store: %s", st.Message()) //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+func (c *adminCtrl) Reshard(req *storev1.ReshardRequest, stream storev1.AdminService_ReshardServer) error {
+	adminLogger.Debug("Called admin controller's Reshard method", "dry_run", req.GetDryRun())
+
+	store, ok := c.store.(resharder)
+	if !ok {
+		return status.Error(codes.Unimplemented, "resharding not supported by current store implementation") //nolint:wrapcheck
+	}
+
+	err := store.Reshard(stream.Context(), req.GetDryRun(), func(resp *storev1.ReshardResponse) error {
+		return stream.Send(resp)
+	})
+	if err != nil {
+		st := status.Convert(err)
+
+		return status.Errorf(st.Code(), "failed to reshard store: %s", st.Message()) //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+func (c *adminCtrl) ReadJournal(req *storev1.ReadJournalRequest, stream storev1.AdminService_ReadJournalServer) error {
+	adminLogger.Debug("Called admin controller's ReadJournal method", "since", req.GetSince())
+
+	if c.journal == nil {
+		return status.Error(codes.FailedPrecondition, "operation journal is not enabled") //nolint:wrapcheck
+	}
+
+	// Include the entries still queued when the call was made
+	if err := c.journal.Flush(stream.Context()); err != nil {
+		return status.Errorf(codes.Unavailable, "failed to flush journal: %v", err) //nolint:wrapcheck
+	}
+
+	err := c.journal.Read(req.GetSince(), func(entry *storev1.JournalEntry) error {
+		return stream.Send(entry)
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			return st.Err() //nolint:wrapcheck
+		}
+
+		return status.Errorf(codes.DataLoss, "failed to read journal: %v", err) //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+func (c *adminCtrl) ListWebhooks(_ *storev1.ListWebhooksRequest, stream storev1.AdminService_ListWebhooksServer) error {
+	adminLogger.Debug("Called admin controller's ListWebhooks method")
+
+	if !c.webhooks.Enabled() {
+		return status.Error(codes.FailedPrecondition, "webhooks are not enabled") //nolint:wrapcheck
+	}
+
+	for _, webhook := range c.webhooks.List() {
+		resp := webhookToProto(webhook.Webhook)
+		resp.Configured = webhook.Configured
+
+		if err := stream.Send(resp); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+func (c *adminCtrl) AddWebhook(_ context.Context, req *storev1.Webhook) (*storev1.Webhook, error) {
+	adminLogger.Debug("Called admin controller's AddWebhook method", "url", req.GetUrl())
+
+	if !c.webhooks.Enabled() {
+		return nil, status.Error(codes.FailedPrecondition, "webhooks are not enabled") //nolint:wrapcheck
+	}
+
+	webhook := types.Webhook{
+		URL:            req.GetUrl(),
+		Secret:         req.GetSecret(),
+		Names:          req.GetNames(),
+		Labels:         req.GetLabels(),
+		MaxAttempts:    int(req.GetMaxAttempts()),
+		InitialBackoff: req.GetInitialBackoff().AsDuration(),
+	}
+
+	for _, eventType := range req.GetEventTypes() {
+		event, ok := webhookEvents[eventType]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported webhook event type: %s", eventType) //nolint:wrapcheck
+		}
+
+		webhook.EventTypes = append(webhook.EventTypes, event)
+	}
+
+	added, err := c.webhooks.Add(webhook)
+	if err != nil {
+		if errors.Is(err, webhooks.ErrInvalid) {
+			return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to add webhook: %v", err) //nolint:wrapcheck
+	}
+
+	return webhookToProto(added), nil
+}
+
+func (c *adminCtrl) RemoveWebhook(_ context.Context, req *storev1.RemoveWebhookRequest) (*emptypb.Empty, error) {
+	adminLogger.Debug("Called admin controller's RemoveWebhook method", "id", req.GetId())
+
+	if !c.webhooks.Enabled() {
+		return nil, status.Error(codes.FailedPrecondition, "webhooks are not enabled") //nolint:wrapcheck
+	}
+
+	if err := c.webhooks.Remove(req.GetId()); err != nil {
+		switch {
+		case errors.Is(err, webhooks.ErrNotFound):
+			return nil, status.Errorf(codes.NotFound, "webhook not found: %s", req.GetId()) //nolint:wrapcheck
+		case errors.Is(err, webhooks.ErrConfigured):
+			return nil, status.Errorf(codes.FailedPrecondition, "webhook %s is part of the server configuration", req.GetId()) //nolint:wrapcheck
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to remove webhook: %v", err) //nolint:wrapcheck
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *adminCtrl) TestWebhook(ctx context.Context, req *storev1.TestWebhookRequest) (*storev1.TestWebhookResponse, error) {
+	adminLogger.Debug("Called admin controller's TestWebhook method", "id", req.GetId())
+
+	if !c.webhooks.Enabled() {
+		return nil, status.Error(codes.FailedPrecondition, "webhooks are not enabled") //nolint:wrapcheck
+	}
+
+	code, err := c.webhooks.Test(ctx, req.GetId())
+	if errors.Is(err, webhooks.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "webhook not found: %s", req.GetId()) //nolint:wrapcheck
+	}
+
+	// Delivery failures are reported in the response, as the call itself succeeded
+	resp := &storev1.TestWebhookResponse{StatusCode: uint32(code)} //nolint:gosec
+	if err != nil {
+		resp.Error = err.Error()
+	}
+
+	return resp, nil
+}
+
+func (c *adminCtrl) GetLogLevels(_ context.Context, _ *storev1.GetLogLevelsRequest) (*storev1.LogLevels, error) {
+	adminLogger.Debug("Called admin controller's GetLogLevels method")
+
+	return logLevelsToProto(), nil
+}
+
+func (c *adminCtrl) SetLogLevel(ctx context.Context, req *storev1.SetLogLevelRequest) (*storev1.LogLevels, error) {
+	adminLogger.Debug("Called admin controller's SetLogLevel method", "component", req.GetComponent(), "level", req.GetLevel())
+
+	if err := logging.SetLevel(req.GetComponent(), req.GetLevel()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to set log level: %v", err) //nolint:wrapcheck
+	}
+
+	logging.WithContext(ctx, adminLogger).Info("Log level changed", "component", req.GetComponent(), "level", req.GetLevel())
+
+	return logLevelsToProto(), nil
+}
+
+func (c *adminCtrl) SelfTest(ctx context.Context, req *storev1.SelfTestRequest) (*storev1.SelfTestReport, error) {
+	adminLogger.Debug("Called admin controller's SelfTest method", "skip_write", req.GetSkipWrite())
+
+	if c.selfTest == nil {
+		return nil, status.Error(codes.Unimplemented, selftest.ErrUnsupported.Error()) //nolint:wrapcheck
+	}
+
+	report, err := c.selfTest.Run(ctx, req.GetSkipWrite())
+	if errors.Is(err, selftest.ErrUnsupported) {
+		return nil, status.Error(codes.Unimplemented, err.Error()) //nolint:wrapcheck
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to run store self-test: %v", err) //nolint:wrapcheck
+	}
+
+	// Failed steps are reported in the response, as the call itself succeeded
+	return report, nil
+}
+
+// logLevelsToProto returns the current log levels in their API representation.
+func logLevelsToProto() *storev1.LogLevels {
+	defaultLevel, components := logging.Levels()
+
+	resp := &storev1.LogLevels{
+		DefaultLevel:    strings.ToLower(defaultLevel.String()),
+		ComponentLevels: make(map[string]string, len(components)),
+	}
+
+	for component, level := range components {
+		resp.ComponentLevels[component] = strings.ToLower(level.String())
+	}
+
+	return resp
+}
+
+// webhookEvents maps the subscribable event types of the API to those of the dispatcher.
+var webhookEvents = map[storev1.WebhookEventType]string{
+	storev1.WebhookEventType_WEBHOOK_EVENT_TYPE_PUSHED:    webhooks.EventPushed,
+	storev1.WebhookEventType_WEBHOOK_EVENT_TYPE_DELETED:   webhooks.EventDeleted,
+	storev1.WebhookEventType_WEBHOOK_EVENT_TYPE_PUBLISHED: webhooks.EventPublished,
+}
+
+// webhookToProto converts a webhook subscription to its API representation, omitting the secret.
+func webhookToProto(webhook types.Webhook) *storev1.Webhook {
+	resp := &storev1.Webhook{
+		Id:          webhook.ID,
+		Url:         webhook.URL,
+		Names:       webhook.Names,
+		Labels:      webhook.Labels,
+		MaxAttempts: uint32(webhook.MaxAttempts), //nolint:gosec
+	}
+
+	if webhook.InitialBackoff > 0 {
+		resp.InitialBackoff = durationpb.New(webhook.InitialBackoff)
+	}
+
+	for eventType, event := range webhookEvents {
+		if slices.Contains(webhook.EventTypes, event) {
+			resp.EventTypes = append(resp.EventTypes, eventType)
+		}
+	}
+
+	slices.Sort(resp.EventTypes)
+
+	return resp
+}
+
+// forgetRecord removes a record deleted by a repair from the search index and the usage accounting.
+func (c *adminCtrl) forgetRecord(cid string) {
+	if err := c.db.RemoveRecord(cid); err != nil {
+		adminLogger.Error("Failed to remove record from search index", "error", err, "cid", cid)
+	}
+
+	if c.quota != nil {
+		if err := c.quota.RecordDelete(cid); err != nil {
+			adminLogger.Error("Failed to release record usage", "error", err, "cid", cid)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// minHeartbeatInterval is the shortest interval of the heartbeats requested by clients.
+const minHeartbeatInterval = time.Second
+
+// heartbeatSender sends the messages of a server stream, and a heartbeat message whenever no
+// message was sent for the requested interval, so that load balancers and proxies see traffic on
+// quiet streams. gRPC streams must not be sent to concurrently, so sends are serialized.
+type heartbeatSender[T any] struct {
+	mu   sync.Mutex
+	send func(T) error
+	sent chan struct{}
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newHeartbeatSender returns a sender of messages with send, which sends heartbeat() every interval
+// without other messages until stopped. No heartbeats are sent if the interval is not set.
+func newHeartbeatSender[T any](interval *durationpb.Duration, send func(T) error, heartbeat func() T) *heartbeatSender[T] {
+	s := &heartbeatSender[T]{
+		send: send,
+		sent: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+	}
+
+	if interval == nil {
+		return s
+	}
+
+	period := max(interval.AsDuration(), minHeartbeatInterval)
+
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		timer := time.NewTimer(period)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-s.sent:
+				timer.Reset(period)
+			case <-timer.C:
+				// Failed heartbeats are reported by the next send, the stream is broken
+				_ = s.Send(heartbeat())
+
+				timer.Reset(period)
+			}
+		}
+	}()
+
+	return s
+}
+
+// Send sends the message.
+func (s *heartbeatSender[T]) Send(msg T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Postpone the next heartbeat
+	select {
+	case s.sent <- struct{}{}:
+	default:
+	}
+
+	return s.send(msg)
+}
+
+// Stop stops sending heartbeats, it must be called before the stream handler returns.
+func (s *heartbeatSender[T]) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
@@ -0,0 +1,39 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/quota"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var quotaLogger = logging.Logger("controller/quota")
+
+type quotaCtrl struct {
+	storev1.UnimplementedQuotaServiceServer
+	quota *quota.Service
+}
+
+// NewQuotaController creates a new quota service controller.
+func NewQuotaController(quotaService *quota.Service) storev1.QuotaServiceServer {
+	return &quotaCtrl{
+		quota: quotaService,
+	}
+}
+
+func (c *quotaCtrl) GetUsage(_ context.Context, req *storev1.GetUsageRequest) (*storev1.GetUsageResponse, error) {
+	quotaLogger.Debug("Called quota controller's GetUsage method", "trust_domain", req.GetTrustDomain())
+
+	usages, err := c.quota.Usage(req.TrustDomain)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get usage: %v", err) //nolint:wrapcheck
+	}
+
+	return &storev1.GetUsageResponse{Usages: usages}, nil
+}
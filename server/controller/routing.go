@@ -8,11 +8,14 @@ import (
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/server/authz"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/server/types/adapters"
 	"github.com/agntcy/dir/utils/logging"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -23,13 +26,27 @@ type routingCtlr struct {
 	routing     types.RoutingAPI
 	store       types.StoreAPI
 	publication types.PublicationAPI
+	db          types.DatabaseAPI
+	authz       *authz.Service
 }
 
-func NewRoutingController(routing types.RoutingAPI, store types.StoreAPI, publication types.PublicationAPI) routingv1.RoutingServiceServer {
+// NewRoutingController creates a new routing service controller.
+// Unpublished records are unpinned in the database, so that they can be deleted.
+// Listing the records of other namespaces is authorized with the authorization service,
+// and denied if it is nil.
+func NewRoutingController(
+	routing types.RoutingAPI,
+	store types.StoreAPI,
+	publication types.PublicationAPI,
+	db types.DatabaseAPI,
+	authzService *authz.Service,
+) routingv1.RoutingServiceServer {
 	return &routingCtlr{
 		routing:                           routing,
 		store:                             store,
 		publication:                       publication,
+		db:                                db,
+		authz:                             authzService,
 		UnimplementedRoutingServiceServer: routingv1.UnimplementedRoutingServiceServer{},
 	}
 }
@@ -37,6 +54,16 @@ func NewRoutingController(routing types.RoutingAPI, store types.StoreAPI, public
 func (c *routingCtlr) Publish(ctx context.Context, req *routingv1.PublishRequest) (*emptypb.Empty, error) {
 	routingLogger.Debug("Called routing controller's Publish method", "req", req)
 
+	if ttl := req.GetTtl(); ttl != nil {
+		if err := ttl.CheckValid(); err != nil || ttl.AsDuration() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "ttl must be a positive duration, got %s", ttl.AsDuration())
+		}
+	}
+
+	// Records are published in the namespace of the caller
+	req, _ = proto.Clone(req).(*routingv1.PublishRequest)
+	req.Namespace, _ = callerNamespace(ctx)
+
 	// Create publication to be handled by the publication service
 	publicationID, err := c.publication.CreatePublication(ctx, req)
 	if err != nil {
@@ -50,26 +77,163 @@ func (c *routingCtlr) Publish(ctx context.Context, req *routingv1.PublishRequest
 	return &emptypb.Empty{}, nil
 }
 
+func (c *routingCtlr) PublishAtomic(ctx context.Context, req *routingv1.PublishAtomicRequest) (*routingv1.PublishAtomicResponse, error) {
+	routingLogger.Debug("Called routing controller's PublishAtomic method", "req", req)
+
+	if len(req.GetRefs()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one record reference is required") //nolint:wrapcheck
+	}
+
+	refs := make([]*corev1.RecordRef, 0, len(req.GetRefs()))
+	seen := make(map[string]bool, len(req.GetRefs()))
+
+	for i, ref := range req.GetRefs() {
+		if ref.GetCid() == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "record reference %d has no CID", i)
+		}
+
+		if !seen[ref.GetCid()] {
+			seen[ref.GetCid()] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	if ttl := req.GetTtl(); ttl != nil {
+		if err := ttl.CheckValid(); err != nil || ttl.AsDuration() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "ttl must be a positive duration, got %s", ttl.AsDuration())
+		}
+	}
+
+	namespace, _ := callerNamespace(ctx)
+
+	transactionID, err := c.publication.PublishAtomic(ctx, refs, types.PublishOptions{
+		TTL:       req.GetTtl().AsDuration(),
+		Namespace: namespace,
+		Public:    req.GetVisibility() == routingv1.Visibility_VISIBILITY_PUBLIC,
+	})
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to publish atomically: %s", st.Message())
+	}
+
+	routingLogger.Info("Published records atomically", "transaction_id", transactionID, "records", len(refs))
+
+	return &routingv1.PublishAtomicResponse{TransactionId: transactionID}, nil
+}
+
 func (c *routingCtlr) List(req *routingv1.ListRequest, srv routingv1.RoutingService_ListServer) error {
 	routingLogger.Debug("Called routing controller's List method", "req", req)
 
-	itemChan, err := c.routing.List(srv.Context(), req)
+	scope, err := c.namespaceScope(srv.Context(), req.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	// Superseded records are filtered here, so the limit is applied to the remaining records
+	limit := req.GetLimit()
+	if !req.GetIncludeSuperseded() && limit > 0 {
+		req, _ = proto.Clone(req).(*routingv1.ListRequest)
+		req.Limit = nil
+	}
+
+	itemChan, err := c.routing.List(srv.Context(), req, scope)
 	if err != nil {
 		st := status.Convert(err)
 
 		return status.Errorf(st.Code(), "failed to list: %s", st.Message())
 	}
 
+	// Drain the remaining items if the limit is reached, so that the routing layer is not blocked
+	defer func() {
+		go func() {
+			for range itemChan { //nolint:revive
+			}
+		}()
+	}()
+
+	sender := newHeartbeatSender(req.GetHeartbeatInterval(), srv.Send, func() *routingv1.ListResponse {
+		return &routingv1.ListResponse{Heartbeat: true}
+	})
+	defer sender.Stop()
+
+	var sent uint32
+
 	// Stream ListResponse items directly to the client
 	for item := range itemChan {
-		if err := srv.Send(item); err != nil {
+		if !req.GetIncludeSuperseded() && c.isSuperseded(item.GetRecordRef().GetCid()) {
+			continue
+		}
+
+		if err := sender.Send(item); err != nil {
 			return status.Errorf(codes.Internal, "failed to send list response: %v", err)
 		}
+
+		sent++
+		if limit > 0 && sent >= limit {
+			break
+		}
 	}
 
 	return nil
 }
 
+// callerNamespace returns the namespace of the caller, its trust domain, and whether it is authenticated.
+func callerNamespace(ctx context.Context) (string, bool) {
+	if sid, ok := authn.SpiffeIDFromContext(ctx); ok {
+		return sid.TrustDomain().String(), true
+	}
+
+	return "", false
+}
+
+// namespaceScope returns the namespaces whose records are listed to the caller for the requested namespace.
+// By default, authenticated callers get the records of their namespace and the public records of the others.
+// Other namespaces can only be requested with the list namespaces permission.
+// Unauthenticated callers, e.g. of servers without authentication, are not isolated.
+func (c *routingCtlr) namespaceScope(ctx context.Context, namespace string) (types.NamespaceScope, error) {
+	caller, authenticated := callerNamespace(ctx)
+
+	switch {
+	case !authenticated && (namespace == "" || namespace == routingv1.NamespaceAll):
+		return types.AllNamespaces, nil
+	case !authenticated:
+		return types.NamespaceScope{Namespaces: []string{namespace}}, nil
+	case namespace == "":
+		return types.NamespaceScope{Namespaces: []string{caller}, Public: true}, nil
+	case namespace == caller:
+		return types.NamespaceScope{Namespaces: []string{caller}}, nil
+	}
+
+	if c.authz == nil {
+		return types.NamespaceScope{}, status.Errorf(codes.PermissionDenied,
+			"listing the records of namespace %q requires authorization to be enabled", namespace)
+	}
+
+	if err := c.authz.Authorize(ctx, authz.ListNamespacesPermission); err != nil {
+		return types.NamespaceScope{}, err //nolint:wrapcheck
+	}
+
+	if namespace == routingv1.NamespaceAll {
+		return types.AllNamespaces, nil
+	}
+
+	return types.NamespaceScope{Namespaces: []string{namespace}}, nil
+}
+
+// isSuperseded checks if a newer equivalent of the record is indexed, e.g. a record migrated from it.
+// Records whose equivalents cannot be looked up are treated as not superseded.
+func (c *routingCtlr) isSuperseded(cid string) bool {
+	_, superseded, err := c.db.GetSupersededBy(cid)
+	if err != nil {
+		routingLogger.Debug("Failed to get superseding record", "cid", cid, "error", err)
+
+		return false
+	}
+
+	return superseded
+}
+
 func (c *routingCtlr) Search(req *routingv1.SearchRequest, srv routingv1.RoutingService_SearchServer) error {
 	routingLogger.Debug("Called routing controller's Search method", "req", req)
 
@@ -118,6 +282,10 @@ func (c *routingCtlr) Unpublish(ctx context.Context, req *routingv1.UnpublishReq
 			return nil, status.Errorf(st.Code(), "failed to unpublish: %s", st.Message())
 		}
 
+		if err := c.db.UnpinRecord(ref.GetCid()); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to unpin record: %v", err)
+		}
+
 		routingLogger.Info("Successfully unpublished record", "cid", ref.GetCid())
 	}
 
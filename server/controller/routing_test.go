@@ -0,0 +1,97 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/authz"
+	authzconfig "github.com/agntcy/dir/server/authz/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRoutingNamespaceScope(t *testing.T) {
+	authzService, err := authz.New(t.Context(), authzconfig.Config{Enabled: true, TrustDomain: "dir.example.org"})
+	require.NoError(t, err)
+
+	withAuthz := &routingCtlr{authz: authzService}
+	withoutAuthz := &routingCtlr{}
+
+	tests := []struct {
+		name      string
+		ctrl      *routingCtlr
+		ctx       context.Context //nolint:containedctx
+		namespace string
+		expected  types.NamespaceScope
+		code      codes.Code
+	}{
+		{
+			name:     "own namespace and public records by default",
+			ctrl:     withAuthz,
+			ctx:      contextForTrustDomain(t, "tenant-a.org"),
+			expected: types.NamespaceScope{Namespaces: []string{"tenant-a.org"}, Public: true},
+		},
+		{
+			name:      "own namespace",
+			ctrl:      withoutAuthz,
+			ctx:       contextForTrustDomain(t, "tenant-a.org"),
+			namespace: "tenant-a.org",
+			expected:  types.NamespaceScope{Namespaces: []string{"tenant-a.org"}},
+		},
+		{
+			name:      "other namespace of an external caller",
+			ctrl:      withAuthz,
+			ctx:       contextForTrustDomain(t, "tenant-a.org"),
+			namespace: "tenant-b.org",
+			code:      codes.PermissionDenied,
+		},
+		{
+			name:      "other namespace without authorization",
+			ctrl:      withoutAuthz,
+			ctx:       contextForTrustDomain(t, "dir.example.org"),
+			namespace: "tenant-b.org",
+			code:      codes.PermissionDenied,
+		},
+		{
+			name:      "other namespace of a privileged caller",
+			ctrl:      withAuthz,
+			ctx:       contextForTrustDomain(t, "dir.example.org"),
+			namespace: routingv1.NamespaceLegacy,
+			expected:  types.NamespaceScope{Namespaces: []string{routingv1.NamespaceLegacy}},
+		},
+		{
+			name:      "all namespaces of a privileged caller",
+			ctrl:      withAuthz,
+			ctx:       contextForTrustDomain(t, "dir.example.org"),
+			namespace: routingv1.NamespaceAll,
+			expected:  types.AllNamespaces,
+		},
+		{
+			name:     "unauthenticated caller",
+			ctrl:     withoutAuthz,
+			ctx:      t.Context(),
+			expected: types.AllNamespaces,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope, err := tt.ctrl.namespaceScope(tt.ctx, tt.namespace)
+			if tt.code != codes.OK {
+				assert.Equal(t, tt.code, status.Code(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, scope)
+		})
+	}
+}
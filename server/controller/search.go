@@ -5,11 +5,14 @@ package controller
 
 import (
 	"fmt"
+	"strings"
 
 	searchv1 "github.com/agntcy/dir/api/search/v1"
 	databaseutils "github.com/agntcy/dir/server/database/utils"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var searchLogger = logging.Logger("controller/search")
@@ -31,7 +34,7 @@ func (c *searchCtlr) Search(req *searchv1.SearchRequest, srv searchv1.SearchServ
 
 	filterOptions, err := databaseutils.QueryToFilters(req.GetQueries())
 	if err != nil {
-		return fmt.Errorf("failed to create filter options: %w", err)
+		return status.Errorf(codes.InvalidArgument, "invalid search query: %v", err)
 	}
 
 	filterOptions = append(filterOptions,
@@ -39,6 +42,27 @@ func (c *searchCtlr) Search(req *searchv1.SearchRequest, srv searchv1.SearchServ
 		types.WithOffset(int(req.GetOffset())),
 	)
 
+	// Only the newest of equivalent records is returned, e.g. not the records they were migrated from
+	if !req.GetIncludeSuperseded() {
+		filterOptions = append(filterOptions, types.WithoutSuperseded())
+	}
+
+	// Rank records by the free-text query, the queries only filter the ranked records
+	if query := strings.TrimSpace(req.GetQuery()); query != "" {
+		scores, err := c.db.GetRecordScores(append(filterOptions, types.WithQuery(query))...)
+		if err != nil {
+			return fmt.Errorf("failed to get record scores: %w", err)
+		}
+
+		for _, score := range scores {
+			if err := srv.Send(&searchv1.SearchResponse{RecordCid: score.CID, Score: score.Score}); err != nil {
+				return fmt.Errorf("failed to send record: %w", err)
+			}
+		}
+
+		return nil
+	}
+
 	recordCIDs, err := c.db.GetRecordCIDs(filterOptions...)
 	if err != nil {
 		return fmt.Errorf("failed to get record CIDs: %w", err)
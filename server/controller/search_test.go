@@ -0,0 +1,81 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	storeconfig "github.com/agntcy/dir/server/store/config"
+	trashconfig "github.com/agntcy/dir/server/trash/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// searchStream collects the CIDs sent by the search controller.
+type searchStream struct {
+	grpc.ServerStream
+
+	ctx  context.Context //nolint:containedctx
+	cids []string
+}
+
+func (s *searchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *searchStream) Send(resp *searchv1.SearchResponse) error {
+	s.cids = append(s.cids, resp.GetRecordCid())
+
+	return nil
+}
+
+func TestSearchSupersededRecords(t *testing.T) {
+	client, db := newTestStoreServer(t, storeconfig.Config{}, nil, nil, trashconfig.Config{})
+	search := NewSearchController(db)
+
+	// A chain of three equivalent records, each migrated from the previous one
+	chain := make([]*corev1.RecordRef, 0, 3)
+
+	for _, description := range []string{"v1", "v2", "v3"} {
+		record := newVersionedRecord("migrated-agent", "v1.0.0", description)
+		if len(chain) > 0 {
+			require.NoError(t, record.SetMigratedFrom(chain[len(chain)-1].GetCid()))
+		}
+
+		chain = append(chain, push(t.Context(), t, client, record)...)
+	}
+
+	searchCIDs := func(req *searchv1.SearchRequest) []string {
+		t.Helper()
+
+		req.Queries = []*searchv1.RecordQuery{{Type: searchv1.RecordQueryType_RECORD_QUERY_TYPE_NAME, Value: "migrated-agent"}}
+
+		stream := &searchStream{ctx: t.Context()}
+		require.NoError(t, search.Search(req, stream))
+
+		return stream.cids
+	}
+
+	// Only the newest record of the chain is returned by default
+	assert.Equal(t, []string{chain[2].GetCid()}, searchCIDs(&searchv1.SearchRequest{}))
+
+	query := "migrated"
+	assert.Equal(t, []string{chain[2].GetCid()}, searchCIDs(&searchv1.SearchRequest{Query: &query}))
+
+	assert.ElementsMatch(t,
+		[]string{chain[0].GetCid(), chain[1].GetCid(), chain[2].GetCid()},
+		searchCIDs(&searchv1.SearchRequest{IncludeSuperseded: true}),
+	)
+
+	// The older records report the newest record of the chain
+	for _, ref := range chain[:2] {
+		assert.Equal(t, chain[2].GetCid(), lookup(t.Context(), t, client, ref).GetSupersededBy())
+	}
+
+	assert.Empty(t, lookup(t.Context(), t, client, chain[2]).GetSupersededBy())
+}
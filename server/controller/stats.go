@@ -0,0 +1,95 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/stats"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultTopRecordsLimit = 10
+	maxTopRecordsLimit     = 1000
+)
+
+var statsLogger = logging.Logger("controller/stats")
+
+type statsCtrl struct {
+	storev1.UnimplementedStatsServiceServer
+	stats *stats.Service
+}
+
+// NewStatsController creates a new stats service controller.
+func NewStatsController(statsService *stats.Service) storev1.StatsServiceServer {
+	return &statsCtrl{
+		stats: statsService,
+	}
+}
+
+func (c *statsCtrl) GetStats(ctx context.Context, ref *corev1.RecordRef) (*storev1.RecordStats, error) {
+	statsLogger.Debug("Called stats controller's GetStats method", "cid", ref.GetCid())
+
+	if ref.GetCid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "record cid is required") //nolint:wrapcheck
+	}
+
+	recordStats, err := c.stats.Get(ctx, ref.GetCid())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get record stats: %v", err) //nolint:wrapcheck
+	}
+
+	return toRecordStats(recordStats), nil
+}
+
+func (c *statsCtrl) TopRecords(ctx context.Context, req *storev1.TopRecordsRequest) (*storev1.TopRecordsResponse, error) {
+	statsLogger.Debug("Called stats controller's TopRecords method", "order", req.GetOrder(), "limit", req.GetLimit())
+
+	limit := int(req.GetLimit())
+
+	switch {
+	case limit == 0:
+		limit = defaultTopRecordsLimit
+	case limit > maxTopRecordsLimit:
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be at most %d", maxTopRecordsLimit) //nolint:wrapcheck
+	}
+
+	order := types.RecordStatsByPulls
+	if req.GetOrder() == storev1.TopRecordsOrder_TOP_RECORDS_ORDER_RECENT {
+		order = types.RecordStatsByRecent
+	}
+
+	top, err := c.stats.Top(ctx, order, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get top records: %v", err) //nolint:wrapcheck
+	}
+
+	records := make([]*storev1.RecordStats, 0, len(top))
+	for _, recordStats := range top {
+		records = append(records, toRecordStats(recordStats))
+	}
+
+	return &storev1.TopRecordsResponse{Records: records}, nil
+}
+
+func toRecordStats(recordStats types.RecordStats) *storev1.RecordStats {
+	result := &storev1.RecordStats{
+		Cid:         recordStats.CID,
+		PullCount:   recordStats.PullCount,
+		LookupCount: recordStats.LookupCount,
+	}
+
+	if !recordStats.LastAccessedAt.IsZero() {
+		result.LastAccessedAt = recordStats.LastAccessedAt.UTC().Format(time.RFC3339)
+	}
+
+	return result
+}
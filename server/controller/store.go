@@ -9,14 +9,33 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/api/extensions"
+	"github.com/agntcy/dir/api/lint"
+	"github.com/agntcy/dir/api/names"
 	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/authz"
+	"github.com/agntcy/dir/server/journal"
+	"github.com/agntcy/dir/server/labels"
+	"github.com/agntcy/dir/server/quota"
+	"github.com/agntcy/dir/server/redaction"
+	"github.com/agntcy/dir/server/scanning"
+	"github.com/agntcy/dir/server/stats"
+	storeconfig "github.com/agntcy/dir/server/store/config"
+	"github.com/agntcy/dir/server/trash"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/agntcy/dir/server/webhooks"
 	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -24,15 +43,100 @@ var storeLogger = logging.Logger("controller/store")
 
 type storeCtrl struct {
 	storev1.UnimplementedStoreServiceServer
-	store types.StoreAPI
-	db    types.DatabaseAPI
+	store   types.StoreAPI
+	db      types.DatabaseAPI
+	routing types.RoutingAPI
+	quota   *quota.Service
+	journal *journal.Journal
+
+	// trash holds the records deleted in soft deletion mode.
+	trash *trash.Service
+
+	// stats counts the pulls and lookups of records.
+	stats *stats.Service
+
+	// redactor redacts pulled records for callers that are not allowed to see all of their values.
+	redactor *redaction.Redactor
+
+	// scanner scans pushed records before they are stored, rejecting them or reporting warnings.
+	scanner scanning.Scanner
+
+	// acl records the owners of pushed records and enforces their access control lists.
+	acl *authz.ACLEnforcer
+
+	// webhooks delivers pushed and deleted records to the subscribed webhooks.
+	webhooks *webhooks.Dispatcher
+
+	// uniqueNameVersion rejects pushes of records whose name and version match a stored record with different content.
+	uniqueNameVersion bool
+
+	// namePolicy is how names and versions that are not in normal form are handled on push.
+	namePolicy string
+
+	// validateExtensions rejects pushes of records whose extension data does not match the registered schemas,
+	// and in strict mode also pushes of records with extensions without a registered schema.
+	validateExtensions bool
+	strictExtensions   bool
+
+	// dependencyPolicy is how pushes of records depending on records by CID that are not stored are handled.
+	dependencyPolicy string
+
+	// cidOptions are the options the CIDs of pushed records are generated with.
+	cidOptions corev1.CIDOptions
+
+	// lint is the configuration of the lint of pushed records, or nil if pushed records are not linted.
+	lint *lint.Config
 }
 
-func NewStoreController(store types.StoreAPI, db types.DatabaseAPI) storev1.StoreServiceServer {
+// NewStoreController creates a new store service controller.
+// Usage accounting and quota enforcement are skipped if the quota service is nil.
+// Force-deleted records are unpublished with the routing service, if it is not nil.
+// Pushed and deleted records are appended to the journal, if it is not nil.
+// Deleted records are moved to the trash, if the trash service is not nil and soft deletion is enabled.
+// Pulls and lookups are counted with the stats service, if it is not nil.
+// Pulled records are redacted with the redactor, if it is not nil.
+// Pushed records are scanned with the scanner, if it is not nil.
+// Record access control lists are enforced with the ACL enforcer, if it is not nil.
+// Pushed and deleted records are delivered to webhooks with the dispatcher, if it is not nil.
+func NewStoreController(
+	store types.StoreAPI,
+	db types.DatabaseAPI,
+	routing types.RoutingAPI,
+	quotaService *quota.Service,
+	opJournal *journal.Journal,
+	trashService *trash.Service,
+	statsService *stats.Service,
+	redactor *redaction.Redactor,
+	scanner scanning.Scanner,
+	aclEnforcer *authz.ACLEnforcer,
+	webhookDispatcher *webhooks.Dispatcher,
+	cfg storeconfig.Config,
+) storev1.StoreServiceServer {
+	var lintConfig *lint.Config
+	if cfg.Lint.Enabled {
+		lintConfig = &cfg.Lint.Config
+	}
+
 	return &storeCtrl{
 		UnimplementedStoreServiceServer: storev1.UnimplementedStoreServiceServer{},
 		store:                           store,
 		db:                              db,
+		routing:                         routing,
+		quota:                           quotaService,
+		journal:                         opJournal,
+		trash:                           trashService,
+		stats:                           statsService,
+		redactor:                        redactor,
+		scanner:                         scanner,
+		acl:                             aclEnforcer,
+		webhooks:                        webhookDispatcher,
+		uniqueNameVersion:               cfg.UniqueNameVersion,
+		namePolicy:                      cfg.NamePolicy,
+		validateExtensions:              cfg.ValidateExtensions,
+		strictExtensions:                cfg.StrictExtensions,
+		dependencyPolicy:                cfg.DependencyPolicy,
+		cidOptions:                      cfg.CIDOptions(),
+		lint:                            lintConfig,
 	}
 }
 
@@ -52,13 +156,59 @@ func (s storeCtrl) Push(stream storev1.StoreService_PushServer) error {
 			return status.Errorf(codes.Internal, "failed to receive record: %v", err)
 		}
 
+		if err := s.applyCIDOptions(record); err != nil {
+			return err
+		}
+
 		isValid, validationErrors, err := record.Validate()
 		if err != nil {
 			return status.Errorf(codes.Internal, "failed to validate record: %v", err)
 		}
 
 		if !isValid {
-			return status.Errorf(codes.InvalidArgument, "record validation failed: %v", validationErrors)
+			return validationError(validationErrors)
+		}
+
+		if err := s.validateRecordNames(record); err != nil {
+			return err
+		}
+
+		if err := s.validateRecordExtensions(record); err != nil {
+			return err
+		}
+
+		if err := s.validateRecordDependencies(stream.Context(), record); err != nil {
+			return err
+		}
+
+		findings, err := s.lintRecord(record)
+		if err != nil {
+			return err
+		}
+
+		// Conflicting records are rejected without failing the rest of the stream
+		conflict, err := s.findConflict(stream.Context(), record)
+		if err != nil {
+			return err
+		}
+
+		if conflict != nil {
+			if err := stream.Send(conflictRef(record.GetCid(), conflict)); err != nil {
+				return status.Errorf(codes.Internal, "failed to send record reference: %v", err)
+			}
+
+			continue
+		}
+
+		if s.quota != nil {
+			if err := s.quota.CheckPush(stream.Context(), record); err != nil {
+				return err
+			}
+		}
+
+		warnings, err := s.scanRecord(stream.Context(), record)
+		if err != nil {
+			return err
 		}
 
 		pushedRef, err := s.pushRecordToStore(stream.Context(), record)
@@ -66,6 +216,10 @@ func (s storeCtrl) Push(stream storev1.StoreService_PushServer) error {
 			return err
 		}
 
+		s.setScanWarnings(pushedRef.GetCid(), warnings)
+
+		pushedRef.LintFindings = findings
+
 		// Send the RecordRef back via stream
 		if err := stream.Send(pushedRef); err != nil {
 			return status.Errorf(codes.Internal, "failed to send record reference: %v", err)
@@ -91,15 +245,12 @@ func (s storeCtrl) Pull(stream storev1.StoreService_PullServer) error {
 
 		storeLogger.Debug("Pull request received", "cid", recordRef.GetCid())
 
-		// Validate record reference
-		if err := s.validateRecordRef(recordRef); err != nil {
-			return err
-		}
-
-		// Pull record from store
-		record, err := s.pullRecordFromStore(stream.Context(), recordRef)
+		// Pull record from store, reporting failures for this reference only
+		record, err := s.pullRecord(stream.Context(), recordRef)
 		if err != nil {
-			return err
+			storeLogger.Debug("Failed to pull record", "error", err, "cid", recordRef.GetCid())
+
+			record = &corev1.Record{Error: recordError(recordRef.GetCid(), err)}
 		}
 
 		// Send Record back via stream
@@ -127,21 +278,14 @@ func (s storeCtrl) Lookup(stream storev1.StoreService_LookupServer) error {
 
 		storeLogger.Debug("Lookup request received", "cid", recordRef.GetCid())
 
-		// Validate CID
-		if recordRef.GetCid() == "" {
-			return status.Error(codes.InvalidArgument, "record cid is required")
-		}
-
-		// Lookup record metadata
-		recordMeta, err := s.store.Lookup(stream.Context(), recordRef)
+		// Lookup record metadata, reporting failures for this reference only
+		recordMeta, err := s.lookupRecord(stream.Context(), recordRef)
 		if err != nil {
-			st := status.Convert(err)
+			storeLogger.Debug("Failed to lookup record", "error", err, "cid", recordRef.GetCid())
 
-			return status.Errorf(st.Code(), "failed to lookup record: %s", st.Message())
+			recordMeta = &corev1.RecordMeta{Cid: recordRef.GetCid(), Error: recordError(recordRef.GetCid(), err)}
 		}
 
-		storeLogger.Debug("Record metadata retrieved successfully", "cid", recordRef.GetCid())
-
 		// Send RecordMeta back via stream
 		if err := stream.Send(recordMeta); err != nil {
 			return status.Errorf(codes.Internal, "failed to send record metadata: %v", err)
@@ -171,29 +315,199 @@ func (s storeCtrl) Delete(stream storev1.StoreService_DeleteServer) error {
 
 		storeLogger.Debug("Delete request received", "cid", recordRef.GetCid())
 
-		// Validate CID
-		if recordRef.GetCid() == "" {
-			return status.Error(codes.InvalidArgument, "record cid is required")
+		if err := s.deleteRecord(stream.Context(), recordRef); err != nil {
+			return err
+		}
+	}
+}
+
+func (s storeCtrl) DeleteWithAck(stream storev1.StoreService_DeleteWithAckServer) error {
+	storeLogger.Debug("Called store controller's DeleteWithAck method")
+
+	for {
+		// Receive RecordRef from stream
+		recordRef, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			storeLogger.Debug("DeleteWithAck stream completed")
+
+			return nil
 		}
 
-		// Delete record from store
-		err = s.store.Delete(stream.Context(), recordRef)
 		if err != nil {
-			st := status.Convert(err)
+			return status.Errorf(codes.Internal, "failed to receive record reference: %v", err)
+		}
+
+		storeLogger.Debug("Delete request received", "cid", recordRef.GetCid())
+
+		// Delete record, reporting failures for this reference only
+		response := &storev1.DeleteResponse{RecordRef: recordRef}
+
+		if err := s.deleteRecord(stream.Context(), recordRef); err != nil {
+			storeLogger.Debug("Failed to delete record", "error", err, "cid", recordRef.GetCid())
+
+			response.Error = recordError(recordRef.GetCid(), err)
+		}
+
+		// Send acknowledgement back via stream
+		if err := stream.Send(response); err != nil {
+			return status.Errorf(codes.Internal, "failed to send delete response: %v", err)
+		}
+	}
+}
+
+// deleteRecord deletes an existing record from the store and removes it from the search index.
+// In soft deletion mode, the record is moved to the trash instead.
+func (s storeCtrl) deleteRecord(ctx context.Context, recordRef *corev1.RecordRef) error {
+	if err := s.validateRecordRef(recordRef); err != nil {
+		return err
+	}
+
+	if err := s.checkTrashed(recordRef.GetCid(), false); err != nil {
+		st := status.Convert(err)
+
+		return status.Errorf(st.Code(), "failed to delete record: %s", st.Message())
+	}
+
+	if err := s.acl.Check(ctx, recordRef.GetCid(), authz.ACLOperationDelete); err != nil {
+		st := status.Convert(err)
+
+		return status.Errorf(st.Code(), "failed to delete record: %s", st.Message())
+	}
+
+	// Make sure the record exists, as stores may treat deleting a missing record as a no-op
+	meta, err := s.store.Lookup(ctx, recordRef)
+	if err != nil {
+		st := status.Convert(err)
+
+		return status.Errorf(st.Code(), "failed to delete record: %s", st.Message())
+	}
+
+	// Webhook filters match the labels of the record, which cannot be read once it is deleted
+	event := s.deletedEvent(ctx, recordRef, meta)
+
+	if s.trash.Enabled() {
+		// Published records stay pinned in the trash, so that their publication is resumed on restore
+		if _, _, err := s.checkPin(ctx, recordRef); err != nil {
+			return err
+		}
+
+		if err := s.trash.Trash(ctx, recordRef, meta); err != nil {
+			return err
+		}
+
+		s.journal.RecordDelete(ctx, recordRef.GetCid(), meta.GetAnnotations()["name"], meta.GetAnnotations()["version"])
+		s.webhooks.Notify(event)
+
+		return nil
+	}
+
+	// Published records are pinned and must be unpublished first
+	if err := s.releasePin(ctx, recordRef); err != nil {
+		return err
+	}
+
+	// Delete record from store
+	if err := s.store.Delete(ctx, recordRef); err != nil {
+		st := status.Convert(err)
+
+		return status.Errorf(st.Code(), "failed to delete record: %s", st.Message())
+	}
+
+	// Clean up search database (secondary operation - don't fail on errors)
+	if err := s.db.RemoveRecord(recordRef.GetCid()); err != nil {
+		// Log error but don't fail the delete - storage is source of truth
+		storeLogger.Error("Failed to remove record from search index", "error", err, "cid", recordRef.GetCid())
+	} else {
+		storeLogger.Debug("Record removed from search index", "cid", recordRef.GetCid())
+	}
 
-			return status.Errorf(st.Code(), "failed to delete record: %s", st.Message())
+	s.acl.Remove(recordRef.GetCid())
+
+	// Release the quota used by the record
+	if s.quota != nil {
+		if err := s.quota.RecordDelete(recordRef.GetCid()); err != nil {
+			storeLogger.Error("Failed to release record usage", "error", err, "cid", recordRef.GetCid())
 		}
+	}
+
+	s.journal.RecordDelete(ctx, recordRef.GetCid(), meta.GetAnnotations()["name"], meta.GetAnnotations()["version"])
+	s.webhooks.Notify(event)
+
+	storeLogger.Info("Record deleted successfully", "cid", recordRef.GetCid())
 
-		// Clean up search database (secondary operation - don't fail on errors)
-		if err := s.db.RemoveRecord(recordRef.GetCid()); err != nil {
-			// Log error but don't fail the delete - storage is source of truth
-			storeLogger.Error("Failed to remove record from search index", "error", err, "cid", recordRef.GetCid())
+	return nil
+}
+
+// deletedEvent returns the webhook event of a record about to be deleted.
+// The record is only pulled for its labels if webhooks are enabled.
+func (s storeCtrl) deletedEvent(ctx context.Context, recordRef *corev1.RecordRef, meta *corev1.RecordMeta) webhooks.Event {
+	event := webhooks.Event{
+		Type: webhooks.EventDeleted,
+		CID:  recordRef.GetCid(),
+		Name: meta.GetAnnotations()["name"],
+	}
+
+	if s.webhooks.Enabled() {
+		if record, err := s.store.Pull(ctx, recordRef); err == nil {
+			event = webhooks.RecordEvent(webhooks.EventDeleted, record)
 		} else {
-			storeLogger.Debug("Record removed from search index", "cid", recordRef.GetCid())
+			storeLogger.Warn("Failed to pull deleted record for webhooks", "error", err, "cid", recordRef.GetCid())
+		}
+	}
+
+	event.Meta = meta
+
+	return event
+}
+
+// releasePin unpins a published record before it is deleted.
+// Deleting a pinned record fails with FailedPrecondition, unless the call requests to force it,
+// in which case the record is unpublished.
+func (s storeCtrl) releasePin(ctx context.Context, recordRef *corev1.RecordRef) error {
+	pin, pinned, err := s.checkPin(ctx, recordRef)
+	if err != nil || !pinned {
+		return err
+	}
+
+	if s.routing != nil {
+		record, err := s.store.Pull(ctx, recordRef)
+		if err != nil {
+			st := status.Convert(err)
+
+			return status.Errorf(st.Code(), "failed to pull record to unpublish: %s", st.Message())
 		}
 
-		storeLogger.Info("Record deleted successfully", "cid", recordRef.GetCid())
+		if err := s.routing.Unpublish(ctx, adapters.NewRecordAdapter(record)); err != nil {
+			st := status.Convert(err)
+
+			return status.Errorf(st.Code(), "failed to unpublish record: %s", st.Message())
+		}
+	}
+
+	if err := s.db.UnpinRecord(recordRef.GetCid()); err != nil {
+		return status.Errorf(codes.Internal, "failed to unpin record: %v", err)
+	}
+
+	storeLogger.Info("Unpublished pinned record for deletion", "cid", recordRef.GetCid(), "publication_id", pin.PublicationID)
+
+	return nil
+}
+
+// checkPin returns the pin of a record to delete.
+// It fails with FailedPrecondition for pinned records, unless the call requests to force the deletion.
+func (s storeCtrl) checkPin(ctx context.Context, recordRef *corev1.RecordRef) (types.RecordPin, bool, error) {
+	pin, pinned, err := s.db.GetRecordPin(recordRef.GetCid())
+	if err != nil {
+		return types.RecordPin{}, false, status.Errorf(codes.Internal, "failed to check record pin: %v", err)
 	}
+
+	if pinned && !storev1.IsDeleteForce(ctx) {
+		return pin, true, status.Errorf(codes.FailedPrecondition,
+			"record %s is published by publication %s with labels [%s], unpublish it or delete it with force",
+			recordRef.GetCid(), pin.PublicationID, strings.Join(pin.Labels, ", "))
+	}
+
+	return pin, pinned, nil
 }
 
 func (s storeCtrl) PushReferrer(stream storev1.StoreService_PushReferrerServer) error {
@@ -281,6 +595,10 @@ func (s storeCtrl) PullReferrer(stream storev1.StoreService_PullReferrerServer)
 			return err
 		}
 
+		if err := s.acl.Check(stream.Context(), request.GetRecordRef().GetCid(), authz.ACLOperationPull); err != nil {
+			return err
+		}
+
 		// Determine referrer type (empty string means all types)
 		referrerType := ""
 		if request.ReferrerType != nil {
@@ -332,7 +650,11 @@ func (s storeCtrl) pushRecordToStore(ctx context.Context, record *corev1.Record)
 		return nil, status.Errorf(codes.Internal, "failed to push record to store: %v", err)
 	}
 
-	storeLogger.Info("Record pushed to store successfully", "cid", pushedRef.GetCid())
+	if pushedRef.GetAlreadyExisted() {
+		storeLogger.Info("Record already exists in store", "cid", pushedRef.GetCid())
+	} else {
+		storeLogger.Info("Record pushed to store successfully", "cid", pushedRef.GetCid())
+	}
 
 	// Add record to search index for discoverability
 	// Use the adapter pattern to convert corev1.Record to types.Record
@@ -344,29 +666,1013 @@ func (s storeCtrl) pushRecordToStore(ctx context.Context, record *corev1.Record)
 		storeLogger.Debug("Record added to search index successfully", "cid", pushedRef.GetCid())
 	}
 
+	// Account the record to the trust domain of the caller
+	if s.quota != nil {
+		if err := s.quota.RecordPush(ctx, record); err != nil {
+			storeLogger.Error("Failed to account record usage", "error", err, "cid", pushedRef.GetCid())
+		}
+	}
+
+	// Pushing a trashed record again restores it
+	if err := s.trash.Reinstate(ctx, record); err != nil {
+		storeLogger.Error("Failed to restore pushed record from trash", "error", err, "cid", pushedRef.GetCid())
+	}
+
+	// Pushing a record again keeps its first owner
+	s.acl.RecordOwner(ctx, pushedRef.GetCid())
+
+	s.journal.RecordPush(ctx, record)
+	s.webhooks.Notify(webhooks.RecordEvent(webhooks.EventPushed, record))
+
 	return pushedRef, nil
 }
 
-// validateRecordRef validates a record reference.
-func (s storeCtrl) validateRecordRef(recordRef *corev1.RecordRef) error {
-	if recordRef.GetCid() == "" {
-		return status.Error(codes.InvalidArgument, "record cid is required")
+// findConflict returns the stored record with the same canonical name and version as the record but a different CID,
+// if unique names and versions are enforced and the push does not request to overwrite it.
+// Records are looked up in the search index, which is cheaper than listing the tags of the store.
+func (s storeCtrl) findConflict(ctx context.Context, record *corev1.Record) (*corev1.RecordConflict, error) {
+	if !s.uniqueNameVersion || storev1.IsPushOverwrite(ctx) {
+		return nil, nil //nolint:nilnil
 	}
 
-	return nil
+	data, err := adapters.NewRecordAdapter(record).GetRecordData()
+	if err != nil || data.GetName() == "" || data.GetVersion() == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	// The name filter matches partially, so candidates are compared exactly
+	candidates, err := s.db.GetRecords(types.WithName(data.GetName()), types.WithVersion(data.GetVersion()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check for conflicting records: %v", err)
+	}
+
+	for _, candidate := range candidates {
+		if candidate.GetCid() == record.GetCid() {
+			continue
+		}
+
+		// Names that are the same record name conflict, as they share their name tags
+		candidateData, err := candidate.GetRecordData()
+		if err != nil ||
+			names.CanonicalName(candidateData.GetName()) != names.CanonicalName(data.GetName()) ||
+			names.CanonicalVersion(candidateData.GetVersion()) != names.CanonicalVersion(data.GetVersion()) {
+			continue
+		}
+
+		storeLogger.Info("Rejected record conflicting with stored record",
+			"cid", record.GetCid(), "name", data.GetName(), "version", data.GetVersion(), "conflict", candidate.GetCid())
+
+		return &corev1.RecordConflict{
+			Name:    data.GetName(),
+			Version: data.GetVersion(),
+			Cid:     candidate.GetCid(),
+		}, nil
+	}
+
+	return nil, nil //nolint:nilnil
 }
 
-// pullRecordFromStore pulls a record from the store with validation.
-func (s storeCtrl) pullRecordFromStore(ctx context.Context, recordRef *corev1.RecordRef) (*corev1.Record, error) {
-	// Pull record from store
-	record, err := s.store.Pull(ctx, recordRef)
+// conflictRef is the Push response of a record rejected because of a conflict.
+func conflictRef(cid string, conflict *corev1.RecordConflict) *corev1.RecordRef {
+	st := status.Newf(codes.AlreadyExists,
+		"record %s:%s is already stored with different content as %s, push with overwrite to replace it",
+		conflict.GetName(), conflict.GetVersion(), conflict.GetCid())
+
+	if detailed, err := st.WithDetails(conflict); err == nil {
+		st = detailed
+	}
+
+	err := st.Err()
+
+	return &corev1.RecordRef{
+		Cid:      cid,
+		Error:    recordError(cid, err),
+		Conflict: conflict,
+	}
+}
+
+// Resolve resolves a discovery tag to the record it currently points to.
+func (s storeCtrl) Resolve(ctx context.Context, req *storev1.ResolveRequest) (*storev1.ResolveResponse, error) {
+	storeLogger.Debug("Called store controller's Resolve method", "tag", req.GetTag())
+
+	if req.GetTag() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tag is required")
+	}
+
+	resolver, ok := s.store.(interface {
+		Resolve(ctx context.Context, tag string) (*corev1.RecordRef, string, error)
+	})
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "tag resolution not supported by current store implementation")
+	}
+
+	ref, warning, err := resolver.Resolve(ctx, req.GetTag())
 	if err != nil {
 		st := status.Convert(err)
 
-		return nil, status.Errorf(st.Code(), "failed to pull record: %s", st.Message())
+		return nil, status.Errorf(st.Code(), "failed to resolve tag: %s", st.Message())
 	}
 
-	storeLogger.Debug("Record pulled successfully", "cid", recordRef.GetCid())
+	// Not all stores can remove the tags of trashed records
+	if err := s.checkTrashed(ref.GetCid(), false); err != nil {
+		st := status.Convert(err)
 
-	return record, nil
+		return nil, status.Errorf(st.Code(), "failed to resolve tag: %s", st.Message())
+	}
+
+	storeLogger.Debug("Tag resolved successfully", "tag", req.GetTag(), "cid", ref.GetCid())
+
+	resp := &storev1.ResolveResponse{RecordRef: ref}
+	if warning != "" {
+		resp.Warning = &warning
+	}
+
+	return resp, nil
+}
+
+// PushDryRun previews a push of the record without storing anything.
+// The CID, annotations and tags come from the store, so the preview matches what Push would write.
+func (s storeCtrl) PushDryRun(ctx context.Context, record *corev1.Record) (*storev1.PushPreview, error) {
+	storeLogger.Debug("Called store controller's PushDryRun method")
+
+	if record.GetData() == nil && record.GetEnvelope() == nil {
+		return nil, status.Error(codes.InvalidArgument, "record is required")
+	}
+
+	previewer, ok := s.store.(interface {
+		PreviewPush(ctx context.Context, record *corev1.Record) (*storev1.PushPreview, error)
+	})
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "push preview not supported by current store implementation")
+	}
+
+	if err := s.applyCIDOptions(record); err != nil {
+		return nil, err
+	}
+
+	_, validationErrors, err := record.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to validate record: %v", err)
+	}
+
+	preview, err := previewer.PreviewPush(ctx, record)
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to preview push: %s", st.Message())
+	}
+
+	for _, label := range labels.ExtractLabels(record).RoutingLabels() {
+		preview.Labels = append(preview.Labels, label.String())
+	}
+
+	preview.ValidationErrors = validationErrors
+
+	storeLogger.Debug("Push preview created", "cid", preview.GetCid(), "tags", preview.GetTags())
+
+	return preview, nil
+}
+
+// PushBundle stores a bundle manifest after checking that all of its members exist.
+func (s storeCtrl) PushBundle(ctx context.Context, bundle *corev1.RecordBundle) (*corev1.RecordRef, error) {
+	storeLogger.Debug("Called store controller's PushBundle method", "members", len(bundle.GetMembers()))
+
+	bundleStore, ok := s.store.(interface {
+		PushBundle(ctx context.Context, bundle *corev1.RecordBundle) (*corev1.RecordRef, error)
+	})
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "bundles not supported by current store implementation")
+	}
+
+	if err := bundle.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bundle: %v", err)
+	}
+
+	for _, member := range bundle.GetMembers() {
+		if _, err := s.store.Lookup(ctx, &corev1.RecordRef{Cid: member.GetCid()}); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil, status.Errorf(codes.FailedPrecondition, "bundle member not found: %s", member.GetCid())
+			}
+
+			return nil, status.Errorf(codes.Internal, "failed to lookup bundle member %s: %v", member.GetCid(), err)
+		}
+	}
+
+	ref, err := bundleStore.PushBundle(ctx, bundle)
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to push bundle: %s", st.Message())
+	}
+
+	storeLogger.Info("Bundle pushed successfully", "cid", ref.GetCid(), "alreadyExisted", ref.GetAlreadyExisted())
+
+	return ref, nil
+}
+
+// PullBundle returns the bundle manifest stored under the given CID.
+func (s storeCtrl) PullBundle(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordBundle, error) {
+	storeLogger.Debug("Called store controller's PullBundle method", "cid", ref.GetCid())
+
+	if err := s.validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	bundleStore, ok := s.store.(interface {
+		PullBundle(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordBundle, error)
+	})
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "bundles not supported by current store implementation")
+	}
+
+	bundle, err := bundleStore.PullBundle(ctx, ref)
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to pull bundle: %s", st.Message())
+	}
+
+	return bundle, nil
+}
+
+// SetLifecycle sets the lifecycle status of an existing record.
+// Only callers of the trust domain that pushed the record may change its status.
+func (s storeCtrl) SetLifecycle(ctx context.Context, req *storev1.SetLifecycleRequest) (*corev1.RecordMeta, error) {
+	storeLogger.Debug("Called store controller's SetLifecycle method", "cid", req.GetRecordRef().GetCid())
+
+	if err := s.validateRecordRef(req.GetRecordRef()); err != nil {
+		return nil, err
+	}
+
+	lifecycleStore, ok := s.store.(interface {
+		SetLifecycle(ctx context.Context, ref *corev1.RecordRef, lifecycle *corev1.Lifecycle) (*corev1.RecordMeta, error)
+	})
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "record lifecycle not supported by current store implementation")
+	}
+
+	cid := req.GetRecordRef().GetCid()
+
+	if err := req.GetLifecycle().Validate(cid); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid lifecycle: %v", err)
+	}
+
+	if _, err := s.store.Lookup(ctx, req.GetRecordRef()); err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to set record lifecycle: %s", st.Message())
+	}
+
+	if successor := req.GetLifecycle().GetSuccessorCid(); successor != "" {
+		if _, err := s.store.Lookup(ctx, &corev1.RecordRef{Cid: successor}); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil, status.Errorf(codes.FailedPrecondition, "successor record not found: %s", successor)
+			}
+
+			return nil, status.Errorf(codes.Internal, "failed to lookup successor record %s: %v", successor, err)
+		}
+	}
+
+	if s.quota != nil {
+		if err := s.quota.CheckOwner(ctx, cid); err != nil {
+			return nil, err
+		}
+	}
+
+	lifecycle := &corev1.Lifecycle{
+		Status:       req.GetLifecycle().GetStatus(),
+		SuccessorCid: req.GetLifecycle().GetSuccessorCid(),
+		Reason:       req.GetLifecycle().GetReason(),
+		UpdatedAt:    time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	meta, err := lifecycleStore.SetLifecycle(ctx, req.GetRecordRef(), lifecycle)
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to set record lifecycle: %s", st.Message())
+	}
+
+	storeLogger.Info("Record lifecycle set", "cid", cid, "status", lifecycle.StatusName())
+
+	s.journal.RecordUpdate(ctx, cid, meta.GetAnnotations()["name"], meta.GetAnnotations()["version"])
+
+	return meta, nil
+}
+
+// SetMetadata updates the operational metadata of an existing record, keeping its CID.
+// Only callers of the trust domain that pushed the record may update its metadata.
+func (s storeCtrl) SetMetadata(ctx context.Context, req *storev1.SetMetadataRequest) (*corev1.RecordMeta, error) {
+	storeLogger.Debug("Called store controller's SetMetadata method", "cid", req.GetRecordRef().GetCid())
+
+	if err := s.validateRecordRef(req.GetRecordRef()); err != nil {
+		return nil, err
+	}
+
+	metadataStore, ok := s.store.(interface {
+		SetMetadata(ctx context.Context, ref *corev1.RecordRef, update map[string]string) (*corev1.RecordMeta, error)
+	})
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "record metadata not supported by current store implementation")
+	}
+
+	cid := req.GetRecordRef().GetCid()
+
+	if err := corev1.ValidateMetadataUpdate(req.GetMetadata()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid metadata: %v", err)
+	}
+
+	if _, err := s.store.Lookup(ctx, req.GetRecordRef()); err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to set record metadata: %s", st.Message())
+	}
+
+	if err := s.acl.Check(ctx, cid, authz.ACLOperationDelete); err != nil {
+		return nil, err
+	}
+
+	if s.quota != nil {
+		if err := s.quota.CheckOwner(ctx, cid); err != nil {
+			return nil, err
+		}
+	}
+
+	meta, err := metadataStore.SetMetadata(ctx, req.GetRecordRef(), req.GetMetadata())
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to set record metadata: %s", st.Message())
+	}
+
+	storeLogger.Info("Record metadata set", "cid", cid, "keys", len(req.GetMetadata()))
+
+	s.journal.RecordUpdate(ctx, cid, meta.GetAnnotations()["name"], meta.GetAnnotations()["version"])
+
+	return meta, nil
+}
+
+// GetMetadata returns the operational metadata of an existing record.
+func (s storeCtrl) GetMetadata(ctx context.Context, req *storev1.GetMetadataRequest) (*storev1.GetMetadataResponse, error) {
+	storeLogger.Debug("Called store controller's GetMetadata method", "cid", req.GetRecordRef().GetCid())
+
+	if err := s.validateRecordRef(req.GetRecordRef()); err != nil {
+		return nil, err
+	}
+
+	metadataStore, ok := s.store.(interface {
+		GetMetadata(ctx context.Context, ref *corev1.RecordRef) (map[string]string, error)
+	})
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "record metadata not supported by current store implementation")
+	}
+
+	metadata, err := metadataStore.GetMetadata(ctx, req.GetRecordRef())
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to get record metadata: %s", st.Message())
+	}
+
+	return &storev1.GetMetadataResponse{Metadata: metadata}, nil
+}
+
+// SetACL sets the access control list of an existing record.
+// Only the caller that first pushed the record may set its access control list.
+func (s storeCtrl) SetACL(ctx context.Context, req *storev1.SetACLRequest) (*corev1.RecordMeta, error) {
+	storeLogger.Debug("Called store controller's SetACL method", "cid", req.GetRecordRef().GetCid())
+
+	if s.acl == nil {
+		return nil, status.Error(codes.FailedPrecondition, "record access control lists require authorization")
+	}
+
+	if err := s.validateRecordRef(req.GetRecordRef()); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.Lookup(ctx, req.GetRecordRef()); err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to set record access control list: %s", st.Message())
+	}
+
+	if err := s.acl.SetACL(ctx, req.GetRecordRef().GetCid(), req.GetAcl()); err != nil {
+		return nil, err
+	}
+
+	return s.lookupRecord(ctx, req.GetRecordRef())
+}
+
+// WatchStore streams the changes recorded in the operation journal after the requested sequence number.
+// Events are sent with the current metadata of their record, changes are only read from the journal.
+func (s storeCtrl) WatchStore(req *storev1.WatchStoreRequest, stream storev1.StoreService_WatchStoreServer) error {
+	storeLogger.Debug("Called store controller's WatchStore method", "from_sequence", req.GetFromSequence())
+
+	if s.journal == nil {
+		return status.Error(codes.FailedPrecondition, "operation journal is not enabled")
+	}
+
+	ctx := stream.Context()
+
+	sender := newHeartbeatSender(req.GetHeartbeatInterval(), stream.Send, func() *storev1.StoreEvent {
+		return &storev1.StoreEvent{
+			Type:      storev1.StoreEventType_STORE_EVENT_TYPE_HEARTBEAT,
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+		}
+	})
+	defer sender.Stop()
+
+	err := s.journal.Watch(ctx, req.GetFromSequence(), func(entry *storev1.JournalEntry) error {
+		return sender.Send(s.storeEvent(ctx, entry))
+	})
+
+	switch {
+	case errors.Is(err, journal.ErrNotRetained):
+		return status.Errorf(codes.OutOfRange, "failed to watch store: %v", err)
+	case errors.Is(err, journal.ErrWatchLag):
+		storeLogger.Warn("Disconnecting slow store watcher", "error", err)
+
+		return status.Errorf(codes.ResourceExhausted, "failed to watch store: %v", err)
+	case errors.Is(err, journal.ErrWatchClosed):
+		return status.Error(codes.Unavailable, "server is stopping")
+	case ctx.Err() != nil:
+		return status.FromContextError(ctx.Err()).Err()
+	case err != nil:
+		if st, ok := status.FromError(err); ok {
+			return st.Err()
+		}
+
+		return status.Errorf(codes.DataLoss, "failed to read journal: %v", err)
+	}
+
+	return nil
+}
+
+// storeEvent converts a journal entry into a store event with the current metadata of its record.
+func (s storeCtrl) storeEvent(ctx context.Context, entry *storev1.JournalEntry) *storev1.StoreEvent {
+	event := &storev1.StoreEvent{
+		Sequence:  entry.GetSequence(),
+		Cid:       entry.GetCid(),
+		Timestamp: entry.GetTimestamp(),
+		Dropped:   entry.GetDropped(),
+	}
+
+	switch entry.GetOperation() { //nolint:exhaustive
+	case storev1.JournalOperation_JOURNAL_OPERATION_PUSH:
+		event.Type = storev1.StoreEventType_STORE_EVENT_TYPE_PUSHED
+	case storev1.JournalOperation_JOURNAL_OPERATION_DELETE:
+		event.Type = storev1.StoreEventType_STORE_EVENT_TYPE_DELETED
+	case storev1.JournalOperation_JOURNAL_OPERATION_UPDATE:
+		event.Type = storev1.StoreEventType_STORE_EVENT_TYPE_METADATA_UPDATED
+	case storev1.JournalOperation_JOURNAL_OPERATION_GAP:
+		event.Type = storev1.StoreEventType_STORE_EVENT_TYPE_GAP
+
+		return event
+	}
+
+	// Records deleted since the change are reported with the metadata known from the journal
+	event.Meta = &corev1.RecordMeta{
+		Cid: entry.GetCid(),
+		Annotations: map[string]string{
+			"name":    entry.GetName(),
+			"version": entry.GetVersion(),
+		},
+	}
+
+	if event.GetType() == storev1.StoreEventType_STORE_EVENT_TYPE_DELETED {
+		return event
+	}
+
+	meta, err := s.store.Lookup(ctx, &corev1.RecordRef{Cid: entry.GetCid()})
+	if err != nil {
+		if status.Code(err) != codes.NotFound {
+			storeLogger.Warn("Failed to lookup watched record", "error", err, "cid", entry.GetCid())
+		}
+
+		return event
+	}
+
+	event.Meta = meta
+
+	return event
+}
+
+// Restore reinstates a record from the trash and returns its metadata.
+func (s storeCtrl) Restore(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	storeLogger.Debug("Called store controller's Restore method", "cid", ref.GetCid())
+
+	if !s.trash.Enabled() {
+		return nil, status.Error(codes.FailedPrecondition, "soft deletion is not enabled")
+	}
+
+	if err := s.validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	record, err := s.trash.Restore(ctx, ref)
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to restore record: %s", st.Message())
+	}
+
+	s.journal.RecordPush(ctx, record)
+	s.webhooks.Notify(webhooks.RecordEvent(webhooks.EventPushed, record))
+
+	return s.lookupRecord(ctx, ref)
+}
+
+// ListTrash streams the records in the trash, most recently deleted first.
+func (s storeCtrl) ListTrash(_ *storev1.ListTrashRequest, stream storev1.StoreService_ListTrashServer) error {
+	storeLogger.Debug("Called store controller's ListTrash method")
+
+	if !s.trash.Enabled() {
+		return status.Error(codes.FailedPrecondition, "soft deletion is not enabled")
+	}
+
+	records, err := s.trash.List()
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		err := stream.Send(&storev1.TrashedRecord{
+			Cid:       record.CID,
+			Name:      record.Name,
+			Version:   record.Version,
+			DeletedAt: record.TrashedAt.UTC().Format(time.RFC3339),
+			ExpiresAt: s.trash.ExpiresAt(record).UTC().Format(time.RFC3339),
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to send trashed record: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Purge permanently deletes a record from the trash.
+func (s storeCtrl) Purge(ctx context.Context, ref *corev1.RecordRef) (*emptypb.Empty, error) {
+	storeLogger.Debug("Called store controller's Purge method", "cid", ref.GetCid())
+
+	if !s.trash.Enabled() {
+		return nil, status.Error(codes.FailedPrecondition, "soft deletion is not enabled")
+	}
+
+	if err := s.validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.trash.Purge(ctx, ref); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// validateRecordRef validates a record reference.
+func (s storeCtrl) validateRecordRef(recordRef *corev1.RecordRef) error {
+	if recordRef.GetCid() == "" {
+		return status.Error(codes.InvalidArgument, "record cid is required")
+	}
+
+	return nil
+}
+
+// pullRecord validates the record reference and pulls the record from the store.
+func (s storeCtrl) pullRecord(ctx context.Context, recordRef *corev1.RecordRef) (*corev1.Record, error) {
+	if err := s.validateRecordRef(recordRef); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkTrashed(recordRef.GetCid(), storev1.IsIncludeDeleted(ctx)); err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to pull record: %s", st.Message())
+	}
+
+	if err := s.acl.Check(ctx, recordRef.GetCid(), authz.ACLOperationPull); err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to pull record: %s", st.Message())
+	}
+
+	// Pull record from store
+	record, err := s.store.Pull(ctx, recordRef)
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to pull record: %s", st.Message())
+	}
+
+	storeLogger.Debug("Record pulled successfully", "cid", recordRef.GetCid())
+
+	s.recordAccess(recordRef.GetCid(), true)
+
+	// Encrypted records are returned as stored, clients with the key decrypt them
+	if record.GetEnvelope() != nil {
+		record.Encrypted = true
+	}
+
+	if s.redactor != nil {
+		record = s.redactor.Redact(ctx, record)
+	}
+
+	return record, nil
+}
+
+// lookupRecord validates the record reference and looks up its metadata in the store.
+func (s storeCtrl) lookupRecord(ctx context.Context, recordRef *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	if err := s.validateRecordRef(recordRef); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkTrashed(recordRef.GetCid(), storev1.IsIncludeDeleted(ctx)); err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to lookup record: %s", st.Message())
+	}
+
+	recordMeta, err := s.store.Lookup(ctx, recordRef)
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to lookup record: %s", st.Message())
+	}
+
+	storeLogger.Debug("Record metadata retrieved successfully", "cid", recordRef.GetCid())
+
+	s.recordAccess(recordRef.GetCid(), false)
+
+	pin, pinned, err := s.db.GetRecordPin(recordRef.GetCid())
+	if err != nil {
+		storeLogger.Warn("Failed to get record pin", "error", err, "cid", recordRef.GetCid())
+	}
+
+	warnings, err := s.db.GetScanWarnings(recordRef.GetCid())
+	if err != nil {
+		storeLogger.Warn("Failed to get record scan warnings", "error", err, "cid", recordRef.GetCid())
+	}
+
+	acl, hasACL, err := s.db.GetRecordACL(recordRef.GetCid())
+	if err != nil {
+		storeLogger.Warn("Failed to get record access control list", "error", err, "cid", recordRef.GetCid())
+	}
+
+	supersededBy, superseded, err := s.db.GetSupersededBy(recordRef.GetCid())
+	if err != nil {
+		storeLogger.Warn("Failed to get superseding record", "error", err, "cid", recordRef.GetCid())
+	}
+
+	if pinned || len(warnings) > 0 || hasACL || superseded {
+		// Stores may share metadata between calls, so the pin, warnings, ACL and superseding record are reported on a copy
+		recordMeta, _ = proto.Clone(recordMeta).(*corev1.RecordMeta)
+	}
+
+	if superseded {
+		recordMeta.SupersededBy = supersededBy
+	}
+
+	if hasACL {
+		recordMeta.Owner = acl.Owner
+
+		if acl.Restricted() {
+			recordMeta.Acl = &corev1.RecordACL{AllowPull: acl.AllowPull, AllowDelete: acl.AllowDelete}
+		}
+	}
+
+	if pinned {
+		recordMeta.Pinned = true
+		recordMeta.PublicationLabels = pin.Labels
+	}
+
+	for _, warning := range warnings {
+		recordMeta.ScanWarnings = append(recordMeta.ScanWarnings, &corev1.ScanFinding{
+			Scanner:  warning.Scanner,
+			Rule:     warning.Rule,
+			Message:  warning.Message,
+			Location: warning.Location,
+		})
+	}
+
+	return recordMeta, nil
+}
+
+// scanRecord scans a pushed record and returns the warnings to report for it.
+// Records with rejecting findings fail with InvalidArgument and the findings as error details.
+func (s storeCtrl) scanRecord(ctx context.Context, record *corev1.Record) ([]types.ScanWarning, error) {
+	if s.scanner == nil {
+		return nil, nil
+	}
+
+	result, err := s.scanner.Scan(ctx, record)
+	if err != nil {
+		storeLogger.Error("Failed to scan record", "error", err, "cid", record.GetCid())
+
+		return nil, status.Errorf(codes.Unavailable, "failed to scan record: %v", err)
+	}
+
+	if err := result.Err(); err != nil {
+		storeLogger.Info("Rejected record flagged by content scan", "cid", record.GetCid(), "findings", len(result.Errors()))
+
+		return nil, err
+	}
+
+	warnings := make([]types.ScanWarning, 0, len(result.Warnings()))
+	for _, finding := range result.Warnings() {
+		warnings = append(warnings, types.ScanWarning{
+			Scanner:  finding.Scanner,
+			Rule:     finding.Rule,
+			Message:  finding.Message,
+			Location: finding.Location,
+		})
+	}
+
+	return warnings, nil
+}
+
+// setScanWarnings replaces the scan warnings of a pushed record, if pushed records are scanned.
+func (s storeCtrl) setScanWarnings(cid string, warnings []types.ScanWarning) {
+	if s.scanner == nil {
+		return
+	}
+
+	if err := s.db.SetScanWarnings(cid, warnings); err != nil {
+		storeLogger.Error("Failed to set record scan warnings", "error", err, "cid", cid)
+	}
+}
+
+// checkTrashed fails with NotFound for records in the trash, unless trashed records are included.
+func (s storeCtrl) checkTrashed(cid string, includeDeleted bool) error {
+	if includeDeleted {
+		return nil
+	}
+
+	trashed, err := s.trash.IsTrashed(cid)
+	if err != nil {
+		return err
+	}
+
+	if trashed {
+		return status.Errorf(codes.NotFound, "record %s is deleted", cid)
+	}
+
+	return nil
+}
+
+// recordAccess counts a pull or lookup of a record and resets its age.
+// Without the stats service, the age is reset with a database write for every access.
+func (s storeCtrl) recordAccess(cid string, pull bool) {
+	if s.stats != nil {
+		if pull {
+			s.stats.RecordPull(cid)
+		} else {
+			s.stats.RecordLookup(cid)
+		}
+
+		return
+	}
+
+	if s.quota == nil {
+		return
+	}
+
+	if err := s.quota.RecordAccess(cid); err != nil {
+		storeLogger.Warn("Failed to update record access time", "error", err, "cid", cid)
+	}
+}
+
+// recordError converts an error for a single record reference into its wire representation.
+// The reference CID is echoed, so that clients can match the failure to the reference,
+// and the status details are kept, so that clients can react to the kind of failure.
+func recordError(cid string, err error) *corev1.RecordError {
+	st := status.Convert(err)
+
+	return &corev1.RecordError{
+		Code:    uint32(st.Code()),
+		Message: st.Message(),
+		Cid:     cid,
+		Details: st.Proto().GetDetails(),
+	}
+}
+
+// validationError is the error of a push rejected because the record does not match its schema.
+// Each schema error is attached as a field violation, so that clients can point at the invalid fields.
+func validationError(validationErrors []string) error {
+	st := status.Newf(codes.InvalidArgument, "record validation failed: %v", validationErrors)
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(validationErrors))
+	for _, validationErr := range validationErrors {
+		field, description := splitValidationError(validationErr)
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+	}
+
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+
+	return st.Err() //nolint:wrapcheck
+}
+
+// splitValidationError splits a schema error like "JSON Schema: skills.0.name: Invalid type"
+// into the path of the field in bracket notation, "skills[0].name", and the description.
+// Errors not about a specific field are returned with an empty path.
+func splitValidationError(validationErr string) (string, string) {
+	field, description, ok := strings.Cut(strings.TrimPrefix(validationErr, "JSON Schema: "), ": ")
+	if !ok || strings.ContainsAny(field, " \t") {
+		return "", validationErr
+	}
+
+	if field == "(root)" {
+		return "", description
+	}
+
+	var path strings.Builder
+
+	for i, segment := range strings.Split(field, ".") {
+		if _, err := strconv.Atoi(segment); err == nil {
+			path.WriteString("[" + segment + "]")
+
+			continue
+		}
+
+		if i > 0 {
+			path.WriteString(".")
+		}
+
+		path.WriteString(segment)
+	}
+
+	return path.String(), description
+}
+
+// lintRecord lints the record with the configured rules, if enabled, returning the findings to attach to its ref.
+// The record is rejected if a finding has the error severity, with the findings attached as field violations.
+func (s storeCtrl) lintRecord(record *corev1.Record) ([]*corev1.LintFinding, error) {
+	if s.lint == nil {
+		return nil, nil
+	}
+
+	findings, err := lint.Run(record, *s.lint)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to lint record: %v", err)
+	}
+
+	if !lint.MaxSeverity(findings).AtLeast(lint.SeverityError) {
+		lintFindings := make([]*corev1.LintFinding, 0, len(findings))
+		for _, finding := range findings {
+			lintFindings = append(lintFindings, &corev1.LintFinding{
+				Severity: string(finding.Severity),
+				RuleId:   finding.RuleID,
+				Path:     finding.Path,
+				Message:  finding.Message,
+			})
+		}
+
+		return lintFindings, nil
+	}
+
+	var (
+		messages   []string
+		violations []*errdetails.BadRequest_FieldViolation
+	)
+
+	for _, finding := range findings {
+		if finding.Severity != lint.SeverityError {
+			continue
+		}
+
+		messages = append(messages, finding.RuleID+": "+finding.Message)
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       finding.Path,
+			Description: finding.RuleID + ": " + finding.Message,
+		})
+	}
+
+	st := status.Newf(codes.InvalidArgument, "record lint failed: %v", messages)
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+
+	return nil, st.Err() //nolint:wrapcheck
+}
+
+// validateRecordExtensions checks the extension data of the record against the registered extension schemas, if enabled.
+// The extensions of encrypted records cannot be read and are not checked.
+func (s storeCtrl) validateRecordExtensions(record *corev1.Record) error {
+	if !s.validateExtensions || record.IsEncrypted() {
+		return nil
+	}
+
+	var opts []extensions.ValidateOption
+	if s.strictExtensions {
+		opts = append(opts, extensions.Strict())
+	}
+
+	if errs := extensions.ValidateExtensions(record, opts...); len(errs) > 0 {
+		return status.Errorf(codes.InvalidArgument, "record extension validation failed: %v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// validateRecordDependencies checks that the records the record depends on by CID are stored,
+// logging missing dependencies with the warn policy and rejecting the record with the enforce policy.
+// The dependencies of encrypted records cannot be read and are not checked.
+func (s storeCtrl) validateRecordDependencies(ctx context.Context, record *corev1.Record) error {
+	if s.dependencyPolicy == "" || s.dependencyPolicy == storeconfig.DependencyPolicyIgnore || record.IsEncrypted() {
+		return nil
+	}
+
+	enforce := s.dependencyPolicy == storeconfig.DependencyPolicyEnforce
+
+	dependencies, err := extensions.RecordDependencies(record)
+	if err != nil {
+		if enforce {
+			return status.Errorf(codes.InvalidArgument, "invalid record dependencies: %v", err)
+		}
+
+		logging.WithContext(ctx, storeLogger).Warn("Record declares invalid dependencies", "cid", record.GetCid(), "error", err)
+
+		return nil
+	}
+
+	var missing []string
+
+	for _, dependency := range dependencies {
+		if dependency.CID == "" || slices.Contains(missing, dependency.CID) {
+			continue
+		}
+
+		if _, err := s.store.Lookup(ctx, &corev1.RecordRef{Cid: dependency.CID}); err != nil {
+			if status.Code(err) != codes.NotFound {
+				return status.Errorf(codes.Internal, "failed to lookup dependency %s: %v", dependency.CID, err)
+			}
+
+			missing = append(missing, dependency.CID)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if enforce {
+		return status.Errorf(codes.FailedPrecondition, "record dependencies not found: %s", strings.Join(missing, ", "))
+	}
+
+	logging.WithContext(ctx, storeLogger).Warn("Record depends on records that are not stored", "cid", record.GetCid(), "missing", missing)
+
+	return nil
+}
+
+// applyCIDOptions sets the CID options of the server on a pushed record.
+// Records whose hash function is set to another one are rejected rather than rehashed,
+// as the CID returned to the client would not match the CID the client calculated.
+func (s storeCtrl) applyCIDOptions(record *corev1.Record) error {
+	if record.GetCidHash() != 0 && record.CIDOptions() != s.cidOptions {
+		return status.Errorf(codes.InvalidArgument, "record CID hash function %s does not match the %s hash function of the server",
+			record.CIDOptions(), s.cidOptions)
+	}
+
+	record.SetCIDOptions(s.cidOptions)
+
+	return nil
+}
+
+// validateRecordNames rejects records whose name or version cannot be normalized, or with the reject policy,
+// is not in normal form. Records without a name or version are left to the record validation.
+func (s storeCtrl) validateRecordNames(record *corev1.Record) error {
+	data, err := adapters.NewRecordAdapter(record).GetRecordData()
+	if err != nil || data == nil {
+		return nil
+	}
+
+	reject := s.namePolicy == storeconfig.NamePolicyReject
+
+	if name := data.GetName(); name != "" {
+		if reject {
+			err = names.ValidateName(name)
+		} else {
+			_, err = names.NormalizeName(name)
+		}
+
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "record validation failed: %v", err)
+		}
+	}
+
+	if version := data.GetVersion(); version != "" {
+		if reject {
+			err = names.ValidateVersion(version)
+		} else {
+			_, err = names.NormalizeVersion(version)
+		}
+
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "record validation failed: %v", err)
+		}
+	}
+
+	return nil
 }
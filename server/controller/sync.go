@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	storev1 "github.com/agntcy/dir/api/store/v1"
 	ociconfig "github.com/agntcy/dir/server/store/oci/config"
@@ -43,7 +44,17 @@ func (c *syncCtlr) CreateSync(_ context.Context, req *storev1.CreateSyncRequest)
 		return nil, status.Errorf(codes.InvalidArgument, "invalid remote directory URL: %v", err)
 	}
 
-	id, err := c.db.CreateSync(req.GetRemoteDirectoryUrl(), req.GetCids())
+	for _, label := range req.GetLabels() {
+		if !types.Label(label).Type().IsValid() {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid label filter %q: must start with one of /skills/, /domains/, /modules/, /locators/", label)
+		}
+	}
+
+	id, err := c.db.CreateSync(req.GetRemoteDirectoryUrl(), types.SyncOptions{
+		CIDs:     req.GetCids(),
+		Labels:   req.GetLabels(),
+		Interval: time.Duration(req.GetIntervalSeconds()) * time.Second,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create sync: %w", err)
 	}
@@ -88,11 +99,22 @@ func (c *syncCtlr) GetSync(_ context.Context, req *storev1.GetSyncRequest) (*sto
 		return nil, fmt.Errorf("failed to get sync by ID: %w", err)
 	}
 
-	return &storev1.GetSyncResponse{
+	resp := &storev1.GetSyncResponse{
 		SyncId:             syncObj.GetID(),
 		RemoteDirectoryUrl: syncObj.GetRemoteDirectoryURL(),
 		Status:             syncObj.GetStatus(),
-	}, nil
+		RecordsSynced:      syncObj.GetRecordsSynced(),
+	}
+
+	if lastError := syncObj.GetLastError(); lastError != "" {
+		resp.LastError = &lastError
+	}
+
+	if lastRun := syncObj.GetLastRunTime(); !lastRun.IsZero() {
+		resp.LastRunTime = lastRun.Format(time.RFC3339)
+	}
+
+	return resp, nil
 }
 
 func (c *syncCtlr) DeleteSync(_ context.Context, req *storev1.DeleteSyncRequest) (*storev1.DeleteSyncResponse, error) {
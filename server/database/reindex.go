@@ -0,0 +1,102 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var logger = logging.Logger("database")
+
+// reindexer is implemented by databases whose record search index can be rebuilt.
+type reindexer interface {
+	NeedsReindex() bool
+	MarkReindexed() error
+}
+
+// lister is implemented by stores that can enumerate their records.
+type lister interface {
+	List(ctx context.Context, fn func(*corev1.RecordRef) error) error
+}
+
+// ReindexIfNeeded rebuilds the record search index from the records in the store,
+// if the index was just created or its schema version changed.
+// Records that cannot be pulled or indexed are skipped, and the index is only marked
+// as rebuilt once all records were indexed, so that failures are retried on the next startup.
+// It is a no-op if the database or store do not support rebuilding the index.
+func ReindexIfNeeded(ctx context.Context, db types.SearchDatabaseAPI, store types.StoreAPI) error {
+	index, ok := db.(reindexer)
+	if !ok || !index.NeedsReindex() {
+		return nil
+	}
+
+	records, ok := store.(lister)
+	if !ok {
+		logger.Warn("Store cannot enumerate records, skipping search index rebuild")
+
+		return nil
+	}
+
+	logger.Info("Rebuilding record search index from store")
+
+	start := time.Now()
+
+	var indexed, failed int
+
+	err := records.List(ctx, func(ref *corev1.RecordRef) error {
+		record, err := store.Pull(ctx, ref)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err() //nolint:wrapcheck
+			}
+
+			// Deleted since it was listed
+			if status.Code(err) == codes.NotFound {
+				return nil
+			}
+
+			logger.Warn("Failed to pull record for search index", "cid", ref.GetCid(), "error", err)
+
+			failed++
+
+			return nil
+		}
+
+		if err := db.AddRecord(adapters.NewRecordAdapter(record)); err != nil {
+			logger.Warn("Failed to index record", "cid", ref.GetCid(), "error", err)
+
+			failed++
+
+			return nil
+		}
+
+		indexed++
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rebuild search index after %d records: %w", indexed, err)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("search index is incomplete, failed to index %d records", failed)
+	}
+
+	if err := index.MarkReindexed(); err != nil {
+		return fmt.Errorf("failed to mark search index as rebuilt: %w", err)
+	}
+
+	logger.Info("Rebuilt record search index", "records", indexed, "duration", time.Since(start))
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/database/sqlite"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// listStore is an in-memory store that can enumerate its records.
+type listStore struct {
+	types.StoreAPI
+
+	records map[string]*corev1.Record
+	pulls   int
+}
+
+func (s *listStore) List(_ context.Context, fn func(*corev1.RecordRef) error) error {
+	for cid := range s.records {
+		if err := fn(&corev1.RecordRef{Cid: cid}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *listStore) Pull(_ context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	s.pulls++
+
+	record, ok := s.records[ref.GetCid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", ref.GetCid())
+	}
+
+	return record, nil
+}
+
+func TestReindexIfNeeded(t *testing.T) {
+	store := &listStore{records: map[string]*corev1.Record{}}
+
+	for _, record := range []*corev1.Record{
+		corev1.New(&typesv1alpha0.Record{
+			Name:          "agent-1",
+			SchemaVersion: "v0.3.1",
+			Version:       "v1.0.0",
+			Locators: []*typesv1alpha0.Locator{
+				{Type: "docker-image", Url: "ghcr.io/agntcy/agent-1"},
+			},
+		}),
+		corev1.New(&typesv1alpha0.Record{
+			Name:          "agent-2",
+			SchemaVersion: "v0.3.1",
+			Version:       "v2.0.0",
+			Locators: []*typesv1alpha0.Locator{
+				{Type: "docker-image", Url: "ghcr.io/agntcy/agent-2"},
+			},
+		}),
+	} {
+		store.records[record.GetCid()] = record
+	}
+
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "dir.db"))
+	require.NoError(t, err)
+	require.True(t, db.NeedsReindex())
+
+	// Records in the store are indexed
+	require.NoError(t, ReindexIfNeeded(t.Context(), db, store))
+	assert.False(t, db.NeedsReindex())
+	assert.Equal(t, 2, store.pulls)
+
+	cids, err := db.GetRecordCIDs(types.WithVersionConstraint(types.VersionGreaterThanOrEqual, "2"))
+	require.NoError(t, err)
+	assert.Len(t, cids, 1)
+
+	cids, err = db.GetRecordCIDs(types.WithLocatorTypes("docker-image"))
+	require.NoError(t, err)
+	assert.Len(t, cids, 2)
+
+	// An up to date index is not rebuilt
+	require.NoError(t, ReindexIfNeeded(t.Context(), db, store))
+	assert.Equal(t, 2, store.pulls)
+}
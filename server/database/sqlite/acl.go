@@ -0,0 +1,122 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecordACL is the owner and access control list of a record.
+// ACLs are kept separately from the search index, so that they survive index rebuilds.
+type RecordACL struct {
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	RecordCID       string `gorm:"column:record_cid;primarykey;not null"`
+	Owner           string `gorm:"not null"`
+	AllowPullJSON   string `gorm:"not null"` // JSON-encoded SPIFFE ID patterns
+	AllowDeleteJSON string `gorm:"not null"` // JSON-encoded SPIFFE ID patterns
+}
+
+func (d *DB) SetRecordOwner(cid string, owner string) error {
+	recordACL := &RecordACL{
+		RecordCID:       cid,
+		Owner:           owner,
+		AllowPullJSON:   "[]",
+		AllowDeleteJSON: "[]",
+	}
+
+	// Keep the owner and access control list of records that already have one
+	err := d.gormDB.Clauses(clause.OnConflict{DoNothing: true}).Create(recordACL).Error
+	if err != nil {
+		return fmt.Errorf("failed to set record owner: %w", err)
+	}
+
+	logger.Debug("Set record owner in SQLite database", "cid", cid, "owner", owner)
+
+	return nil
+}
+
+func (d *DB) GetRecordACL(cid string) (types.RecordACL, bool, error) {
+	var recordACL RecordACL
+
+	err := d.gormDB.Where("record_cid = ?", cid).First(&recordACL).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return types.RecordACL{}, false, nil
+	}
+
+	if err != nil {
+		return types.RecordACL{}, false, fmt.Errorf("failed to get record acl: %w", err)
+	}
+
+	var allowPull, allowDelete []string
+	if err := json.Unmarshal([]byte(recordACL.AllowPullJSON), &allowPull); err != nil {
+		return types.RecordACL{}, false, fmt.Errorf("failed to unmarshal acl pull patterns: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(recordACL.AllowDeleteJSON), &allowDelete); err != nil {
+		return types.RecordACL{}, false, fmt.Errorf("failed to unmarshal acl delete patterns: %w", err)
+	}
+
+	return types.RecordACL{
+		Owner:       recordACL.Owner,
+		AllowPull:   allowPull,
+		AllowDelete: allowDelete,
+	}, true, nil
+}
+
+func (d *DB) SetRecordACL(cid string, allowPull, allowDelete []string) error {
+	if allowPull == nil {
+		allowPull = []string{}
+	}
+
+	if allowDelete == nil {
+		allowDelete = []string{}
+	}
+
+	allowPullJSON, err := json.Marshal(allowPull)
+	if err != nil {
+		return fmt.Errorf("failed to marshal acl pull patterns: %w", err)
+	}
+
+	allowDeleteJSON, err := json.Marshal(allowDelete)
+	if err != nil {
+		return fmt.Errorf("failed to marshal acl delete patterns: %w", err)
+	}
+
+	recordACL := &RecordACL{
+		RecordCID:       cid,
+		AllowPullJSON:   string(allowPullJSON),
+		AllowDeleteJSON: string(allowDeleteJSON),
+	}
+
+	// Keep the owner of records that already have one
+	err = d.gormDB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "record_cid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"allow_pull_json", "allow_delete_json", "updated_at"}),
+	}).Create(recordACL).Error
+	if err != nil {
+		return fmt.Errorf("failed to set record acl: %w", err)
+	}
+
+	logger.Debug("Set record acl in SQLite database", "cid", cid, "allow_pull", len(allowPull), "allow_delete", len(allowDelete))
+
+	return nil
+}
+
+func (d *DB) RemoveRecordACL(cid string) error {
+	if err := d.gormDB.Where("record_cid = ?", cid).Delete(&RecordACL{}).Error; err != nil {
+		return fmt.Errorf("failed to remove record acl: %w", err)
+	}
+
+	logger.Debug("Removed record acl from SQLite database", "cid", cid)
+
+	return nil
+}
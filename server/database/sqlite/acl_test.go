@@ -0,0 +1,66 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordACL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir.db")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	_, exists, err := db.GetRecordACL("cid-1")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// The first pusher owns the record
+	require.NoError(t, db.SetRecordOwner("cid-1", "spiffe://example.org/team-a/agent"))
+	require.NoError(t, db.SetRecordOwner("cid-1", "spiffe://example.org/team-b/agent"))
+
+	acl, exists, err := db.GetRecordACL("cid-1")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, types.RecordACL{Owner: "spiffe://example.org/team-a/agent", AllowPull: []string{}, AllowDelete: []string{}}, acl)
+	assert.False(t, acl.Restricted())
+
+	// Setting the access control list keeps the owner
+	require.NoError(t, db.SetRecordACL("cid-1", []string{"spiffe://example.org/team-a/**"}, nil))
+
+	// Access control lists survive restarts
+	db, err = New(path)
+	require.NoError(t, err)
+
+	acl, _, err = db.GetRecordACL("cid-1")
+	require.NoError(t, err)
+	assert.Equal(t, types.RecordACL{
+		Owner:       "spiffe://example.org/team-a/agent",
+		AllowPull:   []string{"spiffe://example.org/team-a/**"},
+		AllowDelete: []string{},
+	}, acl)
+	assert.True(t, acl.Restricted())
+
+	// Records without an owner can have an access control list
+	require.NoError(t, db.SetRecordACL("cid-2", nil, []string{"spiffe://example.org/admin"}))
+
+	acl, exists, err = db.GetRecordACL("cid-2")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Empty(t, acl.Owner)
+	assert.Equal(t, []string{"spiffe://example.org/admin"}, acl.AllowDelete)
+
+	require.NoError(t, db.RemoveRecordACL("cid-1"))
+	require.NoError(t, db.RemoveRecordACL("cid-1"))
+
+	_, exists, err = db.GetRecordACL("cid-1")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
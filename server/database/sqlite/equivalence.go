@@ -0,0 +1,96 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+)
+
+// RecordEquivalence is an edge of the equivalence graph of records,
+// linking a record to the equivalent record it supersedes, e.g. the record it was migrated from.
+// Edges are only followed while the superseding record is indexed.
+type RecordEquivalence struct {
+	ID            uint `gorm:"primarykey"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	RecordCID     string `gorm:"column:record_cid;not null;index"`
+	SupersedesCID string `gorm:"column:supersedes_cid;not null;index"`
+}
+
+// convertEquivalences derives the records superseded by the record from its annotations.
+func convertEquivalences(annotations map[string]string, recordCID string) []RecordEquivalence {
+	source := annotations[corev1.AnnotationMigratedFrom]
+	if source == "" || source == recordCID {
+		return nil
+	}
+
+	return []RecordEquivalence{{RecordCID: recordCID, SupersedesCID: source}}
+}
+
+// notSupersededCondition matches records for which no newer equivalent record is indexed.
+const notSupersededCondition = "NOT EXISTS (SELECT 1 FROM record_equivalences " +
+	"JOIN records AS newer ON newer.record_cid = record_equivalences.record_cid " +
+	"WHERE record_equivalences.supersedes_cid = records.record_cid)"
+
+// GetSupersededBy returns the CID of the newest record equivalent to the record.
+// The equivalence graph is followed from the record to the records that are not superseded themselves,
+// so chains of migrations resolve to their last record. If the graph branches, e.g. because a record was
+// migrated twice, the most recently indexed of those records is returned. Cycles are not followed twice.
+// It returns false if the record is not superseded.
+func (d *DB) GetSupersededBy(cid string) (string, bool, error) {
+	visited := map[string]struct{}{cid: {}}
+	frontier := []string{cid}
+
+	var newest []string
+
+	for len(frontier) > 0 {
+		var edges []RecordEquivalence
+
+		err := d.gormDB.Select("record_equivalences.*").
+			Joins("JOIN records ON records.record_cid = record_equivalences.record_cid").
+			Where("record_equivalences.supersedes_cid IN ?", frontier).
+			Find(&edges).Error
+		if err != nil {
+			return "", false, fmt.Errorf("failed to get equivalent records: %w", err)
+		}
+
+		successors := map[string][]string{}
+		for _, edge := range edges {
+			successors[edge.SupersedesCID] = append(successors[edge.SupersedesCID], edge.RecordCID)
+		}
+
+		var next []string
+
+		for _, current := range frontier {
+			if current != cid && len(successors[current]) == 0 {
+				newest = append(newest, current)
+			}
+
+			for _, successor := range successors[current] {
+				if _, ok := visited[successor]; ok {
+					continue
+				}
+
+				visited[successor] = struct{}{}
+				next = append(next, successor)
+			}
+		}
+
+		frontier = next
+	}
+
+	if len(newest) == 0 {
+		return "", false, nil
+	}
+
+	var record Record
+	if err := d.gormDB.Where("record_cid IN ?", newest).Order("created_at DESC, record_cid").Take(&record).Error; err != nil {
+		return "", false, fmt.Errorf("failed to get newest equivalent record: %w", err)
+	}
+
+	return record.RecordCID, true, nil
+}
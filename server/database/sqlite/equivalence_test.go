@@ -0,0 +1,83 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func migratedRecord(cid, migratedFrom string) *TestRecord {
+	annotations := map[string]string{}
+	if migratedFrom != "" {
+		annotations[corev1.AnnotationMigratedFrom] = migratedFrom
+	}
+
+	return &TestRecord{cid: cid, data: &TestRecordData{name: "agent", version: "v1.0.0", annotations: annotations}}
+}
+
+func TestRecordEquivalence(t *testing.T) {
+	db, err := New(filepath.Join(t.TempDir(), "dir.db"))
+	require.NoError(t, err)
+
+	// A chain of migrations v1 -> v2 -> v3, and an unrelated record
+	for _, record := range []*TestRecord{
+		migratedRecord("cid-v1", ""),
+		migratedRecord("cid-v2", "cid-v1"),
+		migratedRecord("cid-v3", "cid-v2"),
+		migratedRecord("cid-other", ""),
+	} {
+		require.NoError(t, db.AddRecord(record))
+	}
+
+	cids, err := db.GetRecordCIDs(types.WithName("agent"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cid-v1", "cid-v2", "cid-v3", "cid-other"}, cids)
+
+	cids, err = db.GetRecordCIDs(types.WithName("agent"), types.WithoutSuperseded())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cid-v3", "cid-other"}, cids)
+
+	// Chains resolve to their newest record
+	for _, cid := range []string{"cid-v1", "cid-v2"} {
+		supersededBy, superseded, err := db.GetSupersededBy(cid)
+		require.NoError(t, err)
+		assert.True(t, superseded)
+		assert.Equal(t, "cid-v3", supersededBy)
+	}
+
+	for _, cid := range []string{"cid-v3", "cid-other", "cid-unknown"} {
+		_, superseded, err := db.GetSupersededBy(cid)
+		require.NoError(t, err)
+		assert.False(t, superseded)
+	}
+
+	// A record migrated twice resolves to the most recently indexed branch
+	require.NoError(t, db.AddRecord(migratedRecord("cid-v3-again", "cid-v1")))
+
+	supersededBy, superseded, err := db.GetSupersededBy("cid-v1")
+	require.NoError(t, err)
+	assert.True(t, superseded)
+	assert.Equal(t, "cid-v3-again", supersededBy)
+
+	cids, err = db.GetRecordCIDs(types.WithName("agent"), types.WithoutSuperseded())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cid-v3", "cid-v3-again", "cid-other"}, cids)
+
+	// Removing the newest record makes its predecessor the newest again
+	require.NoError(t, db.RemoveRecord("cid-v3"))
+
+	_, superseded, err = db.GetSupersededBy("cid-v2")
+	require.NoError(t, err)
+	assert.False(t, superseded)
+
+	cids, err = db.GetRecordCIDs(types.WithName("agent"), types.WithoutSuperseded())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cid-v2", "cid-v3-again", "cid-other"}, cids)
+}
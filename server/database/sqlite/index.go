@@ -0,0 +1,75 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// IndexSchemaVersion is the version of the record search index schema.
+// It must be increased whenever indexed record data changes, so that
+// existing indexes are rebuilt from the store on startup.
+const IndexSchemaVersion = 6
+
+// indexSchemaVersionKey is the key of the index schema version in the index state table.
+const indexSchemaVersionKey = "schema_version"
+
+// IndexState holds metadata about the record search index.
+type IndexState struct {
+	Key   string `gorm:"primarykey"`
+	Value string `gorm:"not null"`
+}
+
+// recordTables are the tables holding indexed record data.
+var recordTables = []any{&RecordEquivalence{}, &SearchTerm{}, &LocatorVariant{}, &Annotation{}, &Module{}, &Locator{}, &Skill{}, &Record{}}
+
+// checkIndex determines whether the record search index must be rebuilt,
+// because it was just created or was built with a different schema version.
+// Outdated index data is removed.
+func (d *DB) checkIndex() error {
+	var state IndexState
+
+	err := d.gormDB.Where("key = ?", indexSchemaVersionKey).First(&state).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get index schema version: %w", err)
+	}
+
+	if err == nil && state.Value == strconv.Itoa(IndexSchemaVersion) {
+		return nil
+	}
+
+	logger.Info("Record search index must be rebuilt", "version", state.Value, "expected", IndexSchemaVersion)
+
+	for _, table := range recordTables {
+		if err := d.gormDB.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(table).Error; err != nil {
+			return fmt.Errorf("failed to clear outdated index: %w", err)
+		}
+	}
+
+	d.needsReindex.Store(true)
+
+	return nil
+}
+
+// NeedsReindex reports whether the record search index must be rebuilt from the store.
+func (d *DB) NeedsReindex() bool {
+	return d.needsReindex.Load()
+}
+
+// MarkReindexed records that the record search index was rebuilt with the current schema version.
+func (d *DB) MarkReindexed() error {
+	state := IndexState{Key: indexSchemaVersionKey, Value: strconv.Itoa(IndexSchemaVersion)}
+
+	if err := d.gormDB.Save(&state).Error; err != nil {
+		return fmt.Errorf("failed to set index schema version: %w", err)
+	}
+
+	d.needsReindex.Store(false)
+
+	return nil
+}
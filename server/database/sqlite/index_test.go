@@ -0,0 +1,337 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIndexTestRecord(cid, version, skill, locatorType string, annotations map[string]string) *TestRecord {
+	return &TestRecord{
+		cid: cid,
+		data: &TestRecordData{
+			name:        "agent-" + cid,
+			version:     version,
+			skills:      []types.Skill{&TestSkill{id: 1, name: skill}},
+			locators:    []types.Locator{&TestLocator{locType: locatorType, url: "https://example.com/" + cid}},
+			annotations: annotations,
+		},
+	}
+}
+
+func TestGetRecordCIDs_CompoundQuery(t *testing.T) {
+	db := setupTestDB(t)
+
+	for _, record := range []*TestRecord{
+		newIndexTestRecord("nlp-docker-v1", "v1.5.0", "nlp", "docker-image", map[string]string{"team": "platform"}),
+		newIndexTestRecord("nlp-docker-v2", "v2.0.0", "nlp", "docker-image", map[string]string{"team": "platform-ai", "env": "prod"}),
+		newIndexTestRecord("nlp-docker-v2-rc", "v2.0.0-rc.1", "nlp", "docker-image", nil),
+		newIndexTestRecord("nlp-docker-v3", "3.1", "nlp", "docker-image", map[string]string{"team": "research"}),
+		newIndexTestRecord("nlp-source-v3", "v3.0.0", "nlp", "source-code", nil),
+		newIndexTestRecord("vision-docker-v3", "v3.0.0", "vision", "docker-image", nil),
+		newIndexTestRecord("nlp-docker-latest", "latest", "nlp", "docker-image", nil),
+	} {
+		require.NoError(t, db.AddRecord(record))
+	}
+
+	tests := []struct {
+		name     string
+		opts     []types.FilterOption
+		expected []string
+	}{
+		{
+			name: "skill, locator and minimum version",
+			opts: []types.FilterOption{
+				types.WithSkillNames("nlp"),
+				types.WithLocatorTypes("docker-image"),
+				types.WithVersionConstraint(types.VersionGreaterThanOrEqual, "2"),
+			},
+			expected: []string{"nlp-docker-v2", "nlp-docker-v3"},
+		},
+		{
+			name: "version range",
+			opts: []types.FilterOption{
+				types.WithVersionConstraint(types.VersionGreaterThan, "v1.5.0"),
+				types.WithVersionConstraint(types.VersionLessThan, "v3"),
+			},
+			expected: []string{"nlp-docker-v2", "nlp-docker-v2-rc"},
+		},
+		{
+			name: "maximum version",
+			opts: []types.FilterOption{
+				types.WithVersionConstraint(types.VersionLessThanOrEqual, "1.5.0"),
+			},
+			expected: []string{"nlp-docker-v1"},
+		},
+		{
+			name: "annotation pattern",
+			opts: []types.FilterOption{
+				types.WithAnnotation("team", "platform*"),
+			},
+			expected: []string{"nlp-docker-v1", "nlp-docker-v2"},
+		},
+		{
+			name: "all annotations must match",
+			opts: []types.FilterOption{
+				types.WithAnnotation("team", "platform*"),
+				types.WithAnnotation("env", ""),
+			},
+			expected: []string{"nlp-docker-v2"},
+		},
+		{
+			name: "annotation and version",
+			opts: []types.FilterOption{
+				types.WithAnnotation("team", ""),
+				types.WithVersionConstraint(types.VersionGreaterThan, "2"),
+			},
+			expected: []string{"nlp-docker-v3"},
+		},
+		{
+			name: "invalid version constraint",
+			opts: []types.FilterOption{
+				types.WithVersionConstraint(types.VersionGreaterThan, "latest"),
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cids, err := db.GetRecordCIDs(tt.opts...)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, cids)
+		})
+	}
+
+	// Annotations are returned with records
+	records, err := db.GetRecords(types.WithAnnotation("env", "prod"))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, map[string]string{"team": "platform-ai", "env": "prod"}, mustGetRecordData(t, records[0]).GetAnnotations())
+
+	// Removed records no longer match their annotations
+	require.NoError(t, db.RemoveRecord("nlp-docker-v2"))
+
+	cids, err := db.GetRecordCIDs(types.WithAnnotation("env", "prod"))
+	require.NoError(t, err)
+	assert.Empty(t, cids)
+}
+
+func TestGetRecordCIDs_CreatedAtAndTrustDomain(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Now()
+
+	for cid, age := range map[string]time.Duration{"old-a": 48 * time.Hour, "old-b": 48 * time.Hour, "new-a": time.Minute, "untracked": 48 * time.Hour} {
+		require.NoError(t, db.AddRecord(newIndexTestRecord(cid, "v1.0.0", "nlp", "docker-image", nil)))
+		require.NoError(t, db.gormDB.Model(&Record{}).Where("record_cid = ?", cid).Update("created_at", now.Add(-age)).Error)
+	}
+
+	for cid, trustDomain := range map[string]string{"old-a": "a.example.org", "old-b": "b.example.org", "new-a": "a.example.org"} {
+		require.NoError(t, db.AddRecordUsage(types.RecordUsage{CID: cid, TrustDomain: trustDomain, AccessedAt: now}))
+	}
+
+	tests := []struct {
+		name     string
+		opts     []types.FilterOption
+		expected []string
+	}{
+		{
+			name:     "added before",
+			opts:     []types.FilterOption{types.WithCreatedAt(types.VersionLessThan, now.Add(-24*time.Hour))},
+			expected: []string{"old-a", "old-b", "untracked"},
+		},
+		{
+			name:     "added after",
+			opts:     []types.FilterOption{types.WithCreatedAt(types.VersionGreaterThanOrEqual, now.Add(-time.Hour))},
+			expected: []string{"new-a"},
+		},
+		{
+			name:     "trust domain",
+			opts:     []types.FilterOption{types.WithTrustDomain("a.example.org")},
+			expected: []string{"old-a", "new-a"},
+		},
+		{
+			name:     "trust domain pattern",
+			opts:     []types.FilterOption{types.WithTrustDomain("*.example.org")},
+			expected: []string{"old-a", "old-b", "new-a"},
+		},
+		{
+			name: "trust domain and added before",
+			opts: []types.FilterOption{
+				types.WithTrustDomain("a.example.org"),
+				types.WithCreatedAt(types.VersionLessThan, now.Add(-24*time.Hour)),
+			},
+			expected: []string{"old-a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cids, err := db.GetRecordCIDs(tt.opts...)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, cids)
+		})
+	}
+
+	// Pages of results are stable
+	var paged []string
+
+	for offset := 0; ; offset += 2 {
+		page, err := db.GetRecordCIDs(types.WithLimit(2), types.WithOffset(offset))
+		require.NoError(t, err)
+
+		if len(page) == 0 {
+			break
+		}
+
+		paged = append(paged, page...)
+	}
+
+	assert.Equal(t, []string{"new-a", "old-a", "old-b", "untracked"}, paged)
+}
+
+func TestIndexSchemaVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir.db")
+
+	// A new index must be built
+	db, err := New(path)
+	require.NoError(t, err)
+	assert.True(t, db.NeedsReindex())
+
+	require.NoError(t, db.AddRecord(newIndexTestRecord("cid-1", "v1.0.0", "nlp", "docker-image", nil)))
+	require.NoError(t, db.MarkReindexed())
+	assert.False(t, db.NeedsReindex())
+
+	// An up to date index is kept
+	db, err = New(path)
+	require.NoError(t, err)
+	assert.False(t, db.NeedsReindex())
+
+	cids, err := db.GetRecordCIDs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cid-1"}, cids)
+
+	// An index built with another schema version is cleared and must be rebuilt
+	require.NoError(t, db.gormDB.Save(&IndexState{Key: indexSchemaVersionKey, Value: "1"}).Error)
+
+	db, err = New(path)
+	require.NoError(t, err)
+	assert.True(t, db.NeedsReindex())
+
+	cids, err = db.GetRecordCIDs()
+	require.NoError(t, err)
+	assert.Empty(t, cids)
+}
+
+func TestConcurrentAddAndSearch(t *testing.T) {
+	db, err := New(filepath.Join(t.TempDir(), "dir.db"))
+	require.NoError(t, err)
+
+	const (
+		writers          = 4
+		recordsPerWriter = 25
+		searchers        = 4
+	)
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, writers*recordsPerWriter+searchers*recordsPerWriter)
+
+	for w := range writers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range recordsPerWriter {
+				cid := fmt.Sprintf("cid-%d-%d", w, i)
+				errs <- db.AddRecord(newIndexTestRecord(cid, fmt.Sprintf("v%d.0.0", i), "nlp", "docker-image", map[string]string{"writer": fmt.Sprint(w)}))
+			}
+		}()
+	}
+
+	for range searchers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range recordsPerWriter {
+				_, err := db.GetRecordCIDs(
+					types.WithSkillNames("nlp"),
+					types.WithVersionConstraint(types.VersionGreaterThanOrEqual, "v10"),
+					types.WithAnnotation("writer", "*"),
+				)
+				errs <- err
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	cids, err := db.GetRecordCIDs(types.WithVersionConstraint(types.VersionGreaterThanOrEqual, "v10"))
+	require.NoError(t, err)
+	assert.Len(t, cids, writers*(recordsPerWriter-10))
+}
+
+func TestGetRecordCIDs_LocatorVariants(t *testing.T) {
+	db := setupTestDB(t)
+
+	// 4 locators targeting 2 architectures each
+	multiArch := newIndexTestRecord("multi-arch", "v1.0.0", "nlp", "docker-image", nil)
+	multiArch.data.locators = nil
+
+	for _, locatorType := range []string{"docker-image", "helm-chart", "source-code", "binary"} {
+		multiArch.data.locators = append(multiArch.data.locators, &TestLocator{
+			locType:     locatorType,
+			url:         "https://example.com/" + locatorType,
+			annotations: map[string]string{"arch": "amd64,arm64"},
+		})
+	}
+
+	amd64Only := newIndexTestRecord("amd64-only", "v1.0.0", "nlp", "helm-chart", nil)
+	amd64Only.data.locators[0].(*TestLocator).annotations = map[string]string{"platform": "linux/amd64", "runtime": "kubernetes"}
+
+	for _, record := range []*TestRecord{multiArch, amd64Only, newIndexTestRecord("plain", "v1.0.0", "nlp", "helm-chart", nil)} {
+		require.NoError(t, db.AddRecord(record))
+	}
+
+	tests := []struct {
+		name     string
+		variants []string
+		expected []string
+	}{
+		{name: "exact variant", variants: []string{"helm-chart.arm64"}, expected: []string{"multi-arch"}},
+		{name: "runtime variant", variants: []string{"helm-chart.kubernetes"}, expected: []string{"amd64-only"}},
+		{name: "any locator type", variants: []string{"*.amd64"}, expected: []string{"multi-arch", "amd64-only"}},
+		{name: "all variants must match", variants: []string{"helm-chart.amd64", "binary.arm64"}, expected: []string{"multi-arch"}},
+		{name: "no match", variants: []string{"helm-chart.riscv64"}, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := make([]types.FilterOption, 0, len(tt.variants))
+			for _, variant := range tt.variants {
+				opts = append(opts, types.WithLocatorVariant(variant))
+			}
+
+			cids, err := db.GetRecordCIDs(opts...)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, cids)
+		})
+	}
+}
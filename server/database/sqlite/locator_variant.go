@@ -0,0 +1,36 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"time"
+
+	"github.com/agntcy/dir/server/labels"
+	"github.com/agntcy/dir/server/types"
+)
+
+// LocatorVariant is a locator type qualified with a target architecture or runtime, e.g. "helm_chart.arm64".
+type LocatorVariant struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	RecordCID string `gorm:"column:record_cid;not null;index"`
+	Variant   string `gorm:"not null;index"`
+}
+
+// convertLocatorVariants derives the locator variants of the record locators.
+// All variants are indexed, unlike the manifest annotations which are capped.
+func convertLocatorVariants(locators []types.Locator, recordCID string) []LocatorVariant {
+	variants := labels.LocatorVariants(locators)
+
+	result := make([]LocatorVariant, len(variants))
+	for i, variant := range variants {
+		result[i] = LocatorVariant{
+			RecordCID: recordCID,
+			Variant:   variant,
+		}
+	}
+
+	return result
+}
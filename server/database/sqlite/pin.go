@@ -0,0 +1,85 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecordPin protects a published record from deletion.
+// Pins are kept separately from the search index, so that they survive index rebuilds.
+type RecordPin struct {
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	RecordCID     string `gorm:"column:record_cid;primarykey;not null"`
+	PublicationID string `gorm:"not null"`
+	LabelsJSON    string `gorm:"not null"` // JSON-encoded routing labels
+}
+
+func (d *DB) PinRecord(pin types.RecordPin) error {
+	labelsJSON, err := json.Marshal(pin.Labels)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pin labels: %w", err)
+	}
+
+	recordPin := &RecordPin{
+		RecordCID:     pin.CID,
+		PublicationID: pin.PublicationID,
+		LabelsJSON:    string(labelsJSON),
+	}
+
+	err = d.gormDB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "record_cid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"publication_id", "labels_json", "updated_at"}),
+	}).Create(recordPin).Error
+	if err != nil {
+		return fmt.Errorf("failed to pin record: %w", err)
+	}
+
+	logger.Debug("Pinned record in SQLite database", "cid", pin.CID, "publication_id", pin.PublicationID)
+
+	return nil
+}
+
+func (d *DB) GetRecordPin(cid string) (types.RecordPin, bool, error) {
+	var recordPin RecordPin
+
+	err := d.gormDB.Where("record_cid = ?", cid).First(&recordPin).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return types.RecordPin{}, false, nil
+	}
+
+	if err != nil {
+		return types.RecordPin{}, false, fmt.Errorf("failed to get record pin: %w", err)
+	}
+
+	var labels []string
+	if err := json.Unmarshal([]byte(recordPin.LabelsJSON), &labels); err != nil {
+		return types.RecordPin{}, false, fmt.Errorf("failed to unmarshal pin labels: %w", err)
+	}
+
+	return types.RecordPin{
+		CID:           recordPin.RecordCID,
+		PublicationID: recordPin.PublicationID,
+		Labels:        labels,
+		PinnedAt:      recordPin.UpdatedAt,
+	}, true, nil
+}
+
+func (d *DB) UnpinRecord(cid string) error {
+	if err := d.gormDB.Where("record_cid = ?", cid).Delete(&RecordPin{}).Error; err != nil {
+		return fmt.Errorf("failed to unpin record: %w", err)
+	}
+
+	logger.Debug("Unpinned record in SQLite database", "cid", cid)
+
+	return nil
+}
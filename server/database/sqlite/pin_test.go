@@ -0,0 +1,47 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordPin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir.db")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	_, pinned, err := db.GetRecordPin("cid-1")
+	require.NoError(t, err)
+	assert.False(t, pinned)
+
+	require.NoError(t, db.PinRecord(types.RecordPin{CID: "cid-1", PublicationID: "pub-1", Labels: []string{"/skills/a"}}))
+
+	// Publishing again replaces the pin
+	require.NoError(t, db.PinRecord(types.RecordPin{CID: "cid-1", PublicationID: "pub-2", Labels: []string{"/skills/a", "/skills/b"}}))
+
+	// Pins survive restarts
+	db, err = New(path)
+	require.NoError(t, err)
+
+	pin, pinned, err := db.GetRecordPin("cid-1")
+	require.NoError(t, err)
+	assert.True(t, pinned)
+	assert.Equal(t, "pub-2", pin.PublicationID)
+	assert.Equal(t, []string{"/skills/a", "/skills/b"}, pin.Labels)
+	assert.False(t, pin.PinnedAt.IsZero())
+
+	require.NoError(t, db.UnpinRecord("cid-1"))
+	require.NoError(t, db.UnpinRecord("cid-1"))
+
+	_, pinned, err = db.GetRecordPin("cid-1")
+	require.NoError(t, err)
+	assert.False(t, pinned)
+}
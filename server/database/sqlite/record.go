@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/agntcy/dir/api/names"
 	"github.com/agntcy/dir/server/database/utils"
 	"github.com/agntcy/dir/server/types"
 	"gorm.io/gorm"
@@ -20,9 +21,23 @@ type Record struct {
 	Name      string `gorm:"not null"`
 	Version   string `gorm:"not null"`
 
-	Skills   []Skill   `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
-	Locators []Locator `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
-	Modules  []Module  `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
+	// NormalizedName is the canonical form of the name that name filters match, see the names package.
+	// Name keeps the name the record was pushed with for display.
+	NormalizedName string `gorm:"index"`
+
+	// VersionKey orders semantic versions lexically, empty for other versions.
+	VersionKey string `gorm:"index"`
+
+	Skills      []Skill      `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
+	Locators    []Locator    `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
+	Modules     []Module     `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
+	Annotations []Annotation `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
+
+	LocatorVariants []LocatorVariant `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
+	SearchTerms     []SearchTerm     `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
+
+	// Equivalences link the record to the equivalent records it supersedes.
+	Equivalences []RecordEquivalence `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
 }
 
 // Implement central Record interface.
@@ -40,8 +55,12 @@ type RecordDataAdapter struct {
 }
 
 func (r *RecordDataAdapter) GetAnnotations() map[string]string {
-	// SQLite records don't store annotations, return empty map
-	return make(map[string]string)
+	annotations := make(map[string]string, len(r.record.Annotations))
+	for _, annotation := range r.record.Annotations {
+		annotations[annotation.Key] = annotation.Value
+	}
+
+	return annotations
 }
 
 func (r *RecordDataAdapter) GetDomains() []types.Domain {
@@ -141,13 +160,22 @@ func (d *DB) AddRecord(record types.Record) error {
 	}
 
 	// Build complete Record with all associations
+	versionKey, _ := utils.VersionKey(recordData.GetVersion())
+
 	sqliteRecord := &Record{
-		RecordCID: cid,
-		Name:      recordData.GetName(),
-		Version:   recordData.GetVersion(),
-		Skills:    convertSkills(recordData.GetSkills(), cid),
-		Locators:  convertLocators(recordData.GetLocators(), cid),
-		Modules:   convertModules(recordData.GetModules(), cid),
+		RecordCID:      cid,
+		Name:           recordData.GetName(),
+		NormalizedName: names.CanonicalName(recordData.GetName()),
+		Version:        recordData.GetVersion(),
+		VersionKey:     versionKey,
+		Skills:         convertSkills(recordData.GetSkills(), cid),
+		Locators:       convertLocators(recordData.GetLocators(), cid),
+		Modules:        convertModules(recordData.GetModules(), cid),
+		Annotations:    convertAnnotations(recordData.GetAnnotations(), cid),
+
+		LocatorVariants: convertLocatorVariants(recordData.GetLocators(), cid),
+		SearchTerms:     convertSearchTerms(recordData, cid),
+		Equivalences:    convertEquivalences(recordData.GetAnnotations(), cid),
 	}
 
 	// Let GORM handle the entire creation with associations
@@ -156,7 +184,8 @@ func (d *DB) AddRecord(record types.Record) error {
 	}
 
 	logger.Debug("Added new record with associations to SQLite database", "record_cid", sqliteRecord.RecordCID, "cid", cid,
-		"skills", len(sqliteRecord.Skills), "locators", len(sqliteRecord.Locators), "modules", len(sqliteRecord.Modules))
+		"skills", len(sqliteRecord.Skills), "locators", len(sqliteRecord.Locators), "modules", len(sqliteRecord.Modules),
+		"annotations", len(sqliteRecord.Annotations))
 
 	return nil
 }
@@ -192,7 +221,7 @@ func (d *DB) GetRecords(opts ...types.FilterOption) ([]types.Record, error) {
 
 	// Execute the query to get records.
 	var dbRecords []Record
-	if err := query.Preload("Skills").Preload("Locators").Preload("Modules").Find(&dbRecords).Error; err != nil {
+	if err := query.Preload("Skills").Preload("Locators").Preload("Modules").Preload("Annotations").Find(&dbRecords).Error; err != nil {
 		return nil, fmt.Errorf("failed to query records: %w", err)
 	}
 
@@ -221,7 +250,8 @@ func (d *DB) GetRecordCIDs(opts ...types.FilterOption) ([]string, error) {
 	}
 
 	// Start with the base query for records - only select CID for efficiency.
-	query := d.gormDB.Model(&Record{}).Select("records.record_cid").Distinct()
+	// Order by CID, so that pages of results are stable.
+	query := d.gormDB.Model(&Record{}).Select("records.record_cid").Distinct().Order("records.record_cid")
 
 	// Apply pagination.
 	if cfg.Limit > 0 {
@@ -245,8 +275,63 @@ func (d *DB) GetRecordCIDs(opts ...types.FilterOption) ([]string, error) {
 	return cids, nil
 }
 
+// GetRecordScores retrieves the CIDs of records matching the free-text query, most relevant first.
+// The score of a record is the sum of the weights of the fields the query terms are found in,
+// and records with the same score are ordered by CID, so that pages of results are stable.
+// The other filters only restrict the records that are ranked.
+func (d *DB) GetRecordScores(opts ...types.FilterOption) ([]types.RecordScore, error) {
+	cfg := &types.RecordFilters{}
+
+	for _, opt := range opts {
+		if opt == nil {
+			return nil, errors.New("nil option provided")
+		}
+
+		opt(cfg)
+	}
+
+	terms := utils.SearchTerms(cfg.Query)
+	if len(terms) == 0 {
+		return []types.RecordScore{}, nil
+	}
+
+	// Records matching the filters
+	filtered := d.handleFilterOptions(d.gormDB.Model(&Record{}).Select("records.record_cid"), cfg)
+
+	query := d.gormDB.Model(&SearchTerm{}).
+		Select("search_terms.record_cid, SUM(search_terms.weight) AS score").
+		Where("search_terms.term IN ?", terms).
+		Where("search_terms.record_cid IN (?)", filtered).
+		Group("search_terms.record_cid").
+		Order("score DESC, search_terms.record_cid")
+
+	if cfg.Limit > 0 {
+		query = query.Limit(cfg.Limit)
+	}
+
+	if cfg.Offset > 0 {
+		query = query.Offset(cfg.Offset)
+	}
+
+	var rows []struct {
+		RecordCID string `gorm:"column:record_cid"`
+		Score     float64
+	}
+
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query record scores: %w", err)
+	}
+
+	scores := make([]types.RecordScore, len(rows))
+	for i, row := range rows {
+		scores[i] = types.RecordScore{CID: row.RecordCID, Score: row.Score}
+	}
+
+	return scores, nil
+}
+
 // RemoveRecord removes a record from the search database by CID.
-// Uses CASCADE DELETE to automatically remove related Skills, Locators, and Modules.
+// Uses CASCADE DELETE to automatically remove related Skills, Locators, Modules, Annotations and search terms.
 func (d *DB) RemoveRecord(cid string) error {
 	result := d.gormDB.Where("record_cid = ?", cid).Delete(&Record{})
 
@@ -272,7 +357,8 @@ func (d *DB) RemoveRecord(cid string) error {
 func (d *DB) handleFilterOptions(query *gorm.DB, cfg *types.RecordFilters) *gorm.DB {
 	// Apply record-level filters with wildcard support.
 	if cfg.Name != "" {
-		condition, arg := utils.BuildSingleWildcardCondition("records.name", cfg.Name)
+		// Names that are the same record name match the same filters, e.g. "My Agent" matches "MY_AGENT"
+		condition, arg := utils.BuildSingleWildcardCondition("records.normalized_name", names.NormalizePattern(cfg.Name))
 		query = query.Where(condition, arg)
 	}
 
@@ -281,6 +367,69 @@ func (d *DB) handleFilterOptions(query *gorm.DB, cfg *types.RecordFilters) *gorm
 		query = query.Where(condition, arg)
 	}
 
+	// Apply version comparisons, records without a semantic version never match.
+	for _, constraint := range cfg.VersionConstraints {
+		key, ok := utils.VersionKey(constraint.Version)
+
+		switch constraint.Operator {
+		case types.VersionGreaterThan, types.VersionGreaterThanOrEqual, types.VersionLessThan, types.VersionLessThanOrEqual:
+		default:
+			ok = false
+		}
+
+		if !ok {
+			logger.Warn("Invalid version constraint, no records match", "operator", constraint.Operator, "version", constraint.Version)
+
+			query = query.Where("1 = 0")
+
+			continue
+		}
+
+		query = query.Where("records.version_key != '' AND records.version_key "+string(constraint.Operator)+" ?", key)
+	}
+
+	// Apply creation time comparisons.
+	for _, constraint := range cfg.CreatedAt {
+		switch constraint.Operator {
+		case types.VersionGreaterThan, types.VersionGreaterThanOrEqual, types.VersionLessThan, types.VersionLessThanOrEqual:
+			query = query.Where("records.created_at "+string(constraint.Operator)+" ?", constraint.Time)
+		default:
+			logger.Warn("Invalid creation time constraint, no records match", "operator", constraint.Operator)
+
+			query = query.Where("1 = 0")
+		}
+	}
+
+	// Handle the trust domain filter, records pushed before usage was tracked never match.
+	if cfg.TrustDomain != "" {
+		condition, arg := utils.BuildSingleWildcardCondition("record_usages.trust_domain", cfg.TrustDomain)
+		query = query.Where("EXISTS (SELECT 1 FROM record_usages WHERE record_usages.record_cid = records.record_cid AND "+condition+")", arg)
+	}
+
+	// Hide records superseded by an indexed equivalent record.
+	if cfg.ExcludeSuperseded {
+		query = query.Where(notSupersededCondition)
+	}
+
+	// Handle annotation filters, each of which must match.
+	for _, annotation := range cfg.Annotations {
+		subquery := "SELECT 1 FROM annotations WHERE annotations.record_cid = records.record_cid AND annotations.key = ?"
+		if annotation.Value == "" {
+			query = query.Where("EXISTS ("+subquery+")", annotation.Key)
+
+			continue
+		}
+
+		condition, arg := utils.BuildSingleWildcardCondition("annotations.value", annotation.Value)
+		query = query.Where("EXISTS ("+subquery+" AND "+condition+")", annotation.Key, arg)
+	}
+
+	// Handle locator variant filters, each of which must match.
+	for _, variant := range cfg.LocatorVariants {
+		condition, arg := utils.BuildSingleWildcardCondition("locator_variants.variant", variant)
+		query = query.Where("EXISTS (SELECT 1 FROM locator_variants WHERE locator_variants.record_cid = records.record_cid AND "+condition+")", arg)
+	}
+
 	// Handle skill filters with wildcard support.
 	if len(cfg.SkillIDs) > 0 || len(cfg.SkillNames) > 0 {
 		query = query.Joins("JOIN skills ON skills.record_cid = records.record_cid")
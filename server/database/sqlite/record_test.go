@@ -43,15 +43,21 @@ func mustGetRecordData(t *testing.T, record types.Record) types.RecordData {
 
 // TestRecordData implements types.RecordData interface for testing.
 type TestRecordData struct {
-	name     string
-	version  string
-	skills   []types.Skill
-	locators []types.Locator
-	modules  []types.Module
+	name        string
+	version     string
+	description string
+	skills      []types.Skill
+	locators    []types.Locator
+	modules     []types.Module
+	annotations map[string]string
 }
 
 func (r *TestRecordData) GetAnnotations() map[string]string {
-	return make(map[string]string)
+	if r.annotations == nil {
+		return make(map[string]string)
+	}
+
+	return r.annotations
 }
 
 func (r *TestRecordData) GetSchemaVersion() string {
@@ -67,7 +73,7 @@ func (r *TestRecordData) GetVersion() string {
 }
 
 func (r *TestRecordData) GetDescription() string {
-	return ""
+	return r.description
 }
 
 func (r *TestRecordData) GetAuthors() []string {
@@ -121,12 +127,17 @@ func (s *TestSkill) GetID() uint64 {
 }
 
 type TestLocator struct {
-	locType string
-	url     string
+	locType     string
+	url         string
+	annotations map[string]string
 }
 
 func (l *TestLocator) GetAnnotations() map[string]string {
-	return make(map[string]string)
+	if l.annotations == nil {
+		return make(map[string]string)
+	}
+
+	return l.annotations
 }
 
 func (l *TestLocator) GetType() string {
@@ -165,7 +176,7 @@ func setupTestDB(t *testing.T) *DB {
 	})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&Record{}, &Skill{}, &Locator{}, &Module{}, &Sync{})
+	err = db.AutoMigrate(&Record{}, &Skill{}, &Locator{}, &Module{}, &Annotation{}, &LocatorVariant{}, &SearchTerm{}, &Sync{}, &RecordUsage{})
 	require.NoError(t, err)
 
 	return &DB{
@@ -492,6 +503,39 @@ func TestAddRecord_VerifyRelatedDataInsertion(t *testing.T) {
 	t.Logf("✅ AddRecord properly inserted all related data")
 }
 
+func TestGetRecords_NormalizedName(t *testing.T) {
+	db := setupTestDB(t)
+
+	for cid, name := range map[string]string{
+		"cid-spaces":      "My Agent",
+		"cid-underscores": "MY_AGENT",
+		"cid-hyphens":     "my-agent-v2",
+		"cid-other":       "myagent",
+	} {
+		require.NoError(t, db.AddRecord(&TestRecord{cid: cid, data: &TestRecordData{name: name, version: "v1.0.0"}}))
+	}
+
+	cids, err := db.GetRecordCIDs(types.WithName("my-agent"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cid-spaces", "cid-underscores"}, cids)
+
+	cids, err = db.GetRecordCIDs(types.WithName("My_Agent*"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cid-spaces", "cid-underscores", "cid-hyphens"}, cids)
+
+	// The display name is kept
+	records, err := db.GetRecords(types.WithName("MY AGENT"))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	var displayNames []string
+	for _, record := range records {
+		displayNames = append(displayNames, mustGetRecordData(t, record).GetName())
+	}
+
+	assert.ElementsMatch(t, []string{"My Agent", "MY_AGENT"}, displayNames)
+}
+
 // TestRemoveRecord_VerifyRelatedDataDeletion tests that RemoveRecord deletes all related data.
 func TestRemoveRecord_VerifyRelatedDataDeletion(t *testing.T) {
 	db := setupTestDB(t)
@@ -896,3 +940,92 @@ func TestSkillSearchCompatibility_AcrossVersions(t *testing.T) {
 	t.Logf("   Added CIDs: %v", addedCIDs)
 	t.Logf("   Found by name: %d agents", len(cids))
 }
+
+// TestGetRecordScores tests ranking records by a free-text query.
+func TestGetRecordScores(t *testing.T) {
+	db := setupTestDB(t)
+
+	corpus := []struct {
+		cid         string
+		name        string
+		version     string
+		description string
+		skills      []string
+	}{
+		{"cid-contract", "contract-reviewer", "2.0.0", "Reviews legal contracts", []string{"text summarization"}},
+		{"cid-document", "document-summarizer", "2.0.0", "Summarization of legal documents", []string{"summarization"}},
+		{"cid-image", "image-generator", "2.0.0", "Draws images", []string{"image generation"}},
+		{"cid-legal", "Legal Summarization Agent", "1.0.0", "Condenses court filings", []string{"text summarization"}},
+		{"cid-weather", "weather-agent", "1.0.0", "Forecasts the weather", nil},
+	}
+
+	for _, entry := range corpus {
+		skills := make([]types.Skill, len(entry.skills))
+		for i, name := range entry.skills {
+			skills[i] = &TestSkill{id: uint64(i + 1), name: name}
+		}
+
+		require.NoError(t, db.AddRecord(&TestRecord{
+			cid: entry.cid,
+			data: &TestRecordData{
+				name:        entry.name,
+				version:     entry.version,
+				description: entry.description,
+				skills:      skills,
+			},
+		}))
+	}
+
+	const query = "summarization agent for legal documents"
+
+	cids := func(scores []types.RecordScore) []string {
+		result := make([]string, len(scores))
+		for i, score := range scores {
+			result[i] = score.CID
+		}
+
+		return result
+	}
+
+	t.Run("Query only", func(t *testing.T) {
+		scores, err := db.GetRecordScores(types.WithQuery(query))
+		require.NoError(t, err)
+
+		// Name matches rank above skill and description matches
+		assert.Equal(t, []string{"cid-legal", "cid-document", "cid-weather", "cid-contract"}, cids(scores))
+		assert.InDelta(t, 27, scores[0].Score, 0)
+		assert.InDelta(t, 14, scores[1].Score, 0)
+		assert.InDelta(t, 8, scores[2].Score, 0)
+		assert.InDelta(t, 4, scores[3].Score, 0)
+	})
+
+	t.Run("Query with filters", func(t *testing.T) {
+		scores, err := db.GetRecordScores(types.WithQuery(query), types.WithVersion("2.0.0"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cid-document", "cid-contract"}, cids(scores))
+
+		scores, err = db.GetRecordScores(types.WithQuery(query), types.WithSkillNames("*summarization*"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cid-legal", "cid-document", "cid-contract"}, cids(scores))
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		scores, err := db.GetRecordScores(types.WithQuery(query), types.WithLimit(2), types.WithOffset(1))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cid-document", "cid-weather"}, cids(scores))
+	})
+
+	t.Run("No terms", func(t *testing.T) {
+		scores, err := db.GetRecordScores(types.WithQuery("the"))
+		require.NoError(t, err)
+		assert.Empty(t, scores)
+	})
+
+	t.Run("Removed records", func(t *testing.T) {
+		require.NoError(t, db.RemoveRecord("cid-legal"))
+
+		scores, err := db.GetRecordScores(types.WithQuery(query))
+		require.NoError(t, err)
+		assert.NotContains(t, cids(scores), "cid-legal")
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"fmt"
+
+	"github.com/agntcy/dir/server/types"
+	"gorm.io/gorm"
+)
+
+// ScanWarning is a finding of a content scanner that did not reject the push of a record.
+// Warnings are kept separately from the search index, so that they survive index rebuilds.
+type ScanWarning struct {
+	ID        uint   `gorm:"primarykey"`
+	RecordCID string `gorm:"column:record_cid;not null;index"`
+	Scanner   string `gorm:"not null"`
+	Rule      string `gorm:"not null"`
+	Message   string `gorm:"not null"`
+	Location  string `gorm:"not null"`
+}
+
+func (d *DB) SetScanWarnings(cid string, warnings []types.ScanWarning) error {
+	err := d.gormDB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("record_cid = ?", cid).Delete(&ScanWarning{}).Error; err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if len(warnings) == 0 {
+			return nil
+		}
+
+		rows := make([]ScanWarning, 0, len(warnings))
+		for _, warning := range warnings {
+			rows = append(rows, ScanWarning{
+				RecordCID: cid,
+				Scanner:   warning.Scanner,
+				Rule:      warning.Rule,
+				Message:   warning.Message,
+				Location:  warning.Location,
+			})
+		}
+
+		return tx.Create(&rows).Error //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set scan warnings: %w", err)
+	}
+
+	logger.Debug("Set scan warnings in SQLite database", "cid", cid, "warnings", len(warnings))
+
+	return nil
+}
+
+func (d *DB) GetScanWarnings(cid string) ([]types.ScanWarning, error) {
+	var rows []ScanWarning
+
+	if err := d.gormDB.Where("record_cid = ?", cid).Order("id").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get scan warnings: %w", err)
+	}
+
+	warnings := make([]types.ScanWarning, 0, len(rows))
+	for _, row := range rows {
+		warnings = append(warnings, types.ScanWarning{
+			Scanner:  row.Scanner,
+			Rule:     row.Rule,
+			Message:  row.Message,
+			Location: row.Location,
+		})
+	}
+
+	return warnings, nil
+}
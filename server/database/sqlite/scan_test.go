@@ -0,0 +1,54 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanWarnings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir.db")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	warnings, err := db.GetScanWarnings("cid-1")
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	require.NoError(t, db.SetScanWarnings("cid-1", []types.ScanWarning{
+		{Scanner: "secrets", Rule: "private-key", Message: "possible PEM private key", Location: "modules[0].data"},
+	}))
+
+	// Pushing again replaces the warnings
+	want := []types.ScanWarning{
+		{Scanner: "secrets", Rule: "aws-access-key-id", Message: "possible AWS access key ID", Location: "modules[0].data"},
+		{Scanner: "webhook", Rule: "license", Message: "unknown license"},
+	}
+	require.NoError(t, db.SetScanWarnings("cid-1", want))
+	require.NoError(t, db.SetScanWarnings("cid-2", want[1:]))
+
+	// Warnings survive restarts
+	db, err = New(path)
+	require.NoError(t, err)
+
+	warnings, err = db.GetScanWarnings("cid-1")
+	require.NoError(t, err)
+	assert.Equal(t, want, warnings)
+
+	require.NoError(t, db.SetScanWarnings("cid-1", nil))
+
+	warnings, err = db.GetScanWarnings("cid-1")
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	warnings, err = db.GetScanWarnings("cid-2")
+	require.NoError(t, err)
+	assert.Equal(t, want[1:], warnings)
+}
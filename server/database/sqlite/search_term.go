@@ -0,0 +1,81 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"time"
+
+	"github.com/agntcy/dir/server/database/utils"
+	"github.com/agntcy/dir/server/types"
+)
+
+// Weights of the record fields that free-text queries match,
+// so that name matches rank above skill and extension matches, and those above description matches.
+const (
+	nameTermWeight        = 8
+	skillTermWeight       = 3
+	moduleTermWeight      = 3
+	descriptionTermWeight = 1
+)
+
+// SearchTerm is an entry of the inverted index that free-text queries are matched against.
+// A term found in several fields of a record has an entry for each field.
+type SearchTerm struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	RecordCID string `gorm:"column:record_cid;not null;index"`
+	Term      string `gorm:"not null;index"`
+	Field     string `gorm:"not null"`
+	Weight    int    `gorm:"not null"`
+}
+
+// convertSearchTerms indexes the terms of the record name, description, skill names and module names.
+func convertSearchTerms(recordData types.RecordData, recordCID string) []SearchTerm {
+	var result []SearchTerm
+
+	add := func(field string, weight int, texts ...string) {
+		seen := map[string]struct{}{}
+
+		for _, text := range texts {
+			for _, term := range utils.SearchTerms(text) {
+				if _, ok := seen[term]; ok {
+					continue
+				}
+
+				seen[term] = struct{}{}
+
+				result = append(result, SearchTerm{
+					RecordCID: recordCID,
+					Term:      term,
+					Field:     field,
+					Weight:    weight,
+				})
+			}
+		}
+	}
+
+	add("name", nameTermWeight, recordData.GetName())
+	add("description", descriptionTermWeight, recordData.GetDescription())
+
+	skills := recordData.GetSkills()
+	skillNames := make([]string, len(skills))
+
+	for i, skill := range skills {
+		skillNames[i] = skill.GetName()
+	}
+
+	add("skill", skillTermWeight, skillNames...)
+
+	modules := recordData.GetModules()
+	moduleNames := make([]string, len(modules))
+
+	for i, module := range modules {
+		moduleNames[i] = module.GetName()
+	}
+
+	add("module", moduleTermWeight, moduleNames...)
+
+	return result
+}
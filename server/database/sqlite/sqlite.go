@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/agntcy/dir/utils/logging"
@@ -19,6 +20,9 @@ var logger = logging.Logger("database/sqlite")
 
 type DB struct {
 	gormDB *gorm.DB
+
+	// needsReindex is set if the record search index must be rebuilt from the store.
+	needsReindex atomic.Bool
 }
 
 func newCustomLogger() gormlogger.Interface {
@@ -44,7 +48,7 @@ func New(path string) (*DB, error) {
 	}
 
 	// Migrate record-related schema
-	if err := db.AutoMigrate(Record{}, Locator{}, Skill{}, Module{}); err != nil {
+	if err := db.AutoMigrate(Record{}, Locator{}, Skill{}, Module{}, Annotation{}, LocatorVariant{}, SearchTerm{}, RecordEquivalence{}, IndexState{}); err != nil {
 		return nil, fmt.Errorf("failed to migrate record schema: %w", err)
 	}
 
@@ -58,7 +62,48 @@ func New(path string) (*DB, error) {
 		return nil, fmt.Errorf("failed to migrate publication schema: %w", err)
 	}
 
-	return &DB{
+	// Migrate quota-related schema
+	if err := db.AutoMigrate(RecordUsage{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate quota schema: %w", err)
+	}
+
+	// Migrate pin-related schema
+	if err := db.AutoMigrate(RecordPin{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate pin schema: %w", err)
+	}
+
+	// Migrate trash-related schema
+	if err := db.AutoMigrate(TrashedRecord{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate trash schema: %w", err)
+	}
+
+	// Migrate stats-related schema
+	if err := db.AutoMigrate(RecordStats{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate stats schema: %w", err)
+	}
+
+	// Migrate scan-related schema
+	if err := db.AutoMigrate(ScanWarning{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate scan schema: %w", err)
+	}
+
+	// Migrate acl-related schema
+	if err := db.AutoMigrate(RecordACL{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate acl schema: %w", err)
+	}
+
+	// Migrate webhook-related schema
+	if err := db.AutoMigrate(Webhook{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate webhook schema: %w", err)
+	}
+
+	sqliteDB := &DB{
 		gormDB: db,
-	}, nil
+	}
+
+	if err := sqliteDB.checkIndex(); err != nil {
+		return nil, err
+	}
+
+	return sqliteDB, nil
 }
@@ -0,0 +1,120 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecordStats counts the pulls and lookups of a record.
+// Statistics are kept separately from the search index and are not removed with the record,
+// so that the counts of records that are pushed again continue to grow.
+type RecordStats struct {
+	RecordCID      string    `gorm:"column:record_cid;primarykey;not null"`
+	PullCount      uint64    `gorm:"not null;index"`
+	LookupCount    uint64    `gorm:"not null"`
+	LastAccessedAt time.Time `gorm:"not null;index"`
+}
+
+func (d *DB) AddRecordStats(stats []types.RecordStats) error {
+	if len(stats) == 0 {
+		return nil
+	}
+
+	rows := make([]RecordStats, 0, len(stats))
+	for _, s := range stats {
+		rows = append(rows, RecordStats{
+			RecordCID:      s.CID,
+			PullCount:      s.PullCount,
+			LookupCount:    s.LookupCount,
+			LastAccessedAt: s.LastAccessedAt,
+		})
+	}
+
+	err := d.gormDB.Transaction(func(tx *gorm.DB) error {
+		// Counts are added to the stored ones, and access times only move forward
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "record_cid"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"pull_count":       gorm.Expr("pull_count + excluded.pull_count"),
+				"lookup_count":     gorm.Expr("lookup_count + excluded.lookup_count"),
+				"last_accessed_at": gorm.Expr("MAX(last_accessed_at, excluded.last_accessed_at)"),
+			}),
+		}).Create(&rows).Error
+		if err != nil {
+			return fmt.Errorf("failed to add record stats: %w", err)
+		}
+
+		for _, row := range rows {
+			err := tx.Model(&RecordUsage{}).
+				Where("record_cid = ? AND accessed_at < ?", row.RecordCID, row.LastAccessedAt).
+				Updates(map[string]any{"accessed_at": row.LastAccessedAt, "expired": false}).Error
+			if err != nil {
+				return fmt.Errorf("failed to update record usage: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	logger.Debug("Added record stats to SQLite database", "records", len(rows))
+
+	return nil
+}
+
+func (d *DB) GetRecordStats(cid string) (types.RecordStats, error) {
+	var stats RecordStats
+
+	err := d.gormDB.Where("record_cid = ?", cid).First(&stats).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return types.RecordStats{CID: cid}, nil
+	}
+
+	if err != nil {
+		return types.RecordStats{}, fmt.Errorf("failed to get record stats: %w", err)
+	}
+
+	return stats.toTypes(), nil
+}
+
+func (d *DB) GetTopRecordStats(order types.RecordStatsOrder, limit int) ([]types.RecordStats, error) {
+	query := d.gormDB.Limit(limit)
+
+	switch order {
+	case types.RecordStatsByRecent:
+		query = query.Order("last_accessed_at DESC").Order("record_cid")
+	default:
+		query = query.Order("pull_count DESC").Order("last_accessed_at DESC").Order("record_cid")
+	}
+
+	var rows []RecordStats
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get top record stats: %w", err)
+	}
+
+	stats := make([]types.RecordStats, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, row.toTypes())
+	}
+
+	return stats, nil
+}
+
+func (r RecordStats) toTypes() types.RecordStats {
+	return types.RecordStats{
+		CID:            r.RecordCID,
+		PullCount:      r.PullCount,
+		LookupCount:    r.LookupCount,
+		LastAccessedAt: r.LastAccessedAt,
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordStats(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir.db")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	stats, err := db.GetRecordStats("cid-1")
+	require.NoError(t, err)
+	assert.Equal(t, types.RecordStats{CID: "cid-1"}, stats)
+
+	require.NoError(t, db.AddRecordUsage(types.RecordUsage{CID: "cid-1", TrustDomain: "a.org", AccessedAt: now.Add(-48 * time.Hour)}))
+
+	require.NoError(t, db.AddRecordStats([]types.RecordStats{
+		{CID: "cid-1", PullCount: 3, LookupCount: 1, LastAccessedAt: now.Add(-time.Hour)},
+		{CID: "cid-2", PullCount: 1, LastAccessedAt: now},
+	}))
+
+	// Counts are added up, and an older access time does not move the last access back
+	require.NoError(t, db.AddRecordStats([]types.RecordStats{
+		{CID: "cid-1", PullCount: 2, LookupCount: 1, LastAccessedAt: now.Add(-2 * time.Hour)},
+	}))
+
+	// Statistics survive restarts
+	db, err = New(path)
+	require.NoError(t, err)
+
+	stats, err = db.GetRecordStats("cid-1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), stats.PullCount)
+	assert.Equal(t, uint64(2), stats.LookupCount)
+	assert.WithinDuration(t, now.Add(-time.Hour), stats.LastAccessedAt, time.Second)
+
+	// The access time of the record usage is advanced for the age policy of the quota reaper
+	cids, err := db.GetUnusedRecords("a.org", now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, cids)
+
+	top, err := db.GetTopRecordStats(types.RecordStatsByPulls, 10)
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	assert.Equal(t, "cid-1", top[0].CID)
+
+	top, err = db.GetTopRecordStats(types.RecordStatsByRecent, 1)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	assert.Equal(t, "cid-2", top[0].CID)
+}
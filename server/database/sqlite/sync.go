@@ -21,6 +21,11 @@ type Sync struct {
 	RemoteRegistryURL  string             `gorm:"not null"`
 	CIDs               []string           `gorm:"serializer:json;not null"`
 	Status             storev1.SyncStatus `gorm:"not null"`
+	Labels             []string           `gorm:"serializer:json"`
+	Interval           time.Duration
+	RecordsSynced      uint64
+	LastError          string
+	LastRunTime        time.Time
 }
 
 func (sync *Sync) GetID() string {
@@ -43,11 +48,33 @@ func (sync *Sync) GetStatus() storev1.SyncStatus {
 	return sync.Status
 }
 
-func (d *DB) CreateSync(remoteURL string, cids []string) (string, error) {
+func (sync *Sync) GetLabels() []string {
+	return sync.Labels
+}
+
+func (sync *Sync) GetInterval() time.Duration {
+	return sync.Interval
+}
+
+func (sync *Sync) GetRecordsSynced() uint64 {
+	return sync.RecordsSynced
+}
+
+func (sync *Sync) GetLastError() string {
+	return sync.LastError
+}
+
+func (sync *Sync) GetLastRunTime() time.Time {
+	return sync.LastRunTime
+}
+
+func (d *DB) CreateSync(remoteURL string, opts types.SyncOptions) (string, error) {
 	sync := &Sync{
 		ID:                 uuid.NewString(),
 		RemoteDirectoryURL: remoteURL,
-		CIDs:               cids,
+		CIDs:               opts.CIDs,
+		Labels:             opts.Labels,
+		Interval:           opts.Interval,
 		Status:             storev1.SyncStatus_SYNC_STATUS_PENDING,
 	}
 
@@ -165,6 +192,30 @@ func (d *DB) GetSyncRemoteRegistry(syncID string) (string, error) {
 	return sync.GetRemoteRegistryURL(), nil
 }
 
+func (d *DB) UpdateSyncProgress(syncID string, recordsSynced uint64, lastError string) error {
+	syncObj, err := d.GetSyncByID(syncID)
+	if err != nil {
+		return err
+	}
+
+	sync, ok := syncObj.(*Sync)
+	if !ok {
+		return gorm.ErrInvalidData
+	}
+
+	sync.RecordsSynced += recordsSynced
+	sync.LastError = lastError
+	sync.LastRunTime = time.Now()
+
+	if err := d.gormDB.Save(sync).Error; err != nil {
+		return err
+	}
+
+	logger.Debug("Updated sync progress in SQLite database", "sync_id", sync.GetID(), "records_synced", sync.GetRecordsSynced())
+
+	return nil
+}
+
 func (d *DB) GetSyncCIDs(syncID string) ([]string, error) {
 	syncObj, err := d.GetSyncByID(syncID)
 	if err != nil {
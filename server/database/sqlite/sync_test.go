@@ -0,0 +1,58 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSync_Options(t *testing.T) {
+	db := setupTestDB(t)
+
+	id, err := db.CreateSync("localhost:8888", types.SyncOptions{
+		Labels:   []string{"/skills/natural_language_processing"},
+		Interval: time.Minute,
+	})
+	require.NoError(t, err)
+
+	sync, err := db.GetSyncByID(id)
+	require.NoError(t, err)
+
+	assert.Equal(t, storev1.SyncStatus_SYNC_STATUS_PENDING, sync.GetStatus())
+	assert.Equal(t, []string{"/skills/natural_language_processing"}, sync.GetLabels())
+	assert.Equal(t, time.Minute, sync.GetInterval())
+	assert.Zero(t, sync.GetRecordsSynced())
+	assert.True(t, sync.GetLastRunTime().IsZero())
+}
+
+func TestUpdateSyncProgress(t *testing.T) {
+	db := setupTestDB(t)
+
+	id, err := db.CreateSync("localhost:8888", types.SyncOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, db.UpdateSyncProgress(id, 3, "cid: not found"))
+
+	sync, err := db.GetSyncByID(id)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(3), sync.GetRecordsSynced())
+	assert.Equal(t, "cid: not found", sync.GetLastError())
+	assert.False(t, sync.GetLastRunTime().IsZero())
+
+	// Records are accumulated across runs, the last error is replaced
+	require.NoError(t, db.UpdateSyncProgress(id, 2, ""))
+
+	sync, err = db.GetSyncByID(id)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(5), sync.GetRecordsSynced())
+	assert.Empty(t, sync.GetLastError())
+}
@@ -0,0 +1,97 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TrashedRecord flags a record deleted in soft deletion mode.
+// Trash entries are kept separately from the search index, which no longer lists trashed records.
+type TrashedRecord struct {
+	RecordCID string    `gorm:"column:record_cid;primarykey;not null"`
+	Name      string    `gorm:"not null"`
+	Version   string    `gorm:"not null"`
+	TrashedAt time.Time `gorm:"not null;index"`
+}
+
+func (d *DB) TrashRecord(record types.TrashedRecord) error {
+	trashedRecord := &TrashedRecord{
+		RecordCID: record.CID,
+		Name:      record.Name,
+		Version:   record.Version,
+		TrashedAt: record.TrashedAt,
+	}
+
+	err := d.gormDB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "record_cid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "version", "trashed_at"}),
+	}).Create(trashedRecord).Error
+	if err != nil {
+		return fmt.Errorf("failed to trash record: %w", err)
+	}
+
+	logger.Debug("Trashed record in SQLite database", "cid", record.CID)
+
+	return nil
+}
+
+func (d *DB) GetTrashedRecord(cid string) (types.TrashedRecord, bool, error) {
+	var trashedRecord TrashedRecord
+
+	err := d.gormDB.Where("record_cid = ?", cid).First(&trashedRecord).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return types.TrashedRecord{}, false, nil
+	}
+
+	if err != nil {
+		return types.TrashedRecord{}, false, fmt.Errorf("failed to get trashed record: %w", err)
+	}
+
+	return trashedRecord.toTypes(), true, nil
+}
+
+func (d *DB) GetTrashedRecords(trashedBefore time.Time) ([]types.TrashedRecord, error) {
+	query := d.gormDB.Order("trashed_at DESC")
+	if !trashedBefore.IsZero() {
+		query = query.Where("trashed_at < ?", trashedBefore)
+	}
+
+	var trashedRecords []TrashedRecord
+	if err := query.Find(&trashedRecords).Error; err != nil {
+		return nil, fmt.Errorf("failed to get trashed records: %w", err)
+	}
+
+	records := make([]types.TrashedRecord, 0, len(trashedRecords))
+	for _, trashedRecord := range trashedRecords {
+		records = append(records, trashedRecord.toTypes())
+	}
+
+	return records, nil
+}
+
+func (d *DB) RemoveTrashedRecord(cid string) error {
+	if err := d.gormDB.Where("record_cid = ?", cid).Delete(&TrashedRecord{}).Error; err != nil {
+		return fmt.Errorf("failed to remove trashed record: %w", err)
+	}
+
+	logger.Debug("Removed record from trash in SQLite database", "cid", cid)
+
+	return nil
+}
+
+func (r TrashedRecord) toTypes() types.TrashedRecord {
+	return types.TrashedRecord{
+		CID:       r.RecordCID,
+		Name:      r.Name,
+		Version:   r.Version,
+		TrashedAt: r.TrashedAt,
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashedRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir.db")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	_, trashed, err := db.GetTrashedRecord("cid-1")
+	require.NoError(t, err)
+	assert.False(t, trashed)
+
+	now := time.Now().UTC()
+
+	require.NoError(t, db.TrashRecord(types.TrashedRecord{CID: "cid-1", Name: "agent-1", Version: "v1", TrashedAt: now.Add(-2 * time.Hour)}))
+	require.NoError(t, db.TrashRecord(types.TrashedRecord{CID: "cid-2", Name: "agent-2", Version: "v1", TrashedAt: now}))
+
+	// Trash entries survive restarts
+	db, err = New(path)
+	require.NoError(t, err)
+
+	record, trashed, err := db.GetTrashedRecord("cid-1")
+	require.NoError(t, err)
+	assert.True(t, trashed)
+	assert.Equal(t, "agent-1", record.Name)
+	assert.WithinDuration(t, now.Add(-2*time.Hour), record.TrashedAt, time.Second)
+
+	records, err := db.GetTrashedRecords(time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "cid-2", records[0].CID)
+
+	records, err = db.GetTrashedRecords(now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "cid-1", records[0].CID)
+
+	require.NoError(t, db.RemoveTrashedRecord("cid-1"))
+	require.NoError(t, db.RemoveTrashedRecord("cid-1"))
+
+	_, trashed, err = db.GetTrashedRecord("cid-1")
+	require.NoError(t, err)
+	assert.False(t, trashed)
+}
@@ -0,0 +1,132 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"gorm.io/gorm/clause"
+)
+
+// RecordUsage accounts a record to the trust domain that pushed it.
+// Usage is kept separately from the search index, so that it survives index rebuilds.
+type RecordUsage struct {
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	RecordCID   string    `gorm:"column:record_cid;primarykey;not null"`
+	TrustDomain string    `gorm:"not null;index"`
+	SizeBytes   uint64    `gorm:"not null"`
+	Protected   bool      `gorm:"not null"`
+	Expired     bool      `gorm:"not null"`
+	AccessedAt  time.Time `gorm:"not null;index"`
+}
+
+func (d *DB) AddRecordUsage(usage types.RecordUsage) error {
+	recordUsage := &RecordUsage{
+		RecordCID:   usage.CID,
+		TrustDomain: usage.TrustDomain,
+		SizeBytes:   usage.SizeBytes,
+		Protected:   usage.Protected,
+		AccessedAt:  usage.AccessedAt,
+	}
+
+	// Keep the owner of records that are already accounted
+	err := d.gormDB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "record_cid"}},
+		DoUpdates: clause.Assignments(map[string]any{"accessed_at": usage.AccessedAt, "expired": false}),
+	}).Create(recordUsage).Error
+	if err != nil {
+		return fmt.Errorf("failed to add record usage: %w", err)
+	}
+
+	logger.Debug("Added record usage to SQLite database", "cid", usage.CID, "trust_domain", usage.TrustDomain)
+
+	return nil
+}
+
+func (d *DB) HasRecordUsage(cid string) (bool, error) {
+	var count int64
+	if err := d.gormDB.Model(&RecordUsage{}).Where("record_cid = ?", cid).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to get record usage: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (d *DB) GetRecordOwner(cid string) (string, bool, error) {
+	var owners []string
+	if err := d.gormDB.Model(&RecordUsage{}).Where("record_cid = ?", cid).Limit(1).Pluck("trust_domain", &owners).Error; err != nil {
+		return "", false, fmt.Errorf("failed to get record owner: %w", err)
+	}
+
+	if len(owners) == 0 {
+		return "", false, nil
+	}
+
+	return owners[0], true, nil
+}
+
+func (d *DB) TouchRecordUsage(cid string, accessedAt time.Time) error {
+	err := d.gormDB.Model(&RecordUsage{}).
+		Where("record_cid = ?", cid).
+		Updates(map[string]any{"accessed_at": accessedAt, "expired": false}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update record usage: %w", err)
+	}
+
+	return nil
+}
+
+func (d *DB) RemoveRecordUsage(cid string) error {
+	if err := d.gormDB.Where("record_cid = ?", cid).Delete(&RecordUsage{}).Error; err != nil {
+		return fmt.Errorf("failed to remove record usage: %w", err)
+	}
+
+	logger.Debug("Removed record usage from SQLite database", "cid", cid)
+
+	return nil
+}
+
+func (d *DB) GetUsage(trustDomains ...string) ([]types.TrustDomainUsage, error) {
+	query := d.gormDB.Model(&RecordUsage{}).
+		Select("trust_domain, COUNT(*) AS record_count, COALESCE(SUM(size_bytes), 0) AS total_bytes, " +
+			"COALESCE(SUM(CASE WHEN expired THEN 1 ELSE 0 END), 0) AS expired_record_count").
+		Group("trust_domain").
+		Order("trust_domain")
+
+	if len(trustDomains) > 0 {
+		query = query.Where("trust_domain IN ?", trustDomains)
+	}
+
+	var usages []types.TrustDomainUsage
+	if err := query.Scan(&usages).Error; err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+
+	return usages, nil
+}
+
+func (d *DB) GetUnusedRecords(trustDomain string, accessedBefore time.Time) ([]string, error) {
+	var cids []string
+
+	err := d.gormDB.Model(&RecordUsage{}).
+		Where("trust_domain = ? AND accessed_at < ? AND NOT protected AND NOT expired", trustDomain, accessedBefore).
+		Order("accessed_at").
+		Pluck("record_cid", &cids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unused records: %w", err)
+	}
+
+	return cids, nil
+}
+
+func (d *DB) FlagRecordExpired(cid string) error {
+	if err := d.gormDB.Model(&RecordUsage{}).Where("record_cid = ?", cid).Update("expired", true).Error; err != nil {
+		return fmt.Errorf("failed to flag record as expired: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordUsage_Accounting(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Now()
+
+	require.NoError(t, db.AddRecordUsage(types.RecordUsage{CID: "cid-1", TrustDomain: "a.org", SizeBytes: 100, AccessedAt: now}))
+	require.NoError(t, db.AddRecordUsage(types.RecordUsage{CID: "cid-2", TrustDomain: "a.org", SizeBytes: 50, AccessedAt: now}))
+	require.NoError(t, db.AddRecordUsage(types.RecordUsage{CID: "cid-3", TrustDomain: "b.org", SizeBytes: 10, AccessedAt: now}))
+
+	// Pushing an accounted record again keeps its owner and does not count it twice
+	require.NoError(t, db.AddRecordUsage(types.RecordUsage{CID: "cid-1", TrustDomain: "b.org", SizeBytes: 100, AccessedAt: now}))
+
+	usages, err := db.GetUsage()
+	require.NoError(t, err)
+	assert.Equal(t, []types.TrustDomainUsage{
+		{TrustDomain: "a.org", RecordCount: 2, TotalBytes: 150},
+		{TrustDomain: "b.org", RecordCount: 1, TotalBytes: 10},
+	}, usages)
+
+	exists, err := db.HasRecordUsage("cid-1")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	owner, exists, err := db.GetRecordOwner("cid-1")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, "a.org", owner)
+
+	// Deleted records are no longer accounted
+	require.NoError(t, db.RemoveRecordUsage("cid-1"))
+
+	usages, err = db.GetUsage("a.org")
+	require.NoError(t, err)
+	assert.Equal(t, []types.TrustDomainUsage{{TrustDomain: "a.org", RecordCount: 1, TotalBytes: 50}}, usages)
+
+	exists, err = db.HasRecordUsage("cid-1")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, exists, err = db.GetRecordOwner("cid-1")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	usages, err = db.GetUsage("unknown.org")
+	require.NoError(t, err)
+	assert.Empty(t, usages)
+}
+
+func TestRecordUsage_UnusedRecords(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Now()
+
+	require.NoError(t, db.AddRecordUsage(types.RecordUsage{CID: "old", TrustDomain: "a.org", AccessedAt: now.Add(-2 * time.Hour)}))
+	require.NoError(t, db.AddRecordUsage(types.RecordUsage{CID: "protected", TrustDomain: "a.org", Protected: true, AccessedAt: now.Add(-2 * time.Hour)}))
+	require.NoError(t, db.AddRecordUsage(types.RecordUsage{CID: "recent", TrustDomain: "a.org", AccessedAt: now}))
+	require.NoError(t, db.AddRecordUsage(types.RecordUsage{CID: "other", TrustDomain: "b.org", AccessedAt: now.Add(-2 * time.Hour)}))
+
+	cids, err := db.GetUnusedRecords("a.org", now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old"}, cids)
+
+	// Flagged records are reported once and counted as expired
+	require.NoError(t, db.FlagRecordExpired("old"))
+
+	cids, err = db.GetUnusedRecords("a.org", now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, cids)
+
+	usages, err := db.GetUsage("a.org")
+	require.NoError(t, err)
+	require.Len(t, usages, 1)
+	assert.Equal(t, uint64(1), usages[0].ExpiredRecordCount)
+
+	// Accessing a flagged record clears the flag
+	require.NoError(t, db.TouchRecordUsage("old", now))
+
+	usages, err = db.GetUsage("a.org")
+	require.NoError(t, err)
+	assert.Zero(t, usages[0].ExpiredRecordCount)
+}
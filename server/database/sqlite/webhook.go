@@ -0,0 +1,111 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+)
+
+// Webhook is a webhook subscription added at runtime.
+// Subscriptions of the server configuration are not stored.
+type Webhook struct {
+	CreatedAt      time.Time
+	ID             string `gorm:"primarykey;not null"`
+	URL            string `gorm:"not null"`
+	Secret         string `gorm:"not null"`
+	EventTypesJSON string `gorm:"not null"` // JSON-encoded event types
+	NamesJSON      string `gorm:"not null"` // JSON-encoded name patterns
+	LabelsJSON     string `gorm:"not null"` // JSON-encoded routing labels
+	MaxAttempts    int
+	InitialBackoff time.Duration
+}
+
+func (d *DB) AddWebhook(webhook types.Webhook) error {
+	row := &Webhook{
+		ID:             webhook.ID,
+		URL:            webhook.URL,
+		Secret:         webhook.Secret,
+		MaxAttempts:    webhook.MaxAttempts,
+		InitialBackoff: webhook.InitialBackoff,
+	}
+
+	for _, field := range []struct {
+		values []string
+		json   *string
+	}{
+		{webhook.EventTypes, &row.EventTypesJSON},
+		{webhook.Names, &row.NamesJSON},
+		{webhook.Labels, &row.LabelsJSON},
+	} {
+		if field.values == nil {
+			field.values = []string{}
+		}
+
+		data, err := json.Marshal(field.values)
+		if err != nil {
+			return fmt.Errorf("failed to marshal webhook filters: %w", err)
+		}
+
+		*field.json = string(data)
+	}
+
+	if err := d.gormDB.Create(row).Error; err != nil {
+		return fmt.Errorf("failed to add webhook: %w", err)
+	}
+
+	logger.Debug("Added webhook to SQLite database", "id", webhook.ID, "url", webhook.URL)
+
+	return nil
+}
+
+func (d *DB) GetWebhooks() ([]types.Webhook, error) {
+	var rows []Webhook
+	if err := d.gormDB.Order("created_at, id").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	webhooks := make([]types.Webhook, 0, len(rows))
+
+	for _, row := range rows {
+		webhook := types.Webhook{
+			ID:             row.ID,
+			URL:            row.URL,
+			Secret:         row.Secret,
+			MaxAttempts:    row.MaxAttempts,
+			InitialBackoff: row.InitialBackoff,
+		}
+
+		for _, field := range []struct {
+			json   string
+			values *[]string
+		}{
+			{row.EventTypesJSON, &webhook.EventTypes},
+			{row.NamesJSON, &webhook.Names},
+			{row.LabelsJSON, &webhook.Labels},
+		} {
+			if err := json.Unmarshal([]byte(field.json), field.values); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal webhook filters: %w", err)
+			}
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
+
+func (d *DB) RemoveWebhook(id string) (bool, error) {
+	result := d.gormDB.Where("id = ?", id).Delete(&Webhook{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to remove webhook: %w", result.Error)
+	}
+
+	logger.Debug("Removed webhook from SQLite database", "id", id, "removed", result.RowsAffected > 0)
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,68 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir.db")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	webhooks, err := db.GetWebhooks()
+	require.NoError(t, err)
+	assert.Empty(t, webhooks)
+
+	slack := types.Webhook{
+		ID:             "wh-1",
+		URL:            "https://hooks.example.org/slack",
+		Secret:         "s3cret",
+		EventTypes:     []string{"pushed", "published"},
+		Names:          []string{"cisco.com/*"},
+		Labels:         []string{},
+		MaxAttempts:    3,
+		InitialBackoff: time.Second,
+	}
+	ci := types.Webhook{
+		ID:          "wh-2",
+		URL:         "https://ci.example.org/trigger",
+		EventTypes:  []string{},
+		Names:       []string{},
+		Labels:      []string{"/skills/natural_language_processing"},
+		MaxAttempts: 0,
+	}
+
+	require.NoError(t, db.AddWebhook(slack))
+	require.NoError(t, db.AddWebhook(ci))
+	require.Error(t, db.AddWebhook(ci))
+
+	// Subscriptions survive restarts
+	db, err = New(path)
+	require.NoError(t, err)
+
+	webhooks, err = db.GetWebhooks()
+	require.NoError(t, err)
+	assert.Equal(t, []types.Webhook{slack, ci}, webhooks)
+
+	removed, err := db.RemoveWebhook("wh-1")
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	removed, err = db.RemoveWebhook("wh-1")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	webhooks, err = db.GetWebhooks()
+	require.NoError(t, err)
+	assert.Equal(t, []types.Webhook{ci}, webhooks)
+}
@@ -0,0 +1,55 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// minTermLength is the minimum length of search terms, shorter words are not indexed.
+const minTermLength = 2
+
+// stopWords are common words that carry no meaning for search and are not indexed.
+var stopWords = map[string]struct{}{
+	"a": {}, "an": {}, "and": {}, "are": {}, "as": {}, "at": {}, "be": {}, "by": {}, "for": {}, "from": {},
+	"in": {}, "is": {}, "it": {}, "of": {}, "on": {}, "or": {}, "that": {}, "the": {}, "to": {}, "with": {},
+}
+
+// SearchTerms splits free text into the distinct terms that records are indexed and queried by.
+// Terms are lowercase words of letters and digits, without stop words, and with a plural "s"
+// dropped, e.g. "Summarization Agents for legal_documents" has the terms "summarization",
+// "agent", "legal" and "document".
+func SearchTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+
+	for _, word := range words {
+		if _, ok := stopWords[word]; ok {
+			continue
+		}
+
+		term := stem(word)
+		if len(term) < minTermLength || slices.Contains(terms, term) {
+			continue
+		}
+
+		terms = append(terms, term)
+	}
+
+	return terms
+}
+
+// stem drops a plural "s" from a word, so that singular and plural words match.
+func stem(word string) string {
+	if len(word) <= 3 || !strings.HasSuffix(word, "s") || strings.HasSuffix(word, "ss") {
+		return word
+	}
+
+	return strings.TrimSuffix(word, "s")
+}
@@ -0,0 +1,25 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t,
+		[]string{"summarization", "agent", "legal", "document"},
+		SearchTerms("Summarization Agents for legal_documents"),
+	)
+
+	// Duplicate terms, stop words and single characters are dropped
+	assert.Equal(t, []string{"agent", "v2"}, SearchTerms("the agent, an Agent, agents: x v2"))
+
+	// Short words and words ending in "ss" are not stemmed
+	assert.Equal(t, []string{"gas", "class", "ops"}, SearchTerms("gas class ops"))
+
+	assert.Empty(t, SearchTerms("  - the  "))
+}
@@ -4,9 +4,11 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	searchv1 "github.com/agntcy/dir/api/search/v1"
 	"github.com/agntcy/dir/server/types"
@@ -19,6 +21,12 @@ func QueryToFilters(queries []*searchv1.RecordQuery) ([]types.FilterOption, erro
 	var options []types.FilterOption
 
 	for _, query := range queries {
+		if query.GetOperator() != searchv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_UNSPECIFIED &&
+			query.GetType() != searchv1.RecordQueryType_RECORD_QUERY_TYPE_VERSION &&
+			query.GetType() != searchv1.RecordQueryType_RECORD_QUERY_TYPE_CREATED_AT {
+			return nil, fmt.Errorf("operator %s is only supported for version and creation time queries", query.GetOperator())
+		}
+
 		switch query.GetType() {
 		case searchv1.RecordQueryType_RECORD_QUERY_TYPE_UNSPECIFIED:
 			logger.Warn("Unspecified query type, skipping", "query", query)
@@ -27,7 +35,12 @@ func QueryToFilters(queries []*searchv1.RecordQuery) ([]types.FilterOption, erro
 			options = append(options, types.WithName(query.GetValue()))
 
 		case searchv1.RecordQueryType_RECORD_QUERY_TYPE_VERSION:
-			options = append(options, types.WithVersion(query.GetValue()))
+			option, err := versionFilter(query)
+			if err != nil {
+				return nil, err
+			}
+
+			options = append(options, option)
 
 		case searchv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL_ID:
 			u64, err := strconv.ParseUint(query.GetValue(), 10, 64)
@@ -82,6 +95,34 @@ func QueryToFilters(queries []*searchv1.RecordQuery) ([]types.FilterOption, erro
 				options = append(options, types.WithModuleNames(query.GetValue()))
 			}
 
+		case searchv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR_VARIANT:
+			if strings.TrimSpace(query.GetValue()) != "" {
+				options = append(options, types.WithLocatorVariant(query.GetValue()))
+			}
+
+		case searchv1.RecordQueryType_RECORD_QUERY_TYPE_ANNOTATION:
+			key, value, _ := strings.Cut(query.GetValue(), "=")
+			if strings.TrimSpace(key) == "" {
+				return nil, fmt.Errorf("invalid annotation query %q: expected key=value", query.GetValue())
+			}
+
+			options = append(options, types.WithAnnotation(key, value))
+
+		case searchv1.RecordQueryType_RECORD_QUERY_TYPE_CREATED_AT:
+			option, err := createdAtFilter(query)
+			if err != nil {
+				return nil, err
+			}
+
+			options = append(options, option)
+
+		case searchv1.RecordQueryType_RECORD_QUERY_TYPE_TRUST_DOMAIN:
+			if strings.TrimSpace(query.GetValue()) == "" {
+				return nil, errors.New("invalid trust domain query: trust domain is required")
+			}
+
+			options = append(options, types.WithTrustDomain(query.GetValue()))
+
 		default:
 			logger.Warn("Unknown query type", "type", query.GetType())
 		}
@@ -89,3 +130,52 @@ func QueryToFilters(queries []*searchv1.RecordQuery) ([]types.FilterOption, erro
 
 	return options, nil
 }
+
+// createdAtFilter converts a creation time query into a time comparison filter.
+func createdAtFilter(query *searchv1.RecordQuery) (types.FilterOption, error) {
+	operator, ok := comparisonOperator(query.GetOperator())
+	if !ok {
+		return nil, fmt.Errorf("invalid creation time query: operator %s is not a comparison", query.GetOperator())
+	}
+
+	t, err := time.Parse(time.RFC3339, query.GetValue())
+	if err != nil {
+		return nil, fmt.Errorf("invalid creation time %q: expected an RFC3339 timestamp", query.GetValue())
+	}
+
+	return types.WithCreatedAt(operator, t), nil
+}
+
+// comparisonOperator converts a query comparison operator, ok is false for pattern matching.
+func comparisonOperator(operator searchv1.RecordQueryOperator) (types.VersionOperator, bool) {
+	switch operator { //nolint:exhaustive
+	case searchv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_GREATER_THAN:
+		return types.VersionGreaterThan, true
+	case searchv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_GREATER_THAN_OR_EQUAL:
+		return types.VersionGreaterThanOrEqual, true
+	case searchv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_LESS_THAN:
+		return types.VersionLessThan, true
+	case searchv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_LESS_THAN_OR_EQUAL:
+		return types.VersionLessThanOrEqual, true
+	default:
+		return "", false
+	}
+}
+
+// versionFilter converts a version query into a pattern or comparison filter.
+func versionFilter(query *searchv1.RecordQuery) (types.FilterOption, error) {
+	if query.GetOperator() == searchv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_UNSPECIFIED {
+		return types.WithVersion(query.GetValue()), nil
+	}
+
+	operator, ok := comparisonOperator(query.GetOperator())
+	if !ok {
+		return nil, fmt.Errorf("unknown query operator %s", query.GetOperator())
+	}
+
+	if _, ok := VersionKey(query.GetValue()); !ok {
+		return nil, fmt.Errorf("invalid version %q: comparisons require a semantic version", query.GetValue())
+	}
+
+	return types.WithVersionConstraint(operator, query.GetValue()), nil
+}
@@ -0,0 +1,64 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// versionPartWidth is the width version numbers are zero-padded to.
+	versionPartWidth = 10
+
+	// versionParts is the number of parts of a semantic version core.
+	versionParts = 3
+
+	// releaseSuffix sorts after any pre-release suffix, as releases have precedence over pre-releases.
+	releaseSuffix = "~"
+)
+
+// VersionKey converts a semantic version to a key whose lexical order matches the version order,
+// so that versions can be compared in SQL. A leading "v" is ignored, missing minor and patch
+// versions default to zero and build metadata is dropped, e.g. "v2" and "2.0.0+build" have the
+// same key. Pre-release identifiers are compared lexically.
+// Returns false if the version is not a semantic version.
+func VersionKey(version string) (string, bool) {
+	version = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(version), "v"), "V")
+
+	// Drop build metadata
+	version, _, _ = strings.Cut(version, "+")
+
+	core, prerelease, hasPrerelease := strings.Cut(version, "-")
+	if hasPrerelease && prerelease == "" {
+		return "", false
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) > versionParts {
+		return "", false
+	}
+
+	padded := make([]string, versionParts)
+
+	for i := range padded {
+		part := "0"
+		if i < len(parts) {
+			part = parts[i]
+		}
+
+		if part == "" || len(part) > versionPartWidth || strings.Trim(part, "0123456789") != "" {
+			return "", false
+		}
+
+		padded[i] = fmt.Sprintf("%0*s", versionPartWidth, part)
+	}
+
+	key := strings.Join(padded, ".")
+	if hasPrerelease {
+		return key + "-" + prerelease, true
+	}
+
+	return key + releaseSuffix, true
+}
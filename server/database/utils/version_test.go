@@ -0,0 +1,45 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionKey(t *testing.T) {
+	// Versions in ascending order
+	ordered := []string{
+		"0.9.0",
+		"v1.0.0-alpha",
+		"v1.0.0-rc.1",
+		"1.0.0",
+		"v1.2",
+		"1.10.0",
+		"v2.0.0-beta",
+		"2",
+		"v10.0.1",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		prev, ok := VersionKey(ordered[i-1])
+		assert.True(t, ok, ordered[i-1])
+
+		next, ok := VersionKey(ordered[i])
+		assert.True(t, ok, ordered[i])
+
+		assert.Less(t, prev, next, "%s < %s", ordered[i-1], ordered[i])
+	}
+
+	// Equivalent versions have the same key
+	a, _ := VersionKey("v2")
+	b, _ := VersionKey("2.0.0+build.5")
+	assert.Equal(t, a, b)
+
+	for _, invalid := range []string{"", "latest", "1.2.3.4", "1.x", "1.0.0-", "v1..0"} {
+		_, ok := VersionKey(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import "time"
+
+// DefaultGracePeriod is the default time in-flight requests are given to finish on shutdown.
+const DefaultGracePeriod = 30 * time.Second
+
+// Config contains configuration for draining the server on shutdown.
+type Config struct {
+	// Maximum time to wait for in-flight requests and pending store operations
+	// to finish after draining started, before the server is stopped.
+	GracePeriod time.Duration `json:"grace_period,omitempty" mapstructure:"grace_period"`
+}
@@ -0,0 +1,247 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package drain implements the drain mode of the server.
+// While draining, new requests are rejected so that load balancers move traffic
+// to other replicas, while in-flight requests are given time to finish.
+package drain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/drain/config"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ErrorReason is the reason of the ErrorInfo detail of requests rejected while draining.
+	ErrorReason = "draining"
+
+	// ErrorDomain is the domain of the ErrorInfo detail of requests rejected while draining.
+	ErrorDomain = "dir.agntcy.org"
+)
+
+// exemptMethodPrefixes are services that keep serving while draining.
+// Health checks must report the drain state, and are not waited for.
+var exemptMethodPrefixes = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+// untrackedMethods are long-lived streams that are rejected while draining, but not waited for,
+// as they only end when canceled. They are closed when the server stops.
+var untrackedMethods = []string{
+	storev1.StoreService_WatchStore_FullMethodName,
+}
+
+var logger = logging.Logger("drain")
+
+// ErrDraining is returned by health checks while the server is draining.
+var ErrDraining = errors.New("server is draining")
+
+// Flusher is implemented by components with pending operations,
+// such as store writes, which must be completed before the server stops.
+type Flusher interface {
+	// Flush blocks until all pending operations are completed or the context is done.
+	Flush(ctx context.Context) error
+}
+
+// Service tracks in-flight requests and rejects new ones while draining.
+type Service struct {
+	cfg config.Config
+
+	mu       sync.RWMutex
+	draining bool
+	inflight Tracker
+	flushers []Flusher
+}
+
+// New creates a drain service.
+func New(cfg config.Config) *Service {
+	if cfg.GracePeriod <= 0 {
+		cfg.GracePeriod = config.DefaultGracePeriod
+	}
+
+	return &Service{cfg: cfg}
+}
+
+// AddFlusher registers a component to flush after in-flight requests finished,
+// if it implements the Flusher interface.
+func (s *Service) AddFlusher(target any) {
+	flusher, ok := target.(Flusher)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.flushers = append(s.flushers, flusher)
+}
+
+// GetServerOptions returns gRPC server options that track requests and reject them while draining.
+// The interceptors should run first, so that rejected requests are not processed any further.
+func (s *Service) GetServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if isExempt(info.FullMethod) {
+				return handler(ctx, req)
+			}
+
+			if err := s.acquire(info.FullMethod); err != nil {
+				return nil, err
+			}
+			defer s.inflight.Done()
+
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if isExempt(info.FullMethod) {
+				return handler(srv, ss)
+			}
+
+			if slices.Contains(untrackedMethods, info.FullMethod) {
+				if s.IsDraining() {
+					return drainingError()
+				}
+
+				return handler(srv, ss)
+			}
+
+			if err := s.acquire(info.FullMethod); err != nil {
+				return err
+			}
+			defer s.inflight.Done()
+
+			return handler(srv, ss)
+		}),
+	}
+}
+
+// IsDraining reports whether the server is draining.
+func (s *Service) IsDraining() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.draining
+}
+
+// SetDraining enables or disables drain mode without waiting for in-flight requests.
+func (s *Service) SetDraining(draining bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.draining != draining {
+		logger.Info("Drain mode changed", "draining", draining, "inflight", s.inflight.Count())
+	}
+
+	s.draining = draining
+}
+
+// CheckHealth reports the server as unhealthy while draining,
+// so that load balancers stop routing requests to it.
+func (s *Service) CheckHealth(context.Context) error {
+	if s.IsDraining() {
+		return ErrDraining
+	}
+
+	return nil
+}
+
+// Drain enables drain mode, waits for in-flight requests to finish and flushes
+// the registered components. It gives up after the configured grace period,
+// returning an error if requests or pending operations were still in flight.
+func (s *Service) Drain(ctx context.Context) error {
+	s.SetDraining(true)
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.GracePeriod)
+	defer cancel()
+
+	start := time.Now()
+
+	if err := s.inflight.Wait(ctx); err != nil {
+		return fmt.Errorf("%d requests still in flight after %s: %w", s.inflight.Count(), s.cfg.GracePeriod, err)
+	}
+
+	s.mu.RLock()
+	flushers := append([]Flusher(nil), s.flushers...)
+	s.mu.RUnlock()
+
+	for _, flusher := range flushers {
+		if err := flusher.Flush(ctx); err != nil {
+			return fmt.Errorf("failed to flush pending operations: %w", err)
+		}
+	}
+
+	logger.Info("Server drained", "duration", time.Since(start))
+
+	return nil
+}
+
+// acquire tracks a new request, or rejects it while draining.
+func (s *Service) acquire(method string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.draining {
+		logger.Debug("Rejecting request while draining", "method", method)
+
+		return drainingError()
+	}
+
+	s.inflight.Start()
+
+	return nil
+}
+
+// drainingError returns an Unavailable error with the draining ErrorInfo detail.
+func drainingError() error {
+	st := status.New(codes.Unavailable, "server is draining, retry on another replica")
+
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: ErrorReason,
+		Domain: ErrorDomain,
+	})
+	if err != nil {
+		return st.Err() //nolint:wrapcheck
+	}
+
+	return withDetails.Err() //nolint:wrapcheck
+}
+
+// IsDrainingError reports whether the error was returned because the server was draining.
+func IsDrainingError(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Unavailable {
+		return false
+	}
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetReason() == ErrorReason && info.GetDomain() == ErrorDomain {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isExempt(method string) bool {
+	for _, prefix := range exemptMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,175 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package drain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/drain/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// slowStore is a store service whose pushes block until released.
+type slowStore struct {
+	storev1.UnimplementedStoreServiceServer
+
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *slowStore) Push(stream storev1.StoreService_PushServer) error {
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err //nolint:wrapcheck
+		}
+
+		s.started <- struct{}{}
+		<-s.release
+
+		if err := stream.Send(&corev1.RecordRef{Cid: "cid"}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+// fakeFlusher records whether it was flushed.
+type fakeFlusher struct {
+	flushed bool
+}
+
+func (f *fakeFlusher) Flush(context.Context) error {
+	f.flushed = true
+
+	return nil
+}
+
+func newTestConn(t *testing.T, service *Service, store *slowStore) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024) //nolint:mnd
+
+	server := grpc.NewServer(service.GetServerOptions()...)
+	storev1.RegisterStoreServiceServer(server, store)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func TestDrain(t *testing.T) {
+	service := New(config.Config{GracePeriod: 10 * time.Second})
+	flusher := &fakeFlusher{}
+	service.AddFlusher(flusher)
+
+	store := &slowStore{started: make(chan struct{}), release: make(chan struct{})}
+	conn := newTestConn(t, service, store)
+	client := storev1.NewStoreServiceClient(conn)
+
+	// Start a slow push before draining
+	inflight, err := client.Push(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, inflight.Send(&corev1.Record{}))
+	<-store.started
+
+	drained := make(chan error, 1)
+
+	go func() { drained <- service.Drain(t.Context()) }()
+
+	require.Eventually(t, service.IsDraining, time.Second, 10*time.Millisecond)
+	require.ErrorIs(t, service.CheckHealth(t.Context()), ErrDraining)
+
+	// New streams are rejected
+	rejected, err := client.Push(t.Context())
+	require.NoError(t, err)
+
+	_, err = rejected.Recv()
+	require.Error(t, err)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.True(t, IsDrainingError(err))
+
+	// Health checks are still served
+	_, err = healthpb.NewHealthClient(conn).Check(t.Context(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	// The in-flight push completes
+	select {
+	case err := <-drained:
+		t.Fatalf("drain returned before in-flight request finished: %v", err)
+	default:
+	}
+
+	close(store.release)
+
+	ref, err := inflight.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "cid", ref.GetCid())
+	require.NoError(t, inflight.CloseSend())
+
+	_, err = inflight.Recv()
+	require.ErrorIs(t, err, io.EOF)
+
+	require.NoError(t, <-drained)
+	assert.True(t, flusher.flushed)
+}
+
+func TestDrainGracePeriod(t *testing.T) {
+	service := New(config.Config{GracePeriod: 100 * time.Millisecond})
+
+	store := &slowStore{started: make(chan struct{}), release: make(chan struct{})}
+	client := storev1.NewStoreServiceClient(newTestConn(t, service, store))
+
+	defer close(store.release)
+
+	inflight, err := client.Push(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, inflight.Send(&corev1.Record{}))
+	<-store.started
+
+	err = service.Drain(t.Context())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSetDraining(t *testing.T) {
+	service := New(config.Config{})
+
+	service.SetDraining(true)
+	require.ErrorIs(t, service.CheckHealth(t.Context()), ErrDraining)
+
+	// Draining can be toggled off again
+	service.SetDraining(false)
+	require.NoError(t, service.CheckHealth(t.Context()))
+	assert.False(t, IsDrainingError(status.Error(codes.Unavailable, "unavailable")))
+}
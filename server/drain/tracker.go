@@ -0,0 +1,72 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package drain
+
+import (
+	"context"
+	"sync"
+)
+
+// Tracker counts in-flight operations and waits for them to finish.
+// The zero value is ready to use.
+type Tracker struct {
+	mu    sync.Mutex
+	count int
+	idle  chan struct{}
+}
+
+// Start records the start of an operation. Every call must be matched by a call to Done.
+func (t *Tracker) Start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.count++
+}
+
+// Done records the end of an operation.
+func (t *Tracker) Done() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.count--
+
+	if t.count == 0 && t.idle != nil {
+		close(t.idle)
+		t.idle = nil
+	}
+}
+
+// Count returns the number of in-flight operations.
+func (t *Tracker) Count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.count
+}
+
+// Wait blocks until no operations are in flight or the context is done.
+func (t *Tracker) Wait(ctx context.Context) error {
+	t.mu.Lock()
+
+	if t.count == 0 {
+		t.mu.Unlock()
+
+		return nil
+	}
+
+	if t.idle == nil {
+		t.idle = make(chan struct{})
+	}
+
+	idle := t.idle
+
+	t.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	}
+}
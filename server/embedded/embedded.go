@@ -0,0 +1,262 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package embedded runs a Directory server in-process, e.g. for integration tests of services built on the client.
+//
+// The server composes the same gRPC services as a deployed server, backed by an in-memory or
+// local filesystem store, a temporary search database and a routing node without peers:
+//
+//	srv, err := embedded.Start(ctx, embedded.Options{ListenBufconn: true})
+//	if err != nil {
+//		return err
+//	}
+//	defer srv.Stop()
+//
+//	ref, err := srv.Client.Push(ctx, record)
+package embedded
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/server"
+	"github.com/agntcy/dir/server/config"
+	storeconfig "github.com/agntcy/dir/server/store/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	// bufconnSize is the buffer size of in-memory connections.
+	bufconnSize = 1024 * 1024
+
+	// bufconnAddress is the client target of in-memory connections.
+	bufconnAddress = "passthrough:///bufnet"
+
+	// publicationInterval is how often pending publications are processed,
+	// short so that published records are listed without delay.
+	publicationInterval = 100 * time.Millisecond
+
+	// stopTimeout bounds how long Stop waits for in-flight requests and pending writes.
+	stopTimeout = 10 * time.Second
+)
+
+// StoreType selects the store backing the embedded server.
+type StoreType string
+
+const (
+	// Memory keeps records in memory, they are lost when the server stops.
+	Memory StoreType = "memory"
+
+	// LocalFS keeps records in an OCI layout on the local filesystem, see Options.DataDir.
+	LocalFS StoreType = "localfs"
+)
+
+// Options configure the embedded server.
+type Options struct {
+	// Store selects the store, Memory by default.
+	Store StoreType
+
+	// DataDir is the directory of the search database, the routing datastore and the LocalFS store.
+	// A temporary directory removed by Stop is used by default.
+	DataDir string
+
+	// AuthzDisabled disables authentication and authorization, even if enabled by Configure.
+	// Both are disabled by default, enabling them requires a SPIFFE workload API.
+	AuthzDisabled bool
+
+	// ListenBufconn serves the API on an in-memory connection instead of a loopback TCP port.
+	// Use Server.DialOptions to connect other clients to it.
+	ListenBufconn bool
+
+	// Configure optionally adjusts the server configuration before the server is created.
+	Configure func(*config.Config)
+
+	// ClientOptions are additional options of the pre-wired client.
+	ClientOptions []client.Option
+}
+
+// Server is a running embedded server.
+type Server struct {
+	// Address is the loopback address the server listens on, empty if it listens on an in-memory connection.
+	Address string
+
+	// Client is connected to the server and closed by Stop.
+	Client *client.Client
+
+	server   *server.Server
+	listener net.Listener
+	bufconn  *bufconn.Listener
+	cancel   context.CancelFunc
+	tempDir  string
+}
+
+// Start creates and starts an embedded server. The server runs until Stop is called.
+func Start(ctx context.Context, opts Options) (*Server, error) {
+	srv := &Server{}
+
+	dataDir := opts.DataDir
+	if dataDir == "" {
+		tempDir, err := os.MkdirTemp("", "dir-embedded-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create data directory: %w", err)
+		}
+
+		srv.tempDir = tempDir
+		dataDir = tempDir
+	}
+
+	cfg, err := newConfig(opts, dataDir)
+	if err != nil {
+		srv.removeTempDir()
+
+		return nil, err
+	}
+
+	// Services run until the server is stopped, not until the start context is done
+	ctx, srv.cancel = context.WithCancel(context.WithoutCancel(ctx))
+
+	srv.server, err = server.New(ctx, cfg)
+	if err != nil {
+		srv.cancel()
+		srv.removeTempDir()
+
+		return nil, fmt.Errorf("failed to create server: %w", err)
+	}
+
+	if opts.ListenBufconn {
+		srv.bufconn = bufconn.Listen(bufconnSize)
+		srv.listener = srv.bufconn
+	} else {
+		srv.listener, err = net.Listen("tcp", cfg.ListenAddress) //nolint:noctx
+		if err != nil {
+			srv.close()
+
+			return nil, fmt.Errorf("failed to listen on %s: %w", cfg.ListenAddress, err)
+		}
+
+		srv.Address = srv.listener.Addr().String()
+	}
+
+	if err := srv.server.Start(ctx, srv.listener); err != nil {
+		srv.close()
+
+		return nil, fmt.Errorf("failed to start server: %w", err)
+	}
+
+	srv.Client, err = client.New(append(srv.clientOptions(), opts.ClientOptions...)...)
+	if err != nil {
+		srv.close()
+
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return srv, nil
+}
+
+// DialOptions returns the options to connect a gRPC client to the server at Target.
+func (s *Server) DialOptions() []grpc.DialOption {
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+
+	if s.bufconn != nil {
+		opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.bufconn.DialContext(ctx)
+		}))
+	}
+
+	return opts
+}
+
+// Target returns the gRPC target of the server.
+func (s *Server) Target() string {
+	if s.bufconn != nil {
+		return bufconnAddress
+	}
+
+	return s.Address
+}
+
+// Stop closes the client, waits for in-flight requests and pending store writes to complete,
+// stops the server and removes its temporary data directory.
+func (s *Server) Stop() {
+	if s.Client != nil {
+		_ = s.Client.Close()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+
+	s.server.Drain(ctx)
+	s.close()
+}
+
+// clientOptions returns the options of a client connected to the server.
+func (s *Server) clientOptions() []client.Option {
+	return []client.Option{
+		client.WithConfig(&client.Config{ServerAddress: s.Target()}),
+		client.WithDialOptions(s.DialOptions()...),
+	}
+}
+
+// close stops the server and releases its resources.
+func (s *Server) close() {
+	if s.server != nil {
+		s.server.Close()
+	}
+
+	if s.listener != nil {
+		_ = s.listener.Close()
+	}
+
+	s.cancel()
+	s.removeTempDir()
+}
+
+func (s *Server) removeTempDir() {
+	if s.tempDir != "" {
+		_ = os.RemoveAll(s.tempDir)
+	}
+}
+
+// newConfig returns the configuration of an embedded server storing its data in dataDir.
+func newConfig(opts Options, dataDir string) (*config.Config, error) {
+	cfg := config.Default()
+
+	cfg.ListenAddress = "127.0.0.1:0"
+	cfg.Database.SQLite.DBPath = filepath.Join(dataDir, "dir.db")
+	cfg.Publication.SchedulerInterval = publicationInterval
+
+	// Route within the process only
+	cfg.Routing.ListenAddress = "/ip4/127.0.0.1/tcp/0"
+	cfg.Routing.BootstrapPeers = nil
+	cfg.Routing.DatastoreDir = filepath.Join(dataDir, "routing")
+	cfg.Routing.GossipSub.Enabled = false
+
+	switch opts.Store {
+	case Memory, "":
+		cfg.Store.Provider = storeconfig.ProviderMemory
+	case LocalFS:
+		cfg.Store.Provider = storeconfig.ProviderOCI
+		cfg.Store.OCI.LocalDir = filepath.Join(dataDir, "store")
+		cfg.Store.OCI.RegistryAddress = ""
+	default:
+		return nil, fmt.Errorf("unsupported store %q: expected %q or %q", opts.Store, Memory, LocalFS)
+	}
+
+	if opts.Configure != nil {
+		opts.Configure(cfg)
+	}
+
+	if opts.AuthzDisabled {
+		cfg.Authn.Enabled = false
+		cfg.Authz.Enabled = false
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,118 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package embedded
+
+import (
+	"fmt"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRecord returns a 0.7.0 record passing the schema validation of the server.
+func testRecord(name, version string) *typesv1alpha1.Record {
+	return &typesv1alpha1.Record{
+		Name:          name,
+		Version:       version,
+		SchemaVersion: "0.7.0",
+		Description:   "An agent served by an embedded server",
+		Authors:       []string{"AGNTCY Contributors"},
+		CreatedAt:     "2025-01-01T00:00:00Z",
+		Skills: []*typesv1alpha1.Skill{
+			{Name: "natural_language_processing/natural_language_generation/text_completion", Id: 10201},
+		},
+		Locators: []*typesv1alpha1.Locator{
+			{Type: "docker_image", Url: "https://ghcr.io/agntcy/" + name},
+		},
+	}
+}
+
+func TestStart(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "memory store over bufconn", opts: Options{Store: Memory, ListenBufconn: true, AuthzDisabled: true}},
+		{name: "local filesystem store over TCP", opts: Options{Store: LocalFS, DataDir: t.TempDir(), AuthzDisabled: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, err := Start(t.Context(), tt.opts)
+			require.NoError(t, err)
+
+			defer srv.Stop()
+
+			if tt.opts.ListenBufconn {
+				assert.Empty(t, srv.Address)
+			} else {
+				assert.NotEmpty(t, srv.Address)
+			}
+
+			ref, err := srv.Client.Push(t.Context(), corev1.New(testRecord("embedded-agent", "v1.0.0")))
+			require.NoError(t, err)
+
+			record, err := srv.Client.Pull(t.Context(), ref)
+			require.NoError(t, err)
+			assert.Equal(t, ref.GetCid(), record.GetCid())
+
+			// Other clients connect with the dial options of the server
+			other, err := client.New(
+				client.WithConfig(&client.Config{ServerAddress: srv.Target()}),
+				client.WithDialOptions(srv.DialOptions()...),
+			)
+			require.NoError(t, err)
+
+			defer other.Close()
+
+			meta, err := other.Lookup(t.Context(), ref)
+			require.NoError(t, err)
+			assert.Equal(t, ref.GetCid(), meta.GetCid())
+
+			require.NoError(t, other.Delete(t.Context(), ref))
+
+			_, err = srv.Client.Pull(t.Context(), ref)
+			assert.Error(t, err, "deleted records must not be pulled")
+		})
+	}
+}
+
+func TestStartUnsupportedStore(t *testing.T) {
+	_, err := Start(t.Context(), Options{Store: "s3"})
+	assert.ErrorContains(t, err, "unsupported store")
+}
+
+// BenchmarkPullBatchParallel measures how pulling many small records scales with the number of pull streams.
+func BenchmarkPullBatchParallel(b *testing.B) {
+	const count = 1000
+
+	srv, err := Start(b.Context(), Options{Store: Memory, ListenBufconn: true, AuthzDisabled: true})
+	require.NoError(b, err)
+
+	defer srv.Stop()
+
+	records := make([]*corev1.Record, count)
+	for i := range records {
+		records[i] = corev1.New(testRecord(fmt.Sprintf("benchmark-agent-%d", i), "v1.0.0"))
+	}
+
+	refs, err := srv.Client.PushBatch(b.Context(), records)
+	require.NoError(b, err)
+
+	for _, parallelism := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("streams=%d", parallelism), func(b *testing.B) {
+			for b.Loop() {
+				if _, err := srv.Client.PullBatchParallel(b.Context(), refs, parallelism); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ReportMetric(float64(count*b.N)/b.Elapsed().Seconds(), "records/s")
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package embedded
+
+import (
+	"bytes"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/server/store/oci"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryption(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "memory store", opts: Options{Store: Memory, ListenBufconn: true, AuthzDisabled: true}},
+		{name: "local filesystem store", opts: Options{Store: LocalFS, DataDir: t.TempDir(), ListenBufconn: true, AuthzDisabled: true}},
+	}
+
+	keys, err := client.NewLocalKeyProviderFromKey(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.ClientOptions = []client.Option{client.WithEncryption(keys)}
+
+			srv, err := Start(t.Context(), tt.opts)
+			require.NoError(t, err)
+
+			defer srv.Stop()
+
+			data := testRecord("secret-agent", "v1.0.0")
+			data.Description = "An agent with confidential content"
+			data.Annotations = map[string]string{"team": "research"}
+
+			record := corev1.New(data)
+
+			ref, err := srv.Client.Push(t.Context(), record)
+			require.NoError(t, err)
+			assert.Equal(t, record.GetCid(), ref.GetCid(), "encryption must not change the CID")
+
+			// The authorized client decrypts transparently
+			pulled, err := srv.Client.Pull(t.Context(), ref)
+			require.NoError(t, err)
+			assert.False(t, pulled.IsEncrypted())
+			assert.Equal(t, "An agent with confidential content", pulled.GetData().GetFields()["description"].GetStringValue())
+
+			// The metadata extracted by the client is available to lookups and searches
+			meta, err := srv.Client.Lookup(t.Context(), ref)
+			require.NoError(t, err)
+			assert.Equal(t, "secret-agent", meta.GetAnnotations()[oci.MetadataKeyName])
+			assert.Equal(t, "0.7.0", meta.GetSchemaVersion())
+			assert.Equal(t, "2025-01-01T00:00:00Z", meta.GetCreatedAt())
+			assert.Equal(t, "research", meta.GetAnnotations()["team"])
+			assert.Equal(t, corev1.EncryptionAlgorithmAES256GCM, meta.GetAnnotations()[oci.MetadataKeyEncryption])
+			assert.Equal(t, keys.KeyID(), meta.GetAnnotations()[oci.MetadataKeyEncryptionKeyID])
+
+			resolved, err := srv.Client.Pull(t.Context(), &corev1.RecordRef{Cid: "secret-agent@v1.0.0"})
+			require.NoError(t, err)
+			assert.Equal(t, ref.GetCid(), resolved.GetCid())
+
+			// Clients without the key get the ciphertext with the encrypted marker
+			other, err := client.New(
+				client.WithConfig(&client.Config{ServerAddress: srv.Target()}),
+				client.WithDialOptions(srv.DialOptions()...),
+			)
+			require.NoError(t, err)
+
+			defer other.Close()
+
+			encrypted, err := other.Pull(t.Context(), ref)
+			require.NoError(t, err)
+			assert.True(t, encrypted.GetEncrypted())
+			assert.Nil(t, encrypted.GetData())
+			assert.Equal(t, ref.GetCid(), encrypted.GetCid())
+			assert.NotContains(t, string(encrypted.GetEnvelope().GetCiphertext()), "confidential")
+		})
+	}
+}
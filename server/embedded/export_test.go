@@ -0,0 +1,131 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package embedded
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/server/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportIncremental(t *testing.T) {
+	dataDir := t.TempDir()
+
+	source, err := Start(t.Context(), Options{
+		Store:         Memory,
+		ListenBufconn: true,
+		AuthzDisabled: true,
+		Configure: func(cfg *config.Config) {
+			cfg.Journal.Enabled = true
+			cfg.Journal.Path = filepath.Join(dataDir, "journal")
+		},
+	})
+	require.NoError(t, err)
+
+	defer source.Stop()
+
+	destination, err := Start(t.Context(), Options{Store: Memory, ListenBufconn: true, AuthzDisabled: true})
+	require.NoError(t, err)
+
+	defer destination.Stop()
+
+	var records []*corev1.Record
+
+	push := func(t *testing.T) *corev1.Record {
+		t.Helper()
+
+		record := corev1.New(testRecord("exported-agent", fmt.Sprintf("v1.0.%d", len(records))))
+		records = append(records, record)
+
+		_, err := source.Client.Push(t.Context(), record)
+		require.NoError(t, err)
+
+		return record
+	}
+
+	remove := func(t *testing.T, record *corev1.Record) {
+		t.Helper()
+
+		require.NoError(t, source.Client.Delete(t.Context(), &corev1.RecordRef{Cid: record.GetCid()}))
+	}
+
+	// transfer exports the changes since the checkpoint and imports them into the destination
+	transfer := func(t *testing.T, since uint64) *client.ExportSummary {
+		t.Helper()
+
+		var buf bytes.Buffer
+
+		exported, err := source.Client.Export(t.Context(), &buf, client.ExportOptions{Since: since})
+		require.NoError(t, err)
+
+		manifest, err := client.ReadExportManifest(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, exported.Manifest.ToSequence, manifest.ToSequence)
+
+		imported, err := destination.Client.Import(t.Context(), &buf, client.ImportOptions{Since: since, ApplyDeletes: true})
+		require.NoError(t, err)
+		assert.Equal(t, exported.Records, imported.Records)
+		assert.Equal(t, exported.Deleted, imported.Deleted)
+
+		return exported
+	}
+
+	// assertEqual checks that the destination holds exactly the records of the source
+	assertEqual := func(t *testing.T) {
+		t.Helper()
+
+		for _, record := range records {
+			ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+			_, sourceErr := source.Client.Lookup(t.Context(), ref)
+			_, destinationErr := destination.Client.Lookup(t.Context(), ref)
+			assert.Equal(t, sourceErr == nil, destinationErr == nil, "record %s", record.GetCid())
+		}
+	}
+
+	first, second := push(t), push(t)
+	push(t)
+
+	full := transfer(t, 0)
+	assert.True(t, full.Manifest.Full)
+	assert.Equal(t, 3, full.Records)
+	assertEqual(t)
+
+	// The first incremental export only carries the changes since the full export
+	push(t)
+	remove(t, first)
+
+	incremental := transfer(t, full.Manifest.ToSequence)
+	assert.False(t, incremental.Manifest.Full)
+	assert.Equal(t, full.Manifest.ToSequence, incremental.Manifest.FromSequence)
+	assert.Equal(t, 1, incremental.Records)
+	assert.Equal(t, 1, incremental.Deleted)
+	assertEqual(t)
+
+	// Records pushed and deleted between exports are only exported as deleted
+	transient := push(t)
+	remove(t, transient)
+	remove(t, second)
+
+	last := transfer(t, incremental.Manifest.ToSequence)
+	assert.Equal(t, 0, last.Records)
+	assert.Equal(t, 2, last.Deleted)
+	assertEqual(t)
+
+	// Exports that do not continue the checkpoint are rejected before they are applied
+	var buf bytes.Buffer
+
+	_, err = source.Client.Export(t.Context(), &buf, client.ExportOptions{Since: last.Manifest.ToSequence})
+	require.NoError(t, err)
+
+	_, err = destination.Client.Import(t.Context(), &buf, client.ImportOptions{Since: last.Manifest.ToSequence + 1})
+	require.ErrorIs(t, err, client.ErrExportGap)
+}
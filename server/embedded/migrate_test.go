@@ -0,0 +1,135 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package embedded
+
+import (
+	"fmt"
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	srv, err := Start(t.Context(), Options{Store: Memory, ListenBufconn: true, AuthzDisabled: true})
+	require.NoError(t, err)
+
+	defer srv.Stop()
+
+	c := srv.Client
+
+	// A mixed corpus of v0.3.1 agent records and a 0.7.0 record
+	records := make([]*corev1.Record, 0, 4)
+
+	for i, team := range []string{"platform", "platform", "research"} {
+		records = append(records, corev1.New(&typesv1alpha0.Record{
+			Name:          fmt.Sprintf("legacy-agent-%d", i),
+			Version:       "v1.0.0",
+			SchemaVersion: "v0.3.1",
+			Description:   "A legacy agent",
+			Authors:       []string{"AGNTCY Contributors"},
+			CreatedAt:     "2025-01-01T00:00:00Z",
+			Annotations:   map[string]string{"team": team},
+			Skills:        []*typesv1alpha0.Skill{{CategoryUid: 1, ClassUid: 10201}}, //nolint:mnd
+			Locators: []*typesv1alpha0.Locator{
+				{Type: "docker-image", Url: fmt.Sprintf("https://ghcr.io/agntcy/legacy-agent-%d", i)},
+			},
+			Signature: &typesv1alpha0.Signature{
+				Algorithm:     "ES256",
+				Signature:     "sig",
+				Certificate:   "cert",
+				ContentType:   "application/json",
+				ContentBundle: "e30=",
+				SignedAt:      "2025-01-01T00:00:00Z",
+			},
+		}))
+	}
+
+	currentRecord := testRecord("current-agent", "v1.0.0")
+	currentRecord.Annotations = map[string]string{"team": "platform"}
+
+	current := corev1.New(currentRecord)
+	records = append(records, current)
+
+	_, err = c.PushBatch(t.Context(), records)
+	require.NoError(t, err)
+
+	migrate := func(t *testing.T, opts client.MigrateOptions) map[string]client.MigrateResult {
+		t.Helper()
+
+		opts.From, opts.To, opts.AllowLossy = "v1", "v3", true
+
+		ch, err := c.Migrate(t.Context(), opts)
+		require.NoError(t, err)
+
+		results := map[string]client.MigrateResult{}
+		for result := range ch {
+			require.NoError(t, result.Error)
+			results[result.SourceCID] = result
+		}
+
+		return results
+	}
+
+	// A partial run only migrates the filtered records
+	partial := migrate(t, client.MigrateOptions{
+		Filter:    client.SearchFilter{Annotations: map[string]string{"team": "research"}},
+		RetireOld: true,
+	})
+	require.Len(t, partial, 1)
+	require.Contains(t, partial, records[2].GetCid())
+	assert.False(t, partial[records[2].GetCid()].AlreadyMigrated)
+
+	// A dry run reports the remaining records without migrating them
+	dryRun := migrate(t, client.MigrateOptions{DryRun: true})
+	require.Len(t, dryRun, 3, "records of other versions are not migrated")
+
+	for _, record := range records[:2] {
+		result := dryRun[record.GetCid()]
+		assert.False(t, result.AlreadyMigrated)
+
+		_, err := c.Lookup(t.Context(), &corev1.RecordRef{Cid: result.TargetCID})
+		require.Error(t, err, "dry runs must not push records")
+	}
+
+	// The full run resumes the partial run
+	full := migrate(t, client.MigrateOptions{RetireOld: true})
+	require.Len(t, full, 3)
+	assert.True(t, full[records[2].GetCid()].AlreadyMigrated)
+	assert.Equal(t, partial[records[2].GetCid()].TargetCID, full[records[2].GetCid()].TargetCID)
+
+	for _, record := range records[:3] {
+		result := full[record.GetCid()]
+
+		// The migrated record links to the original record
+		migrated, err := c.Pull(t.Context(), &corev1.RecordRef{Cid: result.TargetCID})
+		require.NoError(t, err)
+		assert.True(t, migrated.HasSchemaVersion("0.7.0"))
+		assert.Equal(t, record.GetCid(), migrated.GetMigratedFrom())
+
+		// The original record links to the migrated record, once
+		targets, err := c.MigratedTo(t.Context(), &corev1.RecordRef{Cid: record.GetCid()})
+		require.NoError(t, err)
+		assert.Equal(t, []string{result.TargetCID}, targets)
+
+		meta, err := c.Lookup(t.Context(), &corev1.RecordRef{Cid: record.GetCid()})
+		require.NoError(t, err)
+		assert.Equal(t, corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED, meta.GetLifecycle().GetStatus())
+		assert.Equal(t, result.TargetCID, meta.GetLifecycle().GetSuccessorCid())
+	}
+
+	// Records of other versions are left alone
+	targets, err := c.MigratedTo(t.Context(), &corev1.RecordRef{Cid: current.GetCid()})
+	require.NoError(t, err)
+	assert.Empty(t, targets)
+
+	// Running the migration again changes nothing
+	for cid, result := range migrate(t, client.MigrateOptions{RetireOld: true}) {
+		assert.True(t, result.AlreadyMigrated, cid)
+		assert.Equal(t, full[cid].TargetCID, result.TargetCID)
+	}
+}
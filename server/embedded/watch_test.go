@@ -0,0 +1,108 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package embedded
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/server/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchStore(t *testing.T) {
+	dataDir := t.TempDir()
+
+	opts := Options{
+		Store:         LocalFS,
+		DataDir:       dataDir,
+		ListenBufconn: true,
+		AuthzDisabled: true,
+		Configure: func(cfg *config.Config) {
+			cfg.Journal.Enabled = true
+			cfg.Journal.Path = filepath.Join(dataDir, "journal")
+		},
+	}
+
+	newRecord := func(version string) *corev1.Record {
+		record := testRecord("watched-agent", version)
+		record.Description = "An agent mirrored by an external indexer"
+
+		return corev1.New(record)
+	}
+
+	// next receives the next event, failing the test if none arrives in time
+	next := func(t *testing.T, eventCh <-chan client.StoreEvent) client.StoreEvent {
+		t.Helper()
+
+		select {
+		case event, ok := <-eventCh:
+			require.True(t, ok, "watch ended")
+			require.NoError(t, event.Error)
+
+			return event
+		case <-time.After(10 * time.Second):
+			t.Fatal("no event received")
+
+			return client.StoreEvent{}
+		}
+	}
+
+	srv, err := Start(t.Context(), opts)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	eventCh, err := srv.Client.WatchStore(ctx, 0)
+	require.NoError(t, err)
+
+	first, second := newRecord("v1.0.0"), newRecord("v2.0.0")
+
+	for _, record := range []*corev1.Record{first, second} {
+		_, err := srv.Client.Push(t.Context(), record)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, srv.Client.Delete(t.Context(), &corev1.RecordRef{Cid: first.GetCid()}))
+
+	pushed := next(t, eventCh)
+	assert.Equal(t, storev1.StoreEventType_STORE_EVENT_TYPE_PUSHED, pushed.GetType())
+	assert.Equal(t, first.GetCid(), pushed.GetCid())
+	assert.Equal(t, "0.7.0", pushed.GetMeta().GetSchemaVersion())
+
+	assert.Equal(t, second.GetCid(), next(t, eventCh).GetCid())
+
+	deleted := next(t, eventCh)
+	assert.Equal(t, storev1.StoreEventType_STORE_EVENT_TYPE_DELETED, deleted.GetType())
+	assert.Equal(t, first.GetCid(), deleted.GetCid())
+	assert.Equal(t, "v1.0.0", deleted.GetMeta().GetAnnotations()["version"])
+
+	cancel()
+	srv.Stop()
+
+	// The watcher resumes after a server restart without losing or repeating events
+	srv, err = Start(t.Context(), opts)
+	require.NoError(t, err)
+
+	defer srv.Stop()
+
+	third := newRecord("v3.0.0")
+
+	_, err = srv.Client.Push(t.Context(), third)
+	require.NoError(t, err)
+
+	eventCh, err = srv.Client.WatchStore(t.Context(), deleted.GetSequence())
+	require.NoError(t, err)
+
+	resumed := next(t, eventCh)
+	assert.Equal(t, deleted.GetSequence()+1, resumed.GetSequence())
+	assert.Equal(t, third.GetCid(), resumed.GetCid())
+}
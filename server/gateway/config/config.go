@@ -0,0 +1,62 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+// Token maps a bearer token to the trust domain its holder is authorized as.
+type Token struct {
+	// Bearer token presented in the Authorization header
+	Token string `json:"token,omitempty" mapstructure:"token"`
+
+	// Trust domain of the token holder, e.g. "example.org"
+	TrustDomain string `json:"trust_domain,omitempty" mapstructure:"trust_domain"`
+}
+
+// Config contains configuration for the HTTP/JSON gateway to the store API.
+type Config struct {
+	// Address the gateway listens on, e.g. "0.0.0.0:8080".
+	// The gateway is disabled if empty.
+	ListenAddress string `json:"listen_address,omitempty" mapstructure:"listen_address"`
+
+	// Bearer tokens accepted by the gateway.
+	// Requests without a token are unauthenticated.
+	Tokens []Token `json:"tokens,omitempty" mapstructure:"tokens"`
+}
+
+// Enabled reports whether the gateway is enabled.
+func (c *Config) Enabled() bool {
+	return c.ListenAddress != ""
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(c.Tokens))
+
+	for i, token := range c.Tokens {
+		if token.Token == "" {
+			return fmt.Errorf("token %d: token is required", i)
+		}
+
+		if _, err := spiffeid.TrustDomainFromString(token.TrustDomain); err != nil {
+			return fmt.Errorf("token %d: invalid trust domain: %w", i, err)
+		}
+
+		if _, ok := seen[token.Token]; ok {
+			return errors.New("tokens must be unique")
+		}
+
+		seen[token.Token] = struct{}{}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package gateway serves the store API over HTTP/JSON for clients that cannot use gRPC.
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/gateway/config"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	// bufSize is the buffer size of the in-process connection to the store service.
+	bufSize = 1024 * 1024
+
+	// readHeaderTimeout limits the time to read request headers.
+	readHeaderTimeout = 10 * time.Second
+
+	// shutdownTimeout limits the time to wait for in-flight requests on stop.
+	shutdownTimeout = 10 * time.Second
+)
+
+var logger = logging.Logger("gateway")
+
+// Service serves the HTTP/JSON gateway.
+// Requests are forwarded to a store service served in-process, so that the
+// authorization, rate limiting and quota checks of the gRPC API apply to them.
+type Service struct {
+	cfg config.Config
+
+	listener   *bufconn.Listener
+	grpcServer *grpc.Server
+	conn       *grpc.ClientConn
+	httpServer *http.Server
+}
+
+// New creates a gateway for the store service.
+// The server options are applied to the in-process gRPC server in addition to
+// the interceptor that authenticates gateway callers, and must not include transport credentials.
+func New(cfg config.Config, store storev1.StoreServiceServer, opts ...grpc.ServerOption) (*Service, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid gateway config: %w", err)
+	}
+
+	// Authenticate gateway callers before any other interceptor
+	serverOpts := append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(identityUnaryInterceptor),
+		grpc.ChainStreamInterceptor(identityStreamInterceptor),
+	}, opts...)
+
+	grpcServer := grpc.NewServer(serverOpts...)
+	storev1.RegisterStoreServiceServer(grpcServer, store)
+
+	listener := bufconn.Listen(bufSize)
+
+	conn, err := grpc.NewClient("passthrough:///gateway",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to store service: %w", err)
+	}
+
+	logger.Info("HTTP gateway initialized", "address", cfg.ListenAddress, "tokens", len(cfg.Tokens))
+
+	return &Service{
+		cfg:        cfg,
+		listener:   listener,
+		grpcServer: grpcServer,
+		conn:       conn,
+		httpServer: &http.Server{
+			Addr:              cfg.ListenAddress,
+			Handler:           NewHandler(storev1.NewStoreServiceClient(conn), cfg.Tokens),
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}, nil
+}
+
+// Start starts serving the gateway in the background.
+func (s *Service) Start(ctx context.Context) error {
+	listen, err := (&net.ListenConfig{}).Listen(ctx, "tcp", s.cfg.ListenAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.cfg.ListenAddress, err)
+	}
+
+	go func() {
+		_ = s.grpcServer.Serve(s.listener)
+	}()
+
+	go func() {
+		logger.Info("HTTP gateway starting", "address", s.cfg.ListenAddress)
+
+		if err := s.httpServer.Serve(listen); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Failed to serve HTTP gateway", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the gateway, waiting for in-flight requests to complete.
+func (s *Service) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err := s.httpServer.Shutdown(ctx)
+
+	_ = s.conn.Close()
+
+	s.grpcServer.GracefulStop()
+
+	if err != nil {
+		return fmt.Errorf("failed to shut down HTTP gateway: %w", err)
+	}
+
+	return nil
+}
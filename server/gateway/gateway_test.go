@@ -0,0 +1,308 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/server/authz"
+	authzconfig "github.com/agntcy/dir/server/authz/config"
+	"github.com/agntcy/dir/server/gateway/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testRecordJSON = `{"name":"gateway-agent","version":"v1.0.0","schema_version":"0.7.0"}`
+
+// storeServer is an in-memory store service that records the trust domains of its callers.
+type storeServer struct {
+	storev1.UnimplementedStoreServiceServer
+
+	mu           sync.Mutex
+	records      map[string]*corev1.Record
+	trustDomains []string
+}
+
+func newStoreServer() *storeServer {
+	return &storeServer{records: make(map[string]*corev1.Record)}
+}
+
+func (s *storeServer) caller(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var trustDomain string
+	if sid, ok := authn.SpiffeIDFromContext(ctx); ok {
+		trustDomain = sid.TrustDomain().String()
+	}
+
+	s.trustDomains = append(s.trustDomains, trustDomain)
+}
+
+func (s *storeServer) get(cid string) (*corev1.Record, *corev1.RecordError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[cid]
+	if !ok {
+		return nil, &corev1.RecordError{Code: uint32(codes.NotFound), Message: "record not found: " + cid}
+	}
+
+	return record, nil
+}
+
+func (s *storeServer) Push(stream storev1.StoreService_PushServer) error {
+	s.caller(stream.Context())
+
+	for {
+		record, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		s.mu.Lock()
+		_, exists := s.records[record.GetCid()]
+		s.records[record.GetCid()] = record
+		s.mu.Unlock()
+
+		if err := stream.Send(&corev1.RecordRef{Cid: record.GetCid(), AlreadyExisted: exists}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func (s *storeServer) Pull(stream storev1.StoreService_PullServer) error {
+	s.caller(stream.Context())
+
+	ref, err := stream.Recv()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	record, recordErr := s.get(ref.GetCid())
+	if recordErr != nil {
+		record = &corev1.Record{Error: recordErr}
+	}
+
+	return stream.Send(record) //nolint:wrapcheck
+}
+
+func (s *storeServer) Lookup(stream storev1.StoreService_LookupServer) error {
+	s.caller(stream.Context())
+
+	ref, err := stream.Recv()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	meta := &corev1.RecordMeta{Cid: ref.GetCid(), SchemaVersion: "0.7.0"}
+	if _, recordErr := s.get(ref.GetCid()); recordErr != nil {
+		meta = &corev1.RecordMeta{Cid: ref.GetCid(), Error: recordErr}
+	}
+
+	return stream.Send(meta) //nolint:wrapcheck
+}
+
+func (s *storeServer) DeleteWithAck(stream storev1.StoreService_DeleteWithAckServer) error {
+	s.caller(stream.Context())
+
+	ref, err := stream.Recv()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	response := &storev1.DeleteResponse{RecordRef: ref}
+
+	if _, recordErr := s.get(ref.GetCid()); recordErr != nil {
+		response.Error = recordErr
+	} else {
+		s.mu.Lock()
+		delete(s.records, ref.GetCid())
+		s.mu.Unlock()
+	}
+
+	return stream.Send(response) //nolint:wrapcheck
+}
+
+// newTestGateway returns an HTTP test server for a gateway to the store server.
+func newTestGateway(t *testing.T, store *storeServer, tokens []config.Token, opts ...grpc.ServerOption) *httptest.Server {
+	t.Helper()
+
+	service, err := New(config.Config{ListenAddress: "127.0.0.1:0", Tokens: tokens}, store, opts...)
+	require.NoError(t, err)
+
+	go func() {
+		_ = service.grpcServer.Serve(service.listener)
+	}()
+
+	server := httptest.NewServer(service.httpServer.Handler)
+
+	t.Cleanup(func() {
+		server.Close()
+		require.NoError(t, service.Stop())
+	})
+
+	return server
+}
+
+func doRequest(t *testing.T, method, url, token, body string) (*http.Response, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), method, url, strings.NewReader(body))
+	require.NoError(t, err)
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp, string(data)
+}
+
+func TestGateway_Records(t *testing.T) {
+	server := newTestGateway(t, newStoreServer(), nil)
+
+	expected, err := corev1.LoadOASFFromReader(strings.NewReader(testRecordJSON))
+	require.NoError(t, err)
+
+	// Push returns the CID in the response body
+	resp, body := doRequest(t, http.MethodPost, server.URL+"/v1/records", "", testRecordJSON)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, body)
+
+	var ref struct {
+		Cid            string `json:"cid"`
+		AlreadyExisted bool   `json:"alreadyExisted"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &ref))
+	assert.Equal(t, expected.GetCid(), ref.Cid)
+
+	resp, body = doRequest(t, http.MethodPost, server.URL+"/v1/records", "", testRecordJSON)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, `"alreadyExisted":true`)
+
+	// Pull returns the canonical OASF document
+	resp, body = doRequest(t, http.MethodGet, server.URL+"/v1/records/"+ref.Cid, "", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	data, err := expected.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, string(data), body)
+
+	// Lookup returns the record metadata
+	resp, body = doRequest(t, http.MethodGet, server.URL+"/v1/records/"+ref.Cid+"/meta", "", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, ref.Cid)
+
+	resp, body = doRequest(t, http.MethodHead, server.URL+"/v1/records/"+ref.Cid+"/meta", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, body)
+
+	// Delete removes the record
+	resp, _ = doRequest(t, http.MethodDelete, server.URL+"/v1/records/"+ref.Cid, "", "")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		resp, body = doRequest(t, method, server.URL+"/v1/records/"+ref.Cid, "", "")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, method)
+		assert.Contains(t, body, `"code":5`)
+	}
+
+	resp, _ = doRequest(t, http.MethodHead, server.URL+"/v1/records/"+ref.Cid+"/meta", "", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestGateway_PushInvalid(t *testing.T) {
+	server := newTestGateway(t, newStoreServer(), nil)
+
+	resp, _ := doRequest(t, http.MethodPost, server.URL+"/v1/records", "", `{"name":`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	oversized := `{"name":"` + strings.Repeat("a", MaxRecordSize) + `"}`
+
+	resp, body := doRequest(t, http.MethodPost, server.URL+"/v1/records", "", oversized)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.Contains(t, body, "maximum allowed size")
+}
+
+func TestGateway_BearerToken(t *testing.T) {
+	store := newStoreServer()
+	server := newTestGateway(t, store, []config.Token{{Token: "secret", TrustDomain: "example.org"}})
+
+	resp, _ := doRequest(t, http.MethodPost, server.URL+"/v1/records", "secret", testRecordJSON)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, _ = doRequest(t, http.MethodPost, server.URL+"/v1/records", "", testRecordJSON)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, _ = doRequest(t, http.MethodPost, server.URL+"/v1/records", "invalid", testRecordJSON)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// Trust domains of token holders are passed to the store service
+	assert.Equal(t, []string{"example.org", ""}, store.trustDomains)
+}
+
+func TestGateway_Authorization(t *testing.T) {
+	authorizer, err := authz.NewAuthorizer(authzconfig.Config{Enabled: true, TrustDomain: "example.org"})
+	require.NoError(t, err)
+
+	interceptor := authz.NewInterceptor(authorizer)
+
+	server := newTestGateway(t, newStoreServer(),
+		[]config.Token{
+			{Token: "internal", TrustDomain: "example.org"},
+			{Token: "external", TrustDomain: "other.org"},
+		},
+		grpc.ChainUnaryInterceptor(authz.UnaryInterceptorFor(interceptor)),
+		grpc.ChainStreamInterceptor(authz.StreamInterceptorFor(interceptor)),
+	)
+
+	resp, _ := doRequest(t, http.MethodPost, server.URL+"/v1/records", "", testRecordJSON)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, _ = doRequest(t, http.MethodPost, server.URL+"/v1/records", "external", testRecordJSON)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp, body := doRequest(t, http.MethodPost, server.URL+"/v1/records", "internal", testRecordJSON)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, body)
+
+	// Pulls are allowed for other trust domains
+	var ref corev1.RecordRef
+	require.NoError(t, json.Unmarshal([]byte(body), &ref))
+
+	resp, _ = doRequest(t, http.MethodGet, server.URL+"/v1/records/"+ref.GetCid(), "external", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, httpStatusFromCode(codes.NotFound))
+	assert.Equal(t, http.StatusTooManyRequests, httpStatusFromCode(codes.ResourceExhausted))
+	assert.Equal(t, http.StatusForbidden, httpStatusFromCode(codes.PermissionDenied))
+	assert.Equal(t, http.StatusInternalServerError, httpStatusFromCode(status.Code(errors.New("unknown"))))
+}
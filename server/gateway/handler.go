@@ -0,0 +1,278 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/gateway/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// MaxRecordSize is the maximum size of a record pushed through the gateway.
+const MaxRecordSize = 4 * 1024 * 1024 // 4MB
+
+// handler serves the store API over HTTP/JSON by calling the store service.
+type handler struct {
+	client storev1.StoreServiceClient
+	tokens []config.Token
+}
+
+// NewHandler returns an HTTP handler for the store API backed by the store service client.
+// Bearer tokens are mapped to the trust domain passed to the store service.
+//
+// Routes:
+//   - GET /v1/records/{cid} pulls the OASF document of a record
+//   - GET, HEAD /v1/records/{cid}/meta looks up the metadata of a record
+//   - POST /v1/records pushes the OASF document in the body and returns the record reference
+//   - DELETE /v1/records/{cid} deletes a record
+func NewHandler(client storev1.StoreServiceClient, tokens []config.Token) http.Handler {
+	h := &handler{
+		client: client,
+		tokens: tokens,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/records/{cid}", h.pull)
+	mux.HandleFunc("GET /v1/records/{cid}/meta", h.lookup)
+	mux.HandleFunc("POST /v1/records", h.push)
+	mux.HandleFunc("DELETE /v1/records/{cid}", h.delete)
+
+	return h.authenticate(mux)
+}
+
+// authenticate maps the bearer token of the request to a trust domain.
+// Requests without an Authorization header are passed on unauthenticated.
+func (h *handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			writeError(w, status.Error(codes.Unauthenticated, "unsupported authorization scheme"))
+
+			return
+		}
+
+		trustDomain, ok := h.trustDomainFor(token)
+		if !ok {
+			writeError(w, status.Error(codes.Unauthenticated, "invalid bearer token"))
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(withTrustDomain(r.Context(), trustDomain)))
+	})
+}
+
+func (h *handler) trustDomainFor(token string) (string, bool) {
+	for _, t := range h.tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Token), []byte(token)) == 1 {
+			return t.TrustDomain, true
+		}
+	}
+
+	return "", false
+}
+
+func (h *handler) pull(w http.ResponseWriter, r *http.Request) {
+	stream, err := h.client.Pull(r.Context())
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	if err := stream.Send(&corev1.RecordRef{Cid: r.PathValue("cid")}); err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	_ = stream.CloseSend()
+
+	record, err := stream.Recv()
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	if record.GetError() != nil {
+		writeError(w, recordError(record.GetError()))
+
+		return
+	}
+
+	data, err := record.Marshal()
+	if err != nil {
+		writeError(w, status.Errorf(codes.Internal, "failed to marshal record: %v", err))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+func (h *handler) lookup(w http.ResponseWriter, r *http.Request) {
+	stream, err := h.client.Lookup(r.Context())
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	if err := stream.Send(&corev1.RecordRef{Cid: r.PathValue("cid")}); err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	_ = stream.CloseSend()
+
+	meta, err := stream.Recv()
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	if meta.GetError() != nil {
+		writeError(w, recordError(meta.GetError()))
+
+		return
+	}
+
+	writeMessage(w, http.StatusOK, meta)
+}
+
+func (h *handler) push(w http.ResponseWriter, r *http.Request) {
+	// Enforce the record size limit before reading the body
+	body := http.MaxBytesReader(w, r.Body, MaxRecordSize)
+
+	// Like pushes over gRPC, records are loaded as is and left to the schema validation
+	record, err := corev1.LoadOASFFromReader(body, corev1.Lenient())
+	if err != nil {
+		if maxBytesErr := (*http.MaxBytesError)(nil); errors.As(err, &maxBytesErr) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, codes.InvalidArgument,
+				fmt.Sprintf("record exceeds maximum allowed size of %d bytes", MaxRecordSize))
+
+			return
+		}
+
+		writeError(w, status.Errorf(codes.InvalidArgument, "failed to load OASF: %v", err))
+
+		return
+	}
+
+	stream, err := h.client.Push(r.Context())
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	if err := stream.Send(record); err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	_ = stream.CloseSend()
+
+	ref, err := stream.Recv()
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	statusCode := http.StatusCreated
+	if ref.GetAlreadyExisted() {
+		statusCode = http.StatusOK
+	}
+
+	writeMessage(w, statusCode, ref)
+}
+
+func (h *handler) delete(w http.ResponseWriter, r *http.Request) {
+	stream, err := h.client.DeleteWithAck(r.Context())
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	if err := stream.Send(&corev1.RecordRef{Cid: r.PathValue("cid")}); err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	_ = stream.CloseSend()
+
+	resp, err := stream.Recv()
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	if resp.GetError() != nil {
+		writeError(w, recordError(resp.GetError()))
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// recordError converts the error of a single record reference to a status error.
+func recordError(recordErr *corev1.RecordError) error {
+	return status.Error(codes.Code(recordErr.GetCode()), recordErr.GetMessage()) //nolint:gosec,wrapcheck
+}
+
+// writeMessage writes a protobuf message as JSON.
+func writeMessage(w http.ResponseWriter, statusCode int, msg proto.Message) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		writeError(w, status.Errorf(codes.Internal, "failed to marshal response: %v", err))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(data)
+}
+
+// writeError writes a gRPC status error as JSON with the matching HTTP status code.
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+
+	writeJSONError(w, httpStatusFromCode(st.Code()), st.Code(), st.Message())
+}
+
+func writeJSONError(w http.ResponseWriter, statusCode int, code codes.Code, message string) {
+	data, _ := protojson.Marshal(status.New(code, message).Proto())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(data)
+}
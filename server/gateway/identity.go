@@ -0,0 +1,88 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/utils/logging"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// trustDomainKey is the metadata key the gateway uses to pass the trust domain
+// of an authenticated HTTP caller to the in-process gRPC server.
+// It is only trusted on the in-process connection, which is not reachable from the network.
+const trustDomainKey = "x-dir-gateway-trust-domain"
+
+// gatewayIDPath is the path of the SPIFFE IDs assigned to gateway callers.
+const gatewayIDPath = "/gateway"
+
+// withTrustDomain returns a context that passes the trust domain to the in-process gRPC server.
+func withTrustDomain(ctx context.Context, trustDomain string) context.Context {
+	if trustDomain == "" {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, trustDomainKey, trustDomain)
+}
+
+// identityFromContext sets the SPIFFE ID of the gateway caller in the context,
+// so that the authorization and rate limiting interceptors apply to HTTP callers.
+func identityFromContext(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get(trustDomainKey)
+	if len(values) == 0 {
+		return ctx, nil
+	}
+
+	trustDomain, err := spiffeid.TrustDomainFromString(values[0])
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid trust domain: %v", err) //nolint:wrapcheck
+	}
+
+	id, err := spiffeid.FromPath(trustDomain, gatewayIDPath)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create SPIFFE ID: %v", err) //nolint:wrapcheck
+	}
+
+	ctx = logging.ContextWithFields(ctx, "trust_domain", trustDomain.String())
+
+	return context.WithValue(ctx, authn.SpiffeIDContextKey, id), nil
+}
+
+func identityUnaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	newCtx, err := identityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(newCtx, req)
+}
+
+func identityStreamInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	newCtx, err := identityFromContext(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &identityServerStream{ServerStream: ss, ctx: newCtx})
+}
+
+// identityServerStream wraps a grpc.ServerStream to override the context.
+//
+//nolint:containedctx // Context is required for gRPC stream wrapping
+type identityServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,160 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package healthcheck implements the standard grpc.health.v1 Health service
+// backed by per-component checks of the server dependencies.
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Names of the server components reported by the health service.
+	ComponentStore   = "store"
+	ComponentRouting = "routing"
+	ComponentAuthz   = "authz"
+	ComponentDrain   = "drain"
+
+	// ComponentSelfTest reports the last self-test of the store backend, see the selftest package.
+	ComponentSelfTest = "store-selftest"
+
+	// DefaultCheckTimeout bounds the duration of a single component check.
+	DefaultCheckTimeout = 5 * time.Second
+)
+
+var logger = logging.Logger("healthcheck")
+
+// Checker is implemented by components that can report their own health.
+type Checker interface {
+	// CheckHealth returns an error if the component is unable to serve requests.
+	CheckHealth(ctx context.Context) error
+}
+
+// CheckFunc checks the health of a single component.
+type CheckFunc func(ctx context.Context) error
+
+type component struct {
+	name  string
+	check CheckFunc
+}
+
+// Service implements the grpc.health.v1 Health service.
+// The empty service name reports the overall server health, which is SERVING
+// only if all registered components are healthy.
+type Service struct {
+	healthpb.UnimplementedHealthServer
+
+	mu         sync.RWMutex
+	components []component
+	timeout    time.Duration
+}
+
+// New creates a health service without any registered components.
+func New() *Service {
+	return &Service{
+		timeout: DefaultCheckTimeout,
+	}
+}
+
+// AddComponent registers a named component check.
+func (s *Service) AddComponent(name string, check CheckFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.components = append(s.components, component{name: name, check: check})
+}
+
+// AddChecker registers a component if it implements the Checker interface.
+// Components that cannot report their health are considered healthy and are skipped.
+func (s *Service) AddChecker(name string, target any) {
+	if checker, ok := target.(Checker); ok {
+		s.AddComponent(name, checker.CheckHealth)
+	}
+}
+
+// Register registers the health service with the gRPC server.
+func (s *Service) Register(server *grpc.Server) {
+	healthpb.RegisterHealthServer(server, s)
+}
+
+// Check returns the serving status of a component, or of the whole server for the empty service name.
+func (s *Service) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if req.GetService() == "" {
+		return &healthpb.HealthCheckResponse{Status: s.checkAll(ctx)}, nil
+	}
+
+	c, ok := s.lookup(req.GetService())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service: %s", req.GetService())
+	}
+
+	return &healthpb.HealthCheckResponse{Status: s.checkComponent(ctx, c)}, nil
+}
+
+// List returns the serving status of the server and all registered components.
+func (s *Service) List(ctx context.Context, _ *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	statuses := make(map[string]*healthpb.HealthCheckResponse)
+	overall := healthpb.HealthCheckResponse_SERVING
+
+	for _, c := range s.snapshot() {
+		componentStatus := s.checkComponent(ctx, c)
+		if componentStatus != healthpb.HealthCheckResponse_SERVING {
+			overall = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		statuses[c.name] = &healthpb.HealthCheckResponse{Status: componentStatus}
+	}
+
+	statuses[""] = &healthpb.HealthCheckResponse{Status: overall}
+
+	return &healthpb.HealthListResponse{Statuses: statuses}, nil
+}
+
+func (s *Service) checkAll(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	for _, c := range s.snapshot() {
+		if s.checkComponent(ctx, c) != healthpb.HealthCheckResponse_SERVING {
+			return healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+
+	return healthpb.HealthCheckResponse_SERVING
+}
+
+func (s *Service) checkComponent(ctx context.Context, c component) healthpb.HealthCheckResponse_ServingStatus {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := c.check(ctx); err != nil {
+		logger.Warn("Component health check failed", "component", c.name, "error", err)
+
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_SERVING
+}
+
+func (s *Service) lookup(name string) (component, bool) {
+	for _, c := range s.snapshot() {
+		if c.name == name {
+			return c, true
+		}
+	}
+
+	return component{}, false
+}
+
+func (s *Service) snapshot() []component {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]component(nil), s.components...)
+}
@@ -0,0 +1,95 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheck
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/agntcy/dir/server/store/oci"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newHealthClient(t *testing.T, service *Service) healthpb.HealthClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024) //nolint:mnd
+
+	server := grpc.NewServer()
+	service.Register(server)
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestHealthServing(t *testing.T) {
+	service := New()
+	service.AddComponent(ComponentStore, func(context.Context) error { return nil })
+	service.AddComponent(ComponentRouting, func(context.Context) error { return nil })
+
+	client := newHealthClient(t, service)
+
+	resp, err := client.Check(t.Context(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	resp, err = client.Check(t.Context(), &healthpb.HealthCheckRequest{Service: ComponentRouting})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	_, err = client.Check(t.Context(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestHealthBrokenStore(t *testing.T) {
+	// Nothing listens on the discard port
+	store, err := oci.New(ociconfig.Config{
+		RegistryAddress: "127.0.0.1:9",
+		RepositoryName:  "dir",
+		AuthConfig:      ociconfig.AuthConfig{Insecure: true},
+	})
+	require.NoError(t, err)
+
+	service := New()
+	service.AddChecker(ComponentStore, store)
+	service.AddComponent(ComponentRouting, func(context.Context) error { return nil })
+
+	client := newHealthClient(t, service)
+
+	resp, err := client.Check(t.Context(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+
+	resp, err = client.Check(t.Context(), &healthpb.HealthCheckRequest{Service: ComponentStore})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+
+	list, err := client.List(t.Context(), &healthpb.HealthListRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, list.GetStatuses()[""].GetStatus())
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, list.GetStatuses()[ComponentStore].GetStatus())
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, list.GetStatuses()[ComponentRouting].GetStatus())
+}
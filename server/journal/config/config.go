@@ -0,0 +1,67 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+)
+
+const (
+	DefaultPath        = "/var/lib/dir/journal"
+	DefaultMaxFileSize = 64 * 1024 * 1024
+	DefaultMaxFiles    = 16
+	DefaultQueueSize   = 1024
+	DefaultMaxWatchLag = 10000
+)
+
+// Config contains configuration for the operation journal.
+// Every record pushed to or deleted from the store is appended to the journal,
+// so that lost records can be identified and recovered from other instances.
+type Config struct {
+	// Indicates if operations are journaled
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Directory of the journal files
+	Path string `json:"path,omitempty" mapstructure:"path"`
+
+	// Size in bytes at which the journal file is rotated
+	MaxFileSize int64 `json:"max_file_size,omitempty" mapstructure:"max_file_size"`
+
+	// Number of journal files to retain, the oldest files are removed on rotation.
+	// Zero retains all files.
+	MaxFiles int `json:"max_files,omitempty" mapstructure:"max_files"`
+
+	// Number of entries buffered for the journal writer.
+	// Operations never wait for the journal, entries that do not fit into the queue
+	// are dropped and recorded as a gap in the journal.
+	QueueSize int `json:"queue_size,omitempty" mapstructure:"queue_size"`
+
+	// Number of entries a store watcher may fall behind the journal before it is disconnected.
+	// Zero disables the limit.
+	MaxWatchLag uint64 `json:"max_watch_lag,omitempty" mapstructure:"max_watch_lag"`
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Path == "" {
+		return errors.New("path is required")
+	}
+
+	if c.MaxFileSize <= 0 {
+		return errors.New("max file size must be positive")
+	}
+
+	if c.MaxFiles < 0 {
+		return errors.New("max files must not be negative")
+	}
+
+	if c.QueueSize <= 0 {
+		return errors.New("queue size must be positive")
+	}
+
+	return nil
+}
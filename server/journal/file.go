@@ -0,0 +1,204 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Journal files are named after the sequence number of their first entry and contain a sequence of frames:
+// the length of the entry as 4-byte big-endian integer, the CRC-32C checksum of the entry, and the entry
+// encoded as protobuf.
+const (
+	segmentPrefix = "journal-"
+	segmentSuffix = ".log"
+
+	frameHeaderSize = 8
+
+	// maxEntrySize bounds the size of an entry, larger lengths indicate a corrupt frame.
+	maxEntrySize = 1 << 20
+)
+
+// ErrCorrupt is returned for journal files with truncated frames or checksum mismatches.
+var ErrCorrupt = errors.New("corrupt journal")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// segment is a journal file.
+type segment struct {
+	path  string
+	first uint64
+}
+
+func segmentName(first uint64) string {
+	return fmt.Sprintf("%s%020d%s", segmentPrefix, first, segmentSuffix)
+}
+
+// listSegments returns the journal files in the directory, ordered by their first sequence number.
+func listSegments(dir string) ([]segment, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list journal files: %w", err)
+	}
+
+	var segments []segment
+
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasPrefix(name, segmentPrefix) || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+
+		first, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, segmentPrefix), segmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		segments = append(segments, segment{path: filepath.Join(dir, name), first: first})
+	}
+
+	slices.SortFunc(segments, func(a, b segment) int {
+		switch {
+		case a.first < b.first:
+			return -1
+		case a.first > b.first:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return segments, nil
+}
+
+// encodeFrame encodes an entry as a checksummed frame.
+func encodeFrame(entry *storev1.JournalEntry) ([]byte, error) {
+	data, err := proto.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode journal entry: %w", err)
+	}
+
+	frame := make([]byte, frameHeaderSize, frameHeaderSize+len(data))
+	binary.BigEndian.PutUint32(frame[0:4], uint32(len(data))) //nolint:gosec
+	binary.BigEndian.PutUint32(frame[4:8], crc32.Checksum(data, crcTable))
+
+	return append(frame, data...), nil
+}
+
+// readSegment passes the entries of a journal file to fn, reading at most limit bytes, or the whole file if limit is negative.
+// It returns the offset after the last valid frame, and an error wrapping ErrCorrupt if a frame is truncated or its
+// checksum does not match, in which case the entries before the corrupt frame have been passed to fn.
+func readSegment(path string, limit int64, fn func(*storev1.JournalEntry) error) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open journal file: %w", err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if limit >= 0 {
+		reader = io.LimitReader(file, limit)
+	}
+
+	buffered := bufio.NewReader(reader)
+	header := make([]byte, frameHeaderSize)
+
+	var offset int64
+
+	for {
+		if _, err := io.ReadFull(buffered, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return offset, nil
+			}
+
+			return offset, fmt.Errorf("%w: %s: truncated frame header at offset %d", ErrCorrupt, filepath.Base(path), offset)
+		}
+
+		size := binary.BigEndian.Uint32(header[0:4])
+		if size > maxEntrySize {
+			return offset, fmt.Errorf("%w: %s: invalid frame size %d at offset %d", ErrCorrupt, filepath.Base(path), size, offset)
+		}
+
+		data := make([]byte, size)
+		if _, err := io.ReadFull(buffered, data); err != nil {
+			return offset, fmt.Errorf("%w: %s: truncated frame at offset %d", ErrCorrupt, filepath.Base(path), offset)
+		}
+
+		if crc32.Checksum(data, crcTable) != binary.BigEndian.Uint32(header[4:8]) {
+			return offset, fmt.Errorf("%w: %s: checksum mismatch at offset %d", ErrCorrupt, filepath.Base(path), offset)
+		}
+
+		entry := &storev1.JournalEntry{}
+		if err := proto.Unmarshal(data, entry); err != nil {
+			return offset, fmt.Errorf("%w: %s: invalid entry at offset %d: %w", ErrCorrupt, filepath.Base(path), offset, err)
+		}
+
+		if err := fn(entry); err != nil {
+			return offset, err
+		}
+
+		offset += int64(frameHeaderSize) + int64(size)
+	}
+}
+
+// ReadDir passes the entries of the journal in dir with a sequence number greater than since to fn, oldest first.
+// It fails with an error wrapping ErrCorrupt if a journal file is corrupt, after passing the entries before the corruption.
+func ReadDir(dir string, since uint64, fn func(*storev1.JournalEntry) error) error {
+	segments, err := listSegments(dir)
+	if err != nil {
+		return err
+	}
+
+	return readSegments(segments, since, -1, fn)
+}
+
+// readSegments passes the entries of the journal files with a sequence number greater than since to fn.
+// At most lastLimit bytes of the last file are read, or the whole file if lastLimit is negative.
+func readSegments(segments []segment, since uint64, lastLimit int64, fn func(*storev1.JournalEntry) error) error {
+	for i, seg := range segments {
+		last := i == len(segments)-1
+
+		// Skip files whose entries are all older
+		if !last && segments[i+1].first <= since+1 {
+			continue
+		}
+
+		limit := int64(-1)
+		if last {
+			limit = lastLimit
+		}
+
+		_, err := readSegment(seg.path, limit, func(entry *storev1.JournalEntry) error {
+			if entry.GetSequence() <= since {
+				return nil
+			}
+
+			return fn(entry)
+		})
+
+		// Files may be removed by rotation while they are read
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
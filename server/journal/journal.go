@@ -0,0 +1,432 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package journal provides an append-only journal of the records pushed to, updated in and deleted from the store.
+// The journal is kept apart from the store, so that records lost with the store can be identified
+// and recovered from other instances with Replay, and changes can be followed with Watch.
+//
+// Journaling never blocks store operations: entries are written by a background writer and entries
+// that do not fit into its queue are dropped and recorded as a gap in the journal.
+package journal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/server/journal/config"
+	"github.com/agntcy/dir/utils/logging"
+)
+
+var logger = logging.Logger("journal")
+
+// request is an entry to write or, if entry is nil, a flush to acknowledge once all preceding entries are written.
+type request struct {
+	entry   *storev1.JournalEntry
+	flushed chan struct{}
+}
+
+// Journal appends store operations to rotating, checksummed journal files.
+// A nil Journal discards all operations.
+type Journal struct {
+	cfg config.Config
+
+	// queue is closed on Close, guarded by closeMu
+	closeMu sync.RWMutex
+	closed  bool
+	queue   chan request
+	done    chan struct{}
+
+	// Entries dropped since the last written entry, and in total
+	pendingDrops atomic.Uint64
+	dropped      atomic.Uint64
+
+	// File state, guarded by mu
+	mu   sync.Mutex
+	file *os.File
+	size int64
+	seq  uint64
+
+	// changed is closed and replaced when an entry is written, guarded by mu
+	changed chan struct{}
+
+	// watchesClosed is closed by CloseWatches
+	watchesClosed    chan struct{}
+	closeWatchesOnce sync.Once
+}
+
+// New opens the journal in the configured directory and starts its writer.
+// A corrupt tail of the last journal file, e.g. left by a crash, is truncated.
+func New(cfg config.Config) (*Journal, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid journal config: %w", err)
+	}
+
+	if err := os.MkdirAll(cfg.Path, 0o750); err != nil { //nolint:mnd
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	j := &Journal{
+		cfg:           cfg,
+		queue:         make(chan request, cfg.QueueSize),
+		done:          make(chan struct{}),
+		changed:       make(chan struct{}),
+		watchesClosed: make(chan struct{}),
+	}
+
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+
+	go j.run()
+
+	logger.Info("Journal opened", "path", cfg.Path, "sequence", j.seq)
+
+	return j, nil
+}
+
+// open recovers the last sequence number and opens the last journal file for appending.
+func (j *Journal) open() error {
+	segments, err := listSegments(j.cfg.Path)
+	if err != nil {
+		return err
+	}
+
+	if len(segments) == 0 {
+		return j.createSegment(1)
+	}
+
+	last := segments[len(segments)-1]
+	j.seq = last.first - 1
+
+	end, err := readSegment(last.path, -1, func(entry *storev1.JournalEntry) error {
+		j.seq = entry.GetSequence()
+
+		return nil
+	})
+	if errors.Is(err, ErrCorrupt) {
+		logger.Warn("Truncating corrupt journal tail", "error", err, "offset", end)
+
+		if err := os.Truncate(last.path, end); err != nil {
+			return fmt.Errorf("failed to truncate corrupt journal tail: %w", err)
+		}
+	} else if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(last.path, os.O_WRONLY|os.O_APPEND, 0o640) //nolint:mnd
+	if err != nil {
+		return fmt.Errorf("failed to open journal file: %w", err)
+	}
+
+	j.file = file
+	j.size = end
+
+	return nil
+}
+
+// createSegment starts a new journal file with the entry of the given sequence number.
+func (j *Journal) createSegment(first uint64) error {
+	file, err := os.OpenFile(j.cfg.Path+string(os.PathSeparator)+segmentName(first), os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o640) //nolint:mnd
+	if err != nil {
+		return fmt.Errorf("failed to create journal file: %w", err)
+	}
+
+	j.file = file
+	j.size = 0
+	j.seq = first - 1
+
+	return nil
+}
+
+// RecordPush journals a record pushed by the caller.
+func (j *Journal) RecordPush(ctx context.Context, record *corev1.Record) {
+	if j == nil {
+		return
+	}
+
+	fields := record.GetData().GetFields()
+
+	name, version := fields["name"].GetStringValue(), fields["version"].GetStringValue()
+	if envelope := record.GetEnvelope(); envelope != nil {
+		name, version = envelope.GetMetadata().GetName(), envelope.GetMetadata().GetVersion()
+	}
+
+	var size uint64
+	if data, err := record.Marshal(); err == nil {
+		size = uint64(len(data))
+	}
+
+	j.append(&storev1.JournalEntry{
+		Operation:   storev1.JournalOperation_JOURNAL_OPERATION_PUSH,
+		Cid:         record.GetCid(),
+		Name:        name,
+		Version:     version,
+		TrustDomain: trustDomainFromContext(ctx),
+		SizeBytes:   size,
+	})
+}
+
+// RecordDelete journals a record deleted by the caller.
+func (j *Journal) RecordDelete(ctx context.Context, cid, name, version string) {
+	if j == nil {
+		return
+	}
+
+	j.append(&storev1.JournalEntry{
+		Operation:   storev1.JournalOperation_JOURNAL_OPERATION_DELETE,
+		Cid:         cid,
+		Name:        name,
+		Version:     version,
+		TrustDomain: trustDomainFromContext(ctx),
+	})
+}
+
+// RecordUpdate journals a change of the lifecycle status or operational metadata of a record by the caller.
+func (j *Journal) RecordUpdate(ctx context.Context, cid, name, version string) {
+	if j == nil {
+		return
+	}
+
+	j.append(&storev1.JournalEntry{
+		Operation:   storev1.JournalOperation_JOURNAL_OPERATION_UPDATE,
+		Cid:         cid,
+		Name:        name,
+		Version:     version,
+		TrustDomain: trustDomainFromContext(ctx),
+	})
+}
+
+// append queues an entry without blocking, dropping it if the queue is full.
+func (j *Journal) append(entry *storev1.JournalEntry) {
+	entry.Timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+
+	j.closeMu.RLock()
+	defer j.closeMu.RUnlock()
+
+	if j.closed {
+		return
+	}
+
+	select {
+	case j.queue <- request{entry: entry}:
+	default:
+		j.pendingDrops.Add(1)
+		dropped := j.dropped.Add(1)
+
+		logger.Error("Journal queue is full, dropping entry", "operation", entry.GetOperation(), "cid", entry.GetCid(), "dropped", dropped)
+	}
+}
+
+// Dropped returns the number of entries dropped because the queue was full.
+func (j *Journal) Dropped() uint64 {
+	if j == nil {
+		return 0
+	}
+
+	return j.dropped.Load()
+}
+
+// run writes queued entries until the journal is closed.
+func (j *Journal) run() {
+	defer close(j.done)
+
+	for req := range j.queue {
+		if req.entry == nil {
+			close(req.flushed)
+
+			continue
+		}
+
+		// Record dropped entries before the next entry, so that readers know the journal is incomplete
+		if drops := j.pendingDrops.Swap(0); drops > 0 {
+			j.write(&storev1.JournalEntry{
+				Operation: storev1.JournalOperation_JOURNAL_OPERATION_GAP,
+				Timestamp: req.entry.GetTimestamp(),
+				Dropped:   drops,
+			})
+		}
+
+		j.write(req.entry)
+	}
+}
+
+// write appends an entry to the journal file, rotating it if it exceeds the maximum size.
+func (j *Journal) write(entry *storev1.JournalEntry) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry.Sequence = j.seq + 1
+
+	frame, err := encodeFrame(entry)
+	if err != nil {
+		logger.Error("Failed to encode journal entry", "error", err, "cid", entry.GetCid())
+
+		return
+	}
+
+	if j.size > 0 && j.size+int64(len(frame)) > j.cfg.MaxFileSize {
+		if err := j.rotate(entry.GetSequence()); err != nil {
+			logger.Error("Failed to rotate journal", "error", err)
+
+			return
+		}
+	}
+
+	if _, err := j.file.Write(frame); err != nil {
+		logger.Error("Failed to write journal entry", "error", err, "cid", entry.GetCid())
+
+		return
+	}
+
+	j.size += int64(len(frame))
+	j.seq = entry.GetSequence()
+
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+// rotate closes the current journal file, starts a new one and removes the oldest files beyond the retention.
+func (j *Journal) rotate(next uint64) error {
+	if err := j.file.Sync(); err != nil {
+		logger.Warn("Failed to sync journal file", "error", err)
+	}
+
+	if err := j.file.Close(); err != nil {
+		logger.Warn("Failed to close journal file", "error", err)
+	}
+
+	if err := j.createSegment(next); err != nil {
+		return err
+	}
+
+	if j.cfg.MaxFiles <= 0 {
+		return nil
+	}
+
+	segments, err := listSegments(j.cfg.Path)
+	if err != nil {
+		return err
+	}
+
+	for len(segments) > j.cfg.MaxFiles {
+		if err := os.Remove(segments[0].path); err != nil {
+			return fmt.Errorf("failed to remove journal file: %w", err)
+		}
+
+		logger.Info("Removed journal file beyond retention", "path", segments[0].path)
+
+		segments = segments[1:]
+	}
+
+	return nil
+}
+
+// Flush waits until all entries queued before the call are written.
+func (j *Journal) Flush(ctx context.Context) error {
+	if j == nil {
+		return nil
+	}
+
+	flushed := make(chan struct{})
+
+	j.closeMu.RLock()
+	if j.closed {
+		j.closeMu.RUnlock()
+
+		return nil
+	}
+
+	select {
+	case j.queue <- request{flushed: flushed}:
+		j.closeMu.RUnlock()
+	case <-ctx.Done():
+		j.closeMu.RUnlock()
+
+		return fmt.Errorf("failed to flush journal: %w", ctx.Err())
+	}
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to flush journal: %w", ctx.Err())
+	}
+}
+
+// Read passes the written entries with a sequence number greater than since to fn, oldest first.
+// Entries that are still queued are not read, use Flush to wait for them.
+func (j *Journal) Read(since uint64, fn func(*storev1.JournalEntry) error) error {
+	j.mu.Lock()
+
+	segments, err := listSegments(j.cfg.Path)
+	size := j.size
+
+	j.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	// The last file may be appended to while it is read
+	return readSegments(segments, since, size, fn)
+}
+
+// Sequence returns the sequence number of the last written entry.
+func (j *Journal) Sequence() uint64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.seq
+}
+
+// Close writes the queued entries and closes the journal.
+func (j *Journal) Close() error {
+	if j == nil {
+		return nil
+	}
+
+	j.closeMu.Lock()
+	if j.closed {
+		j.closeMu.Unlock()
+
+		return nil
+	}
+
+	j.closed = true
+	close(j.queue)
+	j.closeMu.Unlock()
+
+	j.CloseWatches()
+
+	<-j.done
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.file.Sync(); err != nil {
+		logger.Warn("Failed to sync journal file", "error", err)
+	}
+
+	if err := j.file.Close(); err != nil {
+		return fmt.Errorf("failed to close journal file: %w", err)
+	}
+
+	return nil
+}
+
+// trustDomainFromContext returns the trust domain of the caller, empty if unauthenticated.
+func trustDomainFromContext(ctx context.Context) string {
+	if sid, ok := authn.SpiffeIDFromContext(ctx); ok {
+		return sid.TrustDomain().String()
+	}
+
+	return ""
+}
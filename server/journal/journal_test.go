@@ -0,0 +1,331 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/journal/config"
+	"github.com/agntcy/dir/server/store/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig(t *testing.T) config.Config {
+	t.Helper()
+
+	return config.Config{
+		Enabled:     true,
+		Path:        t.TempDir(),
+		MaxFileSize: config.DefaultMaxFileSize,
+		QueueSize:   config.DefaultQueueSize,
+	}
+}
+
+func testRecord(i int) *corev1.Record {
+	return corev1.New(&typesv1alpha0.Record{
+		Name:          fmt.Sprintf("agent-%d", i),
+		Version:       "v1.0.0",
+		SchemaVersion: "v0.3.1",
+	})
+}
+
+func readAll(t *testing.T, j *Journal, since uint64) []*storev1.JournalEntry {
+	t.Helper()
+
+	require.NoError(t, j.Flush(t.Context()))
+
+	var entries []*storev1.JournalEntry
+
+	require.NoError(t, j.Read(since, func(entry *storev1.JournalEntry) error {
+		entries = append(entries, entry)
+
+		return nil
+	}))
+
+	return entries
+}
+
+func TestJournalRecordsOperations(t *testing.T) {
+	j, err := New(testConfig(t))
+	require.NoError(t, err)
+
+	defer j.Close()
+
+	record := testRecord(1)
+	j.RecordPush(t.Context(), record)
+	j.RecordDelete(t.Context(), record.GetCid(), "agent-1", "v1.0.0")
+
+	entries := readAll(t, j, 0)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, uint64(1), entries[0].GetSequence())
+	assert.Equal(t, storev1.JournalOperation_JOURNAL_OPERATION_PUSH, entries[0].GetOperation())
+	assert.Equal(t, record.GetCid(), entries[0].GetCid())
+	assert.Equal(t, "agent-1", entries[0].GetName())
+	assert.Equal(t, "v1.0.0", entries[0].GetVersion())
+	assert.NotZero(t, entries[0].GetSizeBytes())
+	assert.NotEmpty(t, entries[0].GetTimestamp())
+
+	assert.Equal(t, uint64(2), entries[1].GetSequence())
+	assert.Equal(t, storev1.JournalOperation_JOURNAL_OPERATION_DELETE, entries[1].GetOperation())
+
+	// Reading since a sequence number skips older entries
+	entries = readAll(t, j, 1)
+	require.Len(t, entries, 1)
+	assert.Equal(t, uint64(2), entries[0].GetSequence())
+}
+
+func TestJournalNil(t *testing.T) {
+	var j *Journal
+
+	j.RecordPush(t.Context(), testRecord(1))
+	j.RecordDelete(t.Context(), "cid", "", "")
+
+	assert.NoError(t, j.Flush(t.Context()))
+	assert.NoError(t, j.Close())
+	assert.Zero(t, j.Dropped())
+}
+
+func TestJournalRotation(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.MaxFileSize = 512
+	cfg.MaxFiles = 3
+
+	j, err := New(cfg)
+	require.NoError(t, err)
+
+	const count = 50
+
+	for i := range count {
+		j.RecordPush(t.Context(), testRecord(i))
+	}
+
+	entries := readAll(t, j, 0)
+
+	segments, err := listSegments(cfg.Path)
+	require.NoError(t, err)
+	require.Len(t, segments, cfg.MaxFiles, "files beyond retention must be removed")
+
+	for _, seg := range segments {
+		info, err := os.Stat(seg.path)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), cfg.MaxFileSize)
+	}
+
+	// The retained entries are the latest ones, in order
+	require.NotEmpty(t, entries)
+	assert.Equal(t, uint64(count), entries[len(entries)-1].GetSequence())
+	assert.Equal(t, segments[0].first, entries[0].GetSequence())
+
+	for i := 1; i < len(entries); i++ {
+		assert.Equal(t, entries[i-1].GetSequence()+1, entries[i].GetSequence())
+	}
+
+	// Sequence numbers continue after reopening
+	require.NoError(t, j.Close())
+
+	j, err = New(cfg)
+	require.NoError(t, err)
+
+	defer j.Close()
+
+	j.RecordPush(t.Context(), testRecord(count))
+
+	entries = readAll(t, j, count)
+	require.Len(t, entries, 1)
+	assert.Equal(t, uint64(count+1), entries[0].GetSequence())
+}
+
+func TestJournalTruncatedFile(t *testing.T) {
+	cfg := testConfig(t)
+
+	j, err := New(cfg)
+	require.NoError(t, err)
+
+	for i := range 3 {
+		j.RecordPush(t.Context(), testRecord(i))
+	}
+
+	require.NoError(t, j.Close())
+
+	segments, err := listSegments(cfg.Path)
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+
+	info, err := os.Stat(segments[0].path)
+	require.NoError(t, err)
+
+	t.Run("truncated frame", func(t *testing.T) {
+		// Simulate a crash in the middle of writing the last entry
+		require.NoError(t, os.Truncate(segments[0].path, info.Size()-3))
+
+		var entries []*storev1.JournalEntry
+
+		err := ReadDir(cfg.Path, 0, func(entry *storev1.JournalEntry) error {
+			entries = append(entries, entry)
+
+			return nil
+		})
+		require.ErrorIs(t, err, ErrCorrupt)
+		assert.Len(t, entries, 2, "entries before the corrupt frame must be read")
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		data, err := os.ReadFile(segments[0].path)
+		require.NoError(t, err)
+
+		// Flip a bit in the payload of the first entry
+		data[frameHeaderSize] ^= 0x01
+		require.NoError(t, os.WriteFile(segments[0].path, data, 0o600))
+
+		err = ReadDir(cfg.Path, 0, func(*storev1.JournalEntry) error { return nil })
+		require.ErrorIs(t, err, ErrCorrupt)
+		assert.Contains(t, err.Error(), "checksum mismatch")
+
+		// Restore the first entry
+		data[frameHeaderSize] ^= 0x01
+		require.NoError(t, os.WriteFile(segments[0].path, data, 0o600))
+	})
+
+	t.Run("reopen truncates corrupt tail", func(t *testing.T) {
+		j, err := New(cfg)
+		require.NoError(t, err)
+
+		defer j.Close()
+
+		j.RecordPush(t.Context(), testRecord(3))
+
+		entries := readAll(t, j, 0)
+		require.Len(t, entries, 3)
+		assert.Equal(t, []uint64{1, 2, 3}, []uint64{entries[0].GetSequence(), entries[1].GetSequence(), entries[2].GetSequence()})
+		assert.Equal(t, testRecord(3).GetCid(), entries[2].GetCid())
+	})
+}
+
+func TestJournalDropsWhenQueueIsFull(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.QueueSize = 1
+
+	j, err := New(cfg)
+	require.NoError(t, err)
+
+	defer j.Close()
+
+	// Hold the file lock so that the writer blocks and the queue fills up
+	j.mu.Lock()
+
+	const count = 20
+
+	for i := range count {
+		j.RecordPush(t.Context(), testRecord(i))
+	}
+
+	j.mu.Unlock()
+
+	dropped := j.Dropped()
+	require.NotZero(t, dropped, "operations must not block on a full queue")
+
+	// The next written entry is preceded by a gap entry counting the dropped entries
+	require.NoError(t, j.Flush(t.Context()))
+	j.RecordPush(t.Context(), testRecord(count))
+
+	entries := readAll(t, j, 0)
+
+	var (
+		gaps    uint64
+		written int
+	)
+
+	for _, entry := range entries {
+		if entry.GetOperation() == storev1.JournalOperation_JOURNAL_OPERATION_GAP {
+			gaps += entry.GetDropped()
+		} else {
+			written++
+		}
+	}
+
+	assert.Equal(t, dropped, gaps)
+	assert.Equal(t, count+1, written+int(dropped)) //nolint:gosec
+}
+
+func TestReplayRestoresWipedStore(t *testing.T) {
+	ctx := t.Context()
+
+	j, err := New(testConfig(t))
+	require.NoError(t, err)
+
+	defer j.Close()
+
+	local, err := memory.New()
+	require.NoError(t, err)
+
+	peer, err := memory.New()
+	require.NoError(t, err)
+
+	// Push records to the local store and its peer, and journal the local operations
+	records := make([]*corev1.Record, 5)
+	for i := range records {
+		records[i] = testRecord(i)
+
+		for _, store := range []*memory.Store{local, peer} {
+			_, err := store.Push(ctx, records[i])
+			require.NoError(t, err)
+		}
+
+		j.RecordPush(ctx, records[i])
+	}
+
+	// Records deleted afterwards must not be restored
+	require.NoError(t, local.Delete(ctx, &corev1.RecordRef{Cid: records[4].GetCid()}))
+	j.RecordDelete(ctx, records[4].GetCid(), "agent-4", "v1.0.0")
+
+	// Wipe the local store, keeping one record
+	wiped, err := memory.New()
+	require.NoError(t, err)
+
+	_, err = wiped.Push(ctx, records[0])
+	require.NoError(t, err)
+
+	result, err := Replay(ctx, readAll(t, j, 0), peer, wiped)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, result.Live)
+	assert.ElementsMatch(t, []string{records[1].GetCid(), records[2].GetCid(), records[3].GetCid()}, result.Restored)
+	assert.Empty(t, result.Failed)
+
+	for i, record := range records[:4] {
+		pulled, err := wiped.Pull(ctx, &corev1.RecordRef{Cid: record.GetCid()})
+		require.NoError(t, err, "record %d must be restored", i)
+		assert.Equal(t, record.GetCid(), pulled.GetCid())
+	}
+
+	_, err = wiped.Lookup(ctx, &corev1.RecordRef{Cid: records[4].GetCid()})
+	require.Error(t, err, "deleted record must not be restored")
+}
+
+func TestReplayReportsMissingSourceRecords(t *testing.T) {
+	ctx := t.Context()
+
+	source, err := memory.New()
+	require.NoError(t, err)
+
+	target, err := memory.New()
+	require.NoError(t, err)
+
+	record := testRecord(1)
+
+	result, err := Replay(ctx, []*storev1.JournalEntry{
+		{Sequence: 1, Operation: storev1.JournalOperation_JOURNAL_OPERATION_PUSH, Cid: record.GetCid()},
+	}, source, target)
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Restored)
+	require.Contains(t, result.Failed, record.GetCid())
+}
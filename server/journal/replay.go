@@ -0,0 +1,123 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecordSource provides the records to restore, e.g. the store client of a peer instance.
+type RecordSource interface {
+	Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error)
+}
+
+// RecordTarget receives the restored records, e.g. the store of the recovering instance.
+type RecordTarget interface {
+	Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error)
+	Push(ctx context.Context, record *corev1.Record) (*corev1.RecordRef, error)
+}
+
+// ReplayResult summarizes a replay.
+type ReplayResult struct {
+	// Records journaled as pushed and not deleted afterwards
+	Live int
+	// CIDs of the records restored to the target
+	Restored []string
+	// Errors of the records that could not be restored, by CID
+	Failed map[string]error
+}
+
+// Replay restores the records that the journal entries show as present but are missing from the target.
+// The entries are replayed in order, so records deleted after being pushed are not restored.
+// Missing records are pulled from the source, verified against their CID and pushed to the target.
+//
+// Records that cannot be restored are reported in the result rather than failing the replay;
+// an error is only returned if the context is canceled.
+func Replay(ctx context.Context, entries []*storev1.JournalEntry, source RecordSource, target RecordTarget) (*ReplayResult, error) {
+	var (
+		live  []string
+		index = make(map[string]int)
+	)
+
+	for _, entry := range entries {
+		switch entry.GetOperation() { //nolint:exhaustive
+		case storev1.JournalOperation_JOURNAL_OPERATION_PUSH:
+			if _, ok := index[entry.GetCid()]; !ok {
+				index[entry.GetCid()] = len(live)
+				live = append(live, entry.GetCid())
+			}
+		case storev1.JournalOperation_JOURNAL_OPERATION_DELETE:
+			if i, ok := index[entry.GetCid()]; ok {
+				live[i] = ""
+
+				delete(index, entry.GetCid())
+			}
+		case storev1.JournalOperation_JOURNAL_OPERATION_GAP:
+			logger.Warn("Journal has a gap, dropped operations cannot be replayed", "sequence", entry.GetSequence(), "dropped", entry.GetDropped())
+		}
+	}
+
+	result := &ReplayResult{Failed: make(map[string]error)}
+
+	for _, cid := range live {
+		if cid == "" {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return result, fmt.Errorf("replay canceled: %w", err)
+		}
+
+		result.Live++
+
+		ref := &corev1.RecordRef{Cid: cid}
+
+		_, err := target.Lookup(ctx, ref)
+		if err == nil {
+			continue
+		}
+
+		if status.Code(err) != codes.NotFound {
+			result.Failed[cid] = fmt.Errorf("failed to lookup record: %w", err)
+
+			continue
+		}
+
+		if err := restore(ctx, ref, source, target); err != nil {
+			result.Failed[cid] = err
+
+			continue
+		}
+
+		result.Restored = append(result.Restored, cid)
+	}
+
+	logger.Info("Journal replayed", "live", result.Live, "restored", len(result.Restored), "failed", len(result.Failed))
+
+	return result, nil
+}
+
+// restore copies a record from the source to the target.
+func restore(ctx context.Context, ref *corev1.RecordRef, source RecordSource, target RecordTarget) error {
+	record, err := source.Pull(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("failed to pull record from source: %w", err)
+	}
+
+	if !record.MatchesCid(ref.GetCid()) {
+		return fmt.Errorf("record pulled from source has CID %s", record.GetCid())
+	}
+
+	if _, err := target.Push(ctx, record); err != nil {
+		return fmt.Errorf("failed to push record to target: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+)
+
+var (
+	// ErrNotRetained is returned by Watch if entries to watch were removed by rotation,
+	// or the sequence number to watch from is ahead of the journal, e.g. because it was reset.
+	ErrNotRetained = errors.New("journal entries are not retained")
+
+	// ErrWatchLag is returned by Watch if the watcher falls too far behind the journal.
+	ErrWatchLag = errors.New("watcher fell behind the journal")
+
+	// ErrWatchClosed is returned by Watch once the watches are closed with CloseWatches.
+	ErrWatchClosed = errors.New("journal watches are closed")
+)
+
+// Watch passes the entries with a sequence number greater than since to fn, oldest first,
+// and keeps passing new entries as they are written until the context is done, fn fails
+// or the watches are closed.
+//
+// Entries are read from the journal files rather than buffered for the watcher, so slow watchers
+// do not hold entries in memory. Once a watcher has caught up with the journal, it fails with
+// ErrWatchLag if it falls behind the last written entry by more than the configured maximum lag.
+// Missing entries are never skipped: Watch fails with ErrNotRetained instead.
+func (j *Journal) Watch(ctx context.Context, since uint64, fn func(*storev1.JournalEntry) error) error {
+	if seq := j.Sequence(); since > seq {
+		return fmt.Errorf("%w: sequence %d is ahead of the journal at %d", ErrNotRetained, since, seq)
+	}
+
+	last := since
+	caughtUp := false
+
+	for {
+		j.mu.Lock()
+		changed := j.changed
+		j.mu.Unlock()
+
+		err := j.Read(last, func(entry *storev1.JournalEntry) error {
+			if entry.GetSequence() != last+1 {
+				return fmt.Errorf("%w: entries %d to %d were removed", ErrNotRetained, last+1, entry.GetSequence()-1)
+			}
+
+			if caughtUp && j.cfg.MaxWatchLag > 0 {
+				if lag := j.Sequence() - entry.GetSequence(); lag > j.cfg.MaxWatchLag {
+					return fmt.Errorf("%w: %d entries behind", ErrWatchLag, lag)
+				}
+			}
+
+			if err := fn(entry); err != nil {
+				return err
+			}
+
+			last = entry.GetSequence()
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		caughtUp = true
+
+		select {
+		case <-changed:
+		case <-j.watchesClosed:
+			return ErrWatchClosed
+		case <-ctx.Done():
+			return fmt.Errorf("watch canceled: %w", ctx.Err())
+		}
+	}
+}
+
+// CloseWatches ends all current and future watches with ErrWatchClosed,
+// e.g. before the server stops, as watches only end when they are canceled otherwise.
+func (j *Journal) CloseWatches() {
+	if j == nil {
+		return
+	}
+
+	j.closeWatchesOnce.Do(func() {
+		close(j.watchesClosed)
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchFollowsJournal(t *testing.T) {
+	j, err := New(testConfig(t))
+	require.NoError(t, err)
+
+	defer j.Close()
+
+	j.RecordPush(t.Context(), testRecord(1))
+	require.NoError(t, j.Flush(t.Context()))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	entries := make(chan *storev1.JournalEntry)
+	watchErr := make(chan error, 1)
+
+	go func() {
+		watchErr <- j.Watch(ctx, 0, func(entry *storev1.JournalEntry) error {
+			entries <- entry
+
+			return nil
+		})
+	}()
+
+	// Written entries are passed first, then new entries as they are written
+	assert.Equal(t, uint64(1), (<-entries).GetSequence())
+
+	j.RecordUpdate(t.Context(), testRecord(1).GetCid(), "agent-1", "v1.0.0")
+	j.RecordDelete(t.Context(), testRecord(1).GetCid(), "agent-1", "v1.0.0")
+
+	update := <-entries
+	assert.Equal(t, uint64(2), update.GetSequence())
+	assert.Equal(t, storev1.JournalOperation_JOURNAL_OPERATION_UPDATE, update.GetOperation())
+
+	assert.Equal(t, uint64(3), (<-entries).GetSequence())
+
+	cancel()
+	require.ErrorIs(t, <-watchErr, context.Canceled)
+}
+
+func TestWatchResumesFromSequence(t *testing.T) {
+	j, err := New(testConfig(t))
+	require.NoError(t, err)
+
+	defer j.Close()
+
+	for i := range 5 {
+		j.RecordPush(t.Context(), testRecord(i))
+	}
+
+	require.NoError(t, j.Flush(t.Context()))
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+
+	var sequences []uint64
+
+	_ = j.Watch(ctx, 3, func(entry *storev1.JournalEntry) error {
+		sequences = append(sequences, entry.GetSequence())
+		if len(sequences) == 2 {
+			cancel()
+		}
+
+		return nil
+	})
+
+	assert.Equal(t, []uint64{4, 5}, sequences)
+}
+
+func TestWatchNotRetained(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.MaxFileSize = 512
+	cfg.MaxFiles = 1
+
+	j, err := New(cfg)
+	require.NoError(t, err)
+
+	defer j.Close()
+
+	for i := range 50 {
+		j.RecordPush(t.Context(), testRecord(i))
+	}
+
+	require.NoError(t, j.Flush(t.Context()))
+
+	t.Run("rotated entries", func(t *testing.T) {
+		err := j.Watch(t.Context(), 0, func(*storev1.JournalEntry) error { return nil })
+		require.ErrorIs(t, err, ErrNotRetained)
+	})
+
+	t.Run("sequence ahead of the journal", func(t *testing.T) {
+		err := j.Watch(t.Context(), j.Sequence()+1, func(*storev1.JournalEntry) error { return nil })
+		require.ErrorIs(t, err, ErrNotRetained)
+	})
+}
+
+func TestWatchLag(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.MaxWatchLag = 1
+
+	j, err := New(cfg)
+	require.NoError(t, err)
+
+	defer j.Close()
+
+	j.RecordPush(t.Context(), testRecord(0))
+
+	// The watcher is still handling the first entry when the next ones are written
+	err = j.Watch(t.Context(), 0, func(entry *storev1.JournalEntry) error {
+		if entry.GetSequence() == 1 {
+			for i := 1; i <= 3; i++ {
+				j.RecordPush(t.Context(), testRecord(i))
+			}
+
+			require.NoError(t, j.Flush(t.Context()))
+		}
+
+		return nil
+	})
+	require.ErrorIs(t, err, ErrWatchLag)
+}
+
+func TestWatchClosed(t *testing.T) {
+	j, err := New(testConfig(t))
+	require.NoError(t, err)
+
+	watchErr := make(chan error, 1)
+
+	go func() {
+		watchErr <- j.Watch(t.Context(), 0, func(*storev1.JournalEntry) error { return nil })
+	}()
+
+	j.CloseWatches()
+	require.ErrorIs(t, <-watchErr, ErrWatchClosed)
+
+	require.NoError(t, j.Close())
+}
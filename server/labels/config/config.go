@@ -0,0 +1,12 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+type Config struct {
+	// Additional mappings from extension name prefixes to label types,
+	// e.g. "example.com/domains/" -> "domains".
+	// Extensions matching a prefix are labelled with the rest of their name.
+	// Supported label types are "skills", "domains", "modules" and "locators".
+	ExtensionPrefixes map[string]string `json:"extension_prefixes,omitempty" mapstructure:"extension_prefixes"`
+}
@@ -0,0 +1,190 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package labels extracts discovery labels from records.
+// The same labels are used for the record metadata in the store and for routing
+// announcements, so that both always agree.
+package labels
+
+import (
+	"fmt"
+	"maps"
+	"sync/atomic"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	apilabels "github.com/agntcy/dir/api/labels"
+	"github.com/agntcy/dir/server/labels/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+)
+
+// Default extension name prefixes of OASF v0.3.1 records.
+const (
+	SkillsExtensionPrefix   = apilabels.SkillsExtensionPrefix
+	DomainsExtensionPrefix  = apilabels.DomainsExtensionPrefix
+	FeaturesExtensionPrefix = apilabels.FeaturesExtensionPrefix
+)
+
+// Labels is the typed set of discovery labels of a record.
+// Values are deduplicated and kept in record order.
+type Labels struct {
+	Skills   []string
+	Domains  []string
+	Modules  []string
+	Locators []string
+
+	// LocatorVariants are the locator types qualified with their target attributes, e.g. "helm.arm64".
+	// They are stored with the record metadata but not announced for routing, see LocatorVariants.
+	LocatorVariants []string
+
+	// Annotations are the custom annotations of the record.
+	// They are stored with the record metadata but not announced for routing.
+	Annotations map[string]string
+}
+
+// RoutingLabels returns the namespaced routing labels, e.g. "/skills/<name>".
+func (l Labels) RoutingLabels() []types.Label {
+	var result []types.Label
+
+	for _, group := range []struct {
+		labelType types.LabelType
+		values    []string
+	}{
+		{types.LabelTypeSkill, l.Skills},
+		{types.LabelTypeDomain, l.Domains},
+		{types.LabelTypeModule, l.Modules},
+		{types.LabelTypeLocator, l.Locators},
+	} {
+		for _, value := range group.values {
+			result = append(result, types.Label(group.labelType.Prefix()+value))
+		}
+	}
+
+	return result
+}
+
+// Extractor extracts labels from records.
+// The extraction rules are shared with dirctl, see the api labels package.
+type Extractor struct {
+	extractor *apilabels.Extractor
+}
+
+// NewExtractor creates an extractor with the default extension prefixes
+// and the additional prefixes from the config.
+func NewExtractor(cfg config.Config) (*Extractor, error) {
+	extractor, err := apilabels.NewExtractor(cfg.ExtensionPrefixes)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return &Extractor{extractor: extractor}, nil
+}
+
+// Extract returns the labels of the record.
+// Records without data have no labels.
+func (e *Extractor) Extract(record types.Record) Labels {
+	var result Labels
+
+	if record == nil {
+		return result
+	}
+
+	data, err := record.GetRecordData()
+	if err != nil || data == nil {
+		return result
+	}
+
+	for _, label := range e.extractor.Extract(recordFields(data)) {
+		switch types.LabelType(label.Type) {
+		case types.LabelTypeSkill:
+			result.Skills = append(result.Skills, label.Value)
+		case types.LabelTypeDomain:
+			result.Domains = append(result.Domains, label.Value)
+		case types.LabelTypeModule:
+			result.Modules = append(result.Modules, label.Value)
+		case types.LabelTypeLocator:
+			result.Locators = append(result.Locators, label.Value)
+		case types.LabelTypeUnknown:
+			continue
+		default:
+			continue
+		}
+	}
+
+	result.LocatorVariants = LocatorVariants(data.GetLocators())
+
+	if annotations := data.GetAnnotations(); len(annotations) > 0 {
+		result.Annotations = maps.Clone(annotations)
+	}
+
+	return result
+}
+
+// ParseExtension returns the label type and value of an extension or module name.
+// Names matching a known prefix are labelled with the rest of the name,
+// other names are labelled as modules. Malformed names yield an empty value.
+func (e *Extractor) ParseExtension(name string) (types.LabelType, string) {
+	labelType, value := e.extractor.ParseExtension(name)
+
+	return types.LabelType(labelType), value
+}
+
+// recordFields returns the fields of the record data that labels are derived from.
+func recordFields(data types.RecordData) []apilabels.Field {
+	var fields []apilabels.Field
+
+	add := func(labelType apilabels.Type, path string, index int, value string) {
+		fields = append(fields, apilabels.Field{Type: labelType, Path: fmt.Sprintf("%s[%d]", path, index), Value: value})
+	}
+
+	for i, skill := range data.GetSkills() {
+		add(apilabels.TypeSkill, "skills", i, skill.GetName())
+	}
+
+	for i, domain := range data.GetDomains() {
+		add(apilabels.TypeDomain, "domains", i, domain.GetName())
+	}
+
+	for i, module := range data.GetModules() {
+		add(apilabels.TypeModule, "modules", i, module.GetName())
+	}
+
+	for i, locator := range data.GetLocators() {
+		add(apilabels.TypeLocator, "locators", i, locator.GetType())
+	}
+
+	return fields
+}
+
+var defaultExtractor atomic.Pointer[Extractor]
+
+func init() {
+	e, _ := NewExtractor(config.Config{})
+	defaultExtractor.Store(e)
+}
+
+// Configure replaces the default extractor used by ExtractLabels and FromRecord.
+func Configure(cfg config.Config) error {
+	e, err := NewExtractor(cfg)
+	if err != nil {
+		return err
+	}
+
+	defaultExtractor.Store(e)
+
+	return nil
+}
+
+// ExtractLabels returns the labels of the record using the default extractor.
+func ExtractLabels(record *corev1.Record) Labels {
+	if record == nil {
+		return Labels{}
+	}
+
+	return FromRecord(adapters.NewRecordAdapter(record))
+}
+
+// FromRecord returns the labels of the record using the default extractor.
+func FromRecord(record types.Record) Labels {
+	return defaultExtractor.Load().Extract(record)
+}
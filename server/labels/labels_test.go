@@ -0,0 +1,290 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package labels_test
+
+import (
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	apilabels "github.com/agntcy/dir/api/labels"
+	"github.com/agntcy/dir/server/labels"
+	"github.com/agntcy/dir/server/labels/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractLabels(t *testing.T) {
+	t.Run("valid_v1alpha0_record", func(t *testing.T) {
+		// Create a valid v1alpha0 record JSON
+		recordJSON := `{
+			"name": "test-agent",
+			"version": "1.0.0",
+			"schema_version": "v0.3.1",
+			"authors": ["test"],
+			"created_at": "2023-01-01T00:00:00Z",
+			"skills": [
+				{
+					"category_name": "Natural Language Processing",
+					"category_uid": 1,
+					"class_name": "Text Completion",
+					"class_uid": 10201
+				}
+			],
+			"locators": [
+				{
+					"type": "docker-image",
+					"url": "https://example.com/test",
+					"size": 1000,
+					"digest": "sha256:abc123"
+				}
+			],
+			"extensions": [
+				{
+					"name": "schema.oasf.agntcy.org/features/runtime/framework",
+					"version": "v0.0.0",
+					"data": {}
+				}
+			]
+		}`
+
+		record, err := corev1.UnmarshalRecord([]byte(recordJSON))
+		require.NoError(t, err)
+
+		labelList := labels.ExtractLabels(record).RoutingLabels()
+		require.NotNil(t, labelList)
+
+		// Should have at least skill, locator, and module labels
+		assert.GreaterOrEqual(t, len(labelList), 3)
+
+		// Convert to strings for easier assertion
+		labelStrings := make([]string, len(labelList))
+		for i, label := range labelList {
+			labelStrings[i] = label.String()
+		}
+
+		// Check expected labels are present
+		assert.Contains(t, labelStrings, "/skills/Natural Language Processing/Text Completion")
+		assert.Contains(t, labelStrings, "/locators/docker-image")
+		assert.Contains(t, labelStrings, "/modules/runtime/framework") // Schema prefix stripped
+	})
+
+	t.Run("valid_v1alpha1_record", func(t *testing.T) {
+		// Create a valid v1alpha1 record JSON
+		recordJSON := `{
+			"name": "test-agent-v2",
+			"version": "2.0.0",
+			"schema_version": "0.7.0",
+			"authors": ["test"],
+			"created_at": "2023-01-01T00:00:00Z",
+			"skills": [
+				{
+					"name": "Machine Learning/Classification",
+					"id": 20301
+				}
+			],
+			"domains": [
+				{
+					"name": "healthcare/medical_technology",
+					"id": 905
+				}
+			],
+			"locators": [
+				{
+					"type": "http",
+					"url": "https://example.com/v2",
+					"size": 2000,
+					"digest": "sha256:def456"
+				}
+			],
+			"modules": [
+				{
+					"name": "security/authentication",
+					"data": {}
+				}
+			]
+		}`
+
+		record, err := corev1.UnmarshalRecord([]byte(recordJSON))
+		require.NoError(t, err)
+
+		labelList := labels.ExtractLabels(record).RoutingLabels()
+		require.NotNil(t, labelList)
+
+		// Should have skill, domain, locator, and module labels
+		assert.GreaterOrEqual(t, len(labelList), 4)
+
+		// Convert to strings for easier assertion
+		labelStrings := make([]string, len(labelList))
+		for i, label := range labelList {
+			labelStrings[i] = label.String()
+		}
+
+		// Check expected labels are present
+		assert.Contains(t, labelStrings, "/skills/Machine Learning/Classification")
+		assert.Contains(t, labelStrings, "/domains/healthcare/medical_technology")
+		assert.Contains(t, labelStrings, "/locators/http")
+		assert.Contains(t, labelStrings, "/modules/security/authentication") // Direct module name
+	})
+
+	t.Run("invalid_record", func(t *testing.T) {
+		// Create invalid JSON that will fail to unmarshal
+		invalidJSON := `{"invalid": json}`
+
+		record, err := corev1.UnmarshalRecord([]byte(invalidJSON))
+		if err != nil {
+			// If unmarshaling fails, we can't test ExtractLabels
+			t.Skip("Invalid JSON test skipped - unmarshal failed as expected")
+
+			return
+		}
+
+		labelList := labels.ExtractLabels(record).RoutingLabels()
+		// Should handle gracefully and return nil or empty slice
+		assert.Empty(t, labelList)
+	})
+
+	t.Run("nil_record", func(t *testing.T) {
+		labelList := labels.ExtractLabels(nil).RoutingLabels()
+		assert.Nil(t, labelList)
+	})
+}
+
+func TestParseExtension(t *testing.T) {
+	extractor, err := labels.NewExtractor(config.Config{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		extension     string
+		expectedType  types.LabelType
+		expectedValue string
+	}{
+		{"feature", "schema.oasf.agntcy.org/features/runtime/framework", types.LabelTypeModule, "runtime/framework"},
+		{"domain", "schema.oasf.agntcy.org/domains/x/y", types.LabelTypeDomain, "x/y"},
+		{"skill", "schema.oasf.agntcy.org/skills/nlp/text_completion", types.LabelTypeSkill, "nlp/text_completion"},
+		{"plain module", "security/authentication", types.LabelTypeModule, "security/authentication"},
+		{"surrounding whitespace", "  schema.oasf.agntcy.org/domains/x/y  ", types.LabelTypeDomain, "x/y"},
+		{"trailing slash", "schema.oasf.agntcy.org/domains/x/", types.LabelTypeDomain, "x"},
+		{"prefix only", "schema.oasf.agntcy.org/domains/", types.LabelTypeDomain, ""},
+		{"empty", "", types.LabelTypeModule, ""},
+		{"unknown schema namespace", "schema.oasf.agntcy.org/other/x", types.LabelTypeModule, "schema.oasf.agntcy.org/other/x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labelType, value := extractor.ParseExtension(tt.extension)
+			assert.Equal(t, tt.expectedType, labelType)
+			assert.Equal(t, tt.expectedValue, value)
+		})
+	}
+}
+
+func TestExtractLabelsExtensions(t *testing.T) {
+	recordJSON := `{
+		"name": "test-agent",
+		"version": "1.0.0",
+		"schema_version": "v0.3.1",
+		"skills": [
+			{"category_name": "nlp", "class_name": "text_completion"},
+			{"category_name": "nlp", "class_name": "text_completion"}
+		],
+		"locators": [
+			{"type": "docker-image", "url": "https://example.com/a"},
+			{"type": "docker-image", "url": "https://example.com/b"}
+		],
+		"extensions": [
+			{"name": "schema.oasf.agntcy.org/features/runtime/framework", "version": "v0.0.0"},
+			{"name": "schema.oasf.agntcy.org/features/runtime/framework", "version": "v0.0.1"},
+			{"name": "schema.oasf.agntcy.org/domains/healthcare/medical_technology", "version": "v0.0.0"},
+			{"name": "schema.oasf.agntcy.org/domains/", "version": "v0.0.0"}
+		],
+		"annotations": {"team": "agents"}
+	}`
+
+	record, err := corev1.UnmarshalRecord([]byte(recordJSON))
+	require.NoError(t, err)
+
+	// Duplicates and malformed names are dropped
+	assert.Equal(t, labels.Labels{
+		Skills:      []string{"nlp/text_completion"},
+		Domains:     []string{"healthcare/medical_technology"},
+		Modules:     []string{"runtime/framework"},
+		Locators:    []string{"docker-image"},
+		Annotations: map[string]string{"team": "agents"},
+	}, labels.ExtractLabels(record))
+}
+
+func TestExtractorConfig(t *testing.T) {
+	record, err := corev1.UnmarshalRecord([]byte(`{
+		"name": "test-agent",
+		"version": "1.0.0",
+		"schema_version": "0.7.0",
+		"modules": [
+			{"name": "example.com/domains/finance"},
+			{"name": "example.com/domains/finance/banking"}
+		]
+	}`))
+	require.NoError(t, err)
+
+	t.Run("custom prefixes", func(t *testing.T) {
+		extractor, err := labels.NewExtractor(config.Config{
+			ExtensionPrefixes: map[string]string{
+				"example.com/domains/":         "domains",
+				"example.com/domains/finance/": "skills",
+			},
+		})
+		require.NoError(t, err)
+
+		// The most specific prefix wins
+		recordLabels := extractor.Extract(adapters.NewRecordAdapter(record))
+		assert.Equal(t, []string{"finance"}, recordLabels.Domains)
+		assert.Equal(t, []string{"banking"}, recordLabels.Skills)
+		assert.Empty(t, recordLabels.Modules)
+	})
+
+	t.Run("invalid label type", func(t *testing.T) {
+		_, err := labels.NewExtractor(config.Config{
+			ExtensionPrefixes: map[string]string{"example.com/domains/": "unknown"},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("empty prefix", func(t *testing.T) {
+		_, err := labels.NewExtractor(config.Config{
+			ExtensionPrefixes: map[string]string{"/": "domains"},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestExtractLabelsMatchesShared(t *testing.T) {
+	// dirctl derives labels with the shared extractor, it must agree with the announced labels
+	record, err := corev1.UnmarshalRecord([]byte(`{
+		"name": "test-agent",
+		"version": "1.0.0",
+		"schema_version": "v0.3.1",
+		"skills": [{"category_name": "nlp", "class_name": "text_completion"}],
+		"locators": [{"type": "docker-image", "url": "https://example.com/a"}],
+		"extensions": [
+			{"name": "schema.oasf.agntcy.org/features/runtime/framework", "version": "v0.0.0"},
+			{"name": "schema.oasf.agntcy.org/skills/nlp/summarization", "version": "v0.0.0"}
+		]
+	}`))
+	require.NoError(t, err)
+
+	extractor, err := apilabels.NewExtractor(nil)
+	require.NoError(t, err)
+
+	shared, err := extractor.ExtractRecord(record)
+	require.NoError(t, err)
+
+	expected := make([]types.Label, 0, len(shared))
+	for _, label := range shared {
+		expected = append(expected, types.Label(label.String()))
+	}
+
+	assert.Equal(t, expected, labels.ExtractLabels(record).RoutingLabels())
+}
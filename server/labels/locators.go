@@ -0,0 +1,76 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package labels
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/agntcy/dir/server/types"
+)
+
+// Locator annotations describing the target of a locator.
+// Values may list several targets separated by commas, e.g. "amd64,arm64".
+const (
+	// LocatorAnnotationArch is the CPU architecture the locator targets, e.g. "arm64".
+	LocatorAnnotationArch = "arch"
+	// LocatorAnnotationPlatform is the platform the locator targets, e.g. "linux/arm64".
+	// Only the architecture of the platform is used.
+	LocatorAnnotationPlatform = "platform"
+	// LocatorAnnotationRuntime is the runtime the locator targets, e.g. "kubernetes".
+	LocatorAnnotationRuntime = "runtime"
+)
+
+// LocatorVariants returns the locator types qualified with the architectures and runtimes
+// parsed from the locator annotations, e.g. "helm.arm64" and "helm.kubernetes".
+// Values are lowercased, deduplicated and kept in locator order.
+func LocatorVariants(locators []types.Locator) []string {
+	var variants []string
+
+	for _, locator := range locators {
+		locatorType := strings.ToLower(strings.TrimSpace(locator.GetType()))
+		if locatorType == "" {
+			continue
+		}
+
+		for _, attribute := range locatorAttributes(locator.GetAnnotations()) {
+			variant := locatorType + "." + attribute
+			if !slices.Contains(variants, variant) {
+				variants = append(variants, variant)
+			}
+		}
+	}
+
+	return variants
+}
+
+// locatorAttributes returns the architectures followed by the runtimes in the locator annotations.
+func locatorAttributes(annotations map[string]string) []string {
+	var attributes []string
+
+	add := func(value string) {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value != "" && !slices.Contains(attributes, value) {
+			attributes = append(attributes, value)
+		}
+	}
+
+	for _, arch := range strings.Split(annotations[LocatorAnnotationArch], ",") {
+		add(arch)
+	}
+
+	for _, platform := range strings.Split(annotations[LocatorAnnotationPlatform], ",") {
+		// Platforms are "os/arch[/variant]", the architecture variant is dropped
+		if _, arch, ok := strings.Cut(platform, "/"); ok {
+			arch, _, _ = strings.Cut(arch, "/")
+			add(arch)
+		}
+	}
+
+	for _, runtime := range strings.Split(annotations[LocatorAnnotationRuntime], ",") {
+		add(runtime)
+	}
+
+	return attributes
+}
@@ -0,0 +1,55 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package labels_test
+
+import (
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/labels"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMultiArchRecord returns a record with 4 locators targeting 2 architectures each.
+func newMultiArchRecord() *corev1.Record {
+	return corev1.New(&typesv1alpha1.Record{
+		Name:          "multi-arch-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+		Locators: []*typesv1alpha1.Locator{
+			{Type: "docker_image", Url: "ghcr.io/example/agent", Annotations: map[string]string{"platform": "linux/amd64,linux/arm64/v8"}},
+			{Type: "helm_chart", Url: "oci://ghcr.io/example/charts/agent", Annotations: map[string]string{"arch": "amd64, ARM64", "runtime": "kubernetes"}},
+			{Type: "source_code", Url: "https://github.com/example/agent", Annotations: map[string]string{"arch": "amd64,arm64"}},
+			{Type: "binary", Url: "https://example.com/agent", Annotations: map[string]string{"arch": "amd64,arm64", "runtime": "linux"}},
+		},
+	})
+}
+
+func TestLocatorVariants(t *testing.T) {
+	extracted := labels.ExtractLabels(newMultiArchRecord())
+
+	assert.Equal(t, []string{"docker_image", "helm_chart", "source_code", "binary"}, extracted.Locators)
+	assert.Equal(t, []string{
+		"docker_image.amd64", "docker_image.arm64",
+		"helm_chart.amd64", "helm_chart.arm64", "helm_chart.kubernetes",
+		"source_code.amd64", "source_code.arm64",
+		"binary.amd64", "binary.arm64", "binary.linux",
+	}, extracted.LocatorVariants)
+
+	// Variants are not announced for routing
+	for _, label := range extracted.RoutingLabels() {
+		assert.NotContains(t, label.String(), ".arm64")
+	}
+
+	t.Run("locators without attributes have no variants", func(t *testing.T) {
+		record := corev1.New(&typesv1alpha1.Record{
+			Name:          "plain-agent",
+			SchemaVersion: "0.7.0",
+			Locators:      []*typesv1alpha1.Locator{{Type: "docker_image", Url: "ghcr.io/example/plain"}},
+		})
+
+		assert.Empty(t, labels.ExtractLabels(record).LocatorVariants)
+	})
+}
@@ -0,0 +1,18 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+// DefaultPath is the HTTP path metrics are served on.
+const DefaultPath = "/metrics"
+
+// Config contains configuration for serving Prometheus metrics.
+type Config struct {
+	// Address the metrics endpoint listens on, e.g. "0.0.0.0:9090".
+	// Metrics are not served if empty.
+	ListenAddress string `json:"listen_address,omitempty" mapstructure:"listen_address"`
+}
+
+func (c *Config) Enabled() bool {
+	return c.ListenAddress != ""
+}
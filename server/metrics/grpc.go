@@ -0,0 +1,77 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const grpcSubsystem = "grpc_server"
+
+// grpcMetrics holds the collectors recorded by the gRPC interceptors.
+type grpcMetrics struct {
+	handled *prometheus.CounterVec
+	latency *prometheus.HistogramVec
+}
+
+func newGRPCMetrics(registerer prometheus.Registerer) (*grpcMetrics, error) {
+	m := &grpcMetrics{
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: grpcSubsystem,
+			Name:      "handled_total",
+			Help:      "Total number of RPCs completed by the server, by gRPC code.",
+		}, []string{"method", "code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: grpcSubsystem,
+			Name:      "handling_seconds",
+			Help:      "Duration of RPCs from start until completion.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+	}
+
+	var err error
+
+	m.handled, err = Register(registerer, m.handled)
+	if err != nil {
+		return nil, err
+	}
+
+	m.latency, err = Register(registerer, m.latency)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// done records the completion of an RPC.
+func (m *grpcMetrics) done(method string, start time.Time, err error) {
+	m.latency.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	m.handled.WithLabelValues(method, status.Code(err).String()).Inc()
+}
+
+func (m *grpcMetrics) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+	m.done(info.FullMethod, start, err)
+
+	return resp, err
+}
+
+func (m *grpcMetrics) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+
+	err := handler(srv, ss)
+	m.done(info.FullMethod, start, err)
+
+	return err
+}
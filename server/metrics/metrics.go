@@ -0,0 +1,121 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics serves Prometheus metrics of the server, including gRPC request
+// metrics and the metrics registered by components such as the OCI store.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/agntcy/dir/server/metrics/config"
+	"github.com/agntcy/dir/utils/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+)
+
+const (
+	// Namespace is the namespace of all server metrics.
+	Namespace = "dir"
+
+	// readHeaderTimeout limits the time to read request headers.
+	readHeaderTimeout = 10 * time.Second
+
+	// shutdownTimeout limits the time to wait for in-flight scrapes on stop.
+	shutdownTimeout = 5 * time.Second
+)
+
+var logger = logging.Logger("metrics")
+
+// Register registers the collector, reusing an identical collector if one
+// was already registered, so that components can be created more than once.
+func Register[T prometheus.Collector](registerer prometheus.Registerer, collector T) (T, error) {
+	if err := registerer.Register(collector); err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if errors.As(err, &alreadyRegistered) {
+			if existing, ok := alreadyRegistered.ExistingCollector.(T); ok {
+				return existing, nil
+			}
+		}
+
+		return collector, err //nolint:wrapcheck
+	}
+
+	return collector, nil
+}
+
+// Service records gRPC request metrics and serves all metrics
+// of the default Prometheus registry over HTTP.
+type Service struct {
+	cfg config.Config
+
+	grpc       *grpcMetrics
+	httpServer *http.Server
+}
+
+// New creates a metrics service.
+func New(cfg config.Config) (*Service, error) {
+	grpcMetrics, err := newGRPCMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register gRPC metrics: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(config.DefaultPath, promhttp.Handler())
+
+	logger.Info("Metrics service initialized", "address", cfg.ListenAddress, "path", config.DefaultPath)
+
+	return &Service{
+		cfg:  cfg,
+		grpc: grpcMetrics,
+		httpServer: &http.Server{
+			Addr:              cfg.ListenAddress,
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}, nil
+}
+
+// GetServerOptions returns gRPC server options recording request metrics.
+func (s *Service) GetServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(s.grpc.unaryInterceptor),
+		grpc.ChainStreamInterceptor(s.grpc.streamInterceptor),
+	}
+}
+
+// Start starts serving metrics in the background.
+func (s *Service) Start(ctx context.Context) error {
+	listen, err := (&net.ListenConfig{}).Listen(ctx, "tcp", s.cfg.ListenAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.cfg.ListenAddress, err)
+	}
+
+	go func() {
+		logger.Info("Metrics endpoint starting", "address", s.cfg.ListenAddress)
+
+		if err := s.httpServer.Serve(listen); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Failed to serve metrics", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops serving metrics.
+func (s *Service) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shut down metrics endpoint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/dir/server/metrics/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegisterTwice(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	first, err := newGRPCMetrics(registry)
+	require.NoError(t, err)
+
+	second, err := newGRPCMetrics(registry)
+	require.NoError(t, err)
+
+	// Collectors are shared, so metrics recorded by either are exported once
+	assert.Same(t, first.handled, second.handled)
+	assert.Same(t, first.latency, second.latency)
+}
+
+func TestRegisterConflict(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	_, err := Register(registry, prometheus.NewCounter(prometheus.CounterOpts{Name: "conflict", Help: "counter"}))
+	require.NoError(t, err)
+
+	_, err = Register(registry, prometheus.NewGauge(prometheus.GaugeOpts{Name: "conflict", Help: "gauge"}))
+	assert.Error(t, err)
+}
+
+func TestGRPCMetrics(t *testing.T) {
+	m, err := newGRPCMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/agntcy.dir.store.v1.StoreService/PushDryRun"}
+
+	_, err = m.unaryInterceptor(t.Context(), nil, info, func(context.Context, any) (any, error) {
+		return "ok", nil
+	})
+	require.NoError(t, err)
+
+	_, err = m.unaryInterceptor(t.Context(), nil, info, func(context.Context, any) (any, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	})
+	require.Error(t, err)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(m.handled.WithLabelValues(info.FullMethod, codes.OK.String())), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.handled.WithLabelValues(info.FullMethod, codes.NotFound.String())), 0)
+	assert.Equal(t, 1, testutil.CollectAndCount(m.latency))
+}
+
+func TestServeMetrics(t *testing.T) {
+	// Services share the default registry, creating more than one must not fail
+	_, err := New(config.Config{ListenAddress: "127.0.0.1:0"})
+	require.NoError(t, err)
+
+	service, err := New(config.Config{ListenAddress: "127.0.0.1:0"})
+	require.NoError(t, err)
+
+	service.grpc.handled.WithLabelValues("/test.Service/Method", codes.OK.String()).Inc()
+
+	recorder := httptest.NewRecorder()
+	service.httpServer.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, config.DefaultPath, nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, strings.Contains(recorder.Body.String(), "dir_grpc_server_handled_total"))
+}
@@ -7,21 +7,28 @@ import (
 	"context"
 	"sync"
 
+	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/server/publication/config"
 	publypes "github.com/agntcy/dir/server/publication/types"
 	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/agntcy/dir/server/webhooks"
 	"github.com/agntcy/dir/utils/logging"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var logger = logging.Logger("publication")
 
 // Service manages the publication operations.
 type Service struct {
-	db      types.DatabaseAPI
-	store   types.StoreAPI
-	routing types.RoutingAPI
-	config  config.Config
+	db       types.DatabaseAPI
+	store    types.StoreAPI
+	routing  types.RoutingAPI
+	webhooks *webhooks.Dispatcher
+	config   config.Config
 
 	scheduler *Scheduler
 	workers   []*Worker
@@ -31,13 +38,15 @@ type Service struct {
 }
 
 // New creates a new publication service.
-func New(db types.DatabaseAPI, store types.StoreAPI, routing types.RoutingAPI, opts types.APIOptions) (*Service, error) {
+// Published records are reported to the webhook dispatcher, which may be nil.
+func New(db types.DatabaseAPI, store types.StoreAPI, routing types.RoutingAPI, webhookDispatcher *webhooks.Dispatcher, opts types.APIOptions) (*Service, error) {
 	return &Service{
-		db:      db,
-		store:   store,
-		routing: routing,
-		config:  opts.Config().Publication,
-		stopCh:  make(chan struct{}),
+		db:       db,
+		store:    store,
+		routing:  routing,
+		webhooks: webhookDispatcher,
+		config:   opts.Config().Publication,
+		stopCh:   make(chan struct{}),
 	}, nil
 }
 
@@ -46,6 +55,52 @@ func (s *Service) CreatePublication(_ context.Context, req *routingv1.PublishReq
 	return s.db.CreatePublication(req) //nolint:wrapcheck
 }
 
+// PublishAtomic publishes the referenced records together in a new transaction, returning its ID.
+// All records are looked up and their labels computed before anything is announced, and the routing
+// layer commits their announcements together, so that either all records are announced or none of them.
+// Unlike publications, the records are announced before returning.
+func (s *Service) PublishAtomic(ctx context.Context, refs []*corev1.RecordRef, opts types.PublishOptions) (string, error) {
+	atomicRouting, ok := s.routing.(types.AtomicRoutingAPI)
+	if !ok {
+		return "", status.Error(codes.Unimplemented, "routing does not support atomic publication") //nolint:wrapcheck
+	}
+
+	records := make([]*corev1.Record, 0, len(refs))
+	adapted := make([]types.Record, 0, len(refs))
+
+	for i, ref := range refs {
+		if _, err := s.store.Lookup(ctx, ref); err != nil {
+			st := status.Convert(err)
+
+			return "", status.Errorf(st.Code(), "record %d (%s): failed to lookup record: %s", i, ref.GetCid(), st.Message())
+		}
+
+		record, err := s.store.Pull(ctx, ref)
+		if err != nil {
+			st := status.Convert(err)
+
+			return "", status.Errorf(st.Code(), "record %d (%s): failed to pull record: %s", i, ref.GetCid(), st.Message())
+		}
+
+		records = append(records, record)
+		adapted = append(adapted, adapters.NewRecordAdapter(record))
+	}
+
+	transactionID := uuid.NewString()
+
+	if err := atomicRouting.PublishAtomic(ctx, transactionID, adapted, opts); err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	for _, record := range records {
+		published(s.db, s.webhooks, transactionID, record)
+	}
+
+	logger.Info("Published records atomically", "transaction_id", transactionID, "records", len(records))
+
+	return transactionID, nil
+}
+
 // Start begins the publication service operations.
 func (s *Service) Start(ctx context.Context) error {
 	logger.Info("Starting publication service", "workers", s.config.WorkerCount, "interval", s.config.SchedulerInterval)
@@ -59,7 +114,7 @@ func (s *Service) Start(ctx context.Context) error {
 	// Create and start workers
 	s.workers = make([]*Worker, s.config.WorkerCount)
 	for i := range s.config.WorkerCount {
-		s.workers[i] = NewWorker(i, s.db, s.store, s.routing, workQueue, s.config.WorkerTimeout)
+		s.workers[i] = NewWorker(i, s.db, s.store, s.routing, s.webhooks, workQueue, s.config.WorkerTimeout)
 	}
 
 	// Start scheduler
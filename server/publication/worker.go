@@ -12,9 +12,11 @@ import (
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	databaseutils "github.com/agntcy/dir/server/database/utils"
+	"github.com/agntcy/dir/server/labels"
 	publypes "github.com/agntcy/dir/server/publication/types"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/agntcy/dir/server/webhooks"
 )
 
 // Worker processes publication requests from the work queue.
@@ -23,17 +25,19 @@ type Worker struct {
 	db        types.DatabaseAPI
 	store     types.StoreAPI
 	routing   types.RoutingAPI
+	webhooks  *webhooks.Dispatcher
 	workQueue <-chan publypes.WorkItem
 	timeout   time.Duration
 }
 
 // NewWorker creates a new worker instance.
-func NewWorker(id int, db types.DatabaseAPI, store types.StoreAPI, routing types.RoutingAPI, workQueue <-chan publypes.WorkItem, timeout time.Duration) *Worker {
+func NewWorker(id int, db types.DatabaseAPI, store types.StoreAPI, routing types.RoutingAPI, webhookDispatcher *webhooks.Dispatcher, workQueue <-chan publypes.WorkItem, timeout time.Duration) *Worker {
 	return &Worker{
 		id:        id,
 		db:        db,
 		store:     store,
 		routing:   routing,
+		webhooks:  webhookDispatcher,
 		workQueue: workQueue,
 		timeout:   timeout,
 	}
@@ -103,8 +107,14 @@ func (w *Worker) processPublication(ctx context.Context, workItem publypes.WorkI
 	// Announce each CID to the DHT
 	successCount := 0
 
+	opts := types.PublishOptions{
+		TTL:       request.GetTtl().AsDuration(),
+		Namespace: request.GetNamespace(),
+		Public:    request.GetVisibility() == routingv1.Visibility_VISIBILITY_PUBLIC,
+	}
+
 	for _, cid := range cids {
-		if err := w.announceToDHT(timeoutCtx, cid); err != nil {
+		if err := w.announceToDHT(timeoutCtx, workItem.PublicationID, cid, opts); err != nil {
 			logger.Error("Failed to announce CID to DHT", "publication_id", workItem.PublicationID, "cid", cid, "error", err)
 		} else {
 			successCount++
@@ -151,8 +161,9 @@ func (w *Worker) getCIDsFromRequest(_ context.Context, request *routingv1.Publis
 	}
 }
 
-// announceToDHT announces a single CID to the DHT.
-func (w *Worker) announceToDHT(ctx context.Context, cid string) error {
+// announceToDHT announces a single CID to the DHT and pins the record to protect it from deletion.
+// The TTL, namespace and visibility are passed to routing implementations that support them.
+func (w *Worker) announceToDHT(ctx context.Context, publicationID, cid string, opts types.PublishOptions) error {
 	// Create a RecordRef for the CID
 	recordRef := &corev1.RecordRef{
 		Cid: cid,
@@ -168,14 +179,41 @@ func (w *Worker) announceToDHT(ctx context.Context, cid string) error {
 	adapter := adapters.NewRecordAdapter(record)
 
 	// Publish the record to the network
-	err = w.routing.Publish(ctx, adapter)
+	if optsRouting, ok := w.routing.(interface {
+		PublishWithOptions(ctx context.Context, record types.Record, opts types.PublishOptions) error
+	}); ok {
+		err = optsRouting.PublishWithOptions(ctx, adapter, opts)
+	} else {
+		err = w.routing.Publish(ctx, adapter)
+	}
+
 	if err != nil {
 		return fmt.Errorf("failed to publish record to network: %w", err)
 	}
 
+	published(w.db, w.webhooks, publicationID, record)
+
 	return nil
 }
 
+// published notifies webhooks of a published record and pins it to protect it from deletion.
+// The record is announced even if pinning fails, so the publication does not fail.
+func published(db types.DatabaseAPI, dispatcher *webhooks.Dispatcher, publicationID string, record *corev1.Record) {
+	dispatcher.Notify(webhooks.RecordEvent(webhooks.EventPublished, record))
+
+	routingLabels := labels.FromRecord(adapters.NewRecordAdapter(record)).RoutingLabels()
+
+	pinLabels := make([]string, 0, len(routingLabels))
+	for _, label := range routingLabels {
+		pinLabels = append(pinLabels, label.String())
+	}
+
+	pin := types.RecordPin{CID: record.GetCid(), PublicationID: publicationID, Labels: pinLabels}
+	if err := db.PinRecord(pin); err != nil {
+		logger.Error("Failed to pin published record", "publication_id", publicationID, "cid", record.GetCid(), "error", err)
+	}
+}
+
 // markPublicationCompleted marks a publication as completed.
 func (w *Worker) markPublicationCompleted(publicationID string) {
 	if err := w.db.UpdatePublicationStatus(publicationID, routingv1.PublicationStatus_PUBLICATION_STATUS_COMPLETED); err != nil {
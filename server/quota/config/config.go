@@ -0,0 +1,111 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultReaperInterval = 1 * time.Hour
+	DefaultReaperAction   = ReaperActionFlag
+)
+
+// ReaperAction is what the reaper does with records that exceeded their maximum age.
+type ReaperAction string
+
+const (
+	// ReaperActionFlag flags expired records, which are reported in the usage of their trust domain.
+	ReaperActionFlag ReaperAction = "flag"
+
+	// ReaperActionDelete deletes expired records from the store.
+	ReaperActionDelete ReaperAction = "delete"
+)
+
+// Limits are the quotas of a trust domain.
+// Zero values are unlimited.
+type Limits struct {
+	// Maximum number of records
+	MaxRecords uint64 `json:"max_records,omitempty" mapstructure:"max_records"`
+
+	// Maximum total size of the records in bytes
+	MaxBytes uint64 `json:"max_bytes,omitempty" mapstructure:"max_bytes"`
+
+	// Maximum time a record is kept since it was last pushed, pulled or looked up
+	MaxAge time.Duration `json:"max_age,omitempty" mapstructure:"max_age"`
+}
+
+// Rule overrides the default limits for a trust domain.
+type Rule struct {
+	// Trust domain of the caller, e.g. "example.org".
+	TrustDomain string `json:"trust_domain,omitempty" mapstructure:"trust_domain"`
+
+	Limits `mapstructure:",squash"`
+}
+
+// Config contains configuration for record quotas and retention.
+// Records are accounted to the trust domain of the caller that pushed them,
+// callers without an authenticated identity share the empty trust domain.
+type Config struct {
+	// Indicates if quotas and retention are enforced
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Limits for trust domains without a matching rule
+	Default Limits `json:"default,omitempty" mapstructure:"default"`
+
+	// Limits for specific trust domains
+	Rules []Rule `json:"rules,omitempty" mapstructure:"rules"`
+
+	// Interval at which records that exceeded their maximum age are expired
+	ReaperInterval time.Duration `json:"reaper_interval,omitempty" mapstructure:"reaper_interval"`
+
+	// Action taken on expired records, "flag" or "delete".
+	// Records with the "protected" annotation set to "true" are never expired.
+	ReaperAction ReaperAction `json:"reaper_action,omitempty" mapstructure:"reaper_action"`
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.ReaperInterval <= 0 {
+		return errors.New("reaper interval must be positive")
+	}
+
+	switch c.ReaperAction {
+	case ReaperActionFlag, ReaperActionDelete:
+	default:
+		return fmt.Errorf("invalid reaper action %q: expected %q or %q", c.ReaperAction, ReaperActionFlag, ReaperActionDelete)
+	}
+
+	for i, rule := range c.Rules {
+		if rule.TrustDomain == "" {
+			return fmt.Errorf("rule %d: trust domain is required", i)
+		}
+
+		if rule.MaxAge < 0 {
+			return fmt.Errorf("rule %d: max age must not be negative", i)
+		}
+	}
+
+	if c.Default.MaxAge < 0 {
+		return errors.New("default max age must not be negative")
+	}
+
+	return nil
+}
+
+// LimitsFor returns the limits of the trust domain.
+func (c *Config) LimitsFor(trustDomain string) Limits {
+	for _, rule := range c.Rules {
+		if rule.TrustDomain == trustDomain {
+			return rule.Limits
+		}
+	}
+
+	return c.Default
+}
@@ -0,0 +1,244 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/server/quota/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProtectedAnnotation is the record annotation that exempts a record from expiry when set to "true".
+const ProtectedAnnotation = "protected"
+
+var logger = logging.Logger("quota")
+
+// Service accounts pushed records to the trust domain of the caller,
+// enforces the configured quotas on push and expires unused records.
+// Usage is accounted even when quotas are not enforced.
+type Service struct {
+	cfg   config.Config
+	db    types.DatabaseAPI
+	store types.StoreAPI
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates a new quota service.
+func New(cfg config.Config, db types.DatabaseAPI, store types.StoreAPI) (*Service, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid quota config: %w", err)
+	}
+
+	return &Service{
+		cfg:    cfg,
+		db:     db,
+		store:  store,
+		stopCh: make(chan struct{}),
+	}, nil
+}
+
+// trustDomainFromContext returns the trust domain of the caller, empty if unauthenticated.
+func trustDomainFromContext(ctx context.Context) string {
+	if sid, ok := authn.SpiffeIDFromContext(ctx); ok {
+		return sid.TrustDomain().String()
+	}
+
+	return ""
+}
+
+// recordSize returns the size of the canonical record data.
+func recordSize(record *corev1.Record) (uint64, error) {
+	data, err := record.Marshal()
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	return uint64(len(data)), nil
+}
+
+// CheckPush verifies that the record can be pushed within the quota of the caller's trust domain.
+// Records that are already accounted are always accepted.
+// It returns a ResourceExhausted error with the current usage and the exceeded quota
+// in the error details if a quota is exceeded.
+func (s *Service) CheckPush(ctx context.Context, record *corev1.Record) error {
+	if !s.cfg.Enabled {
+		return nil
+	}
+
+	trustDomain := trustDomainFromContext(ctx)
+
+	limits := s.cfg.LimitsFor(trustDomain)
+	if limits.MaxRecords == 0 && limits.MaxBytes == 0 {
+		return nil
+	}
+
+	exists, err := s.db.HasRecordUsage(record.GetCid())
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check quota: %v", err)
+	}
+
+	if exists {
+		return nil
+	}
+
+	size, err := recordSize(record)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to check quota: %v", err)
+	}
+
+	usage, err := s.usage(trustDomain)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check quota: %v", err)
+	}
+
+	var exceeded string
+
+	switch {
+	case limits.MaxRecords > 0 && usage.GetRecordCount()+1 > limits.MaxRecords:
+		exceeded = fmt.Sprintf("record count quota of %d records", limits.MaxRecords)
+	case limits.MaxBytes > 0 && usage.GetTotalBytes()+size > limits.MaxBytes:
+		exceeded = fmt.Sprintf("storage quota of %d bytes", limits.MaxBytes)
+	default:
+		return nil
+	}
+
+	logger.Debug("Quota exceeded", "trust_domain", trustDomain, "cid", record.GetCid(), "quota", exceeded)
+
+	st := status.Newf(codes.ResourceExhausted,
+		"push would exceed the %s of trust domain %q: %d records, %d bytes used",
+		exceeded, trustDomain, usage.GetRecordCount(), usage.GetTotalBytes())
+
+	violation := &errdetails.QuotaFailure_Violation{
+		Subject:     "trust_domain:" + trustDomain,
+		Description: "push would exceed the " + exceeded,
+	}
+
+	if detailed, err := st.WithDetails(usage, &errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{violation}}); err == nil {
+		st = detailed
+	}
+
+	return st.Err() //nolint:wrapcheck
+}
+
+// RecordPush accounts a pushed record to the caller's trust domain.
+func (s *Service) RecordPush(ctx context.Context, record *corev1.Record) error {
+	size, err := recordSize(record)
+	if err != nil {
+		return err
+	}
+
+	var protected bool
+	if data, err := adapters.NewRecordAdapter(record).GetRecordData(); err == nil {
+		protected = data.GetAnnotations()[ProtectedAnnotation] == "true"
+	}
+
+	//nolint:wrapcheck
+	return s.db.AddRecordUsage(types.RecordUsage{
+		CID:         record.GetCid(),
+		TrustDomain: trustDomainFromContext(ctx),
+		SizeBytes:   size,
+		Protected:   protected,
+		AccessedAt:  time.Now(),
+	})
+}
+
+// CheckOwner verifies that the record is owned by the caller's trust domain.
+// Records that are not accounted to a trust domain, e.g. records pushed before
+// usage accounting was introduced, can be changed by any caller.
+// It returns a PermissionDenied error if the record is owned by another trust domain.
+func (s *Service) CheckOwner(ctx context.Context, cid string) error {
+	owner, exists, err := s.db.GetRecordOwner(cid)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check record owner: %v", err)
+	}
+
+	if !exists || owner == "" {
+		return nil
+	}
+
+	if trustDomain := trustDomainFromContext(ctx); trustDomain != owner {
+		return status.Errorf(codes.PermissionDenied, "record %s is owned by trust domain %q", cid, owner)
+	}
+
+	return nil
+}
+
+// RecordAccess marks the record as used, which resets its age.
+func (s *Service) RecordAccess(cid string) error {
+	return s.db.TouchRecordUsage(cid, time.Now()) //nolint:wrapcheck
+}
+
+// RecordDelete releases the quota used by a deleted record.
+func (s *Service) RecordDelete(cid string) error {
+	return s.db.RemoveRecordUsage(cid) //nolint:wrapcheck
+}
+
+// Usage returns the usage and quotas of the trust domain,
+// or of all trust domains owning records if the trust domain is nil.
+func (s *Service) Usage(trustDomain *string) ([]*storev1.QuotaUsage, error) {
+	var trustDomains []string
+	if trustDomain != nil {
+		trustDomains = append(trustDomains, *trustDomain)
+	}
+
+	usages, err := s.db.GetUsage(trustDomains...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+
+	// Report trust domains without records too when they are requested explicitly
+	if trustDomain != nil && len(usages) == 0 {
+		usages = append(usages, types.TrustDomainUsage{TrustDomain: *trustDomain})
+	}
+
+	result := make([]*storev1.QuotaUsage, 0, len(usages))
+	for _, usage := range usages {
+		result = append(result, s.toQuotaUsage(usage))
+	}
+
+	return result, nil
+}
+
+// usage returns the usage and quotas of a single trust domain.
+func (s *Service) usage(trustDomain string) (*storev1.QuotaUsage, error) {
+	usages, err := s.Usage(&trustDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	return usages[0], nil
+}
+
+func (s *Service) toQuotaUsage(usage types.TrustDomainUsage) *storev1.QuotaUsage {
+	quotaUsage := &storev1.QuotaUsage{
+		TrustDomain:        usage.TrustDomain,
+		RecordCount:        usage.RecordCount,
+		TotalBytes:         usage.TotalBytes,
+		ExpiredRecordCount: usage.ExpiredRecordCount,
+	}
+
+	if s.cfg.Enabled {
+		limits := s.cfg.LimitsFor(usage.TrustDomain)
+
+		quotaUsage.MaxRecordCount = limits.MaxRecords
+		quotaUsage.MaxTotalBytes = limits.MaxBytes
+		quotaUsage.MaxAgeSeconds = uint64(limits.MaxAge.Seconds())
+	}
+
+	return quotaUsage
+}
@@ -0,0 +1,306 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/server/database/sqlite"
+	"github.com/agntcy/dir/server/quota/config"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testStore is a minimal in-memory store.
+type testStore struct {
+	mu      sync.Mutex
+	records map[string]*corev1.Record
+}
+
+func (s *testStore) Push(_ context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[record.GetCid()] = record
+
+	return &corev1.RecordRef{Cid: record.GetCid()}, nil
+}
+
+func (s *testStore) Pull(_ context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[ref.GetCid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", ref.GetCid())
+	}
+
+	return record, nil
+}
+
+func (s *testStore) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	if _, err := s.Pull(ctx, ref); err != nil {
+		return nil, err
+	}
+
+	return &corev1.RecordMeta{Cid: ref.GetCid()}, nil
+}
+
+func (s *testStore) Delete(_ context.Context, ref *corev1.RecordRef) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, ref.GetCid())
+
+	return nil
+}
+
+func newTestService(t *testing.T, cfg config.Config) (*Service, *testStore) {
+	t.Helper()
+
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "dir.db"))
+	require.NoError(t, err)
+
+	store := &testStore{records: make(map[string]*corev1.Record)}
+
+	service, err := New(cfg, db, store)
+	require.NoError(t, err)
+
+	return service, store
+}
+
+func newTestRecord(name string, annotations map[string]string) *corev1.Record {
+	return corev1.New(&typesv1alpha0.Record{
+		Name:          name,
+		Version:       "v1.0.0",
+		SchemaVersion: "v0.3.1",
+		Annotations:   annotations,
+	})
+}
+
+// contextFor returns a context authenticated as a workload of the trust domain.
+func contextFor(t *testing.T, trustDomain string) context.Context {
+	t.Helper()
+
+	id, err := spiffeid.FromSegments(spiffeid.RequireTrustDomainFromString(trustDomain), "client")
+	require.NoError(t, err)
+
+	return context.WithValue(t.Context(), authn.SpiffeIDContextKey, id)
+}
+
+// push checks the quota, stores and accounts a record like the store controller does.
+func push(ctx context.Context, s *Service, store *testStore, record *corev1.Record) error {
+	if err := s.CheckPush(ctx, record); err != nil {
+		return err
+	}
+
+	if _, err := store.Push(ctx, record); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return s.RecordPush(ctx, record)
+}
+
+func TestCheckPush_QuotaExceeded(t *testing.T) {
+	service, store := newTestService(t, config.Config{
+		Enabled:        true,
+		Default:        config.Limits{MaxRecords: 2},
+		Rules:          []config.Rule{{TrustDomain: "small.org", Limits: config.Limits{MaxBytes: 1}}},
+		ReaperInterval: time.Hour,
+		ReaperAction:   config.ReaperActionFlag,
+	})
+
+	ctx := contextFor(t, "example.org")
+
+	first := newTestRecord("first", nil)
+	require.NoError(t, push(ctx, service, store, first))
+	require.NoError(t, push(ctx, service, store, newTestRecord("second", nil)))
+
+	// Pushing an accounted record again does not use more quota
+	require.NoError(t, push(ctx, service, store, first))
+
+	err := push(ctx, service, store, newTestRecord("third", nil))
+	require.Error(t, err)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 2)
+
+	usage, ok := st.Details()[0].(*storev1.QuotaUsage)
+	require.True(t, ok)
+	assert.Equal(t, "example.org", usage.GetTrustDomain())
+	assert.Equal(t, uint64(2), usage.GetRecordCount())
+	assert.Equal(t, uint64(2), usage.GetMaxRecordCount())
+	assert.Positive(t, usage.GetTotalBytes())
+
+	failure, ok := st.Details()[1].(*errdetails.QuotaFailure)
+	require.True(t, ok)
+	require.Len(t, failure.GetViolations(), 1)
+	assert.Equal(t, "trust_domain:example.org", failure.GetViolations()[0].GetSubject())
+	assert.Contains(t, failure.GetViolations()[0].GetDescription(), "record count quota")
+
+	// Quotas are tracked per trust domain
+	require.NoError(t, push(contextFor(t, "other.org"), service, store, newTestRecord("third", nil)))
+
+	err = push(contextFor(t, "small.org"), service, store, newTestRecord("fourth", nil))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestCheckPush_Disabled(t *testing.T) {
+	service, store := newTestService(t, config.Config{Default: config.Limits{MaxRecords: 1}})
+
+	ctx := contextFor(t, "example.org")
+
+	require.NoError(t, push(ctx, service, store, newTestRecord("first", nil)))
+	require.NoError(t, push(ctx, service, store, newTestRecord("second", nil)))
+
+	// Usage is accounted even if quotas are not enforced
+	usages, err := service.Usage(nil)
+	require.NoError(t, err)
+	require.Len(t, usages, 1)
+	assert.Equal(t, uint64(2), usages[0].GetRecordCount())
+	assert.Zero(t, usages[0].GetMaxRecordCount())
+}
+
+func TestUsage_AccountingAcrossDeletes(t *testing.T) {
+	service, store := newTestService(t, config.Config{
+		Enabled:        true,
+		Default:        config.Limits{MaxRecords: 1},
+		ReaperInterval: time.Hour,
+		ReaperAction:   config.ReaperActionFlag,
+	})
+
+	ctx := contextFor(t, "example.org")
+	trustDomain := "example.org"
+
+	first := newTestRecord("first", nil)
+	require.NoError(t, push(ctx, service, store, first))
+	require.Error(t, push(ctx, service, store, newTestRecord("second", nil)))
+
+	// Deleting a record releases its quota
+	require.NoError(t, store.Delete(ctx, &corev1.RecordRef{Cid: first.GetCid()}))
+	require.NoError(t, service.RecordDelete(first.GetCid()))
+
+	usages, err := service.Usage(&trustDomain)
+	require.NoError(t, err)
+	require.Len(t, usages, 1)
+	assert.Zero(t, usages[0].GetRecordCount())
+	assert.Zero(t, usages[0].GetTotalBytes())
+
+	require.NoError(t, push(ctx, service, store, newTestRecord("second", nil)))
+
+	usages, err = service.Usage(&trustDomain)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), usages[0].GetRecordCount())
+}
+
+func TestCheckOwner(t *testing.T) {
+	service, store := newTestService(t, config.Config{})
+
+	owned := newTestRecord("owned", nil)
+	require.NoError(t, push(contextFor(t, "example.org"), service, store, owned))
+
+	require.NoError(t, service.CheckOwner(contextFor(t, "example.org"), owned.GetCid()))
+
+	err := service.CheckOwner(contextFor(t, "other.org"), owned.GetCid())
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Records that are not accounted can be changed by anyone
+	require.NoError(t, service.CheckOwner(contextFor(t, "other.org"), newTestRecord("unaccounted", nil).GetCid()))
+}
+
+func TestReap(t *testing.T) {
+	for _, action := range []config.ReaperAction{config.ReaperActionFlag, config.ReaperActionDelete} {
+		t.Run(string(action), func(t *testing.T) {
+			service, store := newTestService(t, config.Config{
+				Enabled:        true,
+				Default:        config.Limits{MaxAge: time.Hour},
+				Rules:          []config.Rule{{TrustDomain: "forever.org"}},
+				ReaperInterval: time.Hour,
+				ReaperAction:   action,
+			})
+
+			ctx := contextFor(t, "example.org")
+
+			unused := newTestRecord("unused", nil)
+			used := newTestRecord("used", nil)
+			protected := newTestRecord("protected", map[string]string{ProtectedAnnotation: "true"})
+			unlimited := newTestRecord("unlimited", nil)
+
+			for _, record := range []*corev1.Record{unused, used, protected} {
+				require.NoError(t, push(ctx, service, store, record))
+			}
+
+			require.NoError(t, push(contextFor(t, "forever.org"), service, store, unlimited))
+
+			// Nothing expires before the maximum age
+			service.reap(ctx, time.Now())
+
+			usages, err := service.Usage(nil)
+			require.NoError(t, err)
+			require.Len(t, usages, 2)
+			assert.Zero(t, usages[0].GetExpiredRecordCount())
+
+			// Pulls and lookups reset the age of a record
+			later := time.Now().Add(2 * time.Hour)
+			require.NoError(t, service.db.TouchRecordUsage(used.GetCid(), later))
+
+			service.reap(ctx, later.Add(time.Minute))
+
+			_, lookupErr := store.Lookup(ctx, &corev1.RecordRef{Cid: unused.GetCid()})
+
+			trustDomain := "example.org"
+
+			usages, err = service.Usage(&trustDomain)
+			require.NoError(t, err)
+
+			switch action {
+			case config.ReaperActionFlag:
+				require.NoError(t, lookupErr)
+				assert.Equal(t, uint64(3), usages[0].GetRecordCount())
+				assert.Equal(t, uint64(1), usages[0].GetExpiredRecordCount())
+			case config.ReaperActionDelete:
+				assert.Equal(t, codes.NotFound, status.Code(lookupErr))
+				assert.Equal(t, uint64(2), usages[0].GetRecordCount())
+				assert.Zero(t, usages[0].GetExpiredRecordCount())
+			}
+
+			// Protected records, used records and records without a maximum age are kept
+			for _, record := range []*corev1.Record{used, protected, unlimited} {
+				_, err := store.Lookup(ctx, &corev1.RecordRef{Cid: record.GetCid()})
+				require.NoError(t, err, record.GetCid())
+			}
+
+			usages, err = service.Usage(nil)
+			require.NoError(t, err)
+			assert.Equal(t, "forever.org", usages[1].GetTrustDomain())
+			assert.Equal(t, uint64(1), usages[1].GetRecordCount())
+			assert.Zero(t, usages[1].GetExpiredRecordCount())
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, (&config.Config{}).Validate())
+	assert.Error(t, (&config.Config{Enabled: true, ReaperAction: config.ReaperActionFlag}).Validate())
+	assert.Error(t, (&config.Config{Enabled: true, ReaperInterval: time.Hour, ReaperAction: "archive"}).Validate())
+	assert.Error(t, (&config.Config{
+		Enabled:        true,
+		ReaperInterval: time.Hour,
+		ReaperAction:   config.ReaperActionDelete,
+		Rules:          []config.Rule{{Limits: config.Limits{MaxRecords: 1}}},
+	}).Validate())
+}
@@ -0,0 +1,119 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"context"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/quota/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Start starts the reaper, which periodically expires records that exceeded their maximum age.
+// The reaper only runs when quotas are enforced.
+func (s *Service) Start(ctx context.Context) error {
+	if !s.cfg.Enabled {
+		return nil
+	}
+
+	logger.Info("Starting record reaper", "interval", s.cfg.ReaperInterval, "action", s.cfg.ReaperAction)
+
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.cfg.ReaperInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.stopCh:
+				return
+			case <-ticker.C:
+				s.reap(ctx, time.Now())
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the reaper.
+func (s *Service) Stop() error {
+	close(s.stopCh)
+	s.wg.Wait()
+
+	logger.Info("Record reaper stopped")
+
+	return nil
+}
+
+// reap expires the unprotected records that were not used for longer than the maximum age of their trust domain.
+func (s *Service) reap(ctx context.Context, now time.Time) {
+	usages, err := s.db.GetUsage()
+	if err != nil {
+		logger.Error("Failed to get usage for record expiry", "error", err)
+
+		return
+	}
+
+	for _, usage := range usages {
+		maxAge := s.cfg.LimitsFor(usage.TrustDomain).MaxAge
+		if maxAge <= 0 {
+			continue
+		}
+
+		cids, err := s.db.GetUnusedRecords(usage.TrustDomain, now.Add(-maxAge))
+		if err != nil {
+			logger.Error("Failed to get unused records", "error", err, "trust_domain", usage.TrustDomain)
+
+			continue
+		}
+
+		expiredCount := 0
+
+		for _, cid := range cids {
+			if err := s.expire(ctx, cid); err != nil {
+				logger.Warn("Failed to expire record", "error", err, "cid", cid, "trust_domain", usage.TrustDomain)
+
+				continue
+			}
+
+			expiredCount++
+		}
+
+		if expiredCount > 0 {
+			logger.Info("Expired unused records",
+				"trust_domain", usage.TrustDomain,
+				"action", s.cfg.ReaperAction,
+				"count", expiredCount)
+		}
+	}
+}
+
+// expire flags or deletes an expired record, depending on the configured action.
+func (s *Service) expire(ctx context.Context, cid string) error {
+	if s.cfg.ReaperAction != config.ReaperActionDelete {
+		return s.db.FlagRecordExpired(cid) //nolint:wrapcheck
+	}
+
+	ref := &corev1.RecordRef{Cid: cid}
+
+	// Records deleted from the store by other means only need to be released
+	if err := s.store.Delete(ctx, ref); err != nil && status.Code(err) != codes.NotFound {
+		return err //nolint:wrapcheck
+	}
+
+	if err := s.db.RemoveRecord(cid); err != nil {
+		logger.Warn("Failed to remove expired record from search index", "error", err, "cid", cid)
+	}
+
+	return s.db.RemoveRecordUsage(cid) //nolint:wrapcheck
+}
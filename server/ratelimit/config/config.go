@@ -0,0 +1,87 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	DefaultRate  = 100
+	DefaultBurst = 200
+)
+
+// Limit is a token bucket limit.
+type Limit struct {
+	// Sustained number of requests per second
+	Rate float64 `json:"rate,omitempty" mapstructure:"rate"`
+
+	// Maximum number of requests allowed at once
+	Burst int `json:"burst,omitempty" mapstructure:"burst"`
+}
+
+func (l Limit) validate() error {
+	if l.Rate <= 0 {
+		return errors.New("rate must be positive")
+	}
+
+	if l.Burst <= 0 {
+		return errors.New("burst must be positive")
+	}
+
+	return nil
+}
+
+// Rule overrides the default limit for a trust domain, an API method or both.
+type Rule struct {
+	// Trust domain of the caller, e.g. "example.org".
+	// Empty matches all trust domains.
+	TrustDomain string `json:"trust_domain,omitempty" mapstructure:"trust_domain"`
+
+	// Full gRPC method name, e.g. "/agntcy.dir.store.v1.StoreService/Push".
+	// Empty matches all methods.
+	Method string `json:"method,omitempty" mapstructure:"method"`
+
+	Limit `mapstructure:",squash"`
+}
+
+// Config contains configuration for request rate limiting.
+// Limits apply per caller trust domain and API method, with each message
+// of a streaming RPC counted as a request.
+type Config struct {
+	// Indicates if rate limiting is enabled
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Limit for trust domains and methods without a matching rule
+	Default Limit `json:"default,omitempty" mapstructure:"default"`
+
+	// Limits for specific trust domains and methods.
+	// Rules matching both the trust domain and the method take precedence
+	// over rules matching the method only, which take precedence over
+	// rules matching the trust domain only.
+	Rules []Rule `json:"rules,omitempty" mapstructure:"rules"`
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if err := c.Default.validate(); err != nil {
+		return fmt.Errorf("invalid default limit: %w", err)
+	}
+
+	for i, rule := range c.Rules {
+		if rule.TrustDomain == "" && rule.Method == "" {
+			return fmt.Errorf("rule %d: trust domain or method is required", i)
+		}
+
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
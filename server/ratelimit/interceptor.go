@@ -0,0 +1,158 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/server/ratelimit/config"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RetryAfterKey is the trailer with the number of seconds
+// to wait before retrying a rate limited request.
+const RetryAfterKey = "retry-after"
+
+// Limiter enforces the configured limits on API requests.
+type Limiter struct {
+	cfg   config.Config
+	store Store
+}
+
+// NewLimiter creates a limiter that keeps its token buckets in the given store.
+func NewLimiter(cfg config.Config, store Store) *Limiter {
+	return &Limiter{
+		cfg:   cfg,
+		store: store,
+	}
+}
+
+// limitFor returns the limit of the most specific rule matching the request.
+func (l *Limiter) limitFor(trustDomain, method string) config.Limit {
+	var methodRule, domainRule *config.Rule
+
+	for i, rule := range l.cfg.Rules {
+		switch {
+		case rule.TrustDomain == trustDomain && rule.Method == method:
+			return rule.Limit
+		case rule.TrustDomain == "" && rule.Method == method:
+			if methodRule == nil {
+				methodRule = &l.cfg.Rules[i]
+			}
+		case rule.TrustDomain == trustDomain && rule.Method == "":
+			if domainRule == nil {
+				domainRule = &l.cfg.Rules[i]
+			}
+		}
+	}
+
+	if methodRule != nil {
+		return methodRule.Limit
+	}
+
+	if domainRule != nil {
+		return domainRule.Limit
+	}
+
+	return l.cfg.Default
+}
+
+// take consumes a request from the bucket of the caller and method.
+// It returns a ResourceExhausted error and the time to wait if the limit is exceeded.
+// Callers without an authenticated identity share the buckets of the empty trust domain.
+//
+//nolint:wrapcheck
+func (l *Limiter) take(ctx context.Context, method string) (time.Duration, error) {
+	var trustDomain string
+	if sid, ok := authn.SpiffeIDFromContext(ctx); ok {
+		trustDomain = sid.TrustDomain().String()
+	}
+
+	allowed, retryAfter, err := l.store.Take(ctx, trustDomain+method, l.limitFor(trustDomain, method))
+	if err != nil {
+		// Do not reject requests when the limiter state is unavailable
+		logger.Error("Failed to check rate limit", "error", err, "method", method, "trust_domain", trustDomain)
+
+		return 0, nil
+	}
+
+	if allowed {
+		return 0, nil
+	}
+
+	logger.Debug("Rate limit exceeded", "method", method, "trust_domain", trustDomain, "retry_after", retryAfter)
+
+	return retryAfter, rateLimitError(method, retryAfter)
+}
+
+// rateLimitError returns a ResourceExhausted error with the time to wait in a RetryInfo detail.
+func rateLimitError(method string, retryAfter time.Duration) error {
+	st := status.Newf(codes.ResourceExhausted, "rate limit exceeded for %s, retry after %s", method, retryAfter)
+
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = detailed
+	}
+
+	return st.Err()
+}
+
+// retryAfterTrailer returns the retry-after hint in whole seconds, rounded up.
+func retryAfterTrailer(retryAfter time.Duration) metadata.MD {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+
+	return metadata.Pairs(RetryAfterKey, strconv.FormatInt(max(seconds, 1), 10))
+}
+
+func UnaryInterceptorFor(l *Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, sInfo *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if retryAfter, err := l.take(ctx, sInfo.FullMethod); err != nil {
+			_ = grpc.SetTrailer(ctx, retryAfterTrailer(retryAfter))
+
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func StreamInterceptorFor(l *Limiter) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, sInfo *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &limitedStream{
+			ServerStream: ss,
+			limiter:      l,
+			method:       sInfo.FullMethod,
+		})
+	}
+}
+
+// limitedStream counts each received message of a stream as a request.
+type limitedStream struct {
+	grpc.ServerStream
+
+	limiter *Limiter
+	method  string
+}
+
+func (s *limitedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if retryAfter, err := s.limiter.take(s.Context(), s.method); err != nil {
+		s.SetTrailer(retryAfterTrailer(retryAfter))
+
+		return err
+	}
+
+	return nil
+}
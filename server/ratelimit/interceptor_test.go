@@ -0,0 +1,282 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/server/ratelimit/config"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeClock is a manually advanced clock.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestLimiter(cfg config.Config) (*Limiter, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+
+	store := NewMemoryStore()
+	store.now = clock.Now
+
+	return NewLimiter(cfg, store), clock
+}
+
+// fakeTransportStream captures the trailers of unary calls.
+type fakeTransportStream struct {
+	grpc.ServerTransportStream
+
+	trailer metadata.MD
+}
+
+func (s *fakeTransportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+
+	return nil
+}
+
+// fakeServerStream receives an endless stream of messages.
+type fakeServerStream struct {
+	grpc.ServerStream
+
+	ctx      context.Context //nolint:containedctx
+	messages int
+	trailer  metadata.MD
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+
+func (s *fakeServerStream) RecvMsg(any) error {
+	s.messages++
+
+	return nil
+}
+
+func (s *fakeServerStream) SetTrailer(md metadata.MD) {
+	s.trailer = metadata.Join(s.trailer, md)
+}
+
+func contextFor(t *testing.T, trustDomain string) context.Context {
+	t.Helper()
+
+	if trustDomain == "" {
+		return t.Context()
+	}
+
+	id, err := spiffeid.FromSegments(spiffeid.RequireTrustDomainFromString(trustDomain), "client")
+	if err != nil {
+		t.Fatalf("failed to create SPIFFE ID: %v", err)
+	}
+
+	return context.WithValue(t.Context(), authn.SpiffeIDContextKey, id)
+}
+
+// callUnary invokes the interceptor with a fake handler n times and returns the number of handled calls.
+func callUnary(t *testing.T, l *Limiter, ctx context.Context, method string, n int) int {
+	t.Helper()
+
+	interceptor := UnaryInterceptorFor(l)
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+
+	var handled int
+
+	handler := func(context.Context, any) (any, error) {
+		handled++
+
+		return nil, nil //nolint:nilnil
+	}
+
+	for range n {
+		transport := &fakeTransportStream{}
+
+		_, err := interceptor(grpc.NewContextWithServerTransportStream(ctx, transport), nil, info, handler)
+		if err == nil {
+			continue
+		}
+
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("expected ResourceExhausted, got %v", err)
+		}
+
+		if got := transport.trailer.Get(RetryAfterKey); len(got) != 1 || got[0] == "" {
+			t.Fatalf("expected retry-after trailer, got %v", transport.trailer)
+		}
+
+		if !hasRetryInfo(err) {
+			t.Fatalf("expected RetryInfo detail, got %v", status.Convert(err).Details())
+		}
+	}
+
+	return handled
+}
+
+func TestUnaryInterceptorRate(t *testing.T) {
+	l, clock := newTestLimiter(config.Config{
+		Default: config.Limit{Rate: 10, Burst: 5},
+	})
+
+	ctx := contextFor(t, "example.org")
+	method := storev1.StoreService_Push_FullMethodName
+
+	// The burst is served at once
+	if handled := callUnary(t, l, ctx, method, 20); handled != 5 {
+		t.Errorf("expected burst of 5 requests, got %d", handled)
+	}
+
+	// Steady state throughput matches the rate
+	var handled int
+
+	for range 100 {
+		clock.Advance(100 * time.Millisecond)
+
+		handled += callUnary(t, l, ctx, method, 5)
+	}
+
+	if handled != 100 {
+		t.Errorf("expected 100 requests in 10s at 10 requests/s, got %d", handled)
+	}
+
+	// The burst is refilled after an idle period
+	clock.Advance(time.Minute)
+
+	if handled := callUnary(t, l, ctx, method, 20); handled != 5 {
+		t.Errorf("expected refilled burst of 5 requests, got %d", handled)
+	}
+}
+
+func TestStreamInterceptorCountsMessages(t *testing.T) {
+	l, clock := newTestLimiter(config.Config{
+		Default: config.Limit{Rate: 1, Burst: 3},
+	})
+
+	interceptor := StreamInterceptorFor(l)
+	info := &grpc.StreamServerInfo{FullMethod: storev1.StoreService_Push_FullMethodName}
+	stream := &fakeServerStream{ctx: contextFor(t, "example.org")}
+
+	// The handler receives messages until the stream fails
+	var received int
+
+	handler := func(_ any, ss grpc.ServerStream) error {
+		for {
+			if err := ss.RecvMsg(nil); err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			received++
+		}
+	}
+
+	err := interceptor(nil, stream, info, handler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+
+	if received != 3 {
+		t.Errorf("expected 3 messages within the burst, got %d", received)
+	}
+
+	if got := stream.trailer.Get(RetryAfterKey); len(got) != 1 || got[0] != "1" {
+		t.Errorf("expected retry-after of 1 second, got %v", stream.trailer)
+	}
+
+	// A new message is accepted once a token is available
+	clock.Advance(time.Second)
+
+	received = 0
+
+	_ = interceptor(nil, stream, info, handler)
+
+	if received != 1 {
+		t.Errorf("expected 1 message after a second, got %d", received)
+	}
+}
+
+func TestStreamInterceptorPassesErrors(t *testing.T) {
+	l, _ := newTestLimiter(config.Config{
+		Default: config.Limit{Rate: 1, Burst: 1},
+	})
+
+	interceptor := StreamInterceptorFor(l)
+	info := &grpc.StreamServerInfo{FullMethod: storev1.StoreService_Push_FullMethodName}
+	stream := &eofServerStream{fakeServerStream{ctx: t.Context()}}
+
+	err := interceptor(nil, stream, info, func(_ any, ss grpc.ServerStream) error {
+		return ss.RecvMsg(nil) //nolint:wrapcheck
+	})
+	if err != io.EOF { //nolint:errorlint
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+// eofServerStream is a stream without messages.
+type eofServerStream struct {
+	fakeServerStream
+}
+
+func (s *eofServerStream) RecvMsg(any) error { return io.EOF }
+
+func TestLimiterRules(t *testing.T) {
+	push := storev1.StoreService_Push_FullMethodName
+	pull := storev1.StoreService_Pull_FullMethodName
+
+	l, _ := newTestLimiter(config.Config{
+		Default: config.Limit{Rate: 1, Burst: 1},
+		Rules: []config.Rule{
+			{TrustDomain: "trusted.org", Limit: config.Limit{Rate: 1, Burst: 10}},
+			{Method: pull, Limit: config.Limit{Rate: 1, Burst: 5}},
+			{TrustDomain: "trusted.org", Method: pull, Limit: config.Limit{Rate: 1, Burst: 20}},
+		},
+	})
+
+	tests := []struct {
+		name        string
+		trustDomain string
+		method      string
+		burst       int
+	}{
+		{"default for external domain", "external.org", push, 1},
+		{"default for unauthenticated caller", "", push, 1},
+		{"trust domain rule", "trusted.org", push, 10},
+		{"method rule", "external.org", pull, 5},
+		{"trust domain and method rule", "trusted.org", pull, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if handled := callUnary(t, l, contextFor(t, tt.trustDomain), tt.method, 50); handled != tt.burst {
+				t.Errorf("expected burst of %d requests, got %d", tt.burst, handled)
+			}
+		})
+	}
+
+	// Each trust domain has its own buckets
+	if handled := callUnary(t, l, contextFor(t, "other.org"), push, 50); handled != 1 {
+		t.Errorf("expected other.org to have its own bucket, got %d requests", handled)
+	}
+}
+
+func hasRetryInfo(err error) bool {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay().AsDuration() > 0 {
+			return true
+		}
+	}
+
+	return false
+}
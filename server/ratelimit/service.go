@@ -0,0 +1,45 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"fmt"
+
+	"github.com/agntcy/dir/server/ratelimit/config"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc"
+)
+
+var logger = logging.Logger("ratelimit")
+
+// Service limits the rate of API requests per trust domain and method.
+type Service struct {
+	limiter *Limiter
+}
+
+// New creates a new rate limiting service with an in-memory store.
+func New(cfg config.Config) (*Service, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rate limit config: %w", err)
+	}
+
+	logger.Info("Rate limiting service initialized",
+		"rate", cfg.Default.Rate,
+		"burst", cfg.Default.Burst,
+		"rules", len(cfg.Rules),
+	)
+
+	return &Service{
+		limiter: NewLimiter(cfg, NewMemoryStore()),
+	}, nil
+}
+
+// GetServerOptions returns gRPC server options for rate limiting.
+// The interceptors must run after authentication to limit by trust domain.
+func (s *Service) GetServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(UnaryInterceptorFor(s.limiter)),
+		grpc.ChainStreamInterceptor(StreamInterceptorFor(s.limiter)),
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/ratelimit/config"
+	"golang.org/x/time/rate"
+)
+
+// Store keeps the token buckets of the rate limiter.
+// A store shared between replicas enforces the limits across a deployment.
+type Store interface {
+	// Take removes a token from the bucket identified by key,
+	// creating the bucket with the given limit if it does not exist.
+	// If no token is available, it returns false and the time until one is.
+	Take(ctx context.Context, key string, limit config.Limit) (bool, time.Duration, error)
+}
+
+// MemoryStore keeps token buckets in memory, limiting a single replica.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*rate.Limiter
+	now     func() time.Time
+}
+
+// NewMemoryStore creates an in-memory token bucket store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*rate.Limiter),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit config.Limit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)
+		s.buckets[key] = bucket
+	}
+
+	now := s.now()
+
+	reservation := bucket.ReserveN(now, 1)
+	if !reservation.OK() {
+		return false, 0, nil
+	}
+
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		// Return the token, the request is rejected instead of delayed
+		reservation.CancelAt(now)
+
+		return false, delay, nil
+	}
+
+	return true, 0, nil
+}
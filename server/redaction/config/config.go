@@ -0,0 +1,100 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMarker     = "[REDACTED]"
+	DefaultOPATimeout = 2 * time.Second
+)
+
+// Rule selects the values to redact from records of a schema version.
+type Rule struct {
+	// Schema version of the records the rule applies to, e.g. "0.7.0".
+	// Rules without a schema version apply to all records.
+	SchemaVersion string `json:"schema_version,omitempty" mapstructure:"schema_version"`
+
+	// Paths of the values to redact, as dot-separated field names where "*" matches
+	// any field or list element, e.g. "locators.*.url" or "extensions.*.data".
+	Paths []string `json:"paths,omitempty" mapstructure:"paths"`
+}
+
+// OPAConfig configures an Open Policy Agent deciding the redaction level of callers.
+type OPAConfig struct {
+	// URL of the OPA decision, e.g. "http://localhost:8181/v1/data/dir/redaction/level".
+	// The decision must be "none" or "redacted". Records are redacted if it is not set.
+	URL string `json:"url,omitempty" mapstructure:"url"`
+
+	// Timeout of a decision request
+	Timeout time.Duration `json:"timeout,omitempty" mapstructure:"timeout"`
+}
+
+// Config contains configuration for the redaction of pulled records.
+// By default, records are redacted for callers outside of the trust domain
+// configured for authorization, see OPA to decide otherwise.
+type Config struct {
+	// Indicates if pulled records are redacted
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Value replacing redacted values
+	Marker string `json:"marker,omitempty" mapstructure:"marker"`
+
+	// Values to redact
+	Rules []Rule `json:"rules,omitempty" mapstructure:"rules"`
+
+	// Optional policy agent deciding the redaction level of callers
+	OPA OPAConfig `json:"opa,omitempty" mapstructure:"opa"`
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Marker == "" {
+		return errors.New("redaction marker is required")
+	}
+
+	if len(c.Rules) == 0 {
+		return errors.New("at least one redaction rule is required")
+	}
+
+	for i, rule := range c.Rules {
+		if len(rule.Paths) == 0 {
+			return fmt.Errorf("rule %d: at least one path is required", i)
+		}
+
+		for _, path := range rule.Paths {
+			if slices.Contains(strings.Split(path, "."), "") {
+				return fmt.Errorf("rule %d: invalid path %q: empty field name", i, path)
+			}
+		}
+	}
+
+	if c.OPA.URL != "" && c.OPA.Timeout <= 0 {
+		return errors.New("OPA timeout must be positive")
+	}
+
+	return nil
+}
+
+// RulesFor returns the rules that apply to records of the schema version.
+func (c *Config) RulesFor(schemaVersion string) []Rule {
+	var rules []Rule
+
+	for _, rule := range c.Rules {
+		if rule.SchemaVersion == "" || rule.SchemaVersion == schemaVersion {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
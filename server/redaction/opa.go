@@ -0,0 +1,97 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package redaction
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/redaction/config"
+	"github.com/agntcy/dir/server/types/adapters"
+)
+
+// opaInput is the input of OPA decisions.
+type opaInput struct {
+	Caller Caller    `json:"caller"`
+	Record opaRecord `json:"record"`
+}
+
+type opaRecord struct {
+	CID           string `json:"cid"`
+	Name          string `json:"name"`
+	Version       string `json:"version"`
+	SchemaVersion string `json:"schema_version"`
+}
+
+// OPADecider decides redaction levels with the Data API of an Open Policy Agent.
+//
+// The decision input is the caller and the record, e.g.
+//
+//	{"input": {"caller": {"spiffe_id": "spiffe://partner.org/agent", "trust_domain": "partner.org", "internal": false},
+//	           "record": {"cid": "baf...", "name": "my-agent", "version": "v1.0.0", "schema_version": "0.7.0"}}}
+//
+// and the result must be "none" or "redacted".
+type OPADecider struct {
+	url    string
+	client *http.Client
+}
+
+// NewOPADecider creates a decider querying the OPA decision at the configured URL.
+func NewOPADecider(cfg config.OPAConfig) *OPADecider {
+	return &OPADecider{url: cfg.URL, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+func (d *OPADecider) Decide(ctx context.Context, caller Caller, record *corev1.Record) (Level, error) {
+	input := opaInput{Caller: caller, Record: opaRecord{CID: record.GetCid(), SchemaVersion: record.GetSchemaVersion()}}
+
+	if data, err := adapters.NewRecordAdapter(record).GetRecordData(); err == nil {
+		input.Record.Name = data.GetName()
+		input.Record.Version = data.GetVersion()
+	}
+
+	body, err := json.Marshal(map[string]any{"input": input})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal OPA input: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create OPA request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query OPA: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to query OPA: unexpected status %s", resp.Status)
+	}
+
+	var decision struct {
+		Result *Level `json:"result"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return "", fmt.Errorf("failed to decode OPA decision: %w", err)
+	}
+
+	if decision.Result == nil {
+		return "", fmt.Errorf("OPA decision %s is undefined", d.url)
+	}
+
+	switch level := *decision.Result; level {
+	case LevelNone, LevelRedacted:
+		return level, nil
+	default:
+		return "", fmt.Errorf("invalid OPA decision %q: expected %q or %q", level, LevelNone, LevelRedacted)
+	}
+}
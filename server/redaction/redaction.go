@@ -0,0 +1,188 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redaction replaces sensitive values of pulled records, e.g. internal hostnames in locators,
+// with a marker for callers that are not allowed to see them.
+package redaction
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/server/redaction/config"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var logger = logging.Logger("redaction")
+
+// Level is how much of a record a caller is allowed to see.
+type Level string
+
+const (
+	// LevelNone returns records unchanged.
+	LevelNone Level = "none"
+
+	// LevelRedacted replaces the values selected by the redaction rules with the marker.
+	LevelRedacted Level = "redacted"
+)
+
+// Caller identifies the caller a record is returned to.
+type Caller struct {
+	// SPIFFE ID of the caller, empty if the caller is not authenticated.
+	SpiffeID string `json:"spiffe_id"`
+
+	// Trust domain of the caller, empty if the caller is not authenticated.
+	TrustDomain string `json:"trust_domain"`
+
+	// Internal reports whether the caller belongs to the trust domain of the server.
+	Internal bool `json:"internal"`
+}
+
+// Decider decides the redaction level of records returned to a caller.
+type Decider interface {
+	Decide(ctx context.Context, caller Caller, record *corev1.Record) (Level, error)
+}
+
+// DeciderFunc adapts a function to a Decider.
+type DeciderFunc func(ctx context.Context, caller Caller, record *corev1.Record) (Level, error)
+
+func (f DeciderFunc) Decide(ctx context.Context, caller Caller, record *corev1.Record) (Level, error) {
+	return f(ctx, caller, record)
+}
+
+// TrustDomainDecider redacts records for callers outside of the trust domain of the server.
+var TrustDomainDecider = DeciderFunc(func(_ context.Context, caller Caller, _ *corev1.Record) (Level, error) {
+	if caller.Internal {
+		return LevelNone, nil
+	}
+
+	return LevelRedacted, nil
+})
+
+// Redactor redacts records returned to callers according to the configured rules.
+type Redactor struct {
+	cfg         config.Config
+	trustDomain string
+	decider     Decider
+}
+
+// New creates a redactor for a server of the trust domain.
+// The redaction level is decided by the configured OPA, or by TrustDomainDecider if none is configured.
+func New(cfg config.Config, trustDomain string) *Redactor {
+	var decider Decider = TrustDomainDecider
+	if cfg.OPA.URL != "" {
+		decider = NewOPADecider(cfg.OPA)
+	}
+
+	return NewWithDecider(cfg, trustDomain, decider)
+}
+
+// NewWithDecider creates a redactor for a server of the trust domain with a custom decider.
+func NewWithDecider(cfg config.Config, trustDomain string, decider Decider) *Redactor {
+	return &Redactor{cfg: cfg, trustDomain: trustDomain, decider: decider}
+}
+
+// Redact returns the record as the caller of the context is allowed to see it.
+// Redacted records are copies with the selected values replaced by the marker, the Redacted flag set
+// and the CID of the original record in OriginalCid. Records are redacted if the decision fails.
+func (r *Redactor) Redact(ctx context.Context, record *corev1.Record) *corev1.Record {
+	rules := r.cfg.RulesFor(record.GetSchemaVersion())
+	if len(rules) == 0 || record.GetData() == nil {
+		return record
+	}
+
+	caller := r.caller(ctx)
+
+	level, err := r.decider.Decide(ctx, caller, record)
+	if err != nil {
+		logger.Warn("Failed to decide redaction level, redacting record", "error", err, "spiffe_id", caller.SpiffeID)
+
+		level = LevelRedacted
+	}
+
+	if level == LevelNone {
+		return record
+	}
+
+	redacted, _ := proto.Clone(record).(*corev1.Record)
+	data := structpb.NewStructValue(redacted.GetData())
+
+	var changed bool
+
+	for _, rule := range rules {
+		for _, path := range rule.Paths {
+			changed = redactPath(data, strings.Split(path, "."), r.cfg.Marker) || changed
+		}
+	}
+
+	if !changed {
+		return record
+	}
+
+	redacted.Redacted = true
+	redacted.OriginalCid = record.GetCid()
+
+	logger.Debug("Redacted record", "cid", redacted.GetOriginalCid(), "spiffe_id", caller.SpiffeID)
+
+	return redacted
+}
+
+// caller returns the caller of the context.
+func (r *Redactor) caller(ctx context.Context) Caller {
+	sid, ok := authn.SpiffeIDFromContext(ctx)
+	if !ok {
+		return Caller{}
+	}
+
+	trustDomain := sid.TrustDomain().String()
+
+	return Caller{
+		SpiffeID:    sid.String(),
+		TrustDomain: trustDomain,
+		Internal:    r.trustDomain != "" && trustDomain == r.trustDomain,
+	}
+}
+
+// redactPath replaces the values at the path below the value with the marker
+// and reports whether any value was replaced.
+func redactPath(value *structpb.Value, path []string, marker string) bool {
+	field, rest := path[0], path[1:]
+
+	var changed bool
+
+	replace := func(child *structpb.Value, set func(*structpb.Value)) {
+		if len(rest) == 0 {
+			set(structpb.NewStringValue(marker))
+
+			changed = true
+		} else if redactPath(child, rest, marker) {
+			changed = true
+		}
+	}
+
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		fields := kind.StructValue.GetFields()
+
+		for name, child := range fields {
+			if field == "*" || field == name {
+				replace(child, func(v *structpb.Value) { fields[name] = v })
+			}
+		}
+	case *structpb.Value_ListValue:
+		values := kind.ListValue.GetValues()
+
+		for i, child := range values {
+			if field == "*" || field == strconv.Itoa(i) {
+				replace(child, func(v *structpb.Value) { values[i] = v })
+			}
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,201 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package redaction
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/server/redaction/config"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const trustDomain = "example.org"
+
+func newRecord() *corev1.Record {
+	return corev1.New(&typesv1alpha1.Record{
+		Name:          "my-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+		Locators: []*typesv1alpha1.Locator{
+			{Type: "docker_image", Url: "registry.internal.example.org/my-agent:v1"},
+			{Type: "source_code", Url: "https://github.com/example/my-agent"},
+		},
+		Modules: []*typesv1alpha1.Module{
+			{Name: "deployment", Data: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"endpoint": structpb.NewStringValue("https://agent.internal.example.org"),
+				"replicas": structpb.NewNumberValue(3),
+			}}},
+		},
+	})
+}
+
+func newConfig() config.Config {
+	return config.Config{
+		Enabled: true,
+		Marker:  config.DefaultMarker,
+		Rules: []config.Rule{
+			{SchemaVersion: "0.7.0", Paths: []string{"locators.0.url", "modules.*.data.endpoint"}},
+			{SchemaVersion: "0.3.1", Paths: []string{"name"}},
+		},
+	}
+}
+
+func contextForTrustDomain(t *testing.T, trustDomain string) context.Context {
+	t.Helper()
+
+	id, err := spiffeid.FromSegments(spiffeid.RequireTrustDomainFromString(trustDomain), "client")
+	require.NoError(t, err)
+
+	return context.WithValue(t.Context(), authn.SpiffeIDContextKey, id)
+}
+
+func field(t *testing.T, record *corev1.Record, path ...any) *structpb.Value {
+	t.Helper()
+
+	value := structpb.NewStructValue(record.GetData())
+
+	for _, key := range path {
+		switch key := key.(type) {
+		case string:
+			value = value.GetStructValue().GetFields()[key]
+		case int:
+			value = value.GetListValue().GetValues()[key]
+		}
+
+		require.NotNil(t, value, "no value at %v", path)
+	}
+
+	return value
+}
+
+func TestRedact(t *testing.T) {
+	redactor := New(newConfig(), trustDomain)
+
+	t.Run("Internal caller", func(t *testing.T) {
+		record := newRecord()
+
+		pulled := redactor.Redact(contextForTrustDomain(t, trustDomain), record)
+
+		assert.Same(t, record, pulled)
+		assert.False(t, pulled.GetRedacted())
+	})
+
+	for name, ctx := range map[string]func(t *testing.T) context.Context{
+		"External caller":        func(t *testing.T) context.Context { return contextForTrustDomain(t, "partner.org") },
+		"Unauthenticated caller": func(t *testing.T) context.Context { return t.Context() },
+	} {
+		t.Run(name, func(t *testing.T) {
+			record := newRecord()
+			cid := record.GetCid()
+
+			pulled := redactor.Redact(ctx(t), record)
+
+			assert.True(t, pulled.GetRedacted())
+			assert.Equal(t, cid, pulled.GetOriginalCid())
+			assert.NotEqual(t, cid, pulled.GetCid(), "redacted content must not match the original CID")
+
+			assert.Equal(t, config.DefaultMarker, field(t, pulled, "locators", 0, "url").GetStringValue())
+			assert.Equal(t, config.DefaultMarker, field(t, pulled, "modules", 0, "data", "endpoint").GetStringValue())
+
+			// Other values are kept
+			assert.Equal(t, "https://github.com/example/my-agent", field(t, pulled, "locators", 1, "url").GetStringValue())
+			assert.InDelta(t, 3, field(t, pulled, "modules", 0, "data", "replicas").GetNumberValue(), 0)
+			assert.Equal(t, "my-agent", field(t, pulled, "name").GetStringValue())
+
+			// The stored record is not modified
+			assert.Equal(t, cid, record.GetCid())
+			assert.Equal(t, "registry.internal.example.org/my-agent:v1", field(t, record, "locators", 0, "url").GetStringValue())
+		})
+	}
+
+	t.Run("No matching values", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Rules = []config.Rule{{Paths: []string{"extensions.*.data"}}}
+
+		record := newRecord()
+
+		pulled := New(cfg, trustDomain).Redact(contextForTrustDomain(t, "partner.org"), record)
+
+		assert.Same(t, record, pulled, "records without redacted values are not marked as redacted")
+	})
+
+	t.Run("Failed decision", func(t *testing.T) {
+		failing := DeciderFunc(func(context.Context, Caller, *corev1.Record) (Level, error) {
+			return "", errors.New("policy unavailable")
+		})
+
+		pulled := NewWithDecider(newConfig(), trustDomain, failing).Redact(contextForTrustDomain(t, trustDomain), newRecord())
+
+		assert.True(t, pulled.GetRedacted(), "records are redacted if the decision fails")
+	})
+}
+
+func TestOPADecider(t *testing.T) {
+	var input struct {
+		Input opaInput `json:"input"`
+	}
+
+	opa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+
+		// Partners see everything, others are redacted
+		level := LevelRedacted
+		if input.Input.Caller.TrustDomain == "partner.org" {
+			level = LevelNone
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"result": level})
+	}))
+	t.Cleanup(opa.Close)
+
+	cfg := newConfig()
+	cfg.OPA = config.OPAConfig{URL: opa.URL + "/v1/data/dir/redaction/level", Timeout: time.Second}
+
+	redactor := New(cfg, trustDomain)
+
+	assert.False(t, redactor.Redact(contextForTrustDomain(t, "partner.org"), newRecord()).GetRedacted())
+	assert.Equal(t, "spiffe://partner.org/client", input.Input.Caller.SpiffeID)
+	assert.Equal(t, "my-agent", input.Input.Record.Name)
+	assert.Equal(t, "0.7.0", input.Input.Record.SchemaVersion)
+
+	assert.True(t, redactor.Redact(contextForTrustDomain(t, trustDomain), newRecord()).GetRedacted())
+	assert.True(t, input.Input.Caller.Internal)
+
+	t.Run("Invalid decision", func(t *testing.T) {
+		invalid := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"result": "partial"}`))
+		}))
+		t.Cleanup(invalid.Close)
+
+		_, err := NewOPADecider(config.OPAConfig{URL: invalid.URL, Timeout: time.Second}).Decide(t.Context(), Caller{}, newRecord())
+		assert.ErrorContains(t, err, `invalid OPA decision "partial"`)
+	})
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := newConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Rules = append(cfg.Rules, config.Rule{Paths: []string{"locators..url"}})
+	assert.ErrorContains(t, cfg.Validate(), `invalid path "locators..url"`)
+
+	cfg = newConfig()
+	cfg.Rules = nil
+	assert.ErrorContains(t, cfg.Validate(), "at least one redaction rule is required")
+
+	cfg = newConfig()
+	assert.Len(t, cfg.RulesFor("0.7.0"), 1)
+}
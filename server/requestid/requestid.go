@@ -0,0 +1,117 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package requestid propagates the request IDs sent by clients.
+// Every request is logged with its ID, as are the logs of handlers using
+// logging.FromContext, and the ID is returned in the
+// RequestInfo detail of failed requests, so that client errors can be
+// correlated with server logs.
+package requestid
+
+import (
+	"context"
+
+	"github.com/agntcy/dir/utils/logging"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataKey is the gRPC metadata key carrying the request ID.
+const MetadataKey = "x-dir-request-id"
+
+var logger = logging.Logger("requestid")
+
+type contextKey struct{}
+
+// FromContext returns the ID of the request handled with the context,
+// or an empty string if the context is not a request context.
+func FromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(contextKey{}).(string)
+
+	return requestID
+}
+
+// Service assigns request IDs to incoming requests.
+type Service struct {
+	generate func() string
+}
+
+// New creates a request ID service. Requests without an ID are assigned a random UUID.
+func New() *Service {
+	return &Service{generate: uuid.NewString}
+}
+
+// GetServerOptions returns gRPC server options that log the ID of every request
+// and add it to the errors returned by the handlers.
+// The interceptors should run first, so that requests rejected by other interceptors have an ID too.
+func (s *Service) GetServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx, requestID := s.handle(ctx, info.FullMethod)
+
+			resp, err := handler(ctx, req)
+
+			return resp, done(info.FullMethod, requestID, err)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, requestID := s.handle(ss.Context(), info.FullMethod)
+
+			err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+
+			return done(info.FullMethod, requestID, err)
+		}),
+	}
+}
+
+// handle reads or assigns the ID of the request and logs it.
+func (s *Service) handle(ctx context.Context, method string) (context.Context, string) {
+	var requestID string
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(MetadataKey); len(ids) > 0 && ids[0] != "" {
+		requestID = ids[0]
+	} else {
+		requestID = s.generate()
+	}
+
+	logger.Debug("Handling request", "method", method, "request_id", requestID)
+
+	// Handlers log the request ID with logging.FromContext
+	ctx = logging.ContextWithFields(ctx, "request_id", requestID)
+
+	return context.WithValue(ctx, contextKey{}, requestID), requestID
+}
+
+// done logs a failed request and adds a RequestInfo detail with the request ID to its error.
+// Errors that are not gRPC status errors are converted to Unknown errors, as the server would.
+func done(method, requestID string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st := status.Convert(err)
+
+	logger.Info("Request failed", "method", method, "request_id", requestID, "code", st.Code(), "error", st.Message())
+
+	withDetails, detailErr := st.WithDetails(&errdetails.RequestInfo{RequestId: requestID})
+	if detailErr != nil {
+		return err
+	}
+
+	return withDetails.Err() //nolint:wrapcheck
+}
+
+// serverStream overrides the context of a server stream with the request context.
+//
+//nolint:containedctx // Context is required for gRPC stream wrapping
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,182 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/utils/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeStore fails every request and records the request IDs seen by its handlers,
+// which log with the request-scoped fields of their context.
+type fakeStore struct {
+	storev1.UnimplementedStoreServiceServer
+
+	mu  sync.Mutex
+	ids []string
+}
+
+func (s *fakeStore) PushBundle(ctx context.Context, _ *corev1.RecordBundle) (*corev1.RecordRef, error) {
+	s.record(ctx)
+
+	return nil, status.Error(codes.FailedPrecondition, "bundle rejected") //nolint:wrapcheck
+}
+
+func (s *fakeStore) Lookup(stream storev1.StoreService_LookupServer) error {
+	s.record(stream.Context())
+
+	return status.Error(codes.Internal, "lookup failed") //nolint:wrapcheck
+}
+
+func (s *fakeStore) record(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ids = append(s.ids, FromContext(ctx))
+
+	logging.WithContext(ctx, logger).Info("Handled by store")
+}
+
+func (s *fakeStore) seen() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.ids...)
+}
+
+// syncBuffer is a buffer that can be written by concurrent handlers.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p) //nolint:wrapcheck
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+// captureLogs replaces the package logger with one writing debug logs to the returned buffer.
+func captureLogs(t *testing.T) *syncBuffer {
+	t.Helper()
+
+	buf := &syncBuffer{}
+	original := logger
+	logger = slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	t.Cleanup(func() { logger = original })
+
+	return buf
+}
+
+func newTestClient(t *testing.T, service *Service, store *fakeStore) storev1.StoreServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024) //nolint:mnd
+
+	server := grpc.NewServer(service.GetServerOptions()...)
+	storev1.RegisterStoreServiceServer(server, store)
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return storev1.NewStoreServiceClient(conn)
+}
+
+// requestInfoID returns the request ID of the RequestInfo detail of the error.
+func requestInfoID(t *testing.T, err error) string {
+	t.Helper()
+
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RequestInfo); ok {
+			return info.GetRequestId()
+		}
+	}
+
+	t.Fatalf("error %v has no RequestInfo detail", err)
+
+	return ""
+}
+
+func TestRequestID(t *testing.T) {
+	logs := captureLogs(t)
+	store := &fakeStore{}
+	client := newTestClient(t, New(), store)
+
+	t.Run("unary", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(t.Context(), MetadataKey, "unary-id")
+
+		_, err := client.PushBundle(ctx, &corev1.RecordBundle{})
+		require.Error(t, err)
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, "unary-id", requestInfoID(t, err))
+		assert.Contains(t, store.seen(), "unary-id")
+		assert.Contains(t, logs.String(), `msg="Handling request" method=/agntcy.dir.store.v1.StoreService/PushBundle request_id=unary-id`)
+		assert.Contains(t, logs.String(), `msg="Request failed" method=/agntcy.dir.store.v1.StoreService/PushBundle request_id=unary-id code=FailedPrecondition`)
+		assert.Contains(t, logs.String(), `msg="Handled by store" request_id=unary-id`)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(t.Context(), MetadataKey, "stream-id")
+
+		stream, err := client.Lookup(ctx)
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		require.Error(t, err)
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, "stream-id", requestInfoID(t, err))
+		assert.Contains(t, store.seen(), "stream-id")
+		assert.Contains(t, logs.String(), "request_id=stream-id")
+		assert.Contains(t, logs.String(), `msg="Handled by store" request_id=stream-id`)
+	})
+
+	t.Run("generated", func(t *testing.T) {
+		_, err := client.PushBundle(t.Context(), &corev1.RecordBundle{})
+		require.Error(t, err)
+
+		requestID := requestInfoID(t, err)
+		assert.NotEmpty(t, requestID)
+		assert.Equal(t, requestID, store.seen()[len(store.seen())-1])
+		assert.Contains(t, logs.String(), "request_id="+requestID)
+	})
+}
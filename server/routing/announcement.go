@@ -0,0 +1,114 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+)
+
+// announcement is the state of a local record announcement, stored under its "/records/CID" key.
+// Records published before TTLs were introduced are stored without a value and never expire.
+type announcement struct {
+	// AnnouncedAt is when the record was last announced.
+	AnnouncedAt time.Time `json:"announced_at"`
+	// TTL is how long the announcement is valid after AnnouncedAt, zero if it does not expire.
+	TTL time.Duration `json:"ttl,omitempty"`
+	// Namespace is the namespace the record was first published in, empty if the publisher was not authenticated.
+	Namespace string `json:"namespace,omitempty"`
+	// Public lists the record to callers of all namespaces.
+	Public bool `json:"public,omitempty"`
+}
+
+func newAnnouncement(opts types.PublishOptions) *announcement {
+	return &announcement{
+		AnnouncedAt: time.Now(),
+		TTL:         opts.TTL,
+		Namespace:   opts.Namespace,
+		Public:      opts.Public,
+	}
+}
+
+// republished returns the announcement of the record published again with the options.
+// The record keeps the namespace it was first published in and gets the TTL of the options.
+// Its visibility is only changed by publications in its namespace.
+func (a *announcement) republished(opts types.PublishOptions) *announcement {
+	public := a.Public
+	if opts.Namespace == a.Namespace {
+		public = opts.Public
+	}
+
+	return &announcement{
+		AnnouncedAt: time.Now(),
+		TTL:         opts.TTL,
+		Namespace:   a.Namespace,
+		Public:      public,
+	}
+}
+
+// unchangedBy reports whether republishing the announcement would leave it unchanged,
+// which is the case for announcements that do not expire with the same visibility.
+func (a *announcement) unchangedBy(republished *announcement) bool {
+	return !a.expires() && !republished.expires() && a.Public == republished.Public
+}
+
+// renewed returns the announcement re-announced now, with the same TTL, namespace and visibility.
+func (a *announcement) renewed() *announcement {
+	renewed := *a
+	renewed.AnnouncedAt = time.Now()
+
+	return &renewed
+}
+
+// parseAnnouncement decodes the value stored under a "/records/CID" key.
+func parseAnnouncement(value []byte) (*announcement, error) {
+	if len(value) == 0 {
+		return &announcement{}, nil
+	}
+
+	var a announcement
+	if err := json.Unmarshal(value, &a); err != nil {
+		return nil, fmt.Errorf("failed to parse announcement: %w", err)
+	}
+
+	return &a, nil
+}
+
+func (a *announcement) marshal() ([]byte, error) {
+	if a.TTL <= 0 && a.Namespace == "" && !a.Public {
+		// Keep the legacy empty value for announcements that do not expire
+		return nil, nil
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize announcement: %w", err)
+	}
+
+	return data, nil
+}
+
+// expires reports whether the announcement has a TTL.
+func (a *announcement) expires() bool {
+	return a.TTL > 0
+}
+
+// remaining returns the time left until the announcement expires, zero if it already expired.
+func (a *announcement) remaining(now time.Time) time.Duration {
+	return max(a.AnnouncedAt.Add(a.TTL).Sub(now), 0)
+}
+
+// expired reports whether the announcement has a TTL that elapsed.
+func (a *announcement) expired(now time.Time) bool {
+	return a.expires() && a.remaining(now) == 0
+}
+
+// dueForReannounce reports whether the announcement should be refreshed,
+// which happens once less than ReannounceThreshold of its TTL remains.
+func (a *announcement) dueForReannounce(now time.Time) bool {
+	return a.expires() && a.remaining(now) < time.Duration(float64(a.TTL)*ReannounceThreshold)
+}
@@ -52,6 +52,7 @@ type CleanupManager struct {
 	dstore      types.Datastore
 	storeAPI    types.StoreAPI
 	server      *p2p.Server
+	localPeerID string
 	publishFunc pubsub.PublishEventHandler // Publishing callback (captures routeRemote state)
 }
 
@@ -74,6 +75,7 @@ func NewCleanupManager(
 		dstore:      dstore,
 		storeAPI:    storeAPI,
 		server:      server,
+		localPeerID: server.Host().ID().String(),
 		publishFunc: publishFunc,
 	}
 }
@@ -369,7 +371,7 @@ func (c *CleanupManager) cleanupLabelsForCID(ctx context.Context, cid string) bo
 	}
 
 	// Find and remove all label keys for this CID across all namespaces
-	localPeerID := c.server.Host().ID().String()
+	localPeerID := c.localPeerID
 
 	for _, namespace := range types.AllLabelTypes() {
 		// Query labels in this namespace that match our CID
@@ -23,6 +23,17 @@ const (
 	// RefreshInterval defines how often DHT routing tables are refreshed.
 	// This is a shorter interval for maintaining network connectivity.
 	RefreshInterval = 30 * time.Second
+	// ReannounceCheckInterval defines how often local announcements with a TTL are checked
+	// for re-announcement and expiry. It bounds how long deleted records stay listed.
+	ReannounceCheckInterval = 1 * time.Minute
+	// StagedTransactionTimeout is how long announcements staged by an atomic publication may stay
+	// uncommitted, e.g. when the server stopped during the publication, before they are removed.
+	StagedTransactionTimeout = 10 * time.Minute
+	// TransactionJanitorInterval defines how often staged announcements are checked for expiry.
+	TransactionJanitorInterval = 1 * time.Minute
+	// ReannounceThreshold is the fraction of the TTL that may remain before an announcement is
+	// re-announced. Half of the TTL leaves room for several check cycles before expiry.
+	ReannounceThreshold = 0.5
 )
 
 // Protocol constants for libp2p DHT and discovery.
@@ -0,0 +1,104 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	labelquery "github.com/agntcy/dir/server/routing/query"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// labelIndex is an in-memory label → records index built from enhanced label keys.
+// It provides the primitive lookups label queries are evaluated against.
+type labelIndex struct {
+	records map[string]labelquery.Set
+	labels  []string // sorted for prefix lookups
+	all     labelquery.Set
+}
+
+// newLabelIndex indexes the label entries announced by the peers accepted by keep.
+func newLabelIndex(entries []NamespaceEntry, keep func(peerID string) bool) *labelIndex {
+	idx := &labelIndex{
+		records: make(map[string]labelquery.Set),
+		all:     labelquery.Set{},
+	}
+
+	for _, entry := range entries {
+		label, cid, peerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil || !keep(peerID) {
+			continue
+		}
+
+		records, ok := idx.records[label.String()]
+		if !ok {
+			records = labelquery.Set{}
+			idx.records[label.String()] = records
+			idx.labels = append(idx.labels, label.String())
+		}
+
+		records[cid] = struct{}{}
+		idx.all[cid] = struct{}{}
+	}
+
+	sort.Strings(idx.labels)
+
+	return idx
+}
+
+// addRecords adds records without labels to the universe of the index.
+func (idx *labelIndex) addRecords(cids ...string) {
+	for _, cid := range cids {
+		idx.all[cid] = struct{}{}
+	}
+}
+
+func (idx *labelIndex) Records(_ context.Context, label string) (labelquery.Set, error) {
+	return idx.records[label], nil
+}
+
+func (idx *labelIndex) Labels(_ context.Context, prefix string) ([]string, error) {
+	start := sort.SearchStrings(idx.labels, prefix)
+
+	end := start
+	for end < len(idx.labels) && strings.HasPrefix(idx.labels[end], prefix) {
+		end++
+	}
+
+	return idx.labels[start:end], nil
+}
+
+func (idx *labelIndex) All(_ context.Context) (labelquery.Set, error) {
+	return idx.all, nil
+}
+
+// evalLabelQuery parses a label query and evaluates it against the index.
+// Malformed queries, queries expanding to too many labels and queries combined
+// with legacy record queries are rejected as invalid arguments.
+func evalLabelQuery(ctx context.Context, q string, queries []*routingv1.RecordQuery, idx labelquery.Index) (labelquery.Set, error) {
+	if len(queries) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "query and queries are mutually exclusive")
+	}
+
+	expr, err := labelquery.Parse(q)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+	}
+
+	matched, err := labelquery.Eval(ctx, expr, idx)
+	if errors.Is(err, labelquery.ErrTooManyLabels) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to evaluate query: %v", err)
+	}
+
+	return matched, nil
+}
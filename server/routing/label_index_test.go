@@ -0,0 +1,138 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/datastore"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestList_LabelQuery(t *testing.T) {
+	dstore, err := datastore.New()
+	require.NoError(t, err)
+
+	store := newMockStore()
+	r := newLocal(store, dstore, testPeerID)
+
+	publish := func(name string, skills [][2]string, locators ...string) string {
+		data := &typesv1alpha0.Record{Name: name, SchemaVersion: "v0.3.1"}
+		for _, skill := range skills {
+			data.Skills = append(data.Skills, &typesv1alpha0.Skill{CategoryName: toPtr(skill[0]), ClassName: toPtr(skill[1])})
+		}
+
+		for _, locator := range locators {
+			data.Locators = append(data.Locators, &typesv1alpha0.Locator{Type: locator, Url: "url"})
+		}
+
+		record := corev1.New(data)
+
+		_, err := store.Push(t.Context(), record)
+		require.NoError(t, err)
+		require.NoError(t, r.Publish(t.Context(), adapters.NewRecordAdapter(record)))
+
+		return record.GetCid()
+	}
+
+	summarizer := publish("summarizer", [][2]string{{"nlp", "summarization"}}, "docker-image")
+	translator := publish("translator", [][2]string{{"nlp", "translation"}}, "source-code")
+	classifier := publish("classifier", [][2]string{{"vision", "classification"}}, "docker-image")
+	bare := publish("bare", nil)
+
+	// A label announced by another peer must not affect local results
+	remoteKey := BuildEnhancedLabelKey(types.Label("/skills/nlp/remote"), "remote-cid", "remote-peer")
+	require.NoError(t, dstore.Put(t.Context(), ipfsdatastore.NewKey(remoteKey), nil))
+
+	list := func(req *routingv1.ListRequest) []string {
+		t.Helper()
+
+		ch, err := r.List(t.Context(), req, types.AllNamespaces)
+		require.NoError(t, err)
+
+		var cids []string
+		for resp := range ch {
+			cids = append(cids, resp.GetRecordRef().GetCid())
+		}
+
+		return cids
+	}
+
+	t.Run("boolean queries", func(t *testing.T) {
+		tests := map[string][]string{
+			"/skills/nlp/*": {summarizer, translator},
+			"/skills/nlp/* AND NOT /locators/docker-image":                        {translator},
+			"/skills/vision/* OR /locators/source-code":                           {translator, classifier},
+			"NOT /locators/docker-image":                                          {translator, bare},
+			"(/skills/nlp/* OR /skills/vision/*) AND NOT /skills/nlp/translation": {summarizer, classifier},
+			"/skills/missing": nil,
+		}
+
+		for q, want := range tests {
+			assert.ElementsMatch(t, want, list(&routingv1.ListRequest{Query: q}), q)
+		}
+	})
+
+	t.Run("equivalent to legacy queries", func(t *testing.T) {
+		tests := []struct {
+			query   string
+			queries []*routingv1.RecordQuery
+		}{
+			{
+				query: "/skills/nlp/summarization",
+				queries: []*routingv1.RecordQuery{
+					{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "nlp/summarization"},
+				},
+			},
+			{
+				query: "/skills/nlp/*",
+				queries: []*routingv1.RecordQuery{
+					{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "nlp"},
+				},
+			},
+			{
+				query: "/skills/nlp/* AND /locators/docker-image",
+				queries: []*routingv1.RecordQuery{
+					{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "nlp"},
+					{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR, Value: "docker-image"},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			legacy := list(&routingv1.ListRequest{Queries: tt.queries})
+			assert.NotEmpty(t, legacy, tt.query)
+			assert.ElementsMatch(t, legacy, list(&routingv1.ListRequest{Query: tt.query}), tt.query)
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		tests := map[string]*routingv1.ListRequest{
+			"syntax error": {Query: "/skills/nlp/* AND"},
+			"both query and queries": {
+				Query:   "/skills/nlp/*",
+				Queries: []*routingv1.RecordQuery{{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "nlp"}},
+			},
+		}
+
+		for name, req := range tests {
+			_, err := r.List(t.Context(), req, types.AllNamespaces)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+		}
+	})
+
+	t.Run("syntax errors report the position", func(t *testing.T) {
+		_, err := r.List(t.Context(), &routingv1.ListRequest{Query: "/skills/nlp/* AND"}, types.AllNamespaces)
+		assert.ErrorContains(t, err, "syntax error at position 18")
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"fmt"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+// namespacesBackfilledKey marks the datastore as backfilled by backfillNamespaces.
+// From then on, announcements without a namespace were published by unauthenticated callers.
+var namespacesBackfilledKey = datastore.NewKey("/meta/namespaces-backfilled")
+
+// backfillNamespaces moves the announcements of records published before namespaces were introduced
+// to the legacy namespace, so that they are not disclosed to the callers of all namespaces.
+// The backfill runs once per datastore.
+func backfillNamespaces(ctx context.Context, dstore types.Datastore) error {
+	backfilled, err := dstore.Has(ctx, namespacesBackfilledKey)
+	if err != nil {
+		return fmt.Errorf("failed to check namespace backfill: %w", err)
+	}
+
+	if backfilled {
+		return nil
+	}
+
+	results, err := dstore.Query(ctx, query.Query{Prefix: "/records/"})
+	if err != nil {
+		return fmt.Errorf("failed to query local records: %w", err)
+	}
+	defer results.Close()
+
+	batch, err := dstore.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	count := 0
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			return fmt.Errorf("failed to read local record: %w", result.Error)
+		}
+
+		ann, err := parseAnnouncement(result.Value)
+		if err != nil {
+			localLogger.Warn("Failed to parse record announcement", "key", result.Key, "error", err)
+
+			ann = &announcement{}
+		}
+
+		if ann.Namespace != "" {
+			continue
+		}
+
+		ann.Namespace = routingv1.NamespaceLegacy
+
+		value, err := ann.marshal()
+		if err != nil {
+			return err
+		}
+
+		if err := batch.Put(ctx, datastore.NewKey(result.Key), value); err != nil {
+			return fmt.Errorf("failed to update announcement: %w", err)
+		}
+
+		count++
+	}
+
+	if err := batch.Put(ctx, namespacesBackfilledKey, nil); err != nil {
+		return fmt.Errorf("failed to mark namespace backfill: %w", err)
+	}
+
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit namespace backfill: %w", err)
+	}
+
+	if count > 0 {
+		localLogger.Info("Moved previously published records to the legacy namespace", "records", count)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/datastore"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listNamespaces lists the records in the scope, returning their namespaces by record name.
+func listNamespaces(t *testing.T, r *routeLocal, scope types.NamespaceScope, names map[string]string) map[string]string {
+	t.Helper()
+
+	ch, err := r.List(t.Context(), &routingv1.ListRequest{}, scope)
+	require.NoError(t, err)
+
+	listed := make(map[string]string)
+	for resp := range ch {
+		listed[names[resp.GetRecordRef().GetCid()]] = resp.GetNamespace()
+	}
+
+	return listed
+}
+
+func TestList_NamespaceScope(t *testing.T) {
+	dstore, err := datastore.New()
+	require.NoError(t, err)
+
+	r := newLocal(newMockStore(), dstore, testPeerID)
+	names := make(map[string]string)
+
+	publish := func(name string, opts types.PublishOptions) {
+		record := newReannounceTestRecord(t, name)
+		names[record.GetCid()] = name
+
+		require.NoError(t, r.PublishWithOptions(t.Context(), adapters.NewRecordAdapter(record), opts))
+	}
+
+	publish("a-private", types.PublishOptions{Namespace: "a.org"})
+	publish("a-public", types.PublishOptions{Namespace: "a.org", Public: true})
+	publish("b-private", types.PublishOptions{Namespace: "b.org"})
+
+	t.Run("own namespace and public records", func(t *testing.T) {
+		listed := listNamespaces(t, r, types.NamespaceScope{Namespaces: []string{"b.org"}, Public: true}, names)
+		assert.Equal(t, map[string]string{"a-public": "a.org", "b-private": "b.org"}, listed)
+	})
+
+	t.Run("single namespace", func(t *testing.T) {
+		listed := listNamespaces(t, r, types.NamespaceScope{Namespaces: []string{"a.org"}}, names)
+		assert.Equal(t, map[string]string{"a-private": "a.org", "a-public": "a.org"}, listed)
+	})
+
+	t.Run("all namespaces", func(t *testing.T) {
+		assert.Len(t, listNamespaces(t, r, types.AllNamespaces, names), 3)
+	})
+
+	t.Run("republishing keeps the namespace", func(t *testing.T) {
+		publish("b-private", types.PublishOptions{Namespace: "a.org", Public: true})
+
+		listed := listNamespaces(t, r, types.NamespaceScope{Namespaces: []string{"c.org"}, Public: true}, names)
+		assert.Equal(t, map[string]string{"a-public": "a.org"}, listed)
+
+		// Only publications in the namespace of the record change its visibility
+		publish("b-private", types.PublishOptions{Namespace: "b.org", Public: true})
+
+		listed = listNamespaces(t, r, types.NamespaceScope{Namespaces: []string{"c.org"}, Public: true}, names)
+		assert.Equal(t, map[string]string{"a-public": "a.org", "b-private": "b.org"}, listed)
+	})
+}
+
+func TestBackfillNamespaces(t *testing.T) {
+	dstore, err := datastore.New()
+	require.NoError(t, err)
+
+	r := newLocal(newMockStore(), dstore, testPeerID)
+
+	// Records published before namespaces were introduced have no namespace
+	legacy := newReannounceTestRecord(t, "legacy")
+	require.NoError(t, r.Publish(t.Context(), adapters.NewRecordAdapter(legacy)))
+
+	require.NoError(t, backfillNamespaces(t.Context(), dstore))
+
+	// Records published afterwards by unauthenticated callers keep the empty namespace
+	anonymous := newReannounceTestRecord(t, "anonymous")
+	require.NoError(t, r.Publish(t.Context(), adapters.NewRecordAdapter(anonymous)))
+
+	require.NoError(t, backfillNamespaces(t.Context(), dstore))
+
+	names := map[string]string{legacy.GetCid(): "legacy", anonymous.GetCid(): "anonymous"}
+	assert.Equal(t, map[string]string{"legacy": routingv1.NamespaceLegacy, "anonymous": ""}, listNamespaces(t, r, types.AllNamespaces, names))
+
+	backfilled, err := dstore.Has(t.Context(), ipfsdatastore.NewKey("/meta/namespaces-backfilled"))
+	require.NoError(t, err)
+	assert.True(t, backfilled)
+}
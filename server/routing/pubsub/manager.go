@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/agntcy/dir/server/labels"
 	"github.com/agntcy/dir/server/routing/internal/p2p"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
@@ -140,7 +141,7 @@ func (m *Manager) PublishRecord(ctx context.Context, record types.Record) error
 	}
 
 	// Extract labels from record (uses shared label extraction logic)
-	labelList := types.GetLabelsFromRecord(record)
+	labelList := labels.FromRecord(record).RoutingLabels()
 	if len(labelList) == 0 {
 		// No labels to publish (not an error, just nothing to do)
 		logger.Debug("Record has no labels, skipping GossipSub announcement", "cid", cid)
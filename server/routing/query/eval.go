@@ -0,0 +1,228 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultMaxExpandedLabels is the default cap on the number of labels a query
+// may resolve to after wildcard expansion.
+const DefaultMaxExpandedLabels = 256
+
+// ErrTooManyLabels is returned by Eval when a query expands to more labels than allowed.
+var ErrTooManyLabels = errors.New("query expands to too many labels")
+
+// Set is a set of record CIDs.
+type Set map[string]struct{}
+
+// NewSet returns a set containing the given CIDs.
+func NewSet(cids ...string) Set {
+	set := make(Set, len(cids))
+	for _, cid := range cids {
+		set[cid] = struct{}{}
+	}
+
+	return set
+}
+
+// Has reports whether the set contains the CID.
+func (s Set) Has(cid string) bool {
+	_, ok := s[cid]
+
+	return ok
+}
+
+// Index provides the primitive label lookups a query is evaluated against.
+type Index interface {
+	// Records returns the records carrying exactly the given label.
+	Records(ctx context.Context, label string) (Set, error)
+
+	// Labels returns all known labels starting with prefix.
+	Labels(ctx context.Context, prefix string) ([]string, error)
+
+	// All returns every record in scope of the query.
+	// It is the universe NOT is evaluated against.
+	All(ctx context.Context) (Set, error)
+}
+
+// Option configures query evaluation.
+type Option func(*evaluator)
+
+// WithMaxExpandedLabels caps the number of labels a query may resolve to.
+// Exact labels count as one, wildcards as the number of labels they expand to.
+func WithMaxExpandedLabels(n int) Option {
+	return func(e *evaluator) {
+		e.maxLabels = n
+	}
+}
+
+// Eval returns the records of the index matched by the expression.
+func Eval(ctx context.Context, expr Expr, index Index, opts ...Option) (Set, error) {
+	e := &evaluator{
+		index:     index,
+		maxLabels: DefaultMaxExpandedLabels,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e.eval(ctx, expr)
+}
+
+type evaluator struct {
+	index     Index
+	maxLabels int
+	labels    int
+}
+
+func (e *evaluator) eval(ctx context.Context, expr Expr) (Set, error) {
+	switch expr := expr.(type) {
+	case *Label:
+		return e.evalLabel(ctx, expr)
+
+	case *And:
+		return e.evalAnd(ctx, expr)
+
+	case *Or:
+		result := Set{}
+
+		for _, operand := range expr.Operands {
+			set, err := e.eval(ctx, operand)
+			if err != nil {
+				return nil, err
+			}
+
+			for cid := range set {
+				result[cid] = struct{}{}
+			}
+		}
+
+		return result, nil
+
+	case *Not:
+		all, err := e.index.All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records: %w", err)
+		}
+
+		excluded, err := e.eval(ctx, expr.Operand)
+		if err != nil {
+			return nil, err
+		}
+
+		return difference(all, excluded), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported expression %T", expr)
+	}
+}
+
+func (e *evaluator) evalLabel(ctx context.Context, label *Label) (Set, error) {
+	labels := []string{label.Value}
+
+	if label.Wildcard {
+		var err error
+
+		labels, err = e.index.Labels(ctx, label.Prefix())
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand %s: %w", label, err)
+		}
+	}
+
+	e.labels += len(labels)
+	if e.labels > e.maxLabels {
+		return nil, fmt.Errorf("%w: %s at position %d exceeds the limit of %d labels", ErrTooManyLabels, label, label.Pos, e.maxLabels)
+	}
+
+	result := Set{}
+
+	for _, value := range labels {
+		set, err := e.index.Records(ctx, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lookup %s: %w", value, err)
+		}
+
+		for cid := range set {
+			result[cid] = struct{}{}
+		}
+	}
+
+	return result, nil
+}
+
+// evalAnd intersects the positive operands and subtracts the negated ones,
+// so that "a AND NOT b" does not need to materialize the whole index.
+func (e *evaluator) evalAnd(ctx context.Context, and *And) (Set, error) {
+	var (
+		result   Set
+		excluded []Set
+	)
+
+	for _, operand := range and.Operands {
+		if not, ok := operand.(*Not); ok {
+			set, err := e.eval(ctx, not.Operand)
+			if err != nil {
+				return nil, err
+			}
+
+			excluded = append(excluded, set)
+
+			continue
+		}
+
+		set, err := e.eval(ctx, operand)
+		if err != nil {
+			return nil, err
+		}
+
+		if result == nil {
+			result = set
+		} else {
+			result = intersection(result, set)
+		}
+	}
+
+	if result == nil {
+		all, err := e.index.All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records: %w", err)
+		}
+
+		result = all
+	}
+
+	for _, set := range excluded {
+		result = difference(result, set)
+	}
+
+	return result, nil
+}
+
+func intersection(a, b Set) Set {
+	result := Set{}
+
+	for cid := range a {
+		if b.Has(cid) {
+			result[cid] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+func difference(a, b Set) Set {
+	result := Set{}
+
+	for cid := range a {
+		if !b.Has(cid) {
+			result[cid] = struct{}{}
+		}
+	}
+
+	return result
+}
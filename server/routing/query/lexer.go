@@ -0,0 +1,99 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenLabel
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+
+	// pos is the 1-based position of the token in the query.
+	pos int
+	// offset is the distance from pos to the start of text, set for quoted labels.
+	offset int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenLabel:
+		return fmt.Sprintf("label %q", t.text)
+	default:
+		return t.text
+	}
+}
+
+var keywords = map[string]tokenKind{
+	"AND": tokenAnd,
+	"OR":  tokenOr,
+	"NOT": tokenNot,
+}
+
+// lex splits a query into tokens, terminated by an EOF token.
+func lex(s string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case isSpace(c):
+			i++
+
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i + 1})
+			i++
+
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i + 1})
+			i++
+
+		case c == '"':
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				return nil, &SyntaxError{Pos: i + 1, Msg: "unterminated quoted label"}
+			}
+
+			tokens = append(tokens, token{kind: tokenLabel, text: s[i+1 : i+1+end], pos: i + 1, offset: 1})
+			i += end + 2 //nolint:mnd // skip both quotes
+
+		default:
+			start := i
+			for i < len(s) && !isSpace(s[i]) && s[i] != '(' && s[i] != ')' && s[i] != '"' {
+				i++
+			}
+
+			word := s[start:i]
+
+			if kind, ok := keywords[strings.ToUpper(word)]; ok {
+				tokens = append(tokens, token{kind: kind, text: strings.ToUpper(word), pos: start + 1})
+
+				continue
+			}
+
+			tokens = append(tokens, token{kind: tokenLabel, text: word, pos: start + 1})
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(s) + 1}), nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,278 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package query implements a small boolean query language over routing labels.
+//
+// Queries combine labels with AND, OR, NOT and parentheses:
+//
+//	/skills/nlp/* AND NOT (/locators/docker_image OR /domains/research)
+//
+// A label is matched exactly, unless it ends with "/*", in which case it
+// matches every label below that prefix. Labels containing spaces or
+// parentheses can be double-quoted. Keywords are case-insensitive and NOT
+// binds tighter than AND, which binds tighter than OR.
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Expr is a node of a parsed query.
+type Expr interface {
+	fmt.Stringer
+
+	expr()
+}
+
+// Label matches records carrying a label.
+// If Wildcard is set, it matches records carrying any label below Value instead.
+type Label struct {
+	Value    string
+	Wildcard bool
+
+	// Pos is the 1-based position of the label in the query.
+	Pos int
+}
+
+// Prefix returns the label prefix matched by a wildcard label.
+func (l *Label) Prefix() string {
+	return l.Value + "/"
+}
+
+func (l *Label) String() string {
+	value := l.Value
+	if l.Wildcard {
+		value += "/*"
+	}
+
+	if strings.ContainsAny(value, " \t\n()") {
+		return `"` + value + `"`
+	}
+
+	return value
+}
+
+// And matches records matched by all of its operands.
+type And struct {
+	Operands []Expr
+}
+
+func (a *And) String() string {
+	return join(a.Operands, " AND ")
+}
+
+// Or matches records matched by any of its operands.
+type Or struct {
+	Operands []Expr
+}
+
+func (o *Or) String() string {
+	return join(o.Operands, " OR ")
+}
+
+// Not matches records not matched by its operand.
+type Not struct {
+	Operand Expr
+}
+
+func (n *Not) String() string {
+	return "NOT " + n.Operand.String()
+}
+
+func (*Label) expr() {}
+func (*And) expr()   {}
+func (*Or) expr()    {}
+func (*Not) expr()   {}
+
+func join(operands []Expr, sep string) string {
+	parts := make([]string, len(operands))
+	for i, operand := range operands {
+		parts[i] = operand.String()
+	}
+
+	return "(" + strings.Join(parts, sep) + ")"
+}
+
+// SyntaxError is returned by Parse for malformed queries.
+type SyntaxError struct {
+	// Pos is the 1-based position in the query where the error was detected.
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+// Parse parses a query string into an expression.
+func Parse(s string) (Expr, error) {
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	if p.peek().kind == tokenEOF {
+		return nil, p.errorf(p.peek(), "empty query")
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorf(tok, "unexpected %s after expression", tok)
+	}
+
+	return expr, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	return &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// parseOr parses: and { OR and }.
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []Expr{left}
+
+	for p.peek().kind == tokenOr {
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		operands = append(operands, right)
+	}
+
+	if len(operands) == 1 {
+		return left, nil
+	}
+
+	return &Or{Operands: operands}, nil
+}
+
+// parseAnd parses: unary { AND unary }.
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []Expr{left}
+
+	for p.peek().kind == tokenAnd {
+		p.next()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		operands = append(operands, right)
+	}
+
+	if len(operands) == 1 {
+		return left, nil
+	}
+
+	return &And{Operands: operands}, nil
+}
+
+// parseUnary parses: NOT unary | primary.
+func (p *parser) parseUnary() (Expr, error) {
+	if p.peek().kind != tokenNot {
+		return p.parsePrimary()
+	}
+
+	p.next()
+
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Not{Operand: operand}, nil
+}
+
+// parsePrimary parses: label | ( or ).
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenLabel:
+		return parseLabel(tok)
+
+	case tokenLParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.peek(); closing.kind != tokenRParen {
+			return nil, p.errorf(closing, "expected ) to close ( at position %d, found %s", tok.pos, closing)
+		}
+
+		p.next()
+
+		return expr, nil
+
+	default:
+		return nil, p.errorf(tok, "expected label or (, found %s", tok)
+	}
+}
+
+// parseLabel validates a label token and converts it into a label expression.
+func parseLabel(tok token) (*Label, error) {
+	value := tok.text
+	label := &Label{Value: value, Pos: tok.pos}
+
+	if strings.HasSuffix(value, "/*") {
+		label.Value = strings.TrimSuffix(value, "/*")
+		label.Wildcard = true
+	}
+
+	if idx := strings.Index(label.Value, "*"); idx >= 0 {
+		return nil, &SyntaxError{
+			Pos: tok.pos + tok.offset + idx,
+			Msg: "wildcard is only supported as a trailing /*",
+		}
+	}
+
+	if !strings.HasPrefix(value, "/") {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("label %q must start with /", value)}
+	}
+
+	if label.Value == "" && !label.Wildcard || strings.HasSuffix(label.Value, "/") {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("label %q has an empty path segment", value)}
+	}
+
+	return label, nil
+}
@@ -0,0 +1,228 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "/skills/nlp", want: "/skills/nlp"},
+		{query: "/skills/nlp/*", want: "/skills/nlp/*"},
+		{query: `"/skills/natural language"`, want: `"/skills/natural language"`},
+		{query: "/a AND /b", want: "(/a AND /b)"},
+		{query: "/a and /b or /c", want: "((/a AND /b) OR /c)"},
+		{query: "/a OR /b AND /c", want: "(/a OR (/b AND /c))"},
+		{query: "(/a OR /b) AND /c", want: "((/a OR /b) AND /c)"},
+		{query: "NOT /a AND /b", want: "(NOT /a AND /b)"},
+		{query: "NOT (/a AND /b)", want: "NOT (/a AND /b)"},
+		{query: "NOT NOT /a", want: "NOT NOT /a"},
+		{query: "/a AND /b AND /c OR /d OR /e", want: "((/a AND /b AND /c) OR /d OR /e)"},
+		{query: "/skills/nlp/* AND NOT /deploy/docker", want: "(/skills/nlp/* AND NOT /deploy/docker)"},
+		{query: "((/a))", want: "/a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			expr, err := Parse(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, expr.String())
+		})
+	}
+}
+
+func TestParseSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{query: "", pos: 1, msg: "empty query"},
+		{query: "   ", pos: 4, msg: "empty query"},
+		{query: "/a AND", pos: 7, msg: "expected label or (, found end of query"},
+		{query: "/a AND OR /b", pos: 8, msg: "expected label or (, found OR"},
+		{query: "/a /b", pos: 4, msg: `unexpected label "/b" after expression`},
+		{query: "(/a OR /b", pos: 10, msg: "expected ) to close ( at position 1, found end of query"},
+		{query: "/a)", pos: 3, msg: "unexpected ) after expression"},
+		{query: "/a AND ()", pos: 9, msg: "expected label or (, found )"},
+		{query: "/a AND skills", pos: 8, msg: `label "skills" must start with /`},
+		{query: "/a AND /skills/*/x", pos: 16, msg: "wildcard is only supported as a trailing /*"},
+		{query: `/a OR "/b*"`, pos: 10, msg: "wildcard is only supported as a trailing /*"},
+		{query: "/a OR /b/", pos: 7, msg: `label "/b/" has an empty path segment`},
+		{query: `/a OR "/b`, pos: 7, msg: "unterminated quoted label"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(tt.query)
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			assert.Equal(t, tt.pos, syntaxErr.Pos)
+			assert.Equal(t, tt.msg, syntaxErr.Msg)
+		})
+	}
+}
+
+// memoryIndex is an Index over a fixed label → records mapping.
+type memoryIndex map[string][]string
+
+func (m memoryIndex) Records(_ context.Context, label string) (Set, error) {
+	return NewSet(m[label]...), nil
+}
+
+func (m memoryIndex) Labels(_ context.Context, prefix string) ([]string, error) {
+	var labels []string
+
+	for label := range m {
+		if strings.HasPrefix(label, prefix) {
+			labels = append(labels, label)
+		}
+	}
+
+	return labels, nil
+}
+
+func (m memoryIndex) All(_ context.Context) (Set, error) {
+	all := Set{}
+
+	for _, cids := range m {
+		for _, cid := range cids {
+			all[cid] = struct{}{}
+		}
+	}
+
+	return all, nil
+}
+
+func TestEval(t *testing.T) {
+	index := memoryIndex{
+		"/skills/nlp":                 {"r1"},
+		"/skills/nlp/summarization":   {"r1", "r2"},
+		"/skills/nlp/translation":     {"r3"},
+		"/skills/vision":              {"r4"},
+		"/locators/docker_image":      {"r1", "r3", "r4"},
+		"/locators/source_code":       {"r2"},
+		"/domains/research":           {"r2", "r4"},
+		"/skills/natural language/qa": {"r5"},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "/skills/nlp", want: []string{"r1"}},
+		{query: "/skills/nlp/*", want: []string{"r1", "r2", "r3"}},
+		{query: "/skills/missing", want: nil},
+		{query: "/skills/nlp/* AND /locators/docker_image", want: []string{"r1", "r3"}},
+		{query: "/skills/nlp/* AND NOT /locators/docker_image", want: []string{"r2"}},
+		{query: "/skills/vision OR /domains/research", want: []string{"r2", "r4"}},
+		{query: "/skills/vision OR /domains/research AND /locators/source_code", want: []string{"r2", "r4"}},
+		{query: "(/skills/vision OR /domains/research) AND /locators/source_code", want: []string{"r2"}},
+		{query: "NOT /locators/docker_image", want: []string{"r2", "r5"}},
+		{query: "NOT /locators/docker_image AND NOT /domains/research", want: []string{"r5"}},
+		{query: "NOT (/skills/nlp/* OR /skills/vision)", want: []string{"r5"}},
+		{query: `"/skills/natural language/*"`, want: []string{"r5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			expr, err := Parse(tt.query)
+			require.NoError(t, err)
+
+			set, err := Eval(t.Context(), expr, index)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sorted(set))
+		})
+	}
+}
+
+func TestEvalExpansionLimit(t *testing.T) {
+	index := memoryIndex{
+		"/skills/nlp/a": {"r1"},
+		"/skills/nlp/b": {"r2"},
+		"/skills/nlp/c": {"r3"},
+		"/skills/other": {"r4"},
+	}
+
+	eval := func(query string, limit int) (Set, error) {
+		expr, err := Parse(query)
+		require.NoError(t, err)
+
+		return Eval(t.Context(), expr, index, WithMaxExpandedLabels(limit))
+	}
+
+	t.Run("wildcard within limit", func(t *testing.T) {
+		set, err := eval("/skills/nlp/*", 3)
+		require.NoError(t, err)
+		assert.Len(t, set, 3)
+	})
+
+	t.Run("wildcard over limit", func(t *testing.T) {
+		_, err := eval("/skills/nlp/*", 2)
+		require.ErrorIs(t, err, ErrTooManyLabels)
+		assert.ErrorContains(t, err, "/skills/nlp/* at position 1")
+	})
+
+	t.Run("limit counts all labels of the query", func(t *testing.T) {
+		_, err := eval("/skills/nlp/* OR /skills/other", 3)
+		require.ErrorIs(t, err, ErrTooManyLabels)
+		assert.ErrorContains(t, err, "/skills/other at position 18")
+	})
+
+	t.Run("default limit", func(t *testing.T) {
+		large := memoryIndex{}
+		for i := range DefaultMaxExpandedLabels + 1 {
+			large["/skills/x/"+strings.Repeat("a", i+1)] = []string{"r"}
+		}
+
+		expr, err := Parse("/skills/x/*")
+		require.NoError(t, err)
+
+		_, err = Eval(t.Context(), expr, large)
+		require.ErrorIs(t, err, ErrTooManyLabels)
+	})
+}
+
+func TestEvalIndexErrors(t *testing.T) {
+	expr, err := Parse("/a AND NOT /b")
+	require.NoError(t, err)
+
+	_, err = Eval(t.Context(), expr, failingIndex{})
+	require.ErrorIs(t, err, errIndex)
+}
+
+var errIndex = errors.New("index unavailable")
+
+type failingIndex struct{}
+
+func (failingIndex) Records(context.Context, string) (Set, error)     { return nil, errIndex }
+func (failingIndex) Labels(context.Context, string) ([]string, error) { return nil, errIndex }
+func (failingIndex) All(context.Context) (Set, error)                 { return nil, errIndex }
+
+func sorted(set Set) []string {
+	if len(set) == 0 {
+		return nil
+	}
+
+	cids := make([]string, 0, len(set))
+	for cid := range set {
+		cids = append(cids, cid)
+	}
+
+	sort.Strings(cids)
+
+	return cids
+}
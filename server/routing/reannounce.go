@@ -0,0 +1,181 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StartReannounceTask starts a background task that re-announces local records published with a TTL
+// before their announcements expire, and removes expired announcements of records deleted from the store.
+// The schedule is derived from the announcements persisted in the datastore, so it survives restarts.
+// The wg parameter is used to track this goroutine in the parent's WaitGroup.
+func (c *CleanupManager) StartReannounceTask(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(ReannounceCheckInterval)
+
+	cleanupLogger.Info("Started TTL re-announcement task", "interval", ReannounceCheckInterval)
+
+	defer func() {
+		ticker.Stop()
+		wg.Done()
+		cleanupLogger.Debug("TTL re-announcement task stopped")
+	}()
+
+	// Catch up with announcements that became due while the server was not running
+	c.reannounceExpiring(ctx, time.Now())
+
+	for {
+		select {
+		case <-ctx.Done():
+			cleanupLogger.Info("TTL re-announcement task stopping (context cancelled)")
+
+			return
+		case <-ticker.C:
+			c.reannounceExpiring(ctx, time.Now())
+		}
+	}
+}
+
+// reannounceExpiring re-announces local records whose announcements are due for re-announcement,
+// and removes expired announcements of records that no longer exist in the store.
+func (c *CleanupManager) reannounceExpiring(ctx context.Context, now time.Time) {
+	due := c.dueAnnouncements(ctx, now)
+	if len(due) == 0 {
+		return
+	}
+
+	reannouncedCount := 0
+	expiredCount := 0
+
+	for cid, ann := range due {
+		ref := &corev1.RecordRef{Cid: cid}
+
+		_, err := c.storeAPI.Lookup(ctx, ref)
+		if status.Code(err) == codes.NotFound {
+			// Deleted records are not re-announced, so their announcements expire
+			if ann.expired(now) && c.cleanupLabelsForCID(ctx, cid) {
+				cleanupLogger.Debug("Removed expired announcement of deleted record", "cid", cid)
+
+				expiredCount++
+			}
+
+			continue
+		}
+
+		if err != nil {
+			cleanupLogger.Warn("Failed to look up record for re-announcement", "cid", cid, "error", err)
+
+			continue
+		}
+
+		if c.reannounce(ctx, cid, ann.TTL) {
+			reannouncedCount++
+		}
+	}
+
+	cleanupLogger.Info("Completed TTL re-announcement cycle",
+		"due", len(due),
+		"reannounced", reannouncedCount,
+		"expired", expiredCount)
+}
+
+// dueAnnouncements returns the local announcements that are due for re-announcement, keyed by CID.
+func (c *CleanupManager) dueAnnouncements(ctx context.Context, now time.Time) map[string]*announcement {
+	results, err := c.dstore.Query(ctx, query.Query{
+		Prefix: "/records/",
+	})
+	if err != nil {
+		cleanupLogger.Error("Failed to query local records for re-announcement", "error", err)
+
+		return nil
+	}
+	defer results.Close()
+
+	due := make(map[string]*announcement)
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			cleanupLogger.Warn("Error reading local record for re-announcement", "error", result.Error)
+
+			continue
+		}
+
+		ann, err := parseAnnouncement(result.Value)
+		if err != nil {
+			cleanupLogger.Warn("Failed to parse record announcement", "key", result.Key, "error", err)
+
+			continue
+		}
+
+		if ann.dueForReannounce(now) {
+			due[path.Base(result.Key)] = ann
+		}
+	}
+
+	return due
+}
+
+// reannounce renews the local announcement of the record and announces it to the network again.
+// Announcements that were unpublished or re-published with a different TTL in the meantime are left untouched.
+func (c *CleanupManager) reannounce(ctx context.Context, cid string, ttl time.Duration) bool {
+	recordKey := datastore.NewKey("/records/" + cid)
+
+	value, err := c.dstore.Get(ctx, recordKey)
+	if errors.Is(err, datastore.ErrNotFound) {
+		return false
+	}
+
+	if err != nil {
+		cleanupLogger.Warn("Failed to get record announcement", "cid", cid, "error", err)
+
+		return false
+	}
+
+	current, err := parseAnnouncement(value)
+	if err != nil || current.TTL != ttl {
+		return false
+	}
+
+	announcementBytes, err := current.renewed().marshal()
+	if err != nil {
+		cleanupLogger.Warn("Failed to renew record announcement", "cid", cid, "error", err)
+
+		return false
+	}
+
+	if err := c.dstore.Put(ctx, recordKey, announcementBytes); err != nil {
+		cleanupLogger.Warn("Failed to renew record announcement", "cid", cid, "error", err)
+
+		return false
+	}
+
+	// Announce to the network on a best-effort basis, the local announcement is already renewed
+	if c.publishFunc != nil {
+		record, err := c.storeAPI.Pull(ctx, &corev1.RecordRef{Cid: cid})
+		if err != nil {
+			cleanupLogger.Warn("Failed to pull record for re-announcement", "cid", cid, "error", err)
+
+			return true
+		}
+
+		if err := c.publishFunc(ctx, adapters.NewRecordAdapter(record)); err != nil {
+			cleanupLogger.Warn("Failed to re-announce record to network", "cid", cid, "error", err)
+		}
+	}
+
+	cleanupLogger.Debug("Re-announced record", "cid", cid, "ttl", ttl)
+
+	return true
+}
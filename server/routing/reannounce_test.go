@@ -0,0 +1,211 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/datastore"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// reannounceStore reports deleted records as not found, like the OCI store.
+type reannounceStore struct {
+	*mockStore
+}
+
+func (s *reannounceStore) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	meta, err := s.mockStore.Lookup(ctx, ref)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", ref.GetCid())
+	}
+
+	return meta, nil
+}
+
+func newReannounceTestRecord(t *testing.T, name string) *corev1.Record {
+	t.Helper()
+
+	return corev1.New(&typesv1alpha0.Record{
+		Name:          name,
+		SchemaVersion: "v0.3.1",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: toPtr("category1"), ClassName: toPtr("class1")},
+		},
+	})
+}
+
+func newReannounceTestManager(dstore types.Datastore, store types.StoreAPI, published *atomic.Int32) *CleanupManager {
+	return &CleanupManager{
+		dstore:      dstore,
+		storeAPI:    store,
+		localPeerID: testPeerID,
+		publishFunc: func(context.Context, types.Record) error {
+			published.Add(1)
+
+			return nil
+		},
+	}
+}
+
+func listTTLs(t *testing.T, r *routeLocal) map[string]*routingv1.ListResponse {
+	t.Helper()
+
+	ch, err := r.List(t.Context(), &routingv1.ListRequest{}, types.AllNamespaces)
+	require.NoError(t, err)
+
+	responses := make(map[string]*routingv1.ListResponse)
+	for resp := range ch {
+		responses[resp.GetRecordRef().GetCid()] = resp
+	}
+
+	return responses
+}
+
+func TestPublishWithTTL_ListRemainingTTL(t *testing.T) {
+	dstore, err := datastore.New()
+	require.NoError(t, err)
+
+	store := &reannounceStore{mockStore: newMockStore()}
+	r := newLocal(store, dstore, testPeerID)
+
+	expiring := newReannounceTestRecord(t, "expiring-agent")
+	permanent := newReannounceTestRecord(t, "permanent-agent")
+
+	require.NoError(t, r.PublishWithTTL(t.Context(), adapters.NewRecordAdapter(expiring), time.Hour))
+	require.NoError(t, r.Publish(t.Context(), adapters.NewRecordAdapter(permanent)))
+
+	responses := listTTLs(t, r)
+	require.Len(t, responses, 2)
+
+	labelCount := len(responses[expiring.GetCid()].GetLabels())
+
+	ttl := responses[expiring.GetCid()].GetTtl().AsDuration()
+	assert.Greater(t, ttl, 59*time.Minute)
+	assert.LessOrEqual(t, ttl, time.Hour)
+	assert.Nil(t, responses[permanent.GetCid()].GetTtl(), "announcements without TTL do not expire")
+
+	t.Run("republish with different TTL updates the announcement", func(t *testing.T) {
+		require.NoError(t, r.PublishWithTTL(t.Context(), adapters.NewRecordAdapter(expiring), 10*time.Minute))
+		require.NoError(t, r.PublishWithTTL(t.Context(), adapters.NewRecordAdapter(permanent), 5*time.Minute))
+
+		responses := listTTLs(t, r)
+		assert.LessOrEqual(t, responses[expiring.GetCid()].GetTtl().AsDuration(), 10*time.Minute)
+		assert.LessOrEqual(t, responses[permanent.GetCid()].GetTtl().AsDuration(), 5*time.Minute)
+
+		// Labels are not duplicated by re-publishing
+		assert.Len(t, responses[expiring.GetCid()].GetLabels(), labelCount)
+	})
+
+	t.Run("republish without TTL removes the expiry", func(t *testing.T) {
+		require.NoError(t, r.Publish(t.Context(), adapters.NewRecordAdapter(expiring)))
+
+		responses := listTTLs(t, r)
+		assert.Nil(t, responses[expiring.GetCid()].GetTtl())
+	})
+}
+
+func TestReannounce_RefreshAndExpiry(t *testing.T) {
+	const ttl = 400 * time.Millisecond
+
+	dstore, err := datastore.New()
+	require.NoError(t, err)
+
+	store := &reannounceStore{mockStore: newMockStore()}
+	r := newLocal(store, dstore, testPeerID)
+
+	var published atomic.Int32
+
+	manager := newReannounceTestManager(dstore, store, &published)
+
+	alive := newReannounceTestRecord(t, "alive-agent")
+	deleted := newReannounceTestRecord(t, "deleted-agent")
+
+	for _, record := range []*corev1.Record{alive, deleted} {
+		_, err := store.Push(t.Context(), record)
+		require.NoError(t, err)
+		require.NoError(t, r.PublishWithTTL(t.Context(), adapters.NewRecordAdapter(record), ttl))
+	}
+
+	require.NoError(t, store.Delete(t.Context(), &corev1.RecordRef{Cid: deleted.GetCid()}))
+
+	// Nothing is due while most of the TTL remains
+	manager.reannounceExpiring(t.Context(), time.Now())
+	assert.Equal(t, int32(0), published.Load())
+
+	// Past the re-announcement threshold, only the record still in the store is refreshed
+	time.Sleep(ttl * 3 / 4)
+	manager.reannounceExpiring(t.Context(), time.Now())
+	assert.Equal(t, int32(1), published.Load())
+
+	responses := listTTLs(t, r)
+	require.Contains(t, responses, alive.GetCid())
+	assert.Greater(t, responses[alive.GetCid()].GetTtl().AsDuration(), ttl/2, "refreshed announcement has a renewed TTL")
+
+	// Once expired, the announcement of the deleted record is no longer listed and gets removed
+	time.Sleep(ttl / 2)
+
+	responses = listTTLs(t, r)
+	assert.Contains(t, responses, alive.GetCid())
+	assert.NotContains(t, responses, deleted.GetCid())
+
+	manager.reannounceExpiring(t.Context(), time.Now())
+
+	exists, err := dstore.Has(t.Context(), ipfsdatastore.NewKey("/records/"+deleted.GetCid()))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = dstore.Has(t.Context(), ipfsdatastore.NewKey("/records/"+alive.GetCid()))
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestReannounce_ReloadsScheduleAfterRestart(t *testing.T) {
+	const ttl = 200 * time.Millisecond
+
+	dir := t.TempDir()
+
+	dstore, err := datastore.New(datastore.WithFsProvider(dir))
+	require.NoError(t, err)
+
+	store := &reannounceStore{mockStore: newMockStore()}
+	record := newReannounceTestRecord(t, "restarted-agent")
+
+	_, err = store.Push(t.Context(), record)
+	require.NoError(t, err)
+	require.NoError(t, newLocal(store, dstore, testPeerID).PublishWithTTL(t.Context(), adapters.NewRecordAdapter(record), ttl))
+	require.NoError(t, dstore.Close())
+
+	// The announcement expires while the server is down
+	time.Sleep(ttl)
+
+	dstore, err = datastore.New(datastore.WithFsProvider(dir))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = dstore.Close() })
+
+	r := newLocal(store, dstore, testPeerID)
+	assert.Empty(t, listTTLs(t, r))
+
+	var published atomic.Int32
+
+	newReannounceTestManager(dstore, store, &published).reannounceExpiring(t.Context(), time.Now())
+	assert.Equal(t, int32(1), published.Load())
+
+	responses := listTTLs(t, r)
+	require.Contains(t, responses, record.GetCid())
+	assert.Positive(t, responses[record.GetCid()].GetTtl().AsDuration())
+}
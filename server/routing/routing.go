@@ -14,14 +14,21 @@ package routing
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/server/datastore"
 	"github.com/agntcy/dir/server/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
 	"google.golang.org/grpc/status"
 )
 
+const tracerName = "github.com/agntcy/dir/server/routing"
+
 type route struct {
 	local  *routeLocal
 	remote *routeRemote
@@ -64,12 +71,53 @@ func New(ctx context.Context, store types.StoreAPI, opts types.APIOptions) (type
 	// Create local router with peer ID
 	mainRounter.local = newLocal(store, dstore, localPeerID)
 
+	// Move the records published before namespaces were introduced to the legacy namespace
+	if err := backfillNamespaces(ctx, dstore); err != nil {
+		return nil, fmt.Errorf("failed to backfill routing namespaces: %w", err)
+	}
+
 	return mainRounter, nil
 }
 
 func (r *route) Publish(ctx context.Context, record types.Record) error {
+	return r.PublishWithTTL(ctx, record, 0)
+}
+
+// PublishWithTTL publishes the record with an announcement that expires after the TTL.
+// The announcement is re-announced in the background while the record remains in the store.
+func (r *route) PublishWithTTL(ctx context.Context, record types.Record, ttl time.Duration) error {
+	return r.PublishWithOptions(ctx, record, types.PublishOptions{TTL: ttl})
+}
+
+// PublishWithOptions publishes the record in the namespace of the options, with an announcement
+// that expires after their TTL. Namespaces only scope List: the record is announced to the network
+// like any other record.
+func (r *route) PublishWithOptions(ctx context.Context, record types.Record, opts types.PublishOptions) error {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "routing.Publish")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("dir.record.cid", record.GetCid()),
+		attribute.String("dir.routing.namespace", opts.Namespace),
+		attribute.Bool("dir.routing.public", opts.Public),
+	)
+
+	if opts.TTL > 0 {
+		span.SetAttributes(attribute.String("dir.routing.ttl", opts.TTL.String()))
+	}
+
+	err := r.publish(ctx, record, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+
+	return err
+}
+
+func (r *route) publish(ctx context.Context, record types.Record, opts types.PublishOptions) error {
 	// Always publish data locally for archival/querying
-	err := r.local.Publish(ctx, record)
+	err := r.local.PublishWithOptions(ctx, record, opts)
 	if err != nil {
 		st := status.Convert(err)
 
@@ -89,10 +137,10 @@ func (r *route) Publish(ctx context.Context, record types.Record) error {
 	return nil
 }
 
-func (r *route) List(ctx context.Context, req *routingv1.ListRequest) (<-chan *routingv1.ListResponse, error) {
+func (r *route) List(ctx context.Context, req *routingv1.ListRequest, scope types.NamespaceScope) (<-chan *routingv1.ListResponse, error) {
 	// List is always local-only - it returns records that this peer is currently providing
 	// This operation does not interact with the network (per proto comment)
-	return r.local.List(ctx, req)
+	return r.local.List(ctx, req, scope)
 }
 
 func (r *route) Search(ctx context.Context, req *routingv1.SearchRequest) (<-chan *routingv1.SearchResponse, error) {
@@ -114,6 +162,24 @@ func (r *route) Unpublish(ctx context.Context, record types.Record) error {
 	return nil
 }
 
+// CheckHealth verifies that the routing layer can serve requests.
+// An empty routing table is healthy, as routing then operates in local-only mode.
+func (r *route) CheckHealth(_ context.Context) error {
+	if r.local == nil {
+		return errors.New("local routing is not initialized")
+	}
+
+	if r.remote == nil || r.remote.server == nil || r.remote.server.DHT() == nil {
+		return errors.New("remote routing is not initialized")
+	}
+
+	if len(r.remote.server.Host().Addrs()) == 0 {
+		return errors.New("routing host has no listen addresses")
+	}
+
+	return nil
+}
+
 // Stop stops the routing services and releases resources.
 // This should be called during server shutdown to clean up gracefully.
 func (r *route) Stop() error {
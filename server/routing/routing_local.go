@@ -11,12 +11,15 @@ import (
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/labels"
+	labelquery "github.com/agntcy/dir/server/routing/query"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
 	"github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 var localLogger = logging.Logger("routing/local")
@@ -37,6 +40,22 @@ func newLocal(store types.StoreAPI, dstore types.Datastore, localPeerID string)
 }
 
 func (r *routeLocal) Publish(ctx context.Context, record types.Record) error {
+	return r.PublishWithTTL(ctx, record, 0)
+}
+
+// PublishWithTTL publishes the record with an announcement that expires after the TTL,
+// unless it is re-announced. A zero TTL publishes an announcement that does not expire.
+// Publishing an already published record updates the TTL of its announcement.
+func (r *routeLocal) PublishWithTTL(ctx context.Context, record types.Record, ttl time.Duration) error {
+	return r.PublishWithOptions(ctx, record, types.PublishOptions{TTL: ttl})
+}
+
+// PublishWithOptions publishes the record in the namespace of the options, with an announcement
+// that expires after their TTL. Publishing an already published record updates the TTL of its
+// announcement, but not its namespace, and only updates its visibility from the same namespace.
+//
+//nolint:cyclop
+func (r *routeLocal) PublishWithOptions(ctx context.Context, record types.Record, opts types.PublishOptions) error {
 	if record == nil {
 		return status.Error(codes.InvalidArgument, "record is required") //nolint:wrapcheck // Mock should return exact error without wrapping
 	}
@@ -69,20 +88,23 @@ func (r *routeLocal) Publish(ctx context.Context, record types.Record) error {
 	}
 
 	if recordExists {
-		localLogger.Info("Skipping republish as record was already published", "cid", cid)
+		return r.updateAnnouncement(ctx, recordKey, cid, opts)
+	}
 
-		return nil
+	announcementBytes, err := newAnnouncement(opts).marshal()
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
 	}
 
 	// store record for later lookup
-	if err := batch.Put(ctx, recordKey, nil); err != nil {
+	if err := batch.Put(ctx, recordKey, announcementBytes); err != nil {
 		return status.Errorf(codes.Internal, "failed to put record key: %v", err)
 	}
 
 	// Update metrics for all record labels and store them locally for queries
 	// Note: This handles ALL local storage for both local-only and network scenarios
 	// Network announcements are handled separately by routing_remote when peers are available
-	labelList := types.GetLabelsFromRecord(record)
+	labelList := labels.FromRecord(record).RoutingLabels()
 	for _, label := range labelList {
 		// Create minimal metadata (PeerID and CID now in key)
 		metadata := &types.LabelMetadata{
@@ -118,13 +140,50 @@ func (r *routeLocal) Publish(ctx context.Context, record types.Record) error {
 		return status.Errorf(codes.Internal, "failed to update metrics: %v", err)
 	}
 
-	localLogger.Info("Successfully published record", "cid", cid)
+	localLogger.Info("Successfully published record", "cid", cid, "ttl", opts.TTL, "namespace", opts.Namespace, "public", opts.Public)
+
+	return nil
+}
+
+// updateAnnouncement renews the announcement of an already published record with the TTL and visibility of the options.
+// Labels and metrics are left untouched, as they do not change between publications.
+func (r *routeLocal) updateAnnouncement(ctx context.Context, recordKey datastore.Key, cid string, opts types.PublishOptions) error {
+	value, err := r.dstore.Get(ctx, recordKey)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get record key: %v", err)
+	}
+
+	existing, err := parseAnnouncement(value)
+	if err != nil {
+		localLogger.Warn("Replacing unreadable announcement", "cid", cid, "error", err)
+
+		existing = &announcement{Namespace: opts.Namespace}
+	}
+
+	republished := existing.republished(opts)
+	if err == nil && existing.unchangedBy(republished) {
+		localLogger.Info("Skipping republish as record was already published", "cid", cid)
+
+		return nil
+	}
+
+	announcementBytes, err := republished.marshal()
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if err := r.dstore.Put(ctx, recordKey, announcementBytes); err != nil {
+		return status.Errorf(codes.Internal, "failed to update record key: %v", err)
+	}
+
+	localLogger.Info("Updated announcement of already published record", "cid", cid,
+		"previous_ttl", existing.TTL, "ttl", opts.TTL, "namespace", republished.Namespace, "public", republished.Public)
 
 	return nil
 }
 
 //nolint:cyclop
-func (r *routeLocal) List(ctx context.Context, req *routingv1.ListRequest) (<-chan *routingv1.ListResponse, error) {
+func (r *routeLocal) List(ctx context.Context, req *routingv1.ListRequest, scope types.NamespaceScope) (<-chan *routingv1.ListResponse, error) {
 	localLogger.Debug("Called local routing's List method", "req", req)
 
 	// ✅ DEFENSIVE: Deduplicate queries for consistent behavior (same as remote Search)
@@ -136,6 +195,22 @@ func (r *routeLocal) List(ctx context.Context, req *routingv1.ListRequest) (<-ch
 			"originalCount", len(originalQueries), "deduplicatedCount", len(deduplicatedQueries))
 	}
 
+	matches := func(ctx context.Context, cid string) bool {
+		return r.matchesAllQueries(ctx, cid, deduplicatedQueries)
+	}
+
+	// Label queries are evaluated upfront so that invalid queries fail the request
+	if req.GetQuery() != "" {
+		matched, err := r.evalLabelQuery(ctx, req.GetQuery(), originalQueries)
+		if err != nil {
+			return nil, err
+		}
+
+		matches = func(_ context.Context, cid string) bool {
+			return matched.Has(cid)
+		}
+	}
+
 	// Output channel for results
 	outCh := make(chan *routingv1.ListResponse)
 
@@ -143,15 +218,25 @@ func (r *routeLocal) List(ctx context.Context, req *routingv1.ListRequest) (<-ch
 	go func() {
 		defer close(outCh)
 
-		r.listLocalRecords(ctx, deduplicatedQueries, req.GetLimit(), outCh)
+		r.listLocalRecords(ctx, matches, scope, req.GetLimit(), req.GetIncludeWithdrawn(), outCh)
 	}()
 
 	return outCh, nil
 }
 
-// listLocalRecords lists all local records with optional query filtering.
-// Uses the simple and efficient approach: start with /records/ index, then filter by queries.
-func (r *routeLocal) listLocalRecords(ctx context.Context, queries []*routingv1.RecordQuery, limit uint32, outCh chan<- *routingv1.ListResponse) {
+// listLocalRecords lists the local records in the namespace scope with optional query filtering.
+// Uses the simple and efficient approach: start with /records/ index, then filter by matches.
+// Withdrawn records are skipped unless includeWithdrawn is set.
+//
+//nolint:cyclop
+func (r *routeLocal) listLocalRecords(
+	ctx context.Context,
+	matches func(context.Context, string) bool,
+	scope types.NamespaceScope,
+	limit uint32,
+	includeWithdrawn bool,
+	outCh chan<- *routingv1.ListResponse,
+) {
 	processedCount := 0
 	limitInt := int(limit)
 
@@ -166,6 +251,8 @@ func (r *routeLocal) listLocalRecords(ctx context.Context, queries []*routingv1.
 	}
 	defer recordResults.Close()
 
+	now := time.Now()
+
 	// Step 2: For each local record, check if it matches ALL queries
 	for result := range recordResults.Next() {
 		if result.Error != nil {
@@ -180,8 +267,29 @@ func (r *routeLocal) listLocalRecords(ctx context.Context, queries []*routingv1.
 			continue
 		}
 
-		// Check if this record matches all queries (AND relationship)
-		if r.matchesAllQueries(ctx, cid, queries) {
+		// Skip expired announcements until they are re-announced or removed
+		ann, err := parseAnnouncement(result.Value)
+		if err != nil {
+			localLogger.Warn("Failed to parse record announcement", "cid", cid, "error", err)
+
+			ann = &announcement{}
+		}
+
+		if ann.expired(now) {
+			continue
+		}
+
+		// Records of other namespaces are not disclosed, unless they are public
+		if !scope.Includes(ann.Namespace, ann.Public) {
+			continue
+		}
+
+		if !includeWithdrawn && r.isWithdrawn(ctx, cid) {
+			continue
+		}
+
+		// Check if this record matches the request filters
+		if matches(ctx, cid) {
 			// Get labels for this record
 			internalLabels := r.getRecordLabelsEfficiently(ctx, cid)
 
@@ -191,12 +299,24 @@ func (r *routeLocal) listLocalRecords(ctx context.Context, queries []*routingv1.
 				apiLabels[i] = label.String()
 			}
 
-			// Send the response
-			outCh <- &routingv1.ListResponse{
-				RecordRef: &corev1.RecordRef{Cid: cid},
-				Labels:    apiLabels,
+			response := &routingv1.ListResponse{
+				RecordRef:  &corev1.RecordRef{Cid: cid},
+				Labels:     apiLabels,
+				Namespace:  ann.Namespace,
+				Visibility: routingv1.Visibility_VISIBILITY_NAMESPACE,
+			}
+
+			if ann.Public {
+				response.Visibility = routingv1.Visibility_VISIBILITY_PUBLIC
+			}
+
+			if ann.expires() {
+				response.Ttl = durationpb.New(ann.remaining(now))
 			}
 
+			// Send the response
+			outCh <- response
+
 			processedCount++
 			if limitInt > 0 && processedCount >= limitInt {
 				break
@@ -204,7 +324,51 @@ func (r *routeLocal) listLocalRecords(ctx context.Context, queries []*routingv1.
 		}
 	}
 
-	localLogger.Debug("Completed List operation", "processed", processedCount, "queries", len(queries))
+	localLogger.Debug("Completed List operation", "processed", processedCount)
+}
+
+// evalLabelQuery evaluates a label query against the labels of local records.
+// Local records without labels are included so that NOT queries can match them.
+func (r *routeLocal) evalLabelQuery(ctx context.Context, q string, queries []*routingv1.RecordQuery) (labelquery.Set, error) {
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get namespace entries: %v", err)
+	}
+
+	idx := newLabelIndex(entries, func(peerID string) bool {
+		return peerID == r.localPeerID
+	})
+
+	recordResults, err := r.dstore.Query(ctx, query.Query{Prefix: "/records/", KeysOnly: true})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query local records: %v", err)
+	}
+	defer recordResults.Close()
+
+	for result := range recordResults.Next() {
+		if result.Error == nil {
+			idx.addRecords(strings.TrimPrefix(result.Key, "/records/"))
+		}
+	}
+
+	return evalLabelQuery(ctx, q, queries, idx)
+}
+
+// isWithdrawn checks if the record has been withdrawn by its publisher.
+// Records whose status cannot be looked up are treated as not withdrawn.
+func (r *routeLocal) isWithdrawn(ctx context.Context, cid string) bool {
+	if r.store == nil {
+		return false
+	}
+
+	meta, err := r.store.Lookup(ctx, &corev1.RecordRef{Cid: cid})
+	if err != nil {
+		localLogger.Debug("Failed to lookup record lifecycle", "cid", cid, "error", err)
+
+		return false
+	}
+
+	return meta.GetLifecycle().IsWithdrawn()
 }
 
 // matchesAllQueries checks if a record matches ALL provided queries (AND relationship).
@@ -277,7 +441,7 @@ func (r *routeLocal) Unpublish(ctx context.Context, record types.Record) error {
 	}
 
 	// keep track of all record labels
-	labelList := types.GetLabelsFromRecord(record)
+	labelList := labels.FromRecord(record).RoutingLabels()
 
 	for _, label := range labelList {
 		// Delete enhanced key with CID and PeerID
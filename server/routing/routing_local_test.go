@@ -47,12 +47,14 @@ func TestPublish_InvalidObject(t *testing.T) {
 }
 
 type mockStore struct {
-	data map[string]*corev1.Record
+	data       map[string]*corev1.Record
+	lifecycles map[string]*corev1.Lifecycle
 }
 
 func newMockStore() *mockStore {
 	return &mockStore{
-		data: make(map[string]*corev1.Record),
+		data:       make(map[string]*corev1.Record),
+		lifecycles: make(map[string]*corev1.Lifecycle),
 	}
 }
 
@@ -70,7 +72,8 @@ func (m *mockStore) Push(_ context.Context, record *corev1.Record) (*corev1.Reco
 func (m *mockStore) Lookup(_ context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
 	if _, exists := m.data[ref.GetCid()]; exists {
 		return &corev1.RecordMeta{
-			Cid: ref.GetCid(),
+			Cid:       ref.GetCid(),
+			Lifecycle: m.lifecycles[ref.GetCid()],
 		}, nil
 	}
 
@@ -169,7 +172,7 @@ func TestPublishList_ValidSingleSkillQuery(t *testing.T) {
 			// list
 			refsChan, err := r.List(t.Context(), &routingv1.ListRequest{
 				Queries: queries,
-			})
+			}, types.AllNamespaces)
 			assert.NoError(t, err)
 
 			// Collect items from the channel
@@ -211,7 +214,7 @@ func TestPublishList_ValidSingleSkillQuery(t *testing.T) {
 				Value: "category2",
 			},
 		},
-	})
+	}, types.AllNamespaces)
 	assert.NoError(t, err)
 
 	// Collect items from the channel
@@ -271,7 +274,7 @@ func TestPublishList_ValidMultiSkillQuery(t *testing.T) {
 					Value: "category2/class2",
 				},
 			},
-		})
+		}, types.AllNamespaces)
 		assert.NoError(t, err)
 
 		// Collect items from the channel
@@ -288,6 +291,69 @@ func TestPublishList_ValidMultiSkillQuery(t *testing.T) {
 	})
 }
 
+func TestList_WithdrawnRecords(t *testing.T) {
+	dstore, err := datastore.New()
+	assert.NoError(t, err)
+
+	store := newMockStore()
+	r := newLocal(store, dstore, testPeerID)
+
+	cids := make(map[corev1.LifecycleStatus]string)
+
+	for _, lifecycleStatus := range []corev1.LifecycleStatus{
+		corev1.LifecycleStatus_LIFECYCLE_STATUS_ACTIVE,
+		corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED,
+		corev1.LifecycleStatus_LIFECYCLE_STATUS_WITHDRAWN,
+	} {
+		record := corev1.New(&typesv1alpha0.Record{
+			Name:          "agent-" + lifecycleStatus.String(),
+			SchemaVersion: "v0.3.1",
+		})
+
+		_, err := store.Push(t.Context(), record)
+		assert.NoError(t, err)
+		assert.NoError(t, r.Publish(t.Context(), adapters.NewRecordAdapter(record)))
+
+		store.lifecycles[record.GetCid()] = &corev1.Lifecycle{Status: lifecycleStatus}
+		cids[lifecycleStatus] = record.GetCid()
+	}
+
+	list := func(includeWithdrawn bool) []string {
+		refsChan, err := r.List(t.Context(), &routingv1.ListRequest{IncludeWithdrawn: includeWithdrawn}, types.AllNamespaces)
+		assert.NoError(t, err)
+
+		var listed []string
+		for ref := range refsChan {
+			listed = append(listed, ref.GetRecordRef().GetCid())
+		}
+
+		return listed
+	}
+
+	t.Run("withdrawn records are excluded by default", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{
+			cids[corev1.LifecycleStatus_LIFECYCLE_STATUS_ACTIVE],
+			cids[corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED],
+		}, list(false))
+	})
+
+	t.Run("withdrawn records are included on request", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{
+			cids[corev1.LifecycleStatus_LIFECYCLE_STATUS_ACTIVE],
+			cids[corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED],
+			cids[corev1.LifecycleStatus_LIFECYCLE_STATUS_WITHDRAWN],
+		}, list(true))
+	})
+
+	t.Run("reactivated records are listed again", func(t *testing.T) {
+		store.lifecycles[cids[corev1.LifecycleStatus_LIFECYCLE_STATUS_WITHDRAWN]] = &corev1.Lifecycle{
+			Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_ACTIVE,
+		}
+
+		assert.Len(t, list(false), 3)
+	})
+}
+
 func newBadgerDatastore(b *testing.B) types.Datastore {
 	b.Helper()
 
@@ -360,7 +426,7 @@ func Benchmark_RouteLocal(b *testing.B) {
 						Value: "category1/class1",
 					},
 				},
-			})
+			}, types.AllNamespaces)
 			assert.NoError(b, err)
 		}
 	})
@@ -386,7 +452,7 @@ func Benchmark_RouteLocal(b *testing.B) {
 						Value: "category1/class1",
 					},
 				},
-			})
+			}, types.AllNamespaces)
 			assert.NoError(b, err)
 		}
 	})
@@ -12,12 +12,12 @@ import (
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/labels"
 	"github.com/agntcy/dir/server/routing/internal/p2p"
 	"github.com/agntcy/dir/server/routing/pubsub"
 	"github.com/agntcy/dir/server/routing/rpc"
 	validators "github.com/agntcy/dir/server/routing/validators"
 	"github.com/agntcy/dir/server/types"
-	"github.com/agntcy/dir/server/types/adapters"
 	"github.com/agntcy/dir/utils/logging"
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore"
@@ -227,6 +227,14 @@ func newRemote(parentCtx context.Context,
 	//nolint:contextcheck // Intentionally passing routing context to child goroutine for lifecycle management
 	go routeAPI.cleanupManager.StartRemoteLabelCleanupTask(routeAPI.ctx, &routeAPI.wg)
 
+	routeAPI.wg.Add(1)
+	//nolint:contextcheck // Intentionally passing routing context to child goroutine for lifecycle management
+	go routeAPI.cleanupManager.StartReannounceTask(routeAPI.ctx, &routeAPI.wg)
+
+	routeAPI.wg.Add(1)
+	//nolint:contextcheck // Intentionally passing routing context to child goroutine for lifecycle management
+	go routeAPI.cleanupManager.StartTransactionJanitorTask(routeAPI.ctx, &routeAPI.wg)
+
 	return routeAPI, nil
 }
 
@@ -318,6 +326,10 @@ func (r *routeRemote) Search(ctx context.Context, req *routingv1.SearchRequest)
 		remoteLogger.Debug("Applied minimum match score for production safety", "original", req.GetMinMatchScore(), "applied", minMatchScore)
 	}
 
+	if req.GetQuery() != "" {
+		return r.searchLabelQuery(ctx, req.GetQuery(), originalQueries, req.GetLimit())
+	}
+
 	outCh := make(chan *routingv1.SearchResponse)
 
 	go func() {
@@ -403,6 +415,59 @@ func (r *routeRemote) searchRemoteRecords(ctx context.Context, queries []*routin
 	remoteLogger.Debug("Completed Search operation", "processed", processedCount, "queries", len(queries))
 }
 
+// searchLabelQuery searches for remote records matching a label query.
+// The query is evaluated upfront against the cached labels of remote peers so that
+// invalid queries fail the request. Each matching record is returned once, with a score of 1.
+func (r *routeRemote) searchLabelQuery(ctx context.Context, q string, queries []*routingv1.RecordQuery, limit uint32) (<-chan *routingv1.SearchResponse, error) {
+	localPeerID := r.server.Host().ID().String()
+
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get namespace entries: %v", err)
+	}
+
+	idx := newLabelIndex(entries, func(peerID string) bool {
+		return peerID != localPeerID
+	})
+
+	matched, err := evalLabelQuery(ctx, q, queries, idx)
+	if err != nil {
+		return nil, err
+	}
+
+	outCh := make(chan *routingv1.SearchResponse)
+
+	go func() {
+		defer close(outCh)
+
+		processedCIDs := make(map[string]bool)
+		limitInt := int(limit)
+
+		for _, entry := range entries {
+			if limitInt > 0 && len(processedCIDs) >= limitInt {
+				break
+			}
+
+			_, keyCID, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+			if err != nil || keyPeerID == localPeerID || processedCIDs[keyCID] || !matched.Has(keyCID) {
+				continue
+			}
+
+			outCh <- &routingv1.SearchResponse{
+				RecordRef:  &corev1.RecordRef{Cid: keyCID},
+				Peer:       r.createPeerInfo(ctx, keyPeerID),
+				MatchScore: 1,
+			}
+
+			processedCIDs[keyCID] = true
+		}
+
+		remoteLogger.Debug("Completed Search operation", "processed", len(processedCIDs), "query", q)
+	}()
+
+	return outCh, nil
+}
+
 // calculateMatchScore calculates how many queries match a remote record (OR logic).
 // Returns the matching queries and the match score for minimum threshold filtering.
 func (r *routeRemote) calculateMatchScore(ctx context.Context, cid string, queries []*routingv1.RecordQuery, peerID string) ([]*routingv1.RecordQuery, uint32) {
@@ -721,9 +786,7 @@ func (r *routeRemote) handleCIDProviderNotification(ctx context.Context, notif *
 		return
 	}
 
-	adapter := adapters.NewRecordAdapter(record)
-
-	labelList := types.GetLabelsFromRecord(adapter)
+	labelList := labels.ExtractLabels(record).RoutingLabels()
 	if len(labelList) == 0 {
 		remoteLogger.Warn("No labels found in remote record",
 			"cid", notif.Ref.GetCid(),
@@ -935,6 +998,7 @@ func (r *routeRemote) Stop() error {
 	// - handleNotify (DHT provider notifications)
 	// - StartLabelRepublishTask (periodic republishing)
 	// - StartRemoteLabelCleanupTask (stale label cleanup)
+	// - StartReannounceTask (TTL re-announcement and expiry)
 	r.cancel()
 
 	// Wait for all goroutines to finish gracefully
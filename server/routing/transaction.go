@@ -0,0 +1,367 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/labels"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stagedPrefix is the prefix of the announcements staged by atomic publications, stored under
+// "/staged/TXID/CID" keys. Staged announcements are not listed until their transaction is committed.
+const stagedPrefix = "/staged/"
+
+// stagedAnnouncement is an announcement staged in a transaction, with the labels computed when it was staged.
+type stagedAnnouncement struct {
+	// StagedAt is when the announcement was staged.
+	StagedAt time.Time `json:"staged_at"`
+	// TTL is the TTL of the announcement once committed, zero if it does not expire.
+	TTL time.Duration `json:"ttl,omitempty"`
+	// Namespace and Public are the namespace and visibility of the announcement once committed.
+	Namespace string `json:"namespace,omitempty"`
+	Public    bool   `json:"public,omitempty"`
+	// Labels are the routing labels of the record.
+	Labels []string `json:"labels,omitempty"`
+}
+
+func stagedKey(transactionID, cid string) datastore.Key {
+	return datastore.NewKey(stagedPrefix + transactionID + "/" + cid)
+}
+
+// PublishAtomic stages the announcements of all records in the transaction and commits them in a single
+// datastore batch, so that the records are listed together or not at all. If any record cannot be staged,
+// the announcements staged so far are rolled back. Staged announcements left behind, e.g. by a failed
+// rollback, are removed by the transaction janitor after StagedTransactionTimeout.
+//
+// The network cannot be updated atomically: the records are announced to the network once committed
+// locally, on a best-effort basis like re-announcements.
+func (r *route) PublishAtomic(ctx context.Context, transactionID string, records []types.Record, opts types.PublishOptions) error {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "routing.PublishAtomic")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("dir.routing.transaction_id", transactionID),
+		attribute.Int("dir.routing.records", len(records)),
+	)
+
+	err := r.publishAtomic(ctx, transactionID, records, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+
+	return err
+}
+
+func (r *route) publishAtomic(ctx context.Context, transactionID string, records []types.Record, opts types.PublishOptions) error {
+	for i, record := range records {
+		if err := r.local.Stage(ctx, transactionID, record, opts); err != nil {
+			r.rollback(ctx, transactionID)
+
+			st := status.Convert(err)
+
+			return status.Errorf(st.Code(), "failed to stage record %d: %s", i, st.Message())
+		}
+	}
+
+	if err := r.local.Commit(ctx, transactionID); err != nil {
+		r.rollback(ctx, transactionID)
+
+		st := status.Convert(err)
+
+		return status.Errorf(st.Code(), "failed to commit transaction: %s", st.Message())
+	}
+
+	if r.hasPeersInRoutingTable() {
+		for _, record := range records {
+			if err := r.remote.Publish(ctx, record); err != nil {
+				localLogger.Warn("Failed to announce committed record to the network",
+					"transaction_id", transactionID, "cid", record.GetCid(), "error", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// rollback removes the staged announcements of a transaction, leaving them to the janitor if it fails.
+func (r *route) rollback(ctx context.Context, transactionID string) {
+	// The rollback completes even if the request is cancelled
+	if err := r.local.Rollback(context.WithoutCancel(ctx), transactionID); err != nil {
+		localLogger.Error("Failed to roll back transaction", "transaction_id", transactionID, "error", err)
+	}
+}
+
+// Stage stages the announcement of the record in the transaction, computing its labels.
+// The record is not listed until the transaction is committed.
+func (r *routeLocal) Stage(ctx context.Context, transactionID string, record types.Record, opts types.PublishOptions) error {
+	if transactionID == "" || strings.Contains(transactionID, "/") {
+		return status.Errorf(codes.InvalidArgument, "invalid transaction ID %q", transactionID)
+	}
+
+	if record == nil {
+		return status.Error(codes.InvalidArgument, "record is required") //nolint:wrapcheck
+	}
+
+	cid := record.GetCid()
+	if cid == "" {
+		return status.Error(codes.InvalidArgument, "record has no CID") //nolint:wrapcheck
+	}
+
+	staged := &stagedAnnouncement{StagedAt: time.Now(), TTL: opts.TTL, Namespace: opts.Namespace, Public: opts.Public}
+	for _, label := range labels.FromRecord(record).RoutingLabels() {
+		staged.Labels = append(staged.Labels, label.String())
+	}
+
+	stagedBytes, err := json.Marshal(staged)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to serialize staged announcement: %v", err)
+	}
+
+	if err := r.dstore.Put(ctx, stagedKey(transactionID, cid), stagedBytes); err != nil {
+		return status.Errorf(codes.Internal, "failed to stage announcement: %v", err)
+	}
+
+	localLogger.Debug("Staged record announcement", "transaction_id", transactionID, "cid", cid)
+
+	return nil
+}
+
+// Commit publishes the announcements staged in the transaction in a single batch.
+// Records that are already published keep their labels and namespace, and get their announcement
+// updated with the options of the transaction like a re-publication.
+//
+//nolint:cyclop
+func (r *routeLocal) Commit(ctx context.Context, transactionID string) error {
+	staged, err := stagedAnnouncements(ctx, r.dstore, stagedPrefix+transactionID+"/")
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to read staged announcements: %v", err)
+	}
+
+	if len(staged) == 0 {
+		return status.Errorf(codes.NotFound, "transaction %s has no staged announcements", transactionID)
+	}
+
+	metrics, err := loadMetrics(ctx, r.dstore)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to load metrics: %v", err)
+	}
+
+	batch, err := r.dstore.Batch(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create batch: %v", err)
+	}
+
+	now := time.Now()
+
+	for _, key := range slices.Sorted(maps.Keys(staged)) {
+		ann := staged[key]
+		cid := path.Base(key)
+		recordKey := datastore.NewKey("/records/" + cid)
+
+		if err := batch.Delete(ctx, datastore.NewKey(key)); err != nil {
+			return status.Errorf(codes.Internal, "failed to delete staged announcement: %v", err)
+		}
+
+		opts := types.PublishOptions{TTL: ann.TTL, Namespace: ann.Namespace, Public: ann.Public}
+		committed := newAnnouncement(opts)
+
+		value, err := r.dstore.Get(ctx, recordKey)
+
+		switch {
+		case err == nil:
+			// Already published records only get their announcement renewed
+			existing, err := parseAnnouncement(value)
+			if err != nil {
+				break
+			}
+
+			committed = existing.republished(opts)
+			if existing.unchangedBy(committed) {
+				continue
+			}
+		case errors.Is(err, datastore.ErrNotFound):
+			for _, label := range ann.Labels {
+				metadataBytes, err := json.Marshal(&types.LabelMetadata{Timestamp: now, LastSeen: now})
+				if err != nil {
+					return status.Errorf(codes.Internal, "failed to serialize label metadata: %v", err)
+				}
+
+				labelKey := datastore.NewKey(BuildEnhancedLabelKey(types.Label(label), cid, r.localPeerID))
+				if err := batch.Put(ctx, labelKey, metadataBytes); err != nil {
+					return status.Errorf(codes.Internal, "failed to put label key: %v", err)
+				}
+
+				metrics.increment(types.Label(label))
+			}
+		default:
+			return status.Errorf(codes.Internal, "failed to get record key: %v", err)
+		}
+
+		announcementBytes, err := committed.marshal()
+		if err != nil {
+			return status.Errorf(codes.Internal, "%v", err)
+		}
+
+		if err := batch.Put(ctx, recordKey, announcementBytes); err != nil {
+			return status.Errorf(codes.Internal, "failed to put record key: %v", err)
+		}
+	}
+
+	if err := batch.Commit(ctx); err != nil {
+		return status.Errorf(codes.Internal, "failed to commit batch: %v", err)
+	}
+
+	if err := metrics.update(ctx, r.dstore); err != nil {
+		return status.Errorf(codes.Internal, "failed to update metrics: %v", err)
+	}
+
+	localLogger.Info("Committed transaction", "transaction_id", transactionID, "records", len(staged))
+
+	return nil
+}
+
+// Rollback removes the announcements staged in the transaction.
+func (r *routeLocal) Rollback(ctx context.Context, transactionID string) error {
+	staged, err := stagedAnnouncements(ctx, r.dstore, stagedPrefix+transactionID+"/")
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to read staged announcements: %v", err)
+	}
+
+	if err := deleteKeys(ctx, r.dstore, slices.Collect(maps.Keys(staged))); err != nil {
+		return status.Errorf(codes.Internal, "failed to delete staged announcements: %v", err)
+	}
+
+	localLogger.Info("Rolled back transaction", "transaction_id", transactionID, "records", len(staged))
+
+	return nil
+}
+
+// StartTransactionJanitorTask starts a background task that removes the staged announcements
+// of transactions that were neither committed nor rolled back within StagedTransactionTimeout.
+// The wg parameter is used to track this goroutine in the parent's WaitGroup.
+func (c *CleanupManager) StartTransactionJanitorTask(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(TransactionJanitorInterval)
+
+	cleanupLogger.Info("Started transaction janitor task", "interval", TransactionJanitorInterval)
+
+	defer func() {
+		ticker.Stop()
+		wg.Done()
+		cleanupLogger.Debug("Transaction janitor task stopped")
+	}()
+
+	// Remove the announcements abandoned while the server was not running
+	c.expireStagedTransactions(ctx, time.Now())
+
+	for {
+		select {
+		case <-ctx.Done():
+			cleanupLogger.Info("Transaction janitor task stopping (context cancelled)")
+
+			return
+		case <-ticker.C:
+			c.expireStagedTransactions(ctx, time.Now())
+		}
+	}
+}
+
+// expireStagedTransactions removes the announcements staged before now minus StagedTransactionTimeout.
+func (c *CleanupManager) expireStagedTransactions(ctx context.Context, now time.Time) {
+	staged, err := stagedAnnouncements(ctx, c.dstore, stagedPrefix)
+	if err != nil {
+		cleanupLogger.Error("Failed to read staged announcements", "error", err)
+
+		return
+	}
+
+	cutoff := now.Add(-StagedTransactionTimeout)
+
+	var expired []string
+
+	for key, ann := range staged {
+		if ann.StagedAt.Before(cutoff) {
+			expired = append(expired, key)
+		}
+	}
+
+	if len(expired) == 0 {
+		return
+	}
+
+	if err := deleteKeys(ctx, c.dstore, expired); err != nil {
+		cleanupLogger.Error("Failed to remove abandoned staged announcements", "error", err)
+
+		return
+	}
+
+	cleanupLogger.Info("Removed abandoned staged announcements", "count", len(expired))
+}
+
+// stagedAnnouncements returns the staged announcements under the prefix, keyed by datastore key.
+// Unreadable announcements are returned as staged at the zero time, so that the janitor removes them.
+func stagedAnnouncements(ctx context.Context, dstore types.Datastore, prefix string) (map[string]*stagedAnnouncement, error) {
+	results, err := dstore.Query(ctx, query.Query{Prefix: prefix})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query staged announcements: %w", err)
+	}
+	defer results.Close()
+
+	staged := make(map[string]*stagedAnnouncement)
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to read staged announcement: %w", result.Error)
+		}
+
+		var ann stagedAnnouncement
+		if err := json.Unmarshal(result.Value, &ann); err != nil {
+			localLogger.Warn("Failed to parse staged announcement", "key", result.Key, "error", err)
+
+			ann = stagedAnnouncement{}
+		}
+
+		staged[result.Key] = &ann
+	}
+
+	return staged, nil
+}
+
+// deleteKeys deletes the keys in a single batch.
+func deleteKeys(ctx context.Context, dstore types.Datastore, keys []string) error {
+	batch, err := dstore.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	for _, key := range keys {
+		if err := batch.Delete(ctx, datastore.NewKey(key)); err != nil {
+			return fmt.Errorf("failed to delete key %s: %w", key, err)
+		}
+	}
+
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/datastore"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// unstageableRecord is a record without a CID, which fails to be staged.
+type unstageableRecord struct {
+	types.Record
+}
+
+func (unstageableRecord) GetCid() string { return "" }
+
+func newTransactionTestRoute(t *testing.T) (*route, types.Datastore) {
+	t.Helper()
+
+	dstore, err := datastore.New()
+	require.NoError(t, err)
+
+	return &route{local: newLocal(newMockStore(), dstore, testPeerID)}, dstore
+}
+
+func newTransactionTestRecords(t *testing.T, count int) []types.Record {
+	t.Helper()
+
+	records := make([]types.Record, count)
+	for i := range records {
+		records[i] = adapters.NewRecordAdapter(newReannounceTestRecord(t, fmt.Sprintf("member-%d", i)))
+	}
+
+	return records
+}
+
+func countStaged(t *testing.T, dstore types.Datastore) int {
+	t.Helper()
+
+	results, err := dstore.Query(t.Context(), query.Query{Prefix: stagedPrefix, KeysOnly: true})
+	require.NoError(t, err)
+
+	entries, err := results.Rest()
+	require.NoError(t, err)
+
+	return len(entries)
+}
+
+func TestPublishAtomic(t *testing.T) {
+	t.Run("all records are listed together", func(t *testing.T) {
+		r, dstore := newTransactionTestRoute(t)
+		records := newTransactionTestRecords(t, 5)
+
+		require.NoError(t, r.PublishAtomic(t.Context(), "tx-1", records, types.PublishOptions{TTL: time.Hour}))
+
+		responses := listTTLs(t, r.local)
+		require.Len(t, responses, len(records))
+
+		for _, record := range records {
+			resp := responses[record.GetCid()]
+			require.NotNil(t, resp, "record %s is listed", record.GetCid())
+			assert.NotEmpty(t, resp.GetLabels(), "labels are committed with the record")
+			assert.LessOrEqual(t, resp.GetTtl().AsDuration(), time.Hour)
+		}
+
+		assert.Zero(t, countStaged(t, dstore), "committed announcements are no longer staged")
+	})
+
+	t.Run("failure on the Nth record publishes none", func(t *testing.T) {
+		r, dstore := newTransactionTestRoute(t)
+		records := newTransactionTestRecords(t, 5)
+		records[3] = unstageableRecord{Record: records[3]}
+
+		err := r.PublishAtomic(t.Context(), "tx-2", records, types.PublishOptions{})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "record 3")
+
+		assert.Empty(t, listTTLs(t, r.local), "no member of a failed transaction is listed")
+		assert.Zero(t, countStaged(t, dstore), "staged announcements are rolled back")
+	})
+
+	t.Run("already published records are renewed", func(t *testing.T) {
+		r, _ := newTransactionTestRoute(t)
+		records := newTransactionTestRecords(t, 2)
+
+		require.NoError(t, r.local.Publish(t.Context(), records[0]))
+		labelCount := len(listTTLs(t, r.local)[records[0].GetCid()].GetLabels())
+
+		require.NoError(t, r.PublishAtomic(t.Context(), "tx-3", records, types.PublishOptions{TTL: 10 * time.Minute}))
+
+		responses := listTTLs(t, r.local)
+		require.Len(t, responses, 2)
+		assert.Len(t, responses[records[0].GetCid()].GetLabels(), labelCount, "labels are not duplicated")
+		assert.LessOrEqual(t, responses[records[0].GetCid()].GetTtl().AsDuration(), 10*time.Minute)
+	})
+}
+
+func TestTransactionJanitor(t *testing.T) {
+	r, dstore := newTransactionTestRoute(t)
+	records := newTransactionTestRecords(t, 3)
+
+	// Stage a transaction that is never committed, e.g. the server stopped during the publication
+	for _, record := range records {
+		require.NoError(t, r.local.Stage(t.Context(), "abandoned", record, types.PublishOptions{}))
+	}
+
+	require.Equal(t, len(records), countStaged(t, dstore))
+	assert.Empty(t, listTTLs(t, r.local), "staged announcements are not listed")
+
+	var published atomic.Int32
+
+	manager := newReannounceTestManager(dstore, r.local.store, &published)
+
+	t.Run("recent stages are kept", func(t *testing.T) {
+		manager.expireStagedTransactions(t.Context(), time.Now())
+		assert.Equal(t, len(records), countStaged(t, dstore))
+	})
+
+	t.Run("abandoned stages are removed", func(t *testing.T) {
+		manager.expireStagedTransactions(t.Context(), time.Now().Add(StagedTransactionTimeout+time.Minute))
+		assert.Zero(t, countStaged(t, dstore))
+
+		err := r.local.Commit(context.Background(), "abandoned")
+		assert.Equal(t, codes.NotFound, status.Code(err), "removed transactions cannot be committed")
+		assert.Empty(t, listTTLs(t, r.local))
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+)
+
+const (
+	// ScannerNoop accepts all records without findings.
+	ScannerNoop = "noop"
+
+	// ScannerSecrets flags secrets embedded in the extension data of records.
+	ScannerSecrets = "secrets"
+
+	// ScannerWebhook scans records with an external service.
+	ScannerWebhook = "webhook"
+)
+
+const (
+	// ActionReject rejects pushes of records with findings.
+	ActionReject = "reject"
+
+	// ActionWarn accepts pushes of records with findings and reports them in the record metadata.
+	ActionWarn = "warn"
+)
+
+const (
+	DefaultTimeout        = 10 * time.Second
+	DefaultSecretsAction  = ActionReject
+	DefaultWebhookTimeout = 5 * time.Second
+)
+
+// Pattern is a deny-list entry of the secrets scanner.
+type Pattern struct {
+	// Name of the pattern, reported as the rule of its findings
+	Name string `json:"name,omitempty" mapstructure:"name"`
+
+	// Regular expression matched against the JSON of extension data, in RE2 syntax
+	Regex string `json:"regex,omitempty" mapstructure:"regex"`
+}
+
+// SecretsConfig configures the secrets scanner.
+type SecretsConfig struct {
+	// Action for records with suspected secrets, "reject" or "warn"
+	Action string `json:"action,omitempty" mapstructure:"action"`
+
+	// Patterns matched in addition to the built-in ones, e.g. tokens of internal services
+	Patterns []Pattern `json:"patterns,omitempty" mapstructure:"patterns"`
+}
+
+// WebhookConfig configures the webhook scanner.
+type WebhookConfig struct {
+	// URL the canonical JSON of pushed records is posted to
+	URL string `json:"url,omitempty" mapstructure:"url"`
+
+	// Timeout of a scan request
+	Timeout time.Duration `json:"timeout,omitempty" mapstructure:"timeout"`
+
+	// Accept pushes with a warning if the webhook fails or times out, instead of rejecting them
+	FailOpen bool `json:"fail_open,omitempty" mapstructure:"fail_open"`
+}
+
+// Config contains configuration for the scanning of pushed records.
+type Config struct {
+	// Scanners run on pushed records in order, any of "noop", "secrets" and "webhook".
+	// Pushed records are not scanned if empty.
+	Scanners []string `json:"scanners,omitempty" mapstructure:"scanners"`
+
+	// Timeout bounding the scan of a record by all scanners
+	Timeout time.Duration `json:"timeout,omitempty" mapstructure:"timeout"`
+
+	// Secrets scanner configuration
+	Secrets SecretsConfig `json:"secrets,omitempty" mapstructure:"secrets"`
+
+	// Webhook scanner configuration
+	Webhook WebhookConfig `json:"webhook,omitempty" mapstructure:"webhook"`
+}
+
+// Enabled reports whether pushed records are scanned.
+func (c *Config) Enabled() bool {
+	return len(c.Scanners) > 0
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	if c.Timeout <= 0 {
+		return errors.New("scan timeout must be positive")
+	}
+
+	for i, scanner := range c.Scanners {
+		if slices.Contains(c.Scanners[:i], scanner) {
+			return fmt.Errorf("scanner %q is configured more than once", scanner)
+		}
+
+		switch scanner {
+		case ScannerNoop:
+		case ScannerSecrets:
+			if err := c.Secrets.validate(); err != nil {
+				return fmt.Errorf("secrets scanner: %w", err)
+			}
+		case ScannerWebhook:
+			if err := c.Webhook.validate(); err != nil {
+				return fmt.Errorf("webhook scanner: %w", err)
+			}
+		default:
+			return fmt.Errorf("invalid scanner %q: expected %q, %q or %q", scanner, ScannerNoop, ScannerSecrets, ScannerWebhook)
+		}
+	}
+
+	return nil
+}
+
+func (c *SecretsConfig) validate() error {
+	if c.Action != ActionReject && c.Action != ActionWarn {
+		return fmt.Errorf("invalid action %q: expected %q or %q", c.Action, ActionReject, ActionWarn)
+	}
+
+	for i, pattern := range c.Patterns {
+		if pattern.Name == "" {
+			return fmt.Errorf("pattern %d: name is required", i)
+		}
+
+		if _, err := regexp.Compile(pattern.Regex); err != nil {
+			return fmt.Errorf("pattern %q: invalid regex: %w", pattern.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *WebhookConfig) validate() error {
+	if c.URL == "" {
+		return errors.New("URL is required")
+	}
+
+	if c.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package scanning scans pushed records for unwanted content, e.g. secrets embedded in extension data,
+// before they are stored and become discoverable.
+package scanning
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/scanning/config"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var logger = logging.Logger("scanning")
+
+// Severity is how a finding affects the push of a record.
+type Severity string
+
+const (
+	// SeverityWarning accepts the push and reports the finding in the record metadata.
+	SeverityWarning Severity = "warning"
+
+	// SeverityError rejects the push.
+	SeverityError Severity = "error"
+)
+
+// Finding describes content of a record flagged by a scanner.
+type Finding struct {
+	// Scanner is the name of the scanner, e.g. "secrets".
+	Scanner string
+
+	// Rule is the rule of the scanner that matched, e.g. "aws-access-key-id".
+	Rule string
+
+	// Message describes the finding.
+	Message string
+
+	// Location locates the flagged content in the record, e.g. "extensions[0].data".
+	Location string
+
+	// Severity is how the finding affects the push.
+	Severity Severity
+}
+
+// Proto converts the finding into its wire representation.
+func (f Finding) Proto() *corev1.ScanFinding {
+	return &corev1.ScanFinding{
+		Scanner:  f.Scanner,
+		Rule:     f.Rule,
+		Message:  f.Message,
+		Location: f.Location,
+	}
+}
+
+func (f Finding) String() string {
+	if f.Location == "" {
+		return fmt.Sprintf("%s/%s: %s", f.Scanner, f.Rule, f.Message)
+	}
+
+	return fmt.Sprintf("%s/%s at %s: %s", f.Scanner, f.Rule, f.Location, f.Message)
+}
+
+// ScanResult contains the findings of a scan.
+type ScanResult struct {
+	Findings []Finding
+}
+
+// Rejected reports whether the push of the scanned record must be rejected.
+func (r ScanResult) Rejected() bool {
+	return len(r.Errors()) > 0
+}
+
+// Errors returns the findings rejecting the push.
+func (r ScanResult) Errors() []Finding {
+	return r.filter(SeverityError)
+}
+
+// Warnings returns the findings reported in the record metadata.
+func (r ScanResult) Warnings() []Finding {
+	return r.filter(SeverityWarning)
+}
+
+// Err returns the error rejecting the push, with the findings as details, or nil if the push is accepted.
+func (r ScanResult) Err() error {
+	errs := r.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(errs))
+	for _, finding := range errs {
+		messages = append(messages, finding.String())
+	}
+
+	st := status.Newf(codes.InvalidArgument, "record rejected by content scan: %s", strings.Join(messages, "; "))
+
+	for _, finding := range errs {
+		if detailed, err := st.WithDetails(finding.Proto()); err == nil {
+			st = detailed
+		}
+	}
+
+	return st.Err() //nolint:wrapcheck
+}
+
+func (r ScanResult) filter(severity Severity) []Finding {
+	var findings []Finding
+
+	for _, finding := range r.Findings {
+		if finding.Severity == severity {
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// Scanner scans pushed records. Scanners must return once the context is done.
+type Scanner interface {
+	Scan(ctx context.Context, record *corev1.Record) (ScanResult, error)
+}
+
+// ScannerFunc adapts a function to a Scanner.
+type ScannerFunc func(ctx context.Context, record *corev1.Record) (ScanResult, error)
+
+func (f ScannerFunc) Scan(ctx context.Context, record *corev1.Record) (ScanResult, error) {
+	return f(ctx, record)
+}
+
+// NoopScanner accepts all records without findings.
+var NoopScanner = ScannerFunc(func(context.Context, *corev1.Record) (ScanResult, error) {
+	return ScanResult{}, nil
+})
+
+// Chain runs scanners in order and merges their findings.
+// It stops at the first scanner failing, but not at findings, so that all findings are reported.
+type Chain []Scanner
+
+func (c Chain) Scan(ctx context.Context, record *corev1.Record) (ScanResult, error) {
+	var result ScanResult
+
+	for _, scanner := range c {
+		scanned, err := scanner.Scan(ctx, record)
+		if err != nil {
+			return ScanResult{}, err
+		}
+
+		result.Findings = append(result.Findings, scanned.Findings...)
+	}
+
+	return result, nil
+}
+
+// WithTimeout bounds the latency of a scanner.
+// Scans taking longer than the timeout fail, even if the scanner does not return once its context is done.
+func WithTimeout(scanner Scanner, timeout time.Duration) Scanner {
+	return ScannerFunc(func(ctx context.Context, record *corev1.Record) (ScanResult, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		type scanned struct {
+			result ScanResult
+			err    error
+		}
+
+		// Buffered, so that scanners returning late do not block
+		done := make(chan scanned, 1)
+
+		go func() {
+			result, err := scanner.Scan(ctx, record)
+			done <- scanned{result: result, err: err}
+		}()
+
+		select {
+		case res := <-done:
+			return res.result, res.err
+		case <-ctx.Done():
+			return ScanResult{}, fmt.Errorf("scan did not complete within %s: %w", timeout, ctx.Err())
+		}
+	})
+}
+
+// New creates the chain of the configured scanners, bounded by the configured timeout.
+func New(cfg config.Config) (Scanner, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scanning configuration: %w", err)
+	}
+
+	chain := make(Chain, 0, len(cfg.Scanners))
+
+	for _, name := range cfg.Scanners {
+		switch name {
+		case config.ScannerNoop:
+			chain = append(chain, NoopScanner)
+		case config.ScannerSecrets:
+			scanner, err := NewSecretsScanner(cfg.Secrets)
+			if err != nil {
+				return nil, err
+			}
+
+			chain = append(chain, scanner)
+		case config.ScannerWebhook:
+			chain = append(chain, NewWebhookScanner(cfg.Webhook))
+		}
+	}
+
+	logger.Info("Scanning pushed records", "scanners", cfg.Scanners, "timeout", cfg.Timeout)
+
+	return WithTimeout(chain, cfg.Timeout), nil
+}
@@ -0,0 +1,116 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package scanning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/api/extensions"
+	"github.com/agntcy/dir/server/scanning/config"
+)
+
+// secretRule flags extension data matching its pattern.
+type secretRule struct {
+	name        string
+	description string
+	pattern     *regexp.Regexp
+}
+
+// builtinSecretRules are the secrets flagged by default.
+// Patterns are matched against the JSON of extension data, so that keys and values can be matched together.
+var builtinSecretRules = []secretRule{
+	{
+		name:        "aws-access-key-id",
+		description: "AWS access key ID",
+		pattern:     regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[A-Z2-7]{16}\b`),
+	},
+	{
+		name:        "aws-secret-access-key",
+		description: "AWS secret access key",
+		pattern:     regexp.MustCompile(`(?i)"[a-z0-9_.-]*secret_?access_?key"\s*:\s*"[A-Za-z0-9/+]{40}"`),
+	},
+	{
+		name:        "private-key",
+		description: "PEM private key",
+		pattern:     regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY(?: BLOCK)?-----`),
+	},
+}
+
+// SecretsScanner flags secrets embedded in the extension data of records, e.g. AWS keys and PEM private keys.
+// Findings do not contain the matched values, so that secrets are not leaked in errors and metadata.
+// Encrypted records are not scanned.
+type SecretsScanner struct {
+	rules    []secretRule
+	severity Severity
+}
+
+// NewSecretsScanner creates a secrets scanner matching the built-in and configured patterns.
+func NewSecretsScanner(cfg config.SecretsConfig) (*SecretsScanner, error) {
+	rules := append([]secretRule{}, builtinSecretRules...)
+
+	for _, pattern := range cfg.Patterns {
+		re, err := regexp.Compile(pattern.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secrets pattern %q: %w", pattern.Name, err)
+		}
+
+		rules = append(rules, secretRule{name: pattern.Name, description: "denied pattern " + pattern.Name, pattern: re})
+	}
+
+	severity := SeverityError
+	if cfg.Action == config.ActionWarn {
+		severity = SeverityWarning
+	}
+
+	return &SecretsScanner{rules: rules, severity: severity}, nil
+}
+
+func (s *SecretsScanner) Scan(ctx context.Context, record *corev1.Record) (ScanResult, error) {
+	if record.IsEncrypted() {
+		return ScanResult{}, nil
+	}
+
+	exts, err := extensions.FromRecord(record)
+	if err != nil {
+		// Records that cannot be decoded are rejected by the validation
+		return ScanResult{}, nil //nolint:nilerr
+	}
+
+	var result ScanResult
+
+	for _, ext := range exts {
+		if err := ctx.Err(); err != nil {
+			return ScanResult{}, err //nolint:wrapcheck
+		}
+
+		if ext.Data == nil {
+			continue
+		}
+
+		data, err := json.Marshal(ext.Data.AsMap())
+		if err != nil {
+			return ScanResult{}, fmt.Errorf("failed to marshal data of %s: %w", ext.Path, err)
+		}
+
+		for _, rule := range s.rules {
+			if !rule.pattern.Match(data) {
+				continue
+			}
+
+			result.Findings = append(result.Findings, Finding{
+				Scanner:  config.ScannerSecrets,
+				Rule:     rule.name,
+				Message:  fmt.Sprintf("possible %s in data of extension %q", rule.description, ext.Name),
+				Location: ext.Path + ".data",
+				Severity: s.severity,
+			})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,124 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package scanning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/scanning/config"
+)
+
+// CIDHeader is the header carrying the CID of the record posted to webhook scanners.
+const CIDHeader = "X-Dir-Record-Cid"
+
+// webhookResponse is the response of webhook scanners.
+type webhookResponse struct {
+	Findings []webhookFinding `json:"findings"`
+}
+
+type webhookFinding struct {
+	Rule     string   `json:"rule"`
+	Message  string   `json:"message"`
+	Location string   `json:"location"`
+	Severity Severity `json:"severity"`
+}
+
+// WebhookScanner scans records with an external service.
+//
+// The canonical JSON of the record is posted to the webhook with its CID in the X-Dir-Record-Cid header,
+// and the webhook responds with its findings, e.g.
+//
+//	{"findings": [{"rule": "malware", "message": "locator artifact is infected", "location": "locators[0]", "severity": "error"}]}
+//
+// where the severity is "error" to reject the push or "warning" to accept it.
+// If the webhook fails or times out, the push is rejected, unless the scanner fails open,
+// in which case the push is accepted with a warning. Encrypted records are not scanned.
+type WebhookScanner struct {
+	url      string
+	failOpen bool
+	client   *http.Client
+}
+
+// NewWebhookScanner creates a scanner posting records to the configured webhook.
+func NewWebhookScanner(cfg config.WebhookConfig) *WebhookScanner {
+	return &WebhookScanner{url: cfg.URL, failOpen: cfg.FailOpen, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+func (s *WebhookScanner) Scan(ctx context.Context, record *corev1.Record) (ScanResult, error) {
+	if record.IsEncrypted() {
+		return ScanResult{}, nil
+	}
+
+	findings, err := s.scan(ctx, record)
+	if err == nil {
+		return ScanResult{Findings: findings}, nil
+	}
+
+	if !s.failOpen {
+		return ScanResult{}, err
+	}
+
+	logger.Warn("Webhook scan failed, accepting record without scan", "error", err, "cid", record.GetCid())
+
+	return ScanResult{Findings: []Finding{{
+		Scanner:  config.ScannerWebhook,
+		Rule:     "unavailable",
+		Message:  fmt.Sprintf("record accepted without scan: %v", err),
+		Severity: SeverityWarning,
+	}}}, nil
+}
+
+func (s *WebhookScanner) scan(ctx context.Context, record *corev1.Record) ([]Finding, error) {
+	body, err := record.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(CIDHeader, record.GetCid())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call scan webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to call scan webhook: unexpected status %s", resp.Status)
+	}
+
+	var decoded webhookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode scan webhook response: %w", err)
+	}
+
+	findings := make([]Finding, 0, len(decoded.Findings))
+
+	for _, finding := range decoded.Findings {
+		if finding.Severity != SeverityError && finding.Severity != SeverityWarning {
+			return nil, fmt.Errorf("invalid severity %q of scan webhook finding %q: expected %q or %q",
+				finding.Severity, finding.Rule, SeverityError, SeverityWarning)
+		}
+
+		findings = append(findings, Finding{
+			Scanner:  config.ScannerWebhook,
+			Rule:     finding.Rule,
+			Message:  finding.Message,
+			Location: finding.Location,
+			Severity: finding.Severity,
+		})
+	}
+
+	return findings, nil
+}
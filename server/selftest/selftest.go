@@ -0,0 +1,164 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package selftest runs the self-test of the store backend at startup and on demand,
+// so that misconfigured registry addresses, credentials and permissions are reported
+// with the failing step instead of failing the first push.
+package selftest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	storeconfig "github.com/agntcy/dir/server/store/config"
+	"github.com/agntcy/dir/utils/logging"
+)
+
+var logger = logging.Logger("selftest")
+
+var (
+	// ErrUnsupported is returned by Run if the store does not support self-tests.
+	ErrUnsupported = errors.New("self-tests not supported by current store implementation")
+
+	// ErrFailed is returned for self-tests with failed steps.
+	ErrFailed = errors.New("store self-test failed")
+)
+
+// Tester is implemented by stores supporting self-tests.
+type Tester interface {
+	// SelfTest checks the store backend step by step, skipping the write probe if skipWrite is set.
+	// Returns nil if the store does not support self-tests after all, e.g. for wrapped stores.
+	SelfTest(ctx context.Context, skipWrite bool) *storev1.SelfTestReport
+}
+
+// Runner runs the self-test of a store and keeps the report of the last run.
+type Runner struct {
+	config storeconfig.SelfTestConfig
+	tester Tester
+
+	mu   sync.RWMutex
+	last *storev1.SelfTestReport
+}
+
+// New creates a runner for the store. Stores not implementing Tester are not checked.
+func New(cfg storeconfig.SelfTestConfig, store any) *Runner {
+	tester, _ := store.(Tester)
+
+	return &Runner{config: cfg, tester: tester}
+}
+
+// Run runs the self-test and keeps its report as the last report. The write probe is skipped
+// if skipWrite is set or if it is disabled in the configuration.
+// Returns ErrUnsupported if the store does not support self-tests.
+func (r *Runner) Run(ctx context.Context, skipWrite bool) (*storev1.SelfTestReport, error) {
+	if r.tester == nil {
+		return nil, ErrUnsupported
+	}
+
+	report := r.tester.SelfTest(ctx, skipWrite || r.config.SkipWrite)
+	if report == nil {
+		return nil, ErrUnsupported
+	}
+
+	r.mu.Lock()
+	r.last = report
+	r.mu.Unlock()
+
+	logReport(report)
+
+	return report, nil
+}
+
+// Startup runs the self-test if it is enabled and supported by the store.
+// Returns an error naming the failed steps if the self-test fails and the
+// server must refuse to start, see storeconfig.SelfTestConfig.
+func (r *Runner) Startup(ctx context.Context) error {
+	if !r.config.Enabled {
+		return nil
+	}
+
+	report, err := r.Run(ctx, false)
+	if errors.Is(err, ErrUnsupported) {
+		logger.Debug("Skipping store self-test", "reason", err)
+
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !report.GetPassed() && r.config.FailOnError {
+		return fmt.Errorf("%w: %s", ErrFailed, failureSummary(report))
+	}
+
+	return nil
+}
+
+// Last returns the report of the last self-test, or nil if none was run.
+func (r *Runner) Last() *storev1.SelfTestReport {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.last
+}
+
+// CheckHealth returns an error naming the failed steps if the last self-test failed.
+// The store is considered healthy until a self-test was run.
+func (r *Runner) CheckHealth(_ context.Context) error {
+	report := r.Last()
+	if report == nil || report.GetPassed() {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrFailed, failureSummary(report))
+}
+
+// logReport logs a PASS, FAIL or SKIP line per step followed by the overall result.
+func logReport(report *storev1.SelfTestReport) {
+	for _, step := range report.GetSteps() {
+		attrs := []any{"step", step.GetName(), "latency", step.GetLatency().AsDuration()}
+
+		switch step.GetStatus() {
+		case storev1.SelfTestStatus_SELF_TEST_STATUS_PASSED:
+			logger.Info("Store self-test step PASS", attrs...)
+		case storev1.SelfTestStatus_SELF_TEST_STATUS_SKIPPED:
+			logger.Info("Store self-test step SKIP", append(attrs, "reason", step.GetError())...)
+		default:
+			logger.Error("Store self-test step FAIL", append(attrs, "failure", failureName(step.GetFailure()), "error", step.GetError())...)
+		}
+	}
+
+	if report.GetPassed() {
+		logger.Info("Store self-test PASS")
+	} else {
+		logger.Error("Store self-test FAIL", "failed", failureSummary(report))
+	}
+}
+
+// failureSummary lists the failed steps with the class and reason of their failure,
+// e.g. "push (permission): ...".
+func failureSummary(report *storev1.SelfTestReport) string {
+	var failed []string
+
+	for _, step := range report.GetSteps() {
+		if step.GetStatus() == storev1.SelfTestStatus_SELF_TEST_STATUS_FAILED {
+			failed = append(failed, fmt.Sprintf("%s (%s): %s", step.GetName(), failureName(step.GetFailure()), step.GetError()))
+		}
+	}
+
+	return strings.Join(failed, "; ")
+}
+
+// failureName returns the lowercase name of a failure class, e.g. "auth".
+func failureName(failure storev1.SelfTestFailure) string {
+	if failure == storev1.SelfTestFailure_SELF_TEST_FAILURE_UNSPECIFIED {
+		return "unknown"
+	}
+
+	return strings.ToLower(strings.TrimPrefix(failure.String(), "SELF_TEST_FAILURE_"))
+}
@@ -0,0 +1,102 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package selftest
+
+import (
+	"context"
+	"testing"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	storeconfig "github.com/agntcy/dir/server/store/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTester reports a fixed report and records the requested write probes.
+type fakeTester struct {
+	report    *storev1.SelfTestReport
+	skipWrite []bool
+}
+
+func (f *fakeTester) SelfTest(_ context.Context, skipWrite bool) *storev1.SelfTestReport {
+	f.skipWrite = append(f.skipWrite, skipWrite)
+
+	return f.report
+}
+
+var failedReport = &storev1.SelfTestReport{
+	Steps: []*storev1.SelfTestStep{
+		{Name: "ping", Status: storev1.SelfTestStatus_SELF_TEST_STATUS_PASSED},
+		{
+			Name:    "push",
+			Status:  storev1.SelfTestStatus_SELF_TEST_STATUS_FAILED,
+			Failure: storev1.SelfTestFailure_SELF_TEST_FAILURE_PERMISSION,
+			Error:   "denied",
+		},
+		{Name: "delete", Status: storev1.SelfTestStatus_SELF_TEST_STATUS_SKIPPED, Error: "push failed"},
+	},
+}
+
+func TestStartup(t *testing.T) {
+	t.Run("fail on error", func(t *testing.T) {
+		runner := New(storeconfig.SelfTestConfig{Enabled: true, FailOnError: true}, &fakeTester{report: failedReport})
+
+		err := runner.Startup(t.Context())
+		require.ErrorIs(t, err, ErrFailed)
+		assert.Contains(t, err.Error(), "push (permission): denied")
+	})
+
+	t.Run("failures are reported by the health check", func(t *testing.T) {
+		runner := New(storeconfig.SelfTestConfig{Enabled: true}, &fakeTester{report: failedReport})
+
+		require.NoError(t, runner.CheckHealth(t.Context()))
+		require.NoError(t, runner.Startup(t.Context()))
+
+		assert.Equal(t, failedReport, runner.Last())
+		assert.ErrorIs(t, runner.CheckHealth(t.Context()), ErrFailed)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		tester := &fakeTester{report: failedReport}
+		runner := New(storeconfig.SelfTestConfig{FailOnError: true}, tester)
+
+		require.NoError(t, runner.Startup(t.Context()))
+		assert.Empty(t, tester.skipWrite)
+		assert.Nil(t, runner.Last())
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		for _, store := range []any{struct{}{}, &fakeTester{}} {
+			runner := New(storeconfig.SelfTestConfig{Enabled: true, FailOnError: true}, store)
+
+			require.NoError(t, runner.Startup(t.Context()))
+
+			_, err := runner.Run(t.Context(), false)
+			assert.ErrorIs(t, err, ErrUnsupported)
+		}
+	})
+}
+
+func TestRunSkipWrite(t *testing.T) {
+	passed := &storev1.SelfTestReport{Passed: true}
+
+	tester := &fakeTester{report: passed}
+	runner := New(storeconfig.SelfTestConfig{}, tester)
+
+	_, err := runner.Run(t.Context(), false)
+	require.NoError(t, err)
+	_, err = runner.Run(t.Context(), true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []bool{false, true}, tester.skipWrite)
+
+	// The configuration skips the write probe of all runs
+	tester = &fakeTester{report: passed}
+	runner = New(storeconfig.SelfTestConfig{SkipWrite: true}, tester)
+
+	_, err = runner.Run(t.Context(), false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []bool{true}, tester.skipWrite)
+}
@@ -22,13 +22,31 @@ import (
 	"github.com/agntcy/dir/server/config"
 	"github.com/agntcy/dir/server/controller"
 	"github.com/agntcy/dir/server/database"
+	"github.com/agntcy/dir/server/drain"
+	"github.com/agntcy/dir/server/gateway"
+	"github.com/agntcy/dir/server/healthcheck"
+	"github.com/agntcy/dir/server/journal"
+	"github.com/agntcy/dir/server/labels"
+	"github.com/agntcy/dir/server/metrics"
 	"github.com/agntcy/dir/server/publication"
+	"github.com/agntcy/dir/server/quota"
+	"github.com/agntcy/dir/server/ratelimit"
+	"github.com/agntcy/dir/server/redaction"
+	"github.com/agntcy/dir/server/requestid"
 	"github.com/agntcy/dir/server/routing"
+	"github.com/agntcy/dir/server/scanning"
+	"github.com/agntcy/dir/server/selftest"
+	"github.com/agntcy/dir/server/stats"
 	"github.com/agntcy/dir/server/store"
 	"github.com/agntcy/dir/server/sync"
+	"github.com/agntcy/dir/server/tracing"
+	"github.com/agntcy/dir/server/trash"
 	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/webhooks"
+	_ "github.com/agntcy/dir/utils/compression" // Registers the gzip and zstd compressors.
 	"github.com/agntcy/dir/utils/logging"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -43,9 +61,19 @@ type Server struct {
 	routing            types.RoutingAPI
 	database           types.DatabaseAPI
 	syncService        *sync.Service
+	drainService       *drain.Service
 	authnService       *authn.Service
 	authzService       *authz.Service
 	publicationService *publication.Service
+	gatewayService     *gateway.Service
+	metricsService     *metrics.Service
+	quotaService       *quota.Service
+	trashService       *trash.Service
+	statsService       *stats.Service
+	webhooks           *webhooks.Dispatcher
+	journal            *journal.Journal
+	selfTest           *selftest.Runner
+	tracingService     *tracing.Service
 	healthzServer      *healthz.Server
 	grpcServer         *grpc.Server
 }
@@ -64,26 +92,155 @@ func Run(ctx context.Context, cfg *config.Config) error {
 	}
 	defer server.Close()
 
+	// Toggle drain mode without stopping the server
+	drainCh := make(chan os.Signal, 1)
+	signal.Notify(drainCh, syscall.SIGUSR1)
+
 	// Wait for deactivation
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 
-	select {
-	case <-ctx.Done():
-		return fmt.Errorf("stopping server due to context cancellation: %w", ctx.Err())
-	case sig := <-sigCh:
-		return fmt.Errorf("stopping server due to signal: %v", sig)
-	case err := <-errCh:
-		return fmt.Errorf("stopping server due to error: %w", err)
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopping server due to context cancellation: %w", ctx.Err())
+		case <-drainCh:
+			draining := !server.drainService.IsDraining()
+			server.drainService.SetDraining(draining)
+			server.healthzServer.SetIsReady(!draining)
+		case sig := <-sigCh:
+			server.Drain(ctx)
+
+			return fmt.Errorf("stopping server due to signal: %v", sig)
+		case err := <-errCh:
+			return fmt.Errorf("stopping server due to error: %w", err)
+		}
 	}
 }
 
+// configureLogging applies the logging configuration of the server
+// on top of the DIRECTORY_LOGGER_* configuration the logging was initialized with.
+func configureLogging(cfg logging.Config) error {
+	if cfg.LogFile == "" && cfg.LogLevel == "" && cfg.LogFormat == "" && len(cfg.ComponentLevels) == 0 {
+		return nil
+	}
+
+	merged, err := logging.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load logging config: %w", err)
+	}
+
+	if cfg.LogFile != "" {
+		merged.LogFile = cfg.LogFile
+	}
+
+	if cfg.LogLevel != "" {
+		merged.LogLevel = cfg.LogLevel
+	}
+
+	if cfg.LogFormat != "" {
+		merged.LogFormat = cfg.LogFormat
+	}
+
+	if len(cfg.ComponentLevels) > 0 {
+		merged.ComponentLevels = cfg.ComponentLevels
+	}
+
+	if err := logging.Configure(merged); err != nil {
+		return fmt.Errorf("failed to configure logging: %w", err)
+	}
+
+	return nil
+}
+
 func New(ctx context.Context, cfg *config.Config) (*Server, error) {
+	if err := configureLogging(cfg.Logging); err != nil {
+		return nil, err
+	}
+
 	logger.Debug("Creating server with config", "config", cfg, "version", version.String())
 
 	// Load options
 	options := types.NewOptions(cfg)
-	serverOpts := []grpc.ServerOption{}
+
+	// gRPC would disconnect clients pinging more often than every 5 minutes if not set
+	keepaliveMinTime := cfg.Keepalive.MinTime
+	if keepaliveMinTime <= 0 {
+		keepaliveMinTime = config.DefaultKeepaliveMinTime
+	}
+
+	serverOpts := []grpc.ServerOption{
+		// Allow client keepalive pings used to detect dead connections
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             keepaliveMinTime,
+			PermitWithoutStream: cfg.Keepalive.PermitWithoutStream,
+		}),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    cfg.Keepalive.Time,
+			Timeout: cfg.Keepalive.Timeout,
+		}),
+	}
+
+	// Options shared with the in-process server of the HTTP gateway,
+	// which authenticates its callers itself
+	var gatewayOpts []grpc.ServerOption
+
+	// Raise message size limits for large records,
+	// compressed calls are accepted with the compressors registered by the compression package
+	if cfg.MaxRecvMsgSize > 0 {
+		serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize))
+		gatewayOpts = append(gatewayOpts, grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize))
+	}
+
+	if cfg.MaxSendMsgSize > 0 {
+		serverOpts = append(serverOpts, grpc.MaxSendMsgSize(cfg.MaxSendMsgSize))
+		gatewayOpts = append(gatewayOpts, grpc.MaxSendMsgSize(cfg.MaxSendMsgSize))
+	}
+
+	// Assign request IDs first, so that all requests are logged with their ID
+	requestIDService := requestid.New()
+	serverOpts = append(serverOpts, requestIDService.GetServerOptions()...)
+	gatewayOpts = append(gatewayOpts, requestIDService.GetServerOptions()...)
+
+	// Reject requests while draining before any other processing
+	drainService := drain.New(cfg.Drain)
+	serverOpts = append(serverOpts, drainService.GetServerOptions()...)
+	gatewayOpts = append(gatewayOpts, drainService.GetServerOptions()...)
+
+	// Record request metrics if the metrics endpoint is enabled.
+	// Store metrics are registered by the store regardless.
+	var metricsService *metrics.Service
+	if cfg.Metrics.Enabled() {
+		var err error
+
+		metricsService, err = metrics.New(cfg.Metrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create metrics service: %w", err)
+		}
+
+		serverOpts = append(serverOpts, metricsService.GetServerOptions()...)
+		gatewayOpts = append(gatewayOpts, metricsService.GetServerOptions()...)
+	}
+
+	// Create tracing service if an OTLP endpoint is configured.
+	// When disabled, spans are recorded by the no-op global tracer provider.
+	var tracingService *tracing.Service
+	if cfg.Tracing.Enabled() {
+		var err error
+
+		tracingService, err = tracing.New(ctx, cfg.Tracing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tracing service: %w", err)
+		}
+
+		serverOpts = append(serverOpts, tracingService.GetServerOptions()...)
+		gatewayOpts = append(gatewayOpts, tracingService.GetServerOptions()...)
+	}
+
+	// Configure label extraction shared by store and routing
+	if err := labels.Configure(cfg.Labels); err != nil {
+		return nil, fmt.Errorf("failed to configure labels: %w", err)
+	}
 
 	// Create APIs
 	storeAPI, err := store.New(options) //nolint:staticcheck
@@ -91,6 +248,8 @@ func New(ctx context.Context, cfg *config.Config) (*Server, error) {
 		return nil, fmt.Errorf("failed to create store: %w", err)
 	}
 
+	selfTestRunner := selftest.New(cfg.Store.SelfTest, storeAPI)
+
 	routingAPI, err := routing.New(ctx, storeAPI, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create routing: %w", err)
@@ -121,34 +280,143 @@ func New(ctx context.Context, cfg *config.Config) (*Server, error) {
 
 	var authzService *authz.Service
 	if cfg.Authz.Enabled {
-		authzService, err = authz.New(ctx, cfg.Authz)
+		authzConfig := cfg.Authz
+		authzConfig.Reflection = cfg.Reflection
+
+		authzService, err = authz.New(ctx, authzConfig)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create authz service: %w", err)
 		}
 
 		//nolint:contextcheck
 		serverOpts = append(serverOpts, authzService.GetServerOptions()...)
+		gatewayOpts = append(gatewayOpts, authzService.GetServerOptions()...)
+	}
+
+	// Rate limit after authorization, so that rejected requests do not consume quota
+	if cfg.RateLimit.Enabled {
+		rateLimitService, err := ratelimit.New(cfg.RateLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create rate limit service: %w", err)
+		}
+
+		serverOpts = append(serverOpts, rateLimitService.GetServerOptions()...)
+		gatewayOpts = append(gatewayOpts, rateLimitService.GetServerOptions()...)
+	}
+
+	// Create webhook dispatcher if enabled
+	var webhookDispatcher *webhooks.Dispatcher
+	if cfg.Webhooks.Enabled {
+		webhookDispatcher, err = webhooks.New(cfg.Webhooks, databaseAPI, storeAPI)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create webhook dispatcher: %w", err)
+		}
 	}
 
 	// Create publication service
-	publicationService, err := publication.New(databaseAPI, storeAPI, routingAPI, options)
+	publicationService, err := publication.New(databaseAPI, storeAPI, routingAPI, webhookDispatcher, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create publication service: %w", err)
 	}
 
+	// Create quota service, records are accounted even if quotas are not enforced
+	quotaService, err := quota.New(cfg.Quota, databaseAPI, storeAPI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quota service: %w", err)
+	}
+
+	// Create trash service, deleted records are only trashed in soft deletion mode
+	trashService, err := trash.New(cfg.Deletion, storeAPI, databaseAPI, routingAPI, quotaService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trash service: %w", err)
+	}
+
+	// Create stats service, which also keeps the last access time of records for the quota reaper
+	statsService, err := stats.New(cfg.Stats, databaseAPI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stats service: %w", err)
+	}
+
+	// Create operation journal if enabled
+	var opJournal *journal.Journal
+	if cfg.Journal.Enabled {
+		opJournal, err = journal.New(cfg.Journal)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create journal: %w", err)
+		}
+	}
+
+	// Create pull redactor if enabled
+	var redactor *redaction.Redactor
+	if cfg.Redaction.Enabled {
+		redactor = redaction.New(cfg.Redaction, cfg.Authz.TrustDomain)
+	}
+
+	// Create record access control list enforcer if authorization is enabled
+	var aclEnforcer *authz.ACLEnforcer
+	if cfg.Authz.Enabled {
+		aclEnforcer = authz.NewACLEnforcer(cfg.Authz, databaseAPI)
+	}
+
+	// Create push scanner if any scanners are configured
+	var scanner scanning.Scanner
+	if cfg.Scanning.Enabled() {
+		scanner, err = scanning.New(cfg.Scanning)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create scanner: %w", err)
+		}
+	}
+
 	// Create a server
 	grpcServer := grpc.NewServer(serverOpts...)
 
 	// Register APIs
-	storev1.RegisterStoreServiceServer(grpcServer, controller.NewStoreController(storeAPI, databaseAPI))
-	routingv1.RegisterRoutingServiceServer(grpcServer, controller.NewRoutingController(routingAPI, storeAPI, publicationService))
+	storeController := controller.NewStoreController(storeAPI, databaseAPI, routingAPI, quotaService, opJournal, trashService, statsService, redactor, scanner, aclEnforcer, webhookDispatcher, cfg.Store)
+	storev1.RegisterStoreServiceServer(grpcServer, storeController)
+	routingv1.RegisterRoutingServiceServer(grpcServer, controller.NewRoutingController(routingAPI, storeAPI, publicationService, databaseAPI, authzService))
 	routingv1.RegisterPublicationServiceServer(grpcServer, controller.NewPublicationController(databaseAPI, options))
 	searchv1.RegisterSearchServiceServer(grpcServer, controller.NewSearchController(databaseAPI))
 	storev1.RegisterSyncServiceServer(grpcServer, controller.NewSyncController(databaseAPI, options))
 	signv1.RegisterSignServiceServer(grpcServer, controller.NewSignController(storeAPI))
+	storev1.RegisterQuotaServiceServer(grpcServer, controller.NewQuotaController(quotaService))
+	storev1.RegisterStatsServiceServer(grpcServer, controller.NewStatsController(statsService))
+	storev1.RegisterAdminServiceServer(grpcServer, controller.NewAdminController(storeAPI, databaseAPI, quotaService, opJournal, webhookDispatcher, selfTestRunner))
+
+	// Create HTTP gateway to the store API if enabled
+	var gatewayService *gateway.Service
+	if cfg.Gateway.Enabled() {
+		gatewayService, err = gateway.New(cfg.Gateway, storeController, gatewayOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gateway service: %w", err)
+		}
+	}
+
+	// Register health service with per-component checks
+	healthService := healthcheck.New()
+	healthService.AddChecker(healthcheck.ComponentStore, storeAPI)
+	healthService.AddChecker(healthcheck.ComponentRouting, routingAPI)
+	healthService.AddChecker(healthcheck.ComponentDrain, drainService)
+	healthService.AddChecker(healthcheck.ComponentSelfTest, selfTestRunner)
+
+	if authzService != nil {
+		healthService.AddChecker(healthcheck.ComponentAuthz, authzService)
+	}
 
-	// Register server
-	reflection.Register(grpcServer)
+	healthService.Register(grpcServer)
+
+	// Register reflection service if enabled, e.g. to call the server with grpcurl
+	if cfg.Reflection {
+		reflection.Register(grpcServer)
+	}
+
+	// Complete pending store writes when draining
+	drainService.AddFlusher(storeAPI)
+
+	if opJournal != nil {
+		drainService.AddFlusher(opJournal)
+	}
+
+	drainService.AddFlusher(statsService)
 
 	return &Server{
 		options:            options,
@@ -156,9 +424,19 @@ func New(ctx context.Context, cfg *config.Config) (*Server, error) {
 		routing:            routingAPI,
 		database:           databaseAPI,
 		syncService:        syncService,
+		drainService:       drainService,
 		authnService:       authnService,
 		authzService:       authzService,
 		publicationService: publicationService,
+		gatewayService:     gatewayService,
+		metricsService:     metricsService,
+		quotaService:       quotaService,
+		trashService:       trashService,
+		statsService:       statsService,
+		journal:            opJournal,
+		selfTest:           selfTestRunner,
+		webhooks:           webhookDispatcher,
+		tracingService:     tracingService,
 		healthzServer:      healthz.NewHealthServer(cfg.HealthCheckAddress),
 		grpcServer:         grpcServer,
 	}, nil
@@ -172,6 +450,18 @@ func (s Server) Routing() types.RoutingAPI { return s.routing }
 
 func (s Server) Database() types.DatabaseAPI { return s.database }
 
+// Drain stops accepting new requests and waits for in-flight requests and
+// pending store writes to complete, up to the configured grace period.
+func (s Server) Drain(ctx context.Context) {
+	s.healthzServer.SetIsReady(false)
+
+	logger.Info("Draining server")
+
+	if err := s.drainService.Drain(context.WithoutCancel(ctx)); err != nil {
+		logger.Warn("Server was not fully drained", "error", err)
+	}
+}
+
 func (s Server) Close() {
 	// Stop routing service (closes GossipSub, p2p server, DHT)
 	if s.routing != nil {
@@ -208,26 +498,67 @@ func (s Server) Close() {
 		}
 	}
 
+	// Stop HTTP gateway if running
+	if s.gatewayService != nil {
+		if err := s.gatewayService.Stop(); err != nil {
+			logger.Error("Failed to stop gateway service", "error", err)
+		}
+	}
+
+	// Stop quota service if running
+	if s.quotaService != nil {
+		if err := s.quotaService.Stop(); err != nil {
+			logger.Error("Failed to stop quota service", "error", err)
+		}
+	}
+
+	// Stop trash service if running
+	if s.trashService != nil {
+		if err := s.trashService.Stop(); err != nil {
+			logger.Error("Failed to stop trash service", "error", err)
+		}
+	}
+
+	// End store watches, which would keep the server from stopping otherwise
+	s.journal.CloseWatches()
+
 	s.grpcServer.GracefulStop()
-}
 
-func (s Server) start(ctx context.Context) error {
-	// Start sync service
-	if s.syncService != nil {
-		if err := s.syncService.Start(ctx); err != nil {
-			return fmt.Errorf("failed to start sync service: %w", err)
+	// Stop stats service once no more accesses are counted, writing the remaining counts
+	if s.statsService != nil {
+		if err := s.statsService.Stop(); err != nil {
+			logger.Error("Failed to stop stats service", "error", err)
 		}
+	}
 
-		logger.Info("Sync service started")
+	// Close the journal once no more operations are recorded
+	if s.journal != nil {
+		if err := s.journal.Close(); err != nil {
+			logger.Error("Failed to close journal", "error", err)
+		}
 	}
 
-	// Start publication service
-	if s.publicationService != nil {
-		if err := s.publicationService.Start(ctx); err != nil {
-			return fmt.Errorf("failed to start publication service: %w", err)
+	// Stop delivering webhooks once no more events are reported
+	s.webhooks.Close()
+
+	// Stop serving metrics once no more requests are recorded
+	if s.metricsService != nil {
+		if err := s.metricsService.Stop(); err != nil {
+			logger.Error("Failed to stop metrics service", "error", err)
 		}
+	}
 
-		logger.Info("Publication service started")
+	// Stop tracing service last to flush spans of in-flight requests
+	if s.tracingService != nil {
+		if err := s.tracingService.Stop(); err != nil {
+			logger.Error("Failed to stop tracing service", "error", err)
+		}
+	}
+}
+
+func (s Server) start(ctx context.Context) error {
+	if err := s.startServices(ctx); err != nil {
+		return err
 	}
 
 	// Create a listener on TCP port
@@ -254,3 +585,93 @@ func (s Server) start(ctx context.Context) error {
 
 	return nil
 }
+
+// Start starts the background services and serves the API on the listener until the server is closed.
+// Unlike Run, it does not serve the health check endpoint nor handle signals,
+// so that the server can be embedded, e.g. in tests listening on an in-memory connection.
+func (s Server) Start(ctx context.Context, listener net.Listener) error {
+	if err := s.startServices(ctx); err != nil {
+		return err
+	}
+
+	go func() {
+		logger.Info("Server starting", "address", listener.Addr().String())
+
+		if err := s.grpcServer.Serve(listener); err != nil {
+			logger.Error("Failed to start server", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// startServices starts the background services of the server.
+func (s Server) startServices(ctx context.Context) error {
+	// Check the store backend before starting the services depending on it
+	if err := s.selfTest.Startup(ctx); err != nil {
+		return fmt.Errorf("failed to check store: %w", err)
+	}
+
+	// Start sync service
+	if s.syncService != nil {
+		if err := s.syncService.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start sync service: %w", err)
+		}
+
+		logger.Info("Sync service started")
+	}
+
+	// Start publication service
+	if s.publicationService != nil {
+		if err := s.publicationService.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start publication service: %w", err)
+		}
+
+		logger.Info("Publication service started")
+	}
+
+	// Start quota service
+	if s.quotaService != nil {
+		if err := s.quotaService.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start quota service: %w", err)
+		}
+	}
+
+	// Start trash service
+	if s.trashService != nil {
+		if err := s.trashService.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start trash service: %w", err)
+		}
+	}
+
+	// Start stats service
+	if s.statsService != nil {
+		if err := s.statsService.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start stats service: %w", err)
+		}
+	}
+
+	// Start HTTP gateway
+	if s.gatewayService != nil {
+		if err := s.gatewayService.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start gateway service: %w", err)
+		}
+	}
+
+	// Start metrics endpoint
+	if s.metricsService != nil {
+		if err := s.metricsService.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start metrics service: %w", err)
+		}
+	}
+
+	// Rebuild the search index in the background if it is missing or outdated.
+	// Searches return partial results until the index is rebuilt.
+	go func() {
+		if err := database.ReindexIfNeeded(ctx, s.database, s.store); err != nil {
+			logger.Error("Failed to rebuild search index", "error", err)
+		}
+	}()
+
+	return nil
+}
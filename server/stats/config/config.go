@@ -0,0 +1,27 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DefaultFlushInterval = 10 * time.Second
+)
+
+// Config contains configuration for record usage statistics.
+type Config struct {
+	// Interval at which the counted pulls and lookups are written to the database
+	FlushInterval time.Duration `json:"flush_interval,omitempty" mapstructure:"flush_interval"`
+}
+
+func (c *Config) Validate() error {
+	if c.FlushInterval <= 0 {
+		return errors.New("flush interval must be positive")
+	}
+
+	return nil
+}
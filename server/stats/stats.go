@@ -0,0 +1,198 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/stats/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/utils/logging"
+)
+
+var logger = logging.Logger("stats")
+
+// Service counts the pulls and lookups of records.
+//
+// Accesses are counted in memory and written to the database in batches at the flush interval,
+// so that the pull path does not write to the database. Flushing also advances the last access
+// time of the record usage, which resets the age of records for the quota reaper.
+type Service struct {
+	cfg config.Config
+	db  types.DatabaseAPI
+
+	mu      sync.Mutex
+	pending map[string]*types.RecordStats
+
+	// flushMu serializes flushes, so that counts are never written twice or out of order.
+	flushMu sync.Mutex
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates a new usage statistics service.
+func New(cfg config.Config, db types.DatabaseAPI) (*Service, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid stats config: %w", err)
+	}
+
+	return &Service{
+		cfg:     cfg,
+		db:      db,
+		pending: make(map[string]*types.RecordStats),
+		stopCh:  make(chan struct{}),
+	}, nil
+}
+
+// RecordPull counts a pull of the record.
+func (s *Service) RecordPull(cid string) {
+	s.count(cid, 1, 0)
+}
+
+// RecordLookup counts a lookup of the record.
+func (s *Service) RecordLookup(cid string) {
+	s.count(cid, 0, 1)
+}
+
+func (s *Service) count(cid string, pulls, lookups uint64) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats, ok := s.pending[cid]
+	if !ok {
+		stats = &types.RecordStats{CID: cid}
+		s.pending[cid] = stats
+	}
+
+	stats.PullCount += pulls
+	stats.LookupCount += lookups
+	stats.LastAccessedAt = now
+}
+
+// Get returns the statistics of the record, including the accesses that were not flushed yet.
+func (s *Service) Get(ctx context.Context, cid string) (types.RecordStats, error) {
+	if err := s.Flush(ctx); err != nil {
+		return types.RecordStats{}, err
+	}
+
+	stats, err := s.db.GetRecordStats(cid)
+	if err != nil {
+		return types.RecordStats{}, fmt.Errorf("failed to get record stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// Top returns the statistics of at most limit records in the given order,
+// including the accesses that were not flushed yet.
+func (s *Service) Top(ctx context.Context, order types.RecordStatsOrder, limit int) ([]types.RecordStats, error) {
+	if err := s.Flush(ctx); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.db.GetTopRecordStats(order, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top record stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// Flush writes the counted accesses to the database.
+// Accesses that fail to be written are kept and written with the next flush.
+func (s *Service) Flush(_ context.Context) error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[string]*types.RecordStats, len(pending))
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	batch := make([]types.RecordStats, 0, len(pending))
+	for _, stats := range pending {
+		batch = append(batch, *stats)
+	}
+
+	if err := s.db.AddRecordStats(batch); err != nil {
+		s.restore(pending)
+
+		return fmt.Errorf("failed to flush record stats: %w", err)
+	}
+
+	logger.Debug("Flushed record stats", "records", len(batch))
+
+	return nil
+}
+
+// restore merges accesses that failed to be flushed back into the pending ones.
+func (s *Service) restore(failed map[string]*types.RecordStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for cid, stats := range failed {
+		pending, ok := s.pending[cid]
+		if !ok {
+			s.pending[cid] = stats
+
+			continue
+		}
+
+		pending.PullCount += stats.PullCount
+		pending.LookupCount += stats.LookupCount
+	}
+}
+
+// Start starts flushing the counted accesses at the flush interval.
+func (s *Service) Start(ctx context.Context) error {
+	logger.Info("Starting record stats flusher", "interval", s.cfg.FlushInterval)
+
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.cfg.FlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.stopCh:
+				return
+			case <-ticker.C:
+				if err := s.Flush(ctx); err != nil {
+					logger.Warn("Failed to flush record stats", "error", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops flushing and writes the remaining counted accesses.
+func (s *Service) Stop() error {
+	close(s.stopCh)
+	s.wg.Wait()
+
+	if err := s.Flush(context.Background()); err != nil {
+		return err
+	}
+
+	logger.Info("Record stats flusher stopped")
+
+	return nil
+}
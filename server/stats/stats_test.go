@@ -0,0 +1,117 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/database/sqlite"
+	"github.com/agntcy/dir/server/stats/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestService(t *testing.T, path string) *Service {
+	t.Helper()
+
+	db, err := sqlite.New(path)
+	require.NoError(t, err)
+
+	service, err := New(config.Config{FlushInterval: time.Hour}, db)
+	require.NoError(t, err)
+
+	return service
+}
+
+func TestConcurrentAccesses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir.db")
+	service := newTestService(t, path)
+
+	require.NoError(t, service.Start(t.Context()))
+
+	const (
+		workers = 16
+		pulls   = 100
+	)
+
+	var wg sync.WaitGroup
+
+	for worker := range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range pulls {
+				service.RecordPull("cid-1")
+
+				if i%10 == 0 {
+					service.RecordLookup(fmt.Sprintf("cid-%d", worker%2+1))
+				}
+
+				// Flushes concurrent with counting must not lose or repeat counts
+				if i%25 == 0 {
+					assert.NoError(t, service.Flush(t.Context()))
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	stats, err := service.Get(t.Context(), "cid-1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(workers*pulls), stats.PullCount)
+	assert.Equal(t, uint64(workers/2*pulls/10), stats.LookupCount)
+
+	// Accesses counted before the restart are flushed when the service stops
+	service.RecordPull("cid-2")
+	require.NoError(t, service.Stop())
+
+	service = newTestService(t, path)
+
+	stats, err = service.Get(t.Context(), "cid-1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(workers*pulls), stats.PullCount)
+
+	service.RecordPull("cid-2")
+
+	top, err := service.Top(t.Context(), types.RecordStatsByRecent, 10)
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	assert.Equal(t, "cid-2", top[0].CID)
+	assert.Equal(t, uint64(2), top[0].PullCount)
+}
+
+func TestConfigValidate(t *testing.T) {
+	_, err := New(config.Config{}, nil)
+	require.Error(t, err)
+}
+
+// BenchmarkRecordPull measures the cost added to every pull by counting it.
+func BenchmarkRecordPull(b *testing.B) {
+	service, err := New(config.Config{FlushInterval: time.Hour}, nil)
+	require.NoError(b, err)
+
+	cids := make([]string, 1000)
+	for i := range cids {
+		cids[i] = fmt.Sprintf("cid-%d", i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			service.RecordPull(cids[i%len(cids)])
+			i++
+		}
+	})
+}
@@ -11,9 +11,12 @@ import (
 	"fmt"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
 	"github.com/ipfs/go-datastore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -33,6 +36,190 @@ func Wrap(source types.StoreAPI, cache types.Datastore) types.StoreAPI {
 	}
 }
 
+// CheckHealth forwards the health check to the source store, if supported.
+func (s *cachedStore) CheckHealth(ctx context.Context) error {
+	checker, ok := s.source.(interface {
+		CheckHealth(ctx context.Context) error
+	})
+	if !ok {
+		return nil
+	}
+
+	return checker.CheckHealth(ctx)
+}
+
+// Flush forwards the flush of pending operations to the source store, if supported.
+func (s *cachedStore) Flush(ctx context.Context) error {
+	flusher, ok := s.source.(interface {
+		Flush(ctx context.Context) error
+	})
+	if !ok {
+		return nil
+	}
+
+	return flusher.Flush(ctx)
+}
+
+// List forwards record enumeration to the source store, if supported.
+func (s *cachedStore) List(ctx context.Context, fn func(*corev1.RecordRef) error) error {
+	lister, ok := s.source.(interface {
+		List(ctx context.Context, fn func(*corev1.RecordRef) error) error
+	})
+	if !ok {
+		return status.Error(codes.Unimplemented, "listing records not supported by current store implementation")
+	}
+
+	return lister.List(ctx, fn)
+}
+
+// Resolve forwards tag resolution to the source store, if supported.
+// Resolutions are not cached, as tags can be re-pointed.
+func (s *cachedStore) Resolve(ctx context.Context, tag string) (*corev1.RecordRef, string, error) {
+	resolver, ok := s.source.(interface {
+		Resolve(ctx context.Context, tag string) (*corev1.RecordRef, string, error)
+	})
+	if !ok {
+		return nil, "", status.Error(codes.Unimplemented, "tag resolution not supported by current store implementation")
+	}
+
+	return resolver.Resolve(ctx, tag)
+}
+
+// PreviewPush forwards the push preview to the source store, if supported.
+func (s *cachedStore) PreviewPush(ctx context.Context, record *corev1.Record) (*storev1.PushPreview, error) {
+	previewer, ok := s.source.(interface {
+		PreviewPush(ctx context.Context, record *corev1.Record) (*storev1.PushPreview, error)
+	})
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "push preview not supported by current store implementation")
+	}
+
+	return previewer.PreviewPush(ctx, record)
+}
+
+// PushBundle forwards the bundle push to the source store, if supported.
+func (s *cachedStore) PushBundle(ctx context.Context, bundle *corev1.RecordBundle) (*corev1.RecordRef, error) {
+	bundleStore, ok := s.source.(interface {
+		PushBundle(ctx context.Context, bundle *corev1.RecordBundle) (*corev1.RecordRef, error)
+	})
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "bundles not supported by current store implementation")
+	}
+
+	return bundleStore.PushBundle(ctx, bundle)
+}
+
+// PullBundle forwards the bundle pull to the source store, if supported.
+// Bundles are not cached.
+func (s *cachedStore) PullBundle(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordBundle, error) {
+	bundleStore, ok := s.source.(interface {
+		PullBundle(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordBundle, error)
+	})
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "bundles not supported by current store implementation")
+	}
+
+	return bundleStore.PullBundle(ctx, ref)
+}
+
+// Fsck forwards the consistency check to the source store, if supported.
+// Records deleted by the repair are evicted from the cache.
+func (s *cachedStore) Fsck(ctx context.Context, repair bool, fn func(*storev1.FsckResponse) error) error {
+	checker, ok := s.source.(interface {
+		Fsck(ctx context.Context, repair bool, fn func(*storev1.FsckResponse) error) error
+	})
+	if !ok {
+		return status.Error(codes.Unimplemented, "consistency checks not supported by current store implementation")
+	}
+
+	return checker.Fsck(ctx, repair, func(resp *storev1.FsckResponse) error {
+		if issue := resp.GetIssue(); issue.GetRepaired() && issue.GetCid() != "" {
+			s.removeFromCache(ctx, issue.GetCid())
+		}
+
+		return fn(resp)
+	})
+}
+
+// Reshard forwards the migration of records between repositories to the source store, if supported.
+// Cached records are not affected, as moved records keep their CID.
+func (s *cachedStore) Reshard(ctx context.Context, dryRun bool, fn func(*storev1.ReshardResponse) error) error {
+	resharder, ok := s.source.(interface {
+		Reshard(ctx context.Context, dryRun bool, fn func(*storev1.ReshardResponse) error) error
+	})
+	if !ok {
+		return status.Error(codes.Unimplemented, "resharding not supported by current store implementation")
+	}
+
+	return resharder.Reshard(ctx, dryRun, fn)
+}
+
+// SelfTest forwards the self-test to the source store, if supported, and returns nil otherwise.
+func (s *cachedStore) SelfTest(ctx context.Context, skipWrite bool) *storev1.SelfTestReport {
+	tester, ok := s.source.(interface {
+		SelfTest(ctx context.Context, skipWrite bool) *storev1.SelfTestReport
+	})
+	if !ok {
+		return nil
+	}
+
+	return tester.SelfTest(ctx, skipWrite)
+}
+
+// SetLifecycle forwards the lifecycle update to the source store, if supported.
+// The cached record and metadata are evicted, as both carry the lifecycle.
+func (s *cachedStore) SetLifecycle(ctx context.Context, ref *corev1.RecordRef, lifecycle *corev1.Lifecycle) (*corev1.RecordMeta, error) {
+	lifecycleStore, ok := s.source.(interface {
+		SetLifecycle(ctx context.Context, ref *corev1.RecordRef, lifecycle *corev1.Lifecycle) (*corev1.RecordMeta, error)
+	})
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "record lifecycle not supported by current store implementation")
+	}
+
+	s.removeFromCache(ctx, ref.GetCid())
+
+	return lifecycleStore.SetLifecycle(ctx, ref, lifecycle)
+}
+
+// SetMetadata forwards the metadata update to the source store, if supported.
+// The cached metadata is evicted, as it carries the operational metadata.
+func (s *cachedStore) SetMetadata(ctx context.Context, ref *corev1.RecordRef, update map[string]string) (*corev1.RecordMeta, error) {
+	metadataStore, ok := s.source.(interface {
+		SetMetadata(ctx context.Context, ref *corev1.RecordRef, update map[string]string) (*corev1.RecordMeta, error)
+	})
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "record metadata not supported by current store implementation")
+	}
+
+	s.removeFromCache(ctx, ref.GetCid())
+
+	return metadataStore.SetMetadata(ctx, ref, update)
+}
+
+// GetMetadata forwards the metadata request to the source store, if supported.
+func (s *cachedStore) GetMetadata(ctx context.Context, ref *corev1.RecordRef) (map[string]string, error) {
+	metadataStore, ok := s.source.(interface {
+		GetMetadata(ctx context.Context, ref *corev1.RecordRef) (map[string]string, error)
+	})
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "record metadata not supported by current store implementation")
+	}
+
+	return metadataStore.GetMetadata(ctx, ref)
+}
+
+// Untag forwards the tag removal to the source store, if supported.
+func (s *cachedStore) Untag(ctx context.Context, ref *corev1.RecordRef) error {
+	untagger, ok := s.source.(interface {
+		Untag(ctx context.Context, ref *corev1.RecordRef) error
+	})
+	if !ok {
+		return status.Error(codes.Unimplemented, "removing tags not supported by current store implementation")
+	}
+
+	return untagger.Untag(ctx, ref)
+}
+
 // Push pushes a record to the source store and caches it.
 func (s *cachedStore) Push(ctx context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
 	logger.Debug("Push: forwarding to source store")
@@ -148,12 +335,17 @@ func (s *cachedStore) getRecordFromCache(ctx context.Context, cid string) (*core
 	}
 
 	// Unmarshal record from bytes
-	var record corev1.Record
-	if err := proto.Unmarshal(data, &record); err != nil {
+	record := &corev1.Record{}
+	if err := proto.Unmarshal(data, record); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal record: %w", err)
 	}
 
-	return &record, nil
+	// The record keeps the hash function of the CID it is cached under
+	if opts, err := corev1.CIDOptionsOf(cid); err == nil {
+		record.SetCIDOptions(opts)
+	}
+
+	return record, nil
 }
 
 // cacheMeta stores record metadata in the cache.
@@ -4,11 +4,55 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/api/lint"
 	oci "github.com/agntcy/dir/server/store/oci/config"
 )
 
 const (
-	DefaultProvider = "oci"
+	ProviderOCI    = "oci"
+	ProviderMemory = "memory"
+
+	DefaultProvider = ProviderOCI
+)
+
+// Policies for record names and versions that are not in normal form, see the api names package.
+const (
+	// NamePolicyNormalize accepts names and versions that can be normalized.
+	// Records keep the name they were pushed with, tags and search use the normal form.
+	NamePolicyNormalize = "normalize"
+
+	// NamePolicyReject only accepts names and versions that are already in normal form.
+	NamePolicyReject = "reject"
+
+	DefaultNamePolicy = NamePolicyNormalize
+)
+
+// Policies for declared CID dependencies of pushed records that do not exist in the store,
+// see the core/dependencies extension of the api extensions package.
+const (
+	// DependencyPolicyIgnore does not check the dependencies of pushed records.
+	DependencyPolicyIgnore = "ignore"
+
+	// DependencyPolicyWarn logs pushes of records with missing dependencies.
+	DependencyPolicyWarn = "warn"
+
+	// DependencyPolicyEnforce rejects pushes of records with missing or invalid dependencies.
+	DependencyPolicyEnforce = "enforce"
+
+	DefaultDependencyPolicy = DependencyPolicyIgnore
+)
+
+// DefaultCIDHash generates CIDs with SHA2-256, matching the SHA-256 digests of OCI registries.
+const DefaultCIDHash = "sha2-256"
+
+const (
+	DefaultSelfTestEnabled     = true
+	DefaultSelfTestSkipWrite   = false
+	DefaultSelfTestFailOnError = false
 )
 
 type Config struct {
@@ -17,4 +61,105 @@ type Config struct {
 
 	// Config for OCI database.
 	OCI oci.Config `json:"oci,omitempty" mapstructure:"oci"`
+
+	// Reject pushes of records whose name and version match a stored record with different content,
+	// unless the push explicitly requests to overwrite it.
+	UniqueNameVersion bool `json:"unique_name_version,omitempty" mapstructure:"unique_name_version"`
+
+	// NamePolicy is how pushes of records whose name or version is not in normal form are handled.
+	// Names and versions that cannot be normalized are always rejected.
+	NamePolicy string `json:"name_policy,omitempty" mapstructure:"name_policy"`
+
+	// Reject pushes of records whose extension data does not match the registered extension schemas.
+	ValidateExtensions bool `json:"validate_extensions,omitempty" mapstructure:"validate_extensions"`
+
+	// Also reject pushes of records with extensions without a registered schema.
+	// Only applies if extensions are validated.
+	StrictExtensions bool `json:"strict_extensions,omitempty" mapstructure:"strict_extensions"`
+
+	// DependencyPolicy is how pushes of records depending on records by CID that are not stored are handled.
+	// Dependencies by name and version constraint are resolved by clients and not checked.
+	DependencyPolicy string `json:"dependency_policy,omitempty" mapstructure:"dependency_policy"`
+
+	// Self-test of the store backend run at startup.
+	SelfTest SelfTestConfig `json:"self_test,omitempty" mapstructure:"self_test"`
+
+	// CIDHash is the hash function the CIDs of pushed records are generated with, "sha2-256" or "sha2-512".
+	// CIDs are self-describing, so records stored with other hash functions can still be pulled and verified.
+	CIDHash string `json:"cid_hash,omitempty" mapstructure:"cid_hash"`
+
+	// Lint of pushed records with the rules of the api lint package.
+	Lint LintConfig `json:"lint,omitempty" mapstructure:"lint"`
+}
+
+// CIDOptions returns the options the CIDs of pushed records are generated with.
+func (c *Config) CIDOptions() corev1.CIDOptions {
+	opts, err := corev1.ParseCIDOptions(c.CIDHash)
+	if err != nil {
+		return corev1.DefaultCIDOptions
+	}
+
+	return opts
+}
+
+// SelfTestConfig represents the configuration of the startup self-test of the store backend,
+// which reports misconfigured registry addresses, credentials and permissions before the first push fails.
+// Stores that do not support self-tests are not checked.
+type SelfTestConfig struct {
+	// Run the self-test at startup.
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Skip the push and delete of the probe blob, for read-only registry credentials.
+	SkipWrite bool `json:"skip_write,omitempty" mapstructure:"skip_write"`
+
+	// Refuse to start if a step of the self-test fails. Otherwise, failures are only logged
+	// and reported by the health service.
+	FailOnError bool `json:"fail_on_error,omitempty" mapstructure:"fail_on_error"`
+}
+
+// LintConfig represents the configuration of the lint of pushed records.
+// Findings are returned with the refs of pushed records, and pushes are only rejected
+// for findings of rules whose severity is escalated to error.
+type LintConfig struct {
+	// Lint pushed records.
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Rules that are disabled or whose severity is overridden.
+	lint.Config `mapstructure:",squash"`
+}
+
+// Validate checks that the name and dependency policies and the lint rules are known, that exactly one known storage
+// provider is selected and that its configuration is valid.
+func (c *Config) Validate() error {
+	switch c.NamePolicy {
+	case NamePolicyNormalize, NamePolicyReject, "":
+	default:
+		return fmt.Errorf("unsupported name policy %q: expected %q or %q", c.NamePolicy, NamePolicyNormalize, NamePolicyReject)
+	}
+
+	switch c.DependencyPolicy {
+	case DependencyPolicyIgnore, DependencyPolicyWarn, DependencyPolicyEnforce, "":
+	default:
+		return fmt.Errorf("unsupported dependency policy %q: expected %q, %q or %q",
+			c.DependencyPolicy, DependencyPolicyIgnore, DependencyPolicyWarn, DependencyPolicyEnforce)
+	}
+
+	if _, err := corev1.ParseCIDOptions(c.CIDHash); err != nil {
+		return fmt.Errorf("unsupported CID hash: %w", err)
+	}
+
+	if err := c.Lint.Validate(lint.DefaultRules()); err != nil {
+		return fmt.Errorf("invalid lint configuration: %w", err)
+	}
+
+	switch c.Provider {
+	case ProviderOCI:
+		return c.OCI.Validate()
+	case ProviderMemory:
+		return nil
+	case "":
+		return errors.New("provider is required")
+	default:
+		return fmt.Errorf("unsupported provider %q: expected %q or %q", c.Provider, ProviderOCI, ProviderMemory)
+	}
 }
@@ -0,0 +1,137 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lock provides the locks stores hold while writing an object, so that concurrent
+// writes of the same object, e.g. pushes of the same record, do not race in the storage backend.
+package lock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsSubsystem = "store_lock"
+
+	// Lock wait outcomes.
+	outcomeAcquired = "acquired"
+	outcomeCanceled = "canceled"
+)
+
+// Locker acquires exclusive locks by key, such as record CIDs.
+// KeyedMutex locks within a process, deployments with multiple replicas sharing a backend
+// can plug in a distributed implementation.
+type Locker interface {
+	// Lock blocks until the lock of the key is acquired or the context is done.
+	// The returned function releases the lock, it is safe to call more than once.
+	Lock(ctx context.Context, key string) (unlock func(), err error)
+}
+
+var _ Locker = &KeyedMutex{}
+
+// KeyedMutex is an in-process Locker. Locks are created on first use and removed
+// once no caller holds or waits for them, so that locking many keys does not leak memory.
+type KeyedMutex struct {
+	store string
+	wait  *prometheus.HistogramVec
+
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+// keyedLock is the lock of a key, referenced by its holder and all waiters.
+type keyedLock struct {
+	held chan struct{}
+	refs int
+}
+
+// NewKeyedMutex creates an in-process Locker for the store, whose wait times are
+// registered with the registerer labeled by the store, e.g. "oci" or "memory".
+func NewKeyedMutex(store string, registerer prometheus.Registerer) (*KeyedMutex, error) {
+	wait, err := metrics.Register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "wait_duration_seconds",
+		Help:      "Time spent waiting for store object locks, e.g. by concurrent pushes of the same record.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"store", "outcome"}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to register lock metrics: %w", err)
+	}
+
+	return &KeyedMutex{
+		store: store,
+		wait:  wait,
+		locks: make(map[string]*keyedLock),
+	}, nil
+}
+
+// Lock blocks until the lock of the key is acquired or the context is done.
+func (m *KeyedMutex) Lock(ctx context.Context, key string) (func(), error) {
+	start := time.Now()
+	lock := m.acquire(key)
+
+	select {
+	case lock.held <- struct{}{}:
+	case <-ctx.Done():
+		m.release(key, lock)
+		m.observe(outcomeCanceled, start)
+
+		return nil, fmt.Errorf("failed to lock %s: %w", key, context.Cause(ctx))
+	}
+
+	m.observe(outcomeAcquired, start)
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			<-lock.held
+			m.release(key, lock)
+		})
+	}, nil
+}
+
+// Len returns the number of keys locked or waited for.
+func (m *KeyedMutex) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.locks)
+}
+
+// acquire returns the lock of the key, creating it if needed, and references it.
+func (m *KeyedMutex) acquire(key string) *keyedLock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &keyedLock{held: make(chan struct{}, 1)}
+		m.locks[key] = lock
+	}
+
+	lock.refs++
+
+	return lock
+}
+
+// release drops a reference to the lock of the key, removing the lock with the last reference.
+func (m *KeyedMutex) release(key string, lock *keyedLock) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lock.refs--
+	if lock.refs == 0 {
+		delete(m.locks, key)
+	}
+}
+
+func (m *KeyedMutex) observe(outcome string, start time.Time) {
+	m.wait.WithLabelValues(m.store, outcome).Observe(time.Since(start).Seconds())
+}
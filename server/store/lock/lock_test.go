@@ -0,0 +1,100 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package lock
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMutex(t *testing.T) *KeyedMutex {
+	t.Helper()
+
+	m, err := NewKeyedMutex("test", prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	return m
+}
+
+func TestKeyedMutexExclusive(t *testing.T) {
+	m := newTestMutex(t)
+
+	var (
+		wg       sync.WaitGroup
+		holders  atomic.Int32
+		overlaps atomic.Int32
+	)
+
+	for range 50 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			unlock, err := m.Lock(t.Context(), "cid")
+			assert.NoError(t, err)
+
+			if holders.Add(1) > 1 {
+				overlaps.Add(1)
+			}
+
+			time.Sleep(time.Millisecond)
+			holders.Add(-1)
+			unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Zero(t, overlaps.Load(), "lock must be held by one caller at a time")
+	assert.Equal(t, 0, m.Len(), "locks must be removed once released")
+	assert.Equal(t, 1, testutil.CollectAndCount(m.wait))
+}
+
+func TestKeyedMutexKeys(t *testing.T) {
+	m := newTestMutex(t)
+
+	unlockA, err := m.Lock(t.Context(), "a")
+	require.NoError(t, err)
+
+	// Other keys are not blocked
+	unlockB, err := m.Lock(t.Context(), "b")
+	require.NoError(t, err)
+	assert.Equal(t, 2, m.Len())
+
+	unlockA()
+	unlockA() // Unlocking twice is a no-op
+	unlockB()
+
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestKeyedMutexCanceled(t *testing.T) {
+	m := newTestMutex(t)
+
+	unlock, err := m.Lock(t.Context(), "cid")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = m.Lock(ctx, "cid")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The canceled waiter released its reference, the holder still has the lock
+	assert.Equal(t, 1, m.Len())
+
+	unlock()
+	assert.Equal(t, 0, m.Len())
+
+	assert.Equal(t, 2, testutil.CollectAndCount(m.wait), "acquired and canceled waits are observed")
+}
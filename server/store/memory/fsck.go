@@ -0,0 +1,130 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/store/oci"
+	"google.golang.org/protobuf/proto"
+)
+
+// Fsck checks the consistency of the store and reports detected issues and
+// a final summary to fn, repairing the issues if requested.
+//
+// Every entry must hold a record whose CID, recomputed from its canonical bytes,
+// matches the key of the entry, and metadata that matches the record.
+func (s *Store) Fsck(ctx context.Context, repair bool, fn func(*storev1.FsckResponse) error) error {
+	if err := s.simulate(ctx, "fsck"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cids := make([]string, 0, len(s.records))
+	for cid := range s.records {
+		cids = append(cids, cid)
+	}
+
+	sort.Strings(cids)
+
+	summary := &storev1.FsckSummary{}
+
+	for _, cid := range cids {
+		if issue := s.checkEntry(cid, repair); issue != nil {
+			summary.Issues++
+			if issue.GetRepaired() {
+				summary.Repaired++
+			}
+
+			if err := fn(&storev1.FsckResponse{Response: &storev1.FsckResponse_Issue{Issue: issue}}); err != nil {
+				return err
+			}
+		}
+
+		summary.CheckedTags++
+		summary.CheckedRecords++
+	}
+
+	err := fn(&storev1.FsckResponse{Response: &storev1.FsckResponse_Progress{Progress: &storev1.FsckProgress{
+		CheckedTags: summary.GetCheckedTags(),
+		TotalTags:   uint64(len(cids)),
+	}}})
+	if err != nil {
+		return err
+	}
+
+	return fn(&storev1.FsckResponse{Response: &storev1.FsckResponse_Summary{Summary: summary}})
+}
+
+// checkEntry checks the entry stored under the CID and repairs it if requested,
+// returning the detected issue, if any.
+// The caller must hold the write lock.
+func (s *Store) checkEntry(cid string, repair bool) *storev1.FsckIssue {
+	e := s.records[cid]
+
+	if e.record == nil {
+		issue := &storev1.FsckIssue{
+			Type:    storev1.FsckIssueType_FSCK_ISSUE_TYPE_MISSING_BLOB,
+			Tag:     cid,
+			Cid:     cid,
+			Message: "entry has no record",
+		}
+
+		if repair {
+			delete(s.records, cid)
+			delete(s.referrers, cid)
+
+			issue.Repaired = true
+		}
+
+		return issue
+	}
+
+	if !e.record.MatchesCid(cid) {
+		actual := e.record.GetCid()
+
+		issue := &storev1.FsckIssue{
+			Type:    storev1.FsckIssueType_FSCK_ISSUE_TYPE_CID_MISMATCH,
+			Tag:     cid,
+			Cid:     cid,
+			Message: fmt.Sprintf("stored content has CID %s", actual),
+		}
+
+		if repair {
+			delete(s.records, cid)
+			delete(s.referrers, cid)
+
+			if _, exists := s.records[actual]; !exists && actual != "" {
+				s.records[actual] = &entry{record: e.record, meta: oci.ExtractRecordMeta(e.record)}
+			}
+
+			issue.Repaired = true
+		}
+
+		return issue
+	}
+
+	if meta := oci.ExtractRecordMeta(e.record); !proto.Equal(meta, e.meta) {
+		issue := &storev1.FsckIssue{
+			Type:    storev1.FsckIssueType_FSCK_ISSUE_TYPE_MISSING_TAG,
+			Tag:     cid,
+			Cid:     cid,
+			Message: "stored metadata does not match the record",
+		}
+
+		if repair {
+			e.meta = meta
+			issue.Repaired = true
+		}
+
+		return issue
+	}
+
+	return nil
+}
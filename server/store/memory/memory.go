@@ -0,0 +1,416 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package memory provides an in-memory implementation of the store API.
+// It is intended for unit tests and in-process servers, not for production use.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/store/lock"
+	"github.com/agntcy/dir/server/store/oci"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/utils/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var logger = logging.Logger("store/memory")
+
+var _ types.StoreAPI = &Store{}
+
+type entry struct {
+	record    *corev1.Record
+	meta      *corev1.RecordMeta
+	lifecycle *corev1.Lifecycle
+	metadata  map[string]string
+}
+
+// Store is an in-memory record store keyed by CID.
+// It is safe for concurrent use.
+type Store struct {
+	mu        sync.RWMutex
+	records   map[string]*entry
+	referrers map[string][]*corev1.RecordReferrer
+
+	options *options
+
+	// pushLocks serializes pushes of the same record, like the OCI store.
+	pushLocks lock.Locker
+
+	rngMu sync.Mutex
+	rng   *rand.Rand
+}
+
+// New creates a new in-memory store.
+func New(opts ...Option) (*Store, error) {
+	options := &options{
+		seed: time.Now().UnixNano(),
+	}
+
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+
+	pushLocks, err := lock.NewKeyedMutex("memory", prometheus.DefaultRegisterer)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return &Store{
+		records:   make(map[string]*entry),
+		referrers: make(map[string][]*corev1.RecordReferrer),
+		options:   options,
+		pushLocks: pushLocks,
+		rng:       rand.New(rand.NewSource(options.seed)), //nolint:gosec
+	}, nil
+}
+
+// Push stores the record keyed by its CID. Pushing an existing record is a no-op,
+// reported by the AlreadyExisted flag of the returned reference.
+func (s *Store) Push(ctx context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
+	if err := s.simulate(ctx, "push"); err != nil {
+		return nil, err
+	}
+
+	recordCID := record.GetCid()
+	if recordCID == "" {
+		return nil, status.Error(codes.InvalidArgument, "failed to calculate record CID") //nolint:wrapcheck
+	}
+
+	unlock, err := s.pushLocks.Lock(ctx, recordCID)
+	if err != nil {
+		return nil, status.FromContextError(err).Err() //nolint:wrapcheck
+	}
+	defer unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.records[recordCID]; exists {
+		logger.Info("Record already exists in memory store", "cid", recordCID)
+
+		return &corev1.RecordRef{Cid: recordCID, AlreadyExisted: true}, nil
+	}
+
+	s.records[recordCID] = &entry{
+		record: proto.Clone(record).(*corev1.Record), //nolint:forcetypeassert
+		meta:   oci.ExtractRecordMeta(record),
+	}
+
+	logger.Debug("Record pushed to memory store", "cid", recordCID)
+
+	return &corev1.RecordRef{Cid: recordCID}, nil
+}
+
+// Pull returns a copy of the stored record.
+func (s *Store) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	if err := s.simulate(ctx, "pull"); err != nil {
+		return nil, err
+	}
+
+	e, err := s.get(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	record := proto.Clone(e.record).(*corev1.Record) //nolint:forcetypeassert
+
+	// CIDs are self-describing, the record keeps the hash function of the CID it is stored under
+	if opts, err := corev1.CIDOptionsOf(ref.GetCid()); err == nil {
+		record.SetCIDOptions(opts)
+	}
+
+	if !e.lifecycle.IsActive() {
+		record.Lifecycle = proto.Clone(e.lifecycle).(*corev1.Lifecycle) //nolint:forcetypeassert
+	}
+
+	return record, nil
+}
+
+// Lookup returns a copy of the stored record metadata.
+func (s *Store) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	if err := s.simulate(ctx, "lookup"); err != nil {
+		return nil, err
+	}
+
+	e, err := s.get(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := proto.Clone(e.meta).(*corev1.RecordMeta) //nolint:forcetypeassert
+
+	meta.Lifecycle = &corev1.Lifecycle{Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_ACTIVE}
+	if e.lifecycle != nil {
+		meta.Lifecycle = proto.Clone(e.lifecycle).(*corev1.Lifecycle) //nolint:forcetypeassert
+	}
+
+	meta.OperationalMetadata = maps.Clone(e.metadata)
+
+	return meta, nil
+}
+
+// SetLifecycle sets the lifecycle status of a record and returns the updated record metadata.
+func (s *Store) SetLifecycle(ctx context.Context, ref *corev1.RecordRef, lifecycle *corev1.Lifecycle) (*corev1.RecordMeta, error) {
+	if err := s.simulate(ctx, "set lifecycle"); err != nil {
+		return nil, err
+	}
+
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	if err := lifecycle.Validate(ref.GetCid()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid lifecycle: %v", err)
+	}
+
+	s.mu.Lock()
+
+	e, ok := s.records[ref.GetCid()]
+	if !ok {
+		s.mu.Unlock()
+
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", ref.GetCid())
+	}
+
+	// Entries are replaced rather than modified, as they are read without holding the lock
+	s.records[ref.GetCid()] = &entry{
+		record:    e.record,
+		meta:      e.meta,
+		lifecycle: proto.Clone(lifecycle).(*corev1.Lifecycle), //nolint:forcetypeassert
+		metadata:  e.metadata,
+	}
+
+	s.mu.Unlock()
+
+	logger.Debug("Record lifecycle updated in memory store", "cid", ref.GetCid(), "status", lifecycle.StatusName())
+
+	return s.Lookup(ctx, ref)
+}
+
+// SetMetadata updates the operational metadata of a record, see corev1.MergeMetadata, and returns the updated record metadata.
+func (s *Store) SetMetadata(ctx context.Context, ref *corev1.RecordRef, update map[string]string) (*corev1.RecordMeta, error) {
+	if err := s.simulate(ctx, "set metadata"); err != nil {
+		return nil, err
+	}
+
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	if err := corev1.ValidateMetadataUpdate(update); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid metadata: %v", err)
+	}
+
+	s.mu.Lock()
+
+	e, ok := s.records[ref.GetCid()]
+	if !ok {
+		s.mu.Unlock()
+
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", ref.GetCid())
+	}
+
+	metadata, err := corev1.MergeMetadata(e.metadata, update)
+	if err != nil {
+		s.mu.Unlock()
+
+		return nil, status.Errorf(codes.InvalidArgument, "invalid metadata: %v", err)
+	}
+
+	// Entries are replaced rather than modified, as they are read without holding the lock
+	s.records[ref.GetCid()] = &entry{
+		record:    e.record,
+		meta:      e.meta,
+		lifecycle: e.lifecycle,
+		metadata:  metadata,
+	}
+
+	s.mu.Unlock()
+
+	logger.Debug("Record metadata updated in memory store", "cid", ref.GetCid(), "keys", len(metadata))
+
+	return s.Lookup(ctx, ref)
+}
+
+// GetMetadata returns a copy of the operational metadata of a record, empty if none was set.
+func (s *Store) GetMetadata(ctx context.Context, ref *corev1.RecordRef) (map[string]string, error) {
+	if err := s.simulate(ctx, "get metadata"); err != nil {
+		return nil, err
+	}
+
+	e, err := s.get(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return maps.Clone(e.metadata), nil
+}
+
+// Delete removes the record and all of its referrers.
+// Deleting a missing record is not an error, matching the OCI store behaviour.
+func (s *Store) Delete(ctx context.Context, ref *corev1.RecordRef) error {
+	if err := s.simulate(ctx, "delete"); err != nil {
+		return err
+	}
+
+	if err := validateRecordRef(ref); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, ref.GetCid())
+	delete(s.referrers, ref.GetCid())
+
+	logger.Debug("Record deleted from memory store", "cid", ref.GetCid())
+
+	return nil
+}
+
+// List walks all stored record references in ascending CID order.
+// Walking stops at the first error returned by walkFn.
+func (s *Store) List(ctx context.Context, walkFn func(*corev1.RecordRef) error) error {
+	if err := s.simulate(ctx, "list"); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+
+	cids := make([]string, 0, len(s.records))
+	for cid := range s.records {
+		cids = append(cids, cid)
+	}
+
+	s.mu.RUnlock()
+
+	sort.Strings(cids)
+
+	for _, cid := range cids {
+		if err := walkFn(&corev1.RecordRef{Cid: cid}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PushReferrer stores a referrer for an existing record.
+func (s *Store) PushReferrer(ctx context.Context, recordCID string, referrer *corev1.RecordReferrer) error {
+	if err := s.simulate(ctx, "push referrer"); err != nil {
+		return err
+	}
+
+	if referrer == nil {
+		return status.Error(codes.InvalidArgument, "referrer is required") //nolint:wrapcheck
+	}
+
+	if referrer.GetType() == "" {
+		return status.Error(codes.InvalidArgument, "referrer type is required") //nolint:wrapcheck
+	}
+
+	if _, err := s.get(&corev1.RecordRef{Cid: recordCID}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.referrers[recordCID] = append(s.referrers[recordCID], proto.Clone(referrer).(*corev1.RecordReferrer)) //nolint:forcetypeassert
+
+	return nil
+}
+
+// WalkReferrers walks referrers of a record in insertion order, optionally filtered by type.
+func (s *Store) WalkReferrers(ctx context.Context, recordCID string, referrerType string, walkFn func(*corev1.RecordReferrer) error) error {
+	if err := s.simulate(ctx, "walk referrers"); err != nil {
+		return err
+	}
+
+	if _, err := s.get(&corev1.RecordRef{Cid: recordCID}); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	referrers := append([]*corev1.RecordReferrer(nil), s.referrers[recordCID]...)
+	s.mu.RUnlock()
+
+	for _, referrer := range referrers {
+		if referrerType != "" && referrer.GetType() != referrerType {
+			continue
+		}
+
+		if err := walkFn(proto.Clone(referrer).(*corev1.RecordReferrer)); err != nil { //nolint:forcetypeassert
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) get(ref *corev1.RecordRef) (*entry, error) {
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.records[ref.GetCid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", ref.GetCid())
+	}
+
+	return e, nil
+}
+
+// simulate applies the configured latency and error injection to an operation.
+func (s *Store) simulate(ctx context.Context, op string) error {
+	if s.options.latency > 0 {
+		select {
+		case <-time.After(s.options.latency):
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err() //nolint:wrapcheck
+		}
+	}
+
+	if s.options.errorRate <= 0 {
+		return nil
+	}
+
+	s.rngMu.Lock()
+	fail := s.rng.Float64() < s.options.errorRate
+	s.rngMu.Unlock()
+
+	if fail {
+		return status.Errorf(codes.Unavailable, "injected failure for %s operation", op)
+	}
+
+	return nil
+}
+
+func validateRecordRef(ref *corev1.RecordRef) error {
+	if ref == nil {
+		return status.Error(codes.InvalidArgument, "record reference cannot be nil") //nolint:wrapcheck
+	}
+
+	if ref.GetCid() == "" {
+		return status.Error(codes.InvalidArgument, "record CID cannot be empty") //nolint:wrapcheck
+	}
+
+	return nil
+}
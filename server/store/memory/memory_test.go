@@ -0,0 +1,409 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/store/lock"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestRecord(name string) *corev1.Record {
+	return corev1.New(&typesv1alpha0.Record{
+		Name:          name,
+		Version:       "v1.0.0",
+		SchemaVersion: "v0.3.1",
+		Description:   "A test agent",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: toPtr("Natural Language Processing"), ClassName: toPtr("Text Completion")},
+		},
+	})
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
+
+func TestStorePushLookupPullDelete(t *testing.T) {
+	ctx := t.Context()
+
+	store, err := New()
+	require.NoError(t, err)
+
+	record := newTestRecord("test-agent")
+
+	ref, err := store.Push(ctx, record)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), ref.GetCid())
+
+	// Pushing the same record again is idempotent
+	ref2, err := store.Push(ctx, record)
+	require.NoError(t, err)
+	assert.Equal(t, ref.GetCid(), ref2.GetCid())
+
+	meta, err := store.Lookup(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, ref.GetCid(), meta.GetCid())
+	assert.Equal(t, "test-agent", meta.GetAnnotations()["name"])
+	assert.Equal(t, "v1.0.0", meta.GetAnnotations()["version"])
+	assert.Equal(t, "v0.3.1", meta.GetSchemaVersion())
+
+	pulled, err := store.Pull(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), pulled.GetCid())
+
+	require.NoError(t, store.Delete(ctx, ref))
+
+	_, err = store.Lookup(ctx, ref)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = store.Pull(ctx, ref)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestStorePushPullCIDOptions(t *testing.T) {
+	ctx := t.Context()
+
+	store, err := New()
+	require.NoError(t, err)
+
+	record := newTestRecord("test-agent-sha512")
+	record.SetCIDOptions(corev1.CIDOptions{MulticodecHash: mh.SHA2_512})
+
+	ref, err := store.Push(ctx, record)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), ref.GetCid())
+	assert.NotEqual(t, newTestRecord("test-agent-sha512").GetCid(), ref.GetCid())
+
+	pulled, err := store.Pull(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, ref.GetCid(), pulled.GetCid())
+
+	// Mutating the pushed record does not affect the stored one
+	record.SetCIDOptions(corev1.DefaultCIDOptions)
+
+	pulled, err = store.Pull(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, ref.GetCid(), pulled.GetCid())
+}
+
+func TestStorePushConcurrent(t *testing.T) {
+	const pushers = 50
+
+	s, err := New(WithLatency(time.Millisecond))
+	require.NoError(t, err)
+
+	record := newTestRecord("concurrent-agent")
+
+	var (
+		wg     sync.WaitGroup
+		stored atomic.Int32
+		pushed atomic.Int32
+	)
+
+	for range pushers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			ref, err := s.Push(t.Context(), record)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, record.GetCid(), ref.GetCid())
+			pushed.Add(1)
+
+			if !ref.GetAlreadyExisted() {
+				stored.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(pushers), pushed.Load())
+	assert.Equal(t, int32(1), stored.Load(), "record must be stored by exactly one push")
+
+	locks, ok := s.pushLocks.(*lock.KeyedMutex)
+	require.True(t, ok)
+	assert.Equal(t, 0, locks.Len(), "push locks must be released")
+}
+
+func TestStoreInvalidRef(t *testing.T) {
+	store, err := New()
+	require.NoError(t, err)
+
+	_, err = store.Pull(t.Context(), nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = store.Lookup(t.Context(), &corev1.RecordRef{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStoreListDeterministicOrder(t *testing.T) {
+	ctx := t.Context()
+
+	store, err := New()
+	require.NoError(t, err)
+
+	var expected []string
+
+	for _, name := range []string{"agent-c", "agent-a", "agent-b", "agent-d"} {
+		ref, err := store.Push(ctx, newTestRecord(name))
+		require.NoError(t, err)
+
+		expected = append(expected, ref.GetCid())
+	}
+
+	sort.Strings(expected)
+
+	for range 3 {
+		var listed []string
+
+		err := store.List(ctx, func(ref *corev1.RecordRef) error {
+			listed = append(listed, ref.GetCid())
+
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, listed)
+	}
+}
+
+func TestStoreReferrers(t *testing.T) {
+	ctx := t.Context()
+
+	store, err := New()
+	require.NoError(t, err)
+
+	ref, err := store.Push(ctx, newTestRecord("test-agent"))
+	require.NoError(t, err)
+
+	require.NoError(t, store.PushReferrer(ctx, ref.GetCid(), &corev1.RecordReferrer{Type: "type-a"}))
+	require.NoError(t, store.PushReferrer(ctx, ref.GetCid(), &corev1.RecordReferrer{Type: "type-b"}))
+
+	var types []string
+
+	err = store.WalkReferrers(ctx, ref.GetCid(), "type-b", func(referrer *corev1.RecordReferrer) error {
+		types = append(types, referrer.GetType())
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"type-b"}, types)
+
+	err = store.PushReferrer(ctx, "missing", &corev1.RecordReferrer{Type: "type-a"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestStoreErrorInjection(t *testing.T) {
+	ctx := t.Context()
+
+	store, err := New(WithErrorRate(1))
+	require.NoError(t, err)
+
+	_, err = store.Push(ctx, newTestRecord("test-agent"))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	_, err = New(WithErrorRate(1.5))
+	assert.Error(t, err)
+}
+
+func TestStoreLatency(t *testing.T) {
+	store, err := New(WithLatency(time.Second))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = store.Push(ctx, newTestRecord("test-agent"))
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestStoreFsck(t *testing.T) {
+	ctx := t.Context()
+
+	store, err := New()
+	require.NoError(t, err)
+
+	var refs []*corev1.RecordRef
+
+	for _, name := range []string{"healthy", "missing-blob", "cid-mismatch", "stale-meta"} {
+		ref, err := store.Push(ctx, newTestRecord(name))
+		require.NoError(t, err)
+
+		refs = append(refs, ref)
+	}
+
+	// Corrupt one entry per issue class
+	store.records[refs[1].GetCid()].record = nil
+
+	moved := store.records[refs[2].GetCid()]
+	delete(store.records, refs[2].GetCid())
+	store.records["baeareimisplaced"] = moved
+
+	store.records[refs[3].GetCid()].meta = &corev1.RecordMeta{Cid: refs[3].GetCid()}
+
+	fsck := func(repair bool) (map[storev1.FsckIssueType]*storev1.FsckIssue, *storev1.FsckSummary) {
+		issues := make(map[storev1.FsckIssueType]*storev1.FsckIssue)
+
+		var summary *storev1.FsckSummary
+
+		err := store.Fsck(ctx, repair, func(resp *storev1.FsckResponse) error {
+			if issue := resp.GetIssue(); issue != nil {
+				issues[issue.GetType()] = issue
+			}
+
+			if resp.GetSummary() != nil {
+				summary = resp.GetSummary()
+			}
+
+			return nil
+		})
+		require.NoError(t, err)
+		require.NotNil(t, summary)
+
+		return issues, summary
+	}
+
+	// Issues are only reported without repair
+	issues, summary := fsck(false)
+	assert.Len(t, issues, 3)
+	assert.Equal(t, refs[1].GetCid(), issues[storev1.FsckIssueType_FSCK_ISSUE_TYPE_MISSING_BLOB].GetCid())
+	assert.Equal(t, "baeareimisplaced", issues[storev1.FsckIssueType_FSCK_ISSUE_TYPE_CID_MISMATCH].GetCid())
+	assert.Equal(t, refs[3].GetCid(), issues[storev1.FsckIssueType_FSCK_ISSUE_TYPE_MISSING_TAG].GetCid())
+	assert.Equal(t, uint64(4), summary.GetCheckedRecords())
+	assert.Equal(t, uint64(3), summary.GetIssues())
+	assert.Zero(t, summary.GetRepaired())
+
+	// Repair fixes every issue
+	issues, summary = fsck(true)
+	assert.Len(t, issues, 3)
+
+	for _, issue := range issues {
+		assert.True(t, issue.GetRepaired(), issue.GetType().String())
+	}
+
+	assert.Equal(t, uint64(3), summary.GetRepaired())
+
+	_, err = store.Pull(ctx, refs[1])
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	pulled, err := store.Pull(ctx, refs[2])
+	require.NoError(t, err)
+	assert.Equal(t, refs[2].GetCid(), pulled.GetCid())
+
+	meta, err := store.Lookup(ctx, refs[3])
+	require.NoError(t, err)
+	assert.Equal(t, "stale-meta", meta.GetAnnotations()["name"])
+
+	// The repaired store is consistent
+	issues, summary = fsck(false)
+	assert.Empty(t, issues)
+	assert.Equal(t, uint64(3), summary.GetCheckedRecords())
+}
+
+func TestStoreSetLifecycle(t *testing.T) {
+	ctx := t.Context()
+
+	store, err := New()
+	require.NoError(t, err)
+
+	ref, err := store.Push(ctx, newTestRecord("agent-v1"))
+	require.NoError(t, err)
+
+	successor, err := store.Push(ctx, newTestRecord("agent-v2"))
+	require.NoError(t, err)
+
+	meta, err := store.Lookup(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.LifecycleStatus_LIFECYCLE_STATUS_ACTIVE, meta.GetLifecycle().GetStatus())
+
+	transitions := []*corev1.Lifecycle{
+		{Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED, SuccessorCid: successor.GetCid(), Reason: "renamed"},
+		{Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_WITHDRAWN, Reason: "security issue"},
+		{Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_ACTIVE},
+	}
+
+	for _, lifecycle := range transitions {
+		meta, err := store.SetLifecycle(ctx, ref, lifecycle)
+		require.NoError(t, err)
+		assert.Equal(t, lifecycle.GetStatus(), meta.GetLifecycle().GetStatus())
+
+		meta, err = store.Lookup(ctx, ref)
+		require.NoError(t, err)
+		assert.Equal(t, lifecycle.GetStatus(), meta.GetLifecycle().GetStatus())
+		assert.Equal(t, lifecycle.GetSuccessorCid(), meta.GetLifecycle().GetSuccessorCid())
+		assert.Equal(t, lifecycle.GetReason(), meta.GetLifecycle().GetReason())
+
+		pulled, err := store.Pull(ctx, ref)
+		require.NoError(t, err)
+		assert.Equal(t, ref.GetCid(), pulled.GetCid())
+
+		if lifecycle.IsActive() {
+			assert.Nil(t, pulled.GetLifecycle())
+		} else {
+			assert.Equal(t, lifecycle.GetStatus(), pulled.GetLifecycle().GetStatus())
+		}
+	}
+
+	_, err = store.SetLifecycle(ctx, ref, &corev1.Lifecycle{
+		Status:       corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED,
+		SuccessorCid: ref.GetCid(),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	require.NoError(t, store.Delete(ctx, successor))
+
+	_, err = store.SetLifecycle(ctx, successor, transitions[1])
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestStoreSetMetadata(t *testing.T) {
+	ctx := t.Context()
+
+	store, err := New()
+	require.NoError(t, err)
+
+	ref, err := store.Push(ctx, newTestRecord("agent"))
+	require.NoError(t, err)
+
+	meta, err := store.SetMetadata(ctx, ref, map[string]string{"team": "platform", "owner": "jane"})
+	require.NoError(t, err)
+	assert.Equal(t, ref.GetCid(), meta.GetCid())
+	assert.Equal(t, map[string]string{"team": "platform", "owner": "jane"}, meta.GetOperationalMetadata())
+
+	// Lifecycle updates keep the metadata
+	_, err = store.SetLifecycle(ctx, ref, &corev1.Lifecycle{Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED})
+	require.NoError(t, err)
+
+	_, err = store.SetMetadata(ctx, ref, map[string]string{"team": "search", "owner": ""})
+	require.NoError(t, err)
+
+	metadata, err := store.GetMetadata(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "search"}, metadata)
+
+	pulled, err := store.Pull(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, ref.GetCid(), pulled.GetCid())
+
+	_, err = store.SetMetadata(ctx, &corev1.RecordRef{Cid: "missing"}, map[string]string{"team": "platform"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
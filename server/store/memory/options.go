@@ -0,0 +1,54 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"errors"
+	"time"
+)
+
+type Option func(*options) error
+
+type options struct {
+	latency   time.Duration
+	errorRate float64
+	seed      int64
+}
+
+// WithLatency adds an artificial delay to every store operation.
+// Useful for exercising timeouts and backpressure in streaming clients.
+func WithLatency(latency time.Duration) Option {
+	return func(o *options) error {
+		if latency < 0 {
+			return errors.New("latency cannot be negative")
+		}
+
+		o.latency = latency
+
+		return nil
+	}
+}
+
+// WithErrorRate makes store operations fail randomly with the given probability.
+// The rate must be in the [0, 1] range where 0 disables error injection.
+func WithErrorRate(rate float64) Option {
+	return func(o *options) error {
+		if rate < 0 || rate > 1 {
+			return errors.New("error rate must be between 0 and 1")
+		}
+
+		o.errorRate = rate
+
+		return nil
+	}
+}
+
+// WithSeed sets the seed used for error injection so that failures are reproducible.
+func WithSeed(seed int64) Option {
+	return func(o *options) error {
+		o.seed = seed
+
+		return nil
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/labels"
 	"github.com/agntcy/dir/server/types/adapters"
 )
 
@@ -58,34 +59,35 @@ func extractManifestAnnotations(record *corev1.Record) map[string]string {
 		annotations[ManifestKeyAuthors] = strings.Join(authors, ",")
 	}
 
-	// Capability discovery - extract skill names
-	if skills := recordData.GetSkills(); len(skills) > 0 {
-		skillNames := make([]string, len(skills))
-		for i, skill := range skills {
-			skillNames[i] = skill.GetName()
-		}
+	// Capability discovery - labels are shared with routing announcements
+	recordLabels := labels.FromRecord(adapter)
 
-		annotations[ManifestKeySkills] = strings.Join(skillNames, ",")
+	if len(recordLabels.Skills) > 0 {
+		annotations[ManifestKeySkills] = strings.Join(recordLabels.Skills, ",")
 	}
 
-	// Extract locator types
-	if locators := recordData.GetLocators(); len(locators) > 0 {
-		locatorTypes := make([]string, len(locators))
-		for i, locator := range locators {
-			locatorTypes[i] = locator.GetType()
-		}
+	if len(recordLabels.Domains) > 0 {
+		annotations[ManifestKeyDomains] = strings.Join(recordLabels.Domains, ",")
+	}
+
+	if len(recordLabels.Locators) > 0 {
+		annotations[ManifestKeyLocatorTypes] = strings.Join(recordLabels.Locators, ",")
+	}
 
-		annotations[ManifestKeyLocatorTypes] = strings.Join(locatorTypes, ",")
+	if len(recordLabels.Modules) > 0 {
+		annotations[ManifestKeyModuleNames] = strings.Join(recordLabels.Modules, ",")
 	}
 
-	// Extract module names
-	if modules := recordData.GetModules(); len(modules) > 0 {
-		moduleNames := make([]string, len(modules))
-		for i, module := range modules {
-			moduleNames[i] = module.GetName()
+	if variants := recordLabels.LocatorVariants; len(variants) > 0 {
+		annotations[ManifestKeyLocatorVariantsCount] = strconv.Itoa(len(variants))
+
+		if len(variants) > maxLocatorVariants {
+			logger.Debug("Capping locator variants in manifest annotations", "count", len(variants), "max", maxLocatorVariants)
+
+			variants = variants[:maxLocatorVariants]
 		}
 
-		annotations[ManifestKeyModuleNames] = strings.Join(moduleNames, ",")
+		annotations[ManifestKeyLocatorVariants] = strings.Join(variants, ",")
 	}
 
 	// Security metadata
@@ -102,21 +104,64 @@ func extractManifestAnnotations(record *corev1.Record) map[string]string {
 		annotations[ManifestKeySigned] = "false"
 	}
 
+	// Encryption header, the remaining parameters are stored in the envelope
+	if header := record.GetEnvelope().GetHeader(); header != nil {
+		annotations[ManifestKeyEncryption] = header.GetAlgorithm()
+		annotations[ManifestKeyEncryptionKeyID] = header.GetKeyId()
+	}
+
 	// Versioning (v1 specific)
 	if previousCid := recordData.GetPreviousRecordCid(); previousCid != "" {
 		annotations[ManifestKeyPreviousCid] = previousCid
 	}
 
 	// Custom annotations from record data -> manifest custom annotations
-	if customAnnotations := recordData.GetAnnotations(); len(customAnnotations) > 0 {
-		for key, value := range customAnnotations {
-			annotations[ManifestKeyCustomPrefix+key] = value
+	for key, value := range recordData.GetAnnotations() {
+		normalizedKey := normalizeCustomAnnotationKey(key)
+		if normalizedKey == "" {
+			logger.Warn("Skipping custom annotation with invalid key", "key", key)
+
+			continue
 		}
+
+		if len(value) > maxCustomAnnotationValueLength {
+			logger.Warn("Truncating custom annotation value", "key", key, "length", len(value), "max_length", maxCustomAnnotationValueLength)
+
+			value = value[:maxCustomAnnotationValueLength]
+		}
+
+		annotations[ManifestKeyCustomPrefix+normalizedKey] = value
 	}
 
 	return annotations
 }
 
+// normalizeCustomAnnotationKey normalizes a custom annotation key for use in OCI manifest annotations.
+// Keys are trimmed, characters other than [a-zA-Z0-9._/-] are replaced with '-',
+// and keys are capped in length. Keys without any valid characters yield an empty key.
+func normalizeCustomAnnotationKey(key string) string {
+	key = strings.TrimSpace(key)
+
+	normalized := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-', r == '/':
+			return r
+		default:
+			return '-'
+		}
+	}, key)
+
+	if len(normalized) > maxCustomAnnotationKeyLength {
+		normalized = normalized[:maxCustomAnnotationKeyLength]
+	}
+
+	if strings.Trim(normalized, "./_-") == "" {
+		return ""
+	}
+
+	return normalized
+}
+
 // parseManifestAnnotations extracts structured metadata from manifest annotations.
 //
 //nolint:cyclop // Function handles multiple metadata extraction paths with justified complexity
@@ -174,6 +219,12 @@ func parseManifestAnnotations(annotations map[string]string) *corev1.RecordMeta
 		recordMeta.Annotations[MetadataKeySkillsCount] = strconv.Itoa(len(skillList))
 	}
 
+	if domains := annotations[ManifestKeyDomains]; domains != "" {
+		recordMeta.Annotations[MetadataKeyDomains] = domains // comma-separated
+		domainList := parseCommaSeparated(domains)
+		recordMeta.Annotations[MetadataKeyDomainsCount] = strconv.Itoa(len(domainList))
+	}
+
 	if locatorTypes := annotations[ManifestKeyLocatorTypes]; locatorTypes != "" {
 		recordMeta.Annotations[MetadataKeyLocatorTypes] = locatorTypes // comma-separated
 		locatorList := parseCommaSeparated(locatorTypes)
@@ -186,6 +237,11 @@ func parseManifestAnnotations(annotations map[string]string) *corev1.RecordMeta
 		recordMeta.Annotations[MetadataKeyModuleCount] = strconv.Itoa(len(moduleList))
 	}
 
+	if locatorVariants := annotations[ManifestKeyLocatorVariants]; locatorVariants != "" {
+		recordMeta.Annotations[MetadataKeyLocatorVariants] = locatorVariants // comma-separated, possibly capped
+		recordMeta.Annotations[MetadataKeyLocatorVariantsCount] = annotations[ManifestKeyLocatorVariantsCount]
+	}
+
 	// Security information (structured and easily accessible)
 	//nolint:nestif // Nested structure needed for conditional signature metadata extraction
 	if signedStr := annotations[ManifestKeySigned]; signedStr != "" {
@@ -202,15 +258,24 @@ func parseManifestAnnotations(annotations map[string]string) *corev1.RecordMeta
 		}
 	}
 
+	if encryption := annotations[ManifestKeyEncryption]; encryption != "" {
+		recordMeta.Annotations[MetadataKeyEncryption] = encryption
+		recordMeta.Annotations[MetadataKeyEncryptionKeyID] = annotations[ManifestKeyEncryptionKeyID]
+	}
+
 	// Versioning information
 	if previousCid := annotations[ManifestKeyPreviousCid]; previousCid != "" {
 		recordMeta.Annotations[MetadataKeyPreviousCid] = previousCid
 	}
 
 	// Custom annotations (those with our custom prefix) - clean namespace
+	// Structured metadata takes precedence over custom annotations with the same key
 	for key, value := range annotations {
-		if strings.HasPrefix(key, ManifestKeyCustomPrefix) {
-			customKey := strings.TrimPrefix(key, ManifestKeyCustomPrefix)
+		if customKey, ok := strings.CutPrefix(key, ManifestKeyCustomPrefix); ok {
+			if _, exists := recordMeta.Annotations[customKey]; exists {
+				continue
+			}
+
 			recordMeta.Annotations[customKey] = value
 		}
 	}
@@ -218,6 +283,19 @@ func parseManifestAnnotations(annotations map[string]string) *corev1.RecordMeta
 	return recordMeta
 }
 
+// ExtractRecordMeta builds the record metadata exactly as the OCI store would
+// return it from Lookup after a Push. This allows other store backends to expose
+// metadata that is indistinguishable from the OCI store.
+func ExtractRecordMeta(record *corev1.Record) *corev1.RecordMeta {
+	annotations := extractManifestAnnotations(record)
+	annotations[ManifestKeyCid] = record.GetCid()
+
+	recordMeta := parseManifestAnnotations(annotations)
+	recordMeta.Cid = record.GetCid()
+
+	return recordMeta
+}
+
 // parseCommaSeparated splits comma-separated values and trims whitespace.
 func parseCommaSeparated(value string) []string {
 	if value == "" {
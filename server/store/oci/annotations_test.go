@@ -4,11 +4,16 @@
 package oci
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 
 	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
 	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/labels"
+	"github.com/agntcy/dir/server/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -344,6 +349,61 @@ func TestParseManifestAnnotations(t *testing.T) {
 	}
 }
 
+func TestExtractManifestAnnotations_CustomAnnotations(t *testing.T) {
+	longValue := strings.Repeat("v", maxCustomAnnotationValueLength+10)
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "test-agent",
+		SchemaVersion: "0.7.0",
+		Annotations: map[string]string{
+			"team":              "platform",
+			" cost center ":     "cc-1234",
+			"example.com/owner": "alice",
+			"!!!":               "invalid",
+			"notes":             longValue,
+		},
+	})
+
+	result := extractManifestAnnotations(record)
+
+	assert.Equal(t, "platform", result[ManifestKeyCustomPrefix+"team"])
+	assert.Equal(t, "cc-1234", result[ManifestKeyCustomPrefix+"cost-center"])
+	assert.Equal(t, "alice", result[ManifestKeyCustomPrefix+"example.com/owner"])
+	assert.Len(t, result[ManifestKeyCustomPrefix+"notes"], maxCustomAnnotationValueLength)
+	assert.NotContains(t, result, ManifestKeyCustomPrefix+"---")
+}
+
+func TestParseManifestAnnotations_CustomDoesNotOverrideStructured(t *testing.T) {
+	recordMeta := parseManifestAnnotations(map[string]string{
+		ManifestKeyName:                  "test-agent",
+		ManifestKeyCustomPrefix + "name": "other",
+		ManifestKeyCustomPrefix + "team": "platform",
+	})
+
+	assert.Equal(t, "test-agent", recordMeta.GetAnnotations()[MetadataKeyName])
+	assert.Equal(t, "platform", recordMeta.GetAnnotations()["team"])
+}
+
+func TestNormalizeCustomAnnotationKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{key: "team", expected: "team"},
+		{key: "  Team  ", expected: "Team"},
+		{key: "cost center", expected: "cost-center"},
+		{key: "example.com/owner", expected: "example.com/owner"},
+		{key: "emoji🙂key", expected: "emoji-key"},
+		{key: "", expected: ""},
+		{key: "...", expected: ""},
+		{key: strings.Repeat("k", maxCustomAnnotationKeyLength+1), expected: strings.Repeat("k", maxCustomAnnotationKeyLength)},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, normalizeCustomAnnotationKey(tt.key), "key %q", tt.key)
+	}
+}
+
 func TestExtractManifestAnnotations_EdgeCases(t *testing.T) {
 	t.Run("Record with empty data", func(t *testing.T) {
 		record := corev1.New(&typesv1alpha0.Record{
@@ -407,3 +467,120 @@ func TestRoundTripConversion(t *testing.T) {
 	assert.Equal(t, "1", recordMeta.GetAnnotations()[MetadataKeySkillsCount])
 	assert.Equal(t, "value", recordMeta.GetAnnotations()["custom"])
 }
+
+func TestManifestAnnotationsMatchRoutingLabels(t *testing.T) {
+	// Store metadata and routing announcements must be derived from the same labels
+	records := map[string]*corev1.Record{
+		"v0.3.1": corev1.New(&typesv1alpha0.Record{
+			Name:          "labels-agent",
+			Version:       "1.0.0",
+			SchemaVersion: "v0.3.1",
+			Skills: []*typesv1alpha0.Skill{
+				{CategoryName: stringPtr("nlp"), ClassName: stringPtr("processing")},
+			},
+			Locators: []*typesv1alpha0.Locator{
+				{Type: "docker"},
+			},
+			Extensions: []*typesv1alpha0.Extension{
+				{Name: "schema.oasf.agntcy.org/domains/finance"},
+				{Name: "schema.oasf.agntcy.org/features/runtime/framework"},
+				{Name: "schema.oasf.agntcy.org/skills/extra"},
+				{Name: "monitoring"},
+			},
+		}),
+		"0.7.0": corev1.New(&typesv1alpha1.Record{
+			Name:          "labels-agent",
+			Version:       "1.0.0",
+			SchemaVersion: "0.7.0",
+			Skills: []*typesv1alpha1.Skill{
+				{Name: "natural_language_processing/summarization"},
+			},
+			Domains: []*typesv1alpha1.Domain{
+				{Name: "technology/software_engineering"},
+			},
+			Locators: []*typesv1alpha1.Locator{
+				{Type: "helm_chart"},
+			},
+			Modules: []*typesv1alpha1.Module{
+				{Name: "runtime/mcp"},
+			},
+		}),
+	}
+
+	keys := map[types.LabelType]string{
+		types.LabelTypeSkill:   ManifestKeySkills,
+		types.LabelTypeDomain:  ManifestKeyDomains,
+		types.LabelTypeModule:  ManifestKeyModuleNames,
+		types.LabelTypeLocator: ManifestKeyLocatorTypes,
+	}
+
+	for version, record := range records {
+		t.Run(version, func(t *testing.T) {
+			annotations := extractManifestAnnotations(record)
+
+			// Group the routing labels by type as they are stored in the annotations
+			routed := map[types.LabelType][]string{}
+
+			for _, label := range labels.ExtractLabels(record).RoutingLabels() {
+				routed[label.Type()] = append(routed[label.Type()], label.Value())
+			}
+
+			for labelType, key := range keys {
+				assert.Equal(t, routed[labelType], parseCommaSeparated(annotations[key]),
+					"store and routing %s labels differ", labelType)
+			}
+		})
+	}
+}
+
+func TestExtractManifestAnnotations_LocatorVariants(t *testing.T) {
+	// 4 locators targeting 2 architectures each
+	locatorTypes := []string{"docker_image", "helm_chart", "source_code", "binary"}
+
+	locators := make([]*typesv1alpha1.Locator, 0, len(locatorTypes))
+	for _, locatorType := range locatorTypes {
+		locators = append(locators, &typesv1alpha1.Locator{
+			Type:        locatorType,
+			Url:         "https://example.com/" + locatorType,
+			Annotations: map[string]string{"arch": "amd64,arm64"},
+		})
+	}
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "multi-arch-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+		Locators:      locators,
+	})
+
+	recordMeta := ExtractRecordMeta(record)
+
+	variants := parseCommaSeparated(recordMeta.GetAnnotations()[MetadataKeyLocatorVariants])
+	assert.Len(t, variants, 8)
+	assert.Contains(t, variants, "helm_chart.arm64")
+	assert.Contains(t, variants, "docker_image.amd64")
+	assert.Equal(t, "8", recordMeta.GetAnnotations()[MetadataKeyLocatorVariantsCount])
+
+	t.Run("variants are capped", func(t *testing.T) {
+		arches := make([]string, 0, maxLocatorVariants)
+		for i := range maxLocatorVariants {
+			arches = append(arches, fmt.Sprintf("arch%d", i))
+		}
+
+		record := corev1.New(&typesv1alpha1.Record{
+			Name:          "many-arch-agent",
+			SchemaVersion: "0.7.0",
+			Locators: []*typesv1alpha1.Locator{
+				{Type: "docker_image", Annotations: map[string]string{"arch": strings.Join(arches, ",")}},
+				{Type: "helm_chart", Annotations: map[string]string{"arch": "amd64"}},
+			},
+		})
+
+		recordMeta := ExtractRecordMeta(record)
+
+		variants := parseCommaSeparated(recordMeta.GetAnnotations()[MetadataKeyLocatorVariants])
+		assert.Len(t, variants, maxLocatorVariants)
+		assert.NotContains(t, variants, "helm_chart.amd64")
+		assert.Equal(t, strconv.Itoa(maxLocatorVariants+1), recordMeta.GetAnnotations()[MetadataKeyLocatorVariantsCount])
+	})
+}
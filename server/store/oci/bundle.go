@@ -0,0 +1,128 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/utils/logging"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/errdef"
+)
+
+const (
+	// mediaTypeBundle is the media type of bundle manifest layers.
+	mediaTypeBundle = "application/vnd.agntcy.dir.bundle.v1+json"
+
+	// objectTypeBundle is the object type annotation of bundle manifests.
+	objectTypeBundle = "bundle"
+)
+
+// PushBundle stores the bundle as a manifest tagged with the bundle CID, like a record,
+// so that it can be signed, verified and deleted by CID with the record APIs.
+// Pushing an existing bundle is a no-op.
+func (s *store) PushBundle(ctx context.Context, bundle *corev1.RecordBundle) (*corev1.RecordRef, error) {
+	log := logging.WithContext(ctx, logger)
+
+	bundleBytes, err := bundle.Marshal()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal bundle: %v", err)
+	}
+
+	bundleCID := bundle.GetCid()
+	if bundleCID == "" {
+		return nil, status.Error(codes.Internal, "failed to calculate bundle CID")
+	}
+
+	ref := &corev1.RecordRef{Cid: bundleCID}
+
+	if _, err := s.repo.Resolve(ctx, bundleCID); err == nil {
+		log.Info("Bundle already exists in OCI store", "cid", bundleCID)
+
+		ref.AlreadyExisted = true
+
+		return ref, nil
+	} else if !errors.Is(err, errdef.ErrNotFound) {
+		log.Debug("Failed to check if bundle exists, pushing it", "cid", bundleCID, "error", err)
+	}
+
+	layerDesc, err := oras.PushBytes(ctx, s.repo, mediaTypeBundle, bundleBytes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to push bundle bytes: %v", err)
+	}
+
+	s.metrics.transferred(directionUploaded, len(bundleBytes))
+
+	annotations := map[string]string{
+		manifestDirObjectTypeKey: objectTypeBundle,
+		ManifestKeyCid:           bundleCID,
+	}
+	if name := bundle.GetName(); name != "" {
+		annotations[ManifestKeyName] = name
+	}
+
+	if err := s.pushManifestWithTags(ctx, bundleCID, layerDesc, annotations, []string{bundleCID}); err != nil {
+		return nil, err
+	}
+
+	log.Info("Bundle pushed to OCI store successfully", "cid", bundleCID, "members", len(bundle.GetMembers()))
+
+	return ref, nil
+}
+
+// PullBundle returns the bundle stored under the given CID.
+// The bundle content is verified against its CID.
+func (s *store) PullBundle(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordBundle, error) {
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	manifest, _, err := s.fetchAndParseManifest(ctx, ref.GetCid())
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.Annotations[manifestDirObjectTypeKey] != objectTypeBundle || len(manifest.Layers) == 0 {
+		return nil, status.Errorf(codes.NotFound, "bundle not found: %s", ref.GetCid())
+	}
+
+	bundleBytes, err := s.fetchLayer(ctx, manifest.Layers[0])
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read bundle %s: %v", ref.GetCid(), err)
+	}
+
+	bundle, err := corev1.UnmarshalBundle(bundleBytes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal bundle %s: %v", ref.GetCid(), err)
+	}
+
+	if bundle.GetCid() != ref.GetCid() {
+		return nil, status.Errorf(codes.DataLoss, "bundle content does not match its CID %s", ref.GetCid())
+	}
+
+	return bundle, nil
+}
+
+func (s *store) fetchLayer(ctx context.Context, desc ocispec.Descriptor) ([]byte, error) {
+	reader, err := s.repo.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	s.metrics.transferred(directionDownloaded, len(data))
+
+	return data, nil
+}
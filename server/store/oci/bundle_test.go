@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPushPullBundle(t *testing.T) {
+	s := newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+
+	var refs []*corev1.RecordRef
+
+	for _, name := range []string{"orchestrator", "worker"} {
+		ref, err := s.Push(testCtx, corev1.New(&typesv1alpha1.Record{
+			Name:          name,
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+		}))
+		require.NoError(t, err)
+
+		refs = append(refs, ref)
+	}
+
+	bundle, err := corev1.BuildBundle(refs, map[string]string{refs[0].GetCid(): "orchestrator"})
+	require.NoError(t, err)
+
+	bundle.Name = "my-app"
+
+	ref, err := s.PushBundle(testCtx, bundle)
+	require.NoError(t, err)
+	assert.Equal(t, bundle.GetCid(), ref.GetCid())
+	assert.False(t, ref.GetAlreadyExisted())
+
+	pulled, err := s.PullBundle(testCtx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, bundle.GetCid(), pulled.GetCid())
+	assert.Equal(t, "my-app", pulled.GetName())
+	assert.Equal(t, "orchestrator", pulled.GetMember(refs[0].GetCid()).GetRole())
+
+	t.Run("push is idempotent", func(t *testing.T) {
+		ref, err := s.PushBundle(testCtx, bundle)
+		require.NoError(t, err)
+		assert.True(t, ref.GetAlreadyExisted())
+	})
+
+	t.Run("bundle is looked up but not pulled as a record", func(t *testing.T) {
+		meta, err := s.Lookup(testCtx, ref)
+		require.NoError(t, err)
+		assert.Equal(t, ref.GetCid(), meta.GetCid())
+
+		_, err = s.Pull(testCtx, ref)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("record is not pulled as a bundle", func(t *testing.T) {
+		_, err := s.PullBundle(testCtx, refs[0])
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newHashedRecord(t *testing.T, name, hash string) *corev1.Record {
+	t.Helper()
+
+	opts, err := corev1.ParseCIDOptions(hash)
+	require.NoError(t, err)
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          name,
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+	record.SetCIDOptions(opts)
+
+	return record
+}
+
+func TestCIDHashRoundTrip(t *testing.T) {
+	// Records with CIDs of different hash functions share the store
+	s := newLocalStore(t, t.TempDir(), testCompressionConfig)
+
+	for _, hash := range []string{"sha2-256", "sha2-512"} {
+		t.Run(hash, func(t *testing.T) {
+			record := newHashedRecord(t, "hashed-agent-"+hash, hash)
+
+			ref, err := s.Push(testCtx, record)
+			require.NoError(t, err)
+			assert.Equal(t, record.GetCid(), ref.GetCid())
+
+			opts, err := corev1.CIDOptionsOf(ref.GetCid())
+			require.NoError(t, err)
+			assert.Equal(t, hash, opts.String())
+
+			meta, err := s.Lookup(testCtx, ref)
+			require.NoError(t, err)
+			assert.Equal(t, ref.GetCid(), meta.GetCid())
+
+			// Pulled records verify against the CID they were pulled by
+			pulled, err := s.Pull(testCtx, ref)
+			require.NoError(t, err)
+			assert.Equal(t, ref.GetCid(), pulled.GetCid())
+			assert.True(t, pulled.MatchesCid(ref.GetCid()))
+
+			resolved, _, err := s.Resolve(testCtx, "hashed-agent-"+hash+":v1.0.0")
+			require.NoError(t, err)
+			assert.Equal(t, ref.GetCid(), resolved.GetCid())
+
+			require.NoError(t, s.Delete(testCtx, ref))
+
+			_, err = s.Lookup(testCtx, ref)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	}
+}
+
+func TestCIDHashUnsupported(t *testing.T) {
+	s := newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "blake3-agent",
+		SchemaVersion: "0.7.0",
+	})
+	record.SetCIDOptions(corev1.CIDOptions{MulticodecHash: mh.BLAKE3})
+
+	_, err := s.Push(testCtx, record)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,84 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"fmt"
+	"strconv"
+
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/klauspost/compress/zstd"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// Media types of record blobs.
+	mediaTypeRecord     = "application/json"
+	mediaTypeRecordZstd = "application/json+zstd"
+
+	// Layer descriptor annotations describing blob compression.
+	layerKeyCompression      = manifestDirObjectKeyPrefix + "/compression"
+	layerKeyUncompressedSize = manifestDirObjectKeyPrefix + "/uncompressed-size"
+
+	compressionZstd = "zstd"
+
+	// Upper bound for decompressed record blobs.
+	maxDecompressedSize = 64 * 1024 * 1024 // 64MB
+)
+
+// shouldCompress reports whether record bytes of the given size should be compressed.
+func shouldCompress(cfg ociconfig.CompressionConfig, size int) bool {
+	return cfg.Enabled && size >= cfg.MinSizeBytes
+}
+
+// compressRecord compresses canonical record bytes using zstd.
+func compressRecord(cfg ociconfig.CompressionConfig, data []byte) ([]byte, error) {
+	level := cfg.Level
+	if level <= 0 {
+		level = ociconfig.DefaultCompressionLevel
+	}
+
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+	}
+	defer encoder.Close()
+
+	return encoder.EncodeAll(data, make([]byte, 0, len(data))), nil
+}
+
+// compressionAnnotations returns the layer annotations marking a zstd-compressed record blob.
+func compressionAnnotations(uncompressedSize int) map[string]string {
+	return map[string]string{
+		layerKeyCompression:      compressionZstd,
+		layerKeyUncompressedSize: strconv.Itoa(uncompressedSize),
+	}
+}
+
+// isCompressedLayer reports whether the layer holds a compressed record blob.
+// Layers without compression annotations are treated as plain JSON for backward compatibility.
+func isCompressedLayer(desc ocispec.Descriptor) bool {
+	return desc.MediaType == mediaTypeRecordZstd || desc.Annotations[layerKeyCompression] == compressionZstd
+}
+
+// decompressRecord decompresses a zstd-compressed record blob.
+func decompressRecord(desc ocispec.Descriptor, data []byte) ([]byte, error) {
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxDecompressedSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+	}
+	defer decoder.Close()
+
+	var buf []byte
+	if size, err := strconv.Atoi(desc.Annotations[layerKeyUncompressedSize]); err == nil && size > 0 && size <= maxDecompressedSize {
+		buf = make([]byte, 0, size)
+	}
+
+	decompressed, err := decoder.DecodeAll(data, buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress record: %w", err)
+	}
+
+	return decompressed, nil
+}
@@ -0,0 +1,180 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var testCompressionConfig = ociconfig.CompressionConfig{
+	Enabled:      true,
+	MinSizeBytes: 1024, //nolint:mnd
+	Level:        ociconfig.DefaultCompressionLevel,
+}
+
+// createLargeTestRecord creates a record with verbose module data that compresses well.
+func createLargeTestRecord(t *testing.T, name string) *corev1.Record {
+	t.Helper()
+
+	data, err := structpb.NewStruct(map[string]any{
+		"description": strings.Repeat("verbose module description ", 4096), //nolint:mnd
+	})
+	require.NoError(t, err)
+
+	return corev1.New(&typesv1alpha1.Record{
+		Name:          name,
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+		Description:   "A large test record",
+		Modules: []*typesv1alpha1.Module{
+			{Name: "test-module", Data: data},
+		},
+	})
+}
+
+func newLocalStore(t *testing.T, dir string, compression ociconfig.CompressionConfig) *store {
+	t.Helper()
+
+	s, err := New(ociconfig.Config{LocalDir: dir, Compression: compression})
+	require.NoError(t, err)
+
+	localStore, ok := s.(*store)
+	require.True(t, ok)
+
+	return localStore
+}
+
+func TestCompressionRoundTrip(t *testing.T) {
+	s := newLocalStore(t, t.TempDir(), testCompressionConfig)
+
+	record := createLargeTestRecord(t, "compressed-agent")
+	recordBytes, err := record.Marshal()
+	require.NoError(t, err)
+
+	ref, err := s.Push(t.Context(), record)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), ref.GetCid(), "CID must be computed over uncompressed bytes")
+
+	// Stored blob must be compressed and smaller than the canonical bytes
+	manifest, _, err := s.fetchAndParseManifest(t.Context(), ref.GetCid())
+	require.NoError(t, err)
+	require.Len(t, manifest.Layers, 1)
+
+	layer := manifest.Layers[0]
+	assert.Equal(t, mediaTypeRecordZstd, layer.MediaType)
+	assert.Equal(t, compressionZstd, layer.Annotations[layerKeyCompression])
+	assert.Less(t, layer.Size, int64(len(recordBytes)))
+
+	// Pull must transparently decompress
+	pulled, err := s.Pull(t.Context(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), pulled.GetCid())
+
+	pulledBytes, err := pulled.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, recordBytes, pulledBytes)
+
+	// Lookup is unaffected
+	meta, err := s.Lookup(t.Context(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, ref.GetCid(), meta.GetCid())
+
+	// Delete removes the compressed blob
+	require.NoError(t, s.Delete(t.Context(), ref))
+
+	_, err = s.Pull(t.Context(), ref)
+	require.Error(t, err)
+}
+
+func TestCompressionBelowThreshold(t *testing.T) {
+	s := newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{
+		Enabled:      true,
+		MinSizeBytes: 1024 * 1024, //nolint:mnd
+	})
+
+	record := createLargeTestRecord(t, "small-agent")
+
+	ref, err := s.Push(t.Context(), record)
+	require.NoError(t, err)
+
+	manifest, _, err := s.fetchAndParseManifest(t.Context(), ref.GetCid())
+	require.NoError(t, err)
+	require.Len(t, manifest.Layers, 1)
+	assert.Equal(t, mediaTypeRecord, manifest.Layers[0].MediaType)
+	assert.False(t, isCompressedLayer(manifest.Layers[0]))
+}
+
+func TestCompressionBackwardCompatibility(t *testing.T) {
+	dir := t.TempDir()
+
+	// Uncompressed blobs must be readable with compression enabled
+	plain := createLargeTestRecord(t, "plain-agent")
+
+	plainRef, err := newLocalStore(t, dir, ociconfig.CompressionConfig{}).Push(t.Context(), plain)
+	require.NoError(t, err)
+
+	pulled, err := newLocalStore(t, dir, testCompressionConfig).Pull(t.Context(), plainRef)
+	require.NoError(t, err)
+	assert.Equal(t, plain.GetCid(), pulled.GetCid())
+
+	// Compressed blobs must be readable with compression disabled
+	compressed := createLargeTestRecord(t, "compressed-agent")
+
+	compressedRef, err := newLocalStore(t, dir, testCompressionConfig).Push(t.Context(), compressed)
+	require.NoError(t, err)
+
+	pulled, err = newLocalStore(t, dir, ociconfig.CompressionConfig{}).Pull(t.Context(), compressedRef)
+	require.NoError(t, err)
+	assert.Equal(t, compressed.GetCid(), pulled.GetCid())
+}
+
+func TestIntegrationCompressionZot(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), time.Minute)
+	defer cancel()
+
+	// Skips if zot is not available
+	setupIntegrationStore(t)
+
+	cfg := integrationConfig
+	cfg.Compression = testCompressionConfig
+
+	s, err := New(cfg)
+	require.NoError(t, err)
+
+	record := createLargeTestRecord(t, "integration-compressed-agent-"+time.Now().Format(time.RFC3339Nano))
+	recordBytes, err := record.Marshal()
+	require.NoError(t, err)
+
+	ref, err := s.Push(ctx, record)
+	require.NoError(t, err)
+
+	// Verify the blob stored in zot is smaller than the canonical bytes
+	manifest := getManifest(ctx, t, ref.GetCid())
+
+	layers, ok := manifest["layers"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, layers, 1)
+
+	layer, ok := layers[0].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, mediaTypeRecordZstd, layer["mediaType"])
+
+	size, ok := layer["size"].(float64)
+	require.True(t, ok)
+	assert.Less(t, int(size), len(recordBytes))
+
+	pulled, err := s.Pull(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), pulled.GetCid())
+}
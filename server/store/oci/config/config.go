@@ -3,10 +3,31 @@
 
 package config
 
+import (
+	"errors"
+	"fmt"
+)
+
 const (
 	DefaultAuthConfigInsecure = true
 	DefaultRegistryAddress    = "127.0.0.1:5000"
 	DefaultRepositoryName     = "dir"
+
+	DefaultCompressionEnabled      = false
+	DefaultCompressionMinSizeBytes = 256 * 1024 // 256KB
+	DefaultCompressionLevel        = 3
+
+	DefaultTagConcurrency = 5
+
+	MinCompressionLevel = 1
+	MaxCompressionLevel = 22
+
+	// NonSemverOrderPushTime re-points floating tags to the last pushed version.
+	NonSemverOrderPushTime = "push-time"
+	// NonSemverOrderLexicographic re-points floating tags to the lexically greatest version.
+	NonSemverOrderLexicographic = "lexicographic"
+
+	DefaultNonSemverOrder = NonSemverOrderPushTime
 )
 
 type Config struct {
@@ -26,6 +47,138 @@ type Config struct {
 
 	// Authentication configuration
 	AuthConfig `json:"auth_config,omitempty" mapstructure:"auth_config"`
+
+	// Record blob compression configuration
+	Compression CompressionConfig `json:"compression,omitempty" mapstructure:"compression"`
+
+	// Maximum number of tags created concurrently for a record manifest.
+	// Uses DefaultTagConcurrency if not set.
+	TagConcurrency int `json:"tag_concurrency,omitempty" mapstructure:"tag_concurrency"`
+
+	// Sharding of records across multiple repositories
+	Sharding ShardingConfig `json:"sharding,omitempty" mapstructure:"sharding"`
+
+	// Operational metadata keys to derive discovery tags from, e.g. "team" tags a record
+	// with the "team" metadata "platform" as "<name>:team-platform".
+	// The tags are updated when the metadata changes.
+	MetadataTagKeys []string `json:"metadata_tag_keys,omitempty" mapstructure:"metadata_tag_keys"`
+
+	// Management of the floating name tags pointing to the highest version of a name
+	TagStrategy TagStrategy `json:"tag_strategy,omitempty" mapstructure:"tag_strategy"`
+}
+
+// TagStrategy configures the floating name tags of records. The "<name>:latest" tag, and the
+// "<name>:<major>" and "<name>:<major>.<minor>" tags if enabled, are only re-pointed by a push
+// if the pushed version is greater than the version they point to, so that pushing an old
+// patch release does not re-point them.
+type TagStrategy struct {
+	// Order of versions that are not semantic versions, NonSemverOrderPushTime or NonSemverOrderLexicographic.
+	// Uses DefaultNonSemverOrder if not set.
+	NonSemverOrder string `json:"non_semver_order,omitempty" mapstructure:"non_semver_order"`
+
+	// Tags records with the floating "<name>:<major>" and "<name>:<major>.<minor>" tags of their semantic version.
+	// Versions like "2" or "2.1", without minor or patch versions, share their name tag with the floating tag.
+	EnableSemverFloatingTags bool `json:"enable_semver_floating_tags,omitempty" mapstructure:"enable_semver_floating_tags"`
+
+	// Lets pre-release versions capture floating tags, including "<name>:latest".
+	IncludePrereleases bool `json:"include_prereleases,omitempty" mapstructure:"include_prereleases"`
+}
+
+// GetNonSemverOrder returns the configured order of non-semantic versions, or the default if not set.
+func (c TagStrategy) GetNonSemverOrder() string {
+	if c.NonSemverOrder == "" {
+		return DefaultNonSemverOrder
+	}
+
+	return c.NonSemverOrder
+}
+
+// GetTagConcurrency returns the configured tag concurrency, or the default if not set.
+func (c Config) GetTagConcurrency() int {
+	if c.TagConcurrency <= 0 {
+		return DefaultTagConcurrency
+	}
+
+	return c.TagConcurrency
+}
+
+// Validate checks that a local directory or a registry is configured
+// and that numeric limits are within their ranges.
+func (c Config) Validate() error {
+	var errs error
+
+	if c.LocalDir == "" && c.RegistryAddress == "" {
+		errs = errors.Join(errs, errors.New("local dir or registry address is required"))
+	}
+
+	if c.LocalDir == "" && c.RepositoryName == "" {
+		errs = errors.Join(errs, errors.New("repository name is required"))
+	}
+
+	if c.TagConcurrency < 0 {
+		errs = errors.Join(errs, errors.New("tag concurrency must not be negative"))
+	}
+
+	switch c.TagStrategy.GetNonSemverOrder() {
+	case NonSemverOrderPushTime, NonSemverOrderLexicographic:
+	default:
+		errs = errors.Join(errs, fmt.Errorf("tag strategy non-semver order must be %q or %q", NonSemverOrderPushTime, NonSemverOrderLexicographic))
+	}
+
+	if c.Compression.Enabled {
+		if c.Compression.MinSizeBytes < 0 {
+			errs = errors.Join(errs, errors.New("compression min size must not be negative"))
+		}
+
+		// Level 0 selects the default level.
+		if c.Compression.Level != 0 && (c.Compression.Level < MinCompressionLevel || c.Compression.Level > MaxCompressionLevel) {
+			errs = errors.Join(errs, fmt.Errorf("compression level must be between %d and %d", MinCompressionLevel, MaxCompressionLevel))
+		}
+	}
+
+	if c.Sharding.Enabled() {
+		if _, err := ParseShardTemplate(c.Sharding.RepositoryTemplate); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid sharding repository template: %w", err))
+		}
+
+		if c.Sharding.FallbackRepository != "" && !IsValidRepository(c.Sharding.FallbackRepository) {
+			errs = errors.Join(errs, fmt.Errorf("invalid sharding fallback repository: %q", c.Sharding.FallbackRepository))
+		}
+	}
+
+	return errs
+}
+
+// ShardingConfig represents the configuration for sharding records across repositories.
+type ShardingConfig struct {
+	// Template of the repository records are pushed to, resolved from the record metadata,
+	// e.g. "agents/{team}" or "agents/{name-prefix}". Sharding is disabled if empty.
+	//
+	// Placeholders are replaced by the name prefix of the record, i.e. its name up to the last "/",
+	// for {name-prefix}, and by the record annotation with that key otherwise.
+	RepositoryTemplate string `json:"repository_template,omitempty" mapstructure:"repository_template"`
+
+	// Repository of records lacking the attributes required by the template, and of bundles.
+	// Defaults to the repository name for remote stores and to the local directory itself for local stores.
+	FallbackRepository string `json:"fallback_repository,omitempty" mapstructure:"fallback_repository"`
+}
+
+// Enabled reports whether records are sharded across repositories.
+func (c ShardingConfig) Enabled() bool {
+	return c.RepositoryTemplate != ""
+}
+
+// CompressionConfig represents the configuration for record blob compression.
+// CIDs are always computed over the uncompressed canonical bytes.
+type CompressionConfig struct {
+	// Enables zstd compression of record blobs.
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Minimum size of the canonical record bytes for compression to apply.
+	MinSizeBytes int `json:"min_size_bytes,omitempty" mapstructure:"min_size_bytes"`
+
+	// zstd compression level, from 1 (fastest) to 22 (best compression).
+	Level int `json:"level,omitempty" mapstructure:"level"`
 }
 
 // AuthConfig represents the configuration for authentication.
@@ -0,0 +1,151 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// NamePrefixPlaceholder is replaced by the name of the record up to its last "/".
+const NamePrefixPlaceholder = "name-prefix"
+
+var (
+	// repositoryRegexp matches repository names as defined by the OCI distribution spec.
+	repositoryRegexp = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|[-]+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|[-]+)[a-z0-9]+)*)*$`)
+
+	// invalidRepositoryChars matches the characters replaced when a value is used in a repository name.
+	invalidRepositoryChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+)
+
+// IsValidRepository reports whether the name is a valid repository name.
+func IsValidRepository(name string) bool {
+	return repositoryRegexp.MatchString(name)
+}
+
+// ShardTemplate is a parsed sharding repository template.
+type ShardTemplate struct {
+	// parts alternate between literal text, at even indexes, and placeholder keys, at odd indexes.
+	parts []string
+}
+
+// ParseShardTemplate parses a repository template such as "agents/{team}".
+// The template must contain at least one placeholder.
+func ParseShardTemplate(template string) (*ShardTemplate, error) {
+	var parts []string
+
+	rest := template
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			if strings.ContainsRune(rest, '}') {
+				return nil, fmt.Errorf("unexpected '}' in %q", template)
+			}
+
+			parts = append(parts, rest)
+
+			break
+		}
+
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated placeholder in %q", template)
+		}
+
+		literal, key := rest[:start], rest[start+1:start+end]
+		if strings.ContainsRune(literal, '}') || strings.ContainsRune(key, '{') {
+			return nil, fmt.Errorf("unexpected brace in %q", template)
+		}
+
+		if key == "" {
+			return nil, fmt.Errorf("empty placeholder in %q", template)
+		}
+
+		parts = append(parts, literal, key)
+		rest = rest[start+end+1:]
+	}
+
+	if len(parts) == 1 {
+		return nil, errors.New("template must contain a placeholder, e.g. {name-prefix}")
+	}
+
+	// Check the literal text with a placeholder value that is always valid
+	example := &ShardTemplate{parts: parts}
+	if repo, _ := example.resolve(func(string) string { return "x" }); !IsValidRepository(repo) {
+		return nil, fmt.Errorf("template %q does not produce valid repository names", template)
+	}
+
+	return example, nil
+}
+
+// Repository resolves the template for a record with the given name and annotations.
+// Values are lowercased and characters that are invalid in repository names are replaced.
+// It returns false if a placeholder has no value or the result is not a valid repository name.
+func (t *ShardTemplate) Repository(name string, annotations map[string]string) (string, bool) {
+	repo, ok := t.resolve(func(key string) string {
+		if key == NamePrefixPlaceholder {
+			if i := strings.LastIndexByte(name, '/'); i > 0 {
+				return name[:i]
+			}
+
+			return ""
+		}
+
+		return annotations[key]
+	})
+	if !ok || !IsValidRepository(repo) {
+		return "", false
+	}
+
+	return repo, true
+}
+
+// StaticPrefix returns the path of the template before its first placeholder, e.g. "agents/" for "agents/{team}".
+// All repositories resolved from the template start with it.
+func (t *ShardTemplate) StaticPrefix() string {
+	literal := t.parts[0]
+
+	return literal[:strings.LastIndexByte(literal, '/')+1]
+}
+
+func (t *ShardTemplate) resolve(value func(key string) string) (string, bool) {
+	var b strings.Builder
+
+	for i, part := range t.parts {
+		if i%2 == 0 {
+			b.WriteString(part)
+
+			continue
+		}
+
+		sanitized := sanitizeRepositoryValue(value(part))
+		if sanitized == "" {
+			return "", false
+		}
+
+		b.WriteString(sanitized)
+	}
+
+	return b.String(), true
+}
+
+// sanitizeRepositoryValue converts a value to repository path components,
+// lowercasing it and replacing invalid characters. Empty components are dropped.
+func sanitizeRepositoryValue(value string) string {
+	components := strings.Split(strings.ToLower(value), "/")
+
+	sanitized := make([]string, 0, len(components))
+	for _, component := range components {
+		component = invalidRepositoryChars.ReplaceAllString(component, "-")
+		component = strings.Trim(component, "._-")
+
+		if component != "" {
+			sanitized = append(sanitized, component)
+		}
+	}
+
+	return strings.Join(sanitized, "/")
+}
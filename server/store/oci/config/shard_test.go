@@ -0,0 +1,54 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseShardTemplate(t *testing.T) {
+	for _, template := range []string{"agents/{team}", "agents/{name-prefix}", "{team}-agents", "{org}/{team}"} {
+		_, err := ParseShardTemplate(template)
+		assert.NoError(t, err, template)
+	}
+
+	for _, template := range []string{"agents", "agents/{}", "agents/{team", "agents/team}", "Agents/{team}", "agents//{team}"} {
+		_, err := ParseShardTemplate(template)
+		assert.Error(t, err, template)
+	}
+}
+
+func TestShardTemplateRepository(t *testing.T) {
+	template, err := ParseShardTemplate("agents/{name-prefix}")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		expected string
+		ok       bool
+	}{
+		{name: "cisco.com/Team A/agent", expected: "agents/cisco.com/team-a", ok: true},
+		{name: "org/agent", expected: "agents/org", ok: true},
+		{name: "agent"},
+		{name: "/agent"},
+		{name: "!!!/agent"},
+	}
+
+	for _, tt := range tests {
+		repository, ok := template.Repository(tt.name, nil)
+		assert.Equal(t, tt.ok, ok, tt.name)
+		assert.Equal(t, tt.expected, repository, tt.name)
+	}
+
+	template, err = ParseShardTemplate("agents/{team}")
+	require.NoError(t, err)
+
+	repository, ok := template.Repository("agent", map[string]string{"team": "Platform_Eng"})
+	assert.True(t, ok)
+	assert.Equal(t, "agents/platform_eng", repository)
+	assert.Equal(t, "agents/", template.StaticPrefix())
+}
@@ -28,17 +28,32 @@ const (
 
 	// Capability Discovery (simple keys).
 	MetadataKeySkills       = "skills"
+	MetadataKeyDomains      = "domains"
 	MetadataKeyLocatorTypes = "locator-types"
 	MetadataKeyModuleNames  = "module-names"
 
+	// MetadataKeyLocatorVariants lists locator types qualified with their
+	// target architectures and runtimes, e.g. "helm_chart.arm64".
+	MetadataKeyLocatorVariants = "locator-variants"
+
 	// Security (simple keys).
 	MetadataKeySigned        = "signed"
 	MetadataKeySignatureAlgo = "signature-algorithm"
 	MetadataKeySignedAt      = "signed-at"
 
+	// Encryption at rest (simple keys), set for records stored as encrypted envelopes.
+	MetadataKeyEncryption      = "encryption"
+	MetadataKeyEncryptionKeyID = "encryption-key-id"
+
 	// Versioning (simple keys).
 	MetadataKeyPreviousCid = "previous-cid"
 
+	// Lifecycle status (simple keys), stored on lifecycle referrer manifests.
+	MetadataKeyLifecycleStatus    = "lifecycle-status"
+	MetadataKeyLifecycleSuccessor = "lifecycle-successor"
+	MetadataKeyLifecycleReason    = "lifecycle-reason"
+	MetadataKeyLifecycleUpdatedAt = "lifecycle-updated-at"
+
 	// Team-based (simple keys).
 	MetadataKeyTeam         = "team"
 	MetadataKeyOrganization = "organization"
@@ -47,9 +62,14 @@ const (
 	// Count metadata (simple keys).
 	MetadataKeyAuthorsCount      = "authors-count"
 	MetadataKeySkillsCount       = "skills-count"
+	MetadataKeyDomainsCount      = "domains-count"
 	MetadataKeyLocatorTypesCount = "locator-types-count"
 	MetadataKeyModuleCount       = "module-names-count"
 
+	// MetadataKeyLocatorVariantsCount is the number of locator variants of the record,
+	// which exceeds the number of listed variants if the list was capped.
+	MetadataKeyLocatorVariantsCount = "locator-variants-count"
+
 	// Derived from MetadataKey constants to ensure consistency.
 
 	// Core Identity (derived from MetadataKey constants).
@@ -66,20 +86,46 @@ const (
 
 	// Capability Discovery (derived from MetadataKey constants).
 	ManifestKeySkills       = manifestDirObjectKeyPrefix + "/" + MetadataKeySkills
+	ManifestKeyDomains      = manifestDirObjectKeyPrefix + "/" + MetadataKeyDomains
 	ManifestKeyLocatorTypes = manifestDirObjectKeyPrefix + "/" + MetadataKeyLocatorTypes
 	ManifestKeyModuleNames  = manifestDirObjectKeyPrefix + "/" + MetadataKeyModuleNames
 
+	ManifestKeyLocatorVariants      = manifestDirObjectKeyPrefix + "/" + MetadataKeyLocatorVariants
+	ManifestKeyLocatorVariantsCount = manifestDirObjectKeyPrefix + "/" + MetadataKeyLocatorVariantsCount
+
 	// Security & Integrity (mixed: some derived, some standalone).
 	ManifestKeySigned        = manifestDirObjectKeyPrefix + "/" + MetadataKeySigned
 	ManifestKeySignatureAlgo = manifestDirObjectKeyPrefix + "/" + MetadataKeySignatureAlgo
 	ManifestKeySignedAt      = manifestDirObjectKeyPrefix + "/" + MetadataKeySignedAt
 
+	// Encryption at rest (derived from MetadataKey constants).
+	ManifestKeyEncryption      = manifestDirObjectKeyPrefix + "/" + MetadataKeyEncryption
+	ManifestKeyEncryptionKeyID = manifestDirObjectKeyPrefix + "/" + MetadataKeyEncryptionKeyID
+
 	// Versioning & Linking (standalone - no simple key equivalents).
 	ManifestKeyPreviousCid = manifestDirObjectKeyPrefix + "/" + MetadataKeyPreviousCid
 
+	// Lifecycle status (derived from MetadataKey constants).
+	ManifestKeyLifecycleStatus    = manifestDirObjectKeyPrefix + "/" + MetadataKeyLifecycleStatus
+	ManifestKeyLifecycleSuccessor = manifestDirObjectKeyPrefix + "/" + MetadataKeyLifecycleSuccessor
+	ManifestKeyLifecycleReason    = manifestDirObjectKeyPrefix + "/" + MetadataKeyLifecycleReason
+	ManifestKeyLifecycleUpdatedAt = manifestDirObjectKeyPrefix + "/" + MetadataKeyLifecycleUpdatedAt
+
+	// Operational metadata, stored in metadata manifests referring to the record manifest.
+	ManifestKeyMetadataPrefix    = manifestDirObjectKeyPrefix + "/metadata."
+	ManifestKeyMetadataUpdatedAt = manifestDirObjectKeyPrefix + "/metadata-updated-at"
+
 	// Custom annotations prefix.
 	ManifestKeyCustomPrefix = manifestDirObjectKeyPrefix + "/custom."
 
+	// Custom annotation limits. Longer keys and values are truncated.
+	maxCustomAnnotationKeyLength   = 128
+	maxCustomAnnotationValueLength = 4096
+
+	// maxLocatorVariants caps the locator variants listed in manifest annotations.
+	// Records with more variants remain fully searchable via the search index.
+	maxLocatorVariants = 16
+
 	// Fallback values for error recovery scenarios.
 	// Used when parsing corrupted storage, legacy records, or external modifications.
 	FallbackSchemaVersion = "v0.3.1"
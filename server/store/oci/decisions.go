@@ -0,0 +1,108 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+)
+
+// tagDecisionsKey is the context key of the tag decisions of a push.
+type tagDecisionsKey struct{}
+
+// tagDecisions collects the decisions for the candidate tags of a push, see corev1.TagDecision,
+// and the outcomes of creating them, so that a single view shows which tags were intended and created.
+type tagDecisions struct {
+	mu        sync.Mutex
+	decisions []corev1.TagDecision
+}
+
+// withTagDecisions returns a context recording the outcomes of created tags into the decisions.
+func withTagDecisions(ctx context.Context, decisions *tagDecisions) context.Context {
+	return context.WithValue(ctx, tagDecisionsKey{}, decisions)
+}
+
+// recordTagOutcome records the outcome of creating a tag into the tag decisions of the context, if any.
+func recordTagOutcome(ctx context.Context, tag string, outcome corev1.TagOutcome, err error) {
+	decisions, ok := ctx.Value(tagDecisionsKey{}).(*tagDecisions)
+	if !ok {
+		return
+	}
+
+	decisions.mu.Lock()
+	defer decisions.mu.Unlock()
+
+	for i := range decisions.decisions {
+		decision := &decisions.decisions[i]
+		if decision.Tag != tag || !decision.Creates() {
+			continue
+		}
+
+		decision.Outcome = outcome
+		if err != nil {
+			decision.Error = err.Error()
+		}
+	}
+}
+
+// list returns a copy of the decisions and their outcomes.
+func (d *tagDecisions) list() []corev1.TagDecision {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return slices.Clone(d.decisions)
+}
+
+// explainTags returns the decisions for the candidate tags of a push: the discovery tags of the record
+// and the floating tags of its name, see splitTags.
+func (s *store) explainTags(plan *pushPlan) []corev1.TagDecision {
+	_, floating := s.splitTags(plan.annotations, plan.tags)
+
+	decisions := slices.Clone(plan.decisions)
+
+	for i := range decisions {
+		decision := &decisions[i]
+
+		// Pre-release versions do not capture the latest tag, unless configured
+		if decision.Source == corev1.TagSourceLatest && decision.Creates() && !slices.Contains(floating, decision.Tag) {
+			decision.Action = corev1.TagActionSkipped
+			decision.Original = plan.annotations[ManifestKeyName] + ":" + corev1.LatestVersion
+		}
+	}
+
+	tags := corev1.CreatedTags(decisions)
+
+	for _, tag := range floating {
+		if !slices.Contains(tags, tag) {
+			decisions = append(decisions, corev1.TagDecision{Tag: tag, Source: corev1.TagSourceFloating, Action: corev1.TagActionCreated})
+		}
+	}
+
+	return decisions
+}
+
+// tagDecisionsToProto converts tag decisions to their API form.
+func tagDecisionsToProto(decisions []corev1.TagDecision) []*storev1.TagDecision {
+	result := make([]*storev1.TagDecision, 0, len(decisions))
+
+	for _, decision := range decisions {
+		tagDecision := &storev1.TagDecision{
+			Tag:    decision.Tag,
+			Source: string(decision.Source),
+			Action: string(decision.Action),
+		}
+
+		if decision.Original != "" {
+			tagDecision.Original = &decision.Original
+		}
+
+		result = append(result, tagDecision)
+	}
+
+	return result
+}
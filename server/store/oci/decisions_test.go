@@ -0,0 +1,94 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pushWithDecisions pushes the record manifest like push and returns the tag decisions with their outcomes.
+func pushWithDecisions(t *testing.T, s *store, record *corev1.Record) []corev1.TagDecision {
+	t.Helper()
+
+	plan, err := preparePush(record)
+	require.NoError(t, err)
+
+	decisions := &tagDecisions{decisions: s.explainTags(plan)}
+	ctx := withTagDecisions(t.Context(), decisions)
+
+	layerDesc, err := s.pushRecordBlob(ctx, plan.recordBytes)
+	require.NoError(t, err)
+	require.NoError(t, s.pushManifestWithTags(ctx, plan.cid, layerDesc, plan.annotations, plan.tags))
+
+	return decisions.list()
+}
+
+func TestTagDecisions(t *testing.T) {
+	s, _, _ := newTaggingStore(t, 2, "decided-agent_latest") //nolint:mnd
+	s.config.TagStrategy.EnableSemverFloatingTags = true
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "Decided Agent",
+		Version:       "v1.2.0",
+		SchemaVersion: "0.7.0",
+	})
+
+	decisions := pushWithDecisions(t, s, record)
+
+	// Intent and registry outcome of each tag, including the failed latest tag
+	assert.Equal(t, []corev1.TagDecision{
+		{Tag: record.GetCid(), Source: corev1.TagSourceCID, Action: corev1.TagActionCreated, Outcome: corev1.TagOutcomeTagged},
+		{
+			Tag: "decided-agent_v1.2.0", Source: corev1.TagSourceVersion, Action: corev1.TagActionNormalized,
+			Original: "Decided Agent:v1.2.0", Outcome: corev1.TagOutcomeTagged,
+		},
+		{
+			Tag: "decided-agent_latest", Source: corev1.TagSourceLatest, Action: corev1.TagActionNormalized,
+			Original: "Decided Agent:latest", Outcome: corev1.TagOutcomeFailed,
+			Error: "rpc error: code = Internal desc = failed to create tag decided-agent_latest: tag rejected",
+		},
+		{Tag: "decided-agent_1", Source: corev1.TagSourceFloating, Action: corev1.TagActionCreated, Outcome: corev1.TagOutcomeTagged},
+		{Tag: "decided-agent_1.2", Source: corev1.TagSourceFloating, Action: corev1.TagActionCreated, Outcome: corev1.TagOutcomeTagged},
+	}, decisions)
+
+	// Floating tags are kept on the greater version
+	older := corev1.New(&typesv1alpha1.Record{
+		Name:          "decided-agent",
+		Version:       "v1.1.0",
+		SchemaVersion: "0.7.0",
+	})
+
+	decisions = pushWithDecisions(t, s, older)
+	require.Len(t, decisions, 5) //nolint:mnd
+	assert.Equal(t, corev1.TagActionCreated, decisions[1].Action)
+	assert.Equal(t, corev1.TagOutcomeKept, decisions[3].Outcome)
+	assert.Equal(t, corev1.TagOutcomeTagged, decisions[4].Outcome)
+}
+
+func TestPreviewPushTagDecisions(t *testing.T) {
+	s := newLocalStore(t, t.TempDir(), testCompressionConfig)
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "preview-agent",
+		Version:       "v2.0.0-rc.1",
+		SchemaVersion: "0.7.0",
+	})
+
+	preview, err := s.PreviewPush(testCtx, record)
+	require.NoError(t, err)
+
+	// Pre-releases do not capture the latest tag
+	assert.Equal(t, []string{record.GetCid(), "preview-agent_v2.0.0-rc.1"}, preview.GetTags())
+
+	decisions := preview.GetTagDecisions()
+	require.Len(t, decisions, 3) //nolint:mnd
+	assert.Equal(t, "latest", decisions[2].GetSource())
+	assert.Equal(t, "skipped", decisions[2].GetAction())
+	assert.Equal(t, "preview-agent:latest", decisions[2].GetOriginal())
+}
@@ -0,0 +1,87 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2"
+)
+
+// mediaTypeRecordEnvelope is the media type of the blobs of encrypted records.
+const mediaTypeRecordEnvelope = "application/vnd.agntcy.dir.record.envelope.v1+protobuf"
+
+// prepareEnvelopePush derives the push plan of an encrypted record.
+// The content cannot be read, so the record is stored under the CID of its plaintext,
+// with the annotations and tags derived from the metadata in its envelope.
+func prepareEnvelopePush(record *corev1.Record) (*pushPlan, error) {
+	if err := record.GetEnvelope().Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid record envelope: %v", err)
+	}
+
+	envelopeBytes, err := record.Marshal()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal record envelope: %v", err)
+	}
+
+	recordCID := record.GetCid()
+
+	manifestAnnotations := extractManifestAnnotations(record)
+	manifestAnnotations[ManifestKeyCid] = recordCID
+
+	decisions := record.ExplainDiscoveryTags()
+
+	return &pushPlan{
+		cid:         recordCID,
+		recordBytes: envelopeBytes,
+		annotations: manifestAnnotations,
+		tags:        corev1.CreatedTags(decisions),
+		decisions:   decisions,
+		encrypted:   true,
+	}, nil
+}
+
+// pushEnvelopeBlob pushes the envelope of an encrypted record as a blob.
+// Envelopes are never compressed, as ciphertext does not compress.
+func (s *store) pushEnvelopeBlob(ctx context.Context, envelopeBytes []byte) (ocispec.Descriptor, error) {
+	ctx, span := startSpan(ctx, spanPushBlob, attrBlobSize.Int(len(envelopeBytes)))
+
+	layerDesc, err := oras.PushBytes(ctx, s.repo, mediaTypeRecordEnvelope, envelopeBytes)
+	if err != nil {
+		err = status.Errorf(codes.Internal, "failed to push record envelope: %v", err)
+	} else {
+		s.metrics.transferred(directionUploaded, len(envelopeBytes))
+	}
+
+	endSpan(span, err)
+
+	return layerDesc, err
+}
+
+// parseEnvelopeContent reads back the envelope of an encrypted record for fsck.
+func parseEnvelopeContent(blob []byte) (*storedContent, error) {
+	record, err := corev1.UnmarshalEnvelope(blob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stored envelope: %w", err)
+	}
+
+	content := &storedContent{cid: record.GetCid()}
+
+	if record.GetEnvelope().Validate() == nil {
+		content.tags = record.DiscoveryTags()
+		content.name = record.GetEnvelope().GetMetadata().GetName()
+		content.restore = func(ctx context.Context, s *store) error {
+			_, err := s.push(ctx, record)
+
+			return err
+		}
+	}
+
+	return content, nil
+}
@@ -0,0 +1,143 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/agntcy/dir/utils/logging"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+)
+
+// splitTags splits the discovery tags of a record manifest into the tags of the record itself,
+// i.e. its CID and name tags, and the floating tags pointing to the highest version of its name:
+// "<name>:latest" and, if enabled by the tag strategy, "<name>:<major>" and "<name>:<major>.<minor>".
+//
+// Pre-release versions get no floating tags unless the tag strategy includes pre-releases.
+// Tags of manifests without a name, e.g. of encrypted records, are returned as they are.
+func (s *store) splitTags(annotations map[string]string, tags []string) ([]string, []string) {
+	name, version := annotations[ManifestKeyName], annotations[ManifestKeyVersion]
+	if name == "" {
+		return tags, nil
+	}
+
+	latest := corev1.NameTag(name, corev1.LatestVersion)
+	if !slices.Contains(tags, latest) {
+		return tags, nil
+	}
+
+	fixed := slices.DeleteFunc(slices.Clone(tags), func(tag string) bool { return tag == latest })
+
+	strategy := s.config.TagStrategy
+	if corev1.IsPrerelease(version) && !strategy.IncludePrereleases {
+		return fixed, nil
+	}
+
+	floating := []string{latest}
+
+	if major, minor, ok := corev1.MajorMinor(version); ok && strategy.EnableSemverFloatingTags {
+		for _, tag := range []string{corev1.NameTag(name, major), corev1.NameTag(name, minor)} {
+			// The version itself may be a major or major.minor version, e.g. "2.1"
+			if !slices.Contains(fixed, tag) && !slices.Contains(floating, tag) {
+				floating = append(floating, tag)
+			}
+		}
+	}
+
+	return fixed, floating
+}
+
+// tagFloating re-points each floating tag to the record manifest if the tag does not exist yet
+// or the record version is greater than the version of the record the tag points to.
+//
+// Like name tags, failing to re-point floating tags is logged without failing the push.
+func (s *store) tagFloating(ctx context.Context, cid string, manifestDesc ocispec.Descriptor, version string, tags []string) {
+	if len(tags) == 0 {
+		return
+	}
+
+	s.tagging.Start()
+	defer s.tagging.Done()
+
+	s.floatingMu.Lock()
+	defer s.floatingMu.Unlock()
+
+	log := logging.WithContext(ctx, logger)
+
+	// Like tagManifest, floating tags being re-pointed complete even if the request is cancelled
+	ctx = context.WithoutCancel(ctx)
+
+	for _, tag := range tags {
+		current, repoint, err := s.shouldRepoint(ctx, tag, manifestDesc, version)
+		if err != nil {
+			log.Warn("Failed to resolve floating tag", "cid", cid, "tag", tag, "error", err)
+			recordTagOutcome(ctx, tag, corev1.TagOutcomeFailed, err)
+
+			continue
+		}
+
+		if !repoint {
+			log.Debug("Keeping floating tag", "cid", cid, "tag", tag, "version", version, "current", current)
+
+			if current == version {
+				recordTagOutcome(ctx, tag, corev1.TagOutcomeUnchanged, nil)
+			} else {
+				recordTagOutcome(ctx, tag, corev1.TagOutcomeKept, nil)
+			}
+
+			continue
+		}
+
+		if err := s.tag(ctx, cid, manifestDesc, tag); err != nil {
+			log.Warn("Failed to re-point floating tag", "cid", cid, "tag", tag, "error", err)
+		}
+	}
+}
+
+// shouldRepoint reports whether a floating tag should be re-pointed to the manifest of a record
+// with the given version, returning the version the tag currently points to.
+func (s *store) shouldRepoint(ctx context.Context, tag string, manifestDesc ocispec.Descriptor, version string) (string, bool, error) {
+	currentDesc, err := s.repo.Resolve(ctx, tag)
+	if errors.Is(err, errdef.ErrNotFound) {
+		return "", true, nil
+	}
+
+	if err != nil {
+		return "", false, err //nolint:wrapcheck
+	}
+
+	if currentDesc.Digest == manifestDesc.Digest {
+		return version, false, nil
+	}
+
+	manifest, err := s.fetchAndParseManifestFromDescriptor(ctx, currentDesc)
+	if err != nil {
+		return "", false, err
+	}
+
+	current := manifest.Annotations[ManifestKeyVersion]
+
+	return current, isNewerVersion(s.config.TagStrategy, version, current), nil
+}
+
+// isNewerVersion reports whether version takes a floating tag from the current version. Semantic versions
+// are compared by precedence, other versions by the non-semver order of the tag strategy.
+func isNewerVersion(strategy ociconfig.TagStrategy, version, current string) bool {
+	if corev1.IsSemver(version) && corev1.IsSemver(current) {
+		return corev1.CompareVersions(version, current) > 0
+	}
+
+	if strategy.GetNonSemverOrder() == ociconfig.NonSemverOrderLexicographic {
+		return strings.Compare(version, current) > 0
+	}
+
+	// The last push wins
+	return true
+}
@@ -0,0 +1,150 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/errdef"
+)
+
+func newTagStrategyStore(t *testing.T, strategy ociconfig.TagStrategy) *store {
+	t.Helper()
+
+	s, err := New(ociconfig.Config{LocalDir: t.TempDir(), TagStrategy: strategy})
+	require.NoError(t, err)
+
+	localStore, ok := s.(*store)
+	require.True(t, ok)
+
+	return localStore
+}
+
+// pushVersions pushes a record of the given name for each version, in order, and returns their CIDs by version.
+func pushVersions(t *testing.T, s *store, name string, versions ...string) map[string]string {
+	t.Helper()
+
+	cids := make(map[string]string, len(versions))
+
+	for _, version := range versions {
+		ref, err := s.Push(t.Context(), corev1.New(&typesv1alpha1.Record{
+			Name:          name,
+			Version:       version,
+			SchemaVersion: "0.7.0",
+		}))
+		require.NoError(t, err)
+
+		cids[version] = ref.GetCid()
+	}
+
+	return cids
+}
+
+// assertTagTargets asserts the record each tag points to, by version. An empty version asserts that the tag does not exist.
+func assertTagTargets(t *testing.T, s *store, name string, cids map[string]string, targets map[string]string) {
+	t.Helper()
+
+	for tag, version := range targets {
+		desc, err := s.repo.Resolve(t.Context(), corev1.NameTag(name, tag))
+		if version == "" {
+			require.ErrorIs(t, err, errdef.ErrNotFound, "tag %s should not exist", tag)
+
+			continue
+		}
+
+		require.NoError(t, err, "tag %s should exist", tag)
+
+		manifest, err := s.fetchAndParseManifestFromDescriptor(t.Context(), desc)
+		require.NoError(t, err)
+		assert.Equal(t, cids[version], manifest.Annotations[ManifestKeyCid], "tag %s should point to %s", tag, version)
+	}
+}
+
+func TestFloatingTags(t *testing.T) {
+	t.Run("Latest only moves to higher versions", func(t *testing.T) {
+		s := newTagStrategyStore(t, ociconfig.TagStrategy{})
+		cids := pushVersions(t, s, "latest-agent", "v1.0.0", "v3.1.0", "v2.0.1", "v3.0.9")
+
+		assertTagTargets(t, s, "latest-agent", cids, map[string]string{
+			"latest": "v3.1.0",
+			"v2.0.1": "v2.0.1",
+			"3":      "",
+			"3.1":    "",
+		})
+	})
+
+	t.Run("Major and minor tags", func(t *testing.T) {
+		s := newTagStrategyStore(t, ociconfig.TagStrategy{EnableSemverFloatingTags: true})
+		cids := pushVersions(t, s, "floating-agent", "v2.1.0", "v1.2.3", "v2.0.5", "v1.2.10", "v1.10.0", "v2.1.1", "v1.2.4")
+
+		assertTagTargets(t, s, "floating-agent", cids, map[string]string{
+			"latest": "v2.1.1",
+			"2":      "v2.1.1",
+			"2.1":    "v2.1.1",
+			"2.0":    "v2.0.5",
+			"1":      "v1.10.0",
+			"1.10":   "v1.10.0",
+			"1.2":    "v1.2.10",
+		})
+	})
+
+	t.Run("Pre-releases do not capture floating tags", func(t *testing.T) {
+		s := newTagStrategyStore(t, ociconfig.TagStrategy{EnableSemverFloatingTags: true})
+		cids := pushVersions(t, s, "prerelease-agent", "v3.0.0-rc.1", "v2.0.0", "v3.0.0-rc.2")
+
+		assertTagTargets(t, s, "prerelease-agent", cids, map[string]string{
+			"latest":      "v2.0.0",
+			"2":           "v2.0.0",
+			"3":           "",
+			"3.0":         "",
+			"v3.0.0-rc.2": "v3.0.0-rc.2",
+		})
+	})
+
+	t.Run("Pre-releases included", func(t *testing.T) {
+		s := newTagStrategyStore(t, ociconfig.TagStrategy{EnableSemverFloatingTags: true, IncludePrereleases: true})
+		cids := pushVersions(t, s, "prerelease-agent", "v3.0.0-rc.2", "v2.0.0", "v3.0.0-rc.1")
+
+		assertTagTargets(t, s, "prerelease-agent", cids, map[string]string{
+			"latest": "v3.0.0-rc.2",
+			"2":      "v2.0.0",
+			"3":      "v3.0.0-rc.2",
+			"3.0":    "v3.0.0-rc.2",
+		})
+
+		// A release takes precedence over its pre-releases
+		cids = pushVersions(t, s, "prerelease-agent", "v3.0.0")
+
+		assertTagTargets(t, s, "prerelease-agent", cids, map[string]string{
+			"latest": "v3.0.0",
+			"3":      "v3.0.0",
+		})
+	})
+
+	t.Run("Non-semver versions by push time", func(t *testing.T) {
+		s := newTagStrategyStore(t, ociconfig.TagStrategy{})
+		cids := pushVersions(t, s, "nightly-agent", "nightly-b", "nightly-a")
+
+		assertTagTargets(t, s, "nightly-agent", cids, map[string]string{"latest": "nightly-a"})
+	})
+
+	t.Run("Non-semver versions lexicographically", func(t *testing.T) {
+		s := newTagStrategyStore(t, ociconfig.TagStrategy{NonSemverOrder: ociconfig.NonSemverOrderLexicographic})
+		cids := pushVersions(t, s, "nightly-agent", "nightly-b", "nightly-a")
+
+		assertTagTargets(t, s, "nightly-agent", cids, map[string]string{"latest": "nightly-b"})
+	})
+
+	t.Run("Re-push does not move floating tags", func(t *testing.T) {
+		s := newTagStrategyStore(t, ociconfig.TagStrategy{})
+		cids := pushVersions(t, s, "repush-agent", "nightly-a", "nightly-b", "nightly-a")
+
+		assertTagTargets(t, s, "repush-agent", cids, map[string]string{"latest": "nightly-b"})
+	})
+}
@@ -0,0 +1,448 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/api/names"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/utils/logging"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+)
+
+// fsckProgressInterval is the number of checked tags between progress reports.
+const fsckProgressInterval = 100
+
+// Fsck checks the consistency of the store and reports progress, detected issues
+// and a final summary to fn, repairing the issues if requested.
+//
+// Every tag must resolve to a manifest whose record blob exists, the CID recomputed
+// from the stored canonical bytes must match the CID expected by the manifest, CID tags
+// must point to the record with that CID, and the discovery tags derived from the record
+// must exist. Records whose names normalize to the same name must have the same name,
+// which is not repaired. After repairing a local store, blobs that are no longer referenced are removed.
+//
+// Records pushed while the check runs may be reported as inconsistent.
+func (s *store) Fsck(ctx context.Context, repair bool, fn func(*storev1.FsckResponse) error) error {
+	tags, err := s.listTags(ctx)
+	if err != nil {
+		return err
+	}
+
+	logging.WithContext(ctx, logger).Info("Checking store consistency", "tags", len(tags), "repair", repair)
+
+	check := &fsck{
+		store:       s,
+		repair:      repair,
+		fn:          fn,
+		checked:     make(map[string]*storedContent),
+		recordNames: make(map[string]*storedContent),
+		summary:     &storev1.FsckSummary{},
+	}
+
+	for i, tag := range tags {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		if err := check.checkTag(ctx, tag); err != nil {
+			return err
+		}
+
+		check.summary.CheckedTags++
+
+		if checked := i + 1; checked%fsckProgressInterval == 0 || checked == len(tags) {
+			err := fn(&storev1.FsckResponse{Response: &storev1.FsckResponse_Progress{Progress: &storev1.FsckProgress{
+				CheckedTags: uint64(checked),
+				TotalTags:   uint64(len(tags)),
+			}}})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if repair {
+		check.summary.Compacted = s.compact(ctx)
+	}
+
+	logging.WithContext(ctx, logger).Info("Store consistency check completed",
+		"tags", check.summary.GetCheckedTags(),
+		"records", check.summary.GetCheckedRecords(),
+		"issues", check.summary.GetIssues(),
+		"repaired", check.summary.GetRepaired())
+
+	return fn(&storev1.FsckResponse{Response: &storev1.FsckResponse_Summary{Summary: check.summary}})
+}
+
+// fsck is the state of a single consistency check.
+type fsck struct {
+	store  *store
+	repair bool
+	fn     func(*storev1.FsckResponse) error
+	// checked holds the content of the checked manifests, nil if the content is not valid.
+	checked map[string]*storedContent
+	// recordNames holds the first checked record for every canonical record name.
+	recordNames map[string]*storedContent
+	summary     *storev1.FsckSummary
+}
+
+// checkTag checks that the tag resolves to a readable manifest, checks the manifest
+// the first time it is seen, and checks that a CID tag points to the record with that CID.
+func (c *fsck) checkTag(ctx context.Context, tag string) error {
+	desc, err := c.store.repo.Resolve(ctx, tag)
+	if errors.Is(err, errdef.ErrNotFound) {
+		// Removed by the repair of an earlier tag
+		return nil
+	}
+
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to resolve tag %s: %v", tag, err)
+	}
+
+	manifest, err := c.readManifest(ctx, desc)
+	if err != nil {
+		return c.report(&storev1.FsckIssue{
+			Type:    storev1.FsckIssueType_FSCK_ISSUE_TYPE_DANGLING_TAG,
+			Tag:     tag,
+			Message: err.Error(),
+		}, func() error { return c.store.deleteManifest(ctx, desc) })
+	}
+
+	content, checked := c.checked[desc.Digest.String()]
+	if !checked {
+		if content, err = c.checkManifest(ctx, tag, desc, manifest); err != nil {
+			return err
+		}
+
+		c.checked[desc.Digest.String()] = content
+	}
+
+	if content == nil || !corev1.IsValidCID(tag) || content.cid == tag {
+		return nil
+	}
+
+	return c.report(&storev1.FsckIssue{
+		Type:    storev1.FsckIssueType_FSCK_ISSUE_TYPE_CID_MISMATCH,
+		Tag:     tag,
+		Cid:     tag,
+		Message: fmt.Sprintf("tag points to record %s", content.cid),
+	}, func() error { return c.store.untag(ctx, tag) })
+}
+
+// checkManifest checks that the record blob of the manifest exists, that its content
+// matches the CID expected by the manifest and that the discovery tags of the record exist.
+// It returns the content of the manifest, or nil if the content is not valid.
+func (c *fsck) checkManifest(ctx context.Context, tag string, desc ocispec.Descriptor, manifest *ocispec.Manifest) (*storedContent, error) {
+	c.summary.CheckedRecords++
+
+	expectedCID := manifest.Annotations[ManifestKeyCid]
+
+	blob, err := c.readBlob(ctx, manifest)
+	if err != nil {
+		return nil, c.report(&storev1.FsckIssue{
+			Type:    storev1.FsckIssueType_FSCK_ISSUE_TYPE_MISSING_BLOB,
+			Tag:     tag,
+			Cid:     expectedCID,
+			Message: err.Error(),
+		}, func() error { return c.store.deleteManifest(ctx, desc) })
+	}
+
+	content, err := parseContent(manifest, blob)
+	if err != nil {
+		return nil, c.report(&storev1.FsckIssue{
+			Type:    storev1.FsckIssueType_FSCK_ISSUE_TYPE_CID_MISMATCH,
+			Tag:     tag,
+			Cid:     expectedCID,
+			Message: err.Error(),
+		}, func() error { return c.store.deleteManifest(ctx, desc) })
+	}
+
+	if expectedCID != "" && content.cid != expectedCID {
+		return nil, c.report(&storev1.FsckIssue{
+			Type:    storev1.FsckIssueType_FSCK_ISSUE_TYPE_CID_MISMATCH,
+			Tag:     tag,
+			Cid:     expectedCID,
+			Message: fmt.Sprintf("manifest %s expects CID %s, stored content has CID %s", desc.Digest, expectedCID, content.cid),
+		}, func() error {
+			if err := c.store.deleteManifest(ctx, desc); err != nil || content.restore == nil {
+				return err
+			}
+
+			return content.restore(ctx, c.store)
+		})
+	}
+
+	for _, discoveryTag := range content.tags {
+		resolved, err := c.store.repo.Resolve(ctx, discoveryTag)
+		if err != nil && !errors.Is(err, errdef.ErrNotFound) {
+			return nil, status.Errorf(codes.Internal, "failed to resolve tag %s: %v", discoveryTag, err)
+		}
+
+		// Name tags may point to other versions, but the CID tag must point to this manifest
+		if err == nil && (discoveryTag != content.cid || resolved.Digest == desc.Digest) {
+			continue
+		}
+
+		err = c.report(&storev1.FsckIssue{
+			Type:    storev1.FsckIssueType_FSCK_ISSUE_TYPE_MISSING_TAG,
+			Tag:     discoveryTag,
+			Cid:     content.cid,
+			Message: fmt.Sprintf("discovery tag %s of record %s does not exist", discoveryTag, content.cid),
+		}, func() error { return c.store.refreshTags(ctx, content.cid, desc, []string{discoveryTag}) })
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.checkName(content); err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// checkName checks that the name of the record does not collide with the name of another record,
+// i.e. that records whose names normalize to the same name have the same name.
+func (c *fsck) checkName(content *storedContent) error {
+	if content.name == "" {
+		return nil
+	}
+
+	canonical := names.CanonicalName(content.name)
+
+	first, ok := c.recordNames[canonical]
+	if !ok {
+		c.recordNames[canonical] = content
+
+		return nil
+	}
+
+	if first.name == content.name {
+		return nil
+	}
+
+	return c.report(&storev1.FsckIssue{
+		Type: storev1.FsckIssueType_FSCK_ISSUE_TYPE_NAME_COLLISION,
+		Tag:  corev1.NameTag(content.name, corev1.LatestVersion),
+		Cid:  content.cid,
+		Message: fmt.Sprintf("name %q of record %s collides with name %q of record %s, both normalize to %q",
+			content.name, content.cid, first.name, first.cid, canonical),
+	}, func() error {
+		return errors.New("colliding records must be deleted or pushed again under another name")
+	})
+}
+
+// report repairs the issue if requested and reports it.
+func (c *fsck) report(issue *storev1.FsckIssue, repair func() error) error {
+	c.summary.Issues++
+
+	if c.repair {
+		if err := repair(); err != nil {
+			issue.RepairError = err.Error()
+		} else {
+			issue.Repaired = true
+			c.summary.Repaired++
+		}
+	}
+
+	logger.Warn("Store inconsistency detected",
+		"type", issue.GetType().String(),
+		"tag", issue.GetTag(),
+		"cid", issue.GetCid(),
+		"message", issue.GetMessage(),
+		"repaired", issue.GetRepaired(),
+		"repair_error", issue.GetRepairError())
+
+	return c.fn(&storev1.FsckResponse{Response: &storev1.FsckResponse_Issue{Issue: issue}})
+}
+
+// readManifest fetches and parses the manifest, failing if it does not exist.
+func (c *fsck) readManifest(ctx context.Context, desc ocispec.Descriptor) (*ocispec.Manifest, error) {
+	exists, err := c.store.repo.Exists(ctx, desc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check manifest %s: %w", desc.Digest, err)
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("manifest %s does not exist", desc.Digest)
+	}
+
+	return c.store.fetchAndParseManifestFromDescriptor(ctx, desc)
+}
+
+// readBlob returns the raw content of the first manifest layer, failing if it does not exist.
+func (c *fsck) readBlob(ctx context.Context, manifest *ocispec.Manifest) ([]byte, error) {
+	if len(manifest.Layers) == 0 {
+		return nil, errors.New("manifest has no layers")
+	}
+
+	layer := manifest.Layers[0]
+
+	exists, err := c.store.repo.Exists(ctx, layer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check blob %s: %w", layer.Digest, err)
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("blob %s does not exist", layer.Digest)
+	}
+
+	return c.store.fetchLayer(ctx, layer)
+}
+
+// storedContent is a record or bundle read back from the store.
+type storedContent struct {
+	// cid is the CID recomputed from the stored canonical bytes.
+	cid string
+	// name is the name of the record, empty for bundles and unnamed records.
+	name string
+	// tags are the discovery tags derived from the content.
+	tags []string
+	// restore pushes the content again under its actual CID,
+	// or is nil if the content is not a valid record or bundle.
+	restore func(ctx context.Context, s *store) error
+}
+
+// parseContent recomputes the CID of the content stored in the blob, and parses
+// it as a record or bundle. Content that cannot be parsed has neither tags nor restore.
+// The content of encrypted records cannot be read, their CID is the plaintext CID recorded in their envelope.
+func parseContent(manifest *ocispec.Manifest, blob []byte) (*storedContent, error) {
+	if manifest.Layers[0].MediaType == mediaTypeRecordEnvelope {
+		return parseEnvelopeContent(blob)
+	}
+
+	data := blob
+
+	if isCompressedLayer(manifest.Layers[0]) {
+		var err error
+		if data, err = decompressRecord(manifest.Layers[0], blob); err != nil {
+			return nil, fmt.Errorf("failed to decompress stored content: %w", err)
+		}
+	}
+
+	// The CID is recomputed with the hash function of the CID the content is stored under
+	opts, err := corev1.CIDOptionsOf(manifest.Annotations[ManifestKeyCid])
+	if err != nil {
+		opts = corev1.DefaultCIDOptions
+	}
+
+	cid, err := opts.CID(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate CID of stored content: %w", err)
+	}
+
+	content := &storedContent{cid: cid}
+
+	if manifest.Annotations[manifestDirObjectTypeKey] == objectTypeBundle {
+		if bundle, err := corev1.UnmarshalBundle(data); err == nil {
+			content.tags = []string{cid}
+			content.restore = func(ctx context.Context, s *store) error {
+				_, err := s.PushBundle(ctx, bundle)
+
+				return err
+			}
+		}
+
+		return content, nil
+	}
+
+	if record, err := corev1.UnmarshalRecord(data); err == nil {
+		record.SetCIDOptions(opts)
+
+		content.tags = record.DiscoveryTags()
+		content.name = record.GetData().GetFields()["name"].GetStringValue()
+		content.restore = func(ctx context.Context, s *store) error {
+			_, err := s.push(ctx, record)
+
+			return err
+		}
+	}
+
+	return content, nil
+}
+
+// listTags returns all tags of the repository.
+func (s *store) listTags(ctx context.Context) ([]string, error) {
+	lister, ok := s.repo.(registry.TagLister)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "listing tags is not supported by the repository")
+	}
+
+	var tags []string
+
+	err := lister.Tags(ctx, "", func(page []string) error {
+		tags = append(tags, page...)
+
+		return nil
+	})
+	if err != nil && !isRepositoryNotFound(err) {
+		return nil, status.Errorf(codes.Internal, "failed to list tags: %v", err)
+	}
+
+	return tags, nil
+}
+
+// deleteManifest deletes the manifest and its tags. Blobs that are no longer referenced
+// are removed by the compaction of local stores or the garbage collection of remote registries.
+func (s *store) deleteManifest(ctx context.Context, desc ocispec.Descriptor) error {
+	deleter, ok := s.repo.(content.Deleter)
+	if !ok {
+		return errors.New("deleting manifests is not supported by the repository")
+	}
+
+	err := deleter.Delete(ctx, desc)
+
+	// Local stores also delete the blobs of the manifest, which fails for missing blobs
+	if exists, existsErr := s.repo.Exists(ctx, desc); existsErr == nil && !exists {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to delete manifest %s: %w", desc.Digest, err)
+	}
+
+	return errors.New("manifest still exists after deletion")
+}
+
+// untag removes a tag without deleting the manifest it points to.
+func (s *store) untag(ctx context.Context, tag string) error {
+	store, ok := s.repo.(*oci.Store)
+	if !ok {
+		return errors.New("removing tags is not supported by remote registries")
+	}
+
+	if err := store.Untag(ctx, tag); err != nil {
+		return fmt.Errorf("failed to remove tag %s: %w", tag, err)
+	}
+
+	return nil
+}
+
+// compact removes the blobs of a local store that are no longer referenced.
+// Remote registries reclaim unreferenced blobs with their own garbage collection.
+func (s *store) compact(ctx context.Context) bool {
+	store, ok := s.repo.(*oci.Store)
+	if !ok {
+		return false
+	}
+
+	if err := store.GC(ctx); err != nil {
+		logging.WithContext(ctx, logger).Warn("Failed to remove unreferenced blobs", "error", err)
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,211 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+// runFsck runs a consistency check and returns the reported issues by type and the summary.
+func runFsck(t *testing.T, s *store, repair bool) (map[storev1.FsckIssueType][]*storev1.FsckIssue, *storev1.FsckSummary) {
+	t.Helper()
+
+	issues := make(map[storev1.FsckIssueType][]*storev1.FsckIssue)
+
+	var (
+		summary  *storev1.FsckSummary
+		progress *storev1.FsckProgress
+	)
+
+	err := s.Fsck(testCtx, repair, func(resp *storev1.FsckResponse) error {
+		switch {
+		case resp.GetIssue() != nil:
+			issues[resp.GetIssue().GetType()] = append(issues[resp.GetIssue().GetType()], resp.GetIssue())
+		case resp.GetProgress() != nil:
+			progress = resp.GetProgress()
+		case resp.GetSummary() != nil:
+			summary = resp.GetSummary()
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, summary)
+	require.NotNil(t, progress)
+	assert.Equal(t, progress.GetTotalTags(), progress.GetCheckedTags())
+
+	return issues, summary
+}
+
+// blobPath returns the path of a blob in a local OCI layout.
+func blobPath(dir string, desc ocispec.Descriptor) string {
+	return filepath.Join(dir, "blobs", desc.Digest.Algorithm().String(), desc.Digest.Encoded())
+}
+
+func TestFsck(t *testing.T) {
+	dir := t.TempDir()
+	s := newLocalStore(t, dir, ociconfig.CompressionConfig{})
+
+	refs := make(map[string]*corev1.RecordRef)
+
+	for _, name := range []string{"dangling", "missing-blob", "mismatch", "other", "untagged", "healthy"} {
+		ref, err := s.Push(testCtx, corev1.New(&typesv1alpha1.Record{
+			Name:          name,
+			Version:       "v1.0.0",
+			SchemaVersion: "0.7.0",
+		}))
+		require.NoError(t, err)
+
+		refs[name] = ref
+	}
+
+	localStore, ok := s.repo.(*oci.Store)
+	require.True(t, ok)
+
+	// Dangling tags: the manifest of the record is lost
+	danglingDesc, err := s.repo.Resolve(testCtx, refs["dangling"].GetCid())
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(blobPath(dir, danglingDesc)))
+
+	// Missing blob: the record blob is lost
+	manifest, _, err := s.fetchAndParseManifest(testCtx, refs["missing-blob"].GetCid())
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(blobPath(dir, manifest.Layers[0])))
+
+	// CID mismatch: the CID tag points to a manifest storing the content of another record
+	otherManifest, _, err := s.fetchAndParseManifest(testCtx, refs["other"].GetCid())
+	require.NoError(t, err)
+
+	otherManifest.Annotations[ManifestKeyCid] = refs["mismatch"].GetCid()
+	forgedBytes, err := json.Marshal(otherManifest)
+	require.NoError(t, err)
+
+	forgedDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, forgedBytes)
+	require.NoError(t, s.repo.Push(testCtx, forgedDesc, bytes.NewReader(forgedBytes)))
+	require.NoError(t, s.repo.Tag(testCtx, forgedDesc, refs["mismatch"].GetCid()))
+
+	// Missing tag: a discovery tag of the record is lost
+	versionTag := corev1.NormalizeTag("untagged:v1.0.0")
+	require.NoError(t, localStore.Untag(testCtx, versionTag))
+
+	t.Run("issues are reported without repair", func(t *testing.T) {
+		issues, summary := runFsck(t, s, false)
+
+		assert.Len(t, issues[storev1.FsckIssueType_FSCK_ISSUE_TYPE_DANGLING_TAG], 3, "CID, version and latest tags")
+		assert.Len(t, issues[storev1.FsckIssueType_FSCK_ISSUE_TYPE_MISSING_BLOB], 1)
+		assert.Len(t, issues[storev1.FsckIssueType_FSCK_ISSUE_TYPE_CID_MISMATCH], 1)
+		assert.Equal(t, refs["mismatch"].GetCid(), issues[storev1.FsckIssueType_FSCK_ISSUE_TYPE_CID_MISMATCH][0].GetCid())
+
+		var missingTags []string
+		for _, issue := range issues[storev1.FsckIssueType_FSCK_ISSUE_TYPE_MISSING_TAG] {
+			missingTags = append(missingTags, issue.GetTag())
+		}
+
+		assert.ElementsMatch(t, []string{versionTag, refs["mismatch"].GetCid()}, missingTags)
+
+		for _, typed := range issues {
+			for _, issue := range typed {
+				assert.False(t, issue.GetRepaired())
+			}
+		}
+
+		assert.Equal(t, uint64(7), summary.GetIssues())
+		assert.Zero(t, summary.GetRepaired())
+		assert.False(t, summary.GetCompacted())
+	})
+
+	t.Run("issues are repaired", func(t *testing.T) {
+		issues, summary := runFsck(t, s, true)
+
+		for issueType, typed := range issues {
+			for _, issue := range typed {
+				assert.True(t, issue.GetRepaired(), "%s: %s", issueType, issue.GetRepairError())
+			}
+		}
+
+		assert.Len(t, issues, 4)
+		assert.Equal(t, summary.GetIssues(), summary.GetRepaired())
+		assert.True(t, summary.GetCompacted())
+	})
+
+	t.Run("repaired store is consistent", func(t *testing.T) {
+		issues, summary := runFsck(t, s, false)
+		assert.Empty(t, issues)
+		assert.Equal(t, uint64(4), summary.GetCheckedRecords())
+
+		for _, name := range []string{"dangling", "missing-blob"} {
+			_, err := s.Lookup(testCtx, refs[name])
+			assert.Equal(t, codes.NotFound, status.Code(err), name)
+		}
+
+		for _, name := range []string{"mismatch", "other", "untagged", "healthy"} {
+			record, err := s.Pull(testCtx, refs[name])
+			require.NoError(t, err, name)
+			assert.Equal(t, refs[name].GetCid(), record.GetCid())
+		}
+
+		desc, err := s.repo.Resolve(testCtx, versionTag)
+		require.NoError(t, err)
+
+		mismatchDesc, err := s.repo.Resolve(testCtx, refs["mismatch"].GetCid())
+		require.NoError(t, err)
+		assert.NotEqual(t, forgedDesc.Digest, mismatchDesc.Digest)
+		assert.NotEqual(t, desc.Digest, mismatchDesc.Digest)
+
+		_, err = os.Stat(blobPath(dir, forgedDesc))
+		assert.True(t, os.IsNotExist(err), "unreferenced manifest is removed")
+	})
+}
+
+func TestFsck_NameCollision(t *testing.T) {
+	s := newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+
+	// Records pushed before names were normalized
+	refs := make(map[string]*corev1.RecordRef)
+
+	for name, version := range map[string]string{"My Agent": "v1.0.0", "MY_AGENT": "v2.0.0", "my-agent-other": "v1.0.0"} {
+		ref, err := s.Push(testCtx, corev1.New(&typesv1alpha1.Record{
+			Name:          name,
+			Version:       version,
+			SchemaVersion: "0.7.0",
+		}))
+		require.NoError(t, err)
+
+		refs[name] = ref
+	}
+
+	for _, repair := range []bool{false, true} {
+		issues, summary := runFsck(t, s, repair)
+
+		collisions := issues[storev1.FsckIssueType_FSCK_ISSUE_TYPE_NAME_COLLISION]
+		require.Len(t, collisions, 1)
+		assert.Len(t, issues, 1)
+
+		collision := collisions[0]
+		assert.Contains(t, []string{refs["My Agent"].GetCid(), refs["MY_AGENT"].GetCid()}, collision.GetCid())
+		assert.Equal(t, "my-agent_latest", collision.GetTag())
+		assert.Contains(t, collision.GetMessage(), `both normalize to "my-agent"`)
+
+		// Collisions are not repaired
+		assert.False(t, collision.GetRepaired())
+		assert.Equal(t, repair, collision.GetRepairError() != "")
+		assert.Zero(t, summary.GetRepaired())
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+const (
+	// healthCheckTimeout bounds the registry ping.
+	healthCheckTimeout = 2 * time.Second
+
+	// healthCacheTTL avoids hammering the registry under frequent probes.
+	healthCacheTTL = 5 * time.Second
+)
+
+// healthCache caches the result of the last health check.
+type healthCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	checkedAt time.Time
+	err       error
+}
+
+// check returns the cached result if it is still fresh, otherwise runs checkFn.
+// Concurrent callers wait for a single in-flight check.
+func (c *healthCache) check(ctx context.Context, checkFn func(context.Context) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.checkedAt.IsZero() && time.Since(c.checkedAt) < c.ttl {
+		return c.err
+	}
+
+	c.err = checkFn(ctx)
+	c.checkedAt = time.Now()
+
+	return c.err
+}
+
+// CheckHealth verifies that the OCI backend is reachable.
+// Results are cached for a few seconds.
+func (s *store) CheckHealth(ctx context.Context) error {
+	return s.health.check(ctx, func(ctx context.Context) error {
+		if err := s.ping(ctx); err != nil {
+			return err
+		}
+
+		s.metrics.pinged()
+
+		return nil
+	})
+}
+
+func (s *store) ping(ctx context.Context) error {
+	switch repo := s.repo.(type) {
+	case *oci.Store:
+		if _, err := os.Stat(s.config.LocalDir); err != nil {
+			return fmt.Errorf("local store is not accessible: %w", err)
+		}
+
+		return nil
+
+	case *remote.Repository:
+		return pingRegistry(ctx, repo)
+
+	default:
+		return nil
+	}
+}
+
+// pingRegistry sends a HEAD request to the registry API base endpoint.
+func pingRegistry(ctx context.Context, repo *remote.Repository) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	scheme := "https"
+	if repo.PlainHTTP {
+		scheme = "http"
+	}
+
+	url := fmt.Sprintf("%s://%s/v2/", scheme, repo.Reference.Host())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create registry ping request: %w", err)
+	}
+
+	resp, err := repo.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("registry is not reachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry ping returned unexpected status: %w",
+			&errcode.ErrorResponse{Method: req.Method, URL: req.URL, StatusCode: resp.StatusCode})
+	}
+
+	return nil
+}
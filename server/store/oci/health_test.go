@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRemoteStore(t *testing.T, registryAddress string) *store {
+	t.Helper()
+
+	s, err := New(ociconfig.Config{
+		RegistryAddress: registryAddress,
+		RepositoryName:  "dir",
+		AuthConfig:      ociconfig.AuthConfig{Insecure: true},
+	})
+	require.NoError(t, err)
+
+	remoteStore, ok := s.(*store)
+	require.True(t, ok)
+
+	return remoteStore
+}
+
+func TestCheckHealthLocal(t *testing.T) {
+	s := newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+
+	assert.NoError(t, s.CheckHealth(t.Context()))
+}
+
+func TestCheckHealthRegistry(t *testing.T) {
+	var pings atomic.Int32
+
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead && r.URL.Path == "/v2/" {
+			pings.Add(1)
+			w.WriteHeader(http.StatusOK)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer registry.Close()
+
+	s := newRemoteStore(t, strings.TrimPrefix(registry.URL, "http://"))
+
+	require.NoError(t, s.CheckHealth(t.Context()))
+	require.NoError(t, s.CheckHealth(t.Context()))
+
+	// Second check is served from cache
+	assert.Equal(t, int32(1), pings.Load())
+}
+
+func TestCheckHealthBrokenRegistry(t *testing.T) {
+	// Nothing listens on the discard port
+	s := newRemoteStore(t, "127.0.0.1:9")
+
+	assert.Error(t, s.CheckHealth(t.Context()))
+}
@@ -13,8 +13,10 @@ import (
 
 	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
 	ociconfig "github.com/agntcy/dir/server/store/oci/config"
 	"github.com/agntcy/dir/server/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -65,7 +67,7 @@ func createTestRecord() *corev1.Record {
 }
 
 // setupIntegrationStore creates a store connected to the local zot registry.
-func setupIntegrationStore(t *testing.T) types.StoreAPI {
+func setupIntegrationStore(t testing.TB) types.StoreAPI {
 	t.Helper()
 
 	// Check if zot registry is available
@@ -171,7 +173,7 @@ func TestIntegrationOCIStoreWorkflow(t *testing.T) {
 		t.Logf("Pushed record with CID: %s", recordRef.GetCid())
 	})
 
-	t.Run("Verify CID Tag Generated", func(t *testing.T) {
+	t.Run("Verify Tags Generated", func(t *testing.T) {
 		// Give registry a moment to process
 		time.Sleep(1 * time.Second)
 
@@ -180,27 +182,28 @@ func TestIntegrationOCIStoreWorkflow(t *testing.T) {
 
 		t.Logf("Found %d tags in registry: %v", len(tags), tags)
 
-		// With CID-only tagging, we should have exactly one tag: the CID
+		// The record is tagged with its CID and its name tags
 		expectedCID := record.GetCid()
 		require.NotEmpty(t, expectedCID, "Record should have a valid CID")
 
-		// Verify the CID tag exists in registry
-		var hasCIDTag bool
-
-		for _, tag := range tags {
-			if tag == expectedCID {
-				hasCIDTag = true
+		assert.Contains(t, tags, expectedCID, "Registry should contain the CID tag: %s", expectedCID)
+		assert.ElementsMatch(t, record.DiscoveryTags(), tags)
+	})
 
-				break
-			}
-		}
+	t.Run("Resolve Name Tag", func(t *testing.T) {
+		resolver, ok := store.(interface {
+			Resolve(ctx context.Context, tag string) (*corev1.RecordRef, string, error)
+		})
+		require.True(t, ok, "Store should support resolving tags")
 
-		assert.True(t, hasCIDTag, "Registry should contain the CID tag: %s", expectedCID)
-		assert.Len(t, tags, 1, "Should have exactly one CID tag, found: %v", tags)
+		ref, warning, err := resolver.Resolve(ctx, "integration-test-agent:latest")
+		require.NoError(t, err, "Failed to resolve name tag")
+		assert.Equal(t, record.GetCid(), ref.GetCid())
+		assert.NotEmpty(t, warning, "Name tags are mutable")
 	})
 
 	t.Run("Verify Manifest Annotations", func(t *testing.T) {
-		// Test with CID tag (the only tag we create now)
+		// Test with the CID tag
 		manifest := getManifest(ctx, t, record.GetCid())
 
 		// Check manifest structure
@@ -319,3 +322,52 @@ func TestIntegrationOCIStoreWorkflow(t *testing.T) {
 }
 
 // TestIntegrationTagStrategy removed - no longer needed with CID-only tagging
+
+func TestIntegrationStoreMetrics(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), integrationTimeout)
+	defer cancel()
+
+	zotStore, ok := setupIntegrationStore(t).(*store)
+	require.True(t, ok)
+
+	m := zotStore.metrics
+	registry := integrationRegistryAddress + "/" + integrationRepositoryName
+
+	pushes := testutil.ToFloat64(m.blobBytes.WithLabelValues(registry, directionUploaded))
+	pulls := testutil.ToFloat64(m.blobBytes.WithLabelValues(registry, directionDownloaded))
+
+	// A new record, so that its blob is uploaded
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          fmt.Sprintf("metrics-agent-%d", time.Now().UnixNano()),
+		Version:       "v1.0.0",
+		SchemaVersion: "v0.3.1",
+	})
+
+	ref, err := zotStore.Push(ctx, record)
+	require.NoError(t, err)
+
+	_, err = zotStore.Pull(ctx, ref)
+	require.NoError(t, err)
+
+	require.NoError(t, zotStore.CheckHealth(ctx))
+
+	assert.Greater(t, testutil.ToFloat64(m.blobBytes.WithLabelValues(registry, directionUploaded)), pushes)
+	assert.Greater(t, testutil.ToFloat64(m.blobBytes.WithLabelValues(registry, directionDownloaded)), pulls)
+	assert.Positive(t, testutil.ToFloat64(m.lastPing.WithLabelValues(registry)))
+	assert.Positive(t, testutil.CollectAndCount(m.latency))
+}
+
+func TestIntegrationSelfTest(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), integrationTimeout)
+	defer cancel()
+
+	zotStore, ok := setupIntegrationStore(t).(*store)
+	require.True(t, ok)
+
+	report := zotStore.SelfTest(ctx, false)
+	require.True(t, report.GetPassed(), "report: %v", report)
+
+	for _, step := range report.GetSteps() {
+		assert.NotEqual(t, storev1.SelfTestStatus_SELF_TEST_STATUS_FAILED, step.GetStatus(), "step %s: %s", step.GetName(), step.GetError())
+	}
+}
@@ -6,6 +6,7 @@ package oci
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
@@ -40,12 +41,12 @@ func (s *store) fetchAndParseManifest(ctx context.Context, cid string) (*ocispec
 	// Resolve manifest from remote tag (this also checks existence and validates CID format)
 	manifestDesc, err := s.repo.Resolve(ctx, cid)
 	if err != nil {
-		internalLogger.Debug("Failed to resolve manifest", "cid", cid, "error", err)
+		logging.WithContext(ctx, internalLogger).Debug("Failed to resolve manifest", "cid", cid, "error", err)
 
 		return nil, nil, status.Errorf(codes.NotFound, "record not found: %s", cid)
 	}
 
-	internalLogger.Debug("Manifest resolved successfully", "cid", cid, "digest", manifestDesc.Digest.String())
+	logging.WithContext(ctx, internalLogger).Debug("Manifest resolved successfully", "cid", cid, "digest", manifestDesc.Digest.String())
 
 	manifest, err := s.fetchAndParseManifestFromDescriptor(ctx, manifestDesc)
 	if err != nil {
@@ -59,7 +60,7 @@ func (s *store) fetchAndParseManifest(ctx context.Context, cid string) (*ocispec
 func (s *store) fetchAndParseManifestFromDescriptor(ctx context.Context, manifestDesc ocispec.Descriptor) (*ocispec.Manifest, error) {
 	// Validate manifest size if available
 	if manifestDesc.Size > 0 {
-		internalLogger.Debug("Manifest size from descriptor", "cid", manifestDesc.Digest.String(), "size", manifestDesc.Size)
+		logging.WithContext(ctx, internalLogger).Debug("Manifest size from descriptor", "cid", manifestDesc.Digest.String(), "size", manifestDesc.Size)
 	}
 
 	// Fetch manifest from remote
@@ -77,7 +78,7 @@ func (s *store) fetchAndParseManifestFromDescriptor(ctx context.Context, manifes
 
 	// Validate manifest size matches descriptor
 	if manifestDesc.Size > 0 && int64(len(manifestData)) != manifestDesc.Size {
-		internalLogger.Warn("Manifest size mismatch",
+		logging.WithContext(ctx, internalLogger).Warn("Manifest size mismatch",
 			"cid", manifestDesc.Digest.String(),
 			"expected", manifestDesc.Size,
 			"actual", len(manifestData))
@@ -96,6 +97,8 @@ func (s *store) fetchAndParseManifestFromDescriptor(ctx context.Context, manifes
 
 // deleteFromOCIStore handles deletion of records from an OCI store.
 func (s *store) deleteFromOCIStore(ctx context.Context, ref *corev1.RecordRef) error {
+	log := logging.WithContext(ctx, internalLogger)
+
 	cid := ref.GetCid()
 
 	store, ok := s.repo.(*oci.Store)
@@ -103,32 +106,40 @@ func (s *store) deleteFromOCIStore(ctx context.Context, ref *corev1.RecordRef) e
 		return status.Errorf(codes.Internal, "expected *oci.Store, got %T", s.repo)
 	}
 
-	internalLogger.Debug("Starting OCI store deletion", "cid", cid)
+	log.Debug("Starting OCI store deletion", "cid", cid)
 
-	var errors []string
+	var (
+		errors []string
+		layers []ocispec.Descriptor
+	)
 
 	// Phase 1: Delete manifest (tags will be cleaned up by OCI GC)
-	internalLogger.Debug("Phase 1: Deleting manifest", "cid", cid)
+	log.Debug("Phase 1: Deleting manifest", "cid", cid)
 
 	manifestDesc, err := s.repo.Resolve(ctx, cid)
 	if err != nil {
 		// Manifest might already be gone - this is not necessarily an error
-		internalLogger.Debug("Failed to resolve manifest during delete (may already be deleted)", "cid", cid, "error", err)
+		log.Debug("Failed to resolve manifest during delete (may already be deleted)", "cid", cid, "error", err)
 		errors = append(errors, fmt.Sprintf("manifest resolve: %v", err))
 	} else {
+		// Remember the layers, as compressed blobs are not addressable by CID
+		if manifest, err := s.fetchAndParseManifestFromDescriptor(ctx, manifestDesc); err == nil {
+			layers = manifest.Layers
+		}
+
 		if err := store.Delete(ctx, manifestDesc); err != nil {
-			internalLogger.Warn("Failed to delete manifest", "cid", cid, "error", err)
+			log.Warn("Failed to delete manifest", "cid", cid, "error", err)
 			errors = append(errors, fmt.Sprintf("manifest delete: %v", err))
 		} else {
-			internalLogger.Debug("Manifest deleted successfully", "cid", cid, "digest", manifestDesc.Digest.String())
+			log.Debug("Manifest deleted successfully", "cid", cid, "digest", manifestDesc.Digest.String())
 		}
 	}
 
 	// Phase 2: Remove blob data (local store - we have full control)
-	internalLogger.Debug("Phase 2: Deleting blob data", "cid", cid)
+	log.Debug("Phase 2: Deleting blob data", "cid", cid)
 
-	if err := s.deleteBlobForLocalStore(ctx, cid, store); err != nil {
-		internalLogger.Warn("Failed to delete blob", "cid", cid, "error", err)
+	if err := s.deleteBlobForLocalStore(ctx, cid, layers, store); err != nil {
+		log.Warn("Failed to delete blob", "cid", cid, "error", err)
 		errors = append(errors, fmt.Sprintf("blob delete: %v", err))
 	}
 
@@ -136,18 +147,35 @@ func (s *store) deleteFromOCIStore(ctx context.Context, ref *corev1.RecordRef) e
 	if len(errors) > 0 {
 		// For local store, we might want to return an error if critical operations failed
 		// But continue with best-effort approach for now
-		internalLogger.Warn("Partial delete completed with some errors", "cid", cid, "errors", errors)
+		log.Warn("Partial delete completed with some errors", "cid", cid, "errors", errors)
 	} else {
-		internalLogger.Info("Record deleted successfully from OCI store", "cid", cid)
+		log.Info("Record deleted successfully from OCI store", "cid", cid)
 	}
 
 	return nil // Best effort - don't fail on partial cleanup
 }
 
-// deleteBlobForLocalStore safely deletes blob data from local OCI store using new CID utility.
-func (s *store) deleteBlobForLocalStore(ctx context.Context, cid string, store *oci.Store) error {
+// deleteBlobForLocalStore safely deletes blob data from local OCI store.
+// Blobs are taken from the manifest layers if known, otherwise derived from the CID.
+func (s *store) deleteBlobForLocalStore(ctx context.Context, cid string, layers []ocispec.Descriptor, store *oci.Store) error {
+	if len(layers) > 0 {
+		for _, layer := range layers {
+			if err := store.Delete(ctx, layer); err != nil {
+				return fmt.Errorf("failed to delete blob %s: %w", layer.Digest.String(), err)
+			}
+
+			logging.WithContext(ctx, internalLogger).Debug("Blob deleted successfully", "cid", cid, "digest", layer.Digest.String())
+		}
+
+		return nil
+	}
+
 	// Convert CID to digest using our new utility function
 	ociDigest, err := corev1.ConvertCIDToDigest(cid)
+	if errors.Is(err, corev1.ErrUnsupportedHash) {
+		return status.Errorf(codes.Unimplemented, "failed to convert CID to digest: %v", err)
+	}
+
 	if err != nil {
 		return fmt.Errorf("failed to convert CID to digest: %w", err)
 	}
@@ -160,13 +188,15 @@ func (s *store) deleteBlobForLocalStore(ctx context.Context, cid string, store *
 		return fmt.Errorf("failed to delete blob: %w", err)
 	}
 
-	internalLogger.Debug("Blob deleted successfully", "cid", cid, "digest", ociDigest.String())
+	logging.WithContext(ctx, internalLogger).Debug("Blob deleted successfully", "cid", cid, "digest", ociDigest.String())
 
 	return nil
 }
 
 // deleteFromRemoteRepository handles deletion of records from a remote repository.
 func (s *store) deleteFromRemoteRepository(ctx context.Context, ref *corev1.RecordRef) error {
+	log := logging.WithContext(ctx, internalLogger)
+
 	cid := ref.GetCid()
 
 	repo, ok := s.repo.(*remote.Repository)
@@ -174,30 +204,30 @@ func (s *store) deleteFromRemoteRepository(ctx context.Context, ref *corev1.Reco
 		return status.Errorf(codes.Internal, "expected *remote.Repository, got %T", s.repo)
 	}
 
-	internalLogger.Debug("Starting remote repository deletion", "cid", cid)
+	log.Debug("Starting remote repository deletion", "cid", cid)
 
 	var errors []string
 
 	// Phase 1: Delete manifest (tags will be cleaned up by OCI GC)
-	internalLogger.Debug("Phase 1: Deleting manifest", "cid", cid)
+	log.Debug("Phase 1: Deleting manifest", "cid", cid)
 
 	manifestDesc, err := s.repo.Resolve(ctx, cid)
 	if err != nil {
-		internalLogger.Debug("Failed to resolve manifest during delete (may already be deleted)", "cid", cid, "error", err)
+		log.Debug("Failed to resolve manifest during delete (may already be deleted)", "cid", cid, "error", err)
 		errors = append(errors, fmt.Sprintf("manifest resolve: %v", err))
 	} else {
 		if err := repo.Manifests().Delete(ctx, manifestDesc); err != nil {
-			internalLogger.Warn("Failed to delete manifest", "cid", cid, "error", err)
+			log.Warn("Failed to delete manifest", "cid", cid, "error", err)
 			errors = append(errors, fmt.Sprintf("manifest delete: %v", err))
 		} else {
-			internalLogger.Debug("Manifest deleted successfully", "cid", cid, "digest", manifestDesc.Digest.String())
+			log.Debug("Manifest deleted successfully", "cid", cid, "digest", manifestDesc.Digest.String())
 		}
 	}
 
 	// Phase 2: Skip blob deletion for remote registries (best practice)
 	// Most remote registries handle blob cleanup via garbage collection
-	internalLogger.Debug("Phase 2: Skipping blob deletion (handled by registry GC)", "cid", cid)
-	internalLogger.Info("Blob cleanup skipped for remote registry - will be handled by garbage collection",
+	log.Debug("Phase 2: Skipping blob deletion (handled by registry GC)", "cid", cid)
+	log.Info("Blob cleanup skipped for remote registry - will be handled by garbage collection",
 		"cid", cid,
 		"note", "This is the recommended approach for remote registries")
 
@@ -205,9 +235,9 @@ func (s *store) deleteFromRemoteRepository(ctx context.Context, ref *corev1.Reco
 	if len(errors) > 0 {
 		// For remote registries, partial failure is common and expected
 		// Many operations may not be supported, but this is normal
-		internalLogger.Warn("Partial delete completed with some errors", "cid", cid, "errors", errors)
+		log.Warn("Partial delete completed with some errors", "cid", cid, "errors", errors)
 	} else {
-		internalLogger.Info("Record deletion completed successfully", "cid", cid)
+		log.Info("Record deletion completed successfully", "cid", cid)
 	}
 
 	return nil // Best effort - remote registries have limited delete capabilities
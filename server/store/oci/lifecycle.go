@@ -0,0 +1,160 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/utils/logging"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/registry"
+)
+
+// SetLifecycle sets the lifecycle status of a record and returns the updated record metadata.
+//
+// The status is stored in the annotations of a lifecycle manifest that refers to the record manifest,
+// as annotating the record manifest itself would change its digest and detach its signatures and referrers.
+// Lifecycle manifests replaced by the new status are deleted on a best-effort basis.
+func (s *store) SetLifecycle(ctx context.Context, ref *corev1.RecordRef, lifecycle *corev1.Lifecycle) (*corev1.RecordMeta, error) {
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	if err := lifecycle.Validate(ref.GetCid()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid lifecycle: %v", err)
+	}
+
+	manifest, manifestDesc, err := s.fetchAndParseManifest(ctx, ref.GetCid())
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.Annotations[manifestDirObjectTypeKey] == objectTypeBundle {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is a bundle, not a record", ref.GetCid())
+	}
+
+	previous, err := s.lifecycleManifests(ctx, *manifestDesc)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list lifecycle manifests for CID %s: %v", ref.GetCid(), err)
+	}
+
+	lifecycleDesc, err := oras.PackManifest(ctx, s.repo, oras.PackManifestVersion1_1, LifecycleArtifactType,
+		oras.PackManifestOptions{
+			Subject:             manifestDesc,
+			ManifestAnnotations: lifecycleAnnotations(lifecycle),
+		},
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to pack lifecycle manifest for CID %s: %v", ref.GetCid(), err)
+	}
+
+	for _, desc := range previous {
+		if desc.Digest == lifecycleDesc.Digest {
+			continue
+		}
+
+		if err := s.deleteManifest(ctx, desc); err != nil {
+			logging.WithContext(ctx, logger).Warn("Failed to delete replaced lifecycle manifest", "cid", ref.GetCid(), "digest", desc.Digest.String(), "error", err)
+		}
+	}
+
+	logging.WithContext(ctx, logger).Info("Record lifecycle updated", "cid", ref.GetCid(), "status", lifecycle.StatusName())
+
+	meta := parseManifestAnnotations(manifest.Annotations)
+	meta.Cid = ref.GetCid()
+	meta.Lifecycle = lifecycle
+
+	return meta, nil
+}
+
+// lifecycle returns the lifecycle of the record with the given manifest.
+// Records without a lifecycle manifest are active. If several lifecycle manifests
+// refer to the record, e.g. after a failed cleanup, the most recent one wins.
+func (s *store) lifecycle(ctx context.Context, manifestDesc ocispec.Descriptor) (*corev1.Lifecycle, error) {
+	manifests, err := s.lifecycleManifests(ctx, manifestDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		latest   = &corev1.Lifecycle{Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_ACTIVE}
+		latestAt time.Time
+	)
+
+	for _, desc := range manifests {
+		annotations := desc.Annotations
+
+		// Registries may omit the annotations from referrer descriptors
+		if _, ok := annotations[ManifestKeyLifecycleStatus]; !ok {
+			manifest, err := s.fetchAndParseManifestFromDescriptor(ctx, desc)
+			if err != nil {
+				return nil, err
+			}
+
+			annotations = manifest.Annotations
+		}
+
+		lifecycle := parseLifecycleAnnotations(annotations)
+
+		updatedAt, _ := time.Parse(time.RFC3339Nano, lifecycle.GetUpdatedAt())
+		if !updatedAt.Before(latestAt) {
+			latest, latestAt = lifecycle, updatedAt
+		}
+	}
+
+	return latest, nil
+}
+
+// lifecycleManifests returns the descriptors of the lifecycle manifests that refer to the record manifest.
+func (s *store) lifecycleManifests(ctx context.Context, manifestDesc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	manifests, err := registry.Referrers(ctx, s.repo, manifestDesc, LifecycleArtifactType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list referrers of manifest %s: %w", manifestDesc.Digest, err)
+	}
+
+	return manifests, nil
+}
+
+// lifecycleAnnotations converts the lifecycle into lifecycle manifest annotations.
+func lifecycleAnnotations(lifecycle *corev1.Lifecycle) map[string]string {
+	annotations := map[string]string{
+		ManifestKeyLifecycleStatus: lifecycle.StatusName(),
+	}
+
+	if successor := lifecycle.GetSuccessorCid(); successor != "" {
+		annotations[ManifestKeyLifecycleSuccessor] = successor
+	}
+
+	if reason := lifecycle.GetReason(); reason != "" {
+		annotations[ManifestKeyLifecycleReason] = reason
+	}
+
+	if updatedAt := lifecycle.GetUpdatedAt(); updatedAt != "" {
+		annotations[ManifestKeyLifecycleUpdatedAt] = updatedAt
+	}
+
+	return annotations
+}
+
+// parseLifecycleAnnotations converts lifecycle manifest annotations into a lifecycle.
+// Unknown statuses are treated as active.
+func parseLifecycleAnnotations(annotations map[string]string) *corev1.Lifecycle {
+	lifecycleStatus, err := corev1.ParseLifecycleStatus(annotations[ManifestKeyLifecycleStatus])
+	if err != nil {
+		lifecycleStatus = corev1.LifecycleStatus_LIFECYCLE_STATUS_ACTIVE
+	}
+
+	return &corev1.Lifecycle{
+		Status:       lifecycleStatus,
+		SuccessorCid: annotations[ManifestKeyLifecycleSuccessor],
+		Reason:       annotations[ManifestKeyLifecycleReason],
+		UpdatedAt:    annotations[ManifestKeyLifecycleUpdatedAt],
+	}
+}
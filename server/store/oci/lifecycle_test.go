@@ -0,0 +1,121 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSetLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	s := newLocalStore(t, dir, ociconfig.CompressionConfig{})
+
+	var refs []*corev1.RecordRef
+
+	for _, version := range []string{"v1.0.0", "v2.0.0"} {
+		ref, err := s.Push(testCtx, corev1.New(&typesv1alpha1.Record{
+			Name:          "agent",
+			Version:       version,
+			SchemaVersion: "0.7.0",
+		}))
+		require.NoError(t, err)
+
+		refs = append(refs, ref)
+	}
+
+	manifestDesc, err := s.repo.Resolve(testCtx, refs[0].GetCid())
+	require.NoError(t, err)
+
+	t.Run("records are active by default", func(t *testing.T) {
+		meta, err := s.Lookup(testCtx, refs[0])
+		require.NoError(t, err)
+		assert.Equal(t, corev1.LifecycleStatus_LIFECYCLE_STATUS_ACTIVE, meta.GetLifecycle().GetStatus())
+
+		record, err := s.Pull(testCtx, refs[0])
+		require.NoError(t, err)
+		assert.Nil(t, record.GetLifecycle())
+	})
+
+	transitions := []*corev1.Lifecycle{
+		{
+			Status:       corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED,
+			SuccessorCid: refs[1].GetCid(),
+			Reason:       "superseded by v2",
+			UpdatedAt:    "2025-01-01T00:00:00Z",
+		},
+		{
+			Status:    corev1.LifecycleStatus_LIFECYCLE_STATUS_WITHDRAWN,
+			Reason:    "security issue",
+			UpdatedAt: "2025-01-02T00:00:00Z",
+		},
+		{
+			Status:    corev1.LifecycleStatus_LIFECYCLE_STATUS_ACTIVE,
+			UpdatedAt: "2025-01-03T00:00:00Z",
+		},
+	}
+
+	for _, lifecycle := range transitions {
+		t.Run("transition to "+lifecycle.StatusName(), func(t *testing.T) {
+			meta, err := s.SetLifecycle(testCtx, refs[0], lifecycle)
+			require.NoError(t, err)
+			assert.Equal(t, refs[0].GetCid(), meta.GetCid())
+			assert.Equal(t, lifecycle.GetStatus(), meta.GetLifecycle().GetStatus())
+
+			// The status survives a restart of the store
+			reopened := newLocalStore(t, dir, ociconfig.CompressionConfig{})
+
+			meta, err = reopened.Lookup(testCtx, refs[0])
+			require.NoError(t, err)
+			assert.Equal(t, lifecycle.GetStatus(), meta.GetLifecycle().GetStatus())
+			assert.Equal(t, lifecycle.GetSuccessorCid(), meta.GetLifecycle().GetSuccessorCid())
+			assert.Equal(t, lifecycle.GetReason(), meta.GetLifecycle().GetReason())
+
+			record, err := reopened.Pull(testCtx, refs[0])
+			require.NoError(t, err)
+			assert.Equal(t, refs[0].GetCid(), record.GetCid(), "the lifecycle is not part of the record CID")
+
+			if lifecycle.IsActive() {
+				assert.Nil(t, record.GetLifecycle())
+			} else {
+				assert.Equal(t, lifecycle.GetStatus(), record.GetLifecycle().GetStatus())
+			}
+
+			// Replaced lifecycle manifests are removed and the record manifest is unchanged
+			manifests, err := reopened.lifecycleManifests(testCtx, manifestDesc)
+			require.NoError(t, err)
+			assert.Len(t, manifests, 1)
+
+			desc, err := reopened.repo.Resolve(testCtx, refs[0].GetCid())
+			require.NoError(t, err)
+			assert.Equal(t, manifestDesc.Digest, desc.Digest)
+		})
+	}
+
+	t.Run("other records are unaffected", func(t *testing.T) {
+		meta, err := s.Lookup(testCtx, refs[1])
+		require.NoError(t, err)
+		assert.True(t, meta.GetLifecycle().IsActive())
+	})
+
+	t.Run("invalid lifecycles are rejected", func(t *testing.T) {
+		_, err := s.SetLifecycle(testCtx, refs[0], &corev1.Lifecycle{
+			Status:       corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED,
+			SuccessorCid: refs[0].GetCid(),
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("missing records are not found", func(t *testing.T) {
+		_, err := s.SetLifecycle(testCtx, &corev1.RecordRef{Cid: "bafkreigh2akiscaildcqabsyg3dfr6chu3fgpregiymsck7e7aqa4s52zy"}, transitions[0])
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -0,0 +1,53 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// List calls fn for every record in the store, in lexical order of their CIDs.
+// Records are enumerated by their CID tags, so other tags such as name tags are skipped.
+func (s *store) List(ctx context.Context, fn func(*corev1.RecordRef) error) error {
+	lister, ok := s.repo.(registry.TagLister)
+	if !ok {
+		return status.Error(codes.Unimplemented, "listing records is not supported by the repository")
+	}
+
+	err := lister.Tags(ctx, "", func(tags []string) error {
+		for _, tag := range tags {
+			if !corev1.IsValidCID(tag) {
+				continue
+			}
+
+			if err := fn(&corev1.RecordRef{Cid: tag}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil && !isRepositoryNotFound(err) {
+		return fmt.Errorf("failed to list records: %w", err)
+	}
+
+	return nil
+}
+
+// isRepositoryNotFound reports whether listing failed because the remote repository does not exist,
+// which is the case until the first record is pushed.
+func isRepositoryNotFound(err error) bool {
+	var errResp *errcode.ErrorResponse
+
+	return errors.As(err, &errResp) && errResp.StatusCode == http.StatusNotFound
+}
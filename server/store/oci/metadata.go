@@ -0,0 +1,243 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/utils/logging"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/registry"
+)
+
+// SetMetadata updates the operational metadata of a record, see corev1.MergeMetadata, and returns the updated record metadata.
+//
+// Like the lifecycle, the metadata is stored in the annotations of a metadata manifest that refers to the record manifest,
+// so that the record manifest digest and thus the CID are untouched. Metadata manifests replaced by the update
+// are deleted on a best-effort basis. Tags derived from the configured metadata keys are re-pointed to the record.
+func (s *store) SetMetadata(ctx context.Context, ref *corev1.RecordRef, update map[string]string) (*corev1.RecordMeta, error) {
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	if err := corev1.ValidateMetadataUpdate(update); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid metadata: %v", err)
+	}
+
+	manifest, manifestDesc, err := s.fetchAndParseManifest(ctx, ref.GetCid())
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.Annotations[manifestDirObjectTypeKey] == objectTypeBundle {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is a bundle, not a record", ref.GetCid())
+	}
+
+	// Updates are read-modify-write, concurrent updates must not lose entries
+	s.metadataMu.Lock()
+	defer s.metadataMu.Unlock()
+
+	previous, err := s.metadataManifests(ctx, *manifestDesc)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list metadata manifests for CID %s: %v", ref.GetCid(), err)
+	}
+
+	current, err := s.latestMetadata(ctx, previous)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get metadata for CID %s: %v", ref.GetCid(), err)
+	}
+
+	metadata, err := corev1.MergeMetadata(current, update)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid metadata: %v", err)
+	}
+
+	metadataDesc, err := oras.PackManifest(ctx, s.repo, oras.PackManifestVersion1_1, MetadataArtifactType,
+		oras.PackManifestOptions{
+			Subject:             manifestDesc,
+			ManifestAnnotations: metadataAnnotations(metadata, time.Now().UTC()),
+		},
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to pack metadata manifest for CID %s: %v", ref.GetCid(), err)
+	}
+
+	for _, desc := range previous {
+		if desc.Digest == metadataDesc.Digest {
+			continue
+		}
+
+		if err := s.deleteManifest(ctx, desc); err != nil {
+			logging.WithContext(ctx, logger).Warn("Failed to delete replaced metadata manifest", "cid", ref.GetCid(), "digest", desc.Digest.String(), "error", err)
+		}
+	}
+
+	if err := s.retagMetadata(ctx, ref, *manifestDesc, current, metadata); err != nil {
+		return nil, err
+	}
+
+	logging.WithContext(ctx, logger).Info("Record metadata updated", "cid", ref.GetCid(), "keys", len(metadata))
+
+	meta := parseManifestAnnotations(manifest.Annotations)
+	meta.Cid = ref.GetCid()
+	meta.OperationalMetadata = metadata
+
+	meta.Lifecycle, err = s.lifecycle(ctx, *manifestDesc)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get lifecycle for CID %s: %v", ref.GetCid(), err)
+	}
+
+	return meta, nil
+}
+
+// GetMetadata returns the operational metadata of a record, empty if none was set.
+func (s *store) GetMetadata(ctx context.Context, ref *corev1.RecordRef) (map[string]string, error) {
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	_, manifestDesc, err := s.fetchAndParseManifest(ctx, ref.GetCid())
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := s.operationalMetadata(ctx, *manifestDesc)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get metadata for CID %s: %v", ref.GetCid(), err)
+	}
+
+	return metadata, nil
+}
+
+// retagMetadata points the tags derived from the new metadata to the record manifest
+// and removes the tags derived from the previous metadata that no longer apply, where the repository supports it.
+func (s *store) retagMetadata(ctx context.Context, ref *corev1.RecordRef, manifestDesc ocispec.Descriptor, previous, metadata map[string]string) error {
+	if len(s.config.MetadataTagKeys) == 0 {
+		return nil
+	}
+
+	record, err := s.pull(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	decisions := &tagDecisions{decisions: record.ExplainMetadataTags(metadata, s.config.MetadataTagKeys)}
+	ctx = withTagDecisions(ctx, decisions)
+
+	defer func() {
+		logging.WithContext(ctx, logger).Debug("Metadata tag decisions of record", "cid", ref.GetCid(), "decisions", decisions.list())
+	}()
+
+	tags := corev1.CreatedTags(decisions.decisions)
+
+	if len(tags) > 0 {
+		if err := s.tagManifest(ctx, ref.GetCid(), manifestDesc, tags); err != nil {
+			return err
+		}
+	}
+
+	for _, tag := range record.MetadataTags(previous, s.config.MetadataTagKeys) {
+		if slices.Contains(tags, tag) {
+			continue
+		}
+
+		// Stale tags may already point to a record pushed later with the same metadata
+		desc, err := s.repo.Resolve(ctx, tag)
+		if err != nil || desc.Digest != manifestDesc.Digest {
+			continue
+		}
+
+		if err := s.untag(ctx, tag); err != nil {
+			logging.WithContext(ctx, logger).Warn("Failed to remove stale metadata tag", "cid", ref.GetCid(), "tag", tag, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// operationalMetadata returns the operational metadata of the record with the given manifest.
+func (s *store) operationalMetadata(ctx context.Context, manifestDesc ocispec.Descriptor) (map[string]string, error) {
+	manifests, err := s.metadataManifests(ctx, manifestDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.latestMetadata(ctx, manifests)
+}
+
+// latestMetadata returns the metadata of the given metadata manifests. If several manifests
+// refer to the record, e.g. after a failed cleanup, the most recent one wins.
+func (s *store) latestMetadata(ctx context.Context, manifests []ocispec.Descriptor) (map[string]string, error) {
+	var (
+		latest   map[string]string
+		latestAt time.Time
+	)
+
+	for _, desc := range manifests {
+		annotations := desc.Annotations
+
+		// Registries may omit the annotations from referrer descriptors
+		if _, ok := annotations[ManifestKeyMetadataUpdatedAt]; !ok {
+			manifest, err := s.fetchAndParseManifestFromDescriptor(ctx, desc)
+			if err != nil {
+				return nil, err
+			}
+
+			annotations = manifest.Annotations
+		}
+
+		metadata, updatedAt := parseMetadataAnnotations(annotations)
+		if latest == nil || !updatedAt.Before(latestAt) {
+			latest, latestAt = metadata, updatedAt
+		}
+	}
+
+	return latest, nil
+}
+
+// metadataManifests returns the descriptors of the metadata manifests that refer to the record manifest.
+func (s *store) metadataManifests(ctx context.Context, manifestDesc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	manifests, err := registry.Referrers(ctx, s.repo, manifestDesc, MetadataArtifactType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list referrers of manifest %s: %w", manifestDesc.Digest, err)
+	}
+
+	return manifests, nil
+}
+
+// metadataAnnotations converts the operational metadata into metadata manifest annotations.
+func metadataAnnotations(metadata map[string]string, updatedAt time.Time) map[string]string {
+	annotations := map[string]string{
+		ManifestKeyMetadataUpdatedAt: updatedAt.Format(time.RFC3339Nano),
+	}
+
+	for key, value := range metadata {
+		annotations[ManifestKeyMetadataPrefix+key] = value
+	}
+
+	return annotations
+}
+
+// parseMetadataAnnotations converts metadata manifest annotations into the operational metadata and its update time.
+func parseMetadataAnnotations(annotations map[string]string) (map[string]string, time.Time) {
+	metadata := make(map[string]string)
+
+	for key, value := range annotations {
+		if name, ok := strings.CutPrefix(key, ManifestKeyMetadataPrefix); ok && name != "" {
+			metadata[name] = value
+		}
+	}
+
+	updatedAt, _ := time.Parse(time.RFC3339Nano, annotations[ManifestKeyMetadataUpdatedAt])
+
+	return metadata, updatedAt
+}
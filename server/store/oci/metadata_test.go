@@ -0,0 +1,122 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSetMetadata(t *testing.T) {
+	dir := t.TempDir()
+
+	newStore := func() *store {
+		s, err := New(ociconfig.Config{LocalDir: dir, MetadataTagKeys: []string{"team", "project"}})
+		require.NoError(t, err)
+
+		localStore, ok := s.(*store)
+		require.True(t, ok)
+
+		return localStore
+	}
+
+	s := newStore()
+
+	ref, err := s.Push(testCtx, corev1.New(&typesv1alpha1.Record{
+		Name:          "agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	}))
+	require.NoError(t, err)
+
+	manifestDesc, err := s.repo.Resolve(testCtx, ref.GetCid())
+	require.NoError(t, err)
+
+	t.Run("records have no metadata by default", func(t *testing.T) {
+		meta, err := s.Lookup(testCtx, ref)
+		require.NoError(t, err)
+		assert.Empty(t, meta.GetOperationalMetadata())
+
+		metadata, err := s.GetMetadata(testCtx, ref)
+		require.NoError(t, err)
+		assert.Empty(t, metadata)
+	})
+
+	t.Run("set metadata keeps the CID", func(t *testing.T) {
+		meta, err := s.SetMetadata(testCtx, ref, map[string]string{"team": "platform", "cost-center": "cc-42"})
+		require.NoError(t, err)
+		assert.Equal(t, ref.GetCid(), meta.GetCid())
+		assert.Equal(t, map[string]string{"team": "platform", "cost-center": "cc-42"}, meta.GetOperationalMetadata())
+
+		// The metadata survives a restart of the store
+		reopened := newStore()
+
+		meta, err = reopened.Lookup(testCtx, ref)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"team": "platform", "cost-center": "cc-42"}, meta.GetOperationalMetadata())
+
+		record, err := reopened.Pull(testCtx, ref)
+		require.NoError(t, err)
+		assert.Equal(t, ref.GetCid(), record.GetCid(), "the metadata is not part of the record CID")
+
+		desc, err := reopened.repo.Resolve(testCtx, ref.GetCid())
+		require.NoError(t, err)
+		assert.Equal(t, manifestDesc.Digest, desc.Digest)
+	})
+
+	t.Run("tags follow the metadata", func(t *testing.T) {
+		resolved, _, err := s.Resolve(testCtx, "agent:team-platform")
+		require.NoError(t, err)
+		assert.Equal(t, ref.GetCid(), resolved.GetCid())
+
+		// Update merges the metadata and re-tags the record
+		meta, err := s.SetMetadata(testCtx, ref, map[string]string{"team": "search", "cost-center": "", "project": "dir"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"team": "search", "project": "dir"}, meta.GetOperationalMetadata())
+
+		for _, tag := range []string{"agent:team-search", "agent:project-dir"} {
+			resolved, _, err := s.Resolve(testCtx, tag)
+			require.NoError(t, err, tag)
+			assert.Equal(t, ref.GetCid(), resolved.GetCid())
+		}
+
+		_, _, err = s.Resolve(testCtx, "agent:team-platform")
+		assert.Equal(t, codes.NotFound, status.Code(err), "stale metadata tags must be removed")
+
+		// Replaced metadata manifests are removed
+		manifests, err := s.metadataManifests(testCtx, manifestDesc)
+		require.NoError(t, err)
+		assert.Len(t, manifests, 1)
+	})
+
+	t.Run("lifecycle and metadata are independent", func(t *testing.T) {
+		_, err := s.SetLifecycle(testCtx, ref, &corev1.Lifecycle{
+			Status:    corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED,
+			UpdatedAt: "2025-01-01T00:00:00Z",
+		})
+		require.NoError(t, err)
+
+		meta, err := s.Lookup(testCtx, ref)
+		require.NoError(t, err)
+		assert.Equal(t, corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED, meta.GetLifecycle().GetStatus())
+		assert.Equal(t, map[string]string{"team": "search", "project": "dir"}, meta.GetOperationalMetadata())
+	})
+
+	t.Run("invalid metadata is rejected", func(t *testing.T) {
+		_, err := s.SetMetadata(testCtx, ref, map[string]string{"Invalid Key": "value"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("missing records are not found", func(t *testing.T) {
+		_, err := s.SetMetadata(testCtx, &corev1.RecordRef{Cid: "bafkreigh2akiscaildcqabsyg3dfr6chu3fgpregiymsck7e7aqa4s52zy"}, map[string]string{"team": "platform"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/agntcy/dir/server/metrics"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+const (
+	metricsSubsystem = "oci_store"
+
+	// Store operations.
+	opPush   = "push"
+	opPull   = "pull"
+	opLookup = "lookup"
+	opDelete = "delete"
+
+	// Blob transfer directions.
+	directionUploaded   = "uploaded"
+	directionDownloaded = "downloaded"
+
+	// Tag error classes.
+	tagErrorCanceled     = "canceled"
+	tagErrorTimeout      = "timeout"
+	tagErrorNotFound     = "not_found"
+	tagErrorUnauthorized = "unauthorized"
+	tagErrorRateLimited  = "rate_limited"
+	tagErrorRegistry     = "registry_error"
+	tagErrorNetwork      = "network"
+	tagErrorOther        = "other"
+
+	// registryLocal is the registry label of stores backed by a local directory.
+	registryLocal = "local"
+)
+
+// storeMetrics holds the Prometheus collectors of a store, labeled by its registry.
+// A nil storeMetrics records nothing.
+type storeMetrics struct {
+	registry string
+
+	latency     *prometheus.HistogramVec
+	tagFailures *prometheus.CounterVec
+	lastPing    *prometheus.GaugeVec
+	blobBytes   *prometheus.CounterVec
+}
+
+func newStoreMetrics(registerer prometheus.Registerer, cfg ociconfig.Config) (*storeMetrics, error) {
+	m := &storeMetrics{
+		registry: registryLabel(cfg),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "operation_duration_seconds",
+			Help:      "Duration of store operations, by gRPC code of the result.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"registry", "operation", "code"}),
+		tagFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "tag_failures_total",
+			Help:      "Total number of manifest tags that could not be created, by error class.",
+		}, []string{"registry", "class"}),
+		lastPing: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "last_successful_ping_timestamp_seconds",
+			Help:      "Unix time of the last successful registry health check.",
+		}, []string{"registry"}),
+		blobBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "blob_bytes_total",
+			Help:      "Total size of blobs uploaded to and downloaded from the registry.",
+		}, []string{"registry", "direction"}),
+	}
+
+	var err error
+
+	m.latency, err = metrics.Register(registerer, m.latency)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	m.tagFailures, err = metrics.Register(registerer, m.tagFailures)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	m.lastPing, err = metrics.Register(registerer, m.lastPing)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	m.blobBytes, err = metrics.Register(registerer, m.blobBytes)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return m, nil
+}
+
+// registryLabel returns the registry label of the store,
+// so that deployments with multiple repositories can be told apart.
+func registryLabel(cfg ociconfig.Config) string {
+	if cfg.LocalDir != "" {
+		return registryLocal
+	}
+
+	return cfg.RegistryAddress + "/" + cfg.RepositoryName
+}
+
+// observe records the duration and result of a store operation.
+func (m *storeMetrics) observe(operation string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+
+	m.latency.WithLabelValues(m.registry, operation, status.Code(err).String()).Observe(time.Since(start).Seconds())
+}
+
+// tagFailed records a failure to create a tag.
+func (m *storeMetrics) tagFailed(err error) {
+	if m == nil {
+		return
+	}
+
+	m.tagFailures.WithLabelValues(m.registry, tagErrorClass(err)).Inc()
+}
+
+// pinged records a successful registry health check.
+func (m *storeMetrics) pinged() {
+	if m == nil {
+		return
+	}
+
+	m.lastPing.WithLabelValues(m.registry).SetToCurrentTime()
+}
+
+// transferred records the size of an uploaded or downloaded blob.
+func (m *storeMetrics) transferred(direction string, size int) {
+	if m == nil {
+		return
+	}
+
+	m.blobBytes.WithLabelValues(m.registry, direction).Add(float64(size))
+}
+
+// tagErrorClass classifies a tag error for the tag failures metric.
+func tagErrorClass(err error) string {
+	var (
+		errResp *errcode.ErrorResponse
+		netErr  net.Error
+	)
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return tagErrorCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return tagErrorTimeout
+	case errors.Is(err, errdef.ErrNotFound):
+		return tagErrorNotFound
+	case errors.As(err, &errResp):
+		switch {
+		case errResp.StatusCode == http.StatusUnauthorized || errResp.StatusCode == http.StatusForbidden:
+			return tagErrorUnauthorized
+		case errResp.StatusCode == http.StatusTooManyRequests:
+			return tagErrorRateLimited
+		case errResp.StatusCode == http.StatusNotFound:
+			return tagErrorNotFound
+		default:
+			return tagErrorRegistry
+		}
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return tagErrorTimeout
+		}
+
+		return tagErrorNetwork
+	default:
+		return tagErrorOther
+	}
+}
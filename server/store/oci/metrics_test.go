@@ -0,0 +1,154 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// useTestMetrics records the metrics of the store to a new registry.
+func useTestMetrics(t *testing.T, s *store) *storeMetrics {
+	t.Helper()
+
+	m, err := newStoreMetrics(prometheus.NewRegistry(), s.config)
+	require.NoError(t, err)
+
+	s.metrics = m
+
+	return m
+}
+
+func TestStoreMetrics(t *testing.T) {
+	s := newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+	m := useTestMetrics(t, s)
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "metrics-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+	recordBytes, err := record.Marshal()
+	require.NoError(t, err)
+
+	ref, err := s.Push(t.Context(), record)
+	require.NoError(t, err)
+
+	_, err = s.Lookup(t.Context(), ref)
+	require.NoError(t, err)
+
+	_, err = s.Pull(t.Context(), ref)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Delete(t.Context(), ref))
+
+	_, err = s.Pull(t.Context(), ref)
+	require.Error(t, err)
+
+	for _, labels := range [][]string{
+		{opPush, codes.OK.String()},
+		{opLookup, codes.OK.String()},
+		{opPull, codes.OK.String()},
+		{opDelete, codes.OK.String()},
+		{opPull, codes.NotFound.String()},
+	} {
+		observer, err := m.latency.GetMetricWithLabelValues(append([]string{registryLocal}, labels...)...)
+		require.NoError(t, err)
+
+		histogram, ok := observer.(prometheus.Histogram)
+		require.True(t, ok)
+		assert.Equal(t, 1, testutil.CollectAndCount(histogram), "operation %v must be observed", labels)
+	}
+
+	assert.Equal(t, 5, testutil.CollectAndCount(m.latency)) //nolint:mnd
+	assert.InDelta(t, len(recordBytes), testutil.ToFloat64(m.blobBytes.WithLabelValues(registryLocal, directionUploaded)), 0)
+	assert.InDelta(t, len(recordBytes), testutil.ToFloat64(m.blobBytes.WithLabelValues(registryLocal, directionDownloaded)), 0)
+
+	t.Run("health check records last successful ping", func(t *testing.T) {
+		require.NoError(t, s.CheckHealth(t.Context()))
+		assert.Positive(t, testutil.ToFloat64(m.lastPing.WithLabelValues(registryLocal)))
+	})
+}
+
+func TestStoreMetricsTagFailures(t *testing.T) {
+	s, _, manifestDesc := newTaggingStore(t, 2, "tag-0", "tag-1")
+	m := useTestMetrics(t, s)
+
+	require.NoError(t, s.tagManifest(t.Context(), "cid", manifestDesc, testTags(4)))
+
+	assert.InDelta(t, 2, testutil.ToFloat64(m.tagFailures.WithLabelValues(registryLocal, tagErrorOther)), 0)
+}
+
+func TestStoreMetricsRegistration(t *testing.T) {
+	t.Run("stores can be created more than once", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+			newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+		})
+	})
+
+	t.Run("stores share collectors and are told apart by registry", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+
+		first, err := newStoreMetrics(registry, ociconfig.Config{RegistryAddress: "registry-a:5000", RepositoryName: "dir"})
+		require.NoError(t, err)
+
+		second, err := newStoreMetrics(registry, ociconfig.Config{RegistryAddress: "registry-b:5000", RepositoryName: "dir"})
+		require.NoError(t, err)
+
+		assert.Same(t, first.blobBytes, second.blobBytes)
+
+		first.transferred(directionUploaded, 10)  //nolint:mnd
+		second.transferred(directionUploaded, 20) //nolint:mnd
+
+		assert.InDelta(t, 10, testutil.ToFloat64(first.blobBytes.WithLabelValues("registry-a:5000/dir", directionUploaded)), 0)
+		assert.InDelta(t, 20, testutil.ToFloat64(first.blobBytes.WithLabelValues("registry-b:5000/dir", directionUploaded)), 0)
+	})
+
+	t.Run("nil metrics record nothing", func(t *testing.T) {
+		var m *storeMetrics
+
+		assert.NotPanics(t, func() {
+			m.observe(opPush, time.Now(), nil)
+			m.tagFailed(errors.New("failed"))
+			m.pinged()
+			m.transferred(directionUploaded, 1)
+		})
+	})
+}
+
+func TestTagErrorClass(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{context.Canceled, tagErrorCanceled},
+		{fmt.Errorf("tag: %w", context.DeadlineExceeded), tagErrorTimeout},
+		{errdef.ErrNotFound, tagErrorNotFound},
+		{&errcode.ErrorResponse{StatusCode: http.StatusUnauthorized}, tagErrorUnauthorized},
+		{&errcode.ErrorResponse{StatusCode: http.StatusForbidden}, tagErrorUnauthorized},
+		{&errcode.ErrorResponse{StatusCode: http.StatusTooManyRequests}, tagErrorRateLimited},
+		{&errcode.ErrorResponse{StatusCode: http.StatusInternalServerError}, tagErrorRegistry},
+		{errors.New("tag rejected"), tagErrorOther},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, tagErrorClass(test.err), "error: %v", test.err)
+	}
+}
@@ -5,20 +5,29 @@ package oci
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"sync"
+	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/agntcy/dir/server/datastore"
+	"github.com/agntcy/dir/server/drain"
 	"github.com/agntcy/dir/server/store/cache"
+	"github.com/agntcy/dir/server/store/lock"
 	ociconfig "github.com/agntcy/dir/server/store/oci/config"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote"
 )
 
@@ -27,40 +36,47 @@ var logger = logging.Logger("store/oci")
 type store struct {
 	repo   oras.GraphTarget
 	config ociconfig.Config
+	health *healthCache
+
+	// tagging tracks pending tag operations, so that they can be completed on shutdown.
+	tagging drain.Tracker
+
+	metrics *storeMetrics
+
+	// pushLocks serializes pushes of the same record, so that concurrent pushers of a record
+	// wait for the first one and then find the record already stored.
+	pushLocks lock.Locker
+
+	// metadataMu serializes operational metadata updates, which read and replace the metadata manifest.
+	metadataMu sync.Mutex
+
+	// floatingMu serializes floating tag updates, which compare the version a tag points to before re-pointing it.
+	floatingMu sync.Mutex
 }
 
 func New(cfg ociconfig.Config) (types.StoreAPI, error) {
 	logger.Debug("Creating OCI store with config", "config", cfg)
 
-	// if local dir used, return client for that local path.
-	// allows mounting of data via volumes
-	// allows S3 usage for backup store
-	if repoPath := cfg.LocalDir; repoPath != "" {
-		repo, err := oci.New(repoPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create local repo: %w", err)
-		}
-
-		return &store{
-			repo:   repo,
-			config: cfg,
-		}, nil
+	storeMetrics, err := newStoreMetrics(prometheus.DefaultRegisterer, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register store metrics: %w", err)
 	}
 
-	repo, err := NewORASRepository(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create remote repo: %w", err)
+	var store types.StoreAPI
+
+	if cfg.Sharding.Enabled() {
+		store, err = newShardedStore(cfg, storeMetrics)
+	} else {
+		store, err = newStore(cfg, storeMetrics)
 	}
 
-	// Create store API
-	store := &store{
-		repo:   repo,
-		config: cfg,
+	if err != nil {
+		return nil, err
 	}
 
-	// If no cache requested, return.
+	// If local dir or no cache requested, return.
 	// Do not use in memory cache as it can get large.
-	if cfg.CacheDir == "" {
+	if cfg.LocalDir != "" || cfg.CacheDir == "" {
 		return store, nil
 	}
 
@@ -74,6 +90,45 @@ func New(cfg ociconfig.Config) (types.StoreAPI, error) {
 	return cache.Wrap(store, cacheDS), nil
 }
 
+// newStore creates a store for the local directory or the remote repository of the config.
+func newStore(cfg ociconfig.Config, storeMetrics *storeMetrics) (*store, error) {
+	pushLocks, err := lock.NewKeyedMutex("oci", prometheus.DefaultRegisterer)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	// if local dir used, return client for that local path.
+	// allows mounting of data via volumes
+	// allows S3 usage for backup store
+	if repoPath := cfg.LocalDir; repoPath != "" {
+		repo, err := oci.New(repoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create local repo: %w", err)
+		}
+
+		return &store{
+			repo:      repo,
+			config:    cfg,
+			health:    &healthCache{ttl: healthCacheTTL},
+			metrics:   storeMetrics,
+			pushLocks: pushLocks,
+		}, nil
+	}
+
+	repo, err := NewORASRepository(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create remote repo: %w", err)
+	}
+
+	return &store{
+		repo:      repo,
+		config:    cfg,
+		health:    &healthCache{ttl: healthCacheTTL},
+		metrics:   storeMetrics,
+		pushLocks: pushLocks,
+	}, nil
+}
+
 // Push record to the OCI registry
 //
 // This creates a blob, a manifest that points to that blob, and a tagged release for that manifest.
@@ -83,7 +138,109 @@ func New(cfg ociconfig.Config) (types.StoreAPI, error) {
 //
 // Ref: https://github.com/oras-project/oras-go/blob/main/docs/Modeling-Artifacts.md
 func (s *store) Push(ctx context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
-	logger.Debug("Pushing record to OCI store", "record", record)
+	start := time.Now()
+	ctx, span := startSpan(ctx, spanPush)
+
+	ref, err := s.push(ctx, record)
+	endSpan(span, err)
+	s.metrics.observe(opPush, start, err)
+
+	return ref, err
+}
+
+func (s *store) push(ctx context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
+	log := logging.WithContext(ctx, logger)
+
+	log.Debug("Pushing record to OCI store", "record", record)
+
+	// Step 1: Marshal the record, calculate its CID and derive its annotations and tags
+	plan, err := preparePush(record)
+	if err != nil {
+		return nil, err
+	}
+
+	recordCID := plan.cid
+
+	trace.SpanFromContext(ctx).SetAttributes(attrCID.String(recordCID))
+
+	// Record the outcome of each tag, so that missing tags can be told apart from tags never intended
+	decisions := &tagDecisions{decisions: s.explainTags(plan)}
+	ctx = withTagDecisions(ctx, decisions)
+
+	defer func() {
+		log.Debug("Tag decisions of record", "cid", recordCID, "decisions", decisions.list())
+	}()
+
+	// Wait for concurrent pushes of the record, which then take the fast path below
+	unlock, err := s.pushLocks.Lock(ctx, recordCID)
+	if err != nil {
+		return nil, status.FromContextError(err).Err() //nolint:wrapcheck
+	}
+	defer unlock()
+
+	// Create record reference
+	recordRef := &corev1.RecordRef{Cid: recordCID}
+
+	// Check if record already exists, in which case its content is not uploaded again
+	if manifestDesc, err := s.repo.Resolve(ctx, recordCID); err == nil {
+		// Re-pushes never re-point floating tags, they only create them if they are missing
+		fixed, floating := s.splitTags(plan.annotations, plan.tags)
+		if err := s.refreshTags(ctx, recordCID, manifestDesc, append(fixed, floating...)); err != nil {
+			return nil, err
+		}
+
+		log.Info("Record already exists in OCI store", "cid", recordCID)
+
+		trace.SpanFromContext(ctx).SetAttributes(attrAlreadyExisted.Bool(true))
+
+		recordRef.AlreadyExisted = true
+
+		return recordRef, nil
+	} else if !errors.Is(err, errdef.ErrNotFound) {
+		log.Debug("Failed to check if record exists, pushing it", "cid", recordCID, "error", err)
+	}
+
+	// Step 2: Push the record data (compressed if configured) and get Layer Descriptor
+	pushBlob := s.pushRecordBlob
+	if plan.encrypted {
+		pushBlob = s.pushEnvelopeBlob
+	}
+
+	layerDesc, err := pushBlob(ctx, plan.recordBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Pack manifest and tag it with the CID tag and name tags
+	// => resolve manifest to record which can be looked up (lookup)
+	// => allows pulling record directly (pull)
+	// => allows resolving name tags such as "my-agent:latest" to the record (resolve)
+	if err := s.pushManifestWithTags(ctx, recordCID, layerDesc, plan.annotations, plan.tags); err != nil {
+		return nil, err
+	}
+
+	log.Info("Record pushed to OCI store successfully", "cid", recordCID)
+
+	// Return record reference
+	return recordRef, nil
+}
+
+// pushPlan is everything push derives from a record before writing to the registry.
+type pushPlan struct {
+	cid         string
+	recordBytes []byte
+	annotations map[string]string
+	tags        []string
+	decisions   []corev1.TagDecision
+	encrypted   bool
+}
+
+// preparePush marshals the record, calculates its CID and derives the manifest annotations and tags.
+// It is shared by push and PreviewPush, so that a preview always matches the actual push.
+func preparePush(record *corev1.Record) (*pushPlan, error) {
+	if record.GetEnvelope() != nil {
+		return prepareEnvelopePush(record)
+	}
 
 	// Marshal the record using canonical JSON marshaling first
 	// This ensures consistent bytes for both CID calculation and storage
@@ -92,89 +249,94 @@ func (s *store) Push(ctx context.Context, record *corev1.Record) (*corev1.Record
 		return nil, status.Errorf(codes.Internal, "failed to marshal record: %v", err)
 	}
 
-	// Step 1: Use oras.PushBytes to push the record data and get Layer Descriptor
-	layerDesc, err := oras.PushBytes(ctx, s.repo, "application/json", recordBytes)
+	// Calculate CID over the uncompressed canonical bytes, with the hash function of the record CID
+	recordDigest, err := record.CIDOptions().Digest(recordBytes)
+	if errors.Is(err, corev1.ErrUnsupportedHash) {
+		return nil, status.Errorf(codes.Unimplemented, "failed to calculate record digest: %v", err)
+	}
+
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to push record bytes: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to calculate record digest: %v", err)
 	}
 
-	// Step 2: Calculate CID from Layer Descriptor's digest using our new utility function
-	recordCID, err := corev1.ConvertDigestToCID(layerDesc.Digest)
+	recordCID, err := corev1.ConvertDigestToCID(recordDigest)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to convert digest to CID: %v", err)
 	}
 
-	// Validate consistency: CID from ORAS digest should match CID from record
+	// Validate consistency: CID from canonical bytes should match CID from record
 	expectedCID := record.GetCid()
 	if recordCID != expectedCID {
 		return nil, status.Errorf(codes.Internal,
-			"CID mismatch: OCI digest CID (%s) != Record CID (%s)",
+			"CID mismatch: calculated CID (%s) != Record CID (%s)",
 			recordCID, expectedCID)
 	}
 
-	logger.Debug("CID validation successful",
-		"cid", recordCID,
-		"digest", layerDesc.Digest.String(),
-		"validation", "ORAS digest CID matches Record CID")
-
-	logger.Debug("Calculated CID from ORAS digest", "cid", recordCID, "digest", layerDesc.Digest.String())
+	logger.Debug("Calculated CID from record digest", "cid", recordCID, "digest", recordDigest.String())
 
-	// Create record reference
-	recordRef := &corev1.RecordRef{Cid: recordCID}
-
-	// Check if record already exists
-	if _, err := s.Lookup(ctx, recordRef); err == nil {
-		logger.Info("Record already exists in OCI store", "cid", recordCID)
-
-		return recordRef, nil
-	}
-
-	// Step 3: Construct manifest annotations and add CID to annotations
+	// Construct manifest annotations and add CID to annotations for discovery
 	manifestAnnotations := extractManifestAnnotations(record)
-	// Add the calculated CID to manifest annotations for discovery
 	manifestAnnotations[ManifestKeyCid] = recordCID
 
-	// Step 4: Pack manifest (in-memory only)
-	manifestDesc, err := oras.PackManifest(ctx, s.repo, oras.PackManifestVersion1_1, ocispec.MediaTypeImageManifest,
-		oras.PackManifestOptions{
-			ManifestAnnotations: manifestAnnotations,
-			Layers: []ocispec.Descriptor{
-				layerDesc,
-			},
-		},
-	)
+	decisions := record.ExplainDiscoveryTags()
+
+	return &pushPlan{
+		cid:         recordCID,
+		recordBytes: recordBytes,
+		annotations: manifestAnnotations,
+		tags:        corev1.CreatedTags(decisions),
+		decisions:   decisions,
+	}, nil
+}
+
+// PreviewPush reports what Push would do for the record without writing to the registry:
+// the CID, the manifest annotations and the tags, and whether the record already exists.
+func (s *store) PreviewPush(ctx context.Context, record *corev1.Record) (*storev1.PushPreview, error) {
+	plan, err := preparePush(record)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to pack manifest: %v", err)
+		return nil, err
 	}
 
-	// Step 5: Create CID tag for content-addressable storage
-	cidTag := recordCID
-	logger.Debug("Generated CID tag", "cid", recordCID, "tag", cidTag)
+	decisions := s.explainTags(plan)
 
-	// Step 6: Tag the manifest with CID tag
-	// => resolve manifest to record which can be looked up (lookup)
-	// => allows pulling record directly (pull)
-	if _, err := oras.Tag(ctx, s.repo, manifestDesc.Digest.String(), cidTag); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create CID tag: %v", err)
+	preview := &storev1.PushPreview{
+		Cid:          plan.cid,
+		Tags:         corev1.CreatedTags(decisions),
+		Annotations:  plan.annotations,
+		TagDecisions: tagDecisionsToProto(decisions),
 	}
 
-	logger.Info("Record pushed to OCI store successfully", "cid", recordCID, "tag", cidTag)
+	_, err = s.repo.Resolve(ctx, plan.cid)
+	switch {
+	case err == nil:
+		preview.AlreadyExists = true
+	case !errors.Is(err, errdef.ErrNotFound):
+		return nil, status.Errorf(codes.Internal, "failed to check if record exists: %v", err)
+	}
 
-	// Return record reference
-	return recordRef, nil
+	return preview, nil
 }
 
 // Lookup checks if the ref exists as a tagged record.
 func (s *store) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	start := time.Now()
+
+	meta, err := s.lookup(ctx, ref)
+	s.metrics.observe(opLookup, start, err)
+
+	return meta, err
+}
+
+func (s *store) lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
 	// Input validation using shared helper
 	if err := validateRecordRef(ref); err != nil {
 		return nil, err
 	}
 
-	logger.Debug("Starting record lookup", "cid", ref.GetCid())
+	logging.WithContext(ctx, logger).Debug("Starting record lookup", "cid", ref.GetCid())
 
 	// Use shared helper to fetch and parse manifest (eliminates code duplication)
-	manifest, _, err := s.fetchAndParseManifest(ctx, ref.GetCid())
+	manifest, manifestDesc, err := s.fetchAndParseManifest(ctx, ref.GetCid())
 	if err != nil {
 		return nil, err // Error already has proper context from helper
 	}
@@ -192,7 +354,19 @@ func (s *store) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.Reco
 	// Set the CID from the request (this is the primary identifier)
 	recordMeta.Cid = ref.GetCid()
 
-	logger.Debug("Record metadata retrieved successfully",
+	// The lifecycle is stored separately, as it can change after the record is pushed
+	recordMeta.Lifecycle, err = s.lifecycle(ctx, *manifestDesc)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get lifecycle for CID %s: %v", ref.GetCid(), err)
+	}
+
+	// Operational metadata is stored separately as well, so that changing it keeps the CID
+	recordMeta.OperationalMetadata, err = s.operationalMetadata(ctx, *manifestDesc)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get metadata for CID %s: %v", ref.GetCid(), err)
+	}
+
+	logging.WithContext(ctx, logger).Debug("Record metadata retrieved successfully",
 		"cid", ref.GetCid(),
 		"type", recordType,
 		"annotationCount", len(manifest.Annotations))
@@ -201,12 +375,23 @@ func (s *store) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.Reco
 }
 
 func (s *store) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	start := time.Now()
+
+	record, err := s.pull(ctx, ref)
+	s.metrics.observe(opPull, start, err)
+
+	return record, err
+}
+
+func (s *store) pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	log := logging.WithContext(ctx, logger)
+
 	// Input validation using shared helper
 	if err := validateRecordRef(ref); err != nil {
 		return nil, err
 	}
 
-	logger.Debug("Starting record pull", "cid", ref.GetCid())
+	log.Debug("Starting record pull", "cid", ref.GetCid())
 
 	// Use shared helper to fetch and parse manifest (eliminates code duplication)
 	manifest, manifestDesc, err := s.fetchAndParseManifest(ctx, ref.GetCid())
@@ -214,6 +399,11 @@ func (s *store) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record
 		return nil, err // Error already has proper context from helper
 	}
 
+	// Bundles are stored like records, but are pulled with PullBundle
+	if manifest.Annotations[manifestDirObjectTypeKey] == objectTypeBundle {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is a bundle, not a record", ref.GetCid())
+	}
+
 	// Validate manifest has layers
 	if len(manifest.Layers) == 0 {
 		return nil, status.Errorf(codes.Internal, "manifest has no layers for CID %s", ref.GetCid())
@@ -221,7 +411,7 @@ func (s *store) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record
 
 	// Handle multiple layers with warning
 	if len(manifest.Layers) > 1 {
-		logger.Warn("Manifest has multiple layers, using first layer",
+		log.Warn("Manifest has multiple layers, using first layer",
 			"cid", ref.GetCid(),
 			"layerCount", len(manifest.Layers))
 	}
@@ -230,14 +420,14 @@ func (s *store) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record
 	blobDesc := manifest.Layers[0]
 
 	// Validate layer media type
-	if blobDesc.MediaType != "application/json" {
-		logger.Warn("Unexpected blob media type",
+	if blobDesc.MediaType != mediaTypeRecord && blobDesc.MediaType != mediaTypeRecordZstd && blobDesc.MediaType != mediaTypeRecordEnvelope {
+		log.Warn("Unexpected blob media type",
 			"cid", ref.GetCid(),
-			"expected", "application/json",
+			"expected", mediaTypeRecord,
 			"actual", blobDesc.MediaType)
 	}
 
-	logger.Debug("Fetching record blob",
+	log.Debug("Fetching record blob",
 		"cid", ref.GetCid(),
 		"blobDigest", blobDesc.Digest.String(),
 		"blobSize", blobDesc.Size,
@@ -256,21 +446,49 @@ func (s *store) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record
 		return nil, status.Errorf(codes.Internal, "failed to read record data for CID %s: %v", ref.GetCid(), err)
 	}
 
+	s.metrics.transferred(directionDownloaded, len(recordData))
+
 	// Validate blob size matches descriptor
 	if blobDesc.Size > 0 && int64(len(recordData)) != blobDesc.Size {
-		logger.Warn("Blob size mismatch",
+		log.Warn("Blob size mismatch",
 			"cid", ref.GetCid(),
 			"expected", blobDesc.Size,
 			"actual", len(recordData))
 	}
 
-	// Unmarshal canonical JSON data back to Record
-	record, err := corev1.UnmarshalRecord(recordData)
+	// Decompress data if the blob was stored compressed.
+	// Blobs without compression annotations are plain canonical JSON.
+	if isCompressedLayer(blobDesc) {
+		recordData, err = decompressRecord(blobDesc, recordData)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to decompress record data for CID %s: %v", ref.GetCid(), err)
+		}
+	}
+
+	// Unmarshal canonical JSON data back to Record, or the envelope of encrypted records
+	unmarshal := corev1.UnmarshalRecord
+	if blobDesc.MediaType == mediaTypeRecordEnvelope {
+		unmarshal = corev1.UnmarshalEnvelope
+	}
+
+	record, err := unmarshal(recordData)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to unmarshal record for CID %s: %v", ref.GetCid(), err)
 	}
 
-	logger.Debug("Record pulled successfully",
+	// CIDs are self-describing, the record keeps the hash function of the CID it is stored under
+	if opts, err := corev1.CIDOptionsOf(ref.GetCid()); err == nil {
+		record.SetCIDOptions(opts)
+	}
+
+	// Pulls of deprecated and withdrawn records succeed, but carry the lifecycle to warn consumers
+	if lifecycle, err := s.lifecycle(ctx, *manifestDesc); err != nil {
+		log.Warn("Failed to get record lifecycle", "cid", ref.GetCid(), "error", err)
+	} else if !lifecycle.IsActive() {
+		record.Lifecycle = lifecycle
+	}
+
+	log.Debug("Record pulled successfully",
 		"cid", ref.GetCid(),
 		"blobSize", len(recordData),
 		"blobDigest", blobDesc.Digest.String(),
@@ -279,8 +497,248 @@ func (s *store) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record
 	return record, nil
 }
 
+// pushManifestWithTags packs a manifest for the record layer and tags it with each of the given tags.
+// Floating tags are only re-pointed to the manifest if its version is the highest, see tagFloating.
+func (s *store) pushManifestWithTags(ctx context.Context, cid string, layerDesc ocispec.Descriptor, annotations map[string]string, tags []string) error {
+	manifestCtx, manifestSpan := startSpan(ctx, spanPushManifest, attrCID.String(cid))
+
+	manifestDesc, err := oras.PackManifest(manifestCtx, s.repo, oras.PackManifestVersion1_1, ocispec.MediaTypeImageManifest,
+		oras.PackManifestOptions{
+			ManifestAnnotations: annotations,
+			Layers: []ocispec.Descriptor{
+				layerDesc,
+			},
+		},
+	)
+	if err != nil {
+		err = status.Errorf(codes.Internal, "failed to pack manifest: %v", err)
+		endSpan(manifestSpan, err)
+
+		return err
+	}
+
+	endSpan(manifestSpan, nil)
+
+	fixed, floating := s.splitTags(annotations, tags)
+
+	if err := s.tagManifest(ctx, cid, manifestDesc, fixed); err != nil {
+		return err
+	}
+
+	s.tagFloating(ctx, cid, manifestDesc, annotations[ManifestKeyVersion], floating)
+	trace.SpanFromContext(ctx).SetAttributes(attrTagsCount.Int(len(tags)))
+
+	return nil
+}
+
+// refreshTags creates the discovery tags missing for an existing record manifest,
+// e.g. because the record was pushed before the tags were introduced.
+// Existing tags are left untouched, so re-pushing a record does not re-point its name tags.
+func (s *store) refreshTags(ctx context.Context, cid string, manifestDesc ocispec.Descriptor, tags []string) error {
+	var missing []string
+
+	for _, tag := range tags {
+		_, err := s.repo.Resolve(ctx, tag)
+		if err == nil {
+			recordTagOutcome(ctx, tag, corev1.TagOutcomeUnchanged, nil)
+
+			continue
+		}
+
+		if !errors.Is(err, errdef.ErrNotFound) {
+			return status.Errorf(codes.Internal, "failed to resolve tag %s: %v", tag, err)
+		}
+
+		missing = append(missing, tag)
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	logging.WithContext(ctx, logger).Info("Refreshing discovery tags of existing record", "cid", cid, "tags", missing)
+
+	return s.tagManifest(ctx, cid, manifestDesc, missing)
+}
+
+// tagManifest tags the record manifest with each of the given tags,
+// creating up to TagConcurrency tags at once. Tags that already point to the manifest are skipped.
+//
+// Failing to create some of the name tags is logged but does not fail the push,
+// as the record remains reachable by its CID. It fails if the CID tag or all tags could not be created.
+//
+// Tags being created complete even if the request is cancelled, so that shutdown can drain them,
+// but no further tags are started. Missing tags are created by refreshTags on the next push.
+func (s *store) tagManifest(ctx context.Context, cid string, manifestDesc ocispec.Descriptor, tags []string) error {
+	trace.SpanFromContext(ctx).SetAttributes(attrTagsCount.Int(len(tags)))
+
+	s.tagging.Start()
+	defer s.tagging.Done()
+
+	tagsCtx := context.WithoutCancel(ctx)
+
+	var (
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, s.config.GetTagConcurrency())
+		errs = make([]error, len(tags))
+	)
+
+	for i, tag := range tags {
+		if ctx.Err() != nil {
+			errs[i] = status.FromContextError(ctx.Err()).Err()
+			recordTagOutcome(ctx, tag, corev1.TagOutcomeFailed, errs[i])
+
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			errs[i] = status.FromContextError(ctx.Err()).Err()
+			recordTagOutcome(ctx, tag, corev1.TagOutcomeFailed, errs[i])
+
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			errs[i] = s.tag(tagsCtx, cid, manifestDesc, tag)
+		}()
+	}
+
+	wg.Wait()
+
+	var (
+		failed    []error
+		cidFailed bool
+	)
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		failed = append(failed, err)
+		cidFailed = cidFailed || tags[i] == cid
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	if cidFailed || len(failed) == len(tags) {
+		return status.Errorf(codes.Internal, "failed to tag manifest: %v", errors.Join(failed...))
+	}
+
+	logging.WithContext(ctx, logger).Warn("Failed to create some tags of the record", "cid", cid, "failed", len(failed), "error", errors.Join(failed...))
+
+	return nil
+}
+
+// tag points the tag to the record manifest, unless it already does.
+func (s *store) tag(ctx context.Context, cid string, manifestDesc ocispec.Descriptor, tag string) error {
+	ctx, span := startSpan(ctx, spanTag, attrCID.String(cid), attrTag.String(tag))
+
+	// Resolving is cheaper than re-tagging, which makes re-pushes idempotent without writes
+	if desc, err := s.repo.Resolve(ctx, tag); err == nil && desc.Digest == manifestDesc.Digest {
+		endSpan(span, nil)
+
+		logging.WithContext(ctx, logger).Debug("Tag already points to manifest", "cid", cid, "tag", tag)
+		recordTagOutcome(ctx, tag, corev1.TagOutcomeUnchanged, nil)
+
+		return nil
+	}
+
+	if _, err := oras.Tag(ctx, s.repo, manifestDesc.Digest.String(), tag); err != nil {
+		s.metrics.tagFailed(err)
+
+		err = status.Errorf(codes.Internal, "failed to create tag %s: %v", tag, err)
+		endSpan(span, err)
+		recordTagOutcome(ctx, tag, corev1.TagOutcomeFailed, err)
+
+		return err
+	}
+
+	endSpan(span, nil)
+	recordTagOutcome(ctx, tag, corev1.TagOutcomeTagged, nil)
+
+	logging.WithContext(ctx, logger).Debug("Tagged manifest", "cid", cid, "tag", tag)
+
+	return nil
+}
+
+// Flush waits for pending tag operations to complete.
+func (s *store) Flush(ctx context.Context) error {
+	return s.tagging.Wait(ctx)
+}
+
+// pushRecordBlob pushes canonical record bytes as a blob, compressing them
+// with zstd if compression is enabled and the record is large enough.
+func (s *store) pushRecordBlob(ctx context.Context, recordBytes []byte) (ocispec.Descriptor, error) {
+	ctx, span := startSpan(ctx, spanPushBlob, attrBlobSize.Int(len(recordBytes)))
+
+	layerDesc, err := s.pushRecordBlobBytes(ctx, recordBytes)
+	endSpan(span, err)
+
+	return layerDesc, err
+}
+
+func (s *store) pushRecordBlobBytes(ctx context.Context, recordBytes []byte) (ocispec.Descriptor, error) {
+	if !shouldCompress(s.config.Compression, len(recordBytes)) {
+		layerDesc, err := oras.PushBytes(ctx, s.repo, mediaTypeRecord, recordBytes)
+		if err != nil {
+			return ocispec.Descriptor{}, status.Errorf(codes.Internal, "failed to push record bytes: %v", err)
+		}
+
+		s.metrics.transferred(directionUploaded, len(recordBytes))
+
+		return layerDesc, nil
+	}
+
+	compressed, err := compressRecord(s.config.Compression, recordBytes)
+	if err != nil {
+		return ocispec.Descriptor{}, status.Errorf(codes.Internal, "failed to compress record bytes: %v", err)
+	}
+
+	layerDesc, err := oras.PushBytes(ctx, s.repo, mediaTypeRecordZstd, compressed)
+	if err != nil {
+		return ocispec.Descriptor{}, status.Errorf(codes.Internal, "failed to push compressed record bytes: %v", err)
+	}
+
+	s.metrics.transferred(directionUploaded, len(compressed))
+
+	// Annotations live on the layer descriptor in the manifest and do not affect the blob digest
+	layerDesc.Annotations = compressionAnnotations(len(recordBytes))
+
+	logging.WithContext(ctx, logger).Debug("Pushed compressed record blob",
+		"size", len(recordBytes),
+		"compressedSize", len(compressed),
+		"digest", layerDesc.Digest.String())
+
+	return layerDesc, nil
+}
+
 func (s *store) Delete(ctx context.Context, ref *corev1.RecordRef) error {
-	logger.Debug("Deleting record from OCI store", "ref", ref)
+	start := time.Now()
+
+	err := s.delete(ctx, ref)
+	s.metrics.observe(opDelete, start, err)
+
+	return err
+}
+
+func (s *store) delete(ctx context.Context, ref *corev1.RecordRef) error {
+	logging.WithContext(ctx, logger).Debug("Deleting record from OCI store", "ref", ref)
 
 	// Input validation using shared helper
 	if err := validateRecordRef(ref); err != nil {
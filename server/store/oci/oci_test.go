@@ -6,16 +6,23 @@ package oci
 
 import (
 	"context"
+	"io"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
 	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/store/lock"
 	ociconfig "github.com/agntcy/dir/server/store/oci/config"
 	"github.com/agntcy/dir/server/types"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
 )
 
 // TODO: this should be configurable to unified Storage API test flow.
@@ -178,9 +185,171 @@ func loadRemoteStore(t *testing.T) types.StoreAPI {
 	return store
 }
 
+// countingTarget counts the blobs and manifests pushed to a target.
+type countingTarget struct {
+	oras.GraphTarget
+
+	pushes atomic.Int32
+}
+
+func (t *countingTarget) Push(ctx context.Context, expected ocispec.Descriptor, content io.Reader) error {
+	t.pushes.Add(1)
+
+	return t.GraphTarget.Push(ctx, expected, content) //nolint:wrapcheck
+}
+
+func TestPushIdempotent(t *testing.T) {
+	s := newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+	target := &countingTarget{GraphTarget: s.repo}
+	s.repo = target
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "idempotent-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+
+	// The first push uploads the record blob and manifest
+	ref, err := s.Push(testCtx, record)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), ref.GetCid())
+	assert.False(t, ref.GetAlreadyExisted())
+
+	pushes := target.pushes.Load()
+	require.Positive(t, pushes)
+
+	// The second push uploads nothing
+	ref, err = s.Push(testCtx, record)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), ref.GetCid())
+	assert.True(t, ref.GetAlreadyExisted())
+	assert.Equal(t, pushes, target.pushes.Load())
+
+	// Missing discovery tags are created again, without uploading content
+	localRepo, ok := target.GraphTarget.(*oci.Store)
+	require.True(t, ok)
+	require.NoError(t, localRepo.Untag(testCtx, "idempotent-agent_latest"))
+
+	ref, err = s.Push(testCtx, record)
+	require.NoError(t, err)
+	assert.True(t, ref.GetAlreadyExisted())
+	assert.Equal(t, pushes, target.pushes.Load())
+
+	resolved, _, err := s.Resolve(testCtx, "idempotent-agent:latest")
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), resolved.GetCid())
+
+	// Existing name tags are not re-pointed by pushing an existing record
+	newer := corev1.New(&typesv1alpha1.Record{
+		Name:          "idempotent-agent",
+		Version:       "v2.0.0",
+		SchemaVersion: "0.7.0",
+	})
+
+	_, err = s.Push(testCtx, newer)
+	require.NoError(t, err)
+
+	_, err = s.Push(testCtx, record)
+	require.NoError(t, err)
+
+	resolved, _, err = s.Resolve(testCtx, "idempotent-agent:latest")
+	require.NoError(t, err)
+	assert.Equal(t, newer.GetCid(), resolved.GetCid())
+}
+
+func TestPushConcurrent(t *testing.T) {
+	const pushers = 50
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "concurrent-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+
+	// Count the blobs and manifests of a single push
+	single := newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+	singleTarget := &countingTarget{GraphTarget: single.repo}
+	single.repo = singleTarget
+
+	_, err := single.Push(testCtx, record)
+	require.NoError(t, err)
+
+	s := newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+	target := &countingTarget{GraphTarget: s.repo}
+	s.repo = target
+
+	var (
+		wg     sync.WaitGroup
+		stored atomic.Int32
+		pushed atomic.Int32
+	)
+
+	for range pushers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			ref, err := s.Push(testCtx, record)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, record.GetCid(), ref.GetCid())
+			pushed.Add(1)
+
+			if !ref.GetAlreadyExisted() {
+				stored.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// Pushers wait for the first one and take the fast path
+	assert.Equal(t, int32(pushers), pushed.Load())
+	assert.Equal(t, int32(1), stored.Load(), "record must be stored by exactly one push")
+	assert.Equal(t, singleTarget.pushes.Load(), target.pushes.Load(), "content must be uploaded once")
+
+	locks, ok := s.pushLocks.(*lock.KeyedMutex)
+	require.True(t, ok)
+	assert.Equal(t, 0, locks.Len(), "push locks must be released")
+}
+
 // TestAllVersionsSkillsAndLocatorsPreservation comprehensively tests skills and locators
 // preservation across all OASF versions (v1, v2, v3) through OCI push/pull cycles.
 // This addresses the reported issue where v3 record skills become empty after push/pull.
+func TestStoreLookupCustomAnnotations(t *testing.T) {
+	store := loadLocalStore(t)
+
+	annotations := map[string]string{
+		"team":              "platform",
+		"organization":      "agntcy",
+		"project":           "directory",
+		"cost-center":       "cc-1234",
+		"example.com/owner": "alice",
+	}
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "annotated-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+		Annotations:   annotations,
+	})
+
+	recordRef, err := store.Push(testCtx, record)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = store.Delete(testCtx, recordRef) })
+
+	recordMeta, err := store.Lookup(testCtx, recordRef)
+	require.NoError(t, err)
+
+	for key, value := range annotations {
+		assert.Equal(t, value, recordMeta.GetAnnotations()[key], "annotation %s", key)
+	}
+}
+
 func TestAllVersionsSkillsAndLocatorsPreservation(t *testing.T) {
 	store := loadLocalStore(t)
 
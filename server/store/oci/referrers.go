@@ -30,7 +30,7 @@ type ReferrersLister interface {
 
 // PushReferrer pushes a generic RecordReferrer as an OCI artifact that references a record as its subject.
 func (s *store) PushReferrer(ctx context.Context, recordCID string, referrer *corev1.RecordReferrer) error {
-	referrersLogger.Debug("Pushing generic referrer to OCI store", "recordCID", recordCID, "type", referrer.GetType())
+	logging.WithContext(ctx, referrersLogger).Debug("Pushing generic referrer to OCI store", "recordCID", recordCID, "type", referrer.GetType())
 
 	if referrer == nil {
 		return status.Error(codes.InvalidArgument, "referrer is required") //nolint:wrapcheck
@@ -77,6 +77,8 @@ func (s *store) PushReferrer(ctx context.Context, recordCID string, referrer *co
 		return fmt.Errorf("failed to push referrer blob: %w", err)
 	}
 
+	s.metrics.transferred(directionUploaded, len(referrerBytes))
+
 	// Resolve the record manifest to get its descriptor for the subject field
 	recordManifestDesc, err := s.repo.Resolve(ctx, recordCID)
 	if err != nil {
@@ -95,8 +97,14 @@ func (s *store) PushReferrer(ctx context.Context, recordCID string, referrer *co
 		annotations["agntcy.dir.referrer.annotation."+key] = value
 	}
 
+	// Attestations are typed as DSSE envelopes so that generic OCI tooling can discover them
+	manifestArtifactType := ocispec.MediaTypeImageManifest
+	if ociArtifactType == AttestationArtifactMediaType {
+		manifestArtifactType = AttestationArtifactType
+	}
+
 	// Create the referrer manifest with proper OCI subject field
-	manifestDesc, err := oras.PackManifest(ctx, s.repo, oras.PackManifestVersion1_1, ocispec.MediaTypeImageManifest,
+	manifestDesc, err := oras.PackManifest(ctx, s.repo, oras.PackManifestVersion1_1, manifestArtifactType,
 		oras.PackManifestOptions{
 			Subject:             &recordManifestDesc,
 			ManifestAnnotations: annotations,
@@ -109,7 +117,7 @@ func (s *store) PushReferrer(ctx context.Context, recordCID string, referrer *co
 		return fmt.Errorf("failed to pack referrer manifest: %w", err)
 	}
 
-	referrersLogger.Debug("Referrer pushed successfully", "digest", manifestDesc.Digest.String(), "type", referrer.GetType())
+	logging.WithContext(ctx, referrersLogger).Debug("Referrer pushed successfully", "digest", manifestDesc.Digest.String(), "type", referrer.GetType())
 
 	return nil
 }
@@ -117,7 +125,9 @@ func (s *store) PushReferrer(ctx context.Context, recordCID string, referrer *co
 // WalkReferrers walks through referrers for a given record CID, calling walkFn for each referrer.
 // If referrerType is empty, all referrers are walked, otherwise only referrers of the specified type.
 func (s *store) WalkReferrers(ctx context.Context, recordCID string, referrerType string, walkFn func(*corev1.RecordReferrer) error) error {
-	referrersLogger.Debug("Walking referrers from OCI store", "recordCID", recordCID, "type", referrerType)
+	log := logging.WithContext(ctx, referrersLogger)
+
+	log.Debug("Walking referrers from OCI store", "recordCID", recordCID, "type", referrerType)
 
 	if recordCID == "" {
 		return status.Error(codes.InvalidArgument, "record CID is required") //nolint:wrapcheck
@@ -161,7 +171,7 @@ func (s *store) WalkReferrers(ctx context.Context, recordCID string, referrerTyp
 			// Extract referrer data from manifest
 			referrer, err := s.extractReferrerFromManifest(ctx, referrerDesc, recordCID)
 			if err != nil {
-				referrersLogger.Error("Failed to extract referrer from manifest", "digest", referrerDesc.Digest.String(), "error", err)
+				log.Error("Failed to extract referrer from manifest", "digest", referrerDesc.Digest.String(), "error", err)
 
 				continue // Skip this referrer but continue with others
 			}
@@ -173,7 +183,7 @@ func (s *store) WalkReferrers(ctx context.Context, recordCID string, referrerTyp
 				return err // Stop walking on error
 			}
 
-			referrersLogger.Debug("Referrer processed successfully", "digest", referrerDesc.Digest.String(), "type", referrer.GetType())
+			log.Debug("Referrer processed successfully", "digest", referrerDesc.Digest.String(), "type", referrer.GetType())
 		}
 
 		return nil // Continue with next batch
@@ -187,7 +197,7 @@ func (s *store) WalkReferrers(ctx context.Context, recordCID string, referrerTyp
 		return status.Errorf(codes.Internal, "failed to walk referrers for manifest %s: %v", recordManifestDesc.Digest.String(), err)
 	}
 
-	referrersLogger.Debug("Successfully walked referrers", "recordCID", recordCID, "type", referrerType)
+	log.Debug("Successfully walked referrers", "recordCID", recordCID, "type", referrerType)
 
 	return nil
 }
@@ -216,6 +226,8 @@ func (s *store) extractReferrerFromManifest(ctx context.Context, manifestDesc oc
 		return nil, status.Errorf(codes.Internal, "failed to read referrer data for CID %s: %v", recordCID, err)
 	}
 
+	s.metrics.transferred(directionDownloaded, len(referrerData))
+
 	referrer := &corev1.RecordReferrer{}
 
 	// If the referrer is not a signature, unmarshal the referrer from JSON
@@ -244,7 +256,7 @@ func (s *store) MediaTypeReferrerMatcher(expectedMediaType string) ReferrerMatch
 	return func(ctx context.Context, referrer ocispec.Descriptor) bool {
 		manifest, err := s.fetchAndParseManifestFromDescriptor(ctx, referrer)
 		if err != nil {
-			referrersLogger.Debug("Failed to fetch and parse referrer manifest", "digest", referrer.Digest.String(), "error", err)
+			logging.WithContext(ctx, referrersLogger).Debug("Failed to fetch and parse referrer manifest", "digest", referrer.Digest.String(), "error", err)
 
 			return false
 		}
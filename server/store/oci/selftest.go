@@ -0,0 +1,227 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// selfTestRepository is the repository the self-test pushes its probe blob to, so that
+// the records repository is not modified. Repository names cannot start with an underscore.
+const selfTestRepository = "dir-healthcheck"
+
+// Names of the self-test steps.
+const (
+	selfTestStepPing     = "ping"
+	selfTestStepPush     = "push"
+	selfTestStepDelete   = "delete"
+	selfTestStepListTags = "list-tags"
+)
+
+// SelfTest checks the store backend step by step: it pings the registry, pushes and deletes
+// a probe blob in the self-test repository unless skipWrite is set, and lists the tags of the
+// records repository. Local stores probe their directory instead.
+//
+// Steps are skipped once the ping failed, and the delete is skipped if the push failed.
+// Failed steps are classified as network, authentication or permission failures where possible.
+func (s *store) SelfTest(ctx context.Context, skipWrite bool) *storev1.SelfTestReport {
+	report := &storev1.SelfTestReport{
+		Passed:    true,
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	run := func(name string, skipReason string, step func(context.Context) error) bool {
+		result := &storev1.SelfTestStep{Name: name}
+		report.Steps = append(report.Steps, result)
+
+		if skipReason != "" {
+			result.Status = storev1.SelfTestStatus_SELF_TEST_STATUS_SKIPPED
+			result.Error = skipReason
+
+			return false
+		}
+
+		start := time.Now()
+		err := step(ctx)
+		result.Latency = durationpb.New(time.Since(start))
+
+		switch {
+		case err == nil:
+			result.Status = storev1.SelfTestStatus_SELF_TEST_STATUS_PASSED
+		case errors.Is(err, errdef.ErrUnsupported):
+			result.Status = storev1.SelfTestStatus_SELF_TEST_STATUS_SKIPPED
+			result.Error = err.Error()
+		default:
+			result.Status = storev1.SelfTestStatus_SELF_TEST_STATUS_FAILED
+			result.Failure = classifySelfTestFailure(err)
+			result.Error = err.Error()
+			report.Passed = false
+		}
+
+		return err == nil
+	}
+
+	var (
+		probe      content.Storage
+		data       = selfTestProbe()
+		descriptor = content.NewDescriptorFromBytes("application/octet-stream", data)
+		skipProbe  string
+		skipList   string
+	)
+
+	if !run(selfTestStepPing, "", s.ping) {
+		skipProbe, skipList = "ping failed", "ping failed"
+	} else if skipWrite {
+		skipProbe = "write probe is disabled"
+	}
+
+	pushed := run(selfTestStepPush, skipProbe, func(ctx context.Context) error {
+		var err error
+
+		probe, err = s.selfTestStorage()
+		if err != nil {
+			return err
+		}
+
+		err = probe.Push(ctx, descriptor, bytes.NewReader(data))
+		if err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+			return fmt.Errorf("failed to push probe blob to %s: %w", s.selfTestTarget(), err)
+		}
+
+		return nil
+	})
+
+	skipDelete := skipProbe
+	if skipDelete == "" && !pushed {
+		skipDelete = "push failed"
+	}
+
+	run(selfTestStepDelete, skipDelete, func(ctx context.Context) error {
+		deleter, ok := probe.(content.Deleter)
+		if !ok {
+			return fmt.Errorf("%w: store does not support deletes", errdef.ErrUnsupported)
+		}
+
+		err := deleter.Delete(ctx, descriptor)
+
+		var errResp *errcode.ErrorResponse
+		if errors.As(err, &errResp) && errResp.StatusCode == http.StatusMethodNotAllowed {
+			return fmt.Errorf("%w: registry does not support blob deletes", errdef.ErrUnsupported)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to delete probe blob from %s: %w", s.selfTestTarget(), err)
+		}
+
+		return nil
+	})
+
+	run(selfTestStepListTags, skipList, s.listTagsProbe)
+
+	return report
+}
+
+// selfTestProbe returns the content of the probe blob, unique to each self-test.
+func selfTestProbe() []byte {
+	return []byte("directory store self-test " + time.Now().UTC().Format(time.RFC3339Nano))
+}
+
+// selfTestStorage returns the storage the probe blob is pushed to: the self-test
+// repository of remote stores, and the OCI layout of local stores.
+func (s *store) selfTestStorage() (content.Storage, error) {
+	if _, ok := s.repo.(*remote.Repository); !ok {
+		storage, ok := s.repo.(content.Storage)
+		if !ok {
+			return nil, fmt.Errorf("%w: store does not support blob pushes", errdef.ErrUnsupported)
+		}
+
+		return storage, nil
+	}
+
+	cfg := s.config
+	cfg.RepositoryName = selfTestRepository
+
+	repo, err := NewORASRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.Blobs(), nil
+}
+
+// selfTestTarget describes where the probe blob is pushed to in errors.
+func (s *store) selfTestTarget() string {
+	if _, ok := s.repo.(*remote.Repository); ok {
+		return fmt.Sprintf("repository %s/%s", s.config.RegistryAddress, selfTestRepository)
+	}
+
+	return "local store " + s.config.LocalDir
+}
+
+// listTagsProbe lists the first page of tags of the records repository.
+// Repositories that do not exist yet pass, as they are created by the first push.
+func (s *store) listTagsProbe(ctx context.Context) error {
+	lister, ok := s.repo.(registry.TagLister)
+	if !ok {
+		return fmt.Errorf("%w: store does not support tag listing", errdef.ErrUnsupported)
+	}
+
+	errListed := errors.New("listed")
+
+	err := lister.Tags(ctx, "", func([]string) error {
+		return errListed
+	})
+
+	var errResp *errcode.ErrorResponse
+
+	switch {
+	case err == nil, errors.Is(err, errListed):
+		return nil
+	case errors.As(err, &errResp) && errResp.StatusCode == http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+}
+
+// classifySelfTestFailure returns the class of a failed self-test step.
+func classifySelfTestFailure(err error) storev1.SelfTestFailure {
+	var (
+		errResp *errcode.ErrorResponse
+		netErr  net.Error
+	)
+
+	switch {
+	case errors.As(err, &errResp):
+		switch errResp.StatusCode {
+		case http.StatusUnauthorized:
+			return storev1.SelfTestFailure_SELF_TEST_FAILURE_AUTH
+		case http.StatusForbidden:
+			return storev1.SelfTestFailure_SELF_TEST_FAILURE_PERMISSION
+		default:
+			return storev1.SelfTestFailure_SELF_TEST_FAILURE_OTHER
+		}
+	case errors.As(err, &netErr), errors.Is(err, context.DeadlineExceeded):
+		return storev1.SelfTestFailure_SELF_TEST_FAILURE_NETWORK
+	case errors.Is(err, os.ErrPermission):
+		return storev1.SelfTestFailure_SELF_TEST_FAILURE_PERMISSION
+	default:
+		return storev1.SelfTestFailure_SELF_TEST_FAILURE_OTHER
+	}
+}
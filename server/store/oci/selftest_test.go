@@ -0,0 +1,184 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// selfTestRegistry serves the registry endpoints used by the self-test.
+// Uploads are rejected with the given status if it is set.
+func selfTestRegistry(t *testing.T, uploadStatus int) string {
+	t.Helper()
+
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/v2/":
+			w.WriteHeader(http.StatusOK)
+
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/"+selfTestRepository+"/blobs/uploads/":
+			if uploadStatus != 0 {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(uploadStatus)
+				_, _ = w.Write([]byte(`{"errors":[{"code":"DENIED","message":"requested access to the resource is denied"}]}`))
+
+				return
+			}
+
+			w.Header().Set("Location", "/v2/"+selfTestRepository+"/blobs/uploads/probe")
+			w.WriteHeader(http.StatusAccepted)
+
+		case r.Method == http.MethodPut && r.URL.Path == "/v2/"+selfTestRepository+"/blobs/uploads/probe":
+			w.WriteHeader(http.StatusCreated)
+
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v2/"+selfTestRepository+"/blobs/"):
+			w.WriteHeader(http.StatusAccepted)
+
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/dir/tags/list":
+			// The records repository does not exist before the first push
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"code":"NAME_UNKNOWN","message":"repository name not known to registry"}]}`))
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(registry.Close)
+
+	return strings.TrimPrefix(registry.URL, "http://")
+}
+
+func stepStatuses(report *storev1.SelfTestReport) map[string]storev1.SelfTestStatus {
+	statuses := map[string]storev1.SelfTestStatus{}
+	for _, step := range report.GetSteps() {
+		statuses[step.GetName()] = step.GetStatus()
+	}
+
+	return statuses
+}
+
+func TestSelfTestLocal(t *testing.T) {
+	s := newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+
+	report := s.SelfTest(t.Context(), false)
+
+	assert.True(t, report.GetPassed(), "report: %v", report)
+	assert.Equal(t, map[string]storev1.SelfTestStatus{
+		selfTestStepPing:     storev1.SelfTestStatus_SELF_TEST_STATUS_PASSED,
+		selfTestStepPush:     storev1.SelfTestStatus_SELF_TEST_STATUS_PASSED,
+		selfTestStepDelete:   storev1.SelfTestStatus_SELF_TEST_STATUS_PASSED,
+		selfTestStepListTags: storev1.SelfTestStatus_SELF_TEST_STATUS_PASSED,
+	}, stepStatuses(report))
+}
+
+func TestSelfTestRegistry(t *testing.T) {
+	s := newRemoteStore(t, selfTestRegistry(t, 0))
+
+	report := s.SelfTest(t.Context(), false)
+
+	require.True(t, report.GetPassed(), "report: %v", report)
+	assert.Equal(t, storev1.SelfTestStatus_SELF_TEST_STATUS_PASSED, stepStatuses(report)[selfTestStepDelete])
+
+	for _, step := range report.GetSteps() {
+		assert.NotNil(t, step.GetLatency(), "step %s has no latency", step.GetName())
+	}
+}
+
+func TestSelfTestSkipWrite(t *testing.T) {
+	s := newRemoteStore(t, selfTestRegistry(t, http.StatusForbidden))
+
+	report := s.SelfTest(t.Context(), true)
+
+	assert.True(t, report.GetPassed(), "report: %v", report)
+	assert.Equal(t, storev1.SelfTestStatus_SELF_TEST_STATUS_SKIPPED, stepStatuses(report)[selfTestStepPush])
+	assert.Equal(t, storev1.SelfTestStatus_SELF_TEST_STATUS_SKIPPED, stepStatuses(report)[selfTestStepDelete])
+}
+
+func TestSelfTestFailures(t *testing.T) {
+	unauthorized := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer unauthorized.Close()
+
+	tests := []struct {
+		name     string
+		address  string
+		step     string
+		failure  storev1.SelfTestFailure
+		message  string
+		skipped  []string
+		username string
+	}{
+		{
+			name:     "wrong credentials",
+			address:  strings.TrimPrefix(unauthorized.URL, "http://"),
+			username: "wrong",
+			step:     selfTestStepPing,
+			failure:  storev1.SelfTestFailure_SELF_TEST_FAILURE_AUTH,
+			message:  "401",
+			skipped:  []string{selfTestStepPush, selfTestStepDelete, selfTestStepListTags},
+		},
+		{
+			name:    "unreachable registry",
+			address: "127.0.0.1:9", // Nothing listens on the discard port
+			step:    selfTestStepPing,
+			failure: storev1.SelfTestFailure_SELF_TEST_FAILURE_NETWORK,
+			message: "registry is not reachable",
+			skipped: []string{selfTestStepPush, selfTestStepDelete, selfTestStepListTags},
+		},
+		{
+			name:    "read-only credentials",
+			address: selfTestRegistry(t, http.StatusForbidden),
+			step:    selfTestStepPush,
+			failure: storev1.SelfTestFailure_SELF_TEST_FAILURE_PERMISSION,
+			message: "failed to push probe blob to repository",
+			skipped: []string{selfTestStepDelete},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(ociconfig.Config{
+				RegistryAddress: tt.address,
+				RepositoryName:  "dir",
+				AuthConfig:      ociconfig.AuthConfig{Insecure: true, Username: tt.username, Password: tt.username},
+			})
+			require.NoError(t, err)
+
+			remoteStore, ok := s.(*store)
+			require.True(t, ok)
+
+			report := remoteStore.SelfTest(t.Context(), false)
+			require.False(t, report.GetPassed())
+
+			var failed *storev1.SelfTestStep
+
+			for _, step := range report.GetSteps() {
+				if step.GetStatus() == storev1.SelfTestStatus_SELF_TEST_STATUS_FAILED {
+					require.Nil(t, failed, "only %s must fail, report: %v", tt.step, report)
+					failed = step
+				}
+			}
+
+			require.NotNil(t, failed)
+			assert.Equal(t, tt.step, failed.GetName())
+			assert.Equal(t, tt.failure, failed.GetFailure())
+			assert.Contains(t, failed.GetError(), tt.message)
+
+			for _, name := range tt.skipped {
+				assert.Equal(t, storev1.SelfTestStatus_SELF_TEST_STATUS_SKIPPED, stepStatuses(report)[name], "step %s", name)
+			}
+		})
+	}
+}
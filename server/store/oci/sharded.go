@@ -0,0 +1,826 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/agntcy/dir/utils/logging"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/errdef"
+)
+
+// localRootRepository is the repository of the local directory itself,
+// which holds the records of local stores without sharding.
+const localRootRepository = "."
+
+// shardedStore distributes records across repositories resolved from their metadata
+// by the repository template, e.g. "agents/{team}". Records lacking the attributes
+// required by the template, and bundles, are stored in the fallback repository.
+//
+// Records are located by CID in any repository, as the template or the record
+// attributes used by it may change. Located records are remembered, other
+// repositories are probed on a miss and discovered if the record is still not found.
+type shardedStore struct {
+	config   ociconfig.Config
+	template *ociconfig.ShardTemplate
+	metrics  *storeMetrics
+
+	mu sync.RWMutex
+	// shards are the stores of the known repositories.
+	shards map[string]*store
+	// located maps the CIDs of located records to their repository.
+	located map[string]string
+}
+
+func newShardedStore(cfg ociconfig.Config, storeMetrics *storeMetrics) (*shardedStore, error) {
+	template, err := ociconfig.ParseShardTemplate(cfg.Sharding.RepositoryTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sharding repository template: %w", err)
+	}
+
+	s := &shardedStore{
+		config:   cfg,
+		template: template,
+		metrics:  storeMetrics,
+		shards:   make(map[string]*store),
+		located:  make(map[string]string),
+	}
+
+	if _, err := s.shard(s.fallback()); err != nil {
+		return nil, err
+	}
+
+	if err := s.discover(context.Background()); err != nil {
+		logger.Warn("Failed to discover repositories of sharded store", "error", err)
+	}
+
+	return s, nil
+}
+
+// fallback returns the repository of records that cannot be sharded.
+func (s *shardedStore) fallback() string {
+	switch {
+	case s.config.Sharding.FallbackRepository != "":
+		return s.config.Sharding.FallbackRepository
+	case s.config.LocalDir != "":
+		return localRootRepository
+	default:
+		return s.config.RepositoryName
+	}
+}
+
+// repositoryFor returns the repository the record belongs to.
+func (s *shardedStore) repositoryFor(record *corev1.Record) string {
+	data, err := adapters.NewRecordAdapter(record).GetRecordData()
+	if err != nil {
+		return s.fallback()
+	}
+
+	repository, ok := s.template.Repository(data.GetName(), data.GetAnnotations())
+	if !ok {
+		return s.fallback()
+	}
+
+	return repository
+}
+
+// shard returns the store of the repository, creating it if needed.
+func (s *shardedStore) shard(repository string) (*store, error) {
+	s.mu.RLock()
+	shard, ok := s.shards[repository]
+	s.mu.RUnlock()
+
+	if ok {
+		return shard, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if shard, ok := s.shards[repository]; ok {
+		return shard, nil
+	}
+
+	cfg := s.config
+	cfg.Sharding = ociconfig.ShardingConfig{}
+
+	if cfg.LocalDir != "" {
+		cfg.LocalDir = filepath.Join(cfg.LocalDir, filepath.FromSlash(repository))
+	} else {
+		cfg.RepositoryName = repository
+	}
+
+	shard, err := newStore(cfg, s.metrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create store for repository %s: %w", repository, err)
+	}
+
+	s.shards[repository] = shard
+
+	return shard, nil
+}
+
+// repositories returns the known repositories in lexical order.
+func (s *shardedStore) repositories() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	repositories := make([]string, 0, len(s.shards))
+	for repository := range s.shards {
+		repositories = append(repositories, repository)
+	}
+
+	slices.Sort(repositories)
+
+	return repositories
+}
+
+// discover adds the existing repositories that may hold records to the known repositories:
+// the subdirectories holding an OCI layout for local stores, and the repositories of the
+// registry catalog under the static prefix of the template for remote stores.
+func (s *shardedStore) discover(ctx context.Context) error {
+	var (
+		repositories []string
+		err          error
+	)
+
+	if s.config.LocalDir != "" {
+		repositories, err = discoverLocalRepositories(s.config.LocalDir)
+	} else {
+		repositories, err = s.discoverRemoteRepositories(ctx)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, repository := range repositories {
+		if _, err := s.shard(repository); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func discoverLocalRepositories(root string) ([]string, error) {
+	var repositories []string
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.IsDir() {
+			return nil
+		}
+
+		// Skip the content of layouts, which may hold nested repositories
+		if entry.Name() == "blobs" && isLocalLayout(filepath.Dir(path)) {
+			return filepath.SkipDir
+		}
+
+		if isLocalLayout(path) {
+			repository, err := filepath.Rel(root, path)
+			if err != nil {
+				return fmt.Errorf("failed to resolve repository of %s: %w", path, err)
+			}
+
+			repositories = append(repositories, filepath.ToSlash(repository))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover local repositories: %w", err)
+	}
+
+	return repositories, nil
+}
+
+func isLocalLayout(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ocispec.ImageLayoutFile))
+
+	return err == nil
+}
+
+func (s *shardedStore) discoverRemoteRepositories(ctx context.Context) ([]string, error) {
+	registry, err := newORASRegistry(s.config)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := s.template.StaticPrefix()
+	fallback := s.fallback()
+
+	var repositories []string
+
+	err = registry.Repositories(ctx, "", func(page []string) error {
+		for _, repository := range page {
+			if repository == fallback || strings.HasPrefix(repository, prefix) {
+				repositories = append(repositories, repository)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registry repositories: %w", err)
+	}
+
+	return repositories, nil
+}
+
+// locate returns the store of the repository holding the record or bundle with the CID.
+func (s *shardedStore) locate(ctx context.Context, cid string) (*store, error) {
+	s.mu.RLock()
+	repository, ok := s.located[cid]
+	s.mu.RUnlock()
+
+	if ok {
+		return s.shard(repository)
+	}
+
+	probed := make(map[string]bool)
+
+	for attempt := 0; attempt < 2; attempt++ {
+		for _, repository := range s.repositories() {
+			if probed[repository] {
+				continue
+			}
+
+			probed[repository] = true
+
+			shard, err := s.shard(repository)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = shard.repo.Resolve(ctx, cid)
+			if err == nil {
+				s.remember(cid, repository)
+
+				return shard, nil
+			}
+
+			if !errors.Is(err, errdef.ErrNotFound) && !isRepositoryNotFound(err) {
+				return nil, status.Errorf(codes.Internal, "failed to locate %s in repository %s: %v", cid, repository, err)
+			}
+		}
+
+		// The record may be in a repository created by another instance
+		if attempt == 0 {
+			if err := s.discover(ctx); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to locate %s: %v", cid, err)
+			}
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "record not found: %s", cid)
+}
+
+func (s *shardedStore) remember(cid, repository string) {
+	s.mu.Lock()
+	s.located[cid] = repository
+	s.mu.Unlock()
+}
+
+func (s *shardedStore) forget(cid string) {
+	s.mu.Lock()
+	delete(s.located, cid)
+	s.mu.Unlock()
+}
+
+// Push stores the record in the repository resolved from its metadata.
+// Records that already exist in another repository are left in place, see Reshard.
+func (s *shardedStore) Push(ctx context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
+	s.mu.RLock()
+	repository, ok := s.located[record.GetCid()]
+	s.mu.RUnlock()
+
+	if !ok {
+		repository = s.repositoryFor(record)
+	}
+
+	shard, err := s.shard(repository)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to push record: %v", err)
+	}
+
+	ref, err := shard.Push(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	s.remember(ref.GetCid(), repository)
+
+	return ref, nil
+}
+
+func (s *shardedStore) PreviewPush(ctx context.Context, record *corev1.Record) (*storev1.PushPreview, error) {
+	shard, err := s.shard(s.repositoryFor(record))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to preview push: %v", err)
+	}
+
+	preview, err := shard.PreviewPush(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	if !preview.GetAlreadyExists() {
+		if _, err := s.locate(ctx, preview.GetCid()); err == nil {
+			preview.AlreadyExists = true
+		}
+	}
+
+	return preview, nil
+}
+
+func (s *shardedStore) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	shard, err := s.locate(ctx, ref.GetCid())
+	if err != nil {
+		return nil, err
+	}
+
+	return shard.Lookup(ctx, ref)
+}
+
+func (s *shardedStore) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	shard, err := s.locate(ctx, ref.GetCid())
+	if err != nil {
+		return nil, err
+	}
+
+	return shard.Pull(ctx, ref)
+}
+
+func (s *shardedStore) Delete(ctx context.Context, ref *corev1.RecordRef) error {
+	if err := validateRecordRef(ref); err != nil {
+		return err
+	}
+
+	shard, err := s.locate(ctx, ref.GetCid())
+	if err != nil {
+		return err
+	}
+
+	if err := shard.Delete(ctx, ref); err != nil {
+		return err
+	}
+
+	s.forget(ref.GetCid())
+
+	return nil
+}
+
+func (s *shardedStore) Untag(ctx context.Context, ref *corev1.RecordRef) error {
+	if err := validateRecordRef(ref); err != nil {
+		return err
+	}
+
+	shard, err := s.locate(ctx, ref.GetCid())
+	if err != nil {
+		return err
+	}
+
+	return shard.Untag(ctx, ref)
+}
+
+// PushBundle stores the bundle in the fallback repository, as bundles have no record metadata.
+func (s *shardedStore) PushBundle(ctx context.Context, bundle *corev1.RecordBundle) (*corev1.RecordRef, error) {
+	shard, err := s.shard(s.fallback())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to push bundle: %v", err)
+	}
+
+	return shard.PushBundle(ctx, bundle)
+}
+
+func (s *shardedStore) PullBundle(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordBundle, error) {
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	shard, err := s.locate(ctx, ref.GetCid())
+	if err != nil {
+		return nil, err
+	}
+
+	return shard.PullBundle(ctx, ref)
+}
+
+func (s *shardedStore) SetLifecycle(ctx context.Context, ref *corev1.RecordRef, lifecycle *corev1.Lifecycle) (*corev1.RecordMeta, error) {
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	shard, err := s.locate(ctx, ref.GetCid())
+	if err != nil {
+		return nil, err
+	}
+
+	return shard.SetLifecycle(ctx, ref, lifecycle)
+}
+
+func (s *shardedStore) SetMetadata(ctx context.Context, ref *corev1.RecordRef, update map[string]string) (*corev1.RecordMeta, error) {
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	shard, err := s.locate(ctx, ref.GetCid())
+	if err != nil {
+		return nil, err
+	}
+
+	return shard.SetMetadata(ctx, ref, update)
+}
+
+func (s *shardedStore) GetMetadata(ctx context.Context, ref *corev1.RecordRef) (map[string]string, error) {
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	shard, err := s.locate(ctx, ref.GetCid())
+	if err != nil {
+		return nil, err
+	}
+
+	return shard.GetMetadata(ctx, ref)
+}
+
+func (s *shardedStore) PushReferrer(ctx context.Context, recordCID string, referrer *corev1.RecordReferrer) error {
+	shard, err := s.locate(ctx, recordCID)
+	if err != nil {
+		return err
+	}
+
+	return shard.PushReferrer(ctx, recordCID, referrer)
+}
+
+func (s *shardedStore) WalkReferrers(ctx context.Context, recordCID string, referrerType string, walkFn func(*corev1.RecordReferrer) error) error {
+	shard, err := s.locate(ctx, recordCID)
+	if err != nil {
+		return err
+	}
+
+	return shard.WalkReferrers(ctx, recordCID, referrerType, walkFn)
+}
+
+func (s *shardedStore) VerifyWithZot(ctx context.Context, recordCID string) (bool, error) {
+	shard, err := s.locate(ctx, recordCID)
+	if err != nil {
+		return false, err
+	}
+
+	return shard.VerifyWithZot(ctx, recordCID)
+}
+
+// Resolve resolves a discovery tag in the first repository holding it, in lexical order.
+// Records with the same name are stored in the same repository if the template only depends on the name.
+func (s *shardedStore) Resolve(ctx context.Context, tag string) (*corev1.RecordRef, string, error) {
+	if normalized := corev1.NormalizeTag(tag); corev1.IsValidCID(normalized) {
+		shard, err := s.locate(ctx, normalized)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return shard.Resolve(ctx, tag)
+	}
+
+	if err := s.discover(ctx); err != nil {
+		logging.WithContext(ctx, logger).Warn("Failed to discover repositories of sharded store", "error", err)
+	}
+
+	var lastErr error
+
+	for _, repository := range s.repositories() {
+		shard, err := s.shard(repository)
+		if err != nil {
+			return nil, "", status.Errorf(codes.Internal, "failed to resolve tag: %v", err)
+		}
+
+		ref, warning, err := shard.Resolve(ctx, tag)
+		if err == nil {
+			s.remember(ref.GetCid(), repository)
+
+			return ref, warning, nil
+		}
+
+		lastErr = err
+		if status.Code(err) != codes.NotFound {
+			return nil, "", err
+		}
+	}
+
+	return nil, "", lastErr
+}
+
+// List calls fn for every record of all repositories, in lexical order of their CIDs.
+func (s *shardedStore) List(ctx context.Context, fn func(*corev1.RecordRef) error) error {
+	if err := s.discover(ctx); err != nil {
+		return fmt.Errorf("failed to list records: %w", err)
+	}
+
+	var cids []string
+
+	for _, repository := range s.repositories() {
+		shard, err := s.shard(repository)
+		if err != nil {
+			return fmt.Errorf("failed to list records: %w", err)
+		}
+
+		err = shard.List(ctx, func(ref *corev1.RecordRef) error {
+			cids = append(cids, ref.GetCid())
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	slices.Sort(cids)
+
+	for _, cid := range slices.Compact(cids) {
+		if err := fn(&corev1.RecordRef{Cid: cid}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Fsck checks the consistency of all repositories, reporting a single summary for all of them.
+func (s *shardedStore) Fsck(ctx context.Context, repair bool, fn func(*storev1.FsckResponse) error) error {
+	if err := s.discover(ctx); err != nil {
+		return status.Errorf(codes.Internal, "failed to discover repositories: %v", err)
+	}
+
+	total := &storev1.FsckSummary{}
+
+	for _, repository := range s.repositories() {
+		shard, err := s.shard(repository)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to check repository %s: %v", repository, err)
+		}
+
+		err = shard.Fsck(ctx, repair, func(resp *storev1.FsckResponse) error {
+			summary := resp.GetSummary()
+			if summary == nil {
+				return fn(resp)
+			}
+
+			total.CheckedTags += summary.GetCheckedTags()
+			total.CheckedRecords += summary.GetCheckedRecords()
+			total.Issues += summary.GetIssues()
+			total.Repaired += summary.GetRepaired()
+			total.Compacted = total.GetCompacted() || summary.GetCompacted()
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Repairs may have deleted located records
+	s.mu.Lock()
+	clear(s.located)
+	s.mu.Unlock()
+
+	return fn(&storev1.FsckResponse{Response: &storev1.FsckResponse_Summary{Summary: total}})
+}
+
+// Flush waits for pending tag operations of all repositories to complete.
+func (s *shardedStore) Flush(ctx context.Context) error {
+	var errs error
+
+	for _, repository := range s.repositories() {
+		shard, err := s.shard(repository)
+		if err != nil {
+			errs = errors.Join(errs, err)
+
+			continue
+		}
+
+		errs = errors.Join(errs, shard.Flush(ctx))
+	}
+
+	return errs
+}
+
+// CheckHealth verifies that the OCI backend is reachable, which is shared by all repositories.
+func (s *shardedStore) CheckHealth(ctx context.Context) error {
+	shard, err := s.shard(s.fallback())
+	if err != nil {
+		return err
+	}
+
+	return shard.CheckHealth(ctx)
+}
+
+// SelfTest checks the OCI backend shared by all repositories, listing the tags of the fallback repository.
+func (s *shardedStore) SelfTest(ctx context.Context, skipWrite bool) *storev1.SelfTestReport {
+	shard, err := s.shard(s.fallback())
+	if err != nil {
+		return &storev1.SelfTestReport{
+			StartedAt: time.Now().UTC().Format(time.RFC3339),
+			Steps: []*storev1.SelfTestStep{{
+				Name:    selfTestStepPing,
+				Status:  storev1.SelfTestStatus_SELF_TEST_STATUS_FAILED,
+				Failure: storev1.SelfTestFailure_SELF_TEST_FAILURE_OTHER,
+				Error:   err.Error(),
+			}},
+		}
+	}
+
+	return shard.SelfTest(ctx, skipWrite)
+}
+
+// Reshard moves the records stored outside of the repository resolved for them by the current template,
+// e.g. after the template changed or sharding was enabled for an existing store.
+// Every misplaced record is reported to fn, followed by the summary.
+//
+// A record is copied with its referrers and re-tagged in the target repository
+// before it is deleted from the source repository, so that it is always reachable.
+func (s *shardedStore) Reshard(ctx context.Context, dryRun bool, fn func(*storev1.ReshardResponse) error) error {
+	if err := s.discover(ctx); err != nil {
+		return status.Errorf(codes.Internal, "failed to discover repositories: %v", err)
+	}
+
+	logging.WithContext(ctx, logger).Info("Resharding store", "template", s.config.Sharding.RepositoryTemplate, "dryRun", dryRun)
+
+	summary := &storev1.ReshardSummary{}
+
+	for _, repository := range s.repositories() {
+		source, err := s.shard(repository)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to reshard repository %s: %v", repository, err)
+		}
+
+		var cids []string
+
+		err = source.List(ctx, func(ref *corev1.RecordRef) error {
+			cids = append(cids, ref.GetCid())
+
+			return nil
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list records of repository %s: %v", repository, err)
+		}
+
+		for _, cid := range cids {
+			if err := ctx.Err(); err != nil {
+				return status.FromContextError(err).Err()
+			}
+
+			summary.CheckedRecords++
+
+			move, err := s.reshardRecord(ctx, source, repository, cid, dryRun)
+			if err != nil {
+				return err
+			}
+
+			if move == nil {
+				continue
+			}
+
+			summary.Misplaced++
+
+			switch {
+			case move.GetMoved():
+				summary.Moved++
+			case move.GetError() != "":
+				summary.Failed++
+			}
+
+			if err := fn(&storev1.ReshardResponse{Response: &storev1.ReshardResponse_Move{Move: move}}); err != nil {
+				return err
+			}
+		}
+	}
+
+	logging.WithContext(ctx, logger).Info("Store resharding completed",
+		"records", summary.GetCheckedRecords(),
+		"misplaced", summary.GetMisplaced(),
+		"moved", summary.GetMoved(),
+		"failed", summary.GetFailed())
+
+	return fn(&storev1.ReshardResponse{Response: &storev1.ReshardResponse_Summary{Summary: summary}})
+}
+
+// reshardRecord moves the record with the CID from the source repository to its target repository.
+// It returns nil if the record is already stored in its target repository.
+func (s *shardedStore) reshardRecord(ctx context.Context, source *store, repository, cid string, dryRun bool) (*storev1.ReshardMove, error) {
+	move := &storev1.ReshardMove{Cid: cid, SourceRepository: repository}
+
+	manifest, manifestDesc, err := source.fetchAndParseManifest(ctx, cid)
+	if err != nil {
+		move.Error = status.Convert(err).Message()
+
+		return move, nil
+	}
+
+	var tags []string
+
+	if manifest.Annotations[manifestDirObjectTypeKey] == objectTypeBundle {
+		move.TargetRepository = s.fallback()
+	} else {
+		record, err := source.pull(ctx, &corev1.RecordRef{Cid: cid})
+		if err != nil {
+			move.Error = status.Convert(err).Message()
+
+			return move, nil
+		}
+
+		move.TargetRepository = s.repositoryFor(record)
+		tags = record.DiscoveryTags()
+
+		// Metadata tags are moved along, the metadata manifest is copied as a referrer
+		if len(s.config.MetadataTagKeys) > 0 {
+			metadata, err := source.operationalMetadata(ctx, *manifestDesc)
+			if err != nil {
+				move.Error = err.Error()
+
+				return move, nil
+			}
+
+			tags = append(tags, record.MetadataTags(metadata, s.config.MetadataTagKeys)...)
+		}
+	}
+
+	if move.GetTargetRepository() == repository {
+		return nil, nil //nolint:nilnil
+	}
+
+	if dryRun {
+		return move, nil
+	}
+
+	if err := s.moveRecord(ctx, source, move.GetTargetRepository(), cid, *manifestDesc, tags); err != nil {
+		logging.WithContext(ctx, logger).Warn("Failed to move record", "cid", cid, "source", repository, "target", move.GetTargetRepository(), "error", err)
+
+		move.Error = err.Error()
+
+		return move, nil
+	}
+
+	logging.WithContext(ctx, logger).Info("Moved record", "cid", cid, "source", repository, "target", move.GetTargetRepository())
+
+	move.Moved = true
+
+	return move, nil
+}
+
+func (s *shardedStore) moveRecord(ctx context.Context, source *store, repository, cid string, manifestDesc ocispec.Descriptor, tags []string) error {
+	target, err := s.shard(repository)
+	if err != nil {
+		return err
+	}
+
+	// Copy the manifest with its referrers, such as signatures and lifecycle manifests
+	if _, err := oras.ExtendedCopy(ctx, source.repo, cid, target.repo, cid, oras.DefaultExtendedCopyOptions); err != nil {
+		return fmt.Errorf("failed to copy record: %w", err)
+	}
+
+	if len(tags) > 0 {
+		if err := target.tagManifest(ctx, cid, manifestDesc, tags); err != nil {
+			return fmt.Errorf("failed to tag record: %w", err)
+		}
+	}
+
+	s.remember(cid, repository)
+
+	if err := source.Delete(ctx, &corev1.RecordRef{Cid: cid}); err != nil {
+		return fmt.Errorf("failed to delete record from source repository: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newShardedLocalStore(t *testing.T, dir string, sharding ociconfig.ShardingConfig) *shardedStore {
+	t.Helper()
+
+	s, err := New(ociconfig.Config{LocalDir: dir, Sharding: sharding})
+	require.NoError(t, err)
+
+	sharded, ok := s.(*shardedStore)
+	require.True(t, ok)
+
+	return sharded
+}
+
+func newTeamRecord(name, team string) *corev1.Record {
+	annotations := map[string]string{}
+	if team != "" {
+		annotations["team"] = team
+	}
+
+	return corev1.New(&typesv1alpha1.Record{
+		Name:          name,
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+		Annotations:   annotations,
+	})
+}
+
+// requireInRepository asserts that the record is stored in the local repository.
+func requireInRepository(t *testing.T, dir, repository, cid string) {
+	t.Helper()
+
+	repo := newLocalStore(t, filepath.Join(dir, filepath.FromSlash(repository)), ociconfig.CompressionConfig{})
+
+	_, err := repo.repo.Resolve(t.Context(), cid)
+	require.NoError(t, err, "record %s must be stored in repository %s", cid, repository)
+}
+
+func TestShardedStoreCrossShardPull(t *testing.T) {
+	dir := t.TempDir()
+	s := newShardedLocalStore(t, dir, ociconfig.ShardingConfig{RepositoryTemplate: "agents/{team}"})
+
+	records := []*corev1.Record{
+		newTeamRecord("alpha-agent", "Alpha"),
+		newTeamRecord("beta-agent", "beta"),
+		newTeamRecord("untagged-agent", ""),
+	}
+
+	for _, record := range records {
+		_, err := s.Push(t.Context(), record)
+		require.NoError(t, err)
+	}
+
+	requireInRepository(t, dir, "agents/alpha", records[0].GetCid())
+	requireInRepository(t, dir, "agents/beta", records[1].GetCid())
+	requireInRepository(t, dir, localRootRepository, records[2].GetCid())
+
+	// A new store does not know where records are and must locate them
+	reopened := newShardedLocalStore(t, dir, ociconfig.ShardingConfig{RepositoryTemplate: "agents/{team}"})
+
+	for _, record := range records {
+		ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+		pulled, err := reopened.Pull(t.Context(), ref)
+		require.NoError(t, err)
+		assert.Equal(t, record.GetCid(), pulled.GetCid())
+
+		meta, err := reopened.Lookup(t.Context(), ref)
+		require.NoError(t, err)
+		assert.Equal(t, record.GetCid(), meta.GetCid())
+	}
+
+	var listed []string
+
+	err := reopened.List(t.Context(), func(ref *corev1.RecordRef) error {
+		listed = append(listed, ref.GetCid())
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{records[0].GetCid(), records[1].GetCid(), records[2].GetCid()}, listed)
+
+	ref, _, err := reopened.Resolve(t.Context(), "beta-agent:v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, records[1].GetCid(), ref.GetCid())
+
+	// Deleted records are no longer found in any repository
+	require.NoError(t, reopened.Delete(t.Context(), &corev1.RecordRef{Cid: records[0].GetCid()}))
+
+	_, err = reopened.Pull(t.Context(), &corev1.RecordRef{Cid: records[0].GetCid()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestShardedStoreFallback(t *testing.T) {
+	dir := t.TempDir()
+	s := newShardedLocalStore(t, dir, ociconfig.ShardingConfig{
+		RepositoryTemplate: "agents/{team}",
+		FallbackRepository: "unsharded",
+	})
+
+	for name, team := range map[string]string{
+		"missing attribute":             "",
+		"attribute without valid chars": "!!!",
+	} {
+		t.Run(name, func(t *testing.T) {
+			record := newTeamRecord("fallback-"+team, team)
+
+			ref, err := s.Push(t.Context(), record)
+			require.NoError(t, err)
+
+			requireInRepository(t, dir, "unsharded", ref.GetCid())
+
+			pulled, err := s.Pull(t.Context(), ref)
+			require.NoError(t, err)
+			assert.Equal(t, record.GetCid(), pulled.GetCid())
+		})
+	}
+
+	_, err := os.Stat(filepath.Join(dir, "agents"))
+	assert.True(t, os.IsNotExist(err), "no record must be sharded")
+}
+
+func TestShardedStoreReshard(t *testing.T) {
+	dir := t.TempDir()
+
+	// Records pushed before sharding was enabled are stored in the local directory itself
+	unsharded := newLocalStore(t, dir, ociconfig.CompressionConfig{})
+
+	sharded := newTeamRecord("alpha-agent", "alpha")
+	unmatched := newTeamRecord("untagged-agent", "")
+
+	for _, record := range []*corev1.Record{sharded, unmatched} {
+		_, err := unsharded.Push(t.Context(), record)
+		require.NoError(t, err)
+	}
+
+	_, err := unsharded.SetLifecycle(t.Context(), &corev1.RecordRef{Cid: sharded.GetCid()}, &corev1.Lifecycle{
+		Status: corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED,
+		Reason: "superseded",
+	})
+	require.NoError(t, err)
+
+	s := newShardedLocalStore(t, dir, ociconfig.ShardingConfig{RepositoryTemplate: "agents/{team}"})
+
+	reshard := func(dryRun bool) ([]*storev1.ReshardMove, *storev1.ReshardSummary) {
+		var (
+			moves   []*storev1.ReshardMove
+			summary *storev1.ReshardSummary
+		)
+
+		err := s.Reshard(t.Context(), dryRun, func(resp *storev1.ReshardResponse) error {
+			if move := resp.GetMove(); move != nil {
+				moves = append(moves, move)
+			}
+
+			if resp.GetSummary() != nil {
+				summary = resp.GetSummary()
+			}
+
+			return nil
+		})
+		require.NoError(t, err)
+		require.NotNil(t, summary)
+
+		return moves, summary
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		moves, summary := reshard(true)
+		require.Len(t, moves, 1)
+		assert.Equal(t, sharded.GetCid(), moves[0].GetCid())
+		assert.Equal(t, localRootRepository, moves[0].GetSourceRepository())
+		assert.Equal(t, "agents/alpha", moves[0].GetTargetRepository())
+		assert.False(t, moves[0].GetMoved())
+		assert.Equal(t, uint64(2), summary.GetCheckedRecords())
+		assert.Equal(t, uint64(0), summary.GetMoved())
+
+		requireInRepository(t, dir, localRootRepository, sharded.GetCid())
+	})
+
+	t.Run("move", func(t *testing.T) {
+		moves, summary := reshard(false)
+		require.Len(t, moves, 1)
+		assert.True(t, moves[0].GetMoved(), moves[0].GetError())
+		assert.Equal(t, uint64(1), summary.GetMoved())
+		assert.Equal(t, uint64(0), summary.GetFailed())
+
+		requireInRepository(t, dir, "agents/alpha", sharded.GetCid())
+		requireInRepository(t, dir, localRootRepository, unmatched.GetCid())
+
+		source := newLocalStore(t, dir, ociconfig.CompressionConfig{})
+
+		_, err := source.repo.Resolve(t.Context(), sharded.GetCid())
+		require.Error(t, err, "moved record must be deleted from the source repository")
+
+		// The lifecycle and discovery tags are moved with the record
+		meta, err := s.Lookup(t.Context(), &corev1.RecordRef{Cid: sharded.GetCid()})
+		require.NoError(t, err)
+		assert.Equal(t, corev1.LifecycleStatus_LIFECYCLE_STATUS_DEPRECATED, meta.GetLifecycle().GetStatus())
+
+		ref, _, err := s.Resolve(t.Context(), "alpha-agent:v1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, sharded.GetCid(), ref.GetCid())
+	})
+
+	t.Run("nothing left to move", func(t *testing.T) {
+		moves, summary := reshard(false)
+		assert.Empty(t, moves)
+		assert.Equal(t, uint64(2), summary.GetCheckedRecords())
+	})
+}
@@ -11,6 +11,7 @@ import (
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	signv1 "github.com/agntcy/dir/api/sign/v1"
 	"github.com/agntcy/dir/utils/cosign"
+	"github.com/agntcy/dir/utils/logging"
 	"github.com/agntcy/dir/utils/zot"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"google.golang.org/grpc/codes"
@@ -19,7 +20,7 @@ import (
 
 // pushSignature stores OCI signature artifacts for a record using cosign attach signature and uploads public key to zot for verification.
 func (s *store) pushSignature(ctx context.Context, recordCID string, referrer *corev1.RecordReferrer) error {
-	referrersLogger.Debug("Pushing signature artifact to OCI store", "recordCID", recordCID)
+	logging.WithContext(ctx, referrersLogger).Debug("Pushing signature artifact to OCI store", "recordCID", recordCID)
 
 	// Decode the signature from the referrer
 	signature := &signv1.Signature{}
@@ -36,14 +37,14 @@ func (s *store) pushSignature(ctx context.Context, recordCID string, referrer *c
 		return status.Errorf(codes.Internal, "failed to attach signature with cosign: %v", err)
 	}
 
-	referrersLogger.Debug("Signature attached successfully using cosign", "recordCID", recordCID)
+	logging.WithContext(ctx, referrersLogger).Debug("Signature attached successfully using cosign", "recordCID", recordCID)
 
 	return nil
 }
 
 // uploadPublicKey uploads a public key to zot for signature verification.
 func (s *store) uploadPublicKey(ctx context.Context, referrer *corev1.RecordReferrer) error {
-	referrersLogger.Debug("Uploading public key to zot for signature verification")
+	logging.WithContext(ctx, referrersLogger).Debug("Uploading public key to zot for signature verification")
 
 	// Decode the public key from the referrer
 	pk := &signv1.PublicKey{}
@@ -67,14 +68,14 @@ func (s *store) uploadPublicKey(ctx context.Context, referrer *corev1.RecordRefe
 		return status.Errorf(codes.Internal, "failed to upload public key to zot for verification: %v", err)
 	}
 
-	referrersLogger.Debug("Successfully uploaded public key to zot for verification")
+	logging.WithContext(ctx, referrersLogger).Debug("Successfully uploaded public key to zot for verification")
 
 	return nil
 }
 
 // attachSignatureWithCosign uses cosign attach signature to attach a signature to a record in the OCI registry.
 func (s *store) attachSignatureWithCosign(ctx context.Context, recordCID string, signature *signv1.Signature) error {
-	referrersLogger.Debug("Attaching signature using cosign attach signature", "recordCID", recordCID)
+	logging.WithContext(ctx, referrersLogger).Debug("Attaching signature using cosign attach signature", "recordCID", recordCID)
 
 	// Construct the OCI image reference for the record
 	imageRef := s.constructImageReference(recordCID)
@@ -94,7 +95,7 @@ func (s *store) attachSignatureWithCosign(ctx context.Context, recordCID string,
 		return fmt.Errorf("failed to attach signature: %w", err)
 	}
 
-	referrersLogger.Debug("Cosign attach signature completed successfully")
+	logging.WithContext(ctx, referrersLogger).Debug("Cosign attach signature completed successfully")
 
 	return nil
 }
@@ -0,0 +1,226 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/registry"
+)
+
+// taggingTarget fails tagging with the given references and tracks concurrent tag calls.
+type taggingTarget struct {
+	oras.GraphTarget
+
+	failing map[string]bool
+	delay   time.Duration
+
+	calls       atomic.Int32
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (t *taggingTarget) Tag(ctx context.Context, desc ocispec.Descriptor, reference string) error {
+	t.calls.Add(1)
+
+	inFlight := t.inFlight.Add(1)
+	defer t.inFlight.Add(-1)
+
+	for {
+		current := t.maxInFlight.Load()
+		if inFlight <= current || t.maxInFlight.CompareAndSwap(current, inFlight) {
+			break
+		}
+	}
+
+	time.Sleep(t.delay)
+
+	if t.failing[reference] {
+		return errors.New("tag rejected")
+	}
+
+	return t.GraphTarget.Tag(ctx, desc, reference) //nolint:wrapcheck
+}
+
+// newTaggingStore returns a local store whose repository is wrapped by a taggingTarget,
+// together with a manifest to tag.
+func newTaggingStore(t *testing.T, concurrency int, failing ...string) (*store, *taggingTarget, ocispec.Descriptor) {
+	t.Helper()
+
+	s := newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+	s.config.TagConcurrency = concurrency
+
+	layerDesc, err := s.pushRecordBlob(t.Context(), []byte(`{"name":"tagged-agent"}`))
+	require.NoError(t, err)
+
+	manifestDesc, err := oras.PackManifest(t.Context(), s.repo, oras.PackManifestVersion1_1, ocispec.MediaTypeImageManifest,
+		oras.PackManifestOptions{Layers: []ocispec.Descriptor{layerDesc}},
+	)
+	require.NoError(t, err)
+
+	target := &taggingTarget{
+		GraphTarget: s.repo,
+		failing:     make(map[string]bool),
+		delay:       10 * time.Millisecond,
+	}
+	for _, reference := range failing {
+		target.failing[reference] = true
+	}
+
+	s.repo = target
+
+	return s, target, manifestDesc
+}
+
+func testTags(n int) []string {
+	tags := []string{"cid"}
+	for i := range n - 1 {
+		tags = append(tags, fmt.Sprintf("tag-%d", i))
+	}
+
+	return tags
+}
+
+func TestTagManifestConcurrency(t *testing.T) {
+	s, target, manifestDesc := newTaggingStore(t, 3)
+
+	tags := testTags(12)
+	require.NoError(t, s.tagManifest(t.Context(), "cid", manifestDesc, tags))
+
+	assert.Equal(t, int32(len(tags)), target.calls.Load())
+	assert.LessOrEqual(t, target.maxInFlight.Load(), int32(3), "tag concurrency must be bounded")
+	assert.Greater(t, target.maxInFlight.Load(), int32(1), "tags must be created concurrently")
+
+	created, err := registry.Tags(t.Context(), target.GraphTarget.(registry.TagLister)) //nolint:forcetypeassert
+	require.NoError(t, err)
+	assert.ElementsMatch(t, tags, created)
+
+	t.Run("tags pointing to the manifest are not re-created", func(t *testing.T) {
+		target.calls.Store(0)
+
+		require.NoError(t, s.tagManifest(t.Context(), "cid", manifestDesc, tags))
+		assert.Zero(t, target.calls.Load())
+	})
+}
+
+func TestTagManifestPartialFailure(t *testing.T) {
+	tags := testTags(10)
+
+	tests := []struct {
+		name    string
+		failing []string
+		wantErr bool
+	}{
+		{name: "some name tags fail", failing: []string{"tag-1", "tag-4", "tag-7"}},
+		{name: "all name tags fail", failing: tags[1:]},
+		{name: "CID tag fails", failing: []string{"cid"}, wantErr: true},
+		{name: "all tags fail", failing: tags, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, target, manifestDesc := newTaggingStore(t, 4, tt.failing...)
+
+			err := s.tagManifest(t.Context(), "cid", manifestDesc, tags)
+
+			// Failures do not stop the remaining tags from being created
+			assert.Equal(t, int32(len(tags)), target.calls.Load())
+
+			if !tt.wantErr {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Equal(t, codes.Internal, status.Code(err))
+
+			for _, tag := range tt.failing {
+				assert.ErrorContains(t, err, "failed to create tag "+tag)
+			}
+		})
+	}
+
+	t.Run("only name tags are refreshed and all fail", func(t *testing.T) {
+		s, _, manifestDesc := newTaggingStore(t, 4, "tag-0", "tag-1")
+
+		err := s.tagManifest(t.Context(), "cid", manifestDesc, []string{"tag-0", "tag-1"})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestTagManifestCancelled(t *testing.T) {
+	s, target, manifestDesc := newTaggingStore(t, 2)
+	target.delay = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	})
+
+	err := s.tagManifest(ctx, "cid", manifestDesc, testTags(20))
+	wg.Wait()
+
+	assert.Equal(t, codes.Canceled, status.Code(err))
+
+	// Tags in flight complete, but no further tags are started
+	assert.Less(t, target.calls.Load(), int32(20))
+	assert.Zero(t, target.inFlight.Load())
+}
+
+// BenchmarkIntegrationTagManifest compares sequential and concurrent tag creation against a local zot registry.
+func BenchmarkIntegrationTagManifest(b *testing.B) {
+	const tagsPerRecord = 20
+
+	setupIntegrationStore(b)
+
+	for _, concurrency := range []int{1, ociconfig.DefaultTagConcurrency} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			cfg := integrationConfig
+			cfg.TagConcurrency = concurrency
+
+			storeAPI, err := New(cfg)
+			require.NoError(b, err)
+
+			s, ok := storeAPI.(*store)
+			require.True(b, ok)
+
+			layerDesc, err := s.pushRecordBlob(b.Context(), []byte(`{"name":"benchmark-agent"}`))
+			require.NoError(b, err)
+
+			manifestDesc, err := oras.PackManifest(b.Context(), s.repo, oras.PackManifestVersion1_1, ocispec.MediaTypeImageManifest,
+				oras.PackManifestOptions{Layers: []ocispec.Descriptor{layerDesc}},
+			)
+			require.NoError(b, err)
+
+			run := time.Now().UnixNano()
+
+			for i := 0; b.Loop(); i++ {
+				tags := make([]string, 0, tagsPerRecord)
+				for j := range tagsPerRecord {
+					tags = append(tags, fmt.Sprintf("bench-%d-%d-%d-%d", run, concurrency, i, j))
+				}
+
+				require.NoError(b, s.tagManifest(b.Context(), tags[0], manifestDesc, tags))
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Resolve resolves a discovery tag to the record it currently points to.
+// The tag is normalized in the same way as on push, so "My_Agent:latest" resolves the "my-agent_latest" tag.
+// A warning is returned for mutable tags, i.e. all tags except CIDs.
+func (s *store) Resolve(ctx context.Context, tag string) (*corev1.RecordRef, string, error) {
+	normalized := corev1.NormalizeDiscoveryTag(tag)
+	if normalized == "" {
+		return nil, "", status.Errorf(codes.InvalidArgument, "invalid tag: %q", tag)
+	}
+
+	logging.WithContext(ctx, logger).Debug("Resolving tag", "tag", tag, "normalized", normalized)
+
+	manifest, manifestDesc, err := s.fetchAndParseManifest(ctx, normalized)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The manifest digest resolves back to the record CID via its annotations
+	cid := manifest.Annotations[ManifestKeyCid]
+	if cid == "" {
+		return nil, "", status.Errorf(codes.NotFound, "tag %s does not point to a record: manifest %s", normalized, manifestDesc.Digest)
+	}
+
+	var warning string
+	if cid != normalized {
+		warning = fmt.Sprintf("tag %s is mutable and currently points to record %s", normalized, cid)
+	}
+
+	return &corev1.RecordRef{Cid: cid}, warning, nil
+}
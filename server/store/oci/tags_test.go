@@ -0,0 +1,111 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2/registry"
+)
+
+func TestResolve(t *testing.T) {
+	s, ok := loadLocalStore(t).(*store)
+	require.True(t, ok, "local store should not be wrapped")
+
+	first := corev1.New(&typesv1alpha1.Record{
+		Name:          "resolve-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+	second := corev1.New(&typesv1alpha1.Record{
+		Name:          "resolve-agent",
+		Version:       "v2.0.0",
+		SchemaVersion: "0.7.0",
+	})
+
+	for _, record := range []*corev1.Record{first, second} {
+		_, err := s.Push(testCtx, record)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name        string
+		tag         string
+		expectedCID string
+		mutable     bool
+	}{
+		{name: "CID", tag: first.GetCid(), expectedCID: first.GetCid()},
+		{name: "Name and version", tag: "resolve-agent:v1.0.0", expectedCID: first.GetCid(), mutable: true},
+		{name: "Normalized input", tag: " Resolve-Agent:V1.0.0 ", expectedCID: first.GetCid(), mutable: true},
+		{name: "Already normalized input", tag: "resolve-agent_v1.0.0", expectedCID: first.GetCid(), mutable: true},
+		{name: "Same record name", tag: "RESOLVE_AGENT:v1.0.0", expectedCID: first.GetCid(), mutable: true},
+		{name: "Latest points to the last push", tag: "resolve-agent:latest", expectedCID: second.GetCid(), mutable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, warning, err := s.Resolve(testCtx, tt.tag)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCID, ref.GetCid())
+			assert.Equal(t, tt.mutable, warning != "", "unexpected warning: %q", warning)
+		})
+	}
+
+	t.Run("Not found", func(t *testing.T) {
+		_, _, err := s.Resolve(testCtx, "unknown-agent:latest")
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Invalid tag", func(t *testing.T) {
+		_, _, err := s.Resolve(testCtx, ":::")
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestPreviewPush(t *testing.T) {
+	s := newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "Preview Agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+
+	preview, err := s.PreviewPush(testCtx, record)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), preview.GetCid())
+	assert.False(t, preview.GetAlreadyExists())
+	assert.Equal(t, record.GetCid(), preview.GetAnnotations()[ManifestKeyCid])
+
+	// Nothing is written by the preview
+	tags, err := registry.Tags(testCtx, s.repo.(registry.TagLister)) //nolint:forcetypeassert
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+
+	_, err = s.Push(testCtx, record)
+	require.NoError(t, err)
+
+	// The preview matches the tags actually created by the push
+	tags, err = registry.Tags(testCtx, s.repo.(registry.TagLister)) //nolint:forcetypeassert
+	require.NoError(t, err)
+	assert.ElementsMatch(t, preview.GetTags(), tags)
+
+	manifest, _, err := s.fetchAndParseManifest(testCtx, record.GetCid())
+	require.NoError(t, err)
+
+	for key, value := range preview.GetAnnotations() {
+		assert.Equal(t, value, manifest.Annotations[key], "annotation %s", key)
+	}
+
+	preview, err = s.PreviewPush(testCtx, record)
+	require.NoError(t, err)
+	assert.True(t, preview.GetAlreadyExists())
+}
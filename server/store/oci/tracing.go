@@ -0,0 +1,46 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// Span names for OCI store operations.
+	spanPush         = "oci.Push"
+	spanPushBlob     = "oci.PushBlob"
+	spanPushManifest = "oci.PushManifest"
+	spanTag          = "oci.Tag"
+
+	// Span attribute keys.
+	attrCID            = attribute.Key("dir.record.cid")
+	attrTag            = attribute.Key("dir.oci.tag")
+	attrTagsCount      = attribute.Key("dir.oci.tags.count")
+	attrBlobSize       = attribute.Key("dir.oci.blob.size")
+	attrAlreadyExisted = attribute.Key("dir.record.already_existed")
+)
+
+const tracerName = "github.com/agntcy/dir/server/store/oci"
+
+// startSpan starts a child span of the span in ctx.
+// Spans are recorded by the global tracer provider, which is a no-op unless tracing is configured.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...)) //nolint:spancheck
+}
+
+// endSpan records err on the span, if any, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,123 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// setupSpanRecorder installs a global tracer provider backed by an in-memory exporter.
+func setupSpanRecorder(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(t.Context())
+	})
+
+	return exporter
+}
+
+// childSpans returns the names of spans whose parent is the given span.
+func childSpans(spans tracetest.SpanStubs, parent tracetest.SpanStub) []string {
+	var names []string
+
+	for _, span := range spans {
+		if span.Parent.SpanID() == parent.SpanContext.SpanID() {
+			names = append(names, span.Name)
+		}
+	}
+
+	return names
+}
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+
+	require.Failf(t, "span not found", "expected span %s", name)
+
+	return tracetest.SpanStub{}
+}
+
+func TestPushTracing(t *testing.T) {
+	exporter := setupSpanRecorder(t)
+
+	s := newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "traced-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+
+	ref, err := s.Push(t.Context(), record)
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	push := findSpan(t, spans, spanPush)
+
+	assert.Contains(t, push.Attributes, attrCID.String(ref.GetCid()))
+	tags := record.DiscoveryTags()
+	assert.Contains(t, push.Attributes, attrTagsCount.Int(len(tags)))
+
+	expected := []string{spanPushBlob, spanPushManifest}
+	for range tags {
+		expected = append(expected, spanTag)
+	}
+
+	assert.ElementsMatch(t, expected, childSpans(spans, push))
+}
+
+func TestPushManifestWithTagsTracing(t *testing.T) {
+	exporter := setupSpanRecorder(t)
+
+	s := newLocalStore(t, t.TempDir(), ociconfig.CompressionConfig{})
+
+	ctx, span := startSpan(t.Context(), spanPush)
+
+	layerDesc, err := s.pushRecordBlob(ctx, []byte(`{"name":"traced-agent"}`))
+	require.NoError(t, err)
+
+	tags := []string{"tag-a", "tag-b", "tag-c"}
+	err = s.pushManifestWithTags(ctx, "cid", layerDesc, map[string]string{ocispec.AnnotationTitle: "traced-agent"}, tags)
+	require.NoError(t, err)
+	endSpan(span, nil)
+
+	spans := exporter.GetSpans()
+	push := findSpan(t, spans, spanPush)
+
+	assert.Contains(t, push.Attributes, attrTagsCount.Int(len(tags)))
+
+	var tagSpans []string
+
+	for _, name := range childSpans(spans, push) {
+		if name == spanTag {
+			tagSpans = append(tagSpans, name)
+		}
+	}
+
+	assert.Len(t, tagSpans, len(tags))
+}
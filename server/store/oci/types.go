@@ -16,8 +16,20 @@ const (
 	// SignatureArtifactType defines the internal OCI media type for signature layers.
 	SignatureArtifactType = "application/vnd.dev.cosign.simplesigning.v1+json"
 
+	// AttestationArtifactMediaType defines the internal OCI media type for attestation referrer blobs.
+	AttestationArtifactMediaType = "application/vnd.agntcy.dir.attestation.v1+json"
+
+	// AttestationArtifactType defines the OCI artifact type of attestation referrer manifests.
+	AttestationArtifactType = "application/vnd.dsse.envelope.v1+json"
+
 	// DefaultReferrerArtifactMediaType defines the default internal OCI media type for referrer blobs.
 	DefaultReferrerArtifactMediaType = "application/vnd.agntcy.dir.referrer.v1+json"
+
+	// LifecycleArtifactType defines the OCI artifact type of lifecycle referrer manifests.
+	LifecycleArtifactType = "application/vnd.agntcy.dir.lifecycle.v1+json"
+
+	// MetadataArtifactType defines the OCI artifact type of operational metadata referrer manifests.
+	MetadataArtifactType = "application/vnd.agntcy.dir.metadata.v1+json"
 )
 
 // apiToOCIType maps Dir API types to internal OCI artifact types.
@@ -27,6 +39,8 @@ func apiToOCIType(apiType string) string {
 		return SignatureArtifactType
 	case corev1.PublicKeyReferrerType:
 		return PublicKeyArtifactMediaType
+	case corev1.AttestationReferrerType:
+		return AttestationArtifactMediaType
 	default:
 		return DefaultReferrerArtifactMediaType
 	}
@@ -39,6 +53,8 @@ func ociToAPIType(ociType string) string {
 		return corev1.SignatureReferrerType
 	case PublicKeyArtifactMediaType:
 		return corev1.PublicKeyReferrerType
+	case AttestationArtifactMediaType:
+		return corev1.AttestationReferrerType
 	default:
 		return ociType // Return the original OCI type if not found
 	}
@@ -0,0 +1,67 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+)
+
+// Untag removes the name tags pointing to the record manifest, so that the record can no longer be resolved
+// by name, e.g. while it is in the trash. The manifest and its CID tag are kept, and the name tags are
+// recreated by pushing the record again. Tags that were re-pointed to another record are left untouched.
+// Removing tags is only supported by local stores.
+func (s *store) Untag(ctx context.Context, ref *corev1.RecordRef) error {
+	if err := validateRecordRef(ref); err != nil {
+		return err
+	}
+
+	if _, ok := s.repo.(*oci.Store); !ok {
+		return status.Error(codes.Unimplemented, "removing tags is not supported by remote registries")
+	}
+
+	record, err := s.Pull(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	manifestDesc, err := s.repo.Resolve(ctx, ref.GetCid())
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to resolve record manifest: %v", err)
+	}
+
+	for _, tag := range record.DiscoveryTags() {
+		if tag == ref.GetCid() {
+			continue
+		}
+
+		desc, err := s.repo.Resolve(ctx, tag)
+		if errors.Is(err, errdef.ErrNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to resolve tag %s: %v", tag, err)
+		}
+
+		if desc.Digest != manifestDesc.Digest {
+			continue
+		}
+
+		if err := s.untag(ctx, tag); err != nil {
+			return status.Errorf(codes.Internal, "%v", err)
+		}
+
+		logging.WithContext(ctx, logger).Debug("Removed record tag", "cid", ref.GetCid(), "tag", tag)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"testing"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUntag(t *testing.T) {
+	s, ok := loadLocalStore(t).(*store)
+	require.True(t, ok, "local store should not be wrapped")
+
+	first := corev1.New(&typesv1alpha1.Record{
+		Name:          "untag-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "0.7.0",
+	})
+	second := corev1.New(&typesv1alpha1.Record{
+		Name:          "untag-agent",
+		Version:       "v2.0.0",
+		SchemaVersion: "0.7.0",
+	})
+
+	for _, record := range []*corev1.Record{first, second} {
+		_, err := s.Push(testCtx, record)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, s.Untag(testCtx, &corev1.RecordRef{Cid: first.GetCid()}))
+
+	// Name tags of the record are removed, its CID tag and tags re-pointed to other records are kept
+	_, _, err := s.Resolve(testCtx, "untag-agent:v1.0.0")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	ref, _, err := s.Resolve(testCtx, first.GetCid())
+	require.NoError(t, err)
+	assert.Equal(t, first.GetCid(), ref.GetCid())
+
+	ref, _, err = s.Resolve(testCtx, "untag-agent:latest")
+	require.NoError(t, err)
+	assert.Equal(t, second.GetCid(), ref.GetCid())
+
+	// Pushing the record again recreates its tags
+	_, err = s.Push(testCtx, first)
+	require.NoError(t, err)
+
+	ref, _, err = s.Resolve(testCtx, "untag-agent:v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, first.GetCid(), ref.GetCid())
+}
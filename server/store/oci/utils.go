@@ -26,7 +26,26 @@ func NewORASRepository(cfg ociconfig.Config) (*remote.Repository, error) {
 
 	// Configure repository
 	repo.PlainHTTP = cfg.Insecure
-	repo.Client = &auth.Client{
+	repo.Client = newAuthClient(cfg)
+
+	return repo, nil
+}
+
+// newORASRegistry creates a new ORAS registry client configured with authentication.
+func newORASRegistry(cfg ociconfig.Config) (*remote.Registry, error) {
+	reg, err := remote.NewRegistry(cfg.RegistryAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote registry: %w", err)
+	}
+
+	reg.PlainHTTP = cfg.Insecure
+	reg.Client = newAuthClient(cfg)
+
+	return reg, nil
+}
+
+func newAuthClient(cfg ociconfig.Config) *auth.Client {
+	return &auth.Client{
 		Client: retry.DefaultClient,
 		Header: http.Header{
 			"User-Agent": {"dir-client"},
@@ -42,6 +61,4 @@ func NewORASRepository(cfg ociconfig.Config) (*remote.Repository, error) {
 			},
 		),
 	}
-
-	return repo, nil
 }
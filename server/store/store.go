@@ -6,6 +6,8 @@ package store
 import (
 	"fmt"
 
+	storeconfig "github.com/agntcy/dir/server/store/config"
+	"github.com/agntcy/dir/server/store/memory"
 	"github.com/agntcy/dir/server/store/oci"
 	"github.com/agntcy/dir/server/types"
 )
@@ -13,7 +15,8 @@ import (
 type Provider string
 
 const (
-	OCI = Provider("oci")
+	OCI    = Provider(storeconfig.ProviderOCI)
+	Memory = Provider(storeconfig.ProviderMemory)
 )
 
 // TODO: add options for adding cache.
@@ -27,6 +30,14 @@ func New(opts types.APIOptions) (types.StoreAPI, error) {
 
 		return store, nil
 
+	case Memory:
+		store, err := memory.New()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create memory store: %w", err)
+		}
+
+		return store, nil
+
 	default:
 		return nil, fmt.Errorf("unsupported provider=%s", provider)
 	}
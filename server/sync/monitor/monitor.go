@@ -51,11 +51,18 @@ type MonitorService struct {
 }
 
 // NewMonitorService creates a new monitor service.
+// Stores kept in a local OCI layout without a registry cannot be monitored, syncs fail to start for them.
 func NewMonitorService(db types.DatabaseAPI, store types.StoreAPI, ociConfig ociconfig.Config, monitorConfig config.Config) (*MonitorService, error) {
 	// Create ORAS repository client
-	repo, err := oci.NewORASRepository(ociConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create ORAS repository client: %w", err)
+	var repo *remote.Repository
+
+	if ociConfig.RegistryAddress != "" {
+		var err error
+
+		repo, err = oci.NewORASRepository(ociConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ORAS repository client: %w", err)
+		}
 	}
 
 	return &MonitorService{
@@ -100,6 +107,10 @@ func (s *MonitorService) Stop() error {
 
 // StartSyncMonitoring begins monitoring when a sync operation starts.
 func (s *MonitorService) StartSyncMonitoring(syncID string) error {
+	if s.repo == nil {
+		return errors.New("registry monitoring requires a registry, the store has none")
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -0,0 +1,255 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/labels"
+	synctypes "github.com/agntcy/dir/server/sync/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// remoteSearchPageSize is the number of CIDs listed from the remote node per search request.
+const remoteSearchPageSize = 1000
+
+// syncFromStore pulls the records missing locally from the remote store API.
+// Each record is verified against its CID and matched against the label filters
+// of the sync before it is stored and indexed locally. Failures of individual
+// records do not stop the sync, they are returned together with the number of
+// records that were synchronized.
+func (w *Worker) syncFromStore(ctx context.Context, item synctypes.WorkItem) (uint64, error) {
+	logger.Debug("Starting store sync operation", "worker_id", w.id, "sync_id", item.SyncID, "remote_url", item.RemoteDirectoryURL)
+
+	conn, err := grpc.NewClient(
+		item.RemoteDirectoryURL,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create gRPC connection to remote node %s: %w", item.RemoteDirectoryURL, err)
+	}
+	defer conn.Close()
+
+	remoteStore := storev1.NewStoreServiceClient(conn)
+
+	cids := item.CIDs
+	if len(cids) == 0 {
+		cids, err = listRemoteCIDs(ctx, searchv1.NewSearchServiceClient(conn))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	var (
+		synced uint64
+		errs   error
+	)
+
+	for _, cid := range cids {
+		ok, err := w.syncRecord(ctx, remoteStore, cid, item.Labels)
+		if err != nil {
+			logger.Warn("Failed to sync record", "worker_id", w.id, "sync_id", item.SyncID, "cid", cid, "error", err)
+
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", cid, err))
+
+			continue
+		}
+
+		if ok {
+			synced++
+		}
+	}
+
+	logger.Info("Store sync operation completed", "worker_id", w.id, "sync_id", item.SyncID, "records", len(cids), "synced", synced)
+
+	return synced, errs
+}
+
+// syncRecord copies a single record and its referrers from the remote node.
+// It reports false if the record already exists locally or does not match the label filters.
+func (w *Worker) syncRecord(ctx context.Context, remoteStore storev1.StoreServiceClient, cid string, labelFilters []string) (bool, error) {
+	ref := &corev1.RecordRef{Cid: cid}
+
+	// Skip records that already exist locally
+	if _, err := w.store.Lookup(ctx, ref); err == nil {
+		return false, nil
+	} else if status.Code(err) != codes.NotFound {
+		return false, fmt.Errorf("failed to lookup local record: %w", err)
+	}
+
+	record, err := pullRemoteRecord(ctx, remoteStore, ref)
+	if err != nil {
+		return false, err
+	}
+
+	// Never trust the remote node, the record must match the CID it was requested by
+	if !record.MatchesCid(cid) {
+		return false, fmt.Errorf("CID mismatch: remote returned record with CID %s", record.GetCid())
+	}
+
+	if !matchesLabels(labels.ExtractLabels(record), labelFilters) {
+		return false, nil
+	}
+
+	isValid, validationErrors, err := record.Validate()
+	if err != nil {
+		return false, fmt.Errorf("failed to validate record: %w", err)
+	}
+
+	if !isValid {
+		return false, fmt.Errorf("record validation failed: %v", validationErrors)
+	}
+
+	if _, err := w.store.Push(ctx, record); err != nil {
+		return false, fmt.Errorf("failed to push record to local store: %w", err)
+	}
+
+	if err := w.db.AddRecord(adapters.NewRecordAdapter(record)); err != nil {
+		// Storage is the source of truth, the record can be re-indexed later
+		logger.Error("Failed to add synced record to search index", "cid", cid, "error", err)
+	}
+
+	// Signatures and public keys are copied on a best-effort basis
+	if err := w.syncReferrers(ctx, remoteStore, ref); err != nil {
+		logger.Warn("Failed to sync record referrers", "cid", cid, "error", err)
+	}
+
+	return true, nil
+}
+
+// syncReferrers copies the referrers of a record, such as signatures, from the remote node.
+func (w *Worker) syncReferrers(ctx context.Context, remoteStore storev1.StoreServiceClient, ref *corev1.RecordRef) error {
+	refStore, ok := w.store.(interface {
+		PushReferrer(context.Context, string, *corev1.RecordReferrer) error
+	})
+	if !ok {
+		return nil
+	}
+
+	stream, err := remoteStore.PullReferrer(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create pull referrer stream: %w", err)
+	}
+
+	if err := stream.Send(&storev1.PullReferrerRequest{RecordRef: ref}); err != nil {
+		return fmt.Errorf("failed to send pull referrer request: %w", err)
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return fmt.Errorf("failed to close pull referrer stream: %w", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to receive referrer: %w", err)
+		}
+
+		if err := refStore.PushReferrer(ctx, ref.GetCid(), resp.GetReferrer()); err != nil {
+			return fmt.Errorf("failed to push referrer to local store: %w", err)
+		}
+	}
+}
+
+// pullRemoteRecord pulls a single record from the remote store API.
+func pullRemoteRecord(ctx context.Context, remoteStore storev1.StoreServiceClient, ref *corev1.RecordRef) (*corev1.Record, error) {
+	stream, err := remoteStore.Pull(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull stream: %w", err)
+	}
+
+	if err := stream.Send(ref); err != nil {
+		return nil, fmt.Errorf("failed to send pull request: %w", err)
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return nil, fmt.Errorf("failed to close pull stream: %w", err)
+	}
+
+	record, err := stream.Recv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull remote record: %w", err)
+	}
+
+	if recordErr := record.GetError(); recordErr != nil {
+		return nil, status.Errorf(codes.Code(recordErr.GetCode()), "failed to pull remote record: %s", recordErr.GetMessage())
+	}
+
+	return record, nil
+}
+
+// listRemoteCIDs lists the CIDs of all records stored on the remote node.
+func listRemoteCIDs(ctx context.Context, remoteSearch searchv1.SearchServiceClient) ([]string, error) {
+	var cids []string
+
+	for offset := uint32(0); ; offset += remoteSearchPageSize {
+		stream, err := remoteSearch.Search(ctx, &searchv1.SearchRequest{
+			Limit:  toPtr(uint32(remoteSearchPageSize)),
+			Offset: toPtr(offset),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search remote records: %w", err)
+		}
+
+		page := 0
+
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to receive remote search result: %w", err)
+			}
+
+			cids = append(cids, resp.GetRecordCid())
+			page++
+		}
+
+		if page < remoteSearchPageSize {
+			return cids, nil
+		}
+	}
+}
+
+// matchesLabels reports whether any label of the record matches one of the filters.
+// A filter matches its own label and the labels nested under it,
+// e.g. "/skills/natural_language_processing" matches "/skills/natural_language_processing/summarization".
+// Records always match when no filters are set.
+func matchesLabels(recordLabels labels.Labels, filters []string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+
+	for _, label := range recordLabels.RoutingLabels() {
+		for _, filter := range filters {
+			filter = strings.TrimSuffix(filter, "/")
+			if label.String() == filter || strings.HasPrefix(label.String(), filter+"/") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
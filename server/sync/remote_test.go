@@ -0,0 +1,38 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"testing"
+
+	"github.com/agntcy/dir/server/labels"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesLabels(t *testing.T) {
+	recordLabels := labels.Labels{
+		Skills:  []string{"natural_language_processing/summarization"},
+		Domains: []string{"technology"},
+	}
+
+	tests := []struct {
+		name    string
+		filters []string
+		want    bool
+	}{
+		{name: "no filters", filters: nil, want: true},
+		{name: "exact label", filters: []string{"/domains/technology"}, want: true},
+		{name: "parent label", filters: []string{"/skills/natural_language_processing"}, want: true},
+		{name: "parent label with trailing slash", filters: []string{"/skills/natural_language_processing/"}, want: true},
+		{name: "label prefix is not a parent", filters: []string{"/skills/natural_language"}, want: false},
+		{name: "other type", filters: []string{"/modules/technology"}, want: false},
+		{name: "any filter matches", filters: []string{"/skills/images", "/domains/technology"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchesLabels(recordLabels, tt.filters))
+		})
+	}
+}
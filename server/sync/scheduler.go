@@ -65,6 +65,11 @@ func (s *Scheduler) processPendingSyncs(ctx context.Context) {
 		logger.Error("Failed to process pending sync creations", "error", err)
 	}
 
+	// Process scheduled syncs that are due to run again
+	if err := s.processDueSyncs(ctx); err != nil {
+		logger.Error("Failed to process due syncs", "error", err)
+	}
+
 	// Process pending sync deletions
 	if err := s.processPendingSyncDeletions(ctx); err != nil {
 		logger.Error("Failed to process pending sync deletions", "error", err)
@@ -92,6 +97,8 @@ func (s *Scheduler) processPendingSyncCreations(ctx context.Context) error {
 			SyncID:             sync.GetID(),
 			RemoteDirectoryURL: sync.GetRemoteDirectoryURL(),
 			CIDs:               sync.GetCIDs(),
+			Labels:             sync.GetLabels(),
+			Interval:           sync.GetInterval(),
 		}
 
 		if err := s.dispatchWorkItem(ctx, workItem); err != nil {
@@ -105,6 +112,42 @@ func (s *Scheduler) processPendingSyncCreations(ctx context.Context) error {
 	return nil
 }
 
+// processDueSyncs re-dispatches scheduled syncs whose interval has elapsed since their last run.
+// A run that takes longer than the interval may be dispatched again, which is harmless
+// since records that already exist locally are skipped.
+func (s *Scheduler) processDueSyncs(ctx context.Context) error {
+	syncs, err := s.db.GetSyncsByStatus(storev1.SyncStatus_SYNC_STATUS_IN_PROGRESS)
+	if err != nil {
+		return fmt.Errorf("failed to get in progress syncs from database: %w", err)
+	}
+
+	for _, sync := range syncs {
+		// Syncs that have not completed their first run are still being processed
+		if sync.GetInterval() <= 0 || sync.GetLastRunTime().IsZero() {
+			continue
+		}
+
+		if time.Since(sync.GetLastRunTime()) < sync.GetInterval() {
+			continue
+		}
+
+		workItem := synctypes.WorkItem{
+			Type:               synctypes.WorkItemTypeSyncCreate,
+			SyncID:             sync.GetID(),
+			RemoteDirectoryURL: sync.GetRemoteDirectoryURL(),
+			CIDs:               sync.GetCIDs(),
+			Labels:             sync.GetLabels(),
+			Interval:           sync.GetInterval(),
+		}
+
+		if err := s.dispatchWorkItem(ctx, workItem); err != nil {
+			logger.Error("Failed to dispatch scheduled sync", "sync_id", sync.GetID(), "error", err)
+		}
+	}
+
+	return nil
+}
+
 // processPendingSyncDeletions handles syncs that need to be deleted.
 func (s *Scheduler) processPendingSyncDeletions(ctx context.Context) error {
 	syncs, err := s.db.GetSyncsByStatus(storev1.SyncStatus_SYNC_STATUS_DELETE_PENDING)
@@ -119,6 +162,8 @@ func (s *Scheduler) processPendingSyncDeletions(ctx context.Context) error {
 			SyncID:             sync.GetID(),
 			RemoteDirectoryURL: sync.GetRemoteDirectoryURL(),
 			CIDs:               sync.GetCIDs(),
+			Labels:             sync.GetLabels(),
+			Interval:           sync.GetInterval(),
 		}
 
 		if err := s.dispatchWorkItem(ctx, workItem); err != nil {
@@ -3,12 +3,16 @@
 
 package types
 
+import "time"
+
 // WorkItem represents a sync task to be processed by workers.
 type WorkItem struct {
 	Type               WorkItemType
 	SyncID             string
 	RemoteDirectoryURL string
 	CIDs               []string
+	Labels             []string
+	Interval           time.Duration
 }
 
 // WorkItemType represents the type of sync task.
@@ -75,6 +75,12 @@ func (w *Worker) processWorkItem(ctx context.Context, item synctypes.WorkItem) {
 
 	switch item.Type {
 	case synctypes.WorkItemTypeSyncCreate:
+		if syncOptions(item).UsesStoreAPI() {
+			finalStatus = w.runStoreSync(workCtx, item)
+
+			break
+		}
+
 		finalStatus = storev1.SyncStatus_SYNC_STATUS_IN_PROGRESS
 
 		err := w.addSync(workCtx, item)
@@ -104,9 +110,40 @@ func (w *Worker) processWorkItem(ctx context.Context, item synctypes.WorkItem) {
 	}
 }
 
+// runStoreSync runs a sync through the store API and records its progress.
+// Scheduled syncs stay in progress after a failed run so that they are retried,
+// one-off syncs fail.
+func (w *Worker) runStoreSync(ctx context.Context, item synctypes.WorkItem) storev1.SyncStatus {
+	finalStatus := storev1.SyncStatus_SYNC_STATUS_IN_PROGRESS
+
+	synced, err := w.syncFromStore(ctx, item)
+
+	var lastError string
+	if err != nil {
+		logger.Error("Sync run failed", "worker_id", w.id, "sync_id", item.SyncID, "error", err)
+
+		lastError = err.Error()
+
+		if item.Interval <= 0 {
+			finalStatus = storev1.SyncStatus_SYNC_STATUS_FAILED
+		}
+	}
+
+	if err := w.db.UpdateSyncProgress(item.SyncID, synced, lastError); err != nil {
+		logger.Error("Failed to update sync progress", "worker_id", w.id, "sync_id", item.SyncID, "error", err)
+	}
+
+	return finalStatus
+}
+
 func (w *Worker) deleteSync(_ context.Context, item synctypes.WorkItem) error {
 	logger.Debug("Starting sync delete operation", "worker_id", w.id, "sync_id", item.SyncID, "remote_url", item.RemoteDirectoryURL)
 
+	// Syncs through the store API have no registry or monitoring to clean up
+	if syncOptions(item).UsesStoreAPI() {
+		return nil
+	}
+
 	// Get remote registry URL from sync object
 	remoteRegistryURL, err := w.db.GetSyncRemoteRegistry(item.SyncID)
 	if err != nil {
@@ -166,6 +203,15 @@ func (w *Worker) addSync(ctx context.Context, item synctypes.WorkItem) error {
 	return nil
 }
 
+// syncOptions returns the options of the sync a work item was created from.
+func syncOptions(item synctypes.WorkItem) types.SyncOptions {
+	return types.SyncOptions{
+		CIDs:     item.CIDs,
+		Labels:   item.Labels,
+		Interval: item.Interval,
+	}
+}
+
 // negotiateCredentials negotiates registry credentials with the remote Directory node.
 func (w *Worker) negotiateCredentials(ctx context.Context, remoteDirectoryURL string) (string, syncconfig.AuthConfig, error) {
 	logger.Debug("Starting credential negotiation", "worker_id", w.id, "remote_url", remoteDirectoryURL)
@@ -0,0 +1,103 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package testutil provides helpers for exercising the Directory API in-process.
+package testutil
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	signv1 "github.com/agntcy/dir/api/sign/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/controller"
+	"github.com/agntcy/dir/server/database/sqlite"
+	storeconfig "github.com/agntcy/dir/server/store/config"
+	"github.com/agntcy/dir/server/store/memory"
+	"github.com/agntcy/dir/server/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	bufSize = 1024 * 1024
+
+	// Address is the target to use when dialing an in-process server.
+	Address = "passthrough:///bufnet"
+)
+
+// InProcessServer is a gRPC server backed by the in-memory store and
+// a temporary SQLite database, listening on an in-memory connection.
+type InProcessServer struct {
+	Store    *memory.Store
+	Database types.DatabaseAPI
+
+	listener *bufconn.Listener
+	server   *grpc.Server
+}
+
+// StartInProcessServer starts a server with store, search and sign services registered.
+// The server and all connections are closed when the test completes.
+func StartInProcessServer(t testing.TB, opts ...memory.Option) *InProcessServer {
+	t.Helper()
+
+	store, err := memory.New(opts...)
+	if err != nil {
+		t.Fatalf("failed to create memory store: %v", err)
+	}
+
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "dir.db"))
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+
+	srv := &InProcessServer{
+		Store:    store,
+		Database: db,
+		listener: bufconn.Listen(bufSize),
+		server:   grpc.NewServer(),
+	}
+
+	storev1.RegisterStoreServiceServer(srv.server, controller.NewStoreController(store, db, nil, nil, nil, nil, nil, nil, nil, nil, nil, storeconfig.Config{}))
+	searchv1.RegisterSearchServiceServer(srv.server, controller.NewSearchController(db))
+	signv1.RegisterSignServiceServer(srv.server, controller.NewSignController(store))
+
+	go func() {
+		_ = srv.server.Serve(srv.listener)
+	}()
+
+	t.Cleanup(srv.server.Stop)
+
+	return srv
+}
+
+// DialOptions returns the options required to connect to the server via Address.
+func (s *InProcessServer) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+}
+
+// Conn returns a new client connection to the server.
+// The connection is closed when the test completes.
+func (s *InProcessServer) Conn(t testing.TB) *grpc.ClientConn {
+	t.Helper()
+
+	conn, err := grpc.NewClient(Address, s.DialOptions()...)
+	if err != nil {
+		t.Fatalf("failed to connect to in-process server: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
@@ -0,0 +1,132 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package testutil
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/client/streaming"
+	"github.com/agntcy/dir/server/store/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testRecord returns a 0.7.0 record passing the schema validation of the server.
+func testRecord(name, version string) *corev1.Record {
+	return corev1.New(&typesv1alpha1.Record{
+		Name:          name,
+		Version:       version,
+		SchemaVersion: "0.7.0",
+		Description:   "A test agent",
+		Authors:       []string{"AGNTCY Contributors"},
+		CreatedAt:     "2025-01-01T00:00:00Z",
+		Skills: []*typesv1alpha1.Skill{
+			{Name: "natural_language_processing/natural_language_generation/text_completion", Id: 10201}, //nolint:mnd
+		},
+		Locators: []*typesv1alpha1.Locator{
+			{Type: "docker_image", Url: "https://ghcr.io/agntcy/" + name},
+		},
+	})
+}
+
+func TestStartInProcessServer(t *testing.T) {
+	srv := StartInProcessServer(t)
+	storeClient := storev1.NewStoreServiceClient(srv.Conn(t))
+
+	record := testRecord("test-agent", "v1.0.0")
+
+	pushStream, err := storeClient.Push(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, pushStream.Send(record))
+
+	ref, err := pushStream.Recv()
+	require.NoError(t, err)
+	require.Nil(t, ref.GetError())
+	assert.Equal(t, record.GetCid(), ref.GetCid())
+	require.NoError(t, pushStream.CloseSend())
+
+	pullStream, err := storeClient.Pull(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, pullStream.Send(ref))
+
+	pulled, err := pullStream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), pulled.GetCid())
+	require.NoError(t, pullStream.CloseSend())
+}
+
+func TestStartInProcessServerInjectedFailures(t *testing.T) {
+	const (
+		count   = 20
+		latency = time.Millisecond
+	)
+
+	srv := StartInProcessServer(t, memory.WithLatency(latency), memory.WithErrorRate(0.5), memory.WithSeed(1)) //nolint:mnd
+
+	// Seed the store directly, retrying injected failures
+	refs := make([]*corev1.RecordRef, count)
+
+	for i := range refs {
+		record := testRecord("flaky-agent", fmt.Sprintf("v1.0.%d", i))
+
+		for attempt := 0; refs[i] == nil; attempt++ {
+			require.Less(t, attempt, 100, "record %d could not be seeded", i)
+
+			ref, err := srv.Store.Push(t.Context(), record)
+			if err == nil {
+				refs[i] = &corev1.RecordRef{Cid: ref.GetCid()}
+			}
+		}
+	}
+
+	c, err := client.New(
+		client.WithConfig(&client.Config{ServerAddress: Address}),
+		client.WithDialOptions(srv.DialOptions()...),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = c.Close() })
+
+	start := time.Now()
+
+	result, err := c.PullStream(t.Context(), streaming.SliceToChan(t.Context(), refs))
+	require.NoError(t, err)
+
+	// Injected failures are reported per record without aborting the stream
+	var pulled, failed int
+
+	for done := false; !done; {
+		select {
+		case err := <-result.ErrCh():
+			require.NoError(t, err)
+		case res := <-result.ResCh():
+			if res.Error != nil {
+				assert.Equal(t, codes.Unavailable, status.Code(res.Error))
+
+				failed++
+
+				continue
+			}
+
+			assert.Equal(t, refs[res.Index].GetCid(), res.Record.GetCid())
+
+			pulled++
+		case <-result.DoneCh():
+			done = true
+		}
+	}
+
+	assert.Equal(t, count, pulled+failed)
+	assert.Positive(t, pulled)
+	assert.Positive(t, failed)
+	assert.GreaterOrEqual(t, time.Since(start), count*latency)
+}
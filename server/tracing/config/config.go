@@ -0,0 +1,42 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import "errors"
+
+const (
+	DefaultOTLPEndpoint  = ""
+	DefaultInsecure      = false
+	DefaultSamplingRatio = 1.0
+)
+
+// Config contains configuration for OpenTelemetry tracing.
+// Tracing is disabled when no OTLP endpoint is configured.
+type Config struct {
+	// OTLP gRPC collector endpoint, e.g. "otel-collector:4317"
+	OTLPEndpoint string `json:"otlp_endpoint,omitempty" mapstructure:"otlp_endpoint"`
+
+	// Disable TLS when connecting to the OTLP collector
+	Insecure bool `json:"insecure,omitempty" mapstructure:"insecure"`
+
+	// Fraction of traces to sample, between 0 and 1
+	SamplingRatio float64 `json:"sampling_ratio,omitempty" mapstructure:"sampling_ratio"`
+}
+
+// Enabled reports whether tracing is configured.
+func (c *Config) Enabled() bool {
+	return c.OTLPEndpoint != ""
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	if c.SamplingRatio < 0 || c.SamplingRatio > 1 {
+		return errors.New("sampling ratio must be between 0 and 1")
+	}
+
+	return nil
+}
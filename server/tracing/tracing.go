@@ -0,0 +1,98 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/agntcy/dir/api/version"
+	"github.com/agntcy/dir/server/tracing/config"
+	"github.com/agntcy/dir/utils/logging"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"google.golang.org/grpc"
+)
+
+const serviceName = "dir-apiserver"
+
+var logger = logging.Logger("tracing")
+
+// Service manages the OpenTelemetry tracer provider and exports spans via OTLP.
+type Service struct {
+	provider *sdktrace.TracerProvider
+}
+
+// New creates a tracing service exporting to the configured OTLP endpoint
+// and registers it as the global tracer provider.
+func New(ctx context.Context, cfg config.Config) (*Service, error) {
+	// Validate
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid tracing config: %w", err)
+	}
+
+	exporterOpts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint),
+	}
+	if cfg.Insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version.Version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	service := NewWithProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SamplingRatio))),
+	))
+
+	logger.Info("Tracing service initialized", "endpoint", cfg.OTLPEndpoint, "samplingRatio", cfg.SamplingRatio)
+
+	return service, nil
+}
+
+// NewWithProvider registers the given tracer provider globally and returns a service for it.
+// This is useful for tests that record spans with an in-memory exporter.
+func NewWithProvider(provider *sdktrace.TracerProvider) *Service {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return &Service{provider: provider}
+}
+
+// GetServerOptions returns the gRPC server options that create a span for each RPC.
+func (s *Service) GetServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithTracerProvider(s.provider))),
+	}
+}
+
+// Stop flushes pending spans and shuts down the tracer provider.
+func (s *Service) Stop() error {
+	if err := s.provider.Shutdown(context.Background()); err != nil {
+		return fmt.Errorf("failed to shutdown tracer provider: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultMode           = ModeHard
+	DefaultRetention      = 168 * time.Hour
+	DefaultReaperInterval = 1 * time.Hour
+)
+
+// Mode is how deleted records are handled.
+type Mode string
+
+const (
+	// ModeHard removes deleted records from the store immediately.
+	ModeHard Mode = "hard"
+
+	// ModeSoft moves deleted records to the trash, from which they can be restored until their retention expires.
+	ModeSoft Mode = "soft"
+)
+
+// Config contains configuration for record deletion.
+type Config struct {
+	// Deletion mode, "hard" or "soft"
+	Mode Mode `json:"mode,omitempty" mapstructure:"mode"`
+
+	// Time deleted records are kept in the trash before they are purged, in soft mode
+	Retention time.Duration `json:"retention,omitempty" mapstructure:"retention"`
+
+	// Interval at which records past their retention are purged, in soft mode
+	ReaperInterval time.Duration `json:"reaper_interval,omitempty" mapstructure:"reaper_interval"`
+}
+
+func (c *Config) Validate() error {
+	switch c.Mode {
+	case ModeHard:
+		return nil
+	case ModeSoft:
+	default:
+		return fmt.Errorf("invalid deletion mode %q: expected %q or %q", c.Mode, ModeHard, ModeSoft)
+	}
+
+	if c.Retention <= 0 {
+		return errors.New("retention must be positive")
+	}
+
+	if c.ReaperInterval <= 0 {
+		return errors.New("reaper interval must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package trash
+
+import (
+	"context"
+	"time"
+)
+
+// Start starts the reaper, which periodically purges the records whose retention expired.
+// The reaper only runs in soft deletion mode.
+func (s *Service) Start(ctx context.Context) error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	logger.Info("Starting trash reaper", "interval", s.cfg.ReaperInterval, "retention", s.cfg.Retention)
+
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.cfg.ReaperInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.stopCh:
+				return
+			case <-ticker.C:
+				s.reap(ctx, time.Now())
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the reaper.
+func (s *Service) Stop() error {
+	close(s.stopCh)
+	s.wg.Wait()
+
+	logger.Info("Trash reaper stopped")
+
+	return nil
+}
+
+// reap purges the records that were trashed longer than the retention ago.
+func (s *Service) reap(ctx context.Context, now time.Time) {
+	records, err := s.db.GetTrashedRecords(now.Add(-s.cfg.Retention))
+	if err != nil {
+		logger.Error("Failed to get expired trashed records", "error", err)
+
+		return
+	}
+
+	purgedCount := 0
+
+	for _, record := range records {
+		if err := s.purge(ctx, record.CID); err != nil {
+			logger.Warn("Failed to purge expired trashed record", "error", err, "cid", record.CID)
+
+			continue
+		}
+
+		purgedCount++
+	}
+
+	if purgedCount > 0 {
+		logger.Info("Purged expired trashed records", "count", purgedCount)
+	}
+}
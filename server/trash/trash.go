@@ -0,0 +1,260 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package trash
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/quota"
+	"github.com/agntcy/dir/server/trash/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var logger = logging.Logger("trash")
+
+// Service moves records deleted in soft deletion mode to the trash, restores them,
+// and purges them once their retention expired.
+//
+// Trashed records are kept in the store, but they are removed from the search index, their name tags
+// are removed and their publication is suspended, so that they can no longer be discovered.
+// The pins of published records are kept, so that restored records are published again.
+type Service struct {
+	cfg     config.Config
+	store   types.StoreAPI
+	db      types.DatabaseAPI
+	routing types.RoutingAPI
+	quota   *quota.Service
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates a new trash service.
+// Publications are not suspended if the routing service is nil,
+// and the quota used by purged records is not released if the quota service is nil.
+func New(cfg config.Config, store types.StoreAPI, db types.DatabaseAPI, routing types.RoutingAPI, quotaService *quota.Service) (*Service, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid deletion config: %w", err)
+	}
+
+	return &Service{
+		cfg:     cfg,
+		store:   store,
+		db:      db,
+		routing: routing,
+		quota:   quotaService,
+		stopCh:  make(chan struct{}),
+	}, nil
+}
+
+// Enabled reports whether deleted records are moved to the trash.
+func (s *Service) Enabled() bool {
+	return s != nil && s.cfg.Mode == config.ModeSoft
+}
+
+// IsTrashed reports whether the record is in the trash.
+func (s *Service) IsTrashed(cid string) (bool, error) {
+	if !s.Enabled() {
+		return false, nil
+	}
+
+	_, trashed, err := s.db.GetTrashedRecord(cid)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to check trash: %v", err)
+	}
+
+	return trashed, nil
+}
+
+// List returns the records in the trash, most recently trashed first.
+func (s *Service) List() ([]types.TrashedRecord, error) {
+	records, err := s.db.GetTrashedRecords(time.Time{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list trash: %v", err)
+	}
+
+	return records, nil
+}
+
+// ExpiresAt returns when the trashed record is purged.
+func (s *Service) ExpiresAt(record types.TrashedRecord) time.Time {
+	return record.TrashedAt.Add(s.cfg.Retention)
+}
+
+// Trash moves a stored record to the trash. Published records are unpublished, but stay pinned.
+func (s *Service) Trash(ctx context.Context, ref *corev1.RecordRef, meta *corev1.RecordMeta) error {
+	record, err := s.store.Pull(ctx, ref)
+	if err != nil {
+		st := status.Convert(err)
+
+		return status.Errorf(st.Code(), "failed to pull record to trash: %s", st.Message())
+	}
+
+	_, pinned, err := s.db.GetRecordPin(ref.GetCid())
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check record pin: %v", err)
+	}
+
+	if pinned && s.routing != nil {
+		if err := s.routing.Unpublish(ctx, adapters.NewRecordAdapter(record)); err != nil {
+			st := status.Convert(err)
+
+			return status.Errorf(st.Code(), "failed to suspend record publication: %s", st.Message())
+		}
+	}
+
+	err = s.db.TrashRecord(types.TrashedRecord{
+		CID:       ref.GetCid(),
+		Name:      meta.GetAnnotations()["name"],
+		Version:   meta.GetAnnotations()["version"],
+		TrashedAt: time.Now(),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to trash record: %v", err)
+	}
+
+	// Name tags are removed on a best-effort basis, trashed records are not resolved by the controller anyway
+	if untagger, ok := s.store.(interface {
+		Untag(ctx context.Context, ref *corev1.RecordRef) error
+	}); ok {
+		if err := untagger.Untag(ctx, ref); err != nil {
+			logger.Debug("Failed to remove tags of trashed record", "error", err, "cid", ref.GetCid())
+		}
+	}
+
+	if err := s.db.RemoveRecord(ref.GetCid()); err != nil {
+		logger.Error("Failed to remove trashed record from search index", "error", err, "cid", ref.GetCid())
+	}
+
+	logger.Info("Record moved to trash", "cid", ref.GetCid(), "publication_suspended", pinned)
+
+	return nil
+}
+
+// Restore reinstates a record from the trash, recreating its tags, its search index entry and its publication.
+// It fails with NotFound if the record is not in the trash, and with FailedPrecondition if its retention expired.
+func (s *Service) Restore(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	trashed, ok, err := s.db.GetTrashedRecord(ref.GetCid())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get trashed record: %v", err)
+	}
+
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record %s is not in the trash", ref.GetCid())
+	}
+
+	if time.Now().After(s.ExpiresAt(trashed)) {
+		return nil, status.Errorf(codes.FailedPrecondition, "retention of record %s expired at %s",
+			ref.GetCid(), s.ExpiresAt(trashed).UTC().Format(time.RFC3339))
+	}
+
+	record, err := s.store.Pull(ctx, ref)
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to pull trashed record: %s", st.Message())
+	}
+
+	// Pushing the stored record again recreates its missing tags
+	if _, err := s.store.Push(ctx, record); err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to restore record tags: %s", st.Message())
+	}
+
+	if err := s.db.AddRecord(adapters.NewRecordAdapter(record)); err != nil {
+		logger.Error("Failed to add restored record to search index", "error", err, "cid", ref.GetCid())
+	}
+
+	if err := s.Reinstate(ctx, record); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Record restored from trash", "cid", ref.GetCid())
+
+	return record, nil
+}
+
+// Reinstate removes a record pushed again from the trash, publishing it again if it is pinned.
+// Records that are not in the trash are left untouched.
+func (s *Service) Reinstate(ctx context.Context, record *corev1.Record) error {
+	trashed, err := s.IsTrashed(record.GetCid())
+	if err != nil || !trashed {
+		return err
+	}
+
+	_, pinned, err := s.db.GetRecordPin(record.GetCid())
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check record pin: %v", err)
+	}
+
+	if pinned && s.routing != nil {
+		if err := s.routing.Publish(ctx, adapters.NewRecordAdapter(record)); err != nil {
+			st := status.Convert(err)
+
+			return status.Errorf(st.Code(), "failed to resume record publication: %s", st.Message())
+		}
+	}
+
+	if err := s.db.RemoveTrashedRecord(record.GetCid()); err != nil {
+		return status.Errorf(codes.Internal, "failed to remove record from trash: %v", err)
+	}
+
+	return nil
+}
+
+// Purge permanently deletes a record from the trash.
+// It fails with FailedPrecondition if the record is not in the trash.
+func (s *Service) Purge(ctx context.Context, ref *corev1.RecordRef) error {
+	trashed, err := s.IsTrashed(ref.GetCid())
+	if err != nil {
+		return err
+	}
+
+	if !trashed {
+		return status.Errorf(codes.FailedPrecondition, "record %s is not in the trash, delete it first", ref.GetCid())
+	}
+
+	return s.purge(ctx, ref.GetCid())
+}
+
+// purge deletes a trashed record from the store, unpins it and releases its quota.
+func (s *Service) purge(ctx context.Context, cid string) error {
+	// Records deleted from the store by other means only need to be released
+	if err := s.store.Delete(ctx, &corev1.RecordRef{Cid: cid}); err != nil && status.Code(err) != codes.NotFound {
+		st := status.Convert(err)
+
+		return status.Errorf(st.Code(), "failed to purge record: %s", st.Message())
+	}
+
+	if err := s.db.UnpinRecord(cid); err != nil {
+		logger.Error("Failed to unpin purged record", "error", err, "cid", cid)
+	}
+
+	if err := s.db.RemoveRecordACL(cid); err != nil {
+		logger.Error("Failed to remove purged record access control list", "error", err, "cid", cid)
+	}
+
+	if s.quota != nil {
+		if err := s.quota.RecordDelete(cid); err != nil {
+			logger.Error("Failed to release purged record usage", "error", err, "cid", cid)
+		}
+	}
+
+	if err := s.db.RemoveTrashedRecord(cid); err != nil {
+		return status.Errorf(codes.Internal, "failed to remove record from trash: %v", err)
+	}
+
+	logger.Info("Record purged from trash", "cid", cid)
+
+	return nil
+}
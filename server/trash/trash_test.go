@@ -0,0 +1,177 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package trash
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/database/sqlite"
+	"github.com/agntcy/dir/server/trash/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testStore is a minimal in-memory store.
+type testStore struct {
+	mu      sync.Mutex
+	records map[string]*corev1.Record
+}
+
+func (s *testStore) Push(_ context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[record.GetCid()] = record
+
+	return &corev1.RecordRef{Cid: record.GetCid()}, nil
+}
+
+func (s *testStore) Pull(_ context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[ref.GetCid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", ref.GetCid())
+	}
+
+	return record, nil
+}
+
+func (s *testStore) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	if _, err := s.Pull(ctx, ref); err != nil {
+		return nil, err
+	}
+
+	return &corev1.RecordMeta{Cid: ref.GetCid()}, nil
+}
+
+func (s *testStore) Delete(_ context.Context, ref *corev1.RecordRef) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, ref.GetCid())
+
+	return nil
+}
+
+func newTestService(t *testing.T, cfg config.Config) (*Service, *testStore, *sqlite.DB) {
+	t.Helper()
+
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "dir.db"))
+	require.NoError(t, err)
+
+	store := &testStore{records: make(map[string]*corev1.Record)}
+
+	service, err := New(cfg, store, db, nil, nil)
+	require.NoError(t, err)
+
+	return service, store, db
+}
+
+func newTestRecord(name string) *corev1.Record {
+	return corev1.New(&typesv1alpha0.Record{
+		Name:          name,
+		Version:       "v1.0.0",
+		SchemaVersion: "v0.3.1",
+	})
+}
+
+func TestReap(t *testing.T) {
+	service, store, db := newTestService(t, config.Config{
+		Mode:           config.ModeSoft,
+		Retention:      24 * time.Hour,
+		ReaperInterval: time.Hour,
+	})
+
+	expired, recent := newTestRecord("expired"), newTestRecord("recent")
+
+	for _, record := range []*corev1.Record{expired, recent} {
+		_, err := store.Push(t.Context(), record)
+		require.NoError(t, err)
+
+		require.NoError(t, service.Trash(t.Context(), &corev1.RecordRef{Cid: record.GetCid()}, &corev1.RecordMeta{}))
+	}
+
+	require.NoError(t, db.PinRecord(types.RecordPin{CID: expired.GetCid(), PublicationID: "pub-1"}))
+
+	// Only records trashed longer than the retention ago are purged
+	service.reap(t.Context(), time.Now().Add(12*time.Hour))
+
+	records, err := service.List()
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	service.reap(t.Context(), time.Now().Add(25*time.Hour))
+
+	records, err = service.List()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	_, err = store.Pull(t.Context(), &corev1.RecordRef{Cid: expired.GetCid()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, pinned, err := db.GetRecordPin(expired.GetCid())
+	require.NoError(t, err)
+	assert.False(t, pinned, "purged records must be unpinned")
+}
+
+func TestRestoreAfterExpiry(t *testing.T) {
+	service, store, db := newTestService(t, config.Config{
+		Mode:           config.ModeSoft,
+		Retention:      time.Hour,
+		ReaperInterval: time.Hour,
+	})
+
+	record := newTestRecord("expired")
+	ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+	_, err := store.Push(t.Context(), record)
+	require.NoError(t, err)
+
+	// The record expired, but was not purged by the reaper yet
+	require.NoError(t, db.TrashRecord(types.TrashedRecord{CID: record.GetCid(), TrashedAt: time.Now().Add(-2 * time.Hour)}))
+
+	_, err = service.Restore(t.Context(), ref)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	require.NoError(t, service.Purge(t.Context(), ref))
+
+	_, err = service.Restore(t.Context(), ref)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.Config
+		wantErr bool
+	}{
+		{name: "hard", cfg: config.Config{Mode: config.ModeHard}},
+		{name: "soft", cfg: config.Config{Mode: config.ModeSoft, Retention: time.Hour, ReaperInterval: time.Hour}},
+		{name: "unknown mode", cfg: config.Config{Mode: "archive"}, wantErr: true},
+		{name: "soft without retention", cfg: config.Config{Mode: config.ModeSoft, ReaperInterval: time.Hour}, wantErr: true},
+		{name: "soft without reaper interval", cfg: config.Config{Mode: config.ModeSoft, Retention: time.Hour}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+// RecordACL is the owner and access control list of a record.
+// Patterns are SPIFFE IDs that may contain globs, empty lists do not restrict the operation.
+type RecordACL struct {
+	// Owner is the SPIFFE ID of the caller that first pushed the record, empty for unauthenticated callers.
+	Owner string
+
+	// AllowPull are the patterns of the callers that can pull the record and its referrers.
+	AllowPull []string
+
+	// AllowDelete are the patterns of the callers that can delete the record and update its metadata.
+	AllowDelete []string
+}
+
+// Restricted reports whether the access control list restricts any operation.
+func (a RecordACL) Restricted() bool {
+	return len(a.AllowPull) > 0 || len(a.AllowDelete) > 0
+}
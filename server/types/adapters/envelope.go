@@ -0,0 +1,89 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package adapters
+
+import (
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types"
+)
+
+// EnvelopeAdapter adapts the metadata of an encrypted record to types.RecordData interface.
+// The content of encrypted records cannot be read, so only the metadata extracted by the client is available,
+// and the record has no description, authors, skills, locators, domains, modules or signature.
+type EnvelopeAdapter struct {
+	metadata *corev1.EnvelopeMetadata
+}
+
+// Compile-time interface check.
+var _ types.RecordData = (*EnvelopeAdapter)(nil)
+
+// NewEnvelopeAdapter creates a new EnvelopeAdapter.
+func NewEnvelopeAdapter(envelope *corev1.RecordEnvelope) *EnvelopeAdapter {
+	return &EnvelopeAdapter{metadata: envelope.GetMetadata()}
+}
+
+// GetAnnotations implements types.RecordData interface.
+func (a *EnvelopeAdapter) GetAnnotations() map[string]string {
+	return a.metadata.GetAnnotations()
+}
+
+// GetSchemaVersion implements types.RecordData interface.
+func (a *EnvelopeAdapter) GetSchemaVersion() string {
+	return a.metadata.GetSchemaVersion()
+}
+
+// GetName implements types.RecordData interface.
+func (a *EnvelopeAdapter) GetName() string {
+	return a.metadata.GetName()
+}
+
+// GetVersion implements types.RecordData interface.
+func (a *EnvelopeAdapter) GetVersion() string {
+	return a.metadata.GetVersion()
+}
+
+// GetDescription implements types.RecordData interface.
+func (a *EnvelopeAdapter) GetDescription() string {
+	return ""
+}
+
+// GetAuthors implements types.RecordData interface.
+func (a *EnvelopeAdapter) GetAuthors() []string {
+	return nil
+}
+
+// GetCreatedAt implements types.RecordData interface.
+func (a *EnvelopeAdapter) GetCreatedAt() string {
+	return a.metadata.GetCreatedAt()
+}
+
+// GetSkills implements types.RecordData interface.
+func (a *EnvelopeAdapter) GetSkills() []types.Skill {
+	return nil
+}
+
+// GetLocators implements types.RecordData interface.
+func (a *EnvelopeAdapter) GetLocators() []types.Locator {
+	return nil
+}
+
+// GetDomains implements types.RecordData interface.
+func (a *EnvelopeAdapter) GetDomains() []types.Domain {
+	return nil
+}
+
+// GetModules implements types.RecordData interface.
+func (a *EnvelopeAdapter) GetModules() []types.Module {
+	return nil
+}
+
+// GetSignature implements types.RecordData interface.
+func (a *EnvelopeAdapter) GetSignature() types.Signature {
+	return nil
+}
+
+// GetPreviousRecordCid implements types.RecordData interface.
+func (a *EnvelopeAdapter) GetPreviousRecordCid() string {
+	return a.metadata.GetAnnotations()[corev1.AnnotationPreviousCid]
+}
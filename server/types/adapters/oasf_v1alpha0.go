@@ -5,25 +5,20 @@ package adapters
 
 import (
 	"fmt"
-	"strings"
 
 	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/oasf-sdk/pkg/decoder"
 )
 
-const featuresSchemaPrefix = "schema.oasf.agntcy.org/features/"
-
 // V1Alpha0Adapter adapts typesv1alpha0.Record to types.RecordData interface.
 type V1Alpha0Adapter struct {
 	record *typesv1alpha0.Record
 }
 
-// Compile-time interface checks.
-var (
-	_ types.RecordData    = (*V1Alpha0Adapter)(nil)
-	_ types.LabelProvider = (*V1Alpha0Adapter)(nil)
-)
+// Compile-time interface check.
+var _ types.RecordData = (*V1Alpha0Adapter)(nil)
 
 // NewV1Alpha0Adapter creates a new V1Alpha0Adapter.
 func NewV1Alpha0Adapter(record *typesv1alpha0.Record) *V1Alpha0Adapter {
@@ -158,9 +153,13 @@ func (a *V1Alpha0Adapter) GetSignature() types.Signature {
 }
 
 // GetPreviousRecordCid implements types.RecordData interface.
+// V1 doesn't have a previous record CID field, so only the previous_cid annotation is used.
 func (a *V1Alpha0Adapter) GetPreviousRecordCid() string {
-	// V1 doesn't have previous record CID
-	return ""
+	if a.record == nil {
+		return ""
+	}
+
+	return a.record.GetAnnotations()[corev1.AnnotationPreviousCid]
 }
 
 // V1Alpha0SignatureAdapter adapts typesv1alpha0.Signature to types.Signature interface.
@@ -361,83 +360,3 @@ func (m *V1Alpha0ModuleAdapter) GetData() map[string]any {
 
 	return *resp
 }
-
-// GetSkillLabels implements types.LabelProvider interface.
-func (a *V1Alpha0Adapter) GetSkillLabels() []types.Label {
-	if a.record == nil {
-		return nil
-	}
-
-	skills := a.record.GetSkills()
-	result := make([]types.Label, 0, len(skills))
-
-	for _, skill := range skills {
-		// Reuse the existing skill adapter logic for name formatting
-		skillAdapter := NewV1Alpha0SkillAdapter(skill)
-		skillName := skillAdapter.GetName()
-
-		skillLabel := types.Label(types.LabelTypeSkill.Prefix() + skillName)
-		result = append(result, skillLabel)
-	}
-
-	return result
-}
-
-// GetLocatorLabels implements types.LabelProvider interface.
-func (a *V1Alpha0Adapter) GetLocatorLabels() []types.Label {
-	if a.record == nil {
-		return nil
-	}
-
-	locators := a.record.GetLocators()
-	result := make([]types.Label, 0, len(locators))
-
-	for _, locator := range locators {
-		locatorAdapter := NewV1Alpha0LocatorAdapter(locator)
-		locatorType := locatorAdapter.GetType()
-
-		locatorLabel := types.Label(types.LabelTypeLocator.Prefix() + locatorType)
-		result = append(result, locatorLabel)
-	}
-
-	return result
-}
-
-// GetDomainLabels implements types.LabelProvider interface.
-func (a *V1Alpha0Adapter) GetDomainLabels() []types.Label {
-	// V1Alpha0 doesn't have domains, return empty slice
-	return []types.Label{}
-}
-
-// GetModuleLabels implements types.LabelProvider interface.
-func (a *V1Alpha0Adapter) GetModuleLabels() []types.Label {
-	if a.record == nil {
-		return nil
-	}
-
-	extensions := a.record.GetExtensions()
-	result := make([]types.Label, 0, len(extensions))
-
-	for _, ext := range extensions {
-		extensionName := ext.GetName()
-
-		// Handle v0.3.1 schema prefix for features - now map to modules
-		name := strings.TrimPrefix(extensionName, featuresSchemaPrefix)
-		moduleLabel := types.Label(types.LabelTypeModule.Prefix() + name)
-		result = append(result, moduleLabel)
-	}
-
-	return result
-}
-
-// GetAllLabels implements types.LabelProvider interface.
-func (a *V1Alpha0Adapter) GetAllLabels() []types.Label {
-	var allLabels []types.Label
-
-	allLabels = append(allLabels, a.GetSkillLabels()...)
-	allLabels = append(allLabels, a.GetDomainLabels()...)
-	allLabels = append(allLabels, a.GetModuleLabels()...)
-	allLabels = append(allLabels, a.GetLocatorLabels()...)
-
-	return allLabels
-}
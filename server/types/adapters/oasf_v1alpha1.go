@@ -5,6 +5,7 @@ package adapters
 
 import (
 	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
+	corev1 "github.com/agntcy/dir/api/core/v1"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/oasf-sdk/pkg/decoder"
 )
@@ -14,11 +15,8 @@ type V1Alpha1Adapter struct {
 	record *typesv1alpha1.Record
 }
 
-// Compile-time interface checks.
-var (
-	_ types.RecordData    = (*V1Alpha1Adapter)(nil)
-	_ types.LabelProvider = (*V1Alpha1Adapter)(nil)
-)
+// Compile-time interface check.
+var _ types.RecordData = (*V1Alpha1Adapter)(nil)
 
 // NewV1Alpha1Adapter creates a new V1Alpha1Adapter.
 func NewV1Alpha1Adapter(record *typesv1alpha1.Record) *V1Alpha1Adapter {
@@ -162,11 +160,16 @@ func (a *V1Alpha1Adapter) GetSignature() types.Signature {
 }
 
 // GetPreviousRecordCid implements types.RecordData interface.
+// The previous_cid annotation takes precedence over the previous_record_cid field.
 func (a *V1Alpha1Adapter) GetPreviousRecordCid() string {
 	if a.record == nil {
 		return ""
 	}
 
+	if cid := a.record.GetAnnotations()[corev1.AnnotationPreviousCid]; cid != "" {
+		return cid
+	}
+
 	return a.record.GetPreviousRecordCid()
 }
 
@@ -408,97 +411,3 @@ func (m *V1Alpha1ModuleAdapter) GetData() map[string]any {
 
 	return *resp
 }
-
-// GetSkillLabels implements types.LabelProvider interface.
-func (a *V1Alpha1Adapter) GetSkillLabels() []types.Label {
-	if a.record == nil {
-		return nil
-	}
-
-	skills := a.record.GetSkills()
-	result := make([]types.Label, 0, len(skills))
-
-	for _, skill := range skills {
-		// Reuse the existing skill adapter logic for name formatting
-		skillAdapter := NewV1Alpha1SkillAdapter(skill)
-		skillName := skillAdapter.GetName()
-
-		skillLabel := types.Label(types.LabelTypeSkill.Prefix() + skillName)
-		result = append(result, skillLabel)
-	}
-
-	return result
-}
-
-// GetLocatorLabels implements types.LabelProvider interface.
-func (a *V1Alpha1Adapter) GetLocatorLabels() []types.Label {
-	if a.record == nil {
-		return nil
-	}
-
-	locators := a.record.GetLocators()
-	result := make([]types.Label, 0, len(locators))
-
-	for _, locator := range locators {
-		locatorAdapter := NewV1Alpha1LocatorAdapter(locator)
-		locatorType := locatorAdapter.GetType()
-
-		locatorLabel := types.Label(types.LabelTypeLocator.Prefix() + locatorType)
-		result = append(result, locatorLabel)
-	}
-
-	return result
-}
-
-// GetDomainLabels implements types.LabelProvider interface.
-func (a *V1Alpha1Adapter) GetDomainLabels() []types.Label {
-	if a.record == nil {
-		return nil
-	}
-
-	domains := a.record.GetDomains()
-	result := make([]types.Label, 0, len(domains))
-
-	for _, domain := range domains {
-		domainAdapter := NewV1Alpha1DomainAdapter(domain)
-		domainName := domainAdapter.GetName()
-
-		domainLabel := types.Label(types.LabelTypeDomain.Prefix() + domainName)
-		result = append(result, domainLabel)
-	}
-
-	return result
-}
-
-// GetModuleLabels implements types.LabelProvider interface.
-func (a *V1Alpha1Adapter) GetModuleLabels() []types.Label {
-	if a.record == nil {
-		return nil
-	}
-
-	modules := a.record.GetModules()
-	result := make([]types.Label, 0, len(modules))
-
-	for _, mod := range modules {
-		moduleAdapter := NewV1Alpha1ModuleAdapter(mod)
-		moduleName := moduleAdapter.GetName()
-
-		// V1Alpha1 modules don't have schema prefixes, use name directly with /modules prefix
-		moduleLabel := types.Label(types.LabelTypeModule.Prefix() + moduleName)
-		result = append(result, moduleLabel)
-	}
-
-	return result
-}
-
-// GetAllLabels implements types.LabelProvider interface.
-func (a *V1Alpha1Adapter) GetAllLabels() []types.Label {
-	var allLabels []types.Label
-
-	allLabels = append(allLabels, a.GetSkillLabels()...)
-	allLabels = append(allLabels, a.GetDomainLabels()...)
-	allLabels = append(allLabels, a.GetModuleLabels()...)
-	allLabels = append(allLabels, a.GetLocatorLabels()...)
-
-	return allLabels
-}
@@ -28,7 +28,12 @@ func (r *RecordAdapter) GetCid() string {
 }
 
 // GetRecordData implements types.Record interface.
+// Encrypted records are adapted from the metadata in their envelope.
 func (r *RecordAdapter) GetRecordData() (types.RecordData, error) {
+	if envelope := r.record.GetEnvelope(); envelope != nil {
+		return NewEnvelopeAdapter(envelope), nil
+	}
+
 	// Decode record
 	decoded, err := r.record.Decode()
 	if err != nil {
@@ -4,6 +4,8 @@
 package types
 
 import (
+	"time"
+
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	storev1 "github.com/agntcy/dir/api/store/v1"
 )
@@ -12,6 +14,13 @@ type DatabaseAPI interface {
 	SearchDatabaseAPI
 	SyncDatabaseAPI
 	PublicationDatabaseAPI
+	QuotaDatabaseAPI
+	PinDatabaseAPI
+	TrashDatabaseAPI
+	StatsDatabaseAPI
+	ScanDatabaseAPI
+	ACLDatabaseAPI
+	WebhookDatabaseAPI
 }
 
 type SearchDatabaseAPI interface {
@@ -25,13 +34,21 @@ type SearchDatabaseAPI interface {
 	// This is more efficient than GetRecords when only CIDs are needed.
 	GetRecordCIDs(opts ...FilterOption) ([]string, error)
 
+	// GetRecordScores retrieves the CIDs of records matching the free-text query set with WithQuery,
+	// most relevant first. Records must match the other filters, which do not affect the score.
+	GetRecordScores(opts ...FilterOption) ([]RecordScore, error)
+
 	// RemoveRecord removes a record from the search database by CID.
 	RemoveRecord(cid string) error
+
+	// GetSupersededBy returns the CID of the newest record equivalent to the record, e.g. migrated from it.
+	// It returns false if the record is not superseded.
+	GetSupersededBy(cid string) (string, bool, error)
 }
 
 type SyncDatabaseAPI interface {
 	// CreateSync creates a new sync object in the database.
-	CreateSync(remoteURL string, cids []string) (string, error)
+	CreateSync(remoteURL string, opts SyncOptions) (string, error)
 
 	// GetSyncByID retrieves a sync object by its ID.
 	GetSyncByID(syncID string) (SyncObject, error)
@@ -51,6 +68,10 @@ type SyncDatabaseAPI interface {
 	// GetSyncRemoteRegistry retrieves the remote registry of a sync object.
 	GetSyncRemoteRegistry(syncID string) (string, error)
 
+	// UpdateSyncProgress records the result of a sync run.
+	// The synced records are added to the total and the last run time is set to now.
+	UpdateSyncProgress(syncID string, recordsSynced uint64, lastError string) error
+
 	// DeleteSync deletes a sync object by its ID.
 	DeleteSync(syncID string) error
 }
@@ -74,3 +95,109 @@ type PublicationDatabaseAPI interface {
 	// DeletePublication deletes a publication object by its ID.
 	DeletePublication(publicationID string) error
 }
+
+type QuotaDatabaseAPI interface {
+	// AddRecordUsage accounts a record to a trust domain.
+	// Records that are already accounted keep their owner and are only marked as accessed.
+	AddRecordUsage(usage RecordUsage) error
+
+	// HasRecordUsage checks if a record is accounted to a trust domain.
+	HasRecordUsage(cid string) (bool, error)
+
+	// GetRecordOwner returns the trust domain a record is accounted to.
+	// It returns false if the record is not accounted.
+	GetRecordOwner(cid string) (string, bool, error)
+
+	// TouchRecordUsage sets the last access time of a record.
+	TouchRecordUsage(cid string, accessedAt time.Time) error
+
+	// RemoveRecordUsage removes a record from the usage accounting.
+	RemoveRecordUsage(cid string) error
+
+	// GetUsage returns the usage of the given trust domains, or of all trust domains owning records if none are given.
+	GetUsage(trustDomains ...string) ([]TrustDomainUsage, error)
+
+	// GetUnusedRecords returns the CIDs of unprotected records of a trust domain
+	// which were last accessed before the given time and are not flagged as expired yet.
+	GetUnusedRecords(trustDomain string, accessedBefore time.Time) ([]string, error)
+
+	// FlagRecordExpired flags a record as expired.
+	FlagRecordExpired(cid string) error
+}
+
+type PinDatabaseAPI interface {
+	// PinRecord pins a published record, replacing its previous pin.
+	PinRecord(pin RecordPin) error
+
+	// GetRecordPin returns the pin of a record.
+	// It returns false if the record is not pinned.
+	GetRecordPin(cid string) (RecordPin, bool, error)
+
+	// UnpinRecord removes the pin of a record. Unpinning a record that is not pinned is a no-op.
+	UnpinRecord(cid string) error
+}
+
+type TrashDatabaseAPI interface {
+	// TrashRecord moves a record to the trash, replacing its previous trash entry.
+	TrashRecord(record TrashedRecord) error
+
+	// GetTrashedRecord returns the trash entry of a record.
+	// It returns false if the record is not in the trash.
+	GetTrashedRecord(cid string) (TrashedRecord, bool, error)
+
+	// GetTrashedRecords returns the records in the trash, most recently trashed first.
+	// Only records trashed before the given time are returned if it is not zero.
+	GetTrashedRecords(trashedBefore time.Time) ([]TrashedRecord, error)
+
+	// RemoveTrashedRecord removes a record from the trash. Removing a record that is not in the trash is a no-op.
+	RemoveTrashedRecord(cid string) error
+}
+
+type StatsDatabaseAPI interface {
+	// AddRecordStats adds the pull and lookup counts of the records to their statistics,
+	// and advances their last access time, which is also the last access time of their usage.
+	AddRecordStats(stats []RecordStats) error
+
+	// GetRecordStats returns the statistics of a record, with zero counts if it was never accessed.
+	GetRecordStats(cid string) (RecordStats, error)
+
+	// GetTopRecordStats returns the statistics of at most limit records in the given order.
+	GetTopRecordStats(order RecordStatsOrder, limit int) ([]RecordStats, error)
+}
+
+type ScanDatabaseAPI interface {
+	// SetScanWarnings replaces the scan warnings of a record. Setting no warnings removes them.
+	SetScanWarnings(cid string, warnings []ScanWarning) error
+
+	// GetScanWarnings returns the scan warnings of a record in the order they were set.
+	GetScanWarnings(cid string) ([]ScanWarning, error)
+}
+
+type ACLDatabaseAPI interface {
+	// SetRecordOwner records the SPIFFE ID of the caller that pushed a record.
+	// Records that already have an owner keep it.
+	SetRecordOwner(cid string, owner string) error
+
+	// GetRecordACL returns the owner and access control list of a record.
+	// It returns false if the record has neither.
+	GetRecordACL(cid string) (RecordACL, bool, error)
+
+	// SetRecordACL replaces the access control list of a record, keeping its owner.
+	SetRecordACL(cid string, allowPull, allowDelete []string) error
+
+	// RemoveRecordACL removes the owner and access control list of a record.
+	// Removing the access control list of a record that has none is a no-op.
+	RemoveRecordACL(cid string) error
+}
+
+type WebhookDatabaseAPI interface {
+	// AddWebhook stores a webhook subscription.
+	AddWebhook(webhook Webhook) error
+
+	// GetWebhooks returns the stored webhook subscriptions, oldest first.
+	GetWebhooks() ([]Webhook, error)
+
+	// RemoveWebhook removes a webhook subscription.
+	// It returns false if the subscription does not exist.
+	RemoveWebhook(id string) (bool, error)
+}
@@ -164,35 +164,3 @@ const (
 	// Enhanced format: /type/label/CID/PeerID splits into ["", "type", "label", "CID", "PeerID"] = 5 parts.
 	MinLabelKeyParts = 5
 )
-
-// GetLabelsFromRecord extracts labels from a record using the LabelProvider interface.
-// This function works at the types interface level, making it usable from any package
-// without circular dependencies.
-//
-// The caller is responsible for wrapping concrete record types (e.g., *corev1.Record)
-// with the appropriate adapter before calling this function.
-//
-// Example:
-//
-//	adapter := adapters.NewRecordAdapter(corev1Record)
-//	labels := types.GetLabelsFromRecord(adapter)
-//
-// Returns:
-//   - []Label: List of all labels extracted from the record
-//   - nil: If record is nil, has no data, or doesn't implement LabelProvider
-func GetLabelsFromRecord(record Record) []Label {
-	if record == nil {
-		return nil
-	}
-
-	recordData, err := record.GetRecordData()
-	if err != nil {
-		return nil
-	}
-
-	if provider, ok := recordData.(LabelProvider); ok {
-		return provider.GetAllLabels()
-	}
-
-	return nil
-}
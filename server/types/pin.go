@@ -0,0 +1,22 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "time"
+
+// RecordPin protects a published record from deletion.
+// Records are pinned when they are published and unpinned when they are unpublished.
+type RecordPin struct {
+	// CID of the record.
+	CID string
+
+	// PublicationID is the ID of the publication that last published the record.
+	PublicationID string
+
+	// Labels are the routing labels the record is published with.
+	Labels []string
+
+	// PinnedAt is when the record was last published.
+	PinnedAt time.Time
+}
@@ -0,0 +1,38 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "time"
+
+// RecordUsage accounts a pushed record to the trust domain that pushed it.
+type RecordUsage struct {
+	// CID of the record.
+	CID string
+
+	// TrustDomain of the caller that pushed the record, empty for unauthenticated callers.
+	TrustDomain string
+
+	// SizeBytes is the size of the canonical record data.
+	SizeBytes uint64
+
+	// Protected records are never expired.
+	Protected bool
+
+	// AccessedAt is when the record was last pushed, pulled or looked up.
+	AccessedAt time.Time
+}
+
+// TrustDomainUsage is the storage usage of a trust domain.
+type TrustDomainUsage struct {
+	TrustDomain string
+
+	// RecordCount is the number of records owned by the trust domain.
+	RecordCount uint64
+
+	// TotalBytes is the total size of the records owned by the trust domain.
+	TotalBytes uint64
+
+	// ExpiredRecordCount is the number of owned records flagged as expired.
+	ExpiredRecordCount uint64
+}
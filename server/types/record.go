@@ -74,13 +74,3 @@ type Locator interface {
 	GetSize() uint64
 	GetDigest() string
 }
-
-// LabelProvider provides routing labels for different record components.
-// Implementations should handle version-specific label generation logic.
-type LabelProvider interface {
-	GetSkillLabels() []Label
-	GetDomainLabels() []Label
-	GetModuleLabels() []Label
-	GetLocatorLabels() []Label
-	GetAllLabels() []Label
-}
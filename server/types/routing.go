@@ -5,7 +5,10 @@ package types
 
 import (
 	"context"
+	"slices"
+	"time"
 
+	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
@@ -18,8 +21,8 @@ type RoutingAPI interface {
 	// The caller must wrap concrete record types (e.g. *corev1.Record) with adapters.NewRecordAdapter()
 	Publish(context.Context, Record) error
 
-	// List all records that this peer is currently providing (local-only operation)
-	List(context.Context, *routingv1.ListRequest) (<-chan *routingv1.ListResponse, error)
+	// List all records that this peer is currently providing in the namespaces of the scope (local-only operation)
+	List(context.Context, *routingv1.ListRequest, NamespaceScope) (<-chan *routingv1.ListResponse, error)
 
 	// Search for records across the network using cached remote announcements
 	Search(context.Context, *routingv1.SearchRequest) (<-chan *routingv1.SearchResponse, error)
@@ -33,8 +36,54 @@ type RoutingAPI interface {
 	Stop() error
 }
 
+// AtomicRoutingAPI is implemented by routing layers that can publish a set of records atomically.
+type AtomicRoutingAPI interface {
+	// PublishAtomic stages the announcements of the records in the transaction and commits them together,
+	// rolling back the staged announcements if any record cannot be staged. The announcements are committed
+	// with the options, like a publication with the same options.
+	// The caller must wrap concrete record types (e.g. *corev1.Record) with adapters.NewRecordAdapter()
+	PublishAtomic(ctx context.Context, transactionID string, records []Record, opts PublishOptions) error
+}
+
+// PublishOptions are the options records are announced with.
+type PublishOptions struct {
+	// TTL of the announcements, zero if they do not expire.
+	TTL time.Duration
+
+	// Namespace the records are published in, the trust domain of the publisher.
+	// Empty if the publisher was not authenticated.
+	Namespace string
+
+	// Public lists the records to callers of all namespaces, not only to callers of their namespace.
+	Public bool
+}
+
+// NamespaceScope selects the namespaces whose records are listed.
+type NamespaceScope struct {
+	// All lists the records of all namespaces, regardless of the other fields.
+	All bool
+
+	// Namespaces whose records are listed.
+	Namespaces []string
+
+	// Public also lists the public records of the other namespaces.
+	Public bool
+}
+
+// AllNamespaces is the scope of the records of all namespaces.
+var AllNamespaces = NamespaceScope{All: true}
+
+// Includes reports whether records published in the namespace, publicly or not, are in the scope.
+func (s NamespaceScope) Includes(namespace string, public bool) bool {
+	return s.All || (s.Public && public) || slices.Contains(s.Namespaces, namespace)
+}
+
 // PublicationAPI handles management of publication tasks.
 type PublicationAPI interface {
 	// CreatePublication creates a new publication task to be processed.
 	CreatePublication(context.Context, *routingv1.PublishRequest) (string, error)
+
+	// PublishAtomic publishes the referenced records together in a new transaction, returning its ID.
+	// Either all records are announced, or none of them.
+	PublishAtomic(ctx context.Context, refs []*corev1.RecordRef, opts PublishOptions) (string, error)
 }
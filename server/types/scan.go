@@ -0,0 +1,19 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+// ScanWarning is a finding of a content scanner that did not reject the push of a record.
+type ScanWarning struct {
+	// Scanner is the name of the scanner, e.g. "secrets".
+	Scanner string
+
+	// Rule is the rule of the scanner that matched.
+	Rule string
+
+	// Message describes the finding.
+	Message string
+
+	// Location locates the flagged content in the record.
+	Location string
+}
@@ -3,16 +3,61 @@
 
 package types
 
+import "time"
+
 type RecordFilters struct {
-	Limit        int
-	Offset       int
-	Name         string
-	Version      string
-	SkillIDs     []uint64
-	SkillNames   []string
-	LocatorTypes []string
-	LocatorURLs  []string
-	ModuleNames  []string
+	Limit              int
+	Offset             int
+	Name               string
+	Version            string
+	VersionConstraints []VersionConstraint
+	SkillIDs           []uint64
+	SkillNames         []string
+	LocatorTypes       []string
+	LocatorURLs        []string
+	ModuleNames        []string
+	LocatorVariants    []string
+	Annotations        []AnnotationFilter
+	CreatedAt          []CreatedAtConstraint
+	TrustDomain        string
+	Query              string
+	ExcludeSuperseded  bool
+}
+
+// RecordScore is the relevance of a record to a free-text query.
+type RecordScore struct {
+	CID   string
+	Score float64
+}
+
+// VersionOperator compares record versions with a version constraint.
+type VersionOperator string
+
+const (
+	VersionGreaterThan        VersionOperator = ">"
+	VersionGreaterThanOrEqual VersionOperator = ">="
+	VersionLessThan           VersionOperator = "<"
+	VersionLessThanOrEqual    VersionOperator = "<="
+)
+
+// VersionConstraint matches records whose semantic version compares to Version with Operator.
+type VersionConstraint struct {
+	Operator VersionOperator
+	Version  string
+}
+
+// CreatedAtConstraint matches records added to the directory at a time that compares to Time with Operator.
+type CreatedAtConstraint struct {
+	Operator VersionOperator
+	Time     time.Time
+}
+
+// AnnotationFilter matches records with an annotation.
+// The key must match exactly, and the value is a wildcard pattern.
+// An empty value matches any value.
+type AnnotationFilter struct {
+	Key   string
+	Value string
 }
 
 type FilterOption func(*RecordFilters)
@@ -31,6 +76,21 @@ func WithOffset(offset int) FilterOption {
 	}
 }
 
+// WithQuery sets the free-text query that records are ranked by.
+func WithQuery(query string) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.Query = query
+	}
+}
+
+// WithoutSuperseded RecordFilters out records superseded by an equivalent record,
+// so that only the newest record of each chain of equivalent records matches.
+func WithoutSuperseded() FilterOption {
+	return func(sc *RecordFilters) {
+		sc.ExcludeSuperseded = true
+	}
+}
+
 // WithName RecordFilters records by name (partial match).
 func WithName(name string) FilterOption {
 	return func(sc *RecordFilters) {
@@ -45,6 +105,45 @@ func WithVersion(version string) FilterOption {
 	}
 }
 
+// WithVersionConstraint RecordFilters records by comparing their semantic version.
+// All constraints must match, e.g. to filter a version range.
+func WithVersionConstraint(operator VersionOperator, version string) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.VersionConstraints = append(sc.VersionConstraints, VersionConstraint{Operator: operator, Version: version})
+	}
+}
+
+// WithCreatedAt RecordFilters records by comparing the time they were added to the directory.
+// All constraints must match, e.g. to filter a time range.
+func WithCreatedAt(operator VersionOperator, t time.Time) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.CreatedAt = append(sc.CreatedAt, CreatedAtConstraint{Operator: operator, Time: t})
+	}
+}
+
+// WithTrustDomain RecordFilters records by the trust domain that pushed them (wildcard match).
+func WithTrustDomain(trustDomain string) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.TrustDomain = trustDomain
+	}
+}
+
+// WithAnnotation RecordFilters records by annotation.
+// All annotation filters must match.
+func WithAnnotation(key, value string) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.Annotations = append(sc.Annotations, AnnotationFilter{Key: key, Value: value})
+	}
+}
+
+// WithLocatorVariant RecordFilters records by locator variant, e.g. "helm_chart.arm64".
+// The variant is a wildcard pattern, and all locator variant filters must match.
+func WithLocatorVariant(variant string) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.LocatorVariants = append(sc.LocatorVariants, variant)
+	}
+}
+
 // WithSkillIDs RecordFilters records by skill IDs.
 func WithSkillIDs(ids ...uint64) FilterOption {
 	return func(sc *RecordFilters) {
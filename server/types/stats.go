@@ -0,0 +1,30 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "time"
+
+// RecordStats are the usage statistics of a record.
+type RecordStats struct {
+	// CID of the record.
+	CID string
+
+	// PullCount and LookupCount are the number of pulls and lookups of the record.
+	PullCount   uint64
+	LookupCount uint64
+
+	// LastAccessedAt is the time of the last pull or lookup, zero if the record was never accessed.
+	LastAccessedAt time.Time
+}
+
+// RecordStatsOrder is the order of the most used records.
+type RecordStatsOrder int
+
+const (
+	// RecordStatsByPulls orders records by their pull count, most pulled first.
+	RecordStatsByPulls RecordStatsOrder = iota
+
+	// RecordStatsByRecent orders records by their last access time, most recent first.
+	RecordStatsByRecent
+)
@@ -3,11 +3,38 @@
 
 package types
 
-import storev1 "github.com/agntcy/dir/api/store/v1"
+import (
+	"time"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+)
 
 type SyncObject interface {
 	GetID() string
 	GetRemoteDirectoryURL() string
 	GetCIDs() []string
 	GetStatus() storev1.SyncStatus
+	GetLabels() []string
+	GetInterval() time.Duration
+	GetRecordsSynced() uint64
+	GetLastError() string
+	GetLastRunTime() time.Time
+}
+
+// SyncOptions configures which records a sync object synchronizes and how often.
+type SyncOptions struct {
+	// CIDs to synchronize. If empty, all records are synchronized.
+	CIDs []string
+
+	// Labels filters records by label, e.g. "/skills/natural_language_processing".
+	Labels []string
+
+	// Interval at which the sync is repeated. Zero means the sync runs once.
+	Interval time.Duration
+}
+
+// UsesStoreAPI reports whether records are synchronized through the store API
+// instead of mirroring the remote registry, which cannot filter or schedule.
+func (o SyncOptions) UsesStoreAPI() bool {
+	return len(o.Labels) > 0 || o.Interval > 0
 }
@@ -0,0 +1,20 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "time"
+
+// TrashedRecord is a record deleted in soft deletion mode.
+// Trashed records are kept in the store until they are restored or purged.
+type TrashedRecord struct {
+	// CID of the record.
+	CID string
+
+	// Name and Version of the record, for listing the trash.
+	Name    string
+	Version string
+
+	// TrashedAt is when the record was deleted.
+	TrashedAt time.Time
+}
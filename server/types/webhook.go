@@ -0,0 +1,33 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "time"
+
+// Webhook is a subscription of an HTTP endpoint to store and routing events.
+type Webhook struct {
+	// ID identifies the subscription.
+	ID string
+
+	// URL is the endpoint the events are POSTed to.
+	URL string
+
+	// Secret is the key the events are signed with.
+	Secret string
+
+	// EventTypes are the delivered event types, e.g. "pushed". Empty lists deliver all events.
+	EventTypes []string
+
+	// Names are the name patterns of which the record must match one. Empty lists match all records.
+	Names []string
+
+	// Labels are the routing labels of which the record must have one. Empty lists match all records.
+	Labels []string
+
+	// MaxAttempts is the number of delivery attempts, zero for the server default.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry, zero for the server default.
+	InitialBackoff time.Duration
+}
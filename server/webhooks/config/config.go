@@ -0,0 +1,166 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"time"
+)
+
+const (
+	// EventPushed is delivered for records pushed to the store.
+	EventPushed = "pushed"
+
+	// EventDeleted is delivered for records deleted from the store.
+	EventDeleted = "deleted"
+
+	// EventPublished is delivered for records published to the network.
+	EventPublished = "published"
+)
+
+const (
+	DefaultQueueSize      = 1024
+	DefaultWorkers        = 4
+	DefaultTimeout        = 10 * time.Second
+	DefaultMaxAttempts    = 5
+	DefaultInitialBackoff = 1 * time.Second
+	DefaultMaxBackoff     = 5 * time.Minute
+	DefaultDeadLetterPath = "/var/lib/dir/webhooks/dead-letter.jsonl"
+)
+
+// Subscription is a webhook subscription of the server configuration.
+type Subscription struct {
+	// ID of the subscription, used to test it and reported in the dead-letter log
+	ID string `json:"id,omitempty" mapstructure:"id"`
+
+	// URL the events are POSTed to
+	URL string `json:"url,omitempty" mapstructure:"url"`
+
+	// Secret the events are signed with, sent in the X-Dir-Signature header
+	Secret string `json:"secret,omitempty" mapstructure:"secret"`
+
+	// Delivered events, any of "pushed", "deleted" and "published". All events are delivered if empty.
+	Events []string `json:"events,omitempty" mapstructure:"events"`
+
+	// Name patterns of which the record must match one, in the path.Match syntax
+	Names []string `json:"names,omitempty" mapstructure:"names"`
+
+	// Routing labels of which the record must have one, e.g. "/skills/natural_language_processing"
+	Labels []string `json:"labels,omitempty" mapstructure:"labels"`
+
+	// Number of delivery attempts, the server default is used if zero
+	MaxAttempts int `json:"max_attempts,omitempty" mapstructure:"max_attempts"`
+
+	// Delay before the first retry, the server default is used if zero
+	InitialBackoff time.Duration `json:"initial_backoff,omitempty" mapstructure:"initial_backoff"`
+}
+
+// Config contains configuration for the webhooks notified of store and routing events.
+// Subscriptions are read from the configuration and can be added at runtime with the admin API.
+type Config struct {
+	// Indicates if events are delivered to webhooks
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Subscriptions of the configuration, which cannot be removed at runtime
+	Subscriptions []Subscription `json:"subscriptions,omitempty" mapstructure:"subscriptions"`
+
+	// Number of deliveries buffered for the workers.
+	// Operations never wait for webhooks, deliveries that do not fit into the queue are dropped.
+	QueueSize int `json:"queue_size,omitempty" mapstructure:"queue_size"`
+
+	// Number of concurrent deliveries
+	Workers int `json:"workers,omitempty" mapstructure:"workers"`
+
+	// Timeout of a delivery attempt
+	Timeout time.Duration `json:"timeout,omitempty" mapstructure:"timeout"`
+
+	// Default number of delivery attempts before an event is moved to the dead-letter log
+	MaxAttempts int `json:"max_attempts,omitempty" mapstructure:"max_attempts"`
+
+	// Default delay before the first retry, doubled for every further retry
+	InitialBackoff time.Duration `json:"initial_backoff,omitempty" mapstructure:"initial_backoff"`
+
+	// Upper bound of the delay between retries
+	MaxBackoff time.Duration `json:"max_backoff,omitempty" mapstructure:"max_backoff"`
+
+	// File the events that could not be delivered are appended to as JSON lines.
+	// Failed deliveries are only logged if empty.
+	DeadLetterPath string `json:"dead_letter_path,omitempty" mapstructure:"dead_letter_path"`
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.QueueSize <= 0 {
+		return errors.New("queue size must be positive")
+	}
+
+	if c.Workers <= 0 {
+		return errors.New("workers must be positive")
+	}
+
+	if c.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+
+	if c.MaxAttempts <= 0 {
+		return errors.New("max attempts must be positive")
+	}
+
+	if c.InitialBackoff <= 0 || c.MaxBackoff < c.InitialBackoff {
+		return errors.New("initial backoff must be positive and not exceed the max backoff")
+	}
+
+	for i, sub := range c.Subscriptions {
+		if sub.ID == "" {
+			return fmt.Errorf("subscription %d: id is required", i)
+		}
+
+		if slices.ContainsFunc(c.Subscriptions[:i], func(other Subscription) bool { return other.ID == sub.ID }) {
+			return fmt.Errorf("subscription %q is configured more than once", sub.ID)
+		}
+
+		if err := sub.Validate(); err != nil {
+			return fmt.Errorf("subscription %q: %w", sub.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the subscription, regardless of whether it is configured or added at runtime.
+func (s *Subscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid URL %q: expected an http or https URL", s.URL)
+	}
+
+	for _, event := range s.Events {
+		if event != EventPushed && event != EventDeleted && event != EventPublished {
+			return fmt.Errorf("invalid event %q: expected %q, %q or %q", event, EventPushed, EventDeleted, EventPublished)
+		}
+	}
+
+	for _, pattern := range s.Names {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid name pattern %q: %w", pattern, err)
+		}
+	}
+
+	if s.MaxAttempts < 0 {
+		return errors.New("max attempts must not be negative")
+	}
+
+	if s.InitialBackoff < 0 {
+		return errors.New("initial backoff must not be negative")
+	}
+
+	return nil
+}
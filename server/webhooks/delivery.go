@@ -0,0 +1,276 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// SignatureHeader is the header of the HMAC-SHA256 signature of the body, "sha256=<hex signature>".
+	SignatureHeader = "X-Dir-Signature"
+
+	// EventHeader is the header of the event type.
+	EventHeader = "X-Dir-Event"
+)
+
+// Message is the JSON body POSTed to webhooks.
+type Message struct {
+	// Type of the event, e.g. "pushed".
+	Type string `json:"type"`
+
+	// CID of the record, empty for test events.
+	CID string `json:"cid,omitempty"`
+
+	// Meta is the metadata of the record in the protobuf JSON format, if known.
+	Meta json.RawMessage `json:"meta,omitempty"`
+
+	// Timestamp of the event in the RFC3339 format.
+	Timestamp string `json:"timestamp"`
+
+	// Sequence number of the event, increasing for every event of the server since it started.
+	// Zero for test events.
+	Sequence uint64 `json:"sequence"`
+}
+
+// Sign returns the value of the signature header of a body signed with the secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// payload is an event queued for delivery, shared by the deliveries to all matching webhooks.
+type payload struct {
+	event     Event
+	timestamp string
+	sequence  uint64
+
+	// The body is marshaled once, on the first delivery
+	once sync.Once
+	data []byte
+	err  error
+}
+
+// body returns the JSON body of the event, looking up the metadata of the record if it is not known.
+func (p *payload) body(ctx context.Context, store types.StoreAPI) ([]byte, error) {
+	p.once.Do(func() {
+		meta := p.event.Meta
+		if meta == nil && p.event.CID != "" && store != nil {
+			var err error
+
+			meta, err = store.Lookup(ctx, &corev1.RecordRef{Cid: p.event.CID})
+			if err != nil {
+				logger.Debug("Failed to lookup record of webhook event", "error", err, "cid", p.event.CID)
+
+				meta = nil
+			}
+		}
+
+		msg := Message{
+			Type:      p.event.Type,
+			CID:       p.event.CID,
+			Timestamp: p.timestamp,
+			Sequence:  p.sequence,
+		}
+
+		if meta != nil {
+			if msg.Meta, p.err = protojson.Marshal(meta); p.err != nil {
+				p.err = fmt.Errorf("failed to marshal record metadata: %w", p.err)
+
+				return
+			}
+		}
+
+		if p.data, p.err = json.Marshal(msg); p.err != nil {
+			p.err = fmt.Errorf("failed to marshal webhook event: %w", p.err)
+		}
+	})
+
+	return p.data, p.err
+}
+
+// delivery is the delivery of an event to a webhook.
+type delivery struct {
+	webhook types.Webhook
+	payload *payload
+}
+
+// run delivers queued events until the dispatcher is closed.
+func (d *Dispatcher) run() {
+	defer d.wg.Done()
+
+	for delivery := range d.queue {
+		if d.ctx.Err() != nil {
+			return
+		}
+
+		d.deliver(delivery)
+	}
+}
+
+// deliver posts an event to a webhook, retrying with exponential backoff,
+// and appends it to the dead-letter log if it cannot be delivered.
+func (d *Dispatcher) deliver(delivery *delivery) {
+	webhook := delivery.webhook
+
+	// Attempts in progress are completed on Close, only pending retries are abandoned
+	ctx := context.WithoutCancel(d.ctx)
+
+	body, err := delivery.payload.body(ctx, d.store)
+	if err != nil {
+		logger.Error("Failed to create webhook event", "error", err, "webhook", webhook.ID)
+
+		return
+	}
+
+	maxAttempts := webhook.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = d.cfg.MaxAttempts
+	}
+
+	backoff := webhook.InitialBackoff
+	if backoff <= 0 {
+		backoff = d.cfg.InitialBackoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		code, err := d.post(ctx, webhook, delivery.payload.event.Type, body)
+		if err == nil {
+			logger.Debug("Delivered webhook event", "webhook", webhook.ID, "event", delivery.payload.event.Type, "cid", delivery.payload.event.CID, "attempt", attempt)
+
+			return
+		}
+
+		if attempt >= maxAttempts || !retryable(code) {
+			d.deadLetter(webhook, body, attempt, err)
+
+			return
+		}
+
+		logger.Warn("Failed to deliver webhook event, retrying", "webhook", webhook.ID, "attempt", attempt, "backoff", backoff, "error", err)
+
+		select {
+		case <-time.After(backoff):
+		case <-d.ctx.Done():
+			logger.Warn("Abandoned webhook delivery on shutdown", "webhook", webhook.ID, "cid", delivery.payload.event.CID)
+
+			return
+		}
+
+		backoff = min(2*backoff, d.cfg.MaxBackoff) //nolint:mnd
+	}
+}
+
+// post sends a signed event to a webhook. It returns the HTTP status code of the response,
+// zero if the request failed, and an error unless the status code is 2xx.
+func (d *Dispatcher) post(ctx context.Context, webhook types.Webhook, eventType string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, eventType)
+
+	if webhook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Drain the body to reuse the connection
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024)) //nolint:mnd
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// retryable reports whether a delivery that failed with the status code is retried.
+// Requests that failed without a response, server errors, timeouts and throttling are retried.
+func retryable(code int) bool {
+	return code == 0 || code >= http.StatusInternalServerError ||
+		code == http.StatusRequestTimeout || code == http.StatusTooManyRequests
+}
+
+// deadLetterEntry is an event that could not be delivered, appended to the dead-letter log.
+type deadLetterEntry struct {
+	Webhook  string          `json:"webhook"`
+	URL      string          `json:"url"`
+	Attempts int             `json:"attempts"`
+	Error    string          `json:"error"`
+	FailedAt string          `json:"failed_at"`
+	Event    json.RawMessage `json:"event"`
+}
+
+// deadLetter records an event that could not be delivered.
+func (d *Dispatcher) deadLetter(webhook types.Webhook, body []byte, attempts int, deliveryErr error) {
+	logger.Error("Failed to deliver webhook event", "webhook", webhook.ID, "url", webhook.URL, "attempts", attempts, "error", deliveryErr)
+
+	if d.cfg.DeadLetterPath == "" {
+		return
+	}
+
+	line, err := json.Marshal(deadLetterEntry{
+		Webhook:  webhook.ID,
+		URL:      webhook.URL,
+		Attempts: attempts,
+		Error:    deliveryErr.Error(),
+		FailedAt: time.Now().UTC().Format(time.RFC3339Nano),
+		Event:    body,
+	})
+	if err != nil {
+		logger.Error("Failed to encode dead-letter entry", "error", err)
+
+		return
+	}
+
+	d.deadLetterMu.Lock()
+	defer d.deadLetterMu.Unlock()
+
+	if err := appendLine(d.cfg.DeadLetterPath, line); err != nil {
+		logger.Error("Failed to append to dead-letter log", "error", err, "path", d.cfg.DeadLetterPath)
+	}
+}
+
+// appendLine appends a line to the file, creating it and its directory if needed.
+func appendLine(path string, line []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil { //nolint:mnd
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640) //nolint:mnd
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+
+	_, err = file.Write(append(line, '\n'))
+
+	return errors.Join(err, file.Close())
+}
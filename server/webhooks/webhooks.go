@@ -0,0 +1,394 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhooks delivers store and routing events of records to HTTP endpoints,
+// e.g. to notify chat channels or trigger CI pipelines when matching records are pushed.
+//
+// Delivery never blocks store operations: events are queued for background workers and deliveries
+// that do not fit into the queue are dropped. Failing deliveries are retried with exponential backoff,
+// events that could not be delivered are appended to a dead-letter log.
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/labels"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/agntcy/dir/server/webhooks/config"
+	"github.com/agntcy/dir/utils/logging"
+	"github.com/google/uuid"
+)
+
+var logger = logging.Logger("webhooks")
+
+var (
+	// ErrNotFound is returned for unknown subscription IDs.
+	ErrNotFound = errors.New("webhook not found")
+
+	// ErrConfigured is returned when removing a subscription of the server configuration.
+	ErrConfigured = errors.New("webhook is part of the server configuration")
+
+	// ErrInvalid is returned when adding a subscription with an invalid URL or filter.
+	ErrInvalid = errors.New("invalid webhook")
+)
+
+// Event types, see the configuration.
+const (
+	EventPushed    = config.EventPushed
+	EventDeleted   = config.EventDeleted
+	EventPublished = config.EventPublished
+
+	// EventTest is the type of the events sent by Test.
+	EventTest = "test"
+)
+
+// Event is a store or routing event of a record.
+type Event struct {
+	// Type of the event, e.g. EventPushed.
+	Type string
+
+	// CID of the record.
+	CID string
+
+	// Name of the record, matched against the name patterns of subscriptions.
+	Name string
+
+	// Labels are the routing labels of the record, matched against the labels of subscriptions.
+	Labels []string
+
+	// Meta is the metadata of the record.
+	// If nil, it is looked up from the store when the event is delivered.
+	Meta *corev1.RecordMeta
+}
+
+// RecordEvent returns an event of the given type for the record.
+func RecordEvent(eventType string, record *corev1.Record) Event {
+	adapter := adapters.NewRecordAdapter(record)
+
+	var name string
+	if data, err := adapter.GetRecordData(); err == nil {
+		name = data.GetName()
+	}
+
+	var recordLabels []string
+	for _, label := range labels.FromRecord(adapter).RoutingLabels() {
+		recordLabels = append(recordLabels, label.String())
+	}
+
+	return Event{
+		Type:   eventType,
+		CID:    record.GetCid(),
+		Name:   name,
+		Labels: recordLabels,
+	}
+}
+
+// Webhook is a subscription of the dispatcher.
+type Webhook struct {
+	types.Webhook
+
+	// Configured is set for subscriptions of the server configuration.
+	Configured bool
+}
+
+// Dispatcher delivers events to the subscribed webhooks.
+// A nil Dispatcher discards all events.
+type Dispatcher struct {
+	cfg    config.Config
+	db     types.WebhookDatabaseAPI
+	store  types.StoreAPI
+	client *http.Client
+
+	// Subscriptions, guarded by mu
+	mu       sync.RWMutex
+	webhooks []Webhook
+
+	// queue is closed on Close, guarded by closeMu
+	closeMu sync.RWMutex
+	closed  bool
+	queue   chan *delivery
+
+	// ctx is canceled on Close to abort retries
+	ctx    context.Context //nolint:containedctx
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	sequence atomic.Uint64
+	dropped  atomic.Uint64
+
+	// deadLetterMu serializes appends to the dead-letter log
+	deadLetterMu sync.Mutex
+}
+
+// New creates a dispatcher for the configured subscriptions and the subscriptions stored in the database,
+// and starts its workers. The metadata of events without metadata is looked up from the store.
+func New(cfg config.Config, db types.WebhookDatabaseAPI, store types.StoreAPI) (*Dispatcher, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid webhooks config: %w", err)
+	}
+
+	stored, err := db.GetWebhooks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load webhooks: %w", err)
+	}
+
+	webhooks := make([]Webhook, 0, len(cfg.Subscriptions)+len(stored))
+
+	for _, sub := range cfg.Subscriptions {
+		webhooks = append(webhooks, Webhook{Webhook: fromSubscription(sub), Configured: true})
+	}
+
+	for _, webhook := range stored {
+		webhooks = append(webhooks, Webhook{Webhook: webhook})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	d := &Dispatcher{
+		cfg:      cfg,
+		db:       db,
+		store:    store,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		webhooks: webhooks,
+		queue:    make(chan *delivery, cfg.QueueSize),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	for range cfg.Workers {
+		d.wg.Add(1)
+
+		go d.run()
+	}
+
+	logger.Info("Webhooks enabled", "subscriptions", len(webhooks), "workers", cfg.Workers)
+
+	return d, nil
+}
+
+// Enabled reports whether events are delivered, i.e. whether the dispatcher is not nil.
+func (d *Dispatcher) Enabled() bool {
+	return d != nil
+}
+
+// Notify queues the deliveries of the event to the matching webhooks without blocking,
+// dropping them if the queue is full.
+func (d *Dispatcher) Notify(event Event) {
+	if d == nil {
+		return
+	}
+
+	d.mu.RLock()
+
+	var matching []Webhook
+
+	for _, webhook := range d.webhooks {
+		if matches(webhook.Webhook, event) {
+			matching = append(matching, webhook)
+		}
+	}
+
+	d.mu.RUnlock()
+
+	if len(matching) == 0 {
+		return
+	}
+
+	payload := &payload{
+		event:     event,
+		timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		sequence:  d.sequence.Add(1),
+	}
+
+	d.closeMu.RLock()
+	defer d.closeMu.RUnlock()
+
+	if d.closed {
+		return
+	}
+
+	for _, webhook := range matching {
+		select {
+		case d.queue <- &delivery{webhook: webhook.Webhook, payload: payload}:
+		default:
+			dropped := d.dropped.Add(1)
+
+			logger.Error("Webhook queue is full, dropping delivery", "webhook", webhook.ID, "event", event.Type, "cid", event.CID, "dropped", dropped)
+		}
+	}
+}
+
+// Dropped returns the number of deliveries dropped because the queue was full.
+func (d *Dispatcher) Dropped() uint64 {
+	if d == nil {
+		return 0
+	}
+
+	return d.dropped.Load()
+}
+
+// List returns the subscriptions, those of the server configuration first.
+func (d *Dispatcher) List() []Webhook {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return slices.Clone(d.webhooks)
+}
+
+// Add stores a subscription and starts delivering events to it.
+// The ID of the subscription is assigned by the dispatcher.
+// It fails with ErrInvalid if the URL or the filters of the subscription are invalid.
+func (d *Dispatcher) Add(webhook types.Webhook) (types.Webhook, error) {
+	webhook.ID = uuid.NewString()
+
+	sub := config.Subscription{
+		URL:            webhook.URL,
+		Events:         webhook.EventTypes,
+		Names:          webhook.Names,
+		MaxAttempts:    webhook.MaxAttempts,
+		InitialBackoff: webhook.InitialBackoff,
+	}
+	if err := sub.Validate(); err != nil {
+		return types.Webhook{}, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	if err := d.db.AddWebhook(webhook); err != nil {
+		return types.Webhook{}, fmt.Errorf("failed to add webhook: %w", err)
+	}
+
+	d.mu.Lock()
+	d.webhooks = append(d.webhooks, Webhook{Webhook: webhook})
+	d.mu.Unlock()
+
+	logger.Info("Webhook added", "id", webhook.ID, "url", webhook.URL)
+
+	return webhook, nil
+}
+
+// Remove removes a subscription added with Add. Deliveries already queued for it are still attempted.
+// It fails with ErrNotFound for unknown subscriptions and ErrConfigured for subscriptions of the configuration.
+func (d *Dispatcher) Remove(id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	i := slices.IndexFunc(d.webhooks, func(webhook Webhook) bool { return webhook.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+
+	if d.webhooks[i].Configured {
+		return ErrConfigured
+	}
+
+	if _, err := d.db.RemoveWebhook(id); err != nil {
+		return fmt.Errorf("failed to remove webhook: %w", err)
+	}
+
+	d.webhooks = slices.Delete(d.webhooks, i, i+1)
+
+	logger.Info("Webhook removed", "id", id)
+
+	return nil
+}
+
+// Test delivers a test event to a subscription once, regardless of its filters.
+// It returns the HTTP status code of the response, zero if the request failed,
+// and an error if the delivery failed.
+func (d *Dispatcher) Test(ctx context.Context, id string) (int, error) {
+	d.mu.RLock()
+	i := slices.IndexFunc(d.webhooks, func(webhook Webhook) bool { return webhook.ID == id })
+
+	var webhook Webhook
+	if i >= 0 {
+		webhook = d.webhooks[i]
+	}
+	d.mu.RUnlock()
+
+	if i < 0 {
+		return 0, ErrNotFound
+	}
+
+	payload := &payload{
+		event:     Event{Type: EventTest},
+		timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	body, err := payload.body(ctx, d.store)
+	if err != nil {
+		return 0, err
+	}
+
+	return d.post(ctx, webhook.Webhook, EventTest, body)
+}
+
+// Close stops the workers once the attempts in progress complete.
+// Queued deliveries and pending retries are abandoned.
+func (d *Dispatcher) Close() {
+	if d == nil {
+		return
+	}
+
+	d.closeMu.Lock()
+	if d.closed {
+		d.closeMu.Unlock()
+
+		return
+	}
+
+	d.closed = true
+	close(d.queue)
+	d.closeMu.Unlock()
+
+	d.cancel()
+	d.wg.Wait()
+}
+
+// matches reports whether the event passes the filters of the webhook.
+func matches(webhook types.Webhook, event Event) bool {
+	if len(webhook.EventTypes) > 0 && !slices.Contains(webhook.EventTypes, event.Type) {
+		return false
+	}
+
+	if len(webhook.Names) > 0 && !slices.ContainsFunc(webhook.Names, func(pattern string) bool {
+		matched, _ := path.Match(pattern, event.Name)
+
+		return matched
+	}) {
+		return false
+	}
+
+	if len(webhook.Labels) > 0 && !slices.ContainsFunc(webhook.Labels, func(filter string) bool {
+		return slices.ContainsFunc(event.Labels, func(label string) bool {
+			return label == filter || strings.HasPrefix(label, strings.TrimSuffix(filter, "/")+"/")
+		})
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// fromSubscription converts a subscription of the configuration.
+func fromSubscription(sub config.Subscription) types.Webhook {
+	return types.Webhook{
+		ID:             sub.ID,
+		URL:            sub.URL,
+		Secret:         sub.Secret,
+		EventTypes:     sub.Events,
+		Names:          sub.Names,
+		Labels:         sub.Labels,
+		MaxAttempts:    sub.MaxAttempts,
+		InitialBackoff: sub.InitialBackoff,
+	}
+}
@@ -0,0 +1,323 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/database/sqlite"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/webhooks/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// endpoint is a webhook endpoint recording the events it receives.
+// It responds with the queued status codes, then with 200.
+type endpoint struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+	received chan struct{}
+}
+
+func newEndpoint(t *testing.T, statuses ...int) *endpoint {
+	t.Helper()
+
+	e := &endpoint{statuses: statuses, received: make(chan struct{}, 100)} //nolint:mnd
+	e.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		e.mu.Lock()
+		e.requests = append(e.requests, r)
+		e.bodies = append(e.bodies, body)
+
+		status := http.StatusOK
+		if len(e.statuses) > 0 {
+			status, e.statuses = e.statuses[0], e.statuses[1:]
+		}
+		e.mu.Unlock()
+
+		w.WriteHeader(status)
+
+		e.received <- struct{}{}
+	}))
+
+	t.Cleanup(e.Close)
+
+	return e
+}
+
+// wait waits for n requests.
+func (e *endpoint) wait(t *testing.T, n int) {
+	t.Helper()
+
+	for range n {
+		select {
+		case <-e.received:
+		case <-time.After(5 * time.Second): //nolint:mnd
+			t.Fatal("timed out waiting for webhook request")
+		}
+	}
+}
+
+func newTestDispatcher(t *testing.T, cfg config.Config) *Dispatcher {
+	t.Helper()
+
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "dir.db"))
+	require.NoError(t, err)
+
+	cfg.Enabled = true
+	cfg.QueueSize = 16
+	cfg.Workers = 1
+	cfg.Timeout = time.Second
+	cfg.MaxAttempts = 3
+	cfg.InitialBackoff = 10 * time.Millisecond
+	cfg.MaxBackoff = 20 * time.Millisecond
+
+	d, err := New(cfg, db, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(d.Close)
+
+	return d
+}
+
+func TestSignature(t *testing.T) {
+	e := newEndpoint(t)
+
+	d := newTestDispatcher(t, config.Config{
+		Subscriptions: []config.Subscription{{ID: "ci", URL: e.URL, Secret: "s3cret"}},
+	})
+
+	meta := &corev1.RecordMeta{Cid: "cid-1", Annotations: map[string]string{"name": "my-agent"}}
+	d.Notify(Event{Type: EventPushed, CID: "cid-1", Name: "my-agent", Meta: meta})
+	e.wait(t, 1)
+
+	req, body := e.requests[0], e.bodies[0]
+	assert.Equal(t, EventPushed, req.Header.Get(EventHeader))
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+	// Receivers verify the signature with the shared secret
+	assert.Equal(t, Sign("s3cret", body), req.Header.Get(SignatureHeader))
+	assert.NotEqual(t, Sign("other", body), req.Header.Get(SignatureHeader))
+
+	var msg Message
+	require.NoError(t, json.Unmarshal(body, &msg))
+	assert.Equal(t, EventPushed, msg.Type)
+	assert.Equal(t, "cid-1", msg.CID)
+	assert.Equal(t, uint64(1), msg.Sequence)
+	assert.NotEmpty(t, msg.Timestamp)
+	assert.JSONEq(t, `{"cid":"cid-1","annotations":{"name":"my-agent"}}`, string(msg.Meta))
+}
+
+func TestRetry(t *testing.T) {
+	deadLetterPath := filepath.Join(t.TempDir(), "webhooks", "dead-letter.jsonl")
+
+	flaky := newEndpoint(t, http.StatusInternalServerError, http.StatusServiceUnavailable)
+	broken := newEndpoint(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+	rejecting := newEndpoint(t, http.StatusBadRequest)
+
+	d := newTestDispatcher(t, config.Config{
+		DeadLetterPath: deadLetterPath,
+		Subscriptions: []config.Subscription{
+			{ID: "flaky", URL: flaky.URL},
+			{ID: "broken", URL: broken.URL},
+			{ID: "rejecting", URL: rejecting.URL},
+		},
+	})
+
+	d.Notify(Event{Type: EventDeleted, CID: "cid-1"})
+
+	// Server errors are retried until the delivery succeeds or the attempts are exhausted
+	flaky.wait(t, 3)
+	broken.wait(t, 3)
+
+	// Client errors are not retried
+	rejecting.wait(t, 1)
+
+	d.Close()
+
+	assert.Len(t, flaky.requests, 3)
+	assert.Len(t, broken.requests, 3)
+	assert.Len(t, rejecting.requests, 1)
+
+	// Retries deliver the same event
+	assert.Equal(t, flaky.bodies[0], flaky.bodies[2])
+
+	data, err := os.ReadFile(deadLetterPath)
+	require.NoError(t, err)
+
+	var entries []deadLetterEntry
+
+	for _, line := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+		var entry deadLetterEntry
+		require.NoError(t, json.Unmarshal(line, &entry))
+
+		entries = append(entries, entry)
+	}
+
+	require.Len(t, entries, 2)
+
+	attempts := map[string]int{}
+	for _, entry := range entries {
+		attempts[entry.Webhook] = entry.Attempts
+
+		assert.JSONEq(t, string(broken.bodies[0]), string(entry.Event))
+	}
+
+	assert.Equal(t, map[string]int{"broken": 3, "rejecting": 1}, attempts)
+}
+
+func TestFilters(t *testing.T) {
+	event := Event{
+		Type:   EventPushed,
+		CID:    "cid-1",
+		Name:   "cisco.com/agents/marketing",
+		Labels: []string{"/skills/natural_language_processing/text_completion", "/locators/docker_image"},
+	}
+
+	tests := []struct {
+		name    string
+		webhook types.Webhook
+		matches bool
+	}{
+		{name: "no filters", webhook: types.Webhook{}, matches: true},
+		{name: "event type", webhook: types.Webhook{EventTypes: []string{EventDeleted, EventPushed}}, matches: true},
+		{name: "other event type", webhook: types.Webhook{EventTypes: []string{EventPublished}}, matches: false},
+		{name: "name pattern", webhook: types.Webhook{Names: []string{"cisco.com/agents/*"}}, matches: true},
+		{name: "other name pattern", webhook: types.Webhook{Names: []string{"cisco.com/*"}}, matches: false},
+		{name: "label", webhook: types.Webhook{Labels: []string{"/locators/docker_image"}}, matches: true},
+		{name: "parent label", webhook: types.Webhook{Labels: []string{"/skills/natural_language_processing"}}, matches: true},
+		{name: "label prefix", webhook: types.Webhook{Labels: []string{"/skills/natural_language"}}, matches: false},
+		{name: "any label", webhook: types.Webhook{Labels: []string{"/domains/finance", "/locators/docker_image"}}, matches: true},
+		{
+			name:    "all filters",
+			webhook: types.Webhook{EventTypes: []string{EventPushed}, Names: []string{"cisco.com/*/*"}, Labels: []string{"/skills"}},
+			matches: true,
+		},
+		{
+			name:    "one filter fails",
+			webhook: types.Webhook{EventTypes: []string{EventPushed}, Names: []string{"cisco.com/*/*"}, Labels: []string{"/domains"}},
+			matches: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.matches, matches(tt.webhook, event))
+		})
+	}
+
+	// Only matching webhooks receive the event
+	matching := newEndpoint(t)
+	other := newEndpoint(t)
+
+	d := newTestDispatcher(t, config.Config{
+		Subscriptions: []config.Subscription{
+			{ID: "matching", URL: matching.URL, Names: []string{"cisco.com/*/*"}},
+			{ID: "other", URL: other.URL, Events: []string{EventDeleted}},
+		},
+	})
+
+	d.Notify(event)
+	matching.wait(t, 1)
+	d.Close()
+
+	assert.Empty(t, other.requests)
+}
+
+func TestManageWebhooks(t *testing.T) {
+	e := newEndpoint(t, http.StatusAccepted)
+
+	d := newTestDispatcher(t, config.Config{
+		Subscriptions: []config.Subscription{{ID: "configured", URL: "https://hooks.example.org"}},
+	})
+
+	_, err := d.Add(types.Webhook{URL: "ftp://hooks.example.org"})
+	require.ErrorIs(t, err, ErrInvalid)
+
+	_, err = d.Add(types.Webhook{URL: e.URL, EventTypes: []string{"updated"}})
+	require.ErrorIs(t, err, ErrInvalid)
+
+	added, err := d.Add(types.Webhook{URL: e.URL, Secret: "s3cret", EventTypes: []string{EventPublished}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, added.ID)
+
+	webhooks := d.List()
+	require.Len(t, webhooks, 2)
+	assert.True(t, webhooks[0].Configured)
+	assert.Equal(t, added, webhooks[1].Webhook)
+
+	// Test events are delivered regardless of the filters
+	code, err := d.Test(t.Context(), added.ID)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, EventTest, e.requests[0].Header.Get(EventHeader))
+	assert.Equal(t, Sign("s3cret", e.bodies[0]), e.requests[0].Header.Get(SignatureHeader))
+
+	_, err = d.Test(t.Context(), "missing")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Configured webhooks cannot be removed
+	require.ErrorIs(t, d.Remove("configured"), ErrConfigured)
+	require.ErrorIs(t, d.Remove("missing"), ErrNotFound)
+	require.NoError(t, d.Remove(added.ID))
+	assert.Len(t, d.List(), 1)
+}
+
+func TestNilDispatcher(t *testing.T) {
+	var d *Dispatcher
+
+	assert.False(t, d.Enabled())
+	d.Notify(Event{Type: EventPushed, CID: "cid-1"})
+	d.Close()
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := config.Config{
+		Enabled:        true,
+		QueueSize:      config.DefaultQueueSize,
+		Workers:        config.DefaultWorkers,
+		Timeout:        config.DefaultTimeout,
+		MaxAttempts:    config.DefaultMaxAttempts,
+		InitialBackoff: config.DefaultInitialBackoff,
+		MaxBackoff:     config.DefaultMaxBackoff,
+		Subscriptions:  []config.Subscription{{ID: "ci", URL: "https://ci.example.org", Events: []string{EventPushed}}},
+	}
+	require.NoError(t, valid.Validate())
+
+	tests := map[string]func(*config.Config){
+		"missing id":        func(c *config.Config) { c.Subscriptions[0].ID = "" },
+		"duplicate id":      func(c *config.Config) { c.Subscriptions = append(c.Subscriptions, c.Subscriptions[0]) },
+		"invalid url":       func(c *config.Config) { c.Subscriptions[0].URL = "ci.example.org" },
+		"invalid event":     func(c *config.Config) { c.Subscriptions[0].Events = []string{"updated"} },
+		"invalid pattern":   func(c *config.Config) { c.Subscriptions[0].Names = []string{"["} },
+		"no workers":        func(c *config.Config) { c.Workers = 0 },
+		"backoff above max": func(c *config.Config) { c.InitialBackoff = 2 * c.MaxBackoff },
+	}
+
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := valid
+			cfg.Subscriptions = append([]config.Subscription{}, valid.Subscriptions...)
+			mutate(&cfg)
+
+			require.Error(t, cfg.Validate())
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package compression registers the gRPC compressors supported by Directory clients and servers.
+// Importing the package registers gzip and zstd, so that servers accept and answer compressed calls,
+// and clients can compress their calls with grpc.UseCompressor.
+package compression
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/gzip" // Registers the gzip compressor.
+)
+
+// Names of the supported compressors.
+const (
+	Gzip = "gzip"
+	Zstd = "zstd"
+)
+
+func init() {
+	encoding.RegisterCompressor(&zstdCompressor{})
+}
+
+// Validate checks that the compressor is supported. An empty name disables compression.
+func Validate(name string) error {
+	switch name {
+	case "", Gzip, Zstd:
+		return nil
+	default:
+		return fmt.Errorf("unsupported compression %q: expected %q or %q", name, Gzip, Zstd)
+	}
+}
+
+// zstdCompressor is a gRPC compressor using zstd.
+// Encoders and decoders are pooled, as they are expensive to create.
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+func (c *zstdCompressor) Name() string {
+	return Zstd
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	encoder, ok := c.encoders.Get().(*zstd.Encoder)
+	if !ok {
+		var err error
+
+		encoder, err = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+		}
+	}
+
+	encoder.Reset(w)
+
+	return &zstdWriter{Encoder: encoder, pool: &c.encoders}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	decoder, ok := c.decoders.Get().(*zstd.Decoder)
+	if !ok {
+		var err error
+
+		decoder, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+		}
+	}
+
+	if err := decoder.Reset(r); err != nil {
+		c.decoders.Put(decoder)
+
+		return nil, fmt.Errorf("failed to reset zstd decoder: %w", err)
+	}
+
+	return &zstdReader{Decoder: decoder, pool: &c.decoders}, nil
+}
+
+// zstdWriter returns its encoder to the pool once the message is compressed.
+type zstdWriter struct {
+	*zstd.Encoder
+
+	pool *sync.Pool
+}
+
+func (w *zstdWriter) Close() error {
+	err := w.Encoder.Close()
+	w.pool.Put(w.Encoder)
+
+	return err //nolint:wrapcheck
+}
+
+// zstdReader returns its decoder to the pool once the message is decompressed.
+type zstdReader struct {
+	*zstd.Decoder
+
+	pool *sync.Pool
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.Decoder == nil {
+		return 0, io.EOF
+	}
+
+	n, err := r.Decoder.Read(p)
+	if errors.Is(err, io.EOF) {
+		r.pool.Put(r.Decoder)
+		r.Decoder = nil
+	}
+
+	return n, err //nolint:wrapcheck
+}
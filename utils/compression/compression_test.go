@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package compression
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc/encoding"
+)
+
+func TestCompressors(t *testing.T) {
+	message := bytes.Repeat([]byte(`{"name":"agent","skills":["natural_language_processing"]}`), 1000)
+
+	for _, name := range []string{Gzip, Zstd} {
+		t.Run(name, func(t *testing.T) {
+			if err := Validate(name); err != nil {
+				t.Fatalf("expected %s to be supported: %v", name, err)
+			}
+
+			compressor := encoding.GetCompressor(name)
+			if compressor == nil {
+				t.Fatalf("expected %s compressor to be registered", name)
+			}
+
+			// Pooled encoders and decoders are reused across messages
+			for range 3 {
+				var compressed bytes.Buffer
+
+				w, err := compressor.Compress(&compressed)
+				if err != nil {
+					t.Fatalf("failed to compress: %v", err)
+				}
+
+				if _, err := w.Write(message); err != nil {
+					t.Fatalf("failed to compress: %v", err)
+				}
+
+				if err := w.Close(); err != nil {
+					t.Fatalf("failed to compress: %v", err)
+				}
+
+				if compressed.Len() > len(message)/10 {
+					t.Errorf("expected message of %d bytes to compress well, got %d bytes", len(message), compressed.Len())
+				}
+
+				r, err := compressor.Decompress(&compressed)
+				if err != nil {
+					t.Fatalf("failed to decompress: %v", err)
+				}
+
+				decompressed, err := io.ReadAll(r)
+				if err != nil {
+					t.Fatalf("failed to decompress: %v", err)
+				}
+
+				if !bytes.Equal(decompressed, message) {
+					t.Errorf("expected decompressed message to match the original")
+				}
+			}
+		})
+	}
+
+	if err := Validate("snappy"); err == nil {
+		t.Error("expected unsupported compression to be rejected")
+	}
+}
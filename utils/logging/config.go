@@ -4,6 +4,7 @@
 package logging
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -14,11 +15,46 @@ import (
 const (
 	DefaultEnvPrefix = "DIRECTORY_LOGGER"
 	DefaultLogLevel  = "INFO"
+	DefaultLogFormat = FormatText
+)
+
+// Log formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
 )
 
 type Config struct {
-	LogFile  string `json:"log_file,omitempty"  mapstructure:"log_file"`
-	LogLevel string `json:"log_level,omitempty" mapstructure:"log_level"`
+	LogFile   string `json:"log_file,omitempty"   mapstructure:"log_file"`
+	LogLevel  string `json:"log_level,omitempty"  mapstructure:"log_level"`
+	LogFormat string `json:"log_format,omitempty" mapstructure:"log_format"`
+
+	// ComponentLevels override the log level of components and the components below them,
+	// as "component=level" entries, e.g. "store/oci=debug" also sets the level of "store/oci/tags".
+	ComponentLevels []string `json:"component_levels,omitempty" mapstructure:"component_levels"`
+}
+
+// Validate checks the log level, format and component levels.
+func (c Config) Validate() error {
+	var errs []error
+
+	if c.LogLevel != "" {
+		if _, err := ParseLevel(c.LogLevel); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	switch c.LogFormat {
+	case "", FormatText, FormatJSON:
+	default:
+		errs = append(errs, fmt.Errorf("invalid log format %q, must be %q or %q", c.LogFormat, FormatText, FormatJSON))
+	}
+
+	if _, err := ParseComponentLevels(c.ComponentLevels); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
 }
 
 func LoadConfig() (*Config, error) {
@@ -36,6 +72,12 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("log_level")
 	v.SetDefault("log_level", DefaultLogLevel)
 
+	_ = v.BindEnv("log_format")
+	v.SetDefault("log_format", DefaultLogFormat)
+
+	_ = v.BindEnv("component_levels")
+	v.SetDefault("component_levels", "")
+
 	// Load configuration into struct
 	decodeHooks := mapstructure.ComposeDecodeHookFunc(
 		mapstructure.TextUnmarshallerHookFunc(),
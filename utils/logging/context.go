@@ -0,0 +1,59 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+type fieldsKey struct{}
+
+// ContextWithFields returns a context whose loggers include the given fields,
+// as key-value pairs or slog.Attr values, in addition to the fields of the parent context.
+// Servers add request-scoped fields, such as the request ID, in interceptors.
+func ContextWithFields(ctx context.Context, args ...any) context.Context {
+	record := slog.NewRecord(time.Time{}, 0, "", 0)
+	record.Add(args...)
+
+	fields := slices.Clip(Fields(ctx))
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = append(fields, attr)
+
+		return true
+	})
+
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// Fields returns the request-scoped fields of the context.
+func Fields(ctx context.Context) []slog.Attr {
+	fields, _ := ctx.Value(fieldsKey{}).([]slog.Attr)
+
+	return fields
+}
+
+// FromContext returns the default logger with the request-scoped fields of the context,
+// e.g. the request ID and the trust domain of the caller.
+func FromContext(ctx context.Context) *slog.Logger {
+	return WithContext(ctx, slog.Default())
+}
+
+// WithContext returns the logger with the request-scoped fields of the context,
+// so that component loggers log requests with their component, e.g. WithContext(ctx, logger).Info(...).
+func WithContext(ctx context.Context, logger *slog.Logger) *slog.Logger {
+	fields := Fields(ctx)
+	if len(fields) == 0 {
+		return logger
+	}
+
+	args := make([]any, len(fields))
+	for i, field := range fields {
+		args[i] = field
+	}
+
+	return logger.With(args...)
+}
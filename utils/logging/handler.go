@@ -0,0 +1,113 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"sync/atomic"
+)
+
+// componentKey is the attribute key naming the component of a logger.
+const componentKey = "component"
+
+// output is the handler that log records are written with.
+// It is replaced when the logging is configured, which takes effect for existing loggers too.
+var output atomic.Pointer[outputHandler]
+
+type outputHandler struct {
+	slog.Handler
+}
+
+// newOutputHandler creates a handler writing records of all levels in the given format,
+// as records are filtered by the level of their component before.
+func newOutputHandler(w io.Writer, format string) *outputHandler {
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+
+	if format == FormatJSON {
+		return &outputHandler{slog.NewJSONHandler(w, opts)}
+	}
+
+	return &outputHandler{slog.NewTextHandler(w, opts)}
+}
+
+// handler filters log records by the level of the component of the logger and writes them with the output handler.
+// The component is taken from the "component" attribute, see Logger.
+type handler struct {
+	component string
+	grouped   bool
+
+	// ops are the attributes and groups of the logger, applied to the output handler.
+	ops []func(slog.Handler) slog.Handler
+
+	// resolved caches the output handler with the ops applied.
+	resolved atomic.Pointer[resolvedHandler]
+}
+
+type resolvedHandler struct {
+	output  *outputHandler
+	handler slog.Handler
+}
+
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= levels.level(h.component)
+}
+
+func (h *handler) Handle(ctx context.Context, record slog.Record) error {
+	return h.resolve().Handle(ctx, record) //nolint:wrapcheck
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	component := h.component
+
+	if !h.grouped {
+		for _, attr := range attrs {
+			if attr.Key == componentKey {
+				component = attr.Value.String()
+			}
+		}
+	}
+
+	return h.with(component, h.grouped, func(out slog.Handler) slog.Handler {
+		return out.WithAttrs(attrs)
+	})
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return h.with(h.component, true, func(out slog.Handler) slog.Handler {
+		return out.WithGroup(name)
+	})
+}
+
+func (h *handler) with(component string, grouped bool, op func(slog.Handler) slog.Handler) *handler {
+	return &handler{
+		component: component,
+		grouped:   grouped,
+		ops:       append(slices.Clip(h.ops), op),
+	}
+}
+
+// resolve returns the current output handler with the attributes and groups of the logger.
+func (h *handler) resolve() slog.Handler {
+	out := output.Load()
+
+	if resolved := h.resolved.Load(); resolved != nil && resolved.output == out {
+		return resolved.handler
+	}
+
+	var result slog.Handler = out
+	for _, op := range h.ops {
+		result = op(result)
+	}
+
+	h.resolved.Store(&resolvedHandler{output: out, handler: result})
+
+	return result
+}
@@ -0,0 +1,138 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+	"sync"
+)
+
+// levels holds the default log level and the level overrides of components.
+var levels = &levelRegistry{
+	defaultLevel: slog.LevelInfo,
+	components:   map[string]slog.Level{},
+}
+
+type levelRegistry struct {
+	mu           sync.RWMutex
+	defaultLevel slog.Level
+	components   map[string]slog.Level
+}
+
+// level returns the level of the component, which is the level of the closest component it is below,
+// e.g. the level of "store/oci" for "store/oci/tags", or the default level.
+func (r *levelRegistry) level(component string) slog.Level {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for name := component; name != ""; {
+		if level, ok := r.components[name]; ok {
+			return level
+		}
+
+		idx := strings.LastIndex(name, "/")
+		if idx < 0 {
+			break
+		}
+
+		name = name[:idx]
+	}
+
+	return r.defaultLevel
+}
+
+// ParseLevel parses a log level name, e.g. "debug" or "WARN".
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+
+	if err := level.UnmarshalText([]byte(strings.ToUpper(strings.TrimSpace(name)))); err != nil {
+		return 0, fmt.Errorf("invalid log level %q: %w", name, err)
+	}
+
+	return level, nil
+}
+
+// ParseComponentLevels parses "component=level" entries, e.g. "store/oci=debug".
+func ParseComponentLevels(entries []string) (map[string]slog.Level, error) {
+	result := make(map[string]slog.Level, len(entries))
+
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		component, name, ok := strings.Cut(entry, "=")
+		component = strings.Trim(strings.TrimSpace(component), "/")
+
+		if !ok || component == "" {
+			return nil, fmt.Errorf("invalid component level %q, must be component=level", entry)
+		}
+
+		level, err := ParseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("component %s: %w", component, err)
+		}
+
+		result[component] = level
+	}
+
+	return result, nil
+}
+
+// SetLevel sets the log level of a component and the components below it at runtime.
+// An empty component sets the default level of all components without an override.
+// An empty level removes the override of the component.
+func SetLevel(component, level string) error {
+	component = strings.Trim(strings.TrimSpace(component), "/")
+
+	if level == "" {
+		if component == "" {
+			return errors.New("default log level cannot be removed")
+		}
+
+		levels.mu.Lock()
+		delete(levels.components, component)
+		levels.mu.Unlock()
+
+		return nil
+	}
+
+	parsed, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	if component == "" {
+		levels.defaultLevel = parsed
+	} else {
+		levels.components[component] = parsed
+	}
+
+	return nil
+}
+
+// Levels returns the default log level and the level overrides of components.
+func Levels() (slog.Level, map[string]slog.Level) {
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+
+	return levels.defaultLevel, maps.Clone(levels.components)
+}
+
+// setLevels replaces the default log level and the level overrides of components.
+func setLevels(defaultLevel slog.Level, components map[string]slog.Level) {
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	levels.defaultLevel = defaultLevel
+	levels.components = components
+}
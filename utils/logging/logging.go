@@ -4,15 +4,21 @@
 package logging
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
-	"strings"
 	"sync"
 )
 
 const filePermission = 0o644
 
-var once sync.Once
+var (
+	once sync.Once
+
+	// configMu serializes configuration changes, and logFile is the log file opened by the last configuration.
+	configMu sync.Mutex
+	logFile  *os.File
+)
 
 // getLogOutput determines where logs should be written.
 func getLogOutput(logFilePath string) *os.File {
@@ -29,25 +35,60 @@ func getLogOutput(logFilePath string) *os.File {
 	return os.Stdout
 }
 
+// InitLogger configures the logging once, defaulting to INFO for an invalid log level.
+// It is called on startup with the DIRECTORY_LOGGER_* environment configuration.
 func InitLogger(cfg *Config) {
 	once.Do(func() {
-		var logLevel slog.Level
+		// Set global logger before other packages initialize.
+		output.Store(newOutputHandler(os.Stdout, DefaultLogFormat))
+		slog.SetDefault(slog.New(&handler{}))
 
-		logOutput := getLogOutput(cfg.LogFile)
+		if err := Configure(cfg); err != nil {
+			slog.Warn("Invalid logging configuration, defaulting to INFO", "error", err)
 
-		// Parse log level; default to INFO if invalid.
-		if err := logLevel.UnmarshalText([]byte(strings.ToLower(cfg.LogLevel))); err != nil {
-			slog.Warn("Invalid log level, defaulting to INFO", "error", err)
-			logLevel = slog.LevelInfo
-		}
+			fallback := *cfg
+			fallback.LogLevel, fallback.LogFormat, fallback.ComponentLevels = DefaultLogLevel, DefaultLogFormat, nil
 
-		// Set global logger before other packages initialize.
-		slog.SetDefault(slog.New(slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: logLevel})))
+			_ = Configure(&fallback)
+		}
 	})
 }
 
+// Configure sets the output, format, default level and component levels of all loggers,
+// including loggers created before. Levels can still be changed afterwards with SetLevel.
+func Configure(cfg *Config) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid logging configuration: %w", err)
+	}
+
+	defaultLevel := slog.LevelInfo
+	if cfg.LogLevel != "" {
+		defaultLevel, _ = ParseLevel(cfg.LogLevel)
+	}
+
+	components, _ := ParseComponentLevels(cfg.ComponentLevels)
+
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	file := getLogOutput(cfg.LogFile)
+	output.Store(newOutputHandler(file, cfg.LogFormat))
+
+	if logFile != nil && logFile != os.Stdout && logFile != file {
+		_ = logFile.Close()
+	}
+
+	logFile = file
+
+	setLevels(defaultLevel, components)
+
+	return nil
+}
+
+// Logger returns the logger of a component, e.g. "store/oci/tags".
+// Records are logged if their level is at least the level of the component, see SetLevel.
 func Logger(component string) *slog.Logger {
-	return slog.Default().With("component", component)
+	return slog.Default().With(componentKey, component)
 }
 
 func init() {
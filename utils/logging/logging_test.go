@@ -0,0 +1,168 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"strings"
+	"testing"
+)
+
+// captureLogs writes the logs of all loggers to the returned buffer in the given format,
+// and restores the output and levels after the test.
+func captureLogs(t *testing.T, format string) *bytes.Buffer {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	original := output.Load()
+	defaultLevel, components := Levels()
+
+	output.Store(newOutputHandler(buf, format))
+
+	t.Cleanup(func() {
+		output.Store(original)
+		setLevels(defaultLevel, components)
+	})
+
+	return buf
+}
+
+func TestComponentLevels(t *testing.T) {
+	logs := captureLogs(t, FormatText)
+
+	// Loggers created before the levels are set use them too
+	loggers := map[string]*slog.Logger{
+		"store/oci/tags": Logger("store/oci/tags"),
+		"store/ocifake":  Logger("store/ocifake"),
+		"authz":          Logger("authz"),
+		"routing":        Logger("routing").With("peer", "p1"),
+	}
+
+	components, err := ParseComponentLevels([]string{"store/oci=debug", "authz=error"})
+	if err != nil {
+		t.Fatalf("failed to parse component levels: %v", err)
+	}
+
+	setLevels(slog.LevelWarn, components)
+
+	for _, logger := range loggers {
+		logger.Debug("debug message")
+		logger.Warn("warn message")
+	}
+
+	lines := logs.String()
+
+	for _, want := range []string{
+		`level=DEBUG msg="debug message" component=store/oci/tags`,
+		`level=WARN msg="warn message" component=store/oci/tags`,
+		`level=WARN msg="warn message" component=store/ocifake`,
+		`level=WARN msg="warn message" component=routing peer=p1`,
+	} {
+		if !strings.Contains(lines, want) {
+			t.Errorf("expected logs to contain %q, got:\n%s", want, lines)
+		}
+	}
+
+	for _, unwanted := range []string{
+		`msg="debug message" component=store/ocifake`,
+		`component=authz`,
+		`msg="debug message" component=routing`,
+	} {
+		if strings.Contains(lines, unwanted) {
+			t.Errorf("expected logs not to contain %q, got:\n%s", unwanted, lines)
+		}
+	}
+
+	// Levels can be changed at runtime
+	logs.Reset()
+
+	if err := SetLevel("authz", "debug"); err != nil {
+		t.Fatalf("failed to set level: %v", err)
+	}
+
+	if err := SetLevel("store/oci", ""); err != nil {
+		t.Fatalf("failed to remove level: %v", err)
+	}
+
+	loggers["authz"].Debug("debug message")
+	loggers["store/oci/tags"].Debug("debug message")
+
+	if !strings.Contains(logs.String(), `msg="debug message" component=authz`) {
+		t.Errorf("expected authz debug logs, got:\n%s", logs.String())
+	}
+
+	if strings.Contains(logs.String(), "component=store/oci/tags") {
+		t.Errorf("expected no store/oci/tags debug logs, got:\n%s", logs.String())
+	}
+
+	defaultLevel, overrides := Levels()
+	if defaultLevel != slog.LevelWarn || !maps.Equal(overrides, map[string]slog.Level{"authz": slog.LevelDebug}) {
+		t.Errorf("unexpected levels %v %v", defaultLevel, overrides)
+	}
+
+	if err := SetLevel("authz", "verbose"); err == nil {
+		t.Error("expected invalid level to fail")
+	}
+
+	if err := SetLevel("", ""); err == nil {
+		t.Error("expected removing the default level to fail")
+	}
+}
+
+func TestContextFields(t *testing.T) {
+	logs := captureLogs(t, FormatJSON)
+	setLevels(slog.LevelInfo, nil)
+
+	ctx := ContextWithFields(t.Context(), "request_id", "req-1")
+	ctx = ContextWithFields(ctx, slog.String("trust_domain", "example.org"))
+
+	WithContext(ctx, Logger("store/oci")).Info("pushed record", "cid", "cid-1")
+	FromContext(t.Context()).Info("no request")
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 2 { //nolint:mnd
+		t.Fatalf("expected 2 log lines, got:\n%s", logs.String())
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("expected JSON logs: %v", err)
+	}
+
+	for key, want := range map[string]string{
+		"component":    "store/oci",
+		"request_id":   "req-1",
+		"trust_domain": "example.org",
+		"cid":          "cid-1",
+	} {
+		if entry[key] != want {
+			t.Errorf("expected %s=%s, got %v", key, want, entry[key])
+		}
+	}
+
+	if strings.Contains(lines[1], "request_id") {
+		t.Errorf("expected no request fields without a request context, got %s", lines[1])
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := Config{LogLevel: "debug", LogFormat: FormatJSON, ComponentLevels: []string{"store/oci=warn"}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected valid config: %v", err)
+	}
+
+	err := Config{LogLevel: "loud", LogFormat: "xml", ComponentLevels: []string{"store/oci"}}.Validate()
+	if err == nil {
+		t.Fatal("expected invalid config to fail")
+	}
+
+	for _, want := range []string{`invalid log level "loud"`, `invalid log format "xml"`, `invalid component level "store/oci"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %v", want, err)
+		}
+	}
+}